@@ -0,0 +1,203 @@
+// Package cache implements an in-process LRU cache with expiring entries,
+// instrumented with Prometheus metrics.
+package cache
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	hitsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "bff",
+		Subsystem: "cache",
+		Name:      "hits_total",
+		Help:      "Amount of lookups served from the cache.",
+	}, []string{"cache"})
+
+	missesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "bff",
+		Subsystem: "cache",
+		Name:      "misses_total",
+		Help:      "Amount of lookups that were not found or expired on the cache.",
+	}, []string{"cache"})
+
+	evictionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "bff",
+		Subsystem: "cache",
+		Name:      "evictions_total",
+		Help:      "Amount of entries evicted because the cache was full.",
+	}, []string{"cache"})
+
+	invalidationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "bff",
+		Subsystem: "cache",
+		Name:      "invalidations_total",
+		Help:      "Amount of times the cache was explicitly invalidated.",
+	}, []string{"cache"})
+
+	entries = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "bff",
+		Subsystem: "cache",
+		Name:      "entries",
+		Help:      "Amount of entries currently stored on the cache.",
+	}, []string{"cache"})
+)
+
+var (
+	ErrMissingName       = errors.New("missing required dependency: Name")
+	ErrInvalidTTL        = errors.New("invalid-ttl")
+	ErrInvalidMaxEntries = errors.New("invalid-max-entries")
+)
+
+// Input is the input (aka dependencies) needed to create a Cache
+type Input struct {
+	// Name identifies the cache on the exported metrics
+	Name string
+	// TTL is how long an entry is served after being stored
+	TTL time.Duration
+	// MaxEntries is the amount of entries kept before evicting the least recently used one
+	MaxEntries int
+	// Now returns the current time, it defaults to time.Now
+	Now func() time.Time
+}
+
+// Cache is a size bounded LRU cache whose entries expire after a TTL.
+// It is safe for concurrent use.
+type Cache[V any] struct {
+	in Input
+
+	mu    sync.Mutex
+	order *list.List
+	items map[string]*list.Element
+}
+
+type entry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time
+}
+
+// New creates a new Cache instance
+func New[V any](in Input) (*Cache[V], error) {
+	if in.Name == "" {
+		return nil, ErrMissingName
+	}
+
+	if in.TTL <= 0 {
+		return nil, ErrInvalidTTL
+	}
+
+	if in.MaxEntries <= 0 {
+		return nil, ErrInvalidMaxEntries
+	}
+
+	if in.Now == nil {
+		in.Now = time.Now
+	}
+
+	return &Cache[V]{
+		in:    in,
+		order: list.New(),
+		items: make(map[string]*list.Element, in.MaxEntries),
+	}, nil
+}
+
+// MustNew creates a new Cache instance
+// It panics if any error is found
+func MustNew[V any](in Input) *Cache[V] {
+	cache, err := New[V](in)
+	if err != nil {
+		panic(err)
+	}
+
+	return cache
+}
+
+// Get returns the value stored under the key, if it exists and didn't expire
+func (c *Cache[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		missesTotal.WithLabelValues(c.in.Name).Inc()
+
+		var zero V
+		return zero, false
+	}
+
+	item := element.Value.(*entry[V])
+	if !c.in.Now().Before(item.expiresAt) {
+		c.remove(element)
+		missesTotal.WithLabelValues(c.in.Name).Inc()
+
+		var zero V
+		return zero, false
+	}
+
+	c.order.MoveToFront(element)
+	hitsTotal.WithLabelValues(c.in.Name).Inc()
+
+	return item.value, true
+}
+
+// Set stores the value under the key, evicting the least recently used entry if the cache is full
+func (c *Cache[V]) Set(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.in.Now().Add(c.in.TTL)
+
+	if element, ok := c.items[key]; ok {
+		item := element.Value.(*entry[V])
+		item.value = value
+		item.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	if c.order.Len() >= c.in.MaxEntries {
+		c.remove(c.order.Back())
+		evictionsTotal.WithLabelValues(c.in.Name).Inc()
+	}
+
+	c.items[key] = c.order.PushFront(&entry[V]{
+		key:       key,
+		value:     value,
+		expiresAt: expiresAt,
+	})
+	entries.WithLabelValues(c.in.Name).Set(float64(c.order.Len()))
+}
+
+// Purge removes every entry from the cache
+func (c *Cache[V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.items = make(map[string]*list.Element, c.in.MaxEntries)
+
+	invalidationsTotal.WithLabelValues(c.in.Name).Inc()
+	entries.WithLabelValues(c.in.Name).Set(0)
+}
+
+// Len returns the amount of entries stored, including the expired ones not yet removed
+func (c *Cache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// remove deletes an element from the cache, it must be called while holding the lock
+func (c *Cache[V]) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.items, element.Value.(*entry[V]).key)
+	entries.WithLabelValues(c.in.Name).Set(float64(c.order.Len()))
+}
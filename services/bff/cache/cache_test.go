@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type CacheSuite struct {
+	suite.Suite
+
+	now time.Time
+}
+
+func (s *CacheSuite) SetupTest() {
+	s.now = time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+}
+
+func (s *CacheSuite) newCache(name string, maxEntries int) *Cache[string] {
+	return MustNew[string](Input{
+		Name:       name,
+		TTL:        time.Minute,
+		MaxEntries: maxEntries,
+		Now:        func() time.Time { return s.now },
+	})
+}
+
+func (s *CacheSuite) Test_New() {
+	s.Run("Should fail to instantiate the Cache with invalid inputs", func() {
+		_, err := New[string](Input{TTL: time.Minute, MaxEntries: 1})
+		s.Equal(ErrMissingName, err)
+
+		_, err = New[string](Input{Name: "test", MaxEntries: 1})
+		s.Equal(ErrInvalidTTL, err)
+
+		_, err = New[string](Input{Name: "test", TTL: time.Minute})
+		s.Equal(ErrInvalidMaxEntries, err)
+	})
+}
+
+func (s *CacheSuite) Test_Get() {
+	s.Run("Should serve stored entries until they expire", func() {
+		cache := s.newCache("get", 10)
+		cache.Set("key", "value")
+
+		got, ok := cache.Get("key")
+		s.True(ok)
+		s.Equal("value", got)
+
+		s.now = s.now.Add(time.Minute)
+
+		_, ok = cache.Get("key")
+		s.False(ok)
+		s.Equal(0, cache.Len())
+
+		s.Equal(float64(1), testutil.ToFloat64(hitsTotal.WithLabelValues("get")))
+		s.Equal(float64(1), testutil.ToFloat64(missesTotal.WithLabelValues("get")))
+	})
+
+	s.Run("Should miss keys that were never stored", func() {
+		cache := s.newCache("miss", 10)
+
+		_, ok := cache.Get("unknown")
+
+		s.False(ok)
+		s.Equal(float64(1), testutil.ToFloat64(missesTotal.WithLabelValues("miss")))
+	})
+}
+
+func (s *CacheSuite) Test_Set() {
+	s.Run("Should evict the least recently used entry when full", func() {
+		cache := s.newCache("evict", 2)
+		cache.Set("a", "1")
+		cache.Set("b", "2")
+
+		// touching "a" makes "b" the least recently used entry
+		_, _ = cache.Get("a")
+		cache.Set("c", "3")
+
+		_, ok := cache.Get("b")
+		s.False(ok)
+
+		_, ok = cache.Get("a")
+		s.True(ok)
+
+		_, ok = cache.Get("c")
+		s.True(ok)
+
+		s.Equal(float64(1), testutil.ToFloat64(evictionsTotal.WithLabelValues("evict")))
+		s.Equal(float64(2), testutil.ToFloat64(entries.WithLabelValues("evict")))
+	})
+
+	s.Run("Should refresh the TTL of an overwritten entry", func() {
+		cache := s.newCache("overwrite", 2)
+		cache.Set("key", "old")
+
+		s.now = s.now.Add(50 * time.Second)
+		cache.Set("key", "new")
+		s.now = s.now.Add(50 * time.Second)
+
+		got, ok := cache.Get("key")
+		s.True(ok)
+		s.Equal("new", got)
+		s.Equal(1, cache.Len())
+	})
+}
+
+func (s *CacheSuite) Test_Purge() {
+	s.Run("Should drop every entry", func() {
+		cache := s.newCache("purge", 10)
+		cache.Set("a", "1")
+		cache.Set("b", "2")
+
+		cache.Purge()
+
+		s.Equal(0, cache.Len())
+		s.Equal(float64(1), testutil.ToFloat64(invalidationsTotal.WithLabelValues("purge")))
+		s.Equal(float64(0), testutil.ToFloat64(entries.WithLabelValues("purge")))
+	})
+}
+
+func TestCacheSuite(t *testing.T) {
+	suite.Run(t, new(CacheSuite))
+}
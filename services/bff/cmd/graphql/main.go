@@ -5,13 +5,22 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/lucasmls/ecommerce/services/bff/cache"
 	graph "github.com/lucasmls/ecommerce/services/bff/ports/graphql"
 	"github.com/lucasmls/ecommerce/services/bff/ports/graphql/generated"
+	"github.com/lucasmls/ecommerce/services/bff/ports/graphql/model"
+	cartPb "github.com/lucasmls/ecommerce/services/cart/ports/grpc/proto"
+	inventoryPb "github.com/lucasmls/ecommerce/services/inventory/ports/grpc/proto"
+	ordersPb "github.com/lucasmls/ecommerce/services/orders/ports/grpc/proto"
+	pricingPb "github.com/lucasmls/ecommerce/services/pricing/ports/grpc/proto"
 	productsPb "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
 	"github.com/lucasmls/ecommerce/shared/grpc"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 
 	"go.opentelemetry.io/otel"
@@ -22,7 +31,11 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 )
 
-const defaultPort = "8080"
+const (
+	defaultPort              = "8080"
+	defaultProductsCacheTTL  = 30 * time.Second
+	defaultProductsCacheSize = 1000
+)
 
 func main() {
 	ctx := context.Background()
@@ -35,6 +48,26 @@ func main() {
 		port = defaultPort
 	}
 
+	productsCacheTTL := defaultProductsCacheTTL
+	if ttl := os.Getenv("PRODUCTS_CACHE_TTL"); ttl != "" {
+		parsedTTL, err := time.ParseDuration(ttl)
+		if err != nil {
+			logger.Fatal("invalid PRODUCTS_CACHE_TTL", zap.Error(err))
+		}
+
+		productsCacheTTL = parsedTTL
+	}
+
+	productsCacheSize := defaultProductsCacheSize
+	if size := os.Getenv("PRODUCTS_CACHE_SIZE"); size != "" {
+		parsedSize, err := strconv.Atoi(size)
+		if err != nil {
+			logger.Fatal("invalid PRODUCTS_CACHE_SIZE", zap.Error(err))
+		}
+
+		productsCacheSize = parsedSize
+	}
+
 	jaegerExporter, err := jaegerExporter.New(
 		jaegerExporter.WithCollectorEndpoint(
 			jaegerExporter.WithEndpoint("http://localhost:14268/api/traces"),
@@ -75,16 +108,70 @@ func main() {
 
 	productsService := productsPb.NewProductsServiceClient(productsServiceConn)
 
+	inventoryServiceGRPCClient := grpc.MustNewClient(grpc.ClientInput{
+		Address: "localhost:8082",
+		Logger:  logger,
+	})
+
+	inventoryServiceConn := inventoryServiceGRPCClient.MustConnect(ctx)
+
+	inventoryService := inventoryPb.NewInventoryServiceClient(inventoryServiceConn)
+
+	cartServiceGRPCClient := grpc.MustNewClient(grpc.ClientInput{
+		Address: "localhost:8083",
+		Logger:  logger,
+	})
+
+	cartServiceConn := cartServiceGRPCClient.MustConnect(ctx)
+
+	cartService := cartPb.NewCartServiceClient(cartServiceConn)
+
+	ordersServiceGRPCClient := grpc.MustNewClient(grpc.ClientInput{
+		Address: "localhost:8084",
+		Logger:  logger,
+	})
+
+	ordersServiceConn := ordersServiceGRPCClient.MustConnect(ctx)
+
+	ordersService := ordersPb.NewOrdersServiceClient(ordersServiceConn)
+
+	pricingServiceGRPCClient := grpc.MustNewClient(grpc.ClientInput{
+		Address: "localhost:8088",
+		Logger:  logger,
+	})
+
+	pricingServiceConn := pricingServiceGRPCClient.MustConnect(ctx)
+
+	pricingService := pricingPb.NewPricingServiceClient(pricingServiceConn)
+
 	graphQlResolver := &graph.Resolver{
-		Logger:          logger,
-		Tracer:          tracer,
-		ProductsService: productsService,
+		Logger:           logger,
+		Tracer:           tracer,
+		ProductsService:  productsService,
+		InventoryService: inventoryService,
+		CartService:      cartService,
+		OrdersService:    ordersService,
+		PricingService:   pricingService,
+		ProductsCache: cache.MustNew[[]*model.Product](cache.Input{
+			Name:       "products",
+			TTL:        productsCacheTTL,
+			MaxEntries: productsCacheSize,
+		}),
 	}
 
 	srv := handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: graphQlResolver}))
+	srv.SetErrorPresenter(graph.NewErrorPresenter(logger))
 
 	http.Handle("/", playground.Handler("GraphQL playground", "/query"))
-	http.Handle("/query", srv)
+	// the actor recorded on the changes and the customer whose carts and orders are reached both come from the
+	// verified access token, so every change is recorded as made by an unknown actor while AUTH_TOKEN_SECRET isn't set
+	authTokenSecret := []byte(os.Getenv("AUTH_TOKEN_SECRET"))
+	if len(authTokenSecret) == 0 {
+		logger.Warn("AUTH_TOKEN_SECRET isn't set, the customers can only use guest carts")
+	}
+
+	http.Handle("/query", graph.AuthMiddleware(authTokenSecret, graph.LoadersMiddleware(productsService, inventoryService, pricingService, srv)))
+	http.Handle("/metrics", promhttp.Handler())
 
 	log.Printf("connect to http://localhost:%s/ for GraphQL playground", port)
 	log.Fatal(http.ListenAndServe(":"+port, nil))
@@ -0,0 +1,177 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	ErrNotFound = errors.New("not-found")
+)
+
+// BatchFunc fetches the values of many keys at once.
+// Keys missing from the returned map are reported as ErrNotFound.
+type BatchFunc[K comparable, V any] func(context.Context, []K) (map[K]V, error)
+
+// LoaderInput is the input (aka dependencies) needed to create a Loader
+type LoaderInput[K comparable, V any] struct {
+	// Fetch is called once per batch of coalesced keys
+	Fetch BatchFunc[K, V]
+	// Wait is how long the Loader waits for more keys before fetching a batch
+	Wait time.Duration
+	// MaxBatch is the maximum amount of keys fetched at once, zero means unlimited
+	MaxBatch int
+}
+
+// Loader coalesces the keys requested within a short window into a single
+// call to Fetch and caches the results for its whole lifetime.
+// It is meant to be request-scoped.
+type Loader[K comparable, V any] struct {
+	in LoaderInput[K, V]
+
+	mu    sync.Mutex
+	cache map[K]*result[V]
+	batch *batch[K, V]
+}
+
+type result[V any] struct {
+	value V
+	err   error
+}
+
+type batch[K comparable, V any] struct {
+	keys    []K
+	done    chan struct{}
+	closing bool
+	results map[K]V
+	err     error
+}
+
+// NewLoader creates a new Loader instance
+func NewLoader[K comparable, V any](in LoaderInput[K, V]) (*Loader[K, V], error) {
+	if in.Fetch == nil {
+		return nil, errors.New("missing required dependency: Fetch")
+	}
+
+	return &Loader[K, V]{
+		in:    in,
+		cache: map[K]*result[V]{},
+	}, nil
+}
+
+// MustNewLoader creates a new Loader instance
+// It panics if any error is found
+func MustNewLoader[K comparable, V any](in LoaderInput[K, V]) *Loader[K, V] {
+	loader, err := NewLoader(in)
+	if err != nil {
+		panic(err)
+	}
+
+	return loader
+}
+
+// Load fetches the value of a single key
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	return l.thunk(ctx, key)()
+}
+
+// LoadMany fetches the values of many keys, preserving their order
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) ([]V, []error) {
+	thunks := make([]func() (V, error), len(keys))
+	for i, key := range keys {
+		thunks[i] = l.thunk(ctx, key)
+	}
+
+	values := make([]V, len(keys))
+	errs := make([]error, len(keys))
+	for i, thunk := range thunks {
+		values[i], errs[i] = thunk()
+	}
+
+	return values, errs
+}
+
+// thunk registers the key into the current batch and returns a function
+// that blocks until its value is available
+func (l *Loader[K, V]) thunk(ctx context.Context, key K) func() (V, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if cached, ok := l.cache[key]; ok {
+		return func() (V, error) {
+			return cached.value, cached.err
+		}
+	}
+
+	if l.batch == nil {
+		l.batch = &batch[K, V]{done: make(chan struct{})}
+	}
+
+	b := l.batch
+	b.add(ctx, l, key)
+
+	return func() (V, error) {
+		<-b.done
+
+		var res result[V]
+		if b.err != nil {
+			res.err = b.err
+		} else if value, ok := b.results[key]; ok {
+			res.value = value
+		} else {
+			res.err = ErrNotFound
+		}
+
+		l.mu.Lock()
+		l.cache[key] = &res
+		l.mu.Unlock()
+
+		return res.value, res.err
+	}
+}
+
+// add appends the key to the batch and schedules its dispatch.
+// It must be called while holding the loader lock.
+func (b *batch[K, V]) add(ctx context.Context, l *Loader[K, V], key K) {
+	for _, existingKey := range b.keys {
+		if existingKey == key {
+			return
+		}
+	}
+
+	b.keys = append(b.keys, key)
+
+	if len(b.keys) == 1 {
+		go b.startTimer(ctx, l)
+	}
+
+	if l.in.MaxBatch != 0 && len(b.keys) >= l.in.MaxBatch && !b.closing {
+		b.closing = true
+		l.batch = nil
+		go b.end(ctx, l)
+	}
+}
+
+func (b *batch[K, V]) startTimer(ctx context.Context, l *Loader[K, V]) {
+	time.Sleep(l.in.Wait)
+	l.mu.Lock()
+
+	// the batch may have been dispatched already because it got full
+	if b.closing {
+		l.mu.Unlock()
+		return
+	}
+
+	b.closing = true
+	l.batch = nil
+	l.mu.Unlock()
+
+	b.end(ctx, l)
+}
+
+func (b *batch[K, V]) end(ctx context.Context, l *Loader[K, V]) {
+	b.results, b.err = l.in.Fetch(ctx, b.keys)
+	close(b.done)
+}
@@ -0,0 +1,129 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LoaderSuite struct {
+	suite.Suite
+
+	mu      sync.Mutex
+	batches [][]int
+}
+
+func (s *LoaderSuite) SetupTest() {
+	s.batches = nil
+}
+
+func (s *LoaderSuite) fetch(ctx context.Context, keys []int) (map[int]string, error) {
+	s.mu.Lock()
+	s.batches = append(s.batches, keys)
+	s.mu.Unlock()
+
+	result := map[int]string{}
+	for _, key := range keys {
+		if key%2 == 0 {
+			result[key] = "even"
+		}
+	}
+
+	return result, nil
+}
+
+func (s *LoaderSuite) Test_NewLoader() {
+	s.Run("Should fail to instantiate the Loader in case a Fetch function isn't provided", func() {
+		_, err := NewLoader(LoaderInput[int, string]{})
+
+		s.Error(err)
+	})
+}
+
+func (s *LoaderSuite) Test_Load() {
+	s.Run("Should coalesce concurrent loads into a single batch", func() {
+		s.SetupTest()
+		loader := MustNewLoader(LoaderInput[int, string]{
+			Fetch: s.fetch,
+			Wait:  50 * time.Millisecond,
+		})
+
+		var wg sync.WaitGroup
+		for _, key := range []int{2, 4, 4, 6} {
+			wg.Add(1)
+			go func(key int) {
+				defer wg.Done()
+
+				got, err := loader.Load(context.Background(), key)
+				s.NoError(err)
+				s.Equal("even", got)
+			}(key)
+		}
+
+		wg.Wait()
+
+		s.Len(s.batches, 1)
+		s.ElementsMatch([]int{2, 4, 6}, s.batches[0])
+	})
+
+	s.Run("Should report missing keys as not found", func() {
+		s.SetupTest()
+		loader := MustNewLoader(LoaderInput[int, string]{Fetch: s.fetch})
+
+		_, err := loader.Load(context.Background(), 1)
+
+		s.ErrorIs(err, ErrNotFound)
+	})
+
+	s.Run("Should serve already loaded keys from the cache", func() {
+		s.SetupTest()
+		loader := MustNewLoader(LoaderInput[int, string]{Fetch: s.fetch})
+
+		_, err := loader.Load(context.Background(), 2)
+		s.NoError(err)
+
+		_, err = loader.Load(context.Background(), 2)
+		s.NoError(err)
+
+		s.Len(s.batches, 1)
+	})
+
+	s.Run("Should report the fetch error to every key of the batch", func() {
+		loader := MustNewLoader(LoaderInput[int, string]{
+			Fetch: func(ctx context.Context, keys []int) (map[int]string, error) {
+				return nil, errors.New("mock error")
+			},
+		})
+
+		_, errs := loader.LoadMany(context.Background(), []int{1, 2})
+
+		s.Equal([]error{errors.New("mock error"), errors.New("mock error")}, errs)
+	})
+}
+
+func (s *LoaderSuite) Test_LoadMany() {
+	s.Run("Should preserve the order of the keys and split batches bigger than MaxBatch", func() {
+		s.SetupTest()
+		loader := MustNewLoader(LoaderInput[int, string]{
+			Fetch:    s.fetch,
+			Wait:     10 * time.Millisecond,
+			MaxBatch: 2,
+		})
+
+		got, errs := loader.LoadMany(context.Background(), []int{2, 3, 4})
+
+		s.Equal([]string{"even", "", "even"}, got)
+		s.NoError(errs[0])
+		s.ErrorIs(errs[1], ErrNotFound)
+		s.NoError(errs[2])
+		s.Len(s.batches, 2)
+	})
+}
+
+func TestLoaderSuite(t *testing.T) {
+	suite.Run(t, new(LoaderSuite))
+}
@@ -4,20 +4,32 @@ go 1.18
 
 require (
 	github.com/99designs/gqlgen v0.14.0
+	github.com/lucasmls/ecommerce/services/cart v0.0.0-00010101000000-000000000000
+	github.com/lucasmls/ecommerce/services/inventory v0.0.0-00010101000000-000000000000
+	github.com/lucasmls/ecommerce/services/orders v0.0.0-00010101000000-000000000000
+	github.com/lucasmls/ecommerce/services/pricing v0.0.0-00010101000000-000000000000
 	github.com/lucasmls/ecommerce/services/products v0.0.0-20211129110730-b8c1e1e0b548
 	github.com/lucasmls/ecommerce/shared v0.0.0-20211019010026-2ed6e2591d9f
+	github.com/prometheus/client_golang v1.12.1
+	github.com/stretchr/testify v1.7.1
 	github.com/vektah/gqlparser/v2 v2.2.0
-	go.opentelemetry.io/otel v1.2.0
+	go.opentelemetry.io/otel v1.3.0
 	go.opentelemetry.io/otel/exporters/jaeger v1.2.0
-	go.opentelemetry.io/otel/sdk v1.2.0
-	go.opentelemetry.io/otel/trace v1.2.0
+	go.opentelemetry.io/otel/sdk v1.3.0
+	go.opentelemetry.io/otel/trace v1.3.0
 	go.uber.org/zap v1.19.1
+	google.golang.org/genproto v0.0.0-20220407144326-9054f6ed7bac
+	google.golang.org/grpc v1.45.0
+	google.golang.org/protobuf v1.28.0
 )
 
 require (
 	github.com/agnivade/levenshtein v1.1.0 // indirect
 	github.com/beorn7/perks v1.0.1 // indirect
 	github.com/cespare/xxhash/v2 v2.1.2 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/go-logr/logr v1.2.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
 	github.com/golang/protobuf v1.5.2 // indirect
 	github.com/gorilla/websocket v1.4.2 // indirect
 	github.com/grpc-ecosystem/go-grpc-middleware v1.3.0 // indirect
@@ -25,7 +37,7 @@ require (
 	github.com/hashicorp/golang-lru v0.5.1 // indirect
 	github.com/matttproud/golang_protobuf_extensions v1.0.1 // indirect
 	github.com/mitchellh/mapstructure v1.4.3 // indirect
-	github.com/prometheus/client_golang v1.12.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/prometheus/client_model v0.2.0 // indirect
 	github.com/prometheus/common v0.32.1 // indirect
 	github.com/prometheus/procfs v0.7.3 // indirect
@@ -35,9 +47,17 @@ require (
 	golang.org/x/net v0.0.0-20220412020605-290c469a71a5 // indirect
 	golang.org/x/sys v0.0.0-20220412211240-33da011f77ad // indirect
 	golang.org/x/text v0.3.7 // indirect
-	google.golang.org/genproto v0.0.0-20220407144326-9054f6ed7bac // indirect
-	google.golang.org/grpc v1.45.0 // indirect
-	google.golang.org/protobuf v1.28.0 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b // indirect
 )
 
 replace github.com/lucasmls/ecommerce/shared => ../../shared
+
+replace github.com/lucasmls/ecommerce/services/products => ../products
+
+replace github.com/lucasmls/ecommerce/services/inventory => ../inventory
+
+replace github.com/lucasmls/ecommerce/services/cart => ../cart
+
+replace github.com/lucasmls/ecommerce/services/orders => ../orders
+
+replace github.com/lucasmls/ecommerce/services/pricing => ../pricing
@@ -0,0 +1,115 @@
+package mapping
+
+import (
+	"github.com/lucasmls/ecommerce/services/bff/ports/graphql/model"
+	cartPb "github.com/lucasmls/ecommerce/services/cart/ports/grpc/proto"
+	productsPb "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+)
+
+// CartRefToProto points to the cart of the customer when there is one, or else to the guest cart with the given id.
+// An empty CartRef is returned when neither is known.
+func CartRefToProto(customer string, cartID *string) *cartPb.CartRef {
+	if customer != "" {
+		return &cartPb.CartRef{UserId: customer}
+	}
+
+	if cartID == nil {
+		return &cartPb.CartRef{}
+	}
+
+	return &cartPb.CartRef{Id: *cartID}
+}
+
+// CartProductIDs lists the products service IDs of the items of a cart service Cart, in their order
+func CartProductIDs(cart *cartPb.Cart) []int32 {
+	ids := make([]int32, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		ids = append(ids, item.ProductId)
+	}
+
+	return ids
+}
+
+// CartFromProto converts a cart service Cart into its GraphQL model, checking the prices of its items
+// against the current products. The items whose product is missing from products, e.g. because it was
+// removed from the catalog, are reported as unavailable.
+func CartFromProto(cart *cartPb.Cart, products map[int32]*productsPb.Product) *model.Cart {
+	response := &model.Cart{
+		Items: make([]*model.CartItem, 0, len(cart.Items)),
+	}
+
+	if cart.Id != "" {
+		id := cart.Id
+		response.ID = &id
+	}
+
+	if cart.ExpiresAt != nil {
+		expiresAt := cart.ExpiresAt.AsTime()
+		response.ExpiresAt = &expiresAt
+	}
+
+	if cart.UpdatedAt != nil {
+		updatedAt := cart.UpdatedAt.AsTime()
+		response.UpdatedAt = &updatedAt
+	}
+
+	// summed up in minor units, so the subtotal doesn't accumulate floating point errors
+	var subtotal int64
+	for _, item := range cart.Items {
+		cartItem := &model.CartItem{
+			ProductID: ProductIDFromProto(item.ProductId),
+			Name:      item.Name,
+			Quantity:  int(item.Quantity),
+			UnitPrice: PriceFromProto(item.UnitPrice),
+			Total:     totalFromProto(item.Quantity, item.UnitPrice),
+		}
+
+		product, ok := products[item.ProductId]
+		if ok && product != nil {
+			currentUnitPrice := PriceFromProto(product.Price)
+
+			cartItem.Product = ProductFromProto(product)
+			cartItem.CurrentUnitPrice = &currentUnitPrice
+			cartItem.PriceChanged = product.Price != item.UnitPrice
+			cartItem.Available = true
+			cartItem.Total = totalFromProto(item.Quantity, product.Price)
+
+			subtotal += int64(item.Quantity) * int64(product.Price)
+		}
+
+		response.TotalQuantity += cartItem.Quantity
+		response.HasPriceChanges = response.HasPriceChanges || cartItem.PriceChanged
+		response.HasUnavailableItems = response.HasUnavailableItems || !cartItem.Available
+		response.Items = append(response.Items, cartItem)
+	}
+
+	response.Subtotal = float64(subtotal) / priceScale
+
+	return response
+}
+
+// totalFromProto multiplies a price in minor units by a quantity, converting it into major units.
+// It's computed on 64 bits since the product of two int32 may overflow them.
+func totalFromProto(quantity int32, unitPrice int32) float64 {
+	return float64(int64(quantity)*int64(unitPrice)) / priceScale
+}
+
+// CartPriceUpdatesToProto takes the current names and prices of the products of a cart service Cart,
+// leaving out the items whose product is missing from products
+func CartPriceUpdatesToProto(cart *cartPb.Cart, products map[int32]*productsPb.Product) []*cartPb.PriceUpdate {
+	updates := []*cartPb.PriceUpdate{}
+	for _, item := range cart.Items {
+		product, ok := products[item.ProductId]
+		if !ok || product == nil {
+			continue
+		}
+
+		updates = append(updates, &cartPb.PriceUpdate{
+			ProductId: product.Id,
+			Name:      product.Name,
+			UnitPrice: product.Price,
+		})
+	}
+
+	return updates
+}
@@ -0,0 +1,106 @@
+package mapping
+
+import (
+	"testing"
+	"time"
+
+	cartPb "github.com/lucasmls/ecommerce/services/cart/ports/grpc/proto"
+	productsPb "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type CartMappingSuite struct {
+	suite.Suite
+}
+
+func (s *CartMappingSuite) Test_CartRefToProto() {
+	cartID := "0123456789abcdef0123456789abcdef"
+
+	s.Equal(&cartPb.CartRef{UserId: "john"}, CartRefToProto("john", &cartID))
+	s.Equal(&cartPb.CartRef{Id: cartID}, CartRefToProto("", &cartID))
+	s.Equal(&cartPb.CartRef{}, CartRefToProto("", nil))
+}
+
+func (s *CartMappingSuite) Test_CartFromProto() {
+	expiresAt := time.Date(2022, 5, 8, 10, 0, 0, 0, time.UTC)
+
+	cart := &cartPb.Cart{
+		Id: "0123456789abcdef0123456789abcdef",
+		Items: []*cartPb.LineItem{
+			{ProductId: 1, Name: "iPhone 13", Quantity: 2, UnitPrice: 79900},
+			{ProductId: 2, Name: "MacBook", Quantity: 1, UnitPrice: 129900},
+			{ProductId: 3, Name: "iPod", Quantity: 3, UnitPrice: 19900},
+		},
+		ExpiresAt: timestamppb.New(expiresAt),
+	}
+
+	products := map[int32]*productsPb.Product{
+		1: {Id: 1, Name: "iPhone 13", Price: 74990},
+		2: {Id: 2, Name: "MacBook", Price: 129900},
+	}
+
+	s.Run("Should check the prices of the items against the current products", func() {
+		got := CartFromProto(cart, products)
+
+		s.Require().NotNil(got.ID)
+		s.Equal(cart.Id, *got.ID)
+		s.Equal(expiresAt, *got.ExpiresAt)
+		s.Nil(got.UpdatedAt)
+		s.Require().Len(got.Items, 3)
+
+		s.Equal("1", got.Items[0].ProductID)
+		s.Equal(799.0, got.Items[0].UnitPrice)
+		s.Equal(749.9, *got.Items[0].CurrentUnitPrice)
+		s.True(got.Items[0].PriceChanged)
+		s.True(got.Items[0].Available)
+		s.Equal(1499.8, got.Items[0].Total)
+		s.Equal("iPhone 13", got.Items[0].Product.Name)
+
+		s.False(got.Items[1].PriceChanged)
+		s.Equal(1299.0, got.Items[1].Total)
+
+		s.Equal(6, got.TotalQuantity)
+		s.Equal(2798.8, got.Subtotal)
+		s.True(got.HasPriceChanges)
+	})
+
+	s.Run("Should report the items whose product is gone as unavailable", func() {
+		got := CartFromProto(cart, products)
+
+		item := got.Items[2]
+		s.False(item.Available)
+		s.False(item.PriceChanged)
+		s.Nil(item.Product)
+		s.Nil(item.CurrentUnitPrice)
+		s.Equal(597.0, item.Total)
+		s.True(got.HasUnavailableItems)
+	})
+
+	s.Run("Should leave the id of a cart that wasn't stored yet empty", func() {
+		got := CartFromProto(&cartPb.Cart{UserId: "john"}, nil)
+
+		s.Nil(got.ID)
+		s.Nil(got.ExpiresAt)
+		s.Empty(got.Items)
+		s.Equal(0.0, got.Subtotal)
+		s.False(got.HasPriceChanges)
+	})
+}
+
+func (s *CartMappingSuite) Test_CartPriceUpdatesToProto() {
+	got := CartPriceUpdatesToProto(&cartPb.Cart{
+		Items: []*cartPb.LineItem{
+			{ProductId: 1, Name: "iPhone", UnitPrice: 79900},
+			{ProductId: 3, Name: "iPod", UnitPrice: 19900},
+		},
+	}, map[int32]*productsPb.Product{
+		1: {Id: 1, Name: "iPhone 13", Price: 74990},
+	})
+
+	s.Equal([]*cartPb.PriceUpdate{{ProductId: 1, Name: "iPhone 13", UnitPrice: 74990}}, got)
+}
+
+func TestCartMappingSuite(t *testing.T) {
+	suite.Run(t, new(CartMappingSuite))
+}
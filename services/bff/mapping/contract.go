@@ -0,0 +1,165 @@
+package mapping
+
+import (
+	cartPb "github.com/lucasmls/ecommerce/services/cart/ports/grpc/proto"
+	inventoryPb "github.com/lucasmls/ecommerce/services/inventory/ports/grpc/proto"
+	ordersPb "github.com/lucasmls/ecommerce/services/orders/ports/grpc/proto"
+	productsPb "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The assertions below pin the products service contract this package was written against.
+// The BFF builds against the products module of this repository (see the replace directive
+// on go.mod), so changing the type of one of these fields on products.proto stops the BFF
+// from compiling until the mapping is updated accordingly.
+var (
+	_ int32  = (&productsPb.Product{}).Id
+	_ string = (&productsPb.Product{}).Name
+	_ string = (&productsPb.Product{}).Description
+	_ int32  = (&productsPb.Product{}).Price
+	_ int32  = (&productsPb.Product{}).Version
+
+	_ *timestamppb.Timestamp = (&productsPb.Product{}).DeletedAt
+
+	_ string = (&productsPb.Product{}).Category
+	_ string = (&productsPb.Product{}).TaxClass
+	_ int32  = (&productsPb.Product{}).Weight
+
+	_ *productsPb.Dimensions = (&productsPb.Product{}).Dimensions
+	_ int32                  = (&productsPb.Dimensions{}).Length
+	_ map[string]string      = (&productsPb.Product{}).Attributes
+
+	_ []int32 = (&productsPb.ListRequest{}).Ids
+	_ int32   = (&productsPb.DeleteRequest{}).Id
+	_ int32   = (&productsPb.RestoreRequest{}).Id
+	_ bool    = (&productsPb.ListRequest{}).OnlyDeleted
+
+	_ *productsPb.Product    = (&productsPb.PatchRequest{}).Product
+	_ *fieldmaskpb.FieldMask = (&productsPb.PatchRequest{}).UpdateMask
+
+	_ int32                    = (&productsPb.GetProductHistoryRequest{}).ProductId
+	_ int32                    = (&productsPb.GetProductHistoryRequest{}).BeforeId
+	_ int32                    = (&productsPb.GetProductHistoryRequest{}).Limit
+	_ []*productsPb.AuditEntry = (&productsPb.GetProductHistoryResponse{}).Entries
+	_ int32                    = (&productsPb.AuditEntry{}).Id
+	_ string                   = (&productsPb.AuditEntry{}).Action
+	_ string                   = (&productsPb.AuditEntry{}).Actor
+	_ *productsPb.Product      = (&productsPb.AuditEntry{}).Before
+	_ *productsPb.Product      = (&productsPb.AuditEntry{}).After
+	_ string                   = (&productsPb.AuditEntry{}).TraceId
+	_ *timestamppb.Timestamp   = (&productsPb.AuditEntry{}).CreatedAt
+
+	_ string                     = (&productsPb.SearchRequest{}).Query
+	_ int32                      = (&productsPb.SearchRequest{}).Limit
+	_ int32                      = (&productsPb.SearchRequest{}).Offset
+	_ []*productsPb.SearchResult = (&productsPb.SearchResponse{}).Results
+	_ *productsPb.Product        = (&productsPb.SearchResult{}).Product
+	_ float64                    = (&productsPb.SearchResult{}).Score
+	_ string                     = (&productsPb.SearchResult{}).NameHighlight
+	_ string                     = (&productsPb.SearchResult{}).DescriptionHighlight
+
+	_ *productsPb.ProductFilter      = (&productsPb.ListRequest{}).Filter
+	_ string                         = (&productsPb.ProductFilter{}).Category
+	_ int32                          = (&productsPb.ProductFilter{}).PriceFrom
+	_ int32                          = (&productsPb.ProductFilter{}).PriceTo
+	_ map[string]string              = (&productsPb.ProductFilter{}).Attributes
+	_ *productsPb.ProductFilter      = (&productsPb.GetFacetsRequest{}).Filter
+	_ []int32                        = (&productsPb.GetFacetsRequest{}).PriceBoundaries
+	_ int32                          = (&productsPb.GetFacetsResponse{}).Total
+	_ []*productsPb.PriceRangeBucket = (&productsPb.GetFacetsResponse{}).PriceRanges
+	_ []*productsPb.FacetBucket      = (&productsPb.GetFacetsResponse{}).Categories
+	_ []*productsPb.AttributeFacet   = (&productsPb.GetFacetsResponse{}).Attributes
+	_ int32                          = (&productsPb.PriceRangeBucket{}).From
+	_ int32                          = (&productsPb.PriceRangeBucket{}).To
+	_ int32                          = (&productsPb.PriceRangeBucket{}).Count
+	_ string                         = (&productsPb.FacetBucket{}).Value
+	_ int32                          = (&productsPb.FacetBucket{}).Count
+	_ string                         = (&productsPb.AttributeFacet{}).Name
+	_ []*productsPb.FacetBucket      = (&productsPb.AttributeFacet{}).Values
+
+	_ []int32                    = (&productsPb.ListImagesRequest{}).ProductIds
+	_ []*productsPb.ProductImage = (&productsPb.ListImagesResponse{}).Data
+	_ int32                      = (&productsPb.ProductImage{}).Id
+	_ int32                      = (&productsPb.ProductImage{}).ProductId
+	_ string                     = (&productsPb.ProductImage{}).Url
+	_ string                     = (&productsPb.ProductImage{}).ContentType
+	_ int32                      = (&productsPb.ProductImage{}).Width
+	_ int32                      = (&productsPb.ProductImage{}).Height
+	_ int32                      = (&productsPb.ProductImage{}).Size
+	_ string                     = (&productsPb.ProductImage{}).AltText
+	_ []*productsPb.Thumbnail    = (&productsPb.ProductImage{}).Thumbnails
+	_ string                     = (&productsPb.Thumbnail{}).Name
+	_ string                     = (&productsPb.Thumbnail{}).Url
+	_ int32                      = (&productsPb.Thumbnail{}).Width
+	_ int32                      = (&productsPb.Thumbnail{}).Height
+
+	_ productsPb.ProductsServiceClient = productsPb.NewProductsServiceClient(nil)
+)
+
+// The same goes for the inventory service contract, which the BFF builds against through its own replace directive.
+var (
+	_ []int32                     = (&inventoryPb.GetStockRequest{}).ProductIds
+	_ []*inventoryPb.ProductStock = (&inventoryPb.GetStockResponse{}).Data
+	_ int32                       = (&inventoryPb.ProductStock{}).ProductId
+	_ int32                       = (&inventoryPb.ProductStock{}).OnHand
+	_ int32                       = (&inventoryPb.ProductStock{}).Reserved
+	_ int32                       = (&inventoryPb.ProductStock{}).Available
+	_ []*inventoryPb.StockLevel   = (&inventoryPb.ProductStock{}).Levels
+	_ string                      = (&inventoryPb.StockLevel{}).Sku
+	_ string                      = (&inventoryPb.StockLevel{}).Warehouse
+	_ int32                       = (&inventoryPb.StockLevel{}).OnHand
+	_ int32                       = (&inventoryPb.StockLevel{}).Reserved
+	_ int32                       = (&inventoryPb.StockLevel{}).Available
+
+	_ inventoryPb.InventoryServiceClient = inventoryPb.NewInventoryServiceClient(nil)
+)
+
+// The same goes for the cart service contract.
+var (
+	_ string                 = (&cartPb.CartRef{}).Id
+	_ string                 = (&cartPb.CartRef{}).UserId
+	_ string                 = (&cartPb.Cart{}).Id
+	_ []*cartPb.LineItem     = (&cartPb.Cart{}).Items
+	_ *timestamppb.Timestamp = (&cartPb.Cart{}).ExpiresAt
+	_ *timestamppb.Timestamp = (&cartPb.Cart{}).UpdatedAt
+	_ int32                  = (&cartPb.LineItem{}).ProductId
+	_ string                 = (&cartPb.LineItem{}).Name
+	_ int32                  = (&cartPb.LineItem{}).Quantity
+	_ int32                  = (&cartPb.LineItem{}).UnitPrice
+	_ int32                  = (&cartPb.PriceUpdate{}).ProductId
+	_ string                 = (&cartPb.PriceUpdate{}).Name
+	_ int32                  = (&cartPb.PriceUpdate{}).UnitPrice
+
+	_ cartPb.CartServiceClient = cartPb.NewCartServiceClient(nil)
+)
+
+// The same goes for the orders service contract.
+var (
+	_ string                       = (&ordersPb.PlaceOrderRequest{}).CustomerId
+	_ []*ordersPb.OrderItemRequest = (&ordersPb.PlaceOrderRequest{}).Items
+	_ int32                        = (&ordersPb.OrderItemRequest{}).ProductId
+	_ int32                        = (&ordersPb.OrderItemRequest{}).Quantity
+	_ string                       = (&ordersPb.ListOrdersRequest{}).CustomerId
+	_ string                       = (&ordersPb.ListOrdersRequest{}).Status
+	_ int32                        = (&ordersPb.ListOrdersRequest{}).Limit
+	_ int32                        = (&ordersPb.Order{}).Id
+	_ string                       = (&ordersPb.Order{}).Number
+	_ string                       = (&ordersPb.Order{}).Status
+	_ []*ordersPb.LineItem         = (&ordersPb.Order{}).Items
+	_ []*ordersPb.StatusChange     = (&ordersPb.Order{}).StatusHistory
+	_ int64                        = (&ordersPb.Order{}).Total
+	_ *timestamppb.Timestamp       = (&ordersPb.Order{}).PlacedAt
+	_ *timestamppb.Timestamp       = (&ordersPb.Order{}).UpdatedAt
+	_ int32                        = (&ordersPb.LineItem{}).ProductId
+	_ string                       = (&ordersPb.LineItem{}).Name
+	_ int32                        = (&ordersPb.LineItem{}).Quantity
+	_ int32                        = (&ordersPb.LineItem{}).UnitPrice
+	_ int64                        = (&ordersPb.LineItem{}).Total
+	_ string                       = (&ordersPb.StatusChange{}).From
+	_ string                       = (&ordersPb.StatusChange{}).To
+	_ string                       = (&ordersPb.StatusChange{}).Reason
+	_ *timestamppb.Timestamp       = (&ordersPb.StatusChange{}).At
+
+	_ ordersPb.OrdersServiceClient = ordersPb.NewOrdersServiceClient(nil)
+)
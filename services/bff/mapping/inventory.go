@@ -0,0 +1,35 @@
+package mapping
+
+import (
+	"github.com/lucasmls/ecommerce/services/bff/ports/graphql/model"
+	inventoryPb "github.com/lucasmls/ecommerce/services/inventory/ports/grpc/proto"
+)
+
+// ProductStockFromProto converts an inventory service ProductStock into its GraphQL model
+func ProductStockFromProto(stock *inventoryPb.ProductStock) *model.ProductStock {
+	response := &model.ProductStock{
+		Available: int(stock.Available),
+		InStock:   stock.Available > 0,
+		OnHand:    int(stock.OnHand),
+		Reserved:  int(stock.Reserved),
+		Levels:    make([]*model.StockLevel, 0, len(stock.Levels)),
+	}
+
+	for _, level := range stock.Levels {
+		stockLevel := &model.StockLevel{
+			Warehouse: level.Warehouse,
+			Available: int(level.Available),
+			OnHand:    int(level.OnHand),
+			Reserved:  int(level.Reserved),
+		}
+
+		if level.Sku != "" {
+			sku := level.Sku
+			stockLevel.Sku = &sku
+		}
+
+		response.Levels = append(response.Levels, stockLevel)
+	}
+
+	return response
+}
@@ -0,0 +1,52 @@
+package mapping
+
+import (
+	"testing"
+
+	"github.com/lucasmls/ecommerce/services/bff/ports/graphql/model"
+	inventoryPb "github.com/lucasmls/ecommerce/services/inventory/ports/grpc/proto"
+	"github.com/stretchr/testify/suite"
+)
+
+type InventoryMappingSuite struct {
+	suite.Suite
+}
+
+func (s *InventoryMappingSuite) Test_ProductStockFromProto() {
+	s.Run("Should convert the stock along with the stock of each warehouse", func() {
+		got := ProductStockFromProto(&inventoryPb.ProductStock{
+			ProductId: 1,
+			OnHand:    12,
+			Reserved:  5,
+			Available: 7,
+			Levels: []*inventoryPb.StockLevel{
+				{ProductId: 1, Warehouse: "east", OnHand: 10, Reserved: 5, Available: 5},
+				{ProductId: 1, Sku: "IPHONE-13-BLUE", Warehouse: "west", OnHand: 2, Available: 2},
+			},
+		})
+
+		sku := "IPHONE-13-BLUE"
+		s.Equal(&model.ProductStock{
+			Available: 7,
+			InStock:   true,
+			OnHand:    12,
+			Reserved:  5,
+			Levels: []*model.StockLevel{
+				{Warehouse: "east", Available: 5, OnHand: 10, Reserved: 5},
+				{Sku: &sku, Warehouse: "west", Available: 2, OnHand: 2},
+			},
+		}, got)
+	})
+
+	s.Run("Should report the products without available units as out of stock", func() {
+		got := ProductStockFromProto(&inventoryPb.ProductStock{ProductId: 2, OnHand: 3, Reserved: 3})
+
+		s.False(got.InStock)
+		s.Equal(0, got.Available)
+		s.Empty(got.Levels)
+	})
+}
+
+func TestInventoryMappingSuite(t *testing.T) {
+	suite.Run(t, new(InventoryMappingSuite))
+}
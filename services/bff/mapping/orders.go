@@ -0,0 +1,110 @@
+package mapping
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/lucasmls/ecommerce/services/bff/ports/graphql/model"
+	ordersPb "github.com/lucasmls/ecommerce/services/orders/ports/grpc/proto"
+)
+
+// PlaceOrderInputToProto converts the input of the placeOrder mutation into an orders service PlaceOrderRequest
+// for the given customer. Only the products and their units are sent, their names and prices are snapshotted
+// from the catalog by the orders service.
+func PlaceOrderInputToProto(customer string, input model.PlaceOrderInput) (*ordersPb.PlaceOrderRequest, error) {
+	req := &ordersPb.PlaceOrderRequest{
+		CustomerId: customer,
+		Items:      make([]*ordersPb.OrderItemRequest, 0, len(input.Items)),
+	}
+
+	for _, item := range input.Items {
+		productID, err := ProductIDToProto(item.ProductID)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Items = append(req.Items, &ordersPb.OrderItemRequest{
+			ProductId: productID,
+			Quantity:  int32(item.Quantity),
+		})
+	}
+
+	return req, nil
+}
+
+// OrdersArgsToProto converts the arguments of the orders query into an orders service ListOrdersRequest
+// for the given customer. A missing limit is left to the orders service default.
+func OrdersArgsToProto(customer string, status *model.OrderStatus, limit *int) *ordersPb.ListOrdersRequest {
+	req := &ordersPb.ListOrdersRequest{CustomerId: customer}
+
+	if status != nil {
+		req.Status = OrderStatusToProto(*status)
+	}
+
+	if limit != nil {
+		req.Limit = int32(*limit)
+	}
+
+	return req
+}
+
+// OrderStatusToProto converts an OrderStatus into the orders service one, its lowercase name
+func OrderStatusToProto(status model.OrderStatus) string {
+	return strings.ToLower(string(status))
+}
+
+// OrderStatusFromProto converts an orders service status into its GraphQL model
+func OrderStatusFromProto(status string) model.OrderStatus {
+	return model.OrderStatus(strings.ToUpper(status))
+}
+
+// OrderFromProto converts an orders service Order into its GraphQL model
+func OrderFromProto(order *ordersPb.Order) *model.Order {
+	response := &model.Order{
+		ID:            strconv.FormatInt(int64(order.Id), 10),
+		Number:        order.Number,
+		Status:        OrderStatusFromProto(order.Status),
+		Items:         make([]*model.OrderItem, 0, len(order.Items)),
+		Total:         float64(order.Total) / priceScale,
+		StatusHistory: make([]*model.OrderStatusChange, 0, len(order.StatusHistory)),
+		PlacedAt:      order.PlacedAt.AsTime(),
+		UpdatedAt:     order.UpdatedAt.AsTime(),
+	}
+
+	for _, item := range order.Items {
+		response.Items = append(response.Items, &model.OrderItem{
+			ProductID: ProductIDFromProto(item.ProductId),
+			Name:      item.Name,
+			Quantity:  int(item.Quantity),
+			UnitPrice: PriceFromProto(item.UnitPrice),
+			Total:     float64(item.Total) / priceScale,
+		})
+	}
+
+	for _, change := range order.StatusHistory {
+		statusChange := &model.OrderStatusChange{
+			From: OrderStatusFromProto(change.From),
+			To:   OrderStatusFromProto(change.To),
+			At:   change.At.AsTime(),
+		}
+
+		if change.Reason != "" {
+			reason := change.Reason
+			statusChange.Reason = &reason
+		}
+
+		response.StatusHistory = append(response.StatusHistory, statusChange)
+	}
+
+	return response
+}
+
+// OrdersFromProto converts many orders service Orders into their GraphQL models
+func OrdersFromProto(orders []*ordersPb.Order) []*model.Order {
+	response := make([]*model.Order, 0, len(orders))
+	for _, order := range orders {
+		response = append(response, OrderFromProto(order))
+	}
+
+	return response
+}
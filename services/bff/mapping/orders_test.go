@@ -0,0 +1,93 @@
+package mapping
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/bff/ports/graphql/model"
+	ordersPb "github.com/lucasmls/ecommerce/services/orders/ports/grpc/proto"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type OrdersMappingSuite struct {
+	suite.Suite
+}
+
+func (s *OrdersMappingSuite) Test_PlaceOrderInputToProto() {
+	s.Run("Should send only the products and their units", func() {
+		got, err := PlaceOrderInputToProto("john", model.PlaceOrderInput{
+			Items: []*model.OrderItemInput{{ProductID: "1", Quantity: 2}, {ProductID: "2", Quantity: 1}},
+		})
+
+		s.NoError(err)
+		s.Equal("john", got.CustomerId)
+		s.Require().Len(got.Items, 2)
+		s.Equal(int32(1), got.Items[0].ProductId)
+		s.Equal(int32(2), got.Items[0].Quantity)
+		s.Equal(int32(2), got.Items[1].ProductId)
+	})
+
+	s.Run("Should fail in case a product id is invalid", func() {
+		_, err := PlaceOrderInputToProto("john", model.PlaceOrderInput{
+			Items: []*model.OrderItemInput{{ProductID: "iphone", Quantity: 1}},
+		})
+
+		s.ErrorIs(err, ErrInvalidProductID)
+	})
+}
+
+func (s *OrdersMappingSuite) Test_OrdersArgsToProto() {
+	status := model.OrderStatusAwaitingPayment
+	limit := 5
+
+	s.Equal(&ordersPb.ListOrdersRequest{CustomerId: "john"}, OrdersArgsToProto("john", nil, nil))
+	s.Equal(
+		&ordersPb.ListOrdersRequest{CustomerId: "john", Status: "awaiting_payment", Limit: 5},
+		OrdersArgsToProto("john", &status, &limit),
+	)
+}
+
+func (s *OrdersMappingSuite) Test_OrderFromProto() {
+	placedAt := time.Date(2022, 10, 19, 10, 0, 0, 0, time.UTC)
+	cancelledAt := placedAt.Add(time.Hour)
+
+	got := OrderFromProto(&ordersPb.Order{
+		Id:     7,
+		Number: "20221019-7K3M9Q",
+		Status: "cancelled",
+		Items: []*ordersPb.LineItem{
+			{ProductId: 1, Name: "iPhone 13", Quantity: 2, UnitPrice: 79900, Total: 159800},
+		},
+		StatusHistory: []*ordersPb.StatusChange{
+			{Event: "cancel", From: "pending", To: "cancelled", Reason: "changed my mind", At: timestamppb.New(cancelledAt)},
+		},
+		Total:     159800,
+		PlacedAt:  timestamppb.New(placedAt),
+		UpdatedAt: timestamppb.New(cancelledAt),
+	})
+
+	s.Equal("7", got.ID)
+	s.Equal("20221019-7K3M9Q", got.Number)
+	s.Equal(model.OrderStatusCancelled, got.Status)
+	s.Equal(1598.0, got.Total)
+	s.Equal(placedAt, got.PlacedAt)
+	s.Equal(cancelledAt, got.UpdatedAt)
+
+	s.Require().Len(got.Items, 1)
+	s.Equal("1", got.Items[0].ProductID)
+	s.Equal("iPhone 13", got.Items[0].Name)
+	s.Equal(799.0, got.Items[0].UnitPrice)
+	s.Equal(1598.0, got.Items[0].Total)
+	s.Nil(got.Items[0].Product)
+
+	s.Require().Len(got.StatusHistory, 1)
+	s.Equal(model.OrderStatusPending, got.StatusHistory[0].From)
+	s.Equal(model.OrderStatusCancelled, got.StatusHistory[0].To)
+	s.Equal("changed my mind", *got.StatusHistory[0].Reason)
+	s.Equal(cancelledAt, got.StatusHistory[0].At)
+}
+
+func TestOrdersMappingSuite(t *testing.T) {
+	suite.Run(t, new(OrdersMappingSuite))
+}
@@ -0,0 +1,35 @@
+package mapping
+
+import (
+	"strings"
+
+	"github.com/lucasmls/ecommerce/services/bff/ports/graphql/model"
+	pricingPb "github.com/lucasmls/ecommerce/services/pricing/ports/grpc/proto"
+)
+
+// ProductPricingFromProto converts the pricing service QuoteLine of a single unit of a product into its GraphQL model
+func ProductPricingFromProto(line *pricingPb.QuoteLine) *model.ProductPricing {
+	response := &model.ProductPricing{
+		BasePrice:   PriceFromProto(line.BaseUnitPrice),
+		Price:       PriceFromProto(line.Total),
+		OnSale:      line.Total < line.Subtotal,
+		Adjustments: make([]*model.PriceAdjustment, 0, len(line.Adjustments)),
+	}
+
+	for _, adjustment := range line.Adjustments {
+		priceAdjustment := &model.PriceAdjustment{
+			Source:      model.PriceAdjustmentSource(strings.ToUpper(adjustment.Source)),
+			Description: adjustment.Description,
+			Amount:      PriceFromProto(adjustment.Amount),
+		}
+
+		if adjustment.Code != "" {
+			code := adjustment.Code
+			priceAdjustment.Code = &code
+		}
+
+		response.Adjustments = append(response.Adjustments, priceAdjustment)
+	}
+
+	return response
+}
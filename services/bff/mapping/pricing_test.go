@@ -0,0 +1,63 @@
+package mapping
+
+import (
+	"testing"
+
+	"github.com/lucasmls/ecommerce/services/bff/ports/graphql/model"
+	pricingPb "github.com/lucasmls/ecommerce/services/pricing/ports/grpc/proto"
+	"github.com/stretchr/testify/suite"
+)
+
+type PricingMappingSuite struct {
+	suite.Suite
+}
+
+func (s *PricingMappingSuite) Test_ProductPricingFromProto() {
+	s.Run("Should convert the price along with the adjustments explaining it", func() {
+		got := ProductPricingFromProto(&pricingPb.QuoteLine{
+			ProductId:     1,
+			Quantity:      1,
+			BaseUnitPrice: 79900,
+			UnitPrice:     74900,
+			Subtotal:      79900,
+			Discount:      12390,
+			Total:         67510,
+			Adjustments: []*pricingPb.Adjustment{
+				{Source: "price_list", SourceId: 1, Description: "Black Friday", Amount: 5000},
+				{Source: "promotion", SourceId: 2, Description: "Members get 10% off", Code: "MEMBERS", Amount: 7390},
+			},
+		})
+
+		code := "MEMBERS"
+		s.Equal(&model.ProductPricing{
+			BasePrice: 799,
+			Price:     675.1,
+			OnSale:    true,
+			Adjustments: []*model.PriceAdjustment{
+				{Source: model.PriceAdjustmentSourcePriceList, Description: "Black Friday", Amount: 50},
+				{Source: model.PriceAdjustmentSourcePromotion, Description: "Members get 10% off", Code: &code, Amount: 73.9},
+			},
+		}, got)
+	})
+
+	s.Run("Should not be on sale in case nothing was taken off", func() {
+		got := ProductPricingFromProto(&pricingPb.QuoteLine{
+			ProductId:     1,
+			Quantity:      1,
+			BaseUnitPrice: 79900,
+			UnitPrice:     79900,
+			Subtotal:      79900,
+			Total:         79900,
+		})
+
+		s.Equal(&model.ProductPricing{
+			BasePrice:   799,
+			Price:       799,
+			Adjustments: []*model.PriceAdjustment{},
+		}, got)
+	})
+}
+
+func TestPricingMappingSuite(t *testing.T) {
+	suite.Run(t, new(PricingMappingSuite))
+}
@@ -0,0 +1,629 @@
+// Package mapping converts between the GraphQL models exposed by the BFF and
+// the protobuf messages of the services it talks to.
+//
+// Every conversion between the two type systems must go through this package,
+// so a change on a service contract breaks the build here instead of silently
+// drifting at runtime.
+package mapping
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lucasmls/ecommerce/services/bff/ports/graphql/model"
+	productsPb "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// priceScale is the amount of minor units (cents) in a major currency unit.
+// The products service stores prices in minor units while GraphQL exposes major units.
+const priceScale = 100
+
+var (
+	ErrInvalidProductID    = errors.New("invalid-product-id")
+	ErrInvalidPrice        = errors.New("invalid-price")
+	ErrDuplicatedAttribute = errors.New("duplicated-attribute")
+)
+
+// ProductIDToProto decodes a GraphQL product ID into a products service ID
+func ProductIDToProto(id string) (int32, error) {
+	decoded, err := strconv.ParseInt(id, 10, 32)
+	if err != nil || decoded <= 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidProductID, id)
+	}
+
+	return int32(decoded), nil
+}
+
+// ProductIDFromProto encodes a products service ID into a GraphQL product ID
+func ProductIDFromProto(id int32) string {
+	return strconv.FormatInt(int64(id), 10)
+}
+
+// ProductIDsToProto decodes many GraphQL product IDs, preserving their order
+func ProductIDsToProto(ids []string) ([]int32, error) {
+	response := make([]int32, 0, len(ids))
+	for _, id := range ids {
+		decoded, err := ProductIDToProto(id)
+		if err != nil {
+			return nil, err
+		}
+
+		response = append(response, decoded)
+	}
+
+	return response, nil
+}
+
+// PriceToProto converts a price in major units into minor units.
+// Prices with fractions of a minor unit are rejected instead of rounded.
+func PriceToProto(price float64) (int32, error) {
+	minorUnits := math.Round(price * priceScale)
+	if math.IsNaN(price) || minorUnits < 0 || minorUnits > math.MaxInt32 || math.Abs(price*priceScale-minorUnits) > 1e-6 {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidPrice, price)
+	}
+
+	return int32(minorUnits), nil
+}
+
+// PriceFromProto converts a price in minor units into major units
+func PriceFromProto(price int32) float64 {
+	return float64(price) / priceScale
+}
+
+// ProductFromProto converts a products service Product into its GraphQL model
+func ProductFromProto(product *productsPb.Product) *model.Product {
+	if product == nil {
+		return nil
+	}
+
+	response := &model.Product{
+		ID:          ProductIDFromProto(product.Id),
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       PriceFromProto(product.Price),
+		Attributes:  AttributesFromProto(product.Attributes),
+		Version:     int(product.Version),
+	}
+
+	if product.Category != "" {
+		response.Category = &product.Category
+	}
+
+	if product.TaxClass != "" {
+		response.TaxClass = &product.TaxClass
+	}
+
+	if product.Weight != 0 {
+		weight := int(product.Weight)
+		response.Weight = &weight
+	}
+
+	response.Dimensions = DimensionsFromProto(product.Dimensions)
+
+	if product.DeletedAt != nil {
+		deletedAt := product.DeletedAt.AsTime()
+		response.DeletedAt = &deletedAt
+	}
+
+	return response
+}
+
+// ProductsFromProto converts many products service Products into their GraphQL models
+func ProductsFromProto(products []*productsPb.Product) []*model.Product {
+	response := make([]*model.Product, 0, len(products))
+	for _, product := range products {
+		response = append(response, ProductFromProto(product))
+	}
+
+	return response
+}
+
+// ProductToProto converts a GraphQL Product into a products service Product
+func ProductToProto(product model.Product) (*productsPb.Product, error) {
+	id, err := ProductIDToProto(product.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	price, err := PriceToProto(product.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &productsPb.Product{
+		Id:          id,
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       price,
+		Version:     int32(product.Version),
+	}
+
+	if product.Category != nil {
+		response.Category = *product.Category
+	}
+
+	if product.TaxClass != nil {
+		response.TaxClass = *product.TaxClass
+	}
+
+	if product.Weight != nil {
+		response.Weight = int32(*product.Weight)
+	}
+
+	if product.Dimensions != nil {
+		response.Dimensions = &productsPb.Dimensions{
+			Length: int32(product.Dimensions.Length),
+			Width:  int32(product.Dimensions.Width),
+			Height: int32(product.Dimensions.Height),
+		}
+	}
+
+	if len(product.Attributes) > 0 {
+		response.Attributes = make(map[string]string, len(product.Attributes))
+		for _, attribute := range product.Attributes {
+			response.Attributes[attribute.Name] = attribute.Value
+		}
+	}
+
+	return response, nil
+}
+
+// RegisterProductInputToProto converts the registerProduct input into a products service Product
+func RegisterProductInputToProto(input model.RegisterProductInput) (*productsPb.Product, error) {
+	price, err := PriceToProto(input.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes, err := AttributesToProto(input.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &productsPb.Product{
+		Name:        input.Name,
+		Description: input.Description,
+		Price:       price,
+		Attributes:  attributes,
+	}
+
+	if input.Category != nil {
+		response.Category = *input.Category
+	}
+
+	if input.TaxClass != nil {
+		response.TaxClass = *input.TaxClass
+	}
+
+	if input.Weight != nil {
+		response.Weight = int32(*input.Weight)
+	}
+
+	response.Dimensions = DimensionsToProto(input.Dimensions)
+
+	return response, nil
+}
+
+// UpdateProductInputToProto converts the updateProduct input into a products service Product.
+// The version is always sent, the products service rejects a stale one.
+func UpdateProductInputToProto(input model.UpdateProductInput) (*productsPb.Product, error) {
+	attributes, err := AttributesToProto(input.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := ProductToProto(model.Product{
+		ID:          input.ID,
+		Name:        input.Name,
+		Description: input.Description,
+		Price:       input.Price,
+		Category:    input.Category,
+		TaxClass:    input.TaxClass,
+		Version:     input.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response.Attributes = attributes
+
+	if input.Weight != nil {
+		response.Weight = int32(*input.Weight)
+	}
+
+	response.Dimensions = DimensionsToProto(input.Dimensions)
+
+	return response, nil
+}
+
+// PatchProductInputToProto converts the patchProduct input into a products service PatchRequest.
+// Only the fields present on the input are listed on the update mask.
+func PatchProductInputToProto(input model.PatchProductInput) (*productsPb.PatchRequest, error) {
+	id, err := ProductIDToProto(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &productsPb.PatchRequest{
+		Product:    &productsPb.Product{Id: id, Version: int32(input.Version)},
+		UpdateMask: &fieldmaskpb.FieldMask{},
+	}
+
+	if input.Name != nil {
+		req.Product.Name = *input.Name
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "name")
+	}
+
+	if input.Description != nil {
+		req.Product.Description = *input.Description
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "description")
+	}
+
+	if input.Price != nil {
+		price, err := PriceToProto(*input.Price)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Product.Price = price
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "price")
+	}
+
+	if input.Category != nil {
+		req.Product.Category = *input.Category
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "category")
+	}
+
+	if input.TaxClass != nil {
+		req.Product.TaxClass = *input.TaxClass
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "tax_class")
+	}
+
+	if input.Weight != nil {
+		req.Product.Weight = int32(*input.Weight)
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "weight")
+	}
+
+	if input.Dimensions != nil {
+		req.Product.Dimensions = DimensionsToProto(input.Dimensions)
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "dimensions")
+	}
+
+	if input.Attributes != nil {
+		attributes, err := AttributesToProto(input.Attributes)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Product.Attributes = attributes
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "attributes")
+	}
+
+	return req, nil
+}
+
+// RemoveProductInputToProto converts the removeProduct input into a products service DeleteRequest
+func RemoveProductInputToProto(input model.RemoveProductInput) (*productsPb.DeleteRequest, error) {
+	id, err := ProductIDToProto(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &productsPb.DeleteRequest{Id: id}, nil
+}
+
+// RestoreProductInputToProto converts the restoreProduct input into a products service RestoreRequest
+func RestoreProductInputToProto(input model.RestoreProductInput) (*productsPb.RestoreRequest, error) {
+	id, err := ProductIDToProto(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &productsPb.RestoreRequest{Id: id}, nil
+}
+
+// ProductHistoryArgsToProto converts the arguments of the Product.history field into a products service GetProductHistoryRequest.
+// A missing limit is left to the products service default.
+func ProductHistoryArgsToProto(productID string, limit *int, before *string) (*productsPb.GetProductHistoryRequest, error) {
+	id, err := ProductIDToProto(productID)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &productsPb.GetProductHistoryRequest{ProductId: id}
+
+	if limit != nil {
+		req.Limit = int32(*limit)
+	}
+
+	if before != nil {
+		beforeID, err := ProductIDToProto(*before)
+		if err != nil {
+			return nil, err
+		}
+
+		req.BeforeId = beforeID
+	}
+
+	return req, nil
+}
+
+// ProductChangeFromProto converts a products service AuditEntry into its GraphQL model
+func ProductChangeFromProto(entry *productsPb.AuditEntry) *model.ProductChange {
+	response := &model.ProductChange{
+		ID:     strconv.FormatInt(int64(entry.Id), 10),
+		Action: model.ProductChangeAction(strings.ToUpper(entry.Action)),
+		Actor:  entry.Actor,
+		Before: ProductFromProto(entry.Before),
+		After:  ProductFromProto(entry.After),
+		At:     entry.CreatedAt.AsTime(),
+	}
+
+	if entry.TraceId != "" {
+		response.TraceID = &entry.TraceId
+	}
+
+	return response
+}
+
+// ProductChangesFromProto converts many products service AuditEntries into their GraphQL models
+func ProductChangesFromProto(entries []*productsPb.AuditEntry) []*model.ProductChange {
+	response := make([]*model.ProductChange, 0, len(entries))
+	for _, entry := range entries {
+		response = append(response, ProductChangeFromProto(entry))
+	}
+
+	return response
+}
+
+// ProductImageFromProto converts a products service ProductImage into its GraphQL model
+func ProductImageFromProto(image *productsPb.ProductImage) *model.ProductImage {
+	response := &model.ProductImage{
+		ID:          strconv.FormatInt(int64(image.Id), 10),
+		URL:         image.Url,
+		ContentType: image.ContentType,
+		Width:       int(image.Width),
+		Height:      int(image.Height),
+		Size:        int(image.Size),
+		Thumbnails:  make([]*model.Thumbnail, 0, len(image.Thumbnails)),
+	}
+
+	if image.AltText != "" {
+		response.AltText = &image.AltText
+	}
+
+	for _, thumbnail := range image.Thumbnails {
+		response.Thumbnails = append(response.Thumbnails, &model.Thumbnail{
+			Name:   thumbnail.Name,
+			URL:    thumbnail.Url,
+			Width:  int(thumbnail.Width),
+			Height: int(thumbnail.Height),
+		})
+	}
+
+	return response
+}
+
+// ProductImagesFromProto converts many products service ProductImages into their GraphQL models
+func ProductImagesFromProto(images []*productsPb.ProductImage) []*model.ProductImage {
+	response := make([]*model.ProductImage, 0, len(images))
+	for _, image := range images {
+		response = append(response, ProductImageFromProto(image))
+	}
+
+	return response
+}
+
+// SearchProductsArgsToProto converts the arguments of the searchProducts query into a products service SearchRequest.
+// A missing limit or sort is left to the products service default.
+func SearchProductsArgsToProto(query string, limit *int, offset *int, sort []*model.ProductSort) *productsPb.SearchRequest {
+	req := &productsPb.SearchRequest{Query: query, Sort: ProductSortToProto(sort)}
+
+	if limit != nil {
+		req.Limit = int32(*limit)
+	}
+
+	if offset != nil {
+		req.Offset = int32(*offset)
+	}
+
+	return req
+}
+
+// ProductSortToProto converts the sort argument of the products queries into the products service sort keys,
+// whose fields are the lowercase names of the GraphQL ones
+func ProductSortToProto(sort []*model.ProductSort) []*productsPb.SortKey {
+	var response []*productsPb.SortKey
+	for _, key := range sort {
+		response = append(response, &productsPb.SortKey{
+			Field:      strings.ToLower(string(key.Field)),
+			Descending: key.Direction == model.SortDirectionDesc,
+		})
+	}
+
+	return response
+}
+
+// ProductSearchResultFromProto converts a products service SearchResult into its GraphQL model
+func ProductSearchResultFromProto(result *productsPb.SearchResult) *model.ProductSearchResult {
+	return &model.ProductSearchResult{
+		Product: ProductFromProto(result.Product),
+		Score:   result.Score,
+		Highlights: &model.ProductHighlights{
+			Name:        result.NameHighlight,
+			Description: result.DescriptionHighlight,
+		},
+	}
+}
+
+// ProductSearchResultsFromProto converts many products service SearchResults into their GraphQL models
+func ProductSearchResultsFromProto(results []*productsPb.SearchResult) []*model.ProductSearchResult {
+	response := make([]*model.ProductSearchResult, 0, len(results))
+	for _, result := range results {
+		response = append(response, ProductSearchResultFromProto(result))
+	}
+
+	return response
+}
+
+// AttributesToProto converts the attributes of an input into the products service ones, rejecting duplicated names
+func AttributesToProto(attributes []*model.ProductAttributeInput) (map[string]string, error) {
+	if len(attributes) == 0 {
+		return nil, nil
+	}
+
+	response := make(map[string]string, len(attributes))
+	for _, attribute := range attributes {
+		if _, ok := response[attribute.Name]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicatedAttribute, attribute.Name)
+		}
+
+		response[attribute.Name] = attribute.Value
+	}
+
+	return response, nil
+}
+
+// AttributesFromProto converts the products service attributes into their GraphQL models, sorted by name
+func AttributesFromProto(attributes map[string]string) []*model.ProductAttribute {
+	response := make([]*model.ProductAttribute, 0, len(attributes))
+	for name, value := range attributes {
+		response = append(response, &model.ProductAttribute{Name: name, Value: value})
+	}
+
+	sort.Slice(response, func(i, j int) bool {
+		return response[i].Name < response[j].Name
+	})
+
+	return response
+}
+
+// DimensionsToProto converts the dimensions of a product input, nil when there are none
+func DimensionsToProto(dimensions *model.ProductDimensionsInput) *productsPb.Dimensions {
+	if dimensions == nil {
+		return nil
+	}
+
+	return &productsPb.Dimensions{
+		Length: int32(dimensions.Length),
+		Width:  int32(dimensions.Width),
+		Height: int32(dimensions.Height),
+	}
+}
+
+// DimensionsFromProto converts the dimensions of a products service Product, nil when they're unknown
+func DimensionsFromProto(dimensions *productsPb.Dimensions) *model.ProductDimensions {
+	if dimensions == nil {
+		return nil
+	}
+
+	return &model.ProductDimensions{
+		Length: int(dimensions.Length),
+		Width:  int(dimensions.Width),
+		Height: int(dimensions.Height),
+	}
+}
+
+// ProductFilterToProto converts the productsConnection filter into a products service ProductFilter
+func ProductFilterToProto(filter *model.ProductFilter) (*productsPb.ProductFilter, error) {
+	if filter == nil {
+		return nil, nil
+	}
+
+	attributes, err := AttributesToProto(filter.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &productsPb.ProductFilter{Attributes: attributes}
+
+	if filter.Category != nil {
+		response.Category = *filter.Category
+	}
+
+	if filter.MinPrice != nil {
+		if response.PriceFrom, err = PriceToProto(*filter.MinPrice); err != nil {
+			return nil, err
+		}
+	}
+
+	if filter.MaxPrice != nil {
+		if response.PriceTo, err = PriceToProto(*filter.MaxPrice); err != nil {
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+// ProductFacetsArgsToProto converts the filter of a ProductConnection and the arguments of its facets field
+// into a products service GetFacetsRequest. Missing price boundaries are left to the products service default.
+func ProductFacetsArgsToProto(filter *model.ProductFilter, priceBoundaries []float64) (*productsPb.GetFacetsRequest, error) {
+	protoFilter, err := ProductFilterToProto(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &productsPb.GetFacetsRequest{Filter: protoFilter}
+	for _, boundary := range priceBoundaries {
+		price, err := PriceToProto(boundary)
+		if err != nil {
+			return nil, err
+		}
+
+		req.PriceBoundaries = append(req.PriceBoundaries, price)
+	}
+
+	return req, nil
+}
+
+// ProductFacetsFromProto converts a products service GetFacetsResponse into its GraphQL model
+func ProductFacetsFromProto(facets *productsPb.GetFacetsResponse) *model.ProductFacets {
+	response := &model.ProductFacets{
+		Total:       int(facets.Total),
+		PriceRanges: make([]*model.PriceRangeBucket, 0, len(facets.PriceRanges)),
+		Categories:  FacetBucketsFromProto(facets.Categories),
+		Attributes:  make([]*model.AttributeFacet, 0, len(facets.Attributes)),
+	}
+
+	for _, bucket := range facets.PriceRanges {
+		priceRange := &model.PriceRangeBucket{
+			From:  PriceFromProto(bucket.From),
+			Count: int(bucket.Count),
+		}
+
+		if bucket.To > 0 {
+			to := PriceFromProto(bucket.To)
+			priceRange.To = &to
+		}
+
+		response.PriceRanges = append(response.PriceRanges, priceRange)
+	}
+
+	for _, attribute := range facets.Attributes {
+		response.Attributes = append(response.Attributes, &model.AttributeFacet{
+			Name:   attribute.Name,
+			Values: FacetBucketsFromProto(attribute.Values),
+		})
+	}
+
+	return response
+}
+
+// FacetBucketsFromProto converts many products service FacetBuckets into their GraphQL models
+func FacetBucketsFromProto(buckets []*productsPb.FacetBucket) []*model.FacetBucket {
+	response := make([]*model.FacetBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		response = append(response, &model.FacetBucket{
+			Value: bucket.Value,
+			Count: int(bucket.Count),
+		})
+	}
+
+	return response
+}
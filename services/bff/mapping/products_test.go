@@ -0,0 +1,559 @@
+package mapping
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/bff/ports/graphql/model"
+	productsPb "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type ProductsMappingSuite struct {
+	suite.Suite
+}
+
+func (s *ProductsMappingSuite) Test_ProductIDToProto() {
+	s.Run("Should decode a valid product ID", func() {
+		got, err := ProductIDToProto("42")
+
+		s.NoError(err)
+		s.Equal(int32(42), got)
+	})
+
+	s.Run("Should reject product IDs that aren't positive 32 bits integers", func() {
+		for _, id := range []string{"", "abc", "0", "-1", "1.5", "2147483648"} {
+			_, err := ProductIDToProto(id)
+
+			s.ErrorIs(err, ErrInvalidProductID, id)
+		}
+	})
+
+	s.Run("Should round trip product IDs", func() {
+		for _, id := range []int32{1, 42, math.MaxInt32} {
+			got, err := ProductIDToProto(ProductIDFromProto(id))
+
+			s.NoError(err)
+			s.Equal(id, got)
+		}
+	})
+}
+
+func (s *ProductsMappingSuite) Test_ProductIDsToProto() {
+	s.Run("Should decode many product IDs preserving their order", func() {
+		got, err := ProductIDsToProto([]string{"3", "1", "2"})
+
+		s.NoError(err)
+		s.Equal([]int32{3, 1, 2}, got)
+	})
+
+	s.Run("Should fail in case any product ID is invalid", func() {
+		_, err := ProductIDsToProto([]string{"3", "abc"})
+
+		s.ErrorIs(err, ErrInvalidProductID)
+	})
+}
+
+func (s *ProductsMappingSuite) Test_PriceToProto() {
+	s.Run("Should convert major units into minor units", func() {
+		cases := map[float64]int32{
+			0:       0,
+			0.1:     10,
+			19.99:   1999,
+			6800:    680000,
+			1234.56: 123456,
+		}
+
+		for price, expected := range cases {
+			got, err := PriceToProto(price)
+
+			s.NoError(err)
+			s.Equal(expected, got, price)
+		}
+	})
+
+	s.Run("Should reject negative, fractional and overflowing prices", func() {
+		for _, price := range []float64{-1, 0.001, 19.999, math.NaN(), math.Inf(1), 1e10} {
+			_, err := PriceToProto(price)
+
+			s.ErrorIs(err, ErrInvalidPrice, price)
+		}
+	})
+
+	s.Run("Should round trip prices", func() {
+		for _, price := range []int32{0, 1, 99, 1999, 680000, math.MaxInt32} {
+			got, err := PriceToProto(PriceFromProto(price))
+
+			s.NoError(err)
+			s.Equal(price, got)
+		}
+	})
+}
+
+func (s *ProductsMappingSuite) Test_Product() {
+	s.Run("Should round trip a Product", func() {
+		product := &productsPb.Product{
+			Id:          7,
+			Name:        "Macbook Air M1",
+			Description: "Fast!",
+			Price:       680000,
+			Category:    "laptops",
+			Attributes:  map[string]string{"color": "silver", "chip": "M1"},
+			Version:     4,
+		}
+
+		category := "laptops"
+		gqlProduct := ProductFromProto(product)
+		s.Equal(&model.Product{
+			ID:          "7",
+			Name:        "Macbook Air M1",
+			Description: "Fast!",
+			Price:       6800,
+			Category:    &category,
+			Attributes: []*model.ProductAttribute{
+				{Name: "chip", Value: "M1"},
+				{Name: "color", Value: "silver"},
+			},
+			Version: 4,
+		}, gqlProduct)
+
+		got, err := ProductToProto(*gqlProduct)
+
+		s.NoError(err)
+		s.True(proto.Equal(product, got))
+	})
+
+	s.Run("Should map the deletion time of a deleted Product", func() {
+		deletedAt := time.Date(2022, 4, 20, 10, 0, 0, 0, time.UTC)
+
+		got := ProductFromProto(&productsPb.Product{Id: 7, DeletedAt: timestamppb.New(deletedAt)})
+
+		s.Equal(&model.Product{ID: "7", DeletedAt: &deletedAt, Attributes: []*model.ProductAttribute{}}, got)
+	})
+
+	s.Run("Should map a nil Product to nil", func() {
+		s.Nil(ProductFromProto(nil))
+	})
+
+	s.Run("Should map many Products", func() {
+		got := ProductsFromProto([]*productsPb.Product{{Id: 1}, {Id: 2}})
+
+		s.Equal([]*model.Product{{ID: "1", Attributes: []*model.ProductAttribute{}}, {ID: "2", Attributes: []*model.ProductAttribute{}}}, got)
+	})
+}
+
+func (s *ProductsMappingSuite) Test_Inputs() {
+	s.Run("Should map the registerProduct input", func() {
+		got, err := RegisterProductInputToProto(model.RegisterProductInput{
+			Name:        "Iphone 13",
+			Description: "Cool",
+			Price:       4500.5,
+		})
+
+		s.NoError(err)
+		s.True(proto.Equal(&productsPb.Product{
+			Name:        "Iphone 13",
+			Description: "Cool",
+			Price:       450050,
+		}, got))
+	})
+
+	s.Run("Should map the updateProduct input", func() {
+		got, err := UpdateProductInputToProto(model.UpdateProductInput{
+			ID:          "3",
+			Name:        "Iphone 13",
+			Description: "Cool",
+			Price:       4500,
+		})
+
+		s.NoError(err)
+		s.True(proto.Equal(&productsPb.Product{
+			Id:          3,
+			Name:        "Iphone 13",
+			Description: "Cool",
+			Price:       450000,
+		}, got))
+	})
+
+	s.Run("Should map the expected version of the updateProduct input", func() {
+		got, err := UpdateProductInputToProto(model.UpdateProductInput{
+			ID:      "3",
+			Price:   45,
+			Version: 4,
+		})
+
+		s.NoError(err)
+		s.True(proto.Equal(&productsPb.Product{Id: 3, Price: 4500, Version: 4}, got))
+	})
+
+	s.Run("Should reject an updateProduct input with an invalid ID", func() {
+		_, err := UpdateProductInputToProto(model.UpdateProductInput{ID: "abc"})
+
+		s.ErrorIs(err, ErrInvalidProductID)
+	})
+
+	s.Run("Should only list the provided fields of the patchProduct input on the mask", func() {
+		description := ""
+		price := 48.5
+		got, err := PatchProductInputToProto(model.PatchProductInput{
+			ID:          "3",
+			Description: &description,
+			Price:       &price,
+			Version:     2,
+		})
+
+		s.NoError(err)
+		s.True(proto.Equal(&productsPb.PatchRequest{
+			Product:    &productsPb.Product{Id: 3, Price: 4850, Version: 2},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"description", "price"}},
+		}, got))
+	})
+
+	s.Run("Should map the category and attributes of the patchProduct input", func() {
+		category := "phones"
+		got, err := PatchProductInputToProto(model.PatchProductInput{
+			ID:         "3",
+			Category:   &category,
+			Attributes: []*model.ProductAttributeInput{{Name: "color", Value: "blue"}},
+		})
+
+		s.NoError(err)
+		s.True(proto.Equal(&productsPb.PatchRequest{
+			Product: &productsPb.Product{
+				Id:         3,
+				Category:   "phones",
+				Attributes: map[string]string{"color": "blue"},
+			},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"category", "attributes"}},
+		}, got))
+	})
+
+	s.Run("Should map the tax class of the patchProduct input", func() {
+		taxClass := "reduced"
+		got, err := PatchProductInputToProto(model.PatchProductInput{ID: "3", TaxClass: &taxClass})
+
+		s.NoError(err)
+		s.True(proto.Equal(&productsPb.PatchRequest{
+			Product:    &productsPb.Product{Id: 3, TaxClass: "reduced"},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"tax_class"}},
+		}, got))
+	})
+
+	s.Run("Should map the weight and the dimensions of the patchProduct input", func() {
+		weight := 174
+		got, err := PatchProductInputToProto(model.PatchProductInput{
+			ID:         "3",
+			Weight:     &weight,
+			Dimensions: &model.ProductDimensionsInput{Length: 147, Width: 72, Height: 8},
+		})
+
+		s.NoError(err)
+		s.True(proto.Equal(&productsPb.PatchRequest{
+			Product: &productsPb.Product{
+				Id:         3,
+				Weight:     174,
+				Dimensions: &productsPb.Dimensions{Length: 147, Width: 72, Height: 8},
+			},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"weight", "dimensions"}},
+		}, got))
+	})
+
+	s.Run("Should reject inputs with duplicated attributes", func() {
+		_, err := RegisterProductInputToProto(model.RegisterProductInput{
+			Name: "Iphone 13",
+			Attributes: []*model.ProductAttributeInput{
+				{Name: "color", Value: "blue"},
+				{Name: "color", Value: "red"},
+			},
+		})
+
+		s.ErrorIs(err, ErrDuplicatedAttribute)
+	})
+
+	s.Run("Should reject a patchProduct input with an invalid price", func() {
+		price := 1.001
+		_, err := PatchProductInputToProto(model.PatchProductInput{ID: "3", Price: &price})
+
+		s.ErrorIs(err, ErrInvalidPrice)
+	})
+
+	s.Run("Should map the removeProduct input", func() {
+		got, err := RemoveProductInputToProto(model.RemoveProductInput{ID: "3"})
+
+		s.NoError(err)
+		s.True(proto.Equal(&productsPb.DeleteRequest{Id: 3}, got))
+	})
+
+	s.Run("Should reject a removeProduct input with an invalid ID", func() {
+		_, err := RemoveProductInputToProto(model.RemoveProductInput{ID: "abc"})
+
+		s.ErrorIs(err, ErrInvalidProductID)
+	})
+
+	s.Run("Should map the restoreProduct input", func() {
+		got, err := RestoreProductInputToProto(model.RestoreProductInput{ID: "3"})
+
+		s.NoError(err)
+		s.True(proto.Equal(&productsPb.RestoreRequest{Id: 3}, got))
+	})
+
+	s.Run("Should reject a restoreProduct input with an invalid ID", func() {
+		_, err := RestoreProductInputToProto(model.RestoreProductInput{ID: "0"})
+
+		s.ErrorIs(err, ErrInvalidProductID)
+	})
+}
+
+func (s *ProductsMappingSuite) Test_ProductFacets() {
+	s.Run("Should map the productsConnection filter", func() {
+		category := "laptops"
+		minPrice := 50.0
+		maxPrice := 100.5
+		got, err := ProductFilterToProto(&model.ProductFilter{
+			Category:   &category,
+			MinPrice:   &minPrice,
+			MaxPrice:   &maxPrice,
+			Attributes: []*model.ProductAttributeInput{{Name: "color", Value: "silver"}},
+		})
+
+		s.NoError(err)
+		s.True(proto.Equal(&productsPb.ProductFilter{
+			Category:   "laptops",
+			PriceFrom:  5000,
+			PriceTo:    10050,
+			Attributes: map[string]string{"color": "silver"},
+		}, got))
+	})
+
+	s.Run("Should map a missing filter to nil", func() {
+		got, err := ProductFilterToProto(nil)
+
+		s.NoError(err)
+		s.Nil(got)
+	})
+
+	s.Run("Should reject a filter with an invalid price", func() {
+		minPrice := -1.0
+		_, err := ProductFilterToProto(&model.ProductFilter{MinPrice: &minPrice})
+
+		s.ErrorIs(err, ErrInvalidPrice)
+	})
+
+	s.Run("Should map the facets arguments", func() {
+		category := "laptops"
+		got, err := ProductFacetsArgsToProto(&model.ProductFilter{Category: &category}, []float64{50, 100})
+
+		s.NoError(err)
+		s.True(proto.Equal(&productsPb.GetFacetsRequest{
+			Filter:          &productsPb.ProductFilter{Category: "laptops"},
+			PriceBoundaries: []int32{5000, 10000},
+		}, got))
+	})
+
+	s.Run("Should map the facets", func() {
+		got := ProductFacetsFromProto(&productsPb.GetFacetsResponse{
+			Total: 3,
+			PriceRanges: []*productsPb.PriceRangeBucket{
+				{From: 0, To: 5000, Count: 1},
+				{From: 5000, Count: 2},
+			},
+			Categories: []*productsPb.FacetBucket{{Value: "laptops", Count: 3}},
+			Attributes: []*productsPb.AttributeFacet{
+				{Name: "color", Values: []*productsPb.FacetBucket{{Value: "silver", Count: 2}, {Value: "gold", Count: 1}}},
+			},
+		})
+
+		to := 50.0
+		s.Equal(&model.ProductFacets{
+			Total: 3,
+			PriceRanges: []*model.PriceRangeBucket{
+				{From: 0, To: &to, Count: 1},
+				{From: 50, Count: 2},
+			},
+			Categories: []*model.FacetBucket{{Value: "laptops", Count: 3}},
+			Attributes: []*model.AttributeFacet{
+				{Name: "color", Values: []*model.FacetBucket{{Value: "silver", Count: 2}, {Value: "gold", Count: 1}}},
+			},
+		}, got)
+	})
+}
+
+func (s *ProductsMappingSuite) Test_ProductHistory() {
+	s.Run("Should map the history arguments", func() {
+		limit := 10
+		before := "42"
+
+		got, err := ProductHistoryArgsToProto("7", &limit, &before)
+
+		s.NoError(err)
+		s.True(proto.Equal(&productsPb.GetProductHistoryRequest{ProductId: 7, Limit: 10, BeforeId: 42}, got))
+	})
+
+	s.Run("Should leave the missing history arguments unset", func() {
+		got, err := ProductHistoryArgsToProto("7", nil, nil)
+
+		s.NoError(err)
+		s.True(proto.Equal(&productsPb.GetProductHistoryRequest{ProductId: 7}, got))
+	})
+
+	s.Run("Should reject an invalid cursor", func() {
+		before := "abc"
+
+		_, err := ProductHistoryArgsToProto("7", nil, &before)
+
+		s.ErrorIs(err, ErrInvalidProductID)
+	})
+
+	s.Run("Should map the changes of a Product", func() {
+		at := time.Date(2022, 4, 20, 10, 0, 0, 0, time.UTC)
+		traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+
+		got := ProductChangesFromProto([]*productsPb.AuditEntry{
+			{
+				Id:        8,
+				ProductId: 7,
+				Action:    "patch",
+				Actor:     "jane",
+				Before:    &productsPb.Product{Id: 7, Price: 680000, Version: 1},
+				After:     &productsPb.Product{Id: 7, Price: 720000, Version: 2},
+				TraceId:   traceID,
+				CreatedAt: timestamppb.New(at),
+			},
+			{
+				Id:        7,
+				ProductId: 7,
+				Action:    "register",
+				Actor:     "unknown",
+				After:     &productsPb.Product{Id: 7, Price: 680000, Version: 1},
+				CreatedAt: timestamppb.New(at),
+			},
+		})
+
+		s.Equal([]*model.ProductChange{
+			{
+				ID:      "8",
+				Action:  model.ProductChangeActionPatch,
+				Actor:   "jane",
+				Before:  &model.Product{ID: "7", Price: 6800, Version: 1, Attributes: []*model.ProductAttribute{}},
+				After:   &model.Product{ID: "7", Price: 7200, Version: 2, Attributes: []*model.ProductAttribute{}},
+				TraceID: &traceID,
+				At:      at,
+			},
+			{
+				ID:     "7",
+				Action: model.ProductChangeActionRegister,
+				Actor:  "unknown",
+				After:  &model.Product{ID: "7", Price: 6800, Version: 1, Attributes: []*model.ProductAttribute{}},
+				At:     at,
+			},
+		}, got)
+	})
+}
+
+func (s *ProductsMappingSuite) Test_ProductImages() {
+	s.Run("Should map the images along with their thumbnails", func() {
+		altText := "Front"
+
+		got := ProductImagesFromProto([]*productsPb.ProductImage{
+			{
+				Id: 3, ProductId: 7, Url: "https://cdn.example.com/products/7/a.jpg", ContentType: "image/jpeg",
+				Width: 1200, Height: 800, Size: 2048, AltText: "Front",
+				Thumbnails: []*productsPb.Thumbnail{{Name: "small", Url: "https://cdn.example.com/products/7/a_small.jpg", Width: 150, Height: 100}},
+			},
+			{Id: 4, ProductId: 7, Url: "https://cdn.example.com/products/7/b.png", ContentType: "image/png", Width: 10, Height: 10, Size: 64},
+		})
+
+		s.Equal([]*model.ProductImage{
+			{
+				ID: "3", URL: "https://cdn.example.com/products/7/a.jpg", ContentType: "image/jpeg",
+				Width: 1200, Height: 800, Size: 2048, AltText: &altText,
+				Thumbnails: []*model.Thumbnail{{Name: "small", URL: "https://cdn.example.com/products/7/a_small.jpg", Width: 150, Height: 100}},
+			},
+			{
+				ID: "4", URL: "https://cdn.example.com/products/7/b.png", ContentType: "image/png",
+				Width: 10, Height: 10, Size: 64, Thumbnails: []*model.Thumbnail{},
+			},
+		}, got)
+	})
+}
+
+func (s *ProductsMappingSuite) Test_ProductSort() {
+	s.Run("Should map every sort field to the products service one", func() {
+		expected := map[model.ProductSortField]string{
+			model.ProductSortFieldName:      "name",
+			model.ProductSortFieldPrice:     "price",
+			model.ProductSortFieldCreatedAt: "created_at",
+			model.ProductSortFieldUpdatedAt: "updated_at",
+			model.ProductSortFieldRelevance: "relevance",
+		}
+
+		s.Len(expected, len(model.AllProductSortField))
+		for field, protoField := range expected {
+			got := ProductSortToProto([]*model.ProductSort{{Field: field, Direction: model.SortDirectionDesc}})
+
+			s.True(proto.Equal(&productsPb.SortKey{Field: protoField, Descending: true}, got[0]), field)
+		}
+	})
+
+	s.Run("Should map a missing sort to nil", func() {
+		s.Nil(ProductSortToProto(nil))
+	})
+}
+
+func (s *ProductsMappingSuite) Test_SearchProducts() {
+	s.Run("Should map the search arguments", func() {
+		limit := 5
+		offset := 10
+
+		got := SearchProductsArgsToProto("macbook pro", &limit, &offset, []*model.ProductSort{
+			{Field: model.ProductSortFieldPrice, Direction: model.SortDirectionAsc},
+			{Field: model.ProductSortFieldRelevance, Direction: model.SortDirectionDesc},
+		})
+
+		s.True(proto.Equal(&productsPb.SearchRequest{
+			Query:  "macbook pro",
+			Limit:  5,
+			Offset: 10,
+			Sort: []*productsPb.SortKey{
+				{Field: "price"},
+				{Field: "relevance", Descending: true},
+			},
+		}, got))
+	})
+
+	s.Run("Should leave the missing search arguments unset", func() {
+		got := SearchProductsArgsToProto("macbook", nil, nil, nil)
+
+		s.True(proto.Equal(&productsPb.SearchRequest{Query: "macbook"}, got))
+	})
+
+	s.Run("Should map the search results", func() {
+		got := ProductSearchResultsFromProto([]*productsPb.SearchResult{
+			{
+				Product:              &productsPb.Product{Id: 7, Name: "Macbook Pro", Description: "Fast!", Price: 1650000, Version: 1},
+				Score:                4,
+				NameHighlight:        "<em>Macbook</em> <em>Pro</em>",
+				DescriptionHighlight: "Fast!",
+			},
+		})
+
+		s.Equal([]*model.ProductSearchResult{
+			{
+				Product: &model.Product{ID: "7", Name: "Macbook Pro", Description: "Fast!", Price: 16500, Version: 1, Attributes: []*model.ProductAttribute{}},
+				Score:   4,
+				Highlights: &model.ProductHighlights{
+					Name:        "<em>Macbook</em> <em>Pro</em>",
+					Description: "Fast!",
+				},
+			},
+		}, got)
+	})
+}
+
+func TestProductsMappingSuite(t *testing.T) {
+	suite.Run(t, new(ProductsMappingSuite))
+}
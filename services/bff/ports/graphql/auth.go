@@ -0,0 +1,55 @@
+package graph
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/lucasmls/ecommerce/shared/actor"
+	"github.com/lucasmls/ecommerce/shared/auth"
+)
+
+// AuthorizationHeader carries the access token of the customer making a request, e.g. "Bearer <token>".
+// The token is forwarded to the services, which verify it on their own.
+const AuthorizationHeader = "Authorization"
+
+// AuthMiddleware verifies the access token of every request, if it has one, putting it into its context.
+// The customer holding the token becomes the actor of the request, the requests without one have no actor and
+// the services record their changes as made by an unknown one. The tokens are ignored when the secret is empty.
+func AuthMiddleware(secret []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get(AuthorizationHeader)
+		if header == "" || len(secret) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		token, ok := auth.ParseBearer(header)
+		if !ok {
+			http.Error(w, auth.ErrInvalidToken.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		claims, err := auth.Verify(secret, token, time.Now())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		ctx := auth.NewContext(r.Context(), token, claims)
+		ctx = actor.NewContext(ctx, claims.Subject)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// customerFromContext tells the customer making a request, as told by its verified access token.
+// No header sent by the caller, such as X-Actor, is ever trusted for it.
+func customerFromContext(ctx context.Context) (string, bool) {
+	claims, ok := auth.FromContext(ctx)
+	if !ok || claims.Subject == "" {
+		return "", false
+	}
+
+	return claims.Subject, true
+}
@@ -0,0 +1,91 @@
+package graph
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/shared/actor"
+	"github.com/lucasmls/ecommerce/shared/auth"
+	"github.com/stretchr/testify/suite"
+)
+
+var tokenSecret = []byte("secret")
+
+type AuthMiddlewareSuite struct {
+	suite.Suite
+}
+
+func (s *AuthMiddlewareSuite) sign(secret []byte, expiresAt time.Time) string {
+	token, err := auth.Sign(secret, auth.Claims{Subject: "c1", Email: "john@example.com", IssuedAt: time.Now(), ExpiresAt: expiresAt})
+	s.Require().NoError(err)
+
+	return token
+}
+
+func (s *AuthMiddlewareSuite) serve(header string) (*httptest.ResponseRecorder, *http.Request) {
+	var served *http.Request
+
+	handler := AuthMiddleware(tokenSecret, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served = r
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/query", nil)
+	if header != "" {
+		req.Header.Set(AuthorizationHeader, header)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	return recorder, served
+}
+
+func (s *AuthMiddlewareSuite) Test_AuthMiddleware() {
+	s.Run("Should put the token and the customer holding it into the context of the request", func() {
+		token := s.sign(tokenSecret, time.Now().Add(time.Hour))
+
+		recorder, served := s.serve("Bearer " + token)
+
+		s.Equal(http.StatusOK, recorder.Code)
+
+		claims, ok := auth.FromContext(served.Context())
+		s.True(ok)
+		s.Equal("c1", claims.Subject)
+
+		forwarded, _ := auth.TokenFromContext(served.Context())
+		s.Equal(token, forwarded)
+
+		name, _ := actor.FromContext(served.Context())
+		s.Equal("c1", name)
+	})
+
+	s.Run("Should leave the context alone when the request has no token", func() {
+		recorder, served := s.serve("")
+
+		s.Equal(http.StatusOK, recorder.Code)
+
+		_, ok := auth.FromContext(served.Context())
+		s.False(ok)
+	})
+
+	s.Run("Should reject the request in case the token can't be verified", func() {
+		testCases := []string{
+			"Basic am9objpzZWNyZXQ=",
+			"Bearer " + s.sign([]byte("another-secret"), time.Now().Add(time.Hour)),
+			"Bearer " + s.sign(tokenSecret, time.Now().Add(-time.Minute)),
+		}
+
+		for _, header := range testCases {
+			recorder, served := s.serve(header)
+
+			s.Equal(http.StatusUnauthorized, recorder.Code)
+			s.Nil(served)
+		}
+	})
+}
+
+func TestAuthMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(AuthMiddlewareSuite))
+}
@@ -0,0 +1,82 @@
+package graph
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lucasmls/ecommerce/services/bff/ports/graphql/model"
+	productsPb "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+)
+
+// productsCacheKey normalizes the arguments of a products query into a cache key,
+// so the same set of ids yields the same key regardless of order and duplicates.
+func productsCacheKey(query string, ids ...int32) string {
+	unique := make(map[int32]bool, len(ids))
+	normalized := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if unique[id] {
+			continue
+		}
+
+		unique[id] = true
+		normalized = append(normalized, int(id))
+	}
+
+	sort.Ints(normalized)
+
+	parts := make([]string, 0, len(normalized))
+	for _, id := range normalized {
+		parts = append(parts, strconv.Itoa(id))
+	}
+
+	return query + ":" + strings.Join(parts, ",")
+}
+
+// sortCacheKey formats the sort of a products query to be appended to its cache key,
+// it is empty when the products aren't sorted so the key stays the same
+func sortCacheKey(keys []*productsPb.SortKey) string {
+	if len(keys) == 0 {
+		return ""
+	}
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		direction := "asc"
+		if key.Descending {
+			direction = "desc"
+		}
+
+		parts = append(parts, key.Field+":"+direction)
+	}
+
+	return "?sort=" + strings.Join(parts, ",")
+}
+
+// cachedProducts looks up the products cache, it always misses when caching is disabled
+func (r *Resolver) cachedProducts(key string) ([]*model.Product, bool) {
+	if r.ProductsCache == nil {
+		return nil, false
+	}
+
+	return r.ProductsCache.Get(key)
+}
+
+// cacheProducts stores the result of a products query, it is a no-op when caching is disabled
+func (r *Resolver) cacheProducts(key string, products []*model.Product) {
+	if r.ProductsCache == nil {
+		return
+	}
+
+	r.ProductsCache.Set(key, products)
+}
+
+// invalidateProductsCache drops every cached products query.
+// It must be called whenever a mutation changes the catalog.
+func (r *Resolver) invalidateProductsCache() {
+	if r.ProductsCache == nil {
+		return
+	}
+
+	r.ProductsCache.Purge()
+}
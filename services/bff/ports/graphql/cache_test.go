@@ -0,0 +1,149 @@
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/bff/cache"
+	"github.com/lucasmls/ecommerce/services/bff/ports/graphql/model"
+	productsPb "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// fakeProductsService counts the calls made to the products service
+type fakeProductsService struct {
+	productsPb.ProductsServiceClient
+
+	listCalls int
+	products  []*productsPb.Product
+}
+
+func (f *fakeProductsService) List(ctx context.Context, in *productsPb.ListRequest, opts ...grpc.CallOption) (*productsPb.ListResponse, error) {
+	f.listCalls++
+
+	if len(in.Ids) == 0 {
+		return &productsPb.ListResponse{Data: f.products}, nil
+	}
+
+	response := &productsPb.ListResponse{}
+	for _, product := range f.products {
+		for _, id := range in.Ids {
+			if product.Id == id {
+				response.Data = append(response.Data, product)
+			}
+		}
+	}
+
+	return response, nil
+}
+
+func (f *fakeProductsService) Delete(ctx context.Context, in *productsPb.DeleteRequest, opts ...grpc.CallOption) (*productsPb.DeleteResponse, error) {
+	return &productsPb.DeleteResponse{Data: "Product deleted successfully"}, nil
+}
+
+type ProductsCacheSuite struct {
+	suite.Suite
+
+	productsService *fakeProductsService
+	resolver        *Resolver
+}
+
+func (s *ProductsCacheSuite) SetupTest() {
+	s.productsService = &fakeProductsService{
+		products: []*productsPb.Product{
+			{Id: 1, Name: "Iphone 13", Description: "Cool", Price: 450000},
+			{Id: 2, Name: "Macbook Pro M1 Max", Description: "Fast!", Price: 1650000},
+		},
+	}
+
+	s.resolver = &Resolver{
+		Logger:          zap.NewNop(),
+		Tracer:          trace.NewNoopTracerProvider().Tracer(""),
+		ProductsService: s.productsService,
+		ProductsCache: cache.MustNew[[]*model.Product](cache.Input{
+			Name:       "products-test",
+			TTL:        time.Minute,
+			MaxEntries: 10,
+		}),
+	}
+}
+
+func (s *ProductsCacheSuite) Test_productsCacheKey() {
+	s.Run("Should normalize the order and duplicates of the ids", func() {
+		s.Equal(productsCacheKey("productsByIds", 1, 2, 3), productsCacheKey("productsByIds", 3, 1, 2, 1))
+		s.NotEqual(productsCacheKey("productsByIds", 1, 2), productsCacheKey("product", 1, 2))
+	})
+
+	s.Run("Should tell the sorts apart, leaving the key of unsorted queries as is", func() {
+		s.Equal("", sortCacheKey(nil))
+		s.Equal("?sort=price:desc,name:asc", sortCacheKey([]*productsPb.SortKey{
+			{Field: "price", Descending: true},
+			{Field: "name"},
+		}))
+	})
+}
+
+func (s *ProductsCacheSuite) Test_Products() {
+	s.Run("Should serve repeated queries from the cache", func() {
+		ctx := context.Background()
+
+		first, err := s.resolver.Query().Products(ctx, nil)
+		s.NoError(err)
+
+		second, err := s.resolver.Query().Products(ctx, nil)
+		s.NoError(err)
+
+		s.Equal(first, second)
+		s.Equal(1, s.productsService.listCalls)
+	})
+
+	s.Run("Should hit the products service again after a mutation succeeds", func() {
+		ctx := context.Background()
+
+		_, err := s.resolver.Mutation().RemoveProduct(ctx, model.RemoveProductInput{ID: "1"})
+		s.NoError(err)
+
+		_, err = s.resolver.Query().Products(ctx, nil)
+		s.NoError(err)
+
+		s.Equal(2, s.productsService.listCalls)
+	})
+
+	s.Run("Should cache each sort apart", func() {
+		ctx := context.Background()
+		byPrice := []*model.ProductSort{{Field: model.ProductSortFieldPrice, Direction: model.SortDirectionDesc}}
+
+		_, err := s.resolver.Query().Products(ctx, byPrice)
+		s.NoError(err)
+
+		_, err = s.resolver.Query().Products(ctx, byPrice)
+		s.NoError(err)
+
+		s.Equal(3, s.productsService.listCalls)
+	})
+}
+
+func (s *ProductsCacheSuite) Test_ProductsByIds() {
+	s.Run("Should share the cache between equivalent sets of ids and preserve the requested order", func() {
+		ctx := context.Background()
+
+		_, err := s.resolver.Query().ProductsByIds(ctx, []string{"1", "2", "3"})
+		s.NoError(err)
+
+		got, err := s.resolver.Query().ProductsByIds(ctx, []string{"3", "2", "1"})
+		s.NoError(err)
+
+		s.Equal(1, s.productsService.listCalls)
+		s.Nil(got[0])
+		s.Equal("2", got[1].ID)
+		s.Equal("1", got[2].ID)
+	})
+}
+
+func TestProductsCacheSuite(t *testing.T) {
+	suite.Run(t, new(ProductsCacheSuite))
+}
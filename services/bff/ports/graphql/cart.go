@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lucasmls/ecommerce/services/bff/dataloader"
+	"github.com/lucasmls/ecommerce/services/bff/mapping"
+	"github.com/lucasmls/ecommerce/services/bff/ports/graphql/model"
+	cartPb "github.com/lucasmls/ecommerce/services/cart/ports/grpc/proto"
+	productsPb "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// errCustomerRequired is returned by the operations that only make sense for a known customer, e.g. placing an order
+	errCustomerRequired = status.Error(codes.Unauthenticated, "customer-required")
+	// errProductUnavailable is returned when putting into a cart a product that can't be bought
+	errProductUnavailable = status.Error(codes.NotFound, "product-unavailable")
+)
+
+// cartRef points to the cart of the customer holding the access token of the request,
+// or else to the guest cart with the given id
+func cartRef(ctx context.Context, cartID *string) *cartPb.CartRef {
+	customer, _ := customerFromContext(ctx)
+	return mapping.CartRefToProto(customer, cartID)
+}
+
+// cartFromProto converts a cart service Cart into its GraphQL model, checking its items against the current products
+func (r *Resolver) cartFromProto(ctx context.Context, cart *cartPb.Cart) (*model.Cart, error) {
+	products, err := r.cartProducts(ctx, cart)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapping.CartFromProto(cart, products), nil
+}
+
+// cartProducts loads the current products of the items of a cart, leaving out the ones that can't be bought anymore
+func (r *Resolver) cartProducts(ctx context.Context, cart *cartPb.Cart) (map[int32]*productsPb.Product, error) {
+	ids := mapping.CartProductIDs(cart)
+
+	products, errs := r.loadersFor(ctx).ProductByID.LoadMany(ctx, ids)
+
+	result := make(map[int32]*productsPb.Product, len(ids))
+	for i, err := range errs {
+		if errors.Is(err, dataloader.ErrNotFound) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		result[ids[i]] = products[i]
+	}
+
+	return result, nil
+}
+
+// cartProduct loads the product about to be put into a cart, whose name and price are snapshotted by the cart service
+func (r *Resolver) cartProduct(ctx context.Context, id string) (*productsPb.Product, error) {
+	productID, err := mapping.ProductIDToProto(id)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := r.loadersFor(ctx).ProductByID.Load(ctx, productID)
+	if errors.Is(err, dataloader.ErrNotFound) {
+		return nil, errProductUnavailable
+	}
+
+	return product, err
+}
@@ -0,0 +1,41 @@
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/shared/actor"
+	"github.com/lucasmls/ecommerce/shared/auth"
+	"github.com/stretchr/testify/suite"
+)
+
+type CartRefSuite struct {
+	suite.Suite
+}
+
+func (s *CartRefSuite) Test_cartRef() {
+	guestCartID := "guest-cart"
+
+	s.Run("Should point to the cart of the customer holding the access token", func() {
+		ctx := auth.NewContext(context.Background(), "token", auth.Claims{Subject: "c1", ExpiresAt: time.Now().Add(time.Hour)})
+
+		ref := cartRef(ctx, &guestCartID)
+
+		s.Equal("c1", ref.UserId)
+		s.Empty(ref.Id)
+	})
+
+	s.Run("Should point to the guest cart in case the request has an actor but no access token", func() {
+		ctx := actor.NewContext(context.Background(), "c1")
+
+		ref := cartRef(ctx, &guestCartID)
+
+		s.Empty(ref.UserId)
+		s.Equal(guestCartID, ref.Id)
+	})
+}
+
+func TestCartRefSuite(t *testing.T) {
+	suite.Run(t, new(CartRefSuite))
+}
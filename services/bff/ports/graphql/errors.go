@@ -0,0 +1,138 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/lucasmls/ecommerce/services/bff/mapping"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Error codes exposed to the GraphQL clients on extensions.code.
+// They are part of the public API of the BFF, so they must never change.
+const (
+	ErrCodeBadUserInput       = "BAD_USER_INPUT"
+	ErrCodeNotFound           = "NOT_FOUND"
+	ErrCodeConflict           = "CONFLICT"
+	ErrCodeFailedPrecondition = "FAILED_PRECONDITION"
+	ErrCodeUnauthenticated    = "UNAUTHENTICATED"
+	ErrCodeForbidden          = "FORBIDDEN"
+	ErrCodeServiceUnavailable = "SERVICE_UNAVAILABLE"
+	ErrCodeInternal           = "INTERNAL_SERVER_ERROR"
+)
+
+// FieldViolation describes why a single input field is invalid
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// grpcStatusError is implemented by the errors returned by gRPC clients
+type grpcStatusError interface {
+	GRPCStatus() *status.Status
+}
+
+// NewErrorPresenter creates an ErrorPresenterFunc that translates the errors returned by the
+// resolvers into GraphQL errors with stable extensions.code values.
+// Messages coming from the services are never exposed, they are only logged.
+func NewErrorPresenter(logger *zap.Logger) graphql.ErrorPresenterFunc {
+	return func(ctx context.Context, err error) *gqlerror.Error {
+		presented := graphql.DefaultErrorPresenter(ctx, err)
+
+		cause := errors.Unwrap(presented)
+		if cause == nil {
+			// errors raised by gqlgen itself (e.g. validation) are already meant for clients
+			return presented
+		}
+
+		var statusErr grpcStatusError
+		switch {
+		case errors.Is(cause, mapping.ErrInvalidProductID), errors.Is(cause, mapping.ErrInvalidPrice),
+			errors.Is(cause, mapping.ErrDuplicatedAttribute):
+			return presentError(presented, cause.Error(), ErrCodeBadUserInput)
+
+		case errors.As(cause, &statusErr):
+			return presentStatus(logger, presented, statusErr.GRPCStatus())
+
+		default:
+			logger.Error("unexpected error while resolving a GraphQL operation", zap.Error(cause))
+			return presentError(presented, "Internal server error", ErrCodeInternal)
+		}
+	}
+}
+
+func presentStatus(logger *zap.Logger, presented *gqlerror.Error, st *status.Status) *gqlerror.Error {
+	switch st.Code() {
+	case codes.InvalidArgument:
+		gqlErr := presentError(presented, "Invalid input", ErrCodeBadUserInput)
+
+		violations := fieldViolations(st)
+		if len(violations) > 0 {
+			gqlErr.Extensions["fieldViolations"] = violations
+		}
+
+		return gqlErr
+
+	case codes.NotFound:
+		return presentError(presented, "Resource not found", ErrCodeNotFound)
+
+	case codes.Aborted:
+		return presentError(presented, "The resource was changed by someone else, reload it and retry", ErrCodeConflict)
+
+	case codes.FailedPrecondition:
+		return presentError(presented, "The operation isn't allowed in the current state of the resource", ErrCodeFailedPrecondition)
+
+	case codes.AlreadyExists:
+		return presentError(presented, "The resource already exists", ErrCodeConflict)
+
+	case codes.Unauthenticated:
+		return presentError(presented, "Authentication required", ErrCodeUnauthenticated)
+
+	case codes.PermissionDenied:
+		return presentError(presented, "Permission denied", ErrCodeForbidden)
+
+	case codes.Unavailable, codes.DeadlineExceeded:
+		logger.Warn("upstream service unavailable", zap.String("code", st.Code().String()), zap.String("message", st.Message()))
+		return presentError(presented, "Service temporarily unavailable, please retry", ErrCodeServiceUnavailable)
+
+	default:
+		logger.Error("upstream service failed", zap.String("code", st.Code().String()), zap.String("message", st.Message()))
+		return presentError(presented, "Internal server error", ErrCodeInternal)
+	}
+}
+
+// fieldViolations extracts the field violations of the BadRequest details of a status
+func fieldViolations(st *status.Status) []FieldViolation {
+	var violations []FieldViolation
+	for _, detail := range st.Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+
+		for _, violation := range badRequest.FieldViolations {
+			violations = append(violations, FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Description,
+			})
+		}
+	}
+
+	return violations
+}
+
+func presentError(presented *gqlerror.Error, message string, code string) *gqlerror.Error {
+	return &gqlerror.Error{
+		Message:   message,
+		Path:      presented.Path,
+		Locations: presented.Locations,
+		Extensions: map[string]interface{}{
+			"code": code,
+		},
+	}
+}
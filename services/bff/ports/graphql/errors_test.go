@@ -0,0 +1,128 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/lucasmls/ecommerce/services/bff/mapping"
+	"github.com/stretchr/testify/suite"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ErrorPresenterSuite struct {
+	suite.Suite
+
+	presenter graphql.ErrorPresenterFunc
+	path      ast.Path
+}
+
+func (s *ErrorPresenterSuite) SetupSuite() {
+	s.presenter = NewErrorPresenter(zap.NewNop())
+	s.path = ast.Path{ast.PathName("product")}
+}
+
+// present mimics what gqlgen does with the errors returned by the resolvers
+func (s *ErrorPresenterSuite) present(err error) *gqlerror.Error {
+	return s.presenter(context.Background(), gqlerror.WrapPath(s.path, err))
+}
+
+func (s *ErrorPresenterSuite) Test_ErrorPresenter() {
+	s.Run("Should keep the errors raised by gqlgen itself", func() {
+		gqlErr := gqlerror.Errorf("must be defined")
+
+		got := s.presenter(context.Background(), gqlErr)
+
+		s.Equal(gqlErr, got)
+	})
+
+	s.Run("Should map NotFound statuses without leaking the upstream message", func() {
+		got := s.present(status.Error(codes.NotFound, "product-not-found"))
+
+		s.Equal("Resource not found", got.Message)
+		s.Equal(ErrCodeNotFound, got.Extensions["code"])
+		s.Equal(s.path, got.Path)
+	})
+
+	s.Run("Should map InvalidArgument statuses including their field violations", func() {
+		st, err := status.New(codes.InvalidArgument, "invalid product").WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "name", Description: "must not be empty"},
+				{Field: "price", Description: "must be positive"},
+			},
+		})
+		s.Require().NoError(err)
+
+		got := s.present(st.Err())
+
+		s.Equal("Invalid input", got.Message)
+		s.Equal(ErrCodeBadUserInput, got.Extensions["code"])
+		s.Equal([]FieldViolation{
+			{Field: "name", Description: "must not be empty"},
+			{Field: "price", Description: "must be positive"},
+		}, got.Extensions["fieldViolations"])
+	})
+
+	s.Run("Should map InvalidArgument statuses without details", func() {
+		got := s.present(status.Error(codes.InvalidArgument, "invalid product"))
+
+		s.Equal(ErrCodeBadUserInput, got.Extensions["code"])
+		s.NotContains(got.Extensions, "fieldViolations")
+	})
+
+	s.Run("Should map the remaining known status codes", func() {
+		cases := map[codes.Code]string{
+			codes.Unauthenticated:    ErrCodeUnauthenticated,
+			codes.PermissionDenied:   ErrCodeForbidden,
+			codes.Aborted:            ErrCodeConflict,
+			codes.FailedPrecondition: ErrCodeFailedPrecondition,
+			codes.AlreadyExists:      ErrCodeConflict,
+			codes.Unavailable:        ErrCodeServiceUnavailable,
+			codes.DeadlineExceeded:   ErrCodeServiceUnavailable,
+			codes.Internal:           ErrCodeInternal,
+			codes.Unknown:            ErrCodeInternal,
+		}
+
+		for code, expected := range cases {
+			got := s.present(status.Error(code, "rpc failure"))
+
+			s.Equal(expected, got.Extensions["code"], code.String())
+			s.NotContains(got.Message, "rpc failure")
+		}
+	})
+
+	s.Run("Should only tell about a concurrent change on Aborted statuses", func() {
+		aborted := s.present(status.Error(codes.Aborted, "product-version-conflict"))
+		failedPrecondition := s.present(status.Error(codes.FailedPrecondition, "product-not-deleted"))
+
+		s.Equal("The resource was changed by someone else, reload it and retry", aborted.Message)
+		s.Equal(ErrCodeConflict, aborted.Extensions["code"])
+		s.Equal("The operation isn't allowed in the current state of the resource", failedPrecondition.Message)
+		s.Equal(ErrCodeFailedPrecondition, failedPrecondition.Extensions["code"])
+	})
+
+	s.Run("Should map invalid inputs detected by the BFF", func() {
+		got := s.present(fmt.Errorf("%w: %q", mapping.ErrInvalidProductID, "abc"))
+
+		s.Equal(ErrCodeBadUserInput, got.Extensions["code"])
+		s.Contains(got.Message, "invalid-product-id")
+	})
+
+	s.Run("Should hide unexpected errors", func() {
+		got := s.present(errors.New("dial tcp 10.0.0.1:8081: connection refused"))
+
+		s.Equal("Internal server error", got.Message)
+		s.Equal(ErrCodeInternal, got.Extensions["code"])
+	})
+}
+
+func TestErrorPresenterSuite(t *testing.T) {
+	suite.Run(t, new(ErrorPresenterSuite))
+}
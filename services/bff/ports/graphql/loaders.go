@@ -0,0 +1,152 @@
+package graph
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/bff/dataloader"
+	inventoryPb "github.com/lucasmls/ecommerce/services/inventory/ports/grpc/proto"
+	pricingPb "github.com/lucasmls/ecommerce/services/pricing/ports/grpc/proto"
+	productsPb "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+)
+
+const (
+	// loaderWait is how long a Loader waits for more keys before hitting the services
+	loaderWait = 2 * time.Millisecond
+	// loaderMaxBatch is the maximum amount of ids sent on a single request
+	loaderMaxBatch = 100
+)
+
+type loadersContextKey struct{}
+
+// PricingKey identifies the price of a product, the pricing service takes the base price from the catalog
+type PricingKey struct {
+	ProductID int32
+	BasePrice int32
+}
+
+// Loaders holds the request-scoped DataLoaders used by the resolvers
+type Loaders struct {
+	ProductByID *dataloader.Loader[int32, *productsPb.Product]
+	// ImagesByProductID loads the images of the products, in their order
+	ImagesByProductID *dataloader.Loader[int32, []*productsPb.ProductImage]
+	// StockByProductID loads the stock of the products from the inventory service
+	StockByProductID *dataloader.Loader[int32, *inventoryPb.ProductStock]
+	// PricingByProduct loads the price of a unit of the products from the pricing service,
+	// quoted for the customer whose token the request carries
+	PricingByProduct *dataloader.Loader[PricingKey, *pricingPb.QuoteLine]
+}
+
+// NewLoaders creates a new set of Loaders, it should be called once per request
+func NewLoaders(
+	productsService productsPb.ProductsServiceClient,
+	inventoryService inventoryPb.InventoryServiceClient,
+	pricingService pricingPb.PricingServiceClient,
+) *Loaders {
+	return &Loaders{
+		ProductByID: dataloader.MustNewLoader(dataloader.LoaderInput[int32, *productsPb.Product]{
+			Wait:     loaderWait,
+			MaxBatch: loaderMaxBatch,
+			Fetch: func(ctx context.Context, ids []int32) (map[int32]*productsPb.Product, error) {
+				products, err := productsService.List(ctx, &productsPb.ListRequest{Ids: ids})
+				if err != nil {
+					return nil, err
+				}
+
+				result := make(map[int32]*productsPb.Product, len(products.Data))
+				for _, product := range products.Data {
+					result[product.Id] = product
+				}
+
+				return result, nil
+			},
+		}),
+		ImagesByProductID: dataloader.MustNewLoader(dataloader.LoaderInput[int32, []*productsPb.ProductImage]{
+			Wait:     loaderWait,
+			MaxBatch: loaderMaxBatch,
+			Fetch: func(ctx context.Context, productIDs []int32) (map[int32][]*productsPb.ProductImage, error) {
+				images, err := productsService.ListImages(ctx, &productsPb.ListImagesRequest{ProductIds: productIDs})
+				if err != nil {
+					return nil, err
+				}
+
+				// the products without images get an empty list instead of a not found error
+				result := make(map[int32][]*productsPb.ProductImage, len(productIDs))
+				for _, id := range productIDs {
+					result[id] = []*productsPb.ProductImage{}
+				}
+
+				for _, image := range images.Data {
+					result[image.ProductId] = append(result[image.ProductId], image)
+				}
+
+				return result, nil
+			},
+		}),
+		StockByProductID: dataloader.MustNewLoader(dataloader.LoaderInput[int32, *inventoryPb.ProductStock]{
+			Wait:     loaderWait,
+			MaxBatch: loaderMaxBatch,
+			Fetch: func(ctx context.Context, productIDs []int32) (map[int32]*inventoryPb.ProductStock, error) {
+				stock, err := inventoryService.GetStock(ctx, &inventoryPb.GetStockRequest{ProductIds: productIDs})
+				if err != nil {
+					return nil, err
+				}
+
+				// the inventory service lists every requested product, even the ones it has no stock of
+				result := make(map[int32]*inventoryPb.ProductStock, len(stock.Data))
+				for _, productStock := range stock.Data {
+					result[productStock.ProductId] = productStock
+				}
+
+				return result, nil
+			},
+		}),
+		PricingByProduct: dataloader.MustNewLoader(dataloader.LoaderInput[PricingKey, *pricingPb.QuoteLine]{
+			Wait:     loaderWait,
+			MaxBatch: loaderMaxBatch,
+			Fetch: func(ctx context.Context, keys []PricingKey) (map[PricingKey]*pricingPb.QuoteLine, error) {
+				lines := make([]*pricingPb.QuoteLineRequest, 0, len(keys))
+				for _, key := range keys {
+					lines = append(lines, &pricingPb.QuoteLineRequest{ProductId: key.ProductID, Quantity: 1, UnitPrice: key.BasePrice})
+				}
+
+				quote, err := pricingService.Quote(ctx, &pricingPb.QuoteRequest{Lines: lines})
+				if err != nil {
+					return nil, err
+				}
+
+				// the quote has a line for every requested one, in the same order
+				result := make(map[PricingKey]*pricingPb.QuoteLine, len(keys))
+				for i, line := range quote.Data.Lines {
+					result[keys[i]] = line
+				}
+
+				return result, nil
+			},
+		}),
+	}
+}
+
+// LoadersMiddleware injects a fresh set of Loaders into every request context
+func LoadersMiddleware(
+	productsService productsPb.ProductsServiceClient,
+	inventoryService inventoryPb.InventoryServiceClient,
+	pricingService pricingPb.PricingServiceClient,
+	next http.Handler,
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), loadersContextKey{}, NewLoaders(productsService, inventoryService, pricingService))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// loadersFor returns the Loaders of the current request.
+// A fresh set is created when the request didn't go through LoadersMiddleware.
+func (r *Resolver) loadersFor(ctx context.Context) *Loaders {
+	if loaders, ok := ctx.Value(loadersContextKey{}).(*Loaders); ok {
+		return loaders
+	}
+
+	return NewLoaders(r.ProductsService, r.InventoryService, r.PricingService)
+}
@@ -0,0 +1,9 @@
+package model
+
+// ProductConnection is a page of Products, it keeps the filter the Products were listed with
+// so its facets are counted over the same Products.
+type ProductConnection struct {
+	Nodes      []*Product `json:"nodes"`
+	TotalCount int        `json:"totalCount"`
+	Filter     *ProductFilter
+}
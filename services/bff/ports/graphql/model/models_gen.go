@@ -2,26 +2,591 @@
 
 package model
 
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// Points to the cart of the customer holding the access token, the cartId being ignored then.
+// Otherwise points to the guest cart with the given cartId.
+type AddToCartInput struct {
+	// Leave it empty to start a new guest cart.
+	CartID    *string `json:"cartId"`
+	ProductID string  `json:"productId"`
+	// Added to the units already in the cart.
+	Quantity int `json:"quantity"`
+}
+
+type AttributeFacet struct {
+	Name string `json:"name"`
+	// The most common first.
+	Values []*FacetBucket `json:"values"`
+}
+
+// The products a customer intends to buy. A customer identified by an access token has a single cart,
+// while guests get a new cart on their first addToCart and must keep its id to get back to it.
+// The prices are checked against the catalog on every read, see CartItem.priceChanged.
+type Cart struct {
+	// Null until something is put into the cart.
+	ID *string `json:"id"`
+	// In the order they were added.
+	Items []*CartItem `json:"items"`
+	// The units of every item.
+	TotalQuantity int `json:"totalQuantity"`
+	// The totals of the available items at their current prices.
+	Subtotal float64 `json:"subtotal"`
+	// Whether the price of any item changed since it was put into the cart, refreshCartPrices accepts the current prices.
+	HasPriceChanges bool `json:"hasPriceChanges"`
+	// Whether any item can't be bought anymore, such items should be removed before checking out.
+	HasUnavailableItems bool `json:"hasUnavailableItems"`
+	// The cart is discarded when it's left untouched until then, every change pushes it forward. Null until something is put into the cart.
+	ExpiresAt *time.Time `json:"expiresAt"`
+	UpdatedAt *time.Time `json:"updatedAt"`
+}
+
+type CartItem struct {
+	ProductID string `json:"productId"`
+	// Null when the product was removed from the catalog.
+	Product *Product `json:"product"`
+	// The name of the product when the item was last changed.
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity"`
+	// The price of the product when the item was last changed.
+	UnitPrice float64 `json:"unitPrice"`
+	// The price the product is sold at now, null when it can't be bought anymore.
+	CurrentUnitPrice *float64 `json:"currentUnitPrice"`
+	// Whether the current price differs from the one the item was put into the cart at.
+	PriceChanged bool `json:"priceChanged"`
+	// False when the product was removed from the catalog, the item doesn't count towards the subtotal then.
+	Available bool `json:"available"`
+	// The quantity times the current price, or times the last known price when the product can't be bought anymore.
+	Total float64 `json:"total"`
+}
+
+type FacetBucket struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// A purchase placed by a customer. The name and price of its items were snapshotted from the catalog when it was placed,
+// so later changes to the products don't change what the customer agreed to pay.
+type Order struct {
+	ID string `json:"id"`
+	// Identifies the order to the customer, e.g. on receipts and support requests.
+	Number string       `json:"number"`
+	Status OrderStatus  `json:"status"`
+	Items  []*OrderItem `json:"items"`
+	// The totals of every item.
+	Total float64 `json:"total"`
+	// Every change of status, oldest first.
+	StatusHistory []*OrderStatusChange `json:"statusHistory"`
+	PlacedAt      time.Time            `json:"placedAt"`
+	UpdatedAt     time.Time            `json:"updatedAt"`
+}
+
+type OrderItem struct {
+	ProductID string `json:"productId"`
+	// The product as it's now, null when it was removed from the catalog.
+	Product *Product `json:"product"`
+	// The name of the product when the order was placed.
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity"`
+	// The price of the product when the order was placed.
+	UnitPrice float64 `json:"unitPrice"`
+	Total     float64 `json:"total"`
+}
+
+type OrderItemInput struct {
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
+}
+
+type OrderStatusChange struct {
+	From   OrderStatus `json:"from"`
+	To     OrderStatus `json:"to"`
+	Reason *string     `json:"reason"`
+	At     time.Time   `json:"at"`
+}
+
+// Only the provided fields are changed, absent fields keep their current value.
+type PatchProductInput struct {
+	ID          string   `json:"ID"`
+	Name        *string  `json:"name"`
+	Description *string  `json:"description"`
+	Price       *float64 `json:"price"`
+	Category    *string  `json:"category"`
+	// Replaces every attribute of the product.
+	Attributes []*ProductAttributeInput `json:"attributes"`
+	TaxClass   *string                  `json:"taxClass"`
+	// In grams.
+	Weight     *int                    `json:"weight"`
+	Dimensions *ProductDimensionsInput `json:"dimensions"`
+	// The version the patch is based on, it fails with a CONFLICT error if the product changed since then.
+	Version int `json:"version"`
+}
+
+type PlaceOrderInput struct {
+	Items []*OrderItemInput `json:"items"`
+}
+
+type PriceAdjustment struct {
+	Source PriceAdjustmentSource `json:"source"`
+	// The name of the price list or the promotion, meant to be shown to the customers.
+	Description string `json:"description"`
+	// The discount code that unlocked the promotion, null when it's automatic.
+	Code *string `json:"code"`
+	// Taken off the price of a unit.
+	Amount float64 `json:"amount"`
+}
+
+type PriceRangeBucket struct {
+	// Inclusive.
+	From float64 `json:"from"`
+	// Exclusive, null when the range has no upper bound.
+	To    *float64 `json:"to"`
+	Count int      `json:"count"`
+}
+
 type Product struct {
 	ID          string  `json:"id"`
 	Name        string  `json:"name"`
 	Description string  `json:"description"`
 	Price       float64 `json:"price"`
+	// Null when the product isn't categorized.
+	Category *string `json:"category"`
+	// Free-form characteristics of the product, such as its color or size, sorted by name.
+	Attributes []*ProductAttribute `json:"attributes"`
+	// The class the product is taxed by, null when it's taxed at the standard rates.
+	TaxClass *string `json:"taxClass"`
+	// The shipping weight of the product in grams, null when it's unknown.
+	Weight *int `json:"weight"`
+	// The size of the product as shipped, null when it's unknown.
+	Dimensions *ProductDimensions `json:"dimensions"`
+	// Incremented on every update. Send it back on updateProduct to reject concurrent edits.
+	Version int `json:"version"`
+	// Set while the product is in the trash, until it's restored with restoreProduct or purged.
+	DeletedAt *time.Time `json:"deletedAt"`
+	// The changes made to the product, newest first. Pass the id of the last change seen as before to fetch older ones.
+	History []*ProductChange `json:"history"`
+	// The images of the product in their order, the first one being its main image.
+	Images []*ProductImage `json:"images"`
+	// The units of the product kept on the warehouses, null when the inventory can't be reached.
+	Stock *ProductStock `json:"stock"`
+	// The price the product is sold at to whoever is asking, after the price lists and the automatic promotions.
+	// Null when the pricing service can't be reached.
+	Pricing *ProductPricing `json:"pricing"`
+}
+
+type ProductAttribute struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type ProductAttributeInput struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type ProductChange struct {
+	ID     string              `json:"id"`
+	Action ProductChangeAction `json:"action"`
+	// Who made the change, as told by the verified access token of the request, or unknown.
+	Actor string `json:"actor"`
+	// The product before the change, null when it was registered.
+	Before  *Product  `json:"before"`
+	After   *Product  `json:"after"`
+	TraceID *string   `json:"traceId"`
+	At      time.Time `json:"at"`
+}
+
+// The size of a product in millimeters.
+type ProductDimensions struct {
+	Length int `json:"length"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// In millimeters, each of them at least 1.
+type ProductDimensionsInput struct {
+	Length int `json:"length"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+type ProductFacets struct {
+	Total int `json:"total"`
+	// One bucket per range, in ascending order, even the empty ones.
+	PriceRanges []*PriceRangeBucket `json:"priceRanges"`
+	// The most common first.
+	Categories []*FacetBucket `json:"categories"`
+	// Sorted by name.
+	Attributes []*AttributeFacet `json:"attributes"`
+}
+
+// Unset fields match every product, the attributes must all match.
+type ProductFilter struct {
+	Category *string `json:"category"`
+	// Inclusive.
+	MinPrice *float64 `json:"minPrice"`
+	// Exclusive.
+	MaxPrice   *float64                 `json:"maxPrice"`
+	Attributes []*ProductAttributeInput `json:"attributes"`
+}
+
+// The product fields with the words matching the search wrapped by <em> and </em>.
+type ProductHighlights struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type ProductImage struct {
+	ID string `json:"id"`
+	// Where the original image is served from.
+	URL         string `json:"url"`
+	ContentType string `json:"contentType"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+	// In bytes.
+	Size int `json:"size"`
+	// Null when the image has no alternative text.
+	AltText *string `json:"altText"`
+	// The image scaled down to each of the configured sizes, keeping its aspect ratio.
+	Thumbnails []*Thumbnail `json:"thumbnails"`
+}
+
+// The effective price of a product, show the base price struck through when it's on sale.
+// Promotions unlocked by discount codes aren't applied, they're only applied when checking out.
+type ProductPricing struct {
+	// The price on the catalog.
+	BasePrice float64 `json:"basePrice"`
+	// What a unit of the product costs, the base price minus every adjustment.
+	Price float64 `json:"price"`
+	// Whether the price is under the base price.
+	OnSale bool `json:"onSale"`
+	// What was taken off the base price and why, in the order applied.
+	Adjustments []*PriceAdjustment `json:"adjustments"`
+}
+
+// A product matching a searchProducts query, the higher the score the more relevant it is.
+type ProductSearchResult struct {
+	Product    *Product           `json:"product"`
+	Score      float64            `json:"score"`
+	Highlights *ProductHighlights `json:"highlights"`
+}
+
+// One of the keys the products are sorted by, the first ones taking precedence. Ties are broken by id.
+type ProductSort struct {
+	Field     ProductSortField `json:"field"`
+	Direction SortDirection    `json:"direction"`
+}
+
+type ProductStock struct {
+	// Units that can still be bought, the ones on hand minus the reserved ones.
+	Available int  `json:"available"`
+	InStock   bool `json:"inStock"`
+	// Units physically kept on the warehouses.
+	OnHand int `json:"onHand"`
+	// Units held by carts and orders that weren't completed yet.
+	Reserved int `json:"reserved"`
+	// The stock of each warehouse, sorted by SKU and then by warehouse.
+	Levels []*StockLevel `json:"levels"`
 }
 
 type RegisterProductInput struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	Price       float64                  `json:"price"`
+	Category    *string                  `json:"category"`
+	Attributes  []*ProductAttributeInput `json:"attributes"`
+	TaxClass    *string                  `json:"taxClass"`
+	// In grams.
+	Weight     *int                    `json:"weight"`
+	Dimensions *ProductDimensionsInput `json:"dimensions"`
+}
+
+type RemoveFromCartInput struct {
+	CartID    *string `json:"cartId"`
+	ProductID string  `json:"productId"`
 }
 
 type RemoveProductInput struct {
 	ID string `json:"ID"`
 }
 
+type RestoreProductInput struct {
+	ID string `json:"ID"`
+}
+
+type StockLevel struct {
+	// Null when the stock is of the product itself rather than one of its variations.
+	Sku       *string `json:"sku"`
+	Warehouse string  `json:"warehouse"`
+	Available int     `json:"available"`
+	OnHand    int     `json:"onHand"`
+	Reserved  int     `json:"reserved"`
+}
+
+type Thumbnail struct {
+	// The name of the size, such as small or medium.
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+type UpdateCartItemInput struct {
+	CartID    *string `json:"cartId"`
+	ProductID string  `json:"productId"`
+	// Replaces the units in the cart, 0 removes the item.
+	Quantity int `json:"quantity"`
+}
+
 type UpdateProductInput struct {
 	ID          string  `json:"ID"`
 	Name        string  `json:"name"`
 	Description string  `json:"description"`
 	Price       float64 `json:"price"`
+	// Omitting it leaves the product uncategorized.
+	Category *string `json:"category"`
+	// Omitting it removes every attribute.
+	Attributes []*ProductAttributeInput `json:"attributes"`
+	// Omitting it taxes the product at the standard rates.
+	TaxClass *string `json:"taxClass"`
+	// In grams, omitting it leaves the weight unknown.
+	Weight *int `json:"weight"`
+	// Omitting it leaves the dimensions unknown.
+	Dimensions *ProductDimensionsInput `json:"dimensions"`
+	// The version the update is based on, it fails with a CONFLICT error if the product changed since then.
+	Version int `json:"version"`
+}
+
+type OrderStatus string
+
+const (
+	// Placed, not submitted for payment yet.
+	OrderStatusPending         OrderStatus = "PENDING"
+	OrderStatusAwaitingPayment OrderStatus = "AWAITING_PAYMENT"
+	OrderStatusPaid            OrderStatus = "PAID"
+	// Handed over to the customer.
+	OrderStatusFulfilled OrderStatus = "FULFILLED"
+	OrderStatusCancelled OrderStatus = "CANCELLED"
+	OrderStatusRefunded  OrderStatus = "REFUNDED"
+)
+
+var AllOrderStatus = []OrderStatus{
+	OrderStatusPending,
+	OrderStatusAwaitingPayment,
+	OrderStatusPaid,
+	OrderStatusFulfilled,
+	OrderStatusCancelled,
+	OrderStatusRefunded,
+}
+
+func (e OrderStatus) IsValid() bool {
+	switch e {
+	case OrderStatusPending, OrderStatusAwaitingPayment, OrderStatusPaid, OrderStatusFulfilled, OrderStatusCancelled, OrderStatusRefunded:
+		return true
+	}
+	return false
+}
+
+func (e OrderStatus) String() string {
+	return string(e)
+}
+
+func (e *OrderStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderStatus", str)
+	}
+	return nil
+}
+
+func (e OrderStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type PriceAdjustmentSource string
+
+const (
+	// A price list, such as the prices of a season or of a segment of customers.
+	PriceAdjustmentSourcePriceList PriceAdjustmentSource = "PRICE_LIST"
+	PriceAdjustmentSourcePromotion PriceAdjustmentSource = "PROMOTION"
+)
+
+var AllPriceAdjustmentSource = []PriceAdjustmentSource{
+	PriceAdjustmentSourcePriceList,
+	PriceAdjustmentSourcePromotion,
+}
+
+func (e PriceAdjustmentSource) IsValid() bool {
+	switch e {
+	case PriceAdjustmentSourcePriceList, PriceAdjustmentSourcePromotion:
+		return true
+	}
+	return false
+}
+
+func (e PriceAdjustmentSource) String() string {
+	return string(e)
+}
+
+func (e *PriceAdjustmentSource) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PriceAdjustmentSource(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PriceAdjustmentSource", str)
+	}
+	return nil
+}
+
+func (e PriceAdjustmentSource) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ProductChangeAction string
+
+const (
+	ProductChangeActionRegister ProductChangeAction = "REGISTER"
+	ProductChangeActionUpdate   ProductChangeAction = "UPDATE"
+	ProductChangeActionPatch    ProductChangeAction = "PATCH"
+	ProductChangeActionDelete   ProductChangeAction = "DELETE"
+	ProductChangeActionRestore  ProductChangeAction = "RESTORE"
+)
+
+var AllProductChangeAction = []ProductChangeAction{
+	ProductChangeActionRegister,
+	ProductChangeActionUpdate,
+	ProductChangeActionPatch,
+	ProductChangeActionDelete,
+	ProductChangeActionRestore,
+}
+
+func (e ProductChangeAction) IsValid() bool {
+	switch e {
+	case ProductChangeActionRegister, ProductChangeActionUpdate, ProductChangeActionPatch, ProductChangeActionDelete, ProductChangeActionRestore:
+		return true
+	}
+	return false
+}
+
+func (e ProductChangeAction) String() string {
+	return string(e)
+}
+
+func (e *ProductChangeAction) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ProductChangeAction(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ProductChangeAction", str)
+	}
+	return nil
+}
+
+func (e ProductChangeAction) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ProductSortField string
+
+const (
+	ProductSortFieldName      ProductSortField = "NAME"
+	ProductSortFieldPrice     ProductSortField = "PRICE"
+	ProductSortFieldCreatedAt ProductSortField = "CREATED_AT"
+	ProductSortFieldUpdatedAt ProductSortField = "UPDATED_AT"
+	// Only allowed by searchProducts, where it's the default.
+	ProductSortFieldRelevance ProductSortField = "RELEVANCE"
+)
+
+var AllProductSortField = []ProductSortField{
+	ProductSortFieldName,
+	ProductSortFieldPrice,
+	ProductSortFieldCreatedAt,
+	ProductSortFieldUpdatedAt,
+	ProductSortFieldRelevance,
+}
+
+func (e ProductSortField) IsValid() bool {
+	switch e {
+	case ProductSortFieldName, ProductSortFieldPrice, ProductSortFieldCreatedAt, ProductSortFieldUpdatedAt, ProductSortFieldRelevance:
+		return true
+	}
+	return false
+}
+
+func (e ProductSortField) String() string {
+	return string(e)
+}
+
+func (e *ProductSortField) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ProductSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ProductSortField", str)
+	}
+	return nil
+}
+
+func (e ProductSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type SortDirection string
+
+const (
+	SortDirectionAsc  SortDirection = "ASC"
+	SortDirectionDesc SortDirection = "DESC"
+)
+
+var AllSortDirection = []SortDirection{
+	SortDirectionAsc,
+	SortDirectionDesc,
+}
+
+func (e SortDirection) IsValid() bool {
+	switch e {
+	case SortDirectionAsc, SortDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e SortDirection) String() string {
+	return string(e)
+}
+
+func (e *SortDirection) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SortDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SortDirection", str)
+	}
+	return nil
+}
+
+func (e SortDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
 }
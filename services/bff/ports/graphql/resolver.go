@@ -1,6 +1,12 @@
 package graph
 
 import (
+	"github.com/lucasmls/ecommerce/services/bff/cache"
+	"github.com/lucasmls/ecommerce/services/bff/ports/graphql/model"
+	cartPb "github.com/lucasmls/ecommerce/services/cart/ports/grpc/proto"
+	inventoryPb "github.com/lucasmls/ecommerce/services/inventory/ports/grpc/proto"
+	ordersPb "github.com/lucasmls/ecommerce/services/orders/ports/grpc/proto"
+	pricingPb "github.com/lucasmls/ecommerce/services/pricing/ports/grpc/proto"
 	productsPb "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
@@ -11,7 +17,14 @@ import (
 // It serves as dependency injection for your app, add any dependencies you require here.
 
 type Resolver struct {
-	Logger          *zap.Logger
-	Tracer          trace.Tracer
-	ProductsService productsPb.ProductsServiceClient
+	Logger           *zap.Logger
+	Tracer           trace.Tracer
+	ProductsService  productsPb.ProductsServiceClient
+	InventoryService inventoryPb.InventoryServiceClient
+	CartService      cartPb.CartServiceClient
+	OrdersService    ordersPb.OrdersServiceClient
+	PricingService   pricingPb.PricingServiceClient
+
+	// ProductsCache caches the products queries, caching is disabled when it is nil
+	ProductsCache *cache.Cache[[]*model.Product]
 }
@@ -0,0 +1,122 @@
+package graph
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/bff/ports/graphql/model"
+	ordersPb "github.com/lucasmls/ecommerce/services/orders/ports/grpc/proto"
+	"github.com/lucasmls/ecommerce/shared/actor"
+	"github.com/lucasmls/ecommerce/shared/auth"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// fakeOrdersService records the orders it's asked to list
+type fakeOrdersService struct {
+	ordersPb.OrdersServiceClient
+
+	listed *ordersPb.ListOrdersRequest
+}
+
+func (s *fakeOrdersService) ListOrders(_ context.Context, req *ordersPb.ListOrdersRequest, _ ...grpc.CallOption) (*ordersPb.ListOrdersResponse, error) {
+	s.listed = req
+	return &ordersPb.ListOrdersResponse{}, nil
+}
+
+type CustomerResolversSuite struct {
+	suite.Suite
+
+	ordersService *fakeOrdersService
+	resolver      *Resolver
+}
+
+func (s *CustomerResolversSuite) SetupTest() {
+	s.ordersService = &fakeOrdersService{}
+	s.resolver = &Resolver{
+		Logger:        zap.NewNop(),
+		Tracer:        trace.NewNoopTracerProvider().Tracer(""),
+		OrdersService: s.ordersService,
+	}
+}
+
+// requestContext is the context a request with the given headers reaches the resolvers with,
+// going through the same middlewares as on the server
+func (s *CustomerResolversSuite) requestContext(headers map[string]string) context.Context {
+	var ctx context.Context
+
+	handler := AuthMiddleware(tokenSecret, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/query", nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	s.Require().NotNil(ctx)
+
+	return ctx
+}
+
+func (s *CustomerResolversSuite) Test_SpoofedActor() {
+	spoofed := map[string]string{"X-Actor": "c1"}
+
+	s.Run("Should not record the actor told by the X-Actor header", func() {
+		_, ok := actor.FromContext(s.requestContext(spoofed))
+
+		s.False(ok)
+	})
+
+	s.Run("Should not list the orders of the customer told by the X-Actor header without an access token", func() {
+		s.SetupTest()
+
+		_, err := s.resolver.Query().Orders(s.requestContext(spoofed), nil, nil)
+
+		s.Equal(errCustomerRequired, err)
+		s.Nil(s.ordersService.listed)
+	})
+
+	s.Run("Should not place orders for the customer told by the X-Actor header without an access token", func() {
+		s.SetupTest()
+
+		input := model.PlaceOrderInput{Items: []*model.OrderItemInput{{ProductID: "1", Quantity: 1}}}
+
+		_, err := s.resolver.Mutation().PlaceOrder(s.requestContext(spoofed), input)
+
+		s.Equal(errCustomerRequired, err)
+	})
+
+	s.Run("Should not merge a cart into the one of the customer told by the X-Actor header without an access token", func() {
+		s.SetupTest()
+
+		_, err := s.resolver.Mutation().MergeCart(s.requestContext(spoofed), "guest-cart")
+
+		s.Equal(errCustomerRequired, err)
+	})
+
+	s.Run("Should list the orders of the customer holding the access token, whatever the X-Actor header tells", func() {
+		s.SetupTest()
+
+		token, err := auth.Sign(tokenSecret, auth.Claims{Subject: "c2", IssuedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)})
+		s.Require().NoError(err)
+
+		_, err = s.resolver.Query().Orders(s.requestContext(map[string]string{
+			"X-Actor":           "c1",
+			AuthorizationHeader: "Bearer " + token,
+		}), nil, nil)
+
+		s.NoError(err)
+		s.Equal("c2", s.ordersService.listed.CustomerId)
+	})
+}
+
+func TestCustomerResolversSuite(t *testing.T) {
+	suite.Run(t, new(CustomerResolversSuite))
+}
@@ -5,36 +5,113 @@ package graph
 
 import (
 	"context"
+	"errors"
 
+	"github.com/lucasmls/ecommerce/services/bff/dataloader"
+	"github.com/lucasmls/ecommerce/services/bff/mapping"
 	"github.com/lucasmls/ecommerce/services/bff/ports/graphql/generated"
 	"github.com/lucasmls/ecommerce/services/bff/ports/graphql/model"
+	cartPb "github.com/lucasmls/ecommerce/services/cart/ports/grpc/proto"
 	grpc_protobuf "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+func (p *productResolver) History(ctx context.Context, obj *model.Product, limit *int, before *string) ([]*model.ProductChange, error) {
+	ctx, span := p.Tracer.Start(ctx, "resolver.ProductHistory")
+	defer span.End()
+
+	p.Logger.Info("querying the history of a product", zap.String("id", obj.ID))
+
+	req, err := mapping.ProductHistoryArgsToProto(obj.ID, limit, before)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := p.ProductsService.GetProductHistory(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapping.ProductChangesFromProto(history.Entries), nil
+}
+
+func (p *productResolver) Images(ctx context.Context, obj *model.Product) ([]*model.ProductImage, error) {
+	ctx, span := p.Tracer.Start(ctx, "resolver.ProductImages")
+	defer span.End()
+
+	productID, err := mapping.ProductIDToProto(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	images, err := p.loadersFor(ctx).ImagesByProductID.Load(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapping.ProductImagesFromProto(images), nil
+}
+
+func (p *productResolver) Pricing(ctx context.Context, obj *model.Product) (*model.ProductPricing, error) {
+	ctx, span := p.Tracer.Start(ctx, "resolver.ProductPricing")
+	defer span.End()
+
+	productID, err := mapping.ProductIDToProto(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	basePrice, err := mapping.PriceToProto(obj.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	line, err := p.loadersFor(ctx).PricingByProduct.Load(ctx, PricingKey{ProductID: productID, BasePrice: basePrice})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapping.ProductPricingFromProto(line), nil
+}
+
+func (p *productResolver) Stock(ctx context.Context, obj *model.Product) (*model.ProductStock, error) {
+	ctx, span := p.Tracer.Start(ctx, "resolver.ProductStock")
+	defer span.End()
+
+	productID, err := mapping.ProductIDToProto(obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	stock, err := p.loadersFor(ctx).StockByProductID.Load(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapping.ProductStockFromProto(stock), nil
+}
+
 func (m *mutationResolver) RegisterProduct(ctx context.Context, input model.RegisterProductInput) (*model.Product, error) {
 	ctx, span := m.Tracer.Start(ctx, "resolver.RegisterProduct")
 	defer span.End()
 
 	m.Logger.Info("registering a new product", zap.Any("input", input))
 
-	registeredProduct, err := m.ProductsService.Register(ctx, &grpc_protobuf.Product{
-		Name:        input.Name,
-		Description: input.Description,
-		Price:       float32(input.Price),
-	})
+	req, err := mapping.RegisterProductInputToProto(input)
 	if err != nil {
 		return nil, err
 	}
 
-	response := &model.Product{
-		ID:          registeredProduct.Data.Id,
-		Name:        registeredProduct.Data.Name,
-		Description: registeredProduct.Data.Description,
-		Price:       float64(registeredProduct.Data.Price),
+	registeredProduct, err := m.ProductsService.Register(ctx, req)
+	if err != nil {
+		return nil, err
 	}
 
-	return response, nil
+	m.invalidateProductsCache()
+
+	return mapping.ProductFromProto(registeredProduct.Data), nil
 }
 
 func (m *mutationResolver) UpdateProduct(ctx context.Context, input model.UpdateProductInput) (*model.Product, error) {
@@ -43,69 +120,525 @@ func (m *mutationResolver) UpdateProduct(ctx context.Context, input model.Update
 
 	m.Logger.Info("updating a product", zap.Any("input", input))
 
-	updatedProduct, err := m.ProductsService.Update(ctx, &grpc_protobuf.Product{
-		Id:          input.ID,
-		Name:        input.Name,
-		Description: input.Description,
-		Price:       float32(input.Price),
-	})
+	req, err := mapping.UpdateProductInputToProto(input)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedProduct, err := m.ProductsService.Update(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	m.invalidateProductsCache()
+
+	return mapping.ProductFromProto(updatedProduct.Data), nil
+}
+
+func (m *mutationResolver) PatchProduct(ctx context.Context, input model.PatchProductInput) (*model.Product, error) {
+	ctx, span := m.Tracer.Start(ctx, "resolver.PatchProduct")
+	defer span.End()
+
+	m.Logger.Info("patching a product", zap.Any("input", input))
+
+	req, err := mapping.PatchProductInputToProto(input)
 	if err != nil {
 		return nil, err
 	}
 
-	response := &model.Product{
-		ID:          updatedProduct.Data.Id,
-		Name:        updatedProduct.Data.Name,
-		Description: updatedProduct.Data.Description,
-		Price:       float64(updatedProduct.Data.Price),
+	patchedProduct, err := m.ProductsService.Patch(ctx, req)
+	if err != nil {
+		return nil, err
 	}
 
-	return response, nil
+	m.invalidateProductsCache()
+
+	return mapping.ProductFromProto(patchedProduct.Data), nil
 }
 
-func (q *queryResolver) Products(ctx context.Context) ([]*model.Product, error) {
+func (q *queryResolver) Products(ctx context.Context, sort []*model.ProductSort) ([]*model.Product, error) {
 	ctx, span := q.Tracer.Start(ctx, "resolver.Products")
 	defer span.End()
 
 	q.Logger.Info("querying products")
 
-	products, err := q.ProductsService.List(ctx, &grpc_protobuf.ListRequest{})
+	req := &grpc_protobuf.ListRequest{Sort: mapping.ProductSortToProto(sort)}
+
+	cacheKey := productsCacheKey("products" + sortCacheKey(req.Sort))
+	if cached, ok := q.cachedProducts(cacheKey); ok {
+		return cached, nil
+	}
+
+	products, err := q.ProductsService.List(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	response := mapping.ProductsFromProto(products.Data)
+	q.cacheProducts(cacheKey, response)
+
+	return response, nil
+}
+
+func (q *queryResolver) Product(ctx context.Context, id string) (*model.Product, error) {
+	ctx, span := q.Tracer.Start(ctx, "resolver.Product")
+	defer span.End()
+
+	q.Logger.Info("querying a product", zap.String("id", id))
+
+	productID, err := mapping.ProductIDToProto(id)
 	if err != nil {
 		return nil, err
 	}
 
-	response := []*model.Product{}
-	for _, product := range products.Data {
-		response = append(response, &model.Product{
-			ID:          product.Id,
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       float64(product.Price),
-		})
+	cacheKey := productsCacheKey("product", productID)
+	if cached, ok := q.cachedProducts(cacheKey); ok {
+		if len(cached) == 0 {
+			return nil, nil
+		}
+
+		return cached[0], nil
 	}
 
+	product, err := q.loadersFor(ctx).ProductByID.Load(ctx, productID)
+	if err != nil {
+		if errors.Is(err, dataloader.ErrNotFound) {
+			q.cacheProducts(cacheKey, []*model.Product{})
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	response := mapping.ProductFromProto(product)
+	q.cacheProducts(cacheKey, []*model.Product{response})
+
 	return response, nil
 }
 
+func (q *queryResolver) ProductsByIds(ctx context.Context, ids []string) ([]*model.Product, error) {
+	ctx, span := q.Tracer.Start(ctx, "resolver.ProductsByIds")
+	defer span.End()
+
+	q.Logger.Info("querying products by ids", zap.Strings("ids", ids))
+
+	productIDs, err := mapping.ProductIDsToProto(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := productsCacheKey("productsByIds", productIDs...)
+	if cached, ok := q.cachedProducts(cacheKey); ok {
+		found := make(map[string]*model.Product, len(cached))
+		for _, product := range cached {
+			found[product.ID] = product
+		}
+
+		response := make([]*model.Product, len(productIDs))
+		for i, productID := range productIDs {
+			response[i] = found[mapping.ProductIDFromProto(productID)]
+		}
+
+		return response, nil
+	}
+
+	products, errs := q.loadersFor(ctx).ProductByID.LoadMany(ctx, productIDs)
+
+	response := make([]*model.Product, len(ids))
+	found := []*model.Product{}
+	for i, product := range products {
+		if errs[i] != nil {
+			if errors.Is(errs[i], dataloader.ErrNotFound) {
+				continue
+			}
+
+			return nil, errs[i]
+		}
+
+		response[i] = mapping.ProductFromProto(product)
+		found = append(found, response[i])
+	}
+
+	q.cacheProducts(cacheKey, found)
+
+	return response, nil
+}
+
+func (q *queryResolver) DeletedProducts(ctx context.Context) ([]*model.Product, error) {
+	ctx, span := q.Tracer.Start(ctx, "resolver.DeletedProducts")
+	defer span.End()
+
+	q.Logger.Info("querying deleted products")
+
+	cacheKey := productsCacheKey("deletedProducts")
+	if cached, ok := q.cachedProducts(cacheKey); ok {
+		return cached, nil
+	}
+
+	products, err := q.ProductsService.List(ctx, &grpc_protobuf.ListRequest{OnlyDeleted: true})
+	if err != nil {
+		return nil, err
+	}
+
+	response := mapping.ProductsFromProto(products.Data)
+	q.cacheProducts(cacheKey, response)
+
+	return response, nil
+}
+
+func (q *queryResolver) SearchProducts(ctx context.Context, query string, limit *int, offset *int, sort []*model.ProductSort) ([]*model.ProductSearchResult, error) {
+	ctx, span := q.Tracer.Start(ctx, "resolver.SearchProducts")
+	defer span.End()
+
+	q.Logger.Info("searching products", zap.String("query", query))
+
+	results, err := q.ProductsService.Search(ctx, mapping.SearchProductsArgsToProto(query, limit, offset, sort))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapping.ProductSearchResultsFromProto(results.Results), nil
+}
+
+func (q *queryResolver) ProductsConnection(ctx context.Context, filter *model.ProductFilter, sort []*model.ProductSort) (*model.ProductConnection, error) {
+	ctx, span := q.Tracer.Start(ctx, "resolver.ProductsConnection")
+	defer span.End()
+
+	q.Logger.Info("querying filtered products")
+
+	protoFilter, err := mapping.ProductFilterToProto(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := q.ProductsService.List(ctx, &grpc_protobuf.ListRequest{
+		Filter: protoFilter,
+		Sort:   mapping.ProductSortToProto(sort),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := mapping.ProductsFromProto(products.Data)
+
+	return &model.ProductConnection{
+		Nodes:      nodes,
+		TotalCount: len(nodes),
+		Filter:     filter,
+	}, nil
+}
+
+func (q *queryResolver) Cart(ctx context.Context, id *string) (*model.Cart, error) {
+	ctx, span := q.Tracer.Start(ctx, "resolver.Cart")
+	defer span.End()
+
+	ref := cartRef(ctx, id)
+	if ref.Id == "" && ref.UserId == "" {
+		return nil, nil
+	}
+
+	cart, err := q.CartService.GetCart(ctx, &cartPb.GetCartRequest{Cart: ref})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return q.cartFromProto(ctx, cart.Data)
+}
+
+func (q *queryResolver) Orders(ctx context.Context, status *model.OrderStatus, limit *int) ([]*model.Order, error) {
+	ctx, span := q.Tracer.Start(ctx, "resolver.Orders")
+	defer span.End()
+
+	customer, ok := customerFromContext(ctx)
+	if !ok {
+		return nil, errCustomerRequired
+	}
+
+	orders, err := q.OrdersService.ListOrders(ctx, mapping.OrdersArgsToProto(customer, status, limit))
+	if err != nil {
+		return nil, err
+	}
+
+	return mapping.OrdersFromProto(orders.Data), nil
+}
+
+func (c *productConnectionResolver) Facets(ctx context.Context, obj *model.ProductConnection, priceBoundaries []float64) (*model.ProductFacets, error) {
+	ctx, span := c.Tracer.Start(ctx, "resolver.ProductFacets")
+	defer span.End()
+
+	req, err := mapping.ProductFacetsArgsToProto(obj.Filter, priceBoundaries)
+	if err != nil {
+		return nil, err
+	}
+
+	facets, err := c.ProductsService.GetFacets(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapping.ProductFacetsFromProto(facets), nil
+}
+
 func (m *mutationResolver) RemoveProduct(ctx context.Context, input model.RemoveProductInput) (string, error) {
 	ctx, span := m.Tracer.Start(ctx, "resolver.RemoveProduct")
 	defer span.End()
 
 	m.Logger.Info("removing a product", zap.String("id", input.ID))
 
-	deleteReponse, err := m.ProductsService.Delete(ctx, &grpc_protobuf.DeleteRequest{Id: input.ID})
+	req, err := mapping.RemoveProductInputToProto(input)
 	if err != nil {
 		return "", err
 	}
 
+	deleteReponse, err := m.ProductsService.Delete(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	m.invalidateProductsCache()
+
 	return deleteReponse.Data, nil
 }
 
+func (m *mutationResolver) RestoreProduct(ctx context.Context, input model.RestoreProductInput) (*model.Product, error) {
+	ctx, span := m.Tracer.Start(ctx, "resolver.RestoreProduct")
+	defer span.End()
+
+	m.Logger.Info("restoring a product", zap.String("id", input.ID))
+
+	req, err := mapping.RestoreProductInputToProto(input)
+	if err != nil {
+		return nil, err
+	}
+
+	restoredProduct, err := m.ProductsService.Restore(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	m.invalidateProductsCache()
+
+	return mapping.ProductFromProto(restoredProduct.Data), nil
+}
+
+func (m *mutationResolver) AddToCart(ctx context.Context, input model.AddToCartInput) (*model.Cart, error) {
+	ctx, span := m.Tracer.Start(ctx, "resolver.AddToCart")
+	defer span.End()
+
+	m.Logger.Info("adding a product to a cart", zap.Any("input", input))
+
+	product, err := m.cartProduct(ctx, input.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := m.CartService.AddItem(ctx, &cartPb.AddItemRequest{
+		Cart:      cartRef(ctx, input.CartID),
+		ProductId: product.Id,
+		Name:      product.Name,
+		Quantity:  int32(input.Quantity),
+		UnitPrice: product.Price,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return m.cartFromProto(ctx, cart.Data)
+}
+
+func (m *mutationResolver) UpdateCartItem(ctx context.Context, input model.UpdateCartItemInput) (*model.Cart, error) {
+	ctx, span := m.Tracer.Start(ctx, "resolver.UpdateCartItem")
+	defer span.End()
+
+	m.Logger.Info("updating an item of a cart", zap.Any("input", input))
+
+	req := &cartPb.UpdateItemRequest{
+		Cart:     cartRef(ctx, input.CartID),
+		Quantity: int32(input.Quantity),
+	}
+
+	// removing the item must work even when its product can't be bought anymore
+	if input.Quantity == 0 {
+		productID, err := mapping.ProductIDToProto(input.ProductID)
+		if err != nil {
+			return nil, err
+		}
+
+		req.ProductId = productID
+	} else {
+		product, err := m.cartProduct(ctx, input.ProductID)
+		if err != nil {
+			return nil, err
+		}
+
+		req.ProductId = product.Id
+		req.Name = product.Name
+		req.UnitPrice = product.Price
+	}
+
+	cart, err := m.CartService.UpdateItem(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.cartFromProto(ctx, cart.Data)
+}
+
+func (m *mutationResolver) RemoveFromCart(ctx context.Context, input model.RemoveFromCartInput) (*model.Cart, error) {
+	ctx, span := m.Tracer.Start(ctx, "resolver.RemoveFromCart")
+	defer span.End()
+
+	m.Logger.Info("removing a product from a cart", zap.Any("input", input))
+
+	productID, err := mapping.ProductIDToProto(input.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	cart, err := m.CartService.RemoveItem(ctx, &cartPb.RemoveItemRequest{
+		Cart:      cartRef(ctx, input.CartID),
+		ProductId: productID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return m.cartFromProto(ctx, cart.Data)
+}
+
+func (m *mutationResolver) ClearCart(ctx context.Context, cartID *string) (*model.Cart, error) {
+	ctx, span := m.Tracer.Start(ctx, "resolver.ClearCart")
+	defer span.End()
+
+	m.Logger.Info("clearing a cart")
+
+	cart, err := m.CartService.ClearCart(ctx, &cartPb.ClearCartRequest{Cart: cartRef(ctx, cartID)})
+	if err != nil {
+		return nil, err
+	}
+
+	return m.cartFromProto(ctx, cart.Data)
+}
+
+func (m *mutationResolver) RefreshCartPrices(ctx context.Context, cartID *string) (*model.Cart, error) {
+	ctx, span := m.Tracer.Start(ctx, "resolver.RefreshCartPrices")
+	defer span.End()
+
+	m.Logger.Info("refreshing the prices of a cart")
+
+	ref := cartRef(ctx, cartID)
+
+	cart, err := m.CartService.GetCart(ctx, &cartPb.GetCartRequest{Cart: ref})
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := m.cartProducts(ctx, cart.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedCart, err := m.CartService.UpdatePrices(ctx, &cartPb.UpdatePricesRequest{
+		Cart:    ref,
+		Updates: mapping.CartPriceUpdatesToProto(cart.Data, products),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapping.CartFromProto(updatedCart.Data, products), nil
+}
+
+func (m *mutationResolver) MergeCart(ctx context.Context, guestCartID string) (*model.Cart, error) {
+	ctx, span := m.Tracer.Start(ctx, "resolver.MergeCart")
+	defer span.End()
+
+	customer, ok := customerFromContext(ctx)
+	if !ok {
+		return nil, errCustomerRequired
+	}
+
+	m.Logger.Info("merging a guest cart", zap.String("guestCartId", guestCartID), zap.String("customer", customer))
+
+	cart, err := m.CartService.MergeCarts(ctx, &cartPb.MergeCartsRequest{
+		GuestCartId: guestCartID,
+		UserId:      customer,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return m.cartFromProto(ctx, cart.Data)
+}
+
+func (m *mutationResolver) PlaceOrder(ctx context.Context, input model.PlaceOrderInput) (*model.Order, error) {
+	ctx, span := m.Tracer.Start(ctx, "resolver.PlaceOrder")
+	defer span.End()
+
+	customer, ok := customerFromContext(ctx)
+	if !ok {
+		return nil, errCustomerRequired
+	}
+
+	m.Logger.Info("placing an order", zap.Any("input", input), zap.String("customer", customer))
+
+	req, err := mapping.PlaceOrderInputToProto(customer, input)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := m.OrdersService.PlaceOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapping.OrderFromProto(order.Data), nil
+}
+
+func (o *orderItemResolver) Product(ctx context.Context, obj *model.OrderItem) (*model.Product, error) {
+	ctx, span := o.Tracer.Start(ctx, "resolver.OrderItemProduct")
+	defer span.End()
+
+	productID, err := mapping.ProductIDToProto(obj.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := o.loadersFor(ctx).ProductByID.Load(ctx, productID)
+	if errors.Is(err, dataloader.ErrNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mapping.ProductFromProto(product), nil
+}
+
 // Mutation returns generated1.MutationResolver implementation.
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
+// OrderItem returns generated1.OrderItemResolver implementation.
+func (r *Resolver) OrderItem() generated.OrderItemResolver { return &orderItemResolver{r} }
+
+// Product returns generated1.ProductResolver implementation.
+func (r *Resolver) Product() generated.ProductResolver { return &productResolver{r} }
+
+// ProductConnection returns generated1.ProductConnectionResolver implementation.
+func (r *Resolver) ProductConnection() generated.ProductConnectionResolver {
+	return &productConnectionResolver{r}
+}
+
 // Query returns generated1.QueryResolver implementation.
 func (r *Resolver) Query() generated.QueryResolver { return &queryResolver{r} }
 
 type mutationResolver struct{ *Resolver }
+type orderItemResolver struct{ *Resolver }
+type productConnectionResolver struct{ *Resolver }
+type productResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
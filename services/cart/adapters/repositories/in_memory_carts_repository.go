@@ -0,0 +1,151 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// InMemoryCartsRepository keeps the Carts in memory.
+type InMemoryCartsRepository struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	// mu guards carts, keyed by ID, and users, which maps the users to the IDs of their Carts
+	mu    sync.Mutex
+	carts map[string]domain.Cart
+	users map[string]string
+}
+
+// NewInMemoryCartsRepository creates a new InMemoryCartsRepository.
+func NewInMemoryCartsRepository(logger *zap.Logger, tracer trace.Tracer) *InMemoryCartsRepository {
+	return &InMemoryCartsRepository{
+		Logger: logger,
+		Tracer: tracer,
+		carts:  map[string]domain.Cart{},
+		users:  map[string]string{},
+	}
+}
+
+// Get fetches a single Cart by its ID from memory.
+func (r *InMemoryCartsRepository) Get(ctx context.Context, id string) (domain.Cart, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Get")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cart, ok := r.carts[id]
+	if !ok {
+		return domain.Cart{}, domain.ErrCartNotFound
+	}
+
+	return copyCart(cart), nil
+}
+
+// GetByUser fetches the Cart of a user from memory.
+func (r *InMemoryCartsRepository) GetByUser(ctx context.Context, userID string) (domain.Cart, error) {
+	_, span := r.Tracer.Start(ctx, "repository.GetByUser")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, ok := r.users[userID]
+	if !ok {
+		return domain.Cart{}, domain.ErrCartNotFound
+	}
+
+	return copyCart(r.carts[id]), nil
+}
+
+// Save stores a Cart in memory, creating it when its Version is 0 and replacing it otherwise.
+func (r *InMemoryCartsRepository) Save(ctx context.Context, cart domain.Cart) (domain.Cart, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Save")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if cart.Version == 0 {
+		if _, ok := r.carts[cart.ID]; ok {
+			return domain.Cart{}, domain.ErrCartAlreadyExists
+		}
+
+		if _, ok := r.users[cart.UserID]; ok && !cart.Guest() {
+			return domain.Cart{}, domain.ErrCartAlreadyExists
+		}
+	} else {
+		stored, ok := r.carts[cart.ID]
+		if !ok || stored.Version != cart.Version {
+			return domain.Cart{}, domain.ErrCartVersionConflict
+		}
+	}
+
+	cart.Version++
+	cart = copyCart(cart)
+
+	r.carts[cart.ID] = cart
+	if !cart.Guest() {
+		r.users[cart.UserID] = cart.ID
+	}
+
+	return copyCart(cart), nil
+}
+
+// Delete removes a Cart from memory.
+func (r *InMemoryCartsRepository) Delete(ctx context.Context, id string) error {
+	_, span := r.Tracer.Start(ctx, "repository.Delete")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.delete(id)
+	return nil
+}
+
+// DeleteExpired removes the Carts that expired at the given time from memory.
+func (r *InMemoryCartsRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	_, span := r.Tracer.Start(ctx, "repository.DeleteExpired")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := 0
+	for id, cart := range r.carts {
+		if cart.Expired(now) {
+			r.delete(id)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
+
+// delete removes a Cart along with its user, it must be called with mu held
+func (r *InMemoryCartsRepository) delete(id string) {
+	cart, ok := r.carts[id]
+	if !ok {
+		return
+	}
+
+	delete(r.carts, id)
+	if !cart.Guest() {
+		delete(r.users, cart.UserID)
+	}
+}
+
+// copyCart keeps the stored Items from being changed through the Carts handed out by the repository
+func copyCart(cart domain.Cart) domain.Cart {
+	if cart.Items != nil {
+		cart.Items = append([]domain.LineItem{}, cart.Items...)
+	}
+
+	return cart
+}
@@ -0,0 +1,149 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	guestCartID = "0123456789abcdef0123456789abcdef"
+	userCartID  = "fedcba9876543210fedcba9876543210"
+)
+
+type InMemoryCartsRepositorySuite struct {
+	suite.Suite
+
+	cartsRepo *InMemoryCartsRepository
+}
+
+func (s *InMemoryCartsRepositorySuite) SetupTest() {
+	s.cartsRepo = NewInMemoryCartsRepository(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""))
+}
+
+func (s *InMemoryCartsRepositorySuite) save(cart domain.Cart) domain.Cart {
+	saved, err := s.cartsRepo.Save(context.Background(), cart)
+	s.Require().NoError(err)
+
+	return saved
+}
+
+func (s *InMemoryCartsRepositorySuite) Test_Get() {
+	s.Run("Should fail in case the cart doesn't exist", func() {
+		_, err := s.cartsRepo.Get(context.Background(), guestCartID)
+
+		s.Equal(domain.ErrCartNotFound, err)
+	})
+
+	s.Run("Should fetch the carts by their ID and by their user", func() {
+		guestCart := s.save(domain.Cart{ID: guestCartID, Items: []domain.LineItem{{ProductID: 1, Quantity: 1}}})
+		userCart := s.save(domain.Cart{ID: userCartID, UserID: "john"})
+
+		got, err := s.cartsRepo.Get(context.Background(), guestCartID)
+		s.NoError(err)
+		s.Equal(guestCart, got)
+
+		got, err = s.cartsRepo.GetByUser(context.Background(), "john")
+		s.NoError(err)
+		s.Equal(userCart, got)
+
+		_, err = s.cartsRepo.GetByUser(context.Background(), "jane")
+		s.Equal(domain.ErrCartNotFound, err)
+	})
+
+	s.Run("Should keep the stored items from being changed through the fetched carts", func() {
+		got, err := s.cartsRepo.Get(context.Background(), guestCartID)
+		s.Require().NoError(err)
+
+		got.Items[0].Quantity = 10
+
+		got, err = s.cartsRepo.Get(context.Background(), guestCartID)
+		s.NoError(err)
+		s.Equal(1, got.Items[0].Quantity)
+	})
+}
+
+func (s *InMemoryCartsRepositorySuite) Test_Save() {
+	s.Run("Should create the cart with the first version", func() {
+		cart := s.save(domain.Cart{ID: guestCartID})
+
+		s.Equal(1, cart.Version)
+	})
+
+	s.Run("Should fail in case the ID of a new cart is taken", func() {
+		_, err := s.cartsRepo.Save(context.Background(), domain.Cart{ID: guestCartID})
+
+		s.Equal(domain.ErrCartAlreadyExists, err)
+	})
+
+	s.Run("Should fail in case the user of a new cart already has one", func() {
+		s.save(domain.Cart{ID: userCartID, UserID: "john"})
+
+		_, err := s.cartsRepo.Save(context.Background(), domain.Cart{ID: "11111111111111111111111111111111", UserID: "john"})
+
+		s.Equal(domain.ErrCartAlreadyExists, err)
+	})
+
+	s.Run("Should replace the cart and increment its version", func() {
+		cart := s.save(domain.Cart{ID: guestCartID, Version: 1, Items: []domain.LineItem{{ProductID: 1, Quantity: 2}}})
+
+		s.Equal(2, cart.Version)
+
+		got, err := s.cartsRepo.Get(context.Background(), guestCartID)
+		s.NoError(err)
+		s.Equal(cart, got)
+	})
+
+	s.Run("Should fail in case the cart was changed since it was read", func() {
+		_, err := s.cartsRepo.Save(context.Background(), domain.Cart{ID: guestCartID, Version: 1})
+
+		s.Equal(domain.ErrCartVersionConflict, err)
+	})
+
+	s.Run("Should fail in case the cart was deleted since it was read", func() {
+		_, err := s.cartsRepo.Save(context.Background(), domain.Cart{ID: "22222222222222222222222222222222", Version: 1})
+
+		s.Equal(domain.ErrCartVersionConflict, err)
+	})
+}
+
+func (s *InMemoryCartsRepositorySuite) Test_Delete() {
+	s.save(domain.Cart{ID: userCartID, UserID: "john"})
+
+	s.NoError(s.cartsRepo.Delete(context.Background(), userCartID))
+
+	_, err := s.cartsRepo.Get(context.Background(), userCartID)
+	s.Equal(domain.ErrCartNotFound, err)
+
+	_, err = s.cartsRepo.GetByUser(context.Background(), "john")
+	s.Equal(domain.ErrCartNotFound, err)
+
+	s.NoError(s.cartsRepo.Delete(context.Background(), userCartID), "deleting a missing cart is a no-op")
+}
+
+func (s *InMemoryCartsRepositorySuite) Test_DeleteExpired() {
+	now := time.Now()
+
+	s.save(domain.Cart{ID: guestCartID, ExpiresAt: now.Add(-time.Minute)})
+	s.save(domain.Cart{ID: userCartID, UserID: "john", ExpiresAt: now})
+	s.save(domain.Cart{ID: "33333333333333333333333333333333", ExpiresAt: now.Add(time.Minute)})
+
+	deleted, err := s.cartsRepo.DeleteExpired(context.Background(), now)
+	s.NoError(err)
+	s.Equal(2, deleted)
+
+	_, err = s.cartsRepo.GetByUser(context.Background(), "john")
+	s.Equal(domain.ErrCartNotFound, err)
+
+	_, err = s.cartsRepo.Get(context.Background(), "33333333333333333333333333333333")
+	s.NoError(err)
+}
+
+func TestInMemoryCartsRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InMemoryCartsRepositorySuite))
+}
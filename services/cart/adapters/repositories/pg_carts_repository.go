@@ -0,0 +1,199 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	"github.com/lucasmls/ecommerce/shared/pg"
+)
+
+const cartColumns = "id, user_id, items, version, created_at, updated_at, expires_at"
+
+// lineItemRecord is how a LineItem is stored on the items column of a cart
+type lineItemRecord struct {
+	ProductID int       `json:"product_id"`
+	Name      string    `json:"name"`
+	Quantity  int       `json:"quantity"`
+	UnitPrice int       `json:"unit_price"`
+	AddedAt   time.Time `json:"added_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// PgCartsRepository keeps the Carts on Postgres, each one on a single row.
+// The rows are only updated while their version is still the one the Cart was read with,
+// so concurrent changes to a Cart don't overwrite each other.
+type PgCartsRepository struct {
+	db *sql.DB
+}
+
+func NewPgCartsRepository(connectionString string) (*PgCartsRepository, error) {
+	db, err := sql.Open(
+		"postgres",
+		connectionString,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	repository := &PgCartsRepository{
+		db: db,
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+func MustNewPgCartsRepository(connectionString string) *PgCartsRepository {
+	repo, err := NewPgCartsRepository(connectionString)
+	if err != nil {
+		panic(err)
+	}
+
+	return repo
+}
+
+// Get fetches a single Cart by its ID
+func (r *PgCartsRepository) Get(ctx context.Context, id string) (domain.Cart, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+cartColumns+" FROM carts WHERE id = $1", id)
+
+	cart, err := scanCart(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Cart{}, domain.ErrCartNotFound
+	}
+
+	return cart, err
+}
+
+// GetByUser fetches the Cart of a user
+func (r *PgCartsRepository) GetByUser(ctx context.Context, userID string) (domain.Cart, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+cartColumns+" FROM carts WHERE user_id = $1", userID)
+
+	cart, err := scanCart(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Cart{}, domain.ErrCartNotFound
+	}
+
+	return cart, err
+}
+
+// Save inserts the Cart when its Version is 0, and otherwise updates it as long as the stored version matches
+func (r *PgCartsRepository) Save(ctx context.Context, cart domain.Cart) (domain.Cart, error) {
+	items, err := json.Marshal(toLineItemRecords(cart.Items))
+	if err != nil {
+		return domain.Cart{}, err
+	}
+
+	// the guest Carts have no user, so they're left out of the unique index on user_id
+	userID := sql.NullString{String: cart.UserID, Valid: !cart.Guest()}
+
+	if cart.Version == 0 {
+		row := r.db.QueryRowContext(
+			ctx,
+			`INSERT INTO carts (`+cartColumns+`)
+			VALUES ($1, $2, $3, 1, $4, $5, $6)
+			RETURNING `+cartColumns,
+			cart.ID, userID, items, cart.CreatedAt, cart.UpdatedAt, cart.ExpiresAt,
+		)
+
+		saved, err := scanCart(row)
+		if pg.IsUniqueViolation(err) {
+			return domain.Cart{}, domain.ErrCartAlreadyExists
+		}
+
+		return saved, err
+	}
+
+	row := r.db.QueryRowContext(
+		ctx,
+		`UPDATE carts
+		SET items = $3, version = version + 1, created_at = $4, updated_at = $5, expires_at = $6
+		WHERE id = $1 AND version = $2
+		RETURNING `+cartColumns,
+		cart.ID, cart.Version, items, cart.CreatedAt, cart.UpdatedAt, cart.ExpiresAt,
+	)
+
+	saved, err := scanCart(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Cart{}, domain.ErrCartVersionConflict
+	}
+
+	return saved, err
+}
+
+// Delete removes a Cart
+func (r *PgCartsRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM carts WHERE id = $1", id)
+	return err
+}
+
+// DeleteExpired removes the Carts that expired at the given time
+func (r *PgCartsRepository) DeleteExpired(ctx context.Context, now time.Time) (int, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM carts WHERE expires_at <= $1", now)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+	return int(deleted), err
+}
+
+func scanCart(row pg.Scanner) (domain.Cart, error) {
+	var cart domain.Cart
+	var userID sql.NullString
+	var items []byte
+
+	err := row.Scan(
+		&cart.ID,
+		&userID,
+		&items,
+		&cart.Version,
+		&cart.CreatedAt,
+		&cart.UpdatedAt,
+		&cart.ExpiresAt,
+	)
+	if err != nil {
+		return domain.Cart{}, err
+	}
+
+	var records []lineItemRecord
+	if err := json.Unmarshal(items, &records); err != nil {
+		return domain.Cart{}, err
+	}
+
+	cart.UserID = userID.String
+	for _, record := range records {
+		cart.Items = append(cart.Items, domain.LineItem{
+			ProductID: record.ProductID,
+			Name:      record.Name,
+			Quantity:  record.Quantity,
+			UnitPrice: record.UnitPrice,
+			AddedAt:   record.AddedAt,
+			UpdatedAt: record.UpdatedAt,
+		})
+	}
+
+	return cart, nil
+}
+
+func toLineItemRecords(items []domain.LineItem) []lineItemRecord {
+	records := make([]lineItemRecord, 0, len(items))
+	for _, item := range items {
+		records = append(records, lineItemRecord{
+			ProductID: item.ProductID,
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+			AddedAt:   item.AddedAt,
+			UpdatedAt: item.UpdatedAt,
+		})
+	}
+
+	return records
+}
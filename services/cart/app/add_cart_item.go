@@ -0,0 +1,35 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) AddCartItem(ctx context.Context, change domain.ItemChange) (domain.Cart, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.AddCartItem")
+	defer span.End()
+
+	a.Logger.Info("adding an item to a cart", zap.Any("change", change))
+
+	// an empty CartRef creates a new guest Cart
+	if change.Cart != (domain.CartRef{}) {
+		if err := change.Cart.Validate(); err != nil {
+			return domain.Cart{}, err
+		}
+	}
+
+	if err := change.Validate(); err != nil {
+		return domain.Cart{}, err
+	}
+
+	if change.Quantity == 0 {
+		return domain.Cart{}, domain.ErrInvalidQuantity
+	}
+
+	return a.change(ctx, change.Cart, func(cart *domain.Cart, now time.Time) error {
+		return cart.AddItem(change, now)
+	})
+}
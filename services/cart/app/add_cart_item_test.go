@@ -0,0 +1,202 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	"github.com/lucasmls/ecommerce/services/cart/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type AddCartItemSuite struct {
+	suite.Suite
+
+	cartsRepo *mocks.CartsRepository
+	app       domain.Application
+}
+
+func (s *AddCartItemSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.cartsRepo = &mocks.CartsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.cartsRepo, time.Hour, 2*time.Hour)
+}
+
+func (s *AddCartItemSuite) Test_AddCartItem() {
+	s.Run("Should fail in case the item is invalid", func() {
+		for _, change := range []domain.ItemChange{
+			{ProductID: 0, Quantity: 1},
+			{ProductID: 1, Quantity: 1, UnitPrice: -1},
+		} {
+			_, err := s.app.AddCartItem(context.Background(), change)
+
+			s.Equal(domain.ErrInvalidCartItem, err)
+		}
+	})
+
+	s.Run("Should fail in case the quantity is out of bounds", func() {
+		for _, quantity := range []int{-1, 0, domain.MaxItemQuantity + 1} {
+			_, err := s.app.AddCartItem(context.Background(), domain.ItemChange{ProductID: 1, Quantity: quantity})
+
+			s.Equal(domain.ErrInvalidQuantity, err)
+		}
+	})
+
+	s.Run("Should fail in case the cart ref is invalid", func() {
+		_, err := s.app.AddCartItem(context.Background(), domain.ItemChange{Cart: domain.CartRef{ID: "not-a-cart-id"}, ProductID: 1, Quantity: 1})
+
+		s.Equal(domain.ErrInvalidCartRef, err)
+	})
+
+	s.Run("Should create a guest cart in case the cart ref is empty", func() {
+		before := time.Now()
+
+		s.cartsRepo.
+			On("Save", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(cart domain.Cart) bool {
+				return len(cart.ID) == 32 &&
+					cart.Guest() &&
+					cart.Version == 0 &&
+					len(cart.Items) == 1 &&
+					cart.Items[0].ProductID == 1 &&
+					cart.Items[0].Name == "iPhone 13" &&
+					cart.Items[0].Quantity == 2 &&
+					cart.Items[0].UnitPrice == 79900 &&
+					!cart.ExpiresAt.Before(before.Add(time.Hour)) &&
+					cart.ExpiresAt.Sub(cart.UpdatedAt) == time.Hour
+			})).
+			Return(func(_ context.Context, cart domain.Cart) domain.Cart {
+				cart.Version++
+				return cart
+			}, nil).
+			Once()
+
+		got, err := s.app.AddCartItem(context.Background(), domain.ItemChange{ProductID: 1, Name: "iPhone 13", Quantity: 2, UnitPrice: 79900})
+
+		s.NoError(err)
+		s.Equal(1, got.Version)
+		s.Equal(159800, got.Subtotal())
+	})
+
+	s.Run("Should add the units to the ones already in the cart of the user", func() {
+		s.cartsRepo.
+			On("GetByUser", mock.AnythingOfType("*context.valueCtx"), "john").
+			Return(domain.Cart{
+				ID:        userCartID,
+				UserID:    "john",
+				Items:     []domain.LineItem{{ProductID: 1, Name: "iPhone 13", Quantity: 1, UnitPrice: 79900}},
+				Version:   2,
+				ExpiresAt: time.Now().Add(time.Hour),
+			}, nil).
+			Once()
+
+		saved := domain.Cart{ID: userCartID, UserID: "john", Version: 3}
+
+		s.cartsRepo.
+			On("Save", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(cart domain.Cart) bool {
+				return cart.ID == userCartID &&
+					cart.Version == 2 &&
+					len(cart.Items) == 1 &&
+					cart.Items[0].Quantity == 3 &&
+					cart.Items[0].UnitPrice == 74900 &&
+					cart.ExpiresAt.Sub(cart.UpdatedAt) == 2*time.Hour
+			})).
+			Return(saved, nil).
+			Once()
+
+		got, err := s.app.AddCartItem(context.Background(), domain.ItemChange{
+			Cart:      domain.CartRef{UserID: "john"},
+			ProductID: 1,
+			Name:      "iPhone 13",
+			Quantity:  2,
+			UnitPrice: 74900,
+		})
+
+		s.NoError(err)
+		s.Equal(saved, got)
+	})
+
+	s.Run("Should fail in case the cart would have too many units of the product", func() {
+		s.cartsRepo.
+			On("GetByUser", mock.AnythingOfType("*context.valueCtx"), "jane").
+			Return(domain.Cart{
+				ID:      userCartID,
+				UserID:  "jane",
+				Items:   []domain.LineItem{{ProductID: 1, Quantity: domain.MaxItemQuantity}},
+				Version: 1,
+			}, nil).
+			Once()
+
+		_, err := s.app.AddCartItem(context.Background(), domain.ItemChange{Cart: domain.CartRef{UserID: "jane"}, ProductID: 1, Quantity: 1})
+
+		s.Equal(domain.ErrInvalidQuantity, err)
+	})
+
+	s.Run("Should apply the change again in case the cart changes concurrently", func() {
+		first := domain.Cart{ID: guestCartID, Version: 1, Items: []domain.LineItem{{ProductID: 1, Quantity: 1}}}
+		second := domain.Cart{ID: guestCartID, Version: 2, Items: []domain.LineItem{{ProductID: 1, Quantity: 1}, {ProductID: 2, Quantity: 1}}}
+
+		s.cartsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), guestCartID).Return(first, nil).Once()
+		s.cartsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), guestCartID).Return(second, nil).Once()
+
+		s.cartsRepo.
+			On("Save", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(cart domain.Cart) bool {
+				return cart.Version == 1
+			})).
+			Return(domain.Cart{}, domain.ErrCartVersionConflict).
+			Once()
+
+		saved := domain.Cart{ID: guestCartID, Version: 3}
+
+		s.cartsRepo.
+			On("Save", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(cart domain.Cart) bool {
+				return cart.Version == 2 && reflect.DeepEqual([]int{1, 2, 3}, productIDs(cart))
+			})).
+			Return(saved, nil).
+			Once()
+
+		got, err := s.app.AddCartItem(context.Background(), domain.ItemChange{Cart: domain.CartRef{ID: guestCartID}, ProductID: 3, Quantity: 1})
+
+		s.NoError(err)
+		s.Equal(saved, got)
+	})
+
+	s.Run("Should give up in case the cart keeps changing concurrently", func() {
+		s.cartsRepo.
+			On("Get", mock.AnythingOfType("*context.valueCtx"), userCartID).
+			Return(domain.Cart{ID: userCartID, Version: 1}, nil).
+			Times(maxChangeAttempts)
+
+		s.cartsRepo.
+			On("Save", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(cart domain.Cart) bool {
+				return cart.ID == userCartID
+			})).
+			Return(domain.Cart{}, domain.ErrCartVersionConflict).
+			Times(maxChangeAttempts)
+
+		_, err := s.app.AddCartItem(context.Background(), domain.ItemChange{Cart: domain.CartRef{ID: userCartID}, ProductID: 1, Quantity: 1})
+
+		s.True(errors.Is(err, domain.ErrCartVersionConflict))
+	})
+}
+
+func productIDs(cart domain.Cart) []int {
+	ids := []int{}
+	for _, item := range cart.Items {
+		ids = append(ids, item.ProductID)
+	}
+
+	return ids
+}
+
+func TestAddCartItemSuite(t *testing.T) {
+	suite.Run(t, new(AddCartItemSuite))
+}
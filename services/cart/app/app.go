@@ -0,0 +1,60 @@
+package app
+
+import (
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// application holds all dependencies the Application needs to work
+type application struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	CartsRepository domain.CartsRepository
+
+	// GuestCartTTL and UserCartTTL are how long the Carts are kept since they were last changed
+	GuestCartTTL time.Duration
+	UserCartTTL  time.Duration
+}
+
+// NewApplication creates a new Application instance.
+// The TTLs that aren't set fall back to domain.DefaultGuestCartTTL and domain.DefaultUserCartTTL.
+func NewApplication(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	cartsRepository domain.CartsRepository,
+	guestCartTTL time.Duration,
+	userCartTTL time.Duration,
+) application {
+	if guestCartTTL <= 0 {
+		guestCartTTL = domain.DefaultGuestCartTTL
+	}
+
+	if userCartTTL <= 0 {
+		userCartTTL = domain.DefaultUserCartTTL
+	}
+
+	return application{
+		Logger:          logger,
+		Tracer:          tracer,
+		CartsRepository: cartsRepository,
+		GuestCartTTL:    guestCartTTL,
+		UserCartTTL:     userCartTTL,
+	}
+}
+
+// MustNewApplication creates a new Application instance
+// It panics if any error is found
+func MustNewApplication(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	cartsRepository domain.CartsRepository,
+	guestCartTTL time.Duration,
+	userCartTTL time.Duration,
+) application {
+	app := NewApplication(logger, tracer, cartsRepository, guestCartTTL, userCartTTL)
+	return app
+}
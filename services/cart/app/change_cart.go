@@ -0,0 +1,121 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	"go.uber.org/zap"
+)
+
+// maxChangeAttempts bounds how many times a change is applied again when the Cart is changed concurrently
+const maxChangeAttempts = 3
+
+// change applies the mutation to the current version of the Cart the CartRef points to and stores it,
+// applying it again on a fresh read of the Cart when it's changed concurrently.
+// Every change pushes the expiration of the Cart forward. A new Cart is only stored when something was put into it.
+func (a application) change(
+	ctx context.Context,
+	ref domain.CartRef,
+	mutate func(cart *domain.Cart, now time.Time) error,
+) (domain.Cart, error) {
+	var err error
+	for attempt := 1; attempt <= maxChangeAttempts; attempt++ {
+		now := time.Now()
+
+		var cart domain.Cart
+		cart, err = a.load(ctx, ref, now)
+		if err != nil {
+			return domain.Cart{}, err
+		}
+
+		if err := mutate(&cart, now); err != nil {
+			return domain.Cart{}, err
+		}
+
+		if cart.Version == 0 && len(cart.Items) == 0 {
+			return cart, nil
+		}
+
+		if cart.ID == "" {
+			if cart.ID, err = newCartID(); err != nil {
+				return domain.Cart{}, err
+			}
+		}
+
+		cart.UpdatedAt = now
+		cart.ExpiresAt = now.Add(a.ttl(cart))
+
+		var saved domain.Cart
+		saved, err = a.CartsRepository.Save(ctx, cart)
+		if !errors.Is(err, domain.ErrCartVersionConflict) && !errors.Is(err, domain.ErrCartAlreadyExists) {
+			return saved, err
+		}
+
+		a.Logger.Info("the cart changed while changing it, trying again", zap.Int("attempt", attempt))
+	}
+
+	return domain.Cart{}, err
+}
+
+// load fetches the Cart the CartRef points to as of the given time.
+// A user without a Cart, or whose Cart expired, gets an empty one, while a missing or expired guest Cart isn't found.
+// An empty CartRef gets a new guest Cart.
+func (a application) load(ctx context.Context, ref domain.CartRef, now time.Time) (domain.Cart, error) {
+	if ref.UserID != "" {
+		cart, err := a.CartsRepository.GetByUser(ctx, ref.UserID)
+		if errors.Is(err, domain.ErrCartNotFound) {
+			return domain.Cart{UserID: ref.UserID, CreatedAt: now}, nil
+		}
+
+		if err != nil {
+			return domain.Cart{}, err
+		}
+
+		if cart.Expired(now) {
+			// the expired Cart is reused rather than removed, so the user keeps a single one
+			cart.Items = nil
+			cart.CreatedAt = now
+		}
+
+		return cart, nil
+	}
+
+	if ref.ID == "" {
+		return domain.Cart{CreatedAt: now}, nil
+	}
+
+	cart, err := a.CartsRepository.Get(ctx, ref.ID)
+	if err != nil {
+		return domain.Cart{}, err
+	}
+
+	// the Cart of a user is only reachable by its user, its ID doesn't work as a secret
+	if !cart.Guest() || cart.Expired(now) {
+		return domain.Cart{}, domain.ErrCartNotFound
+	}
+
+	return cart, nil
+}
+
+// ttl is how long the Cart is kept since it was last changed
+func (a application) ttl(cart domain.Cart) time.Duration {
+	if cart.Guest() {
+		return a.GuestCartTTL
+	}
+
+	return a.UserCartTTL
+}
+
+// newCartID generates a random token, unguessable IDs being what keeps guest Carts private
+func newCartID() (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(token), nil
+}
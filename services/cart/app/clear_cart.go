@@ -0,0 +1,25 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) ClearCart(ctx context.Context, ref domain.CartRef) (domain.Cart, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.ClearCart")
+	defer span.End()
+
+	a.Logger.Info("clearing a cart", zap.Any("cart", ref))
+
+	if err := ref.Validate(); err != nil {
+		return domain.Cart{}, err
+	}
+
+	return a.change(ctx, ref, func(cart *domain.Cart, _ time.Time) error {
+		cart.Items = nil
+		return nil
+	})
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	"github.com/lucasmls/ecommerce/services/cart/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ClearCartSuite struct {
+	suite.Suite
+
+	cartsRepo *mocks.CartsRepository
+	app       domain.Application
+}
+
+func (s *ClearCartSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.cartsRepo = &mocks.CartsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.cartsRepo, 0, 0)
+}
+
+func (s *ClearCartSuite) Test_ClearCart() {
+	s.Run("Should not find a missing guest cart", func() {
+		s.cartsRepo.
+			On("Get", mock.AnythingOfType("*context.valueCtx"), guestCartID).
+			Return(domain.Cart{}, domain.ErrCartNotFound).
+			Once()
+
+		_, err := s.app.ClearCart(context.Background(), domain.CartRef{ID: guestCartID})
+
+		s.Equal(domain.ErrCartNotFound, err)
+	})
+
+	s.Run("Should not store a cart for a user without one", func() {
+		s.cartsRepo.
+			On("GetByUser", mock.AnythingOfType("*context.valueCtx"), "jane").
+			Return(domain.Cart{}, domain.ErrCartNotFound).
+			Once()
+
+		got, err := s.app.ClearCart(context.Background(), domain.CartRef{UserID: "jane"})
+
+		s.NoError(err)
+		s.Equal(0, got.Version)
+		s.cartsRepo.AssertNotCalled(s.T(), "Save", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should take every product out of the cart", func() {
+		s.cartsRepo.
+			On("Get", mock.AnythingOfType("*context.valueCtx"), guestCartID).
+			Return(domain.Cart{
+				ID:        guestCartID,
+				Version:   1,
+				Items:     []domain.LineItem{{ProductID: 1, Quantity: 1}, {ProductID: 2, Quantity: 1}},
+				ExpiresAt: time.Now().Add(time.Hour),
+			}, nil).
+			Once()
+
+		s.cartsRepo.
+			On("Save", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(cart domain.Cart) bool {
+				return cart.ID == guestCartID && len(cart.Items) == 0
+			})).
+			Return(domain.Cart{ID: guestCartID, Version: 2}, nil).
+			Once()
+
+		got, err := s.app.ClearCart(context.Background(), domain.CartRef{ID: guestCartID})
+
+		s.NoError(err)
+		s.Equal(2, got.Version)
+	})
+}
+
+func TestClearCartSuite(t *testing.T) {
+	suite.Run(t, new(ClearCartSuite))
+}
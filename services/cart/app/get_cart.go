@@ -0,0 +1,19 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+)
+
+func (a application) GetCart(ctx context.Context, ref domain.CartRef) (domain.Cart, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.GetCart")
+	defer span.End()
+
+	if err := ref.Validate(); err != nil {
+		return domain.Cart{}, err
+	}
+
+	return a.load(ctx, ref, time.Now())
+}
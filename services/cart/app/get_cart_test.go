@@ -0,0 +1,119 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	"github.com/lucasmls/ecommerce/services/cart/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	guestCartID = "0123456789abcdef0123456789abcdef"
+	userCartID  = "fedcba9876543210fedcba9876543210"
+)
+
+type GetCartSuite struct {
+	suite.Suite
+
+	cartsRepo *mocks.CartsRepository
+	app       domain.Application
+}
+
+func (s *GetCartSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.cartsRepo = &mocks.CartsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.cartsRepo, 0, 0)
+}
+
+func (s *GetCartSuite) Test_GetCart() {
+	s.Run("Should fail in case the cart ref is invalid", func() {
+		for _, ref := range []domain.CartRef{{}, {ID: "not-a-cart-id"}} {
+			_, err := s.app.GetCart(context.Background(), ref)
+
+			s.Equal(domain.ErrInvalidCartRef, err)
+		}
+	})
+
+	s.Run("Should return the guest cart", func() {
+		cart := domain.Cart{ID: guestCartID, Version: 1, ExpiresAt: time.Now().Add(time.Hour)}
+
+		s.cartsRepo.
+			On("Get", mock.AnythingOfType("*context.valueCtx"), guestCartID).
+			Return(cart, nil).
+			Once()
+
+		got, err := s.app.GetCart(context.Background(), domain.CartRef{ID: guestCartID})
+
+		s.NoError(err)
+		s.Equal(cart, got)
+	})
+
+	s.Run("Should not find an expired guest cart", func() {
+		s.cartsRepo.
+			On("Get", mock.AnythingOfType("*context.valueCtx"), guestCartID).
+			Return(domain.Cart{ID: guestCartID, Version: 1, ExpiresAt: time.Now().Add(-time.Minute)}, nil).
+			Once()
+
+		_, err := s.app.GetCart(context.Background(), domain.CartRef{ID: guestCartID})
+
+		s.Equal(domain.ErrCartNotFound, err)
+	})
+
+	s.Run("Should not find the cart of a user by its ID", func() {
+		s.cartsRepo.
+			On("Get", mock.AnythingOfType("*context.valueCtx"), userCartID).
+			Return(domain.Cart{ID: userCartID, UserID: "john", Version: 1, ExpiresAt: time.Now().Add(time.Hour)}, nil).
+			Once()
+
+		_, err := s.app.GetCart(context.Background(), domain.CartRef{ID: userCartID})
+
+		s.Equal(domain.ErrCartNotFound, err)
+	})
+
+	s.Run("Should return an empty cart in case the user has none", func() {
+		s.cartsRepo.
+			On("GetByUser", mock.AnythingOfType("*context.valueCtx"), "jane").
+			Return(domain.Cart{}, domain.ErrCartNotFound).
+			Once()
+
+		got, err := s.app.GetCart(context.Background(), domain.CartRef{ID: guestCartID, UserID: "jane"})
+
+		s.NoError(err)
+		s.Equal("", got.ID)
+		s.Equal("jane", got.UserID)
+		s.Empty(got.Items)
+	})
+
+	s.Run("Should return an expired cart of a user emptied", func() {
+		s.cartsRepo.
+			On("GetByUser", mock.AnythingOfType("*context.valueCtx"), "john").
+			Return(domain.Cart{
+				ID:        userCartID,
+				UserID:    "john",
+				Items:     []domain.LineItem{{ProductID: 1, Quantity: 1}},
+				Version:   3,
+				ExpiresAt: time.Now().Add(-time.Minute),
+			}, nil).
+			Once()
+
+		got, err := s.app.GetCart(context.Background(), domain.CartRef{UserID: "john"})
+
+		s.NoError(err)
+		s.Equal(userCartID, got.ID)
+		s.Equal(3, got.Version)
+		s.Empty(got.Items)
+	})
+}
+
+func TestGetCartSuite(t *testing.T) {
+	suite.Run(t, new(GetCartSuite))
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) MergeCarts(ctx context.Context, guestCartID string, userID string) (domain.Cart, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.MergeCarts")
+	defer span.End()
+
+	a.Logger.Info("merging carts", zap.String("guestCartId", guestCartID), zap.String("userId", userID))
+
+	guestRef := domain.CartRef{ID: guestCartID}
+	userRef := domain.CartRef{UserID: userID}
+
+	if guestCartID == "" || userID == "" {
+		return domain.Cart{}, domain.ErrInvalidCartRef
+	}
+
+	if err := guestRef.Validate(); err != nil {
+		return domain.Cart{}, err
+	}
+
+	if err := userRef.Validate(); err != nil {
+		return domain.Cart{}, err
+	}
+
+	guestCart, err := a.load(ctx, guestRef, time.Now())
+	if err != nil {
+		return domain.Cart{}, err
+	}
+
+	cart, err := a.change(ctx, userRef, func(cart *domain.Cart, _ time.Time) error {
+		return cart.Merge(guestCart)
+	})
+	if err != nil {
+		return domain.Cart{}, err
+	}
+
+	// the merge already happened, a guest Cart left behind is eventually purged once it expires
+	if err := a.CartsRepository.Delete(ctx, guestCart.ID); err != nil {
+		a.Logger.Error("failed to delete a merged guest cart", zap.String("guestCartId", guestCart.ID), zap.Error(err))
+	}
+
+	return cart, nil
+}
@@ -0,0 +1,131 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	"github.com/lucasmls/ecommerce/services/cart/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type MergeCartsSuite struct {
+	suite.Suite
+
+	cartsRepo *mocks.CartsRepository
+	app       domain.Application
+}
+
+func (s *MergeCartsSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.cartsRepo = &mocks.CartsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.cartsRepo, 0, 0)
+}
+
+func (s *MergeCartsSuite) Test_MergeCarts() {
+	now := time.Now()
+
+	guestCart := domain.Cart{
+		ID:      guestCartID,
+		Version: 1,
+		Items: []domain.LineItem{
+			{ProductID: 1, Name: "iPhone 13", Quantity: 60, UnitPrice: 74900, UpdatedAt: now},
+			{ProductID: 2, Name: "MacBook", Quantity: 1, UnitPrice: 129900, UpdatedAt: now},
+		},
+		ExpiresAt: now.Add(time.Hour),
+	}
+
+	s.Run("Should fail in case a cart ref is missing", func() {
+		for _, args := range [][2]string{{"", "john"}, {guestCartID, ""}, {"not-a-cart-id", "john"}} {
+			_, err := s.app.MergeCarts(context.Background(), args[0], args[1])
+
+			s.Equal(domain.ErrInvalidCartRef, err)
+		}
+	})
+
+	s.Run("Should fail in case the guest cart doesn't exist", func() {
+		s.cartsRepo.
+			On("Get", mock.AnythingOfType("*context.valueCtx"), userCartID).
+			Return(domain.Cart{}, domain.ErrCartNotFound).
+			Once()
+
+		_, err := s.app.MergeCarts(context.Background(), userCartID, "john")
+
+		s.Equal(domain.ErrCartNotFound, err)
+	})
+
+	s.Run("Should merge the guest cart into the cart of the user and delete it", func() {
+		s.cartsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), guestCartID).Return(guestCart, nil).Once()
+
+		s.cartsRepo.
+			On("GetByUser", mock.AnythingOfType("*context.valueCtx"), "john").
+			Return(domain.Cart{
+				ID:      userCartID,
+				UserID:  "john",
+				Version: 7,
+				Items: []domain.LineItem{
+					{ProductID: 1, Name: "iPhone 13", Quantity: 50, UnitPrice: 79900, UpdatedAt: now.Add(-time.Hour)},
+					{ProductID: 3, Name: "iPad", Quantity: 1, UnitPrice: 59900, UpdatedAt: now.Add(-time.Hour)},
+				},
+				ExpiresAt: now.Add(time.Hour),
+			}, nil).
+			Once()
+
+		saved := domain.Cart{ID: userCartID, UserID: "john", Version: 8}
+
+		s.cartsRepo.
+			On("Save", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(cart domain.Cart) bool {
+				return cart.ID == userCartID &&
+					len(cart.Items) == 3 &&
+					cart.Items[0].Quantity == domain.MaxItemQuantity &&
+					cart.Items[0].UnitPrice == 74900 &&
+					cart.Items[1].ProductID == 3 &&
+					cart.Items[2].ProductID == 2
+			})).
+			Return(saved, nil).
+			Once()
+
+		s.cartsRepo.On("Delete", mock.AnythingOfType("*context.valueCtx"), guestCartID).Return(nil).Once()
+
+		got, err := s.app.MergeCarts(context.Background(), guestCartID, "john")
+
+		s.NoError(err)
+		s.Equal(saved, got)
+	})
+
+	s.Run("Should create the cart of the user in case it has none", func() {
+		s.cartsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), guestCartID).Return(guestCart, nil).Once()
+
+		s.cartsRepo.
+			On("GetByUser", mock.AnythingOfType("*context.valueCtx"), "jane").
+			Return(domain.Cart{}, domain.ErrCartNotFound).
+			Once()
+
+		s.cartsRepo.
+			On("Save", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(cart domain.Cart) bool {
+				return cart.UserID == "jane" && cart.Version == 0 && len(cart.ID) == 32 && len(cart.Items) == 2
+			})).
+			Return(domain.Cart{UserID: "jane", Version: 1}, nil).
+			Once()
+
+		// the merge went through even though the guest cart was left behind
+		s.cartsRepo.On("Delete", mock.AnythingOfType("*context.valueCtx"), guestCartID).Return(errors.New("connection refused")).Once()
+
+		got, err := s.app.MergeCarts(context.Background(), guestCartID, "jane")
+
+		s.NoError(err)
+		s.Equal(1, got.Version)
+	})
+}
+
+func TestMergeCartsSuite(t *testing.T) {
+	suite.Run(t, new(MergeCartsSuite))
+}
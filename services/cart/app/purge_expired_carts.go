@@ -0,0 +1,24 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func (a application) PurgeExpiredCarts(ctx context.Context) (int, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.PurgeExpiredCarts")
+	defer span.End()
+
+	purged, err := a.CartsRepository.DeleteExpired(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	if purged > 0 {
+		a.Logger.Info("purged expired carts", zap.Int("count", purged))
+	}
+
+	return purged, nil
+}
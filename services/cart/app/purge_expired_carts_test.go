@@ -0,0 +1,61 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	"github.com/lucasmls/ecommerce/services/cart/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type PurgeExpiredCartsSuite struct {
+	suite.Suite
+
+	cartsRepo *mocks.CartsRepository
+	app       domain.Application
+}
+
+func (s *PurgeExpiredCartsSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.cartsRepo = &mocks.CartsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.cartsRepo, 0, 0)
+}
+
+func (s *PurgeExpiredCartsSuite) Test_PurgeExpiredCarts() {
+	s.Run("Should return the error of the repository", func() {
+		repoErr := errors.New("connection refused")
+
+		s.cartsRepo.
+			On("DeleteExpired", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("time.Time")).
+			Return(0, repoErr).
+			Once()
+
+		_, err := s.app.PurgeExpiredCarts(context.Background())
+
+		s.Equal(repoErr, err)
+	})
+
+	s.Run("Should return how many carts were purged", func() {
+		s.cartsRepo.
+			On("DeleteExpired", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("time.Time")).
+			Return(3, nil).
+			Once()
+
+		purged, err := s.app.PurgeExpiredCarts(context.Background())
+
+		s.NoError(err)
+		s.Equal(3, purged)
+	})
+}
+
+func TestPurgeExpiredCartsSuite(t *testing.T) {
+	suite.Run(t, new(PurgeExpiredCartsSuite))
+}
@@ -0,0 +1,24 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) RemoveCartItem(ctx context.Context, ref domain.CartRef, productID int) (domain.Cart, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.RemoveCartItem")
+	defer span.End()
+
+	a.Logger.Info("removing an item from a cart", zap.Any("cart", ref), zap.Int("productId", productID))
+
+	if err := ref.Validate(); err != nil {
+		return domain.Cart{}, err
+	}
+
+	return a.change(ctx, ref, func(cart *domain.Cart, _ time.Time) error {
+		return cart.RemoveItem(productID)
+	})
+}
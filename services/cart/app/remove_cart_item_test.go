@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	"github.com/lucasmls/ecommerce/services/cart/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type RemoveCartItemSuite struct {
+	suite.Suite
+
+	cartsRepo *mocks.CartsRepository
+	app       domain.Application
+}
+
+func (s *RemoveCartItemSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.cartsRepo = &mocks.CartsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.cartsRepo, 0, 0)
+}
+
+func (s *RemoveCartItemSuite) Test_RemoveCartItem() {
+	s.Run("Should fail in case the cart ref is missing", func() {
+		_, err := s.app.RemoveCartItem(context.Background(), domain.CartRef{}, 1)
+
+		s.Equal(domain.ErrInvalidCartRef, err)
+	})
+
+	s.Run("Should fail in case the product isn't in the cart", func() {
+		s.cartsRepo.
+			On("GetByUser", mock.AnythingOfType("*context.valueCtx"), "jane").
+			Return(domain.Cart{}, domain.ErrCartNotFound).
+			Once()
+
+		_, err := s.app.RemoveCartItem(context.Background(), domain.CartRef{UserID: "jane"}, 1)
+
+		s.Equal(domain.ErrItemNotInCart, err)
+	})
+
+	s.Run("Should take the product out of the cart", func() {
+		s.cartsRepo.
+			On("GetByUser", mock.AnythingOfType("*context.valueCtx"), "john").
+			Return(domain.Cart{
+				ID:        userCartID,
+				UserID:    "john",
+				Version:   4,
+				Items:     []domain.LineItem{{ProductID: 1, Quantity: 1}, {ProductID: 2, Quantity: 1}},
+				ExpiresAt: time.Now().Add(time.Hour),
+			}, nil).
+			Once()
+
+		saved := domain.Cart{ID: userCartID, UserID: "john", Version: 5, Items: []domain.LineItem{{ProductID: 2, Quantity: 1}}}
+
+		s.cartsRepo.
+			On("Save", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(cart domain.Cart) bool {
+				return cart.Version == 4 && len(cart.Items) == 1 && cart.Items[0].ProductID == 2
+			})).
+			Return(saved, nil).
+			Once()
+
+		got, err := s.app.RemoveCartItem(context.Background(), domain.CartRef{UserID: "john"}, 1)
+
+		s.NoError(err)
+		s.Equal(saved, got)
+	})
+}
+
+func TestRemoveCartItemSuite(t *testing.T) {
+	suite.Run(t, new(RemoveCartItemSuite))
+}
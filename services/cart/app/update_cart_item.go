@@ -0,0 +1,28 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) UpdateCartItem(ctx context.Context, change domain.ItemChange) (domain.Cart, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.UpdateCartItem")
+	defer span.End()
+
+	a.Logger.Info("updating an item of a cart", zap.Any("change", change))
+
+	if err := change.Cart.Validate(); err != nil {
+		return domain.Cart{}, err
+	}
+
+	if err := change.Validate(); err != nil {
+		return domain.Cart{}, err
+	}
+
+	return a.change(ctx, change.Cart, func(cart *domain.Cart, now time.Time) error {
+		return cart.SetItem(change, now)
+	})
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	"github.com/lucasmls/ecommerce/services/cart/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type UpdateCartItemSuite struct {
+	suite.Suite
+
+	cartsRepo *mocks.CartsRepository
+	app       domain.Application
+}
+
+func (s *UpdateCartItemSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.cartsRepo = &mocks.CartsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.cartsRepo, 0, 0)
+}
+
+func (s *UpdateCartItemSuite) cart() domain.Cart {
+	return domain.Cart{
+		ID:      guestCartID,
+		Version: 1,
+		Items: []domain.LineItem{
+			{ProductID: 1, Name: "iPhone 13", Quantity: 1, UnitPrice: 79900},
+			{ProductID: 2, Name: "MacBook", Quantity: 1, UnitPrice: 129900},
+		},
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+}
+
+func (s *UpdateCartItemSuite) Test_UpdateCartItem() {
+	s.Run("Should fail in case the cart ref is missing", func() {
+		_, err := s.app.UpdateCartItem(context.Background(), domain.ItemChange{ProductID: 1, Quantity: 1})
+
+		s.Equal(domain.ErrInvalidCartRef, err)
+	})
+
+	s.Run("Should fail in case the product isn't in the cart", func() {
+		s.cartsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), guestCartID).Return(s.cart(), nil).Once()
+
+		_, err := s.app.UpdateCartItem(context.Background(), domain.ItemChange{Cart: domain.CartRef{ID: guestCartID}, ProductID: 3, Quantity: 1})
+
+		s.Equal(domain.ErrItemNotInCart, err)
+	})
+
+	s.Run("Should replace the units of the product", func() {
+		s.cartsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), guestCartID).Return(s.cart(), nil).Once()
+
+		s.cartsRepo.
+			On("Save", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(cart domain.Cart) bool {
+				return len(cart.Items) == 2 && cart.Items[1].Quantity == 5 && cart.Items[1].UnitPrice == 119900
+			})).
+			Return(domain.Cart{ID: guestCartID, Version: 2}, nil).
+			Once()
+
+		got, err := s.app.UpdateCartItem(context.Background(), domain.ItemChange{
+			Cart:      domain.CartRef{ID: guestCartID},
+			ProductID: 2,
+			Name:      "MacBook",
+			Quantity:  5,
+			UnitPrice: 119900,
+		})
+
+		s.NoError(err)
+		s.Equal(2, got.Version)
+	})
+
+	s.Run("Should remove the product in case the quantity is 0", func() {
+		s.cartsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), guestCartID).Return(s.cart(), nil).Once()
+
+		s.cartsRepo.
+			On("Save", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(cart domain.Cart) bool {
+				return len(cart.Items) == 1 && cart.Items[0].ProductID == 2
+			})).
+			Return(domain.Cart{ID: guestCartID, Version: 2}, nil).
+			Once()
+
+		_, err := s.app.UpdateCartItem(context.Background(), domain.ItemChange{Cart: domain.CartRef{ID: guestCartID}, ProductID: 1})
+
+		s.NoError(err)
+	})
+}
+
+func TestUpdateCartItemSuite(t *testing.T) {
+	suite.Run(t, new(UpdateCartItemSuite))
+}
@@ -0,0 +1,31 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) UpdateCartPrices(ctx context.Context, ref domain.CartRef, updates []domain.PriceUpdate) (domain.Cart, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.UpdateCartPrices")
+	defer span.End()
+
+	a.Logger.Info("updating the prices of a cart", zap.Any("cart", ref), zap.Any("updates", updates))
+
+	if err := ref.Validate(); err != nil {
+		return domain.Cart{}, err
+	}
+
+	for _, update := range updates {
+		if err := update.Validate(); err != nil {
+			return domain.Cart{}, err
+		}
+	}
+
+	return a.change(ctx, ref, func(cart *domain.Cart, now time.Time) error {
+		cart.UpdatePrices(updates, now)
+		return nil
+	})
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	"github.com/lucasmls/ecommerce/services/cart/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type UpdateCartPricesSuite struct {
+	suite.Suite
+
+	cartsRepo *mocks.CartsRepository
+	app       domain.Application
+}
+
+func (s *UpdateCartPricesSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.cartsRepo = &mocks.CartsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.cartsRepo, 0, 0)
+}
+
+func (s *UpdateCartPricesSuite) Test_UpdateCartPrices() {
+	s.Run("Should fail in case an update is invalid", func() {
+		_, err := s.app.UpdateCartPrices(context.Background(), domain.CartRef{ID: guestCartID}, []domain.PriceUpdate{{ProductID: 1, UnitPrice: -1}})
+
+		s.Equal(domain.ErrInvalidCartItem, err)
+	})
+
+	s.Run("Should refresh the snapshots of the products in the cart", func() {
+		s.cartsRepo.
+			On("Get", mock.AnythingOfType("*context.valueCtx"), guestCartID).
+			Return(domain.Cart{
+				ID:      guestCartID,
+				Version: 1,
+				Items: []domain.LineItem{
+					{ProductID: 1, Name: "iPhone 13", Quantity: 2, UnitPrice: 79900},
+					{ProductID: 2, Name: "MacBook", Quantity: 1, UnitPrice: 129900},
+				},
+				ExpiresAt: time.Now().Add(time.Hour),
+			}, nil).
+			Once()
+
+		s.cartsRepo.
+			On("Save", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(cart domain.Cart) bool {
+				return cart.Items[0].Name == "iPhone 13 Pro" &&
+					cart.Items[0].UnitPrice == 99900 &&
+					cart.Items[1].UnitPrice == 129900 &&
+					len(cart.Items) == 2
+			})).
+			Return(domain.Cart{ID: guestCartID, Version: 2}, nil).
+			Once()
+
+		_, err := s.app.UpdateCartPrices(context.Background(), domain.CartRef{ID: guestCartID}, []domain.PriceUpdate{
+			{ProductID: 1, Name: "iPhone 13 Pro", UnitPrice: 99900},
+			{ProductID: 3, Name: "iPad", UnitPrice: 59900},
+		})
+
+		s.NoError(err)
+	})
+}
+
+func TestUpdateCartPricesSuite(t *testing.T) {
+	suite.Run(t, new(UpdateCartPricesSuite))
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/cart/adapters/repositories"
+	"github.com/lucasmls/ecommerce/services/cart/app"
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	resolvers "github.com/lucasmls/ecommerce/services/cart/ports/grpc"
+	protog "github.com/lucasmls/ecommerce/services/cart/ports/grpc/proto"
+	"github.com/lucasmls/ecommerce/shared/env"
+	"github.com/lucasmls/ecommerce/shared/grpc"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otel "go.opentelemetry.io/otel"
+	otelJaegerExporter "go.opentelemetry.io/otel/exporters/jaeger"
+	otelPropagation "go.opentelemetry.io/otel/propagation"
+	otelSdkResource "go.opentelemetry.io/otel/sdk/resource"
+	otelTraceSdk "go.opentelemetry.io/otel/sdk/trace"
+	otelSemconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.uber.org/zap"
+	gGRPC "google.golang.org/grpc"
+)
+
+// defaultPurgeInterval is how often the expired carts are discarded when PURGE_INTERVAL isn't set
+const defaultPurgeInterval = time.Hour
+
+type ApplicationConfig struct {
+	ServiceName              string `mapstructure:"SERVICE_NAME"`
+	JaegerEndpoint           string `mapstructure:"JAEGER_ENDPOINT"`
+	GrpcServerPort           int    `mapstructure:"GRPC_SERVER_PORT"`
+	MetricsPort              int    `mapstructure:"METRICS_PORT"`
+	PostgresConnectionString string `mapstructure:"PG_CONNECTION_STRING"`
+	// GuestCartTTL and UserCartTTL are how long the carts are kept since they were last changed, e.g. 168h
+	GuestCartTTL time.Duration `mapstructure:"GUEST_CART_TTL"`
+	UserCartTTL  time.Duration `mapstructure:"USER_CART_TTL"`
+	// PurgeInterval is how often the expired carts are discarded, e.g. 1h
+	PurgeInterval time.Duration `mapstructure:"PURGE_INTERVAL"`
+}
+
+func main() {
+	ctx := context.Background()
+
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	config, err := env.LoadConfig[ApplicationConfig]()
+	if err != nil {
+		logger.Fatal("failed to load application config", zap.Error(err))
+	}
+
+	jaegerExporter, err := otelJaegerExporter.New(
+		otelJaegerExporter.WithCollectorEndpoint(
+			otelJaegerExporter.WithEndpoint(config.JaegerEndpoint),
+		),
+	)
+	if err != nil {
+		logger.Fatal("failed to instantiate Jaeger exporter", zap.Error(err))
+	}
+
+	tracingProvider := otelTraceSdk.NewTracerProvider(
+		otelTraceSdk.WithBatcher(jaegerExporter),
+		otelTraceSdk.WithSampler(otelTraceSdk.AlwaysSample()),
+		otelTraceSdk.WithResource(otelSdkResource.NewWithAttributes(
+			otelSemconv.SchemaURL,
+			otelSemconv.ServiceNameKey.String(config.ServiceName),
+		)),
+	)
+
+	defer func() {
+		_ = tracingProvider.Shutdown(ctx)
+	}()
+
+	otel.SetTracerProvider(tracingProvider)
+	otel.SetTextMapPropagator(otelPropagation.NewCompositeTextMapPropagator(
+		otelPropagation.TraceContext{},
+		otelPropagation.Baggage{},
+	))
+
+	tracer := otel.Tracer(config.ServiceName)
+
+	inMemoryCartsRepository := repositories.NewInMemoryCartsRepository(logger, tracer)
+	// postgresCartsRepository := repositories.MustNewPgCartsRepository(config.PostgresConnectionString)
+
+	application := app.MustNewApplication(logger, tracer, inMemoryCartsRepository, config.GuestCartTTL, config.UserCartTTL)
+	cartResolver := resolvers.MustNewCartResolver(logger, tracer, application)
+
+	server := grpc.MustNewServer(grpc.ServerInput{
+		Port:   config.GrpcServerPort,
+		Logger: logger,
+		Registrator: func(server gGRPC.ServiceRegistrar) {
+			protog.RegisterCartServiceServer(server, cartResolver)
+		},
+	})
+
+	go func() {
+		port := fmt.Sprintf(":%d", config.MetricsPort)
+
+		http.Handle("/metrics", promhttp.Handler())
+		_ = http.ListenAndServe(port, nil)
+	}()
+
+	purgeInterval := config.PurgeInterval
+	if purgeInterval <= 0 {
+		purgeInterval = defaultPurgeInterval
+	}
+
+	go purgeExpiredCarts(ctx, logger, application, purgeInterval)
+
+	if err := server.Run(ctx); err != nil {
+		logger.Fatal("failed to run gRPC server", zap.Error(err))
+	}
+}
+
+// purgeExpiredCarts discards the expired carts on every tick of the interval.
+// Running it on many replicas is safe, deleting an already deleted cart is a no-op.
+func purgeExpiredCarts(ctx context.Context, logger *zap.Logger, application domain.Application, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := application.PurgeExpiredCarts(ctx); err != nil {
+				logger.Error("failed to purge the expired carts", zap.Error(err))
+			}
+		}
+	}
+}
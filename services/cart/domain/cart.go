@@ -0,0 +1,272 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+const (
+	// MaxCartItems is the maximum amount of different Products a Cart may have
+	MaxCartItems = 100
+	// MaxItemQuantity is the maximum amount of units of a single Product a Cart may have
+	MaxItemQuantity = 99
+	// MaxItemNameLength is the maximum amount of characters of the name snapshot of a LineItem
+	MaxItemNameLength = 255
+	// MaxUserIDLength is the maximum amount of characters of the ID of the user owning a Cart
+	MaxUserIDLength = 255
+
+	// DefaultGuestCartTTL is how long a guest Cart is kept since it was last changed
+	DefaultGuestCartTTL = 7 * 24 * time.Hour
+	// DefaultUserCartTTL is how long the Cart of a user is kept since it was last changed
+	DefaultUserCartTTL = 30 * 24 * time.Hour
+)
+
+var (
+	ErrCartNotFound        = errors.New("cart-not-found")
+	ErrCartAlreadyExists   = errors.New("cart-already-exists")
+	ErrCartVersionConflict = errors.New("cart-version-conflict")
+	ErrCartFull            = errors.New("cart-full")
+	ErrInvalidCartRef      = errors.New("invalid-cart-ref")
+	ErrInvalidCartItem     = errors.New("invalid-cart-item")
+	ErrInvalidQuantity     = errors.New("invalid-quantity")
+	ErrItemNotInCart       = errors.New("item-not-in-cart")
+)
+
+// cartIDPattern matches the random tokens Carts are identified by
+var cartIDPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// LineItem is an amount of units of a Product put into a Cart.
+// Its Name and UnitPrice are snapshots of the Product taken when the LineItem was last changed,
+// so they may differ from the current ones.
+type LineItem struct {
+	ProductID int
+	Name      string
+	Quantity  int
+	// UnitPrice is in minor currency units (cents)
+	UnitPrice int
+	AddedAt   time.Time
+	UpdatedAt time.Time
+}
+
+// Total is the price of every unit of the LineItem, in minor currency units
+func (i LineItem) Total() int {
+	return i.Quantity * i.UnitPrice
+}
+
+// Cart holds the Products a customer intends to buy.
+// Guest Carts are only reachable by their ID, which works as their secret, while the Cart of a user
+// is reached by its UserID, every user having a single one.
+type Cart struct {
+	ID string
+	// UserID is empty for guest Carts
+	UserID string
+	// Items are kept in the order they were added
+	Items []LineItem
+	// Version is incremented on every change, starting at 1 when the Cart is created.
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// ExpiresAt is pushed forward on every change, the Cart is discarded once it's reached.
+	ExpiresAt time.Time
+}
+
+// Guest reports whether the Cart doesn't belong to a user
+func (c Cart) Guest() bool {
+	return c.UserID == ""
+}
+
+// Expired reports whether the Cart was left untouched for too long at the given time
+func (c Cart) Expired(now time.Time) bool {
+	return !c.ExpiresAt.IsZero() && !now.Before(c.ExpiresAt)
+}
+
+// Subtotal is the price of every LineItem, in minor currency units
+func (c Cart) Subtotal() int {
+	subtotal := 0
+	for _, item := range c.Items {
+		subtotal += item.Total()
+	}
+
+	return subtotal
+}
+
+// item finds the LineItem of a Product, returning its index or -1
+func (c Cart) item(productID int) int {
+	for i, item := range c.Items {
+		if item.ProductID == productID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// AddItem puts units of a Product into the Cart, adding them to the ones already there and refreshing the snapshots
+func (c *Cart) AddItem(change ItemChange, now time.Time) error {
+	i := c.item(change.ProductID)
+	if i < 0 {
+		if len(c.Items) >= MaxCartItems {
+			return ErrCartFull
+		}
+
+		c.Items = append(c.Items, LineItem{
+			ProductID: change.ProductID,
+			Name:      change.Name,
+			Quantity:  change.Quantity,
+			UnitPrice: change.UnitPrice,
+			AddedAt:   now,
+			UpdatedAt: now,
+		})
+
+		return nil
+	}
+
+	quantity := c.Items[i].Quantity + change.Quantity
+	if quantity > MaxItemQuantity {
+		return ErrInvalidQuantity
+	}
+
+	c.Items[i].Quantity = quantity
+	c.Items[i].Name = change.Name
+	c.Items[i].UnitPrice = change.UnitPrice
+	c.Items[i].UpdatedAt = now
+
+	return nil
+}
+
+// SetItem replaces the units of a Product on the Cart, refreshing the snapshots, or removes it when there are none
+func (c *Cart) SetItem(change ItemChange, now time.Time) error {
+	i := c.item(change.ProductID)
+	if i < 0 {
+		return ErrItemNotInCart
+	}
+
+	if change.Quantity == 0 {
+		return c.RemoveItem(change.ProductID)
+	}
+
+	c.Items[i].Quantity = change.Quantity
+	c.Items[i].Name = change.Name
+	c.Items[i].UnitPrice = change.UnitPrice
+	c.Items[i].UpdatedAt = now
+
+	return nil
+}
+
+// RemoveItem takes a Product out of the Cart
+func (c *Cart) RemoveItem(productID int) error {
+	i := c.item(productID)
+	if i < 0 {
+		return ErrItemNotInCart
+	}
+
+	c.Items = append(c.Items[:i], c.Items[i+1:]...)
+	return nil
+}
+
+// UpdatePrices refreshes the snapshots of the LineItems of the given Products, leaving the other ones untouched
+func (c *Cart) UpdatePrices(updates []PriceUpdate, now time.Time) {
+	for _, update := range updates {
+		i := c.item(update.ProductID)
+		if i < 0 {
+			continue
+		}
+
+		c.Items[i].Name = update.Name
+		c.Items[i].UnitPrice = update.UnitPrice
+		c.Items[i].UpdatedAt = now
+	}
+}
+
+// Merge puts the LineItems of another Cart into this one, adding up the units of the Products on both.
+// The snapshots of the most recently changed LineItem win, and the units are capped at MaxItemQuantity
+// rather than failing, since the customer didn't ask for them on a single change.
+func (c *Cart) Merge(other Cart) error {
+	for _, item := range other.Items {
+		i := c.item(item.ProductID)
+		if i < 0 {
+			if len(c.Items) >= MaxCartItems {
+				return ErrCartFull
+			}
+
+			c.Items = append(c.Items, item)
+			continue
+		}
+
+		merged := &c.Items[i]
+
+		merged.Quantity += item.Quantity
+		if merged.Quantity > MaxItemQuantity {
+			merged.Quantity = MaxItemQuantity
+		}
+
+		if item.UpdatedAt.After(merged.UpdatedAt) {
+			merged.Name = item.Name
+			merged.UnitPrice = item.UnitPrice
+			merged.UpdatedAt = item.UpdatedAt
+		}
+	}
+
+	return nil
+}
+
+// CartRef points to a Cart, either to the Cart of a user by its UserID or to a guest Cart by its ID.
+// The UserID takes precedence when both are set.
+type CartRef struct {
+	ID     string
+	UserID string
+}
+
+// Validate checks that the CartRef points to some Cart
+func (r CartRef) Validate() error {
+	if (r.ID == "" && r.UserID == "") || len(r.UserID) > MaxUserIDLength {
+		return ErrInvalidCartRef
+	}
+
+	if r.ID != "" && !cartIDPattern.MatchString(r.ID) {
+		return ErrInvalidCartRef
+	}
+
+	return nil
+}
+
+// ItemChange changes the units of a Product on a Cart, along with its snapshots.
+type ItemChange struct {
+	Cart      CartRef
+	ProductID int
+	Name      string
+	Quantity  int
+	// UnitPrice is in minor currency units (cents)
+	UnitPrice int
+}
+
+// Validate checks the Product, its snapshots and the bounds of the Quantity, whether it may be 0 is left to the operation
+func (c ItemChange) Validate() error {
+	if c.ProductID <= 0 || c.UnitPrice < 0 || len([]rune(c.Name)) > MaxItemNameLength {
+		return ErrInvalidCartItem
+	}
+
+	if c.Quantity < 0 || c.Quantity > MaxItemQuantity {
+		return ErrInvalidQuantity
+	}
+
+	return nil
+}
+
+// PriceUpdate refreshes the snapshots of the LineItem of a Product.
+type PriceUpdate struct {
+	ProductID int
+	Name      string
+	// UnitPrice is in minor currency units (cents)
+	UnitPrice int
+}
+
+// Validate checks the Product and its snapshots
+func (u PriceUpdate) Validate() error {
+	if u.ProductID <= 0 || u.UnitPrice < 0 || len([]rune(u.Name)) > MaxItemNameLength {
+		return ErrInvalidCartItem
+	}
+
+	return nil
+}
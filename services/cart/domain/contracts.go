@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Application defines boundary interfaces of the application
+// It should be called by the ports
+type Application interface {
+	// GetCart fetches the Cart the CartRef points to. A user without a Cart gets an empty one,
+	// which isn't stored, so it has no ID, until something is put into it.
+	GetCart(context.Context, CartRef) (Cart, error)
+
+	// AddCartItem puts units of a Product into a Cart, creating it when missing.
+	// A new guest Cart is created when the CartRef is left empty.
+	AddCartItem(context.Context, ItemChange) (Cart, error)
+
+	// UpdateCartItem replaces the units of a Product on a Cart, removing it when the Quantity is 0
+	UpdateCartItem(context.Context, ItemChange) (Cart, error)
+
+	// RemoveCartItem takes a Product out of a Cart
+	RemoveCartItem(context.Context, CartRef, int) (Cart, error)
+
+	// ClearCart takes every Product out of a Cart
+	ClearCart(context.Context, CartRef) (Cart, error)
+
+	// UpdateCartPrices refreshes the snapshots of the LineItems of a Cart, e.g. after checking the current prices
+	UpdateCartPrices(context.Context, CartRef, []PriceUpdate) (Cart, error)
+
+	// MergeCarts puts the LineItems of a guest Cart, given by its ID, into the Cart of a user,
+	// e.g. when the guest logs in, and discards the guest Cart.
+	MergeCarts(context.Context, string, string) (Cart, error)
+
+	// PurgeExpiredCarts discards the Carts that were left untouched for too long, returning how many were discarded
+	PurgeExpiredCarts(context.Context) (int, error)
+}
+
+type CartsRepository interface {
+	// Get fetches a single Cart by its ID from a data storage.
+	Get(context.Context, string) (Cart, error)
+
+	// GetByUser fetches the Cart of a user from a data storage.
+	GetByUser(context.Context, string) (Cart, error)
+
+	// Save stores a Cart in a data storage, returning it with its new Version.
+	// A Cart whose Version is 0 is created, failing with ErrCartAlreadyExists when its ID or its user is taken.
+	// Any other Cart is only replaced while the stored Version is still the one it was read with, failing with
+	// ErrCartVersionConflict otherwise, so concurrent changes aren't lost.
+	Save(context.Context, Cart) (Cart, error)
+
+	// Delete removes a Cart from a data storage, removing a missing Cart is a no-op.
+	Delete(context.Context, string) error
+
+	// DeleteExpired removes the Carts that expired at the given time from a data storage, returning how many were removed.
+	DeleteExpired(context.Context, time.Time) (int, error)
+}
@@ -0,0 +1,13 @@
+-- the line items are kept along with their cart, which is always read and written as a whole
+CREATE TABLE IF NOT EXISTS carts (
+    id         TEXT PRIMARY KEY,
+    -- null for guest carts, a user has a single cart
+    user_id    TEXT        UNIQUE,
+    items      JSONB       NOT NULL,
+    version    INTEGER     NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX carts_expires_at_idx ON carts (expires_at);
@@ -0,0 +1,249 @@
+package grpc_port
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	pb "github.com/lucasmls/ecommerce/services/cart/ports/grpc/proto"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	InternalServerError = status.Error(codes.Internal, "Internal server error")
+)
+
+var (
+	ErrMissingLogger = errors.New("missing required dependency: Logger")
+	ErrMisingTracer  = errors.New("missing required dependency: Tracer")
+)
+
+// CartResolver ...
+type CartResolver struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+	App    domain.Application
+
+	pb.UnimplementedCartServiceServer
+}
+
+func NewCartResolver(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) (*CartResolver, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMisingTracer
+	}
+
+	return &CartResolver{
+		Logger: logger,
+		Tracer: tracer,
+		App:    app,
+	}, nil
+}
+
+// MustNewCartResolver creates a new CartResolver instance.
+// It panics if any error is found
+func MustNewCartResolver(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) *CartResolver {
+	cartResolver, err := NewCartResolver(logger, tracer, app)
+	if err != nil {
+		panic(err)
+	}
+
+	return cartResolver
+}
+
+func (r *CartResolver) GetCart(ctx context.Context, req *pb.GetCartRequest) (*pb.GetCartResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.GetCart")
+	defer span.End()
+
+	ref := toCartRef(req.Cart)
+
+	cart, err := r.App.GetCart(ctx, ref)
+	if err != nil {
+		return nil, r.toError(err, "failed to get the cart", zap.Any("cart", ref))
+	}
+
+	return &pb.GetCartResponse{Data: toProtoCart(cart)}, nil
+}
+
+func (r *CartResolver) AddItem(ctx context.Context, req *pb.AddItemRequest) (*pb.AddItemResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.AddItem")
+	defer span.End()
+
+	change := domain.ItemChange{
+		Cart:      toCartRef(req.Cart),
+		ProductID: int(req.ProductId),
+		Name:      req.Name,
+		Quantity:  int(req.Quantity),
+		UnitPrice: int(req.UnitPrice),
+	}
+
+	cart, err := r.App.AddCartItem(ctx, change)
+	if err != nil {
+		return nil, r.toError(err, "failed to add the item to the cart", zap.Any("change", change))
+	}
+
+	return &pb.AddItemResponse{Data: toProtoCart(cart)}, nil
+}
+
+func (r *CartResolver) UpdateItem(ctx context.Context, req *pb.UpdateItemRequest) (*pb.UpdateItemResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.UpdateItem")
+	defer span.End()
+
+	change := domain.ItemChange{
+		Cart:      toCartRef(req.Cart),
+		ProductID: int(req.ProductId),
+		Name:      req.Name,
+		Quantity:  int(req.Quantity),
+		UnitPrice: int(req.UnitPrice),
+	}
+
+	cart, err := r.App.UpdateCartItem(ctx, change)
+	if err != nil {
+		return nil, r.toError(err, "failed to update the item of the cart", zap.Any("change", change))
+	}
+
+	return &pb.UpdateItemResponse{Data: toProtoCart(cart)}, nil
+}
+
+func (r *CartResolver) RemoveItem(ctx context.Context, req *pb.RemoveItemRequest) (*pb.RemoveItemResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.RemoveItem")
+	defer span.End()
+
+	ref := toCartRef(req.Cart)
+
+	cart, err := r.App.RemoveCartItem(ctx, ref, int(req.ProductId))
+	if err != nil {
+		return nil, r.toError(err, "failed to remove the item from the cart", zap.Any("cart", ref), zap.Int32("productId", req.ProductId))
+	}
+
+	return &pb.RemoveItemResponse{Data: toProtoCart(cart)}, nil
+}
+
+func (r *CartResolver) ClearCart(ctx context.Context, req *pb.ClearCartRequest) (*pb.ClearCartResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.ClearCart")
+	defer span.End()
+
+	ref := toCartRef(req.Cart)
+
+	cart, err := r.App.ClearCart(ctx, ref)
+	if err != nil {
+		return nil, r.toError(err, "failed to clear the cart", zap.Any("cart", ref))
+	}
+
+	return &pb.ClearCartResponse{Data: toProtoCart(cart)}, nil
+}
+
+func (r *CartResolver) UpdatePrices(ctx context.Context, req *pb.UpdatePricesRequest) (*pb.UpdatePricesResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.UpdatePrices")
+	defer span.End()
+
+	ref := toCartRef(req.Cart)
+
+	updates := make([]domain.PriceUpdate, 0, len(req.Updates))
+	for _, update := range req.Updates {
+		updates = append(updates, domain.PriceUpdate{
+			ProductID: int(update.ProductId),
+			Name:      update.Name,
+			UnitPrice: int(update.UnitPrice),
+		})
+	}
+
+	cart, err := r.App.UpdateCartPrices(ctx, ref, updates)
+	if err != nil {
+		return nil, r.toError(err, "failed to update the prices of the cart", zap.Any("cart", ref), zap.Any("updates", updates))
+	}
+
+	return &pb.UpdatePricesResponse{Data: toProtoCart(cart)}, nil
+}
+
+func (r *CartResolver) MergeCarts(ctx context.Context, req *pb.MergeCartsRequest) (*pb.MergeCartsResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.MergeCarts")
+	defer span.End()
+
+	cart, err := r.App.MergeCarts(ctx, req.GuestCartId, req.UserId)
+	if err != nil {
+		return nil, r.toError(err, "failed to merge the carts", zap.String("guestCartId", req.GuestCartId), zap.String("userId", req.UserId))
+	}
+
+	return &pb.MergeCartsResponse{Data: toProtoCart(cart)}, nil
+}
+
+// toError maps the Cart errors to their gRPC codes, a Cart changed concurrently aborting the call so the client
+// may read it again. Unknown errors are logged and reported as internal.
+func (r *CartResolver) toError(err error, message string, fields ...zap.Field) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCartRef),
+		errors.Is(err, domain.ErrInvalidCartItem),
+		errors.Is(err, domain.ErrInvalidQuantity):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrCartNotFound),
+		errors.Is(err, domain.ErrItemNotInCart):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrCartFull):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrCartVersionConflict),
+		errors.Is(err, domain.ErrCartAlreadyExists):
+		return status.Error(codes.Aborted, err.Error())
+	}
+
+	r.Logger.Error(message, append(fields, zap.Error(err))...)
+	return InternalServerError
+}
+
+func toCartRef(ref *pb.CartRef) domain.CartRef {
+	return domain.CartRef{
+		ID:     ref.GetId(),
+		UserID: ref.GetUserId(),
+	}
+}
+
+func toProtoCart(cart domain.Cart) *pb.Cart {
+	items := make([]*pb.LineItem, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		items = append(items, &pb.LineItem{
+			ProductId: int32(item.ProductID),
+			Name:      item.Name,
+			Quantity:  int32(item.Quantity),
+			UnitPrice: int32(item.UnitPrice),
+			Total:     int32(item.Total()),
+			AddedAt:   toProtoTimestamp(item.AddedAt),
+			UpdatedAt: toProtoTimestamp(item.UpdatedAt),
+		})
+	}
+
+	return &pb.Cart{
+		Id:        cart.ID,
+		UserId:    cart.UserID,
+		Items:     items,
+		Subtotal:  int32(cart.Subtotal()),
+		Version:   int32(cart.Version),
+		CreatedAt: toProtoTimestamp(cart.CreatedAt),
+		UpdatedAt: toProtoTimestamp(cart.UpdatedAt),
+		ExpiresAt: toProtoTimestamp(cart.ExpiresAt),
+	}
+}
+
+// toProtoTimestamp leaves the zero times unset
+func toProtoTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
@@ -0,0 +1,253 @@
+package grpc_port
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/shared/grpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	gGRPC "google.golang.org/grpc"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/lucasmls/ecommerce/services/cart/domain"
+	"github.com/lucasmls/ecommerce/services/cart/mocks"
+	protog "github.com/lucasmls/ecommerce/services/cart/ports/grpc/proto"
+)
+
+const guestCartID = "0123456789abcdef0123456789abcdef"
+
+type CartResolverSuite struct {
+	suite.Suite
+
+	app    *mocks.Application
+	logger *zap.Logger
+	tracer trace.Tracer
+
+	grpcClient     protog.CartServiceClient
+	grpcConnection *gGRPC.ClientConn
+}
+
+func (s *CartResolverSuite) SetupSuite() {
+	s.logger = zap.NewNop()
+	s.tracer = trace.NewNoopTracerProvider().Tracer("")
+	s.app = &mocks.Application{}
+
+	grpcClient, grpcConnection := makeGrpcClientAndServer(
+		context.Background(),
+		s.logger,
+		s.tracer,
+		s.app,
+	)
+
+	s.grpcClient = grpcClient
+	s.grpcConnection = grpcConnection
+}
+
+func (s *CartResolverSuite) TearDownSuite() {
+	err := s.grpcConnection.Close()
+	s.NoError(err)
+}
+
+func (s *CartResolverSuite) Test_NewCartResolver() {
+	s.Run("Should fail to instantiate the CartResolver in case a Logger isn't provided", func() {
+		_, err := NewCartResolver(nil, nil, nil)
+
+		s.Equal(ErrMissingLogger, err)
+	})
+
+	s.Run("Should fail to instantiate the CartResolver in case a Tracer isn't provided", func() {
+		_, err := NewCartResolver(s.logger, nil, nil)
+
+		s.Equal(ErrMisingTracer, err)
+	})
+
+	s.Run("Should successfully instantiate the CartResolver", func() {
+		_, err := NewCartResolver(s.logger, s.tracer, nil)
+
+		s.NoError(err)
+	})
+}
+
+func (s *CartResolverSuite) Test_GetCart() {
+	s.Run("Should return a not found error in case the cart doesn't exist", func() {
+		s.app.
+			On("GetCart", mock.AnythingOfType("*context.valueCtx"), domain.CartRef{ID: guestCartID}).
+			Return(domain.Cart{}, domain.ErrCartNotFound)
+
+		_, err := s.grpcClient.GetCart(context.Background(), &protog.GetCartRequest{Cart: &protog.CartRef{Id: guestCartID}})
+
+		s.Equal(status.Error(codes.NotFound, domain.ErrCartNotFound.Error()), err)
+	})
+
+	s.Run("Should return the cart with its totals", func() {
+		expiresAt := time.Date(2022, 5, 8, 10, 0, 0, 0, time.UTC)
+
+		s.app.
+			On("GetCart", mock.AnythingOfType("*context.valueCtx"), domain.CartRef{UserID: "john"}).
+			Return(domain.Cart{
+				ID:     guestCartID,
+				UserID: "john",
+				Items: []domain.LineItem{
+					{ProductID: 1, Name: "iPhone 13", Quantity: 2, UnitPrice: 79900},
+					{ProductID: 2, Name: "MacBook", Quantity: 1, UnitPrice: 129900},
+				},
+				Version:   3,
+				ExpiresAt: expiresAt,
+			}, nil)
+
+		got, err := s.grpcClient.GetCart(context.Background(), &protog.GetCartRequest{Cart: &protog.CartRef{UserId: "john"}})
+
+		s.NoError(err)
+		s.Equal(int32(289700), got.Data.Subtotal)
+		s.Require().Len(got.Data.Items, 2)
+		s.Equal(int32(159800), got.Data.Items[0].Total)
+		s.Equal(expiresAt, got.Data.ExpiresAt.AsTime())
+		s.Nil(got.Data.CreatedAt)
+	})
+}
+
+func (s *CartResolverSuite) Test_AddItem() {
+	s.Run("Should return an invalid argument error in case the quantity is invalid", func() {
+		s.app.
+			On("AddCartItem", mock.AnythingOfType("*context.valueCtx"), domain.ItemChange{ProductID: 1, Quantity: 0}).
+			Return(domain.Cart{}, domain.ErrInvalidQuantity)
+
+		_, err := s.grpcClient.AddItem(context.Background(), &protog.AddItemRequest{ProductId: 1})
+
+		s.Equal(status.Error(codes.InvalidArgument, domain.ErrInvalidQuantity.Error()), err)
+	})
+
+	s.Run("Should return a failed precondition error in case the cart is full", func() {
+		change := domain.ItemChange{Cart: domain.CartRef{ID: guestCartID}, ProductID: 2, Name: "MacBook", Quantity: 1, UnitPrice: 129900}
+
+		s.app.
+			On("AddCartItem", mock.AnythingOfType("*context.valueCtx"), change).
+			Return(domain.Cart{}, domain.ErrCartFull)
+
+		_, err := s.grpcClient.AddItem(context.Background(), &protog.AddItemRequest{
+			Cart:      &protog.CartRef{Id: guestCartID},
+			ProductId: 2,
+			Name:      "MacBook",
+			Quantity:  1,
+			UnitPrice: 129900,
+		})
+
+		s.Equal(status.Error(codes.FailedPrecondition, domain.ErrCartFull.Error()), err)
+	})
+
+	s.Run("Should return an internal server error in case of unknown errors", func() {
+		change := domain.ItemChange{ProductID: 3, Quantity: 1}
+
+		s.app.
+			On("AddCartItem", mock.AnythingOfType("*context.valueCtx"), change).
+			Return(domain.Cart{}, errors.New("connection refused"))
+
+		_, err := s.grpcClient.AddItem(context.Background(), &protog.AddItemRequest{ProductId: 3, Quantity: 1})
+
+		s.Equal(status.Error(codes.Internal, "Internal server error"), err)
+	})
+}
+
+func (s *CartResolverSuite) Test_UpdatePrices() {
+	s.Run("Should pass the price updates along", func() {
+		s.app.
+			On("UpdateCartPrices", mock.AnythingOfType("*context.valueCtx"), domain.CartRef{ID: guestCartID}, []domain.PriceUpdate{
+				{ProductID: 1, Name: "iPhone 13", UnitPrice: 74900},
+			}).
+			Return(domain.Cart{ID: guestCartID, Version: 2}, nil)
+
+		got, err := s.grpcClient.UpdatePrices(context.Background(), &protog.UpdatePricesRequest{
+			Cart:    &protog.CartRef{Id: guestCartID},
+			Updates: []*protog.PriceUpdate{{ProductId: 1, Name: "iPhone 13", UnitPrice: 74900}},
+		})
+
+		s.NoError(err)
+		s.Equal(int32(2), got.Data.Version)
+	})
+}
+
+func (s *CartResolverSuite) Test_MergeCarts() {
+	s.Run("Should return an aborted error in case the cart kept changing concurrently", func() {
+		s.app.
+			On("MergeCarts", mock.AnythingOfType("*context.valueCtx"), guestCartID, "john").
+			Return(domain.Cart{}, domain.ErrCartVersionConflict)
+
+		_, err := s.grpcClient.MergeCarts(context.Background(), &protog.MergeCartsRequest{GuestCartId: guestCartID, UserId: "john"})
+
+		s.Equal(status.Error(codes.Aborted, domain.ErrCartVersionConflict.Error()), err)
+	})
+}
+
+func TestCartResolverSuite(t *testing.T) {
+	suite.Run(t, new(CartResolverSuite))
+}
+
+func makeGrpcClientAndServer(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	tracerM trace.Tracer,
+	appM domain.Application,
+) (protog.CartServiceClient, *gGRPC.ClientConn) {
+	listener := makeGrpcServer(ctx, loggerM, tracerM, appM)
+	grpcClient, grpcConnection := makeGrpcClient(ctx, loggerM, listener)
+
+	return grpcClient, grpcConnection
+}
+
+func makeGrpcClient(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	listener *bufconn.Listener,
+) (protog.CartServiceClient, *gGRPC.ClientConn) {
+	cartServiceGRPCClient := grpc.MustNewClient(grpc.ClientInput{
+		Logger: loggerM,
+		AdditionalDialOptions: []gGRPC.DialOption{
+			gGRPC.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+				return listener.Dial()
+			}),
+		},
+	})
+
+	cartServiceConn := cartServiceGRPCClient.MustConnect(ctx)
+
+	grpcClient := protog.NewCartServiceClient(cartServiceConn)
+	return grpcClient, cartServiceConn
+}
+
+func makeGrpcServer(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	tracerM trace.Tracer,
+	appM domain.Application,
+) *bufconn.Listener {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.MustNewServer(grpc.ServerInput{
+		Port:   1234,
+		Logger: loggerM,
+		Registrator: func(server gGRPC.ServiceRegistrar) {
+			protog.RegisterCartServiceServer(server, &CartResolver{
+				Logger: loggerM,
+				Tracer: tracerM,
+				App:    appM,
+			})
+		},
+		Listener: listener,
+	})
+
+	go func() {
+		if err := grpcServer.Run(ctx); err != nil {
+			panic(err)
+		}
+	}()
+
+	return listener
+}
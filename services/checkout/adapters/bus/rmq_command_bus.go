@@ -0,0 +1,55 @@
+package bus
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	pb "github.com/lucasmls/ecommerce/services/checkout/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/shared/rmq"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// RmqCommandBus sends the Commands through the checkout exchange of RabbitMQ, routed to their Participants.
+type RmqCommandBus struct {
+	Logger    *zap.Logger
+	Tracer    trace.Tracer
+	Publisher rmq.Publisher
+}
+
+// NewRmqCommandBus creates a new RmqCommandBus.
+func NewRmqCommandBus(logger *zap.Logger, tracer trace.Tracer, publisher rmq.Publisher) *RmqCommandBus {
+	return &RmqCommandBus{
+		Logger:    logger,
+		Tracer:    tracer,
+		Publisher: publisher,
+	}
+}
+
+// Send publishes a Command, routed to its Participant
+func (b *RmqCommandBus) Send(ctx context.Context, command domain.Command) error {
+	ctx, span := b.Tracer.Start(ctx, "bus.Send")
+	defer span.End()
+
+	items := make([]*pb.Item, 0, len(command.Items))
+	for _, item := range command.Items {
+		items = append(items, &pb.Item{
+			ProductId: int32(item.ProductID),
+			Quantity:  int32(item.Quantity),
+		})
+	}
+
+	return b.Publisher.Publish(ctx, pb.Exchange, pb.CommandsRoutingKey(string(command.Participant)), &pb.Command{
+		Id:            command.ID,
+		SagaId:        command.SagaID,
+		Step:          string(command.Step),
+		Action:        string(command.Action),
+		OrderId:       int32(command.OrderID),
+		CustomerId:    command.CustomerID,
+		PaymentMethod: command.PaymentMethod,
+		Items:         items,
+		Amount:        int64(command.Amount),
+		ReservationId: int32(command.ReservationID),
+		PaymentId:     command.PaymentID,
+	})
+}
@@ -0,0 +1,73 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	pb "github.com/lucasmls/ecommerce/services/checkout/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/shared/rmq/rmqtest"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+type RmqCommandBusSuite struct {
+	suite.Suite
+
+	publisher *rmqtest.Publisher
+	bus       *RmqCommandBus
+}
+
+func (s *RmqCommandBusSuite) SetupTest() {
+	s.publisher = &rmqtest.Publisher{}
+	s.bus = NewRmqCommandBus(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), s.publisher)
+}
+
+func (s *RmqCommandBusSuite) Test_Send() {
+	s.Run("Should route the command to its participant", func() {
+		err := s.bus.Send(context.Background(), domain.Command{
+			ID:            "abc:reserve_stock:compensate:1",
+			SagaID:        "abc",
+			Participant:   domain.ParticipantInventory,
+			Step:          domain.StepReserveStock,
+			Action:        domain.ActionCompensate,
+			OrderID:       7,
+			CustomerID:    "john",
+			PaymentMethod: "fake_ok",
+			Items:         []domain.Item{{ProductID: 1, Quantity: 2}},
+			Amount:        159800,
+			ReservationID: 42,
+		})
+
+		s.NoError(err)
+		s.Equal("checkout", s.publisher.Exchange)
+		s.Equal("checkout.commands.inventory", s.publisher.RoutingKey)
+		s.True(proto.Equal(&pb.Command{
+			Id:            "abc:reserve_stock:compensate:1",
+			SagaId:        "abc",
+			Step:          "reserve_stock",
+			Action:        "compensate",
+			OrderId:       7,
+			CustomerId:    "john",
+			PaymentMethod: "fake_ok",
+			Items:         []*pb.Item{{ProductId: 1, Quantity: 2}},
+			Amount:        159800,
+			ReservationId: 42,
+		}, s.publisher.Message))
+	})
+
+	s.Run("Should fail in case the command can't be published", func() {
+		s.publisher.Err = errors.New("connection closed")
+
+		err := s.bus.Send(context.Background(), domain.Command{Participant: domain.ParticipantPayments})
+
+		s.Equal(s.publisher.Err, err)
+	})
+}
+
+func TestRmqCommandBusSuite(t *testing.T) {
+	suite.Run(t, new(RmqCommandBusSuite))
+}
@@ -0,0 +1,174 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// InMemorySagasRepository keeps the Sagas in memory.
+type InMemorySagasRepository struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	// mu guards sagas, indexed by their IDs, and orders, which maps the Order IDs to the IDs of the Sagas checking them out
+	mu     sync.Mutex
+	sagas  map[string]domain.Saga
+	orders map[int]string
+}
+
+// NewInMemorySagasRepository creates a new InMemorySagasRepository.
+func NewInMemorySagasRepository(logger *zap.Logger, tracer trace.Tracer) *InMemorySagasRepository {
+	return &InMemorySagasRepository{
+		Logger: logger,
+		Tracer: tracer,
+		sagas:  map[string]domain.Saga{},
+		orders: map[int]string{},
+	}
+}
+
+// Create stores a new Saga in memory.
+func (r *InMemorySagasRepository) Create(ctx context.Context, saga domain.Saga) (domain.Saga, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Create")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.orders[saga.OrderID]; ok {
+		return domain.Saga{}, domain.ErrSagaAlreadyExists
+	}
+
+	if _, ok := r.sagas[saga.ID]; ok {
+		return domain.Saga{}, domain.ErrSagaAlreadyExists
+	}
+
+	saga = copySaga(saga)
+	saga.Version = 1
+
+	r.sagas[saga.ID] = saga
+	r.orders[saga.OrderID] = saga.ID
+
+	return copySaga(saga), nil
+}
+
+// Get fetches a single Saga from memory.
+func (r *InMemorySagasRepository) Get(ctx context.Context, id string) (domain.Saga, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Get")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	saga, ok := r.sagas[id]
+	if !ok {
+		return domain.Saga{}, domain.ErrSagaNotFound
+	}
+
+	return copySaga(saga), nil
+}
+
+// List lists the Sagas matching the filter from memory, most recently created first.
+func (r *InMemorySagasRepository) List(ctx context.Context, filter domain.SagaFilter) ([]domain.Saga, error) {
+	_, span := r.Tracer.Start(ctx, "repository.List")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := []domain.Saga{}
+	for _, saga := range r.sagas {
+		if filter.OrderID != 0 && saga.OrderID != filter.OrderID {
+			continue
+		}
+
+		if filter.Status != "" && saga.Status != filter.Status {
+			continue
+		}
+
+		result = append(result, copySaga(saga))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.After(result[j].CreatedAt)
+		}
+
+		return result[i].ID > result[j].ID
+	})
+
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[:filter.Limit]
+	}
+
+	return result, nil
+}
+
+// ListStalled lists the unfinished Sagas that weren't updated since the given time from memory, oldest first.
+func (r *InMemorySagasRepository) ListStalled(ctx context.Context, before time.Time, limit int) ([]domain.Saga, error) {
+	_, span := r.Tracer.Start(ctx, "repository.ListStalled")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := []domain.Saga{}
+	for _, saga := range r.sagas {
+		if saga.Finished() || saga.UpdatedAt.After(before) {
+			continue
+		}
+
+		result = append(result, copySaga(saga))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].UpdatedAt.Equal(result[j].UpdatedAt) {
+			return result[i].UpdatedAt.Before(result[j].UpdatedAt)
+		}
+
+		return result[i].ID < result[j].ID
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
+
+// Update replaces a Saga in memory as long as its Version matches the stored one.
+func (r *InMemorySagasRepository) Update(ctx context.Context, saga domain.Saga) (domain.Saga, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Update")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.sagas[saga.ID]
+	if !ok {
+		return domain.Saga{}, domain.ErrSagaNotFound
+	}
+
+	if stored.Version != saga.Version {
+		return domain.Saga{}, domain.ErrSagaVersionConflict
+	}
+
+	saga = copySaga(saga)
+	saga.Version++
+
+	r.sagas[saga.ID] = saga
+
+	return copySaga(saga), nil
+}
+
+// copySaga keeps the stored Items and Steps from being changed through the Sagas handed out by the repository
+func copySaga(saga domain.Saga) domain.Saga {
+	saga.Items = append([]domain.Item{}, saga.Items...)
+	saga.Steps = append([]domain.Step{}, saga.Steps...)
+	return saga
+}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type InMemorySagasRepositorySuite struct {
+	suite.Suite
+
+	sagasRepo *InMemorySagasRepository
+	createdAt time.Time
+}
+
+func (s *InMemorySagasRepositorySuite) SetupTest() {
+	s.sagasRepo = NewInMemorySagasRepository(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""))
+	s.createdAt = time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+}
+
+func (s *InMemorySagasRepositorySuite) create(id string, orderID int, createdAt time.Time) domain.Saga {
+	saga, err := s.sagasRepo.Create(context.Background(), domain.NewSaga(id, domain.CheckoutRequest{OrderID: orderID, CustomerID: "john", PaymentMethod: "fake_ok"}, createdAt))
+	s.Require().NoError(err)
+
+	return saga
+}
+
+func (s *InMemorySagasRepositorySuite) Test_Create() {
+	s.Run("Should set the first version of the saga", func() {
+		saga := s.create("a", 1, s.createdAt)
+
+		s.Equal(1, saga.Version)
+
+		got, err := s.sagasRepo.Get(context.Background(), "a")
+		s.NoError(err)
+		s.Equal(saga, got)
+	})
+
+	s.Run("Should fail in case the order is already being checked out", func() {
+		_, err := s.sagasRepo.Create(context.Background(), domain.Saga{ID: "b", OrderID: 1})
+
+		s.Equal(domain.ErrSagaAlreadyExists, err)
+	})
+}
+
+func (s *InMemorySagasRepositorySuite) Test_Get() {
+	_, err := s.sagasRepo.Get(context.Background(), "missing")
+
+	s.Equal(domain.ErrSagaNotFound, err)
+}
+
+func (s *InMemorySagasRepositorySuite) Test_List() {
+	s.create("a", 1, s.createdAt)
+	s.create("b", 2, s.createdAt.Add(time.Hour))
+	completed := s.create("c", 3, s.createdAt.Add(2*time.Hour))
+
+	completed.Status = domain.SagaStatusCompleted
+	_, err := s.sagasRepo.Update(context.Background(), completed)
+	s.Require().NoError(err)
+
+	s.Run("Should list the most recently created first", func() {
+		got, err := s.sagasRepo.List(context.Background(), domain.SagaFilter{Limit: 2})
+
+		s.NoError(err)
+		s.Require().Len(got, 2)
+		s.Equal("c", got[0].ID)
+		s.Equal("b", got[1].ID)
+	})
+
+	s.Run("Should filter by order and status", func() {
+		got, err := s.sagasRepo.List(context.Background(), domain.SagaFilter{Status: domain.SagaStatusRunning})
+
+		s.NoError(err)
+		s.Len(got, 2)
+
+		got, err = s.sagasRepo.List(context.Background(), domain.SagaFilter{OrderID: 1})
+
+		s.NoError(err)
+		s.Require().Len(got, 1)
+		s.Equal("a", got[0].ID)
+	})
+}
+
+func (s *InMemorySagasRepositorySuite) Test_ListStalled() {
+	s.create("a", 1, s.createdAt.Add(time.Hour))
+	s.create("b", 2, s.createdAt)
+	s.create("c", 3, s.createdAt.Add(2*time.Hour))
+	finished := s.create("d", 4, s.createdAt)
+
+	finished.Status = domain.SagaStatusCompensated
+	_, err := s.sagasRepo.Update(context.Background(), finished)
+	s.Require().NoError(err)
+
+	got, err := s.sagasRepo.ListStalled(context.Background(), s.createdAt.Add(time.Hour), 10)
+
+	s.NoError(err)
+	s.Require().Len(got, 2)
+	s.Equal("b", got[0].ID)
+	s.Equal("a", got[1].ID)
+}
+
+func (s *InMemorySagasRepositorySuite) Test_Update() {
+	saga := s.create("a", 1, s.createdAt)
+
+	s.Run("Should bump the version of the saga", func() {
+		saga.Current = 1
+		updated, err := s.sagasRepo.Update(context.Background(), saga)
+
+		s.NoError(err)
+		s.Equal(2, updated.Version)
+		s.Equal(1, updated.Current)
+	})
+
+	s.Run("Should fail in case the saga changed since it was read", func() {
+		_, err := s.sagasRepo.Update(context.Background(), saga)
+
+		s.Equal(domain.ErrSagaVersionConflict, err)
+	})
+
+	s.Run("Should fail in case the saga doesn't exist", func() {
+		_, err := s.sagasRepo.Update(context.Background(), domain.Saga{ID: "missing"})
+
+		s.Equal(domain.ErrSagaNotFound, err)
+	})
+}
+
+func TestInMemorySagasRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InMemorySagasRepositorySuite))
+}
@@ -0,0 +1,293 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	"github.com/lucasmls/ecommerce/shared/pg"
+)
+
+const sagaColumns = "id, order_id, customer_id, payment_method, items, amount, reservation_id, payment_id, status, steps, current_step, failure_reason, version, created_at, updated_at"
+
+// itemRecord is how an Item is stored on the items column of a saga
+type itemRecord struct {
+	ProductID int `json:"product_id"`
+	Quantity  int `json:"quantity"`
+}
+
+// stepRecord is how a Step is stored on the steps column of a saga
+type stepRecord struct {
+	Name        string    `json:"name"`
+	Participant string    `json:"participant"`
+	Status      string    `json:"status"`
+	Attempts    int       `json:"attempts"`
+	Error       string    `json:"error,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// PgSagasRepository keeps the Sagas on Postgres, each one on a single row.
+// The rows are only updated while their version is still the one the Saga was read with,
+// so a Reply can't be handled twice.
+type PgSagasRepository struct {
+	db *sql.DB
+}
+
+func NewPgSagasRepository(connectionString string) (*PgSagasRepository, error) {
+	db, err := sql.Open(
+		"postgres",
+		connectionString,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	repository := &PgSagasRepository{
+		db: db,
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+func MustNewPgSagasRepository(connectionString string) *PgSagasRepository {
+	repo, err := NewPgSagasRepository(connectionString)
+	if err != nil {
+		panic(err)
+	}
+
+	return repo
+}
+
+// Create inserts a new Saga
+func (r *PgSagasRepository) Create(ctx context.Context, saga domain.Saga) (domain.Saga, error) {
+	items, steps, err := marshalSaga(saga)
+	if err != nil {
+		return domain.Saga{}, err
+	}
+
+	row := r.db.QueryRowContext(
+		ctx,
+		`INSERT INTO sagas (id, order_id, customer_id, payment_method, items, amount, reservation_id, payment_id, status, steps, current_step, failure_reason, version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, 1, $13, $14)
+		RETURNING `+sagaColumns,
+		saga.ID, saga.OrderID, saga.CustomerID, saga.PaymentMethod, items, saga.Amount, saga.ReservationID, saga.PaymentID,
+		string(saga.Status), steps, saga.Current, saga.FailureReason, saga.CreatedAt, saga.UpdatedAt,
+	)
+
+	created, err := scanSaga(row)
+	if pg.IsUniqueViolation(err) {
+		return domain.Saga{}, domain.ErrSagaAlreadyExists
+	}
+
+	return created, err
+}
+
+// Get fetches a single Saga
+func (r *PgSagasRepository) Get(ctx context.Context, id string) (domain.Saga, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+sagaColumns+" FROM sagas WHERE id = $1", id)
+
+	saga, err := scanSaga(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Saga{}, domain.ErrSagaNotFound
+	}
+
+	return saga, err
+}
+
+// List lists the Sagas matching the filter, most recently created first
+func (r *PgSagasRepository) List(ctx context.Context, filter domain.SagaFilter) ([]domain.Saga, error) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	if filter.OrderID != 0 {
+		args = append(args, filter.OrderID)
+		conditions = append(conditions, fmt.Sprintf("order_id = $%d", len(args)))
+	}
+
+	if filter.Status != "" {
+		args = append(args, string(filter.Status))
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	query := "SELECT " + sagaColumns + " FROM sagas"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY created_at DESC, id DESC"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	return r.query(ctx, query, args...)
+}
+
+// ListStalled lists the unfinished Sagas that weren't updated since the given time, oldest first
+func (r *PgSagasRepository) ListStalled(ctx context.Context, before time.Time, limit int) ([]domain.Saga, error) {
+	return r.query(
+		ctx,
+		`SELECT `+sagaColumns+` FROM sagas
+		WHERE status IN ('running', 'compensating') AND updated_at <= $1
+		ORDER BY updated_at, id
+		LIMIT $2`,
+		before, limit,
+	)
+}
+
+// Update replaces a Saga as long as the stored version matches
+func (r *PgSagasRepository) Update(ctx context.Context, saga domain.Saga) (domain.Saga, error) {
+	items, steps, err := marshalSaga(saga)
+	if err != nil {
+		return domain.Saga{}, err
+	}
+
+	row := r.db.QueryRowContext(
+		ctx,
+		`UPDATE sagas
+		SET items = $3, amount = $4, reservation_id = $5, payment_id = $6, status = $7, steps = $8, current_step = $9,
+			failure_reason = $10, version = version + 1, updated_at = $11
+		WHERE id = $1 AND version = $2
+		RETURNING `+sagaColumns,
+		saga.ID, saga.Version, items, saga.Amount, saga.ReservationID, saga.PaymentID, string(saga.Status), steps,
+		saga.Current, saga.FailureReason, saga.UpdatedAt,
+	)
+
+	updated, err := scanSaga(row)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return updated, err
+	}
+
+	// nothing was updated, either because the saga doesn't exist or because its version changed
+	if _, err := r.Get(ctx, saga.ID); err != nil {
+		return domain.Saga{}, err
+	}
+
+	return domain.Saga{}, domain.ErrSagaVersionConflict
+}
+
+func (r *PgSagasRepository) query(ctx context.Context, query string, args ...interface{}) ([]domain.Saga, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sagas := []domain.Saga{}
+	for rows.Next() {
+		saga, err := scanSaga(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		sagas = append(sagas, saga)
+	}
+
+	return sagas, rows.Err()
+}
+
+func marshalSaga(saga domain.Saga) ([]byte, []byte, error) {
+	itemRecords := make([]itemRecord, 0, len(saga.Items))
+	for _, item := range saga.Items {
+		itemRecords = append(itemRecords, itemRecord{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	stepRecords := make([]stepRecord, 0, len(saga.Steps))
+	for _, step := range saga.Steps {
+		stepRecords = append(stepRecords, stepRecord{
+			Name:        string(step.Name),
+			Participant: string(step.Participant),
+			Status:      string(step.Status),
+			Attempts:    step.Attempts,
+			Error:       step.Error,
+			UpdatedAt:   step.UpdatedAt,
+		})
+	}
+
+	items, err := json.Marshal(itemRecords)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	steps, err := json.Marshal(stepRecords)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return items, steps, nil
+}
+
+func scanSaga(row pg.Scanner) (domain.Saga, error) {
+	var saga domain.Saga
+	var status string
+	var items []byte
+	var steps []byte
+
+	err := row.Scan(
+		&saga.ID,
+		&saga.OrderID,
+		&saga.CustomerID,
+		&saga.PaymentMethod,
+		&items,
+		&saga.Amount,
+		&saga.ReservationID,
+		&saga.PaymentID,
+		&status,
+		&steps,
+		&saga.Current,
+		&saga.FailureReason,
+		&saga.Version,
+		&saga.CreatedAt,
+		&saga.UpdatedAt,
+	)
+	if err != nil {
+		return domain.Saga{}, err
+	}
+
+	var itemRecords []itemRecord
+	if err := json.Unmarshal(items, &itemRecords); err != nil {
+		return domain.Saga{}, err
+	}
+
+	var stepRecords []stepRecord
+	if err := json.Unmarshal(steps, &stepRecords); err != nil {
+		return domain.Saga{}, err
+	}
+
+	saga.Status = domain.SagaStatus(status)
+
+	saga.Items = make([]domain.Item, 0, len(itemRecords))
+	for _, record := range itemRecords {
+		saga.Items = append(saga.Items, domain.Item{
+			ProductID: record.ProductID,
+			Quantity:  record.Quantity,
+		})
+	}
+
+	saga.Steps = make([]domain.Step, 0, len(stepRecords))
+	for _, record := range stepRecords {
+		saga.Steps = append(saga.Steps, domain.Step{
+			Name:        domain.StepName(record.Name),
+			Participant: domain.Participant(record.Participant),
+			Status:      domain.StepStatus(record.Status),
+			Attempts:    record.Attempts,
+			Error:       record.Error,
+			UpdatedAt:   record.UpdatedAt,
+		})
+	}
+
+	return saga, nil
+}
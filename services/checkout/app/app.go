@@ -0,0 +1,51 @@
+package app
+
+import (
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// application holds all dependencies the Application needs to work
+type application struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	SagasRepository domain.SagasRepository
+	CommandBus      domain.CommandBus
+
+	// StepTimeout is how long a Command waits for its Reply before being sent again
+	StepTimeout time.Duration
+}
+
+// NewApplication creates a new Application instance
+func NewApplication(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	sagasRepository domain.SagasRepository,
+	commandBus domain.CommandBus,
+	stepTimeout time.Duration,
+) application {
+	return application{
+		Logger:          logger,
+		Tracer:          tracer,
+		SagasRepository: sagasRepository,
+		CommandBus:      commandBus,
+		StepTimeout:     stepTimeout,
+	}
+}
+
+// MustNewApplication creates a new Application instance
+// It panics if any error is found
+func MustNewApplication(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	sagasRepository domain.SagasRepository,
+	commandBus domain.CommandBus,
+	stepTimeout time.Duration,
+) application {
+	app := NewApplication(logger, tracer, sagasRepository, commandBus, stepTimeout)
+	return app
+}
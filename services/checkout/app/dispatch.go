@@ -0,0 +1,31 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	"go.uber.org/zap"
+)
+
+// dispatch persists the Saga and then sends the Command of its current step, if any.
+// The Saga is stored before the Command is sent, so a Reply never finds it behind. A Command that fails to
+// be sent is only logged, since ResumeSagas sends it again once the StepTimeout passes.
+func (a application) dispatch(ctx context.Context, saga domain.Saga) (domain.Saga, error) {
+	command, ok := saga.Dispatch(time.Now())
+
+	saga, err := a.SagasRepository.Update(ctx, saga)
+	if err != nil {
+		return domain.Saga{}, err
+	}
+
+	if !ok {
+		return saga, nil
+	}
+
+	if err := a.CommandBus.Send(ctx, command); err != nil {
+		a.Logger.Error("failed to send a command, it'll be sent again on resume", zap.Any("command", command), zap.Error(err))
+	}
+
+	return saga, nil
+}
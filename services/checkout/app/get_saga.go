@@ -0,0 +1,17 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) GetSaga(ctx context.Context, id string) (domain.Saga, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.GetSaga")
+	defer span.End()
+
+	a.Logger.Info("getting a saga", zap.String("id", id))
+
+	return a.SagasRepository.Get(ctx, id)
+}
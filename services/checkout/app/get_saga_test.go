@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	"github.com/lucasmls/ecommerce/services/checkout/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type GetSagaSuite struct {
+	suite.Suite
+
+	sagasRepo *mocks.SagasRepository
+	app       domain.Application
+}
+
+func (s *GetSagaSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.sagasRepo = &mocks.SagasRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.sagasRepo, &mocks.CommandBus{}, time.Minute)
+}
+
+func (s *GetSagaSuite) Test_GetSaga() {
+	s.Run("Should fail in case the saga doesn't exist", func() {
+		s.sagasRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "missing").Return(domain.Saga{}, domain.ErrSagaNotFound).Once()
+
+		_, err := s.app.GetSaga(context.Background(), "missing")
+
+		s.Equal(domain.ErrSagaNotFound, err)
+	})
+
+	s.Run("Should get the saga", func() {
+		saga := runningSaga(domain.StepConfirmOrder)
+
+		s.sagasRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), sagaID).Return(saga, nil).Once()
+
+		got, err := s.app.GetSaga(context.Background(), sagaID)
+
+		s.NoError(err)
+		s.Equal(saga, got)
+	})
+}
+
+func TestGetSagaSuite(t *testing.T) {
+	suite.Run(t, new(GetSagaSuite))
+}
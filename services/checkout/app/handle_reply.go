@@ -0,0 +1,50 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	"go.uber.org/zap"
+)
+
+// maxReplyAttempts bounds how many times a Reply is handled again when the Saga changes concurrently
+const maxReplyAttempts = 3
+
+func (a application) HandleReply(ctx context.Context, reply domain.Reply) (domain.Saga, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.HandleReply")
+	defer span.End()
+
+	a.Logger.Info("handling a reply", zap.Any("reply", reply))
+
+	var err error
+	for attempt := 1; attempt <= maxReplyAttempts; attempt++ {
+		var saga domain.Saga
+		saga, err = a.SagasRepository.Get(ctx, reply.SagaID)
+		if err != nil {
+			return domain.Saga{}, err
+		}
+
+		// the Reply is checked against the current step on every attempt, since a concurrent Reply
+		// may have moved the Saga past it
+		current, status := saga.Current, saga.Status
+		if err := saga.Handle(reply, time.Now()); err != nil {
+			return domain.Saga{}, err
+		}
+
+		if saga.Current == current && saga.Status == status {
+			// the step failed without failing the Saga, its Command is sent again on resume, once the StepTimeout passes
+			saga, err = a.SagasRepository.Update(ctx, saga)
+		} else {
+			saga, err = a.dispatch(ctx, saga)
+		}
+		if !errors.Is(err, domain.ErrSagaVersionConflict) {
+			return saga, err
+		}
+
+		a.Logger.Info("the saga changed while handling the reply, trying again", zap.Int("attempt", attempt))
+	}
+
+	return domain.Saga{}, err
+}
@@ -0,0 +1,216 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	"github.com/lucasmls/ecommerce/services/checkout/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type HandleReplySuite struct {
+	suite.Suite
+
+	sagasRepo  *mocks.SagasRepository
+	commandBus *mocks.CommandBus
+	app        domain.Application
+}
+
+func (s *HandleReplySuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.sagasRepo = &mocks.SagasRepository{}
+	s.commandBus = &mocks.CommandBus{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.sagasRepo, s.commandBus, time.Minute)
+}
+
+// expectUpdate stores every Saga it's given, bumping its Version
+func (s *HandleReplySuite) expectUpdate() {
+	s.sagasRepo.
+		On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Saga")).
+		Return(func(_ context.Context, saga domain.Saga) domain.Saga {
+			saga.Version++
+			return saga
+		}, nil).
+		Once()
+}
+
+func (s *HandleReplySuite) Test_HandleReply() {
+	s.Run("Should fail in case the reply isn't about the current step", func() {
+		s.SetupTest()
+
+		s.sagasRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), sagaID).Return(runningSaga(domain.StepReserveStock), nil).Once()
+
+		_, err := s.app.HandleReply(context.Background(), domain.Reply{
+			SagaID:  sagaID,
+			Step:    domain.StepSubmitOrder,
+			Action:  domain.ActionExecute,
+			Success: true,
+		})
+
+		s.Equal(domain.ErrUnexpectedReply, err)
+		s.sagasRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should record what the participant replied and run the next step", func() {
+		s.SetupTest()
+
+		s.sagasRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), sagaID).Return(runningSaga(domain.StepChargePayment), nil).Once()
+		s.expectUpdate()
+		s.commandBus.
+			On("Send", mock.AnythingOfType("*context.valueCtx"), domain.Command{
+				ID:            sagaID + ":confirm_order:execute:1",
+				SagaID:        sagaID,
+				Participant:   domain.ParticipantOrders,
+				Step:          domain.StepConfirmOrder,
+				Action:        domain.ActionExecute,
+				OrderID:       7,
+				CustomerID:    "john",
+				PaymentMethod: "fake_ok",
+				Items:         []domain.Item{{ProductID: 1, Quantity: 2}},
+				Amount:        159800,
+				ReservationID: 42,
+				PaymentID:     "pay_1",
+			}).
+			Return(nil).
+			Once()
+
+		got, err := s.app.HandleReply(context.Background(), domain.Reply{
+			SagaID:    sagaID,
+			Step:      domain.StepChargePayment,
+			Action:    domain.ActionExecute,
+			Success:   true,
+			PaymentID: "pay_1",
+		})
+
+		s.NoError(err)
+		s.Equal(domain.SagaStatusRunning, got.Status)
+		s.Equal(domain.StepStatusSucceeded, got.Steps[2].Status)
+		s.Equal("pay_1", got.PaymentID)
+		s.commandBus.AssertExpectations(s.T())
+	})
+
+	s.Run("Should undo the steps that succeeded, in the reverse order, in case a step fails", func() {
+		s.SetupTest()
+
+		s.sagasRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), sagaID).Return(runningSaga(domain.StepChargePayment), nil).Once()
+		s.expectUpdate()
+		s.commandBus.
+			On("Send", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(command domain.Command) bool {
+				return command.Step == domain.StepReserveStock &&
+					command.Action == domain.ActionCompensate &&
+					command.Participant == domain.ParticipantInventory &&
+					command.ReservationID == 42 &&
+					command.ID == sagaID+":reserve_stock:compensate:1"
+			})).
+			Return(nil).
+			Once()
+
+		got, err := s.app.HandleReply(context.Background(), domain.Reply{
+			SagaID: sagaID,
+			Step:   domain.StepChargePayment,
+			Action: domain.ActionExecute,
+			Error:  "card-declined",
+		})
+
+		s.NoError(err)
+		s.Equal(domain.SagaStatusCompensating, got.Status)
+		s.Equal("charge_payment: card-declined", got.FailureReason)
+		s.Equal(domain.StepStatusFailed, got.Steps[2].Status)
+		s.commandBus.AssertExpectations(s.T())
+	})
+
+	s.Run("Should finish the saga once the first step is compensated", func() {
+		s.SetupTest()
+
+		saga := runningSaga(domain.StepReserveStock)
+		saga.Dispatch(time.Now())
+		s.NoError(saga.Handle(domain.Reply{SagaID: sagaID, Step: domain.StepReserveStock, Action: domain.ActionExecute, Error: "out-of-stock"}, time.Now()))
+		s.Require().Equal(domain.StepSubmitOrder, saga.Steps[saga.Current].Name)
+
+		s.sagasRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), sagaID).Return(saga, nil).Once()
+		s.expectUpdate()
+
+		got, err := s.app.HandleReply(context.Background(), domain.Reply{
+			SagaID:  sagaID,
+			Step:    domain.StepSubmitOrder,
+			Action:  domain.ActionCompensate,
+			Success: true,
+		})
+
+		s.NoError(err)
+		s.Equal(domain.SagaStatusCompensated, got.Status)
+		s.Equal(domain.StepStatusCompensated, got.Steps[0].Status)
+		s.commandBus.AssertNotCalled(s.T(), "Send", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should leave a retriable step to be sent again on resume in case it fails", func() {
+		s.SetupTest()
+
+		s.sagasRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), sagaID).Return(runningSaga(domain.StepCommitStock), nil).Once()
+		s.expectUpdate()
+
+		got, err := s.app.HandleReply(context.Background(), domain.Reply{
+			SagaID: sagaID,
+			Step:   domain.StepCommitStock,
+			Action: domain.ActionExecute,
+			Error:  "connection refused",
+		})
+
+		s.NoError(err)
+		s.Equal(domain.SagaStatusRunning, got.Status)
+		s.Equal(domain.StepStatusPending, got.Steps[4].Status)
+		s.Equal("connection refused", got.Steps[4].Error)
+		s.commandBus.AssertNotCalled(s.T(), "Send", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should complete the saga once the last step succeeds", func() {
+		s.SetupTest()
+
+		s.sagasRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), sagaID).Return(runningSaga(domain.StepCommitStock), nil).Once()
+		s.expectUpdate()
+
+		got, err := s.app.HandleReply(context.Background(), domain.Reply{
+			SagaID:  sagaID,
+			Step:    domain.StepCommitStock,
+			Action:  domain.ActionExecute,
+			Success: true,
+		})
+
+		s.NoError(err)
+		s.Equal(domain.SagaStatusCompleted, got.Status)
+		s.commandBus.AssertNotCalled(s.T(), "Send", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should handle the reply again in case the saga changed concurrently", func() {
+		s.SetupTest()
+
+		s.sagasRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), sagaID).Return(runningSaga(domain.StepCommitStock), nil).Twice()
+		s.sagasRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Saga")).
+			Return(domain.Saga{}, domain.ErrSagaVersionConflict).
+			Once()
+		s.expectUpdate()
+
+		got, err := s.app.HandleReply(context.Background(), domain.Reply{
+			SagaID:  sagaID,
+			Step:    domain.StepCommitStock,
+			Action:  domain.ActionExecute,
+			Success: true,
+		})
+
+		s.NoError(err)
+		s.Equal(domain.SagaStatusCompleted, got.Status)
+	})
+}
+
+func TestHandleReplySuite(t *testing.T) {
+	suite.Run(t, new(HandleReplySuite))
+}
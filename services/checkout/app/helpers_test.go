@@ -0,0 +1,40 @@
+package app
+
+import (
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+)
+
+const sagaID = "0123456789abcdef0123456789abcdef"
+
+// runningSaga builds a Saga whose steps before the given one succeeded
+func runningSaga(step domain.StepName) domain.Saga {
+	now := time.Date(2022, 5, 8, 10, 0, 0, 0, time.UTC)
+	saga := domain.NewSaga(sagaID, domain.CheckoutRequest{OrderID: 7, CustomerID: "john", PaymentMethod: "fake_ok"}, now)
+	saga.Version = 1
+
+	replies := []domain.Reply{
+		{Step: domain.StepSubmitOrder, Items: []domain.Item{{ProductID: 1, Quantity: 2}}, Amount: 159800},
+		{Step: domain.StepReserveStock, ReservationID: 42},
+		{Step: domain.StepChargePayment, PaymentID: "pay_1"},
+		{Step: domain.StepConfirmOrder},
+	}
+
+	for _, reply := range replies {
+		if reply.Step == step {
+			break
+		}
+
+		saga.Dispatch(now)
+
+		reply.SagaID = sagaID
+		reply.Action = domain.ActionExecute
+		reply.Success = true
+		if err := saga.Handle(reply, now); err != nil {
+			panic(err)
+		}
+	}
+
+	return saga
+}
@@ -0,0 +1,25 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) ListSagas(ctx context.Context, filter domain.SagaFilter) ([]domain.Saga, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.ListSagas")
+	defer span.End()
+
+	a.Logger.Info("listing sagas", zap.Any("filter", filter))
+
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = domain.DefaultSagasLimit
+	}
+
+	return a.SagasRepository.List(ctx, filter)
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	"github.com/lucasmls/ecommerce/services/checkout/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ListSagasSuite struct {
+	suite.Suite
+
+	sagasRepo *mocks.SagasRepository
+	app       domain.Application
+}
+
+func (s *ListSagasSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.sagasRepo = &mocks.SagasRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.sagasRepo, &mocks.CommandBus{}, time.Minute)
+}
+
+func (s *ListSagasSuite) Test_ListSagas() {
+	s.Run("Should fail in case the filter is invalid", func() {
+		testCases := []domain.SagaFilter{
+			{Status: "failed"},
+			{OrderID: -1},
+			{Limit: domain.MaxSagasLimit + 1},
+		}
+
+		for _, testCase := range testCases {
+			_, err := s.app.ListSagas(context.Background(), testCase)
+
+			s.Equal(domain.ErrInvalidSagaFilter, err)
+		}
+	})
+
+	s.Run("Should default the limit", func() {
+		sagas := []domain.Saga{{ID: sagaID, OrderID: 7}}
+
+		s.sagasRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.SagaFilter{Status: domain.SagaStatusRunning, Limit: domain.DefaultSagasLimit}).
+			Return(sagas, nil).
+			Once()
+
+		got, err := s.app.ListSagas(context.Background(), domain.SagaFilter{Status: domain.SagaStatusRunning})
+
+		s.NoError(err)
+		s.Equal(sagas, got)
+	})
+}
+
+func TestListSagasSuite(t *testing.T) {
+	suite.Run(t, new(ListSagasSuite))
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	"go.uber.org/zap"
+)
+
+// resumeBatchSize is how many stalled Sagas are resumed at once, the remaining ones wait for the next run
+const resumeBatchSize = 100
+
+func (a application) ResumeSagas(ctx context.Context) (int, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.ResumeSagas")
+	defer span.End()
+
+	now := time.Now()
+
+	sagas, err := a.SagasRepository.ListStalled(ctx, now.Add(-a.StepTimeout), resumeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	resumed := 0
+	for _, saga := range sagas {
+		if saga.TimedOut() {
+			saga.Fail(domain.ErrStepTimedOut.Error(), now)
+		}
+
+		_, err := a.dispatch(ctx, saga)
+		if errors.Is(err, domain.ErrSagaVersionConflict) {
+			// a Reply moved the Saga forward in the meantime
+			continue
+		}
+
+		if err != nil {
+			return resumed, err
+		}
+
+		resumed++
+	}
+
+	if resumed > 0 {
+		a.Logger.Info("resumed stalled sagas", zap.Int("count", resumed))
+	}
+
+	return resumed, nil
+}
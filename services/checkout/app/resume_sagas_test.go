@@ -0,0 +1,128 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	"github.com/lucasmls/ecommerce/services/checkout/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ResumeSagasSuite struct {
+	suite.Suite
+
+	sagasRepo  *mocks.SagasRepository
+	commandBus *mocks.CommandBus
+	app        domain.Application
+}
+
+func (s *ResumeSagasSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.sagasRepo = &mocks.SagasRepository{}
+	s.commandBus = &mocks.CommandBus{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.sagasRepo, s.commandBus, time.Minute)
+}
+
+func (s *ResumeSagasSuite) Test_ResumeSagas() {
+	s.Run("Should only look for the sagas stalled for longer than the step timeout", func() {
+		s.SetupTest()
+
+		s.sagasRepo.
+			On("ListStalled", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(before time.Time) bool {
+				return time.Since(before) >= time.Minute && time.Since(before) < 2*time.Minute
+			}), resumeBatchSize).
+			Return(nil, nil).
+			Once()
+
+		got, err := s.app.ResumeSagas(context.Background())
+
+		s.NoError(err)
+		s.Equal(0, got)
+		s.sagasRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Should send the command of the current step again", func() {
+		s.SetupTest()
+
+		saga := runningSaga(domain.StepReserveStock)
+		saga.Dispatch(time.Now())
+
+		s.sagasRepo.On("ListStalled", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("time.Time"), resumeBatchSize).Return([]domain.Saga{saga}, nil).Once()
+		s.sagasRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Saga")).
+			Return(func(_ context.Context, saga domain.Saga) domain.Saga { return saga }, nil).
+			Once()
+		s.commandBus.
+			On("Send", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(command domain.Command) bool {
+				return command.ID == sagaID+":reserve_stock:execute:2"
+			})).
+			Return(nil).
+			Once()
+
+		got, err := s.app.ResumeSagas(context.Background())
+
+		s.NoError(err)
+		s.Equal(1, got)
+		s.commandBus.AssertExpectations(s.T())
+	})
+
+	s.Run("Should fail the step and undo it along with the saga in case it was sent too many times", func() {
+		s.SetupTest()
+
+		saga := runningSaga(domain.StepReserveStock)
+		for attempt := 0; attempt < domain.MaxStepAttempts; attempt++ {
+			saga.Dispatch(time.Now())
+		}
+
+		s.sagasRepo.On("ListStalled", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("time.Time"), resumeBatchSize).Return([]domain.Saga{saga}, nil).Once()
+		s.sagasRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(saga domain.Saga) bool {
+				return saga.Status == domain.SagaStatusCompensating &&
+					saga.FailureReason == "reserve_stock: step-timed-out" &&
+					saga.Steps[1].Status == domain.StepStatusFailed
+			})).
+			Return(func(_ context.Context, saga domain.Saga) domain.Saga { return saga }, nil).
+			Once()
+		s.commandBus.
+			On("Send", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(command domain.Command) bool {
+				return command.Step == domain.StepReserveStock && command.Action == domain.ActionCompensate
+			})).
+			Return(nil).
+			Once()
+
+		got, err := s.app.ResumeSagas(context.Background())
+
+		s.NoError(err)
+		s.Equal(1, got)
+		s.sagasRepo.AssertExpectations(s.T())
+		s.commandBus.AssertExpectations(s.T())
+	})
+
+	s.Run("Should skip the sagas moved forward concurrently", func() {
+		s.SetupTest()
+
+		s.sagasRepo.On("ListStalled", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("time.Time"), resumeBatchSize).Return([]domain.Saga{runningSaga(domain.StepChargePayment)}, nil).Once()
+		s.sagasRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Saga")).
+			Return(domain.Saga{}, domain.ErrSagaVersionConflict).
+			Once()
+
+		got, err := s.app.ResumeSagas(context.Background())
+
+		s.NoError(err)
+		s.Equal(0, got)
+		s.commandBus.AssertNotCalled(s.T(), "Send", mock.Anything, mock.Anything)
+	})
+}
+
+func TestResumeSagasSuite(t *testing.T) {
+	suite.Run(t, new(ResumeSagasSuite))
+}
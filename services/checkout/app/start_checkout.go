@@ -0,0 +1,46 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	"go.uber.org/zap"
+)
+
+// sagaIDSize is the amount of random bytes of a Saga ID
+const sagaIDSize = 16
+
+func (a application) StartCheckout(ctx context.Context, request domain.CheckoutRequest) (domain.Saga, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.StartCheckout")
+	defer span.End()
+
+	a.Logger.Info("starting a checkout", zap.Any("request", request))
+
+	if err := request.Validate(); err != nil {
+		return domain.Saga{}, err
+	}
+
+	id, err := newSagaID()
+	if err != nil {
+		return domain.Saga{}, err
+	}
+
+	saga, err := a.SagasRepository.Create(ctx, domain.NewSaga(id, request, time.Now()))
+	if err != nil {
+		return domain.Saga{}, err
+	}
+
+	return a.dispatch(ctx, saga)
+}
+
+func newSagaID() (string, error) {
+	id := make([]byte, sagaIDSize)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,137 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	"github.com/lucasmls/ecommerce/services/checkout/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type StartCheckoutSuite struct {
+	suite.Suite
+
+	sagasRepo  *mocks.SagasRepository
+	commandBus *mocks.CommandBus
+	app        domain.Application
+}
+
+func (s *StartCheckoutSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.sagasRepo = &mocks.SagasRepository{}
+	s.commandBus = &mocks.CommandBus{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.sagasRepo, s.commandBus, time.Minute)
+}
+
+func (s *StartCheckoutSuite) Test_StartCheckout() {
+	s.Run("Should fail in case the request is invalid", func() {
+		testCases := []domain.CheckoutRequest{
+			{OrderID: 0, CustomerID: "john", PaymentMethod: "fake_ok"},
+			{OrderID: 1, CustomerID: "", PaymentMethod: "fake_ok"},
+			{OrderID: 1, CustomerID: "john", PaymentMethod: ""},
+		}
+
+		for _, testCase := range testCases {
+			_, err := s.app.StartCheckout(context.Background(), testCase)
+
+			s.Equal(domain.ErrInvalidCheckout, err)
+		}
+	})
+
+	s.Run("Should fail in case the order was already checked out", func() {
+		s.sagasRepo.
+			On("Create", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(saga domain.Saga) bool {
+				return saga.OrderID == 2
+			})).
+			Return(domain.Saga{}, domain.ErrSagaAlreadyExists).
+			Once()
+
+		_, err := s.app.StartCheckout(context.Background(), domain.CheckoutRequest{OrderID: 2, CustomerID: "john", PaymentMethod: "fake_ok"})
+
+		s.Equal(domain.ErrSagaAlreadyExists, err)
+	})
+
+	s.Run("Should store the saga before sending the command of its first step", func() {
+		s.sagasRepo.
+			On("Create", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(saga domain.Saga) bool {
+				return saga.OrderID == 1
+			})).
+			Return(func(_ context.Context, saga domain.Saga) domain.Saga {
+				saga.Version = 1
+				return saga
+			}, nil).
+			Once()
+
+		s.sagasRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(saga domain.Saga) bool {
+				return saga.OrderID == 1 && saga.Steps[0].Attempts == 1
+			})).
+			Return(func(_ context.Context, saga domain.Saga) domain.Saga {
+				saga.Version++
+				return saga
+			}, nil).
+			Once()
+
+		s.commandBus.
+			On("Send", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(command domain.Command) bool {
+				return command.Participant == domain.ParticipantOrders &&
+					command.Step == domain.StepSubmitOrder &&
+					command.Action == domain.ActionExecute &&
+					command.OrderID == 1 &&
+					command.CustomerID == "john" &&
+					strings.HasSuffix(command.ID, ":submit_order:execute:1")
+			})).
+			Return(nil).
+			Once()
+
+		got, err := s.app.StartCheckout(context.Background(), domain.CheckoutRequest{OrderID: 1, CustomerID: "john", PaymentMethod: "fake_ok"})
+
+		s.NoError(err)
+		s.Len(got.ID, 32)
+		s.Equal(domain.SagaStatusRunning, got.Status)
+		s.Equal(2, got.Version)
+		s.Len(got.Steps, 5)
+	})
+
+	s.Run("Should leave the command to be sent on resume in case it can't be sent", func() {
+		s.sagasRepo.
+			On("Create", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(saga domain.Saga) bool {
+				return saga.OrderID == 3
+			})).
+			Return(func(_ context.Context, saga domain.Saga) domain.Saga { return saga }, nil).
+			Once()
+
+		s.sagasRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(saga domain.Saga) bool {
+				return saga.OrderID == 3
+			})).
+			Return(func(_ context.Context, saga domain.Saga) domain.Saga { return saga }, nil).
+			Once()
+
+		s.commandBus.
+			On("Send", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(command domain.Command) bool {
+				return command.OrderID == 3
+			})).
+			Return(errors.New("connection refused")).
+			Once()
+
+		got, err := s.app.StartCheckout(context.Background(), domain.CheckoutRequest{OrderID: 3, CustomerID: "john", PaymentMethod: "fake_ok"})
+
+		s.NoError(err)
+		s.Equal(1, got.Steps[0].Attempts)
+	})
+}
+
+func TestStartCheckoutSuite(t *testing.T) {
+	suite.Run(t, new(StartCheckoutSuite))
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/checkout/adapters/bus"
+	"github.com/lucasmls/ecommerce/services/checkout/adapters/repositories"
+	"github.com/lucasmls/ecommerce/services/checkout/app"
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	resolvers "github.com/lucasmls/ecommerce/services/checkout/ports/grpc"
+	protog "github.com/lucasmls/ecommerce/services/checkout/ports/grpc/proto"
+	consumers "github.com/lucasmls/ecommerce/services/checkout/ports/rmq"
+	rmqPb "github.com/lucasmls/ecommerce/services/checkout/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/shared/env"
+	"github.com/lucasmls/ecommerce/shared/grpc"
+	"github.com/lucasmls/ecommerce/shared/rmq"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otel "go.opentelemetry.io/otel"
+	otelJaegerExporter "go.opentelemetry.io/otel/exporters/jaeger"
+	otelPropagation "go.opentelemetry.io/otel/propagation"
+	otelSdkResource "go.opentelemetry.io/otel/sdk/resource"
+	otelTraceSdk "go.opentelemetry.io/otel/sdk/trace"
+	otelSemconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.uber.org/zap"
+	gGRPC "google.golang.org/grpc"
+)
+
+type ApplicationConfig struct {
+	ServiceName              string `mapstructure:"SERVICE_NAME"`
+	JaegerEndpoint           string `mapstructure:"JAEGER_ENDPOINT"`
+	GrpcServerPort           int    `mapstructure:"GRPC_SERVER_PORT"`
+	MetricsPort              int    `mapstructure:"METRICS_PORT"`
+	PostgresConnectionString string `mapstructure:"PG_CONNECTION_STRING"`
+	// RabbitMQURL is where the commands are sent to the participants of the saga and their replies come from
+	RabbitMQURL string `mapstructure:"RABBITMQ_URL"`
+	// StepTimeout is how long a command waits for its reply before being sent again, e.g. 30s
+	StepTimeout time.Duration `mapstructure:"STEP_TIMEOUT"`
+	// ResumeInterval is how often the stalled sagas are looked for, e.g. 10s
+	ResumeInterval time.Duration `mapstructure:"RESUME_INTERVAL"`
+}
+
+func main() {
+	ctx := context.Background()
+
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	config, err := env.LoadConfig[ApplicationConfig]()
+	if err != nil {
+		logger.Fatal("failed to load application config", zap.Error(err))
+	}
+
+	jaegerExporter, err := otelJaegerExporter.New(
+		otelJaegerExporter.WithCollectorEndpoint(
+			otelJaegerExporter.WithEndpoint(config.JaegerEndpoint),
+		),
+	)
+	if err != nil {
+		logger.Fatal("failed to instantiate Jaeger exporter", zap.Error(err))
+	}
+
+	tracingProvider := otelTraceSdk.NewTracerProvider(
+		otelTraceSdk.WithBatcher(jaegerExporter),
+		otelTraceSdk.WithSampler(otelTraceSdk.AlwaysSample()),
+		otelTraceSdk.WithResource(otelSdkResource.NewWithAttributes(
+			otelSemconv.SchemaURL,
+			otelSemconv.ServiceNameKey.String(config.ServiceName),
+		)),
+	)
+
+	defer func() {
+		_ = tracingProvider.Shutdown(ctx)
+	}()
+
+	otel.SetTracerProvider(tracingProvider)
+	otel.SetTextMapPropagator(otelPropagation.NewCompositeTextMapPropagator(
+		otelPropagation.TraceContext{},
+		otelPropagation.Baggage{},
+	))
+
+	tracer := otel.Tracer(config.ServiceName)
+
+	rmqClient := rmq.MustNewClient(rmq.ClientInput{
+		URL:    config.RabbitMQURL,
+		Logger: logger,
+	}).MustConnect()
+	defer rmqClient.Close()
+
+	if err := rmqClient.DeclareExchange(rmqPb.Exchange); err != nil {
+		logger.Fatal("failed to declare the checkout exchange", zap.Error(err))
+	}
+
+	commandBus := bus.NewRmqCommandBus(logger, tracer, rmqClient)
+
+	inMemorySagasRepository := repositories.NewInMemorySagasRepository(logger, tracer)
+	// postgresSagasRepository := repositories.MustNewPgSagasRepository(config.PostgresConnectionString)
+
+	application := app.MustNewApplication(logger, tracer, inMemorySagasRepository, commandBus, config.StepTimeout)
+	checkoutResolver := resolvers.MustNewCheckoutResolver(logger, tracer, application)
+	repliesConsumer := consumers.MustNewRepliesConsumer(logger, tracer, application)
+
+	go func() {
+		err := rmqClient.Consume(ctx, rmq.ConsumerInput{
+			Exchange:    rmqPb.Exchange,
+			Queue:       consumers.RepliesQueue,
+			RoutingKeys: []string{rmqPb.RepliesRoutingKey},
+			Handler:     repliesConsumer.Handle,
+		})
+		if err != nil {
+			logger.Fatal("failed to consume the replies", zap.Error(err))
+		}
+	}()
+
+	go resumeSagas(ctx, logger, application, config.ResumeInterval)
+
+	server := grpc.MustNewServer(grpc.ServerInput{
+		Port:   config.GrpcServerPort,
+		Logger: logger,
+		Registrator: func(server gGRPC.ServiceRegistrar) {
+			protog.RegisterCheckoutServiceServer(server, checkoutResolver)
+		},
+	})
+
+	go func() {
+		port := fmt.Sprintf(":%d", config.MetricsPort)
+
+		http.Handle("/metrics", promhttp.Handler())
+		_ = http.ListenAndServe(port, nil)
+	}()
+
+	if err := server.Run(ctx); err != nil {
+		logger.Fatal("failed to run gRPC server", zap.Error(err))
+	}
+}
+
+// resumeSagas sends again the commands left without a reply on every tick of the interval.
+// Running it on many replicas is safe, a saga is only resumed by the replica that updates it first.
+func resumeSagas(ctx context.Context, logger *zap.Logger, application domain.Application, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := application.ResumeSagas(ctx); err != nil {
+				logger.Error("failed to resume the stalled sagas", zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Application defines boundary interfaces of the application
+// It should be called by the ports
+type Application interface {
+	// StartCheckout starts a Saga checking an Order out, sending the Command of its first step.
+	// It fails with ErrSagaAlreadyExists when the Order was already checked out.
+	StartCheckout(context.Context, CheckoutRequest) (Saga, error)
+
+	// HandleReply moves a Saga forward with the Reply of a Participant, sending the Command of the next step.
+	// It fails with ErrUnexpectedReply when the Reply isn't about the current step of the Saga.
+	HandleReply(context.Context, Reply) (Saga, error)
+
+	// GetSaga fetches a single Saga
+	GetSaga(context.Context, string) (Saga, error)
+
+	// ListSagas lists the Sagas matching the filter, most recently created first
+	ListSagas(context.Context, SagaFilter) ([]Saga, error)
+
+	// ResumeSagas sends again the Commands that weren't replied in time, e.g. because a Participant or this
+	// service crashed, failing the steps sent too many times. It returns how many Sagas were resumed.
+	ResumeSagas(context.Context) (int, error)
+}
+
+type SagasRepository interface {
+	// Create stores a new Saga in a data storage, setting its first Version.
+	// It fails with ErrSagaAlreadyExists when another Saga checks the same Order out.
+	Create(context.Context, Saga) (Saga, error)
+
+	// Get fetches a single Saga from a data storage.
+	Get(context.Context, string) (Saga, error)
+
+	// List lists the Sagas matching the filter from a data storage, most recently created first, ties broken by ID.
+	List(context.Context, SagaFilter) ([]Saga, error)
+
+	// ListStalled lists, oldest first and up to the limit, the unfinished Sagas that weren't updated since the given time.
+	ListStalled(context.Context, time.Time, int) ([]Saga, error)
+
+	// Update replaces a Saga in a data storage, returning it with its new Version.
+	// It's only replaced while the stored Version is still the one it was read with, failing with
+	// ErrSagaVersionConflict otherwise, so a Reply can't be handled twice.
+	Update(context.Context, Saga) (Saga, error)
+}
+
+type CommandBus interface {
+	// Send delivers a Command to its Participant, whose Reply is handed to Application.HandleReply
+	Send(context.Context, Command) error
+}
@@ -0,0 +1,39 @@
+package domain
+
+// Command asks a Participant to run, or to compensate, a step of a Saga.
+// The Participants must handle them idempotently, since a Command is sent again when its Reply takes too long.
+type Command struct {
+	// ID identifies the attempt, e.g. <saga id>:reserve_stock:execute:2
+	ID          string
+	SagaID      string
+	Participant Participant
+	Step        StepName
+	Action      Action
+
+	OrderID       int
+	CustomerID    string
+	PaymentMethod string
+	Items         []Item
+	Amount        int
+	ReservationID int
+	PaymentID     string
+}
+
+// Reply tells how a Participant handled a Command.
+type Reply struct {
+	CommandID string
+	SagaID    string
+	Step      StepName
+	Action    Action
+	Success   bool
+	// Error is why the Command failed, empty when it succeeded
+	Error string
+
+	// Items and Amount are the snapshot of the Order, set when StepSubmitOrder succeeds
+	Items  []Item
+	Amount int
+	// ReservationID is set when StepReserveStock succeeds
+	ReservationID int
+	// PaymentID is set when StepChargePayment succeeds
+	PaymentID string
+}
@@ -0,0 +1,342 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// MaxStepAttempts is how many Commands are sent for a compensable step before giving up on it. The other
+	// steps are sent for as long as it takes, since they may have done what can't be undone anymore.
+	MaxStepAttempts = 3
+	// MaxCustomerIDLength is the maximum amount of characters of the ID of the customer checking out
+	MaxCustomerIDLength = 255
+	// MaxPaymentMethodLength is the maximum amount of characters of the payment method the Order is charged with
+	MaxPaymentMethodLength = 255
+	// DefaultSagasLimit is how many Sagas are listed when the SagaFilter has no Limit
+	DefaultSagasLimit = 20
+	// MaxSagasLimit is the maximum amount of Sagas listed at once
+	MaxSagasLimit = 100
+)
+
+var (
+	ErrSagaNotFound        = errors.New("saga-not-found")
+	ErrSagaAlreadyExists   = errors.New("saga-already-exists")
+	ErrSagaVersionConflict = errors.New("saga-version-conflict")
+	ErrInvalidCheckout     = errors.New("invalid-checkout")
+	ErrInvalidSagaFilter   = errors.New("invalid-saga-filter")
+	ErrUnexpectedReply     = errors.New("unexpected-reply")
+	ErrStepTimedOut        = errors.New("step-timed-out")
+)
+
+// SagaStatus is where a Saga stands on its lifecycle.
+type SagaStatus string
+
+const (
+	// SagaStatusRunning is the status of a Saga running its steps
+	SagaStatusRunning SagaStatus = "running"
+	// SagaStatusCompensating is the status of a Saga undoing its steps, after one of them failed
+	SagaStatusCompensating SagaStatus = "compensating"
+	// SagaStatusCompleted is the final status of a Saga whose steps all succeeded
+	SagaStatusCompleted SagaStatus = "completed"
+	// SagaStatusCompensated is the final status of a Saga that failed, once its steps were undone
+	SagaStatusCompensated SagaStatus = "compensated"
+)
+
+// Validate checks that the SagaStatus is one of the known ones
+func (s SagaStatus) Validate() error {
+	switch s {
+	case SagaStatusRunning, SagaStatusCompensating, SagaStatusCompleted, SagaStatusCompensated:
+		return nil
+	default:
+		return ErrInvalidSagaFilter
+	}
+}
+
+// Finished reports whether the Saga has nothing left to do
+func (s SagaStatus) Finished() bool {
+	return s == SagaStatusCompleted || s == SagaStatusCompensated
+}
+
+// StepStatus is where a Step of a Saga stands.
+type StepStatus string
+
+const (
+	StepStatusPending     StepStatus = "pending"
+	StepStatusSucceeded   StepStatus = "succeeded"
+	StepStatusFailed      StepStatus = "failed"
+	StepStatusCompensated StepStatus = "compensated"
+)
+
+// Action tells whether a Command runs a step or undoes it.
+type Action string
+
+const (
+	ActionExecute    Action = "execute"
+	ActionCompensate Action = "compensate"
+)
+
+// Step is the progress of a Saga on one of its steps.
+type Step struct {
+	Name        StepName
+	Participant Participant
+	Status      StepStatus
+	// Attempts counts the Commands sent for the step since it started running, or since it started being compensated
+	Attempts int
+	// Error is the last failure reported for the step
+	Error     string
+	UpdatedAt time.Time
+}
+
+// Item is an amount of units of a Product being checked out.
+type Item struct {
+	ProductID int
+	Quantity  int
+}
+
+// Saga checks an Order out: it runs the steps on their Participants by sending them Commands and
+// handling their Replies, undoing the steps in the reverse order when one of them fails.
+// It's persisted on every change, so it can be resumed from where it stopped after a crash.
+type Saga struct {
+	ID            string
+	OrderID       int
+	CustomerID    string
+	PaymentMethod string
+	// Items and Amount are a snapshot of the Order, known once StepSubmitOrder succeeds.
+	Items []Item
+	// Amount is in minor currency units (cents)
+	Amount int
+	// ReservationID is known once StepReserveStock succeeds
+	ReservationID int
+	// PaymentID is known once StepChargePayment succeeds
+	PaymentID string
+	Status    SagaStatus
+	// Steps lists every step of the Saga, in the order they run
+	Steps []Step
+	// Current is the index of the step being run, or being compensated while compensating
+	Current int
+	// FailureReason tells which step failed and why, empty unless the Saga failed
+	FailureReason string
+	// Version is incremented on every change, starting at 1 when the Saga is created.
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewSaga creates a running Saga, none of whose steps ran yet
+func NewSaga(id string, request CheckoutRequest, now time.Time) Saga {
+	sagaSteps := make([]Step, 0, len(steps))
+	for _, step := range steps {
+		sagaSteps = append(sagaSteps, Step{
+			Name:        step.name,
+			Participant: step.participant,
+			Status:      StepStatusPending,
+		})
+	}
+
+	return Saga{
+		ID:            id,
+		OrderID:       request.OrderID,
+		CustomerID:    request.CustomerID,
+		PaymentMethod: request.PaymentMethod,
+		Status:        SagaStatusRunning,
+		Steps:         sagaSteps,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// Finished reports whether the Saga has nothing left to do
+func (s Saga) Finished() bool {
+	return s.Status.Finished()
+}
+
+// TimedOut reports whether the current step is compensable and was sent too many times without a Reply
+func (s Saga) TimedOut() bool {
+	return s.Status == SagaStatusRunning && steps[s.Current].kind == compensable && s.Steps[s.Current].Attempts >= MaxStepAttempts
+}
+
+// action is what's being done to the current step
+func (s Saga) action() Action {
+	if s.Status == SagaStatusCompensating {
+		return ActionCompensate
+	}
+
+	return ActionExecute
+}
+
+// Dispatch counts a new attempt of the current step, returning the Command to be sent for it.
+// It returns false when the Saga is finished.
+func (s *Saga) Dispatch(now time.Time) (Command, bool) {
+	if s.Finished() {
+		return Command{}, false
+	}
+
+	step := &s.Steps[s.Current]
+	step.Attempts++
+	step.UpdatedAt = now
+	s.UpdatedAt = now
+
+	action := s.action()
+
+	return Command{
+		ID:            fmt.Sprintf("%s:%s:%s:%d", s.ID, step.Name, action, step.Attempts),
+		SagaID:        s.ID,
+		Participant:   step.Participant,
+		Step:          step.Name,
+		Action:        action,
+		OrderID:       s.OrderID,
+		CustomerID:    s.CustomerID,
+		PaymentMethod: s.PaymentMethod,
+		Items:         s.Items,
+		Amount:        s.Amount,
+		ReservationID: s.ReservationID,
+		PaymentID:     s.PaymentID,
+	}, true
+}
+
+// Handle moves the Saga forward with the Reply to the Command of its current step.
+// A failure of a step that may still fail the Saga starts undoing the steps that succeeded, while the failed
+// compensations and retriable steps are left as they are, to be sent again. It fails with ErrUnexpectedReply,
+// leaving the Saga untouched, when the Reply isn't about the current step, e.g. when it's duplicated.
+func (s *Saga) Handle(reply Reply, now time.Time) error {
+	if s.Finished() || reply.SagaID != s.ID {
+		return ErrUnexpectedReply
+	}
+
+	step := &s.Steps[s.Current]
+	if reply.Step != step.Name || reply.Action != s.action() {
+		return ErrUnexpectedReply
+	}
+
+	step.UpdatedAt = now
+	s.UpdatedAt = now
+
+	switch {
+	case s.Status == SagaStatusRunning && reply.Success:
+		step.Status = StepStatusSucceeded
+		step.Error = ""
+		s.record(reply)
+		s.advance()
+	case s.Status == SagaStatusRunning && steps[s.Current].kind == retriable:
+		step.Error = reply.Error
+	case s.Status == SagaStatusRunning:
+		s.fail(reply.Error)
+		s.rewind()
+	case reply.Success:
+		step.Status = StepStatusCompensated
+		step.Error = ""
+		s.rewind()
+	default:
+		step.Error = reply.Error
+	}
+
+	return nil
+}
+
+// Fail fails the current compensable step because it timed out, undoing it along with the steps that succeeded.
+// The step is undone as well since it may have run even though it wasn't replied, the Participants
+// tell apart the steps they never ran. The other steps are left as they are.
+func (s *Saga) Fail(reason string, now time.Time) {
+	if s.Status != SagaStatusRunning || steps[s.Current].kind != compensable {
+		return
+	}
+
+	step := &s.Steps[s.Current]
+	step.UpdatedAt = now
+	step.Attempts = 0
+	s.UpdatedAt = now
+	s.fail(reason)
+}
+
+// fail records the failure of the current step, the Saga starts undoing its steps
+func (s *Saga) fail(reason string) {
+	step := &s.Steps[s.Current]
+	step.Status = StepStatusFailed
+	step.Error = reason
+
+	s.Status = SagaStatusCompensating
+	s.FailureReason = fmt.Sprintf("%s: %s", step.Name, reason)
+}
+
+// record keeps what the Participant told about the step that succeeded
+func (s *Saga) record(reply Reply) {
+	switch reply.Step {
+	case StepSubmitOrder:
+		s.Items = reply.Items
+		s.Amount = reply.Amount
+	case StepReserveStock:
+		s.ReservationID = reply.ReservationID
+	case StepChargePayment:
+		s.PaymentID = reply.PaymentID
+	}
+}
+
+// advance moves to the next step, completing the Saga after the last one
+func (s *Saga) advance() {
+	if s.Current == len(s.Steps)-1 {
+		s.Status = SagaStatusCompleted
+		return
+	}
+
+	s.Current++
+}
+
+// rewind moves back to the previous compensable step that succeeded, finishing the Saga when there's none
+func (s *Saga) rewind() {
+	for i := s.Current - 1; i >= 0; i-- {
+		if steps[i].kind == compensable && s.Steps[i].Status == StepStatusSucceeded {
+			s.Current = i
+			s.Steps[i].Attempts = 0
+			return
+		}
+	}
+
+	s.Status = SagaStatusCompensated
+}
+
+// CheckoutRequest asks for an Order to be checked out.
+type CheckoutRequest struct {
+	OrderID int
+	// CustomerID must be the one who placed the Order, otherwise StepSubmitOrder fails
+	CustomerID string
+	// PaymentMethod tells the payments gateway what to charge, e.g. a tokenized card
+	PaymentMethod string
+}
+
+// Validate checks the Order, the customer and the payment method
+func (r CheckoutRequest) Validate() error {
+	if r.OrderID <= 0 || r.CustomerID == "" || len(r.CustomerID) > MaxCustomerIDLength {
+		return ErrInvalidCheckout
+	}
+
+	if r.PaymentMethod == "" || len(r.PaymentMethod) > MaxPaymentMethodLength {
+		return ErrInvalidCheckout
+	}
+
+	return nil
+}
+
+// SagaFilter narrows the Sagas down, its unset fields match every Saga.
+type SagaFilter struct {
+	OrderID int
+	Status  SagaStatus
+	// Limit bounds how many Sagas are listed, DefaultSagasLimit when unset
+	Limit int
+}
+
+// Validate checks the status and the bounds of the Limit
+func (f SagaFilter) Validate() error {
+	if f.Status != "" {
+		if err := f.Status.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if f.OrderID < 0 || f.Limit < 0 || f.Limit > MaxSagasLimit {
+		return ErrInvalidSagaFilter
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package domain
+
+// StepName identifies a step of the checkout Saga.
+type StepName string
+
+const (
+	// StepSubmitOrder submits the pending Order for payment, taking a snapshot of its items and amount.
+	// It's compensated by cancelling the Order.
+	StepSubmitOrder StepName = "submit_order"
+	// StepReserveStock holds the units of the items of the Order. It's compensated by releasing them.
+	// The inventory holds them once per Saga, so sending its Command again never holds more units.
+	StepReserveStock StepName = "reserve_stock"
+	// StepChargePayment charges the customer the amount of the Order. It's compensated by refunding it.
+	StepChargePayment StepName = "charge_payment"
+	// StepConfirmOrder records that the Order was paid. Once it succeeds the checkout can't fail anymore.
+	StepConfirmOrder StepName = "confirm_order"
+	// StepCommitStock takes the held units out of the stock. It's retried until it succeeds.
+	StepCommitStock StepName = "commit_stock"
+)
+
+// Participant is a service running steps of the checkout Saga, which receives their Commands.
+type Participant string
+
+const (
+	ParticipantOrders    Participant = "orders"
+	ParticipantInventory Participant = "inventory"
+	ParticipantPayments  Participant = "payments"
+)
+
+// stepKind tells how a failure of a step is handled
+type stepKind int
+
+const (
+	// compensable steps are undone when a later step fails, and their own failure undoes the earlier ones
+	compensable stepKind = iota
+	// the pivot step is the last one that may fail the Saga, it's never undone
+	pivot
+	// retriable steps follow the pivot, they're never undone and are retried until they succeed
+	retriable
+)
+
+// stepDefinition describes a step of the checkout Saga
+type stepDefinition struct {
+	name        StepName
+	participant Participant
+	kind        stepKind
+}
+
+// steps are the steps of the checkout Saga, in the order they run. The compensable
+// ones are undone in the reverse order when the Saga fails.
+var steps = []stepDefinition{
+	{name: StepSubmitOrder, participant: ParticipantOrders, kind: compensable},
+	{name: StepReserveStock, participant: ParticipantInventory, kind: compensable},
+	{name: StepChargePayment, participant: ParticipantPayments, kind: compensable},
+	{name: StepConfirmOrder, participant: ParticipantOrders, kind: pivot},
+	{name: StepCommitStock, participant: ParticipantInventory, kind: retriable},
+}
@@ -0,0 +1,23 @@
+-- the items and the steps are kept along with their saga, which is always read and written as a whole
+CREATE TABLE IF NOT EXISTS sagas (
+    id             TEXT PRIMARY KEY,
+    order_id       INTEGER     NOT NULL UNIQUE,
+    customer_id    TEXT        NOT NULL,
+    items          JSONB       NOT NULL DEFAULT '[]',
+    amount         INTEGER     NOT NULL DEFAULT 0,
+    reservation_id INTEGER     NOT NULL DEFAULT 0,
+    payment_id     TEXT        NOT NULL DEFAULT '',
+    status         TEXT        NOT NULL,
+    steps          JSONB       NOT NULL,
+    current_step   INTEGER     NOT NULL,
+    failure_reason TEXT        NOT NULL DEFAULT '',
+    version        INTEGER     NOT NULL,
+    created_at     TIMESTAMPTZ NOT NULL,
+    updated_at     TIMESTAMPTZ NOT NULL,
+    CONSTRAINT sagas_status_check CHECK (
+        status IN ('running', 'compensating', 'completed', 'compensated')
+    )
+);
+
+-- the unfinished sagas are looked up by how long ago they were updated, to be resumed
+CREATE INDEX sagas_stalled_idx ON sagas (updated_at) WHERE status IN ('running', 'compensating');
@@ -0,0 +1 @@
+ALTER TABLE sagas DROP COLUMN IF EXISTS payment_method;
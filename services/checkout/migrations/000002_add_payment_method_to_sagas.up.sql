@@ -0,0 +1,2 @@
+-- the sagas started before the payments took part on the checkout have no payment method
+ALTER TABLE sagas ADD COLUMN IF NOT EXISTS payment_method TEXT NOT NULL DEFAULT '';
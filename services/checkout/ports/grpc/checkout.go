@@ -0,0 +1,192 @@
+package grpc_port
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	pb "github.com/lucasmls/ecommerce/services/checkout/ports/grpc/proto"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	InternalServerError = status.Error(codes.Internal, "Internal server error")
+)
+
+var (
+	ErrMissingLogger = errors.New("missing required dependency: Logger")
+	ErrMisingTracer  = errors.New("missing required dependency: Tracer")
+)
+
+// CheckoutResolver ...
+type CheckoutResolver struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+	App    domain.Application
+
+	pb.UnimplementedCheckoutServiceServer
+}
+
+func NewCheckoutResolver(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) (*CheckoutResolver, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMisingTracer
+	}
+
+	return &CheckoutResolver{
+		Logger: logger,
+		Tracer: tracer,
+		App:    app,
+	}, nil
+}
+
+// MustNewCheckoutResolver creates a new CheckoutResolver instance.
+// It panics if any error is found
+func MustNewCheckoutResolver(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) *CheckoutResolver {
+	checkoutResolver, err := NewCheckoutResolver(logger, tracer, app)
+	if err != nil {
+		panic(err)
+	}
+
+	return checkoutResolver
+}
+
+func (r *CheckoutResolver) StartCheckout(ctx context.Context, req *pb.StartCheckoutRequest) (*pb.StartCheckoutResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.StartCheckout")
+	defer span.End()
+
+	request := domain.CheckoutRequest{
+		OrderID:       int(req.OrderId),
+		CustomerID:    req.CustomerId,
+		PaymentMethod: req.PaymentMethod,
+	}
+
+	saga, err := r.App.StartCheckout(ctx, request)
+	if err != nil {
+		return nil, r.toError(err, "failed to start the checkout", zap.Any("request", request))
+	}
+
+	return &pb.StartCheckoutResponse{Data: toProtoSaga(saga)}, nil
+}
+
+func (r *CheckoutResolver) GetSaga(ctx context.Context, req *pb.GetSagaRequest) (*pb.GetSagaResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.GetSaga")
+	defer span.End()
+
+	saga, err := r.App.GetSaga(ctx, req.Id)
+	if err != nil {
+		return nil, r.toError(err, "failed to get the saga", zap.String("id", req.Id))
+	}
+
+	return &pb.GetSagaResponse{Data: toProtoSaga(saga)}, nil
+}
+
+func (r *CheckoutResolver) ListSagas(ctx context.Context, req *pb.ListSagasRequest) (*pb.ListSagasResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.ListSagas")
+	defer span.End()
+
+	filter := domain.SagaFilter{
+		OrderID: int(req.OrderId),
+		Status:  domain.SagaStatus(req.Status),
+		Limit:   int(req.Limit),
+	}
+
+	sagas, err := r.App.ListSagas(ctx, filter)
+	if err != nil {
+		return nil, r.toError(err, "failed to list the sagas", zap.Any("filter", filter))
+	}
+
+	response := make([]*pb.Saga, 0, len(sagas))
+	for _, saga := range sagas {
+		response = append(response, toProtoSaga(saga))
+	}
+
+	return &pb.ListSagasResponse{Data: response}, nil
+}
+
+// toError reports the invalid checkouts, the missing Sagas and the checkouts started twice to the client,
+// and logs any other error, replying with an internal one
+func (r *CheckoutResolver) toError(err error, message string, fields ...zap.Field) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCheckout),
+		errors.Is(err, domain.ErrInvalidSagaFilter):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrSagaNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrSagaAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+
+	r.Logger.Error(message, append(fields, zap.Error(err))...)
+	return InternalServerError
+}
+
+func toProtoSaga(saga domain.Saga) *pb.Saga {
+	items := make([]*pb.Item, 0, len(saga.Items))
+	for _, item := range saga.Items {
+		items = append(items, &pb.Item{
+			ProductId: int32(item.ProductID),
+			Quantity:  int32(item.Quantity),
+		})
+	}
+
+	steps := make([]*pb.Step, 0, len(saga.Steps))
+	for _, step := range saga.Steps {
+		steps = append(steps, &pb.Step{
+			Name:        string(step.Name),
+			Participant: string(step.Participant),
+			Status:      string(step.Status),
+			Attempts:    int32(step.Attempts),
+			Error:       step.Error,
+			UpdatedAt:   toProtoTimestamp(step.UpdatedAt),
+		})
+	}
+
+	currentStep := ""
+	if saga.Current < len(saga.Steps) {
+		currentStep = string(saga.Steps[saga.Current].Name)
+	}
+
+	return &pb.Saga{
+		Id:            saga.ID,
+		OrderId:       int32(saga.OrderID),
+		CustomerId:    saga.CustomerID,
+		PaymentMethod: saga.PaymentMethod,
+		Items:         items,
+		Amount:        int64(saga.Amount),
+		ReservationId: int32(saga.ReservationID),
+		PaymentId:     saga.PaymentID,
+		Status:        string(saga.Status),
+		Steps:         steps,
+		CurrentStep:   currentStep,
+		FailureReason: saga.FailureReason,
+		Version:       int32(saga.Version),
+		CreatedAt:     toProtoTimestamp(saga.CreatedAt),
+		UpdatedAt:     toProtoTimestamp(saga.UpdatedAt),
+	}
+}
+
+// toProtoTimestamp leaves the zero times unset
+func toProtoTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
@@ -0,0 +1,223 @@
+package grpc_port
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/shared/grpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	gGRPC "google.golang.org/grpc"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	"github.com/lucasmls/ecommerce/services/checkout/mocks"
+	protog "github.com/lucasmls/ecommerce/services/checkout/ports/grpc/proto"
+)
+
+const sagaID = "0123456789abcdef0123456789abcdef"
+
+type CheckoutResolverSuite struct {
+	suite.Suite
+
+	app    *mocks.Application
+	logger *zap.Logger
+	tracer trace.Tracer
+
+	grpcClient     protog.CheckoutServiceClient
+	grpcConnection *gGRPC.ClientConn
+}
+
+func (s *CheckoutResolverSuite) SetupSuite() {
+	s.logger = zap.NewNop()
+	s.tracer = trace.NewNoopTracerProvider().Tracer("")
+	s.app = &mocks.Application{}
+
+	grpcClient, grpcConnection := makeGrpcClientAndServer(
+		context.Background(),
+		s.logger,
+		s.tracer,
+		s.app,
+	)
+
+	s.grpcClient = grpcClient
+	s.grpcConnection = grpcConnection
+}
+
+func (s *CheckoutResolverSuite) TearDownSuite() {
+	err := s.grpcConnection.Close()
+	s.NoError(err)
+}
+
+func (s *CheckoutResolverSuite) Test_NewCheckoutResolver() {
+	s.Run("Should fail to instantiate the CheckoutResolver in case a Logger isn't provided", func() {
+		_, err := NewCheckoutResolver(nil, nil, nil)
+
+		s.Equal(ErrMissingLogger, err)
+	})
+
+	s.Run("Should fail to instantiate the CheckoutResolver in case a Tracer isn't provided", func() {
+		_, err := NewCheckoutResolver(s.logger, nil, nil)
+
+		s.Equal(ErrMisingTracer, err)
+	})
+
+	s.Run("Should successfully instantiate the CheckoutResolver", func() {
+		_, err := NewCheckoutResolver(s.logger, s.tracer, nil)
+
+		s.NoError(err)
+	})
+}
+
+func (s *CheckoutResolverSuite) Test_StartCheckout() {
+	s.Run("Should return an invalid argument error in case the request is invalid", func() {
+		s.app.
+			On("StartCheckout", mock.AnythingOfType("*context.valueCtx"), domain.CheckoutRequest{CustomerID: "john", PaymentMethod: "fake_ok"}).
+			Return(domain.Saga{}, domain.ErrInvalidCheckout).
+			Once()
+
+		_, err := s.grpcClient.StartCheckout(context.Background(), &protog.StartCheckoutRequest{CustomerId: "john", PaymentMethod: "fake_ok"})
+
+		s.Equal(status.Error(codes.InvalidArgument, domain.ErrInvalidCheckout.Error()), err)
+	})
+
+	s.Run("Should return an already exists error in case the order was already checked out", func() {
+		s.app.
+			On("StartCheckout", mock.AnythingOfType("*context.valueCtx"), domain.CheckoutRequest{OrderID: 2, CustomerID: "john", PaymentMethod: "fake_ok"}).
+			Return(domain.Saga{}, domain.ErrSagaAlreadyExists).
+			Once()
+
+		_, err := s.grpcClient.StartCheckout(context.Background(), &protog.StartCheckoutRequest{OrderId: 2, CustomerId: "john", PaymentMethod: "fake_ok"})
+
+		s.Equal(status.Error(codes.AlreadyExists, domain.ErrSagaAlreadyExists.Error()), err)
+	})
+
+	s.Run("Should return the saga with its current step", func() {
+		createdAt := time.Date(2022, 5, 8, 10, 0, 0, 0, time.UTC)
+		saga := domain.NewSaga(sagaID, domain.CheckoutRequest{OrderID: 1, CustomerID: "john", PaymentMethod: "fake_ok"}, createdAt)
+		saga.Version = 2
+
+		s.app.
+			On("StartCheckout", mock.AnythingOfType("*context.valueCtx"), domain.CheckoutRequest{OrderID: 1, CustomerID: "john", PaymentMethod: "fake_ok"}).
+			Return(saga, nil).
+			Once()
+
+		got, err := s.grpcClient.StartCheckout(context.Background(), &protog.StartCheckoutRequest{OrderId: 1, CustomerId: "john", PaymentMethod: "fake_ok"})
+
+		s.NoError(err)
+		s.Equal(sagaID, got.Data.Id)
+		s.Equal("running", got.Data.Status)
+		s.Equal("submit_order", got.Data.CurrentStep)
+		s.Equal("fake_ok", got.Data.PaymentMethod)
+		s.Require().Len(got.Data.Steps, 5)
+		s.Equal("inventory", got.Data.Steps[1].Participant)
+		s.Nil(got.Data.Steps[0].UpdatedAt)
+		s.Equal(createdAt, got.Data.CreatedAt.AsTime())
+	})
+}
+
+func (s *CheckoutResolverSuite) Test_GetSaga() {
+	s.Run("Should return a not found error in case the saga doesn't exist", func() {
+		s.app.On("GetSaga", mock.AnythingOfType("*context.valueCtx"), "missing").Return(domain.Saga{}, domain.ErrSagaNotFound).Once()
+
+		_, err := s.grpcClient.GetSaga(context.Background(), &protog.GetSagaRequest{Id: "missing"})
+
+		s.Equal(status.Error(codes.NotFound, domain.ErrSagaNotFound.Error()), err)
+	})
+
+	s.Run("Should return an internal server error in case of unknown errors", func() {
+		s.app.On("GetSaga", mock.AnythingOfType("*context.valueCtx"), sagaID).Return(domain.Saga{}, errors.New("connection refused")).Once()
+
+		_, err := s.grpcClient.GetSaga(context.Background(), &protog.GetSagaRequest{Id: sagaID})
+
+		s.Equal(status.Error(codes.Internal, "Internal server error"), err)
+	})
+}
+
+func (s *CheckoutResolverSuite) Test_ListSagas() {
+	s.Run("Should pass the filter along", func() {
+		s.app.
+			On("ListSagas", mock.AnythingOfType("*context.valueCtx"), domain.SagaFilter{Status: domain.SagaStatusCompensated, Limit: 5}).
+			Return([]domain.Saga{{ID: sagaID, Status: domain.SagaStatusCompensated, FailureReason: "reserve_stock: out-of-stock"}}, nil).
+			Once()
+
+		got, err := s.grpcClient.ListSagas(context.Background(), &protog.ListSagasRequest{Status: "compensated", Limit: 5})
+
+		s.NoError(err)
+		s.Require().Len(got.Data, 1)
+		s.Equal("reserve_stock: out-of-stock", got.Data[0].FailureReason)
+	})
+}
+
+func TestCheckoutResolverSuite(t *testing.T) {
+	suite.Run(t, new(CheckoutResolverSuite))
+}
+
+func makeGrpcClientAndServer(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	tracerM trace.Tracer,
+	appM domain.Application,
+) (protog.CheckoutServiceClient, *gGRPC.ClientConn) {
+	listener := makeGrpcServer(ctx, loggerM, tracerM, appM)
+	grpcClient, grpcConnection := makeGrpcClient(ctx, loggerM, listener)
+
+	return grpcClient, grpcConnection
+}
+
+func makeGrpcClient(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	listener *bufconn.Listener,
+) (protog.CheckoutServiceClient, *gGRPC.ClientConn) {
+	checkoutServiceGRPCClient := grpc.MustNewClient(grpc.ClientInput{
+		Logger: loggerM,
+		AdditionalDialOptions: []gGRPC.DialOption{
+			gGRPC.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+				return listener.Dial()
+			}),
+		},
+	})
+
+	checkoutServiceConn := checkoutServiceGRPCClient.MustConnect(ctx)
+
+	grpcClient := protog.NewCheckoutServiceClient(checkoutServiceConn)
+	return grpcClient, checkoutServiceConn
+}
+
+func makeGrpcServer(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	tracerM trace.Tracer,
+	appM domain.Application,
+) *bufconn.Listener {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.MustNewServer(grpc.ServerInput{
+		Port:   1234,
+		Logger: loggerM,
+		Registrator: func(server gGRPC.ServiceRegistrar) {
+			protog.RegisterCheckoutServiceServer(server, &CheckoutResolver{
+				Logger: loggerM,
+				Tracer: tracerM,
+				App:    appM,
+			})
+		},
+		Listener: listener,
+	})
+
+	go func() {
+		if err := grpcServer.Run(ctx); err != nil {
+			panic(err)
+		}
+	}()
+
+	return listener
+}
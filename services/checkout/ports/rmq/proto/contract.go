@@ -0,0 +1,34 @@
+package rmq_protobuf
+
+const (
+	// Exchange is the topic exchange the Commands and the Replies of the checkout saga go through
+	Exchange = "checkout"
+	// RepliesRoutingKey routes the Replies of every participant to the checkout service
+	RepliesRoutingKey = "checkout.replies"
+)
+
+// The participants, the steps they run and the actions on them, as named on the Commands and the Replies
+const (
+	ParticipantOrders    = "orders"
+	ParticipantInventory = "inventory"
+	ParticipantPayments  = "payments"
+
+	StepSubmitOrder   = "submit_order"
+	StepReserveStock  = "reserve_stock"
+	StepChargePayment = "charge_payment"
+	StepConfirmOrder  = "confirm_order"
+	StepCommitStock   = "commit_stock"
+
+	ActionExecute    = "execute"
+	ActionCompensate = "compensate"
+)
+
+// CommandsRoutingKey routes the Commands to a participant, e.g. checkout.commands.inventory
+func CommandsRoutingKey(participant string) string {
+	return "checkout.commands." + participant
+}
+
+// CommandsQueue is the queue the Commands of a participant are routed to, e.g. inventory.checkout.commands
+func CommandsQueue(participant string) string {
+	return participant + ".checkout.commands"
+}
@@ -0,0 +1,108 @@
+package rmq_port
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	pb "github.com/lucasmls/ecommerce/services/checkout/ports/rmq/proto"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// RepliesQueue is the queue the Replies of every participant are routed to
+const RepliesQueue = "checkout.replies"
+
+var (
+	ErrMissingLogger = errors.New("missing required dependency: Logger")
+	ErrMisingTracer  = errors.New("missing required dependency: Tracer")
+)
+
+// RepliesConsumer hands the Replies of the participants to the Application.
+type RepliesConsumer struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+	App    domain.Application
+}
+
+func NewRepliesConsumer(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) (*RepliesConsumer, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMisingTracer
+	}
+
+	return &RepliesConsumer{
+		Logger: logger,
+		Tracer: tracer,
+		App:    app,
+	}, nil
+}
+
+// MustNewRepliesConsumer creates a new RepliesConsumer instance.
+// It panics if any error is found
+func MustNewRepliesConsumer(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) *RepliesConsumer {
+	consumer, err := NewRepliesConsumer(logger, tracer, app)
+	if err != nil {
+		panic(err)
+	}
+
+	return consumer
+}
+
+// Handle handles a Reply, it's meant to be the Handler of the rmq.Client consuming the RepliesQueue.
+// The Replies that are late or duplicated are acknowledged without doing anything, while the ones failing
+// for other reasons are dropped: the Command is sent again on resume, and replied again.
+func (c *RepliesConsumer) Handle(ctx context.Context, body []byte) error {
+	ctx, span := c.Tracer.Start(ctx, "consumer.HandleReply")
+	defer span.End()
+
+	message := &pb.Reply{}
+	if err := proto.Unmarshal(body, message); err != nil {
+		return err
+	}
+
+	reply := toReply(message)
+
+	_, err := c.App.HandleReply(ctx, reply)
+	if errors.Is(err, domain.ErrUnexpectedReply) || errors.Is(err, domain.ErrSagaNotFound) {
+		c.Logger.Info("ignoring a reply", zap.String("commandId", reply.CommandID), zap.Error(err))
+		return nil
+	}
+
+	return err
+}
+
+func toReply(message *pb.Reply) domain.Reply {
+	items := make([]domain.Item, 0, len(message.Items))
+	for _, item := range message.Items {
+		items = append(items, domain.Item{
+			ProductID: int(item.ProductId),
+			Quantity:  int(item.Quantity),
+		})
+	}
+
+	return domain.Reply{
+		CommandID:     message.CommandId,
+		SagaID:        message.SagaId,
+		Step:          domain.StepName(message.Step),
+		Action:        domain.Action(message.Action),
+		Success:       message.Success,
+		Error:         message.Error,
+		Items:         items,
+		Amount:        int(message.Amount),
+		ReservationID: int(message.ReservationId),
+		PaymentID:     message.PaymentId,
+	}
+}
@@ -0,0 +1,114 @@
+package rmq_port
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lucasmls/ecommerce/services/checkout/domain"
+	"github.com/lucasmls/ecommerce/services/checkout/mocks"
+	pb "github.com/lucasmls/ecommerce/services/checkout/ports/rmq/proto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+type RepliesConsumerSuite struct {
+	suite.Suite
+
+	app      *mocks.Application
+	consumer *RepliesConsumer
+}
+
+func (s *RepliesConsumerSuite) SetupSuite() {
+	s.app = &mocks.Application{}
+	s.consumer = MustNewRepliesConsumer(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), s.app)
+}
+
+func (s *RepliesConsumerSuite) marshal(reply *pb.Reply) []byte {
+	body, err := proto.Marshal(reply)
+	s.Require().NoError(err)
+
+	return body
+}
+
+func (s *RepliesConsumerSuite) Test_NewRepliesConsumer() {
+	s.Run("Should fail to instantiate the RepliesConsumer in case a Logger isn't provided", func() {
+		_, err := NewRepliesConsumer(nil, nil, nil)
+
+		s.Equal(ErrMissingLogger, err)
+	})
+
+	s.Run("Should fail to instantiate the RepliesConsumer in case a Tracer isn't provided", func() {
+		_, err := NewRepliesConsumer(zap.NewNop(), nil, nil)
+
+		s.Equal(ErrMisingTracer, err)
+	})
+}
+
+func (s *RepliesConsumerSuite) Test_Handle() {
+	s.Run("Should fail in case the message isn't a reply", func() {
+		err := s.consumer.Handle(context.Background(), []byte("not a reply"))
+
+		s.Error(err)
+	})
+
+	s.Run("Should hand the reply to the application", func() {
+		s.app.
+			On("HandleReply", mock.AnythingOfType("*context.valueCtx"), domain.Reply{
+				CommandID: "abc:submit_order:execute:1",
+				SagaID:    "abc",
+				Step:      domain.StepSubmitOrder,
+				Action:    domain.ActionExecute,
+				Success:   true,
+				Items:     []domain.Item{{ProductID: 1, Quantity: 2}},
+				Amount:    159800,
+			}).
+			Return(domain.Saga{}, nil).
+			Once()
+
+		err := s.consumer.Handle(context.Background(), s.marshal(&pb.Reply{
+			CommandId: "abc:submit_order:execute:1",
+			SagaId:    "abc",
+			Step:      "submit_order",
+			Action:    "execute",
+			Success:   true,
+			Items:     []*pb.Item{{ProductId: 1, Quantity: 2}},
+			Amount:    159800,
+		}))
+
+		s.NoError(err)
+	})
+
+	s.Run("Should acknowledge the late and duplicated replies", func() {
+		s.app.
+			On("HandleReply", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(reply domain.Reply) bool {
+				return reply.CommandID == "abc:submit_order:execute:2"
+			})).
+			Return(domain.Saga{}, domain.ErrUnexpectedReply).
+			Once()
+
+		err := s.consumer.Handle(context.Background(), s.marshal(&pb.Reply{CommandId: "abc:submit_order:execute:2", SagaId: "abc"}))
+
+		s.NoError(err)
+	})
+
+	s.Run("Should fail in case the reply can't be handled", func() {
+		s.app.
+			On("HandleReply", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(reply domain.Reply) bool {
+				return reply.CommandID == "abc:reserve_stock:execute:1"
+			})).
+			Return(domain.Saga{}, errors.New("connection refused")).
+			Once()
+
+		err := s.consumer.Handle(context.Background(), s.marshal(&pb.Reply{CommandId: "abc:reserve_stock:execute:1", SagaId: "abc"}))
+
+		s.EqualError(err, "connection refused")
+	})
+}
+
+func TestRepliesConsumerSuite(t *testing.T) {
+	suite.Run(t, new(RepliesConsumerSuite))
+}
@@ -0,0 +1,55 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	pb "github.com/lucasmls/ecommerce/services/customers/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/shared/rmq"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RmqEventPublisher publishes the CustomerEvents through the customers exchange of RabbitMQ, routed by their type.
+type RmqEventPublisher struct {
+	Logger    *zap.Logger
+	Tracer    trace.Tracer
+	Publisher rmq.Publisher
+}
+
+// NewRmqEventPublisher creates a new RmqEventPublisher.
+func NewRmqEventPublisher(logger *zap.Logger, tracer trace.Tracer, publisher rmq.Publisher) *RmqEventPublisher {
+	return &RmqEventPublisher{
+		Logger:    logger,
+		Tracer:    tracer,
+		Publisher: publisher,
+	}
+}
+
+// Publish publishes a CustomerEvent, routed by its type
+func (p *RmqEventPublisher) Publish(ctx context.Context, event domain.CustomerEvent) error {
+	ctx, span := p.Tracer.Start(ctx, "events.Publish")
+	defer span.End()
+
+	customer := event.Customer
+
+	return p.Publisher.Publish(ctx, pb.Exchange, pb.EventRoutingKey(string(event.Type)), &pb.CustomerEvent{
+		Type:              string(event.Type),
+		CustomerId:        customer.ID,
+		Email:             customer.Email,
+		Name:              customer.Name,
+		EmailVerified:     customer.EmailVerified,
+		VerificationToken: event.VerificationToken,
+		OccurredAt:        toProtoTimestamp(customer.UpdatedAt),
+	})
+}
+
+func toProtoTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
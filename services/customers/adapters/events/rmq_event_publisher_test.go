@@ -0,0 +1,70 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	pb "github.com/lucasmls/ecommerce/services/customers/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/shared/rmq/rmqtest"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type RmqEventPublisherSuite struct {
+	suite.Suite
+
+	publisher      *rmqtest.Publisher
+	eventPublisher *RmqEventPublisher
+}
+
+func (s *RmqEventPublisherSuite) SetupTest() {
+	s.publisher = &rmqtest.Publisher{}
+	s.eventPublisher = NewRmqEventPublisher(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), s.publisher)
+}
+
+func (s *RmqEventPublisherSuite) Test_Publish() {
+	s.Run("Should route the event by its type", func() {
+		updatedAt := time.Date(2022, 5, 10, 10, 0, 0, 0, time.UTC)
+
+		err := s.eventPublisher.Publish(context.Background(), domain.CustomerEvent{
+			Type: domain.EventRegistered,
+			Customer: domain.Customer{
+				ID:        "c1",
+				Email:     "john@example.com",
+				Name:      "John Doe",
+				UpdatedAt: updatedAt,
+			},
+			VerificationToken: "token",
+		})
+
+		s.NoError(err)
+		s.Equal("customers", s.publisher.Exchange)
+		s.Equal("customers.registered", s.publisher.RoutingKey)
+		s.True(proto.Equal(&pb.CustomerEvent{
+			Type:              "registered",
+			CustomerId:        "c1",
+			Email:             "john@example.com",
+			Name:              "John Doe",
+			VerificationToken: "token",
+			OccurredAt:        timestamppb.New(updatedAt),
+		}, s.publisher.Message))
+	})
+
+	s.Run("Should fail in case the event can't be published", func() {
+		s.publisher.Err = errors.New("connection closed")
+
+		err := s.eventPublisher.Publish(context.Background(), domain.CustomerEvent{Type: domain.EventDeleted})
+
+		s.Equal(s.publisher.Err, err)
+	})
+}
+
+func TestRmqEventPublisherSuite(t *testing.T) {
+	suite.Run(t, new(RmqEventPublisherSuite))
+}
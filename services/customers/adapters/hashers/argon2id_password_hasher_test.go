@@ -0,0 +1,81 @@
+package hashers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type Argon2idPasswordHasherSuite struct {
+	suite.Suite
+
+	hasher *Argon2idPasswordHasher
+}
+
+func (s *Argon2idPasswordHasherSuite) SetupSuite() {
+	// cheap params keep the tests fast, they're encoded along with the hashes anyway
+	s.hasher = NewArgon2idPasswordHasher(Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+}
+
+func (s *Argon2idPasswordHasherSuite) Test_Hash() {
+	s.Run("Should encode the params along with the hash", func() {
+		hash, err := s.hasher.Hash("correct horse")
+
+		s.NoError(err)
+		s.True(strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$"))
+	})
+
+	s.Run("Should salt every hash", func() {
+		first, err := s.hasher.Hash("correct horse")
+		s.Require().NoError(err)
+
+		second, err := s.hasher.Hash("correct horse")
+		s.Require().NoError(err)
+
+		s.NotEqual(first, second)
+	})
+}
+
+func (s *Argon2idPasswordHasherSuite) Test_Verify() {
+	hash, err := s.hasher.Hash("correct horse")
+	s.Require().NoError(err)
+
+	s.Run("Should match the password", func() {
+		ok, err := s.hasher.Verify("correct horse", hash)
+
+		s.NoError(err)
+		s.True(ok)
+	})
+
+	s.Run("Should not match another password", func() {
+		ok, err := s.hasher.Verify("battery staple", hash)
+
+		s.NoError(err)
+		s.False(ok)
+	})
+
+	s.Run("Should verify the hashes made with other params", func() {
+		other := NewArgon2idPasswordHasher(Argon2idParams{Memory: 128, Iterations: 2, Parallelism: 2, SaltLength: 8, KeyLength: 16})
+
+		otherHash, err := other.Hash("correct horse")
+		s.Require().NoError(err)
+
+		ok, err := s.hasher.Verify("correct horse", otherHash)
+
+		s.NoError(err)
+		s.True(ok)
+	})
+
+	s.Run("Should fail in case the hash wasn't made by the hasher", func() {
+		for _, invalid := range []string{"", "plain", "$2a$10$abcdefghijklmnopqrstuv", "$argon2i$v=19$m=64,t=1,p=1$c2FsdA$a2V5"} {
+			_, err := s.hasher.Verify("correct horse", invalid)
+
+			s.Equal(ErrInvalidHash, err, invalid)
+		}
+	})
+}
+
+func TestArgon2idPasswordHasherSuite(t *testing.T) {
+	suite.Run(t, new(Argon2idPasswordHasherSuite))
+}
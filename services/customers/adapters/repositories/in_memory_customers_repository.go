@@ -0,0 +1,166 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// InMemoryCustomersRepository keeps the Customers in memory.
+type InMemoryCustomersRepository struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	// mu guards customers, indexed by their IDs, along with emails and verifications, which map the
+	// email addresses and the hashes of the pending verification tokens to the IDs of the Customers
+	mu            sync.Mutex
+	customers     map[string]domain.Customer
+	emails        map[string]string
+	verifications map[string]string
+}
+
+// NewInMemoryCustomersRepository creates a new InMemoryCustomersRepository.
+func NewInMemoryCustomersRepository(logger *zap.Logger, tracer trace.Tracer) *InMemoryCustomersRepository {
+	return &InMemoryCustomersRepository{
+		Logger:        logger,
+		Tracer:        tracer,
+		customers:     map[string]domain.Customer{},
+		emails:        map[string]string{},
+		verifications: map[string]string{},
+	}
+}
+
+// Create stores a new Customer in memory.
+func (r *InMemoryCustomersRepository) Create(ctx context.Context, customer domain.Customer) (domain.Customer, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Create")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.emails[customer.Email]; ok {
+		return domain.Customer{}, domain.ErrEmailAlreadyRegistered
+	}
+
+	customer = copyCustomer(customer)
+	customer.Version = 1
+
+	r.store(customer)
+
+	return copyCustomer(customer), nil
+}
+
+// Get fetches a single Customer from memory.
+func (r *InMemoryCustomersRepository) Get(ctx context.Context, id string) (domain.Customer, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Get")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.get(id)
+}
+
+// GetByEmail fetches the Customer with the given email address from memory.
+func (r *InMemoryCustomersRepository) GetByEmail(ctx context.Context, email string) (domain.Customer, error) {
+	_, span := r.Tracer.Start(ctx, "repository.GetByEmail")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.get(r.emails[email])
+}
+
+// GetByVerificationToken fetches the Customer with a pending Verification of the given token hash from memory.
+func (r *InMemoryCustomersRepository) GetByVerificationToken(ctx context.Context, tokenHash string) (domain.Customer, error) {
+	_, span := r.Tracer.Start(ctx, "repository.GetByVerificationToken")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.get(r.verifications[tokenHash])
+}
+
+// Update replaces a Customer in memory as long as its Version matches the stored one.
+func (r *InMemoryCustomersRepository) Update(ctx context.Context, customer domain.Customer) (domain.Customer, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Update")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.customers[customer.ID]
+	if !ok {
+		return domain.Customer{}, domain.ErrCustomerNotFound
+	}
+
+	if stored.Version != customer.Version {
+		return domain.Customer{}, domain.ErrCustomerVersionConflict
+	}
+
+	if id, ok := r.emails[customer.Email]; ok && id != customer.ID {
+		return domain.Customer{}, domain.ErrEmailAlreadyRegistered
+	}
+
+	customer = copyCustomer(customer)
+	customer.Version++
+
+	r.unindex(stored)
+	r.store(customer)
+
+	return copyCustomer(customer), nil
+}
+
+// Delete removes a Customer from memory.
+func (r *InMemoryCustomersRepository) Delete(ctx context.Context, id string) error {
+	_, span := r.Tracer.Start(ctx, "repository.Delete")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.customers[id]
+	if !ok {
+		return domain.ErrCustomerNotFound
+	}
+
+	r.unindex(stored)
+	delete(r.customers, id)
+
+	return nil
+}
+
+func (r *InMemoryCustomersRepository) get(id string) (domain.Customer, error) {
+	customer, ok := r.customers[id]
+	if !ok {
+		return domain.Customer{}, domain.ErrCustomerNotFound
+	}
+
+	return copyCustomer(customer), nil
+}
+
+func (r *InMemoryCustomersRepository) store(customer domain.Customer) {
+	r.customers[customer.ID] = customer
+	r.emails[customer.Email] = customer.ID
+
+	if customer.Verification.TokenHash != "" {
+		r.verifications[customer.Verification.TokenHash] = customer.ID
+	}
+}
+
+// unindex forgets the email address and the pending verification of a stored Customer, they may change on the next version
+func (r *InMemoryCustomersRepository) unindex(customer domain.Customer) {
+	delete(r.emails, customer.Email)
+	delete(r.verifications, customer.Verification.TokenHash)
+}
+
+// copyCustomer keeps the stored Addresses from being changed through the Customers handed out by the repository
+func copyCustomer(customer domain.Customer) domain.Customer {
+	customer.Addresses = append([]domain.Address{}, customer.Addresses...)
+	return customer
+}
@@ -0,0 +1,159 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type InMemoryCustomersRepositorySuite struct {
+	suite.Suite
+
+	customersRepo *InMemoryCustomersRepository
+	createdAt     time.Time
+}
+
+func (s *InMemoryCustomersRepositorySuite) SetupTest() {
+	s.customersRepo = NewInMemoryCustomersRepository(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""))
+	s.createdAt = time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+}
+
+func (s *InMemoryCustomersRepositorySuite) create(id string, email string) domain.Customer {
+	customer := domain.NewCustomer(id, domain.RegistrationRequest{Email: email, Name: "John"}, "hash", s.createdAt)
+	s.Require().NoError(customer.RequestVerification("token-"+id, s.createdAt.Add(time.Hour), s.createdAt))
+
+	customer, err := s.customersRepo.Create(context.Background(), customer)
+	s.Require().NoError(err)
+
+	return customer
+}
+
+func (s *InMemoryCustomersRepositorySuite) Test_Create() {
+	s.Run("Should set the first version of the customer", func() {
+		customer := s.create("a", "john@example.com")
+
+		s.Equal(1, customer.Version)
+
+		got, err := s.customersRepo.Get(context.Background(), "a")
+		s.NoError(err)
+		s.Equal(customer, got)
+	})
+
+	s.Run("Should fail in case the email address is already registered", func() {
+		_, err := s.customersRepo.Create(context.Background(), domain.Customer{ID: "b", Email: "john@example.com"})
+
+		s.Equal(domain.ErrEmailAlreadyRegistered, err)
+	})
+}
+
+func (s *InMemoryCustomersRepositorySuite) Test_Get() {
+	customer := s.create("a", "john@example.com")
+
+	s.Run("Should find the customer by its email address", func() {
+		got, err := s.customersRepo.GetByEmail(context.Background(), "john@example.com")
+
+		s.NoError(err)
+		s.Equal(customer, got)
+	})
+
+	s.Run("Should find the customer by its pending verification", func() {
+		got, err := s.customersRepo.GetByVerificationToken(context.Background(), "token-a")
+
+		s.NoError(err)
+		s.Equal(customer, got)
+	})
+
+	s.Run("Should fail in case the customer doesn't exist", func() {
+		_, err := s.customersRepo.Get(context.Background(), "missing")
+		s.Equal(domain.ErrCustomerNotFound, err)
+
+		_, err = s.customersRepo.GetByEmail(context.Background(), "jane@example.com")
+		s.Equal(domain.ErrCustomerNotFound, err)
+	})
+
+	s.Run("Should not let the stored addresses be changed through the returned customer", func() {
+		_, err := customer.AddAddress(domain.Address{Recipient: "John", Line1: "Main St", City: "Recife", PostalCode: "50000", Country: "BR"}, s.createdAt)
+		s.Require().NoError(err)
+
+		got, err := s.customersRepo.Get(context.Background(), "a")
+
+		s.NoError(err)
+		s.Empty(got.Addresses)
+	})
+}
+
+func (s *InMemoryCustomersRepositorySuite) Test_Update() {
+	s.Run("Should replace the customer, moving its email address and verification", func() {
+		s.SetupTest()
+		customer := s.create("a", "john@example.com")
+
+		customer.UpdateProfile(domain.ProfileUpdate{Email: "johnny@example.com", Name: "Johnny"}, s.createdAt)
+		s.Require().NoError(customer.RequestVerification("token-b", s.createdAt.Add(time.Hour), s.createdAt))
+
+		got, err := s.customersRepo.Update(context.Background(), customer)
+
+		s.NoError(err)
+		s.Equal(2, got.Version)
+
+		_, err = s.customersRepo.GetByEmail(context.Background(), "john@example.com")
+		s.Equal(domain.ErrCustomerNotFound, err)
+
+		_, err = s.customersRepo.GetByVerificationToken(context.Background(), "token-a")
+		s.Equal(domain.ErrCustomerNotFound, err)
+
+		stored, err := s.customersRepo.GetByVerificationToken(context.Background(), "token-b")
+		s.NoError(err)
+		s.Equal("johnny@example.com", stored.Email)
+	})
+
+	s.Run("Should fail in case the customer changed since it was read", func() {
+		s.SetupTest()
+		customer := s.create("a", "john@example.com")
+		customer.Version = 3
+
+		_, err := s.customersRepo.Update(context.Background(), customer)
+
+		s.Equal(domain.ErrCustomerVersionConflict, err)
+	})
+
+	s.Run("Should fail in case another customer has the email address", func() {
+		s.SetupTest()
+		customer := s.create("a", "john@example.com")
+		s.create("b", "jane@example.com")
+
+		customer.Email = "jane@example.com"
+
+		_, err := s.customersRepo.Update(context.Background(), customer)
+
+		s.Equal(domain.ErrEmailAlreadyRegistered, err)
+	})
+}
+
+func (s *InMemoryCustomersRepositorySuite) Test_Delete() {
+	s.Run("Should free the email address of the deleted customer", func() {
+		s.SetupTest()
+		s.create("a", "john@example.com")
+
+		s.NoError(s.customersRepo.Delete(context.Background(), "a"))
+
+		_, err := s.customersRepo.Get(context.Background(), "a")
+		s.Equal(domain.ErrCustomerNotFound, err)
+
+		s.create("b", "john@example.com")
+	})
+
+	s.Run("Should fail in case the customer doesn't exist", func() {
+		s.SetupTest()
+
+		s.Equal(domain.ErrCustomerNotFound, s.customersRepo.Delete(context.Background(), "missing"))
+	})
+}
+
+func TestInMemoryCustomersRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InMemoryCustomersRepositorySuite))
+}
@@ -0,0 +1,237 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"github.com/lucasmls/ecommerce/shared/pg"
+)
+
+const customerColumns = "id, email, name, phone, password_hash, email_verified, verification_token_hash, verification_expires_at, addresses, next_address_id, version, created_at, updated_at"
+
+// addressRecord is how an Address is stored on the addresses column of a customer
+type addressRecord struct {
+	ID         int    `json:"id"`
+	Label      string `json:"label"`
+	Recipient  string `json:"recipient"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2"`
+	City       string `json:"city"`
+	Region     string `json:"region"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+	Phone      string `json:"phone"`
+	Default    bool   `json:"default"`
+}
+
+// PgCustomersRepository keeps the Customers on Postgres, each one on a single row.
+// The rows are only updated while their version is still the one the Customer was read with,
+// so concurrent changes to an account are never written over each other.
+type PgCustomersRepository struct {
+	db *sql.DB
+}
+
+func NewPgCustomersRepository(connectionString string) (*PgCustomersRepository, error) {
+	db, err := sql.Open(
+		"postgres",
+		connectionString,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	repository := &PgCustomersRepository{
+		db: db,
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+func MustNewPgCustomersRepository(connectionString string) *PgCustomersRepository {
+	repo, err := NewPgCustomersRepository(connectionString)
+	if err != nil {
+		panic(err)
+	}
+
+	return repo
+}
+
+// Create inserts a new Customer
+func (r *PgCustomersRepository) Create(ctx context.Context, customer domain.Customer) (domain.Customer, error) {
+	addresses, err := marshalAddresses(customer.Addresses)
+	if err != nil {
+		return domain.Customer{}, err
+	}
+
+	tokenHash, expiresAt := verificationColumns(customer.Verification)
+
+	row := r.db.QueryRowContext(
+		ctx,
+		`INSERT INTO customers (id, email, name, phone, password_hash, email_verified, verification_token_hash, verification_expires_at, addresses, next_address_id, version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, 1, $11, $12)
+		RETURNING `+customerColumns,
+		customer.ID, customer.Email, customer.Name, customer.Phone, customer.PasswordHash, customer.EmailVerified,
+		tokenHash, expiresAt, addresses, customer.NextAddressID, customer.CreatedAt, customer.UpdatedAt,
+	)
+
+	created, err := scanCustomer(row)
+	if pg.IsUniqueViolation(err) {
+		return domain.Customer{}, domain.ErrEmailAlreadyRegistered
+	}
+
+	return created, err
+}
+
+// Get fetches a single Customer
+func (r *PgCustomersRepository) Get(ctx context.Context, id string) (domain.Customer, error) {
+	return r.get(ctx, "id", id)
+}
+
+// GetByEmail fetches the Customer with the given email address
+func (r *PgCustomersRepository) GetByEmail(ctx context.Context, email string) (domain.Customer, error) {
+	return r.get(ctx, "email", email)
+}
+
+// GetByVerificationToken fetches the Customer with a pending Verification of the given token hash
+func (r *PgCustomersRepository) GetByVerificationToken(ctx context.Context, tokenHash string) (domain.Customer, error) {
+	return r.get(ctx, "verification_token_hash", tokenHash)
+}
+
+// Update replaces a Customer as long as the stored version matches
+func (r *PgCustomersRepository) Update(ctx context.Context, customer domain.Customer) (domain.Customer, error) {
+	addresses, err := marshalAddresses(customer.Addresses)
+	if err != nil {
+		return domain.Customer{}, err
+	}
+
+	tokenHash, expiresAt := verificationColumns(customer.Verification)
+
+	row := r.db.QueryRowContext(
+		ctx,
+		`UPDATE customers
+		SET email = $3, name = $4, phone = $5, password_hash = $6, email_verified = $7, verification_token_hash = $8,
+			verification_expires_at = $9, addresses = $10, next_address_id = $11, version = version + 1, updated_at = $12
+		WHERE id = $1 AND version = $2
+		RETURNING `+customerColumns,
+		customer.ID, customer.Version, customer.Email, customer.Name, customer.Phone, customer.PasswordHash,
+		customer.EmailVerified, tokenHash, expiresAt, addresses, customer.NextAddressID, customer.UpdatedAt,
+	)
+
+	updated, err := scanCustomer(row)
+	if pg.IsUniqueViolation(err) {
+		return domain.Customer{}, domain.ErrEmailAlreadyRegistered
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return updated, err
+	}
+
+	// nothing was updated, either because the customer doesn't exist or because its version changed
+	if _, err := r.Get(ctx, customer.ID); err != nil {
+		return domain.Customer{}, err
+	}
+
+	return domain.Customer{}, domain.ErrCustomerVersionConflict
+}
+
+// Delete removes a Customer
+func (r *PgCustomersRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM customers WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return domain.ErrCustomerNotFound
+	}
+
+	return nil
+}
+
+// get fetches the Customer with the given value on a unique column
+func (r *PgCustomersRepository) get(ctx context.Context, column string, value string) (domain.Customer, error) {
+	if value == "" {
+		return domain.Customer{}, domain.ErrCustomerNotFound
+	}
+
+	row := r.db.QueryRowContext(ctx, "SELECT "+customerColumns+" FROM customers WHERE "+column+" = $1", value)
+
+	customer, err := scanCustomer(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Customer{}, domain.ErrCustomerNotFound
+	}
+
+	return customer, err
+}
+
+// verificationColumns stores the lack of a pending Verification as NULLs, so the unique index on the token hash ignores it
+func verificationColumns(verification domain.Verification) (sql.NullString, sql.NullTime) {
+	if verification.TokenHash == "" {
+		return sql.NullString{}, sql.NullTime{}
+	}
+
+	return sql.NullString{String: verification.TokenHash, Valid: true}, sql.NullTime{Time: verification.ExpiresAt, Valid: true}
+}
+
+func marshalAddresses(addresses []domain.Address) ([]byte, error) {
+	records := make([]addressRecord, 0, len(addresses))
+	for _, address := range addresses {
+		records = append(records, addressRecord(address))
+	}
+
+	return json.Marshal(records)
+}
+
+func scanCustomer(row pg.Scanner) (domain.Customer, error) {
+	var customer domain.Customer
+	var tokenHash sql.NullString
+	var expiresAt sql.NullTime
+	var addresses []byte
+
+	err := row.Scan(
+		&customer.ID,
+		&customer.Email,
+		&customer.Name,
+		&customer.Phone,
+		&customer.PasswordHash,
+		&customer.EmailVerified,
+		&tokenHash,
+		&expiresAt,
+		&addresses,
+		&customer.NextAddressID,
+		&customer.Version,
+		&customer.CreatedAt,
+		&customer.UpdatedAt,
+	)
+	if err != nil {
+		return domain.Customer{}, err
+	}
+
+	var records []addressRecord
+	if err := json.Unmarshal(addresses, &records); err != nil {
+		return domain.Customer{}, err
+	}
+
+	if tokenHash.Valid {
+		customer.Verification = domain.Verification{TokenHash: tokenHash.String, ExpiresAt: expiresAt.Time}
+	}
+
+	customer.Addresses = make([]domain.Address, 0, len(records))
+	for _, record := range records {
+		customer.Addresses = append(customer.Addresses, domain.Address(record))
+	}
+
+	return customer, nil
+}
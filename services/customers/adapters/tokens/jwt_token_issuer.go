@@ -0,0 +1,81 @@
+package tokens
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"github.com/lucasmls/ecommerce/shared/auth"
+)
+
+// JwtTokenIssuer issues the AccessTokens as JWTs signed with a secret shared with the other services,
+// so they can verify them on their own.
+type JwtTokenIssuer struct {
+	secret []byte
+	ttl    time.Duration
+
+	// now tells the current time, it's replaced by the tests
+	now func() time.Time
+}
+
+// NewJwtTokenIssuer creates a new JwtTokenIssuer, whose AccessTokens last for the given ttl
+func NewJwtTokenIssuer(secret []byte, ttl time.Duration) (*JwtTokenIssuer, error) {
+	if len(secret) == 0 {
+		return nil, auth.ErrMissingSecret
+	}
+
+	if ttl <= 0 {
+		return nil, errors.New("the access token ttl should be greater than zero")
+	}
+
+	return &JwtTokenIssuer{
+		secret: secret,
+		ttl:    ttl,
+		now:    time.Now,
+	}, nil
+}
+
+// MustNewJwtTokenIssuer creates a new JwtTokenIssuer
+// It panics if any error is found
+func MustNewJwtTokenIssuer(secret []byte, ttl time.Duration) *JwtTokenIssuer {
+	issuer, err := NewJwtTokenIssuer(secret, ttl)
+	if err != nil {
+		panic(err)
+	}
+
+	return issuer
+}
+
+// Issue returns an AccessToken for the Customer
+func (i *JwtTokenIssuer) Issue(_ context.Context, customer domain.Customer) (domain.AccessToken, error) {
+	now := i.now().UTC().Truncate(time.Second)
+	expiresAt := now.Add(i.ttl)
+
+	token, err := auth.Sign(i.secret, auth.Claims{
+		Subject:       customer.ID,
+		Email:         customer.Email,
+		EmailVerified: customer.EmailVerified,
+		IssuedAt:      now,
+		ExpiresAt:     expiresAt,
+	})
+	if err != nil {
+		return domain.AccessToken{}, err
+	}
+
+	return domain.AccessToken{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// Verify returns the ID of the Customer an AccessToken was issued for
+func (i *JwtTokenIssuer) Verify(_ context.Context, token string) (string, error) {
+	claims, err := auth.Verify(i.secret, token, i.now())
+	if errors.Is(err, auth.ErrExpiredToken) {
+		return "", domain.ErrTokenExpired
+	}
+
+	if err != nil {
+		return "", domain.ErrInvalidToken
+	}
+
+	return claims.Subject, nil
+}
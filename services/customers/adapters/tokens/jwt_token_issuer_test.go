@@ -0,0 +1,85 @@
+package tokens
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"github.com/stretchr/testify/suite"
+)
+
+type JwtTokenIssuerSuite struct {
+	suite.Suite
+
+	now    time.Time
+	issuer *JwtTokenIssuer
+}
+
+func (s *JwtTokenIssuerSuite) SetupTest() {
+	s.now = time.Date(2022, 5, 10, 10, 0, 0, 0, time.UTC)
+
+	s.issuer = MustNewJwtTokenIssuer([]byte("secret"), time.Hour)
+	s.issuer.now = func() time.Time { return s.now }
+}
+
+func (s *JwtTokenIssuerSuite) Test_NewJwtTokenIssuer() {
+	s.Run("Should fail without a secret", func() {
+		_, err := NewJwtTokenIssuer(nil, time.Hour)
+		s.Error(err)
+	})
+
+	s.Run("Should fail without a ttl", func() {
+		_, err := NewJwtTokenIssuer([]byte("secret"), 0)
+		s.Error(err)
+	})
+}
+
+func (s *JwtTokenIssuerSuite) Test_IssueAndVerify() {
+	s.Run("Should verify the token it issued", func() {
+		s.SetupTest()
+
+		accessToken, err := s.issuer.Issue(context.Background(), domain.Customer{ID: "c1", Email: "john@example.com"})
+		s.NoError(err)
+		s.NotEmpty(accessToken.Token)
+		s.Equal(s.now.Add(time.Hour), accessToken.ExpiresAt)
+
+		customerID, err := s.issuer.Verify(context.Background(), accessToken.Token)
+		s.NoError(err)
+		s.Equal("c1", customerID)
+	})
+
+	s.Run("Should fail once the token expired", func() {
+		s.SetupTest()
+
+		accessToken, err := s.issuer.Issue(context.Background(), domain.Customer{ID: "c1"})
+		s.NoError(err)
+
+		s.now = s.now.Add(time.Hour)
+
+		_, err = s.issuer.Verify(context.Background(), accessToken.Token)
+		s.Equal(domain.ErrTokenExpired, err)
+	})
+
+	s.Run("Should fail with a token signed with another secret", func() {
+		s.SetupTest()
+
+		other := MustNewJwtTokenIssuer([]byte("another-secret"), time.Hour)
+		accessToken, err := other.Issue(context.Background(), domain.Customer{ID: "c1"})
+		s.NoError(err)
+
+		_, err = s.issuer.Verify(context.Background(), accessToken.Token)
+		s.Equal(domain.ErrInvalidToken, err)
+	})
+
+	s.Run("Should fail with a malformed token", func() {
+		s.SetupTest()
+
+		_, err := s.issuer.Verify(context.Background(), "not-a-token")
+		s.Equal(domain.ErrInvalidToken, err)
+	})
+}
+
+func TestJwtTokenIssuerSuite(t *testing.T) {
+	suite.Run(t, new(JwtTokenIssuerSuite))
+}
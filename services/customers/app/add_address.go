@@ -0,0 +1,25 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) AddAddress(ctx context.Context, request domain.AddressRequest) (domain.Customer, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.AddAddress")
+	defer span.End()
+
+	a.Logger.Info("adding an address", zap.String("customerId", request.CustomerID))
+
+	if err := request.Validate(); err != nil {
+		return domain.Customer{}, err
+	}
+
+	return a.update(ctx, request.CustomerID, func(customer *domain.Customer) error {
+		_, err := customer.AddAddress(request.Address, time.Now())
+		return err
+	})
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"github.com/lucasmls/ecommerce/services/customers/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type AddAddressSuite struct {
+	suite.Suite
+
+	customersRepo *mocks.CustomersRepository
+	app           domain.Application
+}
+
+func (s *AddAddressSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.customersRepo = &mocks.CustomersRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.customersRepo, &mocks.PasswordHasher{}, &mocks.TokenIssuer{}, &mocks.EventPublisher{}, verificationTTL)
+}
+
+func (s *AddAddressSuite) Test_AddAddress() {
+	s.Run("Should fail in case the address is invalid", func() {
+		s.SetupTest()
+
+		invalid := address()
+		invalid.Country = "Brazil"
+
+		_, err := s.app.AddAddress(context.Background(), domain.AddressRequest{CustomerID: customerID, Address: invalid})
+
+		s.Equal(domain.ErrInvalidAddress, err)
+		s.customersRepo.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should make the first address the default one", func() {
+		s.SetupTest()
+
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(storedCustomer(), nil).Once()
+		s.customersRepo.On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Customer")).Return(bumpVersion, nil).Once()
+
+		got, err := s.app.AddAddress(context.Background(), domain.AddressRequest{CustomerID: customerID, Address: address()})
+
+		s.NoError(err)
+		s.Len(got.Addresses, 1)
+		s.Equal(1, got.Addresses[0].ID)
+		s.True(got.Addresses[0].Default)
+		s.Equal(2, got.NextAddressID)
+	})
+
+	s.Run("Should add the address again to the latest version in case the customer changed concurrently", func() {
+		s.SetupTest()
+
+		latest := storedCustomer()
+		if _, err := latest.AddAddress(address(), latest.UpdatedAt); err != nil {
+			panic(err)
+		}
+		latest.Version = 2
+
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(storedCustomer(), nil).Once()
+		s.customersRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(customer domain.Customer) bool {
+				return customer.Version == 1
+			})).
+			Return(domain.Customer{}, domain.ErrCustomerVersionConflict).
+			Once()
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(latest, nil).Once()
+		s.customersRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(customer domain.Customer) bool {
+				return customer.Version == 2
+			})).
+			Return(bumpVersion, nil).
+			Once()
+
+		work := address()
+		work.Label = "work"
+
+		got, err := s.app.AddAddress(context.Background(), domain.AddressRequest{CustomerID: customerID, Address: work})
+
+		s.NoError(err)
+		s.Len(got.Addresses, 2)
+		s.Equal(2, got.Addresses[1].ID)
+		s.False(got.Addresses[1].Default)
+		s.Equal(3, got.Version)
+	})
+
+	s.Run("Should give up in case the customer keeps changing concurrently", func() {
+		s.SetupTest()
+
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(storedCustomer(), nil).Times(maxUpdateAttempts + 1)
+		s.customersRepo.On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Customer")).Return(domain.Customer{}, domain.ErrCustomerVersionConflict).Times(maxUpdateAttempts)
+
+		_, err := s.app.AddAddress(context.Background(), domain.AddressRequest{CustomerID: customerID, Address: address()})
+
+		s.Equal(domain.ErrCustomerVersionConflict, err)
+		s.customersRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Should fail in case the address book is full", func() {
+		s.SetupTest()
+
+		customer := storedCustomer()
+		for i := 0; i < domain.MaxAddresses; i++ {
+			if _, err := customer.AddAddress(address(), customer.UpdatedAt); err != nil {
+				panic(err)
+			}
+		}
+
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(customer, nil).Once()
+
+		_, err := s.app.AddAddress(context.Background(), domain.AddressRequest{CustomerID: customerID, Address: address()})
+
+		s.Equal(domain.ErrTooManyAddresses, err)
+		s.customersRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAddAddressSuite(t *testing.T) {
+	suite.Run(t, new(AddAddressSuite))
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// application holds all dependencies the Application needs to work
+type application struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	CustomersRepository domain.CustomersRepository
+	PasswordHasher      domain.PasswordHasher
+	TokenIssuer         domain.TokenIssuer
+	EventPublisher      domain.EventPublisher
+
+	// VerificationTTL is how long the tokens verifying the email addresses last
+	VerificationTTL time.Duration
+}
+
+// NewApplication creates a new Application instance
+func NewApplication(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	customersRepository domain.CustomersRepository,
+	passwordHasher domain.PasswordHasher,
+	tokenIssuer domain.TokenIssuer,
+	eventPublisher domain.EventPublisher,
+	verificationTTL time.Duration,
+) application {
+	return application{
+		Logger:              logger,
+		Tracer:              tracer,
+		CustomersRepository: customersRepository,
+		PasswordHasher:      passwordHasher,
+		TokenIssuer:         tokenIssuer,
+		EventPublisher:      eventPublisher,
+		VerificationTTL:     verificationTTL,
+	}
+}
+
+// MustNewApplication creates a new Application instance
+// It panics if any error is found
+func MustNewApplication(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	customersRepository domain.CustomersRepository,
+	passwordHasher domain.PasswordHasher,
+	tokenIssuer domain.TokenIssuer,
+	eventPublisher domain.EventPublisher,
+	verificationTTL time.Duration,
+) application {
+	app := NewApplication(logger, tracer, customersRepository, passwordHasher, tokenIssuer, eventPublisher, verificationTTL)
+	return app
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) Authenticate(ctx context.Context, credentials domain.Credentials) (domain.Session, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.Authenticate")
+	defer span.End()
+
+	email := domain.NormalizeEmail(credentials.Email)
+
+	a.Logger.Info("authenticating a customer", zap.String("email", email))
+
+	customer, err := a.CustomersRepository.GetByEmail(ctx, email)
+	if errors.Is(err, domain.ErrCustomerNotFound) {
+		// the password is hashed anyway, so an unknown email address takes as long to answer as a wrong password
+		_, _ = a.PasswordHasher.Hash(credentials.Password)
+		return domain.Session{}, domain.ErrInvalidCredentials
+	}
+
+	if err != nil {
+		return domain.Session{}, err
+	}
+
+	if err := a.checkPassword(customer, credentials.Password); err != nil {
+		return domain.Session{}, err
+	}
+
+	accessToken, err := a.TokenIssuer.Issue(ctx, customer)
+	if err != nil {
+		return domain.Session{}, err
+	}
+
+	return domain.Session{Customer: customer, AccessToken: accessToken}, nil
+}
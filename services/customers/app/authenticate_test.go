@@ -0,0 +1,81 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"github.com/lucasmls/ecommerce/services/customers/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type AuthenticateSuite struct {
+	suite.Suite
+
+	customersRepo  *mocks.CustomersRepository
+	passwordHasher *mocks.PasswordHasher
+	tokenIssuer    *mocks.TokenIssuer
+	app            domain.Application
+}
+
+func (s *AuthenticateSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.customersRepo = &mocks.CustomersRepository{}
+	s.passwordHasher = &mocks.PasswordHasher{}
+	s.tokenIssuer = &mocks.TokenIssuer{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.customersRepo, s.passwordHasher, s.tokenIssuer, &mocks.EventPublisher{}, verificationTTL)
+}
+
+func (s *AuthenticateSuite) Test_Authenticate() {
+	credentials := domain.Credentials{Email: " John@Example.com ", Password: "correct horse battery staple"}
+
+	s.Run("Should fail with invalid credentials in case the email address is unknown, hashing the password anyway", func() {
+		s.SetupTest()
+
+		s.customersRepo.On("GetByEmail", mock.AnythingOfType("*context.valueCtx"), "john@example.com").Return(domain.Customer{}, domain.ErrCustomerNotFound).Once()
+		s.passwordHasher.On("Hash", "correct horse battery staple").Return(passwordHash, nil).Once()
+
+		_, err := s.app.Authenticate(context.Background(), credentials)
+
+		s.Equal(domain.ErrInvalidCredentials, err)
+		s.passwordHasher.AssertExpectations(s.T())
+	})
+
+	s.Run("Should fail with invalid credentials in case the password is wrong", func() {
+		s.SetupTest()
+
+		s.customersRepo.On("GetByEmail", mock.AnythingOfType("*context.valueCtx"), "john@example.com").Return(storedCustomer(), nil).Once()
+		s.passwordHasher.On("Verify", "correct horse battery staple", passwordHash).Return(false, nil).Once()
+
+		_, err := s.app.Authenticate(context.Background(), credentials)
+
+		s.Equal(domain.ErrInvalidCredentials, err)
+		s.tokenIssuer.AssertNotCalled(s.T(), "Issue", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should issue an access token to the customer", func() {
+		s.SetupTest()
+
+		accessToken := domain.AccessToken{Token: "token", ExpiresAt: time.Date(2022, 5, 10, 11, 0, 0, 0, time.UTC)}
+
+		s.customersRepo.On("GetByEmail", mock.AnythingOfType("*context.valueCtx"), "john@example.com").Return(storedCustomer(), nil).Once()
+		s.passwordHasher.On("Verify", "correct horse battery staple", passwordHash).Return(true, nil).Once()
+		s.tokenIssuer.On("Issue", mock.AnythingOfType("*context.valueCtx"), storedCustomer()).Return(accessToken, nil).Once()
+
+		got, err := s.app.Authenticate(context.Background(), credentials)
+
+		s.NoError(err)
+		s.Equal(domain.Session{Customer: storedCustomer(), AccessToken: accessToken}, got)
+	})
+}
+
+func TestAuthenticateSuite(t *testing.T) {
+	suite.Run(t, new(AuthenticateSuite))
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) ChangePassword(ctx context.Context, change domain.PasswordChange) (domain.Customer, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.ChangePassword")
+	defer span.End()
+
+	a.Logger.Info("changing the password of a customer", zap.String("id", change.CustomerID))
+
+	if err := change.Validate(); err != nil {
+		return domain.Customer{}, err
+	}
+
+	customer, err := a.CustomersRepository.Get(ctx, change.CustomerID)
+	if err != nil {
+		return domain.Customer{}, err
+	}
+
+	if err := a.checkPassword(customer, change.CurrentPassword); err != nil {
+		return domain.Customer{}, err
+	}
+
+	passwordHash, err := a.PasswordHasher.Hash(change.NewPassword)
+	if err != nil {
+		return domain.Customer{}, err
+	}
+
+	return a.update(ctx, change.CustomerID, func(customer *domain.Customer) error {
+		customer.SetPassword(passwordHash, time.Now())
+		return nil
+	})
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"github.com/lucasmls/ecommerce/services/customers/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ChangePasswordSuite struct {
+	suite.Suite
+
+	customersRepo  *mocks.CustomersRepository
+	passwordHasher *mocks.PasswordHasher
+	app            domain.Application
+}
+
+func (s *ChangePasswordSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.customersRepo = &mocks.CustomersRepository{}
+	s.passwordHasher = &mocks.PasswordHasher{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.customersRepo, s.passwordHasher, &mocks.TokenIssuer{}, &mocks.EventPublisher{}, verificationTTL)
+}
+
+func (s *ChangePasswordSuite) Test_ChangePassword() {
+	change := domain.PasswordChange{
+		CustomerID:      customerID,
+		CurrentPassword: "correct horse battery staple",
+		NewPassword:     "another horse battery staple",
+	}
+
+	s.Run("Should fail in case the new password is too short", func() {
+		s.SetupTest()
+
+		_, err := s.app.ChangePassword(context.Background(), domain.PasswordChange{CustomerID: customerID, NewPassword: "short"})
+
+		s.Equal(domain.ErrInvalidPassword, err)
+	})
+
+	s.Run("Should fail in case the current password is wrong", func() {
+		s.SetupTest()
+
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(storedCustomer(), nil).Once()
+		s.passwordHasher.On("Verify", "correct horse battery staple", passwordHash).Return(false, nil).Once()
+
+		_, err := s.app.ChangePassword(context.Background(), change)
+
+		s.Equal(domain.ErrInvalidCredentials, err)
+		s.customersRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should store the hash of the new password", func() {
+		s.SetupTest()
+
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(storedCustomer(), nil).Twice()
+		s.passwordHasher.On("Verify", "correct horse battery staple", passwordHash).Return(true, nil).Once()
+		s.passwordHasher.On("Hash", "another horse battery staple").Return("new-hash", nil).Once()
+		s.customersRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(customer domain.Customer) bool {
+				return customer.PasswordHash == "new-hash"
+			})).
+			Return(bumpVersion, nil).
+			Once()
+
+		got, err := s.app.ChangePassword(context.Background(), change)
+
+		s.NoError(err)
+		s.Equal("new-hash", got.PasswordHash)
+		s.Equal(2, got.Version)
+	})
+}
+
+func TestChangePasswordSuite(t *testing.T) {
+	suite.Run(t, new(ChangePasswordSuite))
+}
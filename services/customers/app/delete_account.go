@@ -0,0 +1,36 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) DeleteAccount(ctx context.Context, deletion domain.AccountDeletion) error {
+	ctx, span := a.Tracer.Start(ctx, "app.DeleteAccount")
+	defer span.End()
+
+	a.Logger.Info("deleting the account of a customer", zap.String("id", deletion.CustomerID))
+
+	if deletion.CustomerID == "" {
+		return domain.ErrCustomerNotFound
+	}
+
+	customer, err := a.CustomersRepository.Get(ctx, deletion.CustomerID)
+	if err != nil {
+		return err
+	}
+
+	if err := a.checkPassword(customer, deletion.Password); err != nil {
+		return err
+	}
+
+	if err := a.CustomersRepository.Delete(ctx, customer.ID); err != nil {
+		return err
+	}
+
+	a.publish(ctx, domain.EventDeleted, customer, "")
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"github.com/lucasmls/ecommerce/services/customers/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type DeleteAccountSuite struct {
+	suite.Suite
+
+	customersRepo  *mocks.CustomersRepository
+	passwordHasher *mocks.PasswordHasher
+	eventPublisher *mocks.EventPublisher
+	app            domain.Application
+}
+
+func (s *DeleteAccountSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.customersRepo = &mocks.CustomersRepository{}
+	s.passwordHasher = &mocks.PasswordHasher{}
+	s.eventPublisher = &mocks.EventPublisher{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.customersRepo, s.passwordHasher, &mocks.TokenIssuer{}, s.eventPublisher, verificationTTL)
+}
+
+func (s *DeleteAccountSuite) Test_DeleteAccount() {
+	deletion := domain.AccountDeletion{CustomerID: customerID, Password: "correct horse battery staple"}
+
+	s.Run("Should fail in case the password is wrong", func() {
+		s.SetupTest()
+
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(storedCustomer(), nil).Once()
+		s.passwordHasher.On("Verify", "correct horse battery staple", passwordHash).Return(false, nil).Once()
+
+		err := s.app.DeleteAccount(context.Background(), deletion)
+
+		s.Equal(domain.ErrInvalidCredentials, err)
+		s.customersRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should delete the customer and tell the other services", func() {
+		s.SetupTest()
+
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(storedCustomer(), nil).Once()
+		s.passwordHasher.On("Verify", "correct horse battery staple", passwordHash).Return(true, nil).Once()
+		s.customersRepo.On("Delete", mock.AnythingOfType("*context.valueCtx"), customerID).Return(nil).Once()
+		s.eventPublisher.
+			On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(event domain.CustomerEvent) bool {
+				return event.Type == domain.EventDeleted && event.Customer.ID == customerID
+			})).
+			Return(nil).
+			Once()
+
+		err := s.app.DeleteAccount(context.Background(), deletion)
+
+		s.NoError(err)
+		s.customersRepo.AssertExpectations(s.T())
+		s.eventPublisher.AssertExpectations(s.T())
+	})
+
+	s.Run("Should fail in case the customer doesn't exist", func() {
+		s.SetupTest()
+
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(domain.Customer{}, domain.ErrCustomerNotFound).Once()
+
+		err := s.app.DeleteAccount(context.Background(), deletion)
+
+		s.Equal(domain.ErrCustomerNotFound, err)
+	})
+}
+
+func TestDeleteAccountSuite(t *testing.T) {
+	suite.Run(t, new(DeleteAccountSuite))
+}
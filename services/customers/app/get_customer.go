@@ -0,0 +1,21 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) GetCustomer(ctx context.Context, id string) (domain.Customer, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.GetCustomer")
+	defer span.End()
+
+	a.Logger.Info("getting a customer", zap.String("id", id))
+
+	if id == "" {
+		return domain.Customer{}, domain.ErrCustomerNotFound
+	}
+
+	return a.CustomersRepository.Get(ctx, id)
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"github.com/lucasmls/ecommerce/services/customers/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type GetCustomerSuite struct {
+	suite.Suite
+
+	customersRepo *mocks.CustomersRepository
+	app           domain.Application
+}
+
+func (s *GetCustomerSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.customersRepo = &mocks.CustomersRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.customersRepo, &mocks.PasswordHasher{}, &mocks.TokenIssuer{}, &mocks.EventPublisher{}, verificationTTL)
+}
+
+func (s *GetCustomerSuite) Test_GetCustomer() {
+	s.Run("Should fail without reaching the repository in case the id is empty", func() {
+		_, err := s.app.GetCustomer(context.Background(), "")
+
+		s.Equal(domain.ErrCustomerNotFound, err)
+		s.customersRepo.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the customer doesn't exist", func() {
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(domain.Customer{}, domain.ErrCustomerNotFound).Once()
+
+		_, err := s.app.GetCustomer(context.Background(), customerID)
+
+		s.Equal(domain.ErrCustomerNotFound, err)
+	})
+
+	s.Run("Should return the customer", func() {
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(storedCustomer(), nil).Once()
+
+		got, err := s.app.GetCustomer(context.Background(), customerID)
+
+		s.NoError(err)
+		s.Equal(storedCustomer(), got)
+	})
+}
+
+func TestGetCustomerSuite(t *testing.T) {
+	suite.Run(t, new(GetCustomerSuite))
+}
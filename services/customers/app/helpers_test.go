@@ -0,0 +1,61 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+)
+
+const (
+	customerID   = "0123456789abcdef0123456789abcdef"
+	passwordHash = "$argon2id$v=19$m=65536,t=3,p=4$c2FsdA$aGFzaA"
+	// verificationToken is the token of the pending Verification of the storedCustomer
+	verificationToken = "verification-token"
+	verificationTTL   = 24 * time.Hour
+)
+
+// verificationExpiresAt is when the pending Verification of the storedCustomer expires, it has to be ahead of the clock
+var verificationExpiresAt = time.Now().Add(verificationTTL)
+
+// registrationRequest is a valid request to open an account
+func registrationRequest() domain.RegistrationRequest {
+	return domain.RegistrationRequest{
+		Email:    "John@Example.com",
+		Password: "correct horse battery staple",
+		Name:     "John Doe",
+	}
+}
+
+// storedCustomer builds a stored Customer that registered and didn't verify the email address yet
+func storedCustomer() domain.Customer {
+	now := time.Date(2022, 5, 10, 10, 0, 0, 0, time.UTC)
+
+	customer := domain.NewCustomer(customerID, registrationRequest(), passwordHash, now)
+	customer.Verification = domain.Verification{
+		TokenHash: hashVerificationToken(verificationToken),
+		ExpiresAt: verificationExpiresAt,
+	}
+	customer.Version = 1
+
+	return customer
+}
+
+// address is a valid Address
+func address() domain.Address {
+	return domain.Address{
+		Label:      "home",
+		Recipient:  "John Doe",
+		Line1:      "Av. Paulista, 1000",
+		City:       "São Paulo",
+		Region:     "SP",
+		PostalCode: "01310-100",
+		Country:    "BR",
+	}
+}
+
+// bumpVersion is what the repository does to a Customer it stores
+func bumpVersion(_ context.Context, customer domain.Customer) domain.Customer {
+	customer.Version++
+	return customer
+}
@@ -0,0 +1,27 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"go.uber.org/zap"
+)
+
+// publish tells the other services about a CustomerEvent once the Customer is stored.
+// The event is only logged when it can't be published, the change to the account already happened.
+func (a application) publish(ctx context.Context, eventType domain.EventType, customer domain.Customer, verificationToken string) {
+	event := domain.CustomerEvent{
+		Type:              eventType,
+		Customer:          customer,
+		VerificationToken: verificationToken,
+	}
+
+	if err := a.EventPublisher.Publish(ctx, event); err != nil {
+		a.Logger.Error(
+			"failed to publish a customer event",
+			zap.String("customerId", customer.ID),
+			zap.String("type", string(eventType)),
+			zap.Error(err),
+		)
+	}
+}
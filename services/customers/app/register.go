@@ -0,0 +1,51 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) Register(ctx context.Context, request domain.RegistrationRequest) (domain.Customer, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.Register")
+	defer span.End()
+
+	a.Logger.Info("registering a customer", zap.String("email", domain.NormalizeEmail(request.Email)))
+
+	if err := request.Validate(); err != nil {
+		return domain.Customer{}, err
+	}
+
+	passwordHash, err := a.PasswordHasher.Hash(request.Password)
+	if err != nil {
+		return domain.Customer{}, err
+	}
+
+	id, err := newCustomerID()
+	if err != nil {
+		return domain.Customer{}, err
+	}
+
+	token, tokenHash, err := newVerificationToken()
+	if err != nil {
+		return domain.Customer{}, err
+	}
+
+	now := time.Now()
+
+	customer := domain.NewCustomer(id, request, passwordHash, now)
+	if err := customer.RequestVerification(tokenHash, now.Add(a.VerificationTTL), now); err != nil {
+		return domain.Customer{}, err
+	}
+
+	customer, err = a.CustomersRepository.Create(ctx, customer)
+	if err != nil {
+		return domain.Customer{}, err
+	}
+
+	a.publish(ctx, domain.EventRegistered, customer, token)
+
+	return customer, nil
+}
@@ -0,0 +1,121 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"github.com/lucasmls/ecommerce/services/customers/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type RegisterSuite struct {
+	suite.Suite
+
+	customersRepo  *mocks.CustomersRepository
+	passwordHasher *mocks.PasswordHasher
+	eventPublisher *mocks.EventPublisher
+	app            domain.Application
+}
+
+func (s *RegisterSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.customersRepo = &mocks.CustomersRepository{}
+	s.passwordHasher = &mocks.PasswordHasher{}
+	s.eventPublisher = &mocks.EventPublisher{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.customersRepo, s.passwordHasher, &mocks.TokenIssuer{}, s.eventPublisher, verificationTTL)
+}
+
+func (s *RegisterSuite) Test_Register() {
+	s.Run("Should fail in case the request is invalid", func() {
+		s.SetupTest()
+
+		testCases := []struct {
+			request domain.RegistrationRequest
+			err     error
+		}{
+			{request: domain.RegistrationRequest{Email: "john", Password: "correct horse battery staple", Name: "John"}, err: domain.ErrInvalidEmail},
+			{request: domain.RegistrationRequest{Email: "john@example.com", Password: "short", Name: "John"}, err: domain.ErrInvalidPassword},
+			{request: domain.RegistrationRequest{Email: "john@example.com", Password: "correct horse battery staple", Name: " "}, err: domain.ErrInvalidCustomer},
+		}
+
+		for _, testCase := range testCases {
+			_, err := s.app.Register(context.Background(), testCase.request)
+
+			s.Equal(testCase.err, err)
+		}
+
+		s.passwordHasher.AssertNotCalled(s.T(), "Hash", mock.Anything)
+	})
+
+	s.Run("Should store the customer with the hashed password and send the verification token", func() {
+		s.SetupTest()
+
+		var storedTokenHash string
+
+		s.passwordHasher.On("Hash", "correct horse battery staple").Return(passwordHash, nil).Once()
+		s.customersRepo.
+			On("Create", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(customer domain.Customer) bool {
+				storedTokenHash = customer.Verification.TokenHash
+				return len(customer.ID) == 2*customerIDSize &&
+					customer.Email == "john@example.com" &&
+					customer.PasswordHash == passwordHash &&
+					!customer.EmailVerified &&
+					customer.Verification.TokenHash != ""
+			})).
+			Return(func(_ context.Context, customer domain.Customer) domain.Customer {
+				customer.Version = 1
+				return customer
+			}, nil).
+			Once()
+		s.eventPublisher.
+			On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(event domain.CustomerEvent) bool {
+				return event.Type == domain.EventRegistered && hashVerificationToken(event.VerificationToken) == storedTokenHash
+			})).
+			Return(nil).
+			Once()
+
+		got, err := s.app.Register(context.Background(), registrationRequest())
+
+		s.NoError(err)
+		s.Equal("john@example.com", got.Email)
+		s.Equal(1, got.Version)
+		s.eventPublisher.AssertExpectations(s.T())
+	})
+
+	s.Run("Should fail in case the email address is already registered", func() {
+		s.SetupTest()
+
+		s.passwordHasher.On("Hash", "correct horse battery staple").Return(passwordHash, nil).Once()
+		s.customersRepo.On("Create", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Customer")).Return(domain.Customer{}, domain.ErrEmailAlreadyRegistered).Once()
+
+		_, err := s.app.Register(context.Background(), registrationRequest())
+
+		s.Equal(domain.ErrEmailAlreadyRegistered, err)
+		s.eventPublisher.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should register the customer even if the event can't be published", func() {
+		s.SetupTest()
+
+		s.passwordHasher.On("Hash", "correct horse battery staple").Return(passwordHash, nil).Once()
+		s.customersRepo.On("Create", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Customer")).Return(storedCustomer(), nil).Once()
+		s.eventPublisher.On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.CustomerEvent")).Return(errors.New("connection closed")).Once()
+
+		got, err := s.app.Register(context.Background(), registrationRequest())
+
+		s.NoError(err)
+		s.Equal(storedCustomer().ID, got.ID)
+	})
+}
+
+func TestRegisterSuite(t *testing.T) {
+	suite.Run(t, new(RegisterSuite))
+}
@@ -0,0 +1,24 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) RemoveAddress(ctx context.Context, removal domain.AddressRemoval) (domain.Customer, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.RemoveAddress")
+	defer span.End()
+
+	a.Logger.Info("removing an address", zap.String("customerId", removal.CustomerID), zap.Int("addressId", removal.AddressID))
+
+	if removal.CustomerID == "" {
+		return domain.Customer{}, domain.ErrCustomerNotFound
+	}
+
+	return a.update(ctx, removal.CustomerID, func(customer *domain.Customer) error {
+		return customer.RemoveAddress(removal.AddressID, time.Now())
+	})
+}
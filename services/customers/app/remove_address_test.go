@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"github.com/lucasmls/ecommerce/services/customers/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type RemoveAddressSuite struct {
+	suite.Suite
+
+	customersRepo *mocks.CustomersRepository
+	app           domain.Application
+}
+
+func (s *RemoveAddressSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.customersRepo = &mocks.CustomersRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.customersRepo, &mocks.PasswordHasher{}, &mocks.TokenIssuer{}, &mocks.EventPublisher{}, verificationTTL)
+}
+
+func (s *RemoveAddressSuite) Test_RemoveAddress() {
+	s.Run("Should fail in case the address doesn't exist", func() {
+		s.SetupTest()
+
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(customerWithAddresses(), nil).Once()
+
+		_, err := s.app.RemoveAddress(context.Background(), domain.AddressRemoval{CustomerID: customerID, AddressID: 7})
+
+		s.Equal(domain.ErrAddressNotFound, err)
+	})
+
+	s.Run("Should make the first address left the default one in case the default one is removed", func() {
+		s.SetupTest()
+
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(customerWithAddresses(), nil).Once()
+		s.customersRepo.On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Customer")).Return(bumpVersion, nil).Once()
+
+		got, err := s.app.RemoveAddress(context.Background(), domain.AddressRemoval{CustomerID: customerID, AddressID: 1})
+
+		s.NoError(err)
+		s.Len(got.Addresses, 1)
+		s.Equal(2, got.Addresses[0].ID)
+		s.True(got.Addresses[0].Default)
+		s.Equal(3, got.NextAddressID)
+	})
+}
+
+func TestRemoveAddressSuite(t *testing.T) {
+	suite.Run(t, new(RemoveAddressSuite))
+}
@@ -0,0 +1,33 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) RequestEmailVerification(ctx context.Context, id string) (domain.Customer, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.RequestEmailVerification")
+	defer span.End()
+
+	a.Logger.Info("requesting an email verification", zap.String("id", id))
+
+	token, tokenHash, err := newVerificationToken()
+	if err != nil {
+		return domain.Customer{}, err
+	}
+
+	customer, err := a.update(ctx, id, func(customer *domain.Customer) error {
+		now := time.Now()
+		return customer.RequestVerification(tokenHash, now.Add(a.VerificationTTL), now)
+	})
+	if err != nil {
+		return domain.Customer{}, err
+	}
+
+	a.publish(ctx, domain.EventVerificationRequested, customer, token)
+
+	return customer, nil
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"github.com/lucasmls/ecommerce/services/customers/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type RequestEmailVerificationSuite struct {
+	suite.Suite
+
+	customersRepo  *mocks.CustomersRepository
+	eventPublisher *mocks.EventPublisher
+	app            domain.Application
+}
+
+func (s *RequestEmailVerificationSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.customersRepo = &mocks.CustomersRepository{}
+	s.eventPublisher = &mocks.EventPublisher{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.customersRepo, &mocks.PasswordHasher{}, &mocks.TokenIssuer{}, s.eventPublisher, verificationTTL)
+}
+
+func (s *RequestEmailVerificationSuite) Test_RequestEmailVerification() {
+	s.Run("Should replace the pending verification and send the new token", func() {
+		s.SetupTest()
+
+		var storedTokenHash string
+
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(storedCustomer(), nil).Once()
+		s.customersRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(customer domain.Customer) bool {
+				storedTokenHash = customer.Verification.TokenHash
+				return customer.Verification.TokenHash != storedCustomer().Verification.TokenHash
+			})).
+			Return(bumpVersion, nil).
+			Once()
+		s.eventPublisher.
+			On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(event domain.CustomerEvent) bool {
+				return event.Type == domain.EventVerificationRequested && hashVerificationToken(event.VerificationToken) == storedTokenHash
+			})).
+			Return(nil).
+			Once()
+
+		got, err := s.app.RequestEmailVerification(context.Background(), customerID)
+
+		s.NoError(err)
+		s.Equal(2, got.Version)
+		s.eventPublisher.AssertExpectations(s.T())
+	})
+
+	s.Run("Should fail in case the email address is already verified", func() {
+		s.SetupTest()
+
+		customer := storedCustomer()
+		customer.EmailVerified = true
+		customer.Verification = domain.Verification{}
+
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(customer, nil).Once()
+
+		_, err := s.app.RequestEmailVerification(context.Background(), customerID)
+
+		s.Equal(domain.ErrEmailAlreadyVerified, err)
+		s.eventPublisher.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the customer doesn't exist", func() {
+		s.SetupTest()
+
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(domain.Customer{}, domain.ErrCustomerNotFound).Once()
+
+		_, err := s.app.RequestEmailVerification(context.Background(), customerID)
+
+		s.Equal(domain.ErrCustomerNotFound, err)
+	})
+}
+
+func TestRequestEmailVerificationSuite(t *testing.T) {
+	suite.Run(t, new(RequestEmailVerificationSuite))
+}
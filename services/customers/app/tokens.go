@@ -0,0 +1,42 @@
+package app
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const (
+	// customerIDSize is the amount of random bytes of a Customer ID
+	customerIDSize = 16
+	// verificationTokenSize is the amount of random bytes of a token verifying an email address
+	verificationTokenSize = 32
+)
+
+func newCustomerID() (string, error) {
+	id := make([]byte, customerIDSize)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(id), nil
+}
+
+// newVerificationToken returns a token to send to a Customer along with the hash to store
+func newVerificationToken() (string, string, error) {
+	token := make([]byte, verificationTokenSize)
+	if _, err := rand.Read(token); err != nil {
+		return "", "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(token)
+	return encoded, hashVerificationToken(encoded), nil
+}
+
+// hashVerificationToken is how a token verifying an email address is stored and looked up.
+// The tokens are random enough that a plain hash keeps them safe from whoever reads the storage.
+func hashVerificationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
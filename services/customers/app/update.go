@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"go.uber.org/zap"
+)
+
+// maxUpdateAttempts is how many times a change is applied to a Customer that keeps changing concurrently
+const maxUpdateAttempts = 3
+
+// update applies a change to a Customer and stores it, applying it again on its latest Version
+// when it changed concurrently
+func (a application) update(ctx context.Context, id string, apply func(*domain.Customer) error) (domain.Customer, error) {
+	customer, err := a.CustomersRepository.Get(ctx, id)
+	if err != nil {
+		return domain.Customer{}, err
+	}
+
+	for attempt := 1; attempt <= maxUpdateAttempts; attempt++ {
+		if err := apply(&customer); err != nil {
+			return domain.Customer{}, err
+		}
+
+		updated, err := a.CustomersRepository.Update(ctx, customer)
+		if err == nil {
+			return updated, nil
+		}
+
+		if !errors.Is(err, domain.ErrCustomerVersionConflict) {
+			return domain.Customer{}, err
+		}
+
+		a.Logger.Info("the customer changed concurrently, trying again", zap.String("id", id), zap.Int("attempt", attempt))
+
+		customer, err = a.CustomersRepository.Get(ctx, id)
+		if err != nil {
+			return domain.Customer{}, err
+		}
+	}
+
+	return domain.Customer{}, domain.ErrCustomerVersionConflict
+}
+
+// checkPassword fails with ErrInvalidCredentials when the password isn't the one of the Customer
+func (a application) checkPassword(customer domain.Customer, password string) error {
+	ok, err := a.PasswordHasher.Verify(password, customer.PasswordHash)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return domain.ErrInvalidCredentials
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) UpdateAddress(ctx context.Context, request domain.AddressRequest) (domain.Customer, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.UpdateAddress")
+	defer span.End()
+
+	a.Logger.Info("updating an address", zap.String("customerId", request.CustomerID), zap.Int("addressId", request.Address.ID))
+
+	if err := request.Validate(); err != nil {
+		return domain.Customer{}, err
+	}
+
+	return a.update(ctx, request.CustomerID, func(customer *domain.Customer) error {
+		_, err := customer.UpdateAddress(request.Address, time.Now())
+		return err
+	})
+}
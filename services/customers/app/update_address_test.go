@@ -0,0 +1,100 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"github.com/lucasmls/ecommerce/services/customers/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type UpdateAddressSuite struct {
+	suite.Suite
+
+	customersRepo *mocks.CustomersRepository
+	app           domain.Application
+}
+
+func (s *UpdateAddressSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.customersRepo = &mocks.CustomersRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.customersRepo, &mocks.PasswordHasher{}, &mocks.TokenIssuer{}, &mocks.EventPublisher{}, verificationTTL)
+}
+
+// customerWithAddresses builds a stored Customer with a home Address, the default one, and a work Address
+func customerWithAddresses() domain.Customer {
+	customer := storedCustomer()
+
+	work := address()
+	work.Label = "work"
+
+	for _, entry := range []domain.Address{address(), work} {
+		if _, err := customer.AddAddress(entry, customer.UpdatedAt); err != nil {
+			panic(err)
+		}
+	}
+
+	return customer
+}
+
+func (s *UpdateAddressSuite) Test_UpdateAddress() {
+	s.Run("Should fail in case the address doesn't exist", func() {
+		s.SetupTest()
+
+		unknown := address()
+		unknown.ID = 7
+
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(customerWithAddresses(), nil).Once()
+
+		_, err := s.app.UpdateAddress(context.Background(), domain.AddressRequest{CustomerID: customerID, Address: unknown})
+
+		s.Equal(domain.ErrAddressNotFound, err)
+	})
+
+	s.Run("Should make the address the default one", func() {
+		s.SetupTest()
+
+		work := address()
+		work.ID = 2
+		work.Label = "office"
+		work.Default = true
+
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(customerWithAddresses(), nil).Once()
+		s.customersRepo.On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Customer")).Return(bumpVersion, nil).Once()
+
+		got, err := s.app.UpdateAddress(context.Background(), domain.AddressRequest{CustomerID: customerID, Address: work})
+
+		s.NoError(err)
+		s.False(got.Addresses[0].Default)
+		s.True(got.Addresses[1].Default)
+		s.Equal("office", got.Addresses[1].Label)
+	})
+
+	s.Run("Should keep the default address the default one", func() {
+		s.SetupTest()
+
+		home := address()
+		home.ID = 1
+		home.Line2 = "apt 42"
+
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(customerWithAddresses(), nil).Once()
+		s.customersRepo.On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Customer")).Return(bumpVersion, nil).Once()
+
+		got, err := s.app.UpdateAddress(context.Background(), domain.AddressRequest{CustomerID: customerID, Address: home})
+
+		s.NoError(err)
+		s.True(got.Addresses[0].Default)
+		s.Equal("apt 42", got.Addresses[0].Line2)
+	})
+}
+
+func TestUpdateAddressSuite(t *testing.T) {
+	suite.Run(t, new(UpdateAddressSuite))
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) UpdateProfile(ctx context.Context, update domain.ProfileUpdate) (domain.Customer, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.UpdateProfile")
+	defer span.End()
+
+	a.Logger.Info("updating the profile of a customer", zap.String("id", update.CustomerID))
+
+	if err := update.Validate(); err != nil {
+		return domain.Customer{}, err
+	}
+
+	token, tokenHash, err := newVerificationToken()
+	if err != nil {
+		return domain.Customer{}, err
+	}
+
+	emailChanged := false
+
+	customer, err := a.update(ctx, update.CustomerID, func(customer *domain.Customer) error {
+		now := time.Now()
+
+		emailChanged = customer.UpdateProfile(update, now)
+		if !emailChanged {
+			return nil
+		}
+
+		return customer.RequestVerification(tokenHash, now.Add(a.VerificationTTL), now)
+	})
+	if err != nil {
+		return domain.Customer{}, err
+	}
+
+	if emailChanged {
+		a.publish(ctx, domain.EventVerificationRequested, customer, token)
+	}
+
+	return customer, nil
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"github.com/lucasmls/ecommerce/services/customers/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type UpdateProfileSuite struct {
+	suite.Suite
+
+	customersRepo  *mocks.CustomersRepository
+	eventPublisher *mocks.EventPublisher
+	app            domain.Application
+}
+
+func (s *UpdateProfileSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.customersRepo = &mocks.CustomersRepository{}
+	s.eventPublisher = &mocks.EventPublisher{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.customersRepo, &mocks.PasswordHasher{}, &mocks.TokenIssuer{}, s.eventPublisher, verificationTTL)
+}
+
+func (s *UpdateProfileSuite) Test_UpdateProfile() {
+	s.Run("Should fail in case the update is invalid", func() {
+		s.SetupTest()
+
+		_, err := s.app.UpdateProfile(context.Background(), domain.ProfileUpdate{CustomerID: customerID, Email: "john@example.com"})
+
+		s.Equal(domain.ErrInvalidCustomer, err)
+		s.customersRepo.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should replace the profile without asking for a verification in case the email address didn't change", func() {
+		s.SetupTest()
+
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(storedCustomer(), nil).Once()
+		s.customersRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(customer domain.Customer) bool {
+				return customer.Name == "Johnny" && customer.Phone == "+55 11 99999-9999"
+			})).
+			Return(bumpVersion, nil).
+			Once()
+
+		got, err := s.app.UpdateProfile(context.Background(), domain.ProfileUpdate{
+			CustomerID: customerID,
+			Email:      "JOHN@example.com",
+			Name:       "Johnny",
+			Phone:      "+55 11 99999-9999",
+		})
+
+		s.NoError(err)
+		s.Equal("Johnny", got.Name)
+		s.eventPublisher.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should ask to verify the new email address in case it changed", func() {
+		s.SetupTest()
+
+		customer := storedCustomer()
+		customer.EmailVerified = true
+		customer.Verification = domain.Verification{}
+
+		var storedTokenHash string
+
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(customer, nil).Once()
+		s.customersRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(customer domain.Customer) bool {
+				storedTokenHash = customer.Verification.TokenHash
+				return customer.Email == "johnny@example.com" && !customer.EmailVerified && customer.Verification.TokenHash != ""
+			})).
+			Return(bumpVersion, nil).
+			Once()
+		s.eventPublisher.
+			On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(event domain.CustomerEvent) bool {
+				return event.Type == domain.EventVerificationRequested && hashVerificationToken(event.VerificationToken) == storedTokenHash
+			})).
+			Return(nil).
+			Once()
+
+		got, err := s.app.UpdateProfile(context.Background(), domain.ProfileUpdate{
+			CustomerID: customerID,
+			Email:      "johnny@example.com",
+			Name:       "John Doe",
+		})
+
+		s.NoError(err)
+		s.False(got.EmailVerified)
+		s.eventPublisher.AssertExpectations(s.T())
+	})
+
+	s.Run("Should fail in case the new email address belongs to another customer", func() {
+		s.SetupTest()
+
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(storedCustomer(), nil).Once()
+		s.customersRepo.On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Customer")).Return(domain.Customer{}, domain.ErrEmailAlreadyRegistered).Once()
+
+		_, err := s.app.UpdateProfile(context.Background(), domain.ProfileUpdate{
+			CustomerID: customerID,
+			Email:      "jane@example.com",
+			Name:       "John Doe",
+		})
+
+		s.Equal(domain.ErrEmailAlreadyRegistered, err)
+		s.eventPublisher.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateProfileSuite(t *testing.T) {
+	suite.Run(t, new(UpdateProfileSuite))
+}
@@ -0,0 +1,26 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+)
+
+func (a application) ValidateToken(ctx context.Context, token string) (domain.Customer, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.ValidateToken")
+	defer span.End()
+
+	id, err := a.TokenIssuer.Verify(ctx, token)
+	if err != nil {
+		return domain.Customer{}, err
+	}
+
+	customer, err := a.CustomersRepository.Get(ctx, id)
+	if errors.Is(err, domain.ErrCustomerNotFound) {
+		// the account was deleted after the token was issued
+		return domain.Customer{}, domain.ErrInvalidToken
+	}
+
+	return customer, err
+}
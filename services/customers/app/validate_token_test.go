@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"github.com/lucasmls/ecommerce/services/customers/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ValidateTokenSuite struct {
+	suite.Suite
+
+	customersRepo *mocks.CustomersRepository
+	tokenIssuer   *mocks.TokenIssuer
+	app           domain.Application
+}
+
+func (s *ValidateTokenSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.customersRepo = &mocks.CustomersRepository{}
+	s.tokenIssuer = &mocks.TokenIssuer{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.customersRepo, &mocks.PasswordHasher{}, s.tokenIssuer, &mocks.EventPublisher{}, verificationTTL)
+}
+
+func (s *ValidateTokenSuite) Test_ValidateToken() {
+	s.Run("Should fail in case the token can't be verified", func() {
+		s.SetupTest()
+
+		s.tokenIssuer.On("Verify", mock.AnythingOfType("*context.valueCtx"), "token").Return("", domain.ErrTokenExpired).Once()
+
+		_, err := s.app.ValidateToken(context.Background(), "token")
+
+		s.Equal(domain.ErrTokenExpired, err)
+		s.customersRepo.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail with an invalid token in case the account was deleted", func() {
+		s.SetupTest()
+
+		s.tokenIssuer.On("Verify", mock.AnythingOfType("*context.valueCtx"), "token").Return(customerID, nil).Once()
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(domain.Customer{}, domain.ErrCustomerNotFound).Once()
+
+		_, err := s.app.ValidateToken(context.Background(), "token")
+
+		s.Equal(domain.ErrInvalidToken, err)
+	})
+
+	s.Run("Should return the customer holding the token", func() {
+		s.SetupTest()
+
+		s.tokenIssuer.On("Verify", mock.AnythingOfType("*context.valueCtx"), "token").Return(customerID, nil).Once()
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(storedCustomer(), nil).Once()
+
+		got, err := s.app.ValidateToken(context.Background(), "token")
+
+		s.NoError(err)
+		s.Equal(storedCustomer(), got)
+	})
+}
+
+func TestValidateTokenSuite(t *testing.T) {
+	suite.Run(t, new(ValidateTokenSuite))
+}
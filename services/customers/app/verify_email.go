@@ -0,0 +1,42 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+)
+
+func (a application) VerifyEmail(ctx context.Context, token string) (domain.Customer, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.VerifyEmail")
+	defer span.End()
+
+	a.Logger.Info("verifying an email address")
+
+	if token == "" {
+		return domain.Customer{}, domain.ErrInvalidVerificationToken
+	}
+
+	tokenHash := hashVerificationToken(token)
+
+	customer, err := a.CustomersRepository.GetByVerificationToken(ctx, tokenHash)
+	if errors.Is(err, domain.ErrCustomerNotFound) {
+		return domain.Customer{}, domain.ErrInvalidVerificationToken
+	}
+
+	if err != nil {
+		return domain.Customer{}, err
+	}
+
+	customer, err = a.update(ctx, customer.ID, func(customer *domain.Customer) error {
+		return customer.VerifyEmail(tokenHash, time.Now())
+	})
+	if err != nil {
+		return domain.Customer{}, err
+	}
+
+	a.publish(ctx, domain.EventEmailVerified, customer, "")
+
+	return customer, nil
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"github.com/lucasmls/ecommerce/services/customers/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type VerifyEmailSuite struct {
+	suite.Suite
+
+	customersRepo  *mocks.CustomersRepository
+	eventPublisher *mocks.EventPublisher
+	app            domain.Application
+}
+
+func (s *VerifyEmailSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.customersRepo = &mocks.CustomersRepository{}
+	s.eventPublisher = &mocks.EventPublisher{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.customersRepo, &mocks.PasswordHasher{}, &mocks.TokenIssuer{}, s.eventPublisher, verificationTTL)
+}
+
+func (s *VerifyEmailSuite) Test_VerifyEmail() {
+	tokenHash := hashVerificationToken(verificationToken)
+
+	s.Run("Should fail in case the token is empty", func() {
+		s.SetupTest()
+
+		_, err := s.app.VerifyEmail(context.Background(), "")
+
+		s.Equal(domain.ErrInvalidVerificationToken, err)
+	})
+
+	s.Run("Should fail in case no customer is waiting for the token", func() {
+		s.SetupTest()
+
+		s.customersRepo.On("GetByVerificationToken", mock.AnythingOfType("*context.valueCtx"), tokenHash).Return(domain.Customer{}, domain.ErrCustomerNotFound).Once()
+
+		_, err := s.app.VerifyEmail(context.Background(), verificationToken)
+
+		s.Equal(domain.ErrInvalidVerificationToken, err)
+	})
+
+	s.Run("Should fail in case the token expired", func() {
+		s.SetupTest()
+
+		customer := storedCustomer()
+		customer.Verification.ExpiresAt = time.Now().Add(-time.Minute)
+
+		s.customersRepo.On("GetByVerificationToken", mock.AnythingOfType("*context.valueCtx"), tokenHash).Return(customer, nil).Once()
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(customer, nil).Once()
+
+		_, err := s.app.VerifyEmail(context.Background(), verificationToken)
+
+		s.Equal(domain.ErrInvalidVerificationToken, err)
+		s.customersRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should mark the email address as verified", func() {
+		s.SetupTest()
+
+		s.customersRepo.On("GetByVerificationToken", mock.AnythingOfType("*context.valueCtx"), tokenHash).Return(storedCustomer(), nil).Once()
+		s.customersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(storedCustomer(), nil).Once()
+		s.customersRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(customer domain.Customer) bool {
+				return customer.EmailVerified && customer.Verification == domain.Verification{}
+			})).
+			Return(bumpVersion, nil).
+			Once()
+		s.eventPublisher.
+			On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(event domain.CustomerEvent) bool {
+				return event.Type == domain.EventEmailVerified && event.VerificationToken == ""
+			})).
+			Return(nil).
+			Once()
+
+		got, err := s.app.VerifyEmail(context.Background(), verificationToken)
+
+		s.NoError(err)
+		s.True(got.EmailVerified)
+		s.eventPublisher.AssertExpectations(s.T())
+	})
+}
+
+func TestVerifyEmailSuite(t *testing.T) {
+	suite.Run(t, new(VerifyEmailSuite))
+}
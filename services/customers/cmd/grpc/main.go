@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/customers/adapters/events"
+	"github.com/lucasmls/ecommerce/services/customers/adapters/hashers"
+	"github.com/lucasmls/ecommerce/services/customers/adapters/repositories"
+	"github.com/lucasmls/ecommerce/services/customers/adapters/tokens"
+	"github.com/lucasmls/ecommerce/services/customers/app"
+	resolvers "github.com/lucasmls/ecommerce/services/customers/ports/grpc"
+	protog "github.com/lucasmls/ecommerce/services/customers/ports/grpc/proto"
+	rmqPb "github.com/lucasmls/ecommerce/services/customers/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/shared/env"
+	"github.com/lucasmls/ecommerce/shared/grpc"
+	"github.com/lucasmls/ecommerce/shared/rmq"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otel "go.opentelemetry.io/otel"
+	otelJaegerExporter "go.opentelemetry.io/otel/exporters/jaeger"
+	otelPropagation "go.opentelemetry.io/otel/propagation"
+	otelSdkResource "go.opentelemetry.io/otel/sdk/resource"
+	otelTraceSdk "go.opentelemetry.io/otel/sdk/trace"
+	otelSemconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.uber.org/zap"
+	gGRPC "google.golang.org/grpc"
+)
+
+type ApplicationConfig struct {
+	ServiceName              string `mapstructure:"SERVICE_NAME"`
+	JaegerEndpoint           string `mapstructure:"JAEGER_ENDPOINT"`
+	GrpcServerPort           int    `mapstructure:"GRPC_SERVER_PORT"`
+	MetricsPort              int    `mapstructure:"METRICS_PORT"`
+	PostgresConnectionString string `mapstructure:"PG_CONNECTION_STRING"`
+	// RabbitMQURL is where the customer events are published to
+	RabbitMQURL string `mapstructure:"RABBITMQ_URL"`
+	// AuthTokenSecret signs the access tokens, every service verifying them has to share it
+	AuthTokenSecret string `mapstructure:"AUTH_TOKEN_SECRET"`
+	// AccessTokenTTL is how long the access tokens last, e.g. 1h
+	AccessTokenTTL time.Duration `mapstructure:"ACCESS_TOKEN_TTL"`
+	// VerificationTokenTTL is how long the tokens verifying the email addresses last, e.g. 24h
+	VerificationTokenTTL time.Duration `mapstructure:"VERIFICATION_TOKEN_TTL"`
+}
+
+func main() {
+	ctx := context.Background()
+
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	config, err := env.LoadConfig[ApplicationConfig]()
+	if err != nil {
+		logger.Fatal("failed to load application config", zap.Error(err))
+	}
+
+	jaegerExporter, err := otelJaegerExporter.New(
+		otelJaegerExporter.WithCollectorEndpoint(
+			otelJaegerExporter.WithEndpoint(config.JaegerEndpoint),
+		),
+	)
+	if err != nil {
+		logger.Fatal("failed to instantiate Jaeger exporter", zap.Error(err))
+	}
+
+	tracingProvider := otelTraceSdk.NewTracerProvider(
+		otelTraceSdk.WithBatcher(jaegerExporter),
+		otelTraceSdk.WithSampler(otelTraceSdk.AlwaysSample()),
+		otelTraceSdk.WithResource(otelSdkResource.NewWithAttributes(
+			otelSemconv.SchemaURL,
+			otelSemconv.ServiceNameKey.String(config.ServiceName),
+		)),
+	)
+
+	defer func() {
+		_ = tracingProvider.Shutdown(ctx)
+	}()
+
+	otel.SetTracerProvider(tracingProvider)
+	otel.SetTextMapPropagator(otelPropagation.NewCompositeTextMapPropagator(
+		otelPropagation.TraceContext{},
+		otelPropagation.Baggage{},
+	))
+
+	tracer := otel.Tracer(config.ServiceName)
+
+	rmqClient := rmq.MustNewClient(rmq.ClientInput{
+		URL:    config.RabbitMQURL,
+		Logger: logger,
+	}).MustConnect()
+	defer rmqClient.Close()
+
+	if err := rmqClient.DeclareExchange(rmqPb.Exchange); err != nil {
+		logger.Fatal("failed to declare the customers exchange", zap.Error(err))
+	}
+
+	eventPublisher := events.NewRmqEventPublisher(logger, tracer, rmqClient)
+
+	inMemoryCustomersRepository := repositories.NewInMemoryCustomersRepository(logger, tracer)
+	// postgresCustomersRepository := repositories.MustNewPgCustomersRepository(config.PostgresConnectionString)
+
+	tokenSecret := []byte(config.AuthTokenSecret)
+
+	passwordHasher := hashers.NewArgon2idPasswordHasher(hashers.DefaultArgon2idParams)
+	tokenIssuer := tokens.MustNewJwtTokenIssuer(tokenSecret, config.AccessTokenTTL)
+
+	application := app.MustNewApplication(
+		logger,
+		tracer,
+		inMemoryCustomersRepository,
+		passwordHasher,
+		tokenIssuer,
+		eventPublisher,
+		config.VerificationTokenTTL,
+	)
+	customersResolver := resolvers.MustNewCustomersResolver(logger, tracer, application)
+
+	server := grpc.MustNewServer(grpc.ServerInput{
+		Port:   config.GrpcServerPort,
+		Logger: logger,
+		Registrator: func(server gGRPC.ServiceRegistrar) {
+			protog.RegisterCustomersServiceServer(server, customersResolver)
+		},
+		TokenSecret: tokenSecret,
+	})
+
+	go func() {
+		port := fmt.Sprintf(":%d", config.MetricsPort)
+
+		http.Handle("/metrics", promhttp.Handler())
+		_ = http.ListenAndServe(port, nil)
+	}()
+
+	if err := server.Run(ctx); err != nil {
+		logger.Fatal("failed to run gRPC server", zap.Error(err))
+	}
+}
@@ -0,0 +1,167 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxAddresses is the maximum amount of Addresses on the address book of a Customer
+	MaxAddresses = 20
+	// MaxAddressFieldLength is the maximum amount of characters of each field of an Address
+	MaxAddressFieldLength = 255
+)
+
+// Address is an entry of the address book of a Customer, e.g. where the orders are shipped to
+type Address struct {
+	// ID identifies the Address among the ones of its Customer
+	ID         int
+	Label      string
+	Recipient  string
+	Line1      string
+	Line2      string
+	City       string
+	Region     string
+	PostalCode string
+	// Country is an ISO 3166-1 alpha-2 code, e.g. BR
+	Country string
+	Phone   string
+	// Default is the Address picked when none is, there's always one while the address book isn't empty
+	Default bool
+}
+
+// Validate checks the Address is valid, its ID isn't checked
+func (a Address) Validate() error {
+	for _, field := range []string{a.Recipient, a.Line1, a.City, a.PostalCode} {
+		if strings.TrimSpace(field) == "" {
+			return ErrInvalidAddress
+		}
+	}
+
+	for _, field := range []string{a.Label, a.Recipient, a.Line1, a.Line2, a.City, a.Region, a.PostalCode} {
+		if utf8.RuneCountInString(field) > MaxAddressFieldLength {
+			return ErrInvalidAddress
+		}
+	}
+
+	if len(a.Country) != 2 || !isUpperLetter(a.Country[0]) || !isUpperLetter(a.Country[1]) {
+		return ErrInvalidAddress
+	}
+
+	if utf8.RuneCountInString(a.Phone) > MaxPhoneLength {
+		return ErrInvalidAddress
+	}
+
+	return nil
+}
+
+// AddAddress puts a new Address on the address book, giving it an ID. The first Address is always the default one.
+func (c *Customer) AddAddress(address Address, now time.Time) (Address, error) {
+	if len(c.Addresses) >= MaxAddresses {
+		return Address{}, ErrTooManyAddresses
+	}
+
+	address.ID = c.NextAddressID
+	address.Default = address.Default || len(c.Addresses) == 0
+
+	c.NextAddressID++
+	c.Addresses = append(c.Addresses, address)
+	c.makeDefault(address)
+	c.UpdatedAt = now
+
+	return address, nil
+}
+
+// UpdateAddress replaces the Address with the same ID. The default Address can only stop being
+// the default one by making another one the default.
+func (c *Customer) UpdateAddress(address Address, now time.Time) (Address, error) {
+	index := c.addressIndex(address.ID)
+	if index < 0 {
+		return Address{}, ErrAddressNotFound
+	}
+
+	address.Default = address.Default || c.Addresses[index].Default
+
+	c.Addresses[index] = address
+	c.makeDefault(address)
+	c.UpdatedAt = now
+
+	return address, nil
+}
+
+// RemoveAddress takes an Address off the address book. When it was the default one, the first Address left becomes the default.
+func (c *Customer) RemoveAddress(id int, now time.Time) error {
+	index := c.addressIndex(id)
+	if index < 0 {
+		return ErrAddressNotFound
+	}
+
+	removed := c.Addresses[index]
+	c.Addresses = append(c.Addresses[:index:index], c.Addresses[index+1:]...)
+
+	if removed.Default && len(c.Addresses) > 0 {
+		c.Addresses[0].Default = true
+	}
+
+	c.UpdatedAt = now
+
+	return nil
+}
+
+// DefaultAddress returns the default Address, if the address book isn't empty
+func (c Customer) DefaultAddress() (Address, bool) {
+	for _, address := range c.Addresses {
+		if address.Default {
+			return address, true
+		}
+	}
+
+	return Address{}, false
+}
+
+func isUpperLetter(b byte) bool {
+	return b >= 'A' && b <= 'Z'
+}
+
+func (c *Customer) addressIndex(id int) int {
+	for i, address := range c.Addresses {
+		if address.ID == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// makeDefault unsets the default flag of the other Addresses when the given one is the default
+func (c *Customer) makeDefault(address Address) {
+	if !address.Default {
+		return
+	}
+
+	for i := range c.Addresses {
+		c.Addresses[i].Default = c.Addresses[i].ID == address.ID
+	}
+}
+
+// AddressRequest adds or updates an Address of a Customer, the ID of the Address picks which one is updated
+type AddressRequest struct {
+	CustomerID string
+	Address    Address
+}
+
+// Validate checks the AddressRequest is valid
+func (r AddressRequest) Validate() error {
+	if r.CustomerID == "" {
+		return ErrCustomerNotFound
+	}
+
+	return r.Address.Validate()
+}
+
+// AddressRemoval removes an Address of a Customer
+type AddressRemoval struct {
+	CustomerID string
+	AddressID  int
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"context"
+)
+
+// Application defines boundary interfaces of the application
+// It should be called by the ports
+type Application interface {
+	// Register opens the account of a Customer, sending a token to verify the email address.
+	// It fails with ErrEmailAlreadyRegistered when another Customer has the same email address.
+	Register(context.Context, RegistrationRequest) (Customer, error)
+
+	// RequestEmailVerification sends a new token to verify the email address of a Customer, the previous one stops working
+	RequestEmailVerification(context.Context, string) (Customer, error)
+
+	// VerifyEmail marks the email address of the Customer the token was sent to as verified.
+	// It fails with ErrInvalidVerificationToken when the token is unknown or expired.
+	VerifyEmail(context.Context, string) (Customer, error)
+
+	// Authenticate signs a Customer in, issuing an AccessToken.
+	// It fails with ErrInvalidCredentials whether the email address is unknown or the password is wrong.
+	Authenticate(context.Context, Credentials) (Session, error)
+
+	// ValidateToken returns the Customer holding an AccessToken, failing with ErrInvalidToken or
+	// ErrTokenExpired when it can't be trusted, and with ErrInvalidToken once the account is deleted.
+	ValidateToken(context.Context, string) (Customer, error)
+
+	// GetCustomer fetches a single Customer
+	GetCustomer(context.Context, string) (Customer, error)
+
+	// UpdateProfile replaces the profile of a Customer. Changing the email address sends a token to verify the new one.
+	UpdateProfile(context.Context, ProfileUpdate) (Customer, error)
+
+	// ChangePassword replaces the password of a Customer, failing with ErrInvalidCredentials when the current one is wrong
+	ChangePassword(context.Context, PasswordChange) (Customer, error)
+
+	// DeleteAccount deletes a Customer along with the address book, failing with ErrInvalidCredentials when the password is wrong
+	DeleteAccount(context.Context, AccountDeletion) error
+
+	// AddAddress puts a new Address on the address book of a Customer
+	AddAddress(context.Context, AddressRequest) (Customer, error)
+
+	// UpdateAddress replaces an Address of the address book of a Customer
+	UpdateAddress(context.Context, AddressRequest) (Customer, error)
+
+	// RemoveAddress takes an Address off the address book of a Customer
+	RemoveAddress(context.Context, AddressRemoval) (Customer, error)
+}
+
+type CustomersRepository interface {
+	// Create stores a new Customer in a data storage, setting its first Version.
+	// It fails with ErrEmailAlreadyRegistered when another Customer has the same email address.
+	Create(context.Context, Customer) (Customer, error)
+
+	// Get fetches a single Customer from a data storage.
+	Get(context.Context, string) (Customer, error)
+
+	// GetByEmail fetches the Customer with the given, normalized, email address from a data storage.
+	GetByEmail(context.Context, string) (Customer, error)
+
+	// GetByVerificationToken fetches the Customer with a pending Verification of the given token hash from a data storage.
+	GetByVerificationToken(context.Context, string) (Customer, error)
+
+	// Update replaces a Customer in a data storage, returning it with its new Version.
+	// It's only replaced while the stored Version is still the one it was read with, failing with
+	// ErrCustomerVersionConflict otherwise, and with ErrEmailAlreadyRegistered when another Customer has the same email address.
+	Update(context.Context, Customer) (Customer, error)
+
+	// Delete removes a Customer from a data storage.
+	Delete(context.Context, string) error
+}
+
+// PasswordHasher hashes the passwords, so they're never stored as they are
+type PasswordHasher interface {
+	// Hash returns the encoded hash of a password, along with whatever is needed to verify it, e.g. its salt
+	Hash(password string) (string, error)
+
+	// Verify tells whether a password matches an encoded hash
+	Verify(password string, encodedHash string) (bool, error)
+}
+
+// TokenIssuer issues the AccessTokens proving who a Customer is
+type TokenIssuer interface {
+	// Issue returns an AccessToken for the Customer
+	Issue(context.Context, Customer) (AccessToken, error)
+
+	// Verify returns the ID of the Customer an AccessToken was issued for, failing with ErrInvalidToken or ErrTokenExpired
+	Verify(context.Context, string) (string, error)
+}
+
+type EventPublisher interface {
+	// Publish tells the other services about a CustomerEvent
+	Publish(context.Context, CustomerEvent) error
+}
@@ -0,0 +1,257 @@
+package domain
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxEmailLength is the maximum amount of characters of an email address
+	MaxEmailLength = 254
+	// MinPasswordLength is the minimum amount of characters of a password
+	MinPasswordLength = 8
+	// MaxPasswordLength is the maximum amount of characters of a password, bounding how long hashing it takes
+	MaxPasswordLength = 128
+	// MaxNameLength is the maximum amount of characters of the name of a Customer
+	MaxNameLength = 255
+	// MaxPhoneLength is the maximum amount of characters of a phone number
+	MaxPhoneLength = 32
+)
+
+var (
+	ErrCustomerNotFound         = errors.New("customer-not-found")
+	ErrCustomerVersionConflict  = errors.New("customer-version-conflict")
+	ErrEmailAlreadyRegistered   = errors.New("email-already-registered")
+	ErrInvalidCustomer          = errors.New("invalid-customer")
+	ErrInvalidEmail             = errors.New("invalid-email")
+	ErrInvalidPassword          = errors.New("invalid-password")
+	ErrInvalidCredentials       = errors.New("invalid-credentials")
+	ErrInvalidVerificationToken = errors.New("invalid-verification-token")
+	ErrEmailAlreadyVerified     = errors.New("email-already-verified")
+	ErrInvalidToken             = errors.New("invalid-token")
+	ErrTokenExpired             = errors.New("token-expired")
+	ErrInvalidAddress           = errors.New("invalid-address")
+	ErrAddressNotFound          = errors.New("address-not-found")
+	ErrTooManyAddresses         = errors.New("too-many-addresses")
+)
+
+// Verification is a pending email verification of a Customer.
+// Only the hash of its token is kept, the token itself is sent to the Customer's email address.
+type Verification struct {
+	TokenHash string
+	ExpiresAt time.Time
+}
+
+// Customer is someone holding an account on the store
+type Customer struct {
+	ID string
+	// Email is normalized to lower case, it's unique among the Customers
+	Email string
+	Name  string
+	Phone string
+	// PasswordHash is the encoded hash of the password, it holds whatever the hasher needs to verify it
+	PasswordHash  string
+	EmailVerified bool
+	// Verification is the pending email verification, the zero value when there's none
+	Verification Verification
+	// Addresses is the address book of the Customer, in the order they were added
+	Addresses []Address
+	// NextAddressID is the ID the next Address added is given, so the IDs of the removed ones aren't reused
+	NextAddressID int
+	// Version is incremented on every change, starting at 1 when the Customer registers.
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewCustomer creates a Customer registering with the given request, whose password was already hashed
+func NewCustomer(id string, request RegistrationRequest, passwordHash string, now time.Time) Customer {
+	return Customer{
+		ID:            id,
+		Email:         NormalizeEmail(request.Email),
+		Name:          strings.TrimSpace(request.Name),
+		Phone:         strings.TrimSpace(request.Phone),
+		PasswordHash:  passwordHash,
+		NextAddressID: 1,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// RequestVerification replaces the pending email verification of the Customer
+func (c *Customer) RequestVerification(tokenHash string, expiresAt time.Time, now time.Time) error {
+	if c.EmailVerified {
+		return ErrEmailAlreadyVerified
+	}
+
+	c.Verification = Verification{TokenHash: tokenHash, ExpiresAt: expiresAt}
+	c.UpdatedAt = now
+
+	return nil
+}
+
+// VerifyEmail marks the email address of the Customer as verified, given the hash of the token of its pending verification
+func (c *Customer) VerifyEmail(tokenHash string, now time.Time) error {
+	if c.Verification.TokenHash == "" || c.Verification.TokenHash != tokenHash || !now.Before(c.Verification.ExpiresAt) {
+		return ErrInvalidVerificationToken
+	}
+
+	c.EmailVerified = true
+	c.Verification = Verification{}
+	c.UpdatedAt = now
+
+	return nil
+}
+
+// UpdateProfile replaces the profile of the Customer, telling whether its email address changed.
+// A new email address has to be verified again.
+func (c *Customer) UpdateProfile(update ProfileUpdate, now time.Time) bool {
+	email := NormalizeEmail(update.Email)
+	emailChanged := email != c.Email
+
+	c.Name = strings.TrimSpace(update.Name)
+	c.Phone = strings.TrimSpace(update.Phone)
+	c.UpdatedAt = now
+
+	if emailChanged {
+		c.Email = email
+		c.EmailVerified = false
+		c.Verification = Verification{}
+	}
+
+	return emailChanged
+}
+
+// SetPassword replaces the hash of the password of the Customer
+func (c *Customer) SetPassword(passwordHash string, now time.Time) {
+	c.PasswordHash = passwordHash
+	c.UpdatedAt = now
+}
+
+// NormalizeEmail is how an email address is stored and looked up
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// validateEmail checks the email address is a bare address, e.g. john@example.com
+func validateEmail(email string) error {
+	email = NormalizeEmail(email)
+	if email == "" || len(email) > MaxEmailLength {
+		return ErrInvalidEmail
+	}
+
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email {
+		return ErrInvalidEmail
+	}
+
+	return nil
+}
+
+func validatePassword(password string) error {
+	length := utf8.RuneCountInString(password)
+	if length < MinPasswordLength || length > MaxPasswordLength {
+		return ErrInvalidPassword
+	}
+
+	return nil
+}
+
+func validateProfile(name string, phone string) error {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > MaxNameLength {
+		return ErrInvalidCustomer
+	}
+
+	if utf8.RuneCountInString(strings.TrimSpace(phone)) > MaxPhoneLength {
+		return ErrInvalidCustomer
+	}
+
+	return nil
+}
+
+// RegistrationRequest is what a customer tells to open an account
+type RegistrationRequest struct {
+	Email    string
+	Password string
+	Name     string
+	Phone    string
+}
+
+// Validate checks the RegistrationRequest is valid
+func (r RegistrationRequest) Validate() error {
+	if err := validateEmail(r.Email); err != nil {
+		return err
+	}
+
+	if err := validatePassword(r.Password); err != nil {
+		return err
+	}
+
+	return validateProfile(r.Name, r.Phone)
+}
+
+// Credentials identify a Customer signing in
+type Credentials struct {
+	Email    string
+	Password string
+}
+
+// ProfileUpdate replaces the profile of a Customer
+type ProfileUpdate struct {
+	CustomerID string
+	Email      string
+	Name       string
+	Phone      string
+}
+
+// Validate checks the ProfileUpdate is valid
+func (u ProfileUpdate) Validate() error {
+	if u.CustomerID == "" {
+		return ErrCustomerNotFound
+	}
+
+	if err := validateEmail(u.Email); err != nil {
+		return err
+	}
+
+	return validateProfile(u.Name, u.Phone)
+}
+
+// PasswordChange replaces the password of a Customer, who has to tell the current one
+type PasswordChange struct {
+	CustomerID      string
+	CurrentPassword string
+	NewPassword     string
+}
+
+// Validate checks the PasswordChange is valid
+func (c PasswordChange) Validate() error {
+	if c.CustomerID == "" {
+		return ErrCustomerNotFound
+	}
+
+	return validatePassword(c.NewPassword)
+}
+
+// AccountDeletion deletes the account of a Customer, who has to tell the password
+type AccountDeletion struct {
+	CustomerID string
+	Password   string
+}
+
+// Session is what a Customer gets when signing in
+type Session struct {
+	Customer Customer
+	// AccessToken is handed to the services along with the calls made on behalf of the Customer
+	AccessToken AccessToken
+}
+
+// AccessToken proves who a Customer is until it expires
+type AccessToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,21 @@
+package domain
+
+// EventType is something that happened to the account of a Customer
+type EventType string
+
+const (
+	// EventRegistered carries the token verifying the email address of the new Customer
+	EventRegistered EventType = "registered"
+	// EventVerificationRequested carries a new token verifying the email address of the Customer, e.g. after changing it
+	EventVerificationRequested EventType = "verification_requested"
+	EventEmailVerified         EventType = "email_verified"
+	EventDeleted               EventType = "deleted"
+)
+
+// CustomerEvent tells the other services about a change to the account of a Customer
+type CustomerEvent struct {
+	Type     EventType
+	Customer Customer
+	// VerificationToken is only set on the events asking the Customer to verify the email address
+	VerificationToken string
+}
@@ -0,0 +1,22 @@
+-- the address book is kept along with its customer, which is always read and written as a whole
+CREATE TABLE IF NOT EXISTS customers (
+    id                      TEXT PRIMARY KEY,
+    email                   TEXT        NOT NULL UNIQUE,
+    name                    TEXT        NOT NULL,
+    phone                   TEXT        NOT NULL DEFAULT '',
+    password_hash           TEXT        NOT NULL,
+    email_verified          BOOLEAN     NOT NULL DEFAULT FALSE,
+    verification_token_hash TEXT,
+    verification_expires_at TIMESTAMPTZ,
+    addresses               JSONB       NOT NULL DEFAULT '[]',
+    next_address_id         INTEGER     NOT NULL DEFAULT 1,
+    version                 INTEGER     NOT NULL,
+    created_at              TIMESTAMPTZ NOT NULL,
+    updated_at              TIMESTAMPTZ NOT NULL,
+    CONSTRAINT customers_verification_check CHECK (
+        (verification_token_hash IS NULL) = (verification_expires_at IS NULL)
+    )
+);
+
+-- the emails verifying the addresses only carry the token, so it's how their customer is found
+CREATE UNIQUE INDEX customers_verification_token_hash_idx ON customers (verification_token_hash) WHERE verification_token_hash IS NOT NULL;
@@ -0,0 +1,387 @@
+package grpc_port
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	pb "github.com/lucasmls/ecommerce/services/customers/ports/grpc/proto"
+	"github.com/lucasmls/ecommerce/shared/auth"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	InternalServerError   = status.Error(codes.Internal, "Internal server error")
+	UnauthenticatedError  = status.Error(codes.Unauthenticated, "missing-token")
+	PermissionDeniedError = status.Error(codes.PermissionDenied, "permission-denied")
+)
+
+var (
+	ErrMissingLogger = errors.New("missing required dependency: Logger")
+	ErrMisingTracer  = errors.New("missing required dependency: Tracer")
+)
+
+// CustomersResolver ...
+type CustomersResolver struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+	App    domain.Application
+
+	pb.UnimplementedCustomersServiceServer
+}
+
+func NewCustomersResolver(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) (*CustomersResolver, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMisingTracer
+	}
+
+	return &CustomersResolver{
+		Logger: logger,
+		Tracer: tracer,
+		App:    app,
+	}, nil
+}
+
+// MustNewCustomersResolver creates a new CustomersResolver instance.
+// It panics if any error is found
+func MustNewCustomersResolver(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) *CustomersResolver {
+	customersResolver, err := NewCustomersResolver(logger, tracer, app)
+	if err != nil {
+		panic(err)
+	}
+
+	return customersResolver
+}
+
+func (r *CustomersResolver) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.Register")
+	defer span.End()
+
+	customer, err := r.App.Register(ctx, domain.RegistrationRequest{
+		Email:    req.Email,
+		Password: req.Password,
+		Name:     req.Name,
+		Phone:    req.Phone,
+	})
+	if err != nil {
+		return nil, r.toError(err, "failed to register the customer", zap.String("email", req.Email))
+	}
+
+	return &pb.RegisterResponse{Data: toProtoCustomer(customer)}, nil
+}
+
+func (r *CustomersResolver) RequestEmailVerification(ctx context.Context, req *pb.RequestEmailVerificationRequest) (*pb.RequestEmailVerificationResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.RequestEmailVerification")
+	defer span.End()
+
+	if err := authorize(ctx, req.CustomerId); err != nil {
+		return nil, err
+	}
+
+	customer, err := r.App.RequestEmailVerification(ctx, req.CustomerId)
+	if err != nil {
+		return nil, r.toError(err, "failed to request the email verification", zap.String("customerID", req.CustomerId))
+	}
+
+	return &pb.RequestEmailVerificationResponse{Data: toProtoCustomer(customer)}, nil
+}
+
+func (r *CustomersResolver) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.VerifyEmailResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.VerifyEmail")
+	defer span.End()
+
+	customer, err := r.App.VerifyEmail(ctx, req.Token)
+	if err != nil {
+		return nil, r.toError(err, "failed to verify the email address")
+	}
+
+	return &pb.VerifyEmailResponse{Data: toProtoCustomer(customer)}, nil
+}
+
+func (r *CustomersResolver) Authenticate(ctx context.Context, req *pb.AuthenticateRequest) (*pb.AuthenticateResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.Authenticate")
+	defer span.End()
+
+	session, err := r.App.Authenticate(ctx, domain.Credentials{
+		Email:    req.Email,
+		Password: req.Password,
+	})
+	if err != nil {
+		return nil, r.toError(err, "failed to authenticate the customer", zap.String("email", req.Email))
+	}
+
+	return &pb.AuthenticateResponse{
+		Data: toProtoCustomer(session.Customer),
+		AccessToken: &pb.AccessToken{
+			Token:     session.AccessToken.Token,
+			ExpiresAt: toProtoTimestamp(session.AccessToken.ExpiresAt),
+		},
+	}, nil
+}
+
+func (r *CustomersResolver) ValidateToken(ctx context.Context, req *pb.ValidateTokenRequest) (*pb.ValidateTokenResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.ValidateToken")
+	defer span.End()
+
+	customer, err := r.App.ValidateToken(ctx, req.Token)
+	if err != nil {
+		return nil, r.toError(err, "failed to validate the token")
+	}
+
+	return &pb.ValidateTokenResponse{Data: toProtoCustomer(customer)}, nil
+}
+
+func (r *CustomersResolver) GetCustomer(ctx context.Context, req *pb.GetCustomerRequest) (*pb.GetCustomerResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.GetCustomer")
+	defer span.End()
+
+	if err := authorize(ctx, req.Id); err != nil {
+		return nil, err
+	}
+
+	customer, err := r.App.GetCustomer(ctx, req.Id)
+	if err != nil {
+		return nil, r.toError(err, "failed to get the customer", zap.String("id", req.Id))
+	}
+
+	return &pb.GetCustomerResponse{Data: toProtoCustomer(customer)}, nil
+}
+
+func (r *CustomersResolver) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.UpdateProfileResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.UpdateProfile")
+	defer span.End()
+
+	if err := authorize(ctx, req.CustomerId); err != nil {
+		return nil, err
+	}
+
+	customer, err := r.App.UpdateProfile(ctx, domain.ProfileUpdate{
+		CustomerID: req.CustomerId,
+		Email:      req.Email,
+		Name:       req.Name,
+		Phone:      req.Phone,
+	})
+	if err != nil {
+		return nil, r.toError(err, "failed to update the profile", zap.String("customerID", req.CustomerId))
+	}
+
+	return &pb.UpdateProfileResponse{Data: toProtoCustomer(customer)}, nil
+}
+
+func (r *CustomersResolver) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.ChangePassword")
+	defer span.End()
+
+	if err := authorize(ctx, req.CustomerId); err != nil {
+		return nil, err
+	}
+
+	customer, err := r.App.ChangePassword(ctx, domain.PasswordChange{
+		CustomerID:      req.CustomerId,
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+	})
+	if err != nil {
+		return nil, r.toError(err, "failed to change the password", zap.String("customerID", req.CustomerId))
+	}
+
+	return &pb.ChangePasswordResponse{Data: toProtoCustomer(customer)}, nil
+}
+
+func (r *CustomersResolver) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.DeleteAccountResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.DeleteAccount")
+	defer span.End()
+
+	if err := authorize(ctx, req.CustomerId); err != nil {
+		return nil, err
+	}
+
+	err := r.App.DeleteAccount(ctx, domain.AccountDeletion{
+		CustomerID: req.CustomerId,
+		Password:   req.Password,
+	})
+	if err != nil {
+		return nil, r.toError(err, "failed to delete the account", zap.String("customerID", req.CustomerId))
+	}
+
+	return &pb.DeleteAccountResponse{}, nil
+}
+
+func (r *CustomersResolver) AddAddress(ctx context.Context, req *pb.AddAddressRequest) (*pb.AddAddressResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.AddAddress")
+	defer span.End()
+
+	if err := authorize(ctx, req.CustomerId); err != nil {
+		return nil, err
+	}
+
+	customer, err := r.App.AddAddress(ctx, domain.AddressRequest{
+		CustomerID: req.CustomerId,
+		Address:    toDomainAddress(req.Address),
+	})
+	if err != nil {
+		return nil, r.toError(err, "failed to add the address", zap.String("customerID", req.CustomerId))
+	}
+
+	return &pb.AddAddressResponse{Data: toProtoCustomer(customer)}, nil
+}
+
+func (r *CustomersResolver) UpdateAddress(ctx context.Context, req *pb.UpdateAddressRequest) (*pb.UpdateAddressResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.UpdateAddress")
+	defer span.End()
+
+	if err := authorize(ctx, req.CustomerId); err != nil {
+		return nil, err
+	}
+
+	customer, err := r.App.UpdateAddress(ctx, domain.AddressRequest{
+		CustomerID: req.CustomerId,
+		Address:    toDomainAddress(req.Address),
+	})
+	if err != nil {
+		return nil, r.toError(err, "failed to update the address", zap.String("customerID", req.CustomerId))
+	}
+
+	return &pb.UpdateAddressResponse{Data: toProtoCustomer(customer)}, nil
+}
+
+func (r *CustomersResolver) RemoveAddress(ctx context.Context, req *pb.RemoveAddressRequest) (*pb.RemoveAddressResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.RemoveAddress")
+	defer span.End()
+
+	if err := authorize(ctx, req.CustomerId); err != nil {
+		return nil, err
+	}
+
+	removal := domain.AddressRemoval{
+		CustomerID: req.CustomerId,
+		AddressID:  int(req.AddressId),
+	}
+
+	customer, err := r.App.RemoveAddress(ctx, removal)
+	if err != nil {
+		return nil, r.toError(err, "failed to remove the address", zap.Any("removal", removal))
+	}
+
+	return &pb.RemoveAddressResponse{Data: toProtoCustomer(customer)}, nil
+}
+
+// authorize checks the call carries the access token of the Customer it's about
+func authorize(ctx context.Context, customerID string) error {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return UnauthenticatedError
+	}
+
+	if claims.Subject != customerID {
+		return PermissionDeniedError
+	}
+
+	return nil
+}
+
+// toError maps the Customer errors to their gRPC codes, the bad credentials and tokens being Unauthenticated.
+// Unknown errors are logged and reported as internal, so no detail about the accounts leaks.
+func (r *CustomersResolver) toError(err error, message string, fields ...zap.Field) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidCustomer),
+		errors.Is(err, domain.ErrInvalidEmail),
+		errors.Is(err, domain.ErrInvalidPassword),
+		errors.Is(err, domain.ErrInvalidAddress),
+		errors.Is(err, domain.ErrInvalidVerificationToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrCustomerNotFound),
+		errors.Is(err, domain.ErrAddressNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrEmailAlreadyRegistered):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrInvalidCredentials),
+		errors.Is(err, domain.ErrInvalidToken),
+		errors.Is(err, domain.ErrTokenExpired):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, domain.ErrEmailAlreadyVerified),
+		errors.Is(err, domain.ErrTooManyAddresses):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrCustomerVersionConflict):
+		return status.Error(codes.Aborted, err.Error())
+	}
+
+	r.Logger.Error(message, append(fields, zap.Error(err))...)
+	return InternalServerError
+}
+
+func toDomainAddress(address *pb.Address) domain.Address {
+	if address == nil {
+		return domain.Address{}
+	}
+
+	return domain.Address{
+		ID:         int(address.Id),
+		Label:      address.Label,
+		Recipient:  address.Recipient,
+		Line1:      address.Line1,
+		Line2:      address.Line2,
+		City:       address.City,
+		Region:     address.Region,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+		Phone:      address.Phone,
+		Default:    address.Default,
+	}
+}
+
+func toProtoCustomer(customer domain.Customer) *pb.Customer {
+	addresses := make([]*pb.Address, 0, len(customer.Addresses))
+	for _, address := range customer.Addresses {
+		addresses = append(addresses, &pb.Address{
+			Id:         int32(address.ID),
+			Label:      address.Label,
+			Recipient:  address.Recipient,
+			Line1:      address.Line1,
+			Line2:      address.Line2,
+			City:       address.City,
+			Region:     address.Region,
+			PostalCode: address.PostalCode,
+			Country:    address.Country,
+			Phone:      address.Phone,
+			Default:    address.Default,
+		})
+	}
+
+	return &pb.Customer{
+		Id:            customer.ID,
+		Email:         customer.Email,
+		Name:          customer.Name,
+		Phone:         customer.Phone,
+		EmailVerified: customer.EmailVerified,
+		Addresses:     addresses,
+		Version:       int32(customer.Version),
+		CreatedAt:     toProtoTimestamp(customer.CreatedAt),
+		UpdatedAt:     toProtoTimestamp(customer.UpdatedAt),
+	}
+}
+
+func toProtoTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
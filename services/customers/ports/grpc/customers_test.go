@@ -0,0 +1,346 @@
+package grpc_port
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/shared/auth"
+	"github.com/lucasmls/ecommerce/shared/grpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	gGRPC "google.golang.org/grpc"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/lucasmls/ecommerce/services/customers/domain"
+	"github.com/lucasmls/ecommerce/services/customers/mocks"
+	protog "github.com/lucasmls/ecommerce/services/customers/ports/grpc/proto"
+)
+
+const customerID = "0123456789abcdef0123456789abcdef"
+
+var tokenSecret = []byte("secret")
+
+type CustomersResolverSuite struct {
+	suite.Suite
+
+	app    *mocks.Application
+	logger *zap.Logger
+	tracer trace.Tracer
+
+	grpcClient     protog.CustomersServiceClient
+	grpcConnection *gGRPC.ClientConn
+}
+
+func (s *CustomersResolverSuite) SetupSuite() {
+	s.logger = zap.NewNop()
+	s.tracer = trace.NewNoopTracerProvider().Tracer("")
+	s.app = &mocks.Application{}
+
+	grpcClient, grpcConnection := makeGrpcClientAndServer(
+		context.Background(),
+		s.logger,
+		s.tracer,
+		s.app,
+	)
+
+	s.grpcClient = grpcClient
+	s.grpcConnection = grpcConnection
+}
+
+func (s *CustomersResolverSuite) TearDownSuite() {
+	err := s.grpcConnection.Close()
+	s.NoError(err)
+}
+
+// asCustomer returns a context carrying an access token of the given Customer, sent along with the calls
+func (s *CustomersResolverSuite) asCustomer(id string) context.Context {
+	now := time.Now()
+	claims := auth.Claims{Subject: id, IssuedAt: now, ExpiresAt: now.Add(time.Hour)}
+
+	token, err := auth.Sign(tokenSecret, claims)
+	s.Require().NoError(err)
+
+	return auth.NewContext(context.Background(), token, claims)
+}
+
+func (s *CustomersResolverSuite) Test_NewCustomersResolver() {
+	s.Run("Should fail to instantiate the CustomersResolver in case a Logger isn't provided", func() {
+		_, err := NewCustomersResolver(nil, nil, nil)
+
+		s.Equal(ErrMissingLogger, err)
+	})
+
+	s.Run("Should fail to instantiate the CustomersResolver in case a Tracer isn't provided", func() {
+		_, err := NewCustomersResolver(s.logger, nil, nil)
+
+		s.Equal(ErrMisingTracer, err)
+	})
+
+	s.Run("Should successfully instantiate the CustomersResolver", func() {
+		_, err := NewCustomersResolver(s.logger, s.tracer, nil)
+
+		s.NoError(err)
+	})
+}
+
+func (s *CustomersResolverSuite) Test_Register() {
+	request := domain.RegistrationRequest{
+		Email:    "john@example.com",
+		Password: "correct horse battery staple",
+		Name:     "John Doe",
+	}
+
+	s.Run("Should return an already exists error in case the email address is taken", func() {
+		s.app.On("Register", mock.AnythingOfType("*context.valueCtx"), request).Return(domain.Customer{}, domain.ErrEmailAlreadyRegistered).Once()
+
+		_, err := s.grpcClient.Register(context.Background(), &protog.RegisterRequest{
+			Email:    "john@example.com",
+			Password: "correct horse battery staple",
+			Name:     "John Doe",
+		})
+
+		s.Equal(status.Error(codes.AlreadyExists, domain.ErrEmailAlreadyRegistered.Error()), err)
+	})
+
+	s.Run("Should return the registered customer", func() {
+		s.app.On("Register", mock.AnythingOfType("*context.valueCtx"), request).Return(domain.Customer{
+			ID:           customerID,
+			Email:        "john@example.com",
+			Name:         "John Doe",
+			PasswordHash: "$argon2id$...",
+			Version:      1,
+		}, nil).Once()
+
+		response, err := s.grpcClient.Register(context.Background(), &protog.RegisterRequest{
+			Email:    "john@example.com",
+			Password: "correct horse battery staple",
+			Name:     "John Doe",
+		})
+
+		s.NoError(err)
+		s.Equal(customerID, response.Data.Id)
+		s.Equal("john@example.com", response.Data.Email)
+		s.False(response.Data.EmailVerified)
+		s.Equal(int32(1), response.Data.Version)
+	})
+}
+
+func (s *CustomersResolverSuite) Test_Authenticate() {
+	credentials := domain.Credentials{Email: "john@example.com", Password: "wrong password"}
+
+	s.Run("Should return an unauthenticated error in case the credentials are wrong", func() {
+		s.app.On("Authenticate", mock.AnythingOfType("*context.valueCtx"), credentials).Return(domain.Session{}, domain.ErrInvalidCredentials).Once()
+
+		_, err := s.grpcClient.Authenticate(context.Background(), &protog.AuthenticateRequest{
+			Email:    "john@example.com",
+			Password: "wrong password",
+		})
+
+		s.Equal(status.Error(codes.Unauthenticated, domain.ErrInvalidCredentials.Error()), err)
+	})
+
+	s.Run("Should return the customer along with the access token", func() {
+		expiresAt := time.Date(2022, 5, 10, 11, 0, 0, 0, time.UTC)
+
+		s.app.On("Authenticate", mock.AnythingOfType("*context.valueCtx"), credentials).Return(domain.Session{
+			Customer:    domain.Customer{ID: customerID},
+			AccessToken: domain.AccessToken{Token: "token", ExpiresAt: expiresAt},
+		}, nil).Once()
+
+		response, err := s.grpcClient.Authenticate(context.Background(), &protog.AuthenticateRequest{
+			Email:    "john@example.com",
+			Password: "wrong password",
+		})
+
+		s.NoError(err)
+		s.Equal(customerID, response.Data.Id)
+		s.Equal("token", response.AccessToken.Token)
+		s.Equal(expiresAt, response.AccessToken.ExpiresAt.AsTime())
+	})
+}
+
+func (s *CustomersResolverSuite) Test_GetCustomer() {
+	s.Run("Should return an unauthenticated error in case the call carries no token", func() {
+		_, err := s.grpcClient.GetCustomer(context.Background(), &protog.GetCustomerRequest{Id: customerID})
+
+		s.Equal(status.Error(codes.Unauthenticated, "missing-token"), err)
+	})
+
+	s.Run("Should return an unauthenticated error in case the token can't be verified", func() {
+		claims := auth.Claims{Subject: customerID, ExpiresAt: time.Now().Add(time.Hour)}
+		token, err := auth.Sign([]byte("another-secret"), claims)
+		s.Require().NoError(err)
+
+		_, err = s.grpcClient.GetCustomer(auth.NewContext(context.Background(), token, claims), &protog.GetCustomerRequest{Id: customerID})
+
+		s.Equal(codes.Unauthenticated, status.Code(err))
+	})
+
+	s.Run("Should return a permission denied error in case the token belongs to another customer", func() {
+		_, err := s.grpcClient.GetCustomer(s.asCustomer("someone-else"), &protog.GetCustomerRequest{Id: customerID})
+
+		s.Equal(status.Error(codes.PermissionDenied, "permission-denied"), err)
+	})
+
+	s.Run("Should return a not found error in case the customer doesn't exist", func() {
+		s.app.On("GetCustomer", mock.AnythingOfType("*context.valueCtx"), customerID).Return(domain.Customer{}, domain.ErrCustomerNotFound).Once()
+
+		_, err := s.grpcClient.GetCustomer(s.asCustomer(customerID), &protog.GetCustomerRequest{Id: customerID})
+
+		s.Equal(status.Error(codes.NotFound, domain.ErrCustomerNotFound.Error()), err)
+	})
+
+	s.Run("Should return the customer with the address book", func() {
+		s.app.On("GetCustomer", mock.AnythingOfType("*context.valueCtx"), customerID).Return(domain.Customer{
+			ID:    customerID,
+			Email: "john@example.com",
+			Addresses: []domain.Address{
+				{ID: 1, Recipient: "John Doe", Line1: "Av. Paulista, 1000", City: "São Paulo", PostalCode: "01310-100", Country: "BR", Default: true},
+			},
+		}, nil).Once()
+
+		response, err := s.grpcClient.GetCustomer(s.asCustomer(customerID), &protog.GetCustomerRequest{Id: customerID})
+
+		s.NoError(err)
+		s.Len(response.Data.Addresses, 1)
+		s.Equal(int32(1), response.Data.Addresses[0].Id)
+		s.True(response.Data.Addresses[0].Default)
+	})
+}
+
+func (s *CustomersResolverSuite) Test_AddAddress() {
+	request := domain.AddressRequest{
+		CustomerID: customerID,
+		Address:    domain.Address{Recipient: "John Doe", Line1: "Av. Paulista, 1000", City: "São Paulo", PostalCode: "01310-100", Country: "br"},
+	}
+
+	s.Run("Should return an invalid argument error in case the address is invalid", func() {
+		s.app.On("AddAddress", mock.AnythingOfType("*context.valueCtx"), request).Return(domain.Customer{}, domain.ErrInvalidAddress).Once()
+
+		_, err := s.grpcClient.AddAddress(s.asCustomer(customerID), &protog.AddAddressRequest{
+			CustomerId: customerID,
+			Address: &protog.Address{
+				Recipient:  "John Doe",
+				Line1:      "Av. Paulista, 1000",
+				City:       "São Paulo",
+				PostalCode: "01310-100",
+				Country:    "br",
+			},
+		})
+
+		s.Equal(status.Error(codes.InvalidArgument, domain.ErrInvalidAddress.Error()), err)
+	})
+
+	s.Run("Should return a failed precondition error in case the address book is full", func() {
+		s.app.On("AddAddress", mock.AnythingOfType("*context.valueCtx"), request).Return(domain.Customer{}, domain.ErrTooManyAddresses).Once()
+
+		_, err := s.grpcClient.AddAddress(s.asCustomer(customerID), &protog.AddAddressRequest{
+			CustomerId: customerID,
+			Address: &protog.Address{
+				Recipient:  "John Doe",
+				Line1:      "Av. Paulista, 1000",
+				City:       "São Paulo",
+				PostalCode: "01310-100",
+				Country:    "br",
+			},
+		})
+
+		s.Equal(status.Error(codes.FailedPrecondition, domain.ErrTooManyAddresses.Error()), err)
+	})
+}
+
+func (s *CustomersResolverSuite) Test_DeleteAccount() {
+	s.Run("Should return a permission denied error in case the token belongs to another customer", func() {
+		_, err := s.grpcClient.DeleteAccount(s.asCustomer("someone-else"), &protog.DeleteAccountRequest{
+			CustomerId: customerID,
+			Password:   "correct horse battery staple",
+		})
+
+		s.Equal(status.Error(codes.PermissionDenied, "permission-denied"), err)
+	})
+
+	s.Run("Should delete the account", func() {
+		deletion := domain.AccountDeletion{CustomerID: customerID, Password: "correct horse battery staple"}
+		s.app.On("DeleteAccount", mock.AnythingOfType("*context.valueCtx"), deletion).Return(nil).Once()
+
+		_, err := s.grpcClient.DeleteAccount(s.asCustomer(customerID), &protog.DeleteAccountRequest{
+			CustomerId: customerID,
+			Password:   "correct horse battery staple",
+		})
+
+		s.NoError(err)
+	})
+}
+
+func TestCustomersResolverSuite(t *testing.T) {
+	suite.Run(t, new(CustomersResolverSuite))
+}
+
+func makeGrpcClientAndServer(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	tracerM trace.Tracer,
+	appM domain.Application,
+) (protog.CustomersServiceClient, *gGRPC.ClientConn) {
+	listener := makeGrpcServer(ctx, loggerM, tracerM, appM)
+	grpcClient, grpcConnection := makeGrpcClient(ctx, loggerM, listener)
+
+	return grpcClient, grpcConnection
+}
+
+func makeGrpcClient(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	listener *bufconn.Listener,
+) (protog.CustomersServiceClient, *gGRPC.ClientConn) {
+	customersServiceGRPCClient := grpc.MustNewClient(grpc.ClientInput{
+		Logger: loggerM,
+		AdditionalDialOptions: []gGRPC.DialOption{
+			gGRPC.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+				return listener.Dial()
+			}),
+		},
+	})
+
+	customersServiceConn := customersServiceGRPCClient.MustConnect(ctx)
+
+	grpcClient := protog.NewCustomersServiceClient(customersServiceConn)
+	return grpcClient, customersServiceConn
+}
+
+func makeGrpcServer(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	tracerM trace.Tracer,
+	appM domain.Application,
+) *bufconn.Listener {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.MustNewServer(grpc.ServerInput{
+		Port:   1234,
+		Logger: loggerM,
+		Registrator: func(server gGRPC.ServiceRegistrar) {
+			protog.RegisterCustomersServiceServer(server, &CustomersResolver{
+				Logger: loggerM,
+				Tracer: tracerM,
+				App:    appM,
+			})
+		},
+		Listener:    listener,
+		TokenSecret: tokenSecret,
+	})
+
+	go func() {
+		if err := grpcServer.Run(ctx); err != nil {
+			panic(err)
+		}
+	}()
+
+	return listener
+}
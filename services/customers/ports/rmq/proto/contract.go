@@ -0,0 +1,9 @@
+package rmq_protobuf
+
+// Exchange is the topic exchange the CustomerEvents go through
+const Exchange = "customers"
+
+// EventRoutingKey routes the CustomerEvents of a type, e.g. customers.registered
+func EventRoutingKey(eventType string) string {
+	return "customers." + eventType
+}
@@ -0,0 +1,50 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	pb "github.com/lucasmls/ecommerce/services/inventory/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/shared/rmq"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RmqEventPublisher publishes the StockEvents through the inventory exchange of RabbitMQ, routed by their type.
+type RmqEventPublisher struct {
+	Logger    *zap.Logger
+	Tracer    trace.Tracer
+	Publisher rmq.Publisher
+}
+
+// NewRmqEventPublisher creates a new RmqEventPublisher.
+func NewRmqEventPublisher(logger *zap.Logger, tracer trace.Tracer, publisher rmq.Publisher) *RmqEventPublisher {
+	return &RmqEventPublisher{
+		Logger:    logger,
+		Tracer:    tracer,
+		Publisher: publisher,
+	}
+}
+
+// Publish publishes a StockEvent, routed by its type
+func (p *RmqEventPublisher) Publish(ctx context.Context, event domain.StockEvent) error {
+	ctx, span := p.Tracer.Start(ctx, "events.Publish")
+	defer span.End()
+
+	return p.Publisher.Publish(ctx, pb.Exchange, pb.EventRoutingKey(string(event.Type)), &pb.StockEvent{
+		Type:       string(event.Type),
+		ProductId:  int32(event.ProductID),
+		Available:  int32(event.Available),
+		OccurredAt: toProtoTimestamp(event.OccurredAt),
+	})
+}
+
+func toProtoTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
@@ -0,0 +1,64 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	pb "github.com/lucasmls/ecommerce/services/inventory/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/shared/rmq/rmqtest"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type RmqEventPublisherSuite struct {
+	suite.Suite
+
+	publisher      *rmqtest.Publisher
+	eventPublisher *RmqEventPublisher
+}
+
+func (s *RmqEventPublisherSuite) SetupTest() {
+	s.publisher = &rmqtest.Publisher{}
+	s.eventPublisher = NewRmqEventPublisher(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), s.publisher)
+}
+
+func (s *RmqEventPublisherSuite) Test_Publish() {
+	s.Run("Should route the event by its type", func() {
+		occurredAt := time.Date(2022, 10, 19, 8, 0, 0, 0, time.UTC)
+
+		err := s.eventPublisher.Publish(context.Background(), domain.StockEvent{
+			Type:       domain.EventBackInStock,
+			ProductID:  2,
+			Available:  3,
+			OccurredAt: occurredAt,
+		})
+
+		s.NoError(err)
+		s.Equal("inventory", s.publisher.Exchange)
+		s.Equal("inventory.back_in_stock", s.publisher.RoutingKey)
+		s.True(proto.Equal(&pb.StockEvent{
+			Type:       "back_in_stock",
+			ProductId:  2,
+			Available:  3,
+			OccurredAt: timestamppb.New(occurredAt),
+		}, s.publisher.Message))
+	})
+
+	s.Run("Should fail in case the event can't be published", func() {
+		s.publisher.Err = errors.New("connection closed")
+
+		err := s.eventPublisher.Publish(context.Background(), domain.StockEvent{Type: domain.EventBackInStock})
+
+		s.Equal(s.publisher.Err, err)
+	})
+}
+
+func TestRmqEventPublisherSuite(t *testing.T) {
+	suite.Run(t, new(RmqEventPublisherSuite))
+}
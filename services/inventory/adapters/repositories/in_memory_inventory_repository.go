@@ -0,0 +1,348 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// stockKey identifies a StockLevel
+type stockKey struct {
+	ProductID int
+	SKU       string
+	Warehouse string
+}
+
+// InMemoryInventoryRepository is both the StockRepository and the ReservationsRepository,
+// so the Reservations change the StockLevels atomically.
+type InMemoryInventoryRepository struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	// mu guards levels and reservations, whose IDs are their positions in the slice
+	mu           sync.Mutex
+	levels       map[stockKey]domain.StockLevel
+	reservations []domain.Reservation
+}
+
+// NewInMemoryInventoryRepository creates a new InMemoryInventoryRepository.
+func NewInMemoryInventoryRepository(logger *zap.Logger, tracer trace.Tracer) *InMemoryInventoryRepository {
+	return &InMemoryInventoryRepository{
+		Logger: logger,
+		Tracer: tracer,
+		levels: map[stockKey]domain.StockLevel{},
+	}
+}
+
+// List lists the StockLevels matching the filter from memory, ordered by Product, SKU and Warehouse.
+func (r *InMemoryInventoryRepository) List(ctx context.Context, filter domain.StockFilter) ([]domain.StockLevel, error) {
+	_, span := r.Tracer.Start(ctx, "repository.List")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	productIDs := map[int]bool{}
+	for _, id := range filter.ProductIDs {
+		productIDs[id] = true
+	}
+
+	result := []domain.StockLevel{}
+	for _, level := range r.levels {
+		if len(productIDs) > 0 && !productIDs[level.ProductID] {
+			continue
+		}
+
+		if filter.SKU != "" && level.SKU != filter.SKU {
+			continue
+		}
+
+		if filter.Warehouse != "" && level.Warehouse != filter.Warehouse {
+			continue
+		}
+
+		result = append(result, level)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return lessKey(keyOf(result[i]), keyOf(result[j]))
+	})
+
+	return result, nil
+}
+
+// Set sets the OnHand units of a StockLevel in memory, creating it when missing.
+func (r *InMemoryInventoryRepository) Set(ctx context.Context, change domain.StockChange) (domain.StockLevel, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Set")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.setOnHand(change, func(domain.StockLevel) int {
+		return change.Quantity
+	})
+}
+
+// Adjust adds the Quantity of the change to the OnHand units of a StockLevel in memory, creating it when missing.
+func (r *InMemoryInventoryRepository) Adjust(ctx context.Context, change domain.StockChange) (domain.StockLevel, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Adjust")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.setOnHand(change, func(level domain.StockLevel) int {
+		return level.OnHand + change.Quantity
+	})
+}
+
+// setOnHand sets the OnHand units of the StockLevel of the change to the ones computed from the current StockLevel.
+// It must be called with mu held.
+func (r *InMemoryInventoryRepository) setOnHand(change domain.StockChange, onHand func(domain.StockLevel) int) (domain.StockLevel, error) {
+	key := stockKey{change.ProductID, change.SKU, change.Warehouse}
+
+	level, ok := r.levels[key]
+	if !ok {
+		level = domain.StockLevel{ProductID: change.ProductID, SKU: change.SKU, Warehouse: change.Warehouse}
+	}
+
+	units := onHand(level)
+	if units < level.Reserved || units < 0 {
+		return domain.StockLevel{}, domain.ErrStockBelowReserved
+	}
+
+	level.OnHand = units
+	level.UpdatedAt = time.Now()
+	r.levels[key] = level
+
+	return level, nil
+}
+
+// Reserve stores a pending Reservation in memory, holding the units of its items.
+func (r *InMemoryInventoryRepository) Reserve(ctx context.Context, reservation domain.Reservation) (domain.Reservation, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Reserve")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.reservationByReference(reservation.Reference); ok {
+		return domain.Reservation{}, domain.ErrReservationReferenceTaken
+	}
+
+	// every item is checked before any unit is held, so a failure holds none of them
+	requested := map[stockKey]int{}
+	for _, item := range reservation.Items {
+		key := stockKey{item.ProductID, item.SKU, item.Warehouse}
+		requested[key] += item.Quantity
+
+		level, ok := r.levels[key]
+		if !ok || level.Available() < requested[key] {
+			return domain.Reservation{}, domain.ErrInsufficientStock
+		}
+	}
+
+	now := time.Now()
+	for key, quantity := range requested {
+		level := r.levels[key]
+		level.Reserved += quantity
+		level.UpdatedAt = now
+		r.levels[key] = level
+	}
+
+	reservation.ID = len(r.reservations) + 1
+	reservation.Items = append([]domain.ReservationItem{}, reservation.Items...)
+
+	r.reservations = append(r.reservations, reservation)
+	return copyReservation(reservation), nil
+}
+
+// GetByReference fetches the Reservation with the given non-empty reference from memory.
+func (r *InMemoryInventoryRepository) GetByReference(ctx context.Context, reference string) (domain.Reservation, error) {
+	_, span := r.Tracer.Start(ctx, "repository.GetByReference")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reservation, ok := r.reservationByReference(reference)
+	if !ok {
+		return domain.Reservation{}, domain.ErrReservationNotFound
+	}
+
+	return copyReservation(reservation), nil
+}
+
+// reservationByReference finds the Reservation with the given reference, the empty one never matches
+func (r *InMemoryInventoryRepository) reservationByReference(reference string) (domain.Reservation, bool) {
+	if reference == "" {
+		return domain.Reservation{}, false
+	}
+
+	for _, reservation := range r.reservations {
+		if reservation.Reference == reference {
+			return reservation, true
+		}
+	}
+
+	return domain.Reservation{}, false
+}
+
+// Get fetches a single Reservation from memory.
+func (r *InMemoryInventoryRepository) Get(ctx context.Context, id int) (domain.Reservation, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Get")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reservation, err := r.reservation(id)
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+
+	return copyReservation(*reservation), nil
+}
+
+// Commit takes the units of a pending Reservation out of the stock kept in memory.
+func (r *InMemoryInventoryRepository) Commit(ctx context.Context, id int, now time.Time) (domain.Reservation, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Commit")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reservation, err := r.reservation(id)
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+
+	if reservation.Status != domain.ReservationStatusPending {
+		return domain.Reservation{}, domain.ErrReservationNotPending
+	}
+
+	if reservation.Expired(now) {
+		return domain.Reservation{}, domain.ErrReservationExpired
+	}
+
+	r.unhold(reservation.Items, true)
+
+	reservation.Status = domain.ReservationStatusCommitted
+	reservation.UpdatedAt = now
+
+	return copyReservation(*reservation), nil
+}
+
+// Release gives the units of a pending Reservation back to the stock kept in memory.
+func (r *InMemoryInventoryRepository) Release(ctx context.Context, id int, status domain.ReservationStatus) (domain.Reservation, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Release")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reservation, err := r.reservation(id)
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+
+	switch reservation.Status {
+	case domain.ReservationStatusReleased, domain.ReservationStatusExpired:
+		return copyReservation(*reservation), nil
+	case domain.ReservationStatusCommitted:
+		return domain.Reservation{}, domain.ErrReservationNotPending
+	}
+
+	r.unhold(reservation.Items, false)
+
+	reservation.Status = status
+	reservation.UpdatedAt = time.Now()
+
+	return copyReservation(*reservation), nil
+}
+
+// Expire releases, as expired, up to the limit of the pending Reservations kept in memory that expired at the given time.
+func (r *InMemoryInventoryRepository) Expire(ctx context.Context, now time.Time, limit int) ([]domain.Reservation, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Expire")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := []domain.Reservation{}
+	for i := range r.reservations {
+		if len(result) >= limit {
+			break
+		}
+
+		reservation := &r.reservations[i]
+		if !reservation.Expired(now) {
+			continue
+		}
+
+		r.unhold(reservation.Items, false)
+
+		reservation.Status = domain.ReservationStatusExpired
+		reservation.UpdatedAt = now
+
+		result = append(result, copyReservation(*reservation))
+	}
+
+	return result, nil
+}
+
+// reservation finds a stored Reservation, it must be called with mu held
+func (r *InMemoryInventoryRepository) reservation(id int) (*domain.Reservation, error) {
+	if id <= 0 || id > len(r.reservations) {
+		return nil, domain.ErrReservationNotFound
+	}
+
+	return &r.reservations[id-1], nil
+}
+
+// unhold removes the units of the items from the Reserved units of their StockLevels, and from the OnHand
+// units too when they're taken out of the stock. It must be called with mu held.
+func (r *InMemoryInventoryRepository) unhold(items []domain.ReservationItem, takeOut bool) {
+	now := time.Now()
+	for _, item := range items {
+		key := stockKey{item.ProductID, item.SKU, item.Warehouse}
+
+		level := r.levels[key]
+		level.Reserved -= item.Quantity
+		if takeOut {
+			level.OnHand -= item.Quantity
+		}
+
+		level.UpdatedAt = now
+		r.levels[key] = level
+	}
+}
+
+func keyOf(level domain.StockLevel) stockKey {
+	return stockKey{level.ProductID, level.SKU, level.Warehouse}
+}
+
+// lessKey orders the StockLevels by Product, SKU and Warehouse
+func lessKey(left stockKey, right stockKey) bool {
+	if left.ProductID != right.ProductID {
+		return left.ProductID < right.ProductID
+	}
+
+	if left.SKU != right.SKU {
+		return left.SKU < right.SKU
+	}
+
+	return left.Warehouse < right.Warehouse
+}
+
+// copyReservation keeps the stored Items from being changed through the Reservations handed out by the repository
+func copyReservation(reservation domain.Reservation) domain.Reservation {
+	reservation.Items = append([]domain.ReservationItem{}, reservation.Items...)
+	return reservation
+}
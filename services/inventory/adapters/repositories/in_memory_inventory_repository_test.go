@@ -0,0 +1,273 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type InMemoryInventoryRepositorySuite struct {
+	suite.Suite
+
+	inventoryRepo *InMemoryInventoryRepository
+}
+
+func (s *InMemoryInventoryRepositorySuite) SetupTest() {
+	s.inventoryRepo = NewInMemoryInventoryRepository(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""))
+
+	for _, change := range []domain.StockChange{
+		{ProductID: 2, Warehouse: "west", Quantity: 4},
+		{ProductID: 1, Warehouse: "west", Quantity: 5},
+		{ProductID: 1, Warehouse: "east", Quantity: 10},
+		{ProductID: 1, SKU: "IPHONE-13-BLUE", Warehouse: "east", Quantity: 2},
+	} {
+		_, err := s.inventoryRepo.Set(context.Background(), change)
+		s.Require().NoError(err)
+	}
+}
+
+func (s *InMemoryInventoryRepositorySuite) reserve(expiresAt time.Time, items ...domain.ReservationItem) domain.Reservation {
+	reservation, err := s.inventoryRepo.Reserve(context.Background(), domain.Reservation{
+		Status:    domain.ReservationStatusPending,
+		Items:     items,
+		ExpiresAt: expiresAt,
+	})
+	s.Require().NoError(err)
+
+	return reservation
+}
+
+func (s *InMemoryInventoryRepositorySuite) level(productID int, sku string, warehouse string) domain.StockLevel {
+	levels, err := s.inventoryRepo.List(context.Background(), domain.StockFilter{ProductIDs: []int{productID}, SKU: sku, Warehouse: warehouse})
+	s.Require().NoError(err)
+
+	for _, level := range levels {
+		if level.SKU == sku {
+			return level
+		}
+	}
+
+	s.FailNow("stock level not found")
+	return domain.StockLevel{}
+}
+
+func (s *InMemoryInventoryRepositorySuite) Test_List() {
+	s.Run("Should list the stock levels ordered by product, sku and warehouse", func() {
+		got, err := s.inventoryRepo.List(context.Background(), domain.StockFilter{})
+
+		s.NoError(err)
+		s.Require().Len(got, 4)
+		s.Equal(stockKey{1, "", "east"}, keyOf(got[0]))
+		s.Equal(stockKey{1, "", "west"}, keyOf(got[1]))
+		s.Equal(stockKey{1, "IPHONE-13-BLUE", "east"}, keyOf(got[2]))
+		s.Equal(stockKey{2, "", "west"}, keyOf(got[3]))
+	})
+
+	s.Run("Should list only the stock levels matching the filter", func() {
+		got, err := s.inventoryRepo.List(context.Background(), domain.StockFilter{ProductIDs: []int{1, 3}, Warehouse: "east"})
+
+		s.NoError(err)
+		s.Require().Len(got, 2)
+		s.Equal(10, got[0].OnHand)
+		s.Equal("IPHONE-13-BLUE", got[1].SKU)
+	})
+}
+
+func (s *InMemoryInventoryRepositorySuite) Test_SetAndAdjust() {
+	s.Run("Should not let the units on hand drop below the reserved ones", func() {
+		s.reserve(time.Now().Add(time.Hour), domain.ReservationItem{ProductID: 2, Warehouse: "west", Quantity: 3})
+
+		_, err := s.inventoryRepo.Set(context.Background(), domain.StockChange{ProductID: 2, Warehouse: "west", Quantity: 2})
+		s.Equal(domain.ErrStockBelowReserved, err)
+
+		_, err = s.inventoryRepo.Adjust(context.Background(), domain.StockChange{ProductID: 2, Warehouse: "west", Quantity: -2})
+		s.Equal(domain.ErrStockBelowReserved, err)
+
+		s.Equal(4, s.level(2, "", "west").OnHand)
+	})
+
+	s.Run("Should add the units to the stock level, creating it when missing", func() {
+		got, err := s.inventoryRepo.Adjust(context.Background(), domain.StockChange{ProductID: 2, Warehouse: "west", Quantity: -1})
+		s.NoError(err)
+		s.Equal(3, got.OnHand)
+		s.Equal(0, got.Available())
+
+		got, err = s.inventoryRepo.Adjust(context.Background(), domain.StockChange{ProductID: 2, Warehouse: "north", Quantity: 7})
+		s.NoError(err)
+		s.Equal(7, got.OnHand)
+
+		_, err = s.inventoryRepo.Adjust(context.Background(), domain.StockChange{ProductID: 3, Warehouse: "north", Quantity: -1})
+		s.Equal(domain.ErrStockBelowReserved, err)
+	})
+}
+
+func (s *InMemoryInventoryRepositorySuite) Test_Reserve() {
+	s.Run("Should hold none of the units in case any item can't be held", func() {
+		_, err := s.inventoryRepo.Reserve(context.Background(), domain.Reservation{
+			Status: domain.ReservationStatusPending,
+			Items: []domain.ReservationItem{
+				{ProductID: 1, Warehouse: "east", Quantity: 6},
+				{ProductID: 1, Warehouse: "east", Quantity: 6},
+			},
+		})
+		s.Equal(domain.ErrInsufficientStock, err)
+
+		_, err = s.inventoryRepo.Reserve(context.Background(), domain.Reservation{
+			Status: domain.ReservationStatusPending,
+			Items: []domain.ReservationItem{
+				{ProductID: 1, Warehouse: "east", Quantity: 1},
+				{ProductID: 1, Warehouse: "south", Quantity: 1},
+			},
+		})
+		s.Equal(domain.ErrInsufficientStock, err)
+
+		s.Equal(0, s.level(1, "", "east").Reserved)
+	})
+
+	s.Run("Should never hold more units than the available ones", func() {
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		reserved := 0
+
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				_, err := s.inventoryRepo.Reserve(context.Background(), domain.Reservation{
+					Status: domain.ReservationStatusPending,
+					Items:  []domain.ReservationItem{{ProductID: 1, Warehouse: "west", Quantity: 2}},
+				})
+				if err == nil {
+					mu.Lock()
+					reserved++
+					mu.Unlock()
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		s.Equal(2, reserved)
+		s.Equal(4, s.level(1, "", "west").Reserved)
+	})
+
+	s.Run("Should hold the units of a reference only once", func() {
+		s.SetupTest()
+		reservation := domain.Reservation{
+			Reference: "checkout:s1",
+			Status:    domain.ReservationStatusPending,
+			Items:     []domain.ReservationItem{{ProductID: 2, Warehouse: "west", Quantity: 1}},
+		}
+
+		reserved, err := s.inventoryRepo.Reserve(context.Background(), reservation)
+		s.Require().NoError(err)
+
+		_, err = s.inventoryRepo.Reserve(context.Background(), reservation)
+		s.Equal(domain.ErrReservationReferenceTaken, err)
+		s.Equal(1, s.level(2, "", "west").Reserved)
+
+		got, err := s.inventoryRepo.GetByReference(context.Background(), "checkout:s1")
+		s.NoError(err)
+		s.Equal(reserved, got)
+
+		_, err = s.inventoryRepo.GetByReference(context.Background(), "")
+		s.Equal(domain.ErrReservationNotFound, err)
+	})
+}
+
+func (s *InMemoryInventoryRepositorySuite) Test_Commit() {
+	s.Run("Should take the units out of the stock", func() {
+		reservation := s.reserve(time.Now().Add(time.Hour), domain.ReservationItem{ProductID: 1, Warehouse: "east", Quantity: 3})
+
+		got, err := s.inventoryRepo.Commit(context.Background(), reservation.ID, time.Now())
+
+		s.NoError(err)
+		s.Equal(domain.ReservationStatusCommitted, got.Status)
+
+		level := s.level(1, "", "east")
+		s.Equal(7, level.OnHand)
+		s.Equal(0, level.Reserved)
+
+		_, err = s.inventoryRepo.Commit(context.Background(), reservation.ID, time.Now())
+		s.Equal(domain.ErrReservationNotPending, err)
+	})
+
+	s.Run("Should fail in case the reservation expired", func() {
+		reservation := s.reserve(time.Now().Add(time.Minute), domain.ReservationItem{ProductID: 1, Warehouse: "east", Quantity: 1})
+
+		_, err := s.inventoryRepo.Commit(context.Background(), reservation.ID, time.Now().Add(time.Hour))
+
+		s.Equal(domain.ErrReservationExpired, err)
+		s.Equal(1, s.level(1, "", "east").Reserved)
+	})
+
+	s.Run("Should fail in case the reservation doesn't exist", func() {
+		_, err := s.inventoryRepo.Commit(context.Background(), 42, time.Now())
+
+		s.Equal(domain.ErrReservationNotFound, err)
+	})
+}
+
+func (s *InMemoryInventoryRepositorySuite) Test_Release() {
+	s.Run("Should give the units back only once", func() {
+		reservation := s.reserve(time.Now().Add(time.Hour), domain.ReservationItem{ProductID: 1, SKU: "IPHONE-13-BLUE", Warehouse: "east", Quantity: 2})
+
+		for i := 0; i < 2; i++ {
+			got, err := s.inventoryRepo.Release(context.Background(), reservation.ID, domain.ReservationStatusReleased)
+
+			s.NoError(err)
+			s.Equal(domain.ReservationStatusReleased, got.Status)
+		}
+
+		level := s.level(1, "IPHONE-13-BLUE", "east")
+		s.Equal(2, level.OnHand)
+		s.Equal(0, level.Reserved)
+	})
+
+	s.Run("Should fail in case the reservation was committed", func() {
+		reservation := s.reserve(time.Now().Add(time.Hour), domain.ReservationItem{ProductID: 2, Warehouse: "west", Quantity: 1})
+
+		_, err := s.inventoryRepo.Commit(context.Background(), reservation.ID, time.Now())
+		s.Require().NoError(err)
+
+		_, err = s.inventoryRepo.Release(context.Background(), reservation.ID, domain.ReservationStatusReleased)
+		s.Equal(domain.ErrReservationNotPending, err)
+	})
+}
+
+func (s *InMemoryInventoryRepositorySuite) Test_Expire() {
+	s.Run("Should give back the units of the expired reservations up to the limit", func() {
+		now := time.Now()
+		first := s.reserve(now.Add(-time.Minute), domain.ReservationItem{ProductID: 1, Warehouse: "east", Quantity: 1})
+		second := s.reserve(now, domain.ReservationItem{ProductID: 1, Warehouse: "east", Quantity: 2})
+		pending := s.reserve(now.Add(time.Minute), domain.ReservationItem{ProductID: 1, Warehouse: "east", Quantity: 4})
+
+		got, err := s.inventoryRepo.Expire(context.Background(), now, 1)
+		s.NoError(err)
+		s.Require().Len(got, 1)
+		s.Equal(first.ID, got[0].ID)
+		s.Equal(domain.ReservationStatusExpired, got[0].Status)
+
+		got, err = s.inventoryRepo.Expire(context.Background(), now, 10)
+		s.NoError(err)
+		s.Require().Len(got, 1)
+		s.Equal(second.ID, got[0].ID)
+
+		s.Equal(4, s.level(1, "", "east").Reserved)
+
+		stored, err := s.inventoryRepo.Get(context.Background(), pending.ID)
+		s.NoError(err)
+		s.Equal(domain.ReservationStatusPending, stored.Status)
+	})
+}
+
+func TestInMemoryInventoryRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InMemoryInventoryRepositorySuite))
+}
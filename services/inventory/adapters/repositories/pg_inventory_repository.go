@@ -0,0 +1,515 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	"github.com/lucasmls/ecommerce/shared/pg"
+)
+
+// checkViolation is the Postgres error code raised when a check constraint is violated
+const checkViolation = "23514"
+
+const (
+	stockLevelColumns  = "product_id, sku, warehouse, on_hand, reserved, updated_at"
+	reservationColumns = "id, reference, status, items, expires_at, created_at, updated_at"
+)
+
+// reservationItemRecord is how a ReservationItem is stored on the items column of a reservation
+type reservationItemRecord struct {
+	ProductID int    `json:"product_id"`
+	SKU       string `json:"sku,omitempty"`
+	Warehouse string `json:"warehouse"`
+	Quantity  int    `json:"quantity"`
+}
+
+// PgInventoryRepository is both the StockRepository and the ReservationsRepository.
+// The stock_levels rows are updated conditionally and always in the order of their primary key,
+// so concurrent Reservations can neither oversell a StockLevel nor deadlock each other.
+type PgInventoryRepository struct {
+	db *sql.DB
+}
+
+func NewPgInventoryRepository(connectionString string) (*PgInventoryRepository, error) {
+	db, err := sql.Open(
+		"postgres",
+		connectionString,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	repository := &PgInventoryRepository{
+		db: db,
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+func MustNewPgInventoryRepository(connectionString string) *PgInventoryRepository {
+	repo, err := NewPgInventoryRepository(connectionString)
+	if err != nil {
+		panic(err)
+	}
+
+	return repo
+}
+
+// List lists the StockLevels matching the filter, ordered by Product, SKU and Warehouse
+func (r *PgInventoryRepository) List(ctx context.Context, filter domain.StockFilter) ([]domain.StockLevel, error) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	if len(filter.ProductIDs) > 0 {
+		productIDs := make([]int64, 0, len(filter.ProductIDs))
+		for _, id := range filter.ProductIDs {
+			productIDs = append(productIDs, int64(id))
+		}
+
+		args = append(args, pq.Array(productIDs))
+		conditions = append(conditions, fmt.Sprintf("product_id = ANY($%d)", len(args)))
+	}
+
+	if filter.SKU != "" {
+		args = append(args, filter.SKU)
+		conditions = append(conditions, fmt.Sprintf("sku = $%d", len(args)))
+	}
+
+	if filter.Warehouse != "" {
+		args = append(args, filter.Warehouse)
+		conditions = append(conditions, fmt.Sprintf("warehouse = $%d", len(args)))
+	}
+
+	query := "SELECT " + stockLevelColumns + " FROM stock_levels"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	rows, err := r.db.QueryContext(ctx, query+" ORDER BY product_id, sku, warehouse", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	levels := []domain.StockLevel{}
+	for rows.Next() {
+		level, err := scanStockLevel(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		levels = append(levels, level)
+	}
+
+	return levels, rows.Err()
+}
+
+// Set sets the OnHand units of a StockLevel, the update is skipped when they'd be fewer than the Reserved ones
+func (r *PgInventoryRepository) Set(ctx context.Context, change domain.StockChange) (domain.StockLevel, error) {
+	return r.upsert(ctx, change, `
+		ON CONFLICT (product_id, sku, warehouse) DO UPDATE
+		SET on_hand = EXCLUDED.on_hand, updated_at = EXCLUDED.updated_at
+		WHERE stock_levels.reserved <= EXCLUDED.on_hand`,
+	)
+}
+
+// Adjust adds the Quantity of the change to the OnHand units of a StockLevel, with the same guarantees of Set
+func (r *PgInventoryRepository) Adjust(ctx context.Context, change domain.StockChange) (domain.StockLevel, error) {
+	return r.upsert(ctx, change, `
+		ON CONFLICT (product_id, sku, warehouse) DO UPDATE
+		SET on_hand = stock_levels.on_hand + EXCLUDED.on_hand, updated_at = EXCLUDED.updated_at
+		WHERE stock_levels.reserved <= stock_levels.on_hand + EXCLUDED.on_hand`,
+	)
+}
+
+// upsert inserts the StockLevel of the change with its Quantity on hand, resolving the conflicts with the given clause.
+// A skipped update, or a new StockLevel with negative units, means the units on hand would be fewer than the reserved ones.
+func (r *PgInventoryRepository) upsert(ctx context.Context, change domain.StockChange, onConflict string) (domain.StockLevel, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`INSERT INTO stock_levels (product_id, sku, warehouse, on_hand, reserved, updated_at)
+		VALUES ($1, $2, $3, $4, 0, $5)`+onConflict+`
+		RETURNING `+stockLevelColumns,
+		change.ProductID, change.SKU, change.Warehouse, change.Quantity, time.Now(),
+	)
+
+	level, err := scanStockLevel(row)
+	if errors.Is(err, sql.ErrNoRows) || isCheckViolation(err) {
+		return domain.StockLevel{}, domain.ErrStockBelowReserved
+	}
+
+	return level, err
+}
+
+// Reserve holds the units of every item with a conditional update, which fails when they aren't available
+func (r *PgInventoryRepository) Reserve(ctx context.Context, reservation domain.Reservation) (domain.Reservation, error) {
+	items, err := json.Marshal(toReservationItemRecords(reservation.Items))
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+	defer tx.Rollback()
+
+	for _, level := range sumByStockLevel(reservation.Items) {
+		result, err := tx.ExecContext(
+			ctx,
+			`UPDATE stock_levels SET reserved = reserved + $4, updated_at = $5
+			WHERE product_id = $1 AND sku = $2 AND warehouse = $3 AND on_hand - reserved >= $4`,
+			level.ProductID, level.SKU, level.Warehouse, level.Quantity, reservation.CreatedAt,
+		)
+		if err != nil {
+			return domain.Reservation{}, err
+		}
+
+		updated, err := result.RowsAffected()
+		if err != nil {
+			return domain.Reservation{}, err
+		}
+
+		if updated == 0 {
+			return domain.Reservation{}, domain.ErrInsufficientStock
+		}
+	}
+
+	err = tx.QueryRowContext(
+		ctx,
+		`INSERT INTO reservations (reference, status, items, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`,
+		reservation.Reference, string(reservation.Status), items,
+		reservation.ExpiresAt, reservation.CreatedAt, reservation.UpdatedAt,
+	).Scan(&reservation.ID)
+	if pg.IsUniqueViolation(err) {
+		return domain.Reservation{}, domain.ErrReservationReferenceTaken
+	}
+
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.Reservation{}, err
+	}
+
+	return reservation, nil
+}
+
+// Get fetches a single Reservation
+func (r *PgInventoryRepository) Get(ctx context.Context, id int) (domain.Reservation, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+reservationColumns+" FROM reservations WHERE id = $1", id)
+
+	reservation, err := scanReservation(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Reservation{}, domain.ErrReservationNotFound
+	}
+
+	return reservation, err
+}
+
+// GetByReference fetches the Reservation with the given non-empty reference
+func (r *PgInventoryRepository) GetByReference(ctx context.Context, reference string) (domain.Reservation, error) {
+	if reference == "" {
+		return domain.Reservation{}, domain.ErrReservationNotFound
+	}
+
+	row := r.db.QueryRowContext(ctx, "SELECT "+reservationColumns+" FROM reservations WHERE reference = $1", reference)
+
+	reservation, err := scanReservation(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Reservation{}, domain.ErrReservationNotFound
+	}
+
+	return reservation, err
+}
+
+// Commit takes the units of a pending Reservation out of the stock, locking the Reservation until it's done
+func (r *PgInventoryRepository) Commit(ctx context.Context, id int, now time.Time) (domain.Reservation, error) {
+	tx, reservation, err := r.lockReservation(ctx, id)
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+	defer tx.Rollback()
+
+	if reservation.Status != domain.ReservationStatusPending {
+		return domain.Reservation{}, domain.ErrReservationNotPending
+	}
+
+	if reservation.Expired(now) {
+		return domain.Reservation{}, domain.ErrReservationExpired
+	}
+
+	if err := unhold(ctx, tx, reservation.Items, true, now); err != nil {
+		return domain.Reservation{}, err
+	}
+
+	reservation, err = updateStatus(ctx, tx, reservation, domain.ReservationStatusCommitted, now)
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.Reservation{}, err
+	}
+
+	return reservation, nil
+}
+
+// Release gives the units of a pending Reservation back, locking the Reservation until it's done
+func (r *PgInventoryRepository) Release(ctx context.Context, id int, status domain.ReservationStatus) (domain.Reservation, error) {
+	tx, reservation, err := r.lockReservation(ctx, id)
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+	defer tx.Rollback()
+
+	switch reservation.Status {
+	case domain.ReservationStatusReleased, domain.ReservationStatusExpired:
+		return reservation, nil
+	case domain.ReservationStatusCommitted:
+		return domain.Reservation{}, domain.ErrReservationNotPending
+	}
+
+	now := time.Now()
+	if err := unhold(ctx, tx, reservation.Items, false, now); err != nil {
+		return domain.Reservation{}, err
+	}
+
+	reservation, err = updateStatus(ctx, tx, reservation, status, now)
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.Reservation{}, err
+	}
+
+	return reservation, nil
+}
+
+// Expire releases the expired Reservations, skipping the ones locked by a Commit or Release in progress
+func (r *PgInventoryRepository) Expire(ctx context.Context, now time.Time, limit int) ([]domain.Reservation, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(
+		ctx,
+		"SELECT "+reservationColumns+` FROM reservations
+		WHERE status = $1 AND expires_at <= $2
+		ORDER BY expires_at, id LIMIT $3
+		FOR UPDATE SKIP LOCKED`,
+		string(domain.ReservationStatusPending), now, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	reservations := []domain.Reservation{}
+	items := []domain.ReservationItem{}
+	for rows.Next() {
+		reservation, err := scanReservation(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+
+		reservations = append(reservations, reservation)
+		items = append(items, reservation.Items...)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// the units of every Reservation are given back at once, so the rows are locked in order
+	if err := unhold(ctx, tx, items, false, now); err != nil {
+		return nil, err
+	}
+
+	for i, reservation := range reservations {
+		reservations[i], err = updateStatus(ctx, tx, reservation, domain.ReservationStatusExpired, now)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+// lockReservation fetches a Reservation in a new transaction, locking it until the transaction ends
+func (r *PgInventoryRepository) lockReservation(ctx context.Context, id int) (*sql.Tx, domain.Reservation, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, domain.Reservation{}, err
+	}
+
+	row := tx.QueryRowContext(ctx, "SELECT "+reservationColumns+" FROM reservations WHERE id = $1 FOR UPDATE", id)
+
+	reservation, err := scanReservation(row)
+	if err != nil {
+		tx.Rollback()
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.Reservation{}, domain.ErrReservationNotFound
+		}
+
+		return nil, domain.Reservation{}, err
+	}
+
+	return tx, reservation, nil
+}
+
+// unhold removes the units of the items from the Reserved units of their StockLevels, and from the OnHand
+// units too when they're taken out of the stock
+func unhold(ctx context.Context, tx *sql.Tx, items []domain.ReservationItem, takeOut bool, now time.Time) error {
+	onHand := "on_hand"
+	if takeOut {
+		onHand = "on_hand - $4"
+	}
+
+	for _, level := range sumByStockLevel(items) {
+		_, err := tx.ExecContext(
+			ctx,
+			`UPDATE stock_levels SET on_hand = `+onHand+`, reserved = reserved - $4, updated_at = $5
+			WHERE product_id = $1 AND sku = $2 AND warehouse = $3`,
+			level.ProductID, level.SKU, level.Warehouse, level.Quantity, now,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func updateStatus(
+	ctx context.Context,
+	tx *sql.Tx,
+	reservation domain.Reservation,
+	status domain.ReservationStatus,
+	now time.Time,
+) (domain.Reservation, error) {
+	_, err := tx.ExecContext(ctx, "UPDATE reservations SET status = $2, updated_at = $3 WHERE id = $1", reservation.ID, string(status), now)
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+
+	reservation.Status = status
+	reservation.UpdatedAt = now
+
+	return reservation, nil
+}
+
+// stockLevelQuantity is an amount of units of a StockLevel
+type stockLevelQuantity struct {
+	stockKey
+	Quantity int
+}
+
+// sumByStockLevel sums the units of the items by StockLevel, in the order the rows are locked
+func sumByStockLevel(items []domain.ReservationItem) []stockLevelQuantity {
+	sums := map[stockKey]int{}
+	for _, item := range items {
+		sums[stockKey{item.ProductID, item.SKU, item.Warehouse}] += item.Quantity
+	}
+
+	result := make([]stockLevelQuantity, 0, len(sums))
+	for key, quantity := range sums {
+		result = append(result, stockLevelQuantity{key, quantity})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return lessKey(result[i].stockKey, result[j].stockKey)
+	})
+
+	return result
+}
+
+func scanStockLevel(row pg.Scanner) (domain.StockLevel, error) {
+	var level domain.StockLevel
+
+	err := row.Scan(&level.ProductID, &level.SKU, &level.Warehouse, &level.OnHand, &level.Reserved, &level.UpdatedAt)
+	if err != nil {
+		return domain.StockLevel{}, err
+	}
+
+	return level, nil
+}
+
+func scanReservation(row pg.Scanner) (domain.Reservation, error) {
+	var reservation domain.Reservation
+	var status string
+	var items []byte
+
+	err := row.Scan(
+		&reservation.ID,
+		&reservation.Reference,
+		&status,
+		&items,
+		&reservation.ExpiresAt,
+		&reservation.CreatedAt,
+		&reservation.UpdatedAt,
+	)
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+
+	var records []reservationItemRecord
+	if err := json.Unmarshal(items, &records); err != nil {
+		return domain.Reservation{}, err
+	}
+
+	reservation.Status = domain.ReservationStatus(status)
+	reservation.Items = make([]domain.ReservationItem, 0, len(records))
+	for _, record := range records {
+		reservation.Items = append(reservation.Items, domain.ReservationItem{
+			ProductID: record.ProductID,
+			SKU:       record.SKU,
+			Warehouse: record.Warehouse,
+			Quantity:  record.Quantity,
+		})
+	}
+
+	return reservation, nil
+}
+
+func toReservationItemRecords(items []domain.ReservationItem) []reservationItemRecord {
+	records := make([]reservationItemRecord, 0, len(items))
+	for _, item := range items {
+		records = append(records, reservationItemRecord{
+			ProductID: item.ProductID,
+			SKU:       item.SKU,
+			Warehouse: item.Warehouse,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	return records
+}
+
+func isCheckViolation(err error) bool {
+	var pgErr *pq.Error
+	return errors.As(err, &pgErr) && pgErr.Code == checkViolation
+}
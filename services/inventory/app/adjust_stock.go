@@ -0,0 +1,31 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) AdjustStock(ctx context.Context, change domain.StockChange) (domain.StockLevel, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.AdjustStock")
+	defer span.End()
+
+	a.Logger.Info("adjusting the stock", zap.Any("change", change))
+
+	if err := change.Validate(); err != nil {
+		return domain.StockLevel{}, err
+	}
+
+	if change.Quantity == 0 {
+		return domain.StockLevel{}, domain.ErrInvalidQuantity
+	}
+
+	if change.Quantity < 0 {
+		return a.StockRepository.Adjust(ctx, change)
+	}
+
+	return a.restock(ctx, change.ProductID, func() (domain.StockLevel, error) {
+		return a.StockRepository.Adjust(ctx, change)
+	})
+}
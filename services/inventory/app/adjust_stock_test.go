@@ -0,0 +1,121 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	"github.com/lucasmls/ecommerce/services/inventory/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type AdjustStockSuite struct {
+	suite.Suite
+
+	stockRepo        *mocks.StockRepository
+	reservationsRepo *mocks.ReservationsRepository
+	publisher        *mocks.EventPublisher
+	app              domain.Application
+}
+
+func (s *AdjustStockSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.stockRepo = &mocks.StockRepository{}
+	s.reservationsRepo = &mocks.ReservationsRepository{}
+	s.publisher = &mocks.EventPublisher{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.stockRepo, s.reservationsRepo, s.publisher)
+}
+
+func (s *AdjustStockSuite) Test_AdjustStock() {
+	s.Run("Should fail in case the stock location is invalid", func() {
+		_, err := s.app.AdjustStock(context.Background(), domain.StockChange{ProductID: 1, Quantity: 1})
+
+		s.Equal(domain.ErrInvalidStockLocation, err)
+	})
+
+	s.Run("Should fail in case nothing is adjusted", func() {
+		_, err := s.app.AdjustStock(context.Background(), domain.StockChange{ProductID: 1, Warehouse: "east"})
+
+		s.Equal(domain.ErrInvalidQuantity, err)
+	})
+
+	s.Run("Should return the adjusted stock level", func() {
+		change := domain.StockChange{ProductID: 1, Warehouse: "east", Quantity: -2}
+		level := domain.StockLevel{ProductID: 1, Warehouse: "east", OnHand: 8}
+
+		s.stockRepo.
+			On("Adjust", mock.AnythingOfType("*context.valueCtx"), change).
+			Return(level, nil)
+
+		got, err := s.app.AdjustStock(context.Background(), change)
+
+		s.NoError(err)
+		s.Equal(level, got)
+	})
+
+	s.Run("Should tell the product is back in stock in case it ran out before the units were received", func() {
+		change := domain.StockChange{ProductID: 2, Warehouse: "east", Quantity: 5}
+		level := domain.StockLevel{ProductID: 2, Warehouse: "east", OnHand: 5}
+
+		s.stockRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.StockFilter{ProductIDs: []int{2}}).
+			Return([]domain.StockLevel{{ProductID: 2, Warehouse: "east"}}, nil).
+			Once()
+
+		s.stockRepo.
+			On("Adjust", mock.AnythingOfType("*context.valueCtx"), change).
+			Return(level, nil).
+			Once()
+
+		s.stockRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.StockFilter{ProductIDs: []int{2}}).
+			Return([]domain.StockLevel{level}, nil).
+			Once()
+
+		s.publisher.
+			On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(event domain.StockEvent) bool {
+				return event.Type == domain.EventBackInStock && event.ProductID == 2 && event.Available == 5
+			})).
+			Return(nil).
+			Once()
+
+		got, err := s.app.AdjustStock(context.Background(), change)
+
+		s.NoError(err)
+		s.Equal(level, got)
+		s.publisher.AssertExpectations(s.T())
+	})
+
+	s.Run("Should not tell anything in case the product was still in stock", func() {
+		change := domain.StockChange{ProductID: 3, Warehouse: "east", Quantity: 5}
+		level := domain.StockLevel{ProductID: 3, Warehouse: "east", OnHand: 6}
+
+		s.stockRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.StockFilter{ProductIDs: []int{3}}).
+			Return([]domain.StockLevel{{ProductID: 3, Warehouse: "east", OnHand: 1}}, nil).
+			Once()
+
+		s.stockRepo.
+			On("Adjust", mock.AnythingOfType("*context.valueCtx"), change).
+			Return(level, nil).
+			Once()
+
+		got, err := s.app.AdjustStock(context.Background(), change)
+
+		s.NoError(err)
+		s.Equal(level, got)
+		s.publisher.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.MatchedBy(func(event domain.StockEvent) bool {
+			return event.ProductID == 3
+		}))
+	})
+}
+
+func TestAdjustStockSuite(t *testing.T) {
+	suite.Run(t, new(AdjustStockSuite))
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// application holds all dependencies the Application needs to work
+type application struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	StockRepository        domain.StockRepository
+	ReservationsRepository domain.ReservationsRepository
+	EventPublisher         domain.EventPublisher
+}
+
+// NewApplication creates a new Application instance
+func NewApplication(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	stockRepository domain.StockRepository,
+	reservationsRepository domain.ReservationsRepository,
+	eventPublisher domain.EventPublisher,
+) application {
+	return application{
+		Logger:                 logger,
+		Tracer:                 tracer,
+		StockRepository:        stockRepository,
+		ReservationsRepository: reservationsRepository,
+		EventPublisher:         eventPublisher,
+	}
+}
+
+// MustNewApplication creates a new Application instance
+// It panics if any error is found
+func MustNewApplication(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	stockRepository domain.StockRepository,
+	reservationsRepository domain.ReservationsRepository,
+	eventPublisher domain.EventPublisher,
+) application {
+	app := NewApplication(logger, tracer, stockRepository, reservationsRepository, eventPublisher)
+	return app
+}
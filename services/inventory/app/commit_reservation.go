@@ -0,0 +1,27 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) CommitReservation(ctx context.Context, id int) (domain.Reservation, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.CommitReservation")
+	defer span.End()
+
+	a.Logger.Info("committing a reservation", zap.Int("id", id))
+
+	reservation, err := a.ReservationsRepository.Commit(ctx, id, time.Now())
+	if errors.Is(err, domain.ErrReservationExpired) {
+		// the units are given back right away instead of waiting for the next ExpireReservations
+		if _, releaseErr := a.ReservationsRepository.Release(ctx, id, domain.ReservationStatusExpired); releaseErr != nil {
+			a.Logger.Error("failed to release an expired reservation", zap.Int("id", id), zap.Error(releaseErr))
+		}
+	}
+
+	return reservation, err
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	"github.com/lucasmls/ecommerce/services/inventory/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type CommitReservationSuite struct {
+	suite.Suite
+
+	stockRepo        *mocks.StockRepository
+	reservationsRepo *mocks.ReservationsRepository
+	app              domain.Application
+}
+
+func (s *CommitReservationSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.stockRepo = &mocks.StockRepository{}
+	s.reservationsRepo = &mocks.ReservationsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.stockRepo, s.reservationsRepo, &mocks.EventPublisher{})
+}
+
+func (s *CommitReservationSuite) Test_CommitReservation() {
+	s.Run("Should return the error in case the reservation isn't pending", func() {
+		s.reservationsRepo.
+			On("Commit", mock.AnythingOfType("*context.valueCtx"), 1, mock.AnythingOfType("time.Time")).
+			Return(domain.Reservation{}, domain.ErrReservationNotPending)
+
+		_, err := s.app.CommitReservation(context.Background(), 1)
+
+		s.Equal(domain.ErrReservationNotPending, err)
+	})
+
+	s.Run("Should release the reservation as expired in case it's too late to commit it", func() {
+		s.reservationsRepo.
+			On("Commit", mock.AnythingOfType("*context.valueCtx"), 2, mock.AnythingOfType("time.Time")).
+			Return(domain.Reservation{}, domain.ErrReservationExpired)
+
+		s.reservationsRepo.
+			On("Release", mock.AnythingOfType("*context.valueCtx"), 2, domain.ReservationStatusExpired).
+			Return(domain.Reservation{ID: 2, Status: domain.ReservationStatusExpired}, nil)
+
+		_, err := s.app.CommitReservation(context.Background(), 2)
+
+		s.Equal(domain.ErrReservationExpired, err)
+		s.reservationsRepo.AssertCalled(s.T(), "Release", mock.AnythingOfType("*context.valueCtx"), 2, domain.ReservationStatusExpired)
+	})
+
+	s.Run("Should return the committed reservation", func() {
+		committed := domain.Reservation{ID: 3, Status: domain.ReservationStatusCommitted}
+
+		s.reservationsRepo.
+			On("Commit", mock.AnythingOfType("*context.valueCtx"), 3, mock.AnythingOfType("time.Time")).
+			Return(committed, nil)
+
+		got, err := s.app.CommitReservation(context.Background(), 3)
+
+		s.NoError(err)
+		s.Equal(committed, got)
+	})
+}
+
+func TestCommitReservationSuite(t *testing.T) {
+	suite.Run(t, new(CommitReservationSuite))
+}
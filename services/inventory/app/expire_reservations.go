@@ -0,0 +1,38 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// expireBatchSize is the amount of Reservations expired at once by ExpireReservations
+const expireBatchSize = 100
+
+func (a application) ExpireReservations(ctx context.Context) (int, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.ExpireReservations")
+	defer span.End()
+
+	now := time.Now()
+
+	expired := 0
+	for {
+		reservations, err := a.ReservationsRepository.Expire(ctx, now, expireBatchSize)
+		if err != nil {
+			return expired, err
+		}
+
+		expired += len(reservations)
+
+		if len(reservations) < expireBatchSize {
+			break
+		}
+	}
+
+	if expired > 0 {
+		a.Logger.Info("expired reservations", zap.Int("count", expired))
+	}
+
+	return expired, nil
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	"github.com/lucasmls/ecommerce/services/inventory/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ExpireReservationsSuite struct {
+	suite.Suite
+
+	stockRepo        *mocks.StockRepository
+	reservationsRepo *mocks.ReservationsRepository
+	app              domain.Application
+}
+
+func (s *ExpireReservationsSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.stockRepo = &mocks.StockRepository{}
+	s.reservationsRepo = &mocks.ReservationsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.stockRepo, s.reservationsRepo, &mocks.EventPublisher{})
+}
+
+func (s *ExpireReservationsSuite) Test_ExpireReservations() {
+	s.Run("Should expire the reservations in batches until there are no more of them", func() {
+		s.reservationsRepo.
+			On("Expire", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("time.Time"), expireBatchSize).
+			Return(make([]domain.Reservation, expireBatchSize), nil).
+			Once()
+
+		s.reservationsRepo.
+			On("Expire", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("time.Time"), expireBatchSize).
+			Return(make([]domain.Reservation, 3), nil).
+			Once()
+
+		got, err := s.app.ExpireReservations(context.Background())
+
+		s.NoError(err)
+		s.Equal(expireBatchSize+3, got)
+		s.reservationsRepo.AssertNumberOfCalls(s.T(), "Expire", 2)
+	})
+}
+
+func (s *ExpireReservationsSuite) Test_ExpireReservations_Failure() {
+	s.Run("Should return how many reservations expired before the repository failed", func() {
+		s.reservationsRepo.
+			On("Expire", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("time.Time"), expireBatchSize).
+			Return(make([]domain.Reservation, expireBatchSize), nil).
+			Once()
+
+		s.reservationsRepo.
+			On("Expire", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("time.Time"), expireBatchSize).
+			Return(nil, errors.New("connection refused")).
+			Once()
+
+		got, err := s.app.ExpireReservations(context.Background())
+
+		s.EqualError(err, "connection refused")
+		s.Equal(expireBatchSize, got)
+	})
+}
+
+func TestExpireReservationsSuite(t *testing.T) {
+	suite.Run(t, new(ExpireReservationsSuite))
+}
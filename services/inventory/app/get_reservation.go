@@ -0,0 +1,17 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) GetReservation(ctx context.Context, id int) (domain.Reservation, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.GetReservation")
+	defer span.End()
+
+	a.Logger.Info("getting a reservation", zap.Int("id", id))
+
+	return a.ReservationsRepository.Get(ctx, id)
+}
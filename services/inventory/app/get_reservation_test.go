@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	"github.com/lucasmls/ecommerce/services/inventory/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type GetReservationSuite struct {
+	suite.Suite
+
+	stockRepo        *mocks.StockRepository
+	reservationsRepo *mocks.ReservationsRepository
+	app              domain.Application
+}
+
+func (s *GetReservationSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.stockRepo = &mocks.StockRepository{}
+	s.reservationsRepo = &mocks.ReservationsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.stockRepo, s.reservationsRepo, &mocks.EventPublisher{})
+}
+
+func (s *GetReservationSuite) Test_GetReservation() {
+	s.Run("Should return the error in case the reservation doesn't exist", func() {
+		s.reservationsRepo.
+			On("Get", mock.AnythingOfType("*context.valueCtx"), 1).
+			Return(domain.Reservation{}, domain.ErrReservationNotFound)
+
+		_, err := s.app.GetReservation(context.Background(), 1)
+
+		s.Equal(domain.ErrReservationNotFound, err)
+	})
+
+	s.Run("Should return the reservation", func() {
+		reservation := domain.Reservation{ID: 2, Reference: "cart-2", Status: domain.ReservationStatusPending}
+
+		s.reservationsRepo.
+			On("Get", mock.AnythingOfType("*context.valueCtx"), 2).
+			Return(reservation, nil)
+
+		got, err := s.app.GetReservation(context.Background(), 2)
+
+		s.NoError(err)
+		s.Equal(reservation, got)
+	})
+}
+
+func TestGetReservationSuite(t *testing.T) {
+	suite.Run(t, new(GetReservationSuite))
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"context"
+	"sort"
+
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) GetStock(ctx context.Context, filter domain.StockFilter) ([]domain.ProductStock, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.GetStock")
+	defer span.End()
+
+	a.Logger.Info("getting the stock", zap.Any("filter", filter))
+
+	levels, err := a.StockRepository.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return sumStock(filter.ProductIDs, levels), nil
+}
+
+// sumStock groups the StockLevels by Product, every one of the given Products is listed even without StockLevels
+func sumStock(productIDs []int, levels []domain.StockLevel) []domain.ProductStock {
+	byProduct := map[int]*domain.ProductStock{}
+	for _, id := range productIDs {
+		byProduct[id] = &domain.ProductStock{ProductID: id, Levels: []domain.StockLevel{}}
+	}
+
+	for _, level := range levels {
+		stock, ok := byProduct[level.ProductID]
+		if !ok {
+			stock = &domain.ProductStock{ProductID: level.ProductID}
+			byProduct[level.ProductID] = stock
+		}
+
+		stock.OnHand += level.OnHand
+		stock.Reserved += level.Reserved
+		stock.Levels = append(stock.Levels, level)
+	}
+
+	result := make([]domain.ProductStock, 0, len(byProduct))
+	for _, stock := range byProduct {
+		result = append(result, *stock)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ProductID < result[j].ProductID
+	})
+
+	return result
+}
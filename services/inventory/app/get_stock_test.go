@@ -0,0 +1,74 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	"github.com/lucasmls/ecommerce/services/inventory/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type GetStockSuite struct {
+	suite.Suite
+
+	stockRepo        *mocks.StockRepository
+	reservationsRepo *mocks.ReservationsRepository
+	app              domain.Application
+}
+
+func (s *GetStockSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.stockRepo = &mocks.StockRepository{}
+	s.reservationsRepo = &mocks.ReservationsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.stockRepo, s.reservationsRepo, &mocks.EventPublisher{})
+}
+
+func (s *GetStockSuite) Test_GetStock() {
+	s.Run("Should return the error in case the repository fails", func() {
+		ctx := context.Background()
+		filter := domain.StockFilter{Warehouse: "failing"}
+
+		s.stockRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), filter).
+			Return(nil, errors.New("connection refused"))
+
+		_, err := s.app.GetStock(ctx, filter)
+
+		s.EqualError(err, "connection refused")
+	})
+
+	s.Run("Should sum up the stock levels of every requested product, even the ones without stock", func() {
+		ctx := context.Background()
+		filter := domain.StockFilter{ProductIDs: []int{3, 1}}
+
+		s.stockRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), filter).
+			Return([]domain.StockLevel{
+				{ProductID: 1, Warehouse: "east", OnHand: 10, Reserved: 4},
+				{ProductID: 1, Warehouse: "west", OnHand: 5, Reserved: 0},
+			}, nil)
+
+		got, err := s.app.GetStock(ctx, filter)
+
+		s.NoError(err)
+		s.Require().Len(got, 2)
+		s.Equal(1, got[0].ProductID)
+		s.Equal(15, got[0].OnHand)
+		s.Equal(4, got[0].Reserved)
+		s.Equal(11, got[0].Available())
+		s.Len(got[0].Levels, 2)
+		s.Equal(domain.ProductStock{ProductID: 3, Levels: []domain.StockLevel{}}, got[1])
+	})
+}
+
+func TestGetStockSuite(t *testing.T) {
+	suite.Run(t, new(GetStockSuite))
+}
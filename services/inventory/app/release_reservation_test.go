@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	"github.com/lucasmls/ecommerce/services/inventory/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ReleaseReservationSuite struct {
+	suite.Suite
+
+	stockRepo        *mocks.StockRepository
+	reservationsRepo *mocks.ReservationsRepository
+	app              domain.Application
+}
+
+func (s *ReleaseReservationSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.stockRepo = &mocks.StockRepository{}
+	s.reservationsRepo = &mocks.ReservationsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.stockRepo, s.reservationsRepo, &mocks.EventPublisher{})
+}
+
+func (s *ReleaseReservationSuite) Test_ReleaseReservation() {
+	s.Run("Should return the error in case the reservation doesn't exist", func() {
+		s.reservationsRepo.
+			On("Release", mock.AnythingOfType("*context.valueCtx"), 1, domain.ReservationStatusReleased).
+			Return(domain.Reservation{}, domain.ErrReservationNotFound)
+
+		_, err := s.app.ReleaseReservation(context.Background(), 1)
+
+		s.Equal(domain.ErrReservationNotFound, err)
+	})
+
+	s.Run("Should return the released reservation", func() {
+		released := domain.Reservation{ID: 2, Status: domain.ReservationStatusReleased}
+
+		s.reservationsRepo.
+			On("Release", mock.AnythingOfType("*context.valueCtx"), 2, domain.ReservationStatusReleased).
+			Return(released, nil)
+
+		got, err := s.app.ReleaseReservation(context.Background(), 2)
+
+		s.NoError(err)
+		s.Equal(released, got)
+	})
+}
+
+func TestReleaseReservationSuite(t *testing.T) {
+	suite.Run(t, new(ReleaseReservationSuite))
+}
@@ -0,0 +1,235 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	"go.uber.org/zap"
+)
+
+// maxReserveAttempts bounds how many times the Warehouses of a Reservation are picked again when
+// the stock changes between picking them and holding the units
+const maxReserveAttempts = 3
+
+func (a application) ReserveStock(ctx context.Context, request domain.ReservationRequest) (domain.Reservation, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.ReserveStock")
+	defer span.End()
+
+	a.Logger.Info("reserving stock", zap.Any("request", request))
+
+	if err := request.Validate(); err != nil {
+		return domain.Reservation{}, err
+	}
+
+	// the units held by the Reservation made for the same reference would be missing from the allocation
+	if reservation, ok, err := a.reservationOf(ctx, request.Reference); ok || err != nil {
+		return reservation, err
+	}
+
+	ttl := request.TTL
+	if ttl == 0 {
+		ttl = domain.DefaultReservationTTL
+	}
+
+	var err error
+	for attempt := 1; attempt <= maxReserveAttempts; attempt++ {
+		var items []domain.ReservationItem
+		items, err = a.allocate(ctx, request.Items)
+		if err != nil {
+			return domain.Reservation{}, err
+		}
+
+		now := time.Now()
+
+		var reservation domain.Reservation
+		reservation, err = a.ReservationsRepository.Reserve(ctx, domain.Reservation{
+			Reference: request.Reference,
+			Status:    domain.ReservationStatusPending,
+			Items:     items,
+			ExpiresAt: now.Add(ttl),
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+		if errors.Is(err, domain.ErrReservationReferenceTaken) {
+			// another request with the same reference made the Reservation in the meantime
+			reservation, _, err = a.reservationOf(ctx, request.Reference)
+			return reservation, err
+		}
+
+		if !errors.Is(err, domain.ErrInsufficientStock) {
+			return reservation, err
+		}
+
+		a.Logger.Info("the stock changed while reserving it, trying again", zap.Int("attempt", attempt))
+	}
+
+	return domain.Reservation{}, err
+}
+
+// reservationOf finds the Reservation already made for a non-empty reference, telling whether there's one.
+// It fails with ErrReservationNotPending when that Reservation doesn't hold its units anymore.
+func (a application) reservationOf(ctx context.Context, reference string) (domain.Reservation, bool, error) {
+	if reference == "" {
+		return domain.Reservation{}, false, nil
+	}
+
+	reservation, err := a.ReservationsRepository.GetByReference(ctx, reference)
+	if errors.Is(err, domain.ErrReservationNotFound) {
+		return domain.Reservation{}, false, nil
+	}
+
+	if err != nil {
+		return domain.Reservation{}, false, err
+	}
+
+	if reservation.Status != domain.ReservationStatusPending {
+		return domain.Reservation{}, true, domain.ErrReservationNotPending
+	}
+
+	a.Logger.Info("the reference was already reserved", zap.Int("reservationId", reservation.ID))
+
+	return reservation, true, nil
+}
+
+// stockKey identifies a StockLevel
+type stockKey struct {
+	ProductID int
+	SKU       string
+	Warehouse string
+}
+
+// productKey identifies the StockLevels of a Product across every Warehouse
+type productKey struct {
+	ProductID int
+	SKU       string
+}
+
+// allocate picks the Warehouses the units of the requested items are taken from, based on the units
+// available right now. The items without a Warehouse take the units from the Warehouses with the most
+// of them first, so they're split across as few Warehouses as possible.
+// The allocated items are merged by StockLevel and ordered by Product, SKU and Warehouse.
+func (a application) allocate(ctx context.Context, items []domain.ReservationItem) ([]domain.ReservationItem, error) {
+	productIDs := []int{}
+	seen := map[int]bool{}
+	for _, item := range items {
+		if !seen[item.ProductID] {
+			seen[item.ProductID] = true
+			productIDs = append(productIDs, item.ProductID)
+		}
+	}
+
+	levels, err := a.StockRepository.List(ctx, domain.StockFilter{ProductIDs: productIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	available := map[stockKey]int{}
+	warehouses := map[productKey][]string{}
+	for _, level := range levels {
+		available[stockKey{level.ProductID, level.SKU, level.Warehouse}] = level.Available()
+		product := productKey{level.ProductID, level.SKU}
+		warehouses[product] = append(warehouses[product], level.Warehouse)
+	}
+
+	// the items asking for a Warehouse go first, so the other ones don't take their units
+	ordered := make([]domain.ReservationItem, 0, len(items))
+	for _, item := range items {
+		if item.Warehouse != "" {
+			ordered = append(ordered, item)
+		}
+	}
+
+	for _, item := range items {
+		if item.Warehouse == "" {
+			ordered = append(ordered, item)
+		}
+	}
+
+	allocated := map[stockKey]int{}
+	for _, item := range ordered {
+		if item.Warehouse != "" {
+			key := stockKey{item.ProductID, item.SKU, item.Warehouse}
+			if available[key] < item.Quantity {
+				return nil, insufficientStock(item)
+			}
+
+			available[key] -= item.Quantity
+			allocated[key] += item.Quantity
+			continue
+		}
+
+		candidates := append([]string{}, warehouses[productKey{item.ProductID, item.SKU}]...)
+		sort.Slice(candidates, func(i, j int) bool {
+			left := available[stockKey{item.ProductID, item.SKU, candidates[i]}]
+			right := available[stockKey{item.ProductID, item.SKU, candidates[j]}]
+			if left != right {
+				return left > right
+			}
+
+			return candidates[i] < candidates[j]
+		})
+
+		remaining := item.Quantity
+		for _, warehouse := range candidates {
+			key := stockKey{item.ProductID, item.SKU, warehouse}
+
+			taken := remaining
+			if available[key] < taken {
+				taken = available[key]
+			}
+
+			if taken <= 0 {
+				break
+			}
+
+			available[key] -= taken
+			allocated[key] += taken
+			remaining -= taken
+		}
+
+		if remaining > 0 {
+			return nil, insufficientStock(item)
+		}
+	}
+
+	result := make([]domain.ReservationItem, 0, len(allocated))
+	for key, quantity := range allocated {
+		result = append(result, domain.ReservationItem{
+			ProductID: key.ProductID,
+			SKU:       key.SKU,
+			Warehouse: key.Warehouse,
+			Quantity:  quantity,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return lessItem(result[i], result[j])
+	})
+
+	return result, nil
+}
+
+// lessItem orders the ReservationItems by Product, SKU and Warehouse
+func lessItem(left domain.ReservationItem, right domain.ReservationItem) bool {
+	if left.ProductID != right.ProductID {
+		return left.ProductID < right.ProductID
+	}
+
+	if left.SKU != right.SKU {
+		return left.SKU < right.SKU
+	}
+
+	return left.Warehouse < right.Warehouse
+}
+
+func insufficientStock(item domain.ReservationItem) error {
+	if item.SKU != "" {
+		return fmt.Errorf("%w: product %d sku %s", domain.ErrInsufficientStock, item.ProductID, item.SKU)
+	}
+
+	return fmt.Errorf("%w: product %d", domain.ErrInsufficientStock, item.ProductID)
+}
@@ -0,0 +1,239 @@
+package app
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	"github.com/lucasmls/ecommerce/services/inventory/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ReserveStockSuite struct {
+	suite.Suite
+
+	stockRepo        *mocks.StockRepository
+	reservationsRepo *mocks.ReservationsRepository
+	app              domain.Application
+}
+
+func (s *ReserveStockSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.stockRepo = &mocks.StockRepository{}
+	s.reservationsRepo = &mocks.ReservationsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.stockRepo, s.reservationsRepo, &mocks.EventPublisher{})
+}
+
+func (s *ReserveStockSuite) Test_ReserveStock() {
+	s.Run("Should fail in case the request is invalid", func() {
+		for request, expected := range map[*domain.ReservationRequest]error{
+			{}: domain.ErrEmptyReservation,
+			{Items: []domain.ReservationItem{{ProductID: 1, Quantity: 0}}}:                      domain.ErrInvalidQuantity,
+			{Items: []domain.ReservationItem{{ProductID: 0, Quantity: 1}}}:                      domain.ErrInvalidStockLocation,
+			{Items: []domain.ReservationItem{{ProductID: 1, Quantity: 1}}, TTL: -time.Hour}:     domain.ErrInvalidReservationTTL,
+			{Items: []domain.ReservationItem{{ProductID: 1, Quantity: 1}}, TTL: 48 * time.Hour}: domain.ErrInvalidReservationTTL,
+		} {
+			_, err := s.app.ReserveStock(context.Background(), *request)
+
+			s.Equal(expected, err)
+		}
+	})
+
+	s.Run("Should fail without reserving anything in case there isn't enough stock", func() {
+		s.stockRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.StockFilter{ProductIDs: []int{1}}).
+			Return([]domain.StockLevel{
+				{ProductID: 1, Warehouse: "east", OnHand: 3, Reserved: 1},
+				{ProductID: 1, Warehouse: "west", OnHand: 1},
+			}, nil)
+
+		_, err := s.app.ReserveStock(context.Background(), domain.ReservationRequest{
+			Items: []domain.ReservationItem{{ProductID: 1, Quantity: 4}},
+		})
+
+		s.ErrorIs(err, domain.ErrInsufficientStock)
+		s.EqualError(err, "insufficient-stock: product 1")
+		s.reservationsRepo.AssertNotCalled(s.T(), "Reserve", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should take the units from the requested warehouses first and then from the fullest ones", func() {
+		s.stockRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.StockFilter{ProductIDs: []int{2, 3}}).
+			Return([]domain.StockLevel{
+				{ProductID: 2, Warehouse: "east", OnHand: 5},
+				{ProductID: 2, Warehouse: "north", OnHand: 2},
+				{ProductID: 2, Warehouse: "west", OnHand: 6, Reserved: 3},
+				{ProductID: 3, SKU: "MACBOOK-GRAY", Warehouse: "east", OnHand: 1},
+			}, nil)
+
+		expectedItems := []domain.ReservationItem{
+			{ProductID: 2, Warehouse: "east", Quantity: 5},
+			{ProductID: 2, Warehouse: "north", Quantity: 1},
+			{ProductID: 2, Warehouse: "west", Quantity: 3},
+			{ProductID: 3, SKU: "MACBOOK-GRAY", Warehouse: "east", Quantity: 1},
+		}
+
+		reserved := domain.Reservation{ID: 1, Reference: "cart-1", Status: domain.ReservationStatusPending, Items: expectedItems}
+
+		s.reservationsRepo.
+			On("GetByReference", mock.AnythingOfType("*context.valueCtx"), "cart-1").
+			Return(domain.Reservation{}, domain.ErrReservationNotFound).
+			Once()
+
+		before := time.Now()
+		s.reservationsRepo.
+			On("Reserve", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(reservation domain.Reservation) bool {
+				return reservation.Reference == "cart-1" &&
+					reservation.Status == domain.ReservationStatusPending &&
+					reflect.DeepEqual(reservation.Items, expectedItems) &&
+					!reservation.ExpiresAt.Before(before.Add(time.Hour)) &&
+					reservation.ExpiresAt.Before(before.Add(time.Hour+time.Minute))
+			})).
+			Return(reserved, nil).
+			Once()
+
+		got, err := s.app.ReserveStock(context.Background(), domain.ReservationRequest{
+			Reference: "cart-1",
+			Items: []domain.ReservationItem{
+				{ProductID: 2, Quantity: 6},
+				{ProductID: 3, SKU: "MACBOOK-GRAY", Quantity: 1},
+				{ProductID: 2, Warehouse: "west", Quantity: 3},
+			},
+			TTL: time.Hour,
+		})
+
+		s.NoError(err)
+		s.Equal(reserved, got)
+	})
+
+	s.Run("Should pick the warehouses again in case the stock changes while reserving it", func() {
+		s.stockRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.StockFilter{ProductIDs: []int{4}}).
+			Return([]domain.StockLevel{
+				{ProductID: 4, Warehouse: "east", OnHand: 2},
+				{ProductID: 4, Warehouse: "west", OnHand: 1},
+			}, nil).
+			Once()
+
+		s.stockRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.StockFilter{ProductIDs: []int{4}}).
+			Return([]domain.StockLevel{
+				{ProductID: 4, Warehouse: "east", OnHand: 2, Reserved: 2},
+				{ProductID: 4, Warehouse: "west", OnHand: 1},
+			}, nil).
+			Once()
+
+		s.reservationsRepo.
+			On("Reserve", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(reservation domain.Reservation) bool {
+				return reflect.DeepEqual(reservation.Items, []domain.ReservationItem{{ProductID: 4, Warehouse: "east", Quantity: 1}})
+			})).
+			Return(domain.Reservation{}, domain.ErrInsufficientStock).
+			Once()
+
+		reserved := domain.Reservation{ID: 2, Status: domain.ReservationStatusPending, Items: []domain.ReservationItem{{ProductID: 4, Warehouse: "west", Quantity: 1}}}
+
+		s.reservationsRepo.
+			On("Reserve", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(reservation domain.Reservation) bool {
+				return reflect.DeepEqual(reservation.Items, reserved.Items) &&
+					reservation.ExpiresAt.Sub(reservation.CreatedAt) == domain.DefaultReservationTTL
+			})).
+			Return(reserved, nil).
+			Once()
+
+		got, err := s.app.ReserveStock(context.Background(), domain.ReservationRequest{
+			Items: []domain.ReservationItem{{ProductID: 4, Quantity: 1}},
+		})
+
+		s.NoError(err)
+		s.Equal(reserved, got)
+	})
+
+	s.Run("Should return the pending reservation already made for the reference without holding more units", func() {
+		reserved := domain.Reservation{
+			ID:        3,
+			Reference: "cart-2",
+			Status:    domain.ReservationStatusPending,
+			Items:     []domain.ReservationItem{{ProductID: 5, Warehouse: "east", Quantity: 2}},
+		}
+
+		s.reservationsRepo.
+			On("GetByReference", mock.AnythingOfType("*context.valueCtx"), "cart-2").
+			Return(reserved, nil).
+			Once()
+
+		got, err := s.app.ReserveStock(context.Background(), domain.ReservationRequest{
+			Reference: "cart-2",
+			Items:     []domain.ReservationItem{{ProductID: 5, Quantity: 2}},
+		})
+
+		s.NoError(err)
+		s.Equal(reserved, got)
+		s.stockRepo.AssertNotCalled(s.T(), "List", mock.Anything, domain.StockFilter{ProductIDs: []int{5}})
+	})
+
+	s.Run("Should fail in case the reservation made for the reference doesn't hold its units anymore", func() {
+		s.reservationsRepo.
+			On("GetByReference", mock.AnythingOfType("*context.valueCtx"), "cart-3").
+			Return(domain.Reservation{ID: 4, Reference: "cart-3", Status: domain.ReservationStatusReleased}, nil).
+			Once()
+
+		_, err := s.app.ReserveStock(context.Background(), domain.ReservationRequest{
+			Reference: "cart-3",
+			Items:     []domain.ReservationItem{{ProductID: 5, Quantity: 2}},
+		})
+
+		s.Equal(domain.ErrReservationNotPending, err)
+		s.stockRepo.AssertNotCalled(s.T(), "List", mock.Anything, domain.StockFilter{ProductIDs: []int{5}})
+	})
+
+	s.Run("Should return the reservation made by another request with the same reference in the meantime", func() {
+		s.reservationsRepo.
+			On("GetByReference", mock.AnythingOfType("*context.valueCtx"), "cart-4").
+			Return(domain.Reservation{}, domain.ErrReservationNotFound).
+			Once()
+
+		s.stockRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.StockFilter{ProductIDs: []int{6}}).
+			Return([]domain.StockLevel{{ProductID: 6, Warehouse: "east", OnHand: 2}}, nil).
+			Once()
+
+		s.reservationsRepo.
+			On("Reserve", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(reservation domain.Reservation) bool {
+				return reservation.Reference == "cart-4"
+			})).
+			Return(domain.Reservation{}, domain.ErrReservationReferenceTaken).
+			Once()
+
+		reserved := domain.Reservation{
+			ID:        5,
+			Reference: "cart-4",
+			Status:    domain.ReservationStatusPending,
+			Items:     []domain.ReservationItem{{ProductID: 6, Warehouse: "east", Quantity: 1}},
+		}
+
+		s.reservationsRepo.
+			On("GetByReference", mock.AnythingOfType("*context.valueCtx"), "cart-4").
+			Return(reserved, nil).
+			Once()
+
+		got, err := s.app.ReserveStock(context.Background(), domain.ReservationRequest{
+			Reference: "cart-4",
+			Items:     []domain.ReservationItem{{ProductID: 6, Quantity: 1}},
+		})
+
+		s.NoError(err)
+		s.Equal(reserved, got)
+	})
+}
+
+func TestReserveStockSuite(t *testing.T) {
+	suite.Run(t, new(ReserveStockSuite))
+}
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	"go.uber.org/zap"
+)
+
+// restock applies a change that may add units to the stock of a Product, telling the other services
+// it's back in stock when none of its units could be reserved before the change and some can after it.
+// The units given back by released or expired Reservations don't count, they were only held for a while.
+func (a application) restock(
+	ctx context.Context,
+	productID int,
+	change func() (domain.StockLevel, error),
+) (domain.StockLevel, error) {
+	before, err := a.available(ctx, productID)
+	if err != nil {
+		return domain.StockLevel{}, err
+	}
+
+	level, err := change()
+	if err != nil || before > 0 {
+		return level, err
+	}
+
+	after, err := a.available(ctx, productID)
+	if err != nil {
+		// the stock already changed, only the event is missed
+		a.Logger.Error("failed to check whether a product is back in stock", zap.Int("productId", productID), zap.Error(err))
+		return level, nil
+	}
+
+	if after > 0 {
+		a.publish(ctx, domain.StockEvent{
+			Type:       domain.EventBackInStock,
+			ProductID:  productID,
+			Available:  after,
+			OccurredAt: time.Now(),
+		})
+	}
+
+	return level, nil
+}
+
+// available sums up the units of a Product that can be reserved across every Warehouse
+func (a application) available(ctx context.Context, productID int) (int, error) {
+	levels, err := a.StockRepository.List(ctx, domain.StockFilter{ProductIDs: []int{productID}})
+	if err != nil {
+		return 0, err
+	}
+
+	available := 0
+	for _, level := range levels {
+		available += level.Available()
+	}
+
+	return available, nil
+}
+
+// publish tells the other services about a StockEvent once the stock changed.
+// The event is only logged when it can't be published, the stock already changed.
+func (a application) publish(ctx context.Context, event domain.StockEvent) {
+	if err := a.EventPublisher.Publish(ctx, event); err != nil {
+		a.Logger.Error(
+			"failed to publish a stock event",
+			zap.Int("productId", event.ProductID),
+			zap.String("type", string(event.Type)),
+			zap.Error(err),
+		)
+	}
+}
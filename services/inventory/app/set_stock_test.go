@@ -0,0 +1,170 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	"github.com/lucasmls/ecommerce/services/inventory/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type SetStockSuite struct {
+	suite.Suite
+
+	stockRepo        *mocks.StockRepository
+	reservationsRepo *mocks.ReservationsRepository
+	publisher        *mocks.EventPublisher
+	app              domain.Application
+}
+
+func (s *SetStockSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.stockRepo = &mocks.StockRepository{}
+	s.reservationsRepo = &mocks.ReservationsRepository{}
+	s.publisher = &mocks.EventPublisher{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.stockRepo, s.reservationsRepo, s.publisher)
+}
+
+func (s *SetStockSuite) Test_SetStock() {
+	s.Run("Should fail in case the stock location is invalid", func() {
+		for _, change := range []domain.StockChange{
+			{ProductID: 0, Warehouse: "east", Quantity: 1},
+			{ProductID: 1, Warehouse: "", Quantity: 1},
+			{ProductID: 1, SKU: "not a sku", Warehouse: "east", Quantity: 1},
+		} {
+			_, err := s.app.SetStock(context.Background(), change)
+
+			s.Equal(domain.ErrInvalidStockLocation, err)
+		}
+	})
+
+	s.Run("Should fail in case the quantity is negative", func() {
+		_, err := s.app.SetStock(context.Background(), domain.StockChange{ProductID: 1, Warehouse: "east", Quantity: -1})
+
+		s.Equal(domain.ErrInvalidQuantity, err)
+	})
+
+	s.Run("Should return the error in case the quantity is lower than the reserved one", func() {
+		change := domain.StockChange{ProductID: 1, Warehouse: "east", Quantity: 2}
+
+		s.stockRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.StockFilter{ProductIDs: []int{1}}).
+			Return([]domain.StockLevel{{ProductID: 1, Warehouse: "east", OnHand: 5, Reserved: 3}}, nil).
+			Once()
+
+		s.stockRepo.
+			On("Set", mock.AnythingOfType("*context.valueCtx"), change).
+			Return(domain.StockLevel{}, domain.ErrStockBelowReserved)
+
+		_, err := s.app.SetStock(context.Background(), change)
+
+		s.Equal(domain.ErrStockBelowReserved, err)
+	})
+
+	s.Run("Should return the stock level", func() {
+		change := domain.StockChange{ProductID: 1, SKU: "IPHONE-13-BLUE", Warehouse: "east", Quantity: 10}
+		level := domain.StockLevel{ProductID: 1, SKU: "IPHONE-13-BLUE", Warehouse: "east", OnHand: 10, Reserved: 3}
+
+		s.stockRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.StockFilter{ProductIDs: []int{1}}).
+			Return([]domain.StockLevel{{ProductID: 1, SKU: "IPHONE-13-BLUE", Warehouse: "east", OnHand: 5, Reserved: 3}}, nil).
+			Once()
+
+		s.stockRepo.
+			On("Set", mock.AnythingOfType("*context.valueCtx"), change).
+			Return(level, nil)
+
+		got, err := s.app.SetStock(context.Background(), change)
+
+		s.NoError(err)
+		s.Equal(level, got)
+		s.publisher.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should tell the product is back in stock in case none of its units could be reserved before", func() {
+		change := domain.StockChange{ProductID: 2, Warehouse: "east", Quantity: 4}
+		level := domain.StockLevel{ProductID: 2, Warehouse: "east", OnHand: 4}
+
+		// every unit was held by a reservation, and the west warehouse ran out
+		s.stockRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.StockFilter{ProductIDs: []int{2}}).
+			Return([]domain.StockLevel{
+				{ProductID: 2, Warehouse: "east", OnHand: 1, Reserved: 1},
+				{ProductID: 2, Warehouse: "west"},
+			}, nil).
+			Once()
+
+		s.stockRepo.
+			On("Set", mock.AnythingOfType("*context.valueCtx"), change).
+			Return(level, nil).
+			Once()
+
+		s.stockRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.StockFilter{ProductIDs: []int{2}}).
+			Return([]domain.StockLevel{
+				{ProductID: 2, Warehouse: "east", OnHand: 4, Reserved: 1},
+				{ProductID: 2, Warehouse: "west"},
+			}, nil).
+			Once()
+
+		s.publisher.
+			On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(event domain.StockEvent) bool {
+				return event.Type == domain.EventBackInStock &&
+					event.ProductID == 2 &&
+					event.Available == 3 &&
+					!event.OccurredAt.IsZero()
+			})).
+			Return(nil).
+			Once()
+
+		got, err := s.app.SetStock(context.Background(), change)
+
+		s.NoError(err)
+		s.Equal(level, got)
+		s.publisher.AssertExpectations(s.T())
+	})
+
+	s.Run("Should set the stock even in case the product being back in stock can't be told", func() {
+		change := domain.StockChange{ProductID: 3, Warehouse: "east", Quantity: 1}
+		level := domain.StockLevel{ProductID: 3, Warehouse: "east", OnHand: 1}
+
+		s.stockRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.StockFilter{ProductIDs: []int{3}}).
+			Return([]domain.StockLevel{}, nil).
+			Once()
+
+		s.stockRepo.
+			On("Set", mock.AnythingOfType("*context.valueCtx"), change).
+			Return(level, nil).
+			Once()
+
+		s.stockRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.StockFilter{ProductIDs: []int{3}}).
+			Return([]domain.StockLevel{level}, nil).
+			Once()
+
+		s.publisher.
+			On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(event domain.StockEvent) bool {
+				return event.ProductID == 3
+			})).
+			Return(errors.New("channel closed")).
+			Once()
+
+		got, err := s.app.SetStock(context.Background(), change)
+
+		s.NoError(err)
+		s.Equal(level, got)
+	})
+}
+
+func TestSetStockSuite(t *testing.T) {
+	suite.Run(t, new(SetStockSuite))
+}
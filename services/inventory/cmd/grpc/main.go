@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	checkoutPb "github.com/lucasmls/ecommerce/services/checkout/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/services/inventory/adapters/events"
+	"github.com/lucasmls/ecommerce/services/inventory/adapters/repositories"
+	"github.com/lucasmls/ecommerce/services/inventory/app"
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	resolvers "github.com/lucasmls/ecommerce/services/inventory/ports/grpc"
+	protog "github.com/lucasmls/ecommerce/services/inventory/ports/grpc/proto"
+	consumers "github.com/lucasmls/ecommerce/services/inventory/ports/rmq"
+	rmqPb "github.com/lucasmls/ecommerce/services/inventory/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/shared/env"
+	"github.com/lucasmls/ecommerce/shared/grpc"
+	"github.com/lucasmls/ecommerce/shared/rmq"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otel "go.opentelemetry.io/otel"
+	otelJaegerExporter "go.opentelemetry.io/otel/exporters/jaeger"
+	otelPropagation "go.opentelemetry.io/otel/propagation"
+	otelSdkResource "go.opentelemetry.io/otel/sdk/resource"
+	otelTraceSdk "go.opentelemetry.io/otel/sdk/trace"
+	otelSemconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.uber.org/zap"
+	gGRPC "google.golang.org/grpc"
+)
+
+// defaultExpirationInterval is how often the expired reservations are released when EXPIRATION_INTERVAL isn't set
+const defaultExpirationInterval = time.Minute
+
+type ApplicationConfig struct {
+	ServiceName              string `mapstructure:"SERVICE_NAME"`
+	JaegerEndpoint           string `mapstructure:"JAEGER_ENDPOINT"`
+	GrpcServerPort           int    `mapstructure:"GRPC_SERVER_PORT"`
+	MetricsPort              int    `mapstructure:"METRICS_PORT"`
+	PostgresConnectionString string `mapstructure:"PG_CONNECTION_STRING"`
+	// ExpirationInterval is how often the expired reservations give their units back, e.g. 1m
+	ExpirationInterval time.Duration `mapstructure:"EXPIRATION_INTERVAL"`
+	// RabbitMQURL is where the commands of the checkout saga come from and the stock events are published to
+	RabbitMQURL string `mapstructure:"RABBITMQ_URL"`
+}
+
+func main() {
+	ctx := context.Background()
+
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	config, err := env.LoadConfig[ApplicationConfig]()
+	if err != nil {
+		logger.Fatal("failed to load application config", zap.Error(err))
+	}
+
+	jaegerExporter, err := otelJaegerExporter.New(
+		otelJaegerExporter.WithCollectorEndpoint(
+			otelJaegerExporter.WithEndpoint(config.JaegerEndpoint),
+		),
+	)
+	if err != nil {
+		logger.Fatal("failed to instantiate Jaeger exporter", zap.Error(err))
+	}
+
+	tracingProvider := otelTraceSdk.NewTracerProvider(
+		otelTraceSdk.WithBatcher(jaegerExporter),
+		otelTraceSdk.WithSampler(otelTraceSdk.AlwaysSample()),
+		otelTraceSdk.WithResource(otelSdkResource.NewWithAttributes(
+			otelSemconv.SchemaURL,
+			otelSemconv.ServiceNameKey.String(config.ServiceName),
+		)),
+	)
+
+	defer func() {
+		_ = tracingProvider.Shutdown(ctx)
+	}()
+
+	otel.SetTracerProvider(tracingProvider)
+	otel.SetTextMapPropagator(otelPropagation.NewCompositeTextMapPropagator(
+		otelPropagation.TraceContext{},
+		otelPropagation.Baggage{},
+	))
+
+	tracer := otel.Tracer(config.ServiceName)
+
+	rmqClient := rmq.MustNewClient(rmq.ClientInput{
+		URL:    config.RabbitMQURL,
+		Logger: logger,
+	}).MustConnect()
+	defer rmqClient.Close()
+
+	if err := rmqClient.DeclareExchange(rmqPb.Exchange); err != nil {
+		logger.Fatal("failed to declare the inventory exchange", zap.Error(err))
+	}
+
+	eventPublisher := events.NewRmqEventPublisher(logger, tracer, rmqClient)
+
+	inMemoryInventoryRepository := repositories.NewInMemoryInventoryRepository(logger, tracer)
+	// postgresInventoryRepository := repositories.MustNewPgInventoryRepository(config.PostgresConnectionString)
+
+	application := app.MustNewApplication(logger, tracer, inMemoryInventoryRepository, inMemoryInventoryRepository, eventPublisher)
+	inventoryResolver := resolvers.MustNewInventoryResolver(logger, tracer, application)
+
+	checkoutConsumer := consumers.MustNewCheckoutConsumer(logger, tracer, application, rmqClient)
+
+	go func() {
+		err := rmqClient.Consume(ctx, rmq.ConsumerInput{
+			Exchange:    checkoutPb.Exchange,
+			Queue:       checkoutPb.CommandsQueue(checkoutPb.ParticipantInventory),
+			RoutingKeys: []string{checkoutPb.CommandsRoutingKey(checkoutPb.ParticipantInventory)},
+			Handler:     checkoutConsumer.Handle,
+		})
+		if err != nil {
+			logger.Fatal("failed to consume the checkout commands", zap.Error(err))
+		}
+	}()
+
+	server := grpc.MustNewServer(grpc.ServerInput{
+		Port:   config.GrpcServerPort,
+		Logger: logger,
+		Registrator: func(server gGRPC.ServiceRegistrar) {
+			protog.RegisterInventoryServiceServer(server, inventoryResolver)
+		},
+	})
+
+	go func() {
+		port := fmt.Sprintf(":%d", config.MetricsPort)
+
+		http.Handle("/metrics", promhttp.Handler())
+		_ = http.ListenAndServe(port, nil)
+	}()
+
+	expirationInterval := config.ExpirationInterval
+	if expirationInterval <= 0 {
+		expirationInterval = defaultExpirationInterval
+	}
+
+	go expireReservations(ctx, logger, application, expirationInterval)
+
+	if err := server.Run(ctx); err != nil {
+		logger.Fatal("failed to run gRPC server", zap.Error(err))
+	}
+}
+
+// expireReservations gives back the units held by the expired reservations on every tick of the interval.
+// Running it on many replicas is safe, each expired reservation is only released once.
+func expireReservations(ctx context.Context, logger *zap.Logger, application domain.Application, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := application.ExpireReservations(ctx); err != nil {
+				logger.Error("failed to expire the reservations", zap.Error(err))
+			}
+		}
+	}
+}
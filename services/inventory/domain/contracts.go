@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Application defines boundary interfaces of the application
+// It should be called by the ports
+type Application interface {
+	// GetStock sums up the stock of the Products matching the filter, ordered by Product.
+	// Every one of the filtered Products is listed, the ones without stock having none of it.
+	GetStock(context.Context, StockFilter) ([]ProductStock, error)
+
+	// SetStock sets the amount of units of a Product kept on a Warehouse, e.g. after counting them
+	SetStock(context.Context, StockChange) (StockLevel, error)
+
+	// AdjustStock adds units of a Product to a Warehouse, e.g. when they're received, or removes them when negative
+	AdjustStock(context.Context, StockChange) (StockLevel, error)
+
+	// ReserveStock holds units of Products until the Reservation is committed, released or expires.
+	// It fails with ErrInsufficientStock, holding none of the units, when any of them can't be held.
+	// A request sent again with the Reference of a pending Reservation gets it back without holding more units,
+	// and fails with ErrReservationNotPending once that Reservation was committed, released or expired.
+	ReserveStock(context.Context, ReservationRequest) (Reservation, error)
+
+	// GetReservation fetches a single Reservation
+	GetReservation(context.Context, int) (Reservation, error)
+
+	// CommitReservation takes the held units out of the stock, failing with ErrReservationExpired if it's too late
+	CommitReservation(context.Context, int) (Reservation, error)
+
+	// ReleaseReservation gives the held units back, releasing an already released or expired Reservation is a no-op
+	ReleaseReservation(context.Context, int) (Reservation, error)
+
+	// ExpireReservations gives back the units held by the Reservations that weren't committed in time,
+	// returning how many Reservations expired
+	ExpireReservations(context.Context) (int, error)
+}
+
+type StockRepository interface {
+	// List lists the StockLevels matching the filter from a data storage, ordered by Product, SKU and Warehouse.
+	List(context.Context, StockFilter) ([]StockLevel, error)
+
+	// Set sets the OnHand units of a StockLevel in a data storage, creating it when missing.
+	// It fails with ErrStockBelowReserved, leaving the StockLevel untouched, when they'd be fewer than the Reserved ones.
+	Set(context.Context, StockChange) (StockLevel, error)
+
+	// Adjust adds the Quantity of the change to the OnHand units of a StockLevel, with the same guarantees of Set.
+	// The read of the current units and the write must happen atomically.
+	Adjust(context.Context, StockChange) (StockLevel, error)
+}
+
+type ReservationsRepository interface {
+	// Reserve stores a pending Reservation in a data storage, setting its ID, and adds the units of its items
+	// to the Reserved units of their StockLevels. The check of the Available units and the write must happen
+	// atomically: it fails with ErrInsufficientStock, holding none of the units, when a StockLevel is missing
+	// or doesn't have enough of them, and with ErrReservationReferenceTaken, holding none of the units, when
+	// another Reservation has its non-empty Reference.
+	Reserve(context.Context, Reservation) (Reservation, error)
+
+	// Get fetches a single Reservation from a data storage.
+	Get(context.Context, int) (Reservation, error)
+
+	// GetByReference fetches the Reservation with the given non-empty Reference from a data storage.
+	GetByReference(context.Context, string) (Reservation, error)
+
+	// Commit takes the units of a pending Reservation out of the OnHand and Reserved units of its StockLevels.
+	// It fails with ErrReservationExpired when the Reservation expired at the given time, and with
+	// ErrReservationNotPending when it isn't pending anymore.
+	Commit(context.Context, int, time.Time) (Reservation, error)
+
+	// Release gives the units of a pending Reservation back, moving it to the given status, either released or expired.
+	// A Reservation that was already released or expired is returned as is, one that was committed fails with
+	// ErrReservationNotPending.
+	Release(context.Context, int, ReservationStatus) (Reservation, error)
+
+	// Expire releases, as expired, up to the limit of the pending Reservations that expired at the given time,
+	// returning them.
+	Expire(context.Context, time.Time, int) ([]Reservation, error)
+}
+
+type EventPublisher interface {
+	// Publish tells the other services about a StockEvent
+	Publish(context.Context, StockEvent) error
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// EventType is something that happened to the stock of a Product
+type EventType string
+
+const (
+	// EventBackInStock tells some units of a Product can be reserved again, after none of them could
+	EventBackInStock EventType = "back_in_stock"
+)
+
+// StockEvent tells the other services about a change to the stock of a Product, e.g. so the customers waiting for it are notified
+type StockEvent struct {
+	Type      EventType
+	ProductID int
+	// Available is the amount of units of the Product that can be reserved right after the change
+	Available  int
+	OccurredAt time.Time
+}
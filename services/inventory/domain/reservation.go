@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultReservationTTL is how long a Reservation holds its units when the request doesn't tell
+	DefaultReservationTTL = 15 * time.Minute
+	// MaxReservationTTL is the longest a Reservation may hold its units
+	MaxReservationTTL = 24 * time.Hour
+	// MaxReservationItems is the maximum amount of items a single Reservation may have
+	MaxReservationItems = 100
+	// MaxReservationReferenceLength is the maximum amount of characters of a Reservation reference
+	MaxReservationReferenceLength = 255
+)
+
+var (
+	ErrEmptyReservation      = errors.New("empty-reservation")
+	ErrInvalidReservation    = errors.New("invalid-reservation")
+	ErrInvalidReservationTTL = errors.New("invalid-reservation-ttl")
+	ErrReservationNotFound   = errors.New("reservation-not-found")
+	ErrReservationExpired    = errors.New("reservation-expired")
+	ErrReservationNotPending = errors.New("reservation-not-pending")
+	// ErrReservationReferenceTaken is returned by the ReservationsRepository when another Reservation has the reference
+	ErrReservationReferenceTaken = errors.New("reservation-reference-taken")
+)
+
+// ReservationStatus is the stage of the lifecycle a Reservation is at
+type ReservationStatus string
+
+const (
+	// ReservationStatusPending holds the units until the Reservation is committed, released or expires
+	ReservationStatusPending ReservationStatus = "pending"
+	// ReservationStatusCommitted took the units out of the stock
+	ReservationStatusCommitted ReservationStatus = "committed"
+	// ReservationStatusReleased gave the units back before the Reservation expired
+	ReservationStatusReleased ReservationStatus = "released"
+	// ReservationStatusExpired gave the units back because the Reservation wasn't committed in time
+	ReservationStatusExpired ReservationStatus = "expired"
+)
+
+// ReservationItem is an amount of units of a Product held on a Warehouse.
+type ReservationItem struct {
+	ProductID int
+	SKU       string
+	// Warehouse may be left empty on a ReservationRequest, letting the units be taken from any Warehouse.
+	Warehouse string
+	Quantity  int
+}
+
+// Reservation holds units of Products until they're committed, e.g. when an order is paid,
+// released, e.g. when it's cancelled, or the Reservation expires.
+type Reservation struct {
+	ID int
+	// Reference identifies what the units are held for, such as a cart or an order.
+	// A non-empty Reference is only reserved once.
+	Reference string
+	Status    ReservationStatus
+	// Items always have a Warehouse, a requested item may be split across many of them.
+	Items     []ReservationItem
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Expired reports whether a pending Reservation outlived its expiration at the given time
+func (r Reservation) Expired(now time.Time) bool {
+	return r.Status == ReservationStatusPending && !now.Before(r.ExpiresAt)
+}
+
+// ReservationRequest asks for units of Products to be held.
+type ReservationRequest struct {
+	Reference string
+	Items     []ReservationItem
+	// TTL is how long the units are held, it defaults to DefaultReservationTTL.
+	TTL time.Duration
+}
+
+// Validate checks the ReservationRequest, the items without a Warehouse are valid
+func (r ReservationRequest) Validate() error {
+	if len(r.Items) == 0 {
+		return ErrEmptyReservation
+	}
+
+	if len(r.Items) > MaxReservationItems || len([]rune(r.Reference)) > MaxReservationReferenceLength {
+		return ErrInvalidReservation
+	}
+
+	if r.TTL < 0 || r.TTL > MaxReservationTTL {
+		return ErrInvalidReservationTTL
+	}
+
+	for _, item := range r.Items {
+		warehouse := item.Warehouse
+		if warehouse == "" {
+			// any valid name does, the item is only checked for its Product and SKU
+			warehouse = "any"
+		}
+
+		if err := validateLocation(item.ProductID, item.SKU, warehouse); err != nil {
+			return err
+		}
+
+		if item.Quantity <= 0 {
+			return ErrInvalidQuantity
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+var (
+	ErrInvalidStockLocation = errors.New("invalid-stock-location")
+	ErrInvalidQuantity      = errors.New("invalid-quantity")
+	ErrStockBelowReserved   = errors.New("stock-below-reserved")
+	ErrInsufficientStock    = errors.New("insufficient-stock")
+)
+
+var (
+	skuPattern       = regexp.MustCompile(`^[A-Za-z0-9._-]{0,64}$`)
+	warehousePattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+)
+
+// StockLevel is the amount of units of a Product kept on a Warehouse.
+type StockLevel struct {
+	ProductID int
+	// SKU identifies a variation of the Product, an empty one stands for the Product itself.
+	SKU       string
+	Warehouse string
+	// OnHand is the amount of units physically kept on the Warehouse.
+	OnHand int
+	// Reserved is the amount of the OnHand units held by pending Reservations, it never exceeds OnHand.
+	Reserved  int
+	UpdatedAt time.Time
+}
+
+// Available is the amount of units that can still be reserved
+func (l StockLevel) Available() int {
+	return l.OnHand - l.Reserved
+}
+
+// ProductStock sums up the StockLevels of a Product across every Warehouse.
+type ProductStock struct {
+	ProductID int
+	OnHand    int
+	Reserved  int
+	// Levels are ordered by SKU and then by Warehouse.
+	Levels []StockLevel
+}
+
+// Available is the amount of units of the Product that can still be reserved
+func (s ProductStock) Available() int {
+	return s.OnHand - s.Reserved
+}
+
+// StockFilter narrows the StockLevels down, its unset fields match every StockLevel.
+type StockFilter struct {
+	ProductIDs []int
+	SKU        string
+	Warehouse  string
+}
+
+// StockChange changes the OnHand units of the StockLevel of a Product on a Warehouse.
+// Quantity is the new amount of units when the stock is set, and the amount added
+// (or removed, when negative) when it's adjusted.
+type StockChange struct {
+	ProductID int
+	SKU       string
+	Warehouse string
+	Quantity  int
+}
+
+// Validate checks that the StockChange points to a valid StockLevel
+func (c StockChange) Validate() error {
+	return validateLocation(c.ProductID, c.SKU, c.Warehouse)
+}
+
+// validateLocation checks the fields identifying a StockLevel
+func validateLocation(productID int, sku string, warehouse string) error {
+	if productID <= 0 || !skuPattern.MatchString(sku) || !warehousePattern.MatchString(warehouse) {
+		return ErrInvalidStockLocation
+	}
+
+	return nil
+}
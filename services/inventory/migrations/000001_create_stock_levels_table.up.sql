@@ -0,0 +1,12 @@
+-- product_id has no foreign key since the products live on the products service
+CREATE TABLE IF NOT EXISTS stock_levels (
+    product_id INTEGER     NOT NULL,
+    sku        TEXT        NOT NULL DEFAULT '',
+    warehouse  TEXT        NOT NULL,
+    on_hand    INTEGER     NOT NULL DEFAULT 0,
+    reserved   INTEGER     NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (product_id, sku, warehouse),
+    -- the last line of defense against overselling, the repository only holds the available units
+    CONSTRAINT stock_levels_reserved_check CHECK (reserved >= 0 AND reserved <= on_hand)
+);
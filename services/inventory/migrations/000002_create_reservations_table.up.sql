@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS reservations (
+    id         SERIAL PRIMARY KEY,
+    reference  TEXT        NOT NULL DEFAULT '',
+    status     TEXT        NOT NULL,
+    items      JSONB       NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+-- only the pending reservations are looked up by their expiration
+CREATE INDEX reservations_pending_expires_at_idx ON reservations (expires_at) WHERE status = 'pending';
@@ -0,0 +1,2 @@
+-- a reference is only reserved once, so a request sent again gets the Reservation it already made
+CREATE UNIQUE INDEX reservations_reference_idx ON reservations (reference) WHERE reference <> '';
@@ -0,0 +1,345 @@
+package grpc_port
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	pb "github.com/lucasmls/ecommerce/services/inventory/ports/grpc/proto"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	InternalServerError = status.Error(codes.Internal, "Internal server error")
+)
+
+var (
+	ErrMissingLogger = errors.New("missing required dependency: Logger")
+	ErrMisingTracer  = errors.New("missing required dependency: Tracer")
+)
+
+// InventoryResolver ...
+type InventoryResolver struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+	App    domain.Application
+
+	pb.UnimplementedInventoryServiceServer
+}
+
+func NewInventoryResolver(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) (*InventoryResolver, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMisingTracer
+	}
+
+	return &InventoryResolver{
+		Logger: logger,
+		Tracer: tracer,
+		App:    app,
+	}, nil
+}
+
+// MustNewInventoryResolver creates a new InventoryResolver instance.
+// It panics if any error is found
+func MustNewInventoryResolver(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) *InventoryResolver {
+	inventoryResolver, err := NewInventoryResolver(logger, tracer, app)
+	if err != nil {
+		panic(err)
+	}
+
+	return inventoryResolver
+}
+
+func (r *InventoryResolver) GetStock(ctx context.Context, req *pb.GetStockRequest) (*pb.GetStockResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.GetStock")
+	defer span.End()
+
+	filter := domain.StockFilter{
+		SKU:       req.Sku,
+		Warehouse: req.Warehouse,
+	}
+	for _, id := range req.ProductIds {
+		filter.ProductIDs = append(filter.ProductIDs, int(id))
+	}
+
+	stock, err := r.App.GetStock(ctx, filter)
+	if err != nil {
+		r.Logger.Sugar().Errorw(
+			"failed to get the stock",
+			zap.Error(err),
+			zap.Any("filter", filter),
+		)
+
+		return nil, InternalServerError
+	}
+
+	response := &pb.GetStockResponse{
+		Data: make([]*pb.ProductStock, 0, len(stock)),
+	}
+
+	for _, productStock := range stock {
+		levels := make([]*pb.StockLevel, 0, len(productStock.Levels))
+		for _, level := range productStock.Levels {
+			levels = append(levels, toProtoStockLevel(level))
+		}
+
+		response.Data = append(response.Data, &pb.ProductStock{
+			ProductId: int32(productStock.ProductID),
+			OnHand:    int32(productStock.OnHand),
+			Reserved:  int32(productStock.Reserved),
+			Available: int32(productStock.Available()),
+			Levels:    levels,
+		})
+	}
+
+	return response, nil
+}
+
+func (r *InventoryResolver) SetStock(ctx context.Context, req *pb.SetStockRequest) (*pb.SetStockResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.SetStock")
+	defer span.End()
+
+	change := domain.StockChange{
+		ProductID: int(req.ProductId),
+		SKU:       req.Sku,
+		Warehouse: req.Warehouse,
+		Quantity:  int(req.OnHand),
+	}
+
+	level, err := r.App.SetStock(ctx, change)
+	if err != nil {
+		if statusErr := toStatusError(err); statusErr != nil {
+			return nil, statusErr
+		}
+
+		r.Logger.Sugar().Errorw(
+			"failed to set the stock",
+			zap.Error(err),
+			zap.Any("change", change),
+		)
+
+		return nil, InternalServerError
+	}
+
+	return &pb.SetStockResponse{Data: toProtoStockLevel(level)}, nil
+}
+
+func (r *InventoryResolver) AdjustStock(ctx context.Context, req *pb.AdjustStockRequest) (*pb.AdjustStockResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.AdjustStock")
+	defer span.End()
+
+	change := domain.StockChange{
+		ProductID: int(req.ProductId),
+		SKU:       req.Sku,
+		Warehouse: req.Warehouse,
+		Quantity:  int(req.Delta),
+	}
+
+	level, err := r.App.AdjustStock(ctx, change)
+	if err != nil {
+		if statusErr := toStatusError(err); statusErr != nil {
+			return nil, statusErr
+		}
+
+		r.Logger.Sugar().Errorw(
+			"failed to adjust the stock",
+			zap.Error(err),
+			zap.Any("change", change),
+		)
+
+		return nil, InternalServerError
+	}
+
+	return &pb.AdjustStockResponse{Data: toProtoStockLevel(level)}, nil
+}
+
+func (r *InventoryResolver) Reserve(ctx context.Context, req *pb.ReserveRequest) (*pb.ReserveResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.Reserve")
+	defer span.End()
+
+	request := domain.ReservationRequest{
+		Reference: req.Reference,
+		Items:     make([]domain.ReservationItem, 0, len(req.Items)),
+	}
+
+	if req.Ttl != nil {
+		if err := req.Ttl.CheckValid(); err != nil {
+			return nil, status.Error(codes.InvalidArgument, domain.ErrInvalidReservationTTL.Error())
+		}
+
+		request.TTL = req.Ttl.AsDuration()
+	}
+
+	for _, item := range req.Items {
+		request.Items = append(request.Items, domain.ReservationItem{
+			ProductID: int(item.ProductId),
+			SKU:       item.Sku,
+			Warehouse: item.Warehouse,
+			Quantity:  int(item.Quantity),
+		})
+	}
+
+	reservation, err := r.App.ReserveStock(ctx, request)
+	if err != nil {
+		if statusErr := toStatusError(err); statusErr != nil {
+			return nil, statusErr
+		}
+
+		r.Logger.Sugar().Errorw(
+			"failed to reserve the stock",
+			zap.Error(err),
+			zap.Any("request", request),
+		)
+
+		return nil, InternalServerError
+	}
+
+	return &pb.ReserveResponse{Data: toProtoReservation(reservation)}, nil
+}
+
+func (r *InventoryResolver) GetReservation(ctx context.Context, req *pb.GetReservationRequest) (*pb.GetReservationResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.GetReservation")
+	defer span.End()
+
+	reservation, err := r.App.GetReservation(ctx, int(req.Id))
+	if err != nil {
+		if statusErr := toStatusError(err); statusErr != nil {
+			return nil, statusErr
+		}
+
+		r.Logger.Sugar().Errorw(
+			"failed to get the reservation",
+			zap.Error(err),
+			zap.Int32("reservationId", req.Id),
+		)
+
+		return nil, InternalServerError
+	}
+
+	return &pb.GetReservationResponse{Data: toProtoReservation(reservation)}, nil
+}
+
+func (r *InventoryResolver) CommitReservation(ctx context.Context, req *pb.CommitReservationRequest) (*pb.CommitReservationResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.CommitReservation")
+	defer span.End()
+
+	reservation, err := r.App.CommitReservation(ctx, int(req.Id))
+	if err != nil {
+		if statusErr := toStatusError(err); statusErr != nil {
+			return nil, statusErr
+		}
+
+		r.Logger.Sugar().Errorw(
+			"failed to commit the reservation",
+			zap.Error(err),
+			zap.Int32("reservationId", req.Id),
+		)
+
+		return nil, InternalServerError
+	}
+
+	return &pb.CommitReservationResponse{Data: toProtoReservation(reservation)}, nil
+}
+
+func (r *InventoryResolver) ReleaseReservation(ctx context.Context, req *pb.ReleaseReservationRequest) (*pb.ReleaseReservationResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.ReleaseReservation")
+	defer span.End()
+
+	reservation, err := r.App.ReleaseReservation(ctx, int(req.Id))
+	if err != nil {
+		if statusErr := toStatusError(err); statusErr != nil {
+			return nil, statusErr
+		}
+
+		r.Logger.Sugar().Errorw(
+			"failed to release the reservation",
+			zap.Error(err),
+			zap.Int32("reservationId", req.Id),
+		)
+
+		return nil, InternalServerError
+	}
+
+	return &pb.ReleaseReservationResponse{Data: toProtoReservation(reservation)}, nil
+}
+
+// toStatusError translates the errors the domain knows about, returning nil for the other ones
+func toStatusError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidStockLocation),
+		errors.Is(err, domain.ErrInvalidQuantity),
+		errors.Is(err, domain.ErrEmptyReservation),
+		errors.Is(err, domain.ErrInvalidReservation),
+		errors.Is(err, domain.ErrInvalidReservationTTL):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrReservationNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrInsufficientStock),
+		errors.Is(err, domain.ErrStockBelowReserved),
+		errors.Is(err, domain.ErrReservationExpired),
+		errors.Is(err, domain.ErrReservationNotPending):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return nil
+	}
+}
+
+func toProtoStockLevel(level domain.StockLevel) *pb.StockLevel {
+	return &pb.StockLevel{
+		ProductId: int32(level.ProductID),
+		Sku:       level.SKU,
+		Warehouse: level.Warehouse,
+		OnHand:    int32(level.OnHand),
+		Reserved:  int32(level.Reserved),
+		Available: int32(level.Available()),
+		UpdatedAt: toProtoTimestamp(level.UpdatedAt),
+	}
+}
+
+func toProtoReservation(reservation domain.Reservation) *pb.Reservation {
+	items := make([]*pb.ReservationItem, 0, len(reservation.Items))
+	for _, item := range reservation.Items {
+		items = append(items, &pb.ReservationItem{
+			ProductId: int32(item.ProductID),
+			Sku:       item.SKU,
+			Warehouse: item.Warehouse,
+			Quantity:  int32(item.Quantity),
+		})
+	}
+
+	return &pb.Reservation{
+		Id:        int32(reservation.ID),
+		Reference: reservation.Reference,
+		Status:    string(reservation.Status),
+		Items:     items,
+		ExpiresAt: toProtoTimestamp(reservation.ExpiresAt),
+		CreatedAt: toProtoTimestamp(reservation.CreatedAt),
+		UpdatedAt: toProtoTimestamp(reservation.UpdatedAt),
+	}
+}
+
+// toProtoTimestamp leaves the zero times unset
+func toProtoTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
@@ -0,0 +1,312 @@
+package grpc_port
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/shared/grpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	gGRPC "google.golang.org/grpc"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	"github.com/lucasmls/ecommerce/services/inventory/mocks"
+	protog "github.com/lucasmls/ecommerce/services/inventory/ports/grpc/proto"
+)
+
+type InventoryResolverSuite struct {
+	suite.Suite
+
+	app    *mocks.Application
+	logger *zap.Logger
+	tracer trace.Tracer
+
+	grpcClient     protog.InventoryServiceClient
+	grpcConnection *gGRPC.ClientConn
+}
+
+func (s *InventoryResolverSuite) SetupSuite() {
+	s.logger = zap.NewNop()
+	s.tracer = trace.NewNoopTracerProvider().Tracer("")
+	s.app = &mocks.Application{}
+
+	grpcClient, grpcConnection := makeGrpcClientAndServer(
+		context.Background(),
+		s.logger,
+		s.tracer,
+		s.app,
+	)
+
+	s.grpcClient = grpcClient
+	s.grpcConnection = grpcConnection
+}
+
+func (s *InventoryResolverSuite) TearDownSuite() {
+	err := s.grpcConnection.Close()
+	s.NoError(err)
+}
+
+func (s *InventoryResolverSuite) Test_NewInventoryResolver() {
+	s.Run("Should fail to instantiate the InventoryResolver in case a Logger isn't provided", func() {
+		_, err := NewInventoryResolver(nil, nil, nil)
+
+		s.Equal(ErrMissingLogger, err)
+	})
+
+	s.Run("Should fail to instantiate the InventoryResolver in case a Tracer isn't provided", func() {
+		_, err := NewInventoryResolver(s.logger, nil, nil)
+
+		s.Equal(ErrMisingTracer, err)
+	})
+
+	s.Run("Should successfully instantiate the InventoryResolver", func() {
+		_, err := NewInventoryResolver(s.logger, s.tracer, nil)
+
+		s.NoError(err)
+	})
+}
+
+func (s *InventoryResolverSuite) Test_GetStock() {
+	s.Run("Should return the stock of the products", func() {
+		updatedAt := time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+
+		s.app.
+			On("GetStock", mock.AnythingOfType("*context.valueCtx"), domain.StockFilter{ProductIDs: []int{1, 2}, Warehouse: "east"}).
+			Return([]domain.ProductStock{
+				{
+					ProductID: 1,
+					OnHand:    10,
+					Reserved:  4,
+					Levels: []domain.StockLevel{
+						{ProductID: 1, Warehouse: "east", OnHand: 10, Reserved: 4, UpdatedAt: updatedAt},
+					},
+				},
+				{ProductID: 2, Levels: []domain.StockLevel{}},
+			}, nil)
+
+		got, err := s.grpcClient.GetStock(context.Background(), &protog.GetStockRequest{ProductIds: []int32{1, 2}, Warehouse: "east"})
+
+		s.NoError(err)
+		s.Require().Len(got.Data, 2)
+		s.Equal(int32(6), got.Data[0].Available)
+		s.Require().Len(got.Data[0].Levels, 1)
+		s.Equal(int32(6), got.Data[0].Levels[0].Available)
+		s.Equal(updatedAt, got.Data[0].Levels[0].UpdatedAt.AsTime())
+		s.Equal(int32(2), got.Data[1].ProductId)
+		s.Empty(got.Data[1].Levels)
+	})
+}
+
+func (s *InventoryResolverSuite) Test_SetStock() {
+	s.Run("Should return a failed precondition error in case the units on hand would be fewer than the reserved ones", func() {
+		s.app.
+			On("SetStock", mock.AnythingOfType("*context.valueCtx"), domain.StockChange{ProductID: 1, Warehouse: "east", Quantity: 1}).
+			Return(domain.StockLevel{}, domain.ErrStockBelowReserved)
+
+		_, err := s.grpcClient.SetStock(context.Background(), &protog.SetStockRequest{ProductId: 1, Warehouse: "east", OnHand: 1})
+
+		s.Equal(status.Error(codes.FailedPrecondition, domain.ErrStockBelowReserved.Error()), err)
+	})
+
+	s.Run("Should return the stock level", func() {
+		s.app.
+			On("SetStock", mock.AnythingOfType("*context.valueCtx"), domain.StockChange{ProductID: 1, SKU: "IPHONE-13-BLUE", Warehouse: "east", Quantity: 8}).
+			Return(domain.StockLevel{ProductID: 1, SKU: "IPHONE-13-BLUE", Warehouse: "east", OnHand: 8, Reserved: 2}, nil)
+
+		got, err := s.grpcClient.SetStock(context.Background(), &protog.SetStockRequest{ProductId: 1, Sku: "IPHONE-13-BLUE", Warehouse: "east", OnHand: 8})
+
+		s.NoError(err)
+		s.Equal(int32(8), got.Data.OnHand)
+		s.Equal(int32(6), got.Data.Available)
+	})
+}
+
+func (s *InventoryResolverSuite) Test_AdjustStock() {
+	s.Run("Should return an invalid argument error in case the stock location is invalid", func() {
+		s.app.
+			On("AdjustStock", mock.AnythingOfType("*context.valueCtx"), domain.StockChange{ProductID: 1, Quantity: 3}).
+			Return(domain.StockLevel{}, domain.ErrInvalidStockLocation)
+
+		_, err := s.grpcClient.AdjustStock(context.Background(), &protog.AdjustStockRequest{ProductId: 1, Delta: 3})
+
+		s.Equal(status.Error(codes.InvalidArgument, domain.ErrInvalidStockLocation.Error()), err)
+	})
+}
+
+func (s *InventoryResolverSuite) Test_Reserve() {
+	s.Run("Should return a failed precondition error in case there isn't enough stock", func() {
+		insufficientStock := fmt.Errorf("%w: product 1", domain.ErrInsufficientStock)
+
+		s.app.
+			On("ReserveStock", mock.AnythingOfType("*context.valueCtx"), domain.ReservationRequest{
+				Reference: "cart-1",
+				Items:     []domain.ReservationItem{{ProductID: 1, Quantity: 100}},
+			}).
+			Return(domain.Reservation{}, insufficientStock)
+
+		_, err := s.grpcClient.Reserve(context.Background(), &protog.ReserveRequest{
+			Reference: "cart-1",
+			Items:     []*protog.ReservationItem{{ProductId: 1, Quantity: 100}},
+		})
+
+		s.Equal(status.Error(codes.FailedPrecondition, "insufficient-stock: product 1"), err)
+	})
+
+	s.Run("Should return the reservation", func() {
+		expiresAt := time.Date(2022, 5, 1, 10, 15, 0, 0, time.UTC)
+
+		s.app.
+			On("ReserveStock", mock.AnythingOfType("*context.valueCtx"), domain.ReservationRequest{
+				Reference: "cart-2",
+				Items:     []domain.ReservationItem{{ProductID: 2, Quantity: 3}},
+				TTL:       5 * time.Minute,
+			}).
+			Return(domain.Reservation{
+				ID:        7,
+				Reference: "cart-2",
+				Status:    domain.ReservationStatusPending,
+				Items: []domain.ReservationItem{
+					{ProductID: 2, Warehouse: "east", Quantity: 2},
+					{ProductID: 2, Warehouse: "west", Quantity: 1},
+				},
+				ExpiresAt: expiresAt,
+			}, nil)
+
+		got, err := s.grpcClient.Reserve(context.Background(), &protog.ReserveRequest{
+			Reference: "cart-2",
+			Items:     []*protog.ReservationItem{{ProductId: 2, Quantity: 3}},
+			Ttl:       durationpb.New(5 * time.Minute),
+		})
+
+		s.NoError(err)
+		s.Equal(int32(7), got.Data.Id)
+		s.Equal("pending", got.Data.Status)
+		s.Len(got.Data.Items, 2)
+		s.Equal("west", got.Data.Items[1].Warehouse)
+		s.Equal(expiresAt, got.Data.ExpiresAt.AsTime())
+		s.Nil(got.Data.CreatedAt)
+	})
+}
+
+func (s *InventoryResolverSuite) Test_GetReservation() {
+	s.Run("Should return not found error in case the reservation isn't stored", func() {
+		s.app.
+			On("GetReservation", mock.AnythingOfType("*context.valueCtx"), 1).
+			Return(domain.Reservation{}, domain.ErrReservationNotFound)
+
+		_, err := s.grpcClient.GetReservation(context.Background(), &protog.GetReservationRequest{Id: 1})
+
+		s.Equal(status.Error(codes.NotFound, domain.ErrReservationNotFound.Error()), err)
+	})
+}
+
+func (s *InventoryResolverSuite) Test_CommitReservation() {
+	s.Run("Should return a failed precondition error in case the reservation expired", func() {
+		s.app.
+			On("CommitReservation", mock.AnythingOfType("*context.valueCtx"), 1).
+			Return(domain.Reservation{}, domain.ErrReservationExpired)
+
+		_, err := s.grpcClient.CommitReservation(context.Background(), &protog.CommitReservationRequest{Id: 1})
+
+		s.Equal(status.Error(codes.FailedPrecondition, domain.ErrReservationExpired.Error()), err)
+	})
+
+	s.Run("Should return a generic error in case we receive a error that we're not aware of", func() {
+		s.app.
+			On("CommitReservation", mock.AnythingOfType("*context.valueCtx"), 2).
+			Return(domain.Reservation{}, errors.New("connection refused"))
+
+		_, err := s.grpcClient.CommitReservation(context.Background(), &protog.CommitReservationRequest{Id: 2})
+
+		s.Equal(status.Error(codes.Internal, "Internal server error"), err)
+	})
+}
+
+func (s *InventoryResolverSuite) Test_ReleaseReservation() {
+	s.Run("Should return the released reservation", func() {
+		s.app.
+			On("ReleaseReservation", mock.AnythingOfType("*context.valueCtx"), 3).
+			Return(domain.Reservation{ID: 3, Status: domain.ReservationStatusReleased}, nil)
+
+		got, err := s.grpcClient.ReleaseReservation(context.Background(), &protog.ReleaseReservationRequest{Id: 3})
+
+		s.NoError(err)
+		s.Equal("released", got.Data.Status)
+	})
+}
+
+func TestInventoryResolverSuite(t *testing.T) {
+	suite.Run(t, new(InventoryResolverSuite))
+}
+
+func makeGrpcClientAndServer(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	tracerM trace.Tracer,
+	appM domain.Application,
+) (protog.InventoryServiceClient, *gGRPC.ClientConn) {
+	listener := makeGrpcServer(ctx, loggerM, tracerM, appM)
+	grpcClient, grpcConnection := makeGrpcClient(ctx, loggerM, listener)
+
+	return grpcClient, grpcConnection
+}
+
+func makeGrpcClient(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	listener *bufconn.Listener,
+) (protog.InventoryServiceClient, *gGRPC.ClientConn) {
+	inventoryServiceGRPCClient := grpc.MustNewClient(grpc.ClientInput{
+		Logger: loggerM,
+		AdditionalDialOptions: []gGRPC.DialOption{
+			gGRPC.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+				return listener.Dial()
+			}),
+		},
+	})
+
+	inventoryServiceConn := inventoryServiceGRPCClient.MustConnect(ctx)
+
+	grpcClient := protog.NewInventoryServiceClient(inventoryServiceConn)
+	return grpcClient, inventoryServiceConn
+}
+
+func makeGrpcServer(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	tracerM trace.Tracer,
+	appM domain.Application,
+) *bufconn.Listener {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.MustNewServer(grpc.ServerInput{
+		Port:   1234,
+		Logger: loggerM,
+		Registrator: func(server gGRPC.ServiceRegistrar) {
+			protog.RegisterInventoryServiceServer(server, &InventoryResolver{
+				Logger: loggerM,
+				Tracer: tracerM,
+				App:    appM,
+			})
+		},
+		Listener: listener,
+	})
+
+	go func() {
+		if err := grpcServer.Run(ctx); err != nil {
+			panic(err)
+		}
+	}()
+
+	return listener
+}
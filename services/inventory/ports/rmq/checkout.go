@@ -0,0 +1,164 @@
+package rmq_port
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	checkoutPb "github.com/lucasmls/ecommerce/services/checkout/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	"github.com/lucasmls/ecommerce/shared/rmq"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// checkoutReservationTTL outlives the payment and the confirmation of the Order, even when they're sent again,
+// so the Reservation is still pending when it's committed
+const checkoutReservationTTL = time.Hour
+
+var (
+	ErrMissingLogger    = errors.New("missing required dependency: Logger")
+	ErrMisingTracer     = errors.New("missing required dependency: Tracer")
+	ErrMissingPublisher = errors.New("missing required dependency: Publisher")
+)
+
+// errUnsupportedCommand is replied to the Commands of the steps the inventory doesn't run
+var errUnsupportedCommand = errors.New("unsupported-command")
+
+// CheckoutConsumer runs the steps of the checkout saga on the inventory: it reserves the units of the Order,
+// releasing them when the checkout fails, and commits them once the Order is confirmed.
+type CheckoutConsumer struct {
+	Logger    *zap.Logger
+	Tracer    trace.Tracer
+	App       domain.Application
+	Publisher rmq.Publisher
+}
+
+func NewCheckoutConsumer(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+	publisher rmq.Publisher,
+) (*CheckoutConsumer, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMisingTracer
+	}
+
+	if publisher == nil {
+		return nil, ErrMissingPublisher
+	}
+
+	return &CheckoutConsumer{
+		Logger:    logger,
+		Tracer:    tracer,
+		App:       app,
+		Publisher: publisher,
+	}, nil
+}
+
+// MustNewCheckoutConsumer creates a new CheckoutConsumer instance.
+// It panics if any error is found
+func MustNewCheckoutConsumer(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+	publisher rmq.Publisher,
+) *CheckoutConsumer {
+	consumer, err := NewCheckoutConsumer(logger, tracer, app, publisher)
+	if err != nil {
+		panic(err)
+	}
+
+	return consumer
+}
+
+// Handle runs a Command of the checkout saga and replies to it, it's meant to be the Handler of the rmq.Client
+// consuming the commands queue of the inventory. Every Command may be handled twice: releasing and committing a
+// Reservation twice is harmless, and the Reservation is referenced by the saga, so reserving again replies with the
+// one already made. The Commands failing for reasons the domain doesn't know about aren't replied, to be sent again.
+func (c *CheckoutConsumer) Handle(ctx context.Context, body []byte) error {
+	ctx, span := c.Tracer.Start(ctx, "consumer.HandleCheckoutCommand")
+	defer span.End()
+
+	command := &checkoutPb.Command{}
+	if err := proto.Unmarshal(body, command); err != nil {
+		return err
+	}
+
+	reply := &checkoutPb.Reply{
+		CommandId: command.Id,
+		SagaId:    command.SagaId,
+		Step:      command.Step,
+		Action:    command.Action,
+		Success:   true,
+	}
+
+	reservation, err := c.run(ctx, command)
+	switch {
+	case err == nil && command.Step == checkoutPb.StepReserveStock && command.Action == checkoutPb.ActionExecute:
+		reply.ReservationId = int32(reservation.ID)
+	case errors.Is(err, errUnsupportedCommand),
+		errors.Is(err, domain.ErrInsufficientStock),
+		errors.Is(err, domain.ErrEmptyReservation),
+		errors.Is(err, domain.ErrInvalidReservation),
+		errors.Is(err, domain.ErrInvalidStockLocation),
+		errors.Is(err, domain.ErrInvalidQuantity),
+		errors.Is(err, domain.ErrReservationNotFound),
+		errors.Is(err, domain.ErrReservationExpired),
+		errors.Is(err, domain.ErrReservationNotPending):
+		reply.Success = false
+		reply.Error = err.Error()
+	case err != nil:
+		return err
+	}
+
+	c.Logger.Info("replying to a checkout command", zap.String("commandId", command.Id), zap.Bool("success", reply.Success))
+
+	return c.Publisher.Publish(ctx, checkoutPb.Exchange, checkoutPb.RepliesRoutingKey, reply)
+}
+
+func (c *CheckoutConsumer) run(ctx context.Context, command *checkoutPb.Command) (domain.Reservation, error) {
+	switch {
+	case command.Step == checkoutPb.StepReserveStock && command.Action == checkoutPb.ActionExecute:
+		request := domain.ReservationRequest{
+			Reference: checkoutReference(command.SagaId),
+			Items:     make([]domain.ReservationItem, 0, len(command.Items)),
+			TTL:       checkoutReservationTTL,
+		}
+		for _, item := range command.Items {
+			request.Items = append(request.Items, domain.ReservationItem{
+				ProductID: int(item.ProductId),
+				Quantity:  int(item.Quantity),
+			})
+		}
+
+		return c.App.ReserveStock(ctx, request)
+	case command.Step == checkoutPb.StepReserveStock && command.Action == checkoutPb.ActionCompensate:
+		if command.ReservationId == 0 {
+			// the units were never reserved, there's nothing to give back
+			return domain.Reservation{}, nil
+		}
+
+		return c.App.ReleaseReservation(ctx, int(command.ReservationId))
+	case command.Step == checkoutPb.StepCommitStock && command.Action == checkoutPb.ActionExecute:
+		reservation, err := c.App.GetReservation(ctx, int(command.ReservationId))
+		if err != nil || reservation.Status == domain.ReservationStatusCommitted {
+			return reservation, err
+		}
+
+		return c.App.CommitReservation(ctx, int(command.ReservationId))
+	default:
+		return domain.Reservation{}, errUnsupportedCommand
+	}
+}
+
+// checkoutReference is the reference of the Reservation of a checkout saga. It's the same for every Command of the
+// saga, so the ones sent again, when redelivered or resumed, get the Reservation already made.
+func checkoutReference(sagaID string) string {
+	return "checkout:" + sagaID
+}
@@ -0,0 +1,219 @@
+package rmq_port
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	checkoutPb "github.com/lucasmls/ecommerce/services/checkout/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/services/inventory/adapters/repositories"
+	"github.com/lucasmls/ecommerce/services/inventory/app"
+	"github.com/lucasmls/ecommerce/services/inventory/domain"
+	"github.com/lucasmls/ecommerce/services/inventory/mocks"
+	"github.com/lucasmls/ecommerce/shared/rmq/rmqtest"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+type CheckoutConsumerSuite struct {
+	suite.Suite
+
+	app       *mocks.Application
+	publisher *rmqtest.Publisher
+	consumer  *CheckoutConsumer
+}
+
+func (s *CheckoutConsumerSuite) SetupTest() {
+	s.app = &mocks.Application{}
+	s.publisher = &rmqtest.Publisher{}
+	s.consumer = MustNewCheckoutConsumer(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), s.app, s.publisher)
+}
+
+func (s *CheckoutConsumerSuite) handle(command *checkoutPb.Command) error {
+	body, err := proto.Marshal(command)
+	s.Require().NoError(err)
+
+	return s.consumer.Handle(context.Background(), body)
+}
+
+// reply is the Reply the consumer published, nil when it didn't reply
+func (s *CheckoutConsumerSuite) reply() *checkoutPb.Reply {
+	reply, _ := s.publisher.Message.(*checkoutPb.Reply)
+	return reply
+}
+
+func (s *CheckoutConsumerSuite) Test_NewCheckoutConsumer() {
+	s.Run("Should fail to instantiate the CheckoutConsumer in case a Publisher isn't provided", func() {
+		_, err := NewCheckoutConsumer(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), nil, nil)
+
+		s.Equal(ErrMissingPublisher, err)
+	})
+}
+
+func (s *CheckoutConsumerSuite) Test_ReserveStockAgain() {
+	s.Run("Should hold the units of a saga only once however many times its reserve command comes", func() {
+		repository := repositories.NewInMemoryInventoryRepository(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""))
+		_, err := repository.Set(context.Background(), domain.StockChange{ProductID: 1, Warehouse: "east", Quantity: 3})
+		s.Require().NoError(err)
+
+		application := app.MustNewApplication(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), repository, repository, &mocks.EventPublisher{})
+		s.consumer = MustNewCheckoutConsumer(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), application, s.publisher)
+
+		command := &checkoutPb.Command{
+			Id:     "abc:reserve_stock:execute:1",
+			SagaId: "abc",
+			Step:   checkoutPb.StepReserveStock,
+			Action: checkoutPb.ActionExecute,
+			Items:  []*checkoutPb.Item{{ProductId: 1, Quantity: 2}},
+		}
+
+		s.NoError(s.handle(command))
+		first := s.reply()
+		s.Require().True(first.Success)
+
+		// redelivered as is, then sent again by the saga once resumed
+		resumed := proto.Clone(command).(*checkoutPb.Command)
+		resumed.Id = "abc:reserve_stock:execute:2"
+
+		for _, again := range []*checkoutPb.Command{command, resumed} {
+			s.NoError(s.handle(again))
+
+			s.True(s.reply().Success)
+			s.Equal(first.ReservationId, s.reply().ReservationId)
+		}
+
+		levels, err := repository.List(context.Background(), domain.StockFilter{ProductIDs: []int{1}})
+		s.Require().NoError(err)
+		s.Equal(2, levels[0].Reserved)
+	})
+}
+
+func (s *CheckoutConsumerSuite) Test_ReserveStock() {
+	command := &checkoutPb.Command{
+		Id:     "abc:reserve_stock:execute:1",
+		SagaId: "abc",
+		Step:   checkoutPb.StepReserveStock,
+		Action: checkoutPb.ActionExecute,
+		Items:  []*checkoutPb.Item{{ProductId: 1, Quantity: 2}},
+	}
+
+	s.Run("Should reserve the units of the order, replying the reservation", func() {
+		s.SetupTest()
+
+		s.app.
+			On("ReserveStock", mock.AnythingOfType("*context.valueCtx"), domain.ReservationRequest{
+				Reference: "checkout:abc",
+				Items:     []domain.ReservationItem{{ProductID: 1, Quantity: 2}},
+				TTL:       checkoutReservationTTL,
+			}).
+			Return(domain.Reservation{ID: 42, Status: domain.ReservationStatusPending}, nil).
+			Once()
+
+		s.NoError(s.handle(command))
+
+		s.Equal(checkoutPb.RepliesRoutingKey, s.publisher.RoutingKey)
+		s.True(proto.Equal(&checkoutPb.Reply{
+			CommandId:     command.Id,
+			SagaId:        "abc",
+			Step:          checkoutPb.StepReserveStock,
+			Action:        checkoutPb.ActionExecute,
+			Success:       true,
+			ReservationId: 42,
+		}, s.reply()))
+	})
+
+	s.Run("Should fail in case there isn't enough stock", func() {
+		s.SetupTest()
+
+		s.app.
+			On("ReserveStock", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.ReservationRequest")).
+			Return(domain.Reservation{}, domain.ErrInsufficientStock).
+			Once()
+
+		s.NoError(s.handle(command))
+
+		s.False(s.reply().Success)
+		s.Equal(domain.ErrInsufficientStock.Error(), s.reply().Error)
+	})
+
+	s.Run("Should leave the command unreplied in case of unknown errors", func() {
+		s.SetupTest()
+
+		s.app.
+			On("ReserveStock", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.ReservationRequest")).
+			Return(domain.Reservation{}, errors.New("connection refused")).
+			Once()
+
+		s.EqualError(s.handle(command), "connection refused")
+		s.Nil(s.reply())
+	})
+}
+
+func (s *CheckoutConsumerSuite) Test_ReleaseReservation() {
+	s.Run("Should give the units back", func() {
+		s.SetupTest()
+
+		s.app.
+			On("ReleaseReservation", mock.AnythingOfType("*context.valueCtx"), 42).
+			Return(domain.Reservation{ID: 42, Status: domain.ReservationStatusReleased}, nil).
+			Once()
+
+		s.NoError(s.handle(&checkoutPb.Command{Id: "abc:reserve_stock:compensate:1", SagaId: "abc", Step: checkoutPb.StepReserveStock, Action: checkoutPb.ActionCompensate, ReservationId: 42}))
+
+		s.True(s.reply().Success)
+	})
+
+	s.Run("Should succeed without a reservation", func() {
+		s.SetupTest()
+
+		s.NoError(s.handle(&checkoutPb.Command{Id: "abc:reserve_stock:compensate:1", SagaId: "abc", Step: checkoutPb.StepReserveStock, Action: checkoutPb.ActionCompensate}))
+
+		s.True(s.reply().Success)
+		s.app.AssertNotCalled(s.T(), "ReleaseReservation", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *CheckoutConsumerSuite) Test_CommitStock() {
+	command := &checkoutPb.Command{Id: "abc:commit_stock:execute:1", SagaId: "abc", Step: checkoutPb.StepCommitStock, Action: checkoutPb.ActionExecute, ReservationId: 42}
+
+	s.Run("Should commit the reservation", func() {
+		s.SetupTest()
+
+		s.app.On("GetReservation", mock.AnythingOfType("*context.valueCtx"), 42).Return(domain.Reservation{ID: 42, Status: domain.ReservationStatusPending}, nil).Once()
+		s.app.On("CommitReservation", mock.AnythingOfType("*context.valueCtx"), 42).Return(domain.Reservation{ID: 42, Status: domain.ReservationStatusCommitted}, nil).Once()
+
+		s.NoError(s.handle(command))
+
+		s.True(s.reply().Success)
+	})
+
+	s.Run("Should succeed in case the reservation was already committed", func() {
+		s.SetupTest()
+
+		s.app.On("GetReservation", mock.AnythingOfType("*context.valueCtx"), 42).Return(domain.Reservation{ID: 42, Status: domain.ReservationStatusCommitted}, nil).Once()
+
+		s.NoError(s.handle(command))
+
+		s.True(s.reply().Success)
+		s.app.AssertNotCalled(s.T(), "CommitReservation", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the reservation expired", func() {
+		s.SetupTest()
+
+		s.app.On("GetReservation", mock.AnythingOfType("*context.valueCtx"), 42).Return(domain.Reservation{ID: 42, Status: domain.ReservationStatusPending}, nil).Once()
+		s.app.On("CommitReservation", mock.AnythingOfType("*context.valueCtx"), 42).Return(domain.Reservation{}, domain.ErrReservationExpired).Once()
+
+		s.NoError(s.handle(command))
+
+		s.False(s.reply().Success)
+		s.Equal(domain.ErrReservationExpired.Error(), s.reply().Error)
+	})
+}
+
+func TestCheckoutConsumerSuite(t *testing.T) {
+	suite.Run(t, new(CheckoutConsumerSuite))
+}
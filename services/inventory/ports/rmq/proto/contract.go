@@ -0,0 +1,9 @@
+package rmq_protobuf
+
+// Exchange is the topic exchange the StockEvents go through
+const Exchange = "inventory"
+
+// EventRoutingKey routes the StockEvents of a type, e.g. inventory.back_in_stock
+func EventRoutingKey(eventType string) string {
+	return "inventory." + eventType
+}
@@ -0,0 +1,95 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	productsPb "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrMissingLogger         = errors.New("missing required dependency: Logger")
+	ErrMissingTracer         = errors.New("missing required dependency: Tracer")
+	ErrMissingProductsClient = errors.New("missing required dependency: ProductsClient")
+)
+
+// GrpcProductsCatalog implements domain.Catalog on top of the ProductsService gRPC API
+type GrpcProductsCatalog struct {
+	Logger         *zap.Logger
+	Tracer         trace.Tracer
+	ProductsClient productsPb.ProductsServiceClient
+}
+
+// NewGrpcProductsCatalog creates a new GrpcProductsCatalog instance
+func NewGrpcProductsCatalog(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	productsClient productsPb.ProductsServiceClient,
+) (*GrpcProductsCatalog, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMissingTracer
+	}
+
+	if productsClient == nil {
+		return nil, ErrMissingProductsClient
+	}
+
+	return &GrpcProductsCatalog{
+		Logger:         logger,
+		Tracer:         tracer,
+		ProductsClient: productsClient,
+	}, nil
+}
+
+// MustNewGrpcProductsCatalog creates a new GrpcProductsCatalog instance
+// It panics if any error is found
+func MustNewGrpcProductsCatalog(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	productsClient productsPb.ProductsServiceClient,
+) *GrpcProductsCatalog {
+	catalog, err := NewGrpcProductsCatalog(logger, tracer, productsClient)
+	if err != nil {
+		panic(err)
+	}
+
+	return catalog
+}
+
+// Products fetches the name of the given Products from the ProductsService.
+// The deleted Products are left out, as there's no point in telling anyone they're back in stock.
+func (c *GrpcProductsCatalog) Products(ctx context.Context, ids []int) (map[int]domain.ProductSnapshot, error) {
+	ctx, span := c.Tracer.Start(ctx, "catalog.Products")
+	defer span.End()
+
+	products := map[int]domain.ProductSnapshot{}
+	if len(ids) == 0 {
+		return products, nil
+	}
+
+	req := &productsPb.ListRequest{Ids: make([]int32, 0, len(ids))}
+	for _, id := range ids {
+		req.Ids = append(req.Ids, int32(id))
+	}
+
+	res, err := c.ProductsClient.List(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, product := range res.Data {
+		products[int(product.Id)] = domain.ProductSnapshot{
+			ID:   int(product.Id),
+			Name: product.Name,
+		}
+	}
+
+	return products, nil
+}
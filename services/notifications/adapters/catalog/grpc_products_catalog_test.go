@@ -0,0 +1,102 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	productsPb "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+)
+
+// fakeProductsService answers List with a fixed set of products, recording the requests
+type fakeProductsService struct {
+	productsPb.ProductsServiceClient
+
+	products []*productsPb.Product
+	err      error
+	requests []*productsPb.ListRequest
+}
+
+func (f *fakeProductsService) List(ctx context.Context, in *productsPb.ListRequest, opts ...grpc.CallOption) (*productsPb.ListResponse, error) {
+	f.requests = append(f.requests, in)
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return &productsPb.ListResponse{Data: f.products}, nil
+}
+
+type GrpcProductsCatalogSuite struct {
+	suite.Suite
+
+	products *fakeProductsService
+	catalog  *GrpcProductsCatalog
+}
+
+func (s *GrpcProductsCatalogSuite) SetupTest() {
+	s.products = &fakeProductsService{
+		products: []*productsPb.Product{
+			{Id: 1, Name: "Keyboard"},
+			{Id: 2, Name: "Mouse"},
+		},
+	}
+
+	s.catalog = MustNewGrpcProductsCatalog(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), s.products)
+}
+
+func (s *GrpcProductsCatalogSuite) Test_NewGrpcProductsCatalog() {
+	tracer := trace.NewNoopTracerProvider().Tracer("")
+
+	_, err := NewGrpcProductsCatalog(nil, tracer, s.products)
+	s.Equal(ErrMissingLogger, err)
+
+	_, err = NewGrpcProductsCatalog(zap.NewNop(), nil, s.products)
+	s.Equal(ErrMissingTracer, err)
+
+	_, err = NewGrpcProductsCatalog(zap.NewNop(), tracer, nil)
+	s.Equal(ErrMissingProductsClient, err)
+}
+
+func (s *GrpcProductsCatalogSuite) Test_Products() {
+	s.Run("Should fetch the name of the products, leaving the missing ones out", func() {
+		got, err := s.catalog.Products(context.Background(), []int{1, 2, 3})
+
+		s.NoError(err)
+		s.Equal(map[int]domain.ProductSnapshot{
+			1: {ID: 1, Name: "Keyboard"},
+			2: {ID: 2, Name: "Mouse"},
+		}, got)
+
+		request := s.products.requests[len(s.products.requests)-1]
+		s.Equal([]int32{1, 2, 3}, request.Ids)
+		s.False(request.IncludeDeleted)
+	})
+
+	s.Run("Should not call the products service in case no product is asked for", func() {
+		requests := len(s.products.requests)
+
+		got, err := s.catalog.Products(context.Background(), nil)
+
+		s.NoError(err)
+		s.Empty(got)
+		s.Len(s.products.requests, requests)
+	})
+
+	s.Run("Should fail in case the products service fails", func() {
+		s.products.err = errors.New("connection refused")
+
+		_, err := s.catalog.Products(context.Background(), []int{1})
+
+		s.EqualError(err, "connection refused")
+	})
+}
+
+func TestGrpcProductsCatalogSuite(t *testing.T) {
+	suite.Run(t, new(GrpcProductsCatalogSuite))
+}
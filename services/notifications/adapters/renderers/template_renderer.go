@@ -0,0 +1,216 @@
+package renderers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// templateExtension is the extension of the files holding the templates
+const templateExtension = ".tmpl"
+
+var (
+	ErrMissingLogger = errors.New("missing required dependency: Logger")
+	ErrMissingTracer = errors.New("missing required dependency: Tracer")
+	ErrMissingFS     = errors.New("missing required dependency: FS")
+)
+
+// separators are the thousands and the decimal separators of the money amounts of each language
+var separators = map[string][2]string{
+	"en": {",", "."},
+	"pt": {".", ","},
+	"es": {".", ","},
+}
+
+// TemplateRenderer renders the text/templates of a file system laid out as <locale>/<template>.<channel>.tmpl,
+// as in en/order_confirmed.email.tmpl. A file may define a subject and a body template, the body being the
+// whole file when it doesn't define one. Every template is parsed up front, so the broken ones fail right away.
+type TemplateRenderer struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	// templates are keyed by their path, without the extension
+	templates map[string]*template.Template
+}
+
+// NewTemplateRenderer creates a new TemplateRenderer instance, parsing every template of the file system
+func NewTemplateRenderer(logger *zap.Logger, tracer trace.Tracer, fsys fs.FS) (*TemplateRenderer, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMissingTracer
+	}
+
+	if fsys == nil {
+		return nil, ErrMissingFS
+	}
+
+	templates := map[string]*template.Template{}
+	err := fs.WalkDir(fsys, ".", func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() || path.Ext(filePath) != templateExtension {
+			return nil
+		}
+
+		content, err := fs.ReadFile(fsys, filePath)
+		if err != nil {
+			return err
+		}
+
+		locale := path.Dir(filePath)
+		parsed, err := template.New(filePath).
+			Option("missingkey=error").
+			Funcs(funcs(locale)).
+			Parse(string(content))
+		if err != nil {
+			return err
+		}
+
+		templates[strings.TrimSuffix(filePath, templateExtension)] = parsed
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TemplateRenderer{
+		Logger:    logger,
+		Tracer:    tracer,
+		templates: templates,
+	}, nil
+}
+
+// MustNewTemplateRenderer creates a new TemplateRenderer instance
+// It panics if any error is found
+func MustNewTemplateRenderer(logger *zap.Logger, tracer trace.Tracer, fsys fs.FS) *TemplateRenderer {
+	renderer, err := NewTemplateRenderer(logger, tracer, fsys)
+	if err != nil {
+		panic(err)
+	}
+
+	return renderer
+}
+
+// Render renders a template for a Channel in the first locale that has it among the given one, its language and the DefaultLocale
+func (r *TemplateRenderer) Render(
+	ctx context.Context,
+	name string,
+	channel domain.Channel,
+	locale string,
+	data map[string]interface{},
+) (domain.Content, error) {
+	_, span := r.Tracer.Start(ctx, "renderer.Render")
+	defer span.End()
+
+	for _, candidate := range fallbacks(locale) {
+		tmpl, ok := r.templates[path.Join(candidate, name+"."+string(channel))]
+		if !ok {
+			continue
+		}
+
+		return render(tmpl, data)
+	}
+
+	return domain.Content{}, domain.ErrTemplateNotFound
+}
+
+func render(tmpl *template.Template, data map[string]interface{}) (domain.Content, error) {
+	content := domain.Content{}
+
+	if subject := tmpl.Lookup("subject"); subject != nil {
+		rendered, err := execute(subject, data)
+		if err != nil {
+			return domain.Content{}, err
+		}
+
+		// a subject spreading over many lines would break the headers of the email
+		content.Subject = strings.Join(strings.Fields(rendered), " ")
+	}
+
+	body := tmpl
+	if defined := tmpl.Lookup("body"); defined != nil {
+		body = defined
+	}
+
+	rendered, err := execute(body, data)
+	if err != nil {
+		return domain.Content{}, err
+	}
+
+	content.Body = strings.TrimSpace(rendered)
+
+	return content, nil
+}
+
+func execute(tmpl *template.Template, data map[string]interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// fallbacks lists the locales a template is looked up in, e.g. pt-BR, pt and en
+func fallbacks(locale string) []string {
+	locales := []string{}
+	if locale != "" {
+		locales = append(locales, locale)
+	}
+
+	if language, _, found := strings.Cut(locale, "-"); found && language != "" {
+		locales = append(locales, language)
+	}
+
+	if locale != domain.DefaultLocale {
+		locales = append(locales, domain.DefaultLocale)
+	}
+
+	return locales
+}
+
+// funcs are the functions the templates of a locale may call
+func funcs(locale string) template.FuncMap {
+	language, _, _ := strings.Cut(locale, "-")
+
+	seps, ok := separators[language]
+	if !ok {
+		seps = separators[domain.DefaultLocale]
+	}
+
+	return template.FuncMap{
+		// money formats an amount in minor currency units (cents), e.g. 123456 as 1,234.56
+		"money": func(cents int) string {
+			return money(cents, seps[0], seps[1])
+		},
+	}
+}
+
+func money(cents int, thousands string, decimal string) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+
+	units := fmt.Sprintf("%d", cents/100)
+	for i := len(units) - 3; i > 0; i -= 3 {
+		units = units[:i] + thousands + units[i:]
+	}
+
+	return fmt.Sprintf("%s%s%s%02d", sign, units, decimal, cents%100)
+}
@@ -0,0 +1,144 @@
+package renderers
+
+import (
+	"context"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type TemplateRendererSuite struct {
+	suite.Suite
+
+	renderer *TemplateRenderer
+}
+
+func (s *TemplateRendererSuite) SetupTest() {
+	s.renderer = MustNewTemplateRenderer(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), fstest.MapFS{
+		"en/greeting.email.tmpl": {Data: []byte(`{{define "subject"}}Hello
+{{.Name}}{{end}}
+{{define "body"}}
+Hi {{.Name}}, you owe {{money .Amount}}.
+{{end}}`)},
+		"en/greeting.sms.tmpl":    {Data: []byte("Hi {{.Name}}\n")},
+		"pt/greeting.email.tmpl":  {Data: []byte(`{{define "subject"}}Olá {{.Name}}{{end}}{{define "body"}}Você deve {{money .Amount}}.{{end}}`)},
+		"pt-BR/greeting.sms.tmpl": {Data: []byte("Oi {{.Name}}")},
+	})
+}
+
+func (s *TemplateRendererSuite) Test_NewTemplateRenderer() {
+	s.Run("Should fail in case a template is broken", func() {
+		_, err := NewTemplateRenderer(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), fstest.MapFS{
+			"en/greeting.email.tmpl": {Data: []byte("{{.Name")},
+		})
+
+		s.Error(err)
+	})
+
+	s.Run("Should fail in case the file system isn't provided", func() {
+		_, err := NewTemplateRenderer(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), nil)
+
+		s.Equal(ErrMissingFS, err)
+	})
+}
+
+func (s *TemplateRendererSuite) Test_Render() {
+	data := map[string]interface{}{"Name": "John", "Amount": 123456}
+
+	s.Run("Should render the subject and the body of the template, on a single line the subject", func() {
+		content, err := s.renderer.Render(context.Background(), "greeting", domain.ChannelEmail, "en", data)
+
+		s.NoError(err)
+		s.Equal(domain.Content{Subject: "Hello John", Body: "Hi John, you owe 1,234.56."}, content)
+	})
+
+	s.Run("Should render the whole template as the body in case it doesn't define one", func() {
+		content, err := s.renderer.Render(context.Background(), "greeting", domain.ChannelSMS, "en", data)
+
+		s.NoError(err)
+		s.Equal(domain.Content{Body: "Hi John"}, content)
+	})
+
+	s.Run("Should render the template of the locale", func() {
+		content, err := s.renderer.Render(context.Background(), "greeting", domain.ChannelSMS, "pt-BR", data)
+
+		s.NoError(err)
+		s.Equal(domain.Content{Body: "Oi John"}, content)
+	})
+
+	s.Run("Should fall back to the language of the locale, formatting the money amounts the way it does", func() {
+		content, err := s.renderer.Render(context.Background(), "greeting", domain.ChannelEmail, "pt-BR", data)
+
+		s.NoError(err)
+		s.Equal(domain.Content{Subject: "Olá John", Body: "Você deve 1.234,56."}, content)
+	})
+
+	s.Run("Should fall back to the default locale", func() {
+		content, err := s.renderer.Render(context.Background(), "greeting", domain.ChannelSMS, "fr-FR", data)
+
+		s.NoError(err)
+		s.Equal(domain.Content{Body: "Hi John"}, content)
+	})
+
+	s.Run("Should fail in case the template can't be sent through the channel", func() {
+		_, err := s.renderer.Render(context.Background(), "farewell", domain.ChannelEmail, "en", data)
+
+		s.Equal(domain.ErrTemplateNotFound, err)
+	})
+
+	s.Run("Should fail in case the data misses a key the template uses", func() {
+		_, err := s.renderer.Render(context.Background(), "greeting", domain.ChannelEmail, "en", map[string]interface{}{"Name": "John"})
+
+		s.Error(err)
+	})
+}
+
+// Test_Templates renders the templates the service ships with, with the data the consumers hand over to them
+func (s *TemplateRendererSuite) Test_Templates() {
+	renderer := MustNewTemplateRenderer(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), os.DirFS("../../templates"))
+
+	shipment := map[string]interface{}{
+		"Name":           "John",
+		"OrderID":        7,
+		"Carrier":        "local",
+		"TrackingNumber": "FK000000015ZZ",
+		"Location":       "",
+	}
+
+	templates := map[string]map[string]interface{}{
+		domain.TemplateVerifyEmail: {"Name": "John", "Token": "abc"},
+		domain.TemplateOrderConfirmed: {
+			"Name":   "John",
+			"Number": "ORD-000007",
+			"Items":  []map[string]interface{}{{"Name": "Keyboard", "Quantity": 2, "UnitPrice": 79900}},
+			"Total":  159800,
+		},
+		domain.TemplateOrderCancelled:         {"Name": "John", "Number": "ORD-000007", "Reason": ""},
+		domain.TemplateShipmentShipped:        shipment,
+		domain.TemplateShipmentOutForDelivery: shipment,
+		domain.TemplateShipmentDelivered:      shipment,
+		domain.TemplateBackInStock:            {"Name": "John", "ProductID": 1, "ProductName": "Keyboard", "Available": 3},
+	}
+
+	for name, data := range templates {
+		for _, locale := range []string{"en", "pt-BR"} {
+			content, err := renderer.Render(context.Background(), name, domain.ChannelEmail, locale, data)
+
+			s.NoError(err, "%s in %s", name, locale)
+			s.NotEmpty(content.Subject, "%s in %s", name, locale)
+			s.NotEmpty(content.Body, "%s in %s", name, locale)
+		}
+	}
+
+	_, err := renderer.Render(context.Background(), domain.TemplateVerifyEmail, domain.ChannelSMS, "en", templates[domain.TemplateVerifyEmail])
+	s.Equal(domain.ErrTemplateNotFound, err)
+}
+
+func TestTemplateRendererSuite(t *testing.T) {
+	suite.Run(t, new(TemplateRendererSuite))
+}
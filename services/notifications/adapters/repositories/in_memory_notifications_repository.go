@@ -0,0 +1,169 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// InMemoryNotificationsRepository keeps the Notifications in memory.
+type InMemoryNotificationsRepository struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	// mu guards notifications, whose IDs are their positions in the slice, and keys, which maps the Key and the
+	// Channel of each Notification to its ID
+	mu            sync.Mutex
+	notifications []domain.Notification
+	keys          map[notificationKey]int
+}
+
+type notificationKey struct {
+	key     string
+	channel domain.Channel
+}
+
+// NewInMemoryNotificationsRepository creates a new InMemoryNotificationsRepository.
+func NewInMemoryNotificationsRepository(logger *zap.Logger, tracer trace.Tracer) *InMemoryNotificationsRepository {
+	return &InMemoryNotificationsRepository{
+		Logger: logger,
+		Tracer: tracer,
+		keys:   map[notificationKey]int{},
+	}
+}
+
+// Create stores a new Notification in memory.
+func (r *InMemoryNotificationsRepository) Create(ctx context.Context, notification domain.Notification) (domain.Notification, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Create")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := notificationKey{key: notification.Key, channel: notification.Channel}
+	if _, ok := r.keys[key]; ok {
+		return domain.Notification{}, domain.ErrNotificationExists
+	}
+
+	notification.ID = len(r.notifications) + 1
+	notification.Version = 1
+
+	r.notifications = append(r.notifications, notification)
+	r.keys[key] = notification.ID
+
+	return notification, nil
+}
+
+// Get fetches a single Notification from memory.
+func (r *InMemoryNotificationsRepository) Get(ctx context.Context, id int) (domain.Notification, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Get")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.get(id)
+}
+
+// List lists the Notifications matching the filter from memory, most recently created first.
+func (r *InMemoryNotificationsRepository) List(ctx context.Context, filter domain.NotificationFilter) ([]domain.Notification, error) {
+	_, span := r.Tracer.Start(ctx, "repository.List")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := []domain.Notification{}
+	for _, notification := range r.notifications {
+		if filter.CustomerID != "" && notification.CustomerID != filter.CustomerID {
+			continue
+		}
+
+		if filter.Status != "" && notification.Status != filter.Status {
+			continue
+		}
+
+		result = append(result, notification)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.After(result[j].CreatedAt)
+		}
+
+		return result[i].ID > result[j].ID
+	})
+
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[:filter.Limit]
+	}
+
+	return result, nil
+}
+
+// ListDue lists the pending Notifications due at a time from memory, the oldest attempts first.
+func (r *InMemoryNotificationsRepository) ListDue(ctx context.Context, at time.Time, limit int) ([]domain.Notification, error) {
+	_, span := r.Tracer.Start(ctx, "repository.ListDue")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := []domain.Notification{}
+	for _, notification := range r.notifications {
+		if notification.Due(at) {
+			result = append(result, notification)
+		}
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].NextAttemptAt.Before(result[j].NextAttemptAt)
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
+
+// Update replaces a Notification in memory as long as its Version matches the stored one.
+func (r *InMemoryNotificationsRepository) Update(ctx context.Context, notification domain.Notification) (domain.Notification, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Update")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, err := r.get(notification.ID)
+	if err != nil {
+		return domain.Notification{}, err
+	}
+
+	if stored.Version != notification.Version {
+		return domain.Notification{}, domain.ErrNotificationVersionConflict
+	}
+
+	// the Key and the Channel identify the Notification, so they never change
+	notification.Key = stored.Key
+	notification.Channel = stored.Channel
+	notification.Version++
+
+	r.notifications[notification.ID-1] = notification
+
+	return notification, nil
+}
+
+// get fetches a stored Notification, the caller must hold mu
+func (r *InMemoryNotificationsRepository) get(id int) (domain.Notification, error) {
+	if id <= 0 || id > len(r.notifications) {
+		return domain.Notification{}, domain.ErrNotificationNotFound
+	}
+
+	return r.notifications[id-1], nil
+}
@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type InMemoryNotificationsRepositorySuite struct {
+	suite.Suite
+
+	notificationsRepo *InMemoryNotificationsRepository
+	createdAt         time.Time
+}
+
+func (s *InMemoryNotificationsRepositorySuite) SetupTest() {
+	s.notificationsRepo = NewInMemoryNotificationsRepository(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""))
+	s.createdAt = time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+}
+
+func (s *InMemoryNotificationsRepositorySuite) create(key string, customerID string, createdAt time.Time) domain.Notification {
+	notification, err := s.notificationsRepo.Create(context.Background(), domain.Notification{
+		Key:           key,
+		CustomerID:    customerID,
+		Category:      domain.CategoryOrders,
+		Template:      domain.TemplateOrderConfirmed,
+		Channel:       domain.ChannelEmail,
+		To:            "john@doe.com",
+		Status:        domain.NotificationStatusPending,
+		NextAttemptAt: createdAt,
+		CreatedAt:     createdAt,
+		UpdatedAt:     createdAt,
+	})
+	s.Require().NoError(err)
+
+	return notification
+}
+
+func (s *InMemoryNotificationsRepositorySuite) Test_Create() {
+	s.Run("Should set the ID and the first version of the notification", func() {
+		notification := s.create("orders:7:paid", "john", s.createdAt)
+
+		s.Equal(1, notification.ID)
+		s.Equal(1, notification.Version)
+
+		got, err := s.notificationsRepo.Get(context.Background(), notification.ID)
+		s.NoError(err)
+		s.Equal(notification, got)
+	})
+
+	s.Run("Should fail in case the event was already notified through the channel", func() {
+		_, err := s.notificationsRepo.Create(context.Background(), domain.Notification{
+			Key:     "orders:7:paid",
+			Channel: domain.ChannelEmail,
+		})
+
+		s.Equal(domain.ErrNotificationExists, err)
+	})
+
+	s.Run("Should create the notification of the same event on another channel", func() {
+		notification, err := s.notificationsRepo.Create(context.Background(), domain.Notification{
+			Key:     "orders:7:paid",
+			Channel: domain.ChannelSMS,
+		})
+
+		s.NoError(err)
+		s.Equal(2, notification.ID)
+	})
+}
+
+func (s *InMemoryNotificationsRepositorySuite) Test_List() {
+	s.SetupTest()
+
+	first := s.create("orders:7:paid", "john", s.createdAt)
+	second := s.create("orders:8:paid", "jane", s.createdAt.Add(time.Minute))
+	third := s.create("orders:9:paid", "john", s.createdAt.Add(time.Minute))
+
+	s.Run("Should list the notifications most recently created first, ties broken by ID", func() {
+		notifications, err := s.notificationsRepo.List(context.Background(), domain.NotificationFilter{})
+
+		s.NoError(err)
+		s.Equal([]domain.Notification{third, second, first}, notifications)
+	})
+
+	s.Run("Should list the notifications of a customer", func() {
+		notifications, err := s.notificationsRepo.List(context.Background(), domain.NotificationFilter{CustomerID: "john", Limit: 1})
+
+		s.NoError(err)
+		s.Equal([]domain.Notification{third}, notifications)
+	})
+}
+
+func (s *InMemoryNotificationsRepositorySuite) Test_ListDue() {
+	s.SetupTest()
+
+	late := s.create("orders:7:paid", "john", s.createdAt.Add(time.Minute))
+	early := s.create("orders:8:paid", "john", s.createdAt)
+	s.create("orders:9:paid", "john", s.createdAt.Add(time.Hour))
+
+	sent := s.create("orders:10:paid", "john", s.createdAt)
+	sent.Delivered(s.createdAt)
+	_, err := s.notificationsRepo.Update(context.Background(), sent)
+	s.Require().NoError(err)
+
+	s.Run("Should list the pending notifications due, the oldest attempts first", func() {
+		notifications, err := s.notificationsRepo.ListDue(context.Background(), s.createdAt.Add(time.Minute), 0)
+
+		s.NoError(err)
+		s.Equal([]domain.Notification{early, late}, notifications)
+	})
+
+	s.Run("Should list up to the limit", func() {
+		notifications, err := s.notificationsRepo.ListDue(context.Background(), s.createdAt.Add(time.Minute), 1)
+
+		s.NoError(err)
+		s.Equal([]domain.Notification{early}, notifications)
+	})
+}
+
+func (s *InMemoryNotificationsRepositorySuite) Test_Update() {
+	s.SetupTest()
+
+	notification := s.create("orders:7:paid", "john", s.createdAt)
+
+	s.Run("Should bump the version of the notification", func() {
+		notification.Attempt(s.createdAt)
+
+		updated, err := s.notificationsRepo.Update(context.Background(), notification)
+
+		s.NoError(err)
+		s.Equal(2, updated.Version)
+		s.Equal(1, updated.Attempts)
+	})
+
+	s.Run("Should fail in case the notification was updated since it was read", func() {
+		_, err := s.notificationsRepo.Update(context.Background(), notification)
+
+		s.Equal(domain.ErrNotificationVersionConflict, err)
+	})
+
+	s.Run("Should fail in case the notification doesn't exist", func() {
+		_, err := s.notificationsRepo.Update(context.Background(), domain.Notification{ID: 3})
+
+		s.Equal(domain.ErrNotificationNotFound, err)
+	})
+}
+
+func TestInMemoryNotificationsRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InMemoryNotificationsRepositorySuite))
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// InMemoryRecipientsRepository keeps the Recipients in memory, keyed by their CustomerID.
+type InMemoryRecipientsRepository struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	// mu guards recipients
+	mu         sync.Mutex
+	recipients map[string]domain.Recipient
+}
+
+// NewInMemoryRecipientsRepository creates a new InMemoryRecipientsRepository.
+func NewInMemoryRecipientsRepository(logger *zap.Logger, tracer trace.Tracer) *InMemoryRecipientsRepository {
+	return &InMemoryRecipientsRepository{
+		Logger:     logger,
+		Tracer:     tracer,
+		recipients: map[string]domain.Recipient{},
+	}
+}
+
+// Save creates or replaces a Recipient in memory.
+func (r *InMemoryRecipientsRepository) Save(ctx context.Context, recipient domain.Recipient) (domain.Recipient, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Save")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.recipients[recipient.CustomerID] = copyRecipient(recipient)
+
+	return copyRecipient(recipient), nil
+}
+
+// Get fetches a single Recipient from memory.
+func (r *InMemoryRecipientsRepository) Get(ctx context.Context, customerID string) (domain.Recipient, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Get")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	recipient, ok := r.recipients[customerID]
+	if !ok {
+		return domain.Recipient{}, domain.ErrRecipientNotFound
+	}
+
+	return copyRecipient(recipient), nil
+}
+
+// Delete removes a Recipient from memory.
+func (r *InMemoryRecipientsRepository) Delete(ctx context.Context, customerID string) error {
+	_, span := r.Tracer.Start(ctx, "repository.Delete")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.recipients[customerID]; !ok {
+		return domain.ErrRecipientNotFound
+	}
+
+	delete(r.recipients, customerID)
+
+	return nil
+}
+
+// copyRecipient keeps the stored Channels from being changed through the Recipients handed out by the repository
+func copyRecipient(recipient domain.Recipient) domain.Recipient {
+	channels := make(map[domain.Category][]domain.Channel, len(recipient.Preferences.Channels))
+	for category, categoryChannels := range recipient.Preferences.Channels {
+		channels[category] = append([]domain.Channel{}, categoryChannels...)
+	}
+
+	recipient.Preferences.Channels = channels
+	return recipient
+}
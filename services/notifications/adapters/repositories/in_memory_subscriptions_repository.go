@@ -0,0 +1,102 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// InMemorySubscriptionsRepository keeps the Subscriptions in memory.
+type InMemorySubscriptionsRepository struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	// mu guards subscriptions
+	mu            sync.Mutex
+	subscriptions map[subscriptionKey]domain.Subscription
+}
+
+type subscriptionKey struct {
+	customerID string
+	productID  int
+}
+
+// NewInMemorySubscriptionsRepository creates a new InMemorySubscriptionsRepository.
+func NewInMemorySubscriptionsRepository(logger *zap.Logger, tracer trace.Tracer) *InMemorySubscriptionsRepository {
+	return &InMemorySubscriptionsRepository{
+		Logger:        logger,
+		Tracer:        tracer,
+		subscriptions: map[subscriptionKey]domain.Subscription{},
+	}
+}
+
+// Save stores a Subscription in memory, keeping the stored one when it already exists.
+func (r *InMemorySubscriptionsRepository) Save(ctx context.Context, subscription domain.Subscription) (domain.Subscription, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Save")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := subscriptionKey{customerID: subscription.CustomerID, productID: subscription.ProductID}
+	if stored, ok := r.subscriptions[key]; ok {
+		return stored, nil
+	}
+
+	r.subscriptions[key] = subscription
+
+	return subscription, nil
+}
+
+// Delete removes a Subscription from memory.
+func (r *InMemorySubscriptionsRepository) Delete(ctx context.Context, subscription domain.Subscription) error {
+	_, span := r.Tracer.Start(ctx, "repository.Delete")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.subscriptions, subscriptionKey{customerID: subscription.CustomerID, productID: subscription.ProductID})
+
+	return nil
+}
+
+// List lists the Subscriptions matching the filter from memory, most recent first.
+func (r *InMemorySubscriptionsRepository) List(ctx context.Context, filter domain.SubscriptionFilter) ([]domain.Subscription, error) {
+	_, span := r.Tracer.Start(ctx, "repository.List")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := []domain.Subscription{}
+	for _, subscription := range r.subscriptions {
+		if filter.CustomerID != "" && subscription.CustomerID != filter.CustomerID {
+			continue
+		}
+
+		if filter.ProductID != 0 && subscription.ProductID != filter.ProductID {
+			continue
+		}
+
+		result = append(result, subscription)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.After(result[j].CreatedAt)
+		}
+
+		if result[i].CustomerID != result[j].CustomerID {
+			return result[i].CustomerID < result[j].CustomerID
+		}
+
+		return result[i].ProductID < result[j].ProductID
+	})
+
+	return result, nil
+}
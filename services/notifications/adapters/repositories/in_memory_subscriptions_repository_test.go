@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type InMemorySubscriptionsRepositorySuite struct {
+	suite.Suite
+
+	subscriptionsRepo *InMemorySubscriptionsRepository
+	createdAt         time.Time
+}
+
+func (s *InMemorySubscriptionsRepositorySuite) SetupTest() {
+	s.subscriptionsRepo = NewInMemorySubscriptionsRepository(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""))
+	s.createdAt = time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+}
+
+func (s *InMemorySubscriptionsRepositorySuite) save(customerID string, productID int, createdAt time.Time) domain.Subscription {
+	subscription, err := s.subscriptionsRepo.Save(context.Background(), domain.Subscription{
+		CustomerID: customerID,
+		ProductID:  productID,
+		CreatedAt:  createdAt,
+	})
+	s.Require().NoError(err)
+
+	return subscription
+}
+
+func (s *InMemorySubscriptionsRepositorySuite) Test_Save() {
+	s.Run("Should keep the stored subscription in case it already exists", func() {
+		s.SetupTest()
+
+		stored := s.save("john", 1, s.createdAt)
+		saved := s.save("john", 1, s.createdAt.Add(time.Hour))
+
+		s.Equal(stored, saved)
+	})
+}
+
+func (s *InMemorySubscriptionsRepositorySuite) Test_List() {
+	s.SetupTest()
+
+	first := s.save("john", 1, s.createdAt)
+	second := s.save("jane", 1, s.createdAt.Add(time.Minute))
+	third := s.save("john", 2, s.createdAt.Add(time.Hour))
+
+	s.Run("Should list the subscriptions to a product, most recent first", func() {
+		subscriptions, err := s.subscriptionsRepo.List(context.Background(), domain.SubscriptionFilter{ProductID: 1})
+
+		s.NoError(err)
+		s.Equal([]domain.Subscription{second, first}, subscriptions)
+	})
+
+	s.Run("Should list the subscriptions of a customer", func() {
+		subscriptions, err := s.subscriptionsRepo.List(context.Background(), domain.SubscriptionFilter{CustomerID: "john"})
+
+		s.NoError(err)
+		s.Equal([]domain.Subscription{third, first}, subscriptions)
+	})
+}
+
+func (s *InMemorySubscriptionsRepositorySuite) Test_Delete() {
+	s.Run("Should remove the subscription", func() {
+		s.SetupTest()
+
+		subscription := s.save("john", 1, s.createdAt)
+
+		s.NoError(s.subscriptionsRepo.Delete(context.Background(), subscription))
+
+		subscriptions, err := s.subscriptionsRepo.List(context.Background(), domain.SubscriptionFilter{CustomerID: "john"})
+		s.NoError(err)
+		s.Empty(subscriptions)
+	})
+
+	s.Run("Should succeed in case the subscription doesn't exist", func() {
+		s.SetupTest()
+
+		s.NoError(s.subscriptionsRepo.Delete(context.Background(), domain.Subscription{CustomerID: "john", ProductID: 1}))
+	})
+}
+
+func TestInMemorySubscriptionsRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InMemorySubscriptionsRepositorySuite))
+}
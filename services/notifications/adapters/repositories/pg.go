@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"database/sql"
+)
+
+// openPg connects to the Postgres database of the Notifications, failing when it can't be reached
+func openPg(connectionString string) (*sql.DB, error) {
+	db, err := sql.Open(
+		"postgres",
+		connectionString,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
@@ -0,0 +1,206 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/lucasmls/ecommerce/shared/pg"
+)
+
+const notificationColumns = `id, key, customer_id, category, template, channel, locale, "to", subject, body, status, attempts, last_error, next_attempt_at, sent_at, version, created_at, updated_at`
+
+// PgNotificationsRepository keeps the Notifications on Postgres, each one on a single row.
+// The rows are only updated while their version is still the one the Notification was read with,
+// so concurrent delivery attempts can't send a Notification twice.
+type PgNotificationsRepository struct {
+	db *sql.DB
+}
+
+func NewPgNotificationsRepository(connectionString string) (*PgNotificationsRepository, error) {
+	db, err := openPg(connectionString)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PgNotificationsRepository{
+		db: db,
+	}, nil
+}
+
+func MustNewPgNotificationsRepository(connectionString string) *PgNotificationsRepository {
+	repo, err := NewPgNotificationsRepository(connectionString)
+	if err != nil {
+		panic(err)
+	}
+
+	return repo
+}
+
+// Create inserts a new Notification
+func (r *PgNotificationsRepository) Create(ctx context.Context, notification domain.Notification) (domain.Notification, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`INSERT INTO notifications (key, customer_id, category, template, channel, locale, "to", subject, body, status, attempts, last_error, next_attempt_at, sent_at, version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, 1, $15, $16)
+		RETURNING `+notificationColumns,
+		notification.Key, notification.CustomerID, string(notification.Category), notification.Template,
+		string(notification.Channel), notification.Locale, notification.To, notification.Subject, notification.Body,
+		string(notification.Status), notification.Attempts, notification.LastError,
+		nullTime(notification.NextAttemptAt), nullTime(notification.SentAt), notification.CreatedAt, notification.UpdatedAt,
+	)
+
+	created, err := scanNotification(row)
+	if pg.IsUniqueViolation(err) {
+		return domain.Notification{}, domain.ErrNotificationExists
+	}
+
+	return created, err
+}
+
+// Get fetches a single Notification
+func (r *PgNotificationsRepository) Get(ctx context.Context, id int) (domain.Notification, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+notificationColumns+" FROM notifications WHERE id = $1", id)
+
+	notification, err := scanNotification(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Notification{}, domain.ErrNotificationNotFound
+	}
+
+	return notification, err
+}
+
+// List lists the Notifications matching the filter, most recently created first
+func (r *PgNotificationsRepository) List(ctx context.Context, filter domain.NotificationFilter) ([]domain.Notification, error) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	if filter.CustomerID != "" {
+		args = append(args, filter.CustomerID)
+		conditions = append(conditions, fmt.Sprintf("customer_id = $%d", len(args)))
+	}
+
+	if filter.Status != "" {
+		args = append(args, string(filter.Status))
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	query := "SELECT " + notificationColumns + " FROM notifications"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY created_at DESC, id DESC"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	return r.list(ctx, query, args...)
+}
+
+// ListDue lists the pending Notifications due at a time, the oldest attempts first
+func (r *PgNotificationsRepository) ListDue(ctx context.Context, at time.Time, limit int) ([]domain.Notification, error) {
+	query := "SELECT " + notificationColumns + " FROM notifications WHERE status = $1 AND next_attempt_at <= $2 ORDER BY next_attempt_at, id"
+	args := []interface{}{string(domain.NotificationStatusPending), at}
+
+	if limit > 0 {
+		args = append(args, limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	return r.list(ctx, query, args...)
+}
+
+// Update replaces a Notification as long as the stored version matches, its key and channel never change
+func (r *PgNotificationsRepository) Update(ctx context.Context, notification domain.Notification) (domain.Notification, error) {
+	row := r.db.QueryRowContext(
+		ctx,
+		`UPDATE notifications
+		SET status = $3, attempts = $4, last_error = $5, next_attempt_at = $6, sent_at = $7, version = version + 1, updated_at = $8
+		WHERE id = $1 AND version = $2
+		RETURNING `+notificationColumns,
+		notification.ID, notification.Version, string(notification.Status), notification.Attempts, notification.LastError,
+		nullTime(notification.NextAttemptAt), nullTime(notification.SentAt), notification.UpdatedAt,
+	)
+
+	updated, err := scanNotification(row)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return updated, err
+	}
+
+	// nothing was updated, either because the notification doesn't exist or because its version changed
+	if _, err := r.Get(ctx, notification.ID); err != nil {
+		return domain.Notification{}, err
+	}
+
+	return domain.Notification{}, domain.ErrNotificationVersionConflict
+}
+
+func (r *PgNotificationsRepository) list(ctx context.Context, query string, args ...interface{}) ([]domain.Notification, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := []domain.Notification{}
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, rows.Err()
+}
+
+func scanNotification(row pg.Scanner) (domain.Notification, error) {
+	var notification domain.Notification
+	var category, channel, status string
+	var nextAttemptAt, sentAt sql.NullTime
+
+	err := row.Scan(
+		&notification.ID,
+		&notification.Key,
+		&notification.CustomerID,
+		&category,
+		&notification.Template,
+		&channel,
+		&notification.Locale,
+		&notification.To,
+		&notification.Subject,
+		&notification.Body,
+		&status,
+		&notification.Attempts,
+		&notification.LastError,
+		&nextAttemptAt,
+		&sentAt,
+		&notification.Version,
+		&notification.CreatedAt,
+		&notification.UpdatedAt,
+	)
+	if err != nil {
+		return domain.Notification{}, err
+	}
+
+	notification.Category = domain.Category(category)
+	notification.Channel = domain.Channel(channel)
+	notification.Status = domain.NotificationStatus(status)
+	notification.NextAttemptAt = nextAttemptAt.Time
+	notification.SentAt = sentAt.Time
+
+	return notification, nil
+}
+
+// nullTime stores the zero times as NULL
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
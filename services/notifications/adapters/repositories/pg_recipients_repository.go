@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/lucasmls/ecommerce/shared/pg"
+)
+
+const recipientColumns = "customer_id, name, email, email_verified, locale, phone, channels, updated_at"
+
+// PgRecipientsRepository keeps the Recipients on Postgres, each one on a single row
+type PgRecipientsRepository struct {
+	db *sql.DB
+}
+
+func NewPgRecipientsRepository(connectionString string) (*PgRecipientsRepository, error) {
+	db, err := openPg(connectionString)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PgRecipientsRepository{
+		db: db,
+	}, nil
+}
+
+func MustNewPgRecipientsRepository(connectionString string) *PgRecipientsRepository {
+	repo, err := NewPgRecipientsRepository(connectionString)
+	if err != nil {
+		panic(err)
+	}
+
+	return repo
+}
+
+// Save upserts a Recipient
+func (r *PgRecipientsRepository) Save(ctx context.Context, recipient domain.Recipient) (domain.Recipient, error) {
+	channels, err := json.Marshal(recipient.Preferences.Channels)
+	if err != nil {
+		return domain.Recipient{}, err
+	}
+
+	row := r.db.QueryRowContext(
+		ctx,
+		`INSERT INTO recipients (`+recipientColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (customer_id) DO UPDATE
+		SET name = $2, email = $3, email_verified = $4, locale = $5, phone = $6, channels = $7, updated_at = $8
+		RETURNING `+recipientColumns,
+		recipient.CustomerID, recipient.Name, recipient.Email, recipient.EmailVerified,
+		recipient.Preferences.Locale, recipient.Preferences.Phone, channels, recipient.UpdatedAt,
+	)
+
+	return scanRecipient(row)
+}
+
+// Get fetches a single Recipient
+func (r *PgRecipientsRepository) Get(ctx context.Context, customerID string) (domain.Recipient, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+recipientColumns+" FROM recipients WHERE customer_id = $1", customerID)
+
+	recipient, err := scanRecipient(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Recipient{}, domain.ErrRecipientNotFound
+	}
+
+	return recipient, err
+}
+
+// Delete removes a Recipient
+func (r *PgRecipientsRepository) Delete(ctx context.Context, customerID string) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM recipients WHERE customer_id = $1", customerID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return domain.ErrRecipientNotFound
+	}
+
+	return nil
+}
+
+func scanRecipient(row pg.Scanner) (domain.Recipient, error) {
+	var recipient domain.Recipient
+	var channels []byte
+
+	err := row.Scan(
+		&recipient.CustomerID,
+		&recipient.Name,
+		&recipient.Email,
+		&recipient.EmailVerified,
+		&recipient.Preferences.Locale,
+		&recipient.Preferences.Phone,
+		&channels,
+		&recipient.UpdatedAt,
+	)
+	if err != nil {
+		return domain.Recipient{}, err
+	}
+
+	if err := json.Unmarshal(channels, &recipient.Preferences.Channels); err != nil {
+		return domain.Recipient{}, err
+	}
+
+	return recipient, nil
+}
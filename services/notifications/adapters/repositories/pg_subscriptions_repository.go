@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/lucasmls/ecommerce/shared/pg"
+)
+
+const subscriptionColumns = "customer_id, product_id, created_at"
+
+// PgSubscriptionsRepository keeps the back in stock Subscriptions on Postgres
+type PgSubscriptionsRepository struct {
+	db *sql.DB
+}
+
+func NewPgSubscriptionsRepository(connectionString string) (*PgSubscriptionsRepository, error) {
+	db, err := openPg(connectionString)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PgSubscriptionsRepository{
+		db: db,
+	}, nil
+}
+
+func MustNewPgSubscriptionsRepository(connectionString string) *PgSubscriptionsRepository {
+	repo, err := NewPgSubscriptionsRepository(connectionString)
+	if err != nil {
+		panic(err)
+	}
+
+	return repo
+}
+
+// Save inserts a Subscription, keeping the stored one when it already exists
+func (r *PgSubscriptionsRepository) Save(ctx context.Context, subscription domain.Subscription) (domain.Subscription, error) {
+	// the no-op update makes the stored row be returned when there's one already
+	row := r.db.QueryRowContext(
+		ctx,
+		`INSERT INTO back_in_stock_subscriptions (`+subscriptionColumns+`)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (customer_id, product_id) DO UPDATE SET customer_id = EXCLUDED.customer_id
+		RETURNING `+subscriptionColumns,
+		subscription.CustomerID, subscription.ProductID, subscription.CreatedAt,
+	)
+
+	return scanSubscription(row)
+}
+
+// Delete removes a Subscription
+func (r *PgSubscriptionsRepository) Delete(ctx context.Context, subscription domain.Subscription) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		"DELETE FROM back_in_stock_subscriptions WHERE customer_id = $1 AND product_id = $2",
+		subscription.CustomerID, subscription.ProductID,
+	)
+
+	return err
+}
+
+// List lists the Subscriptions matching the filter, most recent first
+func (r *PgSubscriptionsRepository) List(ctx context.Context, filter domain.SubscriptionFilter) ([]domain.Subscription, error) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	if filter.CustomerID != "" {
+		args = append(args, filter.CustomerID)
+		conditions = append(conditions, fmt.Sprintf("customer_id = $%d", len(args)))
+	}
+
+	if filter.ProductID != 0 {
+		args = append(args, filter.ProductID)
+		conditions = append(conditions, fmt.Sprintf("product_id = $%d", len(args)))
+	}
+
+	query := "SELECT " + subscriptionColumns + " FROM back_in_stock_subscriptions"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY created_at DESC, customer_id, product_id"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := []domain.Subscription{}
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, rows.Err()
+}
+
+func scanSubscription(row pg.Scanner) (domain.Subscription, error) {
+	var subscription domain.Subscription
+
+	err := row.Scan(&subscription.CustomerID, &subscription.ProductID, &subscription.CreatedAt)
+	if err != nil {
+		return domain.Subscription{}, err
+	}
+
+	return subscription, nil
+}
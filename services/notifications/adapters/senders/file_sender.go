@@ -0,0 +1,114 @@
+package senders
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var ErrMissingWriter = errors.New("missing required dependency: Writer")
+
+// fileRecord is how a Message is written by the FileSender, one JSON document per line
+type fileRecord struct {
+	ID      int       `json:"id"`
+	Channel string    `json:"channel"`
+	To      string    `json:"to"`
+	Subject string    `json:"subject,omitempty"`
+	Body    string    `json:"body"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+// FileSender writes the Messages of a Channel to a file instead of delivering them, logging each one.
+// It stands in for the Channels without a provider, e.g. the SMS while developing.
+type FileSender struct {
+	Logger  *zap.Logger
+	Tracer  trace.Tracer
+	channel domain.Channel
+
+	// mu guards writer, so the lines of concurrent Messages don't interleave
+	mu     sync.Mutex
+	writer io.Writer
+}
+
+// NewFileSender creates a new FileSender instance
+func NewFileSender(logger *zap.Logger, tracer trace.Tracer, channel domain.Channel, writer io.Writer) (*FileSender, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMissingTracer
+	}
+
+	if err := channel.Validate(); err != nil {
+		return nil, err
+	}
+
+	if writer == nil {
+		return nil, ErrMissingWriter
+	}
+
+	return &FileSender{
+		Logger:  logger,
+		Tracer:  tracer,
+		channel: channel,
+		writer:  writer,
+	}, nil
+}
+
+// MustNewFileSender creates a new FileSender instance
+// It panics if any error is found
+func MustNewFileSender(logger *zap.Logger, tracer trace.Tracer, channel domain.Channel, writer io.Writer) *FileSender {
+	sender, err := NewFileSender(logger, tracer, channel, writer)
+	if err != nil {
+		panic(err)
+	}
+
+	return sender
+}
+
+// Channel tells which Channel the FileSender stands in for
+func (s *FileSender) Channel() domain.Channel {
+	return s.channel
+}
+
+// Send writes a Message as a line of JSON
+func (s *FileSender) Send(ctx context.Context, message domain.Message) error {
+	_, span := s.Tracer.Start(ctx, "sender.Send")
+	defer span.End()
+
+	line, err := json.Marshal(fileRecord{
+		ID:      message.ID,
+		Channel: string(s.channel),
+		To:      message.To,
+		Subject: message.Subject,
+		Body:    message.Body,
+		SentAt:  time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.writer.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	s.Logger.Info(
+		"wrote a notification",
+		zap.Int("notificationId", message.ID),
+		zap.String("channel", string(s.channel)),
+		zap.String("subject", message.Subject),
+	)
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package senders
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// readLines splits what the FileSender wrote
+func readLines(r io.Reader) []string {
+	lines := []string{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	return lines
+}
+
+type FileSenderSuite struct {
+	suite.Suite
+
+	file   *bytes.Buffer
+	sender *FileSender
+}
+
+func (s *FileSenderSuite) SetupTest() {
+	s.file = &bytes.Buffer{}
+	s.sender = MustNewFileSender(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), domain.ChannelSMS, s.file)
+}
+
+func (s *FileSenderSuite) Test_NewFileSender() {
+	s.Run("Should fail in case the channel is unknown", func() {
+		_, err := NewFileSender(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), "pigeon", s.file)
+
+		s.Equal(domain.ErrInvalidChannel, err)
+	})
+}
+
+func (s *FileSenderSuite) Test_Send() {
+	s.Run("Should write each message on its own line", func() {
+		s.NoError(s.sender.Send(context.Background(), domain.Message{ID: 1, To: "+5511987654321", Body: "Your order is on its way"}))
+		s.NoError(s.sender.Send(context.Background(), domain.Message{ID: 2, To: "+5511987654321", Body: "Your order\nwas delivered"}))
+
+		lines := readLines(s.file)
+		s.Require().Len(lines, 2)
+
+		record := fileRecord{}
+		s.NoError(json.Unmarshal([]byte(lines[1]), &record))
+		s.Equal(2, record.ID)
+		s.Equal("sms", record.Channel)
+		s.Equal("+5511987654321", record.To)
+		s.Equal("Your order\nwas delivered", record.Body)
+		s.False(record.SentAt.IsZero())
+	})
+}
+
+func TestFileSenderSuite(t *testing.T) {
+	suite.Run(t, new(FileSenderSuite))
+}
@@ -0,0 +1,216 @@
+package senders
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// defaultSmtpTimeout is how long sending an email may take when SmtpSenderInput.Timeout isn't set
+const defaultSmtpTimeout = 10 * time.Second
+
+var (
+	ErrMissingLogger  = errors.New("missing required dependency: Logger")
+	ErrMissingTracer  = errors.New("missing required dependency: Tracer")
+	ErrMissingAddress = errors.New("missing required dependency: Address")
+	ErrInvalidFrom    = errors.New("invalid-from")
+	ErrInvalidTo      = errors.New("invalid-to")
+)
+
+// SmtpSenderInput tells how the SmtpSender reaches its SMTP server
+type SmtpSenderInput struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+	// Address is where the SMTP server listens, e.g. localhost:1025
+	Address string
+	// From is the sender of the emails, e.g. Ecommerce <no-reply@ecommerce.com>
+	From string
+	// Username and Password authenticate to the SMTP server, which is skipped when the Username is empty
+	Username string
+	Password string
+	// Timeout is how long sending an email may take, 10s when unset
+	Timeout time.Duration
+}
+
+// SmtpSender sends the email Notifications through an SMTP server, as plain text.
+// The connection is upgraded with STARTTLS whenever the server supports it.
+type SmtpSender struct {
+	in   SmtpSenderInput
+	from *mail.Address
+}
+
+// NewSmtpSender creates a new SmtpSender instance
+func NewSmtpSender(in SmtpSenderInput) (*SmtpSender, error) {
+	if in.Logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if in.Tracer == nil {
+		return nil, ErrMissingTracer
+	}
+
+	if in.Address == "" {
+		return nil, ErrMissingAddress
+	}
+
+	from, err := mail.ParseAddress(in.From)
+	if err != nil {
+		return nil, ErrInvalidFrom
+	}
+
+	if in.Timeout <= 0 {
+		in.Timeout = defaultSmtpTimeout
+	}
+
+	return &SmtpSender{
+		in:   in,
+		from: from,
+	}, nil
+}
+
+// MustNewSmtpSender creates a new SmtpSender instance
+// It panics if any error is found
+func MustNewSmtpSender(in SmtpSenderInput) *SmtpSender {
+	sender, err := NewSmtpSender(in)
+	if err != nil {
+		panic(err)
+	}
+
+	return sender
+}
+
+// Channel tells the SmtpSender sends emails
+func (s *SmtpSender) Channel() domain.Channel {
+	return domain.ChannelEmail
+}
+
+// Send sends a Message as an email, its ID is on the Message-ID header so repeated ones can be told apart
+func (s *SmtpSender) Send(ctx context.Context, message domain.Message) error {
+	ctx, span := s.in.Tracer.Start(ctx, "sender.Send")
+	defer span.End()
+
+	to, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return ErrInvalidTo
+	}
+
+	body, err := s.compose(to, message)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.in.Timeout)
+	defer cancel()
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", s.in.Address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+
+	host, _, err := net.SplitHostPort(s.in.Address)
+	if err != nil {
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := s.deliver(client, host, to, body); err != nil {
+		return err
+	}
+
+	s.in.Logger.Info("sent an email", zap.Int("notificationId", message.ID))
+
+	return client.Quit()
+}
+
+func (s *SmtpSender) deliver(client *smtp.Client, host string, to *mail.Address, body []byte) error {
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}); err != nil {
+			return err
+		}
+	}
+
+	if s.in.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.in.Username, s.in.Password, host)); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(s.from.Address); err != nil {
+		return err
+	}
+
+	if err := client.Rcpt(to.Address); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := writer.Write(body); err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+// compose writes the headers and the quoted-printable body of the email
+func (s *SmtpSender) compose(to *mail.Address, message domain.Message) ([]byte, error) {
+	var buf bytes.Buffer
+
+	domainName := s.from.Address[strings.LastIndex(s.from.Address, "@")+1:]
+	// the subject is encoded, so it can't carry line breaks into the headers
+	subject := mime.QEncoding.Encode("utf-8", strings.Join(strings.Fields(message.Subject), " "))
+
+	headers := []string{
+		"From: " + s.from.String(),
+		"To: " + to.String(),
+		"Subject: " + subject,
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		fmt.Sprintf("Message-ID: <notification-%d@%s>", message.ID, domainName),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"Content-Transfer-Encoding: quoted-printable",
+	}
+
+	buf.WriteString(strings.Join(headers, "\r\n"))
+	buf.WriteString("\r\n\r\n")
+
+	writer := quotedprintable.NewWriter(&buf)
+	if _, err := writer.Write([]byte(strings.ReplaceAll(message.Body, "\n", "\r\n"))); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
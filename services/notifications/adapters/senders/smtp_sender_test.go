@@ -0,0 +1,201 @@
+package senders
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// sinkMessage is an email received by the smtpSink
+type sinkMessage struct {
+	from string
+	to   string
+	data string
+}
+
+// smtpSink is a local SMTP server speaking just enough of the protocol to receive the emails of the SmtpSender
+type smtpSink struct {
+	listener net.Listener
+	messages chan sinkMessage
+	// rejected is the recipient the sink refuses to deliver to
+	rejected string
+}
+
+func newSmtpSink(rejected string) (*smtpSink, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	sink := &smtpSink{
+		listener: listener,
+		messages: make(chan sinkMessage, 10),
+		rejected: rejected,
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go sink.serve(conn)
+		}
+	}()
+
+	return sink, nil
+}
+
+func (s *smtpSink) serve(conn net.Conn) {
+	defer conn.Close()
+
+	text := textproto.NewConn(conn)
+	_ = text.PrintfLine("220 sink ESMTP")
+
+	message := sinkMessage{}
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		command := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			_ = text.PrintfLine("250 sink")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			message.from = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+			_ = text.PrintfLine("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			message.to = strings.Trim(line[len("RCPT TO:"):], "<> ")
+			if message.to == s.rejected {
+				_ = text.PrintfLine("550 No such user")
+				continue
+			}
+
+			_ = text.PrintfLine("250 OK")
+		case command == "DATA":
+			_ = text.PrintfLine("354 End data with <CR><LF>.<CR><LF>")
+
+			data, err := io.ReadAll(text.DotReader())
+			if err != nil {
+				return
+			}
+
+			message.data = string(data)
+			s.messages <- message
+			message = sinkMessage{}
+
+			_ = text.PrintfLine("250 OK")
+		case command == "QUIT":
+			_ = text.PrintfLine("221 Bye")
+			return
+		default:
+			_ = text.PrintfLine("250 OK")
+		}
+	}
+}
+
+type SmtpSenderSuite struct {
+	suite.Suite
+
+	sink   *smtpSink
+	sender *SmtpSender
+}
+
+func (s *SmtpSenderSuite) SetupTest() {
+	sink, err := newSmtpSink("ghost@doe.com")
+	s.Require().NoError(err)
+
+	s.sink = sink
+	s.sender = MustNewSmtpSender(SmtpSenderInput{
+		Logger:  zap.NewNop(),
+		Tracer:  trace.NewNoopTracerProvider().Tracer(""),
+		Address: sink.listener.Addr().String(),
+		From:    "Ecommerce <no-reply@ecommerce.com>",
+	})
+}
+
+func (s *SmtpSenderSuite) TearDownTest() {
+	_ = s.sink.listener.Close()
+}
+
+func (s *SmtpSenderSuite) Test_NewSmtpSender() {
+	s.Run("Should fail in case the sender of the emails is invalid", func() {
+		_, err := NewSmtpSender(SmtpSenderInput{
+			Logger:  zap.NewNop(),
+			Tracer:  trace.NewNoopTracerProvider().Tracer(""),
+			Address: "localhost:1025",
+			From:    "ecommerce",
+		})
+
+		s.Equal(ErrInvalidFrom, err)
+	})
+}
+
+func (s *SmtpSenderSuite) Test_Send() {
+	s.Run("Should send the message as a plain text email", func() {
+		err := s.sender.Send(context.Background(), domain.Message{
+			ID:      7,
+			To:      "João <joao@doe.com>",
+			Subject: "Seu pedido\nfoi confirmado",
+			Body:    "Olá João,\n\nObrigado pela sua compra!",
+		})
+		s.Require().NoError(err)
+
+		received := <-s.sink.messages
+		s.Equal("no-reply@ecommerce.com", received.from)
+		s.Equal("joao@doe.com", received.to)
+
+		email, err := mail.ReadMessage(strings.NewReader(received.data))
+		s.Require().NoError(err)
+
+		subject, err := new(mime.WordDecoder).DecodeHeader(email.Header.Get("Subject"))
+		s.NoError(err)
+		s.Equal("Seu pedido foi confirmado", subject)
+		s.Equal("<notification-7@ecommerce.com>", email.Header.Get("Message-ID"))
+		s.Equal("text/plain; charset=UTF-8", email.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(quotedprintable.NewReader(email.Body))
+		s.NoError(err)
+		// the sink reads the lines of the email as the text they are, without their CRLF endings
+		s.Equal("Olá João,\n\nObrigado pela sua compra!", strings.TrimSpace(string(body)))
+	})
+
+	s.Run("Should fail in case the server rejects the recipient", func() {
+		err := s.sender.Send(context.Background(), domain.Message{ID: 8, To: "ghost@doe.com", Body: "Hi"})
+
+		s.Error(err)
+		s.Empty(s.sink.messages)
+	})
+
+	s.Run("Should fail in case the recipient is invalid", func() {
+		err := s.sender.Send(context.Background(), domain.Message{ID: 9, To: "john", Body: "Hi"})
+
+		s.Equal(ErrInvalidTo, err)
+	})
+
+	s.Run("Should fail in case the server can't be reached", func() {
+		s.Require().NoError(s.sink.listener.Close())
+
+		err := s.sender.Send(context.Background(), domain.Message{ID: 10, To: "john@doe.com", Body: "Hi"})
+
+		s.Error(err)
+	})
+}
+
+func TestSmtpSenderSuite(t *testing.T) {
+	suite.Run(t, new(SmtpSenderSuite))
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// retryBatchSize is the maximum amount of Notifications RetryDeliveries sends at once
+const retryBatchSize = 100
+
+// application holds all dependencies the Application needs to work
+type application struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	RecipientsRepository    domain.RecipientsRepository
+	NotificationsRepository domain.NotificationsRepository
+	SubscriptionsRepository domain.SubscriptionsRepository
+	Renderer                domain.Renderer
+	Catalog                 domain.Catalog
+
+	// Senders are keyed by their Channel, the Notifications of a Channel without one are never sent
+	Senders map[domain.Channel]domain.Sender
+}
+
+// NewApplication creates a new Application instance
+func NewApplication(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	recipientsRepository domain.RecipientsRepository,
+	notificationsRepository domain.NotificationsRepository,
+	subscriptionsRepository domain.SubscriptionsRepository,
+	renderer domain.Renderer,
+	catalog domain.Catalog,
+	senders []domain.Sender,
+) application {
+	sendersByChannel := make(map[domain.Channel]domain.Sender, len(senders))
+	for _, sender := range senders {
+		sendersByChannel[sender.Channel()] = sender
+	}
+
+	return application{
+		Logger:                  logger,
+		Tracer:                  tracer,
+		RecipientsRepository:    recipientsRepository,
+		NotificationsRepository: notificationsRepository,
+		SubscriptionsRepository: subscriptionsRepository,
+		Renderer:                renderer,
+		Catalog:                 catalog,
+		Senders:                 sendersByChannel,
+	}
+}
+
+// MustNewApplication creates a new Application instance
+// It panics if any error is found
+func MustNewApplication(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	recipientsRepository domain.RecipientsRepository,
+	notificationsRepository domain.NotificationsRepository,
+	subscriptionsRepository domain.SubscriptionsRepository,
+	renderer domain.Renderer,
+	catalog domain.Catalog,
+	senders []domain.Sender,
+) application {
+	app := NewApplication(
+		logger, tracer, recipientsRepository, notificationsRepository, subscriptionsRepository, renderer, catalog, senders,
+	)
+	return app
+}
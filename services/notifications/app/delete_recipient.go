@@ -0,0 +1,37 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) DeleteRecipient(ctx context.Context, customerID string) error {
+	ctx, span := a.Tracer.Start(ctx, "app.DeleteRecipient")
+	defer span.End()
+
+	a.Logger.Info("deleting a recipient", zap.String("customerId", customerID))
+
+	if customerID == "" {
+		return domain.ErrInvalidRecipient
+	}
+
+	subscriptions, err := a.SubscriptionsRepository.List(ctx, domain.SubscriptionFilter{CustomerID: customerID})
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range subscriptions {
+		if err := a.SubscriptionsRepository.Delete(ctx, subscription); err != nil {
+			return err
+		}
+	}
+
+	if err := a.RecipientsRepository.Delete(ctx, customerID); err != nil && !errors.Is(err, domain.ErrRecipientNotFound) {
+		return err
+	}
+
+	return nil
+}
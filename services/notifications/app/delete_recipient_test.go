@@ -0,0 +1,89 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/lucasmls/ecommerce/services/notifications/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type DeleteRecipientSuite struct {
+	suite.Suite
+
+	recipientsRepo    *mocks.RecipientsRepository
+	subscriptionsRepo *mocks.SubscriptionsRepository
+	app               domain.Application
+}
+
+func (s *DeleteRecipientSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.recipientsRepo = &mocks.RecipientsRepository{}
+	s.subscriptionsRepo = &mocks.SubscriptionsRepository{}
+
+	s.app = MustNewApplication(
+		loggerM, tracerM, s.recipientsRepo, &mocks.NotificationsRepository{}, s.subscriptionsRepo,
+		&mocks.Renderer{}, &mocks.Catalog{}, nil,
+	)
+}
+
+func (s *DeleteRecipientSuite) Test_DeleteRecipient() {
+	subscriptions := []domain.Subscription{{CustomerID: "john", ProductID: 1}, {CustomerID: "john", ProductID: 2}}
+
+	s.Run("Should fail in case the customer ID is empty", func() {
+		s.SetupTest()
+
+		err := s.app.DeleteRecipient(context.Background(), "")
+
+		s.Equal(domain.ErrInvalidRecipient, err)
+	})
+
+	s.Run("Should delete the recipient along with its subscriptions", func() {
+		s.SetupTest()
+
+		s.subscriptionsRepo.On("List", mock.AnythingOfType("*context.valueCtx"), domain.SubscriptionFilter{CustomerID: "john"}).Return(subscriptions, nil).Once()
+		s.subscriptionsRepo.On("Delete", mock.AnythingOfType("*context.valueCtx"), subscriptions[0]).Return(nil).Once()
+		s.subscriptionsRepo.On("Delete", mock.AnythingOfType("*context.valueCtx"), subscriptions[1]).Return(nil).Once()
+		s.recipientsRepo.On("Delete", mock.AnythingOfType("*context.valueCtx"), "john").Return(nil).Once()
+
+		err := s.app.DeleteRecipient(context.Background(), "john")
+
+		s.NoError(err)
+		s.subscriptionsRepo.AssertExpectations(s.T())
+		s.recipientsRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Should succeed in case the recipient doesn't exist", func() {
+		s.SetupTest()
+
+		s.subscriptionsRepo.On("List", mock.AnythingOfType("*context.valueCtx"), domain.SubscriptionFilter{CustomerID: "john"}).Return([]domain.Subscription{}, nil).Once()
+		s.recipientsRepo.On("Delete", mock.AnythingOfType("*context.valueCtx"), "john").Return(domain.ErrRecipientNotFound).Once()
+
+		err := s.app.DeleteRecipient(context.Background(), "john")
+
+		s.NoError(err)
+	})
+
+	s.Run("Should keep the recipient in case its subscriptions can't be deleted", func() {
+		s.SetupTest()
+
+		s.subscriptionsRepo.On("List", mock.AnythingOfType("*context.valueCtx"), domain.SubscriptionFilter{CustomerID: "john"}).Return(subscriptions, nil).Once()
+		s.subscriptionsRepo.On("Delete", mock.AnythingOfType("*context.valueCtx"), subscriptions[0]).Return(errors.New("connection refused")).Once()
+
+		err := s.app.DeleteRecipient(context.Background(), "john")
+
+		s.EqualError(err, "connection refused")
+		s.recipientsRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeleteRecipientSuite(t *testing.T) {
+	suite.Run(t, new(DeleteRecipientSuite))
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"go.uber.org/zap"
+)
+
+// deliver runs a delivery attempt of a pending Notification, returning it as it was last stored.
+// The attempt is stored before sending it, so another replica doesn't send the Notification at the same time,
+// and a Notification whose attempt never finishes is sent again once its NextAttemptAt is due.
+// The failures are only logged, the Notification is retried by RetryDeliveries.
+func (a application) deliver(ctx context.Context, notification domain.Notification) domain.Notification {
+	notification.Attempt(time.Now())
+
+	attempted, err := a.NotificationsRepository.Update(ctx, notification)
+	if errors.Is(err, domain.ErrNotificationVersionConflict) {
+		a.Logger.Info("the notification is being delivered already", zap.Int("notificationId", notification.ID))
+		return notification
+	}
+
+	if err != nil {
+		a.Logger.Error("failed to start delivering a notification", zap.Int("notificationId", notification.ID), zap.Error(err))
+		return notification
+	}
+
+	sendErr := domain.ErrSenderUnavailable
+	if sender, ok := a.Senders[attempted.Channel]; ok {
+		sendErr = sender.Send(ctx, domain.Message{
+			ID:      attempted.ID,
+			To:      attempted.To,
+			Subject: attempted.Subject,
+			Body:    attempted.Body,
+		})
+	}
+
+	if sendErr != nil {
+		a.Logger.Warn(
+			"failed to send a notification",
+			zap.Int("notificationId", attempted.ID),
+			zap.Int("attempts", attempted.Attempts),
+			zap.Error(sendErr),
+		)
+		attempted.DeliveryFailed(sendErr, time.Now())
+	} else {
+		attempted.Delivered(time.Now())
+	}
+
+	// a Notification sent but not stored as such is sent again, the Channels are told its ID to drop the repeated ones
+	delivered, err := a.NotificationsRepository.Update(ctx, attempted)
+	if err != nil {
+		a.Logger.Error("failed to store the delivery of a notification", zap.Int("notificationId", attempted.ID), zap.Error(err))
+		return attempted
+	}
+
+	return delivered
+}
@@ -0,0 +1,26 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) GetPreferences(ctx context.Context, customerID string) (domain.Preferences, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.GetPreferences")
+	defer span.End()
+
+	a.Logger.Info("getting the preferences of a recipient", zap.String("customerId", customerID))
+
+	if customerID == "" {
+		return domain.Preferences{}, domain.ErrInvalidRecipient
+	}
+
+	recipient, err := a.RecipientsRepository.Get(ctx, customerID)
+	if err != nil {
+		return domain.Preferences{}, err
+	}
+
+	return recipient.Preferences, nil
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/lucasmls/ecommerce/services/notifications/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type GetPreferencesSuite struct {
+	suite.Suite
+
+	recipientsRepo *mocks.RecipientsRepository
+	app            domain.Application
+}
+
+func (s *GetPreferencesSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.recipientsRepo = &mocks.RecipientsRepository{}
+
+	s.app = MustNewApplication(
+		loggerM, tracerM, s.recipientsRepo, &mocks.NotificationsRepository{}, &mocks.SubscriptionsRepository{},
+		&mocks.Renderer{}, &mocks.Catalog{}, nil,
+	)
+}
+
+func (s *GetPreferencesSuite) Test_GetPreferences() {
+	s.Run("Should fail in case the customer ID is empty", func() {
+		s.SetupTest()
+
+		_, err := s.app.GetPreferences(context.Background(), "")
+
+		s.Equal(domain.ErrInvalidRecipient, err)
+	})
+
+	s.Run("Should fail in case the recipient doesn't exist", func() {
+		s.SetupTest()
+
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "john").Return(domain.Recipient{}, domain.ErrRecipientNotFound).Once()
+
+		_, err := s.app.GetPreferences(context.Background(), "john")
+
+		s.Equal(domain.ErrRecipientNotFound, err)
+	})
+
+	s.Run("Should get the preferences of the recipient", func() {
+		s.SetupTest()
+
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "john").Return(textingRecipient(), nil).Once()
+
+		preferences, err := s.app.GetPreferences(context.Background(), "john")
+
+		s.NoError(err)
+		s.Equal(textingRecipient().Preferences, preferences)
+	})
+}
+
+func TestGetPreferencesSuite(t *testing.T) {
+	suite.Run(t, new(GetPreferencesSuite))
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/lucasmls/ecommerce/services/notifications/mocks"
+)
+
+// recipient is John, who never changed the default preferences
+func recipient() domain.Recipient {
+	return domain.Recipient{
+		CustomerID:    "john",
+		Name:          "John Doe",
+		Email:         "john@doe.com",
+		EmailVerified: true,
+		Preferences:   domain.DefaultPreferences(),
+	}
+}
+
+// textingRecipient is John, also texted about the orders
+func textingRecipient() domain.Recipient {
+	john := recipient()
+	john.Preferences.Phone = "+5511987654321"
+	john.Preferences.Channels[domain.CategoryOrders] = []domain.Channel{domain.ChannelEmail, domain.ChannelSMS}
+
+	return john
+}
+
+// pending is a Notification of John's order confirmation waiting for its first delivery attempt
+func pending(id int, channel domain.Channel) domain.Notification {
+	to := "john@doe.com"
+	if channel == domain.ChannelSMS {
+		to = "+5511987654321"
+	}
+
+	return domain.Notification{
+		ID:            id,
+		Key:           "orders:7:paid",
+		CustomerID:    "john",
+		Category:      domain.CategoryOrders,
+		Template:      domain.TemplateOrderConfirmed,
+		Channel:       channel,
+		Locale:        "en",
+		To:            to,
+		Subject:       "Your order ORD-000007 is confirmed",
+		Body:          "Hi John Doe",
+		Status:        domain.NotificationStatusPending,
+		NextAttemptAt: time.Date(2022, 10, 19, 8, 0, 0, 0, time.UTC),
+		Version:       1,
+	}
+}
+
+// bumpVersion stands for the repositories storing a Notification
+func bumpVersion(_ context.Context, notification domain.Notification) (domain.Notification, error) {
+	notification.Version++
+	return notification, nil
+}
+
+// expectDelivery expects a delivery attempt of a Notification, the sender failing with sendErr.
+// The attempt is stored before sending it, and its outcome right after.
+func expectDelivery(
+	notificationsRepo *mocks.NotificationsRepository,
+	sender *mocks.Sender,
+	notification domain.Notification,
+	sendErr error,
+) {
+	notificationsRepo.
+		On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(n domain.Notification) bool {
+			return n.ID == notification.ID && n.Version == notification.Version &&
+				n.Attempts == notification.Attempts+1 && n.Status == domain.NotificationStatusPending
+		})).
+		Return(bumpVersion).
+		Once()
+
+	sender.
+		On("Send", mock.AnythingOfType("*context.valueCtx"), domain.Message{
+			ID:      notification.ID,
+			To:      notification.To,
+			Subject: notification.Subject,
+			Body:    notification.Body,
+		}).
+		Return(sendErr).
+		Once()
+
+	notificationsRepo.
+		On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(n domain.Notification) bool {
+			return n.ID == notification.ID && n.Version == notification.Version+1
+		})).
+		Return(bumpVersion).
+		Once()
+}
+
+// sender is a Sender of a Channel
+func sender(channel domain.Channel) *mocks.Sender {
+	s := &mocks.Sender{}
+	s.On("Channel").Return(channel)
+	return s
+}
@@ -0,0 +1,31 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) ListNotifications(ctx context.Context, filter domain.NotificationFilter) ([]domain.Notification, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.ListNotifications")
+	defer span.End()
+
+	a.Logger.Info("listing notifications", zap.Any("filter", filter))
+
+	if filter.Limit < 0 || filter.Limit > domain.MaxNotificationsLimit {
+		return nil, domain.ErrInvalidNotificationFilter
+	}
+
+	if filter.Status != "" {
+		if err := filter.Status.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = domain.DefaultNotificationsLimit
+	}
+
+	return a.NotificationsRepository.List(ctx, filter)
+}
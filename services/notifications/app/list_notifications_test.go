@@ -0,0 +1,84 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/lucasmls/ecommerce/services/notifications/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ListNotificationsSuite struct {
+	suite.Suite
+
+	notificationsRepo *mocks.NotificationsRepository
+	app               domain.Application
+}
+
+func (s *ListNotificationsSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.notificationsRepo = &mocks.NotificationsRepository{}
+
+	s.app = MustNewApplication(
+		loggerM, tracerM, &mocks.RecipientsRepository{}, s.notificationsRepo, &mocks.SubscriptionsRepository{},
+		&mocks.Renderer{}, &mocks.Catalog{}, nil,
+	)
+}
+
+func (s *ListNotificationsSuite) Test_ListNotifications() {
+	s.Run("Should fail in case the filter is invalid", func() {
+		s.SetupTest()
+
+		testCases := []struct {
+			filter domain.NotificationFilter
+			err    error
+		}{
+			{filter: domain.NotificationFilter{Limit: -1}, err: domain.ErrInvalidNotificationFilter},
+			{filter: domain.NotificationFilter{Limit: domain.MaxNotificationsLimit + 1}, err: domain.ErrInvalidNotificationFilter},
+			{filter: domain.NotificationFilter{Status: "queued"}, err: domain.ErrInvalidNotificationStatus},
+		}
+
+		for _, tc := range testCases {
+			_, err := s.app.ListNotifications(context.Background(), tc.filter)
+
+			s.Equal(tc.err, err)
+		}
+
+		s.notificationsRepo.AssertNotCalled(s.T(), "List", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should list the notifications matching the filter", func() {
+		s.SetupTest()
+
+		filter := domain.NotificationFilter{CustomerID: "john", Status: domain.NotificationStatusPending, Limit: 10}
+		notifications := []domain.Notification{pending(2, domain.ChannelSMS), pending(1, domain.ChannelEmail)}
+
+		s.notificationsRepo.On("List", mock.AnythingOfType("*context.valueCtx"), filter).Return(notifications, nil).Once()
+
+		listed, err := s.app.ListNotifications(context.Background(), filter)
+
+		s.NoError(err)
+		s.Equal(notifications, listed)
+	})
+
+	s.Run("Should list the default amount of notifications in case the limit isn't set", func() {
+		s.SetupTest()
+
+		s.notificationsRepo.On("List", mock.AnythingOfType("*context.valueCtx"), domain.NotificationFilter{CustomerID: "john", Limit: domain.DefaultNotificationsLimit}).Return([]domain.Notification{}, nil).Once()
+
+		_, err := s.app.ListNotifications(context.Background(), domain.NotificationFilter{CustomerID: "john"})
+
+		s.NoError(err)
+		s.notificationsRepo.AssertExpectations(s.T())
+	})
+}
+
+func TestListNotificationsSuite(t *testing.T) {
+	suite.Run(t, new(ListNotificationsSuite))
+}
@@ -0,0 +1,21 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) ListSubscriptions(ctx context.Context, customerID string) ([]domain.Subscription, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.ListSubscriptions")
+	defer span.End()
+
+	a.Logger.Info("listing the subscriptions of a recipient", zap.String("customerId", customerID))
+
+	if customerID == "" {
+		return nil, domain.ErrInvalidRecipient
+	}
+
+	return a.SubscriptionsRepository.List(ctx, domain.SubscriptionFilter{CustomerID: customerID})
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/lucasmls/ecommerce/services/notifications/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ListSubscriptionsSuite struct {
+	suite.Suite
+
+	subscriptionsRepo *mocks.SubscriptionsRepository
+	app               domain.Application
+}
+
+func (s *ListSubscriptionsSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.subscriptionsRepo = &mocks.SubscriptionsRepository{}
+
+	s.app = MustNewApplication(
+		loggerM, tracerM, &mocks.RecipientsRepository{}, &mocks.NotificationsRepository{}, s.subscriptionsRepo,
+		&mocks.Renderer{}, &mocks.Catalog{}, nil,
+	)
+}
+
+func (s *ListSubscriptionsSuite) Test_ListSubscriptions() {
+	s.Run("Should fail in case the customer ID is empty", func() {
+		s.SetupTest()
+
+		_, err := s.app.ListSubscriptions(context.Background(), "")
+
+		s.Equal(domain.ErrInvalidRecipient, err)
+	})
+
+	s.Run("Should list the subscriptions of the recipient", func() {
+		s.SetupTest()
+
+		subscriptions := []domain.Subscription{{CustomerID: "john", ProductID: 2}, {CustomerID: "john", ProductID: 1}}
+		s.subscriptionsRepo.On("List", mock.AnythingOfType("*context.valueCtx"), domain.SubscriptionFilter{CustomerID: "john"}).Return(subscriptions, nil).Once()
+
+		listed, err := s.app.ListSubscriptions(context.Background(), "john")
+
+		s.NoError(err)
+		s.Equal(subscriptions, listed)
+	})
+}
+
+func TestListSubscriptionsSuite(t *testing.T) {
+	suite.Run(t, new(ListSubscriptionsSuite))
+}
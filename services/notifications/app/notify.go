@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) Notify(ctx context.Context, event domain.Event) ([]domain.Notification, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.Notify")
+	defer span.End()
+
+	a.Logger.Info("notifying an event", zap.String("key", event.Key), zap.String("customerId", event.CustomerID))
+
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+
+	recipient, err := a.RecipientsRepository.Get(ctx, event.CustomerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.notify(ctx, recipient, event)
+}
+
+// notify renders an Event for a Recipient on each Channel its Preferences ask for, and runs the first
+// delivery attempt of each Notification. The Channels the Recipient can't be reached through are skipped.
+func (a application) notify(
+	ctx context.Context,
+	recipient domain.Recipient,
+	event domain.Event,
+) ([]domain.Notification, error) {
+	// the templates may greet the Recipient by its name
+	data := map[string]interface{}{"Name": recipient.Name}
+	for key, value := range event.Data {
+		data[key] = value
+	}
+
+	notifications := []domain.Notification{}
+	for _, channel := range recipient.Preferences.ChannelsFor(event.Category) {
+		to := recipient.Address(channel)
+		if to == "" {
+			continue
+		}
+
+		content, err := a.Renderer.Render(ctx, event.Template, channel, recipient.Preferences.Locale, data)
+		if errors.Is(err, domain.ErrTemplateNotFound) {
+			a.Logger.Warn(
+				"skipping a channel the template can't be sent through",
+				zap.String("template", event.Template),
+				zap.String("channel", string(channel)),
+			)
+			continue
+		}
+
+		if err != nil {
+			return notifications, err
+		}
+
+		now := time.Now()
+		notification, err := a.NotificationsRepository.Create(ctx, domain.Notification{
+			Key:           event.Key,
+			CustomerID:    recipient.CustomerID,
+			Category:      event.Category,
+			Template:      event.Template,
+			Channel:       channel,
+			Locale:        recipient.Preferences.Locale,
+			To:            to,
+			Subject:       content.Subject,
+			Body:          content.Body,
+			Status:        domain.NotificationStatusPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		})
+		if errors.Is(err, domain.ErrNotificationExists) {
+			a.Logger.Info("the event was already notified", zap.String("key", event.Key), zap.String("channel", string(channel)))
+			continue
+		}
+
+		if err != nil {
+			return notifications, err
+		}
+
+		notifications = append(notifications, a.deliver(ctx, notification))
+	}
+
+	return notifications, nil
+}
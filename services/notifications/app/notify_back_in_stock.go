@@ -0,0 +1,74 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) NotifyBackInStock(ctx context.Context, backInStock domain.BackInStock) ([]domain.Notification, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.NotifyBackInStock")
+	defer span.End()
+
+	a.Logger.Info("notifying a product back in stock", zap.Int("productId", backInStock.ProductID))
+
+	if backInStock.ProductID <= 0 {
+		return nil, domain.ErrInvalidEvent
+	}
+
+	subscriptions, err := a.SubscriptionsRepository.List(ctx, domain.SubscriptionFilter{ProductID: backInStock.ProductID})
+	if err != nil {
+		return nil, err
+	}
+
+	notifications := []domain.Notification{}
+	if len(subscriptions) == 0 {
+		return notifications, nil
+	}
+
+	products, err := a.Catalog.Products(ctx, []int{backInStock.ProductID})
+	if err != nil {
+		return nil, err
+	}
+
+	product, ok := products[backInStock.ProductID]
+	if !ok {
+		return nil, domain.ErrProductNotFound
+	}
+
+	for _, subscription := range subscriptions {
+		recipient, err := a.RecipientsRepository.Get(ctx, subscription.CustomerID)
+		switch {
+		case errors.Is(err, domain.ErrRecipientNotFound):
+			// the customer is gone, and so should its Subscriptions
+		case err != nil:
+			return notifications, err
+		default:
+			notified, err := a.notify(ctx, recipient, domain.Event{
+				// the Product may be back in stock many times, each one is notified to the Subscriptions made before it
+				Key:        fmt.Sprintf("back_in_stock:%d:%s:%d", product.ID, recipient.CustomerID, backInStock.OccurredAt.Unix()),
+				CustomerID: recipient.CustomerID,
+				Category:   domain.CategoryBackInStock,
+				Template:   domain.TemplateBackInStock,
+				Data: map[string]interface{}{
+					"ProductID":   product.ID,
+					"ProductName": product.Name,
+					"Available":   backInStock.Available,
+				},
+			})
+			notifications = append(notifications, notified...)
+			if err != nil {
+				return notifications, err
+			}
+		}
+
+		if err := a.SubscriptionsRepository.Delete(ctx, subscription); err != nil {
+			return notifications, err
+		}
+	}
+
+	return notifications, nil
+}
@@ -0,0 +1,130 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/lucasmls/ecommerce/services/notifications/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type NotifyBackInStockSuite struct {
+	suite.Suite
+
+	recipientsRepo    *mocks.RecipientsRepository
+	notificationsRepo *mocks.NotificationsRepository
+	subscriptionsRepo *mocks.SubscriptionsRepository
+	renderer          *mocks.Renderer
+	catalog           *mocks.Catalog
+	emailSender       *mocks.Sender
+	app               domain.Application
+}
+
+func (s *NotifyBackInStockSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.recipientsRepo = &mocks.RecipientsRepository{}
+	s.notificationsRepo = &mocks.NotificationsRepository{}
+	s.subscriptionsRepo = &mocks.SubscriptionsRepository{}
+	s.renderer = &mocks.Renderer{}
+	s.catalog = &mocks.Catalog{}
+	s.emailSender = sender(domain.ChannelEmail)
+
+	s.app = MustNewApplication(
+		loggerM, tracerM, s.recipientsRepo, s.notificationsRepo, s.subscriptionsRepo,
+		s.renderer, s.catalog, []domain.Sender{s.emailSender},
+	)
+}
+
+func (s *NotifyBackInStockSuite) Test_NotifyBackInStock() {
+	occurredAt := time.Date(2022, 10, 19, 8, 0, 0, 0, time.UTC)
+	backInStock := domain.BackInStock{ProductID: 1, Available: 3, OccurredAt: occurredAt}
+	john := domain.Subscription{CustomerID: "john", ProductID: 1}
+	jane := domain.Subscription{CustomerID: "jane", ProductID: 1}
+
+	s.Run("Should fail in case the product is invalid", func() {
+		s.SetupTest()
+
+		_, err := s.app.NotifyBackInStock(context.Background(), domain.BackInStock{})
+
+		s.Equal(domain.ErrInvalidEvent, err)
+	})
+
+	s.Run("Should not look the product up in case nobody subscribed to it", func() {
+		s.SetupTest()
+
+		s.subscriptionsRepo.On("List", mock.AnythingOfType("*context.valueCtx"), domain.SubscriptionFilter{ProductID: 1}).Return([]domain.Subscription{}, nil).Once()
+
+		notifications, err := s.app.NotifyBackInStock(context.Background(), backInStock)
+
+		s.NoError(err)
+		s.Empty(notifications)
+		s.catalog.AssertNotCalled(s.T(), "Products", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the product doesn't exist anymore", func() {
+		s.SetupTest()
+
+		s.subscriptionsRepo.On("List", mock.AnythingOfType("*context.valueCtx"), domain.SubscriptionFilter{ProductID: 1}).Return([]domain.Subscription{john}, nil).Once()
+		s.catalog.On("Products", mock.AnythingOfType("*context.valueCtx"), []int{1}).Return(map[int]domain.ProductSnapshot{}, nil).Once()
+
+		_, err := s.app.NotifyBackInStock(context.Background(), backInStock)
+
+		s.Equal(domain.ErrProductNotFound, err)
+	})
+
+	s.Run("Should notify the subscriptions once, removing the ones of the customers gone", func() {
+		s.SetupTest()
+
+		key := fmt.Sprintf("back_in_stock:1:john:%d", occurredAt.Unix())
+		notification := domain.Notification{
+			ID:         1,
+			Key:        key,
+			CustomerID: "john",
+			Channel:    domain.ChannelEmail,
+			To:         "john@doe.com",
+			Subject:    "Keyboard is back in stock",
+			Body:       "Hi John Doe",
+			Status:     domain.NotificationStatusPending,
+			Version:    1,
+		}
+
+		s.subscriptionsRepo.On("List", mock.AnythingOfType("*context.valueCtx"), domain.SubscriptionFilter{ProductID: 1}).Return([]domain.Subscription{john, jane}, nil).Once()
+		s.catalog.On("Products", mock.AnythingOfType("*context.valueCtx"), []int{1}).Return(map[int]domain.ProductSnapshot{1: {ID: 1, Name: "Keyboard"}}, nil).Once()
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "john").Return(recipient(), nil).Once()
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "jane").Return(domain.Recipient{}, domain.ErrRecipientNotFound).Once()
+		s.renderer.
+			On("Render", mock.AnythingOfType("*context.valueCtx"), domain.TemplateBackInStock, domain.ChannelEmail, "en", map[string]interface{}{
+				"Name":        "John Doe",
+				"ProductID":   1,
+				"ProductName": "Keyboard",
+				"Available":   3,
+			}).
+			Return(domain.Content{Subject: notification.Subject, Body: notification.Body}, nil).
+			Once()
+		s.notificationsRepo.On("Create", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(n domain.Notification) bool {
+			return n.Key == key && n.Category == domain.CategoryBackInStock && n.Template == domain.TemplateBackInStock
+		})).Return(notification, nil).Once()
+		expectDelivery(s.notificationsRepo, s.emailSender, notification, nil)
+		s.subscriptionsRepo.On("Delete", mock.AnythingOfType("*context.valueCtx"), john).Return(nil).Once()
+		s.subscriptionsRepo.On("Delete", mock.AnythingOfType("*context.valueCtx"), jane).Return(nil).Once()
+
+		notifications, err := s.app.NotifyBackInStock(context.Background(), backInStock)
+
+		s.NoError(err)
+		s.Require().Len(notifications, 1)
+		s.Equal(domain.NotificationStatusSent, notifications[0].Status)
+		s.subscriptionsRepo.AssertExpectations(s.T())
+	})
+}
+
+func TestNotifyBackInStockSuite(t *testing.T) {
+	suite.Run(t, new(NotifyBackInStockSuite))
+}
@@ -0,0 +1,266 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/lucasmls/ecommerce/services/notifications/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type NotifySuite struct {
+	suite.Suite
+
+	recipientsRepo    *mocks.RecipientsRepository
+	notificationsRepo *mocks.NotificationsRepository
+	renderer          *mocks.Renderer
+	emailSender       *mocks.Sender
+	smsSender         *mocks.Sender
+	app               domain.Application
+}
+
+func (s *NotifySuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.recipientsRepo = &mocks.RecipientsRepository{}
+	s.notificationsRepo = &mocks.NotificationsRepository{}
+	s.renderer = &mocks.Renderer{}
+	s.emailSender = sender(domain.ChannelEmail)
+	s.smsSender = sender(domain.ChannelSMS)
+
+	s.app = MustNewApplication(
+		loggerM, tracerM, s.recipientsRepo, s.notificationsRepo, &mocks.SubscriptionsRepository{},
+		s.renderer, &mocks.Catalog{}, []domain.Sender{s.emailSender, s.smsSender},
+	)
+}
+
+// orderConfirmed is the Event of John's order 7 being paid
+func orderConfirmed() domain.Event {
+	return domain.Event{
+		Key:        "orders:7:paid",
+		CustomerID: "john",
+		Category:   domain.CategoryOrders,
+		Template:   domain.TemplateOrderConfirmed,
+		Data:       map[string]interface{}{"Number": "ORD-000007"},
+	}
+}
+
+// rendered makes the renderer render the order confirmation for a Channel, greeting John by his name
+func (s *NotifySuite) rendered(channel domain.Channel) {
+	data := map[string]interface{}{"Name": "John Doe", "Number": "ORD-000007"}
+
+	s.renderer.
+		On("Render", mock.AnythingOfType("*context.valueCtx"), domain.TemplateOrderConfirmed, channel, "en", data).
+		Return(domain.Content{Subject: pending(0, channel).Subject, Body: pending(0, channel).Body}, nil).
+		Once()
+}
+
+// created makes the repository create the pending Notification of a Channel
+func (s *NotifySuite) created(id int, channel domain.Channel) {
+	s.notificationsRepo.
+		On("Create", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(n domain.Notification) bool {
+			expected := pending(0, channel)
+			expected.Version = 0
+			expected.NextAttemptAt = n.NextAttemptAt
+			expected.CreatedAt = n.CreatedAt
+			expected.UpdatedAt = n.UpdatedAt
+
+			return n.Channel == channel && s.Equal(expected, n) && n.NextAttemptAt.Equal(n.CreatedAt)
+		})).
+		Return(pending(id, channel), nil).
+		Once()
+}
+
+func (s *NotifySuite) Test_Notify() {
+	s.Run("Should fail in case the event is invalid", func() {
+		s.SetupTest()
+
+		event := orderConfirmed()
+		event.Category = "marketing"
+
+		_, err := s.app.Notify(context.Background(), domain.Event{})
+		s.Equal(domain.ErrInvalidEvent, err)
+
+		_, err = s.app.Notify(context.Background(), event)
+		s.Equal(domain.ErrInvalidCategory, err)
+
+		s.recipientsRepo.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the recipient doesn't exist", func() {
+		s.SetupTest()
+
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "john").Return(domain.Recipient{}, domain.ErrRecipientNotFound).Once()
+
+		_, err := s.app.Notify(context.Background(), orderConfirmed())
+
+		s.Equal(domain.ErrRecipientNotFound, err)
+	})
+
+	s.Run("Should send the event through every channel the recipient asked for", func() {
+		s.SetupTest()
+
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "john").Return(textingRecipient(), nil).Once()
+		s.rendered(domain.ChannelEmail)
+		s.rendered(domain.ChannelSMS)
+		s.created(1, domain.ChannelEmail)
+		s.created(2, domain.ChannelSMS)
+		expectDelivery(s.notificationsRepo, s.emailSender, pending(1, domain.ChannelEmail), nil)
+		expectDelivery(s.notificationsRepo, s.smsSender, pending(2, domain.ChannelSMS), nil)
+
+		notifications, err := s.app.Notify(context.Background(), orderConfirmed())
+
+		s.NoError(err)
+		s.Require().Len(notifications, 2)
+
+		for i, notification := range notifications {
+			s.Equal(i+1, notification.ID)
+			s.Equal(domain.NotificationStatusSent, notification.Status)
+			s.Equal(1, notification.Attempts)
+			s.Equal(3, notification.Version)
+			s.False(notification.SentAt.IsZero())
+			s.True(notification.NextAttemptAt.IsZero())
+		}
+
+		s.emailSender.AssertExpectations(s.T())
+		s.smsSender.AssertExpectations(s.T())
+	})
+
+	s.Run("Should keep the notification pending to be retried in case it can't be sent", func() {
+		s.SetupTest()
+
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "john").Return(recipient(), nil).Once()
+		s.rendered(domain.ChannelEmail)
+		s.created(1, domain.ChannelEmail)
+		expectDelivery(s.notificationsRepo, s.emailSender, pending(1, domain.ChannelEmail), errors.New("connection refused"))
+
+		notifications, err := s.app.Notify(context.Background(), orderConfirmed())
+
+		s.NoError(err)
+		s.Require().Len(notifications, 1)
+		s.Equal(domain.NotificationStatusPending, notifications[0].Status)
+		s.Equal("connection refused", notifications[0].LastError)
+		s.Equal(1, notifications[0].Attempts)
+		s.WithinDuration(notifications[0].UpdatedAt.Add(domain.RetryBackoff), notifications[0].NextAttemptAt, time.Second)
+	})
+
+	s.Run("Should skip the channels the event was already notified through", func() {
+		s.SetupTest()
+
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "john").Return(recipient(), nil).Once()
+		s.rendered(domain.ChannelEmail)
+		s.notificationsRepo.On("Create", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Notification")).Return(domain.Notification{}, domain.ErrNotificationExists).Once()
+
+		notifications, err := s.app.Notify(context.Background(), orderConfirmed())
+
+		s.NoError(err)
+		s.Empty(notifications)
+		s.emailSender.AssertNotCalled(s.T(), "Send", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should skip the channels the template can't be sent through", func() {
+		s.SetupTest()
+
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "john").Return(textingRecipient(), nil).Once()
+		s.rendered(domain.ChannelEmail)
+		s.renderer.On("Render", mock.AnythingOfType("*context.valueCtx"), domain.TemplateOrderConfirmed, domain.ChannelSMS, "en", mock.Anything).Return(domain.Content{}, domain.ErrTemplateNotFound).Once()
+		s.created(1, domain.ChannelEmail)
+		expectDelivery(s.notificationsRepo, s.emailSender, pending(1, domain.ChannelEmail), nil)
+
+		notifications, err := s.app.Notify(context.Background(), orderConfirmed())
+
+		s.NoError(err)
+		s.Len(notifications, 1)
+		s.smsSender.AssertNotCalled(s.T(), "Send", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should not notify the categories the recipient opted out of", func() {
+		s.SetupTest()
+
+		optedOut := recipient()
+		optedOut.Preferences.Channels[domain.CategoryOrders] = []domain.Channel{}
+
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "john").Return(optedOut, nil).Once()
+
+		notifications, err := s.app.Notify(context.Background(), orderConfirmed())
+
+		s.NoError(err)
+		s.Empty(notifications)
+		s.renderer.AssertNotCalled(s.T(), "Render", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should notify the account category by email even when the recipient opted out of it", func() {
+		s.SetupTest()
+
+		optedOut := recipient()
+		optedOut.Preferences.Channels[domain.CategoryAccount] = []domain.Channel{}
+
+		event := domain.Event{
+			Key:        "customers:john:verification:abc",
+			CustomerID: "john",
+			Category:   domain.CategoryAccount,
+			Template:   domain.TemplateVerifyEmail,
+			Data:       map[string]interface{}{"Token": "abc"},
+		}
+
+		verification := domain.Notification{
+			ID:      1,
+			Channel: domain.ChannelEmail,
+			To:      "john@doe.com",
+			Subject: "Verify",
+			Body:    "abc",
+			Status:  domain.NotificationStatusPending,
+			Version: 1,
+		}
+
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "john").Return(optedOut, nil).Once()
+		s.renderer.On("Render", mock.AnythingOfType("*context.valueCtx"), domain.TemplateVerifyEmail, domain.ChannelEmail, "en", mock.Anything).Return(domain.Content{Subject: "Verify", Body: "abc"}, nil).Once()
+		s.notificationsRepo.On("Create", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(n domain.Notification) bool {
+			return n.Key == event.Key && n.Category == domain.CategoryAccount && n.Channel == domain.ChannelEmail
+		})).Return(verification, nil).Once()
+		expectDelivery(s.notificationsRepo, s.emailSender, verification, nil)
+
+		notifications, err := s.app.Notify(context.Background(), event)
+
+		s.NoError(err)
+		s.Len(notifications, 1)
+	})
+
+	s.Run("Should not send the notification another replica is delivering", func() {
+		s.SetupTest()
+
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "john").Return(recipient(), nil).Once()
+		s.rendered(domain.ChannelEmail)
+		s.created(1, domain.ChannelEmail)
+		s.notificationsRepo.On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Notification")).Return(domain.Notification{}, domain.ErrNotificationVersionConflict).Once()
+
+		notifications, err := s.app.Notify(context.Background(), orderConfirmed())
+
+		s.NoError(err)
+		s.Len(notifications, 1)
+		s.emailSender.AssertNotCalled(s.T(), "Send", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the notification can't be created", func() {
+		s.SetupTest()
+
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "john").Return(recipient(), nil).Once()
+		s.rendered(domain.ChannelEmail)
+		s.notificationsRepo.On("Create", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Notification")).Return(domain.Notification{}, errors.New("connection refused")).Once()
+
+		_, err := s.app.Notify(context.Background(), orderConfirmed())
+
+		s.EqualError(err, "connection refused")
+	})
+}
+
+func TestNotifySuite(t *testing.T) {
+	suite.Run(t, new(NotifySuite))
+}
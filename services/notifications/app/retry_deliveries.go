@@ -0,0 +1,32 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) RetryDeliveries(ctx context.Context) (int, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.RetryDeliveries")
+	defer span.End()
+
+	due, err := a.NotificationsRepository.ListDue(ctx, time.Now(), retryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, notification := range due {
+		if a.deliver(ctx, notification).Status == domain.NotificationStatusSent {
+			sent++
+		}
+	}
+
+	if len(due) > 0 {
+		a.Logger.Info("retried the due notifications", zap.Int("due", len(due)), zap.Int("sent", sent))
+	}
+
+	return sent, nil
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/lucasmls/ecommerce/services/notifications/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type RetryDeliveriesSuite struct {
+	suite.Suite
+
+	notificationsRepo *mocks.NotificationsRepository
+	emailSender       *mocks.Sender
+	app               domain.Application
+}
+
+func (s *RetryDeliveriesSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.notificationsRepo = &mocks.NotificationsRepository{}
+	s.emailSender = sender(domain.ChannelEmail)
+
+	s.app = MustNewApplication(
+		loggerM, tracerM, &mocks.RecipientsRepository{}, s.notificationsRepo, &mocks.SubscriptionsRepository{},
+		&mocks.Renderer{}, &mocks.Catalog{}, []domain.Sender{s.emailSender},
+	)
+}
+
+func (s *RetryDeliveriesSuite) Test_RetryDeliveries() {
+	s.Run("Should send the due notifications, counting the ones sent", func() {
+		s.SetupTest()
+
+		first := pending(1, domain.ChannelEmail)
+		first.Attempts = 1
+		first.Version = 3
+
+		second := pending(2, domain.ChannelEmail)
+		second.Attempts = 2
+		second.Version = 5
+
+		s.notificationsRepo.On("ListDue", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("time.Time"), retryBatchSize).Return([]domain.Notification{first, second}, nil).Once()
+		expectDelivery(s.notificationsRepo, s.emailSender, first, nil)
+		expectDelivery(s.notificationsRepo, s.emailSender, second, errors.New("connection refused"))
+
+		sent, err := s.app.RetryDeliveries(context.Background())
+
+		s.NoError(err)
+		s.Equal(1, sent)
+		s.notificationsRepo.AssertExpectations(s.T())
+		s.emailSender.AssertExpectations(s.T())
+	})
+
+	s.Run("Should give the notification up after the last attempt", func() {
+		s.SetupTest()
+
+		last := pending(1, domain.ChannelEmail)
+		last.Attempts = domain.MaxDeliveryAttempts - 1
+
+		s.notificationsRepo.On("ListDue", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("time.Time"), retryBatchSize).Return([]domain.Notification{last}, nil).Once()
+		s.notificationsRepo.On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(n domain.Notification) bool {
+			return n.Version == 1 && n.Attempts == domain.MaxDeliveryAttempts
+		})).Return(bumpVersion).Once()
+		s.emailSender.On("Send", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Message")).Return(errors.New("mailbox full")).Once()
+		s.notificationsRepo.On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(n domain.Notification) bool {
+			return n.Version == 2 && n.Status == domain.NotificationStatusFailed && n.LastError == "mailbox full" && n.NextAttemptAt.IsZero()
+		})).Return(bumpVersion).Once()
+
+		sent, err := s.app.RetryDeliveries(context.Background())
+
+		s.NoError(err)
+		s.Zero(sent)
+		s.notificationsRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Should keep the notifications of a channel without a sender pending", func() {
+		s.SetupTest()
+
+		sms := pending(1, domain.ChannelSMS)
+
+		s.notificationsRepo.On("ListDue", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("time.Time"), retryBatchSize).Return([]domain.Notification{sms}, nil).Once()
+		s.notificationsRepo.On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(n domain.Notification) bool {
+			return n.Version == 1
+		})).Return(bumpVersion).Once()
+		s.notificationsRepo.On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(n domain.Notification) bool {
+			return n.Version == 2 && n.Status == domain.NotificationStatusPending && n.LastError == domain.ErrSenderUnavailable.Error()
+		})).Return(bumpVersion).Once()
+
+		sent, err := s.app.RetryDeliveries(context.Background())
+
+		s.NoError(err)
+		s.Zero(sent)
+		s.notificationsRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Should fail in case the due notifications can't be listed", func() {
+		s.SetupTest()
+
+		s.notificationsRepo.On("ListDue", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("time.Time"), retryBatchSize).Return(nil, errors.New("connection refused")).Once()
+
+		_, err := s.app.RetryDeliveries(context.Background())
+
+		s.EqualError(err, "connection refused")
+	})
+}
+
+func TestRetryDeliveriesSuite(t *testing.T) {
+	suite.Run(t, new(RetryDeliveriesSuite))
+}
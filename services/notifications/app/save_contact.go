@@ -0,0 +1,44 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) SaveContact(ctx context.Context, contact domain.Contact) (domain.Recipient, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.SaveContact")
+	defer span.End()
+
+	a.Logger.Info("saving a contact", zap.String("customerId", contact.CustomerID))
+
+	if contact.CustomerID == "" {
+		return domain.Recipient{}, domain.ErrInvalidRecipient
+	}
+
+	recipient, err := a.RecipientsRepository.Get(ctx, contact.CustomerID)
+	switch {
+	case errors.Is(err, domain.ErrRecipientNotFound):
+		recipient = domain.Recipient{
+			CustomerID:  contact.CustomerID,
+			Preferences: domain.DefaultPreferences(),
+		}
+	case err != nil:
+		return domain.Recipient{}, err
+	}
+
+	recipient.Name = strings.TrimSpace(contact.Name)
+	recipient.Email = strings.TrimSpace(contact.Email)
+	recipient.EmailVerified = contact.EmailVerified
+	recipient.UpdatedAt = time.Now()
+
+	if err := recipient.Validate(); err != nil {
+		return domain.Recipient{}, err
+	}
+
+	return a.RecipientsRepository.Save(ctx, recipient)
+}
@@ -0,0 +1,123 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/lucasmls/ecommerce/services/notifications/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type SaveContactSuite struct {
+	suite.Suite
+
+	recipientsRepo *mocks.RecipientsRepository
+	app            domain.Application
+}
+
+func (s *SaveContactSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.recipientsRepo = &mocks.RecipientsRepository{}
+
+	s.app = MustNewApplication(
+		loggerM, tracerM, s.recipientsRepo, &mocks.NotificationsRepository{}, &mocks.SubscriptionsRepository{},
+		&mocks.Renderer{}, &mocks.Catalog{}, nil,
+	)
+}
+
+func contact() domain.Contact {
+	return domain.Contact{
+		CustomerID:    "john",
+		Name:          " John Doe ",
+		Email:         "john@doe.com",
+		EmailVerified: true,
+	}
+}
+
+func (s *SaveContactSuite) Test_SaveContact() {
+	s.Run("Should fail in case the contact isn't of a customer", func() {
+		s.SetupTest()
+
+		_, err := s.app.SaveContact(context.Background(), domain.Contact{Email: "john@doe.com"})
+
+		s.Equal(domain.ErrInvalidRecipient, err)
+		s.recipientsRepo.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the email address is invalid", func() {
+		s.SetupTest()
+
+		invalid := contact()
+		invalid.Email = "john"
+
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "john").Return(domain.Recipient{}, domain.ErrRecipientNotFound).Once()
+
+		_, err := s.app.SaveContact(context.Background(), invalid)
+
+		s.Equal(domain.ErrInvalidRecipient, err)
+		s.recipientsRepo.AssertNotCalled(s.T(), "Save", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should create the recipient with the default preferences", func() {
+		s.SetupTest()
+
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "john").Return(domain.Recipient{}, domain.ErrRecipientNotFound).Once()
+		s.recipientsRepo.
+			On("Save", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(r domain.Recipient) bool {
+				expected := recipient()
+				expected.UpdatedAt = r.UpdatedAt
+
+				return s.Equal(expected, r) && !r.UpdatedAt.IsZero()
+			})).
+			Return(recipient(), nil).
+			Once()
+
+		saved, err := s.app.SaveContact(context.Background(), contact())
+
+		s.NoError(err)
+		s.Equal(recipient(), saved)
+	})
+
+	s.Run("Should keep the preferences of the recipient", func() {
+		s.SetupTest()
+
+		stored := textingRecipient()
+		stored.Email = "old@doe.com"
+		stored.EmailVerified = false
+
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "john").Return(stored, nil).Once()
+		s.recipientsRepo.
+			On("Save", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(r domain.Recipient) bool {
+				return r.Email == "john@doe.com" && r.EmailVerified && s.Equal(textingRecipient().Preferences, r.Preferences)
+			})).
+			Return(textingRecipient(), nil).
+			Once()
+
+		saved, err := s.app.SaveContact(context.Background(), contact())
+
+		s.NoError(err)
+		s.Equal(textingRecipient(), saved)
+	})
+
+	s.Run("Should fail in case the recipient can't be fetched", func() {
+		s.SetupTest()
+
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "john").Return(domain.Recipient{}, errors.New("connection refused")).Once()
+
+		_, err := s.app.SaveContact(context.Background(), contact())
+
+		s.EqualError(err, "connection refused")
+		s.recipientsRepo.AssertNotCalled(s.T(), "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestSaveContactSuite(t *testing.T) {
+	suite.Run(t, new(SaveContactSuite))
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) SubscribeBackInStock(ctx context.Context, subscription domain.Subscription) (domain.Subscription, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.SubscribeBackInStock")
+	defer span.End()
+
+	a.Logger.Info(
+		"subscribing to a product back in stock",
+		zap.String("customerId", subscription.CustomerID),
+		zap.Int("productId", subscription.ProductID),
+	)
+
+	if err := subscription.Validate(); err != nil {
+		return domain.Subscription{}, err
+	}
+
+	if _, err := a.RecipientsRepository.Get(ctx, subscription.CustomerID); err != nil {
+		return domain.Subscription{}, err
+	}
+
+	products, err := a.Catalog.Products(ctx, []int{subscription.ProductID})
+	if err != nil {
+		return domain.Subscription{}, err
+	}
+
+	if _, ok := products[subscription.ProductID]; !ok {
+		return domain.Subscription{}, domain.ErrProductNotFound
+	}
+
+	subscription.CreatedAt = time.Now()
+
+	return a.SubscriptionsRepository.Save(ctx, subscription)
+}
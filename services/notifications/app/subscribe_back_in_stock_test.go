@@ -0,0 +1,95 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/lucasmls/ecommerce/services/notifications/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type SubscribeBackInStockSuite struct {
+	suite.Suite
+
+	recipientsRepo    *mocks.RecipientsRepository
+	subscriptionsRepo *mocks.SubscriptionsRepository
+	catalog           *mocks.Catalog
+	app               domain.Application
+}
+
+func (s *SubscribeBackInStockSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.recipientsRepo = &mocks.RecipientsRepository{}
+	s.subscriptionsRepo = &mocks.SubscriptionsRepository{}
+	s.catalog = &mocks.Catalog{}
+
+	s.app = MustNewApplication(
+		loggerM, tracerM, s.recipientsRepo, &mocks.NotificationsRepository{}, s.subscriptionsRepo,
+		&mocks.Renderer{}, s.catalog, nil,
+	)
+}
+
+func (s *SubscribeBackInStockSuite) Test_SubscribeBackInStock() {
+	s.Run("Should fail in case the subscription is invalid", func() {
+		s.SetupTest()
+
+		for _, subscription := range []domain.Subscription{{ProductID: 1}, {CustomerID: "john"}} {
+			_, err := s.app.SubscribeBackInStock(context.Background(), subscription)
+
+			s.Equal(domain.ErrInvalidSubscription, err)
+		}
+	})
+
+	s.Run("Should fail in case the recipient doesn't exist", func() {
+		s.SetupTest()
+
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "john").Return(domain.Recipient{}, domain.ErrRecipientNotFound).Once()
+
+		_, err := s.app.SubscribeBackInStock(context.Background(), domain.Subscription{CustomerID: "john", ProductID: 1})
+
+		s.Equal(domain.ErrRecipientNotFound, err)
+	})
+
+	s.Run("Should fail in case the product doesn't exist", func() {
+		s.SetupTest()
+
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "john").Return(recipient(), nil).Once()
+		s.catalog.On("Products", mock.AnythingOfType("*context.valueCtx"), []int{1}).Return(map[int]domain.ProductSnapshot{}, nil).Once()
+
+		_, err := s.app.SubscribeBackInStock(context.Background(), domain.Subscription{CustomerID: "john", ProductID: 1})
+
+		s.Equal(domain.ErrProductNotFound, err)
+		s.subscriptionsRepo.AssertNotCalled(s.T(), "Save", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should save the subscription", func() {
+		s.SetupTest()
+
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "john").Return(recipient(), nil).Once()
+		s.catalog.On("Products", mock.AnythingOfType("*context.valueCtx"), []int{1}).Return(map[int]domain.ProductSnapshot{1: {ID: 1, Name: "Keyboard"}}, nil).Once()
+		s.subscriptionsRepo.
+			On("Save", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(subscription domain.Subscription) bool {
+				return subscription.CustomerID == "john" && subscription.ProductID == 1 && !subscription.CreatedAt.IsZero()
+			})).
+			Return(func(_ context.Context, subscription domain.Subscription) (domain.Subscription, error) {
+				return subscription, nil
+			}).
+			Once()
+
+		subscription, err := s.app.SubscribeBackInStock(context.Background(), domain.Subscription{CustomerID: "john", ProductID: 1})
+
+		s.NoError(err)
+		s.Equal("john", subscription.CustomerID)
+		s.Equal(1, subscription.ProductID)
+	})
+}
+
+func TestSubscribeBackInStockSuite(t *testing.T) {
+	suite.Run(t, new(SubscribeBackInStockSuite))
+}
@@ -0,0 +1,25 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) UnsubscribeBackInStock(ctx context.Context, subscription domain.Subscription) error {
+	ctx, span := a.Tracer.Start(ctx, "app.UnsubscribeBackInStock")
+	defer span.End()
+
+	a.Logger.Info(
+		"unsubscribing from a product back in stock",
+		zap.String("customerId", subscription.CustomerID),
+		zap.Int("productId", subscription.ProductID),
+	)
+
+	if err := subscription.Validate(); err != nil {
+		return err
+	}
+
+	return a.SubscriptionsRepository.Delete(ctx, subscription)
+}
@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/lucasmls/ecommerce/services/notifications/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type UnsubscribeBackInStockSuite struct {
+	suite.Suite
+
+	subscriptionsRepo *mocks.SubscriptionsRepository
+	app               domain.Application
+}
+
+func (s *UnsubscribeBackInStockSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.subscriptionsRepo = &mocks.SubscriptionsRepository{}
+
+	s.app = MustNewApplication(
+		loggerM, tracerM, &mocks.RecipientsRepository{}, &mocks.NotificationsRepository{}, s.subscriptionsRepo,
+		&mocks.Renderer{}, &mocks.Catalog{}, nil,
+	)
+}
+
+func (s *UnsubscribeBackInStockSuite) Test_UnsubscribeBackInStock() {
+	s.Run("Should fail in case the subscription is invalid", func() {
+		s.SetupTest()
+
+		err := s.app.UnsubscribeBackInStock(context.Background(), domain.Subscription{CustomerID: "john"})
+
+		s.Equal(domain.ErrInvalidSubscription, err)
+		s.subscriptionsRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should remove the subscription", func() {
+		s.SetupTest()
+
+		subscription := domain.Subscription{CustomerID: "john", ProductID: 1}
+		s.subscriptionsRepo.On("Delete", mock.AnythingOfType("*context.valueCtx"), subscription).Return(nil).Once()
+
+		err := s.app.UnsubscribeBackInStock(context.Background(), subscription)
+
+		s.NoError(err)
+		s.subscriptionsRepo.AssertExpectations(s.T())
+	})
+}
+
+func TestUnsubscribeBackInStockSuite(t *testing.T) {
+	suite.Run(t, new(UnsubscribeBackInStockSuite))
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) UpdatePreferences(
+	ctx context.Context,
+	customerID string,
+	preferences domain.Preferences,
+) (domain.Preferences, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.UpdatePreferences")
+	defer span.End()
+
+	a.Logger.Info("updating the preferences of a recipient", zap.String("customerId", customerID))
+
+	if customerID == "" {
+		return domain.Preferences{}, domain.ErrInvalidRecipient
+	}
+
+	preferences = preferences.Normalize()
+	if err := preferences.Validate(); err != nil {
+		return domain.Preferences{}, err
+	}
+
+	recipient, err := a.RecipientsRepository.Get(ctx, customerID)
+	if err != nil {
+		return domain.Preferences{}, err
+	}
+
+	recipient.Preferences = preferences
+	recipient.UpdatedAt = time.Now()
+
+	saved, err := a.RecipientsRepository.Save(ctx, recipient)
+	if err != nil {
+		return domain.Preferences{}, err
+	}
+
+	return saved.Preferences, nil
+}
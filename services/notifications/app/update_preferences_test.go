@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/lucasmls/ecommerce/services/notifications/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type UpdatePreferencesSuite struct {
+	suite.Suite
+
+	recipientsRepo *mocks.RecipientsRepository
+	app            domain.Application
+}
+
+func (s *UpdatePreferencesSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.recipientsRepo = &mocks.RecipientsRepository{}
+
+	s.app = MustNewApplication(
+		loggerM, tracerM, s.recipientsRepo, &mocks.NotificationsRepository{}, &mocks.SubscriptionsRepository{},
+		&mocks.Renderer{}, &mocks.Catalog{}, nil,
+	)
+}
+
+func (s *UpdatePreferencesSuite) Test_UpdatePreferences() {
+	s.Run("Should fail in case the preferences are invalid", func() {
+		s.SetupTest()
+
+		testCases := []struct {
+			preferences domain.Preferences
+			err         error
+		}{
+			{preferences: domain.Preferences{Locale: "portuguese"}, err: domain.ErrInvalidLocale},
+			{preferences: domain.Preferences{Phone: "11 98765-4321"}, err: domain.ErrInvalidPhone},
+			{
+				preferences: domain.Preferences{Channels: map[domain.Category][]domain.Channel{"marketing": {domain.ChannelEmail}}},
+				err:         domain.ErrInvalidCategory,
+			},
+			{
+				preferences: domain.Preferences{Channels: map[domain.Category][]domain.Channel{domain.CategoryOrders: {"pigeon"}}},
+				err:         domain.ErrInvalidChannel,
+			},
+			{
+				preferences: domain.Preferences{Channels: map[domain.Category][]domain.Channel{domain.CategoryOrders: {domain.ChannelSMS}}},
+				err:         domain.ErrPhoneRequired,
+			},
+		}
+
+		for _, tc := range testCases {
+			_, err := s.app.UpdatePreferences(context.Background(), "john", tc.preferences)
+
+			s.Equal(tc.err, err)
+		}
+
+		s.recipientsRepo.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the recipient doesn't exist", func() {
+		s.SetupTest()
+
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "john").Return(domain.Recipient{}, domain.ErrRecipientNotFound).Once()
+
+		_, err := s.app.UpdatePreferences(context.Background(), "john", domain.Preferences{})
+
+		s.Equal(domain.ErrRecipientNotFound, err)
+	})
+
+	s.Run("Should replace the preferences, the account category being always sent by email", func() {
+		s.SetupTest()
+
+		expected := domain.Preferences{
+			Locale: "pt-BR",
+			Phone:  "+5511987654321",
+			Channels: map[domain.Category][]domain.Channel{
+				domain.CategoryAccount:     {domain.ChannelEmail},
+				domain.CategoryOrders:      {domain.ChannelSMS},
+				domain.CategoryShipping:    {},
+				domain.CategoryBackInStock: {domain.ChannelEmail},
+			},
+		}
+
+		s.recipientsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), "john").Return(recipient(), nil).Once()
+		s.recipientsRepo.
+			On("Save", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(r domain.Recipient) bool {
+				return r.Email == "john@doe.com" && s.Equal(expected, r.Preferences)
+			})).
+			Return(func(_ context.Context, r domain.Recipient) (domain.Recipient, error) { return r, nil }).
+			Once()
+
+		preferences, err := s.app.UpdatePreferences(context.Background(), "john", domain.Preferences{
+			Locale: " pt-BR ",
+			Phone:  "+5511987654321",
+			Channels: map[domain.Category][]domain.Channel{
+				domain.CategoryAccount:  {},
+				domain.CategoryOrders:   {domain.ChannelSMS, domain.ChannelSMS},
+				domain.CategoryShipping: {},
+			},
+		})
+
+		s.NoError(err)
+		s.Equal(expected, preferences)
+	})
+}
+
+func TestUpdatePreferencesSuite(t *testing.T) {
+	suite.Run(t, new(UpdatePreferencesSuite))
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	customersPb "github.com/lucasmls/ecommerce/services/customers/ports/rmq/proto"
+	inventoryPb "github.com/lucasmls/ecommerce/services/inventory/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/services/notifications/adapters/catalog"
+	"github.com/lucasmls/ecommerce/services/notifications/adapters/renderers"
+	"github.com/lucasmls/ecommerce/services/notifications/adapters/repositories"
+	"github.com/lucasmls/ecommerce/services/notifications/adapters/senders"
+	"github.com/lucasmls/ecommerce/services/notifications/app"
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	resolvers "github.com/lucasmls/ecommerce/services/notifications/ports/grpc"
+	protog "github.com/lucasmls/ecommerce/services/notifications/ports/grpc/proto"
+	consumers "github.com/lucasmls/ecommerce/services/notifications/ports/rmq"
+	ordersPb "github.com/lucasmls/ecommerce/services/orders/ports/rmq/proto"
+	productsPb "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+	shippingPb "github.com/lucasmls/ecommerce/services/shipping/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/shared/env"
+	"github.com/lucasmls/ecommerce/shared/grpc"
+	"github.com/lucasmls/ecommerce/shared/rmq"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otel "go.opentelemetry.io/otel"
+	otelJaegerExporter "go.opentelemetry.io/otel/exporters/jaeger"
+	otelPropagation "go.opentelemetry.io/otel/propagation"
+	otelSdkResource "go.opentelemetry.io/otel/sdk/resource"
+	otelTraceSdk "go.opentelemetry.io/otel/sdk/trace"
+	otelSemconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.uber.org/zap"
+	gGRPC "google.golang.org/grpc"
+)
+
+// defaultRetryInterval is how often the failed deliveries are retried when RETRY_INTERVAL isn't set
+const defaultRetryInterval = time.Minute
+
+type ApplicationConfig struct {
+	ServiceName              string `mapstructure:"SERVICE_NAME"`
+	JaegerEndpoint           string `mapstructure:"JAEGER_ENDPOINT"`
+	GrpcServerPort           int    `mapstructure:"GRPC_SERVER_PORT"`
+	MetricsPort              int    `mapstructure:"METRICS_PORT"`
+	PostgresConnectionString string `mapstructure:"PG_CONNECTION_STRING"`
+	// RabbitMQURL is where the customer, order, shipment and stock events come from
+	RabbitMQURL string `mapstructure:"RABBITMQ_URL"`
+	// ProductsServiceAddress is where the names of the products back in stock come from, e.g. localhost:8081
+	ProductsServiceAddress string `mapstructure:"PRODUCTS_SERVICE_ADDRESS"`
+	// TemplatesDir holds a directory of templates per locale, e.g. templates/en/order_confirmed.email.tmpl
+	TemplatesDir string `mapstructure:"TEMPLATES_DIR"`
+	// SmtpAddress is the SMTP server the emails are sent through, e.g. localhost:1025. The emails are
+	// written to the NotificationsFile instead when it's empty.
+	SmtpAddress  string `mapstructure:"SMTP_ADDRESS"`
+	SmtpFrom     string `mapstructure:"SMTP_FROM"`
+	SmtpUsername string `mapstructure:"SMTP_USERNAME"`
+	SmtpPassword string `mapstructure:"SMTP_PASSWORD"`
+	// NotificationsFile is where the notifications without a provider are written to, one JSON per line,
+	// the standard output when it's empty
+	NotificationsFile string `mapstructure:"NOTIFICATIONS_FILE"`
+	// RetryInterval is how often the failed deliveries are retried, e.g. 1m
+	RetryInterval time.Duration `mapstructure:"RETRY_INTERVAL"`
+}
+
+func main() {
+	ctx := context.Background()
+
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	config, err := env.LoadConfig[ApplicationConfig]()
+	if err != nil {
+		logger.Fatal("failed to load application config", zap.Error(err))
+	}
+
+	jaegerExporter, err := otelJaegerExporter.New(
+		otelJaegerExporter.WithCollectorEndpoint(
+			otelJaegerExporter.WithEndpoint(config.JaegerEndpoint),
+		),
+	)
+	if err != nil {
+		logger.Fatal("failed to instantiate Jaeger exporter", zap.Error(err))
+	}
+
+	tracingProvider := otelTraceSdk.NewTracerProvider(
+		otelTraceSdk.WithBatcher(jaegerExporter),
+		otelTraceSdk.WithSampler(otelTraceSdk.AlwaysSample()),
+		otelTraceSdk.WithResource(otelSdkResource.NewWithAttributes(
+			otelSemconv.SchemaURL,
+			otelSemconv.ServiceNameKey.String(config.ServiceName),
+		)),
+	)
+
+	defer func() {
+		_ = tracingProvider.Shutdown(ctx)
+	}()
+
+	otel.SetTracerProvider(tracingProvider)
+	otel.SetTextMapPropagator(otelPropagation.NewCompositeTextMapPropagator(
+		otelPropagation.TraceContext{},
+		otelPropagation.Baggage{},
+	))
+
+	tracer := otel.Tracer(config.ServiceName)
+
+	productsServiceGRPCClient := grpc.MustNewClient(grpc.ClientInput{
+		Address: config.ProductsServiceAddress,
+		Logger:  logger,
+	})
+
+	productsServiceConn := productsServiceGRPCClient.MustConnect(ctx)
+
+	productsCatalog := catalog.MustNewGrpcProductsCatalog(logger, tracer, productsPb.NewProductsServiceClient(productsServiceConn))
+
+	templateRenderer := renderers.MustNewTemplateRenderer(logger, tracer, os.DirFS(config.TemplatesDir))
+
+	var notificationsFile io.Writer = os.Stdout
+	if config.NotificationsFile != "" {
+		file, err := os.OpenFile(config.NotificationsFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			logger.Fatal("failed to open the notifications file", zap.Error(err))
+		}
+		defer file.Close()
+
+		notificationsFile = file
+	}
+
+	var emailSender domain.Sender = senders.MustNewFileSender(logger, tracer, domain.ChannelEmail, notificationsFile)
+	if config.SmtpAddress != "" {
+		emailSender = senders.MustNewSmtpSender(senders.SmtpSenderInput{
+			Logger:   logger,
+			Tracer:   tracer,
+			Address:  config.SmtpAddress,
+			From:     config.SmtpFrom,
+			Username: config.SmtpUsername,
+			Password: config.SmtpPassword,
+		})
+	}
+
+	// there's no SMS provider yet, the texts are only written to the notifications file
+	notificationSenders := []domain.Sender{
+		emailSender,
+		senders.MustNewFileSender(logger, tracer, domain.ChannelSMS, notificationsFile),
+	}
+
+	inMemoryRecipientsRepository := repositories.NewInMemoryRecipientsRepository(logger, tracer)
+	// postgresRecipientsRepository := repositories.MustNewPgRecipientsRepository(config.PostgresConnectionString)
+	inMemoryNotificationsRepository := repositories.NewInMemoryNotificationsRepository(logger, tracer)
+	// postgresNotificationsRepository := repositories.MustNewPgNotificationsRepository(config.PostgresConnectionString)
+	inMemorySubscriptionsRepository := repositories.NewInMemorySubscriptionsRepository(logger, tracer)
+	// postgresSubscriptionsRepository := repositories.MustNewPgSubscriptionsRepository(config.PostgresConnectionString)
+
+	application := app.MustNewApplication(
+		logger,
+		tracer,
+		inMemoryRecipientsRepository,
+		inMemoryNotificationsRepository,
+		inMemorySubscriptionsRepository,
+		templateRenderer,
+		productsCatalog,
+		notificationSenders,
+	)
+	notificationsResolver := resolvers.MustNewNotificationsResolver(logger, tracer, application)
+
+	rmqClient := rmq.MustNewClient(rmq.ClientInput{
+		URL:    config.RabbitMQURL,
+		Logger: logger,
+	}).MustConnect()
+	defer rmqClient.Close()
+
+	customersConsumer := consumers.MustNewCustomersConsumer(logger, tracer, application)
+	ordersConsumer := consumers.MustNewOrdersConsumer(logger, tracer, application)
+	shippingConsumer := consumers.MustNewShippingConsumer(logger, tracer, application)
+	inventoryConsumer := consumers.MustNewInventoryConsumer(logger, tracer, application)
+
+	consume(ctx, logger, rmqClient, customersPb.Exchange, customersPb.EventRoutingKey, consumers.CustomerEventTypes, customersConsumer.Handle)
+	consume(ctx, logger, rmqClient, ordersPb.Exchange, ordersPb.EventRoutingKey, consumers.OrderStatuses, ordersConsumer.Handle)
+	consume(ctx, logger, rmqClient, shippingPb.Exchange, shippingPb.EventRoutingKey, consumers.ShipmentStatuses, shippingConsumer.Handle)
+	consume(ctx, logger, rmqClient, inventoryPb.Exchange, inventoryPb.EventRoutingKey, consumers.StockEventTypes, inventoryConsumer.Handle)
+
+	server := grpc.MustNewServer(grpc.ServerInput{
+		Port:   config.GrpcServerPort,
+		Logger: logger,
+		Registrator: func(server gGRPC.ServiceRegistrar) {
+			protog.RegisterNotificationsServiceServer(server, notificationsResolver)
+		},
+	})
+
+	go func() {
+		port := fmt.Sprintf(":%d", config.MetricsPort)
+
+		http.Handle("/metrics", promhttp.Handler())
+		_ = http.ListenAndServe(port, nil)
+	}()
+
+	retryInterval := config.RetryInterval
+	if retryInterval <= 0 {
+		retryInterval = defaultRetryInterval
+	}
+
+	go retryDeliveries(ctx, logger, application, retryInterval)
+
+	if err := server.Run(ctx); err != nil {
+		logger.Fatal("failed to run gRPC server", zap.Error(err))
+	}
+}
+
+// consume handles the events of an exchange routed by the given keys, through a queue of the Notifications
+// named after the exchange, e.g. notifications.orders
+func consume(
+	ctx context.Context,
+	logger *zap.Logger,
+	rmqClient *rmq.Client,
+	exchange string,
+	routingKey func(string) string,
+	types []string,
+	handler rmq.Handler,
+) {
+	routingKeys := make([]string, 0, len(types))
+	for _, t := range types {
+		routingKeys = append(routingKeys, routingKey(t))
+	}
+
+	go func() {
+		err := rmqClient.Consume(ctx, rmq.ConsumerInput{
+			Exchange:    exchange,
+			Queue:       "notifications." + exchange,
+			RoutingKeys: routingKeys,
+			Handler:     handler,
+		})
+		if err != nil {
+			logger.Fatal("failed to consume the events", zap.String("exchange", exchange), zap.Error(err))
+		}
+	}()
+}
+
+// retryDeliveries sends again the Notifications that failed to be delivered on every tick of the interval.
+// Running it on many replicas is safe, each Notification is only attempted by one of them at a time.
+func retryDeliveries(ctx context.Context, logger *zap.Logger, application domain.Application, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := application.RetryDeliveries(ctx); err != nil {
+				logger.Error("failed to retry the deliveries", zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package domain
+
+// Channel is how a Notification reaches the customer.
+type Channel string
+
+const (
+	ChannelEmail Channel = "email"
+	ChannelSMS   Channel = "sms"
+)
+
+// Validate checks that the Channel is one of the known ones
+func (c Channel) Validate() error {
+	switch c {
+	case ChannelEmail, ChannelSMS:
+		return nil
+	default:
+		return ErrInvalidChannel
+	}
+}
+
+// Category groups the Notifications the customers may opt in or out of together.
+type Category string
+
+const (
+	// CategoryAccount is about the account of the customer, e.g. verifying its email address.
+	// It can't be opted out of, and it's only sent by email.
+	CategoryAccount Category = "account"
+	// CategoryOrders is about the progress of the Orders of the customer, e.g. its confirmation
+	CategoryOrders Category = "orders"
+	// CategoryShipping is about the progress of the Shipments of the customer, e.g. its delivery
+	CategoryShipping Category = "shipping"
+	// CategoryBackInStock is about the Products the customer asked to be told about once they can be bought again
+	CategoryBackInStock Category = "back_in_stock"
+)
+
+// Categories lists every Category, in the order they're shown to the customers
+var Categories = []Category{CategoryAccount, CategoryOrders, CategoryShipping, CategoryBackInStock}
+
+// Validate checks that the Category is one of the known ones
+func (c Category) Validate() error {
+	for _, category := range Categories {
+		if c == category {
+			return nil
+		}
+	}
+
+	return ErrInvalidCategory
+}
+
+// Mandatory reports whether the Notifications of the Category are sent regardless of the Preferences of the customer
+func (c Category) Mandatory() bool {
+	return c == CategoryAccount
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Application defines boundary interfaces of the application
+// It should be called by the ports
+type Application interface {
+	// SaveContact stores the contact details of a customer, creating its Recipient with the DefaultPreferences
+	// the first time, and keeping its Preferences otherwise.
+	SaveContact(context.Context, Contact) (Recipient, error)
+
+	// DeleteRecipient forgets about a customer, along with its Subscriptions. Deleting a missing Recipient succeeds.
+	DeleteRecipient(context.Context, string) error
+
+	// GetPreferences fetches the Preferences of a Recipient
+	GetPreferences(context.Context, string) (Preferences, error)
+
+	// UpdatePreferences replaces the Preferences of a Recipient, the missing Categories are sent by email
+	UpdatePreferences(context.Context, string, Preferences) (Preferences, error)
+
+	// Notify renders an Event for its Recipient and sends it through every Channel its Preferences ask for.
+	// A Notification that can't be sent is retried later, see RetryDeliveries. The Channels the Event was
+	// already notified through are skipped, so the Notifications listed are the ones created by this call only.
+	Notify(context.Context, Event) ([]Notification, error)
+
+	// SubscribeBackInStock asks for a Recipient to be told once a Product is back in stock, subscribing twice succeeds
+	SubscribeBackInStock(context.Context, Subscription) (Subscription, error)
+
+	// UnsubscribeBackInStock removes a Subscription, removing a missing one succeeds
+	UnsubscribeBackInStock(context.Context, Subscription) error
+
+	// ListSubscriptions lists the Subscriptions of a Recipient, most recent first
+	ListSubscriptions(context.Context, string) ([]Subscription, error)
+
+	// NotifyBackInStock notifies the Subscriptions of a Product back in stock, removing each one once it's notified
+	NotifyBackInStock(context.Context, BackInStock) ([]Notification, error)
+
+	// ListNotifications lists the Notifications matching the filter, most recently created first
+	ListNotifications(context.Context, NotificationFilter) ([]Notification, error)
+
+	// RetryDeliveries sends the pending Notifications whose next delivery attempt is due, returning how many were sent.
+	// Running it on many replicas is safe, each Notification is only attempted by one of them at a time.
+	RetryDeliveries(context.Context) (int, error)
+}
+
+type RecipientsRepository interface {
+	// Save creates or replaces a Recipient in a data storage.
+	Save(context.Context, Recipient) (Recipient, error)
+
+	// Get fetches a single Recipient from a data storage.
+	Get(context.Context, string) (Recipient, error)
+
+	// Delete removes a Recipient from a data storage, failing with ErrRecipientNotFound when it's missing.
+	Delete(context.Context, string) error
+}
+
+type NotificationsRepository interface {
+	// Create stores a new Notification in a data storage, setting its ID and its first Version.
+	// It fails with ErrNotificationExists when there's another Notification of the same Key and Channel.
+	Create(context.Context, Notification) (Notification, error)
+
+	// Get fetches a single Notification from a data storage.
+	Get(context.Context, int) (Notification, error)
+
+	// List lists the Notifications matching the filter from a data storage, most recently created first, ties broken by ID.
+	List(context.Context, NotificationFilter) ([]Notification, error)
+
+	// ListDue lists up to limit pending Notifications whose NextAttemptAt isn't after the given time, the oldest attempts first.
+	ListDue(context.Context, time.Time, int) ([]Notification, error)
+
+	// Update replaces a Notification in a data storage, returning it with its new Version.
+	// It's only replaced while the stored Version is still the one it was read with, failing with
+	// ErrNotificationVersionConflict otherwise.
+	Update(context.Context, Notification) (Notification, error)
+}
+
+type SubscriptionsRepository interface {
+	// Save stores a Subscription in a data storage, keeping the stored one when it already exists.
+	Save(context.Context, Subscription) (Subscription, error)
+
+	// Delete removes a Subscription from a data storage, removing a missing one succeeds.
+	Delete(context.Context, Subscription) error
+
+	// List lists the Subscriptions matching the filter from a data storage, most recent first.
+	List(context.Context, SubscriptionFilter) ([]Subscription, error)
+}
+
+// Renderer renders the templates of the Notifications
+type Renderer interface {
+	// Render renders a template for a Channel in a locale, falling back to the language of the locale and then
+	// to the DefaultLocale. It fails with ErrTemplateNotFound when the template can't be sent through the Channel.
+	Render(ctx context.Context, template string, channel Channel, locale string, data map[string]interface{}) (Content, error)
+}
+
+// Sender delivers the Messages of a Channel, e.g. through an SMTP server
+type Sender interface {
+	// Channel tells which Channel the Sender delivers
+	Channel() Channel
+
+	// Send delivers a Message, it may be called again for the same Message when it fails
+	Send(context.Context, Message) error
+}
+
+type Catalog interface {
+	// Products fetches the name of the given Products, keyed by their ID.
+	// The missing Products are left out rather than failing.
+	Products(context.Context, []int) (map[int]ProductSnapshot, error)
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidEvent = errors.New("invalid-event")
+
+// The templates the Events are rendered with
+const (
+	TemplateVerifyEmail            = "verify_email"
+	TemplateOrderConfirmed         = "order_confirmed"
+	TemplateOrderCancelled         = "order_cancelled"
+	TemplateShipmentShipped        = "shipment_shipped"
+	TemplateShipmentOutForDelivery = "shipment_out_for_delivery"
+	TemplateShipmentDelivered      = "shipment_delivered"
+	TemplateBackInStock            = "back_in_stock"
+)
+
+// Event is something that happened a customer should be told about, e.g. an Order was paid.
+type Event struct {
+	// Key identifies the Event, e.g. orders:7:paid. The Events are delivered more than once, the ones with the
+	// same Key are only notified once.
+	Key        string
+	CustomerID string
+	Category   Category
+	Template   string
+	// Data is handed over to the template, e.g. the number of the Order
+	Data map[string]interface{}
+}
+
+// Validate checks that the Event can be notified
+func (e Event) Validate() error {
+	if e.Key == "" || e.CustomerID == "" || e.Template == "" {
+		return ErrInvalidEvent
+	}
+
+	return e.Category.Validate()
+}
+
+// BackInStock tells some units of a Product can be bought again after none of them could
+type BackInStock struct {
+	ProductID  int
+	Available  int
+	OccurredAt time.Time
+}
@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// MaxDeliveryAttempts is how many times a Notification is sent before it's given up on
+	MaxDeliveryAttempts = 5
+	// RetryBackoff is how long the second delivery attempt of a Notification waits for, it doubles on each attempt after it
+	RetryBackoff = 30 * time.Second
+	// MaxErrorLength is the maximum amount of characters of the LastError of a Notification
+	MaxErrorLength = 500
+	// DefaultNotificationsLimit is how many Notifications are listed when the limit isn't set
+	DefaultNotificationsLimit = 20
+	// MaxNotificationsLimit is the maximum amount of Notifications listed at once
+	MaxNotificationsLimit = 100
+)
+
+var (
+	ErrNotificationNotFound        = errors.New("notification-not-found")
+	ErrNotificationVersionConflict = errors.New("notification-version-conflict")
+	ErrInvalidNotificationFilter   = errors.New("invalid-notification-filter")
+	ErrInvalidNotificationStatus   = errors.New("invalid-notification-status")
+	// ErrNotificationExists tells the Event was already notified through the Channel
+	ErrNotificationExists = errors.New("notification-exists")
+	// ErrTemplateNotFound tells a template can't be sent through a Channel, in any locale
+	ErrTemplateNotFound = errors.New("template-not-found")
+	// ErrSenderUnavailable tells there's no Sender set up for a Channel
+	ErrSenderUnavailable = errors.New("sender-unavailable")
+)
+
+// NotificationStatus is where a Notification stands on its way to the Recipient.
+type NotificationStatus string
+
+const (
+	// NotificationStatusPending is the status of a Notification still being delivered, see Notification.NextAttemptAt
+	NotificationStatusPending NotificationStatus = "pending"
+	// NotificationStatusSent is the final status of a Notification handed over to its Channel
+	NotificationStatusSent NotificationStatus = "sent"
+	// NotificationStatusFailed is the final status of a Notification that couldn't be sent in MaxDeliveryAttempts
+	NotificationStatusFailed NotificationStatus = "failed"
+)
+
+// Validate checks that the NotificationStatus is one of the known ones
+func (s NotificationStatus) Validate() error {
+	switch s {
+	case NotificationStatusPending, NotificationStatusSent, NotificationStatusFailed:
+		return nil
+	default:
+		return ErrInvalidNotificationStatus
+	}
+}
+
+// Notification is a message rendered for a Recipient, sent through a single Channel.
+type Notification struct {
+	ID int
+	// Key identifies the Event the Notification was rendered for, there's a single Notification of each Key on each Channel
+	Key        string
+	CustomerID string
+	Category   Category
+	Template   string
+	Channel    Channel
+	Locale     string
+	// To is the address the Notification is sent to, e.g. the email address of the Recipient
+	To      string
+	Subject string
+	Body    string
+	Status  NotificationStatus
+	// Attempts counts the delivery attempts, including the one in progress
+	Attempts  int
+	LastError string
+	// NextAttemptAt is when a pending Notification is sent again, in case its delivery attempt in progress doesn't finish before it
+	NextAttemptAt time.Time
+	SentAt        time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	// Version is bumped on every update, so concurrent delivery attempts can't send the Notification twice
+	Version int
+}
+
+// RetryDelay is how long a Notification waits before its next delivery attempt, after the given amount of them
+func RetryDelay(attempts int) time.Duration {
+	if attempts <= 1 {
+		return RetryBackoff
+	}
+
+	return RetryBackoff << (attempts - 1)
+}
+
+// Due reports whether a Notification should be sent at a time
+func (n Notification) Due(at time.Time) bool {
+	return n.Status == NotificationStatusPending && !n.NextAttemptAt.After(at)
+}
+
+// Attempt starts a delivery attempt, scheduling the next one in case this one never finishes
+func (n *Notification) Attempt(at time.Time) {
+	n.Attempts++
+	n.NextAttemptAt = at.Add(RetryDelay(n.Attempts))
+	n.UpdatedAt = at
+}
+
+// Delivered finishes the delivery attempt in progress successfully
+func (n *Notification) Delivered(at time.Time) {
+	n.Status = NotificationStatusSent
+	n.LastError = ""
+	n.NextAttemptAt = time.Time{}
+	n.SentAt = at
+	n.UpdatedAt = at
+}
+
+// DeliveryFailed finishes the delivery attempt in progress with an error, giving the Notification up after MaxDeliveryAttempts
+func (n *Notification) DeliveryFailed(err error, at time.Time) {
+	n.LastError = err.Error()
+	if len(n.LastError) > MaxErrorLength {
+		n.LastError = n.LastError[:MaxErrorLength]
+	}
+
+	if n.Attempts >= MaxDeliveryAttempts {
+		n.Status = NotificationStatusFailed
+		n.NextAttemptAt = time.Time{}
+	}
+
+	n.UpdatedAt = at
+}
+
+// NotificationFilter narrows down the Notifications listed
+type NotificationFilter struct {
+	CustomerID string
+	Status     NotificationStatus
+	// Limit is the maximum amount of Notifications listed, up to MaxNotificationsLimit
+	Limit int
+}
+
+// Message is what a Sender hands over to its Channel
+type Message struct {
+	// ID identifies the Notification the Message was rendered for, e.g. so the Channel can drop the repeated ones
+	ID      int
+	To      string
+	Subject string
+	Body    string
+}
+
+// Content is a template rendered for a Recipient, the Subject is empty on the Channels without one
+type Content struct {
+	Subject string
+	Body    string
+}
@@ -0,0 +1,187 @@
+package domain
+
+import (
+	"errors"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultLocale is the locale of the Recipients who didn't pick one, and the one the templates fall back to
+	DefaultLocale = "en"
+	// MaxNameLength is the maximum amount of characters of the name of a Recipient
+	MaxNameLength = 200
+)
+
+var (
+	ErrRecipientNotFound = errors.New("recipient-not-found")
+	ErrInvalidRecipient  = errors.New("invalid-recipient")
+	ErrInvalidLocale     = errors.New("invalid-locale")
+	ErrInvalidPhone      = errors.New("invalid-phone")
+	ErrInvalidChannel    = errors.New("invalid-channel")
+	ErrInvalidCategory   = errors.New("invalid-category")
+	// ErrPhoneRequired tells a Category was opted in by SMS without a phone number to send it to
+	ErrPhoneRequired = errors.New("phone-required")
+)
+
+// localePattern matches the BCP 47 language tags of a language with an optional region, as in en or pt-BR
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+
+// phonePattern matches the E.164 phone numbers, as in +5511987654321
+var phonePattern = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+
+// Preferences tell how a Recipient wants to be notified.
+type Preferences struct {
+	// Locale is the language the Notifications are written in, as in pt-BR
+	Locale string
+	// Phone is where the SMS are sent to, as an E.164 number, e.g. +5511987654321
+	Phone string
+	// Channels are the Channels each Category is sent through, a Category without any is opted out of.
+	// The missing Categories are sent by email.
+	Channels map[Category][]Channel
+}
+
+// DefaultPreferences are the Preferences of the Recipients who never changed them: every Category is sent by email, in English.
+func DefaultPreferences() Preferences {
+	channels := make(map[Category][]Channel, len(Categories))
+	for _, category := range Categories {
+		channels[category] = []Channel{ChannelEmail}
+	}
+
+	return Preferences{
+		Locale:   DefaultLocale,
+		Channels: channels,
+	}
+}
+
+// ChannelsFor lists the Channels the Notifications of a Category are sent through.
+// The mandatory Categories are always sent by email, whatever the Preferences say.
+func (p Preferences) ChannelsFor(category Category) []Channel {
+	if category.Mandatory() {
+		return []Channel{ChannelEmail}
+	}
+
+	channels, ok := p.Channels[category]
+	if !ok {
+		return []Channel{ChannelEmail}
+	}
+
+	return channels
+}
+
+// Normalize trims the Preferences, fills the missing Categories with the defaults and drops the repeated Channels
+func (p Preferences) Normalize() Preferences {
+	normalized := Preferences{
+		Locale:   strings.TrimSpace(p.Locale),
+		Phone:    strings.TrimSpace(p.Phone),
+		Channels: make(map[Category][]Channel, len(Categories)),
+	}
+
+	if normalized.Locale == "" {
+		normalized.Locale = DefaultLocale
+	}
+
+	for category, channels := range p.Channels {
+		unique := []Channel{}
+		for _, channel := range channels {
+			if !containsChannel(unique, channel) {
+				unique = append(unique, channel)
+			}
+		}
+
+		normalized.Channels[category] = unique
+	}
+
+	for _, category := range Categories {
+		if _, ok := normalized.Channels[category]; !ok || category.Mandatory() {
+			normalized.Channels[category] = []Channel{ChannelEmail}
+		}
+	}
+
+	return normalized
+}
+
+// Validate checks that the Preferences are consistent, they're expected to be normalized
+func (p Preferences) Validate() error {
+	if !localePattern.MatchString(p.Locale) {
+		return ErrInvalidLocale
+	}
+
+	if p.Phone != "" && !phonePattern.MatchString(p.Phone) {
+		return ErrInvalidPhone
+	}
+
+	for category, channels := range p.Channels {
+		if err := category.Validate(); err != nil {
+			return err
+		}
+
+		for _, channel := range channels {
+			if err := channel.Validate(); err != nil {
+				return err
+			}
+
+			if channel == ChannelSMS && p.Phone == "" {
+				return ErrPhoneRequired
+			}
+		}
+	}
+
+	return nil
+}
+
+// Recipient is a customer the Notifications can be sent to.
+// Its contact details come from the events of the customers service, its Preferences are set by the customer.
+type Recipient struct {
+	CustomerID    string
+	Name          string
+	Email         string
+	EmailVerified bool
+	Preferences   Preferences
+	UpdatedAt     time.Time
+}
+
+// Address tells where a Notification sent through a Channel goes to, empty when the Recipient can't be reached through it
+func (r Recipient) Address(channel Channel) string {
+	switch channel {
+	case ChannelEmail:
+		return r.Email
+	case ChannelSMS:
+		return r.Preferences.Phone
+	default:
+		return ""
+	}
+}
+
+// Validate checks that the contact details of the Recipient are consistent
+func (r Recipient) Validate() error {
+	if r.CustomerID == "" || len(r.Name) > MaxNameLength {
+		return ErrInvalidRecipient
+	}
+
+	if _, err := mail.ParseAddress(r.Email); err != nil {
+		return ErrInvalidRecipient
+	}
+
+	return r.Preferences.Validate()
+}
+
+// Contact is what the customers service tells about a customer who can be notified
+type Contact struct {
+	CustomerID    string
+	Name          string
+	Email         string
+	EmailVerified bool
+}
+
+func containsChannel(channels []Channel, channel Channel) bool {
+	for _, c := range channels {
+		if c == channel {
+			return true
+		}
+	}
+
+	return false
+}
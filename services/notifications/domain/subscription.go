@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidSubscription = errors.New("invalid-subscription")
+	ErrProductNotFound     = errors.New("product-not-found")
+)
+
+// Subscription asks for a Recipient to be told once a Product is back in stock.
+// It's only notified once, the Subscription is removed right after.
+type Subscription struct {
+	CustomerID string
+	ProductID  int
+	CreatedAt  time.Time
+}
+
+// Validate checks that the Subscription refers to a customer and a Product
+func (s Subscription) Validate() error {
+	if s.CustomerID == "" || s.ProductID <= 0 {
+		return ErrInvalidSubscription
+	}
+
+	return nil
+}
+
+// SubscriptionFilter narrows down the Subscriptions listed, either by customer, by Product or both
+type SubscriptionFilter struct {
+	CustomerID string
+	ProductID  int
+}
+
+// ProductSnapshot is what a Notification tells about a Product
+type ProductSnapshot struct {
+	ID   int
+	Name string
+}
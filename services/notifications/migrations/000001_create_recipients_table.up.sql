@@ -0,0 +1,11 @@
+-- the channels map each category to the channels it's sent through, e.g. {"orders": ["email", "sms"]}
+CREATE TABLE IF NOT EXISTS recipients (
+    customer_id    TEXT PRIMARY KEY,
+    name           TEXT        NOT NULL,
+    email          TEXT        NOT NULL,
+    email_verified BOOLEAN     NOT NULL DEFAULT FALSE,
+    locale         TEXT        NOT NULL,
+    phone          TEXT        NOT NULL DEFAULT '',
+    channels       JSONB       NOT NULL DEFAULT '{}',
+    updated_at     TIMESTAMPTZ NOT NULL
+);
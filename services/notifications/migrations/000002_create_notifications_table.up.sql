@@ -0,0 +1,26 @@
+-- each event is notified once on each channel, even when it's delivered more than once
+CREATE TABLE IF NOT EXISTS notifications (
+    id              SERIAL PRIMARY KEY,
+    key             TEXT        NOT NULL,
+    customer_id     TEXT        NOT NULL,
+    category        TEXT        NOT NULL,
+    template        TEXT        NOT NULL,
+    channel         TEXT        NOT NULL,
+    locale          TEXT        NOT NULL,
+    "to"            TEXT        NOT NULL,
+    subject         TEXT        NOT NULL DEFAULT '',
+    body            TEXT        NOT NULL,
+    status          TEXT        NOT NULL,
+    attempts        INTEGER     NOT NULL DEFAULT 0,
+    last_error      TEXT        NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMPTZ,
+    sent_at         TIMESTAMPTZ,
+    version         INTEGER     NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL,
+    CONSTRAINT notifications_key_channel_key UNIQUE (key, channel),
+    CONSTRAINT notifications_status_check CHECK (status IN ('pending', 'sent', 'failed'))
+);
+
+CREATE INDEX notifications_customer_id_created_at_idx ON notifications (customer_id, created_at DESC);
+CREATE INDEX notifications_next_attempt_at_idx ON notifications (next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS back_in_stock_subscriptions (
+    customer_id TEXT        NOT NULL,
+    product_id  INTEGER     NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (customer_id, product_id)
+);
+
+CREATE INDEX back_in_stock_subscriptions_product_id_idx ON back_in_stock_subscriptions (product_id);
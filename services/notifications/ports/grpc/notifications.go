@@ -0,0 +1,262 @@
+package grpc_port
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	pb "github.com/lucasmls/ecommerce/services/notifications/ports/grpc/proto"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var InternalServerError = status.Error(codes.Internal, "Internal server error")
+
+var (
+	ErrMissingLogger = errors.New("missing required dependency: Logger")
+	ErrMisingTracer  = errors.New("missing required dependency: Tracer")
+)
+
+// NotificationsResolver ...
+type NotificationsResolver struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+	App    domain.Application
+
+	pb.UnimplementedNotificationsServiceServer
+}
+
+func NewNotificationsResolver(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) (*NotificationsResolver, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMisingTracer
+	}
+
+	return &NotificationsResolver{
+		Logger: logger,
+		Tracer: tracer,
+		App:    app,
+	}, nil
+}
+
+// MustNewNotificationsResolver creates a new NotificationsResolver instance.
+// It panics if any error is found
+func MustNewNotificationsResolver(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) *NotificationsResolver {
+	notificationsResolver, err := NewNotificationsResolver(logger, tracer, app)
+	if err != nil {
+		panic(err)
+	}
+
+	return notificationsResolver
+}
+
+func (r *NotificationsResolver) GetPreferences(ctx context.Context, req *pb.GetPreferencesRequest) (*pb.GetPreferencesResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.GetPreferences")
+	defer span.End()
+
+	preferences, err := r.App.GetPreferences(ctx, req.CustomerId)
+	if err != nil {
+		return nil, r.toError(err, "failed to get the preferences", zap.String("customer_id", req.CustomerId))
+	}
+
+	return &pb.GetPreferencesResponse{Data: toProtoPreferences(preferences)}, nil
+}
+
+func (r *NotificationsResolver) UpdatePreferences(ctx context.Context, req *pb.UpdatePreferencesRequest) (*pb.UpdatePreferencesResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.UpdatePreferences")
+	defer span.End()
+
+	preferences, err := r.App.UpdatePreferences(ctx, req.CustomerId, toDomainPreferences(req.Preferences))
+	if err != nil {
+		return nil, r.toError(err, "failed to update the preferences", zap.String("customer_id", req.CustomerId))
+	}
+
+	return &pb.UpdatePreferencesResponse{Data: toProtoPreferences(preferences)}, nil
+}
+
+func (r *NotificationsResolver) SubscribeBackInStock(ctx context.Context, req *pb.SubscribeBackInStockRequest) (*pb.SubscribeBackInStockResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.SubscribeBackInStock")
+	defer span.End()
+
+	subscription, err := r.App.SubscribeBackInStock(ctx, domain.Subscription{
+		CustomerID: req.CustomerId,
+		ProductID:  int(req.ProductId),
+	})
+	if err != nil {
+		return nil, r.toError(
+			err, "failed to subscribe to the product",
+			zap.String("customer_id", req.CustomerId), zap.Int32("product_id", req.ProductId),
+		)
+	}
+
+	return &pb.SubscribeBackInStockResponse{Data: toProtoSubscription(subscription)}, nil
+}
+
+func (r *NotificationsResolver) UnsubscribeBackInStock(ctx context.Context, req *pb.UnsubscribeBackInStockRequest) (*pb.UnsubscribeBackInStockResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.UnsubscribeBackInStock")
+	defer span.End()
+
+	err := r.App.UnsubscribeBackInStock(ctx, domain.Subscription{
+		CustomerID: req.CustomerId,
+		ProductID:  int(req.ProductId),
+	})
+	if err != nil {
+		return nil, r.toError(
+			err, "failed to unsubscribe from the product",
+			zap.String("customer_id", req.CustomerId), zap.Int32("product_id", req.ProductId),
+		)
+	}
+
+	return &pb.UnsubscribeBackInStockResponse{}, nil
+}
+
+func (r *NotificationsResolver) ListSubscriptions(ctx context.Context, req *pb.ListSubscriptionsRequest) (*pb.ListSubscriptionsResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.ListSubscriptions")
+	defer span.End()
+
+	subscriptions, err := r.App.ListSubscriptions(ctx, req.CustomerId)
+	if err != nil {
+		return nil, r.toError(err, "failed to list the subscriptions", zap.String("customer_id", req.CustomerId))
+	}
+
+	response := make([]*pb.Subscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		response = append(response, toProtoSubscription(subscription))
+	}
+
+	return &pb.ListSubscriptionsResponse{Data: response}, nil
+}
+
+func (r *NotificationsResolver) ListNotifications(ctx context.Context, req *pb.ListNotificationsRequest) (*pb.ListNotificationsResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.ListNotifications")
+	defer span.End()
+
+	filter := domain.NotificationFilter{
+		CustomerID: req.CustomerId,
+		Status:     domain.NotificationStatus(req.Status),
+		Limit:      int(req.Limit),
+	}
+
+	notifications, err := r.App.ListNotifications(ctx, filter)
+	if err != nil {
+		return nil, r.toError(err, "failed to list the notifications", zap.Any("filter", filter))
+	}
+
+	response := make([]*pb.Notification, 0, len(notifications))
+	for _, notification := range notifications {
+		response = append(response, toProtoNotification(notification))
+	}
+
+	return &pb.ListNotificationsResponse{Data: response}, nil
+}
+
+// toError reports the invalid Preferences, Subscriptions and filters and the missing Recipients or Products
+// to the client, and logs any other error, replying with an internal one
+func (r *NotificationsResolver) toError(err error, message string, fields ...zap.Field) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidRecipient),
+		errors.Is(err, domain.ErrInvalidLocale),
+		errors.Is(err, domain.ErrInvalidPhone),
+		errors.Is(err, domain.ErrInvalidChannel),
+		errors.Is(err, domain.ErrInvalidCategory),
+		errors.Is(err, domain.ErrPhoneRequired),
+		errors.Is(err, domain.ErrInvalidSubscription),
+		errors.Is(err, domain.ErrInvalidNotificationFilter),
+		errors.Is(err, domain.ErrInvalidNotificationStatus):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrRecipientNotFound),
+		errors.Is(err, domain.ErrProductNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	r.Logger.Error(message, append(fields, zap.Error(err))...)
+	return InternalServerError
+}
+
+func toDomainPreferences(preferences *pb.Preferences) domain.Preferences {
+	channels := make(map[domain.Category][]domain.Channel, len(preferences.GetChannels()))
+	for category, list := range preferences.GetChannels() {
+		categoryChannels := make([]domain.Channel, 0, len(list.GetChannels()))
+		for _, channel := range list.GetChannels() {
+			categoryChannels = append(categoryChannels, domain.Channel(channel))
+		}
+
+		channels[domain.Category(category)] = categoryChannels
+	}
+
+	return domain.Preferences{
+		Locale:   preferences.GetLocale(),
+		Phone:    preferences.GetPhone(),
+		Channels: channels,
+	}
+}
+
+func toProtoPreferences(preferences domain.Preferences) *pb.Preferences {
+	channels := make(map[string]*pb.ChannelList, len(domain.Categories))
+	for _, category := range domain.Categories {
+		list := &pb.ChannelList{Channels: []string{}}
+		for _, channel := range preferences.ChannelsFor(category) {
+			list.Channels = append(list.Channels, string(channel))
+		}
+
+		channels[string(category)] = list
+	}
+
+	return &pb.Preferences{
+		Locale:   preferences.Locale,
+		Phone:    preferences.Phone,
+		Channels: channels,
+	}
+}
+
+func toProtoSubscription(subscription domain.Subscription) *pb.Subscription {
+	return &pb.Subscription{
+		CustomerId: subscription.CustomerID,
+		ProductId:  int32(subscription.ProductID),
+		CreatedAt:  toProtoTimestamp(subscription.CreatedAt),
+	}
+}
+
+func toProtoNotification(notification domain.Notification) *pb.Notification {
+	return &pb.Notification{
+		Id:            int32(notification.ID),
+		CustomerId:    notification.CustomerID,
+		Category:      string(notification.Category),
+		Template:      notification.Template,
+		Channel:       string(notification.Channel),
+		Locale:        notification.Locale,
+		To:            notification.To,
+		Subject:       notification.Subject,
+		Body:          notification.Body,
+		Status:        string(notification.Status),
+		Attempts:      int32(notification.Attempts),
+		LastError:     notification.LastError,
+		NextAttemptAt: toProtoTimestamp(notification.NextAttemptAt),
+		SentAt:        toProtoTimestamp(notification.SentAt),
+		CreatedAt:     toProtoTimestamp(notification.CreatedAt),
+	}
+}
+
+// toProtoTimestamp leaves the zero times unset
+func toProtoTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
@@ -0,0 +1,363 @@
+package grpc_port
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/shared/grpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	gGRPC "google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/lucasmls/ecommerce/services/notifications/mocks"
+	protog "github.com/lucasmls/ecommerce/services/notifications/ports/grpc/proto"
+)
+
+type NotificationsResolverSuite struct {
+	suite.Suite
+
+	app    *mocks.Application
+	logger *zap.Logger
+	tracer trace.Tracer
+
+	grpcClient     protog.NotificationsServiceClient
+	grpcConnection *gGRPC.ClientConn
+}
+
+func (s *NotificationsResolverSuite) SetupSuite() {
+	s.logger = zap.NewNop()
+	s.tracer = trace.NewNoopTracerProvider().Tracer("")
+	s.app = &mocks.Application{}
+
+	grpcClient, grpcConnection := makeGrpcClientAndServer(
+		context.Background(),
+		s.logger,
+		s.tracer,
+		s.app,
+	)
+
+	s.grpcClient = grpcClient
+	s.grpcConnection = grpcConnection
+}
+
+func (s *NotificationsResolverSuite) TearDownSuite() {
+	err := s.grpcConnection.Close()
+	s.NoError(err)
+}
+
+func (s *NotificationsResolverSuite) Test_NewNotificationsResolver() {
+	s.Run("Should fail to instantiate the NotificationsResolver in case a Logger isn't provided", func() {
+		_, err := NewNotificationsResolver(nil, nil, nil)
+
+		s.Equal(ErrMissingLogger, err)
+	})
+
+	s.Run("Should fail to instantiate the NotificationsResolver in case a Tracer isn't provided", func() {
+		_, err := NewNotificationsResolver(s.logger, nil, nil)
+
+		s.Equal(ErrMisingTracer, err)
+	})
+
+	s.Run("Should successfully instantiate the NotificationsResolver", func() {
+		_, err := NewNotificationsResolver(s.logger, s.tracer, nil)
+
+		s.NoError(err)
+	})
+}
+
+func (s *NotificationsResolverSuite) Test_GetPreferences() {
+	s.Run("Should return a not found error in case the recipient doesn't exist", func() {
+		s.app.On("GetPreferences", mock.AnythingOfType("*context.valueCtx"), "cus_1").Return(domain.Preferences{}, domain.ErrRecipientNotFound).Once()
+
+		_, err := s.grpcClient.GetPreferences(context.Background(), &protog.GetPreferencesRequest{CustomerId: "cus_1"})
+
+		s.Equal(status.Error(codes.NotFound, domain.ErrRecipientNotFound.Error()), err)
+	})
+
+	s.Run("Should return the channels of every category", func() {
+		preferences := domain.Preferences{
+			Locale: "pt-BR",
+			Channels: map[domain.Category][]domain.Channel{
+				domain.CategoryOrders:      {domain.ChannelEmail},
+				domain.CategoryBackInStock: {},
+			},
+		}
+
+		s.app.On("GetPreferences", mock.AnythingOfType("*context.valueCtx"), "cus_1").Return(preferences, nil).Once()
+
+		response, err := s.grpcClient.GetPreferences(context.Background(), &protog.GetPreferencesRequest{CustomerId: "cus_1"})
+
+		s.NoError(err)
+		s.True(proto.Equal(&protog.GetPreferencesResponse{
+			Data: &protog.Preferences{
+				Locale: "pt-BR",
+				Channels: map[string]*protog.ChannelList{
+					"account":       {Channels: []string{"email"}},
+					"orders":        {Channels: []string{"email"}},
+					"shipping":      {Channels: []string{"email"}},
+					"back_in_stock": {Channels: []string{}},
+				},
+			},
+		}, response), response.String())
+	})
+}
+
+func (s *NotificationsResolverSuite) Test_UpdatePreferences() {
+	preferences := domain.Preferences{
+		Locale: "en",
+		Phone:  "+5511987654321",
+		Channels: map[domain.Category][]domain.Channel{
+			domain.CategoryShipping: {domain.ChannelEmail, domain.ChannelSMS},
+		},
+	}
+
+	request := &protog.UpdatePreferencesRequest{
+		CustomerId: "cus_1",
+		Preferences: &protog.Preferences{
+			Locale: "en",
+			Phone:  "+5511987654321",
+			Channels: map[string]*protog.ChannelList{
+				"shipping": {Channels: []string{"email", "sms"}},
+			},
+		},
+	}
+
+	s.Run("Should return an invalid argument error in case a sms is asked for without a phone", func() {
+		s.app.On("UpdatePreferences", mock.AnythingOfType("*context.valueCtx"), "cus_1", preferences).Return(domain.Preferences{}, domain.ErrPhoneRequired).Once()
+
+		_, err := s.grpcClient.UpdatePreferences(context.Background(), request)
+
+		s.Equal(status.Error(codes.InvalidArgument, domain.ErrPhoneRequired.Error()), err)
+	})
+
+	s.Run("Should return an internal server error in case the preferences can't be saved", func() {
+		s.app.On("UpdatePreferences", mock.AnythingOfType("*context.valueCtx"), "cus_1", preferences).Return(domain.Preferences{}, errors.New("connection refused")).Once()
+
+		_, err := s.grpcClient.UpdatePreferences(context.Background(), request)
+
+		s.Equal(status.Error(codes.Internal, "Internal server error"), err)
+	})
+
+	s.Run("Should return the updated preferences", func() {
+		s.app.On("UpdatePreferences", mock.AnythingOfType("*context.valueCtx"), "cus_1", preferences).Return(preferences, nil).Once()
+
+		response, err := s.grpcClient.UpdatePreferences(context.Background(), request)
+
+		s.NoError(err)
+		s.Equal([]string{"email", "sms"}, response.Data.Channels["shipping"].Channels)
+		s.Equal([]string{"email"}, response.Data.Channels["orders"].Channels)
+		s.Equal("+5511987654321", response.Data.Phone)
+	})
+}
+
+func (s *NotificationsResolverSuite) Test_SubscribeBackInStock() {
+	subscription := domain.Subscription{CustomerID: "cus_1", ProductID: 7}
+	request := &protog.SubscribeBackInStockRequest{CustomerId: "cus_1", ProductId: 7}
+
+	s.Run("Should return a not found error in case the product doesn't exist", func() {
+		s.app.On("SubscribeBackInStock", mock.AnythingOfType("*context.valueCtx"), subscription).Return(domain.Subscription{}, domain.ErrProductNotFound).Once()
+
+		_, err := s.grpcClient.SubscribeBackInStock(context.Background(), request)
+
+		s.Equal(status.Error(codes.NotFound, domain.ErrProductNotFound.Error()), err)
+	})
+
+	s.Run("Should return the subscription", func() {
+		createdAt := time.Date(2022, 10, 5, 14, 0, 0, 0, time.UTC)
+		created := domain.Subscription{CustomerID: "cus_1", ProductID: 7, CreatedAt: createdAt}
+
+		s.app.On("SubscribeBackInStock", mock.AnythingOfType("*context.valueCtx"), subscription).Return(created, nil).Once()
+
+		response, err := s.grpcClient.SubscribeBackInStock(context.Background(), request)
+
+		s.NoError(err)
+		s.True(proto.Equal(&protog.SubscribeBackInStockResponse{
+			Data: &protog.Subscription{CustomerId: "cus_1", ProductId: 7, CreatedAt: timestamppb.New(createdAt)},
+		}, response), response.String())
+	})
+}
+
+func (s *NotificationsResolverSuite) Test_UnsubscribeBackInStock() {
+	subscription := domain.Subscription{CustomerID: "cus_1", ProductID: 7}
+	request := &protog.UnsubscribeBackInStockRequest{CustomerId: "cus_1", ProductId: 7}
+
+	s.Run("Should return an invalid argument error in case the subscription is invalid", func() {
+		s.app.On("UnsubscribeBackInStock", mock.AnythingOfType("*context.valueCtx"), subscription).Return(domain.ErrInvalidSubscription).Once()
+
+		_, err := s.grpcClient.UnsubscribeBackInStock(context.Background(), request)
+
+		s.Equal(status.Error(codes.InvalidArgument, domain.ErrInvalidSubscription.Error()), err)
+	})
+
+	s.Run("Should unsubscribe from the product", func() {
+		s.app.On("UnsubscribeBackInStock", mock.AnythingOfType("*context.valueCtx"), subscription).Return(nil).Once()
+
+		_, err := s.grpcClient.UnsubscribeBackInStock(context.Background(), request)
+
+		s.NoError(err)
+	})
+}
+
+func (s *NotificationsResolverSuite) Test_ListSubscriptions() {
+	s.Run("Should return the subscriptions of the customer", func() {
+		createdAt := time.Date(2022, 10, 5, 14, 0, 0, 0, time.UTC)
+		subscriptions := []domain.Subscription{
+			{CustomerID: "cus_1", ProductID: 7, CreatedAt: createdAt},
+			{CustomerID: "cus_1", ProductID: 9, CreatedAt: createdAt},
+		}
+
+		s.app.On("ListSubscriptions", mock.AnythingOfType("*context.valueCtx"), "cus_1").Return(subscriptions, nil).Once()
+
+		response, err := s.grpcClient.ListSubscriptions(context.Background(), &protog.ListSubscriptionsRequest{CustomerId: "cus_1"})
+
+		s.NoError(err)
+		s.True(proto.Equal(&protog.ListSubscriptionsResponse{
+			Data: []*protog.Subscription{
+				{CustomerId: "cus_1", ProductId: 7, CreatedAt: timestamppb.New(createdAt)},
+				{CustomerId: "cus_1", ProductId: 9, CreatedAt: timestamppb.New(createdAt)},
+			},
+		}, response), response.String())
+	})
+}
+
+func (s *NotificationsResolverSuite) Test_ListNotifications() {
+	filter := domain.NotificationFilter{CustomerID: "cus_1", Status: domain.NotificationStatusFailed, Limit: 10}
+	request := &protog.ListNotificationsRequest{CustomerId: "cus_1", Status: "failed", Limit: 10}
+
+	s.Run("Should return an invalid argument error in case the filter is invalid", func() {
+		s.app.On("ListNotifications", mock.AnythingOfType("*context.valueCtx"), filter).Return(nil, domain.ErrInvalidNotificationFilter).Once()
+
+		_, err := s.grpcClient.ListNotifications(context.Background(), request)
+
+		s.Equal(status.Error(codes.InvalidArgument, domain.ErrInvalidNotificationFilter.Error()), err)
+	})
+
+	s.Run("Should return the notifications", func() {
+		createdAt := time.Date(2022, 10, 5, 14, 0, 0, 0, time.UTC)
+		nextAttemptAt := createdAt.Add(2 * time.Minute)
+		notifications := []domain.Notification{
+			{
+				ID:            3,
+				Key:           "orders:12:paid",
+				CustomerID:    "cus_1",
+				Category:      domain.CategoryOrders,
+				Template:      domain.TemplateOrderConfirmed,
+				Channel:       domain.ChannelEmail,
+				Locale:        "en",
+				To:            "jane@example.com",
+				Subject:       "Your order #1012 is confirmed",
+				Body:          "Hi Jane",
+				Status:        domain.NotificationStatusFailed,
+				Attempts:      2,
+				LastError:     "connection refused",
+				NextAttemptAt: nextAttemptAt,
+				CreatedAt:     createdAt,
+				UpdatedAt:     createdAt,
+				Version:       4,
+			},
+		}
+
+		s.app.On("ListNotifications", mock.AnythingOfType("*context.valueCtx"), filter).Return(notifications, nil).Once()
+
+		response, err := s.grpcClient.ListNotifications(context.Background(), request)
+
+		s.NoError(err)
+		s.True(proto.Equal(&protog.ListNotificationsResponse{
+			Data: []*protog.Notification{
+				{
+					Id:            3,
+					CustomerId:    "cus_1",
+					Category:      "orders",
+					Template:      "order_confirmed",
+					Channel:       "email",
+					Locale:        "en",
+					To:            "jane@example.com",
+					Subject:       "Your order #1012 is confirmed",
+					Body:          "Hi Jane",
+					Status:        "failed",
+					Attempts:      2,
+					LastError:     "connection refused",
+					NextAttemptAt: timestamppb.New(nextAttemptAt),
+					CreatedAt:     timestamppb.New(createdAt),
+				},
+			},
+		}, response), response.String())
+	})
+}
+
+func TestNotificationsResolverSuite(t *testing.T) {
+	suite.Run(t, new(NotificationsResolverSuite))
+}
+
+func makeGrpcClientAndServer(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	tracerM trace.Tracer,
+	appM domain.Application,
+) (protog.NotificationsServiceClient, *gGRPC.ClientConn) {
+	listener := makeGrpcServer(ctx, loggerM, tracerM, appM)
+	grpcClient, grpcConnection := makeGrpcClient(ctx, loggerM, listener)
+
+	return grpcClient, grpcConnection
+}
+
+func makeGrpcClient(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	listener *bufconn.Listener,
+) (protog.NotificationsServiceClient, *gGRPC.ClientConn) {
+	notificationsServiceGRPCClient := grpc.MustNewClient(grpc.ClientInput{
+		Logger: loggerM,
+		AdditionalDialOptions: []gGRPC.DialOption{
+			gGRPC.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+				return listener.Dial()
+			}),
+		},
+	})
+
+	notificationsServiceConn := notificationsServiceGRPCClient.MustConnect(ctx)
+
+	grpcClient := protog.NewNotificationsServiceClient(notificationsServiceConn)
+	return grpcClient, notificationsServiceConn
+}
+
+func makeGrpcServer(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	tracerM trace.Tracer,
+	appM domain.Application,
+) *bufconn.Listener {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.MustNewServer(grpc.ServerInput{
+		Port:   1234,
+		Logger: loggerM,
+		Registrator: func(server gGRPC.ServiceRegistrar) {
+			protog.RegisterNotificationsServiceServer(server, &NotificationsResolver{
+				Logger: loggerM,
+				Tracer: tracerM,
+				App:    appM,
+			})
+		},
+		Listener: listener,
+	})
+
+	go func() {
+		if err := grpcServer.Run(ctx); err != nil {
+			panic(err)
+		}
+	}()
+
+	return listener
+}
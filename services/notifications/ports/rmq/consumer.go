@@ -0,0 +1,21 @@
+package rmq_port
+
+import (
+	"errors"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+)
+
+var (
+	ErrMissingLogger = errors.New("missing required dependency: Logger")
+	ErrMisingTracer  = errors.New("missing required dependency: Tracer")
+)
+
+// skippable tells whether an Event failed for a reason delivering it again won't fix, e.g. the Recipient
+// was never told about, so that it's acknowledged instead
+func skippable(err error) bool {
+	return errors.Is(err, domain.ErrRecipientNotFound) ||
+		errors.Is(err, domain.ErrProductNotFound) ||
+		errors.Is(err, domain.ErrInvalidEvent) ||
+		errors.Is(err, domain.ErrInvalidRecipient)
+}
@@ -0,0 +1,130 @@
+package rmq_port
+
+import (
+	"context"
+	"fmt"
+
+	customersPb "github.com/lucasmls/ecommerce/services/customers/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// The types of the CustomerEvents
+const (
+	customerRegistered            = "registered"
+	customerVerificationRequested = "verification_requested"
+	customerEmailVerified         = "email_verified"
+	customerDeleted               = "deleted"
+)
+
+// CustomerEventTypes are the types of the CustomerEvents the CustomersConsumer handles
+var CustomerEventTypes = []string{
+	customerRegistered,
+	customerVerificationRequested,
+	customerEmailVerified,
+	customerDeleted,
+}
+
+// CustomersConsumer keeps the contact details of the Recipients in sync with the customers, and sends them
+// the email verifying their address.
+type CustomersConsumer struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+	App    domain.Application
+}
+
+func NewCustomersConsumer(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) (*CustomersConsumer, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMisingTracer
+	}
+
+	return &CustomersConsumer{
+		Logger: logger,
+		Tracer: tracer,
+		App:    app,
+	}, nil
+}
+
+// MustNewCustomersConsumer creates a new CustomersConsumer instance.
+// It panics if any error is found
+func MustNewCustomersConsumer(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) *CustomersConsumer {
+	consumer, err := NewCustomersConsumer(logger, tracer, app)
+	if err != nil {
+		panic(err)
+	}
+
+	return consumer
+}
+
+// Handle handles a CustomerEvent, it's meant to be the Handler of the rmq.Client consuming the customers queue
+// of the Notifications.
+func (c *CustomersConsumer) Handle(ctx context.Context, body []byte) error {
+	ctx, span := c.Tracer.Start(ctx, "consumer.HandleCustomerEvent")
+	defer span.End()
+
+	event := &customersPb.CustomerEvent{}
+	if err := proto.Unmarshal(body, event); err != nil {
+		return err
+	}
+
+	err := c.handle(ctx, event)
+	if skippable(err) {
+		c.Logger.Warn(
+			"skipping a customer event",
+			zap.String("type", event.Type),
+			zap.String("customerId", event.CustomerId),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	return err
+}
+
+func (c *CustomersConsumer) handle(ctx context.Context, event *customersPb.CustomerEvent) error {
+	switch event.Type {
+	case customerRegistered, customerVerificationRequested:
+		if _, err := c.App.SaveContact(ctx, toContact(event)); err != nil {
+			return err
+		}
+
+		_, err := c.App.Notify(ctx, domain.Event{
+			Key:        fmt.Sprintf("customers:%s:verification:%s", event.CustomerId, event.VerificationToken),
+			CustomerID: event.CustomerId,
+			Category:   domain.CategoryAccount,
+			Template:   domain.TemplateVerifyEmail,
+			Data:       map[string]interface{}{"Token": event.VerificationToken},
+		})
+		return err
+	case customerEmailVerified:
+		_, err := c.App.SaveContact(ctx, toContact(event))
+		return err
+	case customerDeleted:
+		return c.App.DeleteRecipient(ctx, event.CustomerId)
+	default:
+		return nil
+	}
+}
+
+func toContact(event *customersPb.CustomerEvent) domain.Contact {
+	return domain.Contact{
+		CustomerID:    event.CustomerId,
+		Name:          event.Name,
+		Email:         event.Email,
+		EmailVerified: event.EmailVerified,
+	}
+}
@@ -0,0 +1,147 @@
+package rmq_port
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	customersPb "github.com/lucasmls/ecommerce/services/customers/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/lucasmls/ecommerce/services/notifications/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+type CustomersConsumerSuite struct {
+	suite.Suite
+
+	app      *mocks.Application
+	consumer *CustomersConsumer
+}
+
+func (s *CustomersConsumerSuite) SetupTest() {
+	s.app = &mocks.Application{}
+	s.consumer = MustNewCustomersConsumer(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), s.app)
+}
+
+func (s *CustomersConsumerSuite) handle(event *customersPb.CustomerEvent) error {
+	body, err := proto.Marshal(event)
+	s.Require().NoError(err)
+
+	return s.consumer.Handle(context.Background(), body)
+}
+
+func (s *CustomersConsumerSuite) Test_NewCustomersConsumer() {
+	s.Run("Should fail to instantiate the CustomersConsumer in case a Logger isn't provided", func() {
+		_, err := NewCustomersConsumer(nil, nil, nil)
+
+		s.Equal(ErrMissingLogger, err)
+	})
+
+	s.Run("Should fail to instantiate the CustomersConsumer in case a Tracer isn't provided", func() {
+		_, err := NewCustomersConsumer(zap.NewNop(), nil, nil)
+
+		s.Equal(ErrMisingTracer, err)
+	})
+}
+
+func (s *CustomersConsumerSuite) Test_Handle() {
+	contact := domain.Contact{CustomerID: "cus_1", Name: "Jane", Email: "jane@example.com"}
+
+	s.Run("Should save the contact and send the verification email of a registered customer", func() {
+		s.SetupTest()
+
+		s.app.On("SaveContact", mock.AnythingOfType("*context.valueCtx"), contact).Return(domain.Recipient{}, nil).Once()
+		s.app.On("Notify", mock.AnythingOfType("*context.valueCtx"), domain.Event{
+			Key:        "customers:cus_1:verification:tok",
+			CustomerID: "cus_1",
+			Category:   domain.CategoryAccount,
+			Template:   domain.TemplateVerifyEmail,
+			Data:       map[string]interface{}{"Token": "tok"},
+		}).Return([]domain.Notification{{ID: 1}}, nil).Once()
+
+		err := s.handle(&customersPb.CustomerEvent{
+			Type:              "registered",
+			CustomerId:        "cus_1",
+			Name:              "Jane",
+			Email:             "jane@example.com",
+			VerificationToken: "tok",
+		})
+
+		s.NoError(err)
+		s.app.AssertExpectations(s.T())
+	})
+
+	s.Run("Should only save the contact of a customer that verified its email", func() {
+		s.SetupTest()
+
+		verified := contact
+		verified.EmailVerified = true
+
+		s.app.On("SaveContact", mock.AnythingOfType("*context.valueCtx"), verified).Return(domain.Recipient{}, nil).Once()
+
+		err := s.handle(&customersPb.CustomerEvent{
+			Type:          "email_verified",
+			CustomerId:    "cus_1",
+			Name:          "Jane",
+			Email:         "jane@example.com",
+			EmailVerified: true,
+		})
+
+		s.NoError(err)
+		s.app.AssertExpectations(s.T())
+		s.app.AssertNotCalled(s.T(), "Notify", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should delete the recipient of a deleted customer", func() {
+		s.SetupTest()
+
+		s.app.On("DeleteRecipient", mock.AnythingOfType("*context.valueCtx"), "cus_1").Return(nil).Once()
+
+		err := s.handle(&customersPb.CustomerEvent{Type: "deleted", CustomerId: "cus_1"})
+
+		s.NoError(err)
+		s.app.AssertExpectations(s.T())
+	})
+
+	s.Run("Should acknowledge the events of invalid recipients", func() {
+		s.SetupTest()
+
+		s.app.On("SaveContact", mock.AnythingOfType("*context.valueCtx"), contact).Return(domain.Recipient{}, domain.ErrInvalidRecipient).Once()
+
+		err := s.handle(&customersPb.CustomerEvent{
+			Type:       "verification_requested",
+			CustomerId: "cus_1",
+			Name:       "Jane",
+			Email:      "jane@example.com",
+		})
+
+		s.NoError(err)
+		s.app.AssertNotCalled(s.T(), "Notify", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the recipient can't be deleted", func() {
+		s.SetupTest()
+
+		s.app.On("DeleteRecipient", mock.AnythingOfType("*context.valueCtx"), "cus_1").Return(errors.New("connection refused")).Once()
+
+		err := s.handle(&customersPb.CustomerEvent{Type: "deleted", CustomerId: "cus_1"})
+
+		s.EqualError(err, "connection refused")
+	})
+
+	s.Run("Should fail to handle a malformed event", func() {
+		s.SetupTest()
+
+		err := s.consumer.Handle(context.Background(), []byte{0xff})
+
+		s.Error(err)
+	})
+}
+
+func TestCustomersConsumerSuite(t *testing.T) {
+	suite.Run(t, new(CustomersConsumerSuite))
+}
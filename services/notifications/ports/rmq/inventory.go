@@ -0,0 +1,87 @@
+package rmq_port
+
+import (
+	"context"
+
+	inventoryPb "github.com/lucasmls/ecommerce/services/inventory/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// stockBackInStock is the type of the StockEvents the InventoryConsumer handles
+const stockBackInStock = "back_in_stock"
+
+// StockEventTypes are the types of the StockEvents the InventoryConsumer handles
+var StockEventTypes = []string{stockBackInStock}
+
+// InventoryConsumer tells the customers subscribed to a Product it's back in stock
+type InventoryConsumer struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+	App    domain.Application
+}
+
+func NewInventoryConsumer(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) (*InventoryConsumer, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMisingTracer
+	}
+
+	return &InventoryConsumer{
+		Logger: logger,
+		Tracer: tracer,
+		App:    app,
+	}, nil
+}
+
+// MustNewInventoryConsumer creates a new InventoryConsumer instance.
+// It panics if any error is found
+func MustNewInventoryConsumer(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) *InventoryConsumer {
+	consumer, err := NewInventoryConsumer(logger, tracer, app)
+	if err != nil {
+		panic(err)
+	}
+
+	return consumer
+}
+
+// Handle handles a StockEvent, it's meant to be the Handler of the rmq.Client consuming the inventory queue
+// of the Notifications.
+func (c *InventoryConsumer) Handle(ctx context.Context, body []byte) error {
+	ctx, span := c.Tracer.Start(ctx, "consumer.HandleStockEvent")
+	defer span.End()
+
+	event := &inventoryPb.StockEvent{}
+	if err := proto.Unmarshal(body, event); err != nil {
+		return err
+	}
+
+	if event.Type != stockBackInStock {
+		return nil
+	}
+
+	_, err := c.App.NotifyBackInStock(ctx, domain.BackInStock{
+		ProductID:  int(event.ProductId),
+		Available:  int(event.Available),
+		OccurredAt: event.OccurredAt.AsTime(),
+	})
+	if skippable(err) {
+		c.Logger.Warn("skipping a stock event", zap.Int32("productId", event.ProductId), zap.Error(err))
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,92 @@
+package rmq_port
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	inventoryPb "github.com/lucasmls/ecommerce/services/inventory/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/lucasmls/ecommerce/services/notifications/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type InventoryConsumerSuite struct {
+	suite.Suite
+
+	app      *mocks.Application
+	consumer *InventoryConsumer
+}
+
+func (s *InventoryConsumerSuite) SetupTest() {
+	s.app = &mocks.Application{}
+	s.consumer = MustNewInventoryConsumer(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), s.app)
+}
+
+func (s *InventoryConsumerSuite) handle(event *inventoryPb.StockEvent) error {
+	body, err := proto.Marshal(event)
+	s.Require().NoError(err)
+
+	return s.consumer.Handle(context.Background(), body)
+}
+
+func (s *InventoryConsumerSuite) Test_Handle() {
+	occurredAt := time.Date(2022, 10, 6, 10, 0, 0, 0, time.UTC)
+	event := &inventoryPb.StockEvent{
+		Type:       "back_in_stock",
+		ProductId:  7,
+		Available:  12,
+		OccurredAt: timestamppb.New(occurredAt),
+	}
+	backInStock := domain.BackInStock{ProductID: 7, Available: 12, OccurredAt: occurredAt}
+
+	s.Run("Should notify the subscriptions of a product back in stock", func() {
+		s.SetupTest()
+
+		s.app.On("NotifyBackInStock", mock.AnythingOfType("*context.valueCtx"), backInStock).Return([]domain.Notification{{ID: 1}}, nil).Once()
+
+		err := s.handle(event)
+
+		s.NoError(err)
+		s.app.AssertExpectations(s.T())
+	})
+
+	s.Run("Should acknowledge the products that don't exist anymore", func() {
+		s.SetupTest()
+
+		s.app.On("NotifyBackInStock", mock.AnythingOfType("*context.valueCtx"), backInStock).Return(nil, domain.ErrProductNotFound).Once()
+
+		err := s.handle(event)
+
+		s.NoError(err)
+	})
+
+	s.Run("Should fail in case the subscriptions can't be notified", func() {
+		s.SetupTest()
+
+		s.app.On("NotifyBackInStock", mock.AnythingOfType("*context.valueCtx"), backInStock).Return(nil, errors.New("connection refused")).Once()
+
+		err := s.handle(event)
+
+		s.EqualError(err, "connection refused")
+	})
+
+	s.Run("Should ignore the other stock events", func() {
+		s.SetupTest()
+
+		err := s.handle(&inventoryPb.StockEvent{Type: "out_of_stock", ProductId: 7})
+
+		s.NoError(err)
+		s.app.AssertNotCalled(s.T(), "NotifyBackInStock", mock.Anything, mock.Anything)
+	})
+}
+
+func TestInventoryConsumerSuite(t *testing.T) {
+	suite.Run(t, new(InventoryConsumerSuite))
+}
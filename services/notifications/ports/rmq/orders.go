@@ -0,0 +1,130 @@
+package rmq_port
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	ordersPb "github.com/lucasmls/ecommerce/services/orders/ports/rmq/proto"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// The statuses of the Orders the customers are told about
+const (
+	orderPaid      = "paid"
+	orderCancelled = "cancelled"
+)
+
+// OrderStatuses are the statuses of the OrderStatusChanged events the OrdersConsumer handles
+var OrderStatuses = []string{orderPaid, orderCancelled}
+
+// OrdersConsumer tells the customers their Orders were confirmed or cancelled
+type OrdersConsumer struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+	App    domain.Application
+}
+
+func NewOrdersConsumer(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) (*OrdersConsumer, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMisingTracer
+	}
+
+	return &OrdersConsumer{
+		Logger: logger,
+		Tracer: tracer,
+		App:    app,
+	}, nil
+}
+
+// MustNewOrdersConsumer creates a new OrdersConsumer instance.
+// It panics if any error is found
+func MustNewOrdersConsumer(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) *OrdersConsumer {
+	consumer, err := NewOrdersConsumer(logger, tracer, app)
+	if err != nil {
+		panic(err)
+	}
+
+	return consumer
+}
+
+// Handle handles an OrderStatusChanged event, it's meant to be the Handler of the rmq.Client consuming the
+// orders queue of the Notifications. The statuses the customers aren't told about are ignored.
+func (c *OrdersConsumer) Handle(ctx context.Context, body []byte) error {
+	ctx, span := c.Tracer.Start(ctx, "consumer.HandleOrderStatusChanged")
+	defer span.End()
+
+	event := &ordersPb.OrderStatusChanged{}
+	if err := proto.Unmarshal(body, event); err != nil {
+		return err
+	}
+
+	notification, ok := toOrderEvent(event)
+	if !ok {
+		return nil
+	}
+
+	_, err := c.App.Notify(ctx, notification)
+	if skippable(err) {
+		c.Logger.Warn(
+			"skipping an order event",
+			zap.Int32("orderId", event.OrderId),
+			zap.String("status", event.Status),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	return err
+}
+
+func toOrderEvent(event *ordersPb.OrderStatusChanged) (domain.Event, bool) {
+	notification := domain.Event{
+		Key:        fmt.Sprintf("orders:%d:%s", event.OrderId, event.Status),
+		CustomerID: event.CustomerId,
+		Category:   domain.CategoryOrders,
+	}
+
+	switch event.Status {
+	case orderPaid:
+		items := make([]map[string]interface{}, 0, len(event.Items))
+		for _, item := range event.Items {
+			items = append(items, map[string]interface{}{
+				"Name":      item.Name,
+				"Quantity":  int(item.Quantity),
+				"UnitPrice": int(item.UnitPrice),
+			})
+		}
+
+		notification.Template = domain.TemplateOrderConfirmed
+		notification.Data = map[string]interface{}{
+			"Number": event.Number,
+			"Items":  items,
+			"Total":  int(event.Total),
+		}
+	case orderCancelled:
+		notification.Template = domain.TemplateOrderCancelled
+		notification.Data = map[string]interface{}{
+			"Number": event.Number,
+			"Reason": event.Reason,
+		}
+	default:
+		return domain.Event{}, false
+	}
+
+	return notification, true
+}
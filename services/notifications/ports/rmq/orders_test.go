@@ -0,0 +1,124 @@
+package rmq_port
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/lucasmls/ecommerce/services/notifications/mocks"
+	ordersPb "github.com/lucasmls/ecommerce/services/orders/ports/rmq/proto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+type OrdersConsumerSuite struct {
+	suite.Suite
+
+	app      *mocks.Application
+	consumer *OrdersConsumer
+}
+
+func (s *OrdersConsumerSuite) SetupTest() {
+	s.app = &mocks.Application{}
+	s.consumer = MustNewOrdersConsumer(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), s.app)
+}
+
+func (s *OrdersConsumerSuite) handle(event *ordersPb.OrderStatusChanged) error {
+	body, err := proto.Marshal(event)
+	s.Require().NoError(err)
+
+	return s.consumer.Handle(context.Background(), body)
+}
+
+func (s *OrdersConsumerSuite) Test_Handle() {
+	s.Run("Should send the confirmation of a paid order", func() {
+		s.SetupTest()
+
+		s.app.On("Notify", mock.AnythingOfType("*context.valueCtx"), domain.Event{
+			Key:        "orders:7:paid",
+			CustomerID: "cus_1",
+			Category:   domain.CategoryOrders,
+			Template:   domain.TemplateOrderConfirmed,
+			Data: map[string]interface{}{
+				"Number": "1007",
+				"Items": []map[string]interface{}{
+					{"Name": "Keyboard", "Quantity": 2, "UnitPrice": 7990},
+				},
+				"Total": 15980,
+			},
+		}).Return([]domain.Notification{{ID: 1}}, nil).Once()
+
+		err := s.handle(&ordersPb.OrderStatusChanged{
+			OrderId:    7,
+			Number:     "1007",
+			CustomerId: "cus_1",
+			Status:     "paid",
+			From:       "awaiting_payment",
+			Items:      []*ordersPb.LineItem{{ProductId: 1, Name: "Keyboard", Quantity: 2, UnitPrice: 7990}},
+			Total:      15980,
+		})
+
+		s.NoError(err)
+		s.app.AssertExpectations(s.T())
+	})
+
+	s.Run("Should tell the reason an order was cancelled", func() {
+		s.SetupTest()
+
+		s.app.On("Notify", mock.AnythingOfType("*context.valueCtx"), domain.Event{
+			Key:        "orders:7:cancelled",
+			CustomerID: "cus_1",
+			Category:   domain.CategoryOrders,
+			Template:   domain.TemplateOrderCancelled,
+			Data:       map[string]interface{}{"Number": "1007", "Reason": "payment declined"},
+		}).Return([]domain.Notification{{ID: 1}}, nil).Once()
+
+		err := s.handle(&ordersPb.OrderStatusChanged{
+			OrderId:    7,
+			Number:     "1007",
+			CustomerId: "cus_1",
+			Status:     "cancelled",
+			Reason:     "payment declined",
+		})
+
+		s.NoError(err)
+		s.app.AssertExpectations(s.T())
+	})
+
+	s.Run("Should ignore the statuses the customers aren't told about", func() {
+		s.SetupTest()
+
+		err := s.handle(&ordersPb.OrderStatusChanged{OrderId: 7, CustomerId: "cus_1", Status: "awaiting_payment"})
+
+		s.NoError(err)
+		s.app.AssertNotCalled(s.T(), "Notify", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should acknowledge the orders of unknown recipients", func() {
+		s.SetupTest()
+
+		s.app.On("Notify", mock.AnythingOfType("*context.valueCtx"), mock.Anything).Return(nil, domain.ErrRecipientNotFound).Once()
+
+		err := s.handle(&ordersPb.OrderStatusChanged{OrderId: 7, CustomerId: "cus_1", Status: "cancelled"})
+
+		s.NoError(err)
+	})
+
+	s.Run("Should fail in case the order can't be notified", func() {
+		s.SetupTest()
+
+		s.app.On("Notify", mock.AnythingOfType("*context.valueCtx"), mock.Anything).Return(nil, errors.New("connection refused")).Once()
+
+		err := s.handle(&ordersPb.OrderStatusChanged{OrderId: 7, CustomerId: "cus_1", Status: "cancelled"})
+
+		s.EqualError(err, "connection refused")
+	})
+}
+
+func TestOrdersConsumerSuite(t *testing.T) {
+	suite.Run(t, new(OrdersConsumerSuite))
+}
@@ -0,0 +1,105 @@
+package rmq_port
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	shippingPb "github.com/lucasmls/ecommerce/services/shipping/ports/rmq/proto"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// shipmentTemplates are the templates of the statuses of the Shipments the customers are told about
+var shipmentTemplates = map[string]string{
+	"in_transit":       domain.TemplateShipmentShipped,
+	"out_for_delivery": domain.TemplateShipmentOutForDelivery,
+	"delivered":        domain.TemplateShipmentDelivered,
+}
+
+// ShipmentStatuses are the statuses of the ShipmentEvents the ShippingConsumer handles
+var ShipmentStatuses = []string{"in_transit", "out_for_delivery", "delivered"}
+
+// ShippingConsumer tells the customers where their Shipments are
+type ShippingConsumer struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+	App    domain.Application
+}
+
+func NewShippingConsumer(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) (*ShippingConsumer, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMisingTracer
+	}
+
+	return &ShippingConsumer{
+		Logger: logger,
+		Tracer: tracer,
+		App:    app,
+	}, nil
+}
+
+// MustNewShippingConsumer creates a new ShippingConsumer instance.
+// It panics if any error is found
+func MustNewShippingConsumer(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) *ShippingConsumer {
+	consumer, err := NewShippingConsumer(logger, tracer, app)
+	if err != nil {
+		panic(err)
+	}
+
+	return consumer
+}
+
+// Handle handles a ShipmentEvent, it's meant to be the Handler of the rmq.Client consuming the shipping queue
+// of the Notifications. The statuses the customers aren't told about are ignored.
+func (c *ShippingConsumer) Handle(ctx context.Context, body []byte) error {
+	ctx, span := c.Tracer.Start(ctx, "consumer.HandleShipmentEvent")
+	defer span.End()
+
+	event := &shippingPb.ShipmentEvent{}
+	if err := proto.Unmarshal(body, event); err != nil {
+		return err
+	}
+
+	template, ok := shipmentTemplates[event.Status]
+	if !ok {
+		return nil
+	}
+
+	_, err := c.App.Notify(ctx, domain.Event{
+		Key:        fmt.Sprintf("shipping:%d:%s", event.ShipmentId, event.Status),
+		CustomerID: event.CustomerId,
+		Category:   domain.CategoryShipping,
+		Template:   template,
+		Data: map[string]interface{}{
+			"OrderID":        int(event.OrderId),
+			"Carrier":        event.Carrier,
+			"TrackingNumber": event.TrackingNumber,
+			"Location":       event.Location,
+		},
+	})
+	if skippable(err) {
+		c.Logger.Warn(
+			"skipping a shipment event",
+			zap.Int32("shipmentId", event.ShipmentId),
+			zap.String("status", event.Status),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,107 @@
+package rmq_port
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lucasmls/ecommerce/services/notifications/domain"
+	"github.com/lucasmls/ecommerce/services/notifications/mocks"
+	shippingPb "github.com/lucasmls/ecommerce/services/shipping/ports/rmq/proto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+type ShippingConsumerSuite struct {
+	suite.Suite
+
+	app      *mocks.Application
+	consumer *ShippingConsumer
+}
+
+func (s *ShippingConsumerSuite) SetupTest() {
+	s.app = &mocks.Application{}
+	s.consumer = MustNewShippingConsumer(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), s.app)
+}
+
+func (s *ShippingConsumerSuite) handle(event *shippingPb.ShipmentEvent) error {
+	body, err := proto.Marshal(event)
+	s.Require().NoError(err)
+
+	return s.consumer.Handle(context.Background(), body)
+}
+
+func (s *ShippingConsumerSuite) Test_Handle() {
+	event := &shippingPb.ShipmentEvent{
+		ShipmentId:     3,
+		OrderId:        7,
+		CustomerId:     "cus_1",
+		Carrier:        "local",
+		TrackingNumber: "LC123456789",
+		Location:       "São Paulo",
+	}
+
+	cases := map[string]string{
+		"in_transit":       domain.TemplateShipmentShipped,
+		"out_for_delivery": domain.TemplateShipmentOutForDelivery,
+		"delivered":        domain.TemplateShipmentDelivered,
+	}
+
+	for status, template := range cases {
+		s.Run("Should notify a shipment "+status, func() {
+			s.SetupTest()
+
+			s.app.On("Notify", mock.AnythingOfType("*context.valueCtx"), domain.Event{
+				Key:        "shipping:3:" + status,
+				CustomerID: "cus_1",
+				Category:   domain.CategoryShipping,
+				Template:   template,
+				Data: map[string]interface{}{
+					"OrderID":        7,
+					"Carrier":        "local",
+					"TrackingNumber": "LC123456789",
+					"Location":       "São Paulo",
+				},
+			}).Return([]domain.Notification{{ID: 1}}, nil).Once()
+
+			shipped := proto.Clone(event).(*shippingPb.ShipmentEvent)
+			shipped.Status = status
+
+			err := s.handle(shipped)
+
+			s.NoError(err)
+			s.app.AssertExpectations(s.T())
+		})
+	}
+
+	s.Run("Should ignore the statuses the customers aren't told about", func() {
+		s.SetupTest()
+
+		labelled := proto.Clone(event).(*shippingPb.ShipmentEvent)
+		labelled.Status = "label_created"
+
+		err := s.handle(labelled)
+
+		s.NoError(err)
+		s.app.AssertNotCalled(s.T(), "Notify", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should acknowledge the shipments of unknown recipients", func() {
+		s.SetupTest()
+
+		s.app.On("Notify", mock.AnythingOfType("*context.valueCtx"), mock.Anything).Return(nil, domain.ErrRecipientNotFound).Once()
+
+		delivered := proto.Clone(event).(*shippingPb.ShipmentEvent)
+		delivered.Status = "delivered"
+
+		err := s.handle(delivered)
+
+		s.NoError(err)
+	})
+}
+
+func TestShippingConsumerSuite(t *testing.T) {
+	suite.Run(t, new(ShippingConsumerSuite))
+}
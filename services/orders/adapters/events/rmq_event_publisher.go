@@ -0,0 +1,70 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/orders/domain"
+	pb "github.com/lucasmls/ecommerce/services/orders/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/shared/rmq"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RmqEventPublisher publishes the OrderStatusChanged events through the orders exchange of RabbitMQ, routed by the new status.
+type RmqEventPublisher struct {
+	Logger    *zap.Logger
+	Tracer    trace.Tracer
+	Publisher rmq.Publisher
+}
+
+// NewRmqEventPublisher creates a new RmqEventPublisher.
+func NewRmqEventPublisher(logger *zap.Logger, tracer trace.Tracer, publisher rmq.Publisher) *RmqEventPublisher {
+	return &RmqEventPublisher{
+		Logger:    logger,
+		Tracer:    tracer,
+		Publisher: publisher,
+	}
+}
+
+// Publish publishes an OrderStatusChanged event, routed by the status the Order moved to
+func (p *RmqEventPublisher) Publish(ctx context.Context, event domain.OrderStatusChanged) error {
+	ctx, span := p.Tracer.Start(ctx, "events.Publish")
+	defer span.End()
+
+	order := event.Order
+	change := event.Change
+
+	items := make([]*pb.LineItem, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, &pb.LineItem{
+			ProductId: int32(item.ProductID),
+			Name:      item.Name,
+			Quantity:  int32(item.Quantity),
+			UnitPrice: int32(item.UnitPrice),
+		})
+	}
+
+	return p.Publisher.Publish(ctx, pb.Exchange, pb.EventRoutingKey(string(change.To)), &pb.OrderStatusChanged{
+		OrderId:    int32(order.ID),
+		Number:     order.Number,
+		CustomerId: order.CustomerID,
+		Status:     string(change.To),
+		From:       string(change.From),
+		Event:      string(change.Event),
+		Reason:     change.Reason,
+		Items:      items,
+		Total:      int32(order.Total()),
+		Version:    int32(order.Version),
+		OccurredAt: toProtoTimestamp(change.At),
+	})
+}
+
+func toProtoTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
@@ -0,0 +1,90 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/orders/domain"
+	pb "github.com/lucasmls/ecommerce/services/orders/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/shared/rmq/rmqtest"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type RmqEventPublisherSuite struct {
+	suite.Suite
+
+	publisher      *rmqtest.Publisher
+	eventPublisher *RmqEventPublisher
+}
+
+func (s *RmqEventPublisherSuite) SetupTest() {
+	s.publisher = &rmqtest.Publisher{}
+	s.eventPublisher = NewRmqEventPublisher(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), s.publisher)
+}
+
+func (s *RmqEventPublisherSuite) Test_Publish() {
+	s.Run("Should route the event by the status the order moved to", func() {
+		paidAt := time.Date(2022, 10, 19, 14, 5, 0, 0, time.UTC)
+
+		err := s.eventPublisher.Publish(context.Background(), domain.OrderStatusChanged{
+			Order: domain.Order{
+				ID:         7,
+				Number:     "20221019-7K3M9Q",
+				CustomerID: "42",
+				Items: []domain.LineItem{
+					{ProductID: 1, Name: "Keyboard", Quantity: 2, UnitPrice: 4990},
+					{ProductID: 2, Name: "Mouse", Quantity: 1, UnitPrice: 1990},
+				},
+				Status:  domain.OrderStatusPaid,
+				Version: 3,
+			},
+			Change: domain.StatusChange{
+				Event:  domain.OrderEventPay,
+				From:   domain.OrderStatusAwaitingPayment,
+				To:     domain.OrderStatusPaid,
+				Reason: "checkout abc",
+				At:     paidAt,
+			},
+		})
+
+		s.NoError(err)
+		s.Equal("orders", s.publisher.Exchange)
+		s.Equal("orders.paid", s.publisher.RoutingKey)
+		s.True(proto.Equal(&pb.OrderStatusChanged{
+			OrderId:    7,
+			Number:     "20221019-7K3M9Q",
+			CustomerId: "42",
+			Status:     "paid",
+			From:       "awaiting_payment",
+			Event:      "pay",
+			Reason:     "checkout abc",
+			Items: []*pb.LineItem{
+				{ProductId: 1, Name: "Keyboard", Quantity: 2, UnitPrice: 4990},
+				{ProductId: 2, Name: "Mouse", Quantity: 1, UnitPrice: 1990},
+			},
+			Total:      11970,
+			Version:    3,
+			OccurredAt: timestamppb.New(paidAt),
+		}, s.publisher.Message), s.publisher.Message)
+	})
+
+	s.Run("Should fail in case the event can't be published", func() {
+		s.publisher.Err = errors.New("connection closed")
+
+		err := s.eventPublisher.Publish(context.Background(), domain.OrderStatusChanged{
+			Change: domain.StatusChange{To: domain.OrderStatusCancelled},
+		})
+
+		s.Equal(s.publisher.Err, err)
+	})
+}
+
+func TestRmqEventPublisherSuite(t *testing.T) {
+	suite.Run(t, new(RmqEventPublisherSuite))
+}
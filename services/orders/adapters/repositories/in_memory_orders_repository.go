@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/lucasmls/ecommerce/services/orders/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// InMemoryOrdersRepository keeps the Orders in memory.
+type InMemoryOrdersRepository struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	// mu guards orders, whose IDs are their positions in the slice, and numbers, which maps the Order numbers to their IDs
+	mu      sync.Mutex
+	orders  []domain.Order
+	numbers map[string]int
+}
+
+// NewInMemoryOrdersRepository creates a new InMemoryOrdersRepository.
+func NewInMemoryOrdersRepository(logger *zap.Logger, tracer trace.Tracer) *InMemoryOrdersRepository {
+	return &InMemoryOrdersRepository{
+		Logger:  logger,
+		Tracer:  tracer,
+		numbers: map[string]int{},
+	}
+}
+
+// Create stores a new Order in memory.
+func (r *InMemoryOrdersRepository) Create(ctx context.Context, order domain.Order) (domain.Order, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Create")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.numbers[order.Number]; ok {
+		return domain.Order{}, domain.ErrOrderNumberTaken
+	}
+
+	order = copyOrder(order)
+	order.ID = len(r.orders) + 1
+	order.Version = 1
+
+	r.orders = append(r.orders, order)
+	r.numbers[order.Number] = order.ID
+
+	return copyOrder(order), nil
+}
+
+// Get fetches a single Order from memory.
+func (r *InMemoryOrdersRepository) Get(ctx context.Context, id int) (domain.Order, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Get")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if id <= 0 || id > len(r.orders) {
+		return domain.Order{}, domain.ErrOrderNotFound
+	}
+
+	return copyOrder(r.orders[id-1]), nil
+}
+
+// List lists the Orders matching the filter from memory, most recently placed first.
+func (r *InMemoryOrdersRepository) List(ctx context.Context, filter domain.OrderFilter) ([]domain.Order, error) {
+	_, span := r.Tracer.Start(ctx, "repository.List")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := []domain.Order{}
+	for _, order := range r.orders {
+		if filter.CustomerID != "" && order.CustomerID != filter.CustomerID {
+			continue
+		}
+
+		if filter.Status != "" && order.Status != filter.Status {
+			continue
+		}
+
+		result = append(result, copyOrder(order))
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if !result[i].PlacedAt.Equal(result[j].PlacedAt) {
+			return result[i].PlacedAt.After(result[j].PlacedAt)
+		}
+
+		return result[i].ID > result[j].ID
+	})
+
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[:filter.Limit]
+	}
+
+	return result, nil
+}
+
+// Update replaces an Order in memory as long as its Version matches the stored one.
+func (r *InMemoryOrdersRepository) Update(ctx context.Context, order domain.Order) (domain.Order, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Update")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if order.ID <= 0 || order.ID > len(r.orders) {
+		return domain.Order{}, domain.ErrOrderNotFound
+	}
+
+	if r.orders[order.ID-1].Version != order.Version {
+		return domain.Order{}, domain.ErrOrderVersionConflict
+	}
+
+	order = copyOrder(order)
+	order.Version++
+
+	r.orders[order.ID-1] = order
+
+	return copyOrder(order), nil
+}
+
+// copyOrder keeps the stored Items and StatusHistory from being changed through the Orders handed out by the repository
+func copyOrder(order domain.Order) domain.Order {
+	order.Items = append([]domain.LineItem{}, order.Items...)
+	order.StatusHistory = append([]domain.StatusChange{}, order.StatusHistory...)
+	return order
+}
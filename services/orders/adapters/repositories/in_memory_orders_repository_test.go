@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/orders/domain"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type InMemoryOrdersRepositorySuite struct {
+	suite.Suite
+
+	ordersRepo *InMemoryOrdersRepository
+	placedAt   time.Time
+}
+
+func (s *InMemoryOrdersRepositorySuite) SetupTest() {
+	s.ordersRepo = NewInMemoryOrdersRepository(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""))
+	s.placedAt = time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+}
+
+func (s *InMemoryOrdersRepositorySuite) create(number string, customerID string, placedAt time.Time) domain.Order {
+	order, err := s.ordersRepo.Create(context.Background(), domain.Order{
+		Number:     number,
+		CustomerID: customerID,
+		Items:      []domain.LineItem{{ProductID: 1, Name: "iPhone 13", Quantity: 1, UnitPrice: 79900}},
+		Status:     domain.OrderStatusPending,
+		PlacedAt:   placedAt,
+		UpdatedAt:  placedAt,
+	})
+	s.Require().NoError(err)
+
+	return order
+}
+
+func (s *InMemoryOrdersRepositorySuite) Test_Create() {
+	s.Run("Should set the ID and the first version of the order", func() {
+		order := s.create("20220501-AAAAAA", "john", s.placedAt)
+
+		s.Equal(1, order.ID)
+		s.Equal(1, order.Version)
+
+		got, err := s.ordersRepo.Get(context.Background(), order.ID)
+		s.NoError(err)
+		s.Equal(order, got)
+	})
+
+	s.Run("Should fail in case the order number is taken", func() {
+		_, err := s.ordersRepo.Create(context.Background(), domain.Order{Number: "20220501-AAAAAA"})
+
+		s.Equal(domain.ErrOrderNumberTaken, err)
+	})
+}
+
+func (s *InMemoryOrdersRepositorySuite) Test_Get() {
+	for _, id := range []int{0, 1} {
+		_, err := s.ordersRepo.Get(context.Background(), id)
+
+		s.Equal(domain.ErrOrderNotFound, err)
+	}
+}
+
+func (s *InMemoryOrdersRepositorySuite) Test_List() {
+	first := s.create("20220501-AAAAAA", "john", s.placedAt)
+	second := s.create("20220501-BBBBBB", "jane", s.placedAt.Add(time.Hour))
+	third := s.create("20220501-CCCCCC", "john", s.placedAt.Add(time.Hour))
+
+	third.Status = domain.OrderStatusCancelled
+	third, err := s.ordersRepo.Update(context.Background(), third)
+	s.Require().NoError(err)
+
+	s.Run("Should list the most recently placed orders first", func() {
+		got, err := s.ordersRepo.List(context.Background(), domain.OrderFilter{})
+
+		s.NoError(err)
+		s.Equal([]domain.Order{third, second, first}, got)
+	})
+
+	s.Run("Should list the orders of the customer with the status", func() {
+		got, err := s.ordersRepo.List(context.Background(), domain.OrderFilter{CustomerID: "john", Status: domain.OrderStatusPending})
+
+		s.NoError(err)
+		s.Equal([]domain.Order{first}, got)
+	})
+
+	s.Run("Should list up to the limit", func() {
+		got, err := s.ordersRepo.List(context.Background(), domain.OrderFilter{Limit: 1})
+
+		s.NoError(err)
+		s.Equal([]domain.Order{third}, got)
+	})
+}
+
+func (s *InMemoryOrdersRepositorySuite) Test_Update() {
+	order := s.create("20220501-AAAAAA", "john", s.placedAt)
+
+	s.Run("Should replace the order and increment its version", func() {
+		s.Require().NoError(order.Apply(domain.OrderEventSubmit, "", s.placedAt))
+
+		updated, err := s.ordersRepo.Update(context.Background(), order)
+
+		s.NoError(err)
+		s.Equal(2, updated.Version)
+		s.Equal(domain.OrderStatusAwaitingPayment, updated.Status)
+		s.Len(updated.StatusHistory, 1)
+	})
+
+	s.Run("Should fail in case the order was changed since it was read", func() {
+		_, err := s.ordersRepo.Update(context.Background(), order)
+
+		s.Equal(domain.ErrOrderVersionConflict, err)
+	})
+
+	s.Run("Should fail in case the order doesn't exist", func() {
+		_, err := s.ordersRepo.Update(context.Background(), domain.Order{ID: 2, Version: 1})
+
+		s.Equal(domain.ErrOrderNotFound, err)
+	})
+}
+
+func TestInMemoryOrdersRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InMemoryOrdersRepositorySuite))
+}
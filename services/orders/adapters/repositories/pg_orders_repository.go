@@ -0,0 +1,271 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/orders/domain"
+	"github.com/lucasmls/ecommerce/shared/pg"
+)
+
+const orderColumns = "id, number, customer_id, items, status, status_history, version, placed_at, updated_at"
+
+// lineItemRecord is how a LineItem is stored on the items column of an order
+type lineItemRecord struct {
+	ProductID int    `json:"product_id"`
+	Name      string `json:"name"`
+	Quantity  int    `json:"quantity"`
+	UnitPrice int    `json:"unit_price"`
+}
+
+// statusChangeRecord is how a StatusChange is stored on the status_history column of an order
+type statusChangeRecord struct {
+	Event  string    `json:"event"`
+	From   string    `json:"from"`
+	To     string    `json:"to"`
+	Reason string    `json:"reason,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+// PgOrdersRepository keeps the Orders on Postgres, each one on a single row.
+// The rows are only updated while their version is still the one the Order was read with,
+// so concurrent transitions can't skip the state machine.
+type PgOrdersRepository struct {
+	db *sql.DB
+}
+
+func NewPgOrdersRepository(connectionString string) (*PgOrdersRepository, error) {
+	db, err := sql.Open(
+		"postgres",
+		connectionString,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	repository := &PgOrdersRepository{
+		db: db,
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+func MustNewPgOrdersRepository(connectionString string) *PgOrdersRepository {
+	repo, err := NewPgOrdersRepository(connectionString)
+	if err != nil {
+		panic(err)
+	}
+
+	return repo
+}
+
+// Create inserts a new Order
+func (r *PgOrdersRepository) Create(ctx context.Context, order domain.Order) (domain.Order, error) {
+	items, history, err := marshalOrder(order)
+	if err != nil {
+		return domain.Order{}, err
+	}
+
+	row := r.db.QueryRowContext(
+		ctx,
+		`INSERT INTO orders (number, customer_id, items, status, status_history, version, placed_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, 1, $6, $7)
+		RETURNING `+orderColumns,
+		order.Number, order.CustomerID, items, string(order.Status), history, order.PlacedAt, order.UpdatedAt,
+	)
+
+	created, err := scanOrder(row)
+	if pg.IsUniqueViolation(err) {
+		return domain.Order{}, domain.ErrOrderNumberTaken
+	}
+
+	return created, err
+}
+
+// Get fetches a single Order
+func (r *PgOrdersRepository) Get(ctx context.Context, id int) (domain.Order, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+orderColumns+" FROM orders WHERE id = $1", id)
+
+	order, err := scanOrder(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Order{}, domain.ErrOrderNotFound
+	}
+
+	return order, err
+}
+
+// List lists the Orders matching the filter, most recently placed first
+func (r *PgOrdersRepository) List(ctx context.Context, filter domain.OrderFilter) ([]domain.Order, error) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	if filter.CustomerID != "" {
+		args = append(args, filter.CustomerID)
+		conditions = append(conditions, fmt.Sprintf("customer_id = $%d", len(args)))
+	}
+
+	if filter.Status != "" {
+		args = append(args, string(filter.Status))
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	query := "SELECT " + orderColumns + " FROM orders"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY placed_at DESC, id DESC"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := []domain.Order{}
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, order)
+	}
+
+	return orders, rows.Err()
+}
+
+// Update replaces an Order as long as the stored version matches
+func (r *PgOrdersRepository) Update(ctx context.Context, order domain.Order) (domain.Order, error) {
+	items, history, err := marshalOrder(order)
+	if err != nil {
+		return domain.Order{}, err
+	}
+
+	row := r.db.QueryRowContext(
+		ctx,
+		`UPDATE orders
+		SET items = $3, status = $4, status_history = $5, version = version + 1, updated_at = $6
+		WHERE id = $1 AND version = $2
+		RETURNING `+orderColumns,
+		order.ID, order.Version, items, string(order.Status), history, order.UpdatedAt,
+	)
+
+	updated, err := scanOrder(row)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return updated, err
+	}
+
+	// nothing was updated, either because the order doesn't exist or because its version changed
+	if _, err := r.Get(ctx, order.ID); err != nil {
+		return domain.Order{}, err
+	}
+
+	return domain.Order{}, domain.ErrOrderVersionConflict
+}
+
+func marshalOrder(order domain.Order) ([]byte, []byte, error) {
+	itemRecords := make([]lineItemRecord, 0, len(order.Items))
+	for _, item := range order.Items {
+		itemRecords = append(itemRecords, lineItemRecord{
+			ProductID: item.ProductID,
+			Name:      item.Name,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice,
+		})
+	}
+
+	historyRecords := make([]statusChangeRecord, 0, len(order.StatusHistory))
+	for _, change := range order.StatusHistory {
+		historyRecords = append(historyRecords, statusChangeRecord{
+			Event:  string(change.Event),
+			From:   string(change.From),
+			To:     string(change.To),
+			Reason: change.Reason,
+			At:     change.At,
+		})
+	}
+
+	items, err := json.Marshal(itemRecords)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	history, err := json.Marshal(historyRecords)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return items, history, nil
+}
+
+func scanOrder(row pg.Scanner) (domain.Order, error) {
+	var order domain.Order
+	var status string
+	var items []byte
+	var history []byte
+
+	err := row.Scan(
+		&order.ID,
+		&order.Number,
+		&order.CustomerID,
+		&items,
+		&status,
+		&history,
+		&order.Version,
+		&order.PlacedAt,
+		&order.UpdatedAt,
+	)
+	if err != nil {
+		return domain.Order{}, err
+	}
+
+	var itemRecords []lineItemRecord
+	if err := json.Unmarshal(items, &itemRecords); err != nil {
+		return domain.Order{}, err
+	}
+
+	var historyRecords []statusChangeRecord
+	if err := json.Unmarshal(history, &historyRecords); err != nil {
+		return domain.Order{}, err
+	}
+
+	order.Status = domain.OrderStatus(status)
+
+	order.Items = make([]domain.LineItem, 0, len(itemRecords))
+	for _, record := range itemRecords {
+		order.Items = append(order.Items, domain.LineItem{
+			ProductID: record.ProductID,
+			Name:      record.Name,
+			Quantity:  record.Quantity,
+			UnitPrice: record.UnitPrice,
+		})
+	}
+
+	order.StatusHistory = make([]domain.StatusChange, 0, len(historyRecords))
+	for _, record := range historyRecords {
+		order.StatusHistory = append(order.StatusHistory, domain.StatusChange{
+			Event:  domain.OrderEvent(record.Event),
+			From:   domain.OrderStatus(record.From),
+			To:     domain.OrderStatus(record.To),
+			Reason: record.Reason,
+			At:     record.At,
+		})
+	}
+
+	return order, nil
+}
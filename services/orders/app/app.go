@@ -0,0 +1,47 @@
+package app
+
+import (
+	"github.com/lucasmls/ecommerce/services/orders/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// application holds all dependencies the Application needs to work
+type application struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	OrdersRepository domain.OrdersRepository
+	Catalog          domain.Catalog
+	EventPublisher   domain.EventPublisher
+}
+
+// NewApplication creates a new Application instance
+func NewApplication(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	ordersRepository domain.OrdersRepository,
+	catalog domain.Catalog,
+	eventPublisher domain.EventPublisher,
+) application {
+	return application{
+		Logger:           logger,
+		Tracer:           tracer,
+		OrdersRepository: ordersRepository,
+		Catalog:          catalog,
+		EventPublisher:   eventPublisher,
+	}
+}
+
+// MustNewApplication creates a new Application instance
+// It panics if any error is found
+func MustNewApplication(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	ordersRepository domain.OrdersRepository,
+	catalog domain.Catalog,
+	eventPublisher domain.EventPublisher,
+) application {
+	app := NewApplication(logger, tracer, ordersRepository, catalog, eventPublisher)
+	return app
+}
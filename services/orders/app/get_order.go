@@ -0,0 +1,18 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/orders/domain"
+)
+
+func (a application) GetOrder(ctx context.Context, id int) (domain.Order, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.GetOrder")
+	defer span.End()
+
+	if id <= 0 {
+		return domain.Order{}, domain.ErrOrderNotFound
+	}
+
+	return a.OrdersRepository.Get(ctx, id)
+}
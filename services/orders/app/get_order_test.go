@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/orders/domain"
+	"github.com/lucasmls/ecommerce/services/orders/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type GetOrderSuite struct {
+	suite.Suite
+
+	ordersRepo *mocks.OrdersRepository
+	app        domain.Application
+}
+
+func (s *GetOrderSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.ordersRepo = &mocks.OrdersRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.ordersRepo, &mocks.Catalog{}, &mocks.EventPublisher{})
+}
+
+func (s *GetOrderSuite) Test_GetOrder() {
+	s.Run("Should fail in case the id is invalid", func() {
+		_, err := s.app.GetOrder(context.Background(), 0)
+
+		s.Equal(domain.ErrOrderNotFound, err)
+	})
+
+	s.Run("Should fail in case the order doesn't exist", func() {
+		s.ordersRepo.
+			On("Get", mock.AnythingOfType("*context.valueCtx"), 2).
+			Return(domain.Order{}, domain.ErrOrderNotFound).
+			Once()
+
+		_, err := s.app.GetOrder(context.Background(), 2)
+
+		s.Equal(domain.ErrOrderNotFound, err)
+	})
+
+	s.Run("Should get the order", func() {
+		order := domain.Order{ID: 1, Number: "20221019-7K3M9Q", Status: domain.OrderStatusPaid, Version: 3}
+
+		s.ordersRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), 1).Return(order, nil).Once()
+
+		got, err := s.app.GetOrder(context.Background(), 1)
+
+		s.NoError(err)
+		s.Equal(order, got)
+	})
+}
+
+func TestGetOrderSuite(t *testing.T) {
+	suite.Run(t, new(GetOrderSuite))
+}
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/orders/domain"
+)
+
+func (a application) ListOrders(ctx context.Context, filter domain.OrderFilter) ([]domain.Order, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.ListOrders")
+	defer span.End()
+
+	if filter.Status != "" {
+		if err := filter.Status.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if filter.Limit < 0 || filter.Limit > domain.MaxOrdersLimit {
+		return nil, domain.ErrInvalidOrder
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = domain.DefaultOrdersLimit
+	}
+
+	return a.OrdersRepository.List(ctx, filter)
+}
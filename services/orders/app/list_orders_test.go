@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/orders/domain"
+	"github.com/lucasmls/ecommerce/services/orders/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ListOrdersSuite struct {
+	suite.Suite
+
+	ordersRepo *mocks.OrdersRepository
+	app        domain.Application
+}
+
+func (s *ListOrdersSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.ordersRepo = &mocks.OrdersRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.ordersRepo, &mocks.Catalog{}, &mocks.EventPublisher{})
+}
+
+func (s *ListOrdersSuite) Test_ListOrders() {
+	s.Run("Should fail in case the filter is invalid", func() {
+		filters := []domain.OrderFilter{
+			{Status: "shipped"},
+			{Limit: -1},
+			{Limit: domain.MaxOrdersLimit + 1},
+		}
+
+		for _, filter := range filters {
+			_, err := s.app.ListOrders(context.Background(), filter)
+
+			s.Equal(domain.ErrInvalidOrder, err)
+		}
+	})
+
+	s.Run("Should list the orders with the default limit", func() {
+		orders := []domain.Order{{ID: 2}, {ID: 1}}
+
+		s.ordersRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.OrderFilter{
+				CustomerID: "john",
+				Status:     domain.OrderStatusPaid,
+				Limit:      domain.DefaultOrdersLimit,
+			}).
+			Return(orders, nil).
+			Once()
+
+		got, err := s.app.ListOrders(context.Background(), domain.OrderFilter{CustomerID: "john", Status: domain.OrderStatusPaid})
+
+		s.NoError(err)
+		s.Equal(orders, got)
+	})
+
+	s.Run("Should keep the limit asked for", func() {
+		s.ordersRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.OrderFilter{CustomerID: "john", Limit: 5}).
+			Return([]domain.Order{}, nil).
+			Once()
+
+		got, err := s.app.ListOrders(context.Background(), domain.OrderFilter{CustomerID: "john", Limit: 5})
+
+		s.NoError(err)
+		s.Empty(got)
+	})
+}
+
+func TestListOrdersSuite(t *testing.T) {
+	suite.Run(t, new(ListOrdersSuite))
+}
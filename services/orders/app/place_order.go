@@ -0,0 +1,128 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/orders/domain"
+	"go.uber.org/zap"
+)
+
+// maxPlaceAttempts bounds how many Order numbers are generated when they clash with existing ones
+const maxPlaceAttempts = 3
+
+// orderNumberAlphabet leaves out the letters mistaken for digits (I, L, O) and U, so the numbers can be read over the phone
+const orderNumberAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// orderNumberLength is the amount of random characters of an Order number, about a billion numbers a day
+const orderNumberLength = 6
+
+func (a application) PlaceOrder(ctx context.Context, request domain.OrderRequest) (domain.Order, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.PlaceOrder")
+	defer span.End()
+
+	a.Logger.Info("placing an order", zap.Any("request", request))
+
+	if err := request.Validate(); err != nil {
+		return domain.Order{}, err
+	}
+
+	requested, err := mergeItems(request.Items)
+	if err != nil {
+		return domain.Order{}, err
+	}
+
+	productIDs := make([]int, 0, len(requested))
+	for _, item := range requested {
+		productIDs = append(productIDs, item.ProductID)
+	}
+
+	products, err := a.Catalog.Products(ctx, productIDs)
+	if err != nil {
+		return domain.Order{}, err
+	}
+
+	items := make([]domain.LineItem, 0, len(requested))
+	for _, item := range requested {
+		product, ok := products[item.ProductID]
+		if !ok {
+			return domain.Order{}, fmt.Errorf("%w: product %d", domain.ErrProductUnavailable, item.ProductID)
+		}
+
+		items = append(items, domain.LineItem{
+			ProductID: product.ID,
+			Name:      product.Name,
+			Quantity:  item.Quantity,
+			UnitPrice: product.Price,
+		})
+	}
+
+	now := time.Now()
+
+	for attempt := 1; attempt <= maxPlaceAttempts; attempt++ {
+		number, err := newOrderNumber(now)
+		if err != nil {
+			return domain.Order{}, err
+		}
+
+		var order domain.Order
+		order, err = a.OrdersRepository.Create(ctx, domain.Order{
+			Number:     number,
+			CustomerID: request.CustomerID,
+			Items:      items,
+			Status:     domain.OrderStatusPending,
+			PlacedAt:   now,
+			UpdatedAt:  now,
+		})
+		if !errors.Is(err, domain.ErrOrderNumberTaken) {
+			return order, err
+		}
+
+		a.Logger.Info("the order number is taken, trying another one", zap.String("number", number), zap.Int("attempt", attempt))
+	}
+
+	return domain.Order{}, domain.ErrOrderNumberTaken
+}
+
+// mergeItems adds up the units of the Products asked for more than once, keeping the order they were first asked for
+func mergeItems(items []domain.OrderItemRequest) ([]domain.OrderItemRequest, error) {
+	merged := make([]domain.OrderItemRequest, 0, len(items))
+	positions := map[int]int{}
+
+	for _, item := range items {
+		i, ok := positions[item.ProductID]
+		if !ok {
+			positions[item.ProductID] = len(merged)
+			merged = append(merged, item)
+			continue
+		}
+
+		merged[i].Quantity += item.Quantity
+		if merged[i].Quantity > domain.MaxItemQuantity {
+			return nil, domain.ErrInvalidQuantity
+		}
+	}
+
+	return merged, nil
+}
+
+// newOrderNumber generates an Order number made of the day it was placed and random characters, e.g. 20221019-7K3M9Q
+func newOrderNumber(placedAt time.Time) (string, error) {
+	alphabetLength := big.NewInt(int64(len(orderNumberAlphabet)))
+
+	random := make([]byte, orderNumberLength)
+	for i := range random {
+		n, err := rand.Int(rand.Reader, alphabetLength)
+		if err != nil {
+			return "", err
+		}
+
+		random[i] = orderNumberAlphabet[n.Int64()]
+	}
+
+	return placedAt.UTC().Format("20060102") + "-" + string(random), nil
+}
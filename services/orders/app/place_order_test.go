@@ -0,0 +1,182 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/orders/domain"
+	"github.com/lucasmls/ecommerce/services/orders/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// orderNumberPattern matches the Order numbers generated by newOrderNumber
+var orderNumberPattern = regexp.MustCompile(`^\d{8}-[0-9A-HJKMNP-TV-Z]{6}$`)
+
+type PlaceOrderSuite struct {
+	suite.Suite
+
+	ordersRepo *mocks.OrdersRepository
+	catalog    *mocks.Catalog
+	app        domain.Application
+}
+
+func (s *PlaceOrderSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.ordersRepo = &mocks.OrdersRepository{}
+	s.catalog = &mocks.Catalog{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.ordersRepo, s.catalog, &mocks.EventPublisher{})
+}
+
+func (s *PlaceOrderSuite) Test_PlaceOrder() {
+	products := map[int]domain.ProductSnapshot{
+		1: {ID: 1, Name: "iPhone 13", Price: 79900},
+		2: {ID: 2, Name: "MacBook", Price: 129900},
+	}
+
+	s.Run("Should fail in case the request is invalid", func() {
+		testCases := []struct {
+			request domain.OrderRequest
+			err     error
+		}{
+			{request: domain.OrderRequest{Items: []domain.OrderItemRequest{{ProductID: 1, Quantity: 1}}}, err: domain.ErrInvalidOrder},
+			{request: domain.OrderRequest{CustomerID: "john"}, err: domain.ErrEmptyOrder},
+			{request: domain.OrderRequest{CustomerID: "john", Items: []domain.OrderItemRequest{{ProductID: 0, Quantity: 1}}}, err: domain.ErrInvalidOrder},
+			{request: domain.OrderRequest{CustomerID: "john", Items: []domain.OrderItemRequest{{ProductID: 1, Quantity: 0}}}, err: domain.ErrInvalidQuantity},
+			{request: domain.OrderRequest{CustomerID: "john", Items: []domain.OrderItemRequest{{ProductID: 1, Quantity: 100}}}, err: domain.ErrInvalidQuantity},
+		}
+
+		for _, testCase := range testCases {
+			_, err := s.app.PlaceOrder(context.Background(), testCase.request)
+
+			s.Equal(testCase.err, err)
+		}
+	})
+
+	s.Run("Should fail in case the units of a product asked for more than once add up to too many", func() {
+		_, err := s.app.PlaceOrder(context.Background(), domain.OrderRequest{
+			CustomerID: "john",
+			Items:      []domain.OrderItemRequest{{ProductID: 1, Quantity: 50}, {ProductID: 1, Quantity: 50}},
+		})
+
+		s.Equal(domain.ErrInvalidQuantity, err)
+	})
+
+	s.Run("Should fail in case a product is unavailable", func() {
+		s.catalog.
+			On("Products", mock.AnythingOfType("*context.valueCtx"), []int{1, 3}).
+			Return(products, nil).
+			Once()
+
+		_, err := s.app.PlaceOrder(context.Background(), domain.OrderRequest{
+			CustomerID: "john",
+			Items:      []domain.OrderItemRequest{{ProductID: 1, Quantity: 1}, {ProductID: 3, Quantity: 1}},
+		})
+
+		s.ErrorIs(err, domain.ErrProductUnavailable)
+	})
+
+	s.Run("Should fail in case the catalog can't be reached", func() {
+		s.catalog.
+			On("Products", mock.AnythingOfType("*context.valueCtx"), []int{1}).
+			Return(nil, errors.New("connection refused")).
+			Once()
+
+		_, err := s.app.PlaceOrder(context.Background(), domain.OrderRequest{
+			CustomerID: "john",
+			Items:      []domain.OrderItemRequest{{ProductID: 1, Quantity: 1}},
+		})
+
+		s.EqualError(err, "connection refused")
+	})
+
+	s.Run("Should place a pending order snapshotting the products", func() {
+		s.catalog.
+			On("Products", mock.AnythingOfType("*context.valueCtx"), []int{2, 1}).
+			Return(products, nil).
+			Once()
+
+		placed := domain.Order{ID: 1, Number: "20221019-7K3M9Q", Version: 1}
+
+		s.ordersRepo.
+			On("Create", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(order domain.Order) bool {
+				return order.CustomerID == "john" &&
+					order.Status == domain.OrderStatusPending &&
+					orderNumberPattern.MatchString(order.Number) &&
+					len(order.Items) == 2 &&
+					order.Items[0] == domain.LineItem{ProductID: 2, Name: "MacBook", Quantity: 1, UnitPrice: 129900} &&
+					order.Items[1] == domain.LineItem{ProductID: 1, Name: "iPhone 13", Quantity: 3, UnitPrice: 79900}
+			})).
+			Return(placed, nil).
+			Once()
+
+		got, err := s.app.PlaceOrder(context.Background(), domain.OrderRequest{
+			CustomerID: "john",
+			Items: []domain.OrderItemRequest{
+				{ProductID: 2, Quantity: 1},
+				{ProductID: 1, Quantity: 1},
+				{ProductID: 1, Quantity: 2},
+			},
+		})
+
+		s.NoError(err)
+		s.Equal(placed, got)
+	})
+
+	s.Run("Should generate another number in case it's taken", func() {
+		s.catalog.
+			On("Products", mock.AnythingOfType("*context.valueCtx"), []int{1}).
+			Return(products, nil).
+			Once()
+
+		s.ordersRepo.
+			On("Create", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Order")).
+			Return(domain.Order{}, domain.ErrOrderNumberTaken).
+			Once()
+
+		placed := domain.Order{ID: 2, Version: 1}
+
+		s.ordersRepo.
+			On("Create", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Order")).
+			Return(placed, nil).
+			Once()
+
+		got, err := s.app.PlaceOrder(context.Background(), domain.OrderRequest{
+			CustomerID: "john",
+			Items:      []domain.OrderItemRequest{{ProductID: 1, Quantity: 1}},
+		})
+
+		s.NoError(err)
+		s.Equal(placed, got)
+	})
+
+	s.Run("Should give up in case every number generated is taken", func() {
+		s.catalog.
+			On("Products", mock.AnythingOfType("*context.valueCtx"), []int{1}).
+			Return(products, nil).
+			Once()
+
+		s.ordersRepo.
+			On("Create", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Order")).
+			Return(domain.Order{}, domain.ErrOrderNumberTaken).
+			Times(maxPlaceAttempts)
+
+		_, err := s.app.PlaceOrder(context.Background(), domain.OrderRequest{
+			CustomerID: "john",
+			Items:      []domain.OrderItemRequest{{ProductID: 1, Quantity: 1}},
+		})
+
+		s.Equal(domain.ErrOrderNumberTaken, err)
+	})
+}
+
+func TestPlaceOrderSuite(t *testing.T) {
+	suite.Run(t, new(PlaceOrderSuite))
+}
@@ -0,0 +1,21 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/orders/domain"
+	"go.uber.org/zap"
+)
+
+// publish tells the other services an Order moved to another status once it's stored.
+// The event is only logged when it can't be published, the Order already changed.
+func (a application) publish(ctx context.Context, order domain.Order, change domain.StatusChange) {
+	if err := a.EventPublisher.Publish(ctx, domain.OrderStatusChanged{Order: order, Change: change}); err != nil {
+		a.Logger.Error(
+			"failed to publish an order event",
+			zap.Int("orderId", order.ID),
+			zap.String("status", string(change.To)),
+			zap.Error(err),
+		)
+	}
+}
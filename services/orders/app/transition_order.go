@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/orders/domain"
+	"go.uber.org/zap"
+)
+
+// maxTransitionAttempts bounds how many times an OrderEvent is applied again when the Order changes concurrently
+const maxTransitionAttempts = 3
+
+func (a application) TransitionOrder(ctx context.Context, transition domain.OrderTransition) (domain.Order, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.TransitionOrder")
+	defer span.End()
+
+	a.Logger.Info("transitioning an order", zap.Any("transition", transition))
+
+	if err := transition.Validate(); err != nil {
+		return domain.Order{}, err
+	}
+
+	var err error
+	for attempt := 1; attempt <= maxTransitionAttempts; attempt++ {
+		var order domain.Order
+		order, err = a.OrdersRepository.Get(ctx, transition.OrderID)
+		if err != nil {
+			return domain.Order{}, err
+		}
+
+		// the state machine is checked against the current status on every attempt, since a concurrent
+		// transition may have made the event invalid
+		if err := order.Apply(transition.Event, transition.Reason, time.Now()); err != nil {
+			return domain.Order{}, err
+		}
+
+		change := order.StatusHistory[len(order.StatusHistory)-1]
+
+		order, err = a.OrdersRepository.Update(ctx, order)
+		if err == nil {
+			a.publish(ctx, order, change)
+			return order, nil
+		}
+
+		if !errors.Is(err, domain.ErrOrderVersionConflict) {
+			return domain.Order{}, err
+		}
+
+		a.Logger.Info("the order changed while transitioning it, trying again", zap.Int("attempt", attempt))
+	}
+
+	return domain.Order{}, err
+}
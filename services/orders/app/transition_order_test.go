@@ -0,0 +1,211 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/orders/domain"
+	"github.com/lucasmls/ecommerce/services/orders/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type TransitionOrderSuite struct {
+	suite.Suite
+
+	ordersRepo *mocks.OrdersRepository
+	publisher  *mocks.EventPublisher
+	app        domain.Application
+}
+
+func (s *TransitionOrderSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.ordersRepo = &mocks.OrdersRepository{}
+	s.publisher = &mocks.EventPublisher{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.ordersRepo, &mocks.Catalog{}, s.publisher)
+}
+
+func (s *TransitionOrderSuite) Test_TransitionOrder() {
+	s.Run("Should fail in case the transition is invalid", func() {
+		testCases := []struct {
+			transition domain.OrderTransition
+			err        error
+		}{
+			{transition: domain.OrderTransition{OrderID: 0, Event: domain.OrderEventPay}, err: domain.ErrInvalidOrder},
+			{transition: domain.OrderTransition{OrderID: 1, Event: "ship"}, err: domain.ErrInvalidOrderEvent},
+		}
+
+		for _, testCase := range testCases {
+			_, err := s.app.TransitionOrder(context.Background(), testCase.transition)
+
+			s.Equal(testCase.err, err)
+		}
+	})
+
+	s.Run("Should follow the state machine", func() {
+		testCases := []struct {
+			from  domain.OrderStatus
+			event domain.OrderEvent
+			to    domain.OrderStatus
+			err   error
+		}{
+			{from: domain.OrderStatusPending, event: domain.OrderEventSubmit, to: domain.OrderStatusAwaitingPayment},
+			{from: domain.OrderStatusAwaitingPayment, event: domain.OrderEventPay, to: domain.OrderStatusPaid},
+			{from: domain.OrderStatusPaid, event: domain.OrderEventFulfill, to: domain.OrderStatusFulfilled},
+			{from: domain.OrderStatusPending, event: domain.OrderEventCancel, to: domain.OrderStatusCancelled},
+			{from: domain.OrderStatusAwaitingPayment, event: domain.OrderEventCancel, to: domain.OrderStatusCancelled},
+			{from: domain.OrderStatusPaid, event: domain.OrderEventRefund, to: domain.OrderStatusRefunded},
+			{from: domain.OrderStatusFulfilled, event: domain.OrderEventRefund, to: domain.OrderStatusRefunded},
+			{from: domain.OrderStatusPending, event: domain.OrderEventPay, err: domain.ErrInvalidTransition},
+			{from: domain.OrderStatusPending, event: domain.OrderEventFulfill, err: domain.ErrInvalidTransition},
+			{from: domain.OrderStatusAwaitingPayment, event: domain.OrderEventRefund, err: domain.ErrInvalidTransition},
+			{from: domain.OrderStatusPaid, event: domain.OrderEventCancel, err: domain.ErrInvalidTransition},
+			{from: domain.OrderStatusCancelled, event: domain.OrderEventSubmit, err: domain.ErrInvalidTransition},
+			{from: domain.OrderStatusRefunded, event: domain.OrderEventFulfill, err: domain.ErrInvalidTransition},
+		}
+
+		for _, testCase := range testCases {
+			testCase := testCase
+
+			s.ordersRepo.
+				On("Get", mock.AnythingOfType("*context.valueCtx"), 1).
+				Return(domain.Order{ID: 1, Status: testCase.from, Version: 2}, nil).
+				Once()
+
+			if testCase.err == nil {
+				s.ordersRepo.
+					On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(order domain.Order) bool {
+						return order.Status == testCase.to &&
+							order.Version == 2 &&
+							len(order.StatusHistory) == 1 &&
+							order.StatusHistory[0].From == testCase.from &&
+							order.StatusHistory[0].Event == testCase.event
+					})).
+					Return(domain.Order{ID: 1, Status: testCase.to, Version: 3}, nil).
+					Once()
+
+				s.publisher.
+					On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(event domain.OrderStatusChanged) bool {
+						return event.Order.ID == 1 && event.Change.From == testCase.from && event.Change.To == testCase.to
+					})).
+					Return(nil).
+					Once()
+			}
+
+			got, err := s.app.TransitionOrder(context.Background(), domain.OrderTransition{OrderID: 1, Event: testCase.event})
+
+			s.Equal(testCase.err, err, "%s --%s-->", testCase.from, testCase.event)
+			s.Equal(testCase.to, got.Status)
+		}
+	})
+
+	s.Run("Should apply the event again in case the order changed concurrently", func() {
+		s.ordersRepo.
+			On("Get", mock.AnythingOfType("*context.valueCtx"), 2).
+			Return(domain.Order{ID: 2, Status: domain.OrderStatusPending, Version: 1}, nil).
+			Once()
+
+		s.ordersRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(order domain.Order) bool {
+				return order.ID == 2 && order.Version == 1
+			})).
+			Return(domain.Order{}, domain.ErrOrderVersionConflict).
+			Once()
+
+		// the order was submitted meanwhile, so it can still be cancelled
+		s.ordersRepo.
+			On("Get", mock.AnythingOfType("*context.valueCtx"), 2).
+			Return(domain.Order{ID: 2, Status: domain.OrderStatusAwaitingPayment, Version: 2}, nil).
+			Once()
+
+		updated := domain.Order{ID: 2, Status: domain.OrderStatusCancelled, Version: 3}
+
+		s.ordersRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(order domain.Order) bool {
+				return order.ID == 2 &&
+					order.Version == 2 &&
+					order.StatusHistory[0].From == domain.OrderStatusAwaitingPayment &&
+					order.StatusHistory[0].Reason == "changed my mind"
+			})).
+			Return(updated, nil).
+			Once()
+
+		s.publisher.
+			On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(event domain.OrderStatusChanged) bool {
+				return event.Order.ID == 2 && event.Change.From == domain.OrderStatusAwaitingPayment
+			})).
+			Return(nil).
+			Once()
+
+		got, err := s.app.TransitionOrder(context.Background(), domain.OrderTransition{
+			OrderID: 2,
+			Event:   domain.OrderEventCancel,
+			Reason:  "changed my mind",
+		})
+
+		s.NoError(err)
+		s.Equal(updated, got)
+	})
+
+	s.Run("Should transition the order even in case its event can't be published", func() {
+		s.ordersRepo.
+			On("Get", mock.AnythingOfType("*context.valueCtx"), 4).
+			Return(domain.Order{ID: 4, Status: domain.OrderStatusAwaitingPayment, Version: 1}, nil).
+			Once()
+
+		updated := domain.Order{ID: 4, Status: domain.OrderStatusPaid, Version: 2}
+
+		s.ordersRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(order domain.Order) bool {
+				return order.ID == 4
+			})).
+			Return(updated, nil).
+			Once()
+
+		s.publisher.
+			On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(event domain.OrderStatusChanged) bool {
+				return event.Order.ID == 4
+			})).
+			Return(errors.New("channel closed")).
+			Once()
+
+		got, err := s.app.TransitionOrder(context.Background(), domain.OrderTransition{OrderID: 4, Event: domain.OrderEventPay})
+
+		s.NoError(err)
+		s.Equal(updated, got)
+	})
+
+	s.Run("Should fail in case the event became invalid while retrying", func() {
+		s.ordersRepo.
+			On("Get", mock.AnythingOfType("*context.valueCtx"), 3).
+			Return(domain.Order{ID: 3, Status: domain.OrderStatusAwaitingPayment, Version: 1}, nil).
+			Once()
+
+		s.ordersRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(order domain.Order) bool {
+				return order.ID == 3
+			})).
+			Return(domain.Order{}, domain.ErrOrderVersionConflict).
+			Once()
+
+		// the order was paid meanwhile
+		s.ordersRepo.
+			On("Get", mock.AnythingOfType("*context.valueCtx"), 3).
+			Return(domain.Order{ID: 3, Status: domain.OrderStatusPaid, Version: 2}, nil).
+			Once()
+
+		_, err := s.app.TransitionOrder(context.Background(), domain.OrderTransition{OrderID: 3, Event: domain.OrderEventCancel})
+
+		s.Equal(domain.ErrInvalidTransition, err)
+	})
+}
+
+func TestTransitionOrderSuite(t *testing.T) {
+	suite.Run(t, new(TransitionOrderSuite))
+}
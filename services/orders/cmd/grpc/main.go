@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	checkoutPb "github.com/lucasmls/ecommerce/services/checkout/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/services/orders/adapters/catalog"
+	"github.com/lucasmls/ecommerce/services/orders/adapters/events"
+	"github.com/lucasmls/ecommerce/services/orders/adapters/repositories"
+	"github.com/lucasmls/ecommerce/services/orders/app"
+	resolvers "github.com/lucasmls/ecommerce/services/orders/ports/grpc"
+	protog "github.com/lucasmls/ecommerce/services/orders/ports/grpc/proto"
+	consumers "github.com/lucasmls/ecommerce/services/orders/ports/rmq"
+	rmqPb "github.com/lucasmls/ecommerce/services/orders/ports/rmq/proto"
+	productsPb "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+	"github.com/lucasmls/ecommerce/shared/env"
+	"github.com/lucasmls/ecommerce/shared/grpc"
+	"github.com/lucasmls/ecommerce/shared/rmq"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otel "go.opentelemetry.io/otel"
+	otelJaegerExporter "go.opentelemetry.io/otel/exporters/jaeger"
+	otelPropagation "go.opentelemetry.io/otel/propagation"
+	otelSdkResource "go.opentelemetry.io/otel/sdk/resource"
+	otelTraceSdk "go.opentelemetry.io/otel/sdk/trace"
+	otelSemconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.uber.org/zap"
+	gGRPC "google.golang.org/grpc"
+)
+
+type ApplicationConfig struct {
+	ServiceName              string `mapstructure:"SERVICE_NAME"`
+	JaegerEndpoint           string `mapstructure:"JAEGER_ENDPOINT"`
+	GrpcServerPort           int    `mapstructure:"GRPC_SERVER_PORT"`
+	MetricsPort              int    `mapstructure:"METRICS_PORT"`
+	PostgresConnectionString string `mapstructure:"PG_CONNECTION_STRING"`
+	// ProductsServiceAddress is where the name and price of the products are snapshotted from, e.g. localhost:8081
+	ProductsServiceAddress string `mapstructure:"PRODUCTS_SERVICE_ADDRESS"`
+	// RabbitMQURL is where the commands of the checkout saga come from and the order events are published to
+	RabbitMQURL string `mapstructure:"RABBITMQ_URL"`
+}
+
+func main() {
+	ctx := context.Background()
+
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	config, err := env.LoadConfig[ApplicationConfig]()
+	if err != nil {
+		logger.Fatal("failed to load application config", zap.Error(err))
+	}
+
+	jaegerExporter, err := otelJaegerExporter.New(
+		otelJaegerExporter.WithCollectorEndpoint(
+			otelJaegerExporter.WithEndpoint(config.JaegerEndpoint),
+		),
+	)
+	if err != nil {
+		logger.Fatal("failed to instantiate Jaeger exporter", zap.Error(err))
+	}
+
+	tracingProvider := otelTraceSdk.NewTracerProvider(
+		otelTraceSdk.WithBatcher(jaegerExporter),
+		otelTraceSdk.WithSampler(otelTraceSdk.AlwaysSample()),
+		otelTraceSdk.WithResource(otelSdkResource.NewWithAttributes(
+			otelSemconv.SchemaURL,
+			otelSemconv.ServiceNameKey.String(config.ServiceName),
+		)),
+	)
+
+	defer func() {
+		_ = tracingProvider.Shutdown(ctx)
+	}()
+
+	otel.SetTracerProvider(tracingProvider)
+	otel.SetTextMapPropagator(otelPropagation.NewCompositeTextMapPropagator(
+		otelPropagation.TraceContext{},
+		otelPropagation.Baggage{},
+	))
+
+	tracer := otel.Tracer(config.ServiceName)
+
+	rmqClient := rmq.MustNewClient(rmq.ClientInput{
+		URL:    config.RabbitMQURL,
+		Logger: logger,
+	}).MustConnect()
+	defer rmqClient.Close()
+
+	if err := rmqClient.DeclareExchange(rmqPb.Exchange); err != nil {
+		logger.Fatal("failed to declare the orders exchange", zap.Error(err))
+	}
+
+	eventPublisher := events.NewRmqEventPublisher(logger, tracer, rmqClient)
+
+	productsServiceGRPCClient := grpc.MustNewClient(grpc.ClientInput{
+		Address: config.ProductsServiceAddress,
+		Logger:  logger,
+	})
+
+	productsServiceConn := productsServiceGRPCClient.MustConnect(ctx)
+
+	productsCatalog := catalog.MustNewGrpcProductsCatalog(logger, tracer, productsPb.NewProductsServiceClient(productsServiceConn))
+
+	inMemoryOrdersRepository := repositories.NewInMemoryOrdersRepository(logger, tracer)
+	// postgresOrdersRepository := repositories.MustNewPgOrdersRepository(config.PostgresConnectionString)
+
+	application := app.MustNewApplication(logger, tracer, inMemoryOrdersRepository, productsCatalog, eventPublisher)
+	ordersResolver := resolvers.MustNewOrdersResolver(logger, tracer, application)
+
+	checkoutConsumer := consumers.MustNewCheckoutConsumer(logger, tracer, application, rmqClient)
+
+	go func() {
+		err := rmqClient.Consume(ctx, rmq.ConsumerInput{
+			Exchange:    checkoutPb.Exchange,
+			Queue:       checkoutPb.CommandsQueue(checkoutPb.ParticipantOrders),
+			RoutingKeys: []string{checkoutPb.CommandsRoutingKey(checkoutPb.ParticipantOrders)},
+			Handler:     checkoutConsumer.Handle,
+		})
+		if err != nil {
+			logger.Fatal("failed to consume the checkout commands", zap.Error(err))
+		}
+	}()
+
+	server := grpc.MustNewServer(grpc.ServerInput{
+		Port:   config.GrpcServerPort,
+		Logger: logger,
+		Registrator: func(server gGRPC.ServiceRegistrar) {
+			protog.RegisterOrdersServiceServer(server, ordersResolver)
+		},
+	})
+
+	go func() {
+		port := fmt.Sprintf(":%d", config.MetricsPort)
+
+		http.Handle("/metrics", promhttp.Handler())
+		_ = http.ListenAndServe(port, nil)
+	}()
+
+	if err := server.Run(ctx); err != nil {
+		logger.Fatal("failed to run gRPC server", zap.Error(err))
+	}
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+)
+
+// Application defines boundary interfaces of the application
+// It should be called by the ports
+type Application interface {
+	// PlaceOrder places a pending Order, snapshotting the name and price of its Products from the catalog.
+	// The units of a Product asked for more than once are added up. It fails with ErrProductUnavailable
+	// when any of the Products can't be bought.
+	PlaceOrder(context.Context, OrderRequest) (Order, error)
+
+	// GetOrder fetches a single Order
+	GetOrder(context.Context, int) (Order, error)
+
+	// ListOrders lists the Orders matching the filter, most recently placed first
+	ListOrders(context.Context, OrderFilter) ([]Order, error)
+
+	// TransitionOrder applies an OrderEvent to an Order, failing with ErrInvalidTransition when the
+	// state machine doesn't allow it on the current status of the Order.
+	TransitionOrder(context.Context, OrderTransition) (Order, error)
+}
+
+type OrdersRepository interface {
+	// Create stores a new Order in a data storage, setting its ID and its first Version.
+	// It fails with ErrOrderNumberTaken when another Order has the same Number.
+	Create(context.Context, Order) (Order, error)
+
+	// Get fetches a single Order from a data storage.
+	Get(context.Context, int) (Order, error)
+
+	// List lists the Orders matching the filter from a data storage, most recently placed first, ties broken by ID.
+	List(context.Context, OrderFilter) ([]Order, error)
+
+	// Update replaces an Order in a data storage, returning it with its new Version.
+	// It's only replaced while the stored Version is still the one it was read with, failing with
+	// ErrOrderVersionConflict otherwise, so concurrent transitions can't skip the state machine.
+	Update(context.Context, Order) (Order, error)
+}
+
+type Catalog interface {
+	// Products fetches the Products that can be bought among the given ones, keyed by their ID.
+	// The missing Products are left out rather than failing.
+	Products(context.Context, []int) (map[int]ProductSnapshot, error)
+}
+
+type EventPublisher interface {
+	// Publish tells the other services an Order moved to another OrderStatus
+	Publish(context.Context, OrderStatusChanged) error
+}
@@ -0,0 +1,8 @@
+package domain
+
+// OrderStatusChanged tells the other services an Order moved to another OrderStatus, e.g. so the customer is notified
+type OrderStatusChanged struct {
+	Order Order
+	// Change is the StatusChange that moved the Order
+	Change StatusChange
+}
@@ -0,0 +1,181 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// MaxOrderItems is the maximum amount of different Products an Order may have
+	MaxOrderItems = 100
+	// MaxItemQuantity is the maximum amount of units of a single Product an Order may have
+	MaxItemQuantity = 99
+	// MaxCustomerIDLength is the maximum amount of characters of the ID of the customer placing an Order
+	MaxCustomerIDLength = 255
+	// MaxReasonLength is the maximum amount of characters of the reason of a StatusChange
+	MaxReasonLength = 500
+)
+
+var (
+	ErrOrderNotFound        = errors.New("order-not-found")
+	ErrOrderNumberTaken     = errors.New("order-number-taken")
+	ErrOrderVersionConflict = errors.New("order-version-conflict")
+	ErrInvalidOrder         = errors.New("invalid-order")
+	ErrEmptyOrder           = errors.New("empty-order")
+	ErrInvalidQuantity      = errors.New("invalid-quantity")
+	ErrProductUnavailable   = errors.New("product-unavailable")
+	ErrInvalidOrderEvent    = errors.New("invalid-order-event")
+	ErrInvalidTransition    = errors.New("invalid-transition")
+)
+
+// LineItem is an amount of units of a Product on an Order.
+// Its Name and UnitPrice are snapshots of the Product taken when the Order was placed, so later
+// changes to the catalog don't change what the customer agreed to pay.
+type LineItem struct {
+	ProductID int
+	Name      string
+	Quantity  int
+	// UnitPrice is in minor currency units (cents)
+	UnitPrice int
+}
+
+// Total is the price of every unit of the LineItem, in minor currency units
+func (i LineItem) Total() int {
+	return i.Quantity * i.UnitPrice
+}
+
+// StatusChange records an OrderEvent that moved an Order from a status to another one.
+type StatusChange struct {
+	Event  OrderEvent
+	From   OrderStatus
+	To     OrderStatus
+	Reason string
+	At     time.Time
+}
+
+// Order is a purchase of Products placed by a customer.
+// Its Status only changes through the OrderEvents of the state machine, see Order.Apply.
+type Order struct {
+	ID int
+	// Number identifies the Order to the customer, e.g. on receipts and support requests
+	Number     string
+	CustomerID string
+	Items      []LineItem
+	Status     OrderStatus
+	// StatusHistory lists every StatusChange of the Order, oldest first
+	StatusHistory []StatusChange
+	// Version is incremented on every change, starting at 1 when the Order is placed.
+	Version   int
+	PlacedAt  time.Time
+	UpdatedAt time.Time
+}
+
+// Total is the price of every LineItem, in minor currency units
+func (o Order) Total() int {
+	total := 0
+	for _, item := range o.Items {
+		total += item.Total()
+	}
+
+	return total
+}
+
+// Apply moves the Order to the status the event leads to, recording the StatusChange.
+// It fails with ErrInvalidTransition, leaving the Order untouched, when the event can't happen on its current status.
+func (o *Order) Apply(event OrderEvent, reason string, now time.Time) error {
+	to, err := o.Status.Next(event)
+	if err != nil {
+		return err
+	}
+
+	o.StatusHistory = append(o.StatusHistory, StatusChange{
+		Event:  event,
+		From:   o.Status,
+		To:     to,
+		Reason: reason,
+		At:     now,
+	})
+
+	o.Status = to
+	o.UpdatedAt = now
+
+	return nil
+}
+
+// OrderItemRequest asks for units of a Product, whose snapshots are taken from the catalog.
+type OrderItemRequest struct {
+	ProductID int
+	Quantity  int
+}
+
+// OrderRequest asks for an Order to be placed.
+type OrderRequest struct {
+	CustomerID string
+	Items      []OrderItemRequest
+}
+
+// Validate checks the customer and the items, a Product may be asked for more than once
+func (r OrderRequest) Validate() error {
+	if r.CustomerID == "" || len(r.CustomerID) > MaxCustomerIDLength {
+		return ErrInvalidOrder
+	}
+
+	if len(r.Items) == 0 {
+		return ErrEmptyOrder
+	}
+
+	if len(r.Items) > MaxOrderItems {
+		return ErrInvalidOrder
+	}
+
+	for _, item := range r.Items {
+		if item.ProductID <= 0 {
+			return ErrInvalidOrder
+		}
+
+		if item.Quantity <= 0 || item.Quantity > MaxItemQuantity {
+			return ErrInvalidQuantity
+		}
+	}
+
+	return nil
+}
+
+// OrderFilter narrows the Orders down, its unset fields match every Order.
+type OrderFilter struct {
+	CustomerID string
+	Status     OrderStatus
+	// Limit bounds how many Orders are listed, DefaultOrdersLimit when unset
+	Limit int
+}
+
+const (
+	// DefaultOrdersLimit is how many Orders are listed when the OrderFilter has no Limit
+	DefaultOrdersLimit = 20
+	// MaxOrdersLimit is the maximum amount of Orders listed at once
+	MaxOrdersLimit = 100
+)
+
+// OrderTransition asks for an OrderEvent to be applied to an Order.
+type OrderTransition struct {
+	OrderID int
+	Event   OrderEvent
+	Reason  string
+}
+
+// Validate checks the Order, the OrderEvent and the length of the Reason
+func (t OrderTransition) Validate() error {
+	if t.OrderID <= 0 || len([]rune(t.Reason)) > MaxReasonLength {
+		return ErrInvalidOrder
+	}
+
+	return t.Event.Validate()
+}
+
+// ProductSnapshot is what the Orders keep of a Product of the catalog.
+type ProductSnapshot struct {
+	ID   int
+	Name string
+	// Price is in minor currency units (cents)
+	Price int
+}
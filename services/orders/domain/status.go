@@ -0,0 +1,104 @@
+package domain
+
+// OrderStatus is where an Order stands on its lifecycle.
+type OrderStatus string
+
+const (
+	// OrderStatusPending is the status of a placed Order that wasn't submitted for payment yet
+	OrderStatusPending OrderStatus = "pending"
+	// OrderStatusAwaitingPayment is the status of an Order waiting for its payment to be captured
+	OrderStatusAwaitingPayment OrderStatus = "awaiting_payment"
+	// OrderStatusPaid is the status of an Order whose payment was captured, waiting to be shipped
+	OrderStatusPaid OrderStatus = "paid"
+	// OrderStatusFulfilled is the status of an Order handed over to the customer
+	OrderStatusFulfilled OrderStatus = "fulfilled"
+	// OrderStatusCancelled is the final status of an Order given up before being paid
+	OrderStatusCancelled OrderStatus = "cancelled"
+	// OrderStatusRefunded is the final status of an Order whose payment was given back
+	OrderStatusRefunded OrderStatus = "refunded"
+)
+
+// Validate checks that the OrderStatus is one of the known ones
+func (s OrderStatus) Validate() error {
+	switch s {
+	case OrderStatusPending, OrderStatusAwaitingPayment, OrderStatusPaid,
+		OrderStatusFulfilled, OrderStatusCancelled, OrderStatusRefunded:
+		return nil
+	default:
+		return ErrInvalidOrder
+	}
+}
+
+// Final reports whether no OrderEvent can happen on the OrderStatus anymore
+func (s OrderStatus) Final() bool {
+	for _, transition := range transitions {
+		for _, from := range transition.from {
+			if from == s {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Next is the OrderStatus the event leads to from this one, failing with ErrInvalidTransition
+// when the event can't happen on it.
+func (s OrderStatus) Next(event OrderEvent) (OrderStatus, error) {
+	transition, ok := transitions[event]
+	if !ok {
+		return "", ErrInvalidOrderEvent
+	}
+
+	for _, from := range transition.from {
+		if from == s {
+			return transition.to, nil
+		}
+	}
+
+	return "", ErrInvalidTransition
+}
+
+// OrderEvent is something that happens to an Order, moving it to another OrderStatus.
+type OrderEvent string
+
+const (
+	// OrderEventSubmit submits a pending Order for payment
+	OrderEventSubmit OrderEvent = "submit"
+	// OrderEventPay records that the payment of an Order was captured
+	OrderEventPay OrderEvent = "pay"
+	// OrderEventFulfill records that a paid Order was handed over to the customer
+	OrderEventFulfill OrderEvent = "fulfill"
+	// OrderEventCancel gives up an Order that wasn't paid yet
+	OrderEventCancel OrderEvent = "cancel"
+	// OrderEventRefund records that the payment of an Order was given back
+	OrderEventRefund OrderEvent = "refund"
+)
+
+// Validate checks that the OrderEvent is one of the known ones
+func (e OrderEvent) Validate() error {
+	if _, ok := transitions[e]; !ok {
+		return ErrInvalidOrderEvent
+	}
+
+	return nil
+}
+
+// transition is an edge of the state machine of the Orders, from any of the statuses to another one
+type transition struct {
+	from []OrderStatus
+	to   OrderStatus
+}
+
+// transitions is the state machine of the Orders, the only way their statuses change:
+//
+//	pending --submit--> awaiting_payment --pay--> paid --fulfill--> fulfilled
+//	pending, awaiting_payment --cancel--> cancelled
+//	paid, fulfilled --refund--> refunded
+var transitions = map[OrderEvent]transition{
+	OrderEventSubmit:  {from: []OrderStatus{OrderStatusPending}, to: OrderStatusAwaitingPayment},
+	OrderEventPay:     {from: []OrderStatus{OrderStatusAwaitingPayment}, to: OrderStatusPaid},
+	OrderEventFulfill: {from: []OrderStatus{OrderStatusPaid}, to: OrderStatusFulfilled},
+	OrderEventCancel:  {from: []OrderStatus{OrderStatusPending, OrderStatusAwaitingPayment}, to: OrderStatusCancelled},
+	OrderEventRefund:  {from: []OrderStatus{OrderStatusPaid, OrderStatusFulfilled}, to: OrderStatusRefunded},
+}
@@ -0,0 +1,17 @@
+-- the line items and the status history are kept along with their order, which is always read and written as a whole
+CREATE TABLE IF NOT EXISTS orders (
+    id             SERIAL PRIMARY KEY,
+    number         TEXT        NOT NULL UNIQUE,
+    customer_id    TEXT        NOT NULL,
+    items          JSONB       NOT NULL,
+    status         TEXT        NOT NULL,
+    status_history JSONB       NOT NULL DEFAULT '[]',
+    version        INTEGER     NOT NULL,
+    placed_at      TIMESTAMPTZ NOT NULL,
+    updated_at     TIMESTAMPTZ NOT NULL,
+    CONSTRAINT orders_status_check CHECK (
+        status IN ('pending', 'awaiting_payment', 'paid', 'fulfilled', 'cancelled', 'refunded')
+    )
+);
+
+CREATE INDEX orders_customer_id_placed_at_idx ON orders (customer_id, placed_at DESC);
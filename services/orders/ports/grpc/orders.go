@@ -0,0 +1,213 @@
+package grpc_port
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/orders/domain"
+	pb "github.com/lucasmls/ecommerce/services/orders/ports/grpc/proto"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	InternalServerError = status.Error(codes.Internal, "Internal server error")
+)
+
+var (
+	ErrMissingLogger = errors.New("missing required dependency: Logger")
+	ErrMisingTracer  = errors.New("missing required dependency: Tracer")
+)
+
+// OrdersResolver ...
+type OrdersResolver struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+	App    domain.Application
+
+	pb.UnimplementedOrdersServiceServer
+}
+
+func NewOrdersResolver(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) (*OrdersResolver, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMisingTracer
+	}
+
+	return &OrdersResolver{
+		Logger: logger,
+		Tracer: tracer,
+		App:    app,
+	}, nil
+}
+
+// MustNewOrdersResolver creates a new OrdersResolver instance.
+// It panics if any error is found
+func MustNewOrdersResolver(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) *OrdersResolver {
+	ordersResolver, err := NewOrdersResolver(logger, tracer, app)
+	if err != nil {
+		panic(err)
+	}
+
+	return ordersResolver
+}
+
+func (r *OrdersResolver) PlaceOrder(ctx context.Context, req *pb.PlaceOrderRequest) (*pb.PlaceOrderResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.PlaceOrder")
+	defer span.End()
+
+	request := domain.OrderRequest{
+		CustomerID: req.CustomerId,
+		Items:      make([]domain.OrderItemRequest, 0, len(req.Items)),
+	}
+	for _, item := range req.Items {
+		request.Items = append(request.Items, domain.OrderItemRequest{
+			ProductID: int(item.ProductId),
+			Quantity:  int(item.Quantity),
+		})
+	}
+
+	order, err := r.App.PlaceOrder(ctx, request)
+	if err != nil {
+		return nil, r.toError(err, "failed to place the order", zap.Any("request", request))
+	}
+
+	return &pb.PlaceOrderResponse{Data: toProtoOrder(order)}, nil
+}
+
+func (r *OrdersResolver) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.GetOrderResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.GetOrder")
+	defer span.End()
+
+	order, err := r.App.GetOrder(ctx, int(req.Id))
+	if err != nil {
+		return nil, r.toError(err, "failed to get the order", zap.Int32("id", req.Id))
+	}
+
+	return &pb.GetOrderResponse{Data: toProtoOrder(order)}, nil
+}
+
+func (r *OrdersResolver) ListOrders(ctx context.Context, req *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.ListOrders")
+	defer span.End()
+
+	filter := domain.OrderFilter{
+		CustomerID: req.CustomerId,
+		Status:     domain.OrderStatus(req.Status),
+		Limit:      int(req.Limit),
+	}
+
+	orders, err := r.App.ListOrders(ctx, filter)
+	if err != nil {
+		return nil, r.toError(err, "failed to list the orders", zap.Any("filter", filter))
+	}
+
+	response := make([]*pb.Order, 0, len(orders))
+	for _, order := range orders {
+		response = append(response, toProtoOrder(order))
+	}
+
+	return &pb.ListOrdersResponse{Data: response}, nil
+}
+
+func (r *OrdersResolver) TransitionOrder(ctx context.Context, req *pb.TransitionOrderRequest) (*pb.TransitionOrderResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.TransitionOrder")
+	defer span.End()
+
+	transition := domain.OrderTransition{
+		OrderID: int(req.OrderId),
+		Event:   domain.OrderEvent(req.Event),
+		Reason:  req.Reason,
+	}
+
+	order, err := r.App.TransitionOrder(ctx, transition)
+	if err != nil {
+		return nil, r.toError(err, "failed to transition the order", zap.Any("transition", transition))
+	}
+
+	return &pb.TransitionOrderResponse{Data: toProtoOrder(order)}, nil
+}
+
+// toError maps the Order errors to their gRPC codes: invalid transitions fail the precondition and stale
+// versions abort, so the client may read the Order again. Unknown errors are logged and reported as internal.
+func (r *OrdersResolver) toError(err error, message string, fields ...zap.Field) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidOrder),
+		errors.Is(err, domain.ErrEmptyOrder),
+		errors.Is(err, domain.ErrInvalidQuantity),
+		errors.Is(err, domain.ErrInvalidOrderEvent):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrOrderNotFound),
+		errors.Is(err, domain.ErrProductUnavailable):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidTransition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrOrderVersionConflict),
+		errors.Is(err, domain.ErrOrderNumberTaken):
+		return status.Error(codes.Aborted, err.Error())
+	}
+
+	r.Logger.Error(message, append(fields, zap.Error(err))...)
+	return InternalServerError
+}
+
+func toProtoOrder(order domain.Order) *pb.Order {
+	items := make([]*pb.LineItem, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, &pb.LineItem{
+			ProductId: int32(item.ProductID),
+			Name:      item.Name,
+			Quantity:  int32(item.Quantity),
+			UnitPrice: int32(item.UnitPrice),
+			Total:     int64(item.Total()),
+		})
+	}
+
+	history := make([]*pb.StatusChange, 0, len(order.StatusHistory))
+	for _, change := range order.StatusHistory {
+		history = append(history, &pb.StatusChange{
+			Event:  string(change.Event),
+			From:   string(change.From),
+			To:     string(change.To),
+			Reason: change.Reason,
+			At:     toProtoTimestamp(change.At),
+		})
+	}
+
+	return &pb.Order{
+		Id:            int32(order.ID),
+		Number:        order.Number,
+		CustomerId:    order.CustomerID,
+		Items:         items,
+		Status:        string(order.Status),
+		StatusHistory: history,
+		Total:         int64(order.Total()),
+		Version:       int32(order.Version),
+		PlacedAt:      toProtoTimestamp(order.PlacedAt),
+		UpdatedAt:     toProtoTimestamp(order.UpdatedAt),
+	}
+}
+
+// toProtoTimestamp leaves the zero times unset
+func toProtoTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
@@ -0,0 +1,304 @@
+package grpc_port
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/shared/grpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	gGRPC "google.golang.org/grpc"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/lucasmls/ecommerce/services/orders/domain"
+	"github.com/lucasmls/ecommerce/services/orders/mocks"
+	protog "github.com/lucasmls/ecommerce/services/orders/ports/grpc/proto"
+)
+
+type OrdersResolverSuite struct {
+	suite.Suite
+
+	app    *mocks.Application
+	logger *zap.Logger
+	tracer trace.Tracer
+
+	grpcClient     protog.OrdersServiceClient
+	grpcConnection *gGRPC.ClientConn
+}
+
+func (s *OrdersResolverSuite) SetupSuite() {
+	s.logger = zap.NewNop()
+	s.tracer = trace.NewNoopTracerProvider().Tracer("")
+	s.app = &mocks.Application{}
+
+	grpcClient, grpcConnection := makeGrpcClientAndServer(
+		context.Background(),
+		s.logger,
+		s.tracer,
+		s.app,
+	)
+
+	s.grpcClient = grpcClient
+	s.grpcConnection = grpcConnection
+}
+
+func (s *OrdersResolverSuite) TearDownSuite() {
+	err := s.grpcConnection.Close()
+	s.NoError(err)
+}
+
+func (s *OrdersResolverSuite) Test_NewOrdersResolver() {
+	s.Run("Should fail to instantiate the OrdersResolver in case a Logger isn't provided", func() {
+		_, err := NewOrdersResolver(nil, nil, nil)
+
+		s.Equal(ErrMissingLogger, err)
+	})
+
+	s.Run("Should fail to instantiate the OrdersResolver in case a Tracer isn't provided", func() {
+		_, err := NewOrdersResolver(s.logger, nil, nil)
+
+		s.Equal(ErrMisingTracer, err)
+	})
+
+	s.Run("Should successfully instantiate the OrdersResolver", func() {
+		_, err := NewOrdersResolver(s.logger, s.tracer, nil)
+
+		s.NoError(err)
+	})
+}
+
+func (s *OrdersResolverSuite) Test_PlaceOrder() {
+	s.Run("Should return a not found error in case a product is unavailable", func() {
+		s.app.
+			On("PlaceOrder", mock.AnythingOfType("*context.valueCtx"), domain.OrderRequest{
+				CustomerID: "john",
+				Items:      []domain.OrderItemRequest{{ProductID: 9, Quantity: 1}},
+			}).
+			Return(domain.Order{}, fmt.Errorf("%w: product 9", domain.ErrProductUnavailable))
+
+		_, err := s.grpcClient.PlaceOrder(context.Background(), &protog.PlaceOrderRequest{
+			CustomerId: "john",
+			Items:      []*protog.OrderItemRequest{{ProductId: 9, Quantity: 1}},
+		})
+
+		s.Equal(status.Error(codes.NotFound, "product-unavailable: product 9"), err)
+	})
+
+	s.Run("Should return an invalid argument error in case the order is empty", func() {
+		s.app.
+			On("PlaceOrder", mock.AnythingOfType("*context.valueCtx"), domain.OrderRequest{CustomerID: "jane", Items: []domain.OrderItemRequest{}}).
+			Return(domain.Order{}, domain.ErrEmptyOrder)
+
+		_, err := s.grpcClient.PlaceOrder(context.Background(), &protog.PlaceOrderRequest{CustomerId: "jane"})
+
+		s.Equal(status.Error(codes.InvalidArgument, domain.ErrEmptyOrder.Error()), err)
+	})
+
+	s.Run("Should return the placed order with its totals", func() {
+		placedAt := time.Date(2022, 10, 19, 10, 0, 0, 0, time.UTC)
+
+		s.app.
+			On("PlaceOrder", mock.AnythingOfType("*context.valueCtx"), domain.OrderRequest{
+				CustomerID: "john",
+				Items:      []domain.OrderItemRequest{{ProductID: 1, Quantity: 2}, {ProductID: 2, Quantity: 1}},
+			}).
+			Return(domain.Order{
+				ID:         1,
+				Number:     "20221019-7K3M9Q",
+				CustomerID: "john",
+				Items: []domain.LineItem{
+					{ProductID: 1, Name: "iPhone 13", Quantity: 2, UnitPrice: 79900},
+					{ProductID: 2, Name: "MacBook", Quantity: 1, UnitPrice: 129900},
+				},
+				Status:    domain.OrderStatusPending,
+				Version:   1,
+				PlacedAt:  placedAt,
+				UpdatedAt: placedAt,
+			}, nil)
+
+		got, err := s.grpcClient.PlaceOrder(context.Background(), &protog.PlaceOrderRequest{
+			CustomerId: "john",
+			Items:      []*protog.OrderItemRequest{{ProductId: 1, Quantity: 2}, {ProductId: 2, Quantity: 1}},
+		})
+
+		s.NoError(err)
+		s.Equal("20221019-7K3M9Q", got.Data.Number)
+		s.Equal("pending", got.Data.Status)
+		s.Equal(int64(289700), got.Data.Total)
+		s.Require().Len(got.Data.Items, 2)
+		s.Equal(int64(159800), got.Data.Items[0].Total)
+		s.Equal(placedAt, got.Data.PlacedAt.AsTime())
+		s.Empty(got.Data.StatusHistory)
+	})
+}
+
+func (s *OrdersResolverSuite) Test_GetOrder() {
+	s.Run("Should return a not found error in case the order doesn't exist", func() {
+		s.app.
+			On("GetOrder", mock.AnythingOfType("*context.valueCtx"), 404).
+			Return(domain.Order{}, domain.ErrOrderNotFound)
+
+		_, err := s.grpcClient.GetOrder(context.Background(), &protog.GetOrderRequest{Id: 404})
+
+		s.Equal(status.Error(codes.NotFound, domain.ErrOrderNotFound.Error()), err)
+	})
+
+	s.Run("Should return an internal error in case of an unknown failure", func() {
+		s.app.
+			On("GetOrder", mock.AnythingOfType("*context.valueCtx"), 500).
+			Return(domain.Order{}, errors.New("connection refused"))
+
+		_, err := s.grpcClient.GetOrder(context.Background(), &protog.GetOrderRequest{Id: 500})
+
+		s.Equal(status.Error(codes.Internal, "Internal server error"), err)
+	})
+}
+
+func (s *OrdersResolverSuite) Test_ListOrders() {
+	s.Run("Should return an invalid argument error in case the status is unknown", func() {
+		s.app.
+			On("ListOrders", mock.AnythingOfType("*context.valueCtx"), domain.OrderFilter{Status: "shipped"}).
+			Return(nil, domain.ErrInvalidOrder)
+
+		_, err := s.grpcClient.ListOrders(context.Background(), &protog.ListOrdersRequest{Status: "shipped"})
+
+		s.Equal(status.Error(codes.InvalidArgument, domain.ErrInvalidOrder.Error()), err)
+	})
+
+	s.Run("Should list the orders of the customer", func() {
+		s.app.
+			On("ListOrders", mock.AnythingOfType("*context.valueCtx"), domain.OrderFilter{CustomerID: "john", Status: domain.OrderStatusPaid, Limit: 5}).
+			Return([]domain.Order{{ID: 2, Status: domain.OrderStatusPaid}, {ID: 1, Status: domain.OrderStatusPaid}}, nil)
+
+		got, err := s.grpcClient.ListOrders(context.Background(), &protog.ListOrdersRequest{CustomerId: "john", Status: "paid", Limit: 5})
+
+		s.NoError(err)
+		s.Require().Len(got.Data, 2)
+		s.Equal(int32(2), got.Data[0].Id)
+		s.Equal(int32(1), got.Data[1].Id)
+	})
+}
+
+func (s *OrdersResolverSuite) Test_TransitionOrder() {
+	s.Run("Should return a failed precondition error in case the status doesn't allow the event", func() {
+		s.app.
+			On("TransitionOrder", mock.AnythingOfType("*context.valueCtx"), domain.OrderTransition{OrderID: 1, Event: domain.OrderEventRefund}).
+			Return(domain.Order{}, domain.ErrInvalidTransition)
+
+		_, err := s.grpcClient.TransitionOrder(context.Background(), &protog.TransitionOrderRequest{OrderId: 1, Event: "refund"})
+
+		s.Equal(status.Error(codes.FailedPrecondition, domain.ErrInvalidTransition.Error()), err)
+	})
+
+	s.Run("Should return an aborted error in case the order kept changing", func() {
+		s.app.
+			On("TransitionOrder", mock.AnythingOfType("*context.valueCtx"), domain.OrderTransition{OrderID: 2, Event: domain.OrderEventPay}).
+			Return(domain.Order{}, domain.ErrOrderVersionConflict)
+
+		_, err := s.grpcClient.TransitionOrder(context.Background(), &protog.TransitionOrderRequest{OrderId: 2, Event: "pay"})
+
+		s.Equal(status.Error(codes.Aborted, domain.ErrOrderVersionConflict.Error()), err)
+	})
+
+	s.Run("Should return the order with its status history", func() {
+		at := time.Date(2022, 10, 19, 11, 0, 0, 0, time.UTC)
+
+		s.app.
+			On("TransitionOrder", mock.AnythingOfType("*context.valueCtx"), domain.OrderTransition{OrderID: 3, Event: domain.OrderEventCancel, Reason: "changed my mind"}).
+			Return(domain.Order{
+				ID:     3,
+				Status: domain.OrderStatusCancelled,
+				StatusHistory: []domain.StatusChange{
+					{Event: domain.OrderEventCancel, From: domain.OrderStatusPending, To: domain.OrderStatusCancelled, Reason: "changed my mind", At: at},
+				},
+				Version: 2,
+			}, nil)
+
+		got, err := s.grpcClient.TransitionOrder(context.Background(), &protog.TransitionOrderRequest{
+			OrderId: 3,
+			Event:   "cancel",
+			Reason:  "changed my mind",
+		})
+
+		s.NoError(err)
+		s.Equal("cancelled", got.Data.Status)
+		s.Require().Len(got.Data.StatusHistory, 1)
+		s.Equal("pending", got.Data.StatusHistory[0].From)
+		s.Equal("changed my mind", got.Data.StatusHistory[0].Reason)
+		s.Equal(at, got.Data.StatusHistory[0].At.AsTime())
+	})
+}
+
+func TestOrdersResolverSuite(t *testing.T) {
+	suite.Run(t, new(OrdersResolverSuite))
+}
+
+func makeGrpcClientAndServer(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	tracerM trace.Tracer,
+	appM domain.Application,
+) (protog.OrdersServiceClient, *gGRPC.ClientConn) {
+	listener := makeGrpcServer(ctx, loggerM, tracerM, appM)
+	grpcClient, grpcConnection := makeGrpcClient(ctx, loggerM, listener)
+
+	return grpcClient, grpcConnection
+}
+
+func makeGrpcClient(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	listener *bufconn.Listener,
+) (protog.OrdersServiceClient, *gGRPC.ClientConn) {
+	ordersServiceGRPCClient := grpc.MustNewClient(grpc.ClientInput{
+		Logger: loggerM,
+		AdditionalDialOptions: []gGRPC.DialOption{
+			gGRPC.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+				return listener.Dial()
+			}),
+		},
+	})
+
+	ordersServiceConn := ordersServiceGRPCClient.MustConnect(ctx)
+
+	grpcClient := protog.NewOrdersServiceClient(ordersServiceConn)
+	return grpcClient, ordersServiceConn
+}
+
+func makeGrpcServer(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	tracerM trace.Tracer,
+	appM domain.Application,
+) *bufconn.Listener {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.MustNewServer(grpc.ServerInput{
+		Port:   1234,
+		Logger: loggerM,
+		Registrator: func(server gGRPC.ServiceRegistrar) {
+			protog.RegisterOrdersServiceServer(server, &OrdersResolver{
+				Logger: loggerM,
+				Tracer: tracerM,
+				App:    appM,
+			})
+		},
+		Listener: listener,
+	})
+
+	go func() {
+		if err := grpcServer.Run(ctx); err != nil {
+			panic(err)
+		}
+	}()
+
+	return listener
+}
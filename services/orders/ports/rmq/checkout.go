@@ -0,0 +1,177 @@
+package rmq_port
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	checkoutPb "github.com/lucasmls/ecommerce/services/checkout/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/services/orders/domain"
+	"github.com/lucasmls/ecommerce/shared/rmq"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	ErrMissingLogger    = errors.New("missing required dependency: Logger")
+	ErrMisingTracer     = errors.New("missing required dependency: Tracer")
+	ErrMissingPublisher = errors.New("missing required dependency: Publisher")
+)
+
+// errUnsupportedCommand is replied to the Commands of the steps the Orders don't run
+var errUnsupportedCommand = errors.New("unsupported-command")
+
+// CheckoutConsumer runs the steps of the checkout saga on the Orders: it submits the Order for payment,
+// cancelling it when the checkout fails, and confirms it once it's paid.
+type CheckoutConsumer struct {
+	Logger    *zap.Logger
+	Tracer    trace.Tracer
+	App       domain.Application
+	Publisher rmq.Publisher
+}
+
+func NewCheckoutConsumer(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+	publisher rmq.Publisher,
+) (*CheckoutConsumer, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMisingTracer
+	}
+
+	if publisher == nil {
+		return nil, ErrMissingPublisher
+	}
+
+	return &CheckoutConsumer{
+		Logger:    logger,
+		Tracer:    tracer,
+		App:       app,
+		Publisher: publisher,
+	}, nil
+}
+
+// MustNewCheckoutConsumer creates a new CheckoutConsumer instance.
+// It panics if any error is found
+func MustNewCheckoutConsumer(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+	publisher rmq.Publisher,
+) *CheckoutConsumer {
+	consumer, err := NewCheckoutConsumer(logger, tracer, app, publisher)
+	if err != nil {
+		panic(err)
+	}
+
+	return consumer
+}
+
+// Handle runs a Command of the checkout saga and replies to it, it's meant to be the Handler of the rmq.Client
+// consuming the commands queue of the Orders. The Commands are sent again when their Reply takes too long, so
+// running them twice must be harmless: a transition the Order already went through is replied as successful.
+// The Commands failing for reasons the domain doesn't know about aren't replied, to be sent again.
+func (c *CheckoutConsumer) Handle(ctx context.Context, body []byte) error {
+	ctx, span := c.Tracer.Start(ctx, "consumer.HandleCheckoutCommand")
+	defer span.End()
+
+	command := &checkoutPb.Command{}
+	if err := proto.Unmarshal(body, command); err != nil {
+		return err
+	}
+
+	reply := &checkoutPb.Reply{
+		CommandId: command.Id,
+		SagaId:    command.SagaId,
+		Step:      command.Step,
+		Action:    command.Action,
+		Success:   true,
+	}
+
+	order, err := c.run(ctx, command)
+	switch {
+	case err == nil && command.Step == checkoutPb.StepSubmitOrder && command.Action == checkoutPb.ActionExecute:
+		for _, item := range order.Items {
+			reply.Items = append(reply.Items, &checkoutPb.Item{
+				ProductId: int32(item.ProductID),
+				Quantity:  int32(item.Quantity),
+			})
+		}
+
+		reply.Amount = int64(order.Total())
+	case errors.Is(err, errUnsupportedCommand),
+		errors.Is(err, domain.ErrOrderNotFound),
+		errors.Is(err, domain.ErrInvalidOrder),
+		errors.Is(err, domain.ErrInvalidTransition):
+		reply.Success = false
+		reply.Error = err.Error()
+	case err != nil:
+		return err
+	}
+
+	c.Logger.Info("replying to a checkout command", zap.String("commandId", command.Id), zap.Bool("success", reply.Success))
+
+	return c.Publisher.Publish(ctx, checkoutPb.Exchange, checkoutPb.RepliesRoutingKey, reply)
+}
+
+func (c *CheckoutConsumer) run(ctx context.Context, command *checkoutPb.Command) (domain.Order, error) {
+	reason := fmt.Sprintf("checkout %s", command.SagaId)
+
+	switch {
+	case command.Step == checkoutPb.StepSubmitOrder && command.Action == checkoutPb.ActionExecute:
+		order, err := c.App.GetOrder(ctx, int(command.OrderId))
+		if err != nil {
+			return domain.Order{}, err
+		}
+
+		// the Orders of other customers are reported as missing, so they can't be told apart from the ones that don't exist
+		if order.CustomerID != command.CustomerId {
+			return domain.Order{}, domain.ErrOrderNotFound
+		}
+
+		return c.transition(ctx, int(command.OrderId), domain.OrderEventSubmit, reason, domain.OrderStatusAwaitingPayment)
+	case command.Step == checkoutPb.StepSubmitOrder && command.Action == checkoutPb.ActionCompensate:
+		order, err := c.transition(ctx, int(command.OrderId), domain.OrderEventCancel, reason, domain.OrderStatusCancelled)
+		if errors.Is(err, domain.ErrOrderNotFound) {
+			// the Order was never submitted, there's nothing to undo
+			return domain.Order{}, nil
+		}
+
+		return order, err
+	case command.Step == checkoutPb.StepConfirmOrder && command.Action == checkoutPb.ActionExecute:
+		return c.transition(ctx, int(command.OrderId), domain.OrderEventPay, reason, domain.OrderStatusPaid)
+	default:
+		return domain.Order{}, errUnsupportedCommand
+	}
+}
+
+// transition applies an event to an Order, succeeding as well when the Order already reached the status it leads to
+func (c *CheckoutConsumer) transition(
+	ctx context.Context,
+	orderID int,
+	event domain.OrderEvent,
+	reason string,
+	target domain.OrderStatus,
+) (domain.Order, error) {
+	order, err := c.App.TransitionOrder(ctx, domain.OrderTransition{
+		OrderID: orderID,
+		Event:   event,
+		Reason:  reason,
+	})
+	if !errors.Is(err, domain.ErrInvalidTransition) {
+		return order, err
+	}
+
+	current, getErr := c.App.GetOrder(ctx, orderID)
+	if getErr == nil && current.Status == target {
+		return current, nil
+	}
+
+	return domain.Order{}, err
+}
@@ -0,0 +1,207 @@
+package rmq_port
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	checkoutPb "github.com/lucasmls/ecommerce/services/checkout/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/services/orders/domain"
+	"github.com/lucasmls/ecommerce/services/orders/mocks"
+	"github.com/lucasmls/ecommerce/shared/rmq/rmqtest"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+type CheckoutConsumerSuite struct {
+	suite.Suite
+
+	app       *mocks.Application
+	publisher *rmqtest.Publisher
+	consumer  *CheckoutConsumer
+}
+
+func (s *CheckoutConsumerSuite) SetupTest() {
+	s.app = &mocks.Application{}
+	s.publisher = &rmqtest.Publisher{}
+	s.consumer = MustNewCheckoutConsumer(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), s.app, s.publisher)
+}
+
+func (s *CheckoutConsumerSuite) handle(command *checkoutPb.Command) error {
+	body, err := proto.Marshal(command)
+	s.Require().NoError(err)
+
+	return s.consumer.Handle(context.Background(), body)
+}
+
+// reply is the Reply the consumer published, nil when it didn't reply
+func (s *CheckoutConsumerSuite) reply() *checkoutPb.Reply {
+	reply, _ := s.publisher.Message.(*checkoutPb.Reply)
+	return reply
+}
+
+func (s *CheckoutConsumerSuite) Test_NewCheckoutConsumer() {
+	s.Run("Should fail to instantiate the CheckoutConsumer in case a Publisher isn't provided", func() {
+		_, err := NewCheckoutConsumer(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), nil, nil)
+
+		s.Equal(ErrMissingPublisher, err)
+	})
+}
+
+func (s *CheckoutConsumerSuite) Test_SubmitOrder() {
+	command := &checkoutPb.Command{
+		Id:         "abc:submit_order:execute:1",
+		SagaId:     "abc",
+		Step:       checkoutPb.StepSubmitOrder,
+		Action:     checkoutPb.ActionExecute,
+		OrderId:    7,
+		CustomerId: "john",
+	}
+
+	s.Run("Should submit the order, replying its items and total", func() {
+		s.SetupTest()
+
+		order := domain.Order{
+			ID:         7,
+			CustomerID: "john",
+			Status:     domain.OrderStatusPending,
+			Items:      []domain.LineItem{{ProductID: 1, Quantity: 2, UnitPrice: 79900}},
+		}
+
+		s.app.On("GetOrder", mock.AnythingOfType("*context.valueCtx"), 7).Return(order, nil).Once()
+
+		order.Status = domain.OrderStatusAwaitingPayment
+		s.app.
+			On("TransitionOrder", mock.AnythingOfType("*context.valueCtx"), domain.OrderTransition{OrderID: 7, Event: domain.OrderEventSubmit, Reason: "checkout abc"}).
+			Return(order, nil).
+			Once()
+
+		s.NoError(s.handle(command))
+
+		s.Equal(checkoutPb.RepliesRoutingKey, s.publisher.RoutingKey)
+		s.True(proto.Equal(&checkoutPb.Reply{
+			CommandId: command.Id,
+			SagaId:    "abc",
+			Step:      checkoutPb.StepSubmitOrder,
+			Action:    checkoutPb.ActionExecute,
+			Success:   true,
+			Items:     []*checkoutPb.Item{{ProductId: 1, Quantity: 2}},
+			Amount:    159800,
+		}, s.reply()))
+	})
+
+	s.Run("Should succeed in case the order was already submitted", func() {
+		s.SetupTest()
+
+		order := domain.Order{ID: 7, CustomerID: "john", Status: domain.OrderStatusAwaitingPayment}
+
+		s.app.On("GetOrder", mock.AnythingOfType("*context.valueCtx"), 7).Return(order, nil).Twice()
+		s.app.
+			On("TransitionOrder", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.OrderTransition")).
+			Return(domain.Order{}, domain.ErrInvalidTransition).
+			Once()
+
+		s.NoError(s.handle(command))
+
+		s.True(s.reply().Success)
+	})
+
+	s.Run("Should fail in case the order was placed by someone else", func() {
+		s.SetupTest()
+
+		s.app.On("GetOrder", mock.AnythingOfType("*context.valueCtx"), 7).Return(domain.Order{ID: 7, CustomerID: "jane"}, nil).Once()
+
+		s.NoError(s.handle(command))
+
+		s.False(s.reply().Success)
+		s.Equal(domain.ErrOrderNotFound.Error(), s.reply().Error)
+		s.app.AssertNotCalled(s.T(), "TransitionOrder", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the order can't be submitted anymore", func() {
+		s.SetupTest()
+
+		order := domain.Order{ID: 7, CustomerID: "john", Status: domain.OrderStatusCancelled}
+
+		s.app.On("GetOrder", mock.AnythingOfType("*context.valueCtx"), 7).Return(order, nil).Twice()
+		s.app.
+			On("TransitionOrder", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.OrderTransition")).
+			Return(domain.Order{}, domain.ErrInvalidTransition).
+			Once()
+
+		s.NoError(s.handle(command))
+
+		s.False(s.reply().Success)
+		s.Equal(domain.ErrInvalidTransition.Error(), s.reply().Error)
+	})
+
+	s.Run("Should leave the command unreplied in case of unknown errors", func() {
+		s.SetupTest()
+
+		s.app.On("GetOrder", mock.AnythingOfType("*context.valueCtx"), 7).Return(domain.Order{}, errors.New("connection refused")).Once()
+
+		s.EqualError(s.handle(command), "connection refused")
+		s.Nil(s.reply())
+	})
+}
+
+func (s *CheckoutConsumerSuite) Test_CancelOrder() {
+	s.Run("Should cancel the order, succeeding in case it was already cancelled", func() {
+		s.SetupTest()
+
+		s.app.
+			On("TransitionOrder", mock.AnythingOfType("*context.valueCtx"), domain.OrderTransition{OrderID: 7, Event: domain.OrderEventCancel, Reason: "checkout abc"}).
+			Return(domain.Order{}, domain.ErrInvalidTransition).
+			Once()
+		s.app.On("GetOrder", mock.AnythingOfType("*context.valueCtx"), 7).Return(domain.Order{ID: 7, Status: domain.OrderStatusCancelled}, nil).Once()
+
+		s.NoError(s.handle(&checkoutPb.Command{
+			Id:      "abc:submit_order:compensate:1",
+			SagaId:  "abc",
+			Step:    checkoutPb.StepSubmitOrder,
+			Action:  checkoutPb.ActionCompensate,
+			OrderId: 7,
+		}))
+
+		s.True(s.reply().Success)
+		s.Equal(checkoutPb.ActionCompensate, s.reply().Action)
+	})
+}
+
+func (s *CheckoutConsumerSuite) Test_ConfirmOrder() {
+	s.Run("Should record the payment of the order", func() {
+		s.SetupTest()
+
+		s.app.
+			On("TransitionOrder", mock.AnythingOfType("*context.valueCtx"), domain.OrderTransition{OrderID: 7, Event: domain.OrderEventPay, Reason: "checkout abc"}).
+			Return(domain.Order{ID: 7, Status: domain.OrderStatusPaid}, nil).
+			Once()
+
+		s.NoError(s.handle(&checkoutPb.Command{
+			Id:      "abc:confirm_order:execute:1",
+			SagaId:  "abc",
+			Step:    checkoutPb.StepConfirmOrder,
+			Action:  checkoutPb.ActionExecute,
+			OrderId: 7,
+		}))
+
+		s.True(s.reply().Success)
+		s.Empty(s.reply().Items)
+	})
+
+	s.Run("Should fail the steps the orders don't run", func() {
+		s.SetupTest()
+
+		s.NoError(s.handle(&checkoutPb.Command{Id: "abc:reserve_stock:execute:1", SagaId: "abc", Step: checkoutPb.StepReserveStock, Action: checkoutPb.ActionExecute}))
+
+		s.False(s.reply().Success)
+		s.Equal("unsupported-command", s.reply().Error)
+	})
+}
+
+func TestCheckoutConsumerSuite(t *testing.T) {
+	suite.Run(t, new(CheckoutConsumerSuite))
+}
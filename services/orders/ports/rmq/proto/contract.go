@@ -0,0 +1,9 @@
+package rmq_protobuf
+
+// Exchange is the topic exchange the OrderStatusChanged events go through
+const Exchange = "orders"
+
+// EventRoutingKey routes the OrderStatusChanged events of a status, e.g. orders.paid
+func EventRoutingKey(status string) string {
+	return "orders." + status
+}
@@ -0,0 +1,59 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	pb "github.com/lucasmls/ecommerce/services/payments/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/shared/rmq"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RmqEventPublisher publishes the PaymentEvents through the payments exchange of RabbitMQ, routed by their type.
+type RmqEventPublisher struct {
+	Logger    *zap.Logger
+	Tracer    trace.Tracer
+	Publisher rmq.Publisher
+}
+
+// NewRmqEventPublisher creates a new RmqEventPublisher.
+func NewRmqEventPublisher(logger *zap.Logger, tracer trace.Tracer, publisher rmq.Publisher) *RmqEventPublisher {
+	return &RmqEventPublisher{
+		Logger:    logger,
+		Tracer:    tracer,
+		Publisher: publisher,
+	}
+}
+
+// Publish publishes a PaymentEvent, routed by its type
+func (p *RmqEventPublisher) Publish(ctx context.Context, event domain.PaymentEvent) error {
+	ctx, span := p.Tracer.Start(ctx, "events.Publish")
+	defer span.End()
+
+	payment := event.Payment
+
+	return p.Publisher.Publish(ctx, pb.Exchange, pb.EventRoutingKey(string(event.Type)), &pb.PaymentEvent{
+		Type:             string(event.Type),
+		PaymentId:        payment.ID,
+		OrderId:          int32(payment.OrderID),
+		CustomerId:       payment.CustomerID,
+		Amount:           event.Amount,
+		Status:           string(payment.Status),
+		AuthorizedAmount: payment.Amount,
+		CapturedAmount:   payment.CapturedAmount,
+		RefundedAmount:   payment.RefundedAmount,
+		FailureReason:    payment.FailureReason,
+		OccurredAt:       toProtoTimestamp(payment.UpdatedAt),
+	})
+}
+
+func toProtoTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
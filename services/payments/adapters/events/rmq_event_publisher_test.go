@@ -0,0 +1,78 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	pb "github.com/lucasmls/ecommerce/services/payments/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/shared/rmq/rmqtest"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type RmqEventPublisherSuite struct {
+	suite.Suite
+
+	publisher      *rmqtest.Publisher
+	eventPublisher *RmqEventPublisher
+}
+
+func (s *RmqEventPublisherSuite) SetupTest() {
+	s.publisher = &rmqtest.Publisher{}
+	s.eventPublisher = NewRmqEventPublisher(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), s.publisher)
+}
+
+func (s *RmqEventPublisherSuite) Test_Publish() {
+	s.Run("Should route the event by its type", func() {
+		updatedAt := time.Date(2022, 5, 8, 10, 0, 0, 0, time.UTC)
+
+		err := s.eventPublisher.Publish(context.Background(), domain.PaymentEvent{
+			Type: domain.EventRefunded,
+			Payment: domain.Payment{
+				ID:             "pay_1",
+				OrderID:        7,
+				CustomerID:     "john",
+				Amount:         159800,
+				CapturedAmount: 159800,
+				RefundedAmount: 50000,
+				Status:         domain.PaymentStatusCaptured,
+				UpdatedAt:      updatedAt,
+			},
+			Amount: 50000,
+		})
+
+		s.NoError(err)
+		s.Equal("payments", s.publisher.Exchange)
+		s.Equal("payments.refunded", s.publisher.RoutingKey)
+		s.True(proto.Equal(&pb.PaymentEvent{
+			Type:             "refunded",
+			PaymentId:        "pay_1",
+			OrderId:          7,
+			CustomerId:       "john",
+			Amount:           50000,
+			Status:           "captured",
+			AuthorizedAmount: 159800,
+			CapturedAmount:   159800,
+			RefundedAmount:   50000,
+			OccurredAt:       timestamppb.New(updatedAt),
+		}, s.publisher.Message))
+	})
+
+	s.Run("Should fail in case the event can't be published", func() {
+		s.publisher.Err = errors.New("connection closed")
+
+		err := s.eventPublisher.Publish(context.Background(), domain.PaymentEvent{Type: domain.EventCaptured})
+
+		s.Equal(s.publisher.Err, err)
+	})
+}
+
+func TestRmqEventPublisherSuite(t *testing.T) {
+	suite.Run(t, new(RmqEventPublisherSuite))
+}
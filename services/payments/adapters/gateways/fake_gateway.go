@@ -0,0 +1,310 @@
+package gateways
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"go.uber.org/zap"
+)
+
+// FakeSignatureHeader is the header carrying the signature of the webhooks posted by the FakeGateway
+const FakeSignatureHeader = "X-Fake-Signature"
+
+const (
+	// fakeReferencePrefix tells apart the references of the FakeGateway from the ones of real gateways
+	fakeReferencePrefix = "fake_"
+	// fakeDeclineReason is the FailureReason of the authorizations the FakeGateway declines
+	fakeDeclineReason = "card declined"
+	// webhookAttempts bounds how many times a webhook is posted until it's acknowledged, as the
+	// Payment it's about may not be stored yet when it's first posted
+	webhookAttempts = 5
+)
+
+// Scenario is how the FakeGateway answers the calls about a payment method
+type Scenario string
+
+const (
+	ScenarioApprove Scenario = "approve"
+	ScenarioDecline Scenario = "decline"
+	// ScenarioUnavailable fails every call as if the gateway couldn't be reached
+	ScenarioUnavailable Scenario = "unavailable"
+	// ScenarioPending answers the authorizations as pending, approving them later through a webhook
+	ScenarioPending Scenario = "pending"
+	// ScenarioPendingDecline answers the authorizations as pending, declining them later through a webhook
+	ScenarioPendingDecline Scenario = "pending_decline"
+	// ScenarioCaptureFails approves the authorizations but declines their captures
+	ScenarioCaptureFails Scenario = "capture_fails"
+)
+
+// DefaultScenarios are the payment methods the FakeGateway knows about when it isn't told otherwise
+var DefaultScenarios = map[string]Scenario{
+	"fake_ok":               ScenarioApprove,
+	"fake_declined":         ScenarioDecline,
+	"fake_unavailable":      ScenarioUnavailable,
+	"fake_pending":          ScenarioPending,
+	"fake_pending_declined": ScenarioPendingDecline,
+	"fake_capture_fails":    ScenarioCaptureFails,
+}
+
+// FakeGatewayInput is the input (aka dependencies) needed to create a FakeGateway
+type FakeGatewayInput struct {
+	Logger *zap.Logger
+	// Secret signs the webhooks
+	Secret string
+	// WebhookURL is where the webhooks settling the pending authorizations are posted, they aren't posted when empty
+	WebhookURL string
+	// WebhookDelay is how long the pending authorizations wait to be settled
+	WebhookDelay time.Duration
+	// Scenarios maps the payment methods to their Scenario, DefaultScenarios when nil
+	Scenarios map[string]Scenario
+	// DefaultScenario is the one of the payment methods missing from Scenarios, ScenarioApprove when empty
+	DefaultScenario Scenario
+	// Client posts the webhooks, http.DefaultClient when nil
+	Client *http.Client
+}
+
+// fakePayment is what the FakeGateway remembers about an authorization
+type fakePayment struct {
+	scenario Scenario
+	status   domain.PaymentStatus
+}
+
+// fakeWebhook is the payload of the webhooks posted by the FakeGateway
+type fakeWebhook struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Reference string `json:"reference"`
+	Reason    string `json:"reason,omitempty"`
+}
+
+// FakeGateway is a PaymentGateway for local development and tests, moving no money at all.
+// It's deterministic: the payment method picks the Scenario of an authorization and its reference
+// is derived from the idempotency key, so calling again with the same key answers the same.
+// It forgets the authorizations when restarted, approving the calls about the ones it doesn't know.
+type FakeGateway struct {
+	in FakeGatewayInput
+
+	// mu guards payments, indexed by their references
+	mu       sync.Mutex
+	payments map[string]*fakePayment
+}
+
+// NewFakeGateway is the FakeGateway constructor
+func NewFakeGateway(in FakeGatewayInput) (*FakeGateway, error) {
+	if in.Logger == nil {
+		return nil, errors.New("missing required dependency: Logger")
+	}
+
+	if in.Secret == "" {
+		return nil, errors.New("missing required dependency: Secret")
+	}
+
+	if in.Scenarios == nil {
+		in.Scenarios = DefaultScenarios
+	}
+
+	if in.DefaultScenario == "" {
+		in.DefaultScenario = ScenarioApprove
+	}
+
+	if in.Client == nil {
+		in.Client = http.DefaultClient
+	}
+
+	return &FakeGateway{
+		in:       in,
+		payments: map[string]*fakePayment{},
+	}, nil
+}
+
+// MustNewFakeGateway is the FakeGateway constructor
+// It panics if any error is found
+func MustNewFakeGateway(in FakeGatewayInput) *FakeGateway {
+	gateway, err := NewFakeGateway(in)
+	if err != nil {
+		panic(err)
+	}
+
+	return gateway
+}
+
+// Authorize answers according to the Scenario of the payment method
+func (g *FakeGateway) Authorize(ctx context.Context, idempotencyKey string, amount int64, paymentMethod string) (domain.Authorization, error) {
+	scenario, ok := g.in.Scenarios[paymentMethod]
+	if !ok {
+		scenario = g.in.DefaultScenario
+	}
+
+	if scenario == ScenarioUnavailable {
+		return domain.Authorization{}, domain.ErrGatewayUnavailable
+	}
+
+	reference := fakeReference(idempotencyKey)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	payment, ok := g.payments[reference]
+	if !ok {
+		payment = &fakePayment{scenario: scenario, status: domain.PaymentStatusAuthorized}
+
+		switch scenario {
+		case ScenarioDecline:
+			payment.status = domain.PaymentStatusFailed
+		case ScenarioPending, ScenarioPendingDecline:
+			payment.status = domain.PaymentStatusPending
+			time.AfterFunc(g.in.WebhookDelay, func() { g.settle(reference) })
+		}
+
+		g.payments[reference] = payment
+	}
+
+	authorization := domain.Authorization{Reference: reference, Status: payment.status}
+	if payment.status == domain.PaymentStatusFailed {
+		authorization.FailureReason = fakeDeclineReason
+	}
+
+	return authorization, nil
+}
+
+// Capture declines the captures of the ScenarioCaptureFails authorizations
+func (g *FakeGateway) Capture(ctx context.Context, idempotencyKey string, reference string, amount int64) error {
+	if g.scenario(reference) == ScenarioCaptureFails {
+		return domain.ErrPaymentDeclined
+	}
+
+	return nil
+}
+
+// Void always succeeds
+func (g *FakeGateway) Void(ctx context.Context, idempotencyKey string, reference string) error {
+	return nil
+}
+
+// Refund always succeeds
+func (g *FakeGateway) Refund(ctx context.Context, idempotencyKey string, reference string, amount int64) error {
+	return nil
+}
+
+// ParseWebhook verifies the hex encoded HMAC-SHA256 signature of a webhook and parses it
+func (g *FakeGateway) ParseWebhook(payload []byte, signature string) (domain.WebhookEvent, error) {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, g.sign(payload)) {
+		return domain.WebhookEvent{}, domain.ErrInvalidWebhook
+	}
+
+	var webhook fakeWebhook
+	if err := json.Unmarshal(payload, &webhook); err != nil {
+		return domain.WebhookEvent{}, domain.ErrInvalidWebhook
+	}
+
+	event := domain.WebhookEvent{
+		ID:        webhook.ID,
+		Type:      domain.WebhookEventType(webhook.Type),
+		Reference: webhook.Reference,
+		Reason:    webhook.Reason,
+	}
+
+	if event.ID == "" || event.Reference == "" ||
+		(event.Type != domain.WebhookEventAuthorized && event.Type != domain.WebhookEventFailed) {
+		return domain.WebhookEvent{}, domain.ErrInvalidWebhook
+	}
+
+	return event, nil
+}
+
+func (g *FakeGateway) scenario(reference string) Scenario {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	payment, ok := g.payments[reference]
+	if !ok {
+		return ScenarioApprove
+	}
+
+	return payment.scenario
+}
+
+// settle ends a pending authorization, posting the webhook telling how it ended
+func (g *FakeGateway) settle(reference string) {
+	g.mu.Lock()
+	payment := g.payments[reference]
+
+	webhook := fakeWebhook{
+		ID:        "evt_" + reference,
+		Type:      string(domain.WebhookEventAuthorized),
+		Reference: reference,
+	}
+
+	payment.status = domain.PaymentStatusAuthorized
+	if payment.scenario == ScenarioPendingDecline {
+		payment.status = domain.PaymentStatusFailed
+		webhook.Type = string(domain.WebhookEventFailed)
+		webhook.Reason = fakeDeclineReason
+	}
+	g.mu.Unlock()
+
+	if g.in.WebhookURL == "" {
+		return
+	}
+
+	payload, err := json.Marshal(webhook)
+	if err != nil {
+		g.in.Logger.Error("failed to encode a webhook", zap.Error(err))
+		return
+	}
+
+	for attempt := 1; attempt <= webhookAttempts; attempt++ {
+		err = g.post(payload)
+		if err == nil {
+			return
+		}
+
+		time.Sleep(time.Duration(attempt) * time.Second)
+	}
+
+	g.in.Logger.Error("failed to post a webhook, giving up", zap.String("reference", reference), zap.Error(err))
+}
+
+func (g *FakeGateway) post(payload []byte) error {
+	request, err := http.NewRequest(http.MethodPost, g.in.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(FakeSignatureHeader, hex.EncodeToString(g.sign(payload)))
+
+	response, err := g.in.Client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusMultipleChoices {
+		return errors.New("the webhook was answered with " + response.Status)
+	}
+
+	return nil
+}
+
+func (g *FakeGateway) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(g.in.Secret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// fakeReference derives the reference of an authorization from its idempotency key
+func fakeReference(idempotencyKey string) string {
+	sum := sha256.Sum256([]byte(idempotencyKey))
+	return fakeReferencePrefix + hex.EncodeToString(sum[:12])
+}
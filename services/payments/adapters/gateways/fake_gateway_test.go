@@ -0,0 +1,174 @@
+package gateways
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+)
+
+type FakeGatewaySuite struct {
+	suite.Suite
+
+	gateway *FakeGateway
+}
+
+func (s *FakeGatewaySuite) SetupTest() {
+	s.gateway = MustNewFakeGateway(FakeGatewayInput{
+		Logger: zap.NewNop(),
+		Secret: "secret",
+	})
+}
+
+func (s *FakeGatewaySuite) Test_NewFakeGateway() {
+	s.Run("Should fail to instantiate the FakeGateway in case a Secret isn't provided", func() {
+		_, err := NewFakeGateway(FakeGatewayInput{Logger: zap.NewNop()})
+
+		s.EqualError(err, "missing required dependency: Secret")
+	})
+}
+
+func (s *FakeGatewaySuite) Test_Authorize() {
+	s.Run("Should answer the same reference for the same idempotency key", func() {
+		first, err := s.gateway.Authorize(context.Background(), "key-1", 1000, "fake_ok")
+		s.NoError(err)
+
+		again, err := s.gateway.Authorize(context.Background(), "key-1", 1000, "fake_ok")
+		s.NoError(err)
+
+		other, err := s.gateway.Authorize(context.Background(), "key-2", 1000, "fake_ok")
+		s.NoError(err)
+
+		s.Equal(domain.PaymentStatusAuthorized, first.Status)
+		s.Equal(first, again)
+		s.NotEqual(first.Reference, other.Reference)
+	})
+
+	s.Run("Should answer according to the scenario of the payment method", func() {
+		declined, err := s.gateway.Authorize(context.Background(), "key-3", 1000, "fake_declined")
+		s.NoError(err)
+		s.Equal(domain.PaymentStatusFailed, declined.Status)
+		s.Equal(fakeDeclineReason, declined.FailureReason)
+
+		_, err = s.gateway.Authorize(context.Background(), "key-4", 1000, "fake_unavailable")
+		s.Equal(domain.ErrGatewayUnavailable, err)
+
+		unknown, err := s.gateway.Authorize(context.Background(), "key-5", 1000, "tok_visa")
+		s.NoError(err)
+		s.Equal(domain.PaymentStatusAuthorized, unknown.Status)
+	})
+
+	s.Run("Should take the scenarios it's configured with", func() {
+		gateway := MustNewFakeGateway(FakeGatewayInput{
+			Logger:          zap.NewNop(),
+			Secret:          "secret",
+			Scenarios:       map[string]Scenario{"tok_visa": ScenarioApprove},
+			DefaultScenario: ScenarioDecline,
+		})
+
+		approved, err := gateway.Authorize(context.Background(), "key-1", 1000, "tok_visa")
+		s.NoError(err)
+		s.Equal(domain.PaymentStatusAuthorized, approved.Status)
+
+		declined, err := gateway.Authorize(context.Background(), "key-2", 1000, "fake_ok")
+		s.NoError(err)
+		s.Equal(domain.PaymentStatusFailed, declined.Status)
+	})
+}
+
+func (s *FakeGatewaySuite) Test_Capture() {
+	approved, err := s.gateway.Authorize(context.Background(), "key-1", 1000, "fake_ok")
+	s.Require().NoError(err)
+
+	failing, err := s.gateway.Authorize(context.Background(), "key-2", 1000, "fake_capture_fails")
+	s.Require().NoError(err)
+	s.Equal(domain.PaymentStatusAuthorized, failing.Status)
+
+	s.NoError(s.gateway.Capture(context.Background(), "capture-1", approved.Reference, 1000))
+	s.Equal(domain.ErrPaymentDeclined, s.gateway.Capture(context.Background(), "capture-2", failing.Reference, 1000))
+	s.NoError(s.gateway.Capture(context.Background(), "capture-3", "fake_forgotten", 1000))
+}
+
+func (s *FakeGatewaySuite) Test_PendingAuthorization() {
+	webhooks := make(chan *http.Request, 1)
+	payloads := make(chan []byte, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := io.ReadAll(r.Body)
+		webhooks <- r
+		payloads <- payload
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	gateway := MustNewFakeGateway(FakeGatewayInput{
+		Logger:       zap.NewNop(),
+		Secret:       "secret",
+		WebhookURL:   server.URL,
+		WebhookDelay: time.Millisecond,
+	})
+
+	authorization, err := gateway.Authorize(context.Background(), "key-1", 1000, "fake_pending_declined")
+	s.Require().NoError(err)
+	s.Equal(domain.PaymentStatusPending, authorization.Status)
+
+	select {
+	case request := <-webhooks:
+		event, err := gateway.ParseWebhook(<-payloads, request.Header.Get(FakeSignatureHeader))
+
+		s.NoError(err)
+		s.Equal(domain.WebhookEventFailed, event.Type)
+		s.Equal(authorization.Reference, event.Reference)
+		s.Equal(fakeDeclineReason, event.Reason)
+	case <-time.After(time.Second):
+		s.Fail("the webhook wasn't posted")
+	}
+
+	settled, err := gateway.Authorize(context.Background(), "key-1", 1000, "fake_pending_declined")
+	s.NoError(err)
+	s.Equal(domain.PaymentStatusFailed, settled.Status)
+}
+
+func (s *FakeGatewaySuite) Test_ParseWebhook() {
+	payload := []byte(`{"id":"evt_1","type":"payment.authorized","reference":"fake_1"}`)
+
+	s.Run("Should parse a signed webhook", func() {
+		event, err := s.gateway.ParseWebhook(payload, hex.EncodeToString(s.gateway.sign(payload)))
+
+		s.NoError(err)
+		s.Equal(domain.WebhookEvent{ID: "evt_1", Type: domain.WebhookEventAuthorized, Reference: "fake_1"}, event)
+	})
+
+	s.Run("Should fail in case the webhook is invalid", func() {
+		unknownType := []byte(`{"id":"evt_1","type":"payment.lost","reference":"fake_1"}`)
+		other := MustNewFakeGateway(FakeGatewayInput{Logger: zap.NewNop(), Secret: "other"})
+
+		testCases := []struct {
+			payload   []byte
+			signature string
+		}{
+			{payload: payload, signature: ""},
+			{payload: payload, signature: "not-hex"},
+			{payload: payload, signature: hex.EncodeToString(other.sign(payload))},
+			{payload: unknownType, signature: hex.EncodeToString(s.gateway.sign(unknownType))},
+			{payload: []byte("{"), signature: hex.EncodeToString(s.gateway.sign([]byte("{")))},
+		}
+
+		for _, testCase := range testCases {
+			_, err := s.gateway.ParseWebhook(testCase.payload, testCase.signature)
+
+			s.Equal(domain.ErrInvalidWebhook, err)
+		}
+	})
+}
+
+func TestFakeGatewaySuite(t *testing.T) {
+	suite.Run(t, new(FakeGatewaySuite))
+}
@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// InMemoryPaymentsRepository keeps the Payments in memory.
+type InMemoryPaymentsRepository struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	// mu guards payments, indexed by their IDs, along with keys and references, which map the
+	// idempotency keys and the gateway references to the IDs of the Payments
+	mu         sync.Mutex
+	payments   map[string]domain.Payment
+	keys       map[string]string
+	references map[string]string
+}
+
+// NewInMemoryPaymentsRepository creates a new InMemoryPaymentsRepository.
+func NewInMemoryPaymentsRepository(logger *zap.Logger, tracer trace.Tracer) *InMemoryPaymentsRepository {
+	return &InMemoryPaymentsRepository{
+		Logger:     logger,
+		Tracer:     tracer,
+		payments:   map[string]domain.Payment{},
+		keys:       map[string]string{},
+		references: map[string]string{},
+	}
+}
+
+// Create stores a new Payment in memory.
+func (r *InMemoryPaymentsRepository) Create(ctx context.Context, payment domain.Payment) (domain.Payment, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Create")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.keys[payment.IdempotencyKey]; ok {
+		return domain.Payment{}, domain.ErrPaymentAlreadyExists
+	}
+
+	if _, ok := r.payments[payment.ID]; ok {
+		return domain.Payment{}, domain.ErrPaymentAlreadyExists
+	}
+
+	payment = copyPayment(payment)
+	payment.Version = 1
+
+	r.store(payment)
+
+	return copyPayment(payment), nil
+}
+
+// Get fetches a single Payment from memory.
+func (r *InMemoryPaymentsRepository) Get(ctx context.Context, id string) (domain.Payment, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Get")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.get(id)
+}
+
+// GetByIdempotencyKey fetches the Payment authorized with the given idempotency key from memory.
+func (r *InMemoryPaymentsRepository) GetByIdempotencyKey(ctx context.Context, key string) (domain.Payment, error) {
+	_, span := r.Tracer.Start(ctx, "repository.GetByIdempotencyKey")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.get(r.keys[key])
+}
+
+// GetByReference fetches the Payment with the given gateway reference from memory.
+func (r *InMemoryPaymentsRepository) GetByReference(ctx context.Context, reference string) (domain.Payment, error) {
+	_, span := r.Tracer.Start(ctx, "repository.GetByReference")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.get(r.references[reference])
+}
+
+// List lists the Payments matching the filter from memory, most recently created first.
+func (r *InMemoryPaymentsRepository) List(ctx context.Context, filter domain.PaymentFilter) ([]domain.Payment, error) {
+	_, span := r.Tracer.Start(ctx, "repository.List")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := []domain.Payment{}
+	for _, payment := range r.payments {
+		if filter.OrderID != 0 && payment.OrderID != filter.OrderID {
+			continue
+		}
+
+		if filter.CustomerID != "" && payment.CustomerID != filter.CustomerID {
+			continue
+		}
+
+		if filter.Status != "" && payment.Status != filter.Status {
+			continue
+		}
+
+		result = append(result, copyPayment(payment))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.After(result[j].CreatedAt)
+		}
+
+		return result[i].ID > result[j].ID
+	})
+
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[:filter.Limit]
+	}
+
+	return result, nil
+}
+
+// Update replaces a Payment in memory as long as its Version matches the stored one.
+func (r *InMemoryPaymentsRepository) Update(ctx context.Context, payment domain.Payment) (domain.Payment, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Update")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.payments[payment.ID]
+	if !ok {
+		return domain.Payment{}, domain.ErrPaymentNotFound
+	}
+
+	if stored.Version != payment.Version {
+		return domain.Payment{}, domain.ErrPaymentVersionConflict
+	}
+
+	payment = copyPayment(payment)
+	payment.Version++
+
+	r.store(payment)
+
+	return copyPayment(payment), nil
+}
+
+func (r *InMemoryPaymentsRepository) get(id string) (domain.Payment, error) {
+	payment, ok := r.payments[id]
+	if !ok {
+		return domain.Payment{}, domain.ErrPaymentNotFound
+	}
+
+	return copyPayment(payment), nil
+}
+
+func (r *InMemoryPaymentsRepository) store(payment domain.Payment) {
+	r.payments[payment.ID] = payment
+	r.keys[payment.IdempotencyKey] = payment.ID
+
+	if payment.GatewayReference != "" {
+		r.references[payment.GatewayReference] = payment.ID
+	}
+}
+
+// copyPayment keeps the stored Operations and WebhookEvents from being changed through the Payments handed out by the repository
+func copyPayment(payment domain.Payment) domain.Payment {
+	payment.Operations = append([]domain.Operation{}, payment.Operations...)
+	payment.WebhookEvents = append([]string{}, payment.WebhookEvents...)
+	return payment
+}
@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type InMemoryPaymentsRepositorySuite struct {
+	suite.Suite
+
+	paymentsRepo *InMemoryPaymentsRepository
+	createdAt    time.Time
+}
+
+func (s *InMemoryPaymentsRepositorySuite) SetupTest() {
+	s.paymentsRepo = NewInMemoryPaymentsRepository(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""))
+	s.createdAt = time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+}
+
+func (s *InMemoryPaymentsRepositorySuite) create(id string, orderID int, createdAt time.Time) domain.Payment {
+	payment, err := s.paymentsRepo.Create(context.Background(), domain.NewPayment(id, domain.AuthorizationRequest{
+		IdempotencyKey: "key-" + id,
+		OrderID:        orderID,
+		CustomerID:     "john",
+		Amount:         159800,
+		PaymentMethod:  "fake_ok",
+	}, createdAt))
+	s.Require().NoError(err)
+
+	return payment
+}
+
+func (s *InMemoryPaymentsRepositorySuite) Test_Create() {
+	s.Run("Should set the first version of the payment", func() {
+		payment := s.create("a", 1, s.createdAt)
+
+		s.Equal(1, payment.Version)
+
+		got, err := s.paymentsRepo.Get(context.Background(), "a")
+		s.NoError(err)
+		s.Equal(payment, got)
+	})
+
+	s.Run("Should fail in case the idempotency key was already used", func() {
+		_, err := s.paymentsRepo.Create(context.Background(), domain.Payment{ID: "b", IdempotencyKey: "key-a"})
+
+		s.Equal(domain.ErrPaymentAlreadyExists, err)
+	})
+}
+
+func (s *InMemoryPaymentsRepositorySuite) Test_Get() {
+	payment := s.create("a", 1, s.createdAt)
+	payment.GatewayReference = "fake_1"
+	_, err := s.paymentsRepo.Update(context.Background(), payment)
+	s.Require().NoError(err)
+
+	s.Run("Should fetch the payment by its idempotency key and its gateway reference", func() {
+		got, err := s.paymentsRepo.GetByIdempotencyKey(context.Background(), "key-a")
+		s.NoError(err)
+		s.Equal("a", got.ID)
+
+		got, err = s.paymentsRepo.GetByReference(context.Background(), "fake_1")
+		s.NoError(err)
+		s.Equal("a", got.ID)
+	})
+
+	s.Run("Should fail in case the payment doesn't exist", func() {
+		_, err := s.paymentsRepo.Get(context.Background(), "missing")
+		s.Equal(domain.ErrPaymentNotFound, err)
+
+		_, err = s.paymentsRepo.GetByIdempotencyKey(context.Background(), "missing")
+		s.Equal(domain.ErrPaymentNotFound, err)
+
+		_, err = s.paymentsRepo.GetByReference(context.Background(), "missing")
+		s.Equal(domain.ErrPaymentNotFound, err)
+	})
+}
+
+func (s *InMemoryPaymentsRepositorySuite) Test_List() {
+	s.create("a", 1, s.createdAt)
+	s.create("b", 2, s.createdAt.Add(time.Hour))
+	authorized := s.create("c", 3, s.createdAt.Add(2*time.Hour))
+
+	authorized.Status = domain.PaymentStatusAuthorized
+	_, err := s.paymentsRepo.Update(context.Background(), authorized)
+	s.Require().NoError(err)
+
+	s.Run("Should list the most recently created first", func() {
+		got, err := s.paymentsRepo.List(context.Background(), domain.PaymentFilter{Limit: 2})
+
+		s.NoError(err)
+		s.Require().Len(got, 2)
+		s.Equal("c", got[0].ID)
+		s.Equal("b", got[1].ID)
+	})
+
+	s.Run("Should filter by order and status", func() {
+		got, err := s.paymentsRepo.List(context.Background(), domain.PaymentFilter{Status: domain.PaymentStatusPending})
+
+		s.NoError(err)
+		s.Len(got, 2)
+
+		got, err = s.paymentsRepo.List(context.Background(), domain.PaymentFilter{OrderID: 1})
+
+		s.NoError(err)
+		s.Require().Len(got, 1)
+		s.Equal("a", got[0].ID)
+	})
+}
+
+func (s *InMemoryPaymentsRepositorySuite) Test_Update() {
+	payment := s.create("a", 1, s.createdAt)
+
+	s.Run("Should bump the version of the payment", func() {
+		payment.Status = domain.PaymentStatusAuthorized
+		updated, err := s.paymentsRepo.Update(context.Background(), payment)
+
+		s.NoError(err)
+		s.Equal(2, updated.Version)
+		s.Equal(domain.PaymentStatusAuthorized, updated.Status)
+	})
+
+	s.Run("Should fail in case the payment changed since it was read", func() {
+		_, err := s.paymentsRepo.Update(context.Background(), payment)
+
+		s.Equal(domain.ErrPaymentVersionConflict, err)
+	})
+
+	s.Run("Should fail in case the payment doesn't exist", func() {
+		_, err := s.paymentsRepo.Update(context.Background(), domain.Payment{ID: "missing"})
+
+		s.Equal(domain.ErrPaymentNotFound, err)
+	})
+}
+
+func TestInMemoryPaymentsRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InMemoryPaymentsRepositorySuite))
+}
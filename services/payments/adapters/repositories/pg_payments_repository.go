@@ -0,0 +1,274 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"github.com/lucasmls/ecommerce/shared/pg"
+)
+
+const paymentColumns = "id, idempotency_key, order_id, customer_id, amount, captured_amount, refunded_amount, payment_method, status, gateway_reference, failure_reason, operations, webhook_events, version, created_at, updated_at"
+
+// operationRecord is how an Operation is stored on the operations column of a payment
+type operationRecord struct {
+	Kind           string    `json:"kind"`
+	IdempotencyKey string    `json:"idempotency_key"`
+	Amount         int64     `json:"amount"`
+	At             time.Time `json:"at"`
+}
+
+// PgPaymentsRepository keeps the Payments on Postgres, each one on a single row.
+// The rows are only updated while their version is still the one the Payment was read with,
+// so the money moved by concurrent operations is never recorded over each other.
+type PgPaymentsRepository struct {
+	db *sql.DB
+}
+
+func NewPgPaymentsRepository(connectionString string) (*PgPaymentsRepository, error) {
+	db, err := sql.Open(
+		"postgres",
+		connectionString,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	repository := &PgPaymentsRepository{
+		db: db,
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+func MustNewPgPaymentsRepository(connectionString string) *PgPaymentsRepository {
+	repo, err := NewPgPaymentsRepository(connectionString)
+	if err != nil {
+		panic(err)
+	}
+
+	return repo
+}
+
+// Create inserts a new Payment
+func (r *PgPaymentsRepository) Create(ctx context.Context, payment domain.Payment) (domain.Payment, error) {
+	operations, webhookEvents, err := marshalPayment(payment)
+	if err != nil {
+		return domain.Payment{}, err
+	}
+
+	row := r.db.QueryRowContext(
+		ctx,
+		`INSERT INTO payments (id, idempotency_key, order_id, customer_id, amount, captured_amount, refunded_amount, payment_method, status, gateway_reference, failure_reason, operations, webhook_events, version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, 1, $14, $15)
+		RETURNING `+paymentColumns,
+		payment.ID, payment.IdempotencyKey, payment.OrderID, payment.CustomerID, payment.Amount, payment.CapturedAmount,
+		payment.RefundedAmount, payment.PaymentMethod, string(payment.Status), payment.GatewayReference, payment.FailureReason,
+		operations, webhookEvents, payment.CreatedAt, payment.UpdatedAt,
+	)
+
+	created, err := scanPayment(row)
+	if pg.IsUniqueViolation(err) {
+		return domain.Payment{}, domain.ErrPaymentAlreadyExists
+	}
+
+	return created, err
+}
+
+// Get fetches a single Payment
+func (r *PgPaymentsRepository) Get(ctx context.Context, id string) (domain.Payment, error) {
+	return r.get(ctx, "id", id)
+}
+
+// GetByIdempotencyKey fetches the Payment authorized with the given idempotency key
+func (r *PgPaymentsRepository) GetByIdempotencyKey(ctx context.Context, key string) (domain.Payment, error) {
+	return r.get(ctx, "idempotency_key", key)
+}
+
+// GetByReference fetches the Payment with the given gateway reference
+func (r *PgPaymentsRepository) GetByReference(ctx context.Context, reference string) (domain.Payment, error) {
+	if reference == "" {
+		return domain.Payment{}, domain.ErrPaymentNotFound
+	}
+
+	return r.get(ctx, "gateway_reference", reference)
+}
+
+// List lists the Payments matching the filter, most recently created first
+func (r *PgPaymentsRepository) List(ctx context.Context, filter domain.PaymentFilter) ([]domain.Payment, error) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	if filter.OrderID != 0 {
+		args = append(args, filter.OrderID)
+		conditions = append(conditions, fmt.Sprintf("order_id = $%d", len(args)))
+	}
+
+	if filter.CustomerID != "" {
+		args = append(args, filter.CustomerID)
+		conditions = append(conditions, fmt.Sprintf("customer_id = $%d", len(args)))
+	}
+
+	if filter.Status != "" {
+		args = append(args, string(filter.Status))
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	query := "SELECT " + paymentColumns + " FROM payments"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY created_at DESC, id DESC"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payments := []domain.Payment{}
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		payments = append(payments, payment)
+	}
+
+	return payments, rows.Err()
+}
+
+// Update replaces a Payment as long as the stored version matches
+func (r *PgPaymentsRepository) Update(ctx context.Context, payment domain.Payment) (domain.Payment, error) {
+	operations, webhookEvents, err := marshalPayment(payment)
+	if err != nil {
+		return domain.Payment{}, err
+	}
+
+	row := r.db.QueryRowContext(
+		ctx,
+		`UPDATE payments
+		SET captured_amount = $3, refunded_amount = $4, status = $5, gateway_reference = $6, failure_reason = $7,
+			operations = $8, webhook_events = $9, version = version + 1, updated_at = $10
+		WHERE id = $1 AND version = $2
+		RETURNING `+paymentColumns,
+		payment.ID, payment.Version, payment.CapturedAmount, payment.RefundedAmount, string(payment.Status),
+		payment.GatewayReference, payment.FailureReason, operations, webhookEvents, payment.UpdatedAt,
+	)
+
+	updated, err := scanPayment(row)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return updated, err
+	}
+
+	// nothing was updated, either because the payment doesn't exist or because its version changed
+	if _, err := r.Get(ctx, payment.ID); err != nil {
+		return domain.Payment{}, err
+	}
+
+	return domain.Payment{}, domain.ErrPaymentVersionConflict
+}
+
+// get fetches the Payment with the given value on a unique column
+func (r *PgPaymentsRepository) get(ctx context.Context, column string, value string) (domain.Payment, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+paymentColumns+" FROM payments WHERE "+column+" = $1", value)
+
+	payment, err := scanPayment(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Payment{}, domain.ErrPaymentNotFound
+	}
+
+	return payment, err
+}
+
+func marshalPayment(payment domain.Payment) ([]byte, []byte, error) {
+	operationRecords := make([]operationRecord, 0, len(payment.Operations))
+	for _, operation := range payment.Operations {
+		operationRecords = append(operationRecords, operationRecord{
+			Kind:           string(operation.Kind),
+			IdempotencyKey: operation.IdempotencyKey,
+			Amount:         operation.Amount,
+			At:             operation.At,
+		})
+	}
+
+	operations, err := json.Marshal(operationRecords)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	webhookEvents, err := json.Marshal(append([]string{}, payment.WebhookEvents...))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return operations, webhookEvents, nil
+}
+
+func scanPayment(row pg.Scanner) (domain.Payment, error) {
+	var payment domain.Payment
+	var status string
+	var operations []byte
+	var webhookEvents []byte
+
+	err := row.Scan(
+		&payment.ID,
+		&payment.IdempotencyKey,
+		&payment.OrderID,
+		&payment.CustomerID,
+		&payment.Amount,
+		&payment.CapturedAmount,
+		&payment.RefundedAmount,
+		&payment.PaymentMethod,
+		&status,
+		&payment.GatewayReference,
+		&payment.FailureReason,
+		&operations,
+		&webhookEvents,
+		&payment.Version,
+		&payment.CreatedAt,
+		&payment.UpdatedAt,
+	)
+	if err != nil {
+		return domain.Payment{}, err
+	}
+
+	var operationRecords []operationRecord
+	if err := json.Unmarshal(operations, &operationRecords); err != nil {
+		return domain.Payment{}, err
+	}
+
+	if err := json.Unmarshal(webhookEvents, &payment.WebhookEvents); err != nil {
+		return domain.Payment{}, err
+	}
+
+	payment.Status = domain.PaymentStatus(status)
+
+	payment.Operations = make([]domain.Operation, 0, len(operationRecords))
+	for _, record := range operationRecords {
+		payment.Operations = append(payment.Operations, domain.Operation{
+			Kind:           domain.OperationKind(record.Kind),
+			IdempotencyKey: record.IdempotencyKey,
+			Amount:         record.Amount,
+			At:             record.At,
+		})
+	}
+
+	return payment, nil
+}
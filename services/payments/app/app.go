@@ -0,0 +1,47 @@
+package app
+
+import (
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// application holds all dependencies the Application needs to work
+type application struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	PaymentsRepository domain.PaymentsRepository
+	Gateway            domain.PaymentGateway
+	EventPublisher     domain.EventPublisher
+}
+
+// NewApplication creates a new Application instance
+func NewApplication(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	paymentsRepository domain.PaymentsRepository,
+	gateway domain.PaymentGateway,
+	eventPublisher domain.EventPublisher,
+) application {
+	return application{
+		Logger:             logger,
+		Tracer:             tracer,
+		PaymentsRepository: paymentsRepository,
+		Gateway:            gateway,
+		EventPublisher:     eventPublisher,
+	}
+}
+
+// MustNewApplication creates a new Application instance
+// It panics if any error is found
+func MustNewApplication(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	paymentsRepository domain.PaymentsRepository,
+	gateway domain.PaymentGateway,
+	eventPublisher domain.EventPublisher,
+) application {
+	app := NewApplication(logger, tracer, paymentsRepository, gateway, eventPublisher)
+	return app
+}
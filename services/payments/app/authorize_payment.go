@@ -0,0 +1,114 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"go.uber.org/zap"
+)
+
+// paymentIDSize is the amount of random bytes of a Payment ID
+const paymentIDSize = 16
+
+func (a application) AuthorizePayment(ctx context.Context, request domain.AuthorizationRequest) (domain.Payment, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.AuthorizePayment")
+	defer span.End()
+
+	a.Logger.Info("authorizing a payment", zap.Any("request", request))
+
+	if err := request.Validate(); err != nil {
+		return domain.Payment{}, err
+	}
+
+	payment, err := a.findOrCreate(ctx, request)
+	if err != nil {
+		return domain.Payment{}, err
+	}
+
+	if payment.GatewayReached() {
+		return payment, nil
+	}
+
+	// the Payment is stored before reaching the gateway, so when the call fails it's retried
+	// with the same key rather than authorizing the amount twice
+	authorization, err := a.Gateway.Authorize(ctx, payment.IdempotencyKey, payment.Amount, payment.PaymentMethod)
+	if err != nil {
+		return domain.Payment{}, err
+	}
+
+	return a.authorize(ctx, payment, authorization)
+}
+
+// findOrCreate fetches the Payment created by an earlier request with the same idempotency key, creating it otherwise
+func (a application) findOrCreate(ctx context.Context, request domain.AuthorizationRequest) (domain.Payment, error) {
+	payment, err := a.PaymentsRepository.GetByIdempotencyKey(ctx, request.IdempotencyKey)
+	if errors.Is(err, domain.ErrPaymentNotFound) {
+		var id string
+		id, err = newPaymentID()
+		if err != nil {
+			return domain.Payment{}, err
+		}
+
+		payment, err = a.PaymentsRepository.Create(ctx, domain.NewPayment(id, request, time.Now()))
+		if errors.Is(err, domain.ErrPaymentAlreadyExists) {
+			// a concurrent request with the same key created it first
+			payment, err = a.PaymentsRepository.GetByIdempotencyKey(ctx, request.IdempotencyKey)
+		}
+	}
+
+	if err != nil {
+		return domain.Payment{}, err
+	}
+
+	if !payment.Matches(request) {
+		return domain.Payment{}, domain.ErrIdempotencyKeyReused
+	}
+
+	return payment, nil
+}
+
+// authorize stores what the gateway answered and publishes it, unless a concurrent request with the same key stored it first
+func (a application) authorize(ctx context.Context, payment domain.Payment, authorization domain.Authorization) (domain.Payment, error) {
+	for attempt := 1; attempt <= maxUpdateAttempts; attempt++ {
+		payment.Authorize(authorization, time.Now())
+
+		updated, err := a.PaymentsRepository.Update(ctx, payment)
+		if err == nil {
+			if eventType, ok := authorizationEvent(updated); ok {
+				a.publish(ctx, eventType, updated, updated.Amount)
+			}
+
+			return updated, nil
+		}
+
+		if !errors.Is(err, domain.ErrPaymentVersionConflict) {
+			return domain.Payment{}, err
+		}
+
+		a.Logger.Info("the payment changed while authorizing it, trying again", zap.Int("attempt", attempt))
+
+		payment, err = a.PaymentsRepository.Get(ctx, payment.ID)
+		if err != nil {
+			return domain.Payment{}, err
+		}
+
+		if payment.GatewayReached() {
+			return payment, nil
+		}
+	}
+
+	return domain.Payment{}, domain.ErrPaymentVersionConflict
+}
+
+func newPaymentID() (string, error) {
+	id := make([]byte, paymentIDSize)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(id), nil
+}
@@ -0,0 +1,210 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"github.com/lucasmls/ecommerce/services/payments/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type AuthorizePaymentSuite struct {
+	suite.Suite
+
+	paymentsRepo   *mocks.PaymentsRepository
+	gateway        *mocks.PaymentGateway
+	eventPublisher *mocks.EventPublisher
+	app            domain.Application
+}
+
+func (s *AuthorizePaymentSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.paymentsRepo = &mocks.PaymentsRepository{}
+	s.gateway = &mocks.PaymentGateway{}
+	s.eventPublisher = &mocks.EventPublisher{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.paymentsRepo, s.gateway, s.eventPublisher)
+}
+
+func (s *AuthorizePaymentSuite) Test_AuthorizePayment() {
+	s.Run("Should fail in case the request is invalid", func() {
+		s.SetupTest()
+
+		testCases := []struct {
+			request domain.AuthorizationRequest
+			err     error
+		}{
+			{request: domain.AuthorizationRequest{OrderID: 7, CustomerID: "john", Amount: 1, PaymentMethod: "fake_ok"}, err: domain.ErrInvalidIdempotencyKey},
+			{request: domain.AuthorizationRequest{IdempotencyKey: "k", CustomerID: "john", Amount: 1, PaymentMethod: "fake_ok"}, err: domain.ErrInvalidPayment},
+			{request: domain.AuthorizationRequest{IdempotencyKey: "k", OrderID: 7, CustomerID: "john", Amount: 1}, err: domain.ErrInvalidPayment},
+			{request: domain.AuthorizationRequest{IdempotencyKey: "k", OrderID: 7, CustomerID: "john", PaymentMethod: "fake_ok"}, err: domain.ErrInvalidAmount},
+		}
+
+		for _, testCase := range testCases {
+			_, err := s.app.AuthorizePayment(context.Background(), testCase.request)
+
+			s.Equal(testCase.err, err)
+		}
+	})
+
+	s.Run("Should store the payment before authorizing it on the gateway", func() {
+		s.SetupTest()
+
+		s.paymentsRepo.On("GetByIdempotencyKey", mock.AnythingOfType("*context.valueCtx"), "checkout:abc:authorize").Return(domain.Payment{}, domain.ErrPaymentNotFound).Once()
+		s.paymentsRepo.
+			On("Create", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(payment domain.Payment) bool {
+				return payment.Status == domain.PaymentStatusPending && payment.Amount == 159800 && len(payment.ID) == 32
+			})).
+			Return(func(_ context.Context, payment domain.Payment) domain.Payment {
+				payment.Version = 1
+				return payment
+			}, nil).
+			Once()
+		s.gateway.
+			On("Authorize", mock.AnythingOfType("*context.valueCtx"), "checkout:abc:authorize", int64(159800), "fake_ok").
+			Return(domain.Authorization{Reference: "fake_1", Status: domain.PaymentStatusAuthorized}, nil).
+			Once()
+		s.paymentsRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(payment domain.Payment) bool {
+				return payment.Status == domain.PaymentStatusAuthorized &&
+					payment.GatewayReference == "fake_1" &&
+					len(payment.Operations) == 1 &&
+					payment.Operations[0].Kind == domain.OperationAuthorize
+			})).
+			Return(func(_ context.Context, payment domain.Payment) domain.Payment {
+				payment.Version++
+				return payment
+			}, nil).
+			Once()
+		s.eventPublisher.
+			On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(event domain.PaymentEvent) bool {
+				return event.Type == domain.EventAuthorized && event.Amount == 159800
+			})).
+			Return(nil).
+			Once()
+
+		got, err := s.app.AuthorizePayment(context.Background(), authorizationRequest())
+
+		s.NoError(err)
+		s.Equal(domain.PaymentStatusAuthorized, got.Status)
+		s.Equal(2, got.Version)
+		s.paymentsRepo.AssertExpectations(s.T())
+		s.eventPublisher.AssertExpectations(s.T())
+	})
+
+	s.Run("Should return a declined payment as failed rather than as an error", func() {
+		s.SetupTest()
+
+		s.paymentsRepo.On("GetByIdempotencyKey", mock.AnythingOfType("*context.valueCtx"), mock.Anything).Return(paymentWith(domain.PaymentStatusPending), nil).Once()
+		s.gateway.
+			On("Authorize", mock.AnythingOfType("*context.valueCtx"), mock.Anything, mock.Anything, mock.Anything).
+			Return(domain.Authorization{Reference: "fake_1", Status: domain.PaymentStatusFailed, FailureReason: "card declined"}, nil).
+			Once()
+		s.paymentsRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Payment")).
+			Return(func(_ context.Context, payment domain.Payment) domain.Payment { return payment }, nil).
+			Once()
+		s.eventPublisher.
+			On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(event domain.PaymentEvent) bool {
+				return event.Type == domain.EventFailed
+			})).
+			Return(errors.New("connection closed")).
+			Once()
+
+		got, err := s.app.AuthorizePayment(context.Background(), authorizationRequest())
+
+		s.NoError(err)
+		s.Equal(domain.PaymentStatusFailed, got.Status)
+		s.Equal("card declined", got.FailureReason)
+	})
+
+	s.Run("Should neither publish nor fail while the authorization is pending", func() {
+		s.SetupTest()
+
+		s.paymentsRepo.On("GetByIdempotencyKey", mock.AnythingOfType("*context.valueCtx"), mock.Anything).Return(paymentWith(domain.PaymentStatusPending), nil).Once()
+		s.gateway.
+			On("Authorize", mock.AnythingOfType("*context.valueCtx"), mock.Anything, mock.Anything, mock.Anything).
+			Return(domain.Authorization{Reference: "fake_1", Status: domain.PaymentStatusPending}, nil).
+			Once()
+		s.paymentsRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Payment")).
+			Return(func(_ context.Context, payment domain.Payment) domain.Payment { return payment }, nil).
+			Once()
+
+		got, err := s.app.AuthorizePayment(context.Background(), authorizationRequest())
+
+		s.NoError(err)
+		s.Equal(domain.PaymentStatusPending, got.Status)
+		s.Equal("fake_1", got.GatewayReference)
+		s.eventPublisher.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should return the payment of an earlier request with the same key without reaching the gateway", func() {
+		s.SetupTest()
+
+		s.paymentsRepo.On("GetByIdempotencyKey", mock.AnythingOfType("*context.valueCtx"), mock.Anything).Return(paymentWith(domain.PaymentStatusAuthorized), nil).Once()
+
+		got, err := s.app.AuthorizePayment(context.Background(), authorizationRequest())
+
+		s.NoError(err)
+		s.Equal(paymentWith(domain.PaymentStatusAuthorized), got)
+		s.gateway.AssertNotCalled(s.T(), "Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the key was used by a different request", func() {
+		s.SetupTest()
+
+		s.paymentsRepo.On("GetByIdempotencyKey", mock.AnythingOfType("*context.valueCtx"), mock.Anything).Return(paymentWith(domain.PaymentStatusAuthorized), nil).Once()
+
+		request := authorizationRequest()
+		request.Amount = 1
+
+		_, err := s.app.AuthorizePayment(context.Background(), request)
+
+		s.Equal(domain.ErrIdempotencyKeyReused, err)
+	})
+
+	s.Run("Should keep the payment pending in case the gateway is unavailable", func() {
+		s.SetupTest()
+
+		s.paymentsRepo.On("GetByIdempotencyKey", mock.AnythingOfType("*context.valueCtx"), mock.Anything).Return(paymentWith(domain.PaymentStatusPending), nil).Once()
+		s.gateway.
+			On("Authorize", mock.AnythingOfType("*context.valueCtx"), mock.Anything, mock.Anything, mock.Anything).
+			Return(domain.Authorization{}, domain.ErrGatewayUnavailable).
+			Once()
+
+		_, err := s.app.AuthorizePayment(context.Background(), authorizationRequest())
+
+		s.Equal(domain.ErrGatewayUnavailable, err)
+		s.paymentsRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should return what a concurrent request with the same key stored", func() {
+		s.SetupTest()
+
+		s.paymentsRepo.On("GetByIdempotencyKey", mock.AnythingOfType("*context.valueCtx"), mock.Anything).Return(paymentWith(domain.PaymentStatusPending), nil).Once()
+		s.gateway.
+			On("Authorize", mock.AnythingOfType("*context.valueCtx"), mock.Anything, mock.Anything, mock.Anything).
+			Return(domain.Authorization{Reference: "fake_1", Status: domain.PaymentStatusAuthorized}, nil).
+			Once()
+		s.paymentsRepo.On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Payment")).Return(domain.Payment{}, domain.ErrPaymentVersionConflict).Once()
+		s.paymentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), paymentID).Return(paymentWith(domain.PaymentStatusAuthorized), nil).Once()
+
+		got, err := s.app.AuthorizePayment(context.Background(), authorizationRequest())
+
+		s.NoError(err)
+		s.Equal(paymentWith(domain.PaymentStatusAuthorized), got)
+		s.eventPublisher.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthorizePaymentSuite(t *testing.T) {
+	suite.Run(t, new(AuthorizePaymentSuite))
+}
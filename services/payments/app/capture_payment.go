@@ -0,0 +1,28 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) CapturePayment(ctx context.Context, request domain.OperationRequest) (domain.Payment, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.CapturePayment")
+	defer span.End()
+
+	a.Logger.Info("capturing a payment", zap.Any("request", request))
+
+	// the whole authorized amount is captured
+	request.Amount = 0
+
+	return a.operate(ctx, request, operation{
+		kind:  domain.OperationCapture,
+		event: domain.EventCaptured,
+		apply: func(payment *domain.Payment, request domain.OperationRequest, now time.Time) error {
+			return payment.Capture(request.IdempotencyKey, now)
+		},
+		call: a.Gateway.Capture,
+	})
+}
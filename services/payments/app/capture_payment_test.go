@@ -0,0 +1,154 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"github.com/lucasmls/ecommerce/services/payments/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type CapturePaymentSuite struct {
+	suite.Suite
+
+	paymentsRepo   *mocks.PaymentsRepository
+	gateway        *mocks.PaymentGateway
+	eventPublisher *mocks.EventPublisher
+	app            domain.Application
+}
+
+func (s *CapturePaymentSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.paymentsRepo = &mocks.PaymentsRepository{}
+	s.gateway = &mocks.PaymentGateway{}
+	s.eventPublisher = &mocks.EventPublisher{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.paymentsRepo, s.gateway, s.eventPublisher)
+}
+
+func (s *CapturePaymentSuite) Test_CapturePayment() {
+	request := domain.OperationRequest{PaymentID: paymentID, IdempotencyKey: "capture-1"}
+
+	s.Run("Should fail in case the request is invalid", func() {
+		s.SetupTest()
+
+		testCases := []struct {
+			request domain.OperationRequest
+			err     error
+		}{
+			{request: domain.OperationRequest{PaymentID: paymentID}, err: domain.ErrInvalidIdempotencyKey},
+			{request: domain.OperationRequest{IdempotencyKey: "capture-1"}, err: domain.ErrInvalidPayment},
+		}
+
+		for _, testCase := range testCases {
+			_, err := s.app.CapturePayment(context.Background(), testCase.request)
+
+			s.Equal(testCase.err, err)
+		}
+	})
+
+	s.Run("Should capture the whole authorized amount on the gateway before storing it", func() {
+		s.SetupTest()
+
+		s.paymentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), paymentID).Return(paymentWith(domain.PaymentStatusAuthorized), nil).Once()
+		s.gateway.On("Capture", mock.AnythingOfType("*context.valueCtx"), "capture-1", "fake_1", int64(159800)).Return(nil).Once()
+		s.paymentsRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(payment domain.Payment) bool {
+				return payment.Status == domain.PaymentStatusCaptured && payment.CapturedAmount == 159800
+			})).
+			Return(func(_ context.Context, payment domain.Payment) domain.Payment {
+				payment.Version++
+				return payment
+			}, nil).
+			Once()
+		s.eventPublisher.
+			On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(event domain.PaymentEvent) bool {
+				return event.Type == domain.EventCaptured && event.Amount == 159800
+			})).
+			Return(nil).
+			Once()
+
+		got, err := s.app.CapturePayment(context.Background(), request)
+
+		s.NoError(err)
+		s.Equal(domain.PaymentStatusCaptured, got.Status)
+		s.Equal(2, got.Version)
+		s.gateway.AssertExpectations(s.T())
+		s.eventPublisher.AssertExpectations(s.T())
+	})
+
+	s.Run("Should return the payment as it is in case it was already captured with the same key", func() {
+		s.SetupTest()
+
+		s.paymentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), paymentID).Return(paymentWith(domain.PaymentStatusCaptured), nil).Once()
+
+		got, err := s.app.CapturePayment(context.Background(), request)
+
+		s.NoError(err)
+		s.Equal(paymentWith(domain.PaymentStatusCaptured), got)
+		s.gateway.AssertNotCalled(s.T(), "Capture", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the key was used by another operation", func() {
+		s.SetupTest()
+
+		s.paymentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), paymentID).Return(paymentWith(domain.PaymentStatusAuthorized), nil).Once()
+
+		_, err := s.app.CapturePayment(context.Background(), domain.OperationRequest{PaymentID: paymentID, IdempotencyKey: "checkout:abc:authorize"})
+
+		s.Equal(domain.ErrIdempotencyKeyReused, err)
+	})
+
+	s.Run("Should fail without reaching the gateway in case the payment isn't authorized", func() {
+		s.SetupTest()
+
+		s.paymentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), paymentID).Return(paymentWith(domain.PaymentStatusPending), nil).Once()
+
+		_, err := s.app.CapturePayment(context.Background(), request)
+
+		s.Equal(domain.ErrInvalidPaymentStatus, err)
+		s.gateway.AssertNotCalled(s.T(), "Capture", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should leave the payment authorized in case the gateway declines the capture", func() {
+		s.SetupTest()
+
+		s.paymentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), paymentID).Return(paymentWith(domain.PaymentStatusAuthorized), nil).Once()
+		s.gateway.On("Capture", mock.AnythingOfType("*context.valueCtx"), mock.Anything, mock.Anything, mock.Anything).Return(domain.ErrPaymentDeclined).Once()
+
+		_, err := s.app.CapturePayment(context.Background(), request)
+
+		s.Equal(domain.ErrPaymentDeclined, err)
+		s.paymentsRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should apply the capture again in case the payment changed concurrently", func() {
+		s.SetupTest()
+
+		s.paymentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), paymentID).Return(paymentWith(domain.PaymentStatusAuthorized), nil).Twice()
+		s.gateway.On("Capture", mock.AnythingOfType("*context.valueCtx"), mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		s.paymentsRepo.On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Payment")).Return(domain.Payment{}, domain.ErrPaymentVersionConflict).Once()
+		s.paymentsRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Payment")).
+			Return(func(_ context.Context, payment domain.Payment) domain.Payment { return payment }, nil).
+			Once()
+		s.eventPublisher.On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.PaymentEvent")).Return(nil).Once()
+
+		got, err := s.app.CapturePayment(context.Background(), request)
+
+		s.NoError(err)
+		s.Equal(domain.PaymentStatusCaptured, got.Status)
+		s.Len(got.Operations, 2)
+		s.gateway.AssertNumberOfCalls(s.T(), "Capture", 1)
+	})
+}
+
+func TestCapturePaymentSuite(t *testing.T) {
+	suite.Run(t, new(CapturePaymentSuite))
+}
@@ -0,0 +1,26 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) GetPayment(ctx context.Context, id string) (domain.Payment, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.GetPayment")
+	defer span.End()
+
+	a.Logger.Info("getting a payment", zap.String("id", id))
+
+	return a.PaymentsRepository.Get(ctx, id)
+}
+
+func (a application) GetPaymentByIdempotencyKey(ctx context.Context, idempotencyKey string) (domain.Payment, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.GetPaymentByIdempotencyKey")
+	defer span.End()
+
+	a.Logger.Info("getting a payment by its idempotency key", zap.String("idempotencyKey", idempotencyKey))
+
+	return a.PaymentsRepository.GetByIdempotencyKey(ctx, idempotencyKey)
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"github.com/lucasmls/ecommerce/services/payments/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type GetPaymentSuite struct {
+	suite.Suite
+
+	paymentsRepo *mocks.PaymentsRepository
+	app          domain.Application
+}
+
+func (s *GetPaymentSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.paymentsRepo = &mocks.PaymentsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.paymentsRepo, &mocks.PaymentGateway{}, &mocks.EventPublisher{})
+}
+
+func (s *GetPaymentSuite) Test_GetPayment() {
+	s.Run("Should fail in case the payment doesn't exist", func() {
+		s.paymentsRepo.
+			On("Get", mock.AnythingOfType("*context.valueCtx"), "unknown").
+			Return(domain.Payment{}, domain.ErrPaymentNotFound).
+			Once()
+
+		_, err := s.app.GetPayment(context.Background(), "unknown")
+
+		s.Equal(domain.ErrPaymentNotFound, err)
+	})
+
+	s.Run("Should get the payment", func() {
+		payment := paymentWith(domain.PaymentStatusAuthorized)
+
+		s.paymentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), paymentID).Return(payment, nil).Once()
+
+		got, err := s.app.GetPayment(context.Background(), paymentID)
+
+		s.NoError(err)
+		s.Equal(payment, got)
+	})
+}
+
+func (s *GetPaymentSuite) Test_GetPaymentByIdempotencyKey() {
+	s.Run("Should get the payment authorized with the key", func() {
+		payment := paymentWith(domain.PaymentStatusAuthorized)
+
+		s.paymentsRepo.
+			On("GetByIdempotencyKey", mock.AnythingOfType("*context.valueCtx"), "checkout:abc:authorize").
+			Return(payment, nil).
+			Once()
+
+		got, err := s.app.GetPaymentByIdempotencyKey(context.Background(), "checkout:abc:authorize")
+
+		s.NoError(err)
+		s.Equal(payment, got)
+	})
+}
+
+func TestGetPaymentSuite(t *testing.T) {
+	suite.Run(t, new(GetPaymentSuite))
+}
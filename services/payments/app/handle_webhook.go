@@ -0,0 +1,56 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+	ctx, span := a.Tracer.Start(ctx, "app.HandleWebhook")
+	defer span.End()
+
+	event, err := a.Gateway.ParseWebhook(payload, signature)
+	if err != nil {
+		return err
+	}
+
+	a.Logger.Info("handling a webhook", zap.Any("event", event))
+
+	payment, err := a.PaymentsRepository.GetByReference(ctx, event.Reference)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 1; attempt <= maxUpdateAttempts; attempt++ {
+		pending := payment.Status == domain.PaymentStatusPending
+		if !payment.Settle(event, time.Now()) {
+			return nil
+		}
+
+		updated, err := a.PaymentsRepository.Update(ctx, payment)
+		if err == nil {
+			if eventType, ok := authorizationEvent(updated); ok && pending {
+				a.publish(ctx, eventType, updated, updated.Amount)
+			}
+
+			return nil
+		}
+
+		if !errors.Is(err, domain.ErrPaymentVersionConflict) {
+			return err
+		}
+
+		a.Logger.Info("the payment changed while handling a webhook, trying again", zap.Int("attempt", attempt))
+
+		payment, err = a.PaymentsRepository.Get(ctx, payment.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return domain.ErrPaymentVersionConflict
+}
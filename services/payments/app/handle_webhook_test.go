@@ -0,0 +1,179 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"github.com/lucasmls/ecommerce/services/payments/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type HandleWebhookSuite struct {
+	suite.Suite
+
+	paymentsRepo   *mocks.PaymentsRepository
+	gateway        *mocks.PaymentGateway
+	eventPublisher *mocks.EventPublisher
+	app            domain.Application
+}
+
+func (s *HandleWebhookSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.paymentsRepo = &mocks.PaymentsRepository{}
+	s.gateway = &mocks.PaymentGateway{}
+	s.eventPublisher = &mocks.EventPublisher{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.paymentsRepo, s.gateway, s.eventPublisher)
+}
+
+// pendingPayment is a Payment whose authorization the gateway is still deciding on
+func pendingPayment() domain.Payment {
+	payment := paymentWith(domain.PaymentStatusPending)
+	payment.Authorize(domain.Authorization{Reference: "fake_1", Status: domain.PaymentStatusPending}, payment.UpdatedAt)
+
+	return payment
+}
+
+func (s *HandleWebhookSuite) Test_HandleWebhook() {
+	payload := []byte(`{"id":"evt_fake_1"}`)
+	authorized := domain.WebhookEvent{ID: "evt_fake_1", Type: domain.WebhookEventAuthorized, Reference: "fake_1"}
+
+	s.Run("Should fail in case the webhook can't be verified", func() {
+		s.SetupTest()
+
+		s.gateway.On("ParseWebhook", payload, "bad").Return(domain.WebhookEvent{}, domain.ErrInvalidWebhook).Once()
+
+		err := s.app.HandleWebhook(context.Background(), payload, "bad")
+
+		s.Equal(domain.ErrInvalidWebhook, err)
+		s.paymentsRepo.AssertNotCalled(s.T(), "GetByReference", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case no payment has the reference", func() {
+		s.SetupTest()
+
+		s.gateway.On("ParseWebhook", payload, "sig").Return(authorized, nil).Once()
+		s.paymentsRepo.On("GetByReference", mock.AnythingOfType("*context.valueCtx"), "fake_1").Return(domain.Payment{}, domain.ErrPaymentNotFound).Once()
+
+		err := s.app.HandleWebhook(context.Background(), payload, "sig")
+
+		s.Equal(domain.ErrPaymentNotFound, err)
+	})
+
+	s.Run("Should settle a pending authorization and publish its outcome", func() {
+		s.SetupTest()
+
+		s.gateway.On("ParseWebhook", payload, "sig").Return(authorized, nil).Once()
+		s.paymentsRepo.On("GetByReference", mock.AnythingOfType("*context.valueCtx"), "fake_1").Return(pendingPayment(), nil).Once()
+		s.paymentsRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(payment domain.Payment) bool {
+				return payment.Status == domain.PaymentStatusAuthorized && len(payment.WebhookEvents) == 1
+			})).
+			Return(func(_ context.Context, payment domain.Payment) domain.Payment { return payment }, nil).
+			Once()
+		s.eventPublisher.
+			On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(event domain.PaymentEvent) bool {
+				return event.Type == domain.EventAuthorized && event.Amount == 159800
+			})).
+			Return(nil).
+			Once()
+
+		err := s.app.HandleWebhook(context.Background(), payload, "sig")
+
+		s.NoError(err)
+		s.paymentsRepo.AssertExpectations(s.T())
+		s.eventPublisher.AssertExpectations(s.T())
+	})
+
+	s.Run("Should store the failure reason of a declined authorization", func() {
+		s.SetupTest()
+
+		failed := domain.WebhookEvent{ID: "evt_fake_1", Type: domain.WebhookEventFailed, Reference: "fake_1", Reason: "card declined"}
+
+		s.gateway.On("ParseWebhook", payload, "sig").Return(failed, nil).Once()
+		s.paymentsRepo.On("GetByReference", mock.AnythingOfType("*context.valueCtx"), "fake_1").Return(pendingPayment(), nil).Once()
+		s.paymentsRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(payment domain.Payment) bool {
+				return payment.Status == domain.PaymentStatusFailed && payment.FailureReason == "card declined"
+			})).
+			Return(func(_ context.Context, payment domain.Payment) domain.Payment { return payment }, nil).
+			Once()
+		s.eventPublisher.
+			On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(event domain.PaymentEvent) bool {
+				return event.Type == domain.EventFailed
+			})).
+			Return(nil).
+			Once()
+
+		err := s.app.HandleWebhook(context.Background(), payload, "sig")
+
+		s.NoError(err)
+		s.eventPublisher.AssertExpectations(s.T())
+	})
+
+	s.Run("Should ignore an event delivered again", func() {
+		s.SetupTest()
+
+		payment := pendingPayment()
+		payment.Settle(authorized, payment.UpdatedAt)
+
+		s.gateway.On("ParseWebhook", payload, "sig").Return(authorized, nil).Once()
+		s.paymentsRepo.On("GetByReference", mock.AnythingOfType("*context.valueCtx"), "fake_1").Return(payment, nil).Once()
+
+		err := s.app.HandleWebhook(context.Background(), payload, "sig")
+
+		s.NoError(err)
+		s.paymentsRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+		s.eventPublisher.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should only record an event arriving after the payment moved on", func() {
+		s.SetupTest()
+
+		voided := pendingPayment()
+		s.Require().NoError(voided.Void("void-1", voided.UpdatedAt))
+
+		s.gateway.On("ParseWebhook", payload, "sig").Return(authorized, nil).Once()
+		s.paymentsRepo.On("GetByReference", mock.AnythingOfType("*context.valueCtx"), "fake_1").Return(voided, nil).Once()
+		s.paymentsRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(payment domain.Payment) bool {
+				return payment.Status == domain.PaymentStatusVoided && len(payment.WebhookEvents) == 1
+			})).
+			Return(func(_ context.Context, payment domain.Payment) domain.Payment { return payment }, nil).
+			Once()
+
+		err := s.app.HandleWebhook(context.Background(), payload, "sig")
+
+		s.NoError(err)
+		s.eventPublisher.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should settle the payment again in case it changed concurrently", func() {
+		s.SetupTest()
+
+		s.gateway.On("ParseWebhook", payload, "sig").Return(authorized, nil).Once()
+		s.paymentsRepo.On("GetByReference", mock.AnythingOfType("*context.valueCtx"), "fake_1").Return(pendingPayment(), nil).Once()
+		s.paymentsRepo.On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Payment")).Return(domain.Payment{}, domain.ErrPaymentVersionConflict).Once()
+		s.paymentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), paymentID).Return(pendingPayment(), nil).Once()
+		s.paymentsRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Payment")).
+			Return(func(_ context.Context, payment domain.Payment) domain.Payment { return payment }, nil).
+			Once()
+		s.eventPublisher.On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.PaymentEvent")).Return(nil).Once()
+
+		err := s.app.HandleWebhook(context.Background(), payload, "sig")
+
+		s.NoError(err)
+		s.paymentsRepo.AssertExpectations(s.T())
+	})
+}
+
+func TestHandleWebhookSuite(t *testing.T) {
+	suite.Run(t, new(HandleWebhookSuite))
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+)
+
+const paymentID = "0123456789abcdef0123456789abcdef"
+
+// authorizationRequest is a valid request to authorize the amount of an Order
+func authorizationRequest() domain.AuthorizationRequest {
+	return domain.AuthorizationRequest{
+		IdempotencyKey: "checkout:abc:authorize",
+		OrderID:        7,
+		CustomerID:     "john",
+		Amount:         159800,
+		PaymentMethod:  "fake_ok",
+	}
+}
+
+// paymentWith builds a stored Payment that went through the gateway up to the given status
+func paymentWith(status domain.PaymentStatus) domain.Payment {
+	now := time.Date(2022, 5, 8, 10, 0, 0, 0, time.UTC)
+
+	payment := domain.NewPayment(paymentID, authorizationRequest(), now)
+	payment.Version = 1
+
+	if status == domain.PaymentStatusPending {
+		return payment
+	}
+
+	payment.Authorize(domain.Authorization{Reference: "fake_1", Status: domain.PaymentStatusAuthorized}, now)
+
+	if status == domain.PaymentStatusCaptured {
+		if err := payment.Capture("capture-1", now); err != nil {
+			panic(err)
+		}
+	}
+
+	return payment
+}
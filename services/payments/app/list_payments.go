@@ -0,0 +1,25 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) ListPayments(ctx context.Context, filter domain.PaymentFilter) ([]domain.Payment, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.ListPayments")
+	defer span.End()
+
+	a.Logger.Info("listing payments", zap.Any("filter", filter))
+
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = domain.DefaultPaymentsLimit
+	}
+
+	return a.PaymentsRepository.List(ctx, filter)
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"github.com/lucasmls/ecommerce/services/payments/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ListPaymentsSuite struct {
+	suite.Suite
+
+	paymentsRepo *mocks.PaymentsRepository
+	app          domain.Application
+}
+
+func (s *ListPaymentsSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.paymentsRepo = &mocks.PaymentsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.paymentsRepo, &mocks.PaymentGateway{}, &mocks.EventPublisher{})
+}
+
+func (s *ListPaymentsSuite) Test_ListPayments() {
+	s.Run("Should fail in case the filter is invalid", func() {
+		filters := []domain.PaymentFilter{
+			{Status: "settled"},
+			{OrderID: -1},
+			{Limit: -1},
+			{Limit: domain.MaxPaymentsLimit + 1},
+		}
+
+		for _, filter := range filters {
+			_, err := s.app.ListPayments(context.Background(), filter)
+
+			s.Equal(domain.ErrInvalidPaymentFilter, err)
+		}
+	})
+
+	s.Run("Should list the payments with the default limit", func() {
+		payments := []domain.Payment{paymentWith(domain.PaymentStatusCaptured)}
+
+		s.paymentsRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.PaymentFilter{
+				OrderID: 7,
+				Status:  domain.PaymentStatusCaptured,
+				Limit:   domain.DefaultPaymentsLimit,
+			}).
+			Return(payments, nil).
+			Once()
+
+		got, err := s.app.ListPayments(context.Background(), domain.PaymentFilter{OrderID: 7, Status: domain.PaymentStatusCaptured})
+
+		s.NoError(err)
+		s.Equal(payments, got)
+	})
+
+	s.Run("Should keep the limit asked for", func() {
+		s.paymentsRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.PaymentFilter{CustomerID: "john", Limit: 5}).
+			Return([]domain.Payment{}, nil).
+			Once()
+
+		got, err := s.app.ListPayments(context.Background(), domain.PaymentFilter{CustomerID: "john", Limit: 5})
+
+		s.NoError(err)
+		s.Empty(got)
+	})
+}
+
+func TestListPaymentsSuite(t *testing.T) {
+	suite.Run(t, new(ListPaymentsSuite))
+}
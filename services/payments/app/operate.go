@@ -0,0 +1,101 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"go.uber.org/zap"
+)
+
+// maxUpdateAttempts bounds how many times a change is applied again when the Payment changes concurrently
+const maxUpdateAttempts = 3
+
+// operation is a capture, a void or a refund of a Payment
+type operation struct {
+	kind  domain.OperationKind
+	event domain.EventType
+
+	// apply changes the Payment, failing when its status doesn't allow the operation
+	apply func(payment *domain.Payment, request domain.OperationRequest, now time.Time) error
+	// call moves the money on the gateway
+	call func(ctx context.Context, idempotencyKey string, reference string, amount int64) error
+}
+
+// operate runs an operation on a Payment: it's applied first to check the Payment allows it, then the money
+// is moved on the gateway and finally the Payment is stored. Running it again with the same idempotency key
+// returns the Payment as it is, so the money is never moved twice.
+func (a application) operate(ctx context.Context, request domain.OperationRequest, op operation) (domain.Payment, error) {
+	if err := request.Validate(); err != nil {
+		return domain.Payment{}, err
+	}
+
+	payment, err := a.PaymentsRepository.Get(ctx, request.PaymentID)
+	if err != nil {
+		return domain.Payment{}, err
+	}
+
+	changed, replayed, err := a.apply(payment, request, op)
+	if err != nil {
+		return domain.Payment{}, err
+	}
+
+	if replayed {
+		return payment, nil
+	}
+
+	// the refunds of all that is left only know their amount once applied
+	amount := changed.Operations[len(changed.Operations)-1].Amount
+
+	if err := op.call(ctx, request.IdempotencyKey, payment.GatewayReference, amount); err != nil {
+		return domain.Payment{}, err
+	}
+
+	for attempt := 1; attempt <= maxUpdateAttempts; attempt++ {
+		updated, err := a.PaymentsRepository.Update(ctx, changed)
+		if err == nil {
+			a.publish(ctx, op.event, updated, amount)
+			return updated, nil
+		}
+
+		if !errors.Is(err, domain.ErrPaymentVersionConflict) {
+			return domain.Payment{}, err
+		}
+
+		a.Logger.Info("the payment changed while operating on it, trying again", zap.Int("attempt", attempt))
+
+		payment, err = a.PaymentsRepository.Get(ctx, request.PaymentID)
+		if err != nil {
+			return domain.Payment{}, err
+		}
+
+		changed, replayed, err = a.apply(payment, request, op)
+		if err != nil {
+			return domain.Payment{}, err
+		}
+
+		if replayed {
+			return payment, nil
+		}
+	}
+
+	return domain.Payment{}, domain.ErrPaymentVersionConflict
+}
+
+// apply returns the Payment changed by the operation, or tells the operation was already applied with the same key
+func (a application) apply(payment domain.Payment, request domain.OperationRequest, op operation) (domain.Payment, bool, error) {
+	replayed, err := payment.Replayed(op.kind, request.IdempotencyKey, request.Amount)
+	if err != nil || replayed {
+		return domain.Payment{}, replayed, err
+	}
+
+	changed := payment
+	changed.Operations = append([]domain.Operation{}, payment.Operations...)
+
+	if err := op.apply(&changed, request, time.Now()); err != nil {
+		return domain.Payment{}, false, err
+	}
+
+	return changed, false, nil
+}
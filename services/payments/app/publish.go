@@ -0,0 +1,40 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"go.uber.org/zap"
+)
+
+// publish tells the other services about a PaymentEvent once the Payment is stored.
+// The event is only logged when it can't be published: the money already moved, so the
+// caller isn't told the Operation failed.
+func (a application) publish(ctx context.Context, eventType domain.EventType, payment domain.Payment, amount int64) {
+	event := domain.PaymentEvent{
+		Type:    eventType,
+		Payment: payment,
+		Amount:  amount,
+	}
+
+	if err := a.EventPublisher.Publish(ctx, event); err != nil {
+		a.Logger.Error(
+			"failed to publish a payment event",
+			zap.String("paymentId", payment.ID),
+			zap.String("type", string(eventType)),
+			zap.Error(err),
+		)
+	}
+}
+
+// authorizationEvent is the PaymentEvent telling how the authorization of a Payment ended, if it did
+func authorizationEvent(payment domain.Payment) (domain.EventType, bool) {
+	switch payment.Status {
+	case domain.PaymentStatusAuthorized:
+		return domain.EventAuthorized, true
+	case domain.PaymentStatusFailed:
+		return domain.EventFailed, true
+	default:
+		return "", false
+	}
+}
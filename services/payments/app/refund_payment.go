@@ -0,0 +1,25 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) RefundPayment(ctx context.Context, request domain.OperationRequest) (domain.Payment, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.RefundPayment")
+	defer span.End()
+
+	a.Logger.Info("refunding a payment", zap.Any("request", request))
+
+	return a.operate(ctx, request, operation{
+		kind:  domain.OperationRefund,
+		event: domain.EventRefunded,
+		apply: func(payment *domain.Payment, request domain.OperationRequest, now time.Time) error {
+			return payment.Refund(request.IdempotencyKey, request.Amount, now)
+		},
+		call: a.Gateway.Refund,
+	})
+}
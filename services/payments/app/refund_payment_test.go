@@ -0,0 +1,119 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"github.com/lucasmls/ecommerce/services/payments/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type RefundPaymentSuite struct {
+	suite.Suite
+
+	paymentsRepo   *mocks.PaymentsRepository
+	gateway        *mocks.PaymentGateway
+	eventPublisher *mocks.EventPublisher
+	app            domain.Application
+}
+
+func (s *RefundPaymentSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.paymentsRepo = &mocks.PaymentsRepository{}
+	s.gateway = &mocks.PaymentGateway{}
+	s.eventPublisher = &mocks.EventPublisher{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.paymentsRepo, s.gateway, s.eventPublisher)
+}
+
+func (s *RefundPaymentSuite) Test_RefundPayment() {
+	s.Run("Should fail in case the amount is negative", func() {
+		s.SetupTest()
+
+		_, err := s.app.RefundPayment(context.Background(), domain.OperationRequest{PaymentID: paymentID, IdempotencyKey: "refund-1", Amount: -1})
+
+		s.Equal(domain.ErrInvalidAmount, err)
+	})
+
+	s.Run("Should refund part of the captured amount, keeping the payment captured", func() {
+		s.SetupTest()
+
+		s.paymentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), paymentID).Return(paymentWith(domain.PaymentStatusCaptured), nil).Once()
+		s.gateway.On("Refund", mock.AnythingOfType("*context.valueCtx"), "refund-1", "fake_1", int64(50000)).Return(nil).Once()
+		s.paymentsRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(payment domain.Payment) bool {
+				return payment.Status == domain.PaymentStatusCaptured && payment.RefundedAmount == 50000
+			})).
+			Return(func(_ context.Context, payment domain.Payment) domain.Payment { return payment }, nil).
+			Once()
+		s.eventPublisher.
+			On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(event domain.PaymentEvent) bool {
+				return event.Type == domain.EventRefunded && event.Amount == 50000
+			})).
+			Return(nil).
+			Once()
+
+		got, err := s.app.RefundPayment(context.Background(), domain.OperationRequest{PaymentID: paymentID, IdempotencyKey: "refund-1", Amount: 50000})
+
+		s.NoError(err)
+		s.Equal(int64(109800), got.Refundable())
+		s.gateway.AssertExpectations(s.T())
+	})
+
+	s.Run("Should refund all that is left in case the amount isn't set", func() {
+		s.SetupTest()
+
+		payment := paymentWith(domain.PaymentStatusCaptured)
+		s.Require().NoError(payment.Refund("refund-1", 50000, payment.UpdatedAt))
+
+		s.paymentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), paymentID).Return(payment, nil).Once()
+		s.gateway.On("Refund", mock.AnythingOfType("*context.valueCtx"), "refund-2", "fake_1", int64(109800)).Return(nil).Once()
+		s.paymentsRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(payment domain.Payment) bool {
+				return payment.Status == domain.PaymentStatusRefunded && payment.RefundedAmount == 159800
+			})).
+			Return(func(_ context.Context, payment domain.Payment) domain.Payment { return payment }, nil).
+			Once()
+		s.eventPublisher.On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.PaymentEvent")).Return(nil).Once()
+
+		got, err := s.app.RefundPayment(context.Background(), domain.OperationRequest{PaymentID: paymentID, IdempotencyKey: "refund-2"})
+
+		s.NoError(err)
+		s.Equal(domain.PaymentStatusRefunded, got.Status)
+		s.gateway.AssertExpectations(s.T())
+	})
+
+	s.Run("Should fail in case more than what was captured is asked for", func() {
+		s.SetupTest()
+
+		s.paymentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), paymentID).Return(paymentWith(domain.PaymentStatusCaptured), nil).Once()
+
+		_, err := s.app.RefundPayment(context.Background(), domain.OperationRequest{PaymentID: paymentID, IdempotencyKey: "refund-1", Amount: 159801})
+
+		s.Equal(domain.ErrRefundExceedsCaptured, err)
+		s.gateway.AssertNotCalled(s.T(), "Refund", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the key was used by a refund of another amount", func() {
+		s.SetupTest()
+
+		payment := paymentWith(domain.PaymentStatusCaptured)
+		s.Require().NoError(payment.Refund("refund-1", 50000, payment.UpdatedAt))
+
+		s.paymentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), paymentID).Return(payment, nil).Once()
+
+		_, err := s.app.RefundPayment(context.Background(), domain.OperationRequest{PaymentID: paymentID, IdempotencyKey: "refund-1", Amount: 10000})
+
+		s.Equal(domain.ErrIdempotencyKeyReused, err)
+	})
+}
+
+func TestRefundPaymentSuite(t *testing.T) {
+	suite.Run(t, new(RefundPaymentSuite))
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) VoidPayment(ctx context.Context, request domain.OperationRequest) (domain.Payment, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.VoidPayment")
+	defer span.End()
+
+	a.Logger.Info("voiding a payment", zap.Any("request", request))
+
+	// nothing was taken, so there's no amount to give back
+	request.Amount = 0
+
+	return a.operate(ctx, request, operation{
+		kind:  domain.OperationVoid,
+		event: domain.EventVoided,
+		apply: func(payment *domain.Payment, request domain.OperationRequest, now time.Time) error {
+			return payment.Void(request.IdempotencyKey, now)
+		},
+		call: func(ctx context.Context, idempotencyKey string, reference string, _ int64) error {
+			return a.Gateway.Void(ctx, idempotencyKey, reference)
+		},
+	})
+}
@@ -0,0 +1,89 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"github.com/lucasmls/ecommerce/services/payments/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type VoidPaymentSuite struct {
+	suite.Suite
+
+	paymentsRepo   *mocks.PaymentsRepository
+	gateway        *mocks.PaymentGateway
+	eventPublisher *mocks.EventPublisher
+	app            domain.Application
+}
+
+func (s *VoidPaymentSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.paymentsRepo = &mocks.PaymentsRepository{}
+	s.gateway = &mocks.PaymentGateway{}
+	s.eventPublisher = &mocks.EventPublisher{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.paymentsRepo, s.gateway, s.eventPublisher)
+}
+
+func (s *VoidPaymentSuite) Test_VoidPayment() {
+	request := domain.OperationRequest{PaymentID: paymentID, IdempotencyKey: "void-1"}
+
+	s.Run("Should void the authorization on the gateway before storing it", func() {
+		s.SetupTest()
+
+		s.paymentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), paymentID).Return(paymentWith(domain.PaymentStatusAuthorized), nil).Once()
+		s.gateway.On("Void", mock.AnythingOfType("*context.valueCtx"), "void-1", "fake_1").Return(nil).Once()
+		s.paymentsRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(payment domain.Payment) bool {
+				return payment.Status == domain.PaymentStatusVoided
+			})).
+			Return(func(_ context.Context, payment domain.Payment) domain.Payment { return payment }, nil).
+			Once()
+		s.eventPublisher.
+			On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(event domain.PaymentEvent) bool {
+				return event.Type == domain.EventVoided
+			})).
+			Return(nil).
+			Once()
+
+		got, err := s.app.VoidPayment(context.Background(), request)
+
+		s.NoError(err)
+		s.Equal(domain.PaymentStatusVoided, got.Status)
+		s.gateway.AssertExpectations(s.T())
+	})
+
+	s.Run("Should fail in case the payment was already captured", func() {
+		s.SetupTest()
+
+		s.paymentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), paymentID).Return(paymentWith(domain.PaymentStatusCaptured), nil).Once()
+
+		_, err := s.app.VoidPayment(context.Background(), request)
+
+		s.Equal(domain.ErrInvalidPaymentStatus, err)
+		s.gateway.AssertNotCalled(s.T(), "Void", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the gateway can't be reached", func() {
+		s.SetupTest()
+
+		s.paymentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), paymentID).Return(paymentWith(domain.PaymentStatusAuthorized), nil).Once()
+		s.gateway.On("Void", mock.AnythingOfType("*context.valueCtx"), mock.Anything, mock.Anything).Return(domain.ErrGatewayUnavailable).Once()
+
+		_, err := s.app.VoidPayment(context.Background(), request)
+
+		s.Equal(domain.ErrGatewayUnavailable, err)
+		s.paymentsRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestVoidPaymentSuite(t *testing.T) {
+	suite.Run(t, new(VoidPaymentSuite))
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	checkoutPb "github.com/lucasmls/ecommerce/services/checkout/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/services/payments/adapters/events"
+	"github.com/lucasmls/ecommerce/services/payments/adapters/gateways"
+	"github.com/lucasmls/ecommerce/services/payments/adapters/repositories"
+	"github.com/lucasmls/ecommerce/services/payments/app"
+	resolvers "github.com/lucasmls/ecommerce/services/payments/ports/grpc"
+	protog "github.com/lucasmls/ecommerce/services/payments/ports/grpc/proto"
+	handlers "github.com/lucasmls/ecommerce/services/payments/ports/http"
+	consumers "github.com/lucasmls/ecommerce/services/payments/ports/rmq"
+	rmqPb "github.com/lucasmls/ecommerce/services/payments/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/shared/env"
+	"github.com/lucasmls/ecommerce/shared/grpc"
+	"github.com/lucasmls/ecommerce/shared/rmq"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otel "go.opentelemetry.io/otel"
+	otelJaegerExporter "go.opentelemetry.io/otel/exporters/jaeger"
+	otelPropagation "go.opentelemetry.io/otel/propagation"
+	otelSdkResource "go.opentelemetry.io/otel/sdk/resource"
+	otelTraceSdk "go.opentelemetry.io/otel/sdk/trace"
+	otelSemconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.uber.org/zap"
+	gGRPC "google.golang.org/grpc"
+)
+
+type ApplicationConfig struct {
+	ServiceName              string `mapstructure:"SERVICE_NAME"`
+	JaegerEndpoint           string `mapstructure:"JAEGER_ENDPOINT"`
+	GrpcServerPort           int    `mapstructure:"GRPC_SERVER_PORT"`
+	MetricsPort              int    `mapstructure:"METRICS_PORT"`
+	PostgresConnectionString string `mapstructure:"PG_CONNECTION_STRING"`
+	// RabbitMQURL is where the checkout commands come from and the payment events are published to
+	RabbitMQURL string `mapstructure:"RABBITMQ_URL"`
+	// FakeGatewaySecret signs the webhooks of the fake gateway
+	FakeGatewaySecret string `mapstructure:"FAKE_GATEWAY_SECRET"`
+	// FakeGatewayWebhookURL is where the fake gateway posts its webhooks, i.e. the /webhooks/fake path of this service
+	FakeGatewayWebhookURL string `mapstructure:"FAKE_GATEWAY_WEBHOOK_URL"`
+	// FakeGatewayWebhookDelay is how long the fake gateway takes to settle the pending authorizations, e.g. 2s
+	FakeGatewayWebhookDelay time.Duration `mapstructure:"FAKE_GATEWAY_WEBHOOK_DELAY"`
+	// FakeGatewayScenario is how the fake gateway answers to the payment methods it doesn't know, e.g. approve
+	FakeGatewayScenario string `mapstructure:"FAKE_GATEWAY_SCENARIO"`
+}
+
+func main() {
+	ctx := context.Background()
+
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	config, err := env.LoadConfig[ApplicationConfig]()
+	if err != nil {
+		logger.Fatal("failed to load application config", zap.Error(err))
+	}
+
+	jaegerExporter, err := otelJaegerExporter.New(
+		otelJaegerExporter.WithCollectorEndpoint(
+			otelJaegerExporter.WithEndpoint(config.JaegerEndpoint),
+		),
+	)
+	if err != nil {
+		logger.Fatal("failed to instantiate Jaeger exporter", zap.Error(err))
+	}
+
+	tracingProvider := otelTraceSdk.NewTracerProvider(
+		otelTraceSdk.WithBatcher(jaegerExporter),
+		otelTraceSdk.WithSampler(otelTraceSdk.AlwaysSample()),
+		otelTraceSdk.WithResource(otelSdkResource.NewWithAttributes(
+			otelSemconv.SchemaURL,
+			otelSemconv.ServiceNameKey.String(config.ServiceName),
+		)),
+	)
+
+	defer func() {
+		_ = tracingProvider.Shutdown(ctx)
+	}()
+
+	otel.SetTracerProvider(tracingProvider)
+	otel.SetTextMapPropagator(otelPropagation.NewCompositeTextMapPropagator(
+		otelPropagation.TraceContext{},
+		otelPropagation.Baggage{},
+	))
+
+	tracer := otel.Tracer(config.ServiceName)
+
+	rmqClient := rmq.MustNewClient(rmq.ClientInput{
+		URL:    config.RabbitMQURL,
+		Logger: logger,
+	}).MustConnect()
+	defer rmqClient.Close()
+
+	if err := rmqClient.DeclareExchange(rmqPb.Exchange); err != nil {
+		logger.Fatal("failed to declare the payments exchange", zap.Error(err))
+	}
+
+	eventPublisher := events.NewRmqEventPublisher(logger, tracer, rmqClient)
+
+	inMemoryPaymentsRepository := repositories.NewInMemoryPaymentsRepository(logger, tracer)
+	// postgresPaymentsRepository := repositories.MustNewPgPaymentsRepository(config.PostgresConnectionString)
+
+	fakeGateway := gateways.MustNewFakeGateway(gateways.FakeGatewayInput{
+		Logger:          logger,
+		Secret:          config.FakeGatewaySecret,
+		WebhookURL:      config.FakeGatewayWebhookURL,
+		WebhookDelay:    config.FakeGatewayWebhookDelay,
+		DefaultScenario: gateways.Scenario(config.FakeGatewayScenario),
+	})
+
+	application := app.MustNewApplication(logger, tracer, inMemoryPaymentsRepository, fakeGateway, eventPublisher)
+	paymentsResolver := resolvers.MustNewPaymentsResolver(logger, tracer, application)
+	webhooksHandler := handlers.MustNewWebhooksHandler(logger, tracer, application, gateways.FakeSignatureHeader)
+	checkoutConsumer := consumers.MustNewCheckoutConsumer(logger, tracer, application, rmqClient)
+
+	go func() {
+		err := rmqClient.Consume(ctx, rmq.ConsumerInput{
+			Exchange:    checkoutPb.Exchange,
+			Queue:       checkoutPb.CommandsQueue(checkoutPb.ParticipantPayments),
+			RoutingKeys: []string{checkoutPb.CommandsRoutingKey(checkoutPb.ParticipantPayments)},
+			Handler:     checkoutConsumer.Handle,
+		})
+		if err != nil {
+			logger.Fatal("failed to consume the checkout commands", zap.Error(err))
+		}
+	}()
+
+	server := grpc.MustNewServer(grpc.ServerInput{
+		Port:   config.GrpcServerPort,
+		Logger: logger,
+		Registrator: func(server gGRPC.ServiceRegistrar) {
+			protog.RegisterPaymentsServiceServer(server, paymentsResolver)
+		},
+	})
+
+	go func() {
+		port := fmt.Sprintf(":%d", config.MetricsPort)
+
+		http.Handle("/metrics", promhttp.Handler())
+
+		// the gateway webhooks are received along with the metrics
+		http.Handle("/webhooks/fake", webhooksHandler)
+
+		_ = http.ListenAndServe(port, nil)
+	}()
+
+	if err := server.Run(ctx); err != nil {
+		logger.Fatal("failed to run gRPC server", zap.Error(err))
+	}
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"context"
+)
+
+// Application defines boundary interfaces of the application
+// It should be called by the ports
+type Application interface {
+	// AuthorizePayment creates a Payment and authorizes its amount on the gateway. A declined authorization
+	// isn't an error, the Payment is returned failed. Authorizing again with the same idempotency key returns
+	// the same Payment, calling the gateway again only when it wasn't reached before, and fails with
+	// ErrIdempotencyKeyReused when the key was used by a different request.
+	AuthorizePayment(context.Context, AuthorizationRequest) (Payment, error)
+
+	// CapturePayment takes the whole authorized amount of a Payment
+	CapturePayment(context.Context, OperationRequest) (Payment, error)
+
+	// VoidPayment releases the authorized amount of a Payment without taking it
+	VoidPayment(context.Context, OperationRequest) (Payment, error)
+
+	// RefundPayment gives back part of the captured amount of a Payment, or all that is left
+	RefundPayment(context.Context, OperationRequest) (Payment, error)
+
+	// GetPayment fetches a single Payment
+	GetPayment(context.Context, string) (Payment, error)
+
+	// GetPaymentByIdempotencyKey fetches the Payment authorized with the given idempotency key
+	GetPaymentByIdempotencyKey(context.Context, string) (Payment, error)
+
+	// ListPayments lists the Payments matching the filter, most recently created first
+	ListPayments(context.Context, PaymentFilter) ([]Payment, error)
+
+	// HandleWebhook applies a webhook of the gateway to the Payment it's about, given its raw payload and signature.
+	// It fails with ErrInvalidWebhook when the gateway can't verify or parse it. The deliveries of an event
+	// already applied are ignored.
+	HandleWebhook(ctx context.Context, payload []byte, signature string) error
+}
+
+type PaymentsRepository interface {
+	// Create stores a new Payment in a data storage, setting its first Version.
+	// It fails with ErrPaymentAlreadyExists when another Payment has the same idempotency key.
+	Create(context.Context, Payment) (Payment, error)
+
+	// Get fetches a single Payment from a data storage.
+	Get(context.Context, string) (Payment, error)
+
+	// GetByIdempotencyKey fetches the Payment authorized with the given idempotency key from a data storage.
+	GetByIdempotencyKey(context.Context, string) (Payment, error)
+
+	// GetByReference fetches the Payment with the given gateway reference from a data storage.
+	GetByReference(context.Context, string) (Payment, error)
+
+	// List lists the Payments matching the filter from a data storage, most recently created first, ties broken by ID.
+	List(context.Context, PaymentFilter) ([]Payment, error)
+
+	// Update replaces a Payment in a data storage, returning it with its new Version.
+	// It's only replaced while the stored Version is still the one it was read with, failing with
+	// ErrPaymentVersionConflict otherwise.
+	Update(context.Context, Payment) (Payment, error)
+}
+
+// PaymentGateway moves the money through a payment provider.
+// Every call carries an idempotency key: calling again with the same key doesn't move the money twice,
+// it answers what the first call did. The calls fail with ErrGatewayUnavailable when the provider
+// can't be reached, and the ones after the authorization with ErrPaymentDeclined when it refuses them.
+type PaymentGateway interface {
+	// Authorize holds an amount on a payment method, a declined authorization is answered as failed rather than as an error
+	Authorize(ctx context.Context, idempotencyKey string, amount int64, paymentMethod string) (Authorization, error)
+
+	// Capture takes an authorized amount
+	Capture(ctx context.Context, idempotencyKey string, reference string, amount int64) error
+
+	// Void releases an authorized amount
+	Void(ctx context.Context, idempotencyKey string, reference string) error
+
+	// Refund gives back part of a captured amount
+	Refund(ctx context.Context, idempotencyKey string, reference string, amount int64) error
+
+	// ParseWebhook verifies the signature of a webhook and parses it, failing with ErrInvalidWebhook otherwise
+	ParseWebhook(payload []byte, signature string) (WebhookEvent, error)
+}
+
+type EventPublisher interface {
+	// Publish tells the other services about a PaymentEvent
+	Publish(context.Context, PaymentEvent) error
+}
@@ -0,0 +1,46 @@
+package domain
+
+// Authorization is what the gateway answered to the authorization of a Payment
+type Authorization struct {
+	// Reference identifies the Payment on the gateway
+	Reference string
+	// Status is either authorized, pending when the gateway tells it later through a webhook, or failed when declined
+	Status        PaymentStatus
+	FailureReason string
+}
+
+// WebhookEventType is what a WebhookEvent tells about a pending authorization
+type WebhookEventType string
+
+const (
+	WebhookEventAuthorized WebhookEventType = "payment.authorized"
+	WebhookEventFailed     WebhookEventType = "payment.failed"
+)
+
+// WebhookEvent is an asynchronous callback of the gateway, telling how a pending authorization ended.
+// The gateway may deliver it more than once, its ID tells the deliveries apart from new events.
+type WebhookEvent struct {
+	ID        string
+	Type      WebhookEventType
+	Reference string
+	Reason    string
+}
+
+// EventType is what happened to a Payment, as published on a PaymentEvent
+type EventType string
+
+const (
+	EventAuthorized EventType = "authorized"
+	EventFailed     EventType = "failed"
+	EventCaptured   EventType = "captured"
+	EventVoided     EventType = "voided"
+	EventRefunded   EventType = "refunded"
+)
+
+// PaymentEvent tells the other services a Payment changed, carrying the Payment as it was right after
+type PaymentEvent struct {
+	Type    EventType
+	Payment Payment
+	// Amount is what the change was about, e.g. the amount of a partial refund
+	Amount int64
+}
@@ -0,0 +1,352 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// MaxCustomerIDLength is the maximum amount of characters of the ID of the customer paying
+	MaxCustomerIDLength = 255
+	// MaxPaymentMethodLength is the maximum amount of characters of a payment method, e.g. a tokenized card
+	MaxPaymentMethodLength = 255
+	// MaxIdempotencyKeyLength is the maximum amount of characters of an idempotency key
+	MaxIdempotencyKeyLength = 255
+	// DefaultPaymentsLimit is how many Payments are listed when the filter doesn't tell
+	DefaultPaymentsLimit = 20
+	// MaxPaymentsLimit is the maximum amount of Payments listed at once
+	MaxPaymentsLimit = 100
+)
+
+var (
+	ErrPaymentNotFound        = errors.New("payment-not-found")
+	ErrPaymentAlreadyExists   = errors.New("payment-already-exists")
+	ErrPaymentVersionConflict = errors.New("payment-version-conflict")
+	ErrInvalidPayment         = errors.New("invalid-payment")
+	ErrInvalidAmount          = errors.New("invalid-amount")
+	ErrInvalidIdempotencyKey  = errors.New("invalid-idempotency-key")
+	ErrIdempotencyKeyReused   = errors.New("idempotency-key-reused")
+	ErrInvalidPaymentStatus   = errors.New("invalid-payment-status")
+	ErrInvalidPaymentFilter   = errors.New("invalid-payment-filter")
+	ErrRefundExceedsCaptured  = errors.New("refund-exceeds-captured")
+	ErrPaymentDeclined        = errors.New("payment-declined")
+	ErrGatewayUnavailable     = errors.New("gateway-unavailable")
+	ErrInvalidWebhook         = errors.New("invalid-webhook")
+)
+
+// PaymentStatus is where a Payment is on its lifecycle:
+// pending -> authorized -> captured -> refunded, or it ends up voided or failed along the way.
+type PaymentStatus string
+
+const (
+	// PaymentStatusPending waits for the gateway to tell whether the authorization went through, e.g. through a webhook
+	PaymentStatusPending    PaymentStatus = "pending"
+	PaymentStatusAuthorized PaymentStatus = "authorized"
+	// PaymentStatusCaptured has its money taken, part of it may have been refunded already
+	PaymentStatusCaptured PaymentStatus = "captured"
+	PaymentStatusVoided   PaymentStatus = "voided"
+	// PaymentStatusRefunded had all of its captured money given back
+	PaymentStatusRefunded PaymentStatus = "refunded"
+	PaymentStatusFailed   PaymentStatus = "failed"
+)
+
+// Validate checks the PaymentStatus is a known one
+func (s PaymentStatus) Validate() error {
+	switch s {
+	case PaymentStatusPending,
+		PaymentStatusAuthorized,
+		PaymentStatusCaptured,
+		PaymentStatusVoided,
+		PaymentStatusRefunded,
+		PaymentStatusFailed:
+		return nil
+	}
+
+	return ErrInvalidPaymentFilter
+}
+
+// OperationKind is what an Operation asked the gateway to do
+type OperationKind string
+
+const (
+	OperationAuthorize OperationKind = "authorize"
+	OperationCapture   OperationKind = "capture"
+	OperationVoid      OperationKind = "void"
+	OperationRefund    OperationKind = "refund"
+)
+
+// Operation records a call to the gateway that changed a Payment.
+// Its IdempotencyKey tells apart a new call from the retry of one that already went through.
+type Operation struct {
+	Kind           OperationKind
+	IdempotencyKey string
+	// Amount is in minor currency units (cents)
+	Amount int64
+	At     time.Time
+}
+
+// Payment is money of a customer taken for an Order through the PaymentGateway.
+type Payment struct {
+	ID string
+	// IdempotencyKey is the one the Payment was authorized with, authorizing it again with the same key is a no-op
+	IdempotencyKey string
+	OrderID        int
+	CustomerID     string
+	// Amount is what was authorized, CapturedAmount and RefundedAmount what was then taken and given back, in cents
+	Amount         int64
+	CapturedAmount int64
+	RefundedAmount int64
+	// PaymentMethod is what the gateway charges, e.g. a tokenized card
+	PaymentMethod string
+	Status        PaymentStatus
+	// GatewayReference identifies the Payment on the gateway, it's empty until the gateway is reached
+	GatewayReference string
+	FailureReason    string
+	// Operations lists every Operation on the Payment, oldest first
+	Operations []Operation
+	// WebhookEvents are the IDs of the WebhookEvents already applied to the Payment
+	WebhookEvents []string
+	// Version is incremented on every change, starting at 1 when the Payment is created.
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewPayment creates a pending Payment that is yet to be authorized on the gateway
+func NewPayment(id string, request AuthorizationRequest, now time.Time) Payment {
+	return Payment{
+		ID:             id,
+		IdempotencyKey: request.IdempotencyKey,
+		OrderID:        request.OrderID,
+		CustomerID:     request.CustomerID,
+		Amount:         request.Amount,
+		PaymentMethod:  request.PaymentMethod,
+		Status:         PaymentStatusPending,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// Matches tells whether the Payment was authorized by the given request, rather than by another one reusing its key
+func (p Payment) Matches(request AuthorizationRequest) bool {
+	return p.IdempotencyKey == request.IdempotencyKey &&
+		p.OrderID == request.OrderID &&
+		p.CustomerID == request.CustomerID &&
+		p.Amount == request.Amount &&
+		p.PaymentMethod == request.PaymentMethod
+}
+
+// GatewayReached tells whether the gateway already answered the authorization of the Payment
+func (p Payment) GatewayReached() bool {
+	return p.GatewayReference != "" || p.Status != PaymentStatusPending
+}
+
+// Replayed tells whether an Operation with the same idempotency key was already applied to the Payment.
+// It fails with ErrIdempotencyKeyReused when the key was used by a different Operation.
+func (p Payment) Replayed(kind OperationKind, key string, amount int64) (bool, error) {
+	for _, operation := range p.Operations {
+		if operation.IdempotencyKey != key {
+			continue
+		}
+
+		// a zero amount refunds whatever is left, so it matches any amount that was refunded with the key
+		if operation.Kind != kind || (amount != 0 && operation.Amount != amount) {
+			return false, ErrIdempotencyKeyReused
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// Authorize applies what the gateway answered to the authorization of the Payment
+func (p *Payment) Authorize(authorization Authorization, now time.Time) {
+	p.GatewayReference = authorization.Reference
+	p.Status = authorization.Status
+	p.FailureReason = authorization.FailureReason
+	p.record(OperationAuthorize, p.IdempotencyKey, p.Amount, now)
+}
+
+// Capture takes the whole authorized amount.
+// It fails with ErrInvalidPaymentStatus unless the Payment is authorized.
+func (p *Payment) Capture(key string, now time.Time) error {
+	if p.Status != PaymentStatusAuthorized {
+		return ErrInvalidPaymentStatus
+	}
+
+	p.Status = PaymentStatusCaptured
+	p.CapturedAmount = p.Amount
+	p.record(OperationCapture, key, p.Amount, now)
+
+	return nil
+}
+
+// Void releases the authorized amount without taking it, a pending Payment the gateway already knows
+// about can be voided as well. It fails with ErrInvalidPaymentStatus otherwise.
+func (p *Payment) Void(key string, now time.Time) error {
+	if p.Status != PaymentStatusAuthorized && !(p.Status == PaymentStatusPending && p.GatewayReference != "") {
+		return ErrInvalidPaymentStatus
+	}
+
+	p.Status = PaymentStatusVoided
+	p.record(OperationVoid, key, 0, now)
+
+	return nil
+}
+
+// Refundable is how much of the captured amount can still be given back
+func (p Payment) Refundable() int64 {
+	return p.CapturedAmount - p.RefundedAmount
+}
+
+// Refund gives back part of the captured amount, or all that is left when the amount is zero.
+// It fails with ErrInvalidPaymentStatus unless the Payment is captured, and with
+// ErrRefundExceedsCaptured when more than what is left is asked for.
+func (p *Payment) Refund(key string, amount int64, now time.Time) error {
+	if p.Status != PaymentStatusCaptured {
+		return ErrInvalidPaymentStatus
+	}
+
+	if amount == 0 {
+		amount = p.Refundable()
+	}
+
+	if amount <= 0 || amount > p.Refundable() {
+		return ErrRefundExceedsCaptured
+	}
+
+	p.RefundedAmount += amount
+	if p.Refundable() == 0 {
+		p.Status = PaymentStatusRefunded
+	}
+
+	p.record(OperationRefund, key, amount, now)
+
+	return nil
+}
+
+// Settle applies a WebhookEvent telling how a pending authorization ended.
+// It returns false, leaving the Payment untouched, when the event was already applied, and records
+// without applying it the events arriving after the Payment moved on, e.g. when it was voided meanwhile.
+func (p *Payment) Settle(event WebhookEvent, now time.Time) bool {
+	for _, id := range p.WebhookEvents {
+		if id == event.ID {
+			return false
+		}
+	}
+
+	p.WebhookEvents = append(p.WebhookEvents, event.ID)
+	p.UpdatedAt = now
+
+	if p.Status != PaymentStatusPending {
+		return true
+	}
+
+	switch event.Type {
+	case WebhookEventAuthorized:
+		p.Status = PaymentStatusAuthorized
+	case WebhookEventFailed:
+		p.Status = PaymentStatusFailed
+		p.FailureReason = event.Reason
+	}
+
+	return true
+}
+
+func (p *Payment) record(kind OperationKind, key string, amount int64, now time.Time) {
+	p.Operations = append(p.Operations, Operation{
+		Kind:           kind,
+		IdempotencyKey: key,
+		Amount:         amount,
+		At:             now,
+	})
+
+	p.UpdatedAt = now
+}
+
+// AuthorizationRequest asks for an amount to be authorized on a payment method.
+// Asking again with the same IdempotencyKey returns the Payment the first request created.
+type AuthorizationRequest struct {
+	IdempotencyKey string
+	OrderID        int
+	CustomerID     string
+	// Amount is in minor currency units (cents)
+	Amount        int64
+	PaymentMethod string
+}
+
+// Validate checks every field is set and within its bounds
+func (r AuthorizationRequest) Validate() error {
+	if err := validateIdempotencyKey(r.IdempotencyKey); err != nil {
+		return err
+	}
+
+	if r.OrderID <= 0 ||
+		r.CustomerID == "" || len(r.CustomerID) > MaxCustomerIDLength ||
+		r.PaymentMethod == "" || len(r.PaymentMethod) > MaxPaymentMethodLength {
+		return ErrInvalidPayment
+	}
+
+	if r.Amount <= 0 {
+		return ErrInvalidAmount
+	}
+
+	return nil
+}
+
+// OperationRequest asks for a capture, a void or a refund of a Payment.
+// Asking again with the same IdempotencyKey is a no-op.
+type OperationRequest struct {
+	PaymentID      string
+	IdempotencyKey string
+	// Amount is only taken into account by the refunds, which give back all that is left when it's zero
+	Amount int64
+}
+
+// Validate checks the Payment and the key are set and the amount isn't negative
+func (r OperationRequest) Validate() error {
+	if err := validateIdempotencyKey(r.IdempotencyKey); err != nil {
+		return err
+	}
+
+	if r.PaymentID == "" {
+		return ErrInvalidPayment
+	}
+
+	if r.Amount < 0 {
+		return ErrInvalidAmount
+	}
+
+	return nil
+}
+
+func validateIdempotencyKey(key string) error {
+	if key == "" || len(key) > MaxIdempotencyKeyLength {
+		return ErrInvalidIdempotencyKey
+	}
+
+	return nil
+}
+
+// PaymentFilter narrows the Payments down, its unset fields match every Payment.
+type PaymentFilter struct {
+	OrderID    int
+	CustomerID string
+	Status     PaymentStatus
+	// Limit bounds how many Payments are listed, DefaultPaymentsLimit when unset
+	Limit int
+}
+
+// Validate checks the status is a known one and the limit is within bounds
+func (f PaymentFilter) Validate() error {
+	if f.OrderID < 0 || f.Limit < 0 || f.Limit > MaxPaymentsLimit {
+		return ErrInvalidPaymentFilter
+	}
+
+	if f.Status != "" {
+		return f.Status.Validate()
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+-- the operations and the webhook events are kept along with their payment, which is always read and written as a whole
+CREATE TABLE IF NOT EXISTS payments (
+    id                TEXT PRIMARY KEY,
+    idempotency_key   TEXT        NOT NULL UNIQUE,
+    order_id          INTEGER     NOT NULL,
+    customer_id       TEXT        NOT NULL,
+    amount            BIGINT      NOT NULL,
+    captured_amount   BIGINT      NOT NULL DEFAULT 0,
+    refunded_amount   BIGINT      NOT NULL DEFAULT 0,
+    payment_method    TEXT        NOT NULL,
+    status            TEXT        NOT NULL,
+    gateway_reference TEXT        NOT NULL DEFAULT '',
+    failure_reason    TEXT        NOT NULL DEFAULT '',
+    operations        JSONB       NOT NULL DEFAULT '[]',
+    webhook_events    JSONB       NOT NULL DEFAULT '[]',
+    version           INTEGER     NOT NULL,
+    created_at        TIMESTAMPTZ NOT NULL,
+    updated_at        TIMESTAMPTZ NOT NULL,
+    CONSTRAINT payments_status_check CHECK (
+        status IN ('pending', 'authorized', 'captured', 'voided', 'refunded', 'failed')
+    ),
+    CONSTRAINT payments_amounts_check CHECK (
+        amount > 0 AND captured_amount <= amount AND refunded_amount <= captured_amount
+    )
+);
+
+-- the webhooks of the gateway tell which payment they are about through its reference
+CREATE UNIQUE INDEX payments_gateway_reference_idx ON payments (gateway_reference) WHERE gateway_reference <> '';
+
+CREATE INDEX payments_order_id_idx ON payments (order_id);
@@ -0,0 +1,241 @@
+package grpc_port
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	pb "github.com/lucasmls/ecommerce/services/payments/ports/grpc/proto"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	InternalServerError = status.Error(codes.Internal, "Internal server error")
+)
+
+var (
+	ErrMissingLogger = errors.New("missing required dependency: Logger")
+	ErrMisingTracer  = errors.New("missing required dependency: Tracer")
+)
+
+// PaymentsResolver ...
+type PaymentsResolver struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+	App    domain.Application
+
+	pb.UnimplementedPaymentsServiceServer
+}
+
+func NewPaymentsResolver(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) (*PaymentsResolver, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMisingTracer
+	}
+
+	return &PaymentsResolver{
+		Logger: logger,
+		Tracer: tracer,
+		App:    app,
+	}, nil
+}
+
+// MustNewPaymentsResolver creates a new PaymentsResolver instance.
+// It panics if any error is found
+func MustNewPaymentsResolver(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) *PaymentsResolver {
+	paymentsResolver, err := NewPaymentsResolver(logger, tracer, app)
+	if err != nil {
+		panic(err)
+	}
+
+	return paymentsResolver
+}
+
+func (r *PaymentsResolver) AuthorizePayment(ctx context.Context, req *pb.AuthorizePaymentRequest) (*pb.AuthorizePaymentResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.AuthorizePayment")
+	defer span.End()
+
+	request := domain.AuthorizationRequest{
+		IdempotencyKey: req.IdempotencyKey,
+		OrderID:        int(req.OrderId),
+		CustomerID:     req.CustomerId,
+		Amount:         req.Amount,
+		PaymentMethod:  req.PaymentMethod,
+	}
+
+	payment, err := r.App.AuthorizePayment(ctx, request)
+	if err != nil {
+		return nil, r.toError(err, "failed to authorize the payment", zap.String("idempotencyKey", request.IdempotencyKey))
+	}
+
+	return &pb.AuthorizePaymentResponse{Data: toProtoPayment(payment)}, nil
+}
+
+func (r *PaymentsResolver) CapturePayment(ctx context.Context, req *pb.CapturePaymentRequest) (*pb.CapturePaymentResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.CapturePayment")
+	defer span.End()
+
+	request := domain.OperationRequest{
+		PaymentID:      req.PaymentId,
+		IdempotencyKey: req.IdempotencyKey,
+	}
+
+	payment, err := r.App.CapturePayment(ctx, request)
+	if err != nil {
+		return nil, r.toError(err, "failed to capture the payment", zap.Any("request", request))
+	}
+
+	return &pb.CapturePaymentResponse{Data: toProtoPayment(payment)}, nil
+}
+
+func (r *PaymentsResolver) VoidPayment(ctx context.Context, req *pb.VoidPaymentRequest) (*pb.VoidPaymentResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.VoidPayment")
+	defer span.End()
+
+	request := domain.OperationRequest{
+		PaymentID:      req.PaymentId,
+		IdempotencyKey: req.IdempotencyKey,
+	}
+
+	payment, err := r.App.VoidPayment(ctx, request)
+	if err != nil {
+		return nil, r.toError(err, "failed to void the payment", zap.Any("request", request))
+	}
+
+	return &pb.VoidPaymentResponse{Data: toProtoPayment(payment)}, nil
+}
+
+func (r *PaymentsResolver) RefundPayment(ctx context.Context, req *pb.RefundPaymentRequest) (*pb.RefundPaymentResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.RefundPayment")
+	defer span.End()
+
+	request := domain.OperationRequest{
+		PaymentID:      req.PaymentId,
+		IdempotencyKey: req.IdempotencyKey,
+		Amount:         req.Amount,
+	}
+
+	payment, err := r.App.RefundPayment(ctx, request)
+	if err != nil {
+		return nil, r.toError(err, "failed to refund the payment", zap.Any("request", request))
+	}
+
+	return &pb.RefundPaymentResponse{Data: toProtoPayment(payment)}, nil
+}
+
+func (r *PaymentsResolver) GetPayment(ctx context.Context, req *pb.GetPaymentRequest) (*pb.GetPaymentResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.GetPayment")
+	defer span.End()
+
+	payment, err := r.App.GetPayment(ctx, req.Id)
+	if err != nil {
+		return nil, r.toError(err, "failed to get the payment", zap.String("id", req.Id))
+	}
+
+	return &pb.GetPaymentResponse{Data: toProtoPayment(payment)}, nil
+}
+
+func (r *PaymentsResolver) ListPayments(ctx context.Context, req *pb.ListPaymentsRequest) (*pb.ListPaymentsResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.ListPayments")
+	defer span.End()
+
+	filter := domain.PaymentFilter{
+		OrderID:    int(req.OrderId),
+		CustomerID: req.CustomerId,
+		Status:     domain.PaymentStatus(req.Status),
+		Limit:      int(req.Limit),
+	}
+
+	payments, err := r.App.ListPayments(ctx, filter)
+	if err != nil {
+		return nil, r.toError(err, "failed to list the payments", zap.Any("filter", filter))
+	}
+
+	response := make([]*pb.Payment, 0, len(payments))
+	for _, payment := range payments {
+		response = append(response, toProtoPayment(payment))
+	}
+
+	return &pb.ListPaymentsResponse{Data: response}, nil
+}
+
+// toError maps the Payment errors to their gRPC codes, a declined Payment failing the precondition and a gateway
+// that can't be reached being Unavailable. Unknown errors are logged and reported as internal.
+func (r *PaymentsResolver) toError(err error, message string, fields ...zap.Field) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidPayment),
+		errors.Is(err, domain.ErrInvalidAmount),
+		errors.Is(err, domain.ErrInvalidIdempotencyKey),
+		errors.Is(err, domain.ErrInvalidPaymentFilter):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrPaymentNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrIdempotencyKeyReused):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrInvalidPaymentStatus),
+		errors.Is(err, domain.ErrRefundExceedsCaptured),
+		errors.Is(err, domain.ErrPaymentDeclined):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrPaymentVersionConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, domain.ErrGatewayUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	r.Logger.Error(message, append(fields, zap.Error(err))...)
+	return InternalServerError
+}
+
+func toProtoPayment(payment domain.Payment) *pb.Payment {
+	operations := make([]*pb.Operation, 0, len(payment.Operations))
+	for _, operation := range payment.Operations {
+		operations = append(operations, &pb.Operation{
+			Kind:           string(operation.Kind),
+			IdempotencyKey: operation.IdempotencyKey,
+			Amount:         operation.Amount,
+			At:             toProtoTimestamp(operation.At),
+		})
+	}
+
+	return &pb.Payment{
+		Id:               payment.ID,
+		IdempotencyKey:   payment.IdempotencyKey,
+		OrderId:          int32(payment.OrderID),
+		CustomerId:       payment.CustomerID,
+		Amount:           payment.Amount,
+		CapturedAmount:   payment.CapturedAmount,
+		RefundedAmount:   payment.RefundedAmount,
+		PaymentMethod:    payment.PaymentMethod,
+		Status:           string(payment.Status),
+		GatewayReference: payment.GatewayReference,
+		FailureReason:    payment.FailureReason,
+		Operations:       operations,
+		Version:          int32(payment.Version),
+		CreatedAt:        toProtoTimestamp(payment.CreatedAt),
+		UpdatedAt:        toProtoTimestamp(payment.UpdatedAt),
+	}
+}
+
+func toProtoTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
@@ -0,0 +1,278 @@
+package grpc_port
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/shared/grpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	gGRPC "google.golang.org/grpc"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"github.com/lucasmls/ecommerce/services/payments/mocks"
+	protog "github.com/lucasmls/ecommerce/services/payments/ports/grpc/proto"
+)
+
+const paymentID = "0123456789abcdef0123456789abcdef"
+
+type PaymentsResolverSuite struct {
+	suite.Suite
+
+	app    *mocks.Application
+	logger *zap.Logger
+	tracer trace.Tracer
+
+	grpcClient     protog.PaymentsServiceClient
+	grpcConnection *gGRPC.ClientConn
+}
+
+func (s *PaymentsResolverSuite) SetupSuite() {
+	s.logger = zap.NewNop()
+	s.tracer = trace.NewNoopTracerProvider().Tracer("")
+	s.app = &mocks.Application{}
+
+	grpcClient, grpcConnection := makeGrpcClientAndServer(
+		context.Background(),
+		s.logger,
+		s.tracer,
+		s.app,
+	)
+
+	s.grpcClient = grpcClient
+	s.grpcConnection = grpcConnection
+}
+
+func (s *PaymentsResolverSuite) TearDownSuite() {
+	err := s.grpcConnection.Close()
+	s.NoError(err)
+}
+
+func (s *PaymentsResolverSuite) Test_NewPaymentsResolver() {
+	s.Run("Should fail to instantiate the PaymentsResolver in case a Logger isn't provided", func() {
+		_, err := NewPaymentsResolver(nil, nil, nil)
+
+		s.Equal(ErrMissingLogger, err)
+	})
+
+	s.Run("Should fail to instantiate the PaymentsResolver in case a Tracer isn't provided", func() {
+		_, err := NewPaymentsResolver(s.logger, nil, nil)
+
+		s.Equal(ErrMisingTracer, err)
+	})
+
+	s.Run("Should successfully instantiate the PaymentsResolver", func() {
+		_, err := NewPaymentsResolver(s.logger, s.tracer, nil)
+
+		s.NoError(err)
+	})
+}
+
+func (s *PaymentsResolverSuite) Test_AuthorizePayment() {
+	request := domain.AuthorizationRequest{
+		IdempotencyKey: "checkout:abc:authorize",
+		OrderID:        7,
+		CustomerID:     "john",
+		Amount:         159800,
+		PaymentMethod:  "fake_ok",
+	}
+
+	s.Run("Should return an already exists error in case the key was used by a different request", func() {
+		s.app.On("AuthorizePayment", mock.AnythingOfType("*context.valueCtx"), request).Return(domain.Payment{}, domain.ErrIdempotencyKeyReused).Once()
+
+		_, err := s.grpcClient.AuthorizePayment(context.Background(), &protog.AuthorizePaymentRequest{
+			IdempotencyKey: "checkout:abc:authorize",
+			OrderId:        7,
+			CustomerId:     "john",
+			Amount:         159800,
+			PaymentMethod:  "fake_ok",
+		})
+
+		s.Equal(status.Error(codes.AlreadyExists, domain.ErrIdempotencyKeyReused.Error()), err)
+	})
+
+	s.Run("Should return an unavailable error in case the gateway can't be reached", func() {
+		s.app.On("AuthorizePayment", mock.AnythingOfType("*context.valueCtx"), request).Return(domain.Payment{}, domain.ErrGatewayUnavailable).Once()
+
+		_, err := s.grpcClient.AuthorizePayment(context.Background(), &protog.AuthorizePaymentRequest{
+			IdempotencyKey: "checkout:abc:authorize",
+			OrderId:        7,
+			CustomerId:     "john",
+			Amount:         159800,
+			PaymentMethod:  "fake_ok",
+		})
+
+		s.Equal(status.Error(codes.Unavailable, domain.ErrGatewayUnavailable.Error()), err)
+	})
+
+	s.Run("Should return the authorized payment with its operations", func() {
+		now := time.Date(2022, 5, 8, 10, 0, 0, 0, time.UTC)
+		payment := domain.NewPayment(paymentID, request, now)
+		payment.Authorize(domain.Authorization{Reference: "fake_1", Status: domain.PaymentStatusAuthorized}, now)
+		payment.Version = 2
+
+		s.app.On("AuthorizePayment", mock.AnythingOfType("*context.valueCtx"), request).Return(payment, nil).Once()
+
+		got, err := s.grpcClient.AuthorizePayment(context.Background(), &protog.AuthorizePaymentRequest{
+			IdempotencyKey: "checkout:abc:authorize",
+			OrderId:        7,
+			CustomerId:     "john",
+			Amount:         159800,
+			PaymentMethod:  "fake_ok",
+		})
+
+		s.NoError(err)
+		s.Equal(paymentID, got.Data.Id)
+		s.Equal("authorized", got.Data.Status)
+		s.Equal("fake_1", got.Data.GatewayReference)
+		s.Equal(int64(159800), got.Data.Amount)
+		s.Require().Len(got.Data.Operations, 1)
+		s.Equal("authorize", got.Data.Operations[0].Kind)
+		s.Equal(now, got.Data.CreatedAt.AsTime())
+	})
+}
+
+func (s *PaymentsResolverSuite) Test_CapturePayment() {
+	s.Run("Should return a failed precondition error in case the payment isn't authorized", func() {
+		s.app.
+			On("CapturePayment", mock.AnythingOfType("*context.valueCtx"), domain.OperationRequest{PaymentID: paymentID, IdempotencyKey: "capture-1"}).
+			Return(domain.Payment{}, domain.ErrInvalidPaymentStatus).
+			Once()
+
+		_, err := s.grpcClient.CapturePayment(context.Background(), &protog.CapturePaymentRequest{PaymentId: paymentID, IdempotencyKey: "capture-1"})
+
+		s.Equal(status.Error(codes.FailedPrecondition, domain.ErrInvalidPaymentStatus.Error()), err)
+	})
+}
+
+func (s *PaymentsResolverSuite) Test_RefundPayment() {
+	s.Run("Should pass the amount along", func() {
+		s.app.
+			On("RefundPayment", mock.AnythingOfType("*context.valueCtx"), domain.OperationRequest{PaymentID: paymentID, IdempotencyKey: "refund-1", Amount: 50000}).
+			Return(domain.Payment{ID: paymentID, Status: domain.PaymentStatusCaptured, CapturedAmount: 159800, RefundedAmount: 50000}, nil).
+			Once()
+
+		got, err := s.grpcClient.RefundPayment(context.Background(), &protog.RefundPaymentRequest{PaymentId: paymentID, IdempotencyKey: "refund-1", Amount: 50000})
+
+		s.NoError(err)
+		s.Equal(int64(50000), got.Data.RefundedAmount)
+	})
+
+	s.Run("Should return a failed precondition error in case more than what was captured is asked for", func() {
+		s.app.
+			On("RefundPayment", mock.AnythingOfType("*context.valueCtx"), domain.OperationRequest{PaymentID: paymentID, IdempotencyKey: "refund-2", Amount: 1}).
+			Return(domain.Payment{}, domain.ErrRefundExceedsCaptured).
+			Once()
+
+		_, err := s.grpcClient.RefundPayment(context.Background(), &protog.RefundPaymentRequest{PaymentId: paymentID, IdempotencyKey: "refund-2", Amount: 1})
+
+		s.Equal(status.Error(codes.FailedPrecondition, domain.ErrRefundExceedsCaptured.Error()), err)
+	})
+}
+
+func (s *PaymentsResolverSuite) Test_GetPayment() {
+	s.Run("Should return a not found error in case the payment doesn't exist", func() {
+		s.app.On("GetPayment", mock.AnythingOfType("*context.valueCtx"), "missing").Return(domain.Payment{}, domain.ErrPaymentNotFound).Once()
+
+		_, err := s.grpcClient.GetPayment(context.Background(), &protog.GetPaymentRequest{Id: "missing"})
+
+		s.Equal(status.Error(codes.NotFound, domain.ErrPaymentNotFound.Error()), err)
+	})
+
+	s.Run("Should return an internal server error in case of unknown errors", func() {
+		s.app.On("GetPayment", mock.AnythingOfType("*context.valueCtx"), paymentID).Return(domain.Payment{}, errors.New("connection refused")).Once()
+
+		_, err := s.grpcClient.GetPayment(context.Background(), &protog.GetPaymentRequest{Id: paymentID})
+
+		s.Equal(status.Error(codes.Internal, "Internal server error"), err)
+	})
+}
+
+func (s *PaymentsResolverSuite) Test_ListPayments() {
+	s.Run("Should pass the filter along", func() {
+		s.app.
+			On("ListPayments", mock.AnythingOfType("*context.valueCtx"), domain.PaymentFilter{OrderID: 7, Status: domain.PaymentStatusFailed, Limit: 5}).
+			Return([]domain.Payment{{ID: paymentID, Status: domain.PaymentStatusFailed, FailureReason: "card declined"}}, nil).
+			Once()
+
+		got, err := s.grpcClient.ListPayments(context.Background(), &protog.ListPaymentsRequest{OrderId: 7, Status: "failed", Limit: 5})
+
+		s.NoError(err)
+		s.Require().Len(got.Data, 1)
+		s.Equal("card declined", got.Data[0].FailureReason)
+	})
+}
+
+func TestPaymentsResolverSuite(t *testing.T) {
+	suite.Run(t, new(PaymentsResolverSuite))
+}
+
+func makeGrpcClientAndServer(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	tracerM trace.Tracer,
+	appM domain.Application,
+) (protog.PaymentsServiceClient, *gGRPC.ClientConn) {
+	listener := makeGrpcServer(ctx, loggerM, tracerM, appM)
+	grpcClient, grpcConnection := makeGrpcClient(ctx, loggerM, listener)
+
+	return grpcClient, grpcConnection
+}
+
+func makeGrpcClient(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	listener *bufconn.Listener,
+) (protog.PaymentsServiceClient, *gGRPC.ClientConn) {
+	paymentsServiceGRPCClient := grpc.MustNewClient(grpc.ClientInput{
+		Logger: loggerM,
+		AdditionalDialOptions: []gGRPC.DialOption{
+			gGRPC.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+				return listener.Dial()
+			}),
+		},
+	})
+
+	paymentsServiceConn := paymentsServiceGRPCClient.MustConnect(ctx)
+
+	grpcClient := protog.NewPaymentsServiceClient(paymentsServiceConn)
+	return grpcClient, paymentsServiceConn
+}
+
+func makeGrpcServer(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	tracerM trace.Tracer,
+	appM domain.Application,
+) *bufconn.Listener {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.MustNewServer(grpc.ServerInput{
+		Port:   1234,
+		Logger: loggerM,
+		Registrator: func(server gGRPC.ServiceRegistrar) {
+			protog.RegisterPaymentsServiceServer(server, &PaymentsResolver{
+				Logger: loggerM,
+				Tracer: tracerM,
+				App:    appM,
+			})
+		},
+		Listener: listener,
+	})
+
+	go func() {
+		if err := grpcServer.Run(ctx); err != nil {
+			panic(err)
+		}
+	}()
+
+	return listener
+}
@@ -0,0 +1,105 @@
+package http_port
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// maxWebhookSize bounds how many bytes of a webhook are read
+const maxWebhookSize = 64 << 10
+
+var (
+	ErrMissingLogger          = errors.New("missing required dependency: Logger")
+	ErrMisingTracer           = errors.New("missing required dependency: Tracer")
+	ErrMissingSignatureHeader = errors.New("missing required dependency: SignatureHeader")
+)
+
+// WebhooksHandler takes the webhooks the gateway posts about the pending authorizations.
+// The gateways post a webhook again until it's answered with a 2xx status, so only the webhooks
+// that can never be handled are answered with a 4xx one.
+type WebhooksHandler struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+	App    domain.Application
+	// SignatureHeader is the header the gateway signs the webhooks on
+	SignatureHeader string
+}
+
+func NewWebhooksHandler(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+	signatureHeader string,
+) (*WebhooksHandler, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMisingTracer
+	}
+
+	if signatureHeader == "" {
+		return nil, ErrMissingSignatureHeader
+	}
+
+	return &WebhooksHandler{
+		Logger:          logger,
+		Tracer:          tracer,
+		App:             app,
+		SignatureHeader: signatureHeader,
+	}, nil
+}
+
+// MustNewWebhooksHandler creates a new WebhooksHandler instance.
+// It panics if any error is found
+func MustNewWebhooksHandler(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+	signatureHeader string,
+) *WebhooksHandler {
+	handler, err := NewWebhooksHandler(logger, tracer, app, signatureHeader)
+	if err != nil {
+		panic(err)
+	}
+
+	return handler
+}
+
+// ServeHTTP answers 204 once the webhook is handled, 400 when it can't be verified, and 404 when the
+// Payment it's about isn't stored, which may happen when it's posted before the authorization is stored.
+func (h *WebhooksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.Tracer.Start(r.Context(), "handler.HandleWebhook")
+	defer span.End()
+
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookSize))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = h.App.HandleWebhook(ctx, payload, r.Header.Get(h.SignatureHeader))
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusNoContent)
+	case errors.Is(err, domain.ErrInvalidWebhook):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Is(err, domain.ErrPaymentNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		h.Logger.Error("failed to handle a webhook", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
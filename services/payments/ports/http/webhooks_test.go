@@ -0,0 +1,99 @@
+package http_port
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"github.com/lucasmls/ecommerce/services/payments/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const signatureHeader = "X-Fake-Signature"
+
+type WebhooksHandlerSuite struct {
+	suite.Suite
+
+	app     *mocks.Application
+	handler *WebhooksHandler
+}
+
+func (s *WebhooksHandlerSuite) SetupTest() {
+	s.app = &mocks.Application{}
+	s.handler = MustNewWebhooksHandler(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), s.app, signatureHeader)
+}
+
+func (s *WebhooksHandlerSuite) post(payload string, signature string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, "/webhooks/fake", strings.NewReader(payload))
+	request.Header.Set(signatureHeader, signature)
+
+	recorder := httptest.NewRecorder()
+	s.handler.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func (s *WebhooksHandlerSuite) Test_NewWebhooksHandler() {
+	s.Run("Should fail to instantiate the WebhooksHandler in case a SignatureHeader isn't provided", func() {
+		_, err := NewWebhooksHandler(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), nil, "")
+
+		s.Equal(ErrMissingSignatureHeader, err)
+	})
+}
+
+func (s *WebhooksHandlerSuite) Test_ServeHTTP() {
+	s.Run("Should answer no content once the webhook is handled", func() {
+		s.SetupTest()
+
+		s.app.
+			On("HandleWebhook", mock.AnythingOfType("*context.valueCtx"), []byte(`{"id":"evt_1"}`), "abc").
+			Return(nil).
+			Once()
+
+		recorder := s.post(`{"id":"evt_1"}`, "abc")
+
+		s.Equal(http.StatusNoContent, recorder.Code)
+		s.app.AssertExpectations(s.T())
+	})
+
+	s.Run("Should translate the errors of the application", func() {
+		testCases := []struct {
+			err  error
+			code int
+		}{
+			{err: domain.ErrInvalidWebhook, code: http.StatusBadRequest},
+			{err: domain.ErrPaymentNotFound, code: http.StatusNotFound},
+			{err: errors.New("connection refused"), code: http.StatusInternalServerError},
+		}
+
+		for _, testCase := range testCases {
+			s.SetupTest()
+
+			s.app.On("HandleWebhook", mock.AnythingOfType("*context.valueCtx"), mock.Anything, mock.Anything).Return(testCase.err).Once()
+
+			recorder := s.post(`{}`, "abc")
+
+			s.Equal(testCase.code, recorder.Code)
+		}
+	})
+
+	s.Run("Should only take posts", func() {
+		s.SetupTest()
+
+		recorder := httptest.NewRecorder()
+		s.handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/webhooks/fake", nil))
+
+		s.Equal(http.StatusMethodNotAllowed, recorder.Code)
+		s.app.AssertNotCalled(s.T(), "HandleWebhook", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestWebhooksHandlerSuite(t *testing.T) {
+	suite.Run(t, new(WebhooksHandlerSuite))
+}
@@ -0,0 +1,215 @@
+package rmq_port
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	checkoutPb "github.com/lucasmls/ecommerce/services/checkout/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"github.com/lucasmls/ecommerce/shared/rmq"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	ErrMissingLogger    = errors.New("missing required dependency: Logger")
+	ErrMisingTracer     = errors.New("missing required dependency: Tracer")
+	ErrMissingPublisher = errors.New("missing required dependency: Publisher")
+)
+
+// errUnsupportedCommand is replied to the Commands of the steps the Payments don't run
+var errUnsupportedCommand = errors.New("unsupported-command")
+
+// CheckoutConsumer runs the charge step of the checkout saga on the Payments: it authorizes and captures
+// the amount of the Order, refunding or voiding it when the checkout fails.
+type CheckoutConsumer struct {
+	Logger    *zap.Logger
+	Tracer    trace.Tracer
+	App       domain.Application
+	Publisher rmq.Publisher
+}
+
+func NewCheckoutConsumer(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+	publisher rmq.Publisher,
+) (*CheckoutConsumer, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMisingTracer
+	}
+
+	if publisher == nil {
+		return nil, ErrMissingPublisher
+	}
+
+	return &CheckoutConsumer{
+		Logger:    logger,
+		Tracer:    tracer,
+		App:       app,
+		Publisher: publisher,
+	}, nil
+}
+
+// MustNewCheckoutConsumer creates a new CheckoutConsumer instance.
+// It panics if any error is found
+func MustNewCheckoutConsumer(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+	publisher rmq.Publisher,
+) *CheckoutConsumer {
+	consumer, err := NewCheckoutConsumer(logger, tracer, app, publisher)
+	if err != nil {
+		panic(err)
+	}
+
+	return consumer
+}
+
+// Handle runs a Command of the checkout saga and replies to it, it's meant to be the Handler of the rmq.Client
+// consuming the commands queue of the Payments. Every call to the gateway takes an idempotency key derived from
+// the saga, so the Commands sent again never move the money twice. The charges whose authorization is pending
+// aren't replied until the gateway settles it, they're replied to one of the Commands sent after that.
+// The Commands failing for reasons the domain doesn't know about, e.g. an unavailable gateway, aren't replied
+// either, to be sent again.
+func (c *CheckoutConsumer) Handle(ctx context.Context, body []byte) error {
+	ctx, span := c.Tracer.Start(ctx, "consumer.HandleCheckoutCommand")
+	defer span.End()
+
+	command := &checkoutPb.Command{}
+	if err := proto.Unmarshal(body, command); err != nil {
+		return err
+	}
+
+	reply := &checkoutPb.Reply{
+		CommandId: command.Id,
+		SagaId:    command.SagaId,
+		Step:      command.Step,
+		Action:    command.Action,
+		Success:   true,
+	}
+
+	payment, pending, err := c.run(ctx, command)
+	switch {
+	case err == nil && pending:
+		c.Logger.Info("the authorization is pending, waiting for the gateway", zap.String("commandId", command.Id))
+		return nil
+	case err == nil && command.Action == checkoutPb.ActionExecute:
+		reply.PaymentId = payment.ID
+	case errors.Is(err, errUnsupportedCommand),
+		errors.Is(err, domain.ErrPaymentDeclined),
+		errors.Is(err, domain.ErrInvalidPayment),
+		errors.Is(err, domain.ErrInvalidAmount),
+		errors.Is(err, domain.ErrIdempotencyKeyReused),
+		errors.Is(err, domain.ErrInvalidPaymentStatus):
+		reply.Success = false
+		reply.Error = err.Error()
+	case err != nil:
+		return err
+	}
+
+	c.Logger.Info("replying to a checkout command", zap.String("commandId", command.Id), zap.Bool("success", reply.Success))
+
+	return c.Publisher.Publish(ctx, checkoutPb.Exchange, checkoutPb.RepliesRoutingKey, reply)
+}
+
+// run returns the Payment of the saga, telling whether its authorization is still pending
+func (c *CheckoutConsumer) run(ctx context.Context, command *checkoutPb.Command) (domain.Payment, bool, error) {
+	switch {
+	case command.Step == checkoutPb.StepChargePayment && command.Action == checkoutPb.ActionExecute:
+		return c.charge(ctx, command)
+	case command.Step == checkoutPb.StepChargePayment && command.Action == checkoutPb.ActionCompensate:
+		payment, err := c.undo(ctx, command)
+		return payment, false, err
+	default:
+		return domain.Payment{}, false, errUnsupportedCommand
+	}
+}
+
+// charge authorizes the amount of the Order and captures it, voiding the authorization when the capture is declined
+func (c *CheckoutConsumer) charge(ctx context.Context, command *checkoutPb.Command) (domain.Payment, bool, error) {
+	payment, err := c.App.AuthorizePayment(ctx, domain.AuthorizationRequest{
+		IdempotencyKey: checkoutKey(command.SagaId, domain.OperationAuthorize),
+		OrderID:        int(command.OrderId),
+		CustomerID:     command.CustomerId,
+		Amount:         command.Amount,
+		PaymentMethod:  command.PaymentMethod,
+	})
+	if err != nil {
+		return domain.Payment{}, false, err
+	}
+
+	switch payment.Status {
+	case domain.PaymentStatusPending:
+		return payment, true, nil
+	case domain.PaymentStatusFailed:
+		return domain.Payment{}, false, domain.ErrPaymentDeclined
+	}
+
+	captured, err := c.App.CapturePayment(ctx, domain.OperationRequest{
+		PaymentID:      payment.ID,
+		IdempotencyKey: checkoutKey(command.SagaId, domain.OperationCapture),
+	})
+	if !errors.Is(err, domain.ErrPaymentDeclined) {
+		return captured, false, err
+	}
+
+	// the charge step failed, so the saga won't undo it: the authorization is released right away
+	if _, err := c.App.VoidPayment(ctx, domain.OperationRequest{
+		PaymentID:      payment.ID,
+		IdempotencyKey: checkoutKey(command.SagaId, domain.OperationVoid),
+	}); err != nil && !errors.Is(err, domain.ErrInvalidPaymentStatus) {
+		return domain.Payment{}, false, err
+	}
+
+	return domain.Payment{}, false, domain.ErrPaymentDeclined
+}
+
+// undo gives back the money the saga took, refunding it once captured and voiding it otherwise
+func (c *CheckoutConsumer) undo(ctx context.Context, command *checkoutPb.Command) (domain.Payment, error) {
+	var payment domain.Payment
+	var err error
+
+	// the saga doesn't know the Payment when the charge wasn't replied, e.g. when it timed out
+	if command.PaymentId != "" {
+		payment, err = c.App.GetPayment(ctx, command.PaymentId)
+	} else {
+		payment, err = c.App.GetPaymentByIdempotencyKey(ctx, checkoutKey(command.SagaId, domain.OperationAuthorize))
+	}
+
+	if errors.Is(err, domain.ErrPaymentNotFound) {
+		// the charge never reached the Payments, there's nothing to undo
+		return domain.Payment{}, nil
+	}
+
+	if err != nil {
+		return domain.Payment{}, err
+	}
+
+	request := domain.OperationRequest{PaymentID: payment.ID}
+
+	switch {
+	case payment.Status == domain.PaymentStatusCaptured:
+		request.IdempotencyKey = checkoutKey(command.SagaId, domain.OperationRefund)
+		return c.App.RefundPayment(ctx, request)
+	case payment.Status == domain.PaymentStatusAuthorized,
+		payment.Status == domain.PaymentStatusPending && payment.GatewayReference != "":
+		request.IdempotencyKey = checkoutKey(command.SagaId, domain.OperationVoid)
+		return c.App.VoidPayment(ctx, request)
+	default:
+		// failed, voided or refunded already, or never authorized on the gateway
+		return payment, nil
+	}
+}
+
+// checkoutKey is the idempotency key of an operation run on behalf of a saga, e.g. checkout:<saga id>:capture
+func checkoutKey(sagaID string, kind domain.OperationKind) string {
+	return fmt.Sprintf("checkout:%s:%s", sagaID, kind)
+}
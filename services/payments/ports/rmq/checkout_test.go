@@ -0,0 +1,252 @@
+package rmq_port
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	checkoutPb "github.com/lucasmls/ecommerce/services/checkout/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/services/payments/domain"
+	"github.com/lucasmls/ecommerce/services/payments/mocks"
+	"github.com/lucasmls/ecommerce/shared/rmq/rmqtest"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+type CheckoutConsumerSuite struct {
+	suite.Suite
+
+	app       *mocks.Application
+	publisher *rmqtest.Publisher
+	consumer  *CheckoutConsumer
+}
+
+func (s *CheckoutConsumerSuite) SetupTest() {
+	s.app = &mocks.Application{}
+	s.publisher = &rmqtest.Publisher{}
+	s.consumer = MustNewCheckoutConsumer(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), s.app, s.publisher)
+}
+
+func (s *CheckoutConsumerSuite) handle(command *checkoutPb.Command) error {
+	body, err := proto.Marshal(command)
+	s.Require().NoError(err)
+
+	return s.consumer.Handle(context.Background(), body)
+}
+
+// reply is the Reply the consumer published, nil when it didn't reply
+func (s *CheckoutConsumerSuite) reply() *checkoutPb.Reply {
+	reply, _ := s.publisher.Message.(*checkoutPb.Reply)
+	return reply
+}
+
+func (s *CheckoutConsumerSuite) Test_NewCheckoutConsumer() {
+	s.Run("Should fail to instantiate the CheckoutConsumer in case a Publisher isn't provided", func() {
+		_, err := NewCheckoutConsumer(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), nil, nil)
+
+		s.Equal(ErrMissingPublisher, err)
+	})
+}
+
+func (s *CheckoutConsumerSuite) Test_ChargePayment() {
+	command := &checkoutPb.Command{
+		Id:            "abc:charge_payment:execute:1",
+		SagaId:        "abc",
+		Step:          checkoutPb.StepChargePayment,
+		Action:        checkoutPb.ActionExecute,
+		OrderId:       7,
+		CustomerId:    "john",
+		Amount:        159800,
+		PaymentMethod: "fake_ok",
+	}
+
+	authorization := domain.AuthorizationRequest{
+		IdempotencyKey: "checkout:abc:authorize",
+		OrderID:        7,
+		CustomerID:     "john",
+		Amount:         159800,
+		PaymentMethod:  "fake_ok",
+	}
+
+	s.Run("Should authorize and capture the amount of the order, replying the payment", func() {
+		s.SetupTest()
+
+		s.app.
+			On("AuthorizePayment", mock.AnythingOfType("*context.valueCtx"), authorization).
+			Return(domain.Payment{ID: "p1", Status: domain.PaymentStatusAuthorized}, nil).
+			Once()
+		s.app.
+			On("CapturePayment", mock.AnythingOfType("*context.valueCtx"), domain.OperationRequest{PaymentID: "p1", IdempotencyKey: "checkout:abc:capture"}).
+			Return(domain.Payment{ID: "p1", Status: domain.PaymentStatusCaptured}, nil).
+			Once()
+
+		s.NoError(s.handle(command))
+
+		s.Equal(checkoutPb.RepliesRoutingKey, s.publisher.RoutingKey)
+		s.True(proto.Equal(&checkoutPb.Reply{
+			CommandId: command.Id,
+			SagaId:    "abc",
+			Step:      checkoutPb.StepChargePayment,
+			Action:    checkoutPb.ActionExecute,
+			Success:   true,
+			PaymentId: "p1",
+		}, s.reply()))
+	})
+
+	s.Run("Should not reply while the authorization is pending", func() {
+		s.SetupTest()
+
+		s.app.
+			On("AuthorizePayment", mock.AnythingOfType("*context.valueCtx"), authorization).
+			Return(domain.Payment{ID: "p1", Status: domain.PaymentStatusPending, GatewayReference: "fake_1"}, nil).
+			Once()
+
+		s.NoError(s.handle(command))
+
+		s.Nil(s.reply())
+		s.app.AssertNotCalled(s.T(), "CapturePayment", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should reply a failure in case the authorization is declined", func() {
+		s.SetupTest()
+
+		s.app.
+			On("AuthorizePayment", mock.AnythingOfType("*context.valueCtx"), authorization).
+			Return(domain.Payment{ID: "p1", Status: domain.PaymentStatusFailed, FailureReason: "card declined"}, nil).
+			Once()
+
+		s.NoError(s.handle(command))
+
+		s.False(s.reply().Success)
+		s.Equal(domain.ErrPaymentDeclined.Error(), s.reply().Error)
+	})
+
+	s.Run("Should void the authorization and reply a failure in case the capture is declined", func() {
+		s.SetupTest()
+
+		s.app.
+			On("AuthorizePayment", mock.AnythingOfType("*context.valueCtx"), authorization).
+			Return(domain.Payment{ID: "p1", Status: domain.PaymentStatusAuthorized}, nil).
+			Once()
+		s.app.
+			On("CapturePayment", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.OperationRequest")).
+			Return(domain.Payment{}, domain.ErrPaymentDeclined).
+			Once()
+		s.app.
+			On("VoidPayment", mock.AnythingOfType("*context.valueCtx"), domain.OperationRequest{PaymentID: "p1", IdempotencyKey: "checkout:abc:void"}).
+			Return(domain.Payment{ID: "p1", Status: domain.PaymentStatusVoided}, nil).
+			Once()
+
+		s.NoError(s.handle(command))
+
+		s.False(s.reply().Success)
+		s.Equal(domain.ErrPaymentDeclined.Error(), s.reply().Error)
+		s.app.AssertExpectations(s.T())
+	})
+
+	s.Run("Should neither reply nor acknowledge the command in case the gateway is unavailable", func() {
+		s.SetupTest()
+
+		s.app.
+			On("AuthorizePayment", mock.AnythingOfType("*context.valueCtx"), authorization).
+			Return(domain.Payment{}, domain.ErrGatewayUnavailable).
+			Once()
+
+		err := s.handle(command)
+
+		s.True(errors.Is(err, domain.ErrGatewayUnavailable))
+		s.Nil(s.reply())
+	})
+}
+
+func (s *CheckoutConsumerSuite) Test_UndoPayment() {
+	command := &checkoutPb.Command{
+		Id:        "abc:charge_payment:compensate:1",
+		SagaId:    "abc",
+		Step:      checkoutPb.StepChargePayment,
+		Action:    checkoutPb.ActionCompensate,
+		PaymentId: "p1",
+	}
+
+	s.Run("Should refund a captured payment", func() {
+		s.SetupTest()
+
+		s.app.On("GetPayment", mock.AnythingOfType("*context.valueCtx"), "p1").Return(domain.Payment{ID: "p1", Status: domain.PaymentStatusCaptured}, nil).Once()
+		s.app.
+			On("RefundPayment", mock.AnythingOfType("*context.valueCtx"), domain.OperationRequest{PaymentID: "p1", IdempotencyKey: "checkout:abc:refund"}).
+			Return(domain.Payment{ID: "p1", Status: domain.PaymentStatusRefunded}, nil).
+			Once()
+
+		s.NoError(s.handle(command))
+
+		s.True(s.reply().Success)
+		s.Empty(s.reply().PaymentId)
+		s.app.AssertExpectations(s.T())
+	})
+
+	s.Run("Should void the authorization of a charge that timed out, finding it by its key", func() {
+		s.SetupTest()
+
+		timedOut := proto.Clone(command).(*checkoutPb.Command)
+		timedOut.PaymentId = ""
+
+		s.app.
+			On("GetPaymentByIdempotencyKey", mock.AnythingOfType("*context.valueCtx"), "checkout:abc:authorize").
+			Return(domain.Payment{ID: "p1", Status: domain.PaymentStatusPending, GatewayReference: "fake_1"}, nil).
+			Once()
+		s.app.
+			On("VoidPayment", mock.AnythingOfType("*context.valueCtx"), domain.OperationRequest{PaymentID: "p1", IdempotencyKey: "checkout:abc:void"}).
+			Return(domain.Payment{ID: "p1", Status: domain.PaymentStatusVoided}, nil).
+			Once()
+
+		s.NoError(s.handle(timedOut))
+
+		s.True(s.reply().Success)
+		s.app.AssertExpectations(s.T())
+	})
+
+	s.Run("Should succeed in case the charge never reached the payments", func() {
+		s.SetupTest()
+
+		s.app.On("GetPayment", mock.AnythingOfType("*context.valueCtx"), "p1").Return(domain.Payment{}, domain.ErrPaymentNotFound).Once()
+
+		s.NoError(s.handle(command))
+
+		s.True(s.reply().Success)
+	})
+
+	s.Run("Should succeed without reaching the gateway in case the payment failed", func() {
+		s.SetupTest()
+
+		s.app.On("GetPayment", mock.AnythingOfType("*context.valueCtx"), "p1").Return(domain.Payment{ID: "p1", Status: domain.PaymentStatusFailed}, nil).Once()
+
+		s.NoError(s.handle(command))
+
+		s.True(s.reply().Success)
+		s.app.AssertNotCalled(s.T(), "VoidPayment", mock.Anything, mock.Anything)
+		s.app.AssertNotCalled(s.T(), "RefundPayment", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *CheckoutConsumerSuite) Test_UnsupportedCommand() {
+	s.Run("Should reply a failure to the commands of the other participants", func() {
+		s.SetupTest()
+
+		s.NoError(s.handle(&checkoutPb.Command{
+			Id:     "abc:reserve_stock:execute:1",
+			SagaId: "abc",
+			Step:   checkoutPb.StepReserveStock,
+			Action: checkoutPb.ActionExecute,
+		}))
+
+		s.False(s.reply().Success)
+		s.Equal(errUnsupportedCommand.Error(), s.reply().Error)
+	})
+}
+
+func TestCheckoutConsumerSuite(t *testing.T) {
+	suite.Run(t, new(CheckoutConsumerSuite))
+}
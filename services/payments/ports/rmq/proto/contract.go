@@ -0,0 +1,9 @@
+package rmq_protobuf
+
+// Exchange is the topic exchange the PaymentEvents go through
+const Exchange = "payments"
+
+// EventRoutingKey routes the PaymentEvents of a type, e.g. payments.captured
+func EventRoutingKey(eventType string) string {
+	return "payments." + eventType
+}
@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// InMemoryPriceListsRepository keeps the PriceLists in memory.
+type InMemoryPriceListsRepository struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	// mu guards priceLists, indexed by their IDs, and lastID
+	mu         sync.Mutex
+	priceLists map[int]domain.PriceList
+	lastID     int
+}
+
+// NewInMemoryPriceListsRepository creates a new InMemoryPriceListsRepository.
+func NewInMemoryPriceListsRepository(logger *zap.Logger, tracer trace.Tracer) *InMemoryPriceListsRepository {
+	return &InMemoryPriceListsRepository{
+		Logger:     logger,
+		Tracer:     tracer,
+		priceLists: map[int]domain.PriceList{},
+	}
+}
+
+// Create stores a new PriceList in memory.
+func (r *InMemoryPriceListsRepository) Create(ctx context.Context, priceList domain.PriceList) (domain.PriceList, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Create")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lastID++
+
+	priceList = copyPriceList(priceList)
+	priceList.ID = r.lastID
+	priceList.Version = 1
+
+	r.priceLists[priceList.ID] = priceList
+
+	return copyPriceList(priceList), nil
+}
+
+// Get fetches a single PriceList from memory.
+func (r *InMemoryPriceListsRepository) Get(ctx context.Context, id int) (domain.PriceList, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Get")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	priceList, ok := r.priceLists[id]
+	if !ok {
+		return domain.PriceList{}, domain.ErrPriceListNotFound
+	}
+
+	return copyPriceList(priceList), nil
+}
+
+// Update replaces a PriceList in memory as long as its Version matches the stored one.
+func (r *InMemoryPriceListsRepository) Update(ctx context.Context, priceList domain.PriceList) (domain.PriceList, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Update")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.priceLists[priceList.ID]
+	if !ok {
+		return domain.PriceList{}, domain.ErrPriceListNotFound
+	}
+
+	if stored.Version != priceList.Version {
+		return domain.PriceList{}, domain.ErrPriceListVersionConflict
+	}
+
+	priceList = copyPriceList(priceList)
+	priceList.Version++
+
+	r.priceLists[priceList.ID] = priceList
+
+	return copyPriceList(priceList), nil
+}
+
+// Delete removes a PriceList from memory.
+func (r *InMemoryPriceListsRepository) Delete(ctx context.Context, id int) error {
+	_, span := r.Tracer.Start(ctx, "repository.Delete")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.priceLists[id]; !ok {
+		return domain.ErrPriceListNotFound
+	}
+
+	delete(r.priceLists, id)
+
+	return nil
+}
+
+// List fetches every PriceList from memory, the oldest first.
+func (r *InMemoryPriceListsRepository) List(ctx context.Context) ([]domain.PriceList, error) {
+	_, span := r.Tracer.Start(ctx, "repository.List")
+	defer span.End()
+
+	return r.list(func(domain.PriceList) bool { return true }), nil
+}
+
+// ListActive fetches the PriceLists whose Schedule contains the given time from memory.
+func (r *InMemoryPriceListsRepository) ListActive(ctx context.Context, at time.Time) ([]domain.PriceList, error) {
+	_, span := r.Tracer.Start(ctx, "repository.ListActive")
+	defer span.End()
+
+	return r.list(func(priceList domain.PriceList) bool { return priceList.Schedule.Contains(at) }), nil
+}
+
+func (r *InMemoryPriceListsRepository) list(keep func(domain.PriceList) bool) []domain.PriceList {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	priceLists := []domain.PriceList{}
+	for _, priceList := range r.priceLists {
+		if keep(priceList) {
+			priceLists = append(priceLists, copyPriceList(priceList))
+		}
+	}
+
+	sort.Slice(priceLists, func(i, j int) bool {
+		return priceLists[i].ID < priceLists[j].ID
+	})
+
+	return priceLists
+}
+
+// copyPriceList keeps the stored Prices from being changed through the PriceLists handed out by the repository
+func copyPriceList(priceList domain.PriceList) domain.PriceList {
+	prices := make(map[int]int, len(priceList.Prices))
+	for productID, price := range priceList.Prices {
+		prices[productID] = price
+	}
+
+	priceList.Prices = prices
+	return priceList
+}
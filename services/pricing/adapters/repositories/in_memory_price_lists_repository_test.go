@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type InMemoryPriceListsRepositorySuite struct {
+	suite.Suite
+
+	priceListsRepo *InMemoryPriceListsRepository
+	now            time.Time
+}
+
+func (s *InMemoryPriceListsRepositorySuite) SetupTest() {
+	s.priceListsRepo = NewInMemoryPriceListsRepository(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""))
+	s.now = time.Date(2022, 5, 12, 10, 0, 0, 0, time.UTC)
+}
+
+func (s *InMemoryPriceListsRepositorySuite) create(name string, schedule domain.Schedule) domain.PriceList {
+	priceList, err := s.priceListsRepo.Create(context.Background(), domain.PriceList{
+		Name:     name,
+		Schedule: schedule,
+		Prices:   map[int]int{1: 9900},
+	})
+	s.Require().NoError(err)
+
+	return priceList
+}
+
+func (s *InMemoryPriceListsRepositorySuite) Test_Create() {
+	s.Run("Should give the price list an id and its first version", func() {
+		s.SetupTest()
+
+		first := s.create("Black Friday", domain.Schedule{})
+		second := s.create("Christmas", domain.Schedule{})
+
+		s.Equal(1, first.ID)
+		s.Equal(2, second.ID)
+		s.Equal(1, first.Version)
+	})
+
+	s.Run("Should keep the stored prices from being changed by the caller", func() {
+		s.SetupTest()
+
+		priceList := s.create("Black Friday", domain.Schedule{})
+		priceList.Prices[1] = 1
+
+		got, err := s.priceListsRepo.Get(context.Background(), priceList.ID)
+
+		s.NoError(err)
+		s.Equal(9900, got.Prices[1])
+	})
+}
+
+func (s *InMemoryPriceListsRepositorySuite) Test_Update() {
+	s.Run("Should bump the version of the price list", func() {
+		s.SetupTest()
+
+		priceList := s.create("Black Friday", domain.Schedule{})
+		priceList.Prices = map[int]int{1: 8900}
+
+		updated, err := s.priceListsRepo.Update(context.Background(), priceList)
+
+		s.NoError(err)
+		s.Equal(2, updated.Version)
+		s.Equal(8900, updated.Prices[1])
+	})
+
+	s.Run("Should fail in case the price list changed since it was read", func() {
+		s.SetupTest()
+
+		priceList := s.create("Black Friday", domain.Schedule{})
+
+		_, err := s.priceListsRepo.Update(context.Background(), priceList)
+		s.NoError(err)
+
+		_, err = s.priceListsRepo.Update(context.Background(), priceList)
+		s.Equal(domain.ErrPriceListVersionConflict, err)
+	})
+
+	s.Run("Should fail in case the price list doesn't exist", func() {
+		s.SetupTest()
+
+		_, err := s.priceListsRepo.Update(context.Background(), domain.PriceList{ID: 7, Version: 1})
+
+		s.Equal(domain.ErrPriceListNotFound, err)
+	})
+}
+
+func (s *InMemoryPriceListsRepositorySuite) Test_Delete() {
+	s.Run("Should remove the price list", func() {
+		s.SetupTest()
+
+		priceList := s.create("Black Friday", domain.Schedule{})
+
+		s.NoError(s.priceListsRepo.Delete(context.Background(), priceList.ID))
+
+		_, err := s.priceListsRepo.Get(context.Background(), priceList.ID)
+		s.Equal(domain.ErrPriceListNotFound, err)
+
+		s.Equal(domain.ErrPriceListNotFound, s.priceListsRepo.Delete(context.Background(), priceList.ID))
+	})
+}
+
+func (s *InMemoryPriceListsRepositorySuite) Test_ListActive() {
+	s.Run("Should only list the price lists whose schedule contains the time, the oldest first", func() {
+		s.SetupTest()
+
+		always := s.create("Always", domain.Schedule{})
+		s.create("Ended", domain.Schedule{EndsAt: s.now})
+		current := s.create("Current", domain.Schedule{StartsAt: s.now, EndsAt: s.now.Add(time.Hour)})
+		s.create("Upcoming", domain.Schedule{StartsAt: s.now.Add(time.Minute)})
+
+		got, err := s.priceListsRepo.ListActive(context.Background(), s.now)
+
+		s.NoError(err)
+		s.Equal([]domain.PriceList{always, current}, got)
+
+		all, err := s.priceListsRepo.List(context.Background())
+
+		s.NoError(err)
+		s.Len(all, 4)
+	})
+}
+
+func TestInMemoryPriceListsRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InMemoryPriceListsRepositorySuite))
+}
@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// InMemoryPromotionsRepository keeps the Promotions in memory.
+type InMemoryPromotionsRepository struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	// mu guards promotions, indexed by their IDs, along with codes, which maps the discount codes
+	// to the IDs of the Promotions, and lastID
+	mu         sync.Mutex
+	promotions map[int]domain.Promotion
+	codes      map[string]int
+	lastID     int
+}
+
+// NewInMemoryPromotionsRepository creates a new InMemoryPromotionsRepository.
+func NewInMemoryPromotionsRepository(logger *zap.Logger, tracer trace.Tracer) *InMemoryPromotionsRepository {
+	return &InMemoryPromotionsRepository{
+		Logger:     logger,
+		Tracer:     tracer,
+		promotions: map[int]domain.Promotion{},
+		codes:      map[string]int{},
+	}
+}
+
+// Create stores a new Promotion in memory.
+func (r *InMemoryPromotionsRepository) Create(ctx context.Context, promotion domain.Promotion) (domain.Promotion, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Create")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.codes[promotion.Code]; ok && promotion.Code != "" {
+		return domain.Promotion{}, domain.ErrCodeAlreadyUsed
+	}
+
+	r.lastID++
+
+	promotion = copyPromotion(promotion)
+	promotion.ID = r.lastID
+	promotion.Version = 1
+
+	r.store(promotion)
+
+	return copyPromotion(promotion), nil
+}
+
+// Get fetches a single Promotion from memory.
+func (r *InMemoryPromotionsRepository) Get(ctx context.Context, id int) (domain.Promotion, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Get")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	promotion, ok := r.promotions[id]
+	if !ok {
+		return domain.Promotion{}, domain.ErrPromotionNotFound
+	}
+
+	return copyPromotion(promotion), nil
+}
+
+// Update replaces a Promotion in memory as long as its Version matches the stored one.
+func (r *InMemoryPromotionsRepository) Update(ctx context.Context, promotion domain.Promotion) (domain.Promotion, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Update")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.promotions[promotion.ID]
+	if !ok {
+		return domain.Promotion{}, domain.ErrPromotionNotFound
+	}
+
+	if stored.Version != promotion.Version {
+		return domain.Promotion{}, domain.ErrPromotionVersionConflict
+	}
+
+	if id, ok := r.codes[promotion.Code]; ok && promotion.Code != "" && id != promotion.ID {
+		return domain.Promotion{}, domain.ErrCodeAlreadyUsed
+	}
+
+	promotion = copyPromotion(promotion)
+	promotion.Version++
+
+	delete(r.codes, stored.Code)
+	r.store(promotion)
+
+	return copyPromotion(promotion), nil
+}
+
+// Delete removes a Promotion from memory.
+func (r *InMemoryPromotionsRepository) Delete(ctx context.Context, id int) error {
+	_, span := r.Tracer.Start(ctx, "repository.Delete")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.promotions[id]
+	if !ok {
+		return domain.ErrPromotionNotFound
+	}
+
+	delete(r.codes, stored.Code)
+	delete(r.promotions, id)
+
+	return nil
+}
+
+// List fetches every Promotion from memory, the oldest first.
+func (r *InMemoryPromotionsRepository) List(ctx context.Context) ([]domain.Promotion, error) {
+	_, span := r.Tracer.Start(ctx, "repository.List")
+	defer span.End()
+
+	return r.list(func(domain.Promotion) bool { return true }), nil
+}
+
+// ListActive fetches the Promotions whose Schedule contains the given time from memory.
+func (r *InMemoryPromotionsRepository) ListActive(ctx context.Context, at time.Time) ([]domain.Promotion, error) {
+	_, span := r.Tracer.Start(ctx, "repository.ListActive")
+	defer span.End()
+
+	return r.list(func(promotion domain.Promotion) bool { return promotion.Schedule.Contains(at) }), nil
+}
+
+func (r *InMemoryPromotionsRepository) store(promotion domain.Promotion) {
+	r.promotions[promotion.ID] = promotion
+
+	if promotion.Code != "" {
+		r.codes[promotion.Code] = promotion.ID
+	}
+}
+
+func (r *InMemoryPromotionsRepository) list(keep func(domain.Promotion) bool) []domain.Promotion {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	promotions := []domain.Promotion{}
+	for _, promotion := range r.promotions {
+		if keep(promotion) {
+			promotions = append(promotions, copyPromotion(promotion))
+		}
+	}
+
+	sort.Slice(promotions, func(i, j int) bool {
+		return promotions[i].ID < promotions[j].ID
+	})
+
+	return promotions
+}
+
+// copyPromotion keeps the stored ProductIDs from being changed through the Promotions handed out by the repository
+func copyPromotion(promotion domain.Promotion) domain.Promotion {
+	promotion.ProductIDs = append([]int{}, promotion.ProductIDs...)
+	return promotion
+}
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type InMemoryPromotionsRepositorySuite struct {
+	suite.Suite
+
+	promotionsRepo *InMemoryPromotionsRepository
+}
+
+func (s *InMemoryPromotionsRepositorySuite) SetupTest() {
+	s.promotionsRepo = NewInMemoryPromotionsRepository(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""))
+}
+
+func (s *InMemoryPromotionsRepositorySuite) create(name string, code string) domain.Promotion {
+	promotion, err := s.promotionsRepo.Create(context.Background(), domain.Promotion{
+		Name:       name,
+		Kind:       domain.PromotionPercentage,
+		Percentage: 10,
+		Code:       code,
+	})
+	s.Require().NoError(err)
+
+	return promotion
+}
+
+func (s *InMemoryPromotionsRepositorySuite) Test_Create() {
+	s.Run("Should fail in case the code is already used", func() {
+		s.SetupTest()
+
+		s.create("Welcome", "WELCOME10")
+
+		_, err := s.promotionsRepo.Create(context.Background(), domain.Promotion{Name: "Another", Code: "WELCOME10"})
+
+		s.Equal(domain.ErrCodeAlreadyUsed, err)
+	})
+
+	s.Run("Should allow many automatic promotions", func() {
+		s.SetupTest()
+
+		first := s.create("Sale", "")
+		second := s.create("Another sale", "")
+
+		s.Equal(1, first.ID)
+		s.Equal(2, second.ID)
+	})
+}
+
+func (s *InMemoryPromotionsRepositorySuite) Test_Update() {
+	s.Run("Should release the previous code of the promotion", func() {
+		s.SetupTest()
+
+		promotion := s.create("Welcome", "WELCOME10")
+		promotion.Code = "HELLO10"
+
+		updated, err := s.promotionsRepo.Update(context.Background(), promotion)
+		s.NoError(err)
+		s.Equal(2, updated.Version)
+
+		reused := s.create("Another welcome", "WELCOME10")
+		s.Equal("WELCOME10", reused.Code)
+	})
+
+	s.Run("Should fail in case the code belongs to another promotion", func() {
+		s.SetupTest()
+
+		s.create("Welcome", "WELCOME10")
+		promotion := s.create("Hello", "HELLO10")
+		promotion.Code = "WELCOME10"
+
+		_, err := s.promotionsRepo.Update(context.Background(), promotion)
+
+		s.Equal(domain.ErrCodeAlreadyUsed, err)
+	})
+
+	s.Run("Should fail in case the promotion changed since it was read", func() {
+		s.SetupTest()
+
+		promotion := s.create("Welcome", "WELCOME10")
+
+		_, err := s.promotionsRepo.Update(context.Background(), promotion)
+		s.NoError(err)
+
+		_, err = s.promotionsRepo.Update(context.Background(), promotion)
+		s.Equal(domain.ErrPromotionVersionConflict, err)
+	})
+}
+
+func (s *InMemoryPromotionsRepositorySuite) Test_Delete() {
+	s.Run("Should release the code of the deleted promotion", func() {
+		s.SetupTest()
+
+		promotion := s.create("Welcome", "WELCOME10")
+
+		s.NoError(s.promotionsRepo.Delete(context.Background(), promotion.ID))
+
+		_, err := s.promotionsRepo.Get(context.Background(), promotion.ID)
+		s.Equal(domain.ErrPromotionNotFound, err)
+
+		s.create("Another welcome", "WELCOME10")
+	})
+}
+
+func TestInMemoryPromotionsRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InMemoryPromotionsRepositorySuite))
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// InMemorySegmentsRepository keeps the segments of the customers in memory.
+type InMemorySegmentsRepository struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	// mu guards segments, indexed by the IDs of the customers
+	mu       sync.Mutex
+	segments map[string][]string
+}
+
+// NewInMemorySegmentsRepository creates a new InMemorySegmentsRepository.
+func NewInMemorySegmentsRepository(logger *zap.Logger, tracer trace.Tracer) *InMemorySegmentsRepository {
+	return &InMemorySegmentsRepository{
+		Logger:   logger,
+		Tracer:   tracer,
+		segments: map[string][]string{},
+	}
+}
+
+// Get fetches the segments of a customer from memory.
+func (r *InMemorySegmentsRepository) Get(ctx context.Context, customerID string) (domain.CustomerSegments, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Get")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return domain.CustomerSegments{
+		CustomerID: customerID,
+		Segments:   append([]string{}, r.segments[customerID]...),
+	}, nil
+}
+
+// Set replaces the segments of a customer in memory, a customer left without any is forgotten.
+func (r *InMemorySegmentsRepository) Set(ctx context.Context, segments domain.CustomerSegments) (domain.CustomerSegments, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Set")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(segments.Segments) == 0 {
+		delete(r.segments, segments.CustomerID)
+		return domain.CustomerSegments{CustomerID: segments.CustomerID, Segments: []string{}}, nil
+	}
+
+	r.segments[segments.CustomerID] = append([]string{}, segments.Segments...)
+
+	return domain.CustomerSegments{
+		CustomerID: segments.CustomerID,
+		Segments:   append([]string{}, segments.Segments...),
+	}, nil
+}
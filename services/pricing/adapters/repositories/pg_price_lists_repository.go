@@ -0,0 +1,212 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"github.com/lucasmls/ecommerce/shared/pg"
+)
+
+const priceListColumns = "id, name, segment, priority, starts_at, ends_at, prices, version, created_at, updated_at"
+
+// activeCondition keeps the rows whose schedule contains the time given as $1
+const activeCondition = "(starts_at IS NULL OR starts_at <= $1) AND (ends_at IS NULL OR ends_at > $1)"
+
+// PgPriceListsRepository keeps the PriceLists on Postgres, each one on a single row along with its prices.
+type PgPriceListsRepository struct {
+	db *sql.DB
+}
+
+func NewPgPriceListsRepository(connectionString string) (*PgPriceListsRepository, error) {
+	db, err := sql.Open(
+		"postgres",
+		connectionString,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	repository := &PgPriceListsRepository{
+		db: db,
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+func MustNewPgPriceListsRepository(connectionString string) *PgPriceListsRepository {
+	repo, err := NewPgPriceListsRepository(connectionString)
+	if err != nil {
+		panic(err)
+	}
+
+	return repo
+}
+
+// Create inserts a new PriceList
+func (r *PgPriceListsRepository) Create(ctx context.Context, priceList domain.PriceList) (domain.PriceList, error) {
+	prices, err := json.Marshal(priceList.Prices)
+	if err != nil {
+		return domain.PriceList{}, err
+	}
+
+	startsAt, endsAt := scheduleColumns(priceList.Schedule)
+
+	row := r.db.QueryRowContext(
+		ctx,
+		`INSERT INTO price_lists (name, segment, priority, starts_at, ends_at, prices, version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, 1, $7, $8)
+		RETURNING `+priceListColumns,
+		priceList.Name, priceList.Segment, priceList.Priority, startsAt, endsAt, prices, priceList.CreatedAt, priceList.UpdatedAt,
+	)
+
+	return scanPriceList(row)
+}
+
+// Get fetches a single PriceList
+func (r *PgPriceListsRepository) Get(ctx context.Context, id int) (domain.PriceList, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+priceListColumns+" FROM price_lists WHERE id = $1", id)
+
+	priceList, err := scanPriceList(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.PriceList{}, domain.ErrPriceListNotFound
+	}
+
+	return priceList, err
+}
+
+// Update replaces a PriceList as long as the stored version matches
+func (r *PgPriceListsRepository) Update(ctx context.Context, priceList domain.PriceList) (domain.PriceList, error) {
+	prices, err := json.Marshal(priceList.Prices)
+	if err != nil {
+		return domain.PriceList{}, err
+	}
+
+	startsAt, endsAt := scheduleColumns(priceList.Schedule)
+
+	row := r.db.QueryRowContext(
+		ctx,
+		`UPDATE price_lists
+		SET name = $3, segment = $4, priority = $5, starts_at = $6, ends_at = $7, prices = $8, version = version + 1, updated_at = $9
+		WHERE id = $1 AND version = $2
+		RETURNING `+priceListColumns,
+		priceList.ID, priceList.Version, priceList.Name, priceList.Segment, priceList.Priority, startsAt, endsAt, prices, priceList.UpdatedAt,
+	)
+
+	updated, err := scanPriceList(row)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return updated, err
+	}
+
+	// nothing was updated, either because the price list doesn't exist or because its version changed
+	if _, err := r.Get(ctx, priceList.ID); err != nil {
+		return domain.PriceList{}, err
+	}
+
+	return domain.PriceList{}, domain.ErrPriceListVersionConflict
+}
+
+// Delete removes a PriceList
+func (r *PgPriceListsRepository) Delete(ctx context.Context, id int) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM price_lists WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return domain.ErrPriceListNotFound
+	}
+
+	return nil
+}
+
+// List fetches every PriceList, the oldest first
+func (r *PgPriceListsRepository) List(ctx context.Context) ([]domain.PriceList, error) {
+	return r.list(ctx, "SELECT "+priceListColumns+" FROM price_lists ORDER BY id")
+}
+
+// ListActive fetches the PriceLists whose Schedule contains the given time
+func (r *PgPriceListsRepository) ListActive(ctx context.Context, at time.Time) ([]domain.PriceList, error) {
+	return r.list(ctx, "SELECT "+priceListColumns+" FROM price_lists WHERE "+activeCondition+" ORDER BY id", at)
+}
+
+func (r *PgPriceListsRepository) list(ctx context.Context, query string, args ...interface{}) ([]domain.PriceList, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	priceLists := []domain.PriceList{}
+	for rows.Next() {
+		priceList, err := scanPriceList(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		priceLists = append(priceLists, priceList)
+	}
+
+	return priceLists, rows.Err()
+}
+
+// scheduleColumns stores the open bounds of a Schedule as NULLs
+func scheduleColumns(schedule domain.Schedule) (sql.NullTime, sql.NullTime) {
+	return sql.NullTime{Time: schedule.StartsAt, Valid: !schedule.StartsAt.IsZero()},
+		sql.NullTime{Time: schedule.EndsAt, Valid: !schedule.EndsAt.IsZero()}
+}
+
+func toDomainSchedule(startsAt sql.NullTime, endsAt sql.NullTime) domain.Schedule {
+	var schedule domain.Schedule
+	if startsAt.Valid {
+		schedule.StartsAt = startsAt.Time
+	}
+
+	if endsAt.Valid {
+		schedule.EndsAt = endsAt.Time
+	}
+
+	return schedule
+}
+
+func scanPriceList(row pg.Scanner) (domain.PriceList, error) {
+	var priceList domain.PriceList
+	var startsAt, endsAt sql.NullTime
+	var prices []byte
+
+	err := row.Scan(
+		&priceList.ID,
+		&priceList.Name,
+		&priceList.Segment,
+		&priceList.Priority,
+		&startsAt,
+		&endsAt,
+		&prices,
+		&priceList.Version,
+		&priceList.CreatedAt,
+		&priceList.UpdatedAt,
+	)
+	if err != nil {
+		return domain.PriceList{}, err
+	}
+
+	if err := json.Unmarshal(prices, &priceList.Prices); err != nil {
+		return domain.PriceList{}, err
+	}
+
+	priceList.Schedule = toDomainSchedule(startsAt, endsAt)
+
+	return priceList, nil
+}
@@ -0,0 +1,216 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"github.com/lucasmls/ecommerce/shared/pg"
+)
+
+const promotionColumns = "id, name, kind, percentage, amount, buy_quantity, get_quantity, product_ids, segment, code, starts_at, ends_at, version, created_at, updated_at"
+
+// PgPromotionsRepository keeps the Promotions on Postgres, each one on a single row.
+// The automatic Promotions store their code as NULL, so the unique index on the codes ignores them.
+type PgPromotionsRepository struct {
+	db *sql.DB
+}
+
+func NewPgPromotionsRepository(connectionString string) (*PgPromotionsRepository, error) {
+	db, err := sql.Open(
+		"postgres",
+		connectionString,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	repository := &PgPromotionsRepository{
+		db: db,
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+func MustNewPgPromotionsRepository(connectionString string) *PgPromotionsRepository {
+	repo, err := NewPgPromotionsRepository(connectionString)
+	if err != nil {
+		panic(err)
+	}
+
+	return repo
+}
+
+// Create inserts a new Promotion
+func (r *PgPromotionsRepository) Create(ctx context.Context, promotion domain.Promotion) (domain.Promotion, error) {
+	startsAt, endsAt := scheduleColumns(promotion.Schedule)
+
+	row := r.db.QueryRowContext(
+		ctx,
+		`INSERT INTO promotions (name, kind, percentage, amount, buy_quantity, get_quantity, product_ids, segment, code, starts_at, ends_at, version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, 1, $12, $13)
+		RETURNING `+promotionColumns,
+		promotion.Name, promotion.Kind, promotion.Percentage, promotion.Amount, promotion.BuyQuantity, promotion.GetQuantity,
+		pq.Array(toInt64s(promotion.ProductIDs)), promotion.Segment, codeColumn(promotion.Code), startsAt, endsAt,
+		promotion.CreatedAt, promotion.UpdatedAt,
+	)
+
+	created, err := scanPromotion(row)
+	if pg.IsUniqueViolation(err) {
+		return domain.Promotion{}, domain.ErrCodeAlreadyUsed
+	}
+
+	return created, err
+}
+
+// Get fetches a single Promotion
+func (r *PgPromotionsRepository) Get(ctx context.Context, id int) (domain.Promotion, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+promotionColumns+" FROM promotions WHERE id = $1", id)
+
+	promotion, err := scanPromotion(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Promotion{}, domain.ErrPromotionNotFound
+	}
+
+	return promotion, err
+}
+
+// Update replaces a Promotion as long as the stored version matches
+func (r *PgPromotionsRepository) Update(ctx context.Context, promotion domain.Promotion) (domain.Promotion, error) {
+	startsAt, endsAt := scheduleColumns(promotion.Schedule)
+
+	row := r.db.QueryRowContext(
+		ctx,
+		`UPDATE promotions
+		SET name = $3, kind = $4, percentage = $5, amount = $6, buy_quantity = $7, get_quantity = $8, product_ids = $9,
+			segment = $10, code = $11, starts_at = $12, ends_at = $13, version = version + 1, updated_at = $14
+		WHERE id = $1 AND version = $2
+		RETURNING `+promotionColumns,
+		promotion.ID, promotion.Version, promotion.Name, promotion.Kind, promotion.Percentage, promotion.Amount,
+		promotion.BuyQuantity, promotion.GetQuantity, pq.Array(toInt64s(promotion.ProductIDs)), promotion.Segment,
+		codeColumn(promotion.Code), startsAt, endsAt, promotion.UpdatedAt,
+	)
+
+	updated, err := scanPromotion(row)
+	if pg.IsUniqueViolation(err) {
+		return domain.Promotion{}, domain.ErrCodeAlreadyUsed
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return updated, err
+	}
+
+	// nothing was updated, either because the promotion doesn't exist or because its version changed
+	if _, err := r.Get(ctx, promotion.ID); err != nil {
+		return domain.Promotion{}, err
+	}
+
+	return domain.Promotion{}, domain.ErrPromotionVersionConflict
+}
+
+// Delete removes a Promotion
+func (r *PgPromotionsRepository) Delete(ctx context.Context, id int) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM promotions WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return domain.ErrPromotionNotFound
+	}
+
+	return nil
+}
+
+// List fetches every Promotion, the oldest first
+func (r *PgPromotionsRepository) List(ctx context.Context) ([]domain.Promotion, error) {
+	return r.list(ctx, "SELECT "+promotionColumns+" FROM promotions ORDER BY id")
+}
+
+// ListActive fetches the Promotions whose Schedule contains the given time
+func (r *PgPromotionsRepository) ListActive(ctx context.Context, at time.Time) ([]domain.Promotion, error) {
+	return r.list(ctx, "SELECT "+promotionColumns+" FROM promotions WHERE "+activeCondition+" ORDER BY id", at)
+}
+
+func (r *PgPromotionsRepository) list(ctx context.Context, query string, args ...interface{}) ([]domain.Promotion, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	promotions := []domain.Promotion{}
+	for rows.Next() {
+		promotion, err := scanPromotion(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		promotions = append(promotions, promotion)
+	}
+
+	return promotions, rows.Err()
+}
+
+func codeColumn(code string) sql.NullString {
+	return sql.NullString{String: code, Valid: code != ""}
+}
+
+func toInt64s(ids []int) []int64 {
+	response := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		response = append(response, int64(id))
+	}
+
+	return response
+}
+
+func scanPromotion(row pg.Scanner) (domain.Promotion, error) {
+	var promotion domain.Promotion
+	var productIDs []int64
+	var code sql.NullString
+	var startsAt, endsAt sql.NullTime
+
+	err := row.Scan(
+		&promotion.ID,
+		&promotion.Name,
+		&promotion.Kind,
+		&promotion.Percentage,
+		&promotion.Amount,
+		&promotion.BuyQuantity,
+		&promotion.GetQuantity,
+		pq.Array(&productIDs),
+		&promotion.Segment,
+		&code,
+		&startsAt,
+		&endsAt,
+		&promotion.Version,
+		&promotion.CreatedAt,
+		&promotion.UpdatedAt,
+	)
+	if err != nil {
+		return domain.Promotion{}, err
+	}
+
+	promotion.ProductIDs = make([]int, 0, len(productIDs))
+	for _, id := range productIDs {
+		promotion.ProductIDs = append(promotion.ProductIDs, int(id))
+	}
+
+	promotion.Code = code.String
+	promotion.Schedule = toDomainSchedule(startsAt, endsAt)
+
+	return promotion, nil
+}
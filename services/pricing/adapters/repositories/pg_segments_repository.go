@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+)
+
+// PgSegmentsRepository keeps the segments of the customers on Postgres, a row per customer belonging to any.
+type PgSegmentsRepository struct {
+	db *sql.DB
+}
+
+func NewPgSegmentsRepository(connectionString string) (*PgSegmentsRepository, error) {
+	db, err := sql.Open(
+		"postgres",
+		connectionString,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	repository := &PgSegmentsRepository{
+		db: db,
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+func MustNewPgSegmentsRepository(connectionString string) *PgSegmentsRepository {
+	repo, err := NewPgSegmentsRepository(connectionString)
+	if err != nil {
+		panic(err)
+	}
+
+	return repo
+}
+
+// Get fetches the segments of a customer
+func (r *PgSegmentsRepository) Get(ctx context.Context, customerID string) (domain.CustomerSegments, error) {
+	segments := []string{}
+
+	row := r.db.QueryRowContext(ctx, "SELECT segments FROM customer_segments WHERE customer_id = $1", customerID)
+
+	err := row.Scan(pq.Array(&segments))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return domain.CustomerSegments{}, err
+	}
+
+	return domain.CustomerSegments{CustomerID: customerID, Segments: segments}, nil
+}
+
+// Set replaces the segments of a customer, the row of a customer left without any is deleted
+func (r *PgSegmentsRepository) Set(ctx context.Context, segments domain.CustomerSegments) (domain.CustomerSegments, error) {
+	if len(segments.Segments) == 0 {
+		if _, err := r.db.ExecContext(ctx, "DELETE FROM customer_segments WHERE customer_id = $1", segments.CustomerID); err != nil {
+			return domain.CustomerSegments{}, err
+		}
+
+		return domain.CustomerSegments{CustomerID: segments.CustomerID, Segments: []string{}}, nil
+	}
+
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO customer_segments (customer_id, segments) VALUES ($1, $2)
+		ON CONFLICT (customer_id) DO UPDATE SET segments = EXCLUDED.segments`,
+		segments.CustomerID, pq.Array(segments.Segments),
+	)
+	if err != nil {
+		return domain.CustomerSegments{}, err
+	}
+
+	return segments, nil
+}
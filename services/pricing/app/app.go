@@ -0,0 +1,47 @@
+package app
+
+import (
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// application holds all dependencies the Application needs to work
+type application struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	PriceListsRepository domain.PriceListsRepository
+	PromotionsRepository domain.PromotionsRepository
+	SegmentsRepository   domain.SegmentsRepository
+}
+
+// NewApplication creates a new Application instance
+func NewApplication(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	priceListsRepository domain.PriceListsRepository,
+	promotionsRepository domain.PromotionsRepository,
+	segmentsRepository domain.SegmentsRepository,
+) application {
+	return application{
+		Logger:               logger,
+		Tracer:               tracer,
+		PriceListsRepository: priceListsRepository,
+		PromotionsRepository: promotionsRepository,
+		SegmentsRepository:   segmentsRepository,
+	}
+}
+
+// MustNewApplication creates a new Application instance
+// It panics if any error is found
+func MustNewApplication(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	priceListsRepository domain.PriceListsRepository,
+	promotionsRepository domain.PromotionsRepository,
+	segmentsRepository domain.SegmentsRepository,
+) application {
+	app := NewApplication(logger, tracer, priceListsRepository, promotionsRepository, segmentsRepository)
+	return app
+}
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) CreatePriceList(ctx context.Context, priceList domain.PriceList) (domain.PriceList, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.CreatePriceList")
+	defer span.End()
+
+	priceList = priceList.Normalize()
+
+	a.Logger.Info("creating a price list", zap.String("name", priceList.Name))
+
+	if err := priceList.Validate(); err != nil {
+		return domain.PriceList{}, err
+	}
+
+	now := time.Now()
+	priceList.CreatedAt = now
+	priceList.UpdatedAt = now
+
+	return a.PriceListsRepository.Create(ctx, priceList)
+}
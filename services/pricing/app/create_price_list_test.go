@@ -0,0 +1,105 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"github.com/lucasmls/ecommerce/services/pricing/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type CreatePriceListSuite struct {
+	suite.Suite
+
+	priceListsRepo *mocks.PriceListsRepository
+	app            domain.Application
+}
+
+func (s *CreatePriceListSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.priceListsRepo = &mocks.PriceListsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.priceListsRepo, &mocks.PromotionsRepository{}, &mocks.SegmentsRepository{})
+}
+
+func (s *CreatePriceListSuite) Test_CreatePriceList() {
+	s.Run("Should fail without reaching the repository in case the price list has no prices", func() {
+		s.SetupTest()
+
+		list := priceList()
+		list.Prices = map[int]int{}
+
+		_, err := s.app.CreatePriceList(context.Background(), list)
+
+		s.Equal(domain.ErrInvalidPriceList, err)
+		s.priceListsRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the schedule ends before it starts", func() {
+		s.SetupTest()
+
+		list := priceList()
+		list.StartsAt, list.EndsAt = list.EndsAt, list.StartsAt
+
+		_, err := s.app.CreatePriceList(context.Background(), list)
+
+		s.Equal(domain.ErrInvalidSchedule, err)
+	})
+
+	s.Run("Should fail in case the segment is invalid", func() {
+		s.SetupTest()
+
+		list := priceList()
+		list.Segment = "vip customers"
+
+		_, err := s.app.CreatePriceList(context.Background(), list)
+
+		s.Equal(domain.ErrInvalidSegment, err)
+	})
+
+	s.Run("Should fail in case the repository fails", func() {
+		s.SetupTest()
+
+		s.priceListsRepo.On("Create", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.PriceList")).Return(domain.PriceList{}, errors.New("connection refused")).Once()
+
+		_, err := s.app.CreatePriceList(context.Background(), priceList())
+
+		s.EqualError(err, "connection refused")
+	})
+
+	s.Run("Should store the normalized price list", func() {
+		s.SetupTest()
+
+		list := priceList()
+		list.Name = "  Black Friday  "
+		list.Segment = " VIP "
+
+		s.priceListsRepo.
+			On("Create", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(list domain.PriceList) bool {
+				return list.Name == "Black Friday" && list.Segment == "vip" && !list.CreatedAt.IsZero() && list.CreatedAt.Equal(list.UpdatedAt)
+			})).
+			Return(func(_ context.Context, list domain.PriceList) domain.PriceList {
+				list.ID = 1
+				list.Version = 1
+				return list
+			}, nil).
+			Once()
+
+		got, err := s.app.CreatePriceList(context.Background(), list)
+
+		s.NoError(err)
+		s.Equal(1, got.ID)
+		s.Equal("vip", got.Segment)
+	})
+}
+
+func TestCreatePriceListSuite(t *testing.T) {
+	suite.Run(t, new(CreatePriceListSuite))
+}
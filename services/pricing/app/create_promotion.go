@@ -0,0 +1,28 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) CreatePromotion(ctx context.Context, promotion domain.Promotion) (domain.Promotion, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.CreatePromotion")
+	defer span.End()
+
+	promotion = promotion.Normalize()
+
+	a.Logger.Info("creating a promotion", zap.String("name", promotion.Name), zap.String("code", promotion.Code))
+
+	if err := promotion.Validate(); err != nil {
+		return domain.Promotion{}, err
+	}
+
+	now := time.Now()
+	promotion.CreatedAt = now
+	promotion.UpdatedAt = now
+
+	return a.PromotionsRepository.Create(ctx, promotion)
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"github.com/lucasmls/ecommerce/services/pricing/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type CreatePromotionSuite struct {
+	suite.Suite
+
+	promotionsRepo *mocks.PromotionsRepository
+	app            domain.Application
+}
+
+func (s *CreatePromotionSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.promotionsRepo = &mocks.PromotionsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, &mocks.PriceListsRepository{}, s.promotionsRepo, &mocks.SegmentsRepository{})
+}
+
+func (s *CreatePromotionSuite) Test_CreatePromotion() {
+	invalid := map[string]func(*domain.Promotion){
+		"the kind is unknown":                  func(p *domain.Promotion) { p.Kind = "free_shipping" },
+		"the percentage is over 100":           func(p *domain.Promotion) { p.Percentage = 101 },
+		"the fixed amount is missing":          func(p *domain.Promotion) { p.Kind = domain.PromotionFixedAmount },
+		"the buy x get y quantities are empty": func(p *domain.Promotion) { p.Kind = domain.PromotionBuyXGetY },
+		"a product id is invalid":              func(p *domain.Promotion) { p.ProductIDs = []int{1, 0} },
+	}
+
+	for name, invalidate := range invalid {
+		s.Run("Should fail without reaching the repository in case "+name, func() {
+			s.SetupTest()
+
+			p := promotion()
+			invalidate(&p)
+
+			_, err := s.app.CreatePromotion(context.Background(), p)
+
+			s.Equal(domain.ErrInvalidPromotion, err)
+			s.promotionsRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+		})
+	}
+
+	s.Run("Should fail in case the code has invalid characters", func() {
+		s.SetupTest()
+
+		p := promotion()
+		p.Code = "TEN OFF"
+
+		_, err := s.app.CreatePromotion(context.Background(), p)
+
+		s.Equal(domain.ErrInvalidCode, err)
+	})
+
+	s.Run("Should fail in case the code is used by another promotion", func() {
+		s.SetupTest()
+
+		s.promotionsRepo.On("Create", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Promotion")).Return(domain.Promotion{}, domain.ErrCodeAlreadyUsed).Once()
+
+		_, err := s.app.CreatePromotion(context.Background(), promotion())
+
+		s.Equal(domain.ErrCodeAlreadyUsed, err)
+	})
+
+	s.Run("Should store the promotion with its code in upper case", func() {
+		s.SetupTest()
+
+		p := promotion()
+		p.Code = " tenoff "
+
+		s.promotionsRepo.
+			On("Create", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(p domain.Promotion) bool {
+				return p.Code == "TENOFF" && !p.CreatedAt.IsZero()
+			})).
+			Return(func(_ context.Context, p domain.Promotion) domain.Promotion {
+				p.ID = 1
+				p.Version = 1
+				return p
+			}, nil).
+			Once()
+
+		got, err := s.app.CreatePromotion(context.Background(), p)
+
+		s.NoError(err)
+		s.Equal(1, got.ID)
+		s.Equal("TENOFF", got.Code)
+	})
+}
+
+func TestCreatePromotionSuite(t *testing.T) {
+	suite.Run(t, new(CreatePromotionSuite))
+}
@@ -0,0 +1,21 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) DeletePriceList(ctx context.Context, id int) error {
+	ctx, span := a.Tracer.Start(ctx, "app.DeletePriceList")
+	defer span.End()
+
+	a.Logger.Info("deleting a price list", zap.Int("id", id))
+
+	if id <= 0 {
+		return domain.ErrPriceListNotFound
+	}
+
+	return a.PriceListsRepository.Delete(ctx, id)
+}
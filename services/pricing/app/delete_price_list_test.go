@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"github.com/lucasmls/ecommerce/services/pricing/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type DeletePriceListSuite struct {
+	suite.Suite
+
+	priceListsRepo *mocks.PriceListsRepository
+	app            domain.Application
+}
+
+func (s *DeletePriceListSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.priceListsRepo = &mocks.PriceListsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.priceListsRepo, &mocks.PromotionsRepository{}, &mocks.SegmentsRepository{})
+}
+
+func (s *DeletePriceListSuite) Test_DeletePriceList() {
+	s.Run("Should fail without reaching the repository in case the id is invalid", func() {
+		err := s.app.DeletePriceList(context.Background(), 0)
+
+		s.Equal(domain.ErrPriceListNotFound, err)
+		s.priceListsRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the price list doesn't exist", func() {
+		s.priceListsRepo.On("Delete", mock.AnythingOfType("*context.valueCtx"), 7).Return(domain.ErrPriceListNotFound).Once()
+
+		err := s.app.DeletePriceList(context.Background(), 7)
+
+		s.Equal(domain.ErrPriceListNotFound, err)
+	})
+
+	s.Run("Should delete the price list", func() {
+		s.priceListsRepo.On("Delete", mock.AnythingOfType("*context.valueCtx"), 1).Return(nil).Once()
+
+		err := s.app.DeletePriceList(context.Background(), 1)
+
+		s.NoError(err)
+	})
+}
+
+func TestDeletePriceListSuite(t *testing.T) {
+	suite.Run(t, new(DeletePriceListSuite))
+}
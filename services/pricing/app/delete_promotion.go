@@ -0,0 +1,21 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) DeletePromotion(ctx context.Context, id int) error {
+	ctx, span := a.Tracer.Start(ctx, "app.DeletePromotion")
+	defer span.End()
+
+	a.Logger.Info("deleting a promotion", zap.Int("id", id))
+
+	if id <= 0 {
+		return domain.ErrPromotionNotFound
+	}
+
+	return a.PromotionsRepository.Delete(ctx, id)
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"github.com/lucasmls/ecommerce/services/pricing/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type DeletePromotionSuite struct {
+	suite.Suite
+
+	promotionsRepo *mocks.PromotionsRepository
+	app            domain.Application
+}
+
+func (s *DeletePromotionSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.promotionsRepo = &mocks.PromotionsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, &mocks.PriceListsRepository{}, s.promotionsRepo, &mocks.SegmentsRepository{})
+}
+
+func (s *DeletePromotionSuite) Test_DeletePromotion() {
+	s.Run("Should fail without reaching the repository in case the id is invalid", func() {
+		err := s.app.DeletePromotion(context.Background(), 0)
+
+		s.Equal(domain.ErrPromotionNotFound, err)
+		s.promotionsRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the promotion doesn't exist", func() {
+		s.promotionsRepo.On("Delete", mock.AnythingOfType("*context.valueCtx"), 7).Return(domain.ErrPromotionNotFound).Once()
+
+		err := s.app.DeletePromotion(context.Background(), 7)
+
+		s.Equal(domain.ErrPromotionNotFound, err)
+	})
+
+	s.Run("Should delete the promotion", func() {
+		s.promotionsRepo.On("Delete", mock.AnythingOfType("*context.valueCtx"), 1).Return(nil).Once()
+
+		err := s.app.DeletePromotion(context.Background(), 1)
+
+		s.NoError(err)
+	})
+}
+
+func TestDeletePromotionSuite(t *testing.T) {
+	suite.Run(t, new(DeletePromotionSuite))
+}
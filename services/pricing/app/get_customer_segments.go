@@ -0,0 +1,21 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) GetCustomerSegments(ctx context.Context, customerID string) (domain.CustomerSegments, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.GetCustomerSegments")
+	defer span.End()
+
+	a.Logger.Info("getting the segments of a customer", zap.String("customerId", customerID))
+
+	if customerID == "" {
+		return domain.CustomerSegments{}, domain.ErrInvalidSegment
+	}
+
+	return a.SegmentsRepository.Get(ctx, customerID)
+}
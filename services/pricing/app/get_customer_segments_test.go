@@ -0,0 +1,52 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"github.com/lucasmls/ecommerce/services/pricing/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type GetCustomerSegmentsSuite struct {
+	suite.Suite
+
+	segmentsRepo *mocks.SegmentsRepository
+	app          domain.Application
+}
+
+func (s *GetCustomerSegmentsSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.segmentsRepo = &mocks.SegmentsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, &mocks.PriceListsRepository{}, &mocks.PromotionsRepository{}, s.segmentsRepo)
+}
+
+func (s *GetCustomerSegmentsSuite) Test_GetCustomerSegments() {
+	s.Run("Should fail without reaching the repository in case the customer is missing", func() {
+		_, err := s.app.GetCustomerSegments(context.Background(), "")
+
+		s.Equal(domain.ErrInvalidSegment, err)
+		s.segmentsRepo.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should return the segments of the customer", func() {
+		segments := domain.CustomerSegments{CustomerID: customerID, Segments: []string{"vip"}}
+		s.segmentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(segments, nil).Once()
+
+		got, err := s.app.GetCustomerSegments(context.Background(), customerID)
+
+		s.NoError(err)
+		s.Equal(segments, got)
+	})
+}
+
+func TestGetCustomerSegmentsSuite(t *testing.T) {
+	suite.Run(t, new(GetCustomerSegmentsSuite))
+}
@@ -0,0 +1,21 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) GetPriceList(ctx context.Context, id int) (domain.PriceList, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.GetPriceList")
+	defer span.End()
+
+	a.Logger.Info("getting a price list", zap.Int("id", id))
+
+	if id <= 0 {
+		return domain.PriceList{}, domain.ErrPriceListNotFound
+	}
+
+	return a.PriceListsRepository.Get(ctx, id)
+}
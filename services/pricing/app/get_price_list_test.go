@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"github.com/lucasmls/ecommerce/services/pricing/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type GetPriceListSuite struct {
+	suite.Suite
+
+	priceListsRepo *mocks.PriceListsRepository
+	app            domain.Application
+}
+
+func (s *GetPriceListSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.priceListsRepo = &mocks.PriceListsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.priceListsRepo, &mocks.PromotionsRepository{}, &mocks.SegmentsRepository{})
+}
+
+func (s *GetPriceListSuite) Test_GetPriceList() {
+	s.Run("Should fail without reaching the repository in case the id is invalid", func() {
+		_, err := s.app.GetPriceList(context.Background(), -1)
+
+		s.Equal(domain.ErrPriceListNotFound, err)
+		s.priceListsRepo.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the price list doesn't exist", func() {
+		s.priceListsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), 7).Return(domain.PriceList{}, domain.ErrPriceListNotFound).Once()
+
+		_, err := s.app.GetPriceList(context.Background(), 7)
+
+		s.Equal(domain.ErrPriceListNotFound, err)
+	})
+
+	s.Run("Should return the price list", func() {
+		s.priceListsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), 1).Return(storedPriceList(), nil).Once()
+
+		got, err := s.app.GetPriceList(context.Background(), 1)
+
+		s.NoError(err)
+		s.Equal(storedPriceList(), got)
+	})
+}
+
+func TestGetPriceListSuite(t *testing.T) {
+	suite.Run(t, new(GetPriceListSuite))
+}
@@ -0,0 +1,21 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) GetPromotion(ctx context.Context, id int) (domain.Promotion, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.GetPromotion")
+	defer span.End()
+
+	a.Logger.Info("getting a promotion", zap.Int("id", id))
+
+	if id <= 0 {
+		return domain.Promotion{}, domain.ErrPromotionNotFound
+	}
+
+	return a.PromotionsRepository.Get(ctx, id)
+}
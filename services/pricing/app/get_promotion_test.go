@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"github.com/lucasmls/ecommerce/services/pricing/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type GetPromotionSuite struct {
+	suite.Suite
+
+	promotionsRepo *mocks.PromotionsRepository
+	app            domain.Application
+}
+
+func (s *GetPromotionSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.promotionsRepo = &mocks.PromotionsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, &mocks.PriceListsRepository{}, s.promotionsRepo, &mocks.SegmentsRepository{})
+}
+
+func (s *GetPromotionSuite) Test_GetPromotion() {
+	s.Run("Should fail without reaching the repository in case the id is invalid", func() {
+		_, err := s.app.GetPromotion(context.Background(), -1)
+
+		s.Equal(domain.ErrPromotionNotFound, err)
+		s.promotionsRepo.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the promotion doesn't exist", func() {
+		s.promotionsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), 7).Return(domain.Promotion{}, domain.ErrPromotionNotFound).Once()
+
+		_, err := s.app.GetPromotion(context.Background(), 7)
+
+		s.Equal(domain.ErrPromotionNotFound, err)
+	})
+
+	s.Run("Should return the promotion", func() {
+		s.promotionsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), 1).Return(storedPromotion(), nil).Once()
+
+		got, err := s.app.GetPromotion(context.Background(), 1)
+
+		s.NoError(err)
+		s.Equal(storedPromotion(), got)
+	})
+}
+
+func TestGetPromotionSuite(t *testing.T) {
+	suite.Run(t, new(GetPromotionSuite))
+}
@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+)
+
+const customerID = "0123456789abcdef0123456789abcdef"
+
+// createdAt is when the stored PriceLists and Promotions were created
+var createdAt = time.Date(2022, 11, 1, 10, 0, 0, 0, time.UTC)
+
+// priceList is a valid PriceList that was never stored
+func priceList() domain.PriceList {
+	return domain.PriceList{
+		Name:     "Black Friday",
+		Priority: 10,
+		Schedule: domain.Schedule{
+			StartsAt: time.Date(2022, 11, 25, 0, 0, 0, 0, time.UTC),
+			EndsAt:   time.Date(2022, 11, 28, 0, 0, 0, 0, time.UTC),
+		},
+		Prices: map[int]int{1: 1500, 2: 900},
+	}
+}
+
+// storedPriceList is the priceList once stored
+func storedPriceList() domain.PriceList {
+	list := priceList()
+	list.ID = 1
+	list.Version = 1
+	list.CreatedAt = createdAt
+	list.UpdatedAt = createdAt
+
+	return list
+}
+
+// promotion is a valid Promotion that was never stored
+func promotion() domain.Promotion {
+	return domain.Promotion{
+		Name:       "Ten off",
+		Kind:       domain.PromotionPercentage,
+		Percentage: 10,
+		ProductIDs: []int{1},
+		Code:       "TENOFF",
+	}
+}
+
+// storedPromotion is the promotion once stored
+func storedPromotion() domain.Promotion {
+	p := promotion()
+	p.ID = 1
+	p.Version = 1
+	p.CreatedAt = createdAt
+	p.UpdatedAt = createdAt
+
+	return p
+}
+
+// bumpPriceListVersion is what the repository does to a PriceList it updates
+func bumpPriceListVersion(_ context.Context, list domain.PriceList) domain.PriceList {
+	list.Version++
+	return list
+}
+
+// bumpPromotionVersion is what the repository does to a Promotion it updates
+func bumpPromotionVersion(_ context.Context, p domain.Promotion) domain.Promotion {
+	p.Version++
+	return p
+}
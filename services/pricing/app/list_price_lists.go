@@ -0,0 +1,16 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+)
+
+func (a application) ListPriceLists(ctx context.Context) ([]domain.PriceList, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.ListPriceLists")
+	defer span.End()
+
+	a.Logger.Info("listing the price lists")
+
+	return a.PriceListsRepository.List(ctx)
+}
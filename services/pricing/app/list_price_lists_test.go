@@ -0,0 +1,53 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"github.com/lucasmls/ecommerce/services/pricing/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ListPriceListsSuite struct {
+	suite.Suite
+
+	priceListsRepo *mocks.PriceListsRepository
+	app            domain.Application
+}
+
+func (s *ListPriceListsSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.priceListsRepo = &mocks.PriceListsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.priceListsRepo, &mocks.PromotionsRepository{}, &mocks.SegmentsRepository{})
+}
+
+func (s *ListPriceListsSuite) Test_ListPriceLists() {
+	s.Run("Should fail in case the repository fails", func() {
+		s.priceListsRepo.On("List", mock.AnythingOfType("*context.valueCtx")).Return(nil, errors.New("connection refused")).Once()
+
+		_, err := s.app.ListPriceLists(context.Background())
+
+		s.EqualError(err, "connection refused")
+	})
+
+	s.Run("Should return every price list", func() {
+		s.priceListsRepo.On("List", mock.AnythingOfType("*context.valueCtx")).Return([]domain.PriceList{storedPriceList()}, nil).Once()
+
+		got, err := s.app.ListPriceLists(context.Background())
+
+		s.NoError(err)
+		s.Equal([]domain.PriceList{storedPriceList()}, got)
+	})
+}
+
+func TestListPriceListsSuite(t *testing.T) {
+	suite.Run(t, new(ListPriceListsSuite))
+}
@@ -0,0 +1,16 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+)
+
+func (a application) ListPromotions(ctx context.Context) ([]domain.Promotion, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.ListPromotions")
+	defer span.End()
+
+	a.Logger.Info("listing the promotions")
+
+	return a.PromotionsRepository.List(ctx)
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"github.com/lucasmls/ecommerce/services/pricing/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ListPromotionsSuite struct {
+	suite.Suite
+
+	promotionsRepo *mocks.PromotionsRepository
+	app            domain.Application
+}
+
+func (s *ListPromotionsSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.promotionsRepo = &mocks.PromotionsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, &mocks.PriceListsRepository{}, s.promotionsRepo, &mocks.SegmentsRepository{})
+}
+
+func (s *ListPromotionsSuite) Test_ListPromotions() {
+	s.Run("Should fail in case the repository fails", func() {
+		s.promotionsRepo.On("List", mock.AnythingOfType("*context.valueCtx")).Return(nil, errors.New("connection refused")).Once()
+
+		_, err := s.app.ListPromotions(context.Background())
+
+		s.EqualError(err, "connection refused")
+	})
+
+	s.Run("Should return every promotion", func() {
+		s.promotionsRepo.On("List", mock.AnythingOfType("*context.valueCtx")).Return([]domain.Promotion{storedPromotion()}, nil).Once()
+
+		got, err := s.app.ListPromotions(context.Background())
+
+		s.NoError(err)
+		s.Equal([]domain.Promotion{storedPromotion()}, got)
+	})
+}
+
+func TestListPromotionsSuite(t *testing.T) {
+	suite.Run(t, new(ListPromotionsSuite))
+}
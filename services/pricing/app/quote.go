@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) Quote(ctx context.Context, request domain.QuoteRequest) (domain.Quote, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.Quote")
+	defer span.End()
+
+	a.Logger.Info("quoting the prices", zap.String("customerId", request.CustomerID), zap.Int("lines", len(request.Lines)))
+
+	if err := request.Validate(); err != nil {
+		return domain.Quote{}, err
+	}
+
+	if request.At.IsZero() {
+		request.At = time.Now()
+	}
+
+	// the guests belong to no segment
+	segments := domain.CustomerSegments{}
+	if request.CustomerID != "" {
+		customerSegments, err := a.SegmentsRepository.Get(ctx, request.CustomerID)
+		if err != nil {
+			return domain.Quote{}, err
+		}
+
+		segments = customerSegments
+	}
+
+	priceLists, err := a.PriceListsRepository.ListActive(ctx, request.At)
+	if err != nil {
+		return domain.Quote{}, err
+	}
+
+	promotions, err := a.PromotionsRepository.ListActive(ctx, request.At)
+	if err != nil {
+		return domain.Quote{}, err
+	}
+
+	return domain.NewQuote(request, segments, priceLists, promotions), nil
+}
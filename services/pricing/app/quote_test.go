@@ -0,0 +1,335 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"github.com/lucasmls/ecommerce/services/pricing/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// quotedAt is when the prices are quoted, during the Black Friday of the priceList
+var quotedAt = time.Date(2022, 11, 26, 12, 0, 0, 0, time.UTC)
+
+type QuoteSuite struct {
+	suite.Suite
+
+	priceListsRepo *mocks.PriceListsRepository
+	promotionsRepo *mocks.PromotionsRepository
+	segmentsRepo   *mocks.SegmentsRepository
+	app            domain.Application
+}
+
+func (s *QuoteSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.priceListsRepo = &mocks.PriceListsRepository{}
+	s.promotionsRepo = &mocks.PromotionsRepository{}
+	s.segmentsRepo = &mocks.SegmentsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.priceListsRepo, s.promotionsRepo, s.segmentsRepo)
+}
+
+// stored makes the repositories return the given active PriceLists and Promotions, and the given segments of the customer
+func (s *QuoteSuite) stored(priceLists []domain.PriceList, promotions []domain.Promotion, segments ...string) {
+	s.segmentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(domain.CustomerSegments{CustomerID: customerID, Segments: segments}, nil).Maybe()
+	s.priceListsRepo.On("ListActive", mock.AnythingOfType("*context.valueCtx"), quotedAt).Return(priceLists, nil).Once()
+	s.promotionsRepo.On("ListActive", mock.AnythingOfType("*context.valueCtx"), quotedAt).Return(promotions, nil).Once()
+}
+
+// request quotes 2 units of the Product 1, whose base price is 2000, for the customer
+func request(codes ...string) domain.QuoteRequest {
+	return domain.QuoteRequest{
+		CustomerID: customerID,
+		Codes:      codes,
+		At:         quotedAt,
+		Lines:      []domain.LineRequest{{ProductID: 1, Quantity: 2, UnitPrice: 2000}},
+	}
+}
+
+func (s *QuoteSuite) Test_Quote() {
+	s.Run("Should fail without reaching the repositories in case the request has no lines", func() {
+		s.SetupTest()
+
+		r := request()
+		r.Lines = nil
+
+		_, err := s.app.Quote(context.Background(), r)
+
+		s.Equal(domain.ErrInvalidQuote, err)
+		s.priceListsRepo.AssertNotCalled(s.T(), "ListActive", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case a code has invalid characters", func() {
+		s.SetupTest()
+
+		_, err := s.app.Quote(context.Background(), request("TEN OFF"))
+
+		s.Equal(domain.ErrInvalidCode, err)
+	})
+
+	s.Run("Should fail in case the repository fails", func() {
+		s.SetupTest()
+
+		s.segmentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), customerID).Return(domain.CustomerSegments{}, nil).Once()
+		s.priceListsRepo.On("ListActive", mock.AnythingOfType("*context.valueCtx"), quotedAt).Return(nil, errors.New("connection refused")).Once()
+
+		_, err := s.app.Quote(context.Background(), request())
+
+		s.EqualError(err, "connection refused")
+	})
+
+	s.Run("Should quote the current prices in case no time is told", func() {
+		s.SetupTest()
+
+		r := request()
+		r.CustomerID = ""
+		r.At = time.Time{}
+
+		now := mock.MatchedBy(func(at time.Time) bool { return time.Since(at) < time.Minute })
+		s.priceListsRepo.On("ListActive", mock.AnythingOfType("*context.valueCtx"), now).Return([]domain.PriceList{}, nil).Once()
+		s.promotionsRepo.On("ListActive", mock.AnythingOfType("*context.valueCtx"), now).Return([]domain.Promotion{}, nil).Once()
+
+		got, err := s.app.Quote(context.Background(), r)
+
+		s.NoError(err)
+		s.False(got.At.IsZero())
+		s.segmentsRepo.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should keep the base prices in case nothing applies", func() {
+		s.SetupTest()
+		s.stored([]domain.PriceList{}, []domain.Promotion{})
+
+		got, err := s.app.Quote(context.Background(), request())
+
+		s.NoError(err)
+		s.Equal(domain.Quote{
+			Lines: []domain.QuoteLine{
+				{ProductID: 1, Quantity: 2, BaseUnitPrice: 2000, UnitPrice: 2000, Subtotal: 4000, Total: 4000, Adjustments: []domain.Adjustment{}},
+			},
+			Subtotal:      4000,
+			Total:         4000,
+			AppliedCodes:  []string{},
+			RejectedCodes: []string{},
+			At:            quotedAt,
+		}, got)
+	})
+
+	s.Run("Should apply the price list with the highest priority", func() {
+		s.SetupTest()
+
+		low := storedPriceList()
+		low.ID = 1
+		low.Priority = 1
+		low.Prices = map[int]int{1: 1000}
+
+		high := storedPriceList()
+		high.ID = 2
+		high.Name = "Members"
+		high.Priority = 5
+		high.Prices = map[int]int{1: 1500}
+
+		s.stored([]domain.PriceList{low, high}, []domain.Promotion{})
+
+		got, err := s.app.Quote(context.Background(), request())
+
+		s.NoError(err)
+		s.Equal(1500, got.Lines[0].UnitPrice)
+		s.Equal([]domain.Adjustment{
+			{Source: domain.AdjustmentPriceList, SourceID: 2, Description: "Members", Amount: 1000},
+		}, got.Lines[0].Adjustments)
+		s.Equal(3000, got.Total)
+	})
+
+	s.Run("Should never raise the price of a product", func() {
+		s.SetupTest()
+
+		higher := storedPriceList()
+		higher.Priority = 5
+		higher.Prices = map[int]int{1: 2500}
+
+		lower := storedPriceList()
+		lower.ID = 2
+		lower.Priority = 1
+		lower.Prices = map[int]int{1: 1800}
+
+		s.stored([]domain.PriceList{higher, lower}, []domain.Promotion{})
+
+		got, err := s.app.Quote(context.Background(), request())
+
+		s.NoError(err)
+		s.Equal(1800, got.Lines[0].UnitPrice)
+		s.Equal(2, got.Lines[0].Adjustments[0].SourceID)
+	})
+
+	s.Run("Should apply the prices of a segment only to the customers belonging to it", func() {
+		s.SetupTest()
+
+		vip := storedPriceList()
+		vip.Segment = "vip"
+
+		s.stored([]domain.PriceList{vip}, []domain.Promotion{}, "wholesale")
+
+		got, err := s.app.Quote(context.Background(), request())
+
+		s.NoError(err)
+		s.Equal(2000, got.Lines[0].UnitPrice)
+
+		s.SetupTest()
+		s.stored([]domain.PriceList{vip}, []domain.Promotion{}, "vip", "wholesale")
+
+		got, err = s.app.Quote(context.Background(), request())
+
+		s.NoError(err)
+		s.Equal(1500, got.Lines[0].UnitPrice)
+	})
+
+	s.Run("Should ignore the price lists and promotions out of their schedules", func() {
+		s.SetupTest()
+
+		expired := storedPriceList()
+		expired.EndsAt = quotedAt
+
+		upcoming := storedPromotion()
+		upcoming.Code = ""
+		upcoming.StartsAt = quotedAt.Add(time.Second)
+
+		s.stored([]domain.PriceList{expired}, []domain.Promotion{upcoming})
+
+		got, err := s.app.Quote(context.Background(), request())
+
+		s.NoError(err)
+		s.Equal(4000, got.Total)
+		s.Empty(got.Lines[0].Adjustments)
+	})
+
+	s.Run("Should apply the promotions unlocked by the codes told and reject the other codes", func() {
+		s.SetupTest()
+
+		s.stored([]domain.PriceList{}, []domain.Promotion{storedPromotion()})
+
+		got, err := s.app.Quote(context.Background(), request(" tenoff", "UNKNOWN", "TENOFF"))
+
+		s.NoError(err)
+		s.Equal([]string{"TENOFF"}, got.AppliedCodes)
+		s.Equal([]string{"UNKNOWN"}, got.RejectedCodes)
+		s.Equal([]domain.Adjustment{
+			{Source: domain.AdjustmentPromotion, SourceID: 1, Description: "Ten off", Code: "TENOFF", Amount: 400},
+		}, got.Lines[0].Adjustments)
+		s.Equal(3600, got.Total)
+	})
+
+	s.Run("Should not apply a promotion whose code wasn't told", func() {
+		s.SetupTest()
+
+		s.stored([]domain.PriceList{}, []domain.Promotion{storedPromotion()})
+
+		got, err := s.app.Quote(context.Background(), request())
+
+		s.NoError(err)
+		s.Equal(4000, got.Total)
+	})
+
+	s.Run("Should reject the code of a promotion covering other products", func() {
+		s.SetupTest()
+
+		p := storedPromotion()
+		p.ProductIDs = []int{2}
+
+		s.stored([]domain.PriceList{}, []domain.Promotion{p})
+
+		got, err := s.app.Quote(context.Background(), request("TENOFF"))
+
+		s.NoError(err)
+		s.Empty(got.AppliedCodes)
+		s.Equal([]string{"TENOFF"}, got.RejectedCodes)
+	})
+
+	s.Run("Should apply only the promotion taking the most off, on top of the price list", func() {
+		s.SetupTest()
+
+		automatic := storedPromotion()
+		automatic.ID = 2
+		automatic.Name = "Five off"
+		automatic.Percentage = 5
+		automatic.Code = ""
+
+		fixed := storedPromotion()
+		fixed.ID = 3
+		fixed.Name = "Two hundred off"
+		fixed.Kind = domain.PromotionFixedAmount
+		fixed.Amount = 200
+		fixed.Code = "TWOHUNDRED"
+
+		s.stored([]domain.PriceList{storedPriceList()}, []domain.Promotion{automatic, storedPromotion(), fixed})
+
+		got, err := s.app.Quote(context.Background(), request("TENOFF", "TWOHUNDRED"))
+
+		s.NoError(err)
+		s.Equal([]domain.Adjustment{
+			{Source: domain.AdjustmentPriceList, SourceID: 1, Description: "Black Friday", Amount: 1000},
+			{Source: domain.AdjustmentPromotion, SourceID: 3, Description: "Two hundred off", Code: "TWOHUNDRED", Amount: 400},
+		}, got.Lines[0].Adjustments)
+		s.Equal(1400, got.Discount)
+		s.Equal(2600, got.Total)
+		s.Equal([]string{"TWOHUNDRED"}, got.AppliedCodes)
+		s.Equal([]string{"TENOFF"}, got.RejectedCodes)
+	})
+
+	s.Run("Should break the ties between promotions with the oldest one", func() {
+		s.SetupTest()
+
+		newer := storedPromotion()
+		newer.ID = 2
+		newer.Code = ""
+
+		older := storedPromotion()
+		older.Code = ""
+
+		s.stored([]domain.PriceList{}, []domain.Promotion{newer, older})
+
+		got, err := s.app.Quote(context.Background(), request())
+
+		s.NoError(err)
+		s.Equal(1, got.Lines[0].Adjustments[0].SourceID)
+	})
+
+	s.Run("Should give away units with the buy x get y promotions", func() {
+		s.SetupTest()
+
+		p := storedPromotion()
+		p.Kind = domain.PromotionBuyXGetY
+		p.BuyQuantity = 2
+		p.GetQuantity = 1
+		p.Code = ""
+
+		s.stored([]domain.PriceList{}, []domain.Promotion{p})
+
+		r := request()
+		r.Lines = []domain.LineRequest{
+			{ProductID: 1, Quantity: 7, UnitPrice: 2000},
+			{ProductID: 2, Quantity: 3, UnitPrice: 1000},
+		}
+
+		got, err := s.app.Quote(context.Background(), r)
+
+		s.NoError(err)
+		s.Equal(4000, got.Lines[0].Discount)
+		s.Equal(10000, got.Lines[0].Total)
+		s.Equal(0, got.Lines[1].Discount)
+		s.Equal(17000, got.Subtotal)
+		s.Equal(13000, got.Total)
+	})
+}
+
+func TestQuoteSuite(t *testing.T) {
+	suite.Run(t, new(QuoteSuite))
+}
@@ -0,0 +1,21 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) SetCustomerSegments(ctx context.Context, segments domain.CustomerSegments) (domain.CustomerSegments, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.SetCustomerSegments")
+	defer span.End()
+
+	a.Logger.Info("setting the segments of a customer", zap.String("customerId", segments.CustomerID), zap.Strings("segments", segments.Segments))
+
+	if err := segments.Validate(); err != nil {
+		return domain.CustomerSegments{}, err
+	}
+
+	return a.SegmentsRepository.Set(ctx, segments.Normalize())
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"github.com/lucasmls/ecommerce/services/pricing/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type SetCustomerSegmentsSuite struct {
+	suite.Suite
+
+	segmentsRepo *mocks.SegmentsRepository
+	app          domain.Application
+}
+
+func (s *SetCustomerSegmentsSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.segmentsRepo = &mocks.SegmentsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, &mocks.PriceListsRepository{}, &mocks.PromotionsRepository{}, s.segmentsRepo)
+}
+
+func (s *SetCustomerSegmentsSuite) Test_SetCustomerSegments() {
+	s.Run("Should fail without reaching the repository in case the customer is missing", func() {
+		s.SetupTest()
+
+		_, err := s.app.SetCustomerSegments(context.Background(), domain.CustomerSegments{Segments: []string{"vip"}})
+
+		s.Equal(domain.ErrInvalidSegment, err)
+		s.segmentsRepo.AssertNotCalled(s.T(), "Set", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case a segment is invalid", func() {
+		s.SetupTest()
+
+		_, err := s.app.SetCustomerSegments(context.Background(), domain.CustomerSegments{CustomerID: customerID, Segments: []string{"vip", "big spenders"}})
+
+		s.Equal(domain.ErrInvalidSegment, err)
+		s.segmentsRepo.AssertNotCalled(s.T(), "Set", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should store the segments normalized, sorted and free of duplicates", func() {
+		s.SetupTest()
+
+		normalized := domain.CustomerSegments{CustomerID: customerID, Segments: []string{"vip", "wholesale"}}
+		s.segmentsRepo.On("Set", mock.AnythingOfType("*context.valueCtx"), normalized).Return(normalized, nil).Once()
+
+		got, err := s.app.SetCustomerSegments(context.Background(), domain.CustomerSegments{
+			CustomerID: customerID,
+			Segments:   []string{"wholesale", " VIP ", "vip"},
+		})
+
+		s.NoError(err)
+		s.Equal(normalized, got)
+	})
+}
+
+func TestSetCustomerSegmentsSuite(t *testing.T) {
+	suite.Run(t, new(SetCustomerSegmentsSuite))
+}
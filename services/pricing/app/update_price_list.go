@@ -0,0 +1,36 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) UpdatePriceList(ctx context.Context, priceList domain.PriceList) (domain.PriceList, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.UpdatePriceList")
+	defer span.End()
+
+	priceList = priceList.Normalize()
+
+	a.Logger.Info("updating a price list", zap.Int("id", priceList.ID), zap.Int("version", priceList.Version))
+
+	if priceList.ID <= 0 {
+		return domain.PriceList{}, domain.ErrPriceListNotFound
+	}
+
+	if err := priceList.Validate(); err != nil {
+		return domain.PriceList{}, err
+	}
+
+	stored, err := a.PriceListsRepository.Get(ctx, priceList.ID)
+	if err != nil {
+		return domain.PriceList{}, err
+	}
+
+	priceList.CreatedAt = stored.CreatedAt
+	priceList.UpdatedAt = time.Now()
+
+	return a.PriceListsRepository.Update(ctx, priceList)
+}
@@ -0,0 +1,100 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"github.com/lucasmls/ecommerce/services/pricing/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type UpdatePriceListSuite struct {
+	suite.Suite
+
+	priceListsRepo *mocks.PriceListsRepository
+	app            domain.Application
+}
+
+func (s *UpdatePriceListSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.priceListsRepo = &mocks.PriceListsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.priceListsRepo, &mocks.PromotionsRepository{}, &mocks.SegmentsRepository{})
+}
+
+func (s *UpdatePriceListSuite) Test_UpdatePriceList() {
+	s.Run("Should fail without reaching the repository in case the id is missing", func() {
+		s.SetupTest()
+
+		_, err := s.app.UpdatePriceList(context.Background(), priceList())
+
+		s.Equal(domain.ErrPriceListNotFound, err)
+		s.priceListsRepo.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the price list is invalid", func() {
+		s.SetupTest()
+
+		list := storedPriceList()
+		list.Prices = map[int]int{0: 100}
+
+		_, err := s.app.UpdatePriceList(context.Background(), list)
+
+		s.Equal(domain.ErrInvalidPriceList, err)
+		s.priceListsRepo.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the price list doesn't exist", func() {
+		s.SetupTest()
+
+		s.priceListsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), 1).Return(domain.PriceList{}, domain.ErrPriceListNotFound).Once()
+
+		_, err := s.app.UpdatePriceList(context.Background(), storedPriceList())
+
+		s.Equal(domain.ErrPriceListNotFound, err)
+		s.priceListsRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the price list changed meanwhile", func() {
+		s.SetupTest()
+
+		s.priceListsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), 1).Return(storedPriceList(), nil).Once()
+		s.priceListsRepo.On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.PriceList")).Return(domain.PriceList{}, domain.ErrPriceListVersionConflict).Once()
+
+		_, err := s.app.UpdatePriceList(context.Background(), storedPriceList())
+
+		s.Equal(domain.ErrPriceListVersionConflict, err)
+	})
+
+	s.Run("Should replace the price list keeping when it was created", func() {
+		s.SetupTest()
+
+		list := storedPriceList()
+		list.CreatedAt = list.CreatedAt.AddDate(1, 0, 0)
+		list.Prices = map[int]int{1: 1200}
+
+		s.priceListsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), 1).Return(storedPriceList(), nil).Once()
+		s.priceListsRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(list domain.PriceList) bool {
+				return list.CreatedAt.Equal(createdAt) && list.UpdatedAt.After(createdAt) && list.Version == 1 && list.Prices[1] == 1200
+			})).
+			Return(bumpPriceListVersion, nil).
+			Once()
+
+		got, err := s.app.UpdatePriceList(context.Background(), list)
+
+		s.NoError(err)
+		s.Equal(2, got.Version)
+		s.Equal(map[int]int{1: 1200}, got.Prices)
+	})
+}
+
+func TestUpdatePriceListSuite(t *testing.T) {
+	suite.Run(t, new(UpdatePriceListSuite))
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) UpdatePromotion(ctx context.Context, promotion domain.Promotion) (domain.Promotion, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.UpdatePromotion")
+	defer span.End()
+
+	promotion = promotion.Normalize()
+
+	a.Logger.Info("updating a promotion", zap.Int("id", promotion.ID), zap.Int("version", promotion.Version))
+
+	if promotion.ID <= 0 {
+		return domain.Promotion{}, domain.ErrPromotionNotFound
+	}
+
+	if err := promotion.Validate(); err != nil {
+		return domain.Promotion{}, err
+	}
+
+	stored, err := a.PromotionsRepository.Get(ctx, promotion.ID)
+	if err != nil {
+		return domain.Promotion{}, err
+	}
+
+	promotion.CreatedAt = stored.CreatedAt
+	promotion.UpdatedAt = time.Now()
+
+	return a.PromotionsRepository.Update(ctx, promotion)
+}
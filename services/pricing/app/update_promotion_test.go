@@ -0,0 +1,90 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"github.com/lucasmls/ecommerce/services/pricing/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type UpdatePromotionSuite struct {
+	suite.Suite
+
+	promotionsRepo *mocks.PromotionsRepository
+	app            domain.Application
+}
+
+func (s *UpdatePromotionSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.promotionsRepo = &mocks.PromotionsRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, &mocks.PriceListsRepository{}, s.promotionsRepo, &mocks.SegmentsRepository{})
+}
+
+func (s *UpdatePromotionSuite) Test_UpdatePromotion() {
+	s.Run("Should fail without reaching the repository in case the id is missing", func() {
+		s.SetupTest()
+
+		_, err := s.app.UpdatePromotion(context.Background(), promotion())
+
+		s.Equal(domain.ErrPromotionNotFound, err)
+		s.promotionsRepo.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the promotion doesn't exist", func() {
+		s.SetupTest()
+
+		s.promotionsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), 1).Return(domain.Promotion{}, domain.ErrPromotionNotFound).Once()
+
+		_, err := s.app.UpdatePromotion(context.Background(), storedPromotion())
+
+		s.Equal(domain.ErrPromotionNotFound, err)
+		s.promotionsRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the promotion changed meanwhile", func() {
+		s.SetupTest()
+
+		s.promotionsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), 1).Return(storedPromotion(), nil).Once()
+		s.promotionsRepo.On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Promotion")).Return(domain.Promotion{}, domain.ErrPromotionVersionConflict).Once()
+
+		_, err := s.app.UpdatePromotion(context.Background(), storedPromotion())
+
+		s.Equal(domain.ErrPromotionVersionConflict, err)
+	})
+
+	s.Run("Should replace the promotion keeping when it was created", func() {
+		s.SetupTest()
+
+		p := storedPromotion()
+		p.CreatedAt = p.CreatedAt.AddDate(1, 0, 0)
+		p.Kind = domain.PromotionBuyXGetY
+		p.BuyQuantity = 2
+		p.GetQuantity = 1
+		p.Code = ""
+
+		s.promotionsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), 1).Return(storedPromotion(), nil).Once()
+		s.promotionsRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(p domain.Promotion) bool {
+				return p.CreatedAt.Equal(createdAt) && p.UpdatedAt.After(createdAt) && p.Kind == domain.PromotionBuyXGetY && p.Code == ""
+			})).
+			Return(bumpPromotionVersion, nil).
+			Once()
+
+		got, err := s.app.UpdatePromotion(context.Background(), p)
+
+		s.NoError(err)
+		s.Equal(2, got.Version)
+	})
+}
+
+func TestUpdatePromotionSuite(t *testing.T) {
+	suite.Run(t, new(UpdatePromotionSuite))
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Application defines boundary interfaces of the application
+// It should be called by the ports
+type Application interface {
+	// CreatePriceList stores a new PriceList
+	CreatePriceList(context.Context, PriceList) (PriceList, error)
+
+	// UpdatePriceList replaces a PriceList, failing with ErrPriceListVersionConflict when its Version isn't the stored one
+	UpdatePriceList(context.Context, PriceList) (PriceList, error)
+
+	// DeletePriceList deletes a PriceList
+	DeletePriceList(context.Context, int) error
+
+	// GetPriceList fetches a single PriceList
+	GetPriceList(context.Context, int) (PriceList, error)
+
+	// ListPriceLists lists every PriceList, the oldest first
+	ListPriceLists(context.Context) ([]PriceList, error)
+
+	// CreatePromotion stores a new Promotion, failing with ErrCodeAlreadyUsed when another Promotion has the same Code
+	CreatePromotion(context.Context, Promotion) (Promotion, error)
+
+	// UpdatePromotion replaces a Promotion, failing with ErrPromotionVersionConflict when its Version isn't the stored one
+	UpdatePromotion(context.Context, Promotion) (Promotion, error)
+
+	// DeletePromotion deletes a Promotion
+	DeletePromotion(context.Context, int) error
+
+	// GetPromotion fetches a single Promotion
+	GetPromotion(context.Context, int) (Promotion, error)
+
+	// ListPromotions lists every Promotion, the oldest first
+	ListPromotions(context.Context) ([]Promotion, error)
+
+	// SetCustomerSegments replaces the segments a customer belongs to
+	SetCustomerSegments(context.Context, CustomerSegments) (CustomerSegments, error)
+
+	// GetCustomerSegments fetches the segments a customer belongs to, a customer without any belongs to none
+	GetCustomerSegments(context.Context, string) (CustomerSegments, error)
+
+	// Quote prices some Products for a customer, explaining every Adjustment made to their base prices
+	Quote(context.Context, QuoteRequest) (Quote, error)
+}
+
+type PriceListsRepository interface {
+	// Create stores a new PriceList in a data storage, setting its ID and its first Version.
+	Create(context.Context, PriceList) (PriceList, error)
+
+	// Get fetches a single PriceList from a data storage.
+	Get(context.Context, int) (PriceList, error)
+
+	// Update replaces a PriceList in a data storage, returning it with its new Version.
+	// It's only replaced while the stored Version is still the one it was read with, failing with ErrPriceListVersionConflict otherwise.
+	Update(context.Context, PriceList) (PriceList, error)
+
+	// Delete removes a PriceList from a data storage.
+	Delete(context.Context, int) error
+
+	// List fetches every PriceList from a data storage, the oldest first.
+	List(context.Context) ([]PriceList, error)
+
+	// ListActive fetches the PriceLists whose Schedule contains the given time from a data storage.
+	ListActive(context.Context, time.Time) ([]PriceList, error)
+}
+
+type PromotionsRepository interface {
+	// Create stores a new Promotion in a data storage, setting its ID and its first Version.
+	// It fails with ErrCodeAlreadyUsed when another Promotion has the same Code.
+	Create(context.Context, Promotion) (Promotion, error)
+
+	// Get fetches a single Promotion from a data storage.
+	Get(context.Context, int) (Promotion, error)
+
+	// Update replaces a Promotion in a data storage, returning it with its new Version.
+	// It's only replaced while the stored Version is still the one it was read with, failing with
+	// ErrPromotionVersionConflict otherwise, and with ErrCodeAlreadyUsed when another Promotion has the same Code.
+	Update(context.Context, Promotion) (Promotion, error)
+
+	// Delete removes a Promotion from a data storage.
+	Delete(context.Context, int) error
+
+	// List fetches every Promotion from a data storage, the oldest first.
+	List(context.Context) ([]Promotion, error)
+
+	// ListActive fetches the Promotions whose Schedule contains the given time from a data storage.
+	ListActive(context.Context, time.Time) ([]Promotion, error)
+}
+
+type SegmentsRepository interface {
+	// Get fetches the segments of a customer from a data storage, a customer without any gets empty CustomerSegments.
+	Get(context.Context, string) (CustomerSegments, error)
+
+	// Set replaces the segments of a customer in a data storage.
+	Set(context.Context, CustomerSegments) (CustomerSegments, error)
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxNameLength is the maximum amount of characters of the name of a PriceList or a Promotion
+	MaxNameLength = 255
+	// MaxPricesPerList is the maximum amount of Products a PriceList prices
+	MaxPricesPerList = 10000
+)
+
+var (
+	ErrPriceListNotFound        = errors.New("price-list-not-found")
+	ErrPriceListVersionConflict = errors.New("price-list-version-conflict")
+	ErrInvalidPriceList         = errors.New("invalid-price-list")
+)
+
+// PriceList sets the prices of some Products, e.g. the sale prices of a season or the prices of a segment.
+// A PriceList never raises the price of a Product, only the prices under the base ones apply.
+type PriceList struct {
+	ID   int
+	Name string
+	// Segment restricts the PriceList to the customers of a segment, every customer gets it when empty
+	Segment string
+	// Priority picks the PriceList applied when many of them price a Product, the highest one wins
+	Priority int
+	Schedule
+	// Prices maps the IDs of the Products to their prices, in minor currency units (cents)
+	Prices map[int]int
+	// Version is incremented on every change, starting at 1 when the PriceList is created.
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Validate checks the PriceList is valid, its ID and Version aren't checked
+func (l PriceList) Validate() error {
+	if !validName(l.Name) {
+		return ErrInvalidPriceList
+	}
+
+	if err := validateSegment(l.Segment); err != nil {
+		return err
+	}
+
+	if err := l.Schedule.Validate(); err != nil {
+		return err
+	}
+
+	if len(l.Prices) == 0 || len(l.Prices) > MaxPricesPerList {
+		return ErrInvalidPriceList
+	}
+
+	for productID, price := range l.Prices {
+		if productID <= 0 || price < 0 {
+			return ErrInvalidPriceList
+		}
+	}
+
+	return nil
+}
+
+// Normalize trims the Name and normalizes the Segment of the PriceList
+func (l PriceList) Normalize() PriceList {
+	l.Name = strings.TrimSpace(l.Name)
+	l.Segment = NormalizeSegment(l.Segment)
+
+	return l
+}
+
+// AppliesTo tells whether the PriceList applies to a customer of the given segments at the given time
+func (l PriceList) AppliesTo(segments CustomerSegments, at time.Time) bool {
+	return l.Schedule.Contains(at) && segments.Includes(l.Segment)
+}
+
+func validName(name string) bool {
+	name = strings.TrimSpace(name)
+	return name != "" && utf8.RuneCountInString(name) <= MaxNameLength
+}
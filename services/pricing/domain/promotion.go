@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxCodeLength is the maximum amount of characters of a discount code
+	MaxCodeLength = 32
+	// MaxPromotionProducts is the maximum amount of Products a Promotion is restricted to
+	MaxPromotionProducts = 1000
+)
+
+var (
+	ErrPromotionNotFound        = errors.New("promotion-not-found")
+	ErrPromotionVersionConflict = errors.New("promotion-version-conflict")
+	ErrInvalidPromotion         = errors.New("invalid-promotion")
+	ErrInvalidCode              = errors.New("invalid-code")
+	ErrCodeAlreadyUsed          = errors.New("code-already-used")
+)
+
+// PromotionKind is how a Promotion discounts the Products
+type PromotionKind string
+
+const (
+	// PromotionPercentage takes a Percentage off the price
+	PromotionPercentage PromotionKind = "percentage"
+	// PromotionFixedAmount takes an Amount off the price of every unit
+	PromotionFixedAmount PromotionKind = "fixed_amount"
+	// PromotionBuyXGetY gives GetQuantity units away for every BuyQuantity units bought
+	PromotionBuyXGetY PromotionKind = "buy_x_get_y"
+)
+
+// Promotion discounts the price of some Products, on top of the PriceLists.
+// The Promotions don't stack, a line gets the one taking the most off its price.
+type Promotion struct {
+	ID   int
+	Name string
+	Kind PromotionKind
+	// Percentage is taken off the price by the percentage Promotions, from 1 to 100
+	Percentage int
+	// Amount is taken off the price of every unit by the fixed amount Promotions, in minor currency units (cents)
+	Amount int
+	// BuyQuantity and GetQuantity set up the buy X get Y Promotions, e.g. buy 2 get 1 gives every third unit away
+	BuyQuantity int
+	GetQuantity int
+	// ProductIDs restricts the Promotion to some Products, every Product gets it when empty
+	ProductIDs []int
+	// Segment restricts the Promotion to the customers of a segment, every customer gets it when empty
+	Segment string
+	// Code is the discount code the customers tell to get the Promotion, it's automatic when empty.
+	// It's normalized to upper case and unique among the Promotions.
+	Code string
+	Schedule
+	// Version is incremented on every change, starting at 1 when the Promotion is created.
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Validate checks the Promotion is valid, its ID and Version aren't checked
+func (p Promotion) Validate() error {
+	if !validName(p.Name) {
+		return ErrInvalidPromotion
+	}
+
+	switch p.Kind {
+	case PromotionPercentage:
+		if p.Percentage < 1 || p.Percentage > 100 {
+			return ErrInvalidPromotion
+		}
+	case PromotionFixedAmount:
+		if p.Amount <= 0 {
+			return ErrInvalidPromotion
+		}
+	case PromotionBuyXGetY:
+		if p.BuyQuantity <= 0 || p.GetQuantity <= 0 {
+			return ErrInvalidPromotion
+		}
+	default:
+		return ErrInvalidPromotion
+	}
+
+	if len(p.ProductIDs) > MaxPromotionProducts {
+		return ErrInvalidPromotion
+	}
+
+	for _, productID := range p.ProductIDs {
+		if productID <= 0 {
+			return ErrInvalidPromotion
+		}
+	}
+
+	if err := validateSegment(p.Segment); err != nil {
+		return err
+	}
+
+	if err := validateCode(p.Code); err != nil {
+		return err
+	}
+
+	return p.Schedule.Validate()
+}
+
+// Normalize trims the Name and normalizes the Segment and the Code of the Promotion
+func (p Promotion) Normalize() Promotion {
+	p.Name = strings.TrimSpace(p.Name)
+	p.Segment = NormalizeSegment(p.Segment)
+	p.Code = NormalizeCode(p.Code)
+
+	return p
+}
+
+// AppliesTo tells whether the Promotion applies to a customer of the given segments, who told the given codes, at the given time
+func (p Promotion) AppliesTo(segments CustomerSegments, codes map[string]bool, at time.Time) bool {
+	if p.Code != "" && !codes[p.Code] {
+		return false
+	}
+
+	return p.Schedule.Contains(at) && segments.Includes(p.Segment)
+}
+
+// Covers tells whether the Promotion discounts a Product
+func (p Promotion) Covers(productID int) bool {
+	if len(p.ProductIDs) == 0 {
+		return true
+	}
+
+	for _, id := range p.ProductIDs {
+		if id == productID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Discount is how much the Promotion takes off some units of a Product, in minor currency units (cents).
+// The percentages are rounded down, so a discount never exceeds the price.
+func (p Promotion) Discount(unitPrice int, quantity int) int {
+	var discount int
+
+	switch p.Kind {
+	case PromotionPercentage:
+		discount = unitPrice * quantity * p.Percentage / 100
+	case PromotionFixedAmount:
+		discount = min(p.Amount, unitPrice) * quantity
+	case PromotionBuyXGetY:
+		free := quantity / (p.BuyQuantity + p.GetQuantity) * p.GetQuantity
+		discount = free * unitPrice
+	}
+
+	return discount
+}
+
+// NormalizeCode is how a discount code is stored and compared
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// validateCode checks a discount code is made of letters, digits, dashes and underscores, an empty one is valid
+func validateCode(code string) error {
+	code = NormalizeCode(code)
+	if len(code) > MaxCodeLength {
+		return ErrInvalidCode
+	}
+
+	for _, r := range code {
+		if !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') && r != '-' && r != '_' {
+			return ErrInvalidCode
+		}
+	}
+
+	return nil
+}
+
+func min(a int, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,256 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+const (
+	// MaxQuoteLines is the maximum amount of lines priced at once
+	MaxQuoteLines = 100
+	// MaxQuoteCodes is the maximum amount of discount codes told at once
+	MaxQuoteCodes = 5
+)
+
+var ErrInvalidQuote = errors.New("invalid-quote")
+
+// AdjustmentSource is what changed the price of a line
+type AdjustmentSource string
+
+const (
+	AdjustmentPriceList AdjustmentSource = "price_list"
+	AdjustmentPromotion AdjustmentSource = "promotion"
+)
+
+// Adjustment explains how much was taken off the price of a line, and why
+type Adjustment struct {
+	Source AdjustmentSource
+	// SourceID is the ID of the PriceList or the Promotion
+	SourceID int
+	// Description is the name of the PriceList or the Promotion, shown to the customers
+	Description string
+	// Code is the discount code that unlocked the Promotion, if any
+	Code string
+	// Amount is taken off the line, in minor currency units (cents)
+	Amount int
+}
+
+// LineRequest is some units of a Product to price
+type LineRequest struct {
+	ProductID int
+	Quantity  int
+	// UnitPrice is the base price of the Product, in minor currency units (cents)
+	UnitPrice int
+}
+
+// QuoteRequest asks for the prices of some Products, e.g. the ones on a cart
+type QuoteRequest struct {
+	// CustomerID picks the segments whose prices apply, only the prices of every customer apply when empty
+	CustomerID string
+	// Codes are the discount codes told by the customer
+	Codes []string
+	// At is when the prices apply, they're the current ones when zero
+	At    time.Time
+	Lines []LineRequest
+}
+
+// Validate checks the QuoteRequest is valid
+func (r QuoteRequest) Validate() error {
+	if len(r.Lines) == 0 || len(r.Lines) > MaxQuoteLines || len(r.Codes) > MaxQuoteCodes {
+		return ErrInvalidQuote
+	}
+
+	for _, line := range r.Lines {
+		if line.ProductID <= 0 || line.Quantity <= 0 || line.UnitPrice < 0 {
+			return ErrInvalidQuote
+		}
+	}
+
+	for _, code := range r.Codes {
+		if err := validateCode(code); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// QuoteLine is the price of some units of a Product, along with the Adjustments explaining it
+type QuoteLine struct {
+	ProductID int
+	Quantity  int
+	// BaseUnitPrice is the price the line was requested with
+	BaseUnitPrice int
+	// UnitPrice is the price after the PriceLists, before the Promotions
+	UnitPrice int
+	// Subtotal is the base price of every unit
+	Subtotal int
+	// Discount is the sum of the Adjustments
+	Discount int
+	// Total is what the customer pays for the line
+	Total       int
+	Adjustments []Adjustment
+}
+
+// Quote is the price of some Products for a customer at some time, all amounts in minor currency units (cents)
+type Quote struct {
+	Lines    []QuoteLine
+	Subtotal int
+	Discount int
+	Total    int
+	// AppliedCodes are the discount codes that took something off
+	AppliedCodes []string
+	// RejectedCodes are the discount codes that took nothing off, whether they're unknown, expired,
+	// restricted to other customers or Products, or beaten by a better Promotion
+	RejectedCodes []string
+	At            time.Time
+}
+
+// NewQuote prices the lines of a request with the PriceLists and the Promotions that apply to the customer.
+//
+// Each line gets the price of the PriceList with the highest Priority pricing its Product under the base price,
+// then the Promotion taking the most off that price. The ties go to the oldest PriceList or Promotion.
+func NewQuote(request QuoteRequest, segments CustomerSegments, priceLists []PriceList, promotions []Promotion) Quote {
+	codes := make(map[string]bool, len(request.Codes))
+	for _, code := range request.Codes {
+		codes[NormalizeCode(code)] = true
+	}
+
+	applicableLists := make([]PriceList, 0, len(priceLists))
+	for _, list := range priceLists {
+		if list.AppliesTo(segments, request.At) {
+			applicableLists = append(applicableLists, list)
+		}
+	}
+
+	sort.SliceStable(applicableLists, func(i, j int) bool {
+		if applicableLists[i].Priority != applicableLists[j].Priority {
+			return applicableLists[i].Priority > applicableLists[j].Priority
+		}
+
+		return applicableLists[i].ID < applicableLists[j].ID
+	})
+
+	applicablePromotions := make([]Promotion, 0, len(promotions))
+	for _, promotion := range promotions {
+		if promotion.AppliesTo(segments, codes, request.At) {
+			applicablePromotions = append(applicablePromotions, promotion)
+		}
+	}
+
+	sort.SliceStable(applicablePromotions, func(i, j int) bool {
+		return applicablePromotions[i].ID < applicablePromotions[j].ID
+	})
+
+	quote := Quote{
+		Lines: make([]QuoteLine, 0, len(request.Lines)),
+		At:    request.At,
+	}
+
+	applied := map[string]bool{}
+
+	for _, lineRequest := range request.Lines {
+		line := priceLine(lineRequest, applicableLists, applicablePromotions)
+
+		for _, adjustment := range line.Adjustments {
+			if adjustment.Code != "" {
+				applied[adjustment.Code] = true
+			}
+		}
+
+		quote.Lines = append(quote.Lines, line)
+		quote.Subtotal += line.Subtotal
+		quote.Discount += line.Discount
+		quote.Total += line.Total
+	}
+
+	quote.AppliedCodes, quote.RejectedCodes = splitCodes(request.Codes, applied)
+
+	return quote
+}
+
+// priceLine prices a line with the PriceLists, sorted by precedence, and the Promotions
+func priceLine(request LineRequest, priceLists []PriceList, promotions []Promotion) QuoteLine {
+	line := QuoteLine{
+		ProductID:     request.ProductID,
+		Quantity:      request.Quantity,
+		BaseUnitPrice: request.UnitPrice,
+		UnitPrice:     request.UnitPrice,
+		Subtotal:      request.UnitPrice * request.Quantity,
+		Adjustments:   []Adjustment{},
+	}
+
+	for _, list := range priceLists {
+		price, ok := list.Prices[request.ProductID]
+		if !ok || price >= request.UnitPrice {
+			continue
+		}
+
+		line.UnitPrice = price
+		line.Adjustments = append(line.Adjustments, Adjustment{
+			Source:      AdjustmentPriceList,
+			SourceID:    list.ID,
+			Description: list.Name,
+			Amount:      (request.UnitPrice - price) * request.Quantity,
+		})
+
+		break
+	}
+
+	var best *Promotion
+	bestDiscount := 0
+
+	for i, promotion := range promotions {
+		if !promotion.Covers(request.ProductID) {
+			continue
+		}
+
+		if discount := promotion.Discount(line.UnitPrice, request.Quantity); discount > bestDiscount {
+			best = &promotions[i]
+			bestDiscount = discount
+		}
+	}
+
+	if best != nil {
+		line.Adjustments = append(line.Adjustments, Adjustment{
+			Source:      AdjustmentPromotion,
+			SourceID:    best.ID,
+			Description: best.Name,
+			Code:        best.Code,
+			Amount:      bestDiscount,
+		})
+	}
+
+	for _, adjustment := range line.Adjustments {
+		line.Discount += adjustment.Amount
+	}
+
+	line.Total = line.Subtotal - line.Discount
+
+	return line
+}
+
+// splitCodes splits the codes told by the customer into the applied and the rejected ones, normalized and free of duplicates
+func splitCodes(codes []string, applied map[string]bool) ([]string, []string) {
+	appliedCodes := []string{}
+	rejectedCodes := []string{}
+	seen := map[string]bool{}
+
+	for _, code := range codes {
+		code = NormalizeCode(code)
+		if seen[code] {
+			continue
+		}
+
+		seen[code] = true
+
+		if applied[code] {
+			appliedCodes = append(appliedCodes, code)
+		} else {
+			rejectedCodes = append(rejectedCodes, code)
+		}
+	}
+
+	return appliedCodes, rejectedCodes
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalidSchedule = errors.New("invalid-schedule")
+
+// Schedule bounds when a PriceList or a Promotion applies
+type Schedule struct {
+	// StartsAt is the inclusive start of the Schedule, a zero value meaning it has always started
+	StartsAt time.Time
+	// EndsAt is the exclusive end of the Schedule, a zero value meaning it never ends
+	EndsAt time.Time
+}
+
+// Validate checks the Schedule ends after it starts
+func (s Schedule) Validate() error {
+	if !s.StartsAt.IsZero() && !s.EndsAt.IsZero() && !s.EndsAt.After(s.StartsAt) {
+		return ErrInvalidSchedule
+	}
+
+	return nil
+}
+
+// Contains tells whether the Schedule applies at the given time
+func (s Schedule) Contains(at time.Time) bool {
+	if !s.StartsAt.IsZero() && at.Before(s.StartsAt) {
+		return false
+	}
+
+	return s.EndsAt.IsZero() || at.Before(s.EndsAt)
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+const (
+	// MaxSegmentLength is the maximum amount of characters of a segment
+	MaxSegmentLength = 64
+	// MaxSegmentsPerCustomer is the maximum amount of segments a customer belongs to
+	MaxSegmentsPerCustomer = 20
+)
+
+var ErrInvalidSegment = errors.New("invalid-segment")
+
+// CustomerSegments are the segments a customer belongs to, e.g. vip or wholesale.
+// The PriceLists and Promotions of a segment only apply to its customers.
+type CustomerSegments struct {
+	CustomerID string
+	// Segments are normalized, sorted and free of duplicates
+	Segments []string
+}
+
+// Validate checks the CustomerSegments are valid
+func (c CustomerSegments) Validate() error {
+	if c.CustomerID == "" || len(c.Segments) > MaxSegmentsPerCustomer {
+		return ErrInvalidSegment
+	}
+
+	for _, segment := range c.Segments {
+		if NormalizeSegment(segment) == "" {
+			return ErrInvalidSegment
+		}
+
+		if err := validateSegment(segment); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Normalize normalizes the Segments, sorting them and dropping the duplicates
+func (c CustomerSegments) Normalize() CustomerSegments {
+	seen := make(map[string]bool, len(c.Segments))
+	segments := make([]string, 0, len(c.Segments))
+
+	for _, segment := range c.Segments {
+		segment = NormalizeSegment(segment)
+		if seen[segment] {
+			continue
+		}
+
+		seen[segment] = true
+		segments = append(segments, segment)
+	}
+
+	sort.Strings(segments)
+
+	return CustomerSegments{CustomerID: c.CustomerID, Segments: segments}
+}
+
+// Includes tells whether the customer belongs to a segment, everyone belongs to the empty one
+func (c CustomerSegments) Includes(segment string) bool {
+	if segment == "" {
+		return true
+	}
+
+	for _, s := range c.Segments {
+		if s == segment {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NormalizeSegment is how a segment is stored and compared
+func NormalizeSegment(segment string) string {
+	return strings.ToLower(strings.TrimSpace(segment))
+}
+
+// validateSegment checks a segment is made of lower case letters, digits, dashes and underscores, an empty one is valid
+func validateSegment(segment string) error {
+	segment = NormalizeSegment(segment)
+	if len(segment) > MaxSegmentLength {
+		return ErrInvalidSegment
+	}
+
+	for _, r := range segment {
+		if !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') && r != '-' && r != '_' {
+			return ErrInvalidSegment
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,13 @@
+-- prices maps the ids of the products to their prices in cents, a list is always read and written as a whole
+CREATE TABLE IF NOT EXISTS price_lists (
+    id         SERIAL PRIMARY KEY,
+    name       TEXT        NOT NULL,
+    segment    TEXT        NOT NULL DEFAULT '',
+    priority   INTEGER     NOT NULL DEFAULT 0,
+    starts_at  TIMESTAMPTZ,
+    ends_at    TIMESTAMPTZ,
+    prices     JSONB       NOT NULL,
+    version    INTEGER     NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
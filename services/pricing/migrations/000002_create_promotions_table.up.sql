@@ -0,0 +1,20 @@
+-- product_ids is empty when the promotion covers every product, code is NULL when it's applied automatically
+CREATE TABLE IF NOT EXISTS promotions (
+    id           SERIAL PRIMARY KEY,
+    name         TEXT        NOT NULL,
+    kind         TEXT        NOT NULL,
+    percentage   INTEGER     NOT NULL DEFAULT 0,
+    amount       INTEGER     NOT NULL DEFAULT 0,
+    buy_quantity INTEGER     NOT NULL DEFAULT 0,
+    get_quantity INTEGER     NOT NULL DEFAULT 0,
+    product_ids  INTEGER[]   NOT NULL DEFAULT '{}',
+    segment      TEXT        NOT NULL DEFAULT '',
+    code         TEXT,
+    starts_at    TIMESTAMPTZ,
+    ends_at      TIMESTAMPTZ,
+    version      INTEGER     NOT NULL DEFAULT 1,
+    created_at   TIMESTAMPTZ NOT NULL,
+    updated_at   TIMESTAMPTZ NOT NULL
+);
+
+CREATE UNIQUE INDEX promotions_code_key ON promotions (code) WHERE code IS NOT NULL;
@@ -0,0 +1,5 @@
+-- customer_id has no foreign key, the customers live on their own service
+CREATE TABLE IF NOT EXISTS customer_segments (
+    customer_id TEXT   PRIMARY KEY,
+    segments    TEXT[] NOT NULL
+);
@@ -0,0 +1,468 @@
+package grpc_port
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	pb "github.com/lucasmls/ecommerce/services/pricing/ports/grpc/proto"
+	"github.com/lucasmls/ecommerce/shared/auth"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	InternalServerError   = status.Error(codes.Internal, "Internal server error")
+	PermissionDeniedError = status.Error(codes.PermissionDenied, "permission-denied")
+)
+
+var (
+	ErrMissingLogger = errors.New("missing required dependency: Logger")
+	ErrMisingTracer  = errors.New("missing required dependency: Tracer")
+)
+
+// PricingResolver ...
+type PricingResolver struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+	App    domain.Application
+
+	pb.UnimplementedPricingServiceServer
+}
+
+func NewPricingResolver(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) (*PricingResolver, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMisingTracer
+	}
+
+	return &PricingResolver{
+		Logger: logger,
+		Tracer: tracer,
+		App:    app,
+	}, nil
+}
+
+// MustNewPricingResolver creates a new PricingResolver instance.
+// It panics if any error is found
+func MustNewPricingResolver(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) *PricingResolver {
+	pricingResolver, err := NewPricingResolver(logger, tracer, app)
+	if err != nil {
+		panic(err)
+	}
+
+	return pricingResolver
+}
+
+func (r *PricingResolver) CreatePriceList(ctx context.Context, req *pb.CreatePriceListRequest) (*pb.CreatePriceListResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.CreatePriceList")
+	defer span.End()
+
+	priceList, err := r.App.CreatePriceList(ctx, toDomainPriceList(req.PriceList))
+	if err != nil {
+		return nil, r.toError(err, "failed to create the price list")
+	}
+
+	return &pb.CreatePriceListResponse{Data: toProtoPriceList(priceList)}, nil
+}
+
+func (r *PricingResolver) UpdatePriceList(ctx context.Context, req *pb.UpdatePriceListRequest) (*pb.UpdatePriceListResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.UpdatePriceList")
+	defer span.End()
+
+	priceList, err := r.App.UpdatePriceList(ctx, toDomainPriceList(req.PriceList))
+	if err != nil {
+		return nil, r.toError(err, "failed to update the price list", zap.Int32("id", req.GetPriceList().GetId()))
+	}
+
+	return &pb.UpdatePriceListResponse{Data: toProtoPriceList(priceList)}, nil
+}
+
+func (r *PricingResolver) DeletePriceList(ctx context.Context, req *pb.DeletePriceListRequest) (*pb.DeletePriceListResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.DeletePriceList")
+	defer span.End()
+
+	if err := r.App.DeletePriceList(ctx, int(req.Id)); err != nil {
+		return nil, r.toError(err, "failed to delete the price list", zap.Int32("id", req.Id))
+	}
+
+	return &pb.DeletePriceListResponse{}, nil
+}
+
+func (r *PricingResolver) GetPriceList(ctx context.Context, req *pb.GetPriceListRequest) (*pb.GetPriceListResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.GetPriceList")
+	defer span.End()
+
+	priceList, err := r.App.GetPriceList(ctx, int(req.Id))
+	if err != nil {
+		return nil, r.toError(err, "failed to get the price list", zap.Int32("id", req.Id))
+	}
+
+	return &pb.GetPriceListResponse{Data: toProtoPriceList(priceList)}, nil
+}
+
+func (r *PricingResolver) ListPriceLists(ctx context.Context, req *pb.ListPriceListsRequest) (*pb.ListPriceListsResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.ListPriceLists")
+	defer span.End()
+
+	priceLists, err := r.App.ListPriceLists(ctx)
+	if err != nil {
+		return nil, r.toError(err, "failed to list the price lists")
+	}
+
+	response := make([]*pb.PriceList, 0, len(priceLists))
+	for _, priceList := range priceLists {
+		response = append(response, toProtoPriceList(priceList))
+	}
+
+	return &pb.ListPriceListsResponse{Data: response}, nil
+}
+
+func (r *PricingResolver) CreatePromotion(ctx context.Context, req *pb.CreatePromotionRequest) (*pb.CreatePromotionResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.CreatePromotion")
+	defer span.End()
+
+	promotion, err := r.App.CreatePromotion(ctx, toDomainPromotion(req.Promotion))
+	if err != nil {
+		return nil, r.toError(err, "failed to create the promotion")
+	}
+
+	return &pb.CreatePromotionResponse{Data: toProtoPromotion(promotion)}, nil
+}
+
+func (r *PricingResolver) UpdatePromotion(ctx context.Context, req *pb.UpdatePromotionRequest) (*pb.UpdatePromotionResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.UpdatePromotion")
+	defer span.End()
+
+	promotion, err := r.App.UpdatePromotion(ctx, toDomainPromotion(req.Promotion))
+	if err != nil {
+		return nil, r.toError(err, "failed to update the promotion", zap.Int32("id", req.GetPromotion().GetId()))
+	}
+
+	return &pb.UpdatePromotionResponse{Data: toProtoPromotion(promotion)}, nil
+}
+
+func (r *PricingResolver) DeletePromotion(ctx context.Context, req *pb.DeletePromotionRequest) (*pb.DeletePromotionResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.DeletePromotion")
+	defer span.End()
+
+	if err := r.App.DeletePromotion(ctx, int(req.Id)); err != nil {
+		return nil, r.toError(err, "failed to delete the promotion", zap.Int32("id", req.Id))
+	}
+
+	return &pb.DeletePromotionResponse{}, nil
+}
+
+func (r *PricingResolver) GetPromotion(ctx context.Context, req *pb.GetPromotionRequest) (*pb.GetPromotionResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.GetPromotion")
+	defer span.End()
+
+	promotion, err := r.App.GetPromotion(ctx, int(req.Id))
+	if err != nil {
+		return nil, r.toError(err, "failed to get the promotion", zap.Int32("id", req.Id))
+	}
+
+	return &pb.GetPromotionResponse{Data: toProtoPromotion(promotion)}, nil
+}
+
+func (r *PricingResolver) ListPromotions(ctx context.Context, req *pb.ListPromotionsRequest) (*pb.ListPromotionsResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.ListPromotions")
+	defer span.End()
+
+	promotions, err := r.App.ListPromotions(ctx)
+	if err != nil {
+		return nil, r.toError(err, "failed to list the promotions")
+	}
+
+	response := make([]*pb.Promotion, 0, len(promotions))
+	for _, promotion := range promotions {
+		response = append(response, toProtoPromotion(promotion))
+	}
+
+	return &pb.ListPromotionsResponse{Data: response}, nil
+}
+
+func (r *PricingResolver) SetCustomerSegments(ctx context.Context, req *pb.SetCustomerSegmentsRequest) (*pb.SetCustomerSegmentsResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.SetCustomerSegments")
+	defer span.End()
+
+	segments, err := r.App.SetCustomerSegments(ctx, domain.CustomerSegments{
+		CustomerID: req.GetSegments().GetCustomerId(),
+		Segments:   req.GetSegments().GetSegments(),
+	})
+	if err != nil {
+		return nil, r.toError(err, "failed to set the segments of the customer", zap.String("customerID", req.GetSegments().GetCustomerId()))
+	}
+
+	return &pb.SetCustomerSegmentsResponse{Data: toProtoCustomerSegments(segments)}, nil
+}
+
+func (r *PricingResolver) GetCustomerSegments(ctx context.Context, req *pb.GetCustomerSegmentsRequest) (*pb.GetCustomerSegmentsResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.GetCustomerSegments")
+	defer span.End()
+
+	segments, err := r.App.GetCustomerSegments(ctx, req.CustomerId)
+	if err != nil {
+		return nil, r.toError(err, "failed to get the segments of the customer", zap.String("customerID", req.CustomerId))
+	}
+
+	return &pb.GetCustomerSegmentsResponse{Data: toProtoCustomerSegments(segments)}, nil
+}
+
+func (r *PricingResolver) Quote(ctx context.Context, req *pb.QuoteRequest) (*pb.QuoteResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.Quote")
+	defer span.End()
+
+	customerID, err := quoteCustomer(ctx, req.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+
+	request := domain.QuoteRequest{
+		CustomerID: customerID,
+		Codes:      req.Codes,
+		Lines:      make([]domain.LineRequest, 0, len(req.Lines)),
+	}
+
+	if req.At != nil {
+		request.At = req.At.AsTime()
+	}
+
+	for _, line := range req.Lines {
+		request.Lines = append(request.Lines, domain.LineRequest{
+			ProductID: int(line.ProductId),
+			Quantity:  int(line.Quantity),
+			UnitPrice: int(line.UnitPrice),
+		})
+	}
+
+	quote, err := r.App.Quote(ctx, request)
+	if err != nil {
+		return nil, r.toError(err, "failed to quote", zap.String("customerID", customerID))
+	}
+
+	return &pb.QuoteResponse{Data: toProtoQuote(quote)}, nil
+}
+
+// quoteCustomer picks the customer a quote is made for. A call carrying the token of a customer is always
+// quoted for that customer, so nobody gets the prices of the segments of someone else.
+func quoteCustomer(ctx context.Context, customerID string) (string, error) {
+	claims, ok := auth.FromContext(ctx)
+	if !ok {
+		return customerID, nil
+	}
+
+	if customerID != "" && customerID != claims.Subject {
+		return "", PermissionDeniedError
+	}
+
+	return claims.Subject, nil
+}
+
+// toError maps the price list and Promotion errors to their gRPC codes, a discount code taken by another Promotion
+// being AlreadyExists. Unknown errors are logged and reported as internal.
+func (r *PricingResolver) toError(err error, message string, fields ...zap.Field) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidPriceList),
+		errors.Is(err, domain.ErrInvalidPromotion),
+		errors.Is(err, domain.ErrInvalidSchedule),
+		errors.Is(err, domain.ErrInvalidSegment),
+		errors.Is(err, domain.ErrInvalidCode),
+		errors.Is(err, domain.ErrInvalidQuote):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrPriceListNotFound),
+		errors.Is(err, domain.ErrPromotionNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrCodeAlreadyUsed):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrPriceListVersionConflict),
+		errors.Is(err, domain.ErrPromotionVersionConflict):
+		return status.Error(codes.Aborted, err.Error())
+	}
+
+	r.Logger.Error(message, append(fields, zap.Error(err))...)
+	return InternalServerError
+}
+
+func toDomainSchedule(schedule *pb.Schedule) domain.Schedule {
+	var response domain.Schedule
+	if schedule.GetStartsAt() != nil {
+		response.StartsAt = schedule.StartsAt.AsTime()
+	}
+
+	if schedule.GetEndsAt() != nil {
+		response.EndsAt = schedule.EndsAt.AsTime()
+	}
+
+	return response
+}
+
+func toDomainPriceList(priceList *pb.PriceList) domain.PriceList {
+	if priceList == nil {
+		return domain.PriceList{}
+	}
+
+	prices := make(map[int]int, len(priceList.Prices))
+	for _, price := range priceList.Prices {
+		prices[int(price.ProductId)] = int(price.Price)
+	}
+
+	return domain.PriceList{
+		ID:       int(priceList.Id),
+		Name:     priceList.Name,
+		Segment:  priceList.Segment,
+		Priority: int(priceList.Priority),
+		Schedule: toDomainSchedule(priceList.Schedule),
+		Prices:   prices,
+		Version:  int(priceList.Version),
+	}
+}
+
+func toDomainPromotion(promotion *pb.Promotion) domain.Promotion {
+	if promotion == nil {
+		return domain.Promotion{}
+	}
+
+	productIDs := make([]int, 0, len(promotion.ProductIds))
+	for _, id := range promotion.ProductIds {
+		productIDs = append(productIDs, int(id))
+	}
+
+	return domain.Promotion{
+		ID:          int(promotion.Id),
+		Name:        promotion.Name,
+		Kind:        domain.PromotionKind(promotion.Kind),
+		Percentage:  int(promotion.Percentage),
+		Amount:      int(promotion.Amount),
+		BuyQuantity: int(promotion.BuyQuantity),
+		GetQuantity: int(promotion.GetQuantity),
+		ProductIDs:  productIDs,
+		Segment:     promotion.Segment,
+		Code:        promotion.Code,
+		Schedule:    toDomainSchedule(promotion.Schedule),
+		Version:     int(promotion.Version),
+	}
+}
+
+func toProtoSchedule(schedule domain.Schedule) *pb.Schedule {
+	return &pb.Schedule{
+		StartsAt: toProtoTimestamp(schedule.StartsAt),
+		EndsAt:   toProtoTimestamp(schedule.EndsAt),
+	}
+}
+
+func toProtoPriceList(priceList domain.PriceList) *pb.PriceList {
+	prices := make([]*pb.ProductPrice, 0, len(priceList.Prices))
+	for productID, price := range priceList.Prices {
+		prices = append(prices, &pb.ProductPrice{ProductId: int32(productID), Price: int32(price)})
+	}
+
+	// the prices are kept on a map, they're sorted so the responses are stable
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].ProductId < prices[j].ProductId
+	})
+
+	return &pb.PriceList{
+		Id:        int32(priceList.ID),
+		Name:      priceList.Name,
+		Segment:   priceList.Segment,
+		Priority:  int32(priceList.Priority),
+		Schedule:  toProtoSchedule(priceList.Schedule),
+		Prices:    prices,
+		Version:   int32(priceList.Version),
+		CreatedAt: toProtoTimestamp(priceList.CreatedAt),
+		UpdatedAt: toProtoTimestamp(priceList.UpdatedAt),
+	}
+}
+
+func toProtoPromotion(promotion domain.Promotion) *pb.Promotion {
+	productIDs := make([]int32, 0, len(promotion.ProductIDs))
+	for _, id := range promotion.ProductIDs {
+		productIDs = append(productIDs, int32(id))
+	}
+
+	return &pb.Promotion{
+		Id:          int32(promotion.ID),
+		Name:        promotion.Name,
+		Kind:        string(promotion.Kind),
+		Percentage:  int32(promotion.Percentage),
+		Amount:      int32(promotion.Amount),
+		BuyQuantity: int32(promotion.BuyQuantity),
+		GetQuantity: int32(promotion.GetQuantity),
+		ProductIds:  productIDs,
+		Segment:     promotion.Segment,
+		Code:        promotion.Code,
+		Schedule:    toProtoSchedule(promotion.Schedule),
+		Version:     int32(promotion.Version),
+		CreatedAt:   toProtoTimestamp(promotion.CreatedAt),
+		UpdatedAt:   toProtoTimestamp(promotion.UpdatedAt),
+	}
+}
+
+func toProtoCustomerSegments(segments domain.CustomerSegments) *pb.CustomerSegments {
+	return &pb.CustomerSegments{
+		CustomerId: segments.CustomerID,
+		Segments:   segments.Segments,
+	}
+}
+
+func toProtoQuote(quote domain.Quote) *pb.Quote {
+	lines := make([]*pb.QuoteLine, 0, len(quote.Lines))
+	for _, line := range quote.Lines {
+		adjustments := make([]*pb.Adjustment, 0, len(line.Adjustments))
+		for _, adjustment := range line.Adjustments {
+			adjustments = append(adjustments, &pb.Adjustment{
+				Source:      string(adjustment.Source),
+				SourceId:    int32(adjustment.SourceID),
+				Description: adjustment.Description,
+				Code:        adjustment.Code,
+				Amount:      int32(adjustment.Amount),
+			})
+		}
+
+		lines = append(lines, &pb.QuoteLine{
+			ProductId:     int32(line.ProductID),
+			Quantity:      int32(line.Quantity),
+			BaseUnitPrice: int32(line.BaseUnitPrice),
+			UnitPrice:     int32(line.UnitPrice),
+			Subtotal:      int32(line.Subtotal),
+			Discount:      int32(line.Discount),
+			Total:         int32(line.Total),
+			Adjustments:   adjustments,
+		})
+	}
+
+	return &pb.Quote{
+		Lines:         lines,
+		Subtotal:      int32(quote.Subtotal),
+		Discount:      int32(quote.Discount),
+		Total:         int32(quote.Total),
+		AppliedCodes:  quote.AppliedCodes,
+		RejectedCodes: quote.RejectedCodes,
+		At:            toProtoTimestamp(quote.At),
+	}
+}
+
+func toProtoTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
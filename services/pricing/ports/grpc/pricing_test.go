@@ -0,0 +1,375 @@
+package grpc_port
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/shared/auth"
+	"github.com/lucasmls/ecommerce/shared/grpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	gGRPC "google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/lucasmls/ecommerce/services/pricing/domain"
+	"github.com/lucasmls/ecommerce/services/pricing/mocks"
+	protog "github.com/lucasmls/ecommerce/services/pricing/ports/grpc/proto"
+)
+
+const customerID = "0123456789abcdef0123456789abcdef"
+
+var tokenSecret = []byte("secret")
+
+type PricingResolverSuite struct {
+	suite.Suite
+
+	app    *mocks.Application
+	logger *zap.Logger
+	tracer trace.Tracer
+
+	grpcClient     protog.PricingServiceClient
+	grpcConnection *gGRPC.ClientConn
+}
+
+func (s *PricingResolverSuite) SetupSuite() {
+	s.logger = zap.NewNop()
+	s.tracer = trace.NewNoopTracerProvider().Tracer("")
+	s.app = &mocks.Application{}
+
+	grpcClient, grpcConnection := makeGrpcClientAndServer(
+		context.Background(),
+		s.logger,
+		s.tracer,
+		s.app,
+	)
+
+	s.grpcClient = grpcClient
+	s.grpcConnection = grpcConnection
+}
+
+func (s *PricingResolverSuite) TearDownSuite() {
+	err := s.grpcConnection.Close()
+	s.NoError(err)
+}
+
+// asCustomer returns a context carrying an access token of the given customer, sent along with the calls
+func (s *PricingResolverSuite) asCustomer(id string) context.Context {
+	now := time.Now()
+	claims := auth.Claims{Subject: id, IssuedAt: now, ExpiresAt: now.Add(time.Hour)}
+
+	token, err := auth.Sign(tokenSecret, claims)
+	s.Require().NoError(err)
+
+	return auth.NewContext(context.Background(), token, claims)
+}
+
+func (s *PricingResolverSuite) Test_NewPricingResolver() {
+	s.Run("Should fail to instantiate the PricingResolver in case a Logger isn't provided", func() {
+		_, err := NewPricingResolver(nil, nil, nil)
+
+		s.Equal(ErrMissingLogger, err)
+	})
+
+	s.Run("Should fail to instantiate the PricingResolver in case a Tracer isn't provided", func() {
+		_, err := NewPricingResolver(s.logger, nil, nil)
+
+		s.Equal(ErrMisingTracer, err)
+	})
+
+	s.Run("Should successfully instantiate the PricingResolver", func() {
+		_, err := NewPricingResolver(s.logger, s.tracer, nil)
+
+		s.NoError(err)
+	})
+}
+
+func (s *PricingResolverSuite) Test_CreatePriceList() {
+	startsAt := time.Date(2022, 11, 25, 0, 0, 0, 0, time.UTC)
+	endsAt := time.Date(2022, 11, 28, 0, 0, 0, 0, time.UTC)
+
+	priceList := domain.PriceList{
+		Name:     "Black Friday",
+		Priority: 10,
+		Schedule: domain.Schedule{StartsAt: startsAt, EndsAt: endsAt},
+		Prices:   map[int]int{1: 1500, 2: 900},
+	}
+
+	request := &protog.CreatePriceListRequest{
+		PriceList: &protog.PriceList{
+			Name:     "Black Friday",
+			Priority: 10,
+			Schedule: &protog.Schedule{StartsAt: timestamppb.New(startsAt), EndsAt: timestamppb.New(endsAt)},
+			Prices:   []*protog.ProductPrice{{ProductId: 2, Price: 900}, {ProductId: 1, Price: 1500}},
+		},
+	}
+
+	s.Run("Should return an invalid argument error in case the schedule is invalid", func() {
+		s.app.On("CreatePriceList", mock.AnythingOfType("*context.valueCtx"), priceList).Return(domain.PriceList{}, domain.ErrInvalidSchedule).Once()
+
+		_, err := s.grpcClient.CreatePriceList(context.Background(), request)
+
+		s.Equal(status.Error(codes.InvalidArgument, domain.ErrInvalidSchedule.Error()), err)
+	})
+
+	s.Run("Should return an internal error in case of an unknown failure", func() {
+		s.app.On("CreatePriceList", mock.AnythingOfType("*context.valueCtx"), priceList).Return(domain.PriceList{}, errors.New("connection refused")).Once()
+
+		_, err := s.grpcClient.CreatePriceList(context.Background(), request)
+
+		s.Equal(status.Error(codes.Internal, "Internal server error"), err)
+	})
+
+	s.Run("Should return the created price list with its prices sorted by product", func() {
+		created := priceList
+		created.ID = 1
+		created.Version = 1
+
+		s.app.On("CreatePriceList", mock.AnythingOfType("*context.valueCtx"), priceList).Return(created, nil).Once()
+
+		response, err := s.grpcClient.CreatePriceList(context.Background(), request)
+
+		s.NoError(err)
+		s.Equal(int32(1), response.Data.Id)
+		s.Equal(startsAt, response.Data.Schedule.StartsAt.AsTime())
+		s.Equal(endsAt, response.Data.Schedule.EndsAt.AsTime())
+		s.Len(response.Data.Prices, 2)
+		s.Equal(int32(1), response.Data.Prices[0].ProductId)
+		s.Equal(int32(1500), response.Data.Prices[0].Price)
+		s.Equal(int32(2), response.Data.Prices[1].ProductId)
+	})
+}
+
+func (s *PricingResolverSuite) Test_UpdatePromotion() {
+	promotion := domain.Promotion{
+		ID:         1,
+		Name:       "Ten off",
+		Kind:       domain.PromotionPercentage,
+		Percentage: 10,
+		ProductIDs: []int{1},
+		Code:       "TENOFF",
+		Version:    2,
+	}
+
+	request := &protog.UpdatePromotionRequest{
+		Promotion: &protog.Promotion{
+			Id:         1,
+			Name:       "Ten off",
+			Kind:       "percentage",
+			Percentage: 10,
+			ProductIds: []int32{1},
+			Code:       "TENOFF",
+			Version:    2,
+		},
+	}
+
+	s.Run("Should return an aborted error in case the promotion changed meanwhile", func() {
+		s.app.On("UpdatePromotion", mock.AnythingOfType("*context.valueCtx"), promotion).Return(domain.Promotion{}, domain.ErrPromotionVersionConflict).Once()
+
+		_, err := s.grpcClient.UpdatePromotion(context.Background(), request)
+
+		s.Equal(status.Error(codes.Aborted, domain.ErrPromotionVersionConflict.Error()), err)
+	})
+
+	s.Run("Should return an already exists error in case the code is used by another promotion", func() {
+		s.app.On("UpdatePromotion", mock.AnythingOfType("*context.valueCtx"), promotion).Return(domain.Promotion{}, domain.ErrCodeAlreadyUsed).Once()
+
+		_, err := s.grpcClient.UpdatePromotion(context.Background(), request)
+
+		s.Equal(status.Error(codes.AlreadyExists, domain.ErrCodeAlreadyUsed.Error()), err)
+	})
+
+	s.Run("Should return the updated promotion", func() {
+		updated := promotion
+		updated.Version = 3
+
+		s.app.On("UpdatePromotion", mock.AnythingOfType("*context.valueCtx"), promotion).Return(updated, nil).Once()
+
+		response, err := s.grpcClient.UpdatePromotion(context.Background(), request)
+
+		s.NoError(err)
+		s.Equal(int32(3), response.Data.Version)
+		s.Equal("percentage", response.Data.Kind)
+		s.Equal([]int32{1}, response.Data.ProductIds)
+		s.Nil(response.Data.Schedule.StartsAt)
+	})
+}
+
+func (s *PricingResolverSuite) Test_GetPromotion() {
+	s.Run("Should return a not found error in case the promotion doesn't exist", func() {
+		s.app.On("GetPromotion", mock.AnythingOfType("*context.valueCtx"), 7).Return(domain.Promotion{}, domain.ErrPromotionNotFound).Once()
+
+		_, err := s.grpcClient.GetPromotion(context.Background(), &protog.GetPromotionRequest{Id: 7})
+
+		s.Equal(status.Error(codes.NotFound, domain.ErrPromotionNotFound.Error()), err)
+	})
+}
+
+func (s *PricingResolverSuite) Test_SetCustomerSegments() {
+	s.Run("Should return the normalized segments of the customer", func() {
+		segments := domain.CustomerSegments{CustomerID: customerID, Segments: []string{"VIP", "wholesale"}}
+		s.app.On("SetCustomerSegments", mock.AnythingOfType("*context.valueCtx"), segments).Return(domain.CustomerSegments{
+			CustomerID: customerID,
+			Segments:   []string{"vip", "wholesale"},
+		}, nil).Once()
+
+		response, err := s.grpcClient.SetCustomerSegments(context.Background(), &protog.SetCustomerSegmentsRequest{
+			Segments: &protog.CustomerSegments{CustomerId: customerID, Segments: []string{"VIP", "wholesale"}},
+		})
+
+		s.NoError(err)
+		s.Equal([]string{"vip", "wholesale"}, response.Data.Segments)
+	})
+}
+
+func (s *PricingResolverSuite) Test_Quote() {
+	at := time.Date(2022, 11, 25, 12, 0, 0, 0, time.UTC)
+
+	lines := []*protog.QuoteLineRequest{{ProductId: 1, Quantity: 2, UnitPrice: 2000}}
+	request := func(customerID string) domain.QuoteRequest {
+		return domain.QuoteRequest{
+			CustomerID: customerID,
+			Codes:      []string{"TENOFF"},
+			At:         at,
+			Lines:      []domain.LineRequest{{ProductID: 1, Quantity: 2, UnitPrice: 2000}},
+		}
+	}
+
+	s.Run("Should return a permission denied error in case the token belongs to another customer", func() {
+		_, err := s.grpcClient.Quote(s.asCustomer("someone-else"), &protog.QuoteRequest{
+			CustomerId: customerID,
+			Codes:      []string{"TENOFF"},
+			At:         timestamppb.New(at),
+			Lines:      lines,
+		})
+
+		s.Equal(status.Error(codes.PermissionDenied, "permission-denied"), err)
+	})
+
+	s.Run("Should return an invalid argument error in case the request is invalid", func() {
+		s.app.On("Quote", mock.AnythingOfType("*context.valueCtx"), request("")).Return(domain.Quote{}, domain.ErrInvalidQuote).Once()
+
+		_, err := s.grpcClient.Quote(context.Background(), &protog.QuoteRequest{
+			Codes: []string{"TENOFF"},
+			At:    timestamppb.New(at),
+			Lines: lines,
+		})
+
+		s.Equal(status.Error(codes.InvalidArgument, domain.ErrInvalidQuote.Error()), err)
+	})
+
+	s.Run("Should quote for the customer holding the token", func() {
+		s.app.On("Quote", mock.AnythingOfType("*context.valueCtx"), request(customerID)).Return(domain.Quote{
+			Lines: []domain.QuoteLine{
+				{
+					ProductID:     1,
+					Quantity:      2,
+					BaseUnitPrice: 2000,
+					UnitPrice:     1500,
+					Subtotal:      4000,
+					Discount:      1300,
+					Total:         2700,
+					Adjustments: []domain.Adjustment{
+						{Source: domain.AdjustmentPriceList, SourceID: 1, Description: "Black Friday", Amount: 1000},
+						{Source: domain.AdjustmentPromotion, SourceID: 2, Description: "Ten off", Code: "TENOFF", Amount: 300},
+					},
+				},
+			},
+			Subtotal:      4000,
+			Discount:      1300,
+			Total:         2700,
+			AppliedCodes:  []string{"TENOFF"},
+			RejectedCodes: []string{},
+			At:            at,
+		}, nil).Once()
+
+		response, err := s.grpcClient.Quote(s.asCustomer(customerID), &protog.QuoteRequest{
+			Codes: []string{"TENOFF"},
+			At:    timestamppb.New(at),
+			Lines: lines,
+		})
+
+		s.NoError(err)
+		s.Equal(int32(2700), response.Data.Total)
+		s.Equal([]string{"TENOFF"}, response.Data.AppliedCodes)
+		s.Len(response.Data.Lines, 1)
+		s.Len(response.Data.Lines[0].Adjustments, 2)
+		s.Equal("price_list", response.Data.Lines[0].Adjustments[0].Source)
+		s.Equal("TENOFF", response.Data.Lines[0].Adjustments[1].Code)
+		s.Equal(int32(300), response.Data.Lines[0].Adjustments[1].Amount)
+	})
+}
+
+func TestPricingResolverSuite(t *testing.T) {
+	suite.Run(t, new(PricingResolverSuite))
+}
+
+func makeGrpcClientAndServer(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	tracerM trace.Tracer,
+	appM domain.Application,
+) (protog.PricingServiceClient, *gGRPC.ClientConn) {
+	listener := makeGrpcServer(ctx, loggerM, tracerM, appM)
+	grpcClient, grpcConnection := makeGrpcClient(ctx, loggerM, listener)
+
+	return grpcClient, grpcConnection
+}
+
+func makeGrpcClient(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	listener *bufconn.Listener,
+) (protog.PricingServiceClient, *gGRPC.ClientConn) {
+	pricingServiceGRPCClient := grpc.MustNewClient(grpc.ClientInput{
+		Logger: loggerM,
+		AdditionalDialOptions: []gGRPC.DialOption{
+			gGRPC.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+				return listener.Dial()
+			}),
+		},
+	})
+
+	pricingServiceConn := pricingServiceGRPCClient.MustConnect(ctx)
+
+	grpcClient := protog.NewPricingServiceClient(pricingServiceConn)
+	return grpcClient, pricingServiceConn
+}
+
+func makeGrpcServer(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	tracerM trace.Tracer,
+	appM domain.Application,
+) *bufconn.Listener {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.MustNewServer(grpc.ServerInput{
+		Port:   1234,
+		Logger: loggerM,
+		Registrator: func(server gGRPC.ServiceRegistrar) {
+			protog.RegisterPricingServiceServer(server, &PricingResolver{
+				Logger: loggerM,
+				Tracer: tracerM,
+				App:    appM,
+			})
+		},
+		Listener:    listener,
+		TokenSecret: tokenSecret,
+	})
+
+	go func() {
+		if err := grpcServer.Run(ctx); err != nil {
+			panic(err)
+		}
+	}()
+
+	return listener
+}
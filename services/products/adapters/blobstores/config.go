@@ -0,0 +1,58 @@
+package blobstores
+
+import (
+	"fmt"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// Supported blob stores
+const (
+	StoreFileSystem = "fs"
+	StoreS3         = "s3"
+)
+
+// Config selects and configures the BlobStore of the commands, it's meant to be squashed into their configs.
+type Config struct {
+	// Store is either fs, the default, or s3
+	Store string `mapstructure:"MEDIA_STORE"`
+	// Directory is where the fs store keeps the blobs
+	Directory string `mapstructure:"MEDIA_DIR"`
+	// BaseURL is where the blobs are served from: the path the fs store Handler is mounted on,
+	// or the public URL of the s3 bucket, as in a CDN
+	BaseURL string `mapstructure:"MEDIA_BASE_URL"`
+
+	S3Endpoint        string `mapstructure:"S3_ENDPOINT"`
+	S3Region          string `mapstructure:"S3_REGION"`
+	S3Bucket          string `mapstructure:"S3_BUCKET"`
+	S3AccessKeyID     string `mapstructure:"S3_ACCESS_KEY_ID"`
+	S3SecretAccessKey string `mapstructure:"S3_SECRET_ACCESS_KEY"`
+}
+
+// NewFromConfig creates the BlobStore selected by the config
+func NewFromConfig(logger *zap.Logger, tracer trace.Tracer, config Config) (domain.BlobStore, error) {
+	switch config.Store {
+	case "", StoreFileSystem:
+		return NewFileSystemBlobStore(FileSystemBlobStoreInput{
+			Logger:    logger,
+			Tracer:    tracer,
+			Directory: config.Directory,
+			BaseURL:   config.BaseURL,
+		})
+	case StoreS3:
+		return NewS3BlobStore(S3BlobStoreInput{
+			Logger:          logger,
+			Tracer:          tracer,
+			Endpoint:        config.S3Endpoint,
+			Region:          config.S3Region,
+			Bucket:          config.S3Bucket,
+			AccessKeyID:     config.S3AccessKeyID,
+			SecretAccessKey: config.S3SecretAccessKey,
+			PublicURL:       config.BaseURL,
+		})
+	}
+
+	return nil, fmt.Errorf("unknown media store %q, it must be one of: fs, s3", config.Store)
+}
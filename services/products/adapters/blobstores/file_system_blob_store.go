@@ -0,0 +1,169 @@
+package blobstores
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var ErrInvalidKey = errors.New("invalid-blob-key")
+
+// FileSystemBlobStoreInput is the input (aka dependencies) to create a FileSystemBlobStore.
+type FileSystemBlobStoreInput struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	// Directory is where the blobs are stored, it's created if missing.
+	Directory string
+	// BaseURL is where the Handler is served from, as in http://localhost:8080/media.
+	BaseURL string
+}
+
+// FileSystemBlobStore stores the blobs as files under a directory, each key being a path relative to it.
+type FileSystemBlobStore struct {
+	in FileSystemBlobStoreInput
+}
+
+// NewFileSystemBlobStore creates a new FileSystemBlobStore.
+func NewFileSystemBlobStore(in FileSystemBlobStoreInput) (*FileSystemBlobStore, error) {
+	if in.Directory == "" {
+		return nil, errors.New("missing required dependency: Directory")
+	}
+
+	if err := os.MkdirAll(in.Directory, 0o755); err != nil {
+		return nil, err
+	}
+
+	in.BaseURL = strings.TrimSuffix(in.BaseURL, "/")
+
+	return &FileSystemBlobStore{in: in}, nil
+}
+
+// MustNewFileSystemBlobStore creates a new FileSystemBlobStore.
+// It panics if any error is found.
+func MustNewFileSystemBlobStore(in FileSystemBlobStoreInput) *FileSystemBlobStore {
+	store, err := NewFileSystemBlobStore(in)
+	if err != nil {
+		panic(err)
+	}
+
+	return store
+}
+
+// Put writes the content to a temporary file first, so a failed write never replaces a previous blob.
+func (s *FileSystemBlobStore) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	_, span := s.in.Tracer.Start(ctx, "blobstore.Put")
+	defer span.End()
+
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	written, err := io.Copy(file, content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if written != size {
+		return fmt.Errorf("blob %s has %d bytes, expected %d", key, written, size)
+	}
+
+	if err := os.Chmod(file.Name(), 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), name)
+}
+
+func (s *FileSystemBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	_, span := s.in.Tracer.Start(ctx, "blobstore.Get")
+	defer span.End()
+
+	name, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, domain.ErrBlobNotFound
+		}
+
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (s *FileSystemBlobStore) Delete(ctx context.Context, key string) error {
+	_, span := s.in.Tracer.Start(ctx, "blobstore.Delete")
+	defer span.End()
+
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// URL returns where the Handler serves the blob from.
+func (s *FileSystemBlobStore) URL(key string) string {
+	return s.in.BaseURL + "/" + escapePath(key)
+}
+
+// Handler serves the blobs by their keys, it's meant to be mounted on the path of the BaseURL with http.StripPrefix.
+// Directories are never listed.
+func (s *FileSystemBlobStore) Handler() http.Handler {
+	files := http.FileServer(http.Dir(s.in.Directory))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/") {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		files.ServeHTTP(w, r)
+	})
+}
+
+// path converts a key into the path of its file, rejecting the keys that would escape the directory.
+func (s *FileSystemBlobStore) path(key string) (string, error) {
+	if key == "" || path.IsAbs(key) || path.Clean(key) != key || key == ".." || strings.HasPrefix(key, "../") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+
+	return filepath.Join(s.in.Directory, filepath.FromSlash(key)), nil
+}
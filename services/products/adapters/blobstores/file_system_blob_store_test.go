@@ -0,0 +1,105 @@
+package blobstores
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type FileSystemBlobStoreSuite struct {
+	suite.Suite
+
+	directory string
+	store     *FileSystemBlobStore
+}
+
+func (s *FileSystemBlobStoreSuite) SetupTest() {
+	s.directory = filepath.Join(s.T().TempDir(), "media")
+
+	s.store = MustNewFileSystemBlobStore(FileSystemBlobStoreInput{
+		Logger:    zap.NewNop(),
+		Tracer:    trace.NewNoopTracerProvider().Tracer(""),
+		Directory: s.directory,
+		BaseURL:   "http://localhost:8080/media/",
+	})
+}
+
+func (s *FileSystemBlobStoreSuite) Test_PutGetDelete() {
+	s.Run("Should store, fetch and remove the files", func() {
+		ctx := context.Background()
+
+		s.Require().NoError(s.store.Put(ctx, "products/1/a.png", strings.NewReader("png"), 3, "image/png"))
+
+		stored, err := os.ReadFile(filepath.Join(s.directory, "products", "1", "a.png"))
+		s.Require().NoError(err)
+		s.Equal("png", string(stored))
+
+		content, err := s.store.Get(ctx, "products/1/a.png")
+		s.Require().NoError(err)
+		got, _ := io.ReadAll(content)
+		content.Close()
+		s.Equal("png", string(got))
+
+		s.NoError(s.store.Delete(ctx, "products/1/a.png"))
+		s.NoError(s.store.Delete(ctx, "products/1/a.png"))
+
+		_, err = s.store.Get(ctx, "products/1/a.png")
+		s.Equal(domain.ErrBlobNotFound, err)
+	})
+
+	s.Run("Should keep the previous file in case the content is incomplete", func() {
+		ctx := context.Background()
+		s.Require().NoError(s.store.Put(ctx, "products/1/b.png", strings.NewReader("png"), 3, "image/png"))
+
+		err := s.store.Put(ctx, "products/1/b.png", strings.NewReader("pn"), 3, "image/png")
+		s.Error(err)
+
+		stored, err := os.ReadFile(filepath.Join(s.directory, "products", "1", "b.png"))
+		s.NoError(err)
+		s.Equal("png", string(stored))
+	})
+
+	s.Run("Should reject keys escaping the directory", func() {
+		for _, key := range []string{"", "../secret", "products/../../secret", "/etc/passwd", "products//a.png"} {
+			err := s.store.Put(context.Background(), key, strings.NewReader("png"), 3, "image/png")
+
+			s.ErrorIs(err, ErrInvalidKey, key)
+		}
+	})
+}
+
+func (s *FileSystemBlobStoreSuite) Test_Handler() {
+	s.Run("Should serve the files from the URLs of their keys, without listing the directories", func() {
+		s.Require().NoError(s.store.Put(context.Background(), "products/1/a.png", strings.NewReader("png"), 3, "image/png"))
+		s.Equal("http://localhost:8080/media/products/1/a.png", s.store.URL("products/1/a.png"))
+
+		server := httptest.NewServer(http.StripPrefix("/media", s.store.Handler()))
+		defer server.Close()
+
+		res, err := http.Get(server.URL + "/media/products/1/a.png")
+		s.Require().NoError(err)
+		body, _ := io.ReadAll(res.Body)
+		res.Body.Close()
+		s.Equal(http.StatusOK, res.StatusCode)
+		s.Equal("png", string(body))
+
+		res, err = http.Get(server.URL + "/media/products/1/")
+		s.Require().NoError(err)
+		res.Body.Close()
+		s.Equal(http.StatusNotFound, res.StatusCode)
+	})
+}
+
+func TestFileSystemBlobStoreSuite(t *testing.T) {
+	suite.Run(t, new(FileSystemBlobStoreSuite))
+}
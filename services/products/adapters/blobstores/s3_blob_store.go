@@ -0,0 +1,329 @@
+package blobstores
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	// unsignedPayload skips hashing the uploads, their integrity is left to TLS
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	// emptyPayloadHash is the SHA-256 of an empty body
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	amzDateFormat = "20060102T150405Z"
+)
+
+// S3BlobStoreInput is the input (aka dependencies) to create a S3BlobStore.
+type S3BlobStoreInput struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	// Endpoint is the URL of the S3 compatible API, as in https://s3.us-east-1.amazonaws.com or http://localhost:9000.
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	// PublicURL is where the objects of the bucket are served from, as in a CDN.
+	// It defaults to the path of the bucket on the Endpoint.
+	PublicURL string
+
+	HTTPClient *http.Client
+	// Now defaults to time.Now, it's only meant to be replaced by the tests.
+	Now func() time.Time
+}
+
+// S3BlobStore stores the blobs as objects of a bucket of an S3 compatible API, as AWS S3 or MinIO.
+// The requests are path-style and signed with AWS Signature Version 4.
+type S3BlobStore struct {
+	in S3BlobStoreInput
+}
+
+// NewS3BlobStore creates a new S3BlobStore.
+func NewS3BlobStore(in S3BlobStoreInput) (*S3BlobStore, error) {
+	if in.Endpoint == "" {
+		return nil, errors.New("missing required dependency: Endpoint")
+	}
+
+	if in.Bucket == "" {
+		return nil, errors.New("missing required dependency: Bucket")
+	}
+
+	if in.AccessKeyID == "" || in.SecretAccessKey == "" {
+		return nil, errors.New("missing required dependency: credentials")
+	}
+
+	if in.Region == "" {
+		in.Region = "us-east-1"
+	}
+
+	if in.HTTPClient == nil {
+		in.HTTPClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	if in.Now == nil {
+		in.Now = time.Now
+	}
+
+	in.Endpoint = strings.TrimSuffix(in.Endpoint, "/")
+	if in.PublicURL == "" {
+		in.PublicURL = in.Endpoint + "/" + in.Bucket
+	}
+
+	in.PublicURL = strings.TrimSuffix(in.PublicURL, "/")
+
+	return &S3BlobStore{in: in}, nil
+}
+
+// MustNewS3BlobStore creates a new S3BlobStore.
+// It panics if any error is found.
+func MustNewS3BlobStore(in S3BlobStoreInput) *S3BlobStore {
+	store, err := NewS3BlobStore(in)
+	if err != nil {
+		panic(err)
+	}
+
+	return store
+}
+
+func (s *S3BlobStore) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	ctx, span := s.in.Tracer.Start(ctx, "blobstore.Put")
+	defer span.End()
+
+	req, err := s.newRequest(ctx, http.MethodPut, key, content)
+	if err != nil {
+		return err
+	}
+
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	res, err := s.do(req, unsignedPayload)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return responseError(res)
+	}
+
+	return nil
+}
+
+func (s *S3BlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	ctx, span := s.in.Tracer.Start(ctx, "blobstore.Get")
+	defer span.End()
+
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return nil, err
+	}
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return res.Body, nil
+	case http.StatusNotFound:
+		res.Body.Close()
+		return nil, domain.ErrBlobNotFound
+	default:
+		defer res.Body.Close()
+		return nil, responseError(res)
+	}
+}
+
+func (s *S3BlobStore) Delete(ctx context.Context, key string) error {
+	ctx, span := s.in.Tracer.Start(ctx, "blobstore.Delete")
+	defer span.End()
+
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.do(req, emptyPayloadHash)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return responseError(res)
+	}
+}
+
+// URL returns where the object is served from, on the PublicURL.
+func (s *S3BlobStore) URL(key string) string {
+	return s.in.PublicURL + "/" + escapePath(key)
+}
+
+func (s *S3BlobStore) newRequest(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	if key == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+
+	return http.NewRequestWithContext(ctx, method, s.in.Endpoint+"/"+escapePath(s.in.Bucket+"/"+key), body)
+}
+
+// do signs the request, with the hash of its payload, and sends it.
+func (s *S3BlobStore) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	signRequest(req, payloadHash, signingCredentials{
+		AccessKeyID:     s.in.AccessKeyID,
+		SecretAccessKey: s.in.SecretAccessKey,
+		Region:          s.in.Region,
+		Service:         "s3",
+	}, s.in.Now())
+
+	return s.in.HTTPClient.Do(req)
+}
+
+// responseError describes an unexpected response, including the start of its body, where S3 details the error.
+func responseError(res *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+
+	return fmt.Errorf("unexpected S3 response %s: %s", res.Status, strings.TrimSpace(string(body)))
+}
+
+// signingCredentials are what the requests are signed with
+type signingCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	Region          string
+	Service         string
+}
+
+// signRequest sets the headers of AWS Signature Version 4 on the request, signing its host along with every
+// header already set. The hash of the payload is either the hex encoded SHA-256 of the body or unsignedPayload.
+func signRequest(req *http.Request, payloadHash string, credentials signingCredentials, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format(amzDateFormat)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.Join(values, ",")
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		escapePath(req.URL.Path),
+		canonicalQuery(req),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{now.Format("20060102"), credentials.Region, credentials.Service, "aws4_request"}, "/")
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := []byte("AWS4" + credentials.SecretAccessKey)
+	for _, part := range []string{now.Format("20060102"), credentials.Region, credentials.Service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		credentials.AccessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+// canonicalQuery sorts the query parameters by name and then by value, encoding them as S3 expects
+func canonicalQuery(req *http.Request) string {
+	query := req.URL.Query()
+
+	var params []string
+	for name, values := range query {
+		for _, value := range values {
+			params = append(params, escape(name, true)+"="+escape(value, true))
+		}
+	}
+
+	sort.Strings(params)
+
+	return strings.Join(params, "&")
+}
+
+// escapePath escapes every segment of a slash separated path
+func escapePath(path string) string {
+	return escape(path, false)
+}
+
+// escape percent-encodes everything but the unreserved characters, and the slashes unless escapeSlash is set
+func escape(value string, escapeSlash bool) string {
+	var escaped strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9', b == '-', b == '_', b == '.', b == '~':
+			escaped.WriteByte(b)
+		case b == '/' && !escapeSlash:
+			escaped.WriteByte(b)
+		default:
+			fmt.Fprintf(&escaped, "%%%02X", b)
+		}
+	}
+
+	return escaped.String()
+}
+
+func hashHex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
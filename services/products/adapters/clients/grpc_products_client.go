@@ -0,0 +1,783 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	pb "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+const (
+	// importChunkSize is the size of the catalog chunks sent by ImportCatalog
+	importChunkSize = 32 * 1024
+	// imageChunkSize is the size of the image chunks sent by UploadProductImage
+	imageChunkSize = 32 * 1024
+)
+
+var (
+	ErrMissingLogger         = errors.New("missing required dependency: Logger")
+	ErrMissingTracer         = errors.New("missing required dependency: Tracer")
+	ErrMissingProductsClient = errors.New("missing required dependency: ProductsClient")
+)
+
+// GrpcProductsClient implements domain.CLI on top of the ProductsService gRPC API
+type GrpcProductsClient struct {
+	Logger         *zap.Logger
+	Tracer         trace.Tracer
+	ProductsClient pb.ProductsServiceClient
+}
+
+// NewGrpcProductsClient creates a new GrpcProductsClient instance
+func NewGrpcProductsClient(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	productsClient pb.ProductsServiceClient,
+) (*GrpcProductsClient, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMissingTracer
+	}
+
+	if productsClient == nil {
+		return nil, ErrMissingProductsClient
+	}
+
+	return &GrpcProductsClient{
+		Logger:         logger,
+		Tracer:         tracer,
+		ProductsClient: productsClient,
+	}, nil
+}
+
+// MustNewGrpcProductsClient creates a new GrpcProductsClient instance
+// It panics if any error is found
+func MustNewGrpcProductsClient(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	productsClient pb.ProductsServiceClient,
+) *GrpcProductsClient {
+	client, err := NewGrpcProductsClient(logger, tracer, productsClient)
+	if err != nil {
+		panic(err)
+	}
+
+	return client
+}
+
+// ListProducts fetches the list of Products from the ProductsService.
+func (c *GrpcProductsClient) ListProducts(ctx context.Context, filter domain.ListProductsFilter) ([]domain.Product, error) {
+	ctx, span := c.Tracer.Start(ctx, "client.ListProducts")
+	defer span.End()
+
+	req := &pb.ListRequest{
+		Filter:         toProtoProductFilter(filter.ProductFilter),
+		IncludeDeleted: filter.IncludeDeleted,
+		OnlyDeleted:    filter.OnlyDeleted,
+		Sort:           toProtoSortKeys(filter.Sort),
+	}
+	for _, id := range filter.IDs {
+		req.Ids = append(req.Ids, int32(id))
+	}
+
+	res, err := c.ProductsClient.List(ctx, req)
+	if err != nil {
+		return nil, fromStatus(fromInvalidArgument(err, domain.ErrInvalidPriceRange, domain.ErrInvalidSort))
+	}
+
+	products := make([]domain.Product, 0, len(res.Data))
+	for _, product := range res.Data {
+		products = append(products, toDomainProduct(product))
+	}
+
+	return products, nil
+}
+
+// GetProduct fetches a single Product from the ProductsService.
+func (c *GrpcProductsClient) GetProduct(ctx context.Context, id int) (domain.Product, error) {
+	ctx, span := c.Tracer.Start(ctx, "client.GetProduct")
+	defer span.End()
+
+	products, err := c.ListProducts(ctx, domain.ListProductsFilter{IDs: []int{id}})
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	for _, product := range products {
+		if product.ID == id {
+			return product, nil
+		}
+	}
+
+	return domain.Product{}, domain.ErrProductNotFound
+}
+
+// RegisterProduct registers a new Product through the ProductsService.
+func (c *GrpcProductsClient) RegisterProduct(ctx context.Context, product domain.Product) (domain.Product, error) {
+	ctx, span := c.Tracer.Start(ctx, "client.RegisterProduct")
+	defer span.End()
+
+	res, err := c.ProductsClient.Register(ctx, toProtoProduct(product))
+	if err != nil {
+		return domain.Product{}, fromStatus(err)
+	}
+
+	return toDomainProduct(res.Data), nil
+}
+
+// UpdateProduct updates a Product through the ProductsService.
+func (c *GrpcProductsClient) UpdateProduct(ctx context.Context, product domain.Product) (domain.Product, error) {
+	ctx, span := c.Tracer.Start(ctx, "client.UpdateProduct")
+	defer span.End()
+
+	res, err := c.ProductsClient.Update(ctx, toProtoProduct(product))
+	if err != nil {
+		return domain.Product{}, fromStatus(err)
+	}
+
+	return toDomainProduct(res.Data), nil
+}
+
+// PatchProduct changes only the listed fields of a Product through the ProductsService.
+func (c *GrpcProductsClient) PatchProduct(ctx context.Context, patch domain.ProductPatch) (domain.Product, error) {
+	ctx, span := c.Tracer.Start(ctx, "client.PatchProduct")
+	defer span.End()
+
+	mask := &fieldmaskpb.FieldMask{Paths: make([]string, 0, len(patch.Fields))}
+	for _, field := range patch.Fields {
+		mask.Paths = append(mask.Paths, string(field))
+	}
+
+	res, err := c.ProductsClient.Patch(ctx, &pb.PatchRequest{
+		Product:    toProtoProduct(patch.Product),
+		UpdateMask: mask,
+	})
+	if err != nil {
+		return domain.Product{}, fromStatus(err)
+	}
+
+	return toDomainProduct(res.Data), nil
+}
+
+// DeleteProduct moves a Product to the trash through the ProductsService.
+func (c *GrpcProductsClient) DeleteProduct(ctx context.Context, id int) error {
+	ctx, span := c.Tracer.Start(ctx, "client.DeleteProduct")
+	defer span.End()
+
+	_, err := c.ProductsClient.Delete(ctx, &pb.DeleteRequest{Id: int32(id)})
+	if err != nil {
+		return fromStatus(err)
+	}
+
+	return nil
+}
+
+// RestoreProduct brings a Product back from the trash through the ProductsService.
+func (c *GrpcProductsClient) RestoreProduct(ctx context.Context, id int) (domain.Product, error) {
+	ctx, span := c.Tracer.Start(ctx, "client.RestoreProduct")
+	defer span.End()
+
+	res, err := c.ProductsClient.Restore(ctx, &pb.RestoreRequest{Id: int32(id)})
+	if err != nil {
+		return domain.Product{}, fromStatus(err)
+	}
+
+	return toDomainProduct(res.Data), nil
+}
+
+// SearchProducts runs a full-text search through the ProductsService.
+func (c *GrpcProductsClient) SearchProducts(ctx context.Context, search domain.ProductSearch) ([]domain.ProductSearchResult, error) {
+	ctx, span := c.Tracer.Start(ctx, "client.SearchProducts")
+	defer span.End()
+
+	res, err := c.ProductsClient.Search(ctx, &pb.SearchRequest{
+		Query:  search.Query,
+		Limit:  int32(search.Limit),
+		Offset: int32(search.Offset),
+		Sort:   toProtoSortKeys(search.Sort),
+	})
+	if err != nil {
+		return nil, fromInvalidArgument(err,
+			domain.ErrEmptySearchQuery,
+			domain.ErrTooManySearchTerms,
+			domain.ErrInvalidSearchLimit,
+			domain.ErrInvalidSort,
+		)
+	}
+
+	results := make([]domain.ProductSearchResult, 0, len(res.Results))
+	for _, result := range res.Results {
+		results = append(results, domain.ProductSearchResult{
+			Product: toDomainProduct(result.Product),
+			Score:   result.Score,
+			Highlights: domain.ProductHighlights{
+				Name:        result.NameHighlight,
+				Description: result.DescriptionHighlight,
+			},
+		})
+	}
+
+	return results, nil
+}
+
+// GetProductFacets counts the Products matching a filter through the ProductsService.
+func (c *GrpcProductsClient) GetProductFacets(ctx context.Context, filter domain.FacetsFilter) (domain.ProductFacets, error) {
+	ctx, span := c.Tracer.Start(ctx, "client.GetProductFacets")
+	defer span.End()
+
+	req := &pb.GetFacetsRequest{
+		Filter: toProtoProductFilter(filter.ProductFilter),
+	}
+	for _, boundary := range filter.PriceBoundaries {
+		req.PriceBoundaries = append(req.PriceBoundaries, int32(boundary))
+	}
+
+	res, err := c.ProductsClient.GetFacets(ctx, req)
+	if err != nil {
+		return domain.ProductFacets{}, fromInvalidArgument(err, domain.ErrInvalidPriceRange, domain.ErrInvalidPriceBoundaries)
+	}
+
+	facets := domain.ProductFacets{
+		Total:       int(res.Total),
+		PriceRanges: make([]domain.PriceRangeBucket, 0, len(res.PriceRanges)),
+		Categories:  toDomainFacetBuckets(res.Categories),
+		Attributes:  make([]domain.AttributeFacet, 0, len(res.Attributes)),
+	}
+
+	for _, bucket := range res.PriceRanges {
+		facets.PriceRanges = append(facets.PriceRanges, domain.PriceRangeBucket{
+			From:  int(bucket.From),
+			To:    int(bucket.To),
+			Count: int(bucket.Count),
+		})
+	}
+
+	for _, attribute := range res.Attributes {
+		facets.Attributes = append(facets.Attributes, domain.AttributeFacet{
+			Name:   attribute.Name,
+			Values: toDomainFacetBuckets(attribute.Values),
+		})
+	}
+
+	return facets, nil
+}
+
+// GetProductHistory lists the changes made to a Product through the ProductsService.
+func (c *GrpcProductsClient) GetProductHistory(ctx context.Context, filter domain.ProductHistoryFilter) ([]domain.AuditEntry, error) {
+	ctx, span := c.Tracer.Start(ctx, "client.GetProductHistory")
+	defer span.End()
+
+	res, err := c.ProductsClient.GetProductHistory(ctx, &pb.GetProductHistoryRequest{
+		ProductId: int32(filter.ProductID),
+		BeforeId:  int32(filter.BeforeID),
+		Limit:     int32(filter.Limit),
+	})
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			return nil, domain.ErrInvalidHistoryLimit
+		}
+
+		return nil, err
+	}
+
+	entries := make([]domain.AuditEntry, 0, len(res.Entries))
+	for _, entry := range res.Entries {
+		e := domain.AuditEntry{
+			ID:        int(entry.Id),
+			ProductID: int(entry.ProductId),
+			Action:    domain.AuditAction(entry.Action),
+			Actor:     entry.Actor,
+			TraceID:   entry.TraceId,
+			CreatedAt: entry.CreatedAt.AsTime(),
+		}
+
+		if entry.Before != nil {
+			before := toDomainProduct(entry.Before)
+			e.Before = &before
+		}
+
+		if entry.After != nil {
+			after := toDomainProduct(entry.After)
+			e.After = &after
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+// RegisterProducts streams a batch of Products to the ProductsService BulkRegister RPC.
+func (c *GrpcProductsClient) RegisterProducts(ctx context.Context, products []domain.Product) ([]domain.ProductResult, error) {
+	ctx, span := c.Tracer.Start(ctx, "client.RegisterProducts")
+	defer span.End()
+
+	stream, err := c.ProductsClient.BulkRegister(ctx)
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+
+	for _, product := range products {
+		if err := stream.Send(toProtoProduct(product)); err != nil {
+			// the server closed the stream, the actual error comes from CloseAndRecv
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return nil, fromStatus(err)
+		}
+	}
+
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+
+	results := make([]domain.ProductResult, len(products))
+	for _, result := range res.Results {
+		if int(result.Index) >= len(results) {
+			continue
+		}
+
+		if result.Error != "" {
+			results[result.Index] = domain.ProductResult{Err: errors.New(result.Error)}
+			continue
+		}
+
+		results[result.Index] = domain.ProductResult{Product: toDomainProduct(result.Data)}
+	}
+
+	return results, nil
+}
+
+// ExportProducts consumes the ProductsService Export RPC, calling fn for every Product.
+func (c *GrpcProductsClient) ExportProducts(ctx context.Context, fn func(domain.Product) error) error {
+	ctx, span := c.Tracer.Start(ctx, "client.ExportProducts")
+	defer span.End()
+
+	stream, err := c.ProductsClient.Export(ctx, &pb.ExportRequest{})
+	if err != nil {
+		return fromStatus(err)
+	}
+
+	for {
+		product, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fromStatus(err)
+		}
+
+		if err := fn(toDomainProduct(product)); err != nil {
+			return err
+		}
+	}
+}
+
+// ImportCatalog streams the catalog file in chunks and returns the server's import report
+func (c *GrpcProductsClient) ImportCatalog(ctx context.Context, r io.Reader, options domain.ImportOptions) (domain.ImportReport, error) {
+	ctx, span := c.Tracer.Start(ctx, "client.ImportCatalog")
+	defer span.End()
+
+	stream, err := c.ProductsClient.ImportCatalog(ctx)
+	if err != nil {
+		return domain.ImportReport{}, fromStatus(err)
+	}
+
+	err = stream.Send(&pb.ImportRequest{
+		Payload: &pb.ImportRequest_Options{
+			Options: &pb.ImportOptions{
+				Format: string(options.Format),
+				Mode:   string(options.Mode),
+				DryRun: options.DryRun,
+			},
+		},
+	})
+	if err != nil && !errors.Is(err, io.EOF) {
+		return domain.ImportReport{}, fromStatus(err)
+	}
+
+	buffer := make([]byte, importChunkSize)
+	for err == nil {
+		var n int
+		n, err = r.Read(buffer)
+		if n > 0 {
+			chunk := make([]byte, n)
+			copy(chunk, buffer[:n])
+
+			if sendErr := stream.Send(&pb.ImportRequest{Payload: &pb.ImportRequest_Chunk{Chunk: chunk}}); sendErr != nil {
+				// io.EOF means the server already replied, its status is returned by CloseAndRecv
+				if !errors.Is(sendErr, io.EOF) {
+					return domain.ImportReport{}, fromStatus(sendErr)
+				}
+
+				break
+			}
+		}
+	}
+
+	if err != nil && !errors.Is(err, io.EOF) {
+		return domain.ImportReport{}, err
+	}
+
+	response, err := stream.CloseAndRecv()
+	if err != nil {
+		return domain.ImportReport{}, fromStatus(err)
+	}
+
+	report := domain.ImportReport{
+		DryRun:  response.DryRun,
+		Created: int(response.Created),
+		Updated: int(response.Updated),
+		Failed:  int(response.Failed),
+	}
+
+	for _, failure := range response.Failures {
+		var failureErr error = errors.New(failure.Error)
+		if len(failure.Violations) > 0 {
+			validationErr := &domain.ValidationError{}
+			for _, violation := range failure.Violations {
+				validationErr.Violations = append(validationErr.Violations, domain.FieldViolation{
+					Field:       violation.Field,
+					Description: violation.Description,
+				})
+			}
+
+			failureErr = validationErr
+		}
+
+		rowErr := domain.ImportRowError{
+			Line: int(failure.Line),
+			Err:  failureErr,
+		}
+
+		if failure.Data != nil {
+			rowErr.Product = toDomainProduct(failure.Data)
+		}
+
+		report.Failures = append(report.Failures, rowErr)
+	}
+
+	return report, nil
+}
+
+// ExportCatalog writes the catalog file streamed by the server into w
+func (c *GrpcProductsClient) ExportCatalog(ctx context.Context, w io.Writer, format domain.CatalogFormat) error {
+	ctx, span := c.Tracer.Start(ctx, "client.ExportCatalog")
+	defer span.End()
+
+	stream, err := c.ProductsClient.ExportCatalog(ctx, &pb.ExportCatalogRequest{Format: string(format)})
+	if err != nil {
+		return fromStatus(err)
+	}
+
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fromStatus(err)
+		}
+
+		if _, err := w.Write(chunk.Data); err != nil {
+			return err
+		}
+	}
+}
+
+// UploadProductImage streams an image to the ProductsService in chunks, after its metadata.
+func (c *GrpcProductsClient) UploadProductImage(ctx context.Context, upload domain.ImageUpload, r io.Reader) (domain.ProductImage, error) {
+	ctx, span := c.Tracer.Start(ctx, "client.UploadProductImage")
+	defer span.End()
+
+	stream, err := c.ProductsClient.UploadImage(ctx)
+	if err != nil {
+		return domain.ProductImage{}, fromStatus(err)
+	}
+
+	err = stream.Send(&pb.UploadImageRequest{
+		Payload: &pb.UploadImageRequest_Metadata{
+			Metadata: &pb.UploadImageMetadata{
+				ProductId: int32(upload.ProductID),
+				AltText:   upload.AltText,
+			},
+		},
+	})
+	if err != nil && !errors.Is(err, io.EOF) {
+		return domain.ProductImage{}, fromStatus(err)
+	}
+
+	buffer := make([]byte, imageChunkSize)
+	for err == nil {
+		var n int
+		n, err = r.Read(buffer)
+		if n > 0 {
+			chunk := make([]byte, n)
+			copy(chunk, buffer[:n])
+
+			if sendErr := stream.Send(&pb.UploadImageRequest{Payload: &pb.UploadImageRequest_Chunk{Chunk: chunk}}); sendErr != nil {
+				// io.EOF means the server already replied, its status is returned by CloseAndRecv
+				if !errors.Is(sendErr, io.EOF) {
+					return domain.ProductImage{}, fromStatus(sendErr)
+				}
+
+				break
+			}
+		}
+	}
+
+	if err != nil && !errors.Is(err, io.EOF) {
+		return domain.ProductImage{}, err
+	}
+
+	response, err := stream.CloseAndRecv()
+	if err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			return domain.ProductImage{}, domain.ErrTooManyImages
+		}
+
+		err = fromInvalidArgument(
+			err,
+			domain.ErrInvalidAltText,
+			domain.ErrImageTooLarge,
+			domain.ErrUnsupportedImageType,
+			domain.ErrInvalidImage,
+		)
+
+		return domain.ProductImage{}, fromStatus(err)
+	}
+
+	return toDomainProductImage(response.Data), nil
+}
+
+// ListProductImages fetches the images of the given Products from the ProductsService.
+func (c *GrpcProductsClient) ListProductImages(ctx context.Context, productIDs []int) ([]domain.ProductImage, error) {
+	ctx, span := c.Tracer.Start(ctx, "client.ListProductImages")
+	defer span.End()
+
+	ids := make([]int32, 0, len(productIDs))
+	for _, id := range productIDs {
+		ids = append(ids, int32(id))
+	}
+
+	res, err := c.ProductsClient.ListImages(ctx, &pb.ListImagesRequest{ProductIds: ids})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+
+	return toDomainProductImages(res.Data), nil
+}
+
+// DeleteProductImage removes an image through the ProductsService.
+func (c *GrpcProductsClient) DeleteProductImage(ctx context.Context, id int) error {
+	ctx, span := c.Tracer.Start(ctx, "client.DeleteProductImage")
+	defer span.End()
+
+	_, err := c.ProductsClient.DeleteImage(ctx, &pb.DeleteImageRequest{Id: int32(id)})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return domain.ErrImageNotFound
+		}
+
+		return fromStatus(err)
+	}
+
+	return nil
+}
+
+// ReorderProductImages places the images of a Product in the given order through the ProductsService.
+func (c *GrpcProductsClient) ReorderProductImages(ctx context.Context, productID int, imageIDs []int) ([]domain.ProductImage, error) {
+	ctx, span := c.Tracer.Start(ctx, "client.ReorderProductImages")
+	defer span.End()
+
+	ids := make([]int32, 0, len(imageIDs))
+	for _, id := range imageIDs {
+		ids = append(ids, int32(id))
+	}
+
+	res, err := c.ProductsClient.ReorderImages(ctx, &pb.ReorderImagesRequest{ProductId: int32(productID), ImageIds: ids})
+	if err != nil {
+		return nil, fromStatus(fromInvalidArgument(err, domain.ErrInvalidImageOrder))
+	}
+
+	return toDomainProductImages(res.Data), nil
+}
+
+// fromStatus translates the gRPC errors that have a domain counterpart
+func fromStatus(err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return domain.ErrProductNotFound
+	case codes.AlreadyExists:
+		return domain.ErrProductSKUAlreadyExists
+	case codes.Aborted:
+		return domain.ErrVersionConflict
+	case codes.FailedPrecondition:
+		return domain.ErrProductNotDeleted
+	}
+
+	return err
+}
+
+// fromInvalidArgument converts an InvalidArgument status back to the domain error it came from,
+// among the given ones, by its message. The details following the domain error are kept.
+func fromInvalidArgument(err error, known ...error) error {
+	if status.Code(err) != codes.InvalidArgument {
+		return err
+	}
+
+	message := status.Convert(err).Message()
+	for _, knownErr := range known {
+		if message == knownErr.Error() {
+			return knownErr
+		}
+
+		if details := strings.TrimPrefix(message, knownErr.Error()+": "); details != message {
+			return fmt.Errorf("%w: %s", knownErr, details)
+		}
+	}
+
+	return err
+}
+
+func toProtoProductFilter(filter domain.ProductFilter) *pb.ProductFilter {
+	return &pb.ProductFilter{
+		Category:   filter.Category,
+		PriceFrom:  int32(filter.PriceFrom),
+		PriceTo:    int32(filter.PriceTo),
+		Attributes: filter.Attributes,
+	}
+}
+
+func toProtoSortKeys(keys []domain.SortKey) []*pb.SortKey {
+	var response []*pb.SortKey
+	for _, key := range keys {
+		response = append(response, &pb.SortKey{
+			Field:      string(key.Field),
+			Descending: key.Descending,
+		})
+	}
+
+	return response
+}
+
+func toDomainFacetBuckets(buckets []*pb.FacetBucket) []domain.FacetBucket {
+	response := make([]domain.FacetBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		response = append(response, domain.FacetBucket{
+			Value: bucket.Value,
+			Count: int(bucket.Count),
+		})
+	}
+
+	return response
+}
+
+func toDomainProduct(product *pb.Product) domain.Product {
+	response := domain.Product{
+		ID:          int(product.Id),
+		SKU:         product.Sku,
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       int(product.Price),
+		Category:    product.Category,
+		Attributes:  product.Attributes,
+		TaxClass:    product.TaxClass,
+		Weight:      int(product.Weight),
+		Dimensions:  toDomainDimensions(product.Dimensions),
+		Version:     int(product.Version),
+	}
+
+	if product.DeletedAt != nil {
+		response.DeletedAt = product.DeletedAt.AsTime()
+	}
+
+	return response
+}
+
+func toProtoProduct(product domain.Product) *pb.Product {
+	return &pb.Product{
+		Id:          int32(product.ID),
+		Sku:         product.SKU,
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       int32(product.Price),
+		Category:    product.Category,
+		Attributes:  product.Attributes,
+		TaxClass:    product.TaxClass,
+		Weight:      int32(product.Weight),
+		Dimensions:  toProtoDimensions(product.Dimensions),
+		Version:     int32(product.Version),
+	}
+}
+
+func toDomainProductImages(images []*pb.ProductImage) []domain.ProductImage {
+	response := make([]domain.ProductImage, 0, len(images))
+	for _, image := range images {
+		response = append(response, toDomainProductImage(image))
+	}
+
+	return response
+}
+
+func toDomainProductImage(image *pb.ProductImage) domain.ProductImage {
+	thumbnails := make([]domain.Thumbnail, 0, len(image.Thumbnails))
+	for _, thumbnail := range image.Thumbnails {
+		thumbnails = append(thumbnails, domain.Thumbnail{
+			Name:   thumbnail.Name,
+			URL:    thumbnail.Url,
+			Width:  int(thumbnail.Width),
+			Height: int(thumbnail.Height),
+		})
+	}
+
+	return domain.ProductImage{
+		ID:          int(image.Id),
+		ProductID:   int(image.ProductId),
+		Position:    int(image.Position),
+		URL:         image.Url,
+		ContentType: image.ContentType,
+		Width:       int(image.Width),
+		Height:      int(image.Height),
+		Size:        int(image.Size),
+		AltText:     image.AltText,
+		Thumbnails:  thumbnails,
+		CreatedAt:   image.CreatedAt.AsTime(),
+	}
+}
+
+// toProtoDimensions converts the Dimensions of a Product, leaving the unknown ones unset
+func toProtoDimensions(dimensions domain.Dimensions) *pb.Dimensions {
+	if dimensions.Zero() {
+		return nil
+	}
+
+	return &pb.Dimensions{
+		Length: int32(dimensions.Length),
+		Width:  int32(dimensions.Width),
+		Height: int32(dimensions.Height),
+	}
+}
+
+func toDomainDimensions(dimensions *pb.Dimensions) domain.Dimensions {
+	return domain.Dimensions{
+		Length: int(dimensions.GetLength()),
+		Width:  int(dimensions.GetWidth()),
+		Height: int(dimensions.GetHeight()),
+	}
+}
@@ -0,0 +1,430 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	gGRPC "google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/lucasmls/ecommerce/services/products/adapters/blobstores"
+	"github.com/lucasmls/ecommerce/services/products/adapters/repositories"
+	"github.com/lucasmls/ecommerce/services/products/app"
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	grpcPort "github.com/lucasmls/ecommerce/services/products/ports/grpc"
+	protog "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+	"github.com/lucasmls/ecommerce/shared/actor"
+	"github.com/lucasmls/ecommerce/shared/grpc"
+)
+
+type GrpcProductsClientSuite struct {
+	suite.Suite
+
+	logger *zap.Logger
+	tracer trace.Tracer
+
+	client         *GrpcProductsClient
+	grpcConnection *gGRPC.ClientConn
+}
+
+func (s *GrpcProductsClientSuite) SetupTest() {
+	ctx := context.Background()
+	s.logger = zap.NewNop()
+	s.tracer = trace.NewNoopTracerProvider().Tracer("")
+
+	repository := repositories.MustNewInMemoryProductsRepository(s.logger, s.tracer, 3)
+	auditRepository := repositories.NewInMemoryAuditRepository(s.logger, s.tracer)
+	application := app.MustNewApplication(s.logger, s.tracer, repository, auditRepository, app.MediaInput{
+		ImagesRepository: repositories.NewInMemoryImagesRepository(s.logger, s.tracer),
+		BlobStore: blobstores.MustNewFileSystemBlobStore(blobstores.FileSystemBlobStoreInput{
+			Logger:    s.logger,
+			Tracer:    s.tracer,
+			Directory: s.T().TempDir(),
+			BaseURL:   "http://localhost:8080/media",
+		}),
+		ThumbnailSizes: []domain.ThumbnailSize{{Name: "small", Width: 40, Height: 40}},
+	})
+	resolver := grpcPort.MustNewProductsResolver(s.logger, s.tracer, application)
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.MustNewServer(grpc.ServerInput{
+		Port:   1234,
+		Logger: s.logger,
+		Registrator: func(server gGRPC.ServiceRegistrar) {
+			protog.RegisterProductsServiceServer(server, resolver)
+		},
+		Listener: listener,
+	})
+
+	go func() {
+		_ = server.Run(ctx)
+	}()
+
+	s.grpcConnection = grpc.MustNewClient(grpc.ClientInput{
+		Logger: s.logger,
+		AdditionalDialOptions: []gGRPC.DialOption{
+			gGRPC.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+				return listener.Dial()
+			}),
+		},
+	}).MustConnect(ctx)
+
+	s.client = MustNewGrpcProductsClient(s.logger, s.tracer, protog.NewProductsServiceClient(s.grpcConnection))
+}
+
+func (s *GrpcProductsClientSuite) TearDownTest() {
+	s.NoError(s.grpcConnection.Close())
+}
+
+func (s *GrpcProductsClientSuite) Test_NewGrpcProductsClient() {
+	s.Run("Should fail to instantiate the client in case a dependency isn't provided", func() {
+		_, err := NewGrpcProductsClient(nil, nil, nil)
+		s.Equal(ErrMissingLogger, err)
+
+		_, err = NewGrpcProductsClient(s.logger, nil, nil)
+		s.Equal(ErrMissingTracer, err)
+
+		_, err = NewGrpcProductsClient(s.logger, s.tracer, nil)
+		s.Equal(ErrMissingProductsClient, err)
+	})
+}
+
+func (s *GrpcProductsClientSuite) Test_Lifecycle() {
+	ctx := actor.NewContext(context.Background(), "jane")
+	product := domain.Product{ID: 1, Name: "Iphone 13", Description: "Cool", Price: 4500}
+
+	registered, err := s.client.RegisterProduct(ctx, product)
+	s.Require().NoError(err)
+
+	product.Version = 1
+	s.Equal(product, registered)
+
+	got, err := s.client.GetProduct(ctx, 1)
+	s.NoError(err)
+	s.Equal(product, got)
+
+	product.Name = "Iphone 13 Pro"
+	updated, err := s.client.UpdateProduct(ctx, product)
+	s.NoError(err)
+
+	_, err = s.client.UpdateProduct(ctx, product)
+	s.ErrorIs(err, domain.ErrVersionConflict)
+
+	product.Version = 2
+	s.Equal(product, updated)
+
+	patched, err := s.client.PatchProduct(ctx, domain.ProductPatch{
+		Product: domain.Product{ID: 1, Price: 4800, Version: 2},
+		Fields:  []domain.ProductField{domain.ProductFieldPrice},
+	})
+	s.NoError(err)
+
+	product.Price = 4800
+	product.Version = 3
+	s.Equal(product, patched)
+
+	products, err := s.client.ListProducts(ctx, domain.ListProductsFilter{})
+	s.NoError(err)
+	s.Equal([]domain.Product{product}, products)
+
+	_, err = s.client.RestoreProduct(ctx, 1)
+	s.ErrorIs(err, domain.ErrProductNotDeleted)
+
+	s.NoError(s.client.DeleteProduct(ctx, 1))
+
+	_, err = s.client.GetProduct(ctx, 1)
+	s.ErrorIs(err, domain.ErrProductNotFound)
+
+	err = s.client.DeleteProduct(ctx, 1)
+	s.ErrorIs(err, domain.ErrProductNotFound)
+
+	trash, err := s.client.ListProducts(ctx, domain.ListProductsFilter{OnlyDeleted: true})
+	s.NoError(err)
+	s.Require().Len(trash, 1)
+	s.True(trash[0].Deleted())
+
+	restored, err := s.client.RestoreProduct(ctx, 1)
+	s.NoError(err)
+
+	product.Version = 5
+	s.Equal(product, restored)
+
+	got, err = s.client.GetProduct(ctx, 1)
+	s.NoError(err)
+	s.Equal(product, got)
+
+	history, err := s.client.GetProductHistory(ctx, domain.ProductHistoryFilter{ProductID: 1})
+	s.NoError(err)
+	s.Require().Len(history, 5)
+
+	var actions []domain.AuditAction
+	for _, entry := range history {
+		s.Equal("jane", entry.Actor)
+		actions = append(actions, entry.Action)
+	}
+
+	s.Equal([]domain.AuditAction{
+		domain.AuditActionRestore,
+		domain.AuditActionDelete,
+		domain.AuditActionPatch,
+		domain.AuditActionUpdate,
+		domain.AuditActionRegister,
+	}, actions)
+	s.Equal(product, *history[0].After)
+	s.True(history[0].Before.Deleted())
+	s.Nil(history[4].Before)
+
+	older, err := s.client.GetProductHistory(ctx, domain.ProductHistoryFilter{ProductID: 1, BeforeID: history[1].ID, Limit: 2})
+	s.NoError(err)
+	s.Equal(history[2:4], older)
+
+	_, err = s.client.GetProductHistory(ctx, domain.ProductHistoryFilter{ProductID: 1, Limit: -1})
+	s.ErrorIs(err, domain.ErrInvalidHistoryLimit)
+}
+
+func (s *GrpcProductsClientSuite) Test_RegisterProductsAndExport() {
+	ctx := context.Background()
+	products := []domain.Product{
+		{ID: 3, Name: "Macbook Air M1", Description: "Nice!", Price: 6900},
+		{ID: 1, Name: "Iphone 13", Description: "Cool", Price: 4500},
+		{ID: 2, Name: "Macbook Pro M1 Max", Description: "Fast!", Price: 16500},
+		{ID: 4, Name: "Ipad", Description: "Big", Price: 3000},
+	}
+
+	results, err := s.client.RegisterProducts(ctx, products)
+	s.Require().NoError(err)
+	s.Require().Len(results, len(products))
+
+	for i := range products {
+		products[i].Version = 1
+	}
+
+	for i := range products[:3] {
+		s.NoError(results[i].Err)
+		s.Equal(products[i], results[i].Product)
+	}
+
+	// the storage errors aren't told to the clients
+	s.EqualError(results[3].Err, "internal error")
+
+	var exported []domain.Product
+	err = s.client.ExportProducts(ctx, func(product domain.Product) error {
+		exported = append(exported, product)
+		return nil
+	})
+
+	s.NoError(err)
+	s.Equal([]domain.Product{products[1], products[2], products[0]}, exported)
+}
+
+func (s *GrpcProductsClientSuite) Test_SearchProducts() {
+	ctx := context.Background()
+	products := []domain.Product{
+		{ID: 1, Name: "Iphone 13", Description: "Cool", Price: 4500},
+		{ID: 2, Name: "Macbook Pro M1 Max", Description: "Fast!", Price: 16500},
+		{ID: 3, Name: "Magic Mouse", Description: "Works with any Macbook", Price: 700},
+	}
+
+	for _, product := range products {
+		_, err := s.client.RegisterProduct(ctx, product)
+		s.Require().NoError(err)
+	}
+
+	results, err := s.client.SearchProducts(ctx, domain.ProductSearch{Query: "macbok"})
+	s.NoError(err)
+	s.Require().Len(results, 2)
+
+	s.Equal(2, results[0].Product.ID)
+	s.Equal(domain.ProductHighlights{Name: "<em>Macbook</em> Pro M1 Max", Description: "Fast!"}, results[0].Highlights)
+	s.Equal(3, results[1].Product.ID)
+	s.Equal(domain.ProductHighlights{Name: "Magic Mouse", Description: "Works with any <em>Macbook</em>"}, results[1].Highlights)
+	s.Greater(results[0].Score, results[1].Score)
+
+	_, err = s.client.SearchProducts(ctx, domain.ProductSearch{Query: ""})
+	s.ErrorIs(err, domain.ErrEmptySearchQuery)
+
+	_, err = s.client.SearchProducts(ctx, domain.ProductSearch{Query: "macbook", Limit: -1})
+	s.ErrorIs(err, domain.ErrInvalidSearchLimit)
+
+	results, err = s.client.SearchProducts(ctx, domain.ProductSearch{
+		Query: "macbook",
+		Sort:  []domain.SortKey{{Field: domain.SortFieldPrice}},
+	})
+	s.NoError(err)
+	s.Require().Len(results, 2)
+	s.Equal(3, results[0].Product.ID)
+	s.Equal(2, results[1].Product.ID)
+}
+
+func (s *GrpcProductsClientSuite) Test_ListProductsSorted() {
+	ctx := context.Background()
+	products := []domain.Product{
+		{ID: 1, Name: "Iphone 13", Price: 4500},
+		{ID: 2, Name: "Pixel 6", Price: 3000},
+		{ID: 3, Name: "Galaxy S22", Price: 4500},
+	}
+
+	for _, product := range products {
+		_, err := s.client.RegisterProduct(ctx, product)
+		s.Require().NoError(err)
+	}
+
+	got, err := s.client.ListProducts(ctx, domain.ListProductsFilter{
+		Sort: []domain.SortKey{{Field: domain.SortFieldPrice, Descending: true}, {Field: domain.SortFieldName}},
+	})
+	s.NoError(err)
+
+	ids := make([]int, 0, len(got))
+	for _, product := range got {
+		ids = append(ids, product.ID)
+	}
+
+	s.Equal([]int{3, 1, 2}, ids)
+
+	_, err = s.client.ListProducts(ctx, domain.ListProductsFilter{Sort: []domain.SortKey{{Field: "color"}}})
+	s.ErrorIs(err, domain.ErrInvalidSort)
+	s.EqualError(err, `invalid-sort: unknown field "color"`)
+}
+
+func (s *GrpcProductsClientSuite) Test_GetProductFacets() {
+	ctx := context.Background()
+	products := []domain.Product{
+		{ID: 1, Name: "Iphone 13", Price: 4500, Category: "phones", Attributes: map[string]string{"color": "blue"}},
+		{ID: 2, Name: "Pixel 6", Price: 3000, Category: "phones", Attributes: map[string]string{"color": "black"}},
+		{ID: 3, Name: "Macbook Air M1", Price: 6900, Category: "laptops"},
+	}
+
+	for _, product := range products {
+		_, err := s.client.RegisterProduct(ctx, product)
+		s.Require().NoError(err)
+	}
+
+	facets, err := s.client.GetProductFacets(ctx, domain.FacetsFilter{PriceBoundaries: []int{5000}})
+	s.NoError(err)
+	s.Equal(domain.ProductFacets{
+		Total:       3,
+		PriceRanges: []domain.PriceRangeBucket{{From: 0, To: 5000, Count: 2}, {From: 5000, Count: 1}},
+		Categories:  []domain.FacetBucket{{Value: "phones", Count: 2}, {Value: "laptops", Count: 1}},
+		Attributes: []domain.AttributeFacet{
+			{Name: "color", Values: []domain.FacetBucket{{Value: "black", Count: 1}, {Value: "blue", Count: 1}}},
+		},
+	}, facets)
+
+	phones, err := s.client.ListProducts(ctx, domain.ListProductsFilter{
+		ProductFilter: domain.ProductFilter{Category: "phones", Attributes: map[string]string{"color": "blue"}},
+	})
+	s.NoError(err)
+	s.Equal([]domain.Product{
+		{ID: 1, Name: "Iphone 13", Price: 4500, Category: "phones", Attributes: map[string]string{"color": "blue"}, Version: 1},
+	}, phones)
+
+	_, err = s.client.GetProductFacets(ctx, domain.FacetsFilter{PriceBoundaries: []int{5000, 5000}})
+	s.ErrorIs(err, domain.ErrInvalidPriceBoundaries)
+
+	_, err = s.client.ListProducts(ctx, domain.ListProductsFilter{ProductFilter: domain.ProductFilter{PriceTo: -1}})
+	s.ErrorIs(err, domain.ErrInvalidPriceRange)
+}
+
+func (s *GrpcProductsClientSuite) Test_Images() {
+	ctx := actor.NewContext(context.Background(), "jane")
+
+	_, err := s.client.RegisterProduct(ctx, domain.Product{ID: 1, Name: "Iphone 13", Price: 4500})
+	s.Require().NoError(err)
+
+	var content bytes.Buffer
+	s.Require().NoError(png.Encode(&content, image.NewRGBA(image.Rect(0, 0, 80, 60))))
+	size := content.Len()
+
+	_, err = s.client.UploadProductImage(ctx, domain.ImageUpload{ProductID: 2}, bytes.NewReader(content.Bytes()))
+	s.ErrorIs(err, domain.ErrProductNotFound)
+
+	_, err = s.client.UploadProductImage(ctx, domain.ImageUpload{ProductID: 1}, strings.NewReader("name,price"))
+	s.ErrorIs(err, domain.ErrUnsupportedImageType)
+
+	// bigger than a single chunk, so it's streamed in many of them
+	_, err = s.client.UploadProductImage(ctx, domain.ImageUpload{ProductID: 1}, bytes.NewReader(make([]byte, domain.MaxImageSize+1)))
+	s.ErrorIs(err, domain.ErrImageTooLarge)
+
+	front, err := s.client.UploadProductImage(ctx, domain.ImageUpload{ProductID: 1, AltText: "Front"}, bytes.NewReader(content.Bytes()))
+	s.Require().NoError(err)
+	s.Equal([]int{0, 80, 60, size}, []int{front.Position, front.Width, front.Height, front.Size})
+	s.Equal("Front", front.AltText)
+	s.True(strings.HasPrefix(front.URL, "http://localhost:8080/media/products/1/"), front.URL)
+	s.Require().Len(front.Thumbnails, 1)
+	s.Equal([]int{40, 30}, []int{front.Thumbnails[0].Width, front.Thumbnails[0].Height})
+
+	back, err := s.client.UploadProductImage(ctx, domain.ImageUpload{ProductID: 1, AltText: "Back"}, bytes.NewReader(content.Bytes()))
+	s.Require().NoError(err)
+	s.Equal(1, back.Position)
+
+	_, err = s.client.ReorderProductImages(ctx, 1, []int{back.ID})
+	s.ErrorIs(err, domain.ErrInvalidImageOrder)
+
+	reordered, err := s.client.ReorderProductImages(ctx, 1, []int{back.ID, front.ID})
+	s.NoError(err)
+	s.Equal([]string{"Back", "Front"}, []string{reordered[0].AltText, reordered[1].AltText})
+
+	s.NoError(s.client.DeleteProductImage(ctx, back.ID))
+	s.ErrorIs(s.client.DeleteProductImage(ctx, back.ID), domain.ErrImageNotFound)
+
+	images, err := s.client.ListProductImages(ctx, []int{1})
+	s.NoError(err)
+	s.Require().Len(images, 1)
+	s.Equal(front.ID, images[0].ID)
+	s.Equal(0, images[0].Position)
+}
+
+func TestGrpcProductsClientSuite(t *testing.T) {
+	suite.Run(t, new(GrpcProductsClientSuite))
+}
+
+func (s *GrpcProductsClientSuite) Test_ImportAndExportCatalog() {
+	ctx := context.Background()
+	content := "id,sku,name,description,price\n" +
+		"1,IPH-13,Iphone 13,Cool,4500\n" +
+		"2,MBA-M1,Macbook Air M1,Fast!,6800\n" +
+		"3,MBP-M1,,Nameless,16500\n"
+	options := domain.ImportOptions{Format: domain.CatalogFormatCSV, Mode: domain.ImportModeCreate, DryRun: true}
+
+	report, err := s.client.ImportCatalog(ctx, strings.NewReader(content), options)
+	s.Require().NoError(err)
+	s.Equal(domain.ImportReport{
+		DryRun:  true,
+		Created: 2,
+		Failed:  1,
+		Failures: []domain.ImportRowError{
+			{
+				Line:    4,
+				Product: domain.Product{ID: 3, SKU: "MBP-M1", Description: "Nameless", Price: 16500},
+				Err: &domain.ValidationError{Violations: []domain.FieldViolation{
+					{Field: "name", Description: "must not be empty"},
+				}},
+			},
+		},
+	}, report)
+
+	products, err := s.client.ListProducts(ctx, domain.ListProductsFilter{})
+	s.Require().NoError(err)
+	s.Empty(products)
+
+	options.DryRun = false
+	report, err = s.client.ImportCatalog(ctx, strings.NewReader(content), options)
+	s.Require().NoError(err)
+	s.Equal(2, report.Created)
+
+	var exported bytes.Buffer
+	s.Require().NoError(s.client.ExportCatalog(ctx, &exported, domain.CatalogFormatNDJSON))
+	s.Equal(
+		`{"id":1,"sku":"IPH-13","name":"Iphone 13","description":"Cool","price":4500}`+"\n"+
+			`{"id":2,"sku":"MBA-M1","name":"Macbook Air M1","description":"Fast!","price":6800}`+"\n",
+		exported.String(),
+	)
+}
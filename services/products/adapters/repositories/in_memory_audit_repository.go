@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type InMemoryAuditRepository struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	// mu guards entries, which are kept in the order they were recorded
+	mu      sync.Mutex
+	entries []domain.AuditEntry
+}
+
+// NewInMemoryAuditRepository creates a new InMemoryAuditRepository.
+func NewInMemoryAuditRepository(logger *zap.Logger, tracer trace.Tracer) *InMemoryAuditRepository {
+	return &InMemoryAuditRepository{
+		Logger: logger,
+		Tracer: tracer,
+	}
+}
+
+// Record stores an AuditEntry in-memory, its ID is its position in the log.
+func (r *InMemoryAuditRepository) Record(ctx context.Context, entry domain.AuditEntry) (domain.AuditEntry, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Record")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry.ID = len(r.entries) + 1
+	entry.Before = copyProduct(entry.Before)
+	entry.After = copyProduct(entry.After)
+
+	r.entries = append(r.entries, entry)
+	return entry, nil
+}
+
+// ListByProduct lists the AuditEntries of a Product from memory, newest first.
+func (r *InMemoryAuditRepository) ListByProduct(ctx context.Context, filter domain.ProductHistoryFilter) ([]domain.AuditEntry, error) {
+	_, span := r.Tracer.Start(ctx, "repository.ListByProduct")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := []domain.AuditEntry{}
+	for i := len(r.entries) - 1; i >= 0 && len(result) < filter.Limit; i-- {
+		entry := r.entries[i]
+		if entry.ProductID != filter.ProductID {
+			continue
+		}
+
+		if filter.BeforeID != 0 && entry.ID >= filter.BeforeID {
+			continue
+		}
+
+		result = append(result, entry)
+	}
+
+	return result, nil
+}
+
+// copyProduct keeps the stored snapshots from being changed through the pointers handed to the repository
+func copyProduct(product *domain.Product) *domain.Product {
+	if product == nil {
+		return nil
+	}
+
+	snapshot := *product
+	return &snapshot
+}
@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type InMemoryAuditRepositorySuite struct {
+	suite.Suite
+
+	auditRepo *InMemoryAuditRepository
+}
+
+func (s *InMemoryAuditRepositorySuite) SetupTest() {
+	s.auditRepo = NewInMemoryAuditRepository(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""))
+
+	registered := domain.Product{ID: 1, Name: "Iphone 13", Price: 4500, Version: 1}
+	updated := domain.Product{ID: 1, Name: "Iphone 13", Price: 4200, Version: 2}
+	other := domain.Product{ID: 2, Name: "Macbook Air M1", Price: 6800, Version: 1}
+
+	for _, entry := range []domain.AuditEntry{
+		{ProductID: 1, Action: domain.AuditActionRegister, Actor: "jane", After: &registered},
+		{ProductID: 2, Action: domain.AuditActionRegister, Actor: "john", After: &other},
+		{ProductID: 1, Action: domain.AuditActionUpdate, Actor: "john", Before: &registered, After: &updated},
+	} {
+		_, err := s.auditRepo.Record(context.Background(), entry)
+		s.Require().NoError(err)
+	}
+}
+
+func (s *InMemoryAuditRepositorySuite) Test_Record() {
+	s.Run("Should number the entries and keep a copy of the snapshots", func() {
+		after := domain.Product{ID: 2, Name: "Macbook Air M1", Price: 7200, Version: 2}
+
+		got, err := s.auditRepo.Record(context.Background(), domain.AuditEntry{
+			ProductID: 2,
+			Action:    domain.AuditActionPatch,
+			After:     &after,
+		})
+		after.Price = 0
+
+		s.NoError(err)
+		s.Equal(4, got.ID)
+		s.Equal(7200, got.After.Price)
+	})
+}
+
+func (s *InMemoryAuditRepositorySuite) Test_ListByProduct() {
+	s.Run("Should list the entries of the product newest first", func() {
+		got, err := s.auditRepo.ListByProduct(context.Background(), domain.ProductHistoryFilter{ProductID: 1, Limit: 10})
+
+		s.NoError(err)
+		s.Require().Len(got, 2)
+		s.Equal(3, got[0].ID)
+		s.Equal(domain.AuditActionUpdate, got[0].Action)
+		s.Equal(4500, got[0].Before.Price)
+		s.Equal(1, got[1].ID)
+		s.Nil(got[1].Before)
+	})
+
+	s.Run("Should list the entries before the cursor up to the limit", func() {
+		got, err := s.auditRepo.ListByProduct(context.Background(), domain.ProductHistoryFilter{ProductID: 1, BeforeID: 3, Limit: 1})
+
+		s.NoError(err)
+		s.Require().Len(got, 1)
+		s.Equal(1, got[0].ID)
+	})
+
+	s.Run("Should return an empty list for products without changes", func() {
+		got, err := s.auditRepo.ListByProduct(context.Background(), domain.ProductHistoryFilter{ProductID: 3, Limit: 10})
+
+		s.NoError(err)
+		s.Empty(got)
+	})
+}
+
+func TestInMemoryAuditRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InMemoryAuditRepositorySuite))
+}
@@ -0,0 +1,209 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type InMemoryImagesRepository struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	// mu guards images and lastID
+	mu     sync.Mutex
+	images map[int]domain.ProductImage
+	lastID int
+}
+
+// NewInMemoryImagesRepository creates a new InMemoryImagesRepository.
+func NewInMemoryImagesRepository(logger *zap.Logger, tracer trace.Tracer) *InMemoryImagesRepository {
+	return &InMemoryImagesRepository{
+		Logger: logger,
+		Tracer: tracer,
+		images: map[int]domain.ProductImage{},
+	}
+}
+
+// Create stores a ProductImage in-memory, placing it after the other images of its Product.
+func (r *InMemoryImagesRepository) Create(ctx context.Context, image domain.ProductImage) (domain.ProductImage, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Create")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	position := len(r.byProduct(image.ProductID))
+	if position >= domain.MaxProductImages {
+		return domain.ProductImage{}, domain.ErrTooManyImages
+	}
+
+	r.lastID++
+	image.ID = r.lastID
+	image.Position = position
+	image.Thumbnails = copyThumbnails(image.Thumbnails)
+
+	r.images[image.ID] = image
+	return image, nil
+}
+
+// Get fetches a single ProductImage from memory.
+func (r *InMemoryImagesRepository) Get(ctx context.Context, id int) (domain.ProductImage, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Get")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	image, ok := r.images[id]
+	if !ok {
+		return domain.ProductImage{}, domain.ErrImageNotFound
+	}
+
+	image.Thumbnails = copyThumbnails(image.Thumbnails)
+	return image, nil
+}
+
+// ListByProducts lists the ProductImages of the given Products from memory, ordered by Product and then by Position.
+func (r *InMemoryImagesRepository) ListByProducts(ctx context.Context, productIDs []int) ([]domain.ProductImage, error) {
+	_, span := r.Tracer.Start(ctx, "repository.ListByProducts")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := append([]int{}, productIDs...)
+	sort.Ints(ids)
+
+	result := []domain.ProductImage{}
+	for i, productID := range ids {
+		if i > 0 && ids[i-1] == productID {
+			continue
+		}
+
+		for _, image := range r.byProduct(productID) {
+			image.Thumbnails = copyThumbnails(image.Thumbnails)
+			result = append(result, image)
+		}
+	}
+
+	return result, nil
+}
+
+// Delete removes a ProductImage from memory, moving the images after it one position up.
+func (r *InMemoryImagesRepository) Delete(ctx context.Context, id int) error {
+	_, span := r.Tracer.Start(ctx, "repository.Delete")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	image, ok := r.images[id]
+	if !ok {
+		return domain.ErrImageNotFound
+	}
+
+	delete(r.images, id)
+
+	for _, other := range r.byProduct(image.ProductID) {
+		if other.Position > image.Position {
+			other.Position--
+			r.images[other.ID] = other
+		}
+	}
+
+	return nil
+}
+
+// Reorder places the images of a Product in the given order of IDs.
+func (r *InMemoryImagesRepository) Reorder(ctx context.Context, productID int, ids []int) ([]domain.ProductImage, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Reorder")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	images := r.byProduct(productID)
+	if err := validateImageOrder(images, ids); err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.ProductImage, 0, len(ids))
+	for position, id := range ids {
+		image := r.images[id]
+		image.Position = position
+		r.images[id] = image
+
+		image.Thumbnails = copyThumbnails(image.Thumbnails)
+		result = append(result, image)
+	}
+
+	return result, nil
+}
+
+// DeleteByProduct removes every ProductImage of a Product from memory, returning them.
+func (r *InMemoryImagesRepository) DeleteByProduct(ctx context.Context, productID int) ([]domain.ProductImage, error) {
+	_, span := r.Tracer.Start(ctx, "repository.DeleteByProduct")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	images := r.byProduct(productID)
+	for _, image := range images {
+		delete(r.images, image.ID)
+	}
+
+	return images, nil
+}
+
+// byProduct returns the images of a Product ordered by Position, the caller must hold mu
+func (r *InMemoryImagesRepository) byProduct(productID int) []domain.ProductImage {
+	images := []domain.ProductImage{}
+	for _, image := range r.images {
+		if image.ProductID == productID {
+			images = append(images, image)
+		}
+	}
+
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].Position < images[j].Position
+	})
+
+	return images
+}
+
+// validateImageOrder checks the IDs list every one of the images exactly once
+func validateImageOrder(images []domain.ProductImage, ids []int) error {
+	if len(ids) != len(images) {
+		return domain.ErrInvalidImageOrder
+	}
+
+	pending := make(map[int]bool, len(images))
+	for _, image := range images {
+		pending[image.ID] = true
+	}
+
+	for _, id := range ids {
+		if !pending[id] {
+			return domain.ErrInvalidImageOrder
+		}
+
+		delete(pending, id)
+	}
+
+	return nil
+}
+
+// copyThumbnails keeps the stored thumbnails from being changed through the slices handed out by the repository
+func copyThumbnails(thumbnails []domain.Thumbnail) []domain.Thumbnail {
+	if thumbnails == nil {
+		return nil
+	}
+
+	return append([]domain.Thumbnail{}, thumbnails...)
+}
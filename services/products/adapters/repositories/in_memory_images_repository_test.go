@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type InMemoryImagesRepositorySuite struct {
+	suite.Suite
+
+	imagesRepo *InMemoryImagesRepository
+}
+
+func (s *InMemoryImagesRepositorySuite) SetupTest() {
+	s.imagesRepo = NewInMemoryImagesRepository(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""))
+
+	for _, image := range []domain.ProductImage{
+		{ProductID: 1, Key: "products/1/a.png"},
+		{ProductID: 2, Key: "products/2/b.png"},
+		{ProductID: 1, Key: "products/1/c.png", Thumbnails: []domain.Thumbnail{{Name: "small", Key: "products/1/c_small.png"}}},
+		{ProductID: 1, Key: "products/1/d.png"},
+	} {
+		_, err := s.imagesRepo.Create(context.Background(), image)
+		s.Require().NoError(err)
+	}
+}
+
+func (s *InMemoryImagesRepositorySuite) keys(images []domain.ProductImage) []string {
+	keys := []string{}
+	for _, image := range images {
+		keys = append(keys, image.Key)
+	}
+
+	return keys
+}
+
+func (s *InMemoryImagesRepositorySuite) Test_Create() {
+	s.Run("Should place the image after the other images of the product", func() {
+		got, err := s.imagesRepo.Create(context.Background(), domain.ProductImage{ProductID: 2, Key: "products/2/e.png"})
+
+		s.NoError(err)
+		s.Equal(5, got.ID)
+		s.Equal(1, got.Position)
+	})
+
+	s.Run("Should reject images beyond the maximum of the product", func() {
+		for i := 0; i < domain.MaxProductImages-2; i++ {
+			_, err := s.imagesRepo.Create(context.Background(), domain.ProductImage{ProductID: 2})
+			s.Require().NoError(err)
+		}
+
+		_, err := s.imagesRepo.Create(context.Background(), domain.ProductImage{ProductID: 2})
+
+		s.Equal(domain.ErrTooManyImages, err)
+	})
+}
+
+func (s *InMemoryImagesRepositorySuite) Test_Get() {
+	s.Run("Should return not found error in case the image isn't stored", func() {
+		_, err := s.imagesRepo.Get(context.Background(), 99)
+
+		s.Equal(domain.ErrImageNotFound, err)
+	})
+
+	s.Run("Should keep the stored thumbnails from being changed", func() {
+		got, err := s.imagesRepo.Get(context.Background(), 3)
+		s.Require().NoError(err)
+		got.Thumbnails[0].Key = "changed"
+
+		got, err = s.imagesRepo.Get(context.Background(), 3)
+
+		s.NoError(err)
+		s.Equal("products/1/c_small.png", got.Thumbnails[0].Key)
+	})
+}
+
+func (s *InMemoryImagesRepositorySuite) Test_ListByProducts() {
+	s.Run("Should list the images ordered by product and then by position", func() {
+		got, err := s.imagesRepo.ListByProducts(context.Background(), []int{2, 1, 3})
+
+		s.NoError(err)
+		s.Equal([]string{"products/1/a.png", "products/1/c.png", "products/1/d.png", "products/2/b.png"}, s.keys(got))
+		s.Equal([]int{0, 1, 2, 0}, []int{got[0].Position, got[1].Position, got[2].Position, got[3].Position})
+	})
+}
+
+func (s *InMemoryImagesRepositorySuite) Test_Delete() {
+	s.Run("Should move the images after the deleted one a position up", func() {
+		s.NoError(s.imagesRepo.Delete(context.Background(), 1))
+
+		got, err := s.imagesRepo.ListByProducts(context.Background(), []int{1})
+
+		s.NoError(err)
+		s.Equal([]string{"products/1/c.png", "products/1/d.png"}, s.keys(got))
+		s.Equal([]int{0, 1}, []int{got[0].Position, got[1].Position})
+	})
+
+	s.Run("Should return not found error in case the image isn't stored", func() {
+		s.Equal(domain.ErrImageNotFound, s.imagesRepo.Delete(context.Background(), 1))
+	})
+}
+
+func (s *InMemoryImagesRepositorySuite) Test_Reorder() {
+	s.Run("Should reject orders that don't list every image of the product exactly once", func() {
+		for _, ids := range [][]int{{4, 3}, {4, 3, 3}, {4, 3, 2}, {4, 3, 1, 2}} {
+			_, err := s.imagesRepo.Reorder(context.Background(), 1, ids)
+
+			s.Equal(domain.ErrInvalidImageOrder, err, ids)
+		}
+	})
+
+	s.Run("Should place the images in the given order", func() {
+		got, err := s.imagesRepo.Reorder(context.Background(), 1, []int{4, 1, 3})
+		s.NoError(err)
+		s.Equal([]string{"products/1/d.png", "products/1/a.png", "products/1/c.png"}, s.keys(got))
+
+		listed, err := s.imagesRepo.ListByProducts(context.Background(), []int{1})
+		s.NoError(err)
+		s.Equal(got, listed)
+	})
+}
+
+func (s *InMemoryImagesRepositorySuite) Test_DeleteByProduct() {
+	s.Run("Should remove and return every image of the product", func() {
+		got, err := s.imagesRepo.DeleteByProduct(context.Background(), 1)
+		s.NoError(err)
+		s.Equal([]string{"products/1/a.png", "products/1/c.png", "products/1/d.png"}, s.keys(got))
+
+		remaining, err := s.imagesRepo.ListByProducts(context.Background(), []int{1, 2})
+		s.NoError(err)
+		s.Equal([]string{"products/2/b.png"}, s.keys(remaining))
+	})
+}
+
+func TestInMemoryImagesRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InMemoryImagesRepositorySuite))
+}
@@ -4,6 +4,10 @@ import (
 	"context"
 	"errors"
 	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/lucasmls/ecommerce/services/products/domain"
 	"go.opentelemetry.io/otel/trace"
@@ -20,7 +24,18 @@ type InMemoryProductsRepository struct {
 	Tracer      trace.Tracer
 	StorageSize int
 
+	// mu guards storage, index and timestamps, so the version checks and the writes happen atomically
+	mu      *sync.Mutex
 	storage map[int]domain.Product
+	// index is an inverted index of the words of the Products name and description to their IDs
+	index map[string]map[int]bool
+	// timestamps keeps when the Products were created and last updated, by ID, to sort them
+	timestamps map[int]productTimestamps
+}
+
+type productTimestamps struct {
+	createdAt time.Time
+	updatedAt time.Time
 }
 
 // NewInMemoryProductsRepository creates a new InMemoryProductsRepository.
@@ -37,7 +52,10 @@ func NewInMemoryProductsRepository(
 		Logger:      logger,
 		Tracer:      tracer,
 		StorageSize: storageSize,
+		mu:          &sync.Mutex{},
 		storage:     make(map[int]domain.Product, storageSize),
+		index:       map[string]map[int]bool{},
+		timestamps:  map[int]productTimestamps{},
 	}, nil
 }
 
@@ -61,6 +79,9 @@ func (r InMemoryProductsRepository) Create(ctx context.Context, product domain.P
 	_, span := r.Tracer.Start(ctx, "repository.Create")
 	defer span.End()
 
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	if len(r.storage) == r.StorageSize {
 		return domain.Product{}, ErrStorageLimitReached
 	}
@@ -69,7 +90,12 @@ func (r InMemoryProductsRepository) Create(ctx context.Context, product domain.P
 		product.ID = rand.Intn(1000)
 	}
 
-	r.storage[product.ID] = product
+	if r.skuTaken(product) {
+		return domain.Product{}, domain.ErrProductSKUAlreadyExists
+	}
+
+	product.Version = 1
+	r.store(product)
 	return product, nil
 }
 
@@ -78,50 +104,418 @@ func (r InMemoryProductsRepository) Update(ctx context.Context, product domain.P
 	_, span := r.Tracer.Start(ctx, "repository.Update")
 	defer span.End()
 
-	if _, ok := r.storage[product.ID]; !ok {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.storage[product.ID]
+	if !ok || stored.Deleted() {
 		return domain.Product{}, domain.ErrProductNotFound
 	}
 
-	r.storage[product.ID] = product
+	if product.Version != stored.Version {
+		return domain.Product{}, domain.ErrVersionConflict
+	}
+
+	if r.skuTaken(product) {
+		return domain.Product{}, domain.ErrProductSKUAlreadyExists
+	}
+
+	product.Version = stored.Version + 1
+	r.store(product)
+	return product, nil
+}
+
+// Patch changes only the listed fields of a product in-memory.
+func (r InMemoryProductsRepository) Patch(ctx context.Context, patch domain.ProductPatch) (domain.Product, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Patch")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.storage[patch.Product.ID]
+	if !ok || stored.Deleted() {
+		return domain.Product{}, domain.ErrProductNotFound
+	}
+
+	if patch.Product.Version != stored.Version {
+		return domain.Product{}, domain.ErrVersionConflict
+	}
+
+	product := patch.Apply(stored)
+	if r.skuTaken(product) {
+		return domain.Product{}, domain.ErrProductSKUAlreadyExists
+	}
+
+	product.Version = stored.Version + 1
+	r.store(product)
 	return product, nil
 }
 
-// Delete deletes a Product from memory.
+// skuTaken reports whether another stored Product, even a deleted one, already has the given Product SKU.
+func (r InMemoryProductsRepository) skuTaken(product domain.Product) bool {
+	if product.SKU == "" {
+		return false
+	}
+
+	for _, stored := range r.storage {
+		if stored.SKU == product.SKU && stored.ID != product.ID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// store writes the Product into storage, keeping the index and timestamps up to date.
+func (r InMemoryProductsRepository) store(product domain.Product) {
+	now := time.Now()
+
+	timestamps := productTimestamps{createdAt: now, updatedAt: now}
+	if stored, ok := r.storage[product.ID]; ok {
+		r.unindex(stored)
+		timestamps.createdAt = r.timestamps[product.ID].createdAt
+	}
+
+	r.timestamps[product.ID] = timestamps
+
+	product.Attributes = copyAttributes(product.Attributes)
+	r.storage[product.ID] = product
+
+	for _, word := range productWords(product) {
+		if r.index[word] == nil {
+			r.index[word] = map[int]bool{}
+		}
+
+		r.index[word][product.ID] = true
+	}
+}
+
+// unindex removes the words of the Product from the index.
+func (r InMemoryProductsRepository) unindex(product domain.Product) {
+	for _, word := range productWords(product) {
+		delete(r.index[word], product.ID)
+		if len(r.index[word]) == 0 {
+			delete(r.index, word)
+		}
+	}
+}
+
+// copyAttributes copies the attributes, so the stored Products can't be changed through the ones given by the callers
+func copyAttributes(attributes map[string]string) map[string]string {
+	if attributes == nil {
+		return nil
+	}
+
+	copied := make(map[string]string, len(attributes))
+	for name, value := range attributes {
+		copied[name] = value
+	}
+
+	return copied
+}
+
+// productWords returns the searchable words of a Product.
+func productWords(product domain.Product) []string {
+	return append(domain.Tokenize(product.Name), domain.Tokenize(product.Description)...)
+}
+
+// Delete moves a Product to the trash in-memory.
 func (r InMemoryProductsRepository) Delete(ctx context.Context, id int) error {
 	_, span := r.Tracer.Start(ctx, "repository.Delete")
 	defer span.End()
 
-	_, found := r.storage[id]
-	if !found {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, found := r.storage[id]
+	if !found || stored.Deleted() {
 		return domain.ErrProductNotFound
 	}
 
-	delete(r.storage, id)
+	stored.DeletedAt = time.Now()
+	stored.Version++
+	r.store(stored)
 
 	return nil
 }
 
+// Restore brings a Product back from the trash in-memory.
+func (r InMemoryProductsRepository) Restore(ctx context.Context, id int) (domain.Product, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Restore")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, found := r.storage[id]
+	if !found {
+		return domain.Product{}, domain.ErrProductNotFound
+	}
+
+	if !stored.Deleted() {
+		return domain.Product{}, domain.ErrProductNotDeleted
+	}
+
+	stored.DeletedAt = time.Time{}
+	stored.Version++
+	r.store(stored)
+
+	return stored, nil
+}
+
+// Purge removes from memory the Products deleted before the given time.
+func (r InMemoryProductsRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Purge")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var purged int
+	for id, product := range r.storage {
+		if product.Deleted() && product.DeletedAt.Before(deletedBefore) {
+			r.unindex(product)
+			delete(r.storage, id)
+			delete(r.timestamps, id)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
 // List all products from memory.
 func (r InMemoryProductsRepository) List(ctx context.Context, filter domain.ListProductsFilter) ([]domain.Product, error) {
 	_, span := r.Tracer.Start(ctx, "repository.List")
 	defer span.End()
 
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	filterIndex := map[int]bool{}
 	for _, id := range filter.IDs {
 		filterIndex[id] = true
 	}
 
+	skuIndex := map[string]bool{}
+	for _, sku := range filter.SKUs {
+		skuIndex[sku] = true
+	}
+
 	var result []domain.Product
 	for _, product := range r.storage {
-		if len(filter.IDs) == 0 {
-			result = append(result, product)
+		if len(filter.IDs) > 0 && !filterIndex[product.ID] {
 			continue
 		}
 
-		if _, ok := filterIndex[product.ID]; ok {
-			result = append(result, product)
+		if len(filter.SKUs) > 0 && !skuIndex[product.SKU] {
+			continue
+		}
+
+		if !filter.Matches(product) {
+			continue
+		}
+
+		if product.Deleted() && !filter.IncludeDeleted && !filter.OnlyDeleted {
+			continue
+		}
+
+		if !product.Deleted() && filter.OnlyDeleted {
+			continue
+		}
+
+		result = append(result, product)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return r.less(result[i], result[j], 0, 0, filter.Sort)
+	})
+
+	return result, nil
+}
+
+// CreateMany creates a batch of Products in-memory.
+func (r InMemoryProductsRepository) CreateMany(ctx context.Context, products []domain.Product) ([]domain.ProductResult, error) {
+	ctx, span := r.Tracer.Start(ctx, "repository.CreateMany")
+	defer span.End()
+
+	results := make([]domain.ProductResult, 0, len(products))
+	for _, product := range products {
+		createdProduct, err := r.Create(ctx, product)
+		results = append(results, domain.ProductResult{
+			Product: createdProduct,
+			Err:     err,
+		})
+	}
+
+	return results, nil
+}
+
+// ListPage lists a page of products from memory ordered by ID.
+func (r InMemoryProductsRepository) ListPage(ctx context.Context, filter domain.ExportProductsFilter) ([]domain.Product, error) {
+	_, span := r.Tracer.Start(ctx, "repository.ListPage")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make([]int, 0, len(r.storage))
+	for id, product := range r.storage {
+		if id > filter.AfterID && !product.Deleted() {
+			ids = append(ids, id)
 		}
 	}
 
+	sort.Ints(ids)
+
+	if len(ids) > filter.Limit {
+		ids = ids[:filter.Limit]
+	}
+
+	result := make([]domain.Product, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, r.storage[id])
+	}
+
 	return result, nil
 }
+
+// Search finds the Products matching the search using the in-memory inverted index.
+// The candidates are the Products having, for every term, a word matching it.
+func (r InMemoryProductsRepository) Search(ctx context.Context, search domain.ProductSearch) ([]domain.ProductSearchResult, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Search")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	terms := search.Terms()
+
+	var candidates map[int]bool
+	for _, term := range terms {
+		matching := map[int]bool{}
+		for word, ids := range r.index {
+			if domain.MatchTerm(term, word) == 0 {
+				continue
+			}
+
+			for id := range ids {
+				if candidates == nil || candidates[id] {
+					matching[id] = true
+				}
+			}
+		}
+
+		candidates = matching
+	}
+
+	results := []domain.ProductSearchResult{}
+	for id := range candidates {
+		product := r.storage[id]
+		if product.Deleted() {
+			continue
+		}
+
+		results = append(results, domain.ProductSearchResult{
+			Product: product,
+			Score:   domain.ScoreProduct(product, terms),
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return r.less(results[i].Product, results[j].Product, results[i].Score, results[j].Score, search.Sort)
+	})
+
+	if search.Offset >= len(results) {
+		return []domain.ProductSearchResult{}, nil
+	}
+
+	results = results[search.Offset:]
+	if len(results) > search.Limit {
+		results = results[:search.Limit]
+	}
+
+	return results, nil
+}
+
+// less reports whether the Product a is ordered before b by the sort keys, and then by ID.
+// The relevance of the Products is given by their search scores.
+func (r InMemoryProductsRepository) less(a, b domain.Product, aScore, bScore float64, keys []domain.SortKey) bool {
+	for _, key := range keys {
+		var cmp int
+		switch key.Field {
+		case domain.SortFieldName:
+			cmp = strings.Compare(a.Name, b.Name)
+		case domain.SortFieldPrice:
+			cmp = compareInts(a.Price, b.Price)
+		case domain.SortFieldCreatedAt:
+			cmp = compareTimes(r.timestamps[a.ID].createdAt, r.timestamps[b.ID].createdAt)
+		case domain.SortFieldUpdatedAt:
+			cmp = compareTimes(r.timestamps[a.ID].updatedAt, r.timestamps[b.ID].updatedAt)
+		case domain.SortFieldRelevance:
+			cmp = compareFloats(aScore, bScore)
+		}
+
+		if key.Descending {
+			cmp = -cmp
+		}
+
+		if cmp != 0 {
+			return cmp < 0
+		}
+	}
+
+	return a.ID < b.ID
+}
+
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func compareTimes(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Facets counts the Products matching the filter from memory.
+func (r InMemoryProductsRepository) Facets(ctx context.Context, filter domain.FacetsFilter) (domain.ProductFacets, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Facets")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var products []domain.Product
+	for _, product := range r.storage {
+		if !product.Deleted() && filter.Matches(product) {
+			products = append(products, product)
+		}
+	}
+
+	return domain.AggregateFacets(products, filter.PriceBoundaries), nil
+}
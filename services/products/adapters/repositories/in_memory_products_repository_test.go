@@ -3,7 +3,9 @@ package repositories
 import (
 	"context"
 	"math/rand"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/lucasmls/ecommerce/services/products/domain"
 	"github.com/stretchr/testify/suite"
@@ -36,7 +38,10 @@ func (s *NewInMemoryProductsRepositorySuite) Test_NewInMemoryProductsRepository(
 			Logger:      s.loggerM,
 			Tracer:      s.tracerM,
 			StorageSize: 10,
+			mu:          &sync.Mutex{},
 			storage:     map[int]domain.Product{},
+			index:       map[string]map[int]bool{},
+			timestamps:  map[int]productTimestamps{},
 		}
 
 		got, err := NewInMemoryProductsRepository(s.loggerM, s.tracerM, 10)
@@ -62,7 +67,10 @@ func (s *NewInMemoryProductsRepositorySuite) Test_MustNewInMemoryProductsReposit
 			Logger:      s.loggerM,
 			Tracer:      s.tracerM,
 			StorageSize: 10,
+			mu:          &sync.Mutex{},
 			storage:     map[int]domain.Product{},
+			index:       map[string]map[int]bool{},
+			timestamps:  map[int]productTimestamps{},
 		}
 
 		got := MustNewInMemoryProductsRepository(s.loggerM, s.tracerM, 10)
@@ -96,6 +104,7 @@ func (s *CreateSuite) Test_Create() {
 
 		createInput := product
 		expectedResult := product
+		expectedResult.Version = 1
 
 		ctx := context.Background()
 		got, err := s.productsRepo.Create(ctx, createInput)
@@ -111,6 +120,7 @@ func (s *CreateSuite) Test_Create() {
 			Name:        "Macbook Air M1",
 			Description: "Fast!",
 			Price:       7000,
+			Version:     1,
 		}
 
 		ctx := context.Background()
@@ -171,15 +181,24 @@ func (s *UpdateSuite) Test_Update() {
 		s.Equal(domain.ErrProductNotFound, err)
 	})
 
+	s.Run("Should not update the Product when the provided version is stale", func() {
+		ctx := context.Background()
+
+		_, err := s.productsRepo.Update(ctx, domain.Product{ID: 1, Name: "Iphone 12 Pro", Version: 7})
+		s.Equal(domain.ErrVersionConflict, err)
+	})
+
 	s.Run("Should update the provided Product", func() {
 		product := domain.Product{
 			ID:          1,
 			Name:        "Macbook Air M1",
 			Description: "Fast!",
 			Price:       7000,
+			Version:     1,
 		}
 
 		expectedResult := product
+		expectedResult.Version = 2
 
 		ctx := context.Background()
 		updateInput := product
@@ -189,6 +208,96 @@ func (s *UpdateSuite) Test_Update() {
 		s.NoError(err)
 		s.Equal(expectedResult, got)
 	})
+
+	s.Run("Should reject the second of two updates based on the same version", func() {
+		ctx := context.Background()
+		product := domain.Product{ID: 1, Name: "Macbook Air M2", Description: "Faster!", Price: 9000, Version: 2}
+
+		got, err := s.productsRepo.Update(ctx, product)
+		s.NoError(err)
+		s.Equal(3, got.Version)
+
+		_, err = s.productsRepo.Update(ctx, product)
+		s.Equal(domain.ErrVersionConflict, err)
+	})
+
+	s.Run("Should not update the Product without the version the update is based on", func() {
+		ctx := context.Background()
+		product := domain.Product{ID: 1, Name: "Macbook Air M2", Description: "Faster!", Price: 9000}
+
+		_, err := s.productsRepo.Update(ctx, product)
+		s.Equal(domain.ErrVersionConflict, err)
+	})
+}
+
+type PatchSuite struct {
+	suite.Suite
+
+	loggerM      *zap.Logger
+	tracerM      trace.Tracer
+	productsRepo domain.ProductsRepository
+}
+
+func (s *PatchSuite) SetupTest() {
+	s.loggerM = zap.NewNop()
+	s.tracerM = trace.NewNoopTracerProvider().Tracer("")
+	s.productsRepo = MustNewInMemoryProductsRepository(s.loggerM, s.tracerM, 10)
+
+	ctx := context.Background()
+	for _, product := range []domain.Product{
+		{ID: 1, SKU: "IPH-12", Name: "Iphone 12", Description: "Cool", Price: 4500},
+		{ID: 2, SKU: "MBA-M1", Name: "Macbook Air M1", Description: "Fast!", Price: 6800},
+	} {
+		_, err := s.productsRepo.Create(ctx, product)
+		s.Require().NoError(err)
+	}
+}
+
+func (s *PatchSuite) Test_Patch() {
+	s.Run("Should return not found error in case the specified product isn't stored", func() {
+		s.SetupTest()
+
+		_, err := s.productsRepo.Patch(context.Background(), domain.ProductPatch{
+			Product: domain.Product{ID: 3, Name: "Ipad"},
+			Fields:  []domain.ProductField{domain.ProductFieldName},
+		})
+
+		s.Equal(domain.ErrProductNotFound, err)
+	})
+
+	s.Run("Should only change the listed fields", func() {
+		s.SetupTest()
+
+		got, err := s.productsRepo.Patch(context.Background(), domain.ProductPatch{
+			Product: domain.Product{ID: 1, Name: "Ignored", Price: 5000, Version: 1},
+			Fields:  []domain.ProductField{domain.ProductFieldPrice},
+		})
+
+		s.NoError(err)
+		s.Equal(domain.Product{ID: 1, SKU: "IPH-12", Name: "Iphone 12", Description: "Cool", Price: 5000, Version: 2}, got)
+	})
+
+	s.Run("Should not patch the Product when the provided version is stale", func() {
+		s.SetupTest()
+
+		_, err := s.productsRepo.Patch(context.Background(), domain.ProductPatch{
+			Product: domain.Product{ID: 1, Price: 5000, Version: 3},
+			Fields:  []domain.ProductField{domain.ProductFieldPrice},
+		})
+
+		s.Equal(domain.ErrVersionConflict, err)
+	})
+
+	s.Run("Should not patch the SKU to one taken by another Product", func() {
+		s.SetupTest()
+
+		_, err := s.productsRepo.Patch(context.Background(), domain.ProductPatch{
+			Product: domain.Product{ID: 1, SKU: "MBA-M1", Version: 1},
+			Fields:  []domain.ProductField{domain.ProductFieldSKU},
+		})
+
+		s.Equal(domain.ErrProductSKUAlreadyExists, err)
+	})
 }
 
 type ListSuite struct {
@@ -228,9 +337,9 @@ func (s *ListSuite) SetupTest() {
 func (s *ListSuite) Test_List() {
 	s.Run("Should list all products that were stored", func() {
 		expectedResult := []domain.Product{
-			{ID: 1, Name: "Iphone 13", Description: "Cool", Price: 4500},
-			{ID: 2, Name: "Macbook Pro M1 Max", Description: "Fast!", Price: 16500},
-			{ID: 3, Name: "Macbook Air M1", Description: "Nice!", Price: 6900},
+			{ID: 1, Name: "Iphone 13", Description: "Cool", Price: 4500, Version: 1},
+			{ID: 2, Name: "Macbook Pro M1 Max", Description: "Fast!", Price: 16500, Version: 1},
+			{ID: 3, Name: "Macbook Air M1", Description: "Nice!", Price: 6900, Version: 1},
 		}
 
 		ctx := context.Background()
@@ -244,7 +353,7 @@ func (s *ListSuite) Test_List() {
 
 	s.Run("Should list only the products that matches the provided filter", func() {
 		expectedResult := []domain.Product{
-			{ID: 1, Name: "Iphone 13", Description: "Cool", Price: 4500},
+			{ID: 1, Name: "Iphone 13", Description: "Cool", Price: 4500, Version: 1},
 		}
 
 		ctx := context.Background()
@@ -290,11 +399,633 @@ func (s *DeleteSuite) Test_Delete() {
 		s.ErrorIs(err, domain.ErrProductNotFound)
 	})
 
-	s.Run("Should remove the specified Product", func() {
+	s.Run("Should move the specified Product to the trash", func() {
+		ctx := context.Background()
+
+		err := s.productsRepo.Delete(ctx, 100)
+		s.NoError(err)
+
+		trash, err := s.productsRepo.List(ctx, domain.ListProductsFilter{OnlyDeleted: true})
+		s.NoError(err)
+		s.Require().Len(trash, 1)
+		s.True(trash[0].Deleted())
+		s.Equal(2, trash[0].Version)
+	})
+
+	s.Run("Should return not found error in case the specified product is already deleted", func() {
 		ctx := context.Background()
 
 		err := s.productsRepo.Delete(ctx, 100)
+		s.ErrorIs(err, domain.ErrProductNotFound)
+	})
+}
+
+type TrashSuite struct {
+	suite.Suite
+
+	loggerM      *zap.Logger
+	tracerM      trace.Tracer
+	productsRepo domain.ProductsRepository
+}
+
+func (s *TrashSuite) SetupTest() {
+	s.loggerM = zap.NewNop()
+	s.tracerM = trace.NewNoopTracerProvider().Tracer("")
+	s.productsRepo = MustNewInMemoryProductsRepository(s.loggerM, s.tracerM, 10)
+
+	ctx := context.Background()
+	for _, product := range []domain.Product{
+		{ID: 1, SKU: "IPH-13", Name: "Iphone 13", Description: "Cool", Price: 4500},
+		{ID: 2, SKU: "MBA-M1", Name: "Macbook Air M1", Description: "Fast!", Price: 6800},
+	} {
+		_, err := s.productsRepo.Create(ctx, product)
+		s.Require().NoError(err)
+	}
+
+	s.Require().NoError(s.productsRepo.Delete(ctx, 2))
+}
+
+func (s *TrashSuite) Test_List() {
+	s.Run("Should leave the deleted products out by default", func() {
+		s.SetupTest()
+
+		got, err := s.productsRepo.List(context.Background(), domain.ListProductsFilter{})
+
+		s.NoError(err)
+		s.Equal([]domain.Product{
+			{ID: 1, SKU: "IPH-13", Name: "Iphone 13", Description: "Cool", Price: 4500, Version: 1},
+		}, got)
+	})
+
+	s.Run("Should list the deleted products along with the others", func() {
+		s.SetupTest()
+
+		got, err := s.productsRepo.List(context.Background(), domain.ListProductsFilter{IncludeDeleted: true})
+
 		s.NoError(err)
+		s.Len(got, 2)
+	})
+
+	s.Run("Should leave the deleted products out of the pages", func() {
+		s.SetupTest()
+
+		got, err := s.productsRepo.ListPage(context.Background(), domain.ExportProductsFilter{Limit: 10})
+
+		s.NoError(err)
+		s.Require().Len(got, 1)
+		s.Equal(1, got[0].ID)
+	})
+}
+
+func (s *TrashSuite) Test_WriteDeleted() {
+	s.Run("Should not update a deleted Product", func() {
+		s.SetupTest()
+
+		_, err := s.productsRepo.Update(context.Background(), domain.Product{ID: 2, Name: "Macbook Air M2", Version: 1})
+
+		s.Equal(domain.ErrProductNotFound, err)
+	})
+
+	s.Run("Should not patch a deleted Product", func() {
+		s.SetupTest()
+
+		_, err := s.productsRepo.Patch(context.Background(), domain.ProductPatch{
+			Product: domain.Product{ID: 2, Price: 7000, Version: 1},
+			Fields:  []domain.ProductField{domain.ProductFieldPrice},
+		})
+
+		s.Equal(domain.ErrProductNotFound, err)
+	})
+
+	s.Run("Should keep the SKU of a deleted Product taken", func() {
+		s.SetupTest()
+
+		_, err := s.productsRepo.Create(context.Background(), domain.Product{ID: 3, SKU: "MBA-M1", Name: "Macbook Air M2"})
+
+		s.Equal(domain.ErrProductSKUAlreadyExists, err)
+	})
+}
+
+func (s *TrashSuite) Test_Restore() {
+	s.Run("Should return not found error in case the specified product isn't stored", func() {
+		s.SetupTest()
+
+		_, err := s.productsRepo.Restore(context.Background(), 3)
+
+		s.Equal(domain.ErrProductNotFound, err)
+	})
+
+	s.Run("Should not restore a Product that isn't deleted", func() {
+		s.SetupTest()
+
+		_, err := s.productsRepo.Restore(context.Background(), 1)
+
+		s.Equal(domain.ErrProductNotDeleted, err)
+	})
+
+	s.Run("Should bring the Product back from the trash", func() {
+		s.SetupTest()
+		ctx := context.Background()
+
+		got, err := s.productsRepo.Restore(ctx, 2)
+
+		s.NoError(err)
+		s.Equal(domain.Product{ID: 2, SKU: "MBA-M1", Name: "Macbook Air M1", Description: "Fast!", Price: 6800, Version: 3}, got)
+
+		products, err := s.productsRepo.List(ctx, domain.ListProductsFilter{IDs: []int{2}})
+		s.NoError(err)
+		s.Equal([]domain.Product{got}, products)
+	})
+}
+
+func (s *TrashSuite) Test_Purge() {
+	s.Run("Should keep the Products deleted after the given time", func() {
+		s.SetupTest()
+
+		purged, err := s.productsRepo.Purge(context.Background(), time.Now().Add(-time.Hour))
+
+		s.NoError(err)
+		s.Equal(0, purged)
+	})
+
+	s.Run("Should remove only the Products deleted before the given time", func() {
+		s.SetupTest()
+		ctx := context.Background()
+
+		purged, err := s.productsRepo.Purge(ctx, time.Now().Add(time.Second))
+
+		s.NoError(err)
+		s.Equal(1, purged)
+
+		got, err := s.productsRepo.List(ctx, domain.ListProductsFilter{IncludeDeleted: true})
+		s.NoError(err)
+		s.Require().Len(got, 1)
+		s.Equal(1, got[0].ID)
+
+		_, err = s.productsRepo.Restore(ctx, 2)
+		s.Equal(domain.ErrProductNotFound, err)
+	})
+}
+
+type CreateManySuite struct {
+	suite.Suite
+
+	loggerM      *zap.Logger
+	tracerM      trace.Tracer
+	productsRepo domain.ProductsRepository
+}
+
+func (s *CreateManySuite) SetupSuite() {
+	s.loggerM = zap.NewNop()
+	s.tracerM = trace.NewNoopTracerProvider().Tracer("")
+	s.productsRepo = MustNewInMemoryProductsRepository(s.loggerM, s.tracerM, 2)
+}
+
+func (s *CreateManySuite) Test_CreateMany() {
+	s.Run("Should store every Product it has room for and report the ones it couldn't store", func() {
+		products := []domain.Product{
+			{ID: 1, Name: "Iphone 13", Description: "Cool", Price: 4500},
+			{ID: 2, Name: "Macbook Pro M1 Max", Description: "Fast!", Price: 16500},
+			{ID: 3, Name: "Macbook Air M1", Description: "Nice!", Price: 6900},
+		}
+
+		stored := append([]domain.Product{}, products[:2]...)
+		for i := range stored {
+			stored[i].Version = 1
+		}
+
+		expectedResult := []domain.ProductResult{
+			{Product: stored[0]},
+			{Product: stored[1]},
+			{Err: ErrStorageLimitReached},
+		}
+
+		ctx := context.Background()
+		got, err := s.productsRepo.CreateMany(ctx, products)
+
+		s.NoError(err)
+		s.Equal(expectedResult, got)
+	})
+}
+
+type ListPageSuite struct {
+	suite.Suite
+
+	loggerM      *zap.Logger
+	tracerM      trace.Tracer
+	productsRepo domain.ProductsRepository
+}
+
+func (s *ListPageSuite) SetupSuite() {
+	s.loggerM = zap.NewNop()
+	s.tracerM = trace.NewNoopTracerProvider().Tracer("")
+	s.productsRepo = MustNewInMemoryProductsRepository(s.loggerM, s.tracerM, 3)
+}
+
+func (s *ListPageSuite) SetupTest() {
+	ctx := context.Background()
+
+	products := []domain.Product{
+		{ID: 3, Name: "Macbook Air M1", Description: "Nice!", Price: 6900},
+		{ID: 1, Name: "Iphone 13", Description: "Cool", Price: 4500},
+		{ID: 2, Name: "Macbook Pro M1 Max", Description: "Fast!", Price: 16500},
+	}
+
+	for _, product := range products {
+		_, err := s.productsRepo.Create(ctx, product)
+		s.NoError(err)
+	}
+}
+
+func (s *ListPageSuite) Test_ListPage() {
+	s.Run("Should list the first page of products ordered by ID", func() {
+		expectedResult := []domain.Product{
+			{ID: 1, Name: "Iphone 13", Description: "Cool", Price: 4500, Version: 1},
+			{ID: 2, Name: "Macbook Pro M1 Max", Description: "Fast!", Price: 16500, Version: 1},
+		}
+
+		ctx := context.Background()
+		got, err := s.productsRepo.ListPage(ctx, domain.ExportProductsFilter{Limit: 2})
+
+		s.NoError(err)
+		s.Equal(expectedResult, got)
+	})
+
+	s.Run("Should list only the products after the provided cursor", func() {
+		expectedResult := []domain.Product{
+			{ID: 3, Name: "Macbook Air M1", Description: "Nice!", Price: 6900, Version: 1},
+		}
+
+		ctx := context.Background()
+		got, err := s.productsRepo.ListPage(ctx, domain.ExportProductsFilter{AfterID: 2, Limit: 2})
+
+		s.NoError(err)
+		s.Equal(expectedResult, got)
+	})
+}
+
+type SKUSuite struct {
+	suite.Suite
+
+	loggerM      *zap.Logger
+	tracerM      trace.Tracer
+	productsRepo domain.ProductsRepository
+}
+
+func (s *SKUSuite) SetupTest() {
+	s.loggerM = zap.NewNop()
+	s.tracerM = trace.NewNoopTracerProvider().Tracer("")
+	s.productsRepo = MustNewInMemoryProductsRepository(s.loggerM, s.tracerM, 10)
+
+	ctx := context.Background()
+	products := []domain.Product{
+		{ID: 1, SKU: "IPH-13", Name: "Iphone 13", Description: "Cool", Price: 4500},
+		{ID: 2, SKU: "MBA-M1", Name: "Macbook Air M1", Description: "Nice!", Price: 6900},
+		{ID: 3, Name: "Macbook Pro M1 Max", Description: "Fast!", Price: 16500},
+	}
+
+	for _, product := range products {
+		_, err := s.productsRepo.Create(ctx, product)
+		s.NoError(err)
+	}
+}
+
+func (s *SKUSuite) Test_SKU() {
+	s.Run("Should not create a Product with a SKU already in use", func() {
+		_, err := s.productsRepo.Create(context.Background(), domain.Product{ID: 4, SKU: "IPH-13", Name: "Iphone 13 Mini"})
+
+		s.Equal(domain.ErrProductSKUAlreadyExists, err)
+	})
+
+	s.Run("Should not update a Product to a SKU used by another one", func() {
+		_, err := s.productsRepo.Update(context.Background(), domain.Product{ID: 2, SKU: "IPH-13", Name: "Macbook Air M1", Version: 1})
+
+		s.Equal(domain.ErrProductSKUAlreadyExists, err)
+	})
+
+	s.Run("Should keep the SKU of the updated Product", func() {
+		product := domain.Product{ID: 1, SKU: "IPH-13", Name: "Iphone 13", Description: "Cooler", Price: 4200, Version: 1}
+
+		got, err := s.productsRepo.Update(context.Background(), product)
+
+		s.NoError(err)
+		s.Equal("IPH-13", got.SKU)
+	})
+
+	s.Run("Should list only the products that matches the provided SKUs", func() {
+		got, err := s.productsRepo.List(context.Background(), domain.ListProductsFilter{SKUs: []string{"MBA-M1", "UNKNOWN"}})
+
+		s.NoError(err)
+		s.ElementsMatch([]domain.Product{
+			{ID: 2, SKU: "MBA-M1", Name: "Macbook Air M1", Description: "Nice!", Price: 6900, Version: 1},
+		}, got)
+	})
+}
+
+type SearchSuite struct {
+	suite.Suite
+
+	loggerM      *zap.Logger
+	tracerM      trace.Tracer
+	productsRepo domain.ProductsRepository
+}
+
+func (s *SearchSuite) SetupTest() {
+	s.loggerM = zap.NewNop()
+	s.tracerM = trace.NewNoopTracerProvider().Tracer("")
+	s.productsRepo = MustNewInMemoryProductsRepository(s.loggerM, s.tracerM, 10)
+
+	ctx := context.Background()
+	products := []domain.Product{
+		{ID: 1, Name: "Iphone 13", Description: "Cool", Price: 4500},
+		{ID: 2, Name: "Macbook Air M1", Description: "Nice!", Price: 6900},
+		{ID: 3, Name: "Macbook Pro M1 Max", Description: "Fast!", Price: 16500},
+		{ID: 4, Name: "Magic Keyboard", Description: "Works with any Macbook", Price: 900},
+	}
+
+	for _, product := range products {
+		_, err := s.productsRepo.Create(ctx, product)
+		s.NoError(err)
+	}
+}
+
+func (s *SearchSuite) ids(results []domain.ProductSearchResult) []int {
+	ids := make([]int, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, result.Product.ID)
+	}
+
+	return ids
+}
+
+func (s *SearchSuite) Test_Search() {
+	s.Run("Should rank the matches on the name above the ones on the description", func() {
+		got, err := s.productsRepo.Search(context.Background(), domain.ProductSearch{Query: "macbook", Limit: 10, Sort: domain.DefaultSearchSort})
+
+		s.NoError(err)
+		s.Equal([]int{2, 3, 4}, s.ids(got))
+		s.Equal(2.0, got[0].Score)
+		s.Equal(1.0, got[2].Score)
+	})
+
+	s.Run("Should match every term of the query", func() {
+		got, err := s.productsRepo.Search(context.Background(), domain.ProductSearch{Query: "Macbook PRO", Limit: 10, Sort: domain.DefaultSearchSort})
+
+		s.NoError(err)
+		s.Equal([]int{3}, s.ids(got))
+	})
+
+	s.Run("Should match the words starting with the term", func() {
+		got, err := s.productsRepo.Search(context.Background(), domain.ProductSearch{Query: "mac", Limit: 10, Sort: domain.DefaultSearchSort})
+
+		s.NoError(err)
+		s.Equal([]int{2, 3, 4}, s.ids(got))
+	})
+
+	s.Run("Should match the terms with typos, ranking them below the exact matches", func() {
+		got, err := s.productsRepo.Search(context.Background(), domain.ProductSearch{Query: "macbok", Limit: 10, Sort: domain.DefaultSearchSort})
+
+		s.NoError(err)
+		s.Equal([]int{2, 3, 4}, s.ids(got))
+		s.Equal(1.0, got[0].Score)
+	})
+
+	s.Run("Should not return anything when a term doesn't match", func() {
+		got, err := s.productsRepo.Search(context.Background(), domain.ProductSearch{Query: "macbook chair", Limit: 10, Sort: domain.DefaultSearchSort})
+
+		s.NoError(err)
+		s.Equal([]domain.ProductSearchResult{}, got)
+	})
+
+	s.Run("Should paginate the results", func() {
+		got, err := s.productsRepo.Search(context.Background(), domain.ProductSearch{Query: "macbook", Limit: 1, Offset: 1, Sort: domain.DefaultSearchSort})
+
+		s.NoError(err)
+		s.Equal([]int{3}, s.ids(got))
+	})
+
+	s.Run("Should leave the deleted products out", func() {
+		s.NoError(s.productsRepo.Delete(context.Background(), 3))
+
+		got, err := s.productsRepo.Search(context.Background(), domain.ProductSearch{Query: "macbook", Limit: 10, Sort: domain.DefaultSearchSort})
+
+		s.NoError(err)
+		s.Equal([]int{2, 4}, s.ids(got))
+	})
+}
+
+func (s *SearchSuite) Test_Reindex() {
+	s.Run("Should search the updated words of a product", func() {
+		_, err := s.productsRepo.Update(context.Background(), domain.Product{ID: 1, Name: "Iphone 13 Pro", Description: "Cooler", Price: 5500, Version: 1})
+		s.NoError(err)
+
+		got, err := s.productsRepo.Search(context.Background(), domain.ProductSearch{Query: "pro", Limit: 10})
+		s.NoError(err)
+		s.Equal([]int{1, 3}, s.ids(got))
+
+		got, err = s.productsRepo.Search(context.Background(), domain.ProductSearch{Query: "cool", Limit: 10})
+		s.NoError(err)
+		s.Equal([]int{1}, s.ids(got))
+		s.Equal(0.75, got[0].Score)
+	})
+
+	s.Run("Should remove the purged products from the index", func() {
+		s.NoError(s.productsRepo.Delete(context.Background(), 4))
+
+		_, err := s.productsRepo.Purge(context.Background(), time.Now().Add(time.Second))
+		s.NoError(err)
+
+		repo := s.productsRepo.(InMemoryProductsRepository)
+		s.NotContains(repo.index, "keyboard")
+	})
+}
+
+type FacetsSuite struct {
+	suite.Suite
+
+	loggerM      *zap.Logger
+	tracerM      trace.Tracer
+	productsRepo domain.ProductsRepository
+}
+
+func (s *FacetsSuite) SetupTest() {
+	s.loggerM = zap.NewNop()
+	s.tracerM = trace.NewNoopTracerProvider().Tracer("")
+	s.productsRepo = MustNewInMemoryProductsRepository(s.loggerM, s.tracerM, 10)
+
+	ctx := context.Background()
+	products := []domain.Product{
+		{ID: 1, Name: "Iphone 13", Price: 4500, Category: "phones", Attributes: map[string]string{"color": "blue"}},
+		{ID: 2, Name: "Iphone 12", Price: 3500, Category: "phones", Attributes: map[string]string{"color": "black"}},
+		{ID: 3, Name: "Macbook Air M1", Price: 6900, Category: "laptops", Attributes: map[string]string{"color": "black", "storage": "256GB"}},
+		{ID: 4, Name: "Case", Price: 900},
+		{ID: 5, Name: "Pixel 6", Price: 3000, Category: "phones", Attributes: map[string]string{"color": "black"}},
+	}
+
+	for _, product := range products {
+		_, err := s.productsRepo.Create(ctx, product)
+		s.NoError(err)
+	}
+
+	s.NoError(s.productsRepo.Delete(ctx, 5))
+}
+
+func (s *FacetsSuite) Test_Facets() {
+	s.Run("Should count the products by price range, category and attribute values", func() {
+		got, err := s.productsRepo.Facets(context.Background(), domain.FacetsFilter{PriceBoundaries: []int{1000, 5000}})
+
+		s.NoError(err)
+		s.Equal(domain.ProductFacets{
+			Total: 4,
+			PriceRanges: []domain.PriceRangeBucket{
+				{From: 0, To: 1000, Count: 1},
+				{From: 1000, To: 5000, Count: 2},
+				{From: 5000, Count: 1},
+			},
+			Categories: []domain.FacetBucket{
+				{Value: "phones", Count: 2},
+				{Value: "laptops", Count: 1},
+			},
+			Attributes: []domain.AttributeFacet{
+				{Name: "color", Values: []domain.FacetBucket{{Value: "black", Count: 2}, {Value: "blue", Count: 1}}},
+				{Name: "storage", Values: []domain.FacetBucket{{Value: "256GB", Count: 1}}},
+			},
+		}, got)
+	})
+
+	s.Run("Should count only the products matching the filter", func() {
+		got, err := s.productsRepo.Facets(context.Background(), domain.FacetsFilter{
+			ProductFilter:   domain.ProductFilter{PriceTo: 5000, Attributes: map[string]string{"color": "black"}},
+			PriceBoundaries: []int{1000},
+		})
+
+		s.NoError(err)
+		s.Equal(domain.ProductFacets{
+			Total: 1,
+			PriceRanges: []domain.PriceRangeBucket{
+				{From: 0, To: 1000},
+				{From: 1000, Count: 1},
+			},
+			Categories: []domain.FacetBucket{{Value: "phones", Count: 1}},
+			Attributes: []domain.AttributeFacet{
+				{Name: "color", Values: []domain.FacetBucket{{Value: "black", Count: 1}}},
+			},
+		}, got)
+	})
+}
+
+func (s *FacetsSuite) Test_List() {
+	s.Run("Should list only the products matching the filter", func() {
+		got, err := s.productsRepo.List(context.Background(), domain.ListProductsFilter{
+			ProductFilter: domain.ProductFilter{Category: "phones", PriceFrom: 4000},
+		})
+
+		s.NoError(err)
+		s.Equal([]domain.Product{
+			{ID: 1, Name: "Iphone 13", Price: 4500, Category: "phones", Attributes: map[string]string{"color": "blue"}, Version: 1},
+		}, got)
+	})
+
+	s.Run("Should not let the callers change the stored attributes", func() {
+		attributes := map[string]string{"color": "red"}
+		_, err := s.productsRepo.Update(context.Background(), domain.Product{ID: 4, Name: "Case", Price: 900, Attributes: attributes, Version: 1})
+		s.NoError(err)
+
+		attributes["color"] = "green"
+
+		got, err := s.productsRepo.List(context.Background(), domain.ListProductsFilter{IDs: []int{4}})
+		s.NoError(err)
+		s.Equal(map[string]string{"color": "red"}, got[0].Attributes)
+	})
+}
+
+type SortSuite struct {
+	suite.Suite
+
+	productsRepo domain.ProductsRepository
+}
+
+func (s *SortSuite) SetupTest() {
+	s.productsRepo = MustNewInMemoryProductsRepository(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), 10)
+
+	// created out of ID order, so the creation order can be told apart from it
+	products := []domain.Product{
+		{ID: 3, Name: "Macbook Pro M1 Max", Description: "Fast!", Price: 16500},
+		{ID: 1, Name: "Iphone 13", Description: "Cool", Price: 4500},
+		{ID: 4, Name: "Magic Keyboard", Description: "Works with any Macbook", Price: 4500},
+		{ID: 2, Name: "Macbook Air M1", Description: "Nice!", Price: 6900},
+	}
+
+	for _, product := range products {
+		_, err := s.productsRepo.Create(context.Background(), product)
+		s.NoError(err)
+	}
+}
+
+func (s *SortSuite) list(sort ...domain.SortKey) []int {
+	products, err := s.productsRepo.List(context.Background(), domain.ListProductsFilter{Sort: sort})
+	s.NoError(err)
+
+	ids := make([]int, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.ID)
+	}
+
+	return ids
+}
+
+func (s *SortSuite) Test_List() {
+	s.Run("Should order by ID when there's no sort", func() {
+		s.Equal([]int{1, 2, 3, 4}, s.list())
+	})
+
+	s.Run("Should order by name, comparing it byte-wise", func() {
+		s.Equal([]int{1, 2, 3, 4}, s.list(domain.SortKey{Field: domain.SortFieldName}))
+		s.Equal([]int{4, 3, 2, 1}, s.list(domain.SortKey{Field: domain.SortFieldName, Descending: true}))
+	})
+
+	s.Run("Should break the ties by ID", func() {
+		s.Equal([]int{1, 4, 2, 3}, s.list(domain.SortKey{Field: domain.SortFieldPrice}))
+		s.Equal([]int{3, 2, 1, 4}, s.list(domain.SortKey{Field: domain.SortFieldPrice, Descending: true}))
+	})
+
+	s.Run("Should order by the following keys when the first ones tie", func() {
+		s.Equal([]int{4, 1, 2, 3}, s.list(
+			domain.SortKey{Field: domain.SortFieldPrice},
+			domain.SortKey{Field: domain.SortFieldName, Descending: true},
+		))
+	})
+
+	s.Run("Should order by creation time", func() {
+		s.Equal([]int{3, 1, 4, 2}, s.list(domain.SortKey{Field: domain.SortFieldCreatedAt}))
+		s.Equal([]int{2, 4, 1, 3}, s.list(domain.SortKey{Field: domain.SortFieldCreatedAt, Descending: true}))
+	})
+
+	s.Run("Should order by update time, keeping the creation time of updated products", func() {
+		_, err := s.productsRepo.Update(context.Background(), domain.Product{ID: 3, Name: "Macbook Pro M2", Price: 17500, Version: 1})
+		s.NoError(err)
+
+		s.Equal([]int{1, 4, 2, 3}, s.list(domain.SortKey{Field: domain.SortFieldUpdatedAt}))
+		s.Equal([]int{3, 1, 4, 2}, s.list(domain.SortKey{Field: domain.SortFieldCreatedAt}))
+	})
+}
+
+func (s *SortSuite) Test_Search() {
+	s.Run("Should order the search results by the sort keys", func() {
+		got, err := s.productsRepo.Search(context.Background(), domain.ProductSearch{
+			Query: "macbook",
+			Limit: 10,
+			Sort:  []domain.SortKey{{Field: domain.SortFieldPrice}},
+		})
+
+		s.NoError(err)
+
+		ids := make([]int, 0, len(got))
+		for _, result := range got {
+			ids = append(ids, result.Product.ID)
+		}
+
+		s.Equal([]int{4, 2, 3}, ids)
 	})
 }
 
@@ -303,5 +1034,13 @@ func TestInMemoryProductsRepositorySuites(t *testing.T) {
 	suite.Run(t, new(CreateSuite))
 	suite.Run(t, new(ListSuite))
 	suite.Run(t, new(UpdateSuite))
+	suite.Run(t, new(PatchSuite))
 	suite.Run(t, new(DeleteSuite))
+	suite.Run(t, new(TrashSuite))
+	suite.Run(t, new(CreateManySuite))
+	suite.Run(t, new(SKUSuite))
+	suite.Run(t, new(ListPageSuite))
+	suite.Run(t, new(SearchSuite))
+	suite.Run(t, new(FacetsSuite))
+	suite.Run(t, new(SortSuite))
 }
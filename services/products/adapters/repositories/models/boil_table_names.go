@@ -4,7 +4,11 @@
 package models
 
 var TableNames = struct {
-	Products string
+	ProductAuditEntries string
+	ProductImages       string
+	Products            string
 }{
-	Products: "products",
+	ProductAuditEntries: "product_audit_entries",
+	ProductImages:       "product_images",
+	Products:            "products",
 }
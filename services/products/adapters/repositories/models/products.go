@@ -14,21 +14,33 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 	"github.com/volatiletech/sqlboiler/v4/queries/qmhelper"
+	"github.com/volatiletech/sqlboiler/v4/types"
 	"github.com/volatiletech/strmangle"
 )
 
 // Product is an object representing the database table.
 type Product struct {
-	ID          int       `boil:"id" json:"id" toml:"id" yaml:"id"`
-	Name        string    `boil:"name" json:"name" toml:"name" yaml:"name"`
-	Description string    `boil:"description" json:"description" toml:"description" yaml:"description"`
-	Price       int       `boil:"price" json:"price" toml:"price" yaml:"price"`
-	CreatedAt   time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt   time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ID          int        `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Name        string     `boil:"name" json:"name" toml:"name" yaml:"name"`
+	Description string     `boil:"description" json:"description" toml:"description" yaml:"description"`
+	Price       int        `boil:"price" json:"price" toml:"price" yaml:"price"`
+	CreatedAt   time.Time  `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt   time.Time  `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	Sku         string     `boil:"sku" json:"sku" toml:"sku" yaml:"sku"`
+	Version     int        `boil:"version" json:"version" toml:"version" yaml:"version"`
+	DeletedAt   null.Time  `boil:"deleted_at" json:"deleted_at,omitempty" toml:"deleted_at" yaml:"deleted_at,omitempty"`
+	Category    string     `boil:"category" json:"category" toml:"category" yaml:"category"`
+	Attributes  types.JSON `boil:"attributes" json:"attributes" toml:"attributes" yaml:"attributes"`
+	TaxClass    string     `boil:"tax_class" json:"tax_class" toml:"tax_class" yaml:"tax_class"`
+	Weight      int        `boil:"weight" json:"weight" toml:"weight" yaml:"weight"`
+	Length      int        `boil:"length" json:"length" toml:"length" yaml:"length"`
+	Width       int        `boil:"width" json:"width" toml:"width" yaml:"width"`
+	Height      int        `boil:"height" json:"height" toml:"height" yaml:"height"`
 
 	R *productR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L productL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -41,6 +53,16 @@ var ProductColumns = struct {
 	Price       string
 	CreatedAt   string
 	UpdatedAt   string
+	Sku         string
+	Version     string
+	DeletedAt   string
+	Category    string
+	Attributes  string
+	TaxClass    string
+	Weight      string
+	Length      string
+	Width       string
+	Height      string
 }{
 	ID:          "id",
 	Name:        "name",
@@ -48,6 +70,16 @@ var ProductColumns = struct {
 	Price:       "price",
 	CreatedAt:   "created_at",
 	UpdatedAt:   "updated_at",
+	Sku:         "sku",
+	Version:     "version",
+	DeletedAt:   "deleted_at",
+	Category:    "category",
+	Attributes:  "attributes",
+	TaxClass:    "tax_class",
+	Weight:      "weight",
+	Length:      "length",
+	Width:       "width",
+	Height:      "height",
 }
 
 var ProductTableColumns = struct {
@@ -57,6 +89,16 @@ var ProductTableColumns = struct {
 	Price       string
 	CreatedAt   string
 	UpdatedAt   string
+	Sku         string
+	Version     string
+	DeletedAt   string
+	Category    string
+	Attributes  string
+	TaxClass    string
+	Weight      string
+	Length      string
+	Width       string
+	Height      string
 }{
 	ID:          "products.id",
 	Name:        "products.name",
@@ -64,77 +106,44 @@ var ProductTableColumns = struct {
 	Price:       "products.price",
 	CreatedAt:   "products.created_at",
 	UpdatedAt:   "products.updated_at",
+	Sku:         "products.sku",
+	Version:     "products.version",
+	DeletedAt:   "products.deleted_at",
+	Category:    "products.category",
+	Attributes:  "products.attributes",
+	TaxClass:    "products.tax_class",
+	Weight:      "products.weight",
+	Length:      "products.length",
+	Width:       "products.width",
+	Height:      "products.height",
 }
 
 // Generated where
 
-type whereHelperint struct{ field string }
+type whereHelpernull_Time struct{ field string }
 
-func (w whereHelperint) EQ(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint) NEQ(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint) LT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint) LTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint) GT(x int) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint) GTE(x int) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperint) IN(slice []int) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
 }
-func (w whereHelperint) NIN(slice []int) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
 }
-
-type whereHelperstring struct{ field string }
-
-func (w whereHelperstring) EQ(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-type whereHelpertime_Time struct{ field string }
-
-func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.EQ, x)
-}
-func (w whereHelpertime_Time) NEQ(x time.Time) qm.QueryMod {
-	return qmhelper.Where(w.field, qmhelper.NEQ, x)
-}
-func (w whereHelpertime_Time) LT(x time.Time) qm.QueryMod {
+func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LT, x)
 }
-func (w whereHelpertime_Time) LTE(x time.Time) qm.QueryMod {
+func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LTE, x)
 }
-func (w whereHelpertime_Time) GT(x time.Time) qm.QueryMod {
+func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GT, x)
 }
-func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
+func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
+func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 var ProductWhere = struct {
 	ID          whereHelperint
 	Name        whereHelperstring
@@ -142,6 +151,16 @@ var ProductWhere = struct {
 	Price       whereHelperint
 	CreatedAt   whereHelpertime_Time
 	UpdatedAt   whereHelpertime_Time
+	Sku         whereHelperstring
+	Version     whereHelperint
+	DeletedAt   whereHelpernull_Time
+	Category    whereHelperstring
+	Attributes  whereHelpertypes_JSON
+	TaxClass    whereHelperstring
+	Weight      whereHelperint
+	Length      whereHelperint
+	Width       whereHelperint
+	Height      whereHelperint
 }{
 	ID:          whereHelperint{field: "\"products\".\"id\""},
 	Name:        whereHelperstring{field: "\"products\".\"name\""},
@@ -149,6 +168,16 @@ var ProductWhere = struct {
 	Price:       whereHelperint{field: "\"products\".\"price\""},
 	CreatedAt:   whereHelpertime_Time{field: "\"products\".\"created_at\""},
 	UpdatedAt:   whereHelpertime_Time{field: "\"products\".\"updated_at\""},
+	Sku:         whereHelperstring{field: "\"products\".\"sku\""},
+	Version:     whereHelperint{field: "\"products\".\"version\""},
+	DeletedAt:   whereHelpernull_Time{field: "\"products\".\"deleted_at\""},
+	Category:    whereHelperstring{field: "\"products\".\"category\""},
+	Attributes:  whereHelpertypes_JSON{field: "\"products\".\"attributes\""},
+	TaxClass:    whereHelperstring{field: "\"products\".\"tax_class\""},
+	Weight:      whereHelperint{field: "\"products\".\"weight\""},
+	Length:      whereHelperint{field: "\"products\".\"length\""},
+	Width:       whereHelperint{field: "\"products\".\"width\""},
+	Height:      whereHelperint{field: "\"products\".\"height\""},
 }
 
 // ProductRels is where relationship names are stored.
@@ -168,9 +197,9 @@ func (*productR) NewStruct() *productR {
 type productL struct{}
 
 var (
-	productAllColumns            = []string{"id", "name", "description", "price", "created_at", "updated_at"}
-	productColumnsWithoutDefault = []string{"name", "description", "price", "created_at", "updated_at"}
-	productColumnsWithDefault    = []string{"id"}
+	productAllColumns            = []string{"id", "name", "description", "price", "created_at", "updated_at", "sku", "version", "deleted_at", "category", "attributes", "tax_class", "weight", "length", "width", "height"}
+	productColumnsWithoutDefault = []string{"name", "description", "price", "created_at", "updated_at", "deleted_at"}
+	productColumnsWithDefault    = []string{"id", "sku", "version", "category", "attributes", "tax_class", "weight", "length", "width", "height"}
 	productPrimaryKeyColumns     = []string{"id"}
 )
 
@@ -0,0 +1,220 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/products/adapters/repositories/models"
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// productSnapshot is how a Product is stored on the before and after columns of an audit entry
+type productSnapshot struct {
+	ID          int                 `json:"id"`
+	SKU         string              `json:"sku"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Price       int                 `json:"price"`
+	Category    string              `json:"category,omitempty"`
+	Attributes  map[string]string   `json:"attributes,omitempty"`
+	TaxClass    string              `json:"tax_class,omitempty"`
+	Weight      int                 `json:"weight,omitempty"`
+	Dimensions  *dimensionsSnapshot `json:"dimensions,omitempty"`
+	Version     int                 `json:"version"`
+	DeletedAt   *time.Time          `json:"deleted_at,omitempty"`
+}
+
+// dimensionsSnapshot is how the Dimensions of a Product are stored on an audit entry, in millimeters
+type dimensionsSnapshot struct {
+	Length int `json:"length"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+type PgAuditRepository struct {
+	db *sql.DB
+}
+
+func NewPgAuditRepository(connectionString string) (*PgAuditRepository, error) {
+	db, err := sql.Open(
+		"postgres",
+		connectionString,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	repository := &PgAuditRepository{
+		db: db,
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+func MustNewPgAuditRepository(connectionString string) *PgAuditRepository {
+	repo, err := NewPgAuditRepository(connectionString)
+	if err != nil {
+		panic(err)
+	}
+
+	return repo
+}
+
+func (r *PgAuditRepository) Record(ctx context.Context, entry domain.AuditEntry) (domain.AuditEntry, error) {
+	after, err := json.Marshal(toProductSnapshot(*entry.After))
+	if err != nil {
+		return domain.AuditEntry{}, err
+	}
+
+	e := models.ProductAuditEntry{
+		ProductID: entry.ProductID,
+		Action:    string(entry.Action),
+		Actor:     entry.Actor,
+		After:     after,
+		TraceID:   entry.TraceID,
+		CreatedAt: entry.CreatedAt,
+	}
+
+	if entry.Before != nil {
+		before, err := json.Marshal(toProductSnapshot(*entry.Before))
+		if err != nil {
+			return domain.AuditEntry{}, err
+		}
+
+		e.Before = null.JSONFrom(before)
+	}
+
+	err = e.Insert(ctx, r.db, boil.Infer())
+	if err != nil {
+		return domain.AuditEntry{}, err
+	}
+
+	return toDomainAuditEntry(&e)
+}
+
+// ListByProduct lists the audit entries of a Product, newest first
+func (r *PgAuditRepository) ListByProduct(ctx context.Context, filter domain.ProductHistoryFilter) ([]domain.AuditEntry, error) {
+	queryMods := []qm.QueryMod{
+		models.ProductAuditEntryWhere.ProductID.EQ(filter.ProductID),
+		qm.OrderBy(models.ProductAuditEntryColumns.ID + " desc"),
+		qm.Limit(filter.Limit),
+	}
+
+	if filter.BeforeID != 0 {
+		queryMods = append(queryMods, models.ProductAuditEntryWhere.ID.LT(filter.BeforeID))
+	}
+
+	entries, err := models.ProductAuditEntries(queryMods...).All(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]domain.AuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		e, err := toDomainAuditEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+
+		response = append(response, e)
+	}
+
+	return response, nil
+}
+
+// toDomainAuditEntry converts a database ProductAuditEntry into a domain.AuditEntry
+func toDomainAuditEntry(entry *models.ProductAuditEntry) (domain.AuditEntry, error) {
+	e := domain.AuditEntry{
+		ID:        entry.ID,
+		ProductID: entry.ProductID,
+		Action:    domain.AuditAction(entry.Action),
+		Actor:     entry.Actor,
+		TraceID:   entry.TraceID,
+		CreatedAt: entry.CreatedAt,
+	}
+
+	var after productSnapshot
+	if err := entry.After.Unmarshal(&after); err != nil {
+		return domain.AuditEntry{}, err
+	}
+
+	e.After = after.toDomain()
+
+	if entry.Before.Valid {
+		var before productSnapshot
+		if err := entry.Before.Unmarshal(&before); err != nil {
+			return domain.AuditEntry{}, err
+		}
+
+		e.Before = before.toDomain()
+	}
+
+	return e, nil
+}
+
+func toProductSnapshot(product domain.Product) productSnapshot {
+	snapshot := productSnapshot{
+		ID:          product.ID,
+		SKU:         product.SKU,
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       product.Price,
+		Category:    product.Category,
+		Attributes:  product.Attributes,
+		TaxClass:    product.TaxClass,
+		Weight:      product.Weight,
+		Version:     product.Version,
+	}
+
+	if !product.Dimensions.Zero() {
+		snapshot.Dimensions = &dimensionsSnapshot{
+			Length: product.Dimensions.Length,
+			Width:  product.Dimensions.Width,
+			Height: product.Dimensions.Height,
+		}
+	}
+
+	if product.Deleted() {
+		snapshot.DeletedAt = &product.DeletedAt
+	}
+
+	return snapshot
+}
+
+func (s productSnapshot) toDomain() *domain.Product {
+	product := &domain.Product{
+		ID:          s.ID,
+		SKU:         s.SKU,
+		Name:        s.Name,
+		Description: s.Description,
+		Price:       s.Price,
+		Category:    s.Category,
+		Attributes:  s.Attributes,
+		TaxClass:    s.TaxClass,
+		Weight:      s.Weight,
+		Version:     s.Version,
+	}
+
+	if s.Dimensions != nil {
+		product.Dimensions = domain.Dimensions{
+			Length: s.Dimensions.Length,
+			Width:  s.Dimensions.Width,
+			Height: s.Dimensions.Height,
+		}
+	}
+
+	if s.DeletedAt != nil {
+		product.DeletedAt = *s.DeletedAt
+	}
+
+	return product
+}
@@ -0,0 +1,325 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/lucasmls/ecommerce/services/products/adapters/repositories/models"
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// thumbnailRecord is how a Thumbnail is stored on the thumbnails column of an image
+type thumbnailRecord struct {
+	Name   string `json:"name"`
+	Key    string `json:"key"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+type PgImagesRepository struct {
+	db *sql.DB
+}
+
+func NewPgImagesRepository(connectionString string) (*PgImagesRepository, error) {
+	db, err := sql.Open(
+		"postgres",
+		connectionString,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	repository := &PgImagesRepository{
+		db: db,
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+func MustNewPgImagesRepository(connectionString string) *PgImagesRepository {
+	repo, err := NewPgImagesRepository(connectionString)
+	if err != nil {
+		panic(err)
+	}
+
+	return repo
+}
+
+// Create inserts an image after the other images of its Product
+func (r *PgImagesRepository) Create(ctx context.Context, image domain.ProductImage) (domain.ProductImage, error) {
+	thumbnails, err := json.Marshal(toThumbnailRecords(image.Thumbnails))
+	if err != nil {
+		return domain.ProductImage{}, err
+	}
+
+	tx, err := r.lockProduct(ctx, image.ProductID)
+	if err != nil {
+		return domain.ProductImage{}, err
+	}
+	defer tx.Rollback()
+
+	count, err := models.ProductImages(models.ProductImageWhere.ProductID.EQ(image.ProductID)).Count(ctx, tx)
+	if err != nil {
+		return domain.ProductImage{}, err
+	}
+
+	if count >= domain.MaxProductImages {
+		return domain.ProductImage{}, domain.ErrTooManyImages
+	}
+
+	i := models.ProductImage{
+		ProductID:   image.ProductID,
+		Position:    int(count),
+		BlobKey:     image.Key,
+		ContentType: image.ContentType,
+		Width:       image.Width,
+		Height:      image.Height,
+		Size:        image.Size,
+		AltText:     image.AltText,
+		Thumbnails:  thumbnails,
+		CreatedAt:   image.CreatedAt,
+	}
+
+	if err := i.Insert(ctx, tx, boil.Infer()); err != nil {
+		return domain.ProductImage{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.ProductImage{}, err
+	}
+
+	return toDomainProductImage(&i)
+}
+
+func (r *PgImagesRepository) Get(ctx context.Context, id int) (domain.ProductImage, error) {
+	i, err := models.FindProductImage(ctx, r.db, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ProductImage{}, domain.ErrImageNotFound
+		}
+
+		return domain.ProductImage{}, err
+	}
+
+	return toDomainProductImage(i)
+}
+
+// ListByProducts lists the images of the given Products, ordered by Product and then by position
+func (r *PgImagesRepository) ListByProducts(ctx context.Context, productIDs []int) ([]domain.ProductImage, error) {
+	if len(productIDs) == 0 {
+		return []domain.ProductImage{}, nil
+	}
+
+	images, err := models.ProductImages(
+		models.ProductImageWhere.ProductID.IN(productIDs),
+		qm.OrderBy(models.ProductImageColumns.ProductID+", "+models.ProductImageColumns.Position),
+	).All(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDomainProductImages(images)
+}
+
+// Delete removes an image, moving the images after it one position up
+func (r *PgImagesRepository) Delete(ctx context.Context, id int) error {
+	i, err := models.FindProductImage(ctx, r.db, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrImageNotFound
+		}
+
+		return err
+	}
+
+	tx, err := r.lockProduct(ctx, i.ProductID)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// the image may have been moved, or deleted, before the lock was acquired
+	i, err = models.FindProductImage(ctx, tx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrImageNotFound
+		}
+
+		return err
+	}
+
+	if _, err := i.Delete(ctx, tx); err != nil {
+		return err
+	}
+
+	_, err = queries.Raw(
+		"UPDATE product_images SET position = position - 1 WHERE product_id = $1 AND position > $2",
+		i.ProductID, i.Position,
+	).ExecContext(ctx, tx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Reorder places the images of a Product in the given order of IDs
+func (r *PgImagesRepository) Reorder(ctx context.Context, productID int, ids []int) ([]domain.ProductImage, error) {
+	tx, err := r.lockProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	images, err := models.ProductImages(
+		models.ProductImageWhere.ProductID.EQ(productID),
+		qm.OrderBy(models.ProductImageColumns.Position),
+	).All(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := toDomainProductImages(images)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateImageOrder(current, ids); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]*models.ProductImage, len(images))
+	for _, image := range images {
+		byID[image.ID] = image
+	}
+
+	reordered := make(models.ProductImageSlice, 0, len(ids))
+	for position, id := range ids {
+		image := byID[id]
+		image.Position = position
+
+		if _, err := image.Update(ctx, tx, boil.Whitelist(models.ProductImageColumns.Position)); err != nil {
+			return nil, err
+		}
+
+		reordered = append(reordered, image)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return toDomainProductImages(reordered)
+}
+
+// DeleteByProduct removes every image of a Product, returning them
+func (r *PgImagesRepository) DeleteByProduct(ctx context.Context, productID int) ([]domain.ProductImage, error) {
+	tx, err := r.lockProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	images, err := models.ProductImages(
+		models.ProductImageWhere.ProductID.EQ(productID),
+		qm.OrderBy(models.ProductImageColumns.Position),
+	).All(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := images.DeleteAll(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return toDomainProductImages(images)
+}
+
+// lockProduct begins a transaction holding an advisory lock on the images of a Product,
+// so their positions are only ever changed by one transaction at a time
+func (r *PgImagesRepository) lockProduct(ctx context.Context, productID int) (*sql.Tx, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock('product_images'::regclass::oid::int, $1)", productID); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+func toDomainProductImages(images models.ProductImageSlice) ([]domain.ProductImage, error) {
+	response := make([]domain.ProductImage, 0, len(images))
+	for _, image := range images {
+		i, err := toDomainProductImage(image)
+		if err != nil {
+			return nil, err
+		}
+
+		response = append(response, i)
+	}
+
+	return response, nil
+}
+
+// toDomainProductImage converts a database ProductImage into a domain.ProductImage
+func toDomainProductImage(image *models.ProductImage) (domain.ProductImage, error) {
+	var records []thumbnailRecord
+	if err := image.Thumbnails.Unmarshal(&records); err != nil {
+		return domain.ProductImage{}, err
+	}
+
+	thumbnails := make([]domain.Thumbnail, 0, len(records))
+	for _, record := range records {
+		thumbnails = append(thumbnails, domain.Thumbnail{
+			Name:   record.Name,
+			Key:    record.Key,
+			Width:  record.Width,
+			Height: record.Height,
+		})
+	}
+
+	return domain.ProductImage{
+		ID:          image.ID,
+		ProductID:   image.ProductID,
+		Position:    image.Position,
+		Key:         image.BlobKey,
+		ContentType: image.ContentType,
+		Width:       image.Width,
+		Height:      image.Height,
+		Size:        image.Size,
+		AltText:     image.AltText,
+		Thumbnails:  thumbnails,
+		CreatedAt:   image.CreatedAt,
+	}, nil
+}
+
+func toThumbnailRecords(thumbnails []domain.Thumbnail) []thumbnailRecord {
+	records := make([]thumbnailRecord, 0, len(thumbnails))
+	for _, thumbnail := range thumbnails {
+		records = append(records, thumbnailRecord{
+			Name:   thumbnail.Name,
+			Key:    thumbnail.Key,
+			Width:  thumbnail.Width,
+			Height: thumbnail.Height,
+		})
+	}
+
+	return records
+}
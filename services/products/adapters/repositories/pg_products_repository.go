@@ -3,14 +3,80 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"strconv"
+	"strings"
+	"time"
 
-	_ "github.com/lib/pq"
 	"github.com/lucasmls/ecommerce/services/products/adapters/repositories/models"
 	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/lucasmls/ecommerce/shared/pg"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+	"github.com/volatiletech/sqlboiler/v4/types"
 )
 
+// productFieldColumns maps the patchable Product fields to their columns
+var productFieldColumns = map[domain.ProductField][]string{
+	domain.ProductFieldSKU:         {models.ProductColumns.Sku},
+	domain.ProductFieldName:        {models.ProductColumns.Name},
+	domain.ProductFieldDescription: {models.ProductColumns.Description},
+	domain.ProductFieldPrice:       {models.ProductColumns.Price},
+	domain.ProductFieldCategory:    {models.ProductColumns.Category},
+	domain.ProductFieldAttributes:  {models.ProductColumns.Attributes},
+	domain.ProductFieldTaxClass:    {models.ProductColumns.TaxClass},
+	domain.ProductFieldWeight:      {models.ProductColumns.Weight},
+	domain.ProductFieldDimensions:  {models.ProductColumns.Length, models.ProductColumns.Width, models.ProductColumns.Height},
+}
+
+// sortColumns maps the sort fields to the expressions the Products are ordered by.
+// Names are compared byte-wise, as Go compares strings, so every repository sorts them the same way.
+var sortColumns = map[domain.SortField]string{
+	domain.SortFieldName:      models.ProductTableColumns.Name + ` COLLATE "C"`,
+	domain.SortFieldPrice:     models.ProductTableColumns.Price,
+	domain.SortFieldCreatedAt: models.ProductTableColumns.CreatedAt,
+	domain.SortFieldUpdatedAt: models.ProductTableColumns.UpdatedAt,
+	domain.SortFieldRelevance: "score",
+}
+
+// searchQuery scores the Products matching the prefixes of every term, or resembling the whole query
+// despite typos, by their text search rank plus their trigram similarity to the query.
+// It's followed by the ORDER BY clause built by orderBy and by the LIMIT $3 OFFSET $4 pagination.
+// The expressions match the ones of the products_search_idx and products_search_trgm_idx indexes.
+const searchQuery = `
+SELECT products.*,
+       ts_rank(setweight(to_tsvector('simple', name), 'A') || setweight(to_tsvector('simple', description), 'B'), query) +
+       word_similarity($2, name || ' ' || description) AS score
+FROM products, to_tsquery('simple', $1) AS query
+WHERE deleted_at IS NULL
+  AND (
+    (setweight(to_tsvector('simple', name), 'A') || setweight(to_tsvector('simple', description), 'B')) @@ query
+    OR $2 <% (name || ' ' || description)
+  )
+`
+
+// productSearchRow is a row returned by searchQuery
+type productSearchRow struct {
+	models.Product `boil:",bind"`
+	Score          float64 `boil:"score"`
+}
+
+// priceRangeRow is a row of the price ranges facet, bucket is the index of the range
+type priceRangeRow struct {
+	Bucket int `boil:"bucket"`
+	Count  int `boil:"count"`
+}
+
+// facetRow is a row of the categories and attributes facets, name is only set for the attributes
+type facetRow struct {
+	Name  string `boil:"name"`
+	Value string `boil:"value"`
+	Count int    `boil:"count"`
+}
+
 type PgProductsRepository struct {
 	db *sql.DB
 }
@@ -46,28 +112,148 @@ func MustNewPgProductsRepository(connectionString string) *PgProductsRepository
 
 func (r *PgProductsRepository) Create(ctx context.Context, product domain.Product) (domain.Product, error) {
 	p := models.Product{
+		Sku:         product.SKU,
 		Name:        product.Name,
 		Description: product.Description,
 		Price:       product.Price,
+		Category:    product.Category,
+		Attributes:  toJSONAttributes(product.Attributes),
+		TaxClass:    product.TaxClass,
+		Weight:      product.Weight,
+		Length:      product.Dimensions.Length,
+		Width:       product.Dimensions.Width,
+		Height:      product.Dimensions.Height,
 	}
 
 	err := p.Insert(ctx, r.db, boil.Infer())
 	if err != nil {
-		return domain.Product{}, err
+		return domain.Product{}, fromPgError(err)
 	}
 
-	response := domain.Product{
-		ID:          p.ID,
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
+	return toDomainProduct(&p), nil
+}
+
+// Update updates a Product, locking its row so the version check and the write happen atomically
+func (r *PgProductsRepository) Update(ctx context.Context, product domain.Product) (domain.Product, error) {
+	return r.updateLocked(ctx, product.ID, boil.Infer(), func(p *models.Product) error {
+		if err := checkWritable(p, product.Version); err != nil {
+			return err
+		}
+
+		p.Sku = product.SKU
+		p.Name = product.Name
+		p.Description = product.Description
+		p.Price = product.Price
+		p.Category = product.Category
+		p.Attributes = toJSONAttributes(product.Attributes)
+		p.TaxClass = product.TaxClass
+		p.Weight = product.Weight
+		p.Length = product.Dimensions.Length
+		p.Width = product.Dimensions.Width
+		p.Height = product.Dimensions.Height
+
+		return nil
+	})
+}
+
+// Patch updates only the columns of the fields listed on the patch
+func (r *PgProductsRepository) Patch(ctx context.Context, patch domain.ProductPatch) (domain.Product, error) {
+	columns := []string{models.ProductColumns.Version, models.ProductColumns.UpdatedAt}
+	for _, field := range patch.Fields {
+		fieldColumns, ok := productFieldColumns[field]
+		if !ok {
+			return domain.Product{}, domain.ErrInvalidProductField
+		}
+
+		columns = append(columns, fieldColumns...)
 	}
 
-	return response, nil
+	return r.updateLocked(ctx, patch.Product.ID, boil.Whitelist(columns...), func(p *models.Product) error {
+		if err := checkWritable(p, patch.Product.Version); err != nil {
+			return err
+		}
+
+		patched := patch.Apply(toDomainProduct(p))
+
+		p.Sku = patched.SKU
+		p.Name = patched.Name
+		p.Description = patched.Description
+		p.Price = patched.Price
+		p.Category = patched.Category
+		p.Attributes = toJSONAttributes(patched.Attributes)
+		p.TaxClass = patched.TaxClass
+		p.Weight = patched.Weight
+		p.Length = patched.Dimensions.Length
+		p.Width = patched.Dimensions.Width
+		p.Height = patched.Dimensions.Height
+
+		return nil
+	})
 }
 
-func (r *PgProductsRepository) Update(ctx context.Context, product domain.Product) (domain.Product, error) {
-	p, err := models.FindProduct(ctx, r.db, product.ID)
+// Delete soft-deletes a Product, setting its deleted_at
+func (r *PgProductsRepository) Delete(ctx context.Context, id int) error {
+	columns := boil.Whitelist(models.ProductColumns.DeletedAt, models.ProductColumns.Version, models.ProductColumns.UpdatedAt)
+
+	_, err := r.updateLocked(ctx, id, columns, func(p *models.Product) error {
+		if err := checkWritable(p, 0); err != nil {
+			return err
+		}
+
+		p.DeletedAt = null.TimeFrom(time.Now())
+
+		return nil
+	})
+
+	return err
+}
+
+// Restore clears the deleted_at of a soft-deleted Product
+func (r *PgProductsRepository) Restore(ctx context.Context, id int) (domain.Product, error) {
+	columns := boil.Whitelist(models.ProductColumns.DeletedAt, models.ProductColumns.Version, models.ProductColumns.UpdatedAt)
+
+	return r.updateLocked(ctx, id, columns, func(p *models.Product) error {
+		if !p.DeletedAt.Valid {
+			return domain.ErrProductNotDeleted
+		}
+
+		p.DeletedAt = null.Time{}
+
+		return nil
+	})
+}
+
+// Purge hard-deletes the Products soft-deleted before the given time
+func (r *PgProductsRepository) Purge(ctx context.Context, deletedBefore time.Time) (int, error) {
+	purged, err := models.Products(
+		models.ProductWhere.DeletedAt.IsNotNull(),
+		models.ProductWhere.DeletedAt.LT(null.TimeFrom(deletedBefore)),
+	).DeleteAll(ctx, r.db)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(purged), nil
+}
+
+// updateLocked locks the row of a Product, applies the changes, increments its version and writes the given columns.
+// The changes are discarded if apply fails.
+func (r *PgProductsRepository) updateLocked(
+	ctx context.Context,
+	id int,
+	columns boil.Columns,
+	apply func(*models.Product) error,
+) (domain.Product, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.Product{}, err
+	}
+	defer tx.Rollback()
+
+	p, err := models.Products(
+		models.ProductWhere.ID.EQ(id),
+		qm.For("UPDATE"),
+	).One(ctx, tx)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.Product{}, domain.ErrProductNotFound
@@ -76,58 +262,292 @@ func (r *PgProductsRepository) Update(ctx context.Context, product domain.Produc
 		return domain.Product{}, err
 	}
 
-	p.Name = product.Name
-	p.Description = product.Description
-	p.Price = product.Price
+	if err := apply(p); err != nil {
+		return domain.Product{}, err
+	}
 
-	_, err = p.Update(ctx, r.db, boil.Infer())
+	p.Version++
+
+	_, err = p.Update(ctx, tx, columns)
 	if err != nil {
-		return domain.Product{}, err
+		return domain.Product{}, fromPgError(err)
 	}
 
-	response := domain.Product{
-		ID:          p.ID,
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
+	if err := tx.Commit(); err != nil {
+		return domain.Product{}, err
 	}
 
-	return response, nil
+	return toDomainProduct(p), nil
 }
 
-func (r *PgProductsRepository) Delete(ctx context.Context, id int) error {
-	product, err := models.FindProduct(ctx, r.db, id)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return domain.ErrProductNotFound
-		}
-
-		return err
+// checkWritable fails if the Product is deleted or if version doesn't match its stored one
+func checkWritable(p *models.Product, version int) error {
+	if p.DeletedAt.Valid {
+		return domain.ErrProductNotFound
 	}
 
-	_, err = product.Delete(ctx, r.db)
-	if err != nil {
-		return err
+	if version != p.Version {
+		return domain.ErrVersionConflict
 	}
 
 	return nil
 }
 
 func (r *PgProductsRepository) List(ctx context.Context, filter domain.ListProductsFilter) ([]domain.Product, error) {
-	products, err := models.Products().All(ctx, r.db)
+	queryMods := productFilterMods(filter.ProductFilter)
+	if len(filter.IDs) > 0 {
+		queryMods = append(queryMods, models.ProductWhere.ID.IN(filter.IDs))
+	}
+
+	if len(filter.SKUs) > 0 {
+		queryMods = append(queryMods, models.ProductWhere.Sku.IN(filter.SKUs))
+	}
+
+	switch {
+	case filter.OnlyDeleted:
+		queryMods = append(queryMods, models.ProductWhere.DeletedAt.IsNotNull())
+	case !filter.IncludeDeleted:
+		queryMods = append(queryMods, models.ProductWhere.DeletedAt.IsNull())
+	}
+
+	queryMods = append(queryMods, qm.OrderBy(orderBy(filter.Sort)))
+
+	products, err := models.Products(queryMods...).All(ctx, r.db)
 	if err != nil {
 		return nil, err
 	}
 
 	var response []domain.Product
 	for _, product := range products {
-		response = append(response, domain.Product{
-			ID:          product.ID,
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-		})
+		response = append(response, toDomainProduct(product))
 	}
 
 	return response, err
 }
+
+func (r *PgProductsRepository) CreateMany(ctx context.Context, products []domain.Product) ([]domain.ProductResult, error) {
+	results := make([]domain.ProductResult, 0, len(products))
+	for _, product := range products {
+		createdProduct, err := r.Create(ctx, product)
+		results = append(results, domain.ProductResult{
+			Product: createdProduct,
+			Err:     err,
+		})
+	}
+
+	return results, nil
+}
+
+func (r *PgProductsRepository) ListPage(ctx context.Context, filter domain.ExportProductsFilter) ([]domain.Product, error) {
+	products, err := models.Products(
+		models.ProductWhere.ID.GT(filter.AfterID),
+		models.ProductWhere.DeletedAt.IsNull(),
+		qm.OrderBy(models.ProductColumns.ID+" asc"),
+		qm.Limit(filter.Limit),
+	).All(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]domain.Product, 0, len(products))
+	for _, product := range products {
+		response = append(response, toDomainProduct(product))
+	}
+
+	return response, nil
+}
+
+// Search finds the Products matching the search using Postgres full-text search and trigrams
+func (r *PgProductsRepository) Search(ctx context.Context, search domain.ProductSearch) ([]domain.ProductSearchResult, error) {
+	terms := search.Terms()
+
+	prefixes := make([]string, 0, len(terms))
+	for _, term := range terms {
+		prefixes = append(prefixes, term+":*")
+	}
+
+	var rows []productSearchRow
+	err := queries.Raw(
+		searchQuery+"ORDER BY "+orderBy(search.Sort)+"\nLIMIT $3 OFFSET $4",
+		strings.Join(prefixes, " & "),
+		strings.Join(terms, " "),
+		search.Limit,
+		search.Offset,
+	).Bind(ctx, r.db, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]domain.ProductSearchResult, 0, len(rows))
+	for _, row := range rows {
+		response = append(response, domain.ProductSearchResult{
+			Product: toDomainProduct(&row.Product),
+			Score:   row.Score,
+		})
+	}
+
+	return response, nil
+}
+
+// Facets counts the Products matching the filter with one aggregation query per facet
+func (r *PgProductsRepository) Facets(ctx context.Context, filter domain.FacetsFilter) (domain.ProductFacets, error) {
+	queryMods := append(productFilterMods(filter.ProductFilter), models.ProductWhere.DeletedAt.IsNull())
+
+	boundaries := make([]string, 0, len(filter.PriceBoundaries))
+	for _, boundary := range filter.PriceBoundaries {
+		boundaries = append(boundaries, strconv.Itoa(boundary))
+	}
+
+	// width_bucket returns 0 for the prices below the first boundary and i for the ones from the i-th on,
+	// the boundaries are validated integers so they're safe to be inlined
+	var priceRanges []priceRangeRow
+	err := models.Products(append(queryMods,
+		qm.Select("width_bucket(price, ARRAY["+strings.Join(boundaries, ",")+"]::integer[]) AS bucket", "count(*) AS count"),
+		qm.GroupBy("bucket"),
+	)...).Bind(ctx, r.db, &priceRanges)
+	if err != nil {
+		return domain.ProductFacets{}, err
+	}
+
+	var categories []facetRow
+	err = models.Products(append(queryMods,
+		qm.Select("category AS value", "count(*) AS count"),
+		models.ProductWhere.Category.NEQ(""),
+		qm.GroupBy("category"),
+	)...).Bind(ctx, r.db, &categories)
+	if err != nil {
+		return domain.ProductFacets{}, err
+	}
+
+	var attributes []facetRow
+	err = models.Products(append(queryMods,
+		qm.Select("attribute.key AS name", "attribute.value AS value", "count(*) AS count"),
+		qm.From("jsonb_each_text(products.attributes) AS attribute"),
+		qm.GroupBy("attribute.key, attribute.value"),
+	)...).Bind(ctx, r.db, &attributes)
+	if err != nil {
+		return domain.ProductFacets{}, err
+	}
+
+	facets := domain.ProductFacets{
+		PriceRanges: domain.PriceRanges(filter.PriceBoundaries),
+		Categories:  make([]domain.FacetBucket, 0, len(categories)),
+		Attributes:  []domain.AttributeFacet{},
+	}
+
+	for _, row := range priceRanges {
+		facets.PriceRanges[row.Bucket].Count = row.Count
+		facets.Total += row.Count
+	}
+
+	for _, row := range categories {
+		facets.Categories = append(facets.Categories, domain.FacetBucket{Value: row.Value, Count: row.Count})
+	}
+
+	attributeIndex := map[string]int{}
+	for _, row := range attributes {
+		i, ok := attributeIndex[row.Name]
+		if !ok {
+			i = len(facets.Attributes)
+			attributeIndex[row.Name] = i
+			facets.Attributes = append(facets.Attributes, domain.AttributeFacet{Name: row.Name})
+		}
+
+		facets.Attributes[i].Values = append(facets.Attributes[i].Values, domain.FacetBucket{Value: row.Value, Count: row.Count})
+	}
+
+	domain.SortFacets(&facets)
+
+	return facets, nil
+}
+
+// productFilterMods converts a domain.ProductFilter into query mods
+func productFilterMods(filter domain.ProductFilter) []qm.QueryMod {
+	var queryMods []qm.QueryMod
+	if filter.Category != "" {
+		queryMods = append(queryMods, models.ProductWhere.Category.EQ(filter.Category))
+	}
+
+	if filter.PriceFrom > 0 {
+		queryMods = append(queryMods, models.ProductWhere.Price.GTE(filter.PriceFrom))
+	}
+
+	if filter.PriceTo > 0 {
+		queryMods = append(queryMods, models.ProductWhere.Price.LT(filter.PriceTo))
+	}
+
+	if len(filter.Attributes) > 0 {
+		queryMods = append(queryMods, qm.Where(models.ProductTableColumns.Attributes+" @> ?", toJSONAttributes(filter.Attributes)))
+	}
+
+	return queryMods
+}
+
+// orderBy builds the ORDER BY clause of the sort keys, breaking the ties by ID.
+// The keys must have been validated, so every field has a column.
+func orderBy(keys []domain.SortKey) string {
+	clauses := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		direction := " ASC"
+		if key.Descending {
+			direction = " DESC"
+		}
+
+		clauses = append(clauses, sortColumns[key.Field]+direction)
+	}
+
+	return strings.Join(append(clauses, models.ProductTableColumns.ID+" ASC"), ", ")
+}
+
+// toJSONAttributes encodes the Product attributes as a JSON object, an empty one when there are none
+func toJSONAttributes(attributes map[string]string) types.JSON {
+	if len(attributes) == 0 {
+		return types.JSON("{}")
+	}
+
+	// a map of strings always encodes
+	data, _ := json.Marshal(attributes)
+
+	return data
+}
+
+// toDomainAttributes decodes the Product attributes, leaving them nil when there are none
+func toDomainAttributes(data types.JSON) map[string]string {
+	var attributes map[string]string
+	if err := data.Unmarshal(&attributes); err != nil || len(attributes) == 0 {
+		return nil
+	}
+
+	return attributes
+}
+
+// toDomainProduct converts a database Product into a domain.Product
+func toDomainProduct(product *models.Product) domain.Product {
+	return domain.Product{
+		ID:          product.ID,
+		SKU:         product.Sku,
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       product.Price,
+		Category:    product.Category,
+		Attributes:  toDomainAttributes(product.Attributes),
+		TaxClass:    product.TaxClass,
+		Weight:      product.Weight,
+		Dimensions: domain.Dimensions{
+			Length: product.Length,
+			Width:  product.Width,
+			Height: product.Height,
+		},
+		Version:   product.Version,
+		DeletedAt: product.DeletedAt.Time,
+	}
+}
+
+// fromPgError translates the Postgres errors the domain knows about
+func fromPgError(err error) error {
+	if pg.IsUniqueViolation(err) {
+		return domain.ErrProductSKUAlreadyExists
+	}
+
+	return err
+}
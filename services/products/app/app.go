@@ -12,6 +12,18 @@ type application struct {
 	Tracer trace.Tracer
 
 	ProductsRepository domain.ProductsRepository
+	AuditRepository    domain.AuditRepository
+	ImagesRepository   domain.ImagesRepository
+	BlobStore          domain.BlobStore
+	ThumbnailSizes     []domain.ThumbnailSize
+}
+
+// MediaInput is the input (aka dependencies) needed to store the images of the Products
+type MediaInput struct {
+	ImagesRepository domain.ImagesRepository
+	BlobStore        domain.BlobStore
+	// ThumbnailSizes are the sizes the thumbnails are generated at, they default to domain.DefaultThumbnailSizes
+	ThumbnailSizes []domain.ThumbnailSize
 }
 
 // NewApplication creates a new Application instance
@@ -19,11 +31,22 @@ func NewApplication(
 	logger *zap.Logger,
 	tracer trace.Tracer,
 	productsRepository domain.ProductsRepository,
+	auditRepository domain.AuditRepository,
+	media MediaInput,
 ) application {
+	thumbnailSizes := media.ThumbnailSizes
+	if len(thumbnailSizes) == 0 {
+		thumbnailSizes = domain.DefaultThumbnailSizes
+	}
+
 	return application{
 		Logger:             logger,
 		Tracer:             tracer,
 		ProductsRepository: productsRepository,
+		AuditRepository:    auditRepository,
+		ImagesRepository:   media.ImagesRepository,
+		BlobStore:          media.BlobStore,
+		ThumbnailSizes:     thumbnailSizes,
 	}
 }
 
@@ -33,7 +56,9 @@ func MustNewApplication(
 	logger *zap.Logger,
 	tracer trace.Tracer,
 	productsRepository domain.ProductsRepository,
+	auditRepository domain.AuditRepository,
+	media MediaInput,
 ) application {
-	app := NewApplication(logger, tracer, productsRepository)
+	app := NewApplication(logger, tracer, productsRepository, auditRepository, media)
 	return app
 }
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/lucasmls/ecommerce/shared/actor"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// audit records a change made to a Product, before is nil when it was registered.
+// The change is already stored by then, so failing to record it is logged instead of failing the operation.
+func (a application) audit(ctx context.Context, action domain.AuditAction, before *domain.Product, after domain.Product) {
+	name, ok := actor.FromContext(ctx)
+	if !ok {
+		name = actor.Unknown
+	}
+
+	entry := domain.AuditEntry{
+		ProductID: after.ID,
+		Action:    action,
+		Actor:     name,
+		Before:    before,
+		After:     &after,
+		CreatedAt: time.Now(),
+	}
+
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		entry.TraceID = spanContext.TraceID().String()
+	}
+
+	if _, err := a.AuditRepository.Record(ctx, entry); err != nil {
+		a.Logger.Error(
+			"failed to record a product change",
+			zap.Error(err),
+			zap.Int("productId", entry.ProductID),
+			zap.String("action", string(action)),
+		)
+	}
+}
+
+// storedProduct fetches the stored Product with the given ID, it's nil if there isn't one
+func (a application) storedProduct(ctx context.Context, id int, includeDeleted bool) (*domain.Product, error) {
+	products, err := a.ProductsRepository.List(ctx, domain.ListProductsFilter{
+		IDs:            []int{id},
+		IncludeDeleted: includeDeleted,
+	})
+	if err != nil || len(products) == 0 {
+		return nil, err
+	}
+
+	return &products[0], nil
+}
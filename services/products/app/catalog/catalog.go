@@ -0,0 +1,82 @@
+// Package catalog reads and writes Products as CSV or NDJSON catalog files.
+//
+// Both formats carry the same fields: id, sku, name, description, price, in minor
+// currency units (cents), category, attributes, tax class, weight, in grams, and dimensions, in
+// millimeters. On CSV files the attributes are written as a JSON object, as in {"color":"red"},
+// and the dimensions as the length, width and height columns, left empty when unknown.
+package catalog
+
+import (
+	"io"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+)
+
+const (
+	columnID          = "id"
+	columnSKU         = "sku"
+	columnName        = "name"
+	columnDescription = "description"
+	columnPrice       = "price"
+	columnCategory    = "category"
+	columnAttributes  = "attributes"
+	columnTaxClass    = "tax_class"
+	columnWeight      = "weight"
+	columnLength      = "length"
+	columnWidth       = "width"
+	columnHeight      = "height"
+)
+
+// columns are the catalog columns, in the order they're written
+var columns = []string{
+	columnID, columnSKU, columnName, columnDescription, columnPrice, columnCategory, columnAttributes, columnTaxClass,
+	columnWeight, columnLength, columnWidth, columnHeight,
+}
+
+// Reader reads the rows of a catalog file
+type Reader interface {
+	// Read returns the next row, or io.EOF once every row was read.
+	// Rows that can't be parsed are returned with their Err set, any other error
+	// means the file can't be read any further.
+	Read() (domain.ImportRow, error)
+}
+
+// Writer writes Products into a catalog file
+type Writer interface {
+	// Write writes a single Product
+	Write(domain.Product) error
+
+	// Flush writes any buffered data into the underlying io.Writer
+	Flush() error
+}
+
+// NewReader creates a Reader for the given format
+func NewReader(format domain.CatalogFormat, r io.Reader) (Reader, error) {
+	switch format {
+	case domain.CatalogFormatCSV:
+		return newCSVReader(r)
+	case domain.CatalogFormatNDJSON:
+		return newNDJSONReader(r), nil
+	default:
+		return nil, domain.ErrInvalidCatalogFormat
+	}
+}
+
+// NewWriter creates a Writer for the given format
+func NewWriter(format domain.CatalogFormat, w io.Writer) (Writer, error) {
+	switch format {
+	case domain.CatalogFormatCSV:
+		return newCSVWriter(w), nil
+	case domain.CatalogFormatNDJSON:
+		return newNDJSONWriter(w), nil
+	default:
+		return nil, domain.ErrInvalidCatalogFormat
+	}
+}
+
+// fieldError builds the error of a single field that couldn't be parsed
+func fieldError(field string, description string) error {
+	return &domain.ValidationError{
+		Violations: []domain.FieldViolation{{Field: field, Description: description}},
+	}
+}
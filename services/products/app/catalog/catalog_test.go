@@ -0,0 +1,235 @@
+package catalog
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+)
+
+type CatalogSuite struct {
+	suite.Suite
+}
+
+func (s *CatalogSuite) readAll(reader Reader) []domain.ImportRow {
+	var rows []domain.ImportRow
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows
+		}
+
+		s.Require().NoError(err)
+		rows = append(rows, row)
+	}
+}
+
+func (s *CatalogSuite) Test_NewReader() {
+	s.Run("Should reject unknown formats", func() {
+		_, err := NewReader("xml", strings.NewReader(""))
+
+		s.Equal(domain.ErrInvalidCatalogFormat, err)
+	})
+
+	s.Run("Should reject CSV files without the required columns", func() {
+		_, err := NewReader(domain.CatalogFormatCSV, strings.NewReader("sku,name\nIPH-13,Iphone 13\n"))
+
+		s.ErrorIs(err, domain.ErrMissingCatalogColumn)
+	})
+
+	s.Run("Should reject CSV files with unknown columns", func() {
+		_, err := NewReader(domain.CatalogFormatCSV, strings.NewReader("name,price,color\n"))
+
+		s.ErrorIs(err, domain.ErrUnknownCatalogColumn)
+	})
+
+	s.Run("Should reject CSV files with duplicated columns", func() {
+		_, err := NewReader(domain.CatalogFormatCSV, strings.NewReader("name,price,Name\n"))
+
+		s.ErrorIs(err, domain.ErrDuplicatedCatalogColumn)
+	})
+}
+
+func (s *CatalogSuite) Test_CSVReader() {
+	s.Run("Should read every row, reporting the ones that can't be parsed", func() {
+		content := "\ufeffSKU, Name ,description,price\n" +
+			"IPH-13,Iphone 13,\"Cool, really\",4500\n" +
+			"MBA-M1,Macbook Air M1,Fast!,68.00\n" +
+			"MBP-M1,Macbook Pro M1\n" +
+			"MBP-M1-MAX,Macbook Pro M1 Max,\"Multi\nline\",16500\n"
+
+		reader, err := NewReader(domain.CatalogFormatCSV, strings.NewReader(content))
+		s.Require().NoError(err)
+
+		rows := s.readAll(reader)
+
+		s.Require().Len(rows, 4)
+		s.Equal(domain.ImportRow{
+			Line:    2,
+			Product: domain.Product{SKU: "IPH-13", Name: "Iphone 13", Description: "Cool, really", Price: 4500},
+		}, rows[0])
+
+		s.Equal(3, rows[1].Line)
+		s.Equal(&domain.ValidationError{Violations: []domain.FieldViolation{
+			{Field: "price", Description: "must be an integer amount of cents"},
+		}}, rows[1].Err)
+
+		s.Equal(4, rows[2].Line)
+		s.Error(rows[2].Err)
+
+		s.Equal(domain.ImportRow{
+			Line:    5,
+			Product: domain.Product{SKU: "MBP-M1-MAX", Name: "Macbook Pro M1 Max", Description: "Multi\nline", Price: 16500},
+		}, rows[3])
+	})
+
+	s.Run("Should read the category and the attributes", func() {
+		content := "name,price,category,attributes\n" +
+			"Iphone 13,4500,phones,\"{\"\"color\"\":\"\"blue\"\"}\"\n" +
+			"Iphone 12,3500,phones,blue\n" +
+			"Ipad,3000,,\n"
+
+		reader, err := NewReader(domain.CatalogFormatCSV, strings.NewReader(content))
+		s.Require().NoError(err)
+
+		rows := s.readAll(reader)
+
+		s.Require().Len(rows, 3)
+		s.Equal(domain.ImportRow{
+			Line:    2,
+			Product: domain.Product{Name: "Iphone 13", Price: 4500, Category: "phones", Attributes: map[string]string{"color": "blue"}},
+		}, rows[0])
+
+		s.Equal(&domain.ValidationError{Violations: []domain.FieldViolation{
+			{Field: "attributes", Description: "must be a JSON object of strings"},
+		}}, rows[1].Err)
+
+		s.Equal(domain.ImportRow{Line: 4, Product: domain.Product{Name: "Ipad", Price: 3000}}, rows[2])
+	})
+
+	s.Run("Should read the weight and the dimensions, leaving the empty ones unknown", func() {
+		content := "name,price,weight,length,width,height\n" +
+			"Iphone 13,4500,174,147,72,8\n" +
+			"Iphone 12,3500,0.2,,,\n" +
+			"Ipad,3000,,,,\n"
+
+		reader, err := NewReader(domain.CatalogFormatCSV, strings.NewReader(content))
+		s.Require().NoError(err)
+
+		rows := s.readAll(reader)
+
+		s.Require().Len(rows, 3)
+		s.Equal(domain.ImportRow{
+			Line: 2,
+			Product: domain.Product{
+				Name:       "Iphone 13",
+				Price:      4500,
+				Weight:     174,
+				Dimensions: domain.Dimensions{Length: 147, Width: 72, Height: 8},
+			},
+		}, rows[0])
+
+		s.Equal(&domain.ValidationError{Violations: []domain.FieldViolation{
+			{Field: "weight", Description: "must be an integer amount of grams"},
+		}}, rows[1].Err)
+
+		s.Equal(domain.ImportRow{Line: 4, Product: domain.Product{Name: "Ipad", Price: 3000}}, rows[2])
+	})
+}
+
+func (s *CatalogSuite) Test_NDJSONReader() {
+	s.Run("Should read every line, skipping blank ones and reporting the ones that can't be parsed", func() {
+		content := `{"id":1,"sku":"IPH-13","name":"Iphone 13","description":"Cool","price":4500}
+
+{"name":"Macbook Air M1","price":"68.00"}
+{"name":"Macbook Pro M1","color":"gray","price":16500}
+{"name":"Macbook Pro M1 Max",
+{"name":"Ipad","price":3000}`
+
+		reader, err := NewReader(domain.CatalogFormatNDJSON, strings.NewReader(content))
+		s.Require().NoError(err)
+
+		rows := s.readAll(reader)
+
+		s.Require().Len(rows, 5)
+		s.Equal(domain.ImportRow{
+			Line:    1,
+			Product: domain.Product{ID: 1, SKU: "IPH-13", Name: "Iphone 13", Description: "Cool", Price: 4500},
+		}, rows[0])
+
+		s.Equal(3, rows[1].Line)
+		s.Equal(&domain.ValidationError{Violations: []domain.FieldViolation{
+			{Field: "price", Description: "must be of type int"},
+		}}, rows[1].Err)
+
+		s.Equal(4, rows[2].Line)
+		s.ErrorContains(rows[2].Err, "unknown field")
+
+		s.Equal(5, rows[3].Line)
+		s.Error(rows[3].Err)
+
+		s.Equal(domain.ImportRow{Line: 6, Product: domain.Product{Name: "Ipad", Price: 3000}}, rows[4])
+	})
+}
+
+func (s *CatalogSuite) Test_Writer() {
+	products := []domain.Product{
+		{
+			ID:          1,
+			SKU:         "IPH-13",
+			Name:        "Iphone 13",
+			Description: "Cool, really",
+			Price:       4500,
+			Category:    "phones",
+			Attributes:  map[string]string{"color": "blue", "storage": "128GB"},
+			TaxClass:    "electronics",
+			Weight:      174,
+			Dimensions:  domain.Dimensions{Length: 147, Width: 72, Height: 8},
+		},
+		{ID: 2, Name: "Macbook Air M1", Description: "Fast!", Price: 6800},
+	}
+
+	s.Run("Should write a header even when there are no products", func() {
+		var buffer bytes.Buffer
+
+		writer, err := NewWriter(domain.CatalogFormatCSV, &buffer)
+		s.Require().NoError(err)
+		s.Require().NoError(writer.Flush())
+
+		s.Equal("id,sku,name,description,price,category,attributes,tax_class,weight,length,width,height\n", buffer.String())
+	})
+
+	for _, format := range []domain.CatalogFormat{domain.CatalogFormatCSV, domain.CatalogFormatNDJSON} {
+		s.Run("Should write products that can be read back as "+string(format), func() {
+			var buffer bytes.Buffer
+
+			writer, err := NewWriter(format, &buffer)
+			s.Require().NoError(err)
+
+			for _, product := range products {
+				s.Require().NoError(writer.Write(product))
+			}
+			s.Require().NoError(writer.Flush())
+
+			reader, err := NewReader(format, &buffer)
+			s.Require().NoError(err)
+
+			rows := s.readAll(reader)
+
+			s.Require().Len(rows, len(products))
+			for i, product := range products {
+				s.NoError(rows[i].Err)
+				s.Equal(product, rows[i].Product)
+			}
+		})
+	}
+}
+
+func TestCatalogSuite(t *testing.T) {
+	suite.Run(t, new(CatalogSuite))
+}
@@ -0,0 +1,246 @@
+package catalog
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+)
+
+type csvReader struct {
+	reader *csv.Reader
+	// index maps each column to its position on the records
+	index map[string]int
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: %s", domain.ErrMissingCatalogColumn, columnName)
+		}
+
+		return nil, err
+	}
+
+	index := make(map[string]int, len(header))
+	for position, column := range header {
+		column = strings.ToLower(strings.TrimSpace(column))
+		if position == 0 {
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+
+		if !isColumn(column) {
+			return nil, fmt.Errorf("%w: %s", domain.ErrUnknownCatalogColumn, column)
+		}
+
+		if _, ok := index[column]; ok {
+			return nil, fmt.Errorf("%w: %s", domain.ErrDuplicatedCatalogColumn, column)
+		}
+
+		index[column] = position
+	}
+
+	for _, required := range []string{columnName, columnPrice} {
+		if _, ok := index[required]; !ok {
+			return nil, fmt.Errorf("%w: %s", domain.ErrMissingCatalogColumn, required)
+		}
+	}
+
+	return &csvReader{
+		reader: reader,
+		index:  index,
+	}, nil
+}
+
+func (r *csvReader) Read() (domain.ImportRow, error) {
+	record, err := r.reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return domain.ImportRow{}, io.EOF
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return domain.ImportRow{Line: parseErr.StartLine, Err: parseErr.Err}, nil
+		}
+
+		return domain.ImportRow{}, err
+	}
+
+	line, _ := r.reader.FieldPos(0)
+	row := domain.ImportRow{Line: line}
+
+	var violations []domain.FieldViolation
+	for _, column := range columns {
+		position, ok := r.index[column]
+		if !ok {
+			continue
+		}
+
+		value := strings.TrimSpace(record[position])
+
+		switch column {
+		case columnID:
+			if value == "" {
+				continue
+			}
+
+			id, err := strconv.Atoi(value)
+			if err != nil {
+				violations = append(violations, domain.FieldViolation{Field: columnID, Description: "must be an integer"})
+				continue
+			}
+
+			row.Product.ID = id
+		case columnSKU:
+			row.Product.SKU = value
+		case columnName:
+			row.Product.Name = value
+		case columnDescription:
+			row.Product.Description = value
+		case columnPrice:
+			price, err := strconv.Atoi(value)
+			if err != nil {
+				violations = append(violations, domain.FieldViolation{Field: columnPrice, Description: "must be an integer amount of cents"})
+				continue
+			}
+
+			row.Product.Price = price
+		case columnCategory:
+			row.Product.Category = value
+		case columnAttributes:
+			if value == "" {
+				continue
+			}
+
+			if err := json.Unmarshal([]byte(value), &row.Product.Attributes); err != nil {
+				violations = append(violations, domain.FieldViolation{Field: columnAttributes, Description: "must be a JSON object of strings"})
+			}
+		case columnTaxClass:
+			row.Product.TaxClass = value
+		case columnWeight, columnLength, columnWidth, columnHeight:
+			if value == "" {
+				continue
+			}
+
+			measure, err := strconv.Atoi(value)
+			if err != nil {
+				unit := "millimeters"
+				if column == columnWeight {
+					unit = "grams"
+				}
+
+				violations = append(violations, domain.FieldViolation{Field: column, Description: "must be an integer amount of " + unit})
+				continue
+			}
+
+			*measureOf(&row.Product, column) = measure
+		}
+	}
+
+	if len(violations) > 0 {
+		row.Err = &domain.ValidationError{Violations: violations}
+	}
+
+	return row, nil
+}
+
+type csvWriter struct {
+	writer        *csv.Writer
+	headerWritten bool
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{
+		writer: csv.NewWriter(w),
+	}
+}
+
+func (w *csvWriter) Write(product domain.Product) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	var attributes string
+	if len(product.Attributes) > 0 {
+		// a map of strings always encodes
+		data, _ := json.Marshal(product.Attributes)
+		attributes = string(data)
+	}
+
+	return w.writer.Write([]string{
+		strconv.Itoa(product.ID),
+		product.SKU,
+		product.Name,
+		product.Description,
+		strconv.Itoa(product.Price),
+		product.Category,
+		attributes,
+		product.TaxClass,
+		formatMeasure(product.Weight),
+		formatMeasure(product.Dimensions.Length),
+		formatMeasure(product.Dimensions.Width),
+		formatMeasure(product.Dimensions.Height),
+	})
+}
+
+// measureOf points to the weight or to one of the dimensions of a Product, by column
+func measureOf(product *domain.Product, column string) *int {
+	switch column {
+	case columnLength:
+		return &product.Dimensions.Length
+	case columnWidth:
+		return &product.Dimensions.Width
+	case columnHeight:
+		return &product.Dimensions.Height
+	default:
+		return &product.Weight
+	}
+}
+
+// formatMeasure leaves the unknown, zero, measures empty
+func formatMeasure(measure int) string {
+	if measure == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(measure)
+}
+
+func (w *csvWriter) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// writeHeader writes the header once, so even empty catalogs can be imported back
+func (w *csvWriter) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+
+	w.headerWritten = true
+	return w.writer.Write(columns)
+}
+
+func isColumn(column string) bool {
+	for _, known := range columns {
+		if known == column {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,149 @@
+package catalog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+)
+
+// ndjsonRecord is a single NDJSON line
+type ndjsonRecord struct {
+	ID          int               `json:"id,omitempty"`
+	SKU         string            `json:"sku,omitempty"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Price       int               `json:"price"`
+	Category    string            `json:"category,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	TaxClass    string            `json:"tax_class,omitempty"`
+	Weight      int               `json:"weight,omitempty"`
+	Dimensions  *ndjsonDimensions `json:"dimensions,omitempty"`
+}
+
+// ndjsonDimensions are the dimensions of a Product, in millimeters
+type ndjsonDimensions struct {
+	Length int `json:"length"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+type ndjsonReader struct {
+	reader *bufio.Reader
+	line   int
+}
+
+func newNDJSONReader(r io.Reader) *ndjsonReader {
+	return &ndjsonReader{
+		reader: bufio.NewReader(r),
+	}
+}
+
+func (r *ndjsonReader) Read() (domain.ImportRow, error) {
+	for {
+		data, err := r.reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return domain.ImportRow{}, err
+		}
+
+		if len(data) == 0 && errors.Is(err, io.EOF) {
+			return domain.ImportRow{}, io.EOF
+		}
+
+		r.line++
+
+		data = bytes.TrimSpace(data)
+		if len(data) == 0 {
+			continue
+		}
+
+		return r.parse(data), nil
+	}
+}
+
+func (r *ndjsonReader) parse(data []byte) domain.ImportRow {
+	row := domain.ImportRow{Line: r.line}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var record ndjsonRecord
+	if err := decoder.Decode(&record); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			row.Err = fieldError(typeErr.Field, fmt.Sprintf("must be of type %s", typeErr.Type))
+			return row
+		}
+
+		row.Err = err
+		return row
+	}
+
+	row.Product = domain.Product{
+		ID:          record.ID,
+		SKU:         record.SKU,
+		Name:        record.Name,
+		Description: record.Description,
+		Price:       record.Price,
+		Category:    record.Category,
+		Attributes:  record.Attributes,
+		TaxClass:    record.TaxClass,
+		Weight:      record.Weight,
+	}
+
+	if record.Dimensions != nil {
+		row.Product.Dimensions = domain.Dimensions{
+			Length: record.Dimensions.Length,
+			Width:  record.Dimensions.Width,
+			Height: record.Dimensions.Height,
+		}
+	}
+
+	return row
+}
+
+type ndjsonWriter struct {
+	writer  *bufio.Writer
+	encoder *json.Encoder
+}
+
+func newNDJSONWriter(w io.Writer) *ndjsonWriter {
+	writer := bufio.NewWriter(w)
+
+	return &ndjsonWriter{
+		writer:  writer,
+		encoder: json.NewEncoder(writer),
+	}
+}
+
+func (w *ndjsonWriter) Write(product domain.Product) error {
+	record := ndjsonRecord{
+		ID:          product.ID,
+		SKU:         product.SKU,
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       product.Price,
+		Category:    product.Category,
+		Attributes:  product.Attributes,
+		TaxClass:    product.TaxClass,
+		Weight:      product.Weight,
+	}
+
+	if !product.Dimensions.Zero() {
+		record.Dimensions = &ndjsonDimensions{
+			Length: product.Dimensions.Length,
+			Width:  product.Dimensions.Width,
+			Height: product.Dimensions.Height,
+		}
+	}
+
+	return w.encoder.Encode(record)
+}
+
+func (w *ndjsonWriter) Flush() error {
+	return w.writer.Flush()
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 
+	"github.com/lucasmls/ecommerce/services/products/domain"
 	"go.uber.org/zap"
 )
 
@@ -12,10 +13,23 @@ func (a application) DeleteProduct(ctx context.Context, id int) error {
 
 	a.Logger.Info("deleting a product", zap.Any("id", id))
 
-	err := a.ProductsRepository.Delete(ctx, id)
+	before, err := a.storedProduct(ctx, id, false)
 	if err != nil {
 		return err
 	}
 
+	err = a.ProductsRepository.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	after, err := a.storedProduct(ctx, id, true)
+	if err != nil || after == nil {
+		a.Logger.Error("failed to fetch the deleted product to record its deletion", zap.Error(err), zap.Int("id", id))
+		return nil
+	}
+
+	a.audit(ctx, domain.AuditActionDelete, before, *after)
+
 	return nil
 }
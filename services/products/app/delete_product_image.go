@@ -0,0 +1,28 @@
+package app
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+func (a application) DeleteProductImage(ctx context.Context, id int) error {
+	ctx, span := a.Tracer.Start(ctx, "app.DeleteProductImage")
+	defer span.End()
+
+	a.Logger.Info("deleting a product image", zap.Int("id", id))
+
+	image, err := a.ImagesRepository.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := a.ImagesRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	// the blobs are deleted last, so a failure never leaves an image without its files
+	a.deleteBlobs(ctx, imageKeys(image))
+
+	return nil
+}
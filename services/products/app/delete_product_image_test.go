@@ -0,0 +1,72 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/lucasmls/ecommerce/services/products/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type DeleteProductImageSuite struct {
+	suite.Suite
+
+	imagesRepo *mocks.ImagesRepository
+	blobStore  *mocks.BlobStore
+	app        domain.Application
+}
+
+func (s *DeleteProductImageSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.imagesRepo = &mocks.ImagesRepository{}
+	s.blobStore = &mocks.BlobStore{}
+
+	s.app = MustNewApplication(loggerM, tracerM, &mocks.ProductsRepository{}, &mocks.AuditRepository{}, MediaInput{
+		ImagesRepository: s.imagesRepo,
+		BlobStore:        s.blobStore,
+	})
+}
+
+func (s *DeleteProductImageSuite) Test_DeleteProductImage() {
+	s.Run("Should return not found error in case the image isn't stored", func() {
+		s.SetupTest()
+		s.imagesRepo.
+			On("Get", mock.AnythingOfType("*context.valueCtx"), 1).
+			Return(domain.ProductImage{}, domain.ErrImageNotFound)
+
+		err := s.app.DeleteProductImage(context.Background(), 1)
+
+		s.Equal(domain.ErrImageNotFound, err)
+		s.imagesRepo.AssertNotCalled(s.T(), "Delete", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should delete the image along with its files", func() {
+		s.SetupTest()
+		image := domain.ProductImage{
+			ID:         2,
+			Key:        "products/1/abc.jpg",
+			Thumbnails: []domain.Thumbnail{{Name: "small", Key: "products/1/abc_small.jpg"}},
+		}
+
+		s.imagesRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), 2).Return(image, nil)
+		s.imagesRepo.On("Delete", mock.AnythingOfType("*context.valueCtx"), 2).Return(nil)
+		s.blobStore.On("Delete", mock.AnythingOfType("*context.valueCtx"), "products/1/abc.jpg").Return(nil)
+		s.blobStore.On("Delete", mock.AnythingOfType("*context.valueCtx"), "products/1/abc_small.jpg").Return(nil)
+
+		err := s.app.DeleteProductImage(context.Background(), 2)
+
+		s.NoError(err)
+		s.imagesRepo.AssertExpectations(s.T())
+		s.blobStore.AssertExpectations(s.T())
+	})
+}
+
+func TestDeleteProductImageSuite(t *testing.T) {
+	suite.Run(t, new(DeleteProductImageSuite))
+}
@@ -2,7 +2,9 @@ package app
 
 import (
 	"context"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 
@@ -17,6 +19,7 @@ type DeleteProductSuite struct {
 	suite.Suite
 
 	productsRepo *mocks.ProductsRepository
+	auditRepo    *mocks.AuditRepository
 	app          domain.Application
 }
 
@@ -24,8 +27,9 @@ func (s *DeleteProductSuite) SetupSuite() {
 	loggerM := zap.NewNop()
 	tracerM := trace.NewNoopTracerProvider().Tracer("")
 	s.productsRepo = &mocks.ProductsRepository{}
+	s.auditRepo = &mocks.AuditRepository{}
 
-	s.app = NewApplication(loggerM, tracerM, s.productsRepo)
+	s.app = NewApplication(loggerM, tracerM, s.productsRepo, s.auditRepo, MediaInput{})
 }
 
 func (s *DeleteProductSuite) Test_DeleteProduct() {
@@ -33,6 +37,13 @@ func (s *DeleteProductSuite) Test_DeleteProduct() {
 		ctx := context.Background()
 		productId := 1
 
+		s.productsRepo.
+			On("List",
+				mock.AnythingOfType("*context.valueCtx"),
+				domain.ListProductsFilter{IDs: []int{productId}},
+			).
+			Return([]domain.Product{}, nil)
+
 		s.productsRepo.
 			On("Delete",
 				mock.AnythingOfType("*context.valueCtx"),
@@ -48,6 +59,15 @@ func (s *DeleteProductSuite) Test_DeleteProduct() {
 	s.Run("Should delete the specified product", func() {
 		ctx := context.Background()
 		productId := 2
+		stored := domain.Product{ID: productId, Name: "Macbook Air M1", Price: 6800, Version: 1}
+		deleted := domain.Product{ID: productId, Name: "Macbook Air M1", Price: 6800, Version: 2, DeletedAt: time.Now()}
+
+		s.productsRepo.
+			On("List",
+				mock.AnythingOfType("*context.valueCtx"),
+				domain.ListProductsFilter{IDs: []int{productId}},
+			).
+			Return([]domain.Product{stored}, nil)
 
 		s.productsRepo.
 			On("Delete",
@@ -56,9 +76,30 @@ func (s *DeleteProductSuite) Test_DeleteProduct() {
 			).
 			Return(nil)
 
+		s.productsRepo.
+			On("List",
+				mock.AnythingOfType("*context.valueCtx"),
+				domain.ListProductsFilter{IDs: []int{productId}, IncludeDeleted: true},
+			).
+			Return([]domain.Product{deleted}, nil)
+
+		s.auditRepo.
+			On("Record",
+				mock.AnythingOfType("*context.valueCtx"),
+				mock.MatchedBy(func(entry domain.AuditEntry) bool {
+					return entry.Action == domain.AuditActionDelete &&
+						entry.ProductID == productId &&
+						entry.Actor == "unknown" &&
+						reflect.DeepEqual(*entry.Before, stored) &&
+						reflect.DeepEqual(*entry.After, deleted)
+				}),
+			).
+			Return(domain.AuditEntry{}, nil)
+
 		err := s.app.DeleteProduct(ctx, productId)
 
 		s.NoError(err)
+		s.auditRepo.AssertExpectations(s.T())
 	})
 }
 
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"io"
+
+	"github.com/lucasmls/ecommerce/services/products/app/catalog"
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"go.uber.org/zap"
+)
+
+// exportCatalogPageSize is how many Products are fetched at once while exporting a catalog
+const exportCatalogPageSize = 500
+
+func (a application) ExportCatalog(ctx context.Context, w io.Writer, format domain.CatalogFormat) error {
+	ctx, span := a.Tracer.Start(ctx, "app.ExportCatalog")
+	defer span.End()
+
+	a.Logger.Info("exporting the catalog", zap.String("format", string(format)))
+
+	writer, err := catalog.NewWriter(format, w)
+	if err != nil {
+		return err
+	}
+
+	filter := domain.ExportProductsFilter{Limit: exportCatalogPageSize}
+	for {
+		products, err := a.ProductsRepository.ListPage(ctx, filter)
+		if err != nil {
+			return err
+		}
+
+		for _, product := range products {
+			if err := writer.Write(product); err != nil {
+				return err
+			}
+		}
+
+		if len(products) < filter.Limit {
+			break
+		}
+
+		filter.AfterID = products[len(products)-1].ID
+	}
+
+	return writer.Flush()
+}
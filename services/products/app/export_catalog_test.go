@@ -0,0 +1,79 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/lucasmls/ecommerce/services/products/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ExportCatalogSuite struct {
+	suite.Suite
+
+	productsRepo *mocks.ProductsRepository
+	auditRepo    *mocks.AuditRepository
+	app          domain.Application
+}
+
+func (s *ExportCatalogSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.productsRepo = &mocks.ProductsRepository{}
+	s.auditRepo = &mocks.AuditRepository{}
+
+	s.app = NewApplication(loggerM, tracerM, s.productsRepo, s.auditRepo, MediaInput{})
+}
+
+func (s *ExportCatalogSuite) Test_ExportCatalog() {
+	s.Run("Should fail when the format is unknown", func() {
+		s.SetupTest()
+
+		err := s.app.ExportCatalog(context.Background(), &bytes.Buffer{}, "xml")
+
+		s.Equal(domain.ErrInvalidCatalogFormat, err)
+	})
+
+	s.Run("Should fail when repository.ListPage returns any error", func() {
+		s.SetupTest()
+		s.productsRepo.
+			On("ListPage", mock.AnythingOfType("*context.valueCtx"), domain.ExportProductsFilter{Limit: exportCatalogPageSize}).
+			Return(nil, errors.New("failed to list Products"))
+
+		err := s.app.ExportCatalog(context.Background(), &bytes.Buffer{}, domain.CatalogFormatCSV)
+
+		s.Equal(errors.New("failed to list Products"), err)
+	})
+
+	s.Run("Should write every stored Product as CSV", func() {
+		s.SetupTest()
+		s.productsRepo.
+			On("ListPage", mock.AnythingOfType("*context.valueCtx"), domain.ExportProductsFilter{Limit: exportCatalogPageSize}).
+			Return([]domain.Product{
+				{ID: 1, SKU: "IPH-13", Name: "Iphone 13", Description: "Cool, really", Price: 4500},
+				{ID: 2, Name: "Macbook Air M1", Description: "Fast!", Price: 6800},
+			}, nil)
+
+		var buffer bytes.Buffer
+		err := s.app.ExportCatalog(context.Background(), &buffer, domain.CatalogFormatCSV)
+
+		s.NoError(err)
+		s.Equal(
+			"id,sku,name,description,price,category,attributes,tax_class,weight,length,width,height\n"+
+				"1,IPH-13,Iphone 13,\"Cool, really\",4500,,,,,,,\n"+
+				"2,,Macbook Air M1,Fast!,6800,,,,,,,\n",
+			buffer.String(),
+		)
+	})
+}
+
+func TestExportCatalogSuite(t *testing.T) {
+	suite.Run(t, new(ExportCatalogSuite))
+}
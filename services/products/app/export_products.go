@@ -0,0 +1,22 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) ExportProducts(ctx context.Context, filter domain.ExportProductsFilter) ([]domain.Product, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.ExportProducts")
+	defer span.End()
+
+	a.Logger.Info("exporting a page of products", zap.Any("filter", filter))
+
+	products, err := a.ProductsRepository.ListPage(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
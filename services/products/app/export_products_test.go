@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/lucasmls/ecommerce/services/products/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ExportProductsSuite struct {
+	suite.Suite
+
+	productsRepo *mocks.ProductsRepository
+	auditRepo    *mocks.AuditRepository
+	app          domain.Application
+}
+
+func (s *ExportProductsSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.productsRepo = &mocks.ProductsRepository{}
+	s.auditRepo = &mocks.AuditRepository{}
+
+	s.app = NewApplication(loggerM, tracerM, s.productsRepo, s.auditRepo, MediaInput{})
+}
+
+func (s *ExportProductsSuite) Test_ExportProducts() {
+	s.Run("Should fail when repository.ListPage returns any error", func() {
+		ctx := context.Background()
+		filter := domain.ExportProductsFilter{AfterID: 0, Limit: 10}
+
+		s.productsRepo.On("ListPage",
+			mock.AnythingOfType("*context.valueCtx"),
+			filter,
+		).Return(nil, errors.New("failed to list products from the datastore"))
+
+		_, err := s.app.ExportProducts(ctx, filter)
+
+		s.Equal(errors.New("failed to list products from the datastore"), err)
+	})
+
+	s.Run("Should return the page of Products returned by the repository layer", func() {
+		ctx := context.Background()
+		filter := domain.ExportProductsFilter{AfterID: 1, Limit: 2}
+		products := []domain.Product{
+			{ID: 2, Name: "Iphone 13", Description: "Cool", Price: 4500},
+			{ID: 3, Name: "Macbook Pro M1 Max", Description: "Fast!", Price: 16500},
+		}
+
+		s.productsRepo.On("ListPage",
+			mock.AnythingOfType("*context.valueCtx"),
+			filter,
+		).Return(products, nil)
+
+		got, err := s.app.ExportProducts(ctx, filter)
+
+		s.NoError(err)
+		s.Equal(products, got)
+	})
+}
+
+func TestExportProductsSuite(t *testing.T) {
+	suite.Run(t, new(ExportProductsSuite))
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) GetProductFacets(ctx context.Context, filter domain.FacetsFilter) (domain.ProductFacets, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.GetProductFacets")
+	defer span.End()
+
+	a.Logger.Info("counting the product facets", zap.Any("filter", filter))
+
+	if err := filter.Validate(); err != nil {
+		return domain.ProductFacets{}, err
+	}
+
+	if len(filter.PriceBoundaries) == 0 {
+		filter.PriceBoundaries = domain.DefaultPriceBoundaries
+	}
+
+	facets, err := a.ProductsRepository.Facets(ctx, filter)
+	if err != nil {
+		return domain.ProductFacets{}, err
+	}
+
+	return facets, nil
+}
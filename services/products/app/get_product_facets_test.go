@@ -0,0 +1,89 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/lucasmls/ecommerce/services/products/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type GetProductFacetsSuite struct {
+	suite.Suite
+
+	productsRepo *mocks.ProductsRepository
+	app          domain.Application
+}
+
+func (s *GetProductFacetsSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.productsRepo = &mocks.ProductsRepository{}
+
+	s.app = NewApplication(loggerM, tracerM, s.productsRepo, &mocks.AuditRepository{}, MediaInput{})
+}
+
+func (s *GetProductFacetsSuite) Test_GetProductFacets() {
+	s.Run("Should reject invalid price ranges", func() {
+		s.SetupTest()
+
+		_, err := s.app.GetProductFacets(context.Background(), domain.FacetsFilter{
+			ProductFilter: domain.ProductFilter{PriceFrom: -1},
+		})
+
+		s.Equal(domain.ErrInvalidPriceRange, err)
+		s.productsRepo.AssertNotCalled(s.T(), "Facets", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should reject price boundaries that aren't increasing", func() {
+		s.SetupTest()
+
+		_, err := s.app.GetProductFacets(context.Background(), domain.FacetsFilter{PriceBoundaries: []int{5000, 1000}})
+
+		s.Equal(domain.ErrInvalidPriceBoundaries, err)
+	})
+
+	s.Run("Should count the facets using the default price boundaries", func() {
+		s.SetupTest()
+		filter := domain.FacetsFilter{ProductFilter: domain.ProductFilter{Category: "phones"}}
+		facets := domain.ProductFacets{
+			Total:       1,
+			PriceRanges: []domain.PriceRangeBucket{{From: 0, To: 5000, Count: 1}},
+			Categories:  []domain.FacetBucket{{Value: "phones", Count: 1}},
+		}
+
+		s.productsRepo.
+			On("Facets", mock.AnythingOfType("*context.valueCtx"), domain.FacetsFilter{
+				ProductFilter:   filter.ProductFilter,
+				PriceBoundaries: domain.DefaultPriceBoundaries,
+			}).
+			Return(facets, nil)
+
+		got, err := s.app.GetProductFacets(context.Background(), filter)
+
+		s.NoError(err)
+		s.Equal(facets, got)
+	})
+
+	s.Run("Should fail when repository.Facets returns any error", func() {
+		s.SetupTest()
+
+		s.productsRepo.
+			On("Facets", mock.AnythingOfType("*context.valueCtx"), mock.Anything).
+			Return(domain.ProductFacets{}, errors.New("failed to count the facets"))
+
+		_, err := s.app.GetProductFacets(context.Background(), domain.FacetsFilter{})
+
+		s.Equal(errors.New("failed to count the facets"), err)
+	})
+}
+
+func TestGetProductFacetsSuite(t *testing.T) {
+	suite.Run(t, new(GetProductFacetsSuite))
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) GetProductHistory(ctx context.Context, filter domain.ProductHistoryFilter) ([]domain.AuditEntry, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.GetProductHistory")
+	defer span.End()
+
+	a.Logger.Info("fetching the history of a product", zap.Any("filter", filter))
+
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = domain.DefaultProductHistoryLimit
+	}
+
+	entries, err := a.AuditRepository.ListByProduct(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/lucasmls/ecommerce/services/products/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type GetProductHistorySuite struct {
+	suite.Suite
+
+	productsRepo *mocks.ProductsRepository
+	auditRepo    *mocks.AuditRepository
+	app          domain.Application
+}
+
+func (s *GetProductHistorySuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.productsRepo = &mocks.ProductsRepository{}
+	s.auditRepo = &mocks.AuditRepository{}
+
+	s.app = NewApplication(loggerM, tracerM, s.productsRepo, s.auditRepo, MediaInput{})
+}
+
+func (s *GetProductHistorySuite) Test_GetProductHistory() {
+	s.Run("Should reject limits above the maximum", func() {
+		s.SetupTest()
+
+		_, err := s.app.GetProductHistory(context.Background(), domain.ProductHistoryFilter{
+			ProductID: 1,
+			Limit:     domain.MaxProductHistoryLimit + 1,
+		})
+
+		s.Equal(domain.ErrInvalidHistoryLimit, err)
+		s.auditRepo.AssertNotCalled(s.T(), "ListByProduct", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should list the entries using the default limit", func() {
+		s.SetupTest()
+		entries := []domain.AuditEntry{
+			{ID: 2, ProductID: 1, Action: domain.AuditActionUpdate, Actor: "jane"},
+			{ID: 1, ProductID: 1, Action: domain.AuditActionRegister, Actor: "jane"},
+		}
+
+		s.auditRepo.
+			On("ListByProduct", mock.AnythingOfType("*context.valueCtx"), domain.ProductHistoryFilter{
+				ProductID: 1,
+				Limit:     domain.DefaultProductHistoryLimit,
+			}).
+			Return(entries, nil)
+
+		got, err := s.app.GetProductHistory(context.Background(), domain.ProductHistoryFilter{ProductID: 1})
+
+		s.NoError(err)
+		s.Equal(entries, got)
+	})
+
+	s.Run("Should fail when repository.ListByProduct returns any error", func() {
+		s.SetupTest()
+
+		s.auditRepo.
+			On("ListByProduct", mock.AnythingOfType("*context.valueCtx"), mock.Anything).
+			Return(nil, errors.New("failed to list the audit entries"))
+
+		_, err := s.app.GetProductHistory(context.Background(), domain.ProductHistoryFilter{ProductID: 1, Limit: 10})
+
+		s.Equal(errors.New("failed to list the audit entries"), err)
+	})
+}
+
+func (s *GetProductHistorySuite) Test_Audit() {
+	s.Run("Should not fail the change when it can't be recorded", func() {
+		s.SetupTest()
+		product := domain.Product{ID: 1, Name: "Iphone 13", Price: 4500, Version: 1}
+
+		s.productsRepo.
+			On("Create", mock.AnythingOfType("*context.valueCtx"), product).
+			Return(product, nil)
+
+		s.auditRepo.
+			On("Record", mock.AnythingOfType("*context.valueCtx"), mock.Anything).
+			Return(domain.AuditEntry{}, errors.New("failed to record the change"))
+
+		got, err := s.app.RegisterProduct(context.Background(), product)
+
+		s.NoError(err)
+		s.Equal(product, got)
+	})
+}
+
+func TestGetProductHistorySuite(t *testing.T) {
+	suite.Run(t, new(GetProductHistorySuite))
+}
@@ -0,0 +1,294 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sort"
+
+	"github.com/lucasmls/ecommerce/services/products/app/catalog"
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"go.uber.org/zap"
+)
+
+// importBatchSize is how many rows are matched against the stored Products at once
+const importBatchSize = 100
+
+// catalogImport holds the state of a single ImportCatalog call
+type catalogImport struct {
+	options domain.ImportOptions
+	report  domain.ImportReport
+	// seenIDs and seenSKUs hold the keys of the rows already imported, so
+	// duplicated rows are reported instead of silently overwriting each other
+	seenIDs  map[int]bool
+	seenSKUs map[string]bool
+}
+
+func (a application) ImportCatalog(ctx context.Context, r io.Reader, options domain.ImportOptions) (domain.ImportReport, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.ImportCatalog")
+	defer span.End()
+
+	a.Logger.Info("importing a catalog", zap.Any("options", options))
+
+	if err := options.Validate(); err != nil {
+		return domain.ImportReport{}, err
+	}
+
+	reader, err := catalog.NewReader(options.Format, r)
+	if err != nil {
+		return domain.ImportReport{}, err
+	}
+
+	state := &catalogImport{
+		options:  options,
+		report:   domain.ImportReport{DryRun: options.DryRun},
+		seenIDs:  map[int]bool{},
+		seenSKUs: map[string]bool{},
+	}
+
+	batch := make([]domain.ImportRow, 0, importBatchSize)
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return state.report, err
+		}
+
+		batch = append(batch, row)
+		if len(batch) < importBatchSize {
+			continue
+		}
+
+		if err := a.importBatch(ctx, state, batch); err != nil {
+			return state.report, err
+		}
+
+		batch = batch[:0]
+	}
+
+	if err := a.importBatch(ctx, state, batch); err != nil {
+		return state.report, err
+	}
+
+	sort.SliceStable(state.report.Failures, func(i, j int) bool {
+		return state.report.Failures[i].Line < state.report.Failures[j].Line
+	})
+
+	a.Logger.Info(
+		"catalog imported",
+		zap.Int("created", state.report.Created),
+		zap.Int("updated", state.report.Updated),
+		zap.Int("failed", state.report.Failed),
+		zap.Bool("dry_run", options.DryRun),
+	)
+
+	return state.report, nil
+}
+
+// importBatch validates a batch of rows, matches them against the stored Products and,
+// unless on a dry-run, creates or updates each one of them
+func (a application) importBatch(ctx context.Context, state *catalogImport, rows []domain.ImportRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	valid := make([]domain.ImportRow, 0, len(rows))
+	for _, row := range rows {
+		if row.Err == nil {
+			row.Err = row.Product.Validate()
+		}
+
+		if row.Err == nil {
+			row.Err = state.claimKeys(row.Product)
+		}
+
+		if row.Err != nil {
+			state.fail(row.Line, row.Product, row.Err)
+			continue
+		}
+
+		valid = append(valid, row)
+	}
+
+	byID, bySKU, err := a.storedProducts(ctx, valid)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range valid {
+		product, update, err := state.resolve(row.Product, byID, bySKU)
+		if err != nil {
+			state.fail(row.Line, row.Product, err)
+			continue
+		}
+
+		if !state.options.DryRun {
+			var stored domain.Product
+			if update {
+				stored, err = a.ProductsRepository.Update(ctx, product)
+			} else {
+				stored, err = a.ProductsRepository.Create(ctx, product)
+			}
+
+			if err != nil {
+				state.fail(row.Line, product, err)
+				continue
+			}
+
+			if update {
+				before := byID[stored.ID]
+				if before.ID == 0 {
+					before = bySKU[stored.SKU]
+				}
+
+				a.audit(ctx, domain.AuditActionUpdate, &before, stored)
+			} else {
+				a.audit(ctx, domain.AuditActionRegister, nil, stored)
+			}
+		}
+
+		if update {
+			state.report.Updated++
+		} else {
+			state.report.Created++
+		}
+	}
+
+	return nil
+}
+
+// storedProducts fetches the stored Products matching the IDs or SKUs of the given rows
+func (a application) storedProducts(ctx context.Context, rows []domain.ImportRow) (map[int]domain.Product, map[string]domain.Product, error) {
+	var ids []int
+	var skus []string
+	for _, row := range rows {
+		if row.Product.ID != 0 {
+			ids = append(ids, row.Product.ID)
+		}
+
+		if row.Product.SKU != "" {
+			skus = append(skus, row.Product.SKU)
+		}
+	}
+
+	byID := make(map[int]domain.Product, len(ids))
+	bySKU := make(map[string]domain.Product, len(skus))
+
+	if len(ids) > 0 {
+		products, err := a.ProductsRepository.List(ctx, domain.ListProductsFilter{IDs: ids})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, product := range products {
+			byID[product.ID] = product
+		}
+	}
+
+	if len(skus) > 0 {
+		products, err := a.ProductsRepository.List(ctx, domain.ListProductsFilter{SKUs: skus})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, product := range products {
+			bySKU[product.SKU] = product
+		}
+	}
+
+	return byID, bySKU, nil
+}
+
+// claimKeys fails when another row of the import already has the Product ID or SKU
+func (s *catalogImport) claimKeys(product domain.Product) error {
+	if product.ID != 0 && s.seenIDs[product.ID] {
+		return domain.ErrDuplicatedImportKey
+	}
+
+	if product.SKU != "" && s.seenSKUs[product.SKU] {
+		return domain.ErrDuplicatedImportKey
+	}
+
+	if product.ID != 0 {
+		s.seenIDs[product.ID] = true
+	}
+
+	if product.SKU != "" {
+		s.seenSKUs[product.SKU] = true
+	}
+
+	return nil
+}
+
+// resolve decides, according to the import mode, whether the row creates or updates a Product.
+// Updates carry the Version of the matched Product, so they fail if it changes in the meantime.
+func (s *catalogImport) resolve(
+	product domain.Product,
+	byID map[int]domain.Product,
+	bySKU map[string]domain.Product,
+) (domain.Product, bool, error) {
+	storedByID, foundByID := byID[product.ID]
+	storedBySKU, foundBySKU := bySKU[product.SKU]
+
+	switch s.options.Mode {
+	case domain.ImportModeCreate:
+		if foundByID {
+			return product, false, domain.ErrProductAlreadyExists
+		}
+
+		if foundBySKU {
+			return product, false, domain.ErrProductSKUAlreadyExists
+		}
+
+		return product, false, nil
+	case domain.ImportModeUpsertByID:
+		if product.ID == 0 {
+			if foundBySKU {
+				return product, false, domain.ErrProductSKUAlreadyExists
+			}
+
+			return product, false, nil
+		}
+
+		if !foundByID {
+			return product, false, domain.ErrProductNotFound
+		}
+
+		if foundBySKU && storedBySKU.ID != storedByID.ID {
+			return product, false, domain.ErrProductSKUAlreadyExists
+		}
+
+		product.Version = storedByID.Version
+		return product, true, nil
+	case domain.ImportModeUpsertBySKU:
+		if product.SKU == "" || !foundBySKU {
+			if foundByID {
+				return product, false, domain.ErrProductAlreadyExists
+			}
+
+			return product, false, nil
+		}
+
+		if product.ID != 0 && product.ID != storedBySKU.ID {
+			return product, false, domain.ErrProductSKUAlreadyExists
+		}
+
+		product.ID = storedBySKU.ID
+		product.Version = storedBySKU.Version
+		return product, true, nil
+	default:
+		return product, false, domain.ErrInvalidImportMode
+	}
+}
+
+func (s *catalogImport) fail(line int, product domain.Product, err error) {
+	s.report.Failed++
+	s.report.Failures = append(s.report.Failures, domain.ImportRowError{
+		Line:    line,
+		Product: product,
+		Err:     err,
+	})
+}
@@ -0,0 +1,209 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/lucasmls/ecommerce/services/products/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ImportCatalogSuite struct {
+	suite.Suite
+
+	productsRepo *mocks.ProductsRepository
+	auditRepo    *mocks.AuditRepository
+	app          domain.Application
+}
+
+func (s *ImportCatalogSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.productsRepo = &mocks.ProductsRepository{}
+	s.auditRepo = &mocks.AuditRepository{}
+
+	s.app = NewApplication(loggerM, tracerM, s.productsRepo, s.auditRepo, MediaInput{})
+}
+
+func (s *ImportCatalogSuite) Test_ImportCatalog() {
+	s.Run("Should fail when the import mode is unknown", func() {
+		s.SetupTest()
+
+		_, err := s.app.ImportCatalog(context.Background(), strings.NewReader(""), domain.ImportOptions{
+			Format: domain.CatalogFormatCSV,
+			Mode:   "replace",
+		})
+
+		s.Equal(domain.ErrInvalidImportMode, err)
+	})
+
+	s.Run("Should fail when the catalog header is invalid", func() {
+		s.SetupTest()
+
+		_, err := s.app.ImportCatalog(context.Background(), strings.NewReader("name\n"), domain.ImportOptions{
+			Format: domain.CatalogFormatCSV,
+			Mode:   domain.ImportModeCreate,
+		})
+
+		s.ErrorIs(err, domain.ErrMissingCatalogColumn)
+	})
+
+	s.Run("Should report what would be stored on dry-runs without storing anything", func() {
+		s.SetupTest()
+		content := "sku,name,description,price\n" +
+			"IPH-13,Iphone 13,Cooler,4200\n" +
+			"MBA-M1,Macbook Air M1,Fast!,6800\n" +
+			",,Nameless,-1\n" +
+			"IPH-13,Iphone 13 again,Cool,4500\n"
+
+		s.productsRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.ListProductsFilter{SKUs: []string{"IPH-13", "MBA-M1"}}).
+			Return([]domain.Product{{ID: 7, SKU: "IPH-13", Name: "Iphone 13", Description: "Cool", Price: 4500}}, nil)
+
+		report, err := s.app.ImportCatalog(context.Background(), strings.NewReader(content), domain.ImportOptions{
+			Format: domain.CatalogFormatCSV,
+			Mode:   domain.ImportModeUpsertBySKU,
+			DryRun: true,
+		})
+
+		s.NoError(err)
+		s.Equal(true, report.DryRun)
+		s.Equal(1, report.Created)
+		s.Equal(1, report.Updated)
+		s.Equal(2, report.Failed)
+
+		s.Require().Len(report.Failures, 2)
+		s.Equal(4, report.Failures[0].Line)
+		s.Equal(&domain.ValidationError{Violations: []domain.FieldViolation{
+			{Field: "name", Description: "must not be empty"},
+			{Field: "price", Description: "must not be negative"},
+		}}, report.Failures[0].Err)
+		s.Equal(5, report.Failures[1].Line)
+		s.Equal(domain.ErrDuplicatedImportKey, report.Failures[1].Err)
+
+		s.productsRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+		s.productsRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should update the Products matching the SKU and create the remaining ones", func() {
+		s.SetupTest()
+		content := `{"sku":"IPH-13","name":"Iphone 13","description":"Cooler","price":4200}
+{"sku":"MBA-M1","name":"Macbook Air M1","description":"Fast!","price":6800}
+{"sku":"IPAD-9","name":"Ipad","description":"Big","price":3000}
+`
+
+		s.productsRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.ListProductsFilter{SKUs: []string{"IPH-13", "MBA-M1", "IPAD-9"}}).
+			Return([]domain.Product{{ID: 7, SKU: "IPH-13", Name: "Iphone 13", Description: "Cool", Price: 4500}}, nil)
+
+		s.productsRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), domain.Product{ID: 7, SKU: "IPH-13", Name: "Iphone 13", Description: "Cooler", Price: 4200}).
+			Return(domain.Product{ID: 7, SKU: "IPH-13", Name: "Iphone 13", Description: "Cooler", Price: 4200}, nil)
+
+		s.productsRepo.
+			On("Create", mock.AnythingOfType("*context.valueCtx"), domain.Product{SKU: "MBA-M1", Name: "Macbook Air M1", Description: "Fast!", Price: 6800}).
+			Return(domain.Product{ID: 8, SKU: "MBA-M1", Name: "Macbook Air M1", Description: "Fast!", Price: 6800}, nil)
+
+		s.productsRepo.
+			On("Create", mock.AnythingOfType("*context.valueCtx"), domain.Product{SKU: "IPAD-9", Name: "Ipad", Description: "Big", Price: 3000}).
+			Return(domain.Product{}, errors.New("storage-limit-reached"))
+
+		s.auditRepo.
+			On("Record", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(entry domain.AuditEntry) bool {
+				return entry.Action == domain.AuditActionUpdate && entry.ProductID == 7 && entry.Before.Description == "Cool" && entry.After.Description == "Cooler"
+			})).
+			Return(domain.AuditEntry{}, nil).
+			Once()
+
+		s.auditRepo.
+			On("Record", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(entry domain.AuditEntry) bool {
+				return entry.Action == domain.AuditActionRegister && entry.ProductID == 8 && entry.Before == nil
+			})).
+			Return(domain.AuditEntry{}, nil).
+			Once()
+
+		report, err := s.app.ImportCatalog(context.Background(), strings.NewReader(content), domain.ImportOptions{
+			Format: domain.CatalogFormatNDJSON,
+			Mode:   domain.ImportModeUpsertBySKU,
+		})
+
+		s.NoError(err)
+		s.Equal(domain.ImportReport{
+			Created: 1,
+			Updated: 1,
+			Failed:  1,
+			Failures: []domain.ImportRowError{
+				{
+					Line:    3,
+					Product: domain.Product{SKU: "IPAD-9", Name: "Ipad", Description: "Big", Price: 3000},
+					Err:     errors.New("storage-limit-reached"),
+				},
+			},
+		}, report)
+		s.auditRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Should reject rows matching stored Products when creating", func() {
+		s.SetupTest()
+		content := "id,name,price\n1,Iphone 13,4500\n"
+
+		s.productsRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.ListProductsFilter{IDs: []int{1}}).
+			Return([]domain.Product{{ID: 1, Name: "Iphone 12", Price: 3500}}, nil)
+
+		report, err := s.app.ImportCatalog(context.Background(), strings.NewReader(content), domain.ImportOptions{
+			Format: domain.CatalogFormatCSV,
+			Mode:   domain.ImportModeCreate,
+		})
+
+		s.NoError(err)
+		s.Equal(1, report.Failed)
+		s.Require().Len(report.Failures, 1)
+		s.Equal(domain.ErrProductAlreadyExists, report.Failures[0].Err)
+	})
+
+	s.Run("Should reject rows with unknown IDs when upserting by ID", func() {
+		s.SetupTest()
+		content := "id,name,price\n1,Iphone 13,4500\n"
+
+		s.productsRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.ListProductsFilter{IDs: []int{1}}).
+			Return(nil, nil)
+
+		report, err := s.app.ImportCatalog(context.Background(), strings.NewReader(content), domain.ImportOptions{
+			Format: domain.CatalogFormatCSV,
+			Mode:   domain.ImportModeUpsertByID,
+		})
+
+		s.NoError(err)
+		s.Require().Len(report.Failures, 1)
+		s.Equal(domain.ErrProductNotFound, report.Failures[0].Err)
+	})
+
+	s.Run("Should fail when the stored Products can't be fetched", func() {
+		s.SetupTest()
+		content := "id,name,price\n1,Iphone 13,4500\n"
+
+		s.productsRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.ListProductsFilter{IDs: []int{1}}).
+			Return(nil, errors.New("failed to list Products"))
+
+		_, err := s.app.ImportCatalog(context.Background(), strings.NewReader(content), domain.ImportOptions{
+			Format: domain.CatalogFormatCSV,
+			Mode:   domain.ImportModeUpsertByID,
+		})
+
+		s.Equal(errors.New("failed to list Products"), err)
+	})
+}
+
+func TestImportCatalogSuite(t *testing.T) {
+	suite.Run(t, new(ImportCatalogSuite))
+}
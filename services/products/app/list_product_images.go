@@ -0,0 +1,26 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) ListProductImages(ctx context.Context, productIDs []int) ([]domain.ProductImage, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.ListProductImages")
+	defer span.End()
+
+	a.Logger.Info("listing product images", zap.Ints("productIds", productIDs))
+
+	images, err := a.ImagesRepository.ListByProducts(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range images {
+		images[i] = a.withURLs(images[i])
+	}
+
+	return images, nil
+}
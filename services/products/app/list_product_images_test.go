@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/lucasmls/ecommerce/services/products/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ListProductImagesSuite struct {
+	suite.Suite
+
+	imagesRepo *mocks.ImagesRepository
+	blobStore  *mocks.BlobStore
+	app        domain.Application
+}
+
+func (s *ListProductImagesSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.imagesRepo = &mocks.ImagesRepository{}
+	s.blobStore = &mocks.BlobStore{}
+
+	s.app = MustNewApplication(loggerM, tracerM, &mocks.ProductsRepository{}, &mocks.AuditRepository{}, MediaInput{
+		ImagesRepository: s.imagesRepo,
+		BlobStore:        s.blobStore,
+	})
+
+	s.blobStore.
+		On("URL", mock.AnythingOfType("string")).
+		Return(func(key string) string { return "/media/" + key })
+}
+
+func (s *ListProductImagesSuite) Test_ListProductImages() {
+	s.Run("Should list the images of the products along with their URLs", func() {
+		s.imagesRepo.
+			On("ListByProducts", mock.AnythingOfType("*context.valueCtx"), []int{1, 2}).
+			Return([]domain.ProductImage{
+				{ID: 1, ProductID: 1, Key: "products/1/a.png", Thumbnails: []domain.Thumbnail{{Name: "small", Key: "products/1/a_small.png"}}},
+				{ID: 3, ProductID: 2, Position: 0, Key: "products/2/b.jpg"},
+			}, nil)
+
+		got, err := s.app.ListProductImages(context.Background(), []int{1, 2})
+
+		s.NoError(err)
+		s.Equal([]domain.ProductImage{
+			{
+				ID: 1, ProductID: 1, Key: "products/1/a.png", URL: "/media/products/1/a.png",
+				Thumbnails: []domain.Thumbnail{{Name: "small", Key: "products/1/a_small.png", URL: "/media/products/1/a_small.png"}},
+			},
+			{ID: 3, ProductID: 2, Key: "products/2/b.jpg", URL: "/media/products/2/b.jpg", Thumbnails: []domain.Thumbnail{}},
+		}, got)
+	})
+}
+
+func TestListProductImagesSuite(t *testing.T) {
+	suite.Run(t, new(ListProductImagesSuite))
+}
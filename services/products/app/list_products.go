@@ -13,6 +13,10 @@ func (a application) ListProducts(ctx context.Context, filter domain.ListProduct
 
 	a.Logger.Info("listing products", zap.Any("filter", filter))
 
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
 	products, err := a.ProductsRepository.List(ctx, filter)
 	if err != nil {
 		return nil, err
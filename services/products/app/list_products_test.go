@@ -18,6 +18,7 @@ type ListProductsSuite struct {
 	suite.Suite
 
 	productsRepo *mocks.ProductsRepository
+	auditRepo    *mocks.AuditRepository
 	app          domain.Application
 }
 
@@ -25,11 +26,34 @@ func (s *ListProductsSuite) SetupSuite() {
 	loggerM := zap.NewNop()
 	tracerM := trace.NewNoopTracerProvider().Tracer("")
 	s.productsRepo = &mocks.ProductsRepository{}
+	s.auditRepo = &mocks.AuditRepository{}
 
-	s.app = NewApplication(loggerM, tracerM, s.productsRepo)
+	s.app = NewApplication(loggerM, tracerM, s.productsRepo, s.auditRepo, MediaInput{})
 }
 
 func (s *ListProductsSuite) Test_ListProducts() {
+	s.Run("Should reject invalid price ranges", func() {
+		_, err := s.app.ListProducts(context.Background(), domain.ListProductsFilter{
+			ProductFilter: domain.ProductFilter{PriceFrom: 5000, PriceTo: 1000},
+		})
+
+		s.Equal(domain.ErrInvalidPriceRange, err)
+	})
+
+	s.Run("Should reject sorting by relevance, unknown or repeated fields", func() {
+		for _, sort := range [][]domain.SortKey{
+			{{Field: domain.SortFieldRelevance}},
+			{{Field: "color"}},
+			{{Field: domain.SortFieldPrice}, {Field: domain.SortFieldPrice, Descending: true}},
+		} {
+			_, err := s.app.ListProducts(context.Background(), domain.ListProductsFilter{Sort: sort})
+
+			s.ErrorIs(err, domain.ErrInvalidSort)
+		}
+
+		s.productsRepo.AssertNotCalled(s.T(), "List", mock.Anything, mock.Anything)
+	})
+
 	s.Run("Should fail when repository.List returns any error", func() {
 		ctx := context.Background()
 		filter := domain.ListProductsFilter{}
@@ -0,0 +1,168 @@
+// Package media decodes the uploaded images and scales them down into thumbnails.
+//
+// Only the formats of the standard library are supported: JPEG, PNG and GIF, of which
+// only the first frame is kept. The thumbnails of JPEG images are encoded as JPEG, the
+// others as PNG so their transparency is kept.
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+)
+
+const (
+	ContentTypeJPEG = "image/jpeg"
+	ContentTypePNG  = "image/png"
+	ContentTypeGIF  = "image/gif"
+
+	// jpegQuality is the quality the JPEG thumbnails are encoded with
+	jpegQuality = 85
+)
+
+// extensions are the file extensions of the supported content types
+var extensions = map[string]string{
+	ContentTypeJPEG: ".jpg",
+	ContentTypePNG:  ".png",
+	ContentTypeGIF:  ".gif",
+}
+
+// Image is a decoded image along with the content type it was decoded from
+type Image struct {
+	image.Image
+	ContentType string
+}
+
+// Decode detects the content type of the data, ignoring whatever the uploader claimed it to be, and decodes it.
+// It fails with domain.ErrUnsupportedImageType for anything but JPEG, PNG and GIF images, and with
+// domain.ErrInvalidImage for corrupted images or images with more than domain.MaxImagePixels.
+func Decode(data []byte) (Image, error) {
+	contentType := http.DetectContentType(data)
+	if _, ok := extensions[contentType]; !ok {
+		return Image{}, domain.ErrUnsupportedImageType
+	}
+
+	// the dimensions are checked before decoding, so huge images are never allocated
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > domain.MaxImagePixels {
+		return Image{}, domain.ErrInvalidImage
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return Image{}, domain.ErrInvalidImage
+	}
+
+	return Image{Image: decoded, ContentType: contentType}, nil
+}
+
+// Extension returns the file extension of a supported content type, as in .jpg
+func Extension(contentType string) string {
+	return extensions[contentType]
+}
+
+// ThumbnailContentType is the content type the thumbnails of an image of the given content type are encoded as
+func ThumbnailContentType(contentType string) string {
+	if contentType == ContentTypeJPEG {
+		return ContentTypeJPEG
+	}
+
+	return ContentTypePNG
+}
+
+// Encode writes the image encoded as the given content type, which must be either JPEG or PNG
+func Encode(w io.Writer, img image.Image, contentType string) error {
+	if contentType == ContentTypeJPEG {
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: jpegQuality})
+	}
+
+	return png.Encode(w, img)
+}
+
+// Fit returns the dimensions of an image of the given width and height scaled down to fit
+// within the bounds, keeping its aspect ratio. Images already fitting keep their dimensions.
+func Fit(width, height, maxWidth, maxHeight int) (int, int) {
+	if width <= maxWidth && height <= maxHeight {
+		return width, height
+	}
+
+	// compares maxWidth/width against maxHeight/height without rounding errors
+	if maxWidth*height <= maxHeight*width {
+		return maxWidth, max1(height * maxWidth / width)
+	}
+
+	return max1(width * maxHeight / height), maxHeight
+}
+
+// Thumbnail scales the image down to fit within the bounds, keeping its aspect ratio.
+// Every pixel of the thumbnail is the average of the pixels it covers on the image.
+func Thumbnail(img image.Image, maxWidth, maxHeight int) image.Image {
+	bounds := img.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+
+	width, height := Fit(srcWidth, srcHeight, maxWidth, maxHeight)
+
+	// premultiplied colors, so transparent pixels don't bleed their color into the others
+	src := image.NewRGBA(image.Rect(0, 0, srcWidth, srcHeight))
+	draw.Draw(src, src.Bounds(), img, bounds.Min, draw.Src)
+
+	if width == srcWidth && height == srcHeight {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := span(y, height, srcHeight)
+
+		for x := 0; x < width; x++ {
+			x0, x1 := span(x, width, srcWidth)
+
+			var r, g, b, a, count int
+			for sy := y0; sy < y1; sy++ {
+				offset := src.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					r += int(src.Pix[offset])
+					g += int(src.Pix[offset+1])
+					b += int(src.Pix[offset+2])
+					a += int(src.Pix[offset+3])
+					offset += 4
+					count++
+				}
+			}
+
+			offset := dst.PixOffset(x, y)
+			dst.Pix[offset] = uint8(r / count)
+			dst.Pix[offset+1] = uint8(g / count)
+			dst.Pix[offset+2] = uint8(b / count)
+			dst.Pix[offset+3] = uint8(a / count)
+		}
+	}
+
+	return dst
+}
+
+// span returns the range of source pixels, along one axis, covered by the i-th of n destination pixels
+func span(i, n, size int) (int, int) {
+	start := i * size / n
+	end := (i + 1) * size / n
+	if end <= start {
+		end = start + 1
+	}
+
+	return start, end
+}
+
+func max1(value int) int {
+	if value < 1 {
+		return 1
+	}
+
+	return value
+}
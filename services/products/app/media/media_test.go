@@ -0,0 +1,137 @@
+package media
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+)
+
+type MediaSuite struct {
+	suite.Suite
+}
+
+func (s *MediaSuite) encodePNG(img image.Image) []byte {
+	var buf bytes.Buffer
+	s.Require().NoError(png.Encode(&buf, img))
+
+	return buf.Bytes()
+}
+
+func (s *MediaSuite) Test_Decode() {
+	s.Run("Should decode PNG, JPEG and GIF images detecting their content type", func() {
+		img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+
+		var jpegBuf, gifBuf bytes.Buffer
+		s.Require().NoError(jpeg.Encode(&jpegBuf, img, nil))
+		s.Require().NoError(gif.Encode(&gifBuf, img, nil))
+
+		for contentType, data := range map[string][]byte{
+			ContentTypePNG:  s.encodePNG(img),
+			ContentTypeJPEG: jpegBuf.Bytes(),
+			ContentTypeGIF:  gifBuf.Bytes(),
+		} {
+			got, err := Decode(data)
+
+			s.NoError(err, contentType)
+			s.Equal(contentType, got.ContentType)
+			s.Equal(image.Rect(0, 0, 4, 2), got.Bounds())
+		}
+	})
+
+	s.Run("Should reject anything but images", func() {
+		_, err := Decode([]byte("<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>"))
+
+		s.Equal(domain.ErrUnsupportedImageType, err)
+	})
+
+	s.Run("Should reject corrupted images", func() {
+		data := s.encodePNG(image.NewRGBA(image.Rect(0, 0, 4, 2)))
+
+		_, err := Decode(data[:len(data)/2])
+
+		s.Equal(domain.ErrInvalidImage, err)
+	})
+
+	s.Run("Should reject images with too many pixels without decoding them", func() {
+		// a valid PNG header claiming a 10000x10000 image, with no pixels at all
+		data := s.encodePNG(image.NewGray(image.Rect(0, 0, 1, 1)))
+		header := append([]byte{}, data[:33]...)
+		copy(header[16:24], []byte{0, 0, 0x27, 0x10, 0, 0, 0x27, 0x10})
+
+		_, err := Decode(header)
+
+		s.Equal(domain.ErrInvalidImage, err)
+	})
+}
+
+func (s *MediaSuite) Test_Fit() {
+	s.Run("Should scale the images down keeping their aspect ratio", func() {
+		width, height := Fit(1200, 800, 150, 150)
+		s.Equal([]int{150, 100}, []int{width, height})
+
+		width, height = Fit(800, 1200, 150, 150)
+		s.Equal([]int{100, 150}, []int{width, height})
+
+		width, height = Fit(3000, 10, 150, 150)
+		s.Equal([]int{150, 1}, []int{width, height})
+	})
+
+	s.Run("Should keep the images that already fit", func() {
+		width, height := Fit(100, 40, 150, 150)
+
+		s.Equal([]int{100, 40}, []int{width, height})
+	})
+}
+
+func (s *MediaSuite) Test_Thumbnail() {
+	s.Run("Should average the pixels covered by each thumbnail pixel", func() {
+		img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+		for x := 0; x < 4; x++ {
+			img.Set(x, 0, color.RGBA{R: 255, A: 255})
+			img.Set(x, 1, color.RGBA{B: 255, A: 255})
+		}
+
+		got := Thumbnail(img, 2, 2)
+
+		s.Equal(image.Rect(0, 0, 2, 1), got.Bounds())
+		s.Equal(color.RGBA{R: 127, B: 127, A: 255}, got.At(0, 0))
+		s.Equal(color.RGBA{R: 127, B: 127, A: 255}, got.At(1, 0))
+	})
+
+	s.Run("Should keep the size of the images that already fit", func() {
+		img := image.NewGray(image.Rect(10, 10, 30, 20))
+
+		got := Thumbnail(img, 150, 150)
+
+		s.Equal(image.Rect(0, 0, 20, 10), got.Bounds())
+	})
+}
+
+func (s *MediaSuite) Test_Encode() {
+	s.Run("Should encode JPEG thumbnails as JPEG and the others as PNG", func() {
+		s.Equal(ContentTypeJPEG, ThumbnailContentType(ContentTypeJPEG))
+		s.Equal(ContentTypePNG, ThumbnailContentType(ContentTypePNG))
+		s.Equal(ContentTypePNG, ThumbnailContentType(ContentTypeGIF))
+
+		for _, contentType := range []string{ContentTypeJPEG, ContentTypePNG} {
+			var buf bytes.Buffer
+			s.NoError(Encode(&buf, image.NewRGBA(image.Rect(0, 0, 3, 3)), contentType))
+
+			got, err := Decode(buf.Bytes())
+			s.NoError(err)
+			s.Equal(contentType, got.ContentType)
+		}
+	})
+}
+
+func TestMediaSuite(t *testing.T) {
+	suite.Run(t, new(MediaSuite))
+}
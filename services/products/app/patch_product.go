@@ -0,0 +1,37 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) PatchProduct(ctx context.Context, patch domain.ProductPatch) (domain.Product, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.PatchProduct")
+	defer span.End()
+
+	a.Logger.Info("patching a product", zap.Any("patch", patch))
+
+	if err := patch.Validate(); err != nil {
+		return domain.Product{}, err
+	}
+
+	if patch.Product.Version == 0 {
+		return domain.Product{}, domain.ErrMissingProductVersion
+	}
+
+	before, err := a.storedProduct(ctx, patch.Product.ID, false)
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	patchedProduct, err := a.ProductsRepository.Patch(ctx, patch)
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	a.audit(ctx, domain.AuditActionPatch, before, patchedProduct)
+
+	return patchedProduct, nil
+}
@@ -0,0 +1,121 @@
+package app
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/lucasmls/ecommerce/services/products/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type PatchProductSuite struct {
+	suite.Suite
+
+	productsRepo *mocks.ProductsRepository
+	auditRepo    *mocks.AuditRepository
+	app          domain.Application
+}
+
+func (s *PatchProductSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.productsRepo = &mocks.ProductsRepository{}
+	s.auditRepo = &mocks.AuditRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.productsRepo, s.auditRepo, MediaInput{})
+}
+
+func (s *PatchProductSuite) Test_PatchProduct() {
+	s.Run("Should reject a patch without fields", func() {
+		s.SetupTest()
+
+		_, err := s.app.PatchProduct(context.Background(), domain.ProductPatch{Product: domain.Product{ID: 1}})
+
+		s.ErrorIs(err, domain.ErrEmptyProductPatch)
+		s.productsRepo.AssertNotCalled(s.T(), "Patch", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should reject unknown fields", func() {
+		s.SetupTest()
+		patch := domain.ProductPatch{
+			Product: domain.Product{ID: 1, Name: "Iphone 13"},
+			Fields:  []domain.ProductField{"stock"},
+		}
+
+		_, err := s.app.PatchProduct(context.Background(), patch)
+
+		s.ErrorIs(err, domain.ErrInvalidProductField)
+		s.productsRepo.AssertNotCalled(s.T(), "Patch", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should only validate the listed fields", func() {
+		s.SetupTest()
+		patch := domain.ProductPatch{
+			Product: domain.Product{ID: 1, Price: -1},
+			Fields:  []domain.ProductField{domain.ProductFieldPrice},
+		}
+
+		_, err := s.app.PatchProduct(context.Background(), patch)
+
+		s.Equal(&domain.ValidationError{Violations: []domain.FieldViolation{
+			{Field: "price", Description: "must not be negative"},
+		}}, err)
+		s.productsRepo.AssertNotCalled(s.T(), "Patch", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should return the patched product", func() {
+		s.SetupTest()
+		patch := domain.ProductPatch{
+			Product: domain.Product{ID: 2, Price: 7200, Version: 1},
+			Fields:  []domain.ProductField{domain.ProductFieldPrice},
+		}
+		stored := domain.Product{ID: 2, Name: "Macbook Air M1", Description: "Fast!", Price: 6800, Version: 1}
+		product := domain.Product{ID: 2, Name: "Macbook Air M1", Description: "Fast!", Price: 7200, Version: 2}
+
+		s.productsRepo.On("List",
+			mock.AnythingOfType("*context.valueCtx"),
+			domain.ListProductsFilter{IDs: []int{2}},
+		).Return([]domain.Product{stored}, nil)
+
+		s.productsRepo.On("Patch",
+			mock.AnythingOfType("*context.valueCtx"),
+			patch,
+		).Return(product, nil)
+
+		s.auditRepo.On("Record",
+			mock.AnythingOfType("*context.valueCtx"),
+			mock.MatchedBy(func(entry domain.AuditEntry) bool {
+				return entry.Action == domain.AuditActionPatch && reflect.DeepEqual(*entry.Before, stored) && reflect.DeepEqual(*entry.After, product)
+			}),
+		).Return(domain.AuditEntry{}, nil)
+
+		got, err := s.app.PatchProduct(context.Background(), patch)
+
+		s.NoError(err)
+		s.Equal(product, got)
+		s.auditRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Should require the version the patch is based on", func() {
+		s.SetupTest()
+		patch := domain.ProductPatch{
+			Product: domain.Product{ID: 2, Price: 7200},
+			Fields:  []domain.ProductField{domain.ProductFieldPrice},
+		}
+
+		_, err := s.app.PatchProduct(context.Background(), patch)
+
+		s.Equal(domain.ErrMissingProductVersion, err)
+		s.productsRepo.AssertNotCalled(s.T(), "Patch", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPatchProductSuite(t *testing.T) {
+	suite.Run(t, new(PatchProductSuite))
+}
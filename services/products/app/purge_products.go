@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) PurgeProducts(ctx context.Context, retention time.Duration) (int, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.PurgeProducts")
+	defer span.End()
+
+	if retention < 0 {
+		return 0, domain.ErrInvalidPurgeRetention
+	}
+
+	deletedBefore := time.Now().Add(-retention)
+
+	a.Logger.Info("purging deleted products", zap.Time("deleted_before", deletedBefore))
+
+	trash, err := a.ProductsRepository.List(ctx, domain.ListProductsFilter{OnlyDeleted: true})
+	if err != nil {
+		return 0, err
+	}
+
+	var candidates []int
+	for _, product := range trash {
+		if product.DeletedAt.Before(deletedBefore) {
+			candidates = append(candidates, product.ID)
+		}
+	}
+
+	purged, err := a.ProductsRepository.Purge(ctx, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(candidates) > 0 {
+		a.purgeImages(ctx, candidates)
+	}
+
+	a.Logger.Info("purged deleted products", zap.Int("purged", purged))
+
+	return purged, nil
+}
+
+// purgeImages removes the images of the given Products once they're purged, leaving the images of the ones
+// restored in the meantime untouched. The Products are already gone by then, so failures are only logged.
+func (a application) purgeImages(ctx context.Context, productIDs []int) {
+	remaining, err := a.ProductsRepository.List(ctx, domain.ListProductsFilter{IDs: productIDs, IncludeDeleted: true})
+	if err != nil {
+		a.Logger.Error("failed to list the purged products to remove their images", zap.Error(err))
+		return
+	}
+
+	kept := make(map[int]bool, len(remaining))
+	for _, product := range remaining {
+		kept[product.ID] = true
+	}
+
+	for _, productID := range productIDs {
+		if kept[productID] {
+			continue
+		}
+
+		images, err := a.ImagesRepository.DeleteByProduct(ctx, productID)
+		if err != nil {
+			a.Logger.Error("failed to remove the images of a purged product", zap.Error(err), zap.Int("productId", productID))
+			continue
+		}
+
+		for _, image := range images {
+			a.deleteBlobs(ctx, imageKeys(image))
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/lucasmls/ecommerce/services/products/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type PurgeProductsSuite struct {
+	suite.Suite
+
+	productsRepo *mocks.ProductsRepository
+	auditRepo    *mocks.AuditRepository
+	imagesRepo   *mocks.ImagesRepository
+	blobStore    *mocks.BlobStore
+	app          domain.Application
+}
+
+func (s *PurgeProductsSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.productsRepo = &mocks.ProductsRepository{}
+	s.auditRepo = &mocks.AuditRepository{}
+	s.imagesRepo = &mocks.ImagesRepository{}
+	s.blobStore = &mocks.BlobStore{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.productsRepo, s.auditRepo, MediaInput{
+		ImagesRepository: s.imagesRepo,
+		BlobStore:        s.blobStore,
+	})
+}
+
+func (s *PurgeProductsSuite) Test_PurgeProducts() {
+	s.Run("Should reject a negative retention", func() {
+		s.SetupTest()
+
+		_, err := s.app.PurgeProducts(context.Background(), -time.Hour)
+
+		s.Equal(domain.ErrInvalidPurgeRetention, err)
+		s.productsRepo.AssertNotCalled(s.T(), "Purge", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should purge the products deleted before the retention period", func() {
+		s.SetupTest()
+		retention := 30 * 24 * time.Hour
+		lowerBound := time.Now().Add(-retention)
+
+		s.productsRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.ListProductsFilter{OnlyDeleted: true}).
+			Return([]domain.Product{}, nil)
+
+		s.productsRepo.
+			On("Purge",
+				mock.AnythingOfType("*context.valueCtx"),
+				mock.MatchedBy(func(deletedBefore time.Time) bool {
+					return !deletedBefore.Before(lowerBound) && deletedBefore.Before(time.Now().Add(-retention+time.Minute))
+				}),
+			).
+			Return(4, nil)
+
+		purged, err := s.app.PurgeProducts(context.Background(), retention)
+
+		s.NoError(err)
+		s.Equal(4, purged)
+		s.imagesRepo.AssertNotCalled(s.T(), "DeleteByProduct", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should remove the images of the purged products only", func() {
+		s.SetupTest()
+		retention := 30 * 24 * time.Hour
+		image := domain.ProductImage{
+			ID:         3,
+			ProductID:  1,
+			Key:        "products/1/abc.png",
+			Thumbnails: []domain.Thumbnail{{Name: "small", Key: "products/1/abc_small.png"}},
+		}
+
+		s.productsRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.ListProductsFilter{OnlyDeleted: true}).
+			Return([]domain.Product{
+				{ID: 1, DeletedAt: time.Now().Add(-2 * retention)},
+				{ID: 2, DeletedAt: time.Now().Add(-2 * retention)},
+				{ID: 3, DeletedAt: time.Now()},
+			}, nil)
+
+		s.productsRepo.
+			On("Purge", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("time.Time")).
+			Return(1, nil)
+
+		// the second product was restored before being purged
+		s.productsRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.ListProductsFilter{IDs: []int{1, 2}, IncludeDeleted: true}).
+			Return([]domain.Product{{ID: 2}}, nil)
+
+		s.imagesRepo.
+			On("DeleteByProduct", mock.AnythingOfType("*context.valueCtx"), 1).
+			Return([]domain.ProductImage{image}, nil)
+
+		s.blobStore.On("Delete", mock.AnythingOfType("*context.valueCtx"), "products/1/abc.png").Return(nil)
+		s.blobStore.On("Delete", mock.AnythingOfType("*context.valueCtx"), "products/1/abc_small.png").Return(nil)
+
+		purged, err := s.app.PurgeProducts(context.Background(), retention)
+
+		s.NoError(err)
+		s.Equal(1, purged)
+		s.imagesRepo.AssertNumberOfCalls(s.T(), "DeleteByProduct", 1)
+		s.blobStore.AssertExpectations(s.T())
+	})
+}
+
+func TestPurgeProductsSuite(t *testing.T) {
+	suite.Run(t, new(PurgeProductsSuite))
+}
@@ -18,5 +18,7 @@ func (a application) RegisterProduct(ctx context.Context, product domain.Product
 		return domain.Product{}, err
 	}
 
+	a.audit(ctx, domain.AuditActionRegister, nil, registeredProduct)
+
 	return registeredProduct, nil
 }
@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/lucasmls/ecommerce/services/products/domain"
 	"github.com/lucasmls/ecommerce/services/products/mocks"
+	"github.com/lucasmls/ecommerce/shared/actor"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
@@ -19,6 +21,7 @@ type RegisterProductSuite struct {
 	suite.Suite
 
 	productsRepo *mocks.ProductsRepository
+	auditRepo    *mocks.AuditRepository
 	app          domain.Application
 }
 
@@ -26,8 +29,9 @@ func (s *RegisterProductSuite) SetupSuite() {
 	loggerM := zap.NewNop()
 	tracerM := trace.NewNoopTracerProvider().Tracer("")
 	s.productsRepo = &mocks.ProductsRepository{}
+	s.auditRepo = &mocks.AuditRepository{}
 
-	s.app = NewApplication(loggerM, tracerM, s.productsRepo)
+	s.app = NewApplication(loggerM, tracerM, s.productsRepo, s.auditRepo, MediaInput{})
 }
 
 func (s *RegisterProductSuite) Test_RegisterProduct() {
@@ -64,10 +68,23 @@ func (s *RegisterProductSuite) Test_RegisterProduct() {
 			product,
 		).Return(product, nil)
 
-		got, err := s.app.RegisterProduct(ctx, product)
+		s.auditRepo.On("Record",
+			mock.AnythingOfType("*context.valueCtx"),
+			mock.MatchedBy(func(entry domain.AuditEntry) bool {
+				return entry.Action == domain.AuditActionRegister &&
+					entry.ProductID == product.ID &&
+					entry.Actor == "jane" &&
+					entry.Before == nil &&
+					reflect.DeepEqual(*entry.After, product) &&
+					!entry.CreatedAt.IsZero()
+			}),
+		).Return(domain.AuditEntry{}, nil)
+
+		got, err := s.app.RegisterProduct(actor.NewContext(ctx, "jane"), product)
 
 		s.NoError(err)
 		s.Equal(product, got)
+		s.auditRepo.AssertExpectations(s.T())
 	})
 }
 
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) RegisterProducts(ctx context.Context, products []domain.Product) ([]domain.ProductResult, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.RegisterProducts")
+	defer span.End()
+
+	a.Logger.Info("registering a batch of products", zap.Int("size", len(products)))
+
+	results, err := a.ProductsRepository.CreateMany(ctx, products)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		if result.Err == nil {
+			a.audit(ctx, domain.AuditActionRegister, nil, result.Product)
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/lucasmls/ecommerce/services/products/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type RegisterProductsSuite struct {
+	suite.Suite
+
+	productsRepo *mocks.ProductsRepository
+	auditRepo    *mocks.AuditRepository
+	app          domain.Application
+}
+
+func (s *RegisterProductsSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.productsRepo = &mocks.ProductsRepository{}
+	s.auditRepo = &mocks.AuditRepository{}
+
+	s.app = NewApplication(loggerM, tracerM, s.productsRepo, s.auditRepo, MediaInput{})
+}
+
+func (s *RegisterProductsSuite) Test_RegisterProducts() {
+	s.Run("Should fail when repository.CreateMany returns any error", func() {
+		ctx := context.Background()
+		products := []domain.Product{
+			{ID: 1, Name: "Macbook Air M1", Description: "Fast!", Price: 6800},
+		}
+
+		s.productsRepo.On("CreateMany",
+			mock.AnythingOfType("*context.valueCtx"),
+			products,
+		).Return(nil, errors.New("failed to register Products"))
+
+		_, err := s.app.RegisterProducts(ctx, products)
+
+		s.Equal(errors.New("failed to register Products"), err)
+	})
+
+	s.Run("Should return the outcome of every Product of the batch", func() {
+		ctx := context.Background()
+		products := []domain.Product{
+			{ID: 2, Name: "Iphone 13", Description: "Cool", Price: 4500},
+			{ID: 3, Name: "Macbook Pro M1 Max", Description: "Fast!", Price: 16500},
+		}
+
+		results := []domain.ProductResult{
+			{Product: products[0]},
+			{Err: errors.New("storage-limit-reached")},
+		}
+
+		s.productsRepo.On("CreateMany",
+			mock.AnythingOfType("*context.valueCtx"),
+			products,
+		).Return(results, nil)
+
+		s.auditRepo.On("Record",
+			mock.AnythingOfType("*context.valueCtx"),
+			mock.MatchedBy(func(entry domain.AuditEntry) bool {
+				return entry.Action == domain.AuditActionRegister && reflect.DeepEqual(*entry.After, products[0])
+			}),
+		).Return(domain.AuditEntry{}, nil).Once()
+
+		got, err := s.app.RegisterProducts(ctx, products)
+
+		s.NoError(err)
+		s.Equal(results, got)
+		s.auditRepo.AssertExpectations(s.T())
+	})
+}
+
+func TestRegisterProductsSuite(t *testing.T) {
+	suite.Run(t, new(RegisterProductsSuite))
+}
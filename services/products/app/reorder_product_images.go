@@ -0,0 +1,26 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) ReorderProductImages(ctx context.Context, productID int, imageIDs []int) ([]domain.ProductImage, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.ReorderProductImages")
+	defer span.End()
+
+	a.Logger.Info("reordering product images", zap.Int("productId", productID), zap.Ints("imageIds", imageIDs))
+
+	images, err := a.ImagesRepository.Reorder(ctx, productID, imageIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range images {
+		images[i] = a.withURLs(images[i])
+	}
+
+	return images, nil
+}
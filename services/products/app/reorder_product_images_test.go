@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/lucasmls/ecommerce/services/products/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ReorderProductImagesSuite struct {
+	suite.Suite
+
+	imagesRepo *mocks.ImagesRepository
+	blobStore  *mocks.BlobStore
+	app        domain.Application
+}
+
+func (s *ReorderProductImagesSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.imagesRepo = &mocks.ImagesRepository{}
+	s.blobStore = &mocks.BlobStore{}
+
+	s.app = MustNewApplication(loggerM, tracerM, &mocks.ProductsRepository{}, &mocks.AuditRepository{}, MediaInput{
+		ImagesRepository: s.imagesRepo,
+		BlobStore:        s.blobStore,
+	})
+
+	s.blobStore.
+		On("URL", mock.AnythingOfType("string")).
+		Return(func(key string) string { return "/media/" + key })
+}
+
+func (s *ReorderProductImagesSuite) Test_ReorderProductImages() {
+	s.Run("Should return the error in case the order doesn't list every image of the product", func() {
+		s.imagesRepo.
+			On("Reorder", mock.AnythingOfType("*context.valueCtx"), 1, []int{2}).
+			Return(nil, domain.ErrInvalidImageOrder)
+
+		_, err := s.app.ReorderProductImages(context.Background(), 1, []int{2})
+
+		s.Equal(domain.ErrInvalidImageOrder, err)
+	})
+
+	s.Run("Should return the reordered images along with their URLs", func() {
+		s.imagesRepo.
+			On("Reorder", mock.AnythingOfType("*context.valueCtx"), 1, []int{2, 1}).
+			Return([]domain.ProductImage{
+				{ID: 2, ProductID: 1, Position: 0, Key: "products/1/b.png"},
+				{ID: 1, ProductID: 1, Position: 1, Key: "products/1/a.png"},
+			}, nil)
+
+		got, err := s.app.ReorderProductImages(context.Background(), 1, []int{2, 1})
+
+		s.NoError(err)
+		s.Equal([]domain.ProductImage{
+			{ID: 2, ProductID: 1, Position: 0, Key: "products/1/b.png", URL: "/media/products/1/b.png", Thumbnails: []domain.Thumbnail{}},
+			{ID: 1, ProductID: 1, Position: 1, Key: "products/1/a.png", URL: "/media/products/1/a.png", Thumbnails: []domain.Thumbnail{}},
+		}, got)
+	})
+}
+
+func TestReorderProductImagesSuite(t *testing.T) {
+	suite.Run(t, new(ReorderProductImagesSuite))
+}
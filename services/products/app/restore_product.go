@@ -0,0 +1,29 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) RestoreProduct(ctx context.Context, id int) (domain.Product, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.RestoreProduct")
+	defer span.End()
+
+	a.Logger.Info("restoring a product", zap.Any("id", id))
+
+	before, err := a.storedProduct(ctx, id, true)
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	restoredProduct, err := a.ProductsRepository.Restore(ctx, id)
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	a.audit(ctx, domain.AuditActionRestore, before, restoredProduct)
+
+	return restoredProduct, nil
+}
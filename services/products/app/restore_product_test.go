@@ -0,0 +1,82 @@
+package app
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/lucasmls/ecommerce/services/products/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type RestoreProductSuite struct {
+	suite.Suite
+
+	productsRepo *mocks.ProductsRepository
+	auditRepo    *mocks.AuditRepository
+	app          domain.Application
+}
+
+func (s *RestoreProductSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.productsRepo = &mocks.ProductsRepository{}
+	s.auditRepo = &mocks.AuditRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.productsRepo, s.auditRepo, MediaInput{})
+}
+
+func (s *RestoreProductSuite) Test_RestoreProduct() {
+	s.Run("Should return the error in case the specified product isn't deleted", func() {
+		ctx := context.Background()
+		product := domain.Product{ID: 1, Name: "Iphone 13", Price: 4500, Version: 1}
+
+		s.productsRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.ListProductsFilter{IDs: []int{1}, IncludeDeleted: true}).
+			Return([]domain.Product{product}, nil)
+
+		s.productsRepo.
+			On("Restore", mock.AnythingOfType("*context.valueCtx"), 1).
+			Return(domain.Product{}, domain.ErrProductNotDeleted)
+
+		_, err := s.app.RestoreProduct(ctx, 1)
+
+		s.Equal(domain.ErrProductNotDeleted, err)
+	})
+
+	s.Run("Should return the restored product", func() {
+		ctx := context.Background()
+		deleted := domain.Product{ID: 2, Name: "Macbook Air M1", Description: "Fast!", Price: 6800, Version: 2, DeletedAt: time.Now()}
+		product := domain.Product{ID: 2, Name: "Macbook Air M1", Description: "Fast!", Price: 6800, Version: 3}
+
+		s.productsRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.ListProductsFilter{IDs: []int{2}, IncludeDeleted: true}).
+			Return([]domain.Product{deleted}, nil)
+
+		s.productsRepo.
+			On("Restore", mock.AnythingOfType("*context.valueCtx"), 2).
+			Return(product, nil)
+
+		s.auditRepo.
+			On("Record", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(entry domain.AuditEntry) bool {
+				return entry.Action == domain.AuditActionRestore && reflect.DeepEqual(*entry.Before, deleted) && reflect.DeepEqual(*entry.After, product)
+			})).
+			Return(domain.AuditEntry{}, nil)
+
+		got, err := s.app.RestoreProduct(ctx, 2)
+
+		s.NoError(err)
+		s.Equal(product, got)
+		s.auditRepo.AssertExpectations(s.T())
+	})
+}
+
+func TestRestoreProductSuite(t *testing.T) {
+	suite.Run(t, new(RestoreProductSuite))
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) SearchProducts(ctx context.Context, search domain.ProductSearch) ([]domain.ProductSearchResult, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.SearchProducts")
+	defer span.End()
+
+	a.Logger.Info("searching products", zap.Any("search", search))
+
+	if err := search.Validate(); err != nil {
+		return nil, err
+	}
+
+	if search.Limit == 0 {
+		search.Limit = domain.DefaultSearchLimit
+	}
+
+	if len(search.Sort) == 0 {
+		search.Sort = domain.DefaultSearchSort
+	}
+
+	results, err := a.ProductsRepository.Search(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	terms := search.Terms()
+	for i := range results {
+		results[i].Highlights = domain.HighlightProduct(results[i].Product, terms)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,131 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/lucasmls/ecommerce/services/products/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type SearchProductsSuite struct {
+	suite.Suite
+
+	productsRepo *mocks.ProductsRepository
+	app          domain.Application
+}
+
+func (s *SearchProductsSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.productsRepo = &mocks.ProductsRepository{}
+
+	s.app = NewApplication(loggerM, tracerM, s.productsRepo, &mocks.AuditRepository{}, MediaInput{})
+}
+
+func (s *SearchProductsSuite) Test_SearchProducts() {
+	s.Run("Should reject an empty query", func() {
+		s.SetupTest()
+
+		_, err := s.app.SearchProducts(context.Background(), domain.ProductSearch{Query: " ?! "})
+
+		s.Equal(domain.ErrEmptySearchQuery, err)
+		s.productsRepo.AssertNotCalled(s.T(), "Search", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should reject queries with too many words", func() {
+		s.SetupTest()
+
+		_, err := s.app.SearchProducts(context.Background(), domain.ProductSearch{Query: "a b c d e f g h i j k"})
+
+		s.Equal(domain.ErrTooManySearchTerms, err)
+	})
+
+	s.Run("Should reject limits above the maximum", func() {
+		s.SetupTest()
+
+		_, err := s.app.SearchProducts(context.Background(), domain.ProductSearch{
+			Query: "macbook",
+			Limit: domain.MaxSearchLimit + 1,
+		})
+
+		s.Equal(domain.ErrInvalidSearchLimit, err)
+	})
+
+	s.Run("Should reject unknown sort fields", func() {
+		s.SetupTest()
+
+		_, err := s.app.SearchProducts(context.Background(), domain.ProductSearch{
+			Query: "macbook",
+			Sort:  []domain.SortKey{{Field: "color"}},
+		})
+
+		s.ErrorIs(err, domain.ErrInvalidSort)
+	})
+
+	s.Run("Should search using the given sort", func() {
+		s.SetupTest()
+		sort := []domain.SortKey{{Field: domain.SortFieldPrice}, {Field: domain.SortFieldRelevance, Descending: true}}
+
+		s.productsRepo.
+			On("Search", mock.AnythingOfType("*context.valueCtx"), domain.ProductSearch{
+				Query: "macbook",
+				Limit: domain.DefaultSearchLimit,
+				Sort:  sort,
+			}).
+			Return([]domain.ProductSearchResult{}, nil)
+
+		_, err := s.app.SearchProducts(context.Background(), domain.ProductSearch{Query: "macbook", Sort: sort})
+
+		s.NoError(err)
+	})
+
+	s.Run("Should search using the default limit and sort and highlight the matches", func() {
+		s.SetupTest()
+		product := domain.Product{ID: 1, Name: "Macbook Air M1", Description: "The lightest Mac", Price: 6900}
+
+		s.productsRepo.
+			On("Search", mock.AnythingOfType("*context.valueCtx"), domain.ProductSearch{
+				Query: "mac air",
+				Limit: domain.DefaultSearchLimit,
+				Sort:  domain.DefaultSearchSort,
+			}).
+			Return([]domain.ProductSearchResult{{Product: product, Score: 4.5}}, nil)
+
+		got, err := s.app.SearchProducts(context.Background(), domain.ProductSearch{Query: "mac air"})
+
+		s.NoError(err)
+		s.Equal([]domain.ProductSearchResult{
+			{
+				Product: product,
+				Score:   4.5,
+				Highlights: domain.ProductHighlights{
+					Name:        "<em>Macbook</em> <em>Air</em> M1",
+					Description: "The lightest <em>Mac</em>",
+				},
+			},
+		}, got)
+	})
+
+	s.Run("Should fail when repository.Search returns any error", func() {
+		s.SetupTest()
+
+		s.productsRepo.
+			On("Search", mock.AnythingOfType("*context.valueCtx"), mock.Anything).
+			Return(nil, errors.New("failed to search the products"))
+
+		_, err := s.app.SearchProducts(context.Background(), domain.ProductSearch{Query: "macbook"})
+
+		s.Equal(errors.New("failed to search the products"), err)
+	})
+}
+
+func TestSearchProductsSuite(t *testing.T) {
+	suite.Run(t, new(SearchProductsSuite))
+}
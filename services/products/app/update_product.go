@@ -13,10 +13,21 @@ func (a application) UpdateProduct(ctx context.Context, product domain.Product)
 
 	a.Logger.Info("updating a product", zap.Any("product", product))
 
+	if product.Version == 0 {
+		return domain.Product{}, domain.ErrMissingProductVersion
+	}
+
+	before, err := a.storedProduct(ctx, product.ID, false)
+	if err != nil {
+		return domain.Product{}, err
+	}
+
 	updatedProduct, err := a.ProductsRepository.Update(ctx, product)
 	if err != nil {
 		return domain.Product{}, err
 	}
 
+	a.audit(ctx, domain.AuditActionUpdate, before, updatedProduct)
+
 	return updatedProduct, nil
 }
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
@@ -17,6 +18,7 @@ type UpdateProductSuite struct {
 	suite.Suite
 
 	productsRepo *mocks.ProductsRepository
+	auditRepo    *mocks.AuditRepository
 	app          domain.Application
 }
 
@@ -24,8 +26,9 @@ func (s *UpdateProductSuite) SetupSuite() {
 	loggerM := zap.NewNop()
 	tracerM := trace.NewNoopTracerProvider().Tracer("")
 	s.productsRepo = &mocks.ProductsRepository{}
+	s.auditRepo = &mocks.AuditRepository{}
 
-	s.app = MustNewApplication(loggerM, tracerM, s.productsRepo)
+	s.app = MustNewApplication(loggerM, tracerM, s.productsRepo, s.auditRepo, MediaInput{})
 }
 
 func (s *UpdateProductSuite) Test_UpdateProduct() {
@@ -36,8 +39,14 @@ func (s *UpdateProductSuite) Test_UpdateProduct() {
 			Name:        "Macbook Air M1",
 			Description: "Fast!",
 			Price:       6800,
+			Version:     1,
 		}
 
+		s.productsRepo.On("List",
+			mock.AnythingOfType("*context.valueCtx"),
+			domain.ListProductsFilter{IDs: []int{1}},
+		).Return([]domain.Product{}, nil)
+
 		s.productsRepo.On("Update",
 			mock.AnythingOfType("*context.valueCtx"),
 			product,
@@ -55,17 +64,46 @@ func (s *UpdateProductSuite) Test_UpdateProduct() {
 			Name:        "Macbook Air M1 - Updated",
 			Description: "Fast!!!",
 			Price:       6800,
+			Version:     1,
+		}
+
+		stored := domain.Product{
+			ID:          2,
+			Name:        "Macbook Air M1",
+			Description: "Fast!",
+			Price:       6800,
+			Version:     1,
 		}
 
+		s.productsRepo.On("List",
+			mock.AnythingOfType("*context.valueCtx"),
+			domain.ListProductsFilter{IDs: []int{2}},
+		).Return([]domain.Product{stored}, nil)
+
 		s.productsRepo.On("Update",
 			mock.AnythingOfType("*context.valueCtx"),
 			product,
 		).Return(product, nil)
 
+		s.auditRepo.On("Record",
+			mock.AnythingOfType("*context.valueCtx"),
+			mock.MatchedBy(func(entry domain.AuditEntry) bool {
+				return entry.Action == domain.AuditActionUpdate && reflect.DeepEqual(*entry.Before, stored) && reflect.DeepEqual(*entry.After, product)
+			}),
+		).Return(domain.AuditEntry{}, nil)
+
 		got, err := s.app.UpdateProduct(ctx, product)
 
 		s.NoError(err)
 		s.Equal(product, got)
+		s.auditRepo.AssertExpectations(s.T())
+	})
+
+	s.Run("Should require the version the update is based on", func() {
+		_, err := s.app.UpdateProduct(context.Background(), domain.Product{ID: 3, Name: "Ipad", Price: 3000})
+
+		s.Equal(domain.ErrMissingProductVersion, err)
+		s.productsRepo.AssertNotCalled(s.T(), "Update", mock.Anything, domain.Product{ID: 3, Name: "Ipad", Price: 3000})
 	})
 }
 
@@ -0,0 +1,158 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/products/app/media"
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) UploadProductImage(ctx context.Context, upload domain.ImageUpload, content io.Reader) (domain.ProductImage, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.UploadProductImage")
+	defer span.End()
+
+	a.Logger.Info("uploading a product image", zap.Any("upload", upload))
+
+	if err := upload.Validate(); err != nil {
+		return domain.ProductImage{}, err
+	}
+
+	product, err := a.storedProduct(ctx, upload.ProductID, false)
+	if err != nil {
+		return domain.ProductImage{}, err
+	}
+
+	if product == nil {
+		return domain.ProductImage{}, domain.ErrProductNotFound
+	}
+
+	data, err := io.ReadAll(io.LimitReader(content, domain.MaxImageSize+1))
+	if err != nil {
+		return domain.ProductImage{}, err
+	}
+
+	if len(data) > domain.MaxImageSize {
+		return domain.ProductImage{}, domain.ErrImageTooLarge
+	}
+
+	decoded, err := media.Decode(data)
+	if err != nil {
+		return domain.ProductImage{}, err
+	}
+
+	// the keys are random, so the URLs of deleted images are never reused by the new ones
+	keyPrefix, err := imageKeyPrefix(upload.ProductID)
+	if err != nil {
+		return domain.ProductImage{}, err
+	}
+
+	bounds := decoded.Bounds()
+	image := domain.ProductImage{
+		ProductID:   upload.ProductID,
+		Key:         keyPrefix + media.Extension(decoded.ContentType),
+		ContentType: decoded.ContentType,
+		Width:       bounds.Dx(),
+		Height:      bounds.Dy(),
+		Size:        len(data),
+		AltText:     upload.AltText,
+		CreatedAt:   time.Now(),
+	}
+
+	var storedKeys []string
+	put := func(key string, content []byte, contentType string) error {
+		if err := a.BlobStore.Put(ctx, key, bytes.NewReader(content), int64(len(content)), contentType); err != nil {
+			a.deleteBlobs(ctx, storedKeys)
+			return err
+		}
+
+		storedKeys = append(storedKeys, key)
+		return nil
+	}
+
+	if err := put(image.Key, data, image.ContentType); err != nil {
+		return domain.ProductImage{}, err
+	}
+
+	thumbnailType := media.ThumbnailContentType(decoded.ContentType)
+	for _, size := range a.ThumbnailSizes {
+		thumbnail := media.Thumbnail(decoded, size.Width, size.Height)
+
+		var encoded bytes.Buffer
+		if err := media.Encode(&encoded, thumbnail, thumbnailType); err != nil {
+			a.deleteBlobs(ctx, storedKeys)
+			return domain.ProductImage{}, err
+		}
+
+		key := keyPrefix + "_" + size.Name + media.Extension(thumbnailType)
+		if err := put(key, encoded.Bytes(), thumbnailType); err != nil {
+			return domain.ProductImage{}, err
+		}
+
+		image.Thumbnails = append(image.Thumbnails, domain.Thumbnail{
+			Name:   size.Name,
+			Key:    key,
+			Width:  thumbnail.Bounds().Dx(),
+			Height: thumbnail.Bounds().Dy(),
+		})
+	}
+
+	createdImage, err := a.ImagesRepository.Create(ctx, image)
+	if err != nil {
+		a.deleteBlobs(ctx, storedKeys)
+		return domain.ProductImage{}, err
+	}
+
+	return a.withURLs(createdImage), nil
+}
+
+// imageKeyPrefix generates a random key for a new image of the Product, its thumbnails keys start with it
+func imageKeyPrefix(productID int) (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("products/%d/%s", productID, hex.EncodeToString(id)), nil
+}
+
+// deleteBlobs removes the blobs of the given keys from the BlobStore.
+// Failing to remove them only leaves unused blobs behind, so it's logged instead of failing the operation.
+func (a application) deleteBlobs(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := a.BlobStore.Delete(ctx, key); err != nil {
+			a.Logger.Error("failed to delete a blob", zap.Error(err), zap.String("key", key))
+		}
+	}
+}
+
+// imageKeys returns the keys of the image and of its thumbnails
+func imageKeys(image domain.ProductImage) []string {
+	keys := []string{image.Key}
+	for _, thumbnail := range image.Thumbnails {
+		keys = append(keys, thumbnail.Key)
+	}
+
+	return keys
+}
+
+// withURLs sets the URLs the image and its thumbnails are served from
+func (a application) withURLs(image domain.ProductImage) domain.ProductImage {
+	image.URL = a.BlobStore.URL(image.Key)
+
+	thumbnails := make([]domain.Thumbnail, 0, len(image.Thumbnails))
+	for _, thumbnail := range image.Thumbnails {
+		thumbnail.URL = a.BlobStore.URL(thumbnail.Key)
+		thumbnails = append(thumbnails, thumbnail)
+	}
+
+	image.Thumbnails = thumbnails
+
+	return image
+}
@@ -0,0 +1,189 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/lucasmls/ecommerce/services/products/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type UploadProductImageSuite struct {
+	suite.Suite
+
+	productsRepo *mocks.ProductsRepository
+	imagesRepo   *mocks.ImagesRepository
+	blobStore    *mocks.BlobStore
+	app          domain.Application
+}
+
+func (s *UploadProductImageSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.productsRepo = &mocks.ProductsRepository{}
+	s.imagesRepo = &mocks.ImagesRepository{}
+	s.blobStore = &mocks.BlobStore{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.productsRepo, &mocks.AuditRepository{}, MediaInput{
+		ImagesRepository: s.imagesRepo,
+		BlobStore:        s.blobStore,
+		ThumbnailSizes:   []domain.ThumbnailSize{{Name: "small", Width: 100, Height: 100}},
+	})
+
+	s.blobStore.
+		On("URL", mock.AnythingOfType("string")).
+		Return(func(key string) string { return "https://cdn.test/" + key })
+}
+
+func (s *UploadProductImageSuite) storeProduct(id int) {
+	s.productsRepo.
+		On("List", mock.AnythingOfType("*context.valueCtx"), domain.ListProductsFilter{IDs: []int{id}}).
+		Return([]domain.Product{{ID: id, Name: "Iphone 13"}}, nil)
+}
+
+func (s *UploadProductImageSuite) pngImage(width, height int) []byte {
+	var buf bytes.Buffer
+	s.Require().NoError(png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))))
+
+	return buf.Bytes()
+}
+
+func (s *UploadProductImageSuite) Test_UploadProductImage() {
+	s.Run("Should reject alternative texts that are too long", func() {
+		s.SetupTest()
+		upload := domain.ImageUpload{ProductID: 1, AltText: strings.Repeat("a", domain.MaxImageAltTextLength+1)}
+
+		_, err := s.app.UploadProductImage(context.Background(), upload, bytes.NewReader(s.pngImage(1, 1)))
+
+		s.Equal(domain.ErrInvalidAltText, err)
+	})
+
+	s.Run("Should return not found error in case the product isn't stored", func() {
+		s.SetupTest()
+		s.productsRepo.
+			On("List", mock.AnythingOfType("*context.valueCtx"), domain.ListProductsFilter{IDs: []int{1}}).
+			Return([]domain.Product{}, nil)
+
+		_, err := s.app.UploadProductImage(context.Background(), domain.ImageUpload{ProductID: 1}, bytes.NewReader(s.pngImage(1, 1)))
+
+		s.Equal(domain.ErrProductNotFound, err)
+	})
+
+	s.Run("Should reject images that are too large without storing anything", func() {
+		s.SetupTest()
+		s.storeProduct(1)
+
+		content := bytes.NewReader(make([]byte, domain.MaxImageSize+1))
+		_, err := s.app.UploadProductImage(context.Background(), domain.ImageUpload{ProductID: 1}, content)
+
+		s.Equal(domain.ErrImageTooLarge, err)
+		s.blobStore.AssertNotCalled(s.T(), "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should reject anything but images", func() {
+		s.SetupTest()
+		s.storeProduct(1)
+
+		_, err := s.app.UploadProductImage(context.Background(), domain.ImageUpload{ProductID: 1}, strings.NewReader("name,price"))
+
+		s.Equal(domain.ErrUnsupportedImageType, err)
+	})
+
+	s.Run("Should store the image along with its thumbnails", func() {
+		s.SetupTest()
+		s.storeProduct(1)
+		data := s.pngImage(400, 200)
+
+		s.blobStore.
+			On("Put", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("string"), mock.Anything, mock.AnythingOfType("int64"), "image/png").
+			Return(nil)
+
+		s.imagesRepo.
+			On("Create", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.ProductImage")).
+			Return(func(_ context.Context, image domain.ProductImage) domain.ProductImage {
+				image.ID = 7
+				return image
+			}, nil)
+
+		got, err := s.app.UploadProductImage(context.Background(), domain.ImageUpload{ProductID: 1, AltText: "Front"}, bytes.NewReader(data))
+
+		s.NoError(err)
+		s.Equal(7, got.ID)
+		s.Equal("image/png", got.ContentType)
+		s.Equal([]int{400, 200, len(data)}, []int{got.Width, got.Height, got.Size})
+		s.Equal("Front", got.AltText)
+		s.True(strings.HasPrefix(got.Key, "products/1/"), got.Key)
+		s.Equal("https://cdn.test/"+got.Key, got.URL)
+
+		s.Require().Len(got.Thumbnails, 1)
+		thumbnail := got.Thumbnails[0]
+		s.Equal("small", thumbnail.Name)
+		s.Equal([]int{100, 50}, []int{thumbnail.Width, thumbnail.Height})
+		s.Equal(strings.TrimSuffix(got.Key, ".png")+"_small.png", thumbnail.Key)
+		s.Equal("https://cdn.test/"+thumbnail.Key, thumbnail.URL)
+
+		s.blobStore.AssertNumberOfCalls(s.T(), "Put", 2)
+	})
+
+	s.Run("Should delete the stored files in case the image can't be created", func() {
+		s.SetupTest()
+		s.storeProduct(1)
+
+		s.blobStore.
+			On("Put", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("string"), mock.Anything, mock.AnythingOfType("int64"), "image/png").
+			Return(nil)
+
+		s.blobStore.
+			On("Delete", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("string")).
+			Return(nil)
+
+		s.imagesRepo.
+			On("Create", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.ProductImage")).
+			Return(domain.ProductImage{}, domain.ErrTooManyImages)
+
+		_, err := s.app.UploadProductImage(context.Background(), domain.ImageUpload{ProductID: 1}, bytes.NewReader(s.pngImage(10, 10)))
+
+		s.Equal(domain.ErrTooManyImages, err)
+		s.blobStore.AssertNumberOfCalls(s.T(), "Delete", 2)
+	})
+
+	s.Run("Should delete the stored files in case a thumbnail can't be stored", func() {
+		s.SetupTest()
+		s.storeProduct(1)
+		storeErr := errors.New("store unavailable")
+
+		s.blobStore.
+			On("Put", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(key string) bool {
+				return !strings.Contains(key, "_small")
+			}), mock.Anything, mock.AnythingOfType("int64"), "image/png").
+			Return(nil)
+
+		s.blobStore.
+			On("Put", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("string"), mock.Anything, mock.AnythingOfType("int64"), "image/png").
+			Return(storeErr)
+
+		s.blobStore.
+			On("Delete", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("string")).
+			Return(nil)
+
+		_, err := s.app.UploadProductImage(context.Background(), domain.ImageUpload{ProductID: 1}, bytes.NewReader(s.pngImage(10, 10)))
+
+		s.Equal(storeErr, err)
+		s.blobStore.AssertNumberOfCalls(s.T(), "Delete", 1)
+		s.imagesRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUploadProductImageSuite(t *testing.T) {
+	suite.Run(t, new(UploadProductImageSuite))
+}
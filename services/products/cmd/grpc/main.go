@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/lucasmls/ecommerce/services/products/adapters/blobstores"
 	"github.com/lucasmls/ecommerce/services/products/adapters/repositories"
 	"github.com/lucasmls/ecommerce/services/products/app"
+	"github.com/lucasmls/ecommerce/services/products/domain"
 	resolvers "github.com/lucasmls/ecommerce/services/products/ports/grpc"
 	protog "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
 	"github.com/lucasmls/ecommerce/shared/env"
@@ -28,6 +30,12 @@ type ApplicationConfig struct {
 	GrpcServerPort           int    `mapstructure:"GRPC_SERVER_PORT"`
 	MetricsPort              int    `mapstructure:"METRICS_PORT"`
 	PostgresConnectionString string `mapstructure:"PG_CONNECTION_STRING"`
+	// ThumbnailSizes are the sizes the thumbnails are generated at, e.g. small=150x150,medium=600x600
+	ThumbnailSizes string `mapstructure:"THUMBNAIL_SIZES"`
+	// AuthTokenSecret verifies the access tokens of the customers, shared with the customers service
+	AuthTokenSecret string `mapstructure:"AUTH_TOKEN_SECRET"`
+
+	Media blobstores.Config `mapstructure:",squash"`
 }
 
 func main() {
@@ -73,8 +81,30 @@ func main() {
 
 	inMemoryProductsRepository := repositories.MustNewInMemoryProductsRepository(logger, tracer, 10)
 	// postgresProductsRepository := repositories.MustNewPgProductsRepository(config.PostgresConnectionString)
+	inMemoryAuditRepository := repositories.NewInMemoryAuditRepository(logger, tracer)
+	// postgresAuditRepository := repositories.MustNewPgAuditRepository(config.PostgresConnectionString)
+
+	inMemoryImagesRepository := repositories.NewInMemoryImagesRepository(logger, tracer)
+	// postgresImagesRepository := repositories.MustNewPgImagesRepository(config.PostgresConnectionString)
+
+	blobStore, err := blobstores.NewFromConfig(logger, tracer, config.Media)
+	if err != nil {
+		logger.Fatal("failed to instantiate the media store", zap.Error(err))
+	}
 
-	application := app.MustNewApplication(logger, tracer, inMemoryProductsRepository)
+	var thumbnailSizes []domain.ThumbnailSize
+	if config.ThumbnailSizes != "" {
+		thumbnailSizes, err = domain.ParseThumbnailSizes(config.ThumbnailSizes)
+		if err != nil {
+			logger.Fatal("failed to parse the thumbnail sizes", zap.Error(err))
+		}
+	}
+
+	application := app.MustNewApplication(logger, tracer, inMemoryProductsRepository, inMemoryAuditRepository, app.MediaInput{
+		ImagesRepository: inMemoryImagesRepository,
+		BlobStore:        blobStore,
+		ThumbnailSizes:   thumbnailSizes,
+	})
 	productsResolver := resolvers.MustNewProductsResolver(logger, tracer, application)
 
 	server := grpc.MustNewServer(grpc.ServerInput{
@@ -83,12 +113,19 @@ func main() {
 		Registrator: func(server gGRPC.ServiceRegistrar) {
 			protog.RegisterProductsServiceServer(server, productsResolver)
 		},
+		TokenSecret: []byte(config.AuthTokenSecret),
 	})
 
 	go func() {
 		port := fmt.Sprintf(":%d", config.MetricsPort)
 
 		http.Handle("/metrics", promhttp.Handler())
+
+		// the images are served along with the metrics, unless they're stored on S3
+		if fileSystemBlobStore, ok := blobStore.(*blobstores.FileSystemBlobStore); ok {
+			http.Handle("/media/", http.StripPrefix("/media", fileSystemBlobStore.Handler()))
+		}
+
 		_ = http.ListenAndServe(port, nil)
 	}()
 
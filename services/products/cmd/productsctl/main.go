@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/products/adapters/clients"
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	cliPort "github.com/lucasmls/ecommerce/services/products/ports/cli"
+	protog "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+	"github.com/lucasmls/ecommerce/shared/actor"
+	"github.com/lucasmls/ecommerce/shared/grpc"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultAddress = "localhost:8081"
+	defaultTimeout = 30 * time.Second
+)
+
+// errUsage tells main the usage was already printed and it must exit with the usage status
+var errUsage = errors.New("invalid usage")
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		if errors.Is(err, errUsage) {
+			os.Exit(2)
+		}
+
+		fail(err)
+	}
+}
+
+// run executes the command described by args, it returns instead of exiting so the deferred
+// cleanups always run
+func run(args []string) error {
+	flags := flag.NewFlagSet("productsctl", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), cliPort.Usage, "\nFlags:\n")
+		flags.PrintDefaults()
+	}
+
+	address := flags.String("addr", envOrDefault("PRODUCTS_GRPC_ADDRESS", defaultAddress), "address of the products gRPC server (env PRODUCTS_GRPC_ADDRESS)")
+	output := flags.String("o", envOrDefault("PRODUCTSCTL_OUTPUT", cliPort.OutputTable), "output format: table, json or yaml (env PRODUCTSCTL_OUTPUT)")
+	actorName := flags.String("actor", envOrDefault("PRODUCTSCTL_ACTOR", os.Getenv("USER")), "who is making the changes, recorded on the product history (env PRODUCTSCTL_ACTOR)")
+	timeout := flags.Duration("timeout", defaultTimeout, "maximum duration of the command")
+	verbose := flags.Bool("v", false, "enable verbose logging")
+
+	_ = flags.Parse(args)
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return errUsage
+	}
+
+	logger := zap.NewNop()
+	if *verbose {
+		logger, _ = zap.NewDevelopment()
+	}
+	defer logger.Sync()
+
+	tracer := trace.NewNoopTracerProvider().Tracer("productsctl")
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	if *actorName != "" {
+		ctx = actor.NewContext(ctx, *actorName)
+	}
+
+	productsServiceConn, err := grpc.MustNewClient(grpc.ClientInput{
+		Address: *address,
+		Logger:  logger,
+	}).Connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer productsServiceConn.Close()
+
+	productsClient := clients.MustNewGrpcProductsClient(
+		logger,
+		tracer,
+		protog.NewProductsServiceClient(productsServiceConn),
+	)
+
+	commands, err := cliPort.NewProductsCommands(cliPort.ProductsCommandsInput{
+		CLI:    productsClient,
+		Stdin:  os.Stdin,
+		Stdout: os.Stdout,
+		Output: *output,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := commands.Run(ctx, flags.Args()); err != nil {
+		if errors.Is(err, cliPort.ErrUnknownCommand) {
+			fmt.Fprintln(os.Stderr, err)
+			flags.Usage()
+			return errUsage
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func envOrDefault(key string, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return fallback
+}
+
+func fail(err error) {
+	if errors.Is(err, domain.ErrProductNotFound) {
+		fmt.Fprintln(os.Stderr, "product not found")
+	} else {
+		fmt.Fprintln(os.Stderr, "error:", err)
+	}
+
+	os.Exit(1)
+}
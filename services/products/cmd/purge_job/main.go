@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/products/adapters/blobstores"
+	"github.com/lucasmls/ecommerce/services/products/adapters/repositories"
+	"github.com/lucasmls/ecommerce/services/products/app"
+	"github.com/lucasmls/ecommerce/shared/env"
+	otel "go.opentelemetry.io/otel"
+	otelJaegerExporter "go.opentelemetry.io/otel/exporters/jaeger"
+	otelSdkResource "go.opentelemetry.io/otel/sdk/resource"
+	otelTraceSdk "go.opentelemetry.io/otel/sdk/trace"
+	otelSemconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.uber.org/zap"
+)
+
+// purgeTimeout bounds a single run of the job
+const purgeTimeout = 10 * time.Minute
+
+type ApplicationConfig struct {
+	ServiceName              string `mapstructure:"SERVICE_NAME"`
+	JaegerEndpoint           string `mapstructure:"JAEGER_ENDPOINT"`
+	PostgresConnectionString string `mapstructure:"PG_CONNECTION_STRING"`
+	// PurgeRetention is how long deleted products stay in the trash, e.g. 720h
+	PurgeRetention time.Duration `mapstructure:"PURGE_RETENTION"`
+
+	Media blobstores.Config `mapstructure:",squash"`
+}
+
+// The purge job permanently removes the products deleted longer than the retention period ago.
+// It runs once and exits, so it's meant to be scheduled, e.g. by a Kubernetes CronJob.
+func main() {
+	ctx, cancel := context.WithTimeout(context.Background(), purgeTimeout)
+	defer cancel()
+
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	config, err := env.LoadConfig[ApplicationConfig]()
+	if err != nil {
+		logger.Fatal("failed to load application config", zap.Error(err))
+	}
+
+	jaegerExporter, err := otelJaegerExporter.New(
+		otelJaegerExporter.WithCollectorEndpoint(
+			otelJaegerExporter.WithEndpoint(config.JaegerEndpoint),
+		),
+	)
+	if err != nil {
+		logger.Fatal("failed to instantiate Jaeger exporter", zap.Error(err))
+	}
+
+	tracingProvider := otelTraceSdk.NewTracerProvider(
+		otelTraceSdk.WithBatcher(jaegerExporter),
+		otelTraceSdk.WithSampler(otelTraceSdk.AlwaysSample()),
+		otelTraceSdk.WithResource(otelSdkResource.NewWithAttributes(
+			otelSemconv.SchemaURL,
+			otelSemconv.ServiceNameKey.String(config.ServiceName),
+		)),
+	)
+
+	defer func() {
+		_ = tracingProvider.Shutdown(context.Background())
+	}()
+
+	otel.SetTracerProvider(tracingProvider)
+	tracer := otel.Tracer(config.ServiceName)
+
+	postgresProductsRepository := repositories.MustNewPgProductsRepository(config.PostgresConnectionString)
+	postgresAuditRepository := repositories.MustNewPgAuditRepository(config.PostgresConnectionString)
+	postgresImagesRepository := repositories.MustNewPgImagesRepository(config.PostgresConnectionString)
+
+	blobStore, err := blobstores.NewFromConfig(logger, tracer, config.Media)
+	if err != nil {
+		logger.Fatal("failed to instantiate the media store", zap.Error(err))
+	}
+
+	application := app.MustNewApplication(logger, tracer, postgresProductsRepository, postgresAuditRepository, app.MediaInput{
+		ImagesRepository: postgresImagesRepository,
+		BlobStore:        blobStore,
+	})
+
+	purged, err := application.PurgeProducts(ctx, config.PurgeRetention)
+	if err != nil {
+		logger.Fatal("failed to purge the deleted products", zap.Error(err))
+	}
+
+	logger.Info("purged the deleted products", zap.Int("purged", purged), zap.Duration("retention", config.PurgeRetention))
+}
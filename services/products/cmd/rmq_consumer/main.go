@@ -66,8 +66,10 @@ func main() {
 	tracer := otel.Tracer("products")
 
 	productsInMemoryRepository := repositories.MustNewInMemoryProductsRepository(logger, tracer, 10)
+	auditInMemoryRepository := repositories.NewInMemoryAuditRepository(logger, tracer)
 
-	application := app.MustNewApplication(logger, tracer, productsInMemoryRepository)
+	// the consumer only registers products, so it never touches their images
+	application := app.MustNewApplication(logger, tracer, productsInMemoryRepository, auditInMemoryRepository, app.MediaInput{})
 
 	rmqProductsConsumer := rmqPort.MustNewProductsConsumer(rmqPort.ProductsConsumerInput{
 		Logger: logger,
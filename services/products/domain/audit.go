@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultProductHistoryLimit is the amount of AuditEntries listed when the filter doesn't set a Limit
+	DefaultProductHistoryLimit = 50
+	// MaxProductHistoryLimit is the maximum amount of AuditEntries listed at once
+	MaxProductHistoryLimit = 500
+)
+
+var (
+	ErrInvalidHistoryLimit = errors.New("invalid-history-limit")
+)
+
+// AuditAction names the operation that changed a Product
+type AuditAction string
+
+const (
+	AuditActionRegister AuditAction = "register"
+	AuditActionUpdate   AuditAction = "update"
+	AuditActionPatch    AuditAction = "patch"
+	AuditActionDelete   AuditAction = "delete"
+	AuditActionRestore  AuditAction = "restore"
+)
+
+// AuditEntry records a change made to a Product
+type AuditEntry struct {
+	ID        int
+	ProductID int
+	Action    AuditAction
+	// Actor identifies who made the change, it's "unknown" when the request didn't tell
+	Actor string
+	// Before is the Product as it was before the change, nil when it was registered
+	Before *Product
+	// After is the Product as it was left by the change
+	After *Product
+	// TraceID links the change to the trace of the request that made it, if it was traced
+	TraceID   string
+	CreatedAt time.Time
+}
+
+// ProductHistoryFilter represents a filter passed to GetProductHistory, entries are listed newest first
+type ProductHistoryFilter struct {
+	ProductID int
+	// BeforeID is the cursor, only AuditEntries with a lower ID are listed when it's set
+	BeforeID int
+	// Limit is the maximum amount of AuditEntries listed, it defaults to DefaultProductHistoryLimit
+	Limit int
+}
+
+// Validate checks the Limit of the filter
+func (f ProductHistoryFilter) Validate() error {
+	if f.Limit < 0 || f.Limit > MaxProductHistoryLimit {
+		return ErrInvalidHistoryLimit
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CatalogFormat is the file format of a catalog import or export
+type CatalogFormat string
+
+const (
+	CatalogFormatCSV    CatalogFormat = "csv"
+	CatalogFormatNDJSON CatalogFormat = "ndjson"
+)
+
+// ImportMode defines how imported rows are matched against the stored Products
+type ImportMode string
+
+const (
+	// ImportModeCreate registers every row as a new Product, rows matching a stored Product fail
+	ImportModeCreate ImportMode = "create"
+	// ImportModeUpsertByID updates the Product with the row ID, rows without an ID are created
+	ImportModeUpsertByID ImportMode = "upsert-by-id"
+	// ImportModeUpsertBySKU updates the Product with the row SKU, creating it when it isn't stored
+	ImportModeUpsertBySKU ImportMode = "upsert-by-sku"
+)
+
+var (
+	ErrInvalidCatalogFormat = errors.New("invalid-catalog-format")
+	ErrInvalidImportMode    = errors.New("invalid-import-mode")
+	ErrDuplicatedImportKey  = errors.New("duplicated-import-key")
+
+	ErrMissingCatalogColumn    = errors.New("missing-catalog-column")
+	ErrUnknownCatalogColumn    = errors.New("unknown-catalog-column")
+	ErrDuplicatedCatalogColumn = errors.New("duplicated-catalog-column")
+)
+
+// ImportOptions configures a catalog import
+type ImportOptions struct {
+	Format CatalogFormat
+	Mode   ImportMode
+	// DryRun validates and matches every row without storing anything
+	DryRun bool
+}
+
+// Validate checks the import options
+func (o ImportOptions) Validate() error {
+	if err := o.Format.Validate(); err != nil {
+		return err
+	}
+
+	switch o.Mode {
+	case ImportModeCreate, ImportModeUpsertByID, ImportModeUpsertBySKU:
+		return nil
+	default:
+		return ErrInvalidImportMode
+	}
+}
+
+// Validate checks the catalog format
+func (f CatalogFormat) Validate() error {
+	switch f {
+	case CatalogFormatCSV, CatalogFormatNDJSON:
+		return nil
+	default:
+		return ErrInvalidCatalogFormat
+	}
+}
+
+// ImportRow is a single Product read from a catalog file
+type ImportRow struct {
+	// Line is the 1-based line of the row on the imported file
+	Line    int
+	Product Product
+	// Err is set when the row couldn't be parsed
+	Err error
+}
+
+// ImportRowError is an imported row that couldn't be stored
+type ImportRowError struct {
+	Line    int
+	Product Product
+	Err     error
+}
+
+func (e ImportRowError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+func (e ImportRowError) Unwrap() error {
+	return e.Err
+}
+
+// ImportReport summarizes a catalog import
+// On dry-runs Created and Updated count what would have been stored
+type ImportReport struct {
+	DryRun   bool
+	Created  int
+	Updated  int
+	Failed   int
+	Failures []ImportRowError
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"io"
+	"time"
+)
 
 // Application defines boundary interfaces of the application
 // It should be called by the ports
@@ -11,38 +15,196 @@ type Application interface {
 	// RegisterProduct registers a new Product
 	RegisterProduct(context.Context, Product) (Product, error)
 
-	// UpdateProduct updates a Product
+	// UpdateProduct updates a Product, failing with ErrVersionConflict if its Version is stale
 	UpdateProduct(context.Context, Product) (Product, error)
 
-	// DeleteProduct deletes a Product
+	// PatchProduct changes only the fields listed on the patch, leaving the others untouched
+	PatchProduct(context.Context, ProductPatch) (Product, error)
+
+	// DeleteProduct moves a Product to the trash
 	DeleteProduct(context.Context, int) error
+
+	// RestoreProduct brings a deleted Product back from the trash
+	RestoreProduct(context.Context, int) (Product, error)
+
+	// GetProductHistory lists the changes made to a Product, newest first
+	GetProductHistory(context.Context, ProductHistoryFilter) ([]AuditEntry, error)
+
+	// SearchProducts finds the Products matching a full-text search, the most relevant first
+	SearchProducts(context.Context, ProductSearch) ([]ProductSearchResult, error)
+
+	// GetProductFacets counts the Products matching a filter by their price range, category and attribute values
+	GetProductFacets(context.Context, FacetsFilter) (ProductFacets, error)
+
+	// UploadProductImage stores an image of a Product, along with its thumbnails, placing it after the other ones
+	UploadProductImage(context.Context, ImageUpload, io.Reader) (ProductImage, error)
+
+	// ListProductImages lists the images of the given Products, ordered by Product and then by Position
+	ListProductImages(context.Context, []int) ([]ProductImage, error)
+
+	// DeleteProductImage removes an image, along with its thumbnails
+	DeleteProductImage(context.Context, int) error
+
+	// ReorderProductImages places the images of a Product in the given order of IDs
+	ReorderProductImages(context.Context, int, []int) ([]ProductImage, error)
+
+	// PurgeProducts permanently removes the Products deleted longer than the retention ago, along with
+	// their images, returning how many were removed
+	PurgeProducts(context.Context, time.Duration) (int, error)
+
+	// RegisterProducts registers a batch of Products, reporting the outcome of each one
+	RegisterProducts(context.Context, []Product) ([]ProductResult, error)
+
+	// ExportProducts fetches a page of Products ordered by ID, starting after the given cursor
+	ExportProducts(context.Context, ExportProductsFilter) ([]Product, error)
+
+	// ImportCatalog validates and stores every Product of a catalog file
+	ImportCatalog(context.Context, io.Reader, ImportOptions) (ImportReport, error)
+
+	// ExportCatalog writes every Product, ordered by ID, as a catalog file
+	ExportCatalog(context.Context, io.Writer, CatalogFormat) error
 }
 
 // CLI defines the boundary interfaces of the application
 // It should be called by the ports
 type CLI interface {
+	// ListProducts fetches the list of Products
+	ListProducts(context.Context, ListProductsFilter) ([]Product, error)
+
+	// GetProduct fetches a single Product
+	GetProduct(context.Context, int) (Product, error)
+
 	// RegisterProduct registers a new Product
 	RegisterProduct(context.Context, Product) (Product, error)
 
-	// DeleteProduct deletes a Product
+	// UpdateProduct updates a Product, failing with ErrVersionConflict if its Version is stale
+	UpdateProduct(context.Context, Product) (Product, error)
+
+	// PatchProduct changes only the fields listed on the patch, leaving the others untouched
+	PatchProduct(context.Context, ProductPatch) (Product, error)
+
+	// DeleteProduct moves a Product to the trash
 	DeleteProduct(context.Context, int) error
+
+	// RestoreProduct brings a deleted Product back from the trash
+	RestoreProduct(context.Context, int) (Product, error)
+
+	// GetProductHistory lists the changes made to a Product, newest first
+	GetProductHistory(context.Context, ProductHistoryFilter) ([]AuditEntry, error)
+
+	// SearchProducts finds the Products matching a full-text search, the most relevant first
+	SearchProducts(context.Context, ProductSearch) ([]ProductSearchResult, error)
+
+	// GetProductFacets counts the Products matching a filter by their price range, category and attribute values
+	GetProductFacets(context.Context, FacetsFilter) (ProductFacets, error)
+
+	// UploadProductImage stores an image of a Product, along with its thumbnails, placing it after the other ones
+	UploadProductImage(context.Context, ImageUpload, io.Reader) (ProductImage, error)
+
+	// ListProductImages lists the images of the given Products, ordered by Product and then by Position
+	ListProductImages(context.Context, []int) ([]ProductImage, error)
+
+	// DeleteProductImage removes an image, along with its thumbnails
+	DeleteProductImage(context.Context, int) error
+
+	// ReorderProductImages places the images of a Product in the given order of IDs
+	ReorderProductImages(context.Context, int, []int) ([]ProductImage, error)
+
+	// RegisterProducts registers a batch of Products, reporting the outcome of each one
+	RegisterProducts(context.Context, []Product) ([]ProductResult, error)
+
+	// ExportProducts calls the given function for every Product, ordered by ID
+	ExportProducts(context.Context, func(Product) error) error
+
+	// ImportCatalog validates and stores every Product of a catalog file
+	ImportCatalog(context.Context, io.Reader, ImportOptions) (ImportReport, error)
+
+	// ExportCatalog writes every Product, ordered by ID, as a catalog file
+	ExportCatalog(context.Context, io.Writer, CatalogFormat) error
 }
 
 type ProductsRepository interface {
 	// Create creates a new Product in a data storage.
 	Create(context.Context, Product) (Product, error)
 
-	// Update updates a Product in a data storage.
+	// Update updates a Product in a data storage, incrementing its Version.
+	// The Version check and the write must happen atomically.
 	Update(context.Context, Product) (Product, error)
 
-	// Delete deletes a Product from a data storage.
+	// Patch writes only the fields listed on the patch, with the same guarantees of Update.
+	Patch(context.Context, ProductPatch) (Product, error)
+
+	// Delete soft-deletes a Product, setting its DeletedAt and incrementing its Version.
+	// Deleted Products are left out of every other operation but List and Restore.
 	Delete(context.Context, int) error
 
-	// List all Products from a data storage.
+	// Restore clears the DeletedAt of a deleted Product, incrementing its Version.
+	Restore(context.Context, int) (Product, error)
+
+	// Purge permanently removes the Products deleted before the given time, returning how many were removed.
+	Purge(context.Context, time.Time) (int, error)
+
+	// List all Products from a data storage matching the filter, leaving the deleted ones out unless the filter asks for them.
+	// They're ordered by the Sort of the filter and then by ID.
 	List(context.Context, ListProductsFilter) ([]Product, error)
+
+	// CreateMany creates a batch of Products in a data storage.
+	// A failure to store one Product doesn't prevent the others from being stored.
+	CreateMany(context.Context, []Product) ([]ProductResult, error)
+
+	// ListPage lists a page of the Products that aren't deleted from a data storage ordered by ID.
+	ListPage(context.Context, ExportProductsFilter) ([]Product, error)
+
+	// Search finds the Products that aren't deleted matching every term of the search, ordered by the Sort
+	// of the search and then by ID. The Limit and Sort of the search are always set, and the Highlights of
+	// the results are left to the caller.
+	Search(context.Context, ProductSearch) ([]ProductSearchResult, error)
+
+	// Facets counts the Products that aren't deleted matching the filter, with the buckets sorted as by SortFacets.
+	// The PriceBoundaries of the filter are always set.
+	Facets(context.Context, FacetsFilter) (ProductFacets, error)
+}
+
+type AuditRepository interface {
+	// Record stores an AuditEntry in a data storage, setting its ID.
+	Record(context.Context, AuditEntry) (AuditEntry, error)
+
+	// ListByProduct lists the AuditEntries of a Product from a data storage, newest first.
+	ListByProduct(context.Context, ProductHistoryFilter) ([]AuditEntry, error)
 }
 
 // ListProductsFilter represents a filter passed to List
 type ListProductsFilter struct {
-	IDs []int
+	ProductFilter
+	IDs  []int
+	SKUs []string
+	// IncludeDeleted lists the deleted Products along with the others
+	IncludeDeleted bool
+	// OnlyDeleted lists only the deleted Products, that is, the trash
+	OnlyDeleted bool
+	// Sort orders the Products, they're ordered by ID when it's empty
+	Sort []SortKey
+}
+
+// Validate checks the price range and the sort of the filter
+func (f ListProductsFilter) Validate() error {
+	if err := f.ProductFilter.Validate(); err != nil {
+		return err
+	}
+
+	return ValidateSort(f.Sort, false)
+}
+
+// ExportProductsFilter represents a filter passed to ListPage
+type ExportProductsFilter struct {
+	// AfterID is the cursor, only Products with a greater ID are returned
+	AfterID int
+	// Limit is the maximum amount of Products returned
+	Limit int
+}
+
+// ProductResult represents the outcome of a single Product of a batch operation
+type ProductResult struct {
+	Product Product
+	Err     error
 }
@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+)
+
+// MaxPriceBoundaries is the maximum amount of boundaries splitting the prices into ranges
+const MaxPriceBoundaries = 20
+
+// DefaultPriceBoundaries split the prices into the ranges under $50, $50 to $100, $100 to $500,
+// $500 to $1000 and above $1000, in minor currency units (cents)
+var DefaultPriceBoundaries = []int{5000, 10000, 50000, 100000}
+
+var (
+	ErrInvalidPriceRange      = errors.New("invalid-price-range")
+	ErrInvalidPriceBoundaries = errors.New("invalid-price-boundaries")
+)
+
+// ProductFilter narrows the Products down by their category, price and attributes.
+// Its zero value matches every Product.
+type ProductFilter struct {
+	// Category matches only the Products of the given category
+	Category string
+	// PriceFrom is the inclusive lower bound of the price
+	PriceFrom int
+	// PriceTo is the exclusive upper bound of the price, zero meaning there's none
+	PriceTo int
+	// Attributes matches only the Products having every one of the given attribute values
+	Attributes map[string]string
+}
+
+// Validate checks the price range of the filter
+func (f ProductFilter) Validate() error {
+	if f.PriceFrom < 0 || f.PriceTo < 0 || (f.PriceTo > 0 && f.PriceTo <= f.PriceFrom) {
+		return ErrInvalidPriceRange
+	}
+
+	return nil
+}
+
+// Matches reports whether the Product passes the filter
+func (f ProductFilter) Matches(product Product) bool {
+	if f.Category != "" && product.Category != f.Category {
+		return false
+	}
+
+	if product.Price < f.PriceFrom || (f.PriceTo > 0 && product.Price >= f.PriceTo) {
+		return false
+	}
+
+	for name, value := range f.Attributes {
+		if actual, ok := product.Attributes[name]; !ok || actual != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FacetsFilter represents a filter passed to Facets
+type FacetsFilter struct {
+	ProductFilter
+	// PriceBoundaries split the prices into the ranges [0, b1), [b1, b2) ... [bn, ∞),
+	// they default to DefaultPriceBoundaries
+	PriceBoundaries []int
+}
+
+// Validate checks the filter and that the PriceBoundaries are positive and strictly increasing
+func (f FacetsFilter) Validate() error {
+	if err := f.ProductFilter.Validate(); err != nil {
+		return err
+	}
+
+	if len(f.PriceBoundaries) > MaxPriceBoundaries {
+		return ErrInvalidPriceBoundaries
+	}
+
+	previous := 0
+	for _, boundary := range f.PriceBoundaries {
+		if boundary <= previous {
+			return ErrInvalidPriceBoundaries
+		}
+
+		previous = boundary
+	}
+
+	return nil
+}
+
+// ProductFacets counts the Products matching a filter by their price range, category and attribute values
+type ProductFacets struct {
+	// Total is the amount of Products matching the filter
+	Total int
+	// PriceRanges has one bucket per range, in ascending order, even the empty ones
+	PriceRanges []PriceRangeBucket
+	// Categories has the categorized Products by category, the most common first
+	Categories []FacetBucket
+	// Attributes has one facet per attribute name, in alphabetical order
+	Attributes []AttributeFacet
+}
+
+// PriceRangeBucket counts the Products priced from From, inclusive, to To, exclusive.
+// A zero To means the range has no upper bound.
+type PriceRangeBucket struct {
+	From  int
+	To    int
+	Count int
+}
+
+// FacetBucket counts the Products having a value
+type FacetBucket struct {
+	Value string
+	Count int
+}
+
+// AttributeFacet counts the Products by the values of an attribute, the most common first
+type AttributeFacet struct {
+	Name   string
+	Values []FacetBucket
+}
+
+// PriceRanges builds the empty buckets of the ranges split by the boundaries
+func PriceRanges(boundaries []int) []PriceRangeBucket {
+	buckets := make([]PriceRangeBucket, 0, len(boundaries)+1)
+
+	from := 0
+	for _, boundary := range boundaries {
+		buckets = append(buckets, PriceRangeBucket{From: from, To: boundary})
+		from = boundary
+	}
+
+	return append(buckets, PriceRangeBucket{From: from})
+}
+
+// AggregateFacets counts the Products by their price range, category and attribute values
+func AggregateFacets(products []Product, boundaries []int) ProductFacets {
+	facets := ProductFacets{
+		Total:       len(products),
+		PriceRanges: PriceRanges(boundaries),
+	}
+
+	categories := map[string]int{}
+	attributes := map[string]map[string]int{}
+	for _, product := range products {
+		// the boundaries are sorted, so the range is the amount of them not above the price
+		bucket := sort.Search(len(boundaries), func(i int) bool {
+			return boundaries[i] > product.Price
+		})
+		facets.PriceRanges[bucket].Count++
+
+		if product.Category != "" {
+			categories[product.Category]++
+		}
+
+		for name, value := range product.Attributes {
+			if attributes[name] == nil {
+				attributes[name] = map[string]int{}
+			}
+
+			attributes[name][value]++
+		}
+	}
+
+	facets.Categories = toFacetBuckets(categories)
+
+	facets.Attributes = make([]AttributeFacet, 0, len(attributes))
+	for name, values := range attributes {
+		facets.Attributes = append(facets.Attributes, AttributeFacet{
+			Name:   name,
+			Values: toFacetBuckets(values),
+		})
+	}
+
+	SortFacets(&facets)
+
+	return facets
+}
+
+// SortFacets orders the buckets of the facets, the most common values first and
+// then alphabetically, and the attributes by name
+func SortFacets(facets *ProductFacets) {
+	sortFacetBuckets(facets.Categories)
+
+	for _, attribute := range facets.Attributes {
+		sortFacetBuckets(attribute.Values)
+	}
+
+	sort.Slice(facets.Attributes, func(i, j int) bool {
+		return facets.Attributes[i].Name < facets.Attributes[j].Name
+	})
+}
+
+func toFacetBuckets(counts map[string]int) []FacetBucket {
+	buckets := make([]FacetBucket, 0, len(counts))
+	for value, count := range counts {
+		buckets = append(buckets, FacetBucket{Value: value, Count: count})
+	}
+
+	return buckets
+}
+
+func sortFacetBuckets(buckets []FacetBucket) {
+	sort.Slice(buckets, func(i, j int) bool {
+		if buckets[i].Count != buckets[j].Count {
+			return buckets[i].Count > buckets[j].Count
+		}
+
+		return buckets[i].Value < buckets[j].Value
+	})
+}
@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxImageSize is the maximum size of an uploaded image, in bytes
+	MaxImageSize = 10 << 20
+	// MaxImagePixels is the maximum amount of pixels of an uploaded image, it guards against images
+	// that are small once compressed but huge once decoded
+	MaxImagePixels = 40_000_000
+	// MaxProductImages is the maximum amount of images a Product may have
+	MaxProductImages = 20
+	// MaxImageAltTextLength is the maximum amount of characters of an image alternative text
+	MaxImageAltTextLength = 255
+)
+
+var (
+	ErrImageNotFound        = errors.New("image-not-found")
+	ErrImageTooLarge        = errors.New("image-too-large")
+	ErrUnsupportedImageType = errors.New("unsupported-image-type")
+	ErrInvalidImage         = errors.New("invalid-image")
+	ErrTooManyImages        = errors.New("too-many-product-images")
+	ErrInvalidImageOrder    = errors.New("invalid-image-order")
+	ErrInvalidAltText       = errors.New("invalid-image-alt-text")
+	ErrInvalidThumbnailSize = errors.New("invalid-thumbnail-size")
+	ErrBlobNotFound         = errors.New("blob-not-found")
+)
+
+// ProductImage is an image of a Product, stored on a BlobStore along with its thumbnails.
+type ProductImage struct {
+	ID        int
+	ProductID int
+	// Position orders the images of a Product, the first one, at 0, being its main image.
+	Position int
+	// Key identifies the original image on the BlobStore.
+	Key         string
+	ContentType string
+	Width       int
+	Height      int
+	// Size of the original image, in bytes.
+	Size    int
+	AltText string
+	// Thumbnails are the image scaled down to each of the configured ThumbnailSizes.
+	Thumbnails []Thumbnail
+	CreatedAt  time.Time
+	// URL is where the original image is served from, it's set by the Application and never stored.
+	URL string
+}
+
+// Thumbnail is a ProductImage scaled down to fit within a ThumbnailSize, keeping its aspect ratio.
+type Thumbnail struct {
+	// Name is the name of the ThumbnailSize it was generated for.
+	Name   string
+	Key    string
+	Width  int
+	Height int
+	// URL is where the thumbnail is served from, it's set by the Application and never stored.
+	URL string
+}
+
+// ThumbnailSize is one of the sizes the thumbnails of the images are generated at
+type ThumbnailSize struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+// DefaultThumbnailSizes are used when no other sizes are configured
+var DefaultThumbnailSizes = []ThumbnailSize{
+	{Name: "small", Width: 150, Height: 150},
+	{Name: "medium", Width: 600, Height: 600},
+}
+
+// ParseThumbnailSizes parses comma separated name=WIDTHxHEIGHT sizes, as in small=150x150,medium=600x600
+func ParseThumbnailSizes(spec string) ([]ThumbnailSize, error) {
+	var sizes []ThumbnailSize
+	seen := map[string]bool{}
+	for _, rawSize := range strings.Split(spec, ",") {
+		name, dimensions, ok := strings.Cut(strings.TrimSpace(rawSize), "=")
+		rawWidth, rawHeight, ok2 := strings.Cut(dimensions, "x")
+		width, widthErr := strconv.Atoi(rawWidth)
+		height, heightErr := strconv.Atoi(rawHeight)
+		if !ok || !ok2 || name == "" || widthErr != nil || heightErr != nil || width <= 0 || height <= 0 {
+			return nil, fmt.Errorf("%w: %q must be name=WIDTHxHEIGHT", ErrInvalidThumbnailSize, rawSize)
+		}
+
+		if seen[name] {
+			return nil, fmt.Errorf("%w: %s is defined more than once", ErrInvalidThumbnailSize, name)
+		}
+
+		seen[name] = true
+		sizes = append(sizes, ThumbnailSize{Name: name, Width: width, Height: height})
+	}
+
+	return sizes, nil
+}
+
+// ImageUpload describes an image being uploaded to a Product
+type ImageUpload struct {
+	ProductID int
+	AltText   string
+}
+
+// Validate checks the upload targets a Product and the length of its alternative text
+func (u ImageUpload) Validate() error {
+	if u.ProductID <= 0 {
+		return ErrProductNotFound
+	}
+
+	if utf8.RuneCountInString(u.AltText) > MaxImageAltTextLength {
+		return ErrInvalidAltText
+	}
+
+	return nil
+}
+
+type ImagesRepository interface {
+	// Create stores a ProductImage in a data storage, setting its ID and placing it after the other images
+	// of the Product. It fails with ErrTooManyImages if the Product already has MaxProductImages.
+	Create(context.Context, ProductImage) (ProductImage, error)
+
+	// Get fetches a single ProductImage from a data storage.
+	Get(context.Context, int) (ProductImage, error)
+
+	// ListByProducts lists the ProductImages of the given Products from a data storage,
+	// ordered by Product and then by Position.
+	ListByProducts(context.Context, []int) ([]ProductImage, error)
+
+	// Delete removes a ProductImage from a data storage, moving the images after it one position up.
+	Delete(context.Context, int) error
+
+	// Reorder places the images of a Product in the given order of IDs, which must list every one of them
+	// exactly once, failing with ErrInvalidImageOrder otherwise.
+	Reorder(context.Context, int, []int) ([]ProductImage, error)
+
+	// DeleteByProduct removes every ProductImage of a Product from a data storage, returning them.
+	DeleteByProduct(context.Context, int) ([]ProductImage, error)
+}
+
+// BlobStore stores the image files, or any other binary large object, by key
+type BlobStore interface {
+	// Put stores the content read from the reader, of the given size and content type, under the key,
+	// replacing any previous one.
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+
+	// Get opens the content stored under the key, failing with ErrBlobNotFound if there's none.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the content stored under the key, succeeding if there's none.
+	Delete(ctx context.Context, key string) error
+
+	// URL returns where the content stored under the key is served from.
+	URL(key string) string
+}
@@ -1,15 +1,301 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxProductNameLength is the maximum amount of characters of a Product name
+	MaxProductNameLength = 255
+	// MaxProductDescriptionLength is the maximum amount of characters of a Product description
+	MaxProductDescriptionLength = 5000
+	// MaxProductCategoryLength is the maximum amount of characters of a Product category
+	MaxProductCategoryLength = 64
+	// MaxProductAttributes is the maximum amount of attributes a Product may have
+	MaxProductAttributes = 32
+	// MaxProductAttributeNameLength is the maximum amount of characters of a Product attribute name
+	MaxProductAttributeNameLength = 64
+	// MaxProductAttributeValueLength is the maximum amount of characters of a Product attribute value
+	MaxProductAttributeValueLength = 255
+	// MaxProductTaxClassLength is the maximum amount of characters of a Product tax class
+	MaxProductTaxClassLength = 32
+	// MaxProductWeight is the maximum weight of a Product, in grams
+	MaxProductWeight = 1_000_000
+	// MaxProductDimension is the maximum length, width or height of a Product, in millimeters
+	MaxProductDimension = 10_000
+)
 
 // Product represents a product in the system.
 type Product struct {
 	ID          int
+	SKU         string
 	Name        string
 	Description string
 	Price       int
+	// Category groups similar Products, an empty one means the Product isn't categorized.
+	Category string
+	// Attributes are the free-form characteristics of the Product, such as its color or size, by name.
+	Attributes map[string]string
+	// TaxClass picks the tax rates that apply to the Product, such as reduced, an empty one means the standard rates.
+	TaxClass string
+	// Weight is how much the packed Product weighs, in grams, a zero one means it's unknown.
+	Weight int
+	// Dimensions are the ones of the packed Product, zero ones mean they're unknown.
+	Dimensions Dimensions
+	// Version is incremented on every update, starting at 1 when the Product is created.
+	// Updates must carry the Version they're based on, they only succeed if it still matches the stored one.
+	Version int
+	// DeletedAt is set when the Product is deleted, a zero value means it isn't.
+	// Deleted Products are kept, and can be restored, until they're purged.
+	DeletedAt time.Time
+}
+
+// Dimensions are the length, width and height of a box, in millimeters
+type Dimensions struct {
+	Length int
+	Width  int
+	Height int
+}
+
+// Zero reports whether the Dimensions are unknown
+func (d Dimensions) Zero() bool {
+	return d == Dimensions{}
+}
+
+// Deleted reports whether the Product is in the trash
+func (p Product) Deleted() bool {
+	return !p.DeletedAt.IsZero()
 }
 
 var (
-	ErrProductNotFound = errors.New("product-not-found")
+	ErrProductNotFound         = errors.New("product-not-found")
+	ErrProductAlreadyExists    = errors.New("product-already-exists")
+	ErrProductSKUAlreadyExists = errors.New("product-sku-already-exists")
+	ErrVersionConflict         = errors.New("product-version-conflict")
+	ErrMissingProductVersion   = errors.New("missing-product-version")
+	ErrEmptyProductPatch       = errors.New("empty-product-patch")
+	ErrInvalidProductField     = errors.New("invalid-product-field")
+	ErrProductNotDeleted       = errors.New("product-not-deleted")
+	ErrInvalidPurgeRetention   = errors.New("invalid-purge-retention")
+)
+
+// ProductField names a Product field that can be changed by a ProductPatch
+type ProductField string
+
+const (
+	ProductFieldSKU         ProductField = "sku"
+	ProductFieldName        ProductField = "name"
+	ProductFieldDescription ProductField = "description"
+	ProductFieldPrice       ProductField = "price"
+	ProductFieldCategory    ProductField = "category"
+	ProductFieldAttributes  ProductField = "attributes"
+	ProductFieldTaxClass    ProductField = "tax_class"
+	ProductFieldWeight      ProductField = "weight"
+	ProductFieldDimensions  ProductField = "dimensions"
 )
+
+// ParseProductField converts a field name into a ProductField
+func ParseProductField(name string) (ProductField, error) {
+	switch field := ProductField(name); field {
+	case ProductFieldSKU, ProductFieldName, ProductFieldDescription, ProductFieldPrice,
+		ProductFieldCategory, ProductFieldAttributes, ProductFieldTaxClass, ProductFieldWeight, ProductFieldDimensions:
+		return field, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidProductField, name)
+	}
+}
+
+// ProductPatch changes only the listed Fields of a stored Product, copying them from Product.
+// Product.ID identifies the stored Product and Product.Version, which is required, guards the change.
+type ProductPatch struct {
+	Product Product
+	Fields  []ProductField
+}
+
+// Validate checks that the patch lists at least one field and that the listed fields are valid
+func (p ProductPatch) Validate() error {
+	if len(p.Fields) == 0 {
+		return ErrEmptyProductPatch
+	}
+
+	listed := make(map[string]bool, len(p.Fields))
+	for _, field := range p.Fields {
+		if _, err := ParseProductField(string(field)); err != nil {
+			return err
+		}
+
+		listed[string(field)] = true
+	}
+
+	err := p.Product.Validate()
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+
+	var violations []FieldViolation
+	for _, violation := range validationErr.Violations {
+		// the violations of an attribute are named after it, as in attributes.color
+		if listed[strings.SplitN(violation.Field, ".", 2)[0]] {
+			violations = append(violations, violation)
+		}
+	}
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+
+	return nil
+}
+
+// Apply returns a copy of product with the patched fields
+func (p ProductPatch) Apply(product Product) Product {
+	for _, field := range p.Fields {
+		switch field {
+		case ProductFieldSKU:
+			product.SKU = p.Product.SKU
+		case ProductFieldName:
+			product.Name = p.Product.Name
+		case ProductFieldDescription:
+			product.Description = p.Product.Description
+		case ProductFieldPrice:
+			product.Price = p.Product.Price
+		case ProductFieldCategory:
+			product.Category = p.Product.Category
+		case ProductFieldAttributes:
+			product.Attributes = p.Product.Attributes
+		case ProductFieldTaxClass:
+			product.TaxClass = p.Product.TaxClass
+		case ProductFieldWeight:
+			product.Weight = p.Product.Weight
+		case ProductFieldDimensions:
+			product.Dimensions = p.Product.Dimensions
+		}
+	}
+
+	return product
+}
+
+var skuPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+var taxClassPattern = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+// FieldViolation describes why a single field is invalid
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// ValidationError is returned when one or more fields of an entity are invalid
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	descriptions := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		descriptions = append(descriptions, fmt.Sprintf("%s %s", violation.Field, violation.Description))
+	}
+
+	return "invalid-product: " + strings.Join(descriptions, ", ")
+}
+
+// Validate checks the Product fields, returning a *ValidationError describing every invalid one
+func (p Product) Validate() error {
+	var violations []FieldViolation
+
+	if p.ID < 0 {
+		violations = append(violations, FieldViolation{Field: "id", Description: "must not be negative"})
+	}
+
+	if p.SKU != "" && !skuPattern.MatchString(p.SKU) {
+		violations = append(violations, FieldViolation{Field: "sku", Description: "must have up to 64 letters, digits, dots, dashes or underscores"})
+	}
+
+	if strings.TrimSpace(p.Name) == "" {
+		violations = append(violations, FieldViolation{Field: "name", Description: "must not be empty"})
+	} else if len([]rune(p.Name)) > MaxProductNameLength {
+		violations = append(violations, FieldViolation{Field: "name", Description: fmt.Sprintf("must have at most %d characters", MaxProductNameLength)})
+	}
+
+	if len([]rune(p.Description)) > MaxProductDescriptionLength {
+		violations = append(violations, FieldViolation{Field: "description", Description: fmt.Sprintf("must have at most %d characters", MaxProductDescriptionLength)})
+	}
+
+	if p.Price < 0 {
+		violations = append(violations, FieldViolation{Field: "price", Description: "must not be negative"})
+	}
+
+	if len([]rune(p.Category)) > MaxProductCategoryLength {
+		violations = append(violations, FieldViolation{Field: "category", Description: fmt.Sprintf("must have at most %d characters", MaxProductCategoryLength)})
+	}
+
+	if p.TaxClass != "" && !taxClassPattern.MatchString(p.TaxClass) {
+		violations = append(violations, FieldViolation{Field: "tax_class", Description: fmt.Sprintf("must have up to %d lowercase letters, digits, dashes or underscores", MaxProductTaxClassLength)})
+	}
+
+	if p.Weight < 0 || p.Weight > MaxProductWeight {
+		violations = append(violations, FieldViolation{Field: "weight", Description: fmt.Sprintf("must be between 0 and %d grams", MaxProductWeight)})
+	}
+
+	if !p.Dimensions.Zero() && !p.Dimensions.valid() {
+		violations = append(violations, FieldViolation{Field: "dimensions", Description: fmt.Sprintf("must all be between 1 and %d millimeters", MaxProductDimension)})
+	}
+
+	violations = append(violations, p.validateAttributes()...)
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+
+	return nil
+}
+
+// valid reports whether every one of the Dimensions is within bounds
+func (d Dimensions) valid() bool {
+	for _, dimension := range []int{d.Length, d.Width, d.Height} {
+		if dimension < 1 || dimension > MaxProductDimension {
+			return false
+		}
+	}
+
+	return true
+}
+
+// validateAttributes checks the amount of Attributes and the length of their names and values
+func (p Product) validateAttributes() []FieldViolation {
+	if len(p.Attributes) > MaxProductAttributes {
+		return []FieldViolation{{Field: "attributes", Description: fmt.Sprintf("must have at most %d entries", MaxProductAttributes)}}
+	}
+
+	names := make([]string, 0, len(p.Attributes))
+	for name := range p.Attributes {
+		names = append(names, name)
+	}
+
+	// sorted so the violations are always reported in the same order
+	sort.Strings(names)
+
+	var violations []FieldViolation
+	for _, name := range names {
+		field := "attributes." + name
+
+		if strings.TrimSpace(name) == "" {
+			violations = append(violations, FieldViolation{Field: "attributes", Description: "must not have empty names"})
+		} else if len([]rune(name)) > MaxProductAttributeNameLength {
+			violations = append(violations, FieldViolation{Field: field, Description: fmt.Sprintf("name must have at most %d characters", MaxProductAttributeNameLength)})
+		}
+
+		if len([]rune(p.Attributes[name])) > MaxProductAttributeValueLength {
+			violations = append(violations, FieldViolation{Field: field, Description: fmt.Sprintf("must have at most %d characters", MaxProductAttributeValueLength)})
+		}
+	}
+
+	return violations
+}
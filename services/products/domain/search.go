@@ -0,0 +1,261 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultSearchLimit is the amount of results returned when the search doesn't set a Limit
+	DefaultSearchLimit = 20
+	// MaxSearchLimit is the maximum amount of results returned at once
+	MaxSearchLimit = 100
+	// MaxSearchTerms is the maximum amount of words a search query may have
+	MaxSearchTerms = 10
+
+	// HighlightStart and HighlightEnd wrap the words of a highlighted text matching the search
+	HighlightStart = "<em>"
+	HighlightEnd   = "</em>"
+)
+
+// How a word matching a search term is scored, exact matches rank above prefixes, which rank above typos,
+// and how much the field the word was found in weighs
+const (
+	exactMatchScore  = 1.0
+	prefixMatchScore = 0.75
+	typoMatchScore   = 0.5
+
+	nameWeight        = 2.0
+	descriptionWeight = 1.0
+)
+
+var (
+	ErrEmptySearchQuery   = errors.New("empty-search-query")
+	ErrTooManySearchTerms = errors.New("too-many-search-terms")
+	ErrInvalidSearchLimit = errors.New("invalid-search-limit")
+)
+
+// ProductSearch represents a full-text search over the name and description of the Products.
+// Every word of the Query must match, either exactly, as a prefix or with a few typos.
+type ProductSearch struct {
+	Query string
+	// Limit is the maximum amount of results, it defaults to DefaultSearchLimit
+	Limit int
+	// Offset is the amount of results skipped, to fetch the next pages
+	Offset int
+	// Sort orders the results, it defaults to DefaultSearchSort
+	Sort []SortKey
+}
+
+// DefaultSearchSort orders the search results by relevance, the most relevant first
+var DefaultSearchSort = []SortKey{{Field: SortFieldRelevance, Descending: true}}
+
+// Validate checks the Query has between 1 and MaxSearchTerms words and that the pagination and sort are valid
+func (s ProductSearch) Validate() error {
+	terms := s.Terms()
+	if len(terms) == 0 {
+		return ErrEmptySearchQuery
+	}
+
+	if len(terms) > MaxSearchTerms {
+		return ErrTooManySearchTerms
+	}
+
+	if s.Limit < 0 || s.Limit > MaxSearchLimit || s.Offset < 0 {
+		return ErrInvalidSearchLimit
+	}
+
+	return ValidateSort(s.Sort, true)
+}
+
+// Terms returns the normalized words of the Query
+func (s ProductSearch) Terms() []string {
+	return Tokenize(s.Query)
+}
+
+// ProductSearchResult is a Product matching a ProductSearch, the higher the Score the more relevant it is
+type ProductSearchResult struct {
+	Product    Product
+	Score      float64
+	Highlights ProductHighlights
+}
+
+// ProductHighlights are the Product fields with the words matching the search wrapped
+// by HighlightStart and HighlightEnd
+type ProductHighlights struct {
+	Name        string
+	Description string
+}
+
+// Tokenize splits a text into lowercase words made of letters and digits
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// MatchTerm scores how well a word matches a search term, zero meaning it doesn't.
+// The term matches words equal to it, starting with it, or starting with something at most
+// a couple of typos away from it, how many depending on the term length.
+func MatchTerm(term string, word string) float64 {
+	if term == word {
+		return exactMatchScore
+	}
+
+	if strings.HasPrefix(word, term) {
+		return prefixMatchScore
+	}
+
+	allowedTypos := typoTolerance(term)
+	if allowedTypos > 0 && prefixDistance([]rune(term), []rune(word)) <= allowedTypos {
+		return typoMatchScore
+	}
+
+	return 0
+}
+
+// ScoreProduct scores how relevant a Product is to the search terms, zero meaning
+// that at least one of the terms doesn't match its name nor its description.
+// Matches on the name weigh more than the ones on the description.
+func ScoreProduct(product Product, terms []string) float64 {
+	nameWords := Tokenize(product.Name)
+	descriptionWords := Tokenize(product.Description)
+
+	var score float64
+	for _, term := range terms {
+		termScore := nameWeight*bestMatch(term, nameWords) + descriptionWeight*bestMatch(term, descriptionWords)
+		if termScore == 0 {
+			return 0
+		}
+
+		score += termScore
+	}
+
+	return score
+}
+
+// HighlightProduct wraps the words of the Product name and description matching the search terms
+func HighlightProduct(product Product, terms []string) ProductHighlights {
+	return ProductHighlights{
+		Name:        Highlight(product.Name, terms),
+		Description: Highlight(product.Description, terms),
+	}
+}
+
+// Highlight wraps the words of the text matching any of the search terms by HighlightStart and HighlightEnd
+func Highlight(text string, terms []string) string {
+	var highlighted strings.Builder
+
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+
+		word := text[start:end]
+		if matchesAny(strings.ToLower(word), terms) {
+			highlighted.WriteString(HighlightStart + word + HighlightEnd)
+		} else {
+			highlighted.WriteString(word)
+		}
+
+		start = -1
+	}
+
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+
+			continue
+		}
+
+		flush(i)
+		highlighted.WriteRune(r)
+	}
+
+	flush(len(text))
+
+	return highlighted.String()
+}
+
+// bestMatch returns the best score of the term against the words
+func bestMatch(term string, words []string) float64 {
+	var best float64
+	for _, word := range words {
+		if score := MatchTerm(term, word); score > best {
+			best = score
+		}
+	}
+
+	return best
+}
+
+// matchesAny reports whether any of the terms matches the word
+func matchesAny(word string, terms []string) bool {
+	for _, term := range terms {
+		if MatchTerm(term, word) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// typoTolerance is how many typos a term may have, short terms must be typed right
+func typoTolerance(term string) int {
+	switch length := utf8.RuneCountInString(term); {
+	case length >= 8:
+		return 2
+	case length >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// prefixDistance is the smallest edit distance between the term and any prefix of the word
+func prefixDistance(term []rune, word []rune) int {
+	previous := make([]int, len(word)+1)
+	current := make([]int, len(word)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(term); i++ {
+		current[0] = i
+		for j := 1; j <= len(word); j++ {
+			cost := 1
+			if term[i-1] == word[j-1] {
+				cost = 0
+			}
+
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+
+		previous, current = current, previous
+	}
+
+	distance := previous[0]
+	for _, d := range previous {
+		if d < distance {
+			distance = d
+		}
+	}
+
+	return distance
+}
+
+func minInt(values ...int) int {
+	smallest := values[0]
+	for _, value := range values[1:] {
+		if value < smallest {
+			smallest = value
+		}
+	}
+
+	return smallest
+}
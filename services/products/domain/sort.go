@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SortField names what the Products can be sorted by
+type SortField string
+
+const (
+	SortFieldName      SortField = "name"
+	SortFieldPrice     SortField = "price"
+	SortFieldCreatedAt SortField = "created_at"
+	SortFieldUpdatedAt SortField = "updated_at"
+	// SortFieldRelevance sorts by the Score of the search results, so only searches can use it
+	SortFieldRelevance SortField = "relevance"
+)
+
+var ErrInvalidSort = errors.New("invalid-sort")
+
+// SortKey is one of the keys the Products are sorted by, the first ones taking precedence
+type SortKey struct {
+	Field      SortField
+	Descending bool
+}
+
+// String formats the key as field:asc or field:desc
+func (k SortKey) String() string {
+	if k.Descending {
+		return string(k.Field) + ":desc"
+	}
+
+	return string(k.Field) + ":asc"
+}
+
+// ParseSortKey converts a field optionally followed by :asc or :desc, the default, into a SortKey
+func ParseSortKey(spec string) (SortKey, error) {
+	name, direction, _ := strings.Cut(strings.TrimSpace(spec), ":")
+
+	key := SortKey{Field: SortField(name)}
+	switch direction {
+	case "", "asc":
+	case "desc":
+		key.Descending = true
+	default:
+		return SortKey{}, fmt.Errorf("%w: unknown direction %q", ErrInvalidSort, direction)
+	}
+
+	return key, nil
+}
+
+// ValidateSort checks the keys sort by known fields, at most once each.
+// Relevance is only allowed when the Products are being searched.
+func ValidateSort(keys []SortKey, search bool) error {
+	seen := make(map[SortField]bool, len(keys))
+	for _, key := range keys {
+		switch key.Field {
+		case SortFieldName, SortFieldPrice, SortFieldCreatedAt, SortFieldUpdatedAt:
+		case SortFieldRelevance:
+			if !search {
+				return fmt.Errorf("%w: %s is only allowed when searching", ErrInvalidSort, key.Field)
+			}
+		default:
+			return fmt.Errorf("%w: unknown field %q", ErrInvalidSort, key.Field)
+		}
+
+		if seen[key.Field] {
+			return fmt.Errorf("%w: %s is sorted by more than once", ErrInvalidSort, key.Field)
+		}
+
+		seen[key.Field] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,3 @@
+ALTER TABLE products ADD COLUMN sku TEXT NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX products_sku_key ON products (sku) WHERE sku <> '';
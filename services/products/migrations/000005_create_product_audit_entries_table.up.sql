@@ -0,0 +1,13 @@
+-- product_id has no foreign key so the history outlives purged products
+CREATE TABLE IF NOT EXISTS product_audit_entries (
+    id         SERIAL PRIMARY KEY,
+    product_id INTEGER     NOT NULL,
+    action     TEXT        NOT NULL,
+    actor      TEXT        NOT NULL,
+    before     JSONB,
+    after      JSONB       NOT NULL,
+    trace_id   TEXT        NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX product_audit_entries_product_id_idx ON product_audit_entries (product_id, id DESC);
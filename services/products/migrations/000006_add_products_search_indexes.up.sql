@@ -0,0 +1,8 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- The expressions must match the ones used by the search queries, so the indexes are used
+CREATE INDEX products_search_idx ON products USING GIN (
+    (setweight(to_tsvector('simple', name), 'A') || setweight(to_tsvector('simple', description), 'B'))
+);
+
+CREATE INDEX products_search_trgm_idx ON products USING GIN ((name || ' ' || description) gin_trgm_ops);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS products_attributes_idx;
+
+DROP INDEX IF EXISTS products_category_idx;
+
+ALTER TABLE products DROP COLUMN IF EXISTS attributes;
+ALTER TABLE products DROP COLUMN IF EXISTS category;
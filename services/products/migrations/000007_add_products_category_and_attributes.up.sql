@@ -0,0 +1,6 @@
+ALTER TABLE products ADD COLUMN category TEXT NOT NULL DEFAULT '';
+ALTER TABLE products ADD COLUMN attributes JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX products_category_idx ON products (category) WHERE category <> '';
+-- jsonb_path_ops supports the containment (@>) queries filtering the products by their attributes
+CREATE INDEX products_attributes_idx ON products USING GIN (attributes jsonb_path_ops);
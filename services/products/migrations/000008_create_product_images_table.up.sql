@@ -0,0 +1,16 @@
+-- product_id has no foreign key so the images of purged products can be listed, and their files removed, after them
+CREATE TABLE IF NOT EXISTS product_images (
+    id           SERIAL PRIMARY KEY,
+    product_id   INTEGER     NOT NULL,
+    position     INTEGER     NOT NULL,
+    blob_key     TEXT        NOT NULL,
+    content_type TEXT        NOT NULL,
+    width        INTEGER     NOT NULL,
+    height       INTEGER     NOT NULL,
+    size         INTEGER     NOT NULL,
+    alt_text     TEXT        NOT NULL DEFAULT '',
+    thumbnails   JSONB       NOT NULL DEFAULT '[]',
+    created_at   TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX product_images_product_id_idx ON product_images (product_id, position);
@@ -0,0 +1,2 @@
+-- an empty tax class means the standard rates apply
+ALTER TABLE products ADD COLUMN tax_class TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,6 @@
+-- the weight is in grams and the dimensions in millimeters, zero ones are unknown
+ALTER TABLE products
+  ADD COLUMN weight INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN length INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN width  INTEGER NOT NULL DEFAULT 0,
+  ADD COLUMN height INTEGER NOT NULL DEFAULT 0;
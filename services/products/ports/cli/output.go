@@ -0,0 +1,523 @@
+package cli_port
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"gopkg.in/yaml.v3"
+)
+
+// Supported output formats
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+
+	// OutputNDJSON writes one JSON product per line, only export supports it as import reads it back
+	OutputNDJSON = "ndjson"
+)
+
+var (
+	ErrInvalidOutput = errors.New("invalid output format, it must be one of: table, json, yaml")
+)
+
+// productOutput is the representation of a Product printed by the commands
+type productOutput struct {
+	ID          int               `json:"id" yaml:"id"`
+	SKU         string            `json:"sku,omitempty" yaml:"sku,omitempty"`
+	Name        string            `json:"name" yaml:"name"`
+	Description string            `json:"description" yaml:"description"`
+	Price       int               `json:"price" yaml:"price"`
+	Category    string            `json:"category,omitempty" yaml:"category,omitempty"`
+	TaxClass    string            `json:"tax_class,omitempty" yaml:"tax_class,omitempty"`
+	Weight      int               `json:"weight,omitempty" yaml:"weight,omitempty"`
+	Dimensions  *dimensionsOutput `json:"dimensions,omitempty" yaml:"dimensions,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+	Version     int               `json:"version,omitempty" yaml:"version,omitempty"`
+	DeletedAt   *time.Time        `json:"deleted_at,omitempty" yaml:"deleted_at,omitempty"`
+}
+
+// dimensionsOutput is the representation of the Dimensions of a Product printed by the commands
+type dimensionsOutput struct {
+	Length int `json:"length" yaml:"length"`
+	Width  int `json:"width" yaml:"width"`
+	Height int `json:"height" yaml:"height"`
+}
+
+// value returns the Dimensions, the zero ones when there are none
+func (d *dimensionsOutput) value() domain.Dimensions {
+	if d == nil {
+		return domain.Dimensions{}
+	}
+
+	return domain.Dimensions{Length: d.Length, Width: d.Width, Height: d.Height}
+}
+
+// resultOutput is the representation of a ProductResult printed by the commands
+type resultOutput struct {
+	Index   int            `json:"index" yaml:"index"`
+	Product *productOutput `json:"product,omitempty" yaml:"product,omitempty"`
+	Error   string         `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// reportOutput is the representation of an ImportReport printed by the commands
+type reportOutput struct {
+	DryRun   bool            `json:"dry_run" yaml:"dry_run"`
+	Created  int             `json:"created" yaml:"created"`
+	Updated  int             `json:"updated" yaml:"updated"`
+	Failed   int             `json:"failed" yaml:"failed"`
+	Failures []failureOutput `json:"failures" yaml:"failures"`
+}
+
+// failureOutput is the representation of an ImportRowError printed by the commands
+type failureOutput struct {
+	Line  int    `json:"line" yaml:"line"`
+	SKU   string `json:"sku,omitempty" yaml:"sku,omitempty"`
+	Name  string `json:"name,omitempty" yaml:"name,omitempty"`
+	Error string `json:"error" yaml:"error"`
+}
+
+// entryOutput is the representation of an AuditEntry printed by the commands
+type entryOutput struct {
+	ID        int            `json:"id" yaml:"id"`
+	Action    string         `json:"action" yaml:"action"`
+	Actor     string         `json:"actor" yaml:"actor"`
+	Before    *productOutput `json:"before,omitempty" yaml:"before,omitempty"`
+	After     *productOutput `json:"after,omitempty" yaml:"after,omitempty"`
+	TraceID   string         `json:"trace_id,omitempty" yaml:"trace_id,omitempty"`
+	CreatedAt time.Time      `json:"created_at" yaml:"created_at"`
+}
+
+// searchResultOutput is the representation of a ProductSearchResult printed by the commands
+type searchResultOutput struct {
+	Product    productOutput    `json:"product" yaml:"product"`
+	Score      float64          `json:"score" yaml:"score"`
+	Highlights highlightsOutput `json:"highlights" yaml:"highlights"`
+}
+
+// highlightsOutput is the representation of the ProductHighlights printed by the commands
+type highlightsOutput struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+}
+
+// facetsOutput is the representation of the ProductFacets printed by the commands
+type facetsOutput struct {
+	Total       int                `json:"total" yaml:"total"`
+	PriceRanges []priceRangeOutput `json:"price_ranges" yaml:"price_ranges"`
+	Categories  []bucketOutput     `json:"categories" yaml:"categories"`
+	Attributes  []attributeOutput  `json:"attributes" yaml:"attributes"`
+}
+
+// priceRangeOutput is the representation of a PriceRangeBucket printed by the commands
+type priceRangeOutput struct {
+	From  int `json:"from" yaml:"from"`
+	To    int `json:"to,omitempty" yaml:"to,omitempty"`
+	Count int `json:"count" yaml:"count"`
+}
+
+// bucketOutput is the representation of a FacetBucket printed by the commands
+type bucketOutput struct {
+	Value string `json:"value" yaml:"value"`
+	Count int    `json:"count" yaml:"count"`
+}
+
+// attributeOutput is the representation of an AttributeFacet printed by the commands
+type attributeOutput struct {
+	Name   string         `json:"name" yaml:"name"`
+	Values []bucketOutput `json:"values" yaml:"values"`
+}
+
+// imageOutput is the representation of a ProductImage printed by the commands
+type imageOutput struct {
+	ID          int               `json:"id" yaml:"id"`
+	ProductID   int               `json:"product_id" yaml:"product_id"`
+	Position    int               `json:"position" yaml:"position"`
+	URL         string            `json:"url" yaml:"url"`
+	ContentType string            `json:"content_type" yaml:"content_type"`
+	Width       int               `json:"width" yaml:"width"`
+	Height      int               `json:"height" yaml:"height"`
+	Size        int               `json:"size" yaml:"size"`
+	AltText     string            `json:"alt_text,omitempty" yaml:"alt_text,omitempty"`
+	Thumbnails  []thumbnailOutput `json:"thumbnails" yaml:"thumbnails"`
+	CreatedAt   time.Time         `json:"created_at" yaml:"created_at"`
+}
+
+// thumbnailOutput is the representation of a Thumbnail printed by the commands
+type thumbnailOutput struct {
+	Name   string `json:"name" yaml:"name"`
+	URL    string `json:"url" yaml:"url"`
+	Width  int    `json:"width" yaml:"width"`
+	Height int    `json:"height" yaml:"height"`
+}
+
+func validateOutput(output string) error {
+	switch output {
+	case OutputTable, OutputJSON, OutputYAML:
+		return nil
+	default:
+		return ErrInvalidOutput
+	}
+}
+
+func toProductOutput(product domain.Product) productOutput {
+	output := productOutput{
+		ID:          product.ID,
+		SKU:         product.SKU,
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       product.Price,
+		Category:    product.Category,
+		Attributes:  product.Attributes,
+		TaxClass:    product.TaxClass,
+		Weight:      product.Weight,
+		Version:     product.Version,
+	}
+
+	if !product.Dimensions.Zero() {
+		output.Dimensions = &dimensionsOutput{
+			Length: product.Dimensions.Length,
+			Width:  product.Dimensions.Width,
+			Height: product.Dimensions.Height,
+		}
+	}
+
+	if product.Deleted() {
+		output.DeletedAt = &product.DeletedAt
+	}
+
+	return output
+}
+
+func printProducts(w io.Writer, output string, products []domain.Product) error {
+	data := make([]productOutput, 0, len(products))
+	for _, product := range products {
+		data = append(data, toProductOutput(product))
+	}
+
+	switch output {
+	case OutputJSON:
+		return printJSON(w, data)
+	case OutputYAML:
+		return printYAML(w, data)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tDESCRIPTION\tPRICE")
+	for _, product := range data {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%d\n", product.ID, product.Name, product.Description, product.Price)
+	}
+
+	return tw.Flush()
+}
+
+func printProduct(w io.Writer, output string, product domain.Product) error {
+	switch output {
+	case OutputJSON:
+		return printJSON(w, toProductOutput(product))
+	case OutputYAML:
+		return printYAML(w, toProductOutput(product))
+	}
+
+	return printProducts(w, output, []domain.Product{product})
+}
+
+func printHistory(w io.Writer, output string, entries []domain.AuditEntry) error {
+	data := make([]entryOutput, 0, len(entries))
+	for _, entry := range entries {
+		item := entryOutput{
+			ID:        entry.ID,
+			Action:    string(entry.Action),
+			Actor:     entry.Actor,
+			TraceID:   entry.TraceID,
+			CreatedAt: entry.CreatedAt,
+		}
+
+		if entry.Before != nil {
+			before := toProductOutput(*entry.Before)
+			item.Before = &before
+		}
+
+		if entry.After != nil {
+			after := toProductOutput(*entry.After)
+			item.After = &after
+		}
+
+		data = append(data, item)
+	}
+
+	switch output {
+	case OutputJSON:
+		return printJSON(w, data)
+	case OutputYAML:
+		return printYAML(w, data)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tACTION\tACTOR\tAT\tVERSION")
+	for _, item := range data {
+		version := 0
+		if item.After != nil {
+			version = item.After.Version
+		}
+
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%d\n", item.ID, item.Action, item.Actor, item.CreatedAt.Format(time.RFC3339), version)
+	}
+
+	return tw.Flush()
+}
+
+func printSearchResults(w io.Writer, output string, results []domain.ProductSearchResult) error {
+	data := make([]searchResultOutput, 0, len(results))
+	for _, result := range results {
+		data = append(data, searchResultOutput{
+			Product: toProductOutput(result.Product),
+			Score:   result.Score,
+			Highlights: highlightsOutput{
+				Name:        result.Highlights.Name,
+				Description: result.Highlights.Description,
+			},
+		})
+	}
+
+	switch output {
+	case OutputJSON:
+		return printJSON(w, data)
+	case OutputYAML:
+		return printYAML(w, data)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tSCORE\tMATCH")
+	for _, item := range data {
+		// show the description only when the search matched nothing on the name
+		match := item.Highlights.Name
+		if !strings.Contains(match, domain.HighlightStart) {
+			match = item.Highlights.Description
+		}
+
+		fmt.Fprintf(tw, "%d\t%s\t%.2f\t%s\n", item.Product.ID, item.Product.Name, item.Score, match)
+	}
+
+	return tw.Flush()
+}
+
+func printFacets(w io.Writer, output string, facets domain.ProductFacets) error {
+	data := facetsOutput{
+		Total:       facets.Total,
+		PriceRanges: make([]priceRangeOutput, 0, len(facets.PriceRanges)),
+		Categories:  toBucketsOutput(facets.Categories),
+		Attributes:  make([]attributeOutput, 0, len(facets.Attributes)),
+	}
+
+	for _, bucket := range facets.PriceRanges {
+		data.PriceRanges = append(data.PriceRanges, priceRangeOutput{From: bucket.From, To: bucket.To, Count: bucket.Count})
+	}
+
+	for _, attribute := range facets.Attributes {
+		data.Attributes = append(data.Attributes, attributeOutput{Name: attribute.Name, Values: toBucketsOutput(attribute.Values)})
+	}
+
+	switch output {
+	case OutputJSON:
+		return printJSON(w, data)
+	case OutputYAML:
+		return printYAML(w, data)
+	}
+
+	fmt.Fprintf(w, "%d products\n", data.Total)
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "FACET\tVALUE\tCOUNT")
+	for _, bucket := range data.PriceRanges {
+		value := fmt.Sprintf("%d+", bucket.From)
+		if bucket.To > 0 {
+			value = fmt.Sprintf("%d-%d", bucket.From, bucket.To)
+		}
+
+		fmt.Fprintf(tw, "price\t%s\t%d\n", value, bucket.Count)
+	}
+
+	for _, bucket := range data.Categories {
+		fmt.Fprintf(tw, "category\t%s\t%d\n", bucket.Value, bucket.Count)
+	}
+
+	for _, attribute := range data.Attributes {
+		for _, bucket := range attribute.Values {
+			fmt.Fprintf(tw, "%s\t%s\t%d\n", attribute.Name, bucket.Value, bucket.Count)
+		}
+	}
+
+	return tw.Flush()
+}
+
+func toBucketsOutput(buckets []domain.FacetBucket) []bucketOutput {
+	data := make([]bucketOutput, 0, len(buckets))
+	for _, bucket := range buckets {
+		data = append(data, bucketOutput{Value: bucket.Value, Count: bucket.Count})
+	}
+
+	return data
+}
+
+func printResults(w io.Writer, output string, results []domain.ProductResult) error {
+	data := make([]resultOutput, 0, len(results))
+	for i, result := range results {
+		item := resultOutput{Index: i}
+		if result.Err != nil {
+			item.Error = result.Err.Error()
+		} else {
+			product := toProductOutput(result.Product)
+			item.Product = &product
+		}
+
+		data = append(data, item)
+	}
+
+	switch output {
+	case OutputJSON:
+		return printJSON(w, data)
+	case OutputYAML:
+		return printYAML(w, data)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "INDEX\tID\tNAME\tERROR")
+	for _, item := range data {
+		if item.Product != nil {
+			fmt.Fprintf(tw, "%d\t%d\t%s\t\n", item.Index, item.Product.ID, item.Product.Name)
+			continue
+		}
+
+		fmt.Fprintf(tw, "%d\t\t\t%s\n", item.Index, item.Error)
+	}
+
+	return tw.Flush()
+}
+
+func printReport(w io.Writer, output string, report domain.ImportReport) error {
+	data := reportOutput{
+		DryRun:   report.DryRun,
+		Created:  report.Created,
+		Updated:  report.Updated,
+		Failed:   report.Failed,
+		Failures: make([]failureOutput, 0, len(report.Failures)),
+	}
+
+	for _, failure := range report.Failures {
+		data.Failures = append(data.Failures, failureOutput{
+			Line:  failure.Line,
+			SKU:   failure.Product.SKU,
+			Name:  failure.Product.Name,
+			Error: failure.Err.Error(),
+		})
+	}
+
+	switch output {
+	case OutputJSON:
+		return printJSON(w, data)
+	case OutputYAML:
+		return printYAML(w, data)
+	}
+
+	summary := "imported"
+	if data.DryRun {
+		summary = "dry-run"
+	}
+
+	fmt.Fprintf(w, "%s: %d created, %d updated, %d failed\n", summary, data.Created, data.Updated, data.Failed)
+	if len(data.Failures) == 0 {
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "LINE\tSKU\tNAME\tERROR")
+	for _, failure := range data.Failures {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\n", failure.Line, failure.SKU, failure.Name, failure.Error)
+	}
+
+	return tw.Flush()
+}
+
+func toImageOutput(image domain.ProductImage) imageOutput {
+	thumbnails := make([]thumbnailOutput, 0, len(image.Thumbnails))
+	for _, thumbnail := range image.Thumbnails {
+		thumbnails = append(thumbnails, thumbnailOutput{
+			Name:   thumbnail.Name,
+			URL:    thumbnail.URL,
+			Width:  thumbnail.Width,
+			Height: thumbnail.Height,
+		})
+	}
+
+	return imageOutput{
+		ID:          image.ID,
+		ProductID:   image.ProductID,
+		Position:    image.Position,
+		URL:         image.URL,
+		ContentType: image.ContentType,
+		Width:       image.Width,
+		Height:      image.Height,
+		Size:        image.Size,
+		AltText:     image.AltText,
+		Thumbnails:  thumbnails,
+		CreatedAt:   image.CreatedAt,
+	}
+}
+
+func printImages(w io.Writer, output string, images []domain.ProductImage) error {
+	data := make([]imageOutput, 0, len(images))
+	for _, image := range images {
+		data = append(data, toImageOutput(image))
+	}
+
+	switch output {
+	case OutputJSON:
+		return printJSON(w, data)
+	case OutputYAML:
+		return printYAML(w, data)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tPOSITION\tSIZE\tALT\tURL")
+	for _, item := range data {
+		fmt.Fprintf(tw, "%d\t%d\t%dx%d\t%s\t%s\n", item.ID, item.Position, item.Width, item.Height, item.AltText, item.URL)
+	}
+
+	return tw.Flush()
+}
+
+func printImage(w io.Writer, output string, image domain.ProductImage) error {
+	switch output {
+	case OutputJSON:
+		return printJSON(w, toImageOutput(image))
+	case OutputYAML:
+		return printYAML(w, toImageOutput(image))
+	}
+
+	return printImages(w, output, []domain.ProductImage{image})
+}
+
+func printJSON(w io.Writer, data interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(data)
+}
+
+func printYAML(w io.Writer, data interface{}) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(data); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
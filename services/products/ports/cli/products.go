@@ -0,0 +1,867 @@
+package cli_port
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+)
+
+var (
+	ErrMissingCLI     = errors.New("missing required dependency: CLI")
+	ErrMissingStdout  = errors.New("missing required dependency: Stdout")
+	ErrUnknownCommand = errors.New("unknown command")
+	ErrMissingID      = errors.New("a product id must be provided")
+	ErrImportFailed   = errors.New("some rows couldn't be imported")
+	ErrMissingImageID = errors.New("an image id must be provided")
+	ErrMissingFile    = errors.New("a file must be provided")
+	ErrMissingVersion = errors.New("the product version must be provided")
+)
+
+// Usage describes the commands accepted by ProductsCommands
+const Usage = `Usage: productsctl [flags] <command> [command flags]
+
+Commands:
+  list            [-ids 1,2,3] [-include-deleted] [-trash]             list products, -trash lists only the deleted ones
+                  [-category C] [-min-price N] [-max-price N]          -max-price is exclusive
+                  [-attributes k=v,...] [-sort price:desc,name]        -sort breaks the ties by id
+  get             <id>                                                 show a single product
+  register        [-sku SKU] -name NAME -description DESC -price PRICE register a new product
+                  [-category C] [-attributes k=v,...] [-tax-class T]   -weight is in grams, -dimensions in
+                  [-weight G] [-dimensions LxWxH]                      millimeters, as in 147x72x8
+  update, patch   <id> -version N [-sku SKU] [-name NAME]              change only the given fields of a product,
+                  [-description DESC] [-price PRICE] [-category C]     -attributes replaces all of them, the change
+                  [-attributes k=v,...] [-tax-class T] [-weight G]     is rejected if the product changed since
+                  [-dimensions LxWxH]                                  the given version
+  delete          <id>                                                 move a product to the trash
+  restore         <id>                                                 bring a deleted product back from the trash
+  history         <id> [-limit N] [-before ID]                         list the changes made to a product, newest first
+  search          [-limit N] [-offset N] [-sort relevance:desc]        search products by name and description,
+                  <words...>                                           the most relevant first by default
+  facets          [-category C] [-min-price N] [-max-price N]          count the products by price range, category
+                  [-attributes k=v,...] [-boundaries 5000,10000]       and attribute values
+  import          [-file products.json]                                register the products of a JSON or NDJSON file
+  export          [-file products.json]                                write every product to a file or stdout,
+                  [-format ndjson|table|json|yaml]                     as NDJSON that import reads back by default
+  import-catalog  [-file catalog.csv] [-format csv|ndjson] [-dry-run]  validate and import a CSV or NDJSON catalog
+                  [-mode create|upsert-by-id|upsert-by-sku]
+  export-catalog  [-file catalog.csv] [-format csv|ndjson]             write every product as a CSV or NDJSON catalog
+  images          <id>                                                 list the images of a product, in their order
+  upload-image    <id> -file photo.jpg [-alt TEXT]                     add a JPEG, PNG or GIF image to a product
+  delete-image    <image id>                                           remove an image along with its thumbnails
+  reorder-images  <id> -order 3,1,2                                    reorder the images of a product, listing
+                                                                       every one of them
+`
+
+// ProductsCommandsInput is the input (aka dependencies) needed to create the ProductsCommands
+type ProductsCommandsInput struct {
+	CLI    domain.CLI
+	Stdin  io.Reader
+	Stdout io.Writer
+	// Output is the format used to print the results, it defaults to table
+	Output string
+}
+
+// ProductsCommands dispatches the productsctl subcommands to the domain.CLI
+type ProductsCommands struct {
+	in ProductsCommandsInput
+}
+
+// NewProductsCommands creates a new ProductsCommands instance
+func NewProductsCommands(in ProductsCommandsInput) (*ProductsCommands, error) {
+	if in.CLI == nil {
+		return nil, ErrMissingCLI
+	}
+
+	if in.Stdout == nil {
+		return nil, ErrMissingStdout
+	}
+
+	if in.Stdin == nil {
+		in.Stdin = os.Stdin
+	}
+
+	if in.Output == "" {
+		in.Output = OutputTable
+	}
+
+	if err := validateOutput(in.Output); err != nil {
+		return nil, err
+	}
+
+	return &ProductsCommands{in: in}, nil
+}
+
+// MustNewProductsCommands creates a new ProductsCommands instance
+// It panics if any error is found
+func MustNewProductsCommands(in ProductsCommandsInput) *ProductsCommands {
+	commands, err := NewProductsCommands(in)
+	if err != nil {
+		panic(err)
+	}
+
+	return commands
+}
+
+// Run executes the command described by args, e.g. ["get", "1"]
+func (c *ProductsCommands) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: no command provided", ErrUnknownCommand)
+	}
+
+	command, args := args[0], args[1:]
+
+	switch command {
+	case "list":
+		return c.list(ctx, args)
+	case "get":
+		return c.get(ctx, args)
+	case "register":
+		return c.register(ctx, args)
+	case "update", "patch":
+		return c.patch(ctx, command, args)
+	case "delete":
+		return c.delete(ctx, args)
+	case "restore":
+		return c.restore(ctx, args)
+	case "history":
+		return c.history(ctx, args)
+	case "search":
+		return c.search(ctx, args)
+	case "facets":
+		return c.facets(ctx, args)
+	case "import":
+		return c.importProducts(ctx, args)
+	case "export":
+		return c.exportProducts(ctx, args)
+	case "import-catalog":
+		return c.importCatalog(ctx, args)
+	case "export-catalog":
+		return c.exportCatalog(ctx, args)
+	case "images":
+		return c.images(ctx, args)
+	case "upload-image":
+		return c.uploadImage(ctx, args)
+	case "delete-image":
+		return c.deleteImage(ctx, args)
+	case "reorder-images":
+		return c.reorderImages(ctx, args)
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownCommand, command)
+	}
+}
+
+func (c *ProductsCommands) list(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("list", flag.ContinueOnError)
+	ids := flags.String("ids", "", "comma separated list of product ids")
+	includeDeleted := flags.Bool("include-deleted", false, "list the deleted products along with the others")
+	trash := flags.Bool("trash", false, "list only the deleted products")
+	productFilter := productFilterFlags(flags)
+	var sortKeys sortFlag
+	flags.Var(&sortKeys, "sort", "comma separated fields to sort by, optionally followed by :asc or :desc")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	filter := domain.ListProductsFilter{
+		ProductFilter:  productFilter(),
+		IncludeDeleted: *includeDeleted,
+		OnlyDeleted:    *trash,
+		Sort:           sortKeys,
+	}
+	if *ids != "" {
+		for _, rawID := range strings.Split(*ids, ",") {
+			id, err := parseID(strings.TrimSpace(rawID))
+			if err != nil {
+				return err
+			}
+
+			filter.IDs = append(filter.IDs, id)
+		}
+	}
+
+	products, err := c.in.CLI.ListProducts(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return printProducts(c.in.Stdout, c.in.Output, products)
+}
+
+func (c *ProductsCommands) get(ctx context.Context, args []string) error {
+	id, _, err := idArgument(args)
+	if err != nil {
+		return err
+	}
+
+	product, err := c.in.CLI.GetProduct(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return printProduct(c.in.Stdout, c.in.Output, product)
+}
+
+func (c *ProductsCommands) register(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("register", flag.ContinueOnError)
+	sku := flags.String("sku", "", "product stock keeping unit")
+	name := flags.String("name", "", "product name")
+	description := flags.String("description", "", "product description")
+	price := flags.Int("price", 0, "product price in cents")
+	category := flags.String("category", "", "product category")
+	taxClass := flags.String("tax-class", "", "product tax class, empty means standard")
+	weight := flags.Int("weight", 0, "product weight in grams, 0 means unknown")
+	dimensions := &dimensionsFlag{}
+	flags.Var(dimensions, "dimensions", "product length, width and height in millimeters, as in 147x72x8")
+	attributes := attributesFlag{}
+	flags.Var(attributes, "attributes", "comma separated product attributes, as in color=red,size=M")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	product, err := c.in.CLI.RegisterProduct(ctx, domain.Product{
+		SKU:         *sku,
+		Name:        *name,
+		Description: *description,
+		Price:       *price,
+		Category:    *category,
+		TaxClass:    *taxClass,
+		Weight:      *weight,
+		Dimensions:  domain.Dimensions(*dimensions),
+		Attributes:  attributes.value(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return printProduct(c.in.Stdout, c.in.Output, product)
+}
+
+// patch backs both update and patch, only the given fields are sent so an update never
+// overwrites the fields the user didn't mention
+func (c *ProductsCommands) patch(ctx context.Context, command string, args []string) error {
+	id, args, err := idArgument(args)
+	if err != nil {
+		return err
+	}
+
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	sku := flags.String(string(domain.ProductFieldSKU), "", "product stock keeping unit")
+	name := flags.String(string(domain.ProductFieldName), "", "product name")
+	description := flags.String(string(domain.ProductFieldDescription), "", "product description")
+	price := flags.Int(string(domain.ProductFieldPrice), 0, "product price in cents")
+	category := flags.String(string(domain.ProductFieldCategory), "", "product category")
+	taxClass := flags.String("tax-class", "", "product tax class, empty means standard")
+	weight := flags.Int("weight", 0, "product weight in grams, 0 means unknown")
+	dimensions := &dimensionsFlag{}
+	flags.Var(dimensions, "dimensions", "product length, width and height in millimeters, as in 147x72x8")
+	attributes := attributesFlag{}
+	flags.Var(attributes, string(domain.ProductFieldAttributes), "comma separated product attributes, as in color=red,size=M")
+	version := flags.Int("version", 0, "product version the change is based on")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *version <= 0 {
+		return ErrMissingVersion
+	}
+
+	patch := domain.ProductPatch{
+		Product: domain.Product{
+			ID:          id,
+			SKU:         *sku,
+			Name:        *name,
+			Description: *description,
+			Price:       *price,
+			Category:    *category,
+			TaxClass:    *taxClass,
+			Weight:      *weight,
+			Dimensions:  domain.Dimensions(*dimensions),
+			Attributes:  attributes.value(),
+			Version:     *version,
+		},
+	}
+
+	// Only the flags explicitly set are patched, so an omitted flag keeps the stored value,
+	// dashed flag names map to the underscored field names
+	flags.Visit(func(f *flag.Flag) {
+		if field, err := domain.ParseProductField(strings.ReplaceAll(f.Name, "-", "_")); err == nil {
+			patch.Fields = append(patch.Fields, field)
+		}
+	})
+
+	product, err := c.in.CLI.PatchProduct(ctx, patch)
+	if err != nil {
+		return err
+	}
+
+	return printProduct(c.in.Stdout, c.in.Output, product)
+}
+
+func (c *ProductsCommands) delete(ctx context.Context, args []string) error {
+	id, _, err := idArgument(args)
+	if err != nil {
+		return err
+	}
+
+	if err := c.in.CLI.DeleteProduct(ctx, id); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(c.in.Stdout, "product %d deleted\n", id)
+	return err
+}
+
+func (c *ProductsCommands) restore(ctx context.Context, args []string) error {
+	id, _, err := idArgument(args)
+	if err != nil {
+		return err
+	}
+
+	product, err := c.in.CLI.RestoreProduct(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return printProduct(c.in.Stdout, c.in.Output, product)
+}
+
+func (c *ProductsCommands) history(ctx context.Context, args []string) error {
+	id, args, err := idArgument(args)
+	if err != nil {
+		return err
+	}
+
+	flags := flag.NewFlagSet("history", flag.ContinueOnError)
+	limit := flags.Int("limit", 0, "maximum amount of changes listed, 0 uses the server default")
+	before := flags.Int("before", 0, "list only the changes older than the one with this id")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	entries, err := c.in.CLI.GetProductHistory(ctx, domain.ProductHistoryFilter{
+		ProductID: id,
+		BeforeID:  *before,
+		Limit:     *limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printHistory(c.in.Stdout, c.in.Output, entries)
+}
+
+func (c *ProductsCommands) search(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("search", flag.ContinueOnError)
+	limit := flags.Int("limit", 0, "maximum amount of results, 0 uses the server default")
+	offset := flags.Int("offset", 0, "amount of results skipped, to fetch the next pages")
+	var sortKeys sortFlag
+	flags.Var(&sortKeys, "sort", "comma separated fields to sort by, optionally followed by :asc or :desc")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	results, err := c.in.CLI.SearchProducts(ctx, domain.ProductSearch{
+		Query:  strings.Join(flags.Args(), " "),
+		Limit:  *limit,
+		Offset: *offset,
+		Sort:   sortKeys,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printSearchResults(c.in.Stdout, c.in.Output, results)
+}
+
+func (c *ProductsCommands) facets(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("facets", flag.ContinueOnError)
+	productFilter := productFilterFlags(flags)
+	boundaries := flags.String("boundaries", "", "comma separated prices, in cents, splitting the price ranges")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	filter := domain.FacetsFilter{ProductFilter: productFilter()}
+	if *boundaries != "" {
+		for _, rawBoundary := range strings.Split(*boundaries, ",") {
+			boundary, err := strconv.Atoi(strings.TrimSpace(rawBoundary))
+			if err != nil {
+				return fmt.Errorf("invalid price boundary: %q", rawBoundary)
+			}
+
+			filter.PriceBoundaries = append(filter.PriceBoundaries, boundary)
+		}
+	}
+
+	facets, err := c.in.CLI.GetProductFacets(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return printFacets(c.in.Stdout, c.in.Output, facets)
+}
+
+func (c *ProductsCommands) importProducts(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	file := flags.String("file", "-", "JSON or NDJSON file to import, - reads from stdin")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	reader, closeFn, err := c.openInput(*file)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	products, err := decodeProducts(reader)
+	if err != nil {
+		return err
+	}
+
+	results, err := c.in.CLI.RegisterProducts(ctx, products)
+	if err != nil {
+		return err
+	}
+
+	return printResults(c.in.Stdout, c.in.Output, results)
+}
+
+func (c *ProductsCommands) exportProducts(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	file := flags.String("file", "-", "file to write the products to, - writes to stdout")
+	format := flags.String("format", OutputNDJSON, "ndjson, which import reads back, or one of the table, json and yaml outputs")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *format != OutputNDJSON {
+		if err := validateOutput(*format); err != nil {
+			return err
+		}
+	}
+
+	if *file == "-" {
+		return c.writeProducts(ctx, c.in.Stdout, *format)
+	}
+
+	f, err := os.Create(*file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := c.writeProducts(ctx, f, *format); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+// writeProducts writes every exported product to w, streaming them when the format is NDJSON
+func (c *ProductsCommands) writeProducts(ctx context.Context, w io.Writer, format string) error {
+	if format == OutputNDJSON {
+		encoder := json.NewEncoder(w)
+		return c.in.CLI.ExportProducts(ctx, func(product domain.Product) error {
+			return encoder.Encode(toProductOutput(product))
+		})
+	}
+
+	var products []domain.Product
+	err := c.in.CLI.ExportProducts(ctx, func(product domain.Product) error {
+		products = append(products, product)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return printProducts(w, format, products)
+}
+
+func (c *ProductsCommands) importCatalog(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("import-catalog", flag.ContinueOnError)
+	file := flags.String("file", "-", "CSV or NDJSON catalog to import, - reads from stdin")
+	format := flags.String("format", "", "catalog format, csv or ndjson, defaults to the file extension")
+	mode := flags.String("mode", string(domain.ImportModeCreate), "create, upsert-by-id or upsert-by-sku")
+	dryRun := flags.Bool("dry-run", false, "validate the catalog without storing anything")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	reader, closeFn, err := c.openInput(*file)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	report, err := c.in.CLI.ImportCatalog(ctx, reader, domain.ImportOptions{
+		Format: catalogFormat(*format, *file),
+		Mode:   domain.ImportMode(*mode),
+		DryRun: *dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := printReport(c.in.Stdout, c.in.Output, report); err != nil {
+		return err
+	}
+
+	if report.Failed > 0 {
+		return fmt.Errorf("%w: %d failed", ErrImportFailed, report.Failed)
+	}
+
+	return nil
+}
+
+func (c *ProductsCommands) exportCatalog(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("export-catalog", flag.ContinueOnError)
+	file := flags.String("file", "-", "file to write the catalog to, - writes to stdout")
+	format := flags.String("format", "", "catalog format, csv or ndjson, defaults to the file extension")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *file == "-" {
+		return c.in.CLI.ExportCatalog(ctx, c.in.Stdout, catalogFormat(*format, *file))
+	}
+
+	f, err := os.Create(*file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := c.in.CLI.ExportCatalog(ctx, f, catalogFormat(*format, *file)); err != nil {
+		return err
+	}
+
+	return f.Close()
+}
+
+func (c *ProductsCommands) images(ctx context.Context, args []string) error {
+	id, _, err := idArgument(args)
+	if err != nil {
+		return err
+	}
+
+	images, err := c.in.CLI.ListProductImages(ctx, []int{id})
+	if err != nil {
+		return err
+	}
+
+	return printImages(c.in.Stdout, c.in.Output, images)
+}
+
+func (c *ProductsCommands) uploadImage(ctx context.Context, args []string) error {
+	id, args, err := idArgument(args)
+	if err != nil {
+		return err
+	}
+
+	flags := flag.NewFlagSet("upload-image", flag.ContinueOnError)
+	file := flags.String("file", "", "JPEG, PNG or GIF image to upload, - reads from stdin")
+	altText := flags.String("alt", "", "alternative text describing the image")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *file == "" {
+		return ErrMissingFile
+	}
+
+	reader, closeFn, err := c.openInput(*file)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	image, err := c.in.CLI.UploadProductImage(ctx, domain.ImageUpload{ProductID: id, AltText: *altText}, reader)
+	if err != nil {
+		return err
+	}
+
+	return printImage(c.in.Stdout, c.in.Output, image)
+}
+
+func (c *ProductsCommands) deleteImage(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return ErrMissingImageID
+	}
+
+	id, err := strconv.Atoi(args[0])
+	if err != nil || id <= 0 {
+		return fmt.Errorf("invalid image id: %q", args[0])
+	}
+
+	if err := c.in.CLI.DeleteProductImage(ctx, id); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(c.in.Stdout, "image %d deleted\n", id)
+	return err
+}
+
+func (c *ProductsCommands) reorderImages(ctx context.Context, args []string) error {
+	id, args, err := idArgument(args)
+	if err != nil {
+		return err
+	}
+
+	flags := flag.NewFlagSet("reorder-images", flag.ContinueOnError)
+	order := flags.String("order", "", "comma separated image ids, in their new order")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var imageIDs []int
+	for _, rawID := range strings.Split(*order, ",") {
+		imageID, err := strconv.Atoi(strings.TrimSpace(rawID))
+		if err != nil || imageID <= 0 {
+			return fmt.Errorf("invalid image id: %q", rawID)
+		}
+
+		imageIDs = append(imageIDs, imageID)
+	}
+
+	images, err := c.in.CLI.ReorderProductImages(ctx, id, imageIDs)
+	if err != nil {
+		return err
+	}
+
+	return printImages(c.in.Stdout, c.in.Output, images)
+}
+
+func (c *ProductsCommands) openInput(file string) (io.Reader, func(), error) {
+	if file == "-" {
+		return c.in.Stdin, func() {}, nil
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return f, func() { _ = f.Close() }, nil
+}
+
+// decodeProducts reads either a JSON array of products or one JSON product per line
+func decodeProducts(r io.Reader) ([]domain.Product, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []productOutput
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &data); err != nil {
+			return nil, err
+		}
+	} else {
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		for {
+			var product productOutput
+			err := decoder.Decode(&product)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			data = append(data, product)
+		}
+	}
+
+	products := make([]domain.Product, 0, len(data))
+	for _, product := range data {
+		products = append(products, domain.Product{
+			ID:          product.ID,
+			SKU:         product.SKU,
+			Name:        product.Name,
+			Description: product.Description,
+			Price:       product.Price,
+			Category:    product.Category,
+			Attributes:  product.Attributes,
+			TaxClass:    product.TaxClass,
+			Weight:      product.Weight,
+			Dimensions:  product.Dimensions.value(),
+		})
+	}
+
+	return products, nil
+}
+
+// catalogFormat returns the explicit format or, when there's none, the one matching the file extension
+func catalogFormat(format string, file string) domain.CatalogFormat {
+	if format != "" {
+		return domain.CatalogFormat(format)
+	}
+
+	if strings.EqualFold(filepath.Ext(file), ".csv") {
+		return domain.CatalogFormatCSV
+	}
+
+	return domain.CatalogFormatNDJSON
+}
+
+// productFilterFlags defines the flags of a domain.ProductFilter, returning a function building it once they're parsed
+func productFilterFlags(flags *flag.FlagSet) func() domain.ProductFilter {
+	category := flags.String("category", "", "only the products of this category")
+	minPrice := flags.Int("min-price", 0, "only the products priced from this amount of cents")
+	maxPrice := flags.Int("max-price", 0, "only the products priced under this amount of cents")
+	attributes := attributesFlag{}
+	flags.Var(attributes, "attributes", "only the products having these attributes, as in color=red,size=M")
+
+	return func() domain.ProductFilter {
+		return domain.ProductFilter{
+			Category:   *category,
+			PriceFrom:  *minPrice,
+			PriceTo:    *maxPrice,
+			Attributes: attributes.value(),
+		}
+	}
+}
+
+// attributesFlag is a flag of comma separated name=value pairs, it may be repeated to add more of them
+type attributesFlag map[string]string
+
+func (f attributesFlag) String() string {
+	pairs := make([]string, 0, len(f))
+	for name, value := range f {
+		pairs = append(pairs, name+"="+value)
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, ",")
+}
+
+func (f attributesFlag) Set(raw string) error {
+	if raw == "" {
+		return nil
+	}
+
+	for _, pair := range strings.Split(raw, ",") {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("invalid attribute %q, it must be name=value", pair)
+		}
+
+		f[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+
+	return nil
+}
+
+// value returns the attributes, nil when there are none
+func (f attributesFlag) value() map[string]string {
+	if len(f) == 0 {
+		return nil
+	}
+
+	return f
+}
+
+// dimensionsFlag is a flag of the length, width and height of a product, in millimeters, as in 147x72x8
+type dimensionsFlag domain.Dimensions
+
+func (f *dimensionsFlag) String() string {
+	if f == nil || domain.Dimensions(*f).Zero() {
+		return ""
+	}
+
+	return fmt.Sprintf("%dx%dx%d", f.Length, f.Width, f.Height)
+}
+
+func (f *dimensionsFlag) Set(raw string) error {
+	if raw == "" {
+		*f = dimensionsFlag{}
+		return nil
+	}
+
+	parts := strings.Split(raw, "x")
+	if len(parts) != 3 {
+		return fmt.Errorf("invalid dimensions %q, they must be LxWxH", raw)
+	}
+
+	measures := make([]int, 0, len(parts))
+	for _, part := range parts {
+		measure, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return fmt.Errorf("invalid dimensions %q, they must be LxWxH", raw)
+		}
+
+		measures = append(measures, measure)
+	}
+
+	*f = dimensionsFlag{Length: measures[0], Width: measures[1], Height: measures[2]}
+
+	return nil
+}
+
+// sortFlag is a flag of comma separated sort keys, as in price:desc,name
+type sortFlag []domain.SortKey
+
+func (f *sortFlag) String() string {
+	keys := make([]string, 0, len(*f))
+	for _, key := range *f {
+		keys = append(keys, key.String())
+	}
+
+	return strings.Join(keys, ",")
+}
+
+func (f *sortFlag) Set(raw string) error {
+	*f = nil
+	if raw == "" {
+		return nil
+	}
+
+	for _, spec := range strings.Split(raw, ",") {
+		key, err := domain.ParseSortKey(spec)
+		if err != nil {
+			return err
+		}
+
+		*f = append(*f, key)
+	}
+
+	return nil
+}
+
+// idArgument extracts the leading positional product id from args
+func idArgument(args []string) (int, []string, error) {
+	if len(args) == 0 {
+		return 0, nil, ErrMissingID
+	}
+
+	id, err := parseID(args[0])
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return id, args[1:], nil
+}
+
+func parseID(raw string) (int, error) {
+	id, err := strconv.Atoi(raw)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid product id: %q", raw)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,696 @@
+package cli_port
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	"github.com/lucasmls/ecommerce/services/products/mocks"
+)
+
+type ProductsCommandsSuite struct {
+	suite.Suite
+
+	cli    *mocks.CLI
+	stdout *bytes.Buffer
+}
+
+func (s *ProductsCommandsSuite) SetupTest() {
+	s.cli = &mocks.CLI{}
+	s.stdout = &bytes.Buffer{}
+}
+
+func (s *ProductsCommandsSuite) commands(output string, stdin string) *ProductsCommands {
+	return MustNewProductsCommands(ProductsCommandsInput{
+		CLI:    s.cli,
+		Stdin:  strings.NewReader(stdin),
+		Stdout: s.stdout,
+		Output: output,
+	})
+}
+
+func (s *ProductsCommandsSuite) Test_NewProductsCommands() {
+	s.Run("Should fail to instantiate the ProductsCommands in case a CLI isn't provided", func() {
+		_, err := NewProductsCommands(ProductsCommandsInput{Stdout: s.stdout})
+
+		s.Equal(ErrMissingCLI, err)
+	})
+
+	s.Run("Should fail to instantiate the ProductsCommands with an unknown output format", func() {
+		_, err := NewProductsCommands(ProductsCommandsInput{CLI: s.cli, Stdout: s.stdout, Output: "xml"})
+
+		s.Equal(ErrInvalidOutput, err)
+	})
+}
+
+func (s *ProductsCommandsSuite) Test_Run() {
+	s.Run("Should reject unknown commands", func() {
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{"purge"})
+
+		s.ErrorIs(err, ErrUnknownCommand)
+	})
+}
+
+func (s *ProductsCommandsSuite) Test_List() {
+	s.Run("Should print the filtered products as a table", func() {
+		s.SetupTest()
+		s.cli.
+			On("ListProducts", mock.Anything, domain.ListProductsFilter{IDs: []int{1, 2}}).
+			Return([]domain.Product{
+				{ID: 1, Name: "Iphone 13", Description: "Cool", Price: 4500},
+				{ID: 2, Name: "Macbook Air M1", Description: "Fast!", Price: 6800},
+			}, nil)
+
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{"list", "-ids", "1,2"})
+
+		s.NoError(err)
+		s.Equal(
+			"ID  NAME            DESCRIPTION  PRICE\n"+
+				"1   Iphone 13       Cool         4500\n"+
+				"2   Macbook Air M1  Fast!        6800\n",
+			s.stdout.String(),
+		)
+	})
+
+	s.Run("Should sort the products by the given keys", func() {
+		s.SetupTest()
+		s.cli.
+			On("ListProducts", mock.Anything, domain.ListProductsFilter{
+				Sort: []domain.SortKey{{Field: domain.SortFieldPrice, Descending: true}, {Field: domain.SortFieldName}},
+			}).
+			Return([]domain.Product{}, nil)
+
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{"list", "-sort", "price:desc, name:asc"})
+
+		s.NoError(err)
+	})
+
+	s.Run("Should reject unknown sort directions", func() {
+		s.SetupTest()
+
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{"list", "-sort", "price:up"})
+
+		s.ErrorContains(err, `invalid-sort: unknown direction "up"`)
+		s.cli.AssertNotCalled(s.T(), "ListProducts", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should reject invalid ids", func() {
+		s.SetupTest()
+
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{"list", "-ids", "1,abc"})
+
+		s.Error(err)
+		s.cli.AssertNotCalled(s.T(), "ListProducts", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *ProductsCommandsSuite) Test_Get() {
+	s.Run("Should print the product as JSON", func() {
+		s.SetupTest()
+		s.cli.
+			On("GetProduct", mock.Anything, 1).
+			Return(domain.Product{ID: 1, Name: "Iphone 13", Description: "Cool", Price: 4500}, nil)
+
+		err := s.commands(OutputJSON, "").Run(context.Background(), []string{"get", "1"})
+
+		s.NoError(err)
+		s.JSONEq(`{"id":1,"name":"Iphone 13","description":"Cool","price":4500}`, s.stdout.String())
+	})
+
+	s.Run("Should print the product as YAML", func() {
+		s.SetupTest()
+		s.cli.
+			On("GetProduct", mock.Anything, 1).
+			Return(domain.Product{ID: 1, Name: "Iphone 13", Description: "Cool", Price: 4500}, nil)
+
+		err := s.commands(OutputYAML, "").Run(context.Background(), []string{"get", "1"})
+
+		s.NoError(err)
+		s.Equal("id: 1\nname: Iphone 13\ndescription: Cool\nprice: 4500\n", s.stdout.String())
+	})
+
+	s.Run("Should propagate not found errors", func() {
+		s.SetupTest()
+		s.cli.
+			On("GetProduct", mock.Anything, 2).
+			Return(domain.Product{}, domain.ErrProductNotFound)
+
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{"get", "2"})
+
+		s.ErrorIs(err, domain.ErrProductNotFound)
+	})
+
+	s.Run("Should require an id", func() {
+		s.SetupTest()
+
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{"get"})
+
+		s.Equal(ErrMissingID, err)
+	})
+}
+
+func (s *ProductsCommandsSuite) Test_RegisterAndUpdate() {
+	s.Run("Should register the product described by the flags", func() {
+		s.SetupTest()
+		product := domain.Product{Name: "Iphone 13", Description: "Cool", Price: 4500}
+		s.cli.
+			On("RegisterProduct", mock.Anything, product).
+			Return(domain.Product{ID: 7, Name: "Iphone 13", Description: "Cool", Price: 4500}, nil)
+
+		err := s.commands(OutputJSON, "").Run(context.Background(), []string{
+			"register", "-name", "Iphone 13", "-description", "Cool", "-price", "4500",
+		})
+
+		s.NoError(err)
+		s.JSONEq(`{"id":7,"name":"Iphone 13","description":"Cool","price":4500}`, s.stdout.String())
+	})
+
+	s.Run("Should update only the given fields of the product identified by the positional id", func() {
+		s.SetupTest()
+		patch := domain.ProductPatch{
+			Product: domain.Product{ID: 7, Name: "Iphone 13 Pro", Description: "Cooler", Price: 5500, Version: 2},
+			Fields: []domain.ProductField{
+				domain.ProductFieldDescription,
+				domain.ProductFieldName,
+				domain.ProductFieldPrice,
+			},
+		}
+		s.cli.
+			On("PatchProduct", mock.Anything, patch).
+			Return(domain.Product{ID: 7, Name: "Iphone 13 Pro", Description: "Cooler", Price: 5500, Version: 3}, nil)
+
+		err := s.commands(OutputJSON, "").Run(context.Background(), []string{
+			"update", "7", "-name", "Iphone 13 Pro", "-description", "Cooler", "-price", "5500", "-version", "2",
+		})
+
+		s.NoError(err)
+		s.JSONEq(`{"id":7,"name":"Iphone 13 Pro","description":"Cooler","price":5500,"version":3}`, s.stdout.String())
+	})
+
+	s.Run("Should require the version the update is based on", func() {
+		s.SetupTest()
+
+		err := s.commands(OutputJSON, "").Run(context.Background(), []string{"update", "7", "-name", "Iphone 13 Pro"})
+
+		s.ErrorIs(err, ErrMissingVersion)
+		s.cli.AssertNotCalled(s.T(), "PatchProduct", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *ProductsCommandsSuite) Test_Patch() {
+	s.Run("Should only patch the fields given as flags", func() {
+		s.SetupTest()
+		patch := domain.ProductPatch{
+			Product: domain.Product{ID: 7, Price: 5500, Version: 2},
+			Fields:  []domain.ProductField{domain.ProductFieldPrice},
+		}
+		s.cli.
+			On("PatchProduct", mock.Anything, patch).
+			Return(domain.Product{ID: 7, Name: "Iphone 13", Description: "Cool", Price: 5500, Version: 3}, nil)
+
+		err := s.commands(OutputJSON, "").Run(context.Background(), []string{
+			"patch", "7", "-price", "5500", "-version", "2",
+		})
+
+		s.NoError(err)
+		s.JSONEq(`{"id":7,"name":"Iphone 13","description":"Cool","price":5500,"version":3}`, s.stdout.String())
+	})
+
+	s.Run("Should patch a field explicitly set to an empty value", func() {
+		s.SetupTest()
+		patch := domain.ProductPatch{
+			Product: domain.Product{ID: 7, Version: 1},
+			Fields:  []domain.ProductField{domain.ProductFieldDescription},
+		}
+		s.cli.
+			On("PatchProduct", mock.Anything, patch).
+			Return(domain.Product{ID: 7, Name: "Iphone 13", Price: 5500}, nil)
+
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{"patch", "7", "-description", "", "-version", "1"})
+
+		s.NoError(err)
+		s.cli.AssertExpectations(s.T())
+	})
+}
+
+func (s *ProductsCommandsSuite) Test_Delete() {
+	s.Run("Should delete the product", func() {
+		s.SetupTest()
+		s.cli.On("DeleteProduct", mock.Anything, 3).Return(nil)
+
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{"delete", "3"})
+
+		s.NoError(err)
+		s.Equal("product 3 deleted\n", s.stdout.String())
+	})
+}
+
+func (s *ProductsCommandsSuite) Test_Trash() {
+	s.Run("Should list only the deleted products with their deletion time", func() {
+		s.SetupTest()
+		deletedAt := time.Date(2022, 4, 20, 10, 0, 0, 0, time.UTC)
+		s.cli.
+			On("ListProducts", mock.Anything, domain.ListProductsFilter{OnlyDeleted: true}).
+			Return([]domain.Product{{ID: 3, Name: "Ipad", Description: "Big", Price: 3000, Version: 2, DeletedAt: deletedAt}}, nil)
+
+		err := s.commands(OutputJSON, "").Run(context.Background(), []string{"list", "-trash"})
+
+		s.NoError(err)
+		s.JSONEq(`[
+			{"id":3,"name":"Ipad","description":"Big","price":3000,"version":2,"deleted_at":"2022-04-20T10:00:00Z"}
+		]`, s.stdout.String())
+	})
+
+	s.Run("Should restore the product", func() {
+		s.SetupTest()
+		s.cli.
+			On("RestoreProduct", mock.Anything, 3).
+			Return(domain.Product{ID: 3, Name: "Ipad", Description: "Big", Price: 3000, Version: 3}, nil)
+
+		err := s.commands(OutputJSON, "").Run(context.Background(), []string{"restore", "3"})
+
+		s.NoError(err)
+		s.JSONEq(`{"id":3,"name":"Ipad","description":"Big","price":3000,"version":3}`, s.stdout.String())
+	})
+}
+
+func (s *ProductsCommandsSuite) Test_History() {
+	s.Run("Should print the changes made to the product", func() {
+		s.SetupTest()
+		at := time.Date(2022, 4, 20, 10, 0, 0, 0, time.UTC)
+		s.cli.
+			On("GetProductHistory", mock.Anything, domain.ProductHistoryFilter{ProductID: 3, BeforeID: 9, Limit: 1}).
+			Return([]domain.AuditEntry{{
+				ID:        8,
+				ProductID: 3,
+				Action:    domain.AuditActionPatch,
+				Actor:     "jane",
+				Before:    &domain.Product{ID: 3, Name: "Ipad", Description: "Big", Price: 3000, Version: 1},
+				After:     &domain.Product{ID: 3, Name: "Ipad", Description: "Big", Price: 3200, Version: 2},
+				CreatedAt: at,
+			}}, nil)
+
+		err := s.commands(OutputJSON, "").Run(context.Background(), []string{"history", "3", "-limit", "1", "-before", "9"})
+
+		s.NoError(err)
+		s.JSONEq(`[{
+			"id":8,
+			"action":"patch",
+			"actor":"jane",
+			"before":{"id":3,"name":"Ipad","description":"Big","price":3000,"version":1},
+			"after":{"id":3,"name":"Ipad","description":"Big","price":3200,"version":2},
+			"created_at":"2022-04-20T10:00:00Z"
+		}]`, s.stdout.String())
+	})
+
+	s.Run("Should print the changes as a table", func() {
+		s.SetupTest()
+		at := time.Date(2022, 4, 20, 10, 0, 0, 0, time.UTC)
+		s.cli.
+			On("GetProductHistory", mock.Anything, domain.ProductHistoryFilter{ProductID: 3}).
+			Return([]domain.AuditEntry{{
+				ID:        7,
+				ProductID: 3,
+				Action:    domain.AuditActionRegister,
+				Actor:     "jane",
+				After:     &domain.Product{ID: 3, Name: "Ipad", Description: "Big", Price: 3000, Version: 1},
+				CreatedAt: at,
+			}}, nil)
+
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{"history", "3"})
+
+		s.NoError(err)
+		s.Equal("ID  ACTION    ACTOR  AT                    VERSION\n7   register  jane   2022-04-20T10:00:00Z  1\n", s.stdout.String())
+	})
+}
+
+func (s *ProductsCommandsSuite) Test_Facets() {
+	s.Run("Should count the products matching the filter flags", func() {
+		s.SetupTest()
+		s.cli.
+			On("GetProductFacets", mock.Anything, domain.FacetsFilter{
+				ProductFilter: domain.ProductFilter{
+					Category:   "phones",
+					PriceTo:    10000,
+					Attributes: map[string]string{"color": "black", "storage": "128GB"},
+				},
+				PriceBoundaries: []int{5000},
+			}).
+			Return(domain.ProductFacets{
+				Total:       1,
+				PriceRanges: []domain.PriceRangeBucket{{From: 0, To: 5000}, {From: 5000, Count: 1}},
+				Categories:  []domain.FacetBucket{{Value: "phones", Count: 1}},
+				Attributes: []domain.AttributeFacet{
+					{Name: "color", Values: []domain.FacetBucket{{Value: "black", Count: 1}}},
+				},
+			}, nil)
+
+		err := s.commands(OutputJSON, "").Run(context.Background(), []string{
+			"facets", "-category", "phones", "-max-price", "10000",
+			"-attributes", "color=black", "-attributes", "storage=128GB", "-boundaries", "5000",
+		})
+
+		s.NoError(err)
+		s.JSONEq(`{
+			"total":1,
+			"price_ranges":[{"from":0,"to":5000,"count":0},{"from":5000,"count":1}],
+			"categories":[{"value":"phones","count":1}],
+			"attributes":[{"name":"color","values":[{"value":"black","count":1}]}]
+		}`, s.stdout.String())
+	})
+
+	s.Run("Should print the facets as a table", func() {
+		s.SetupTest()
+		s.cli.
+			On("GetProductFacets", mock.Anything, domain.FacetsFilter{}).
+			Return(domain.ProductFacets{
+				Total:       2,
+				PriceRanges: []domain.PriceRangeBucket{{From: 0, To: 5000, Count: 2}, {From: 5000}},
+				Categories:  []domain.FacetBucket{{Value: "phones", Count: 2}},
+				Attributes: []domain.AttributeFacet{
+					{Name: "color", Values: []domain.FacetBucket{{Value: "black", Count: 2}}},
+				},
+			}, nil)
+
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{"facets"})
+
+		s.NoError(err)
+		s.Equal("2 products\n"+
+			"FACET     VALUE   COUNT\n"+
+			"price     0-5000  2\n"+
+			"price     5000+   0\n"+
+			"category  phones  2\n"+
+			"color     black   2\n", s.stdout.String())
+	})
+
+	s.Run("Should reject malformed attributes", func() {
+		s.SetupTest()
+
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{"facets", "-attributes", "black"})
+
+		s.ErrorContains(err, "it must be name=value")
+	})
+}
+
+func (s *ProductsCommandsSuite) Test_Search() {
+	s.Run("Should search the products with every word of the arguments", func() {
+		s.SetupTest()
+		s.cli.
+			On("SearchProducts", mock.Anything, domain.ProductSearch{
+				Query:  "macbook pro",
+				Limit:  5,
+				Offset: 10,
+				Sort:   []domain.SortKey{{Field: domain.SortFieldPrice}},
+			}).
+			Return([]domain.ProductSearchResult{{
+				Product:    domain.Product{ID: 2, Name: "Macbook Pro", Description: "Fast!", Price: 16500, Version: 1},
+				Score:      4,
+				Highlights: domain.ProductHighlights{Name: "<em>Macbook</em> <em>Pro</em>", Description: "Fast!"},
+			}}, nil)
+
+		err := s.commands(OutputJSON, "").Run(context.Background(), []string{"search", "-limit", "5", "-offset", "10", "-sort", "price", "macbook", "pro"})
+
+		s.NoError(err)
+		s.JSONEq(`[{
+			"product":{"id":2,"name":"Macbook Pro","description":"Fast!","price":16500,"version":1},
+			"score":4,
+			"highlights":{"name":"<em>Macbook</em> <em>Pro</em>","description":"Fast!"}
+		}]`, s.stdout.String())
+	})
+
+	s.Run("Should print the results as a table, showing where they matched", func() {
+		s.SetupTest()
+		s.cli.
+			On("SearchProducts", mock.Anything, domain.ProductSearch{Query: "macbook"}).
+			Return([]domain.ProductSearchResult{
+				{
+					Product:    domain.Product{ID: 2, Name: "Macbook Pro", Description: "Fast!"},
+					Score:      2,
+					Highlights: domain.ProductHighlights{Name: "<em>Macbook</em> Pro", Description: "Fast!"},
+				},
+				{
+					Product:    domain.Product{ID: 3, Name: "Mouse", Description: "For Macbook"},
+					Score:      1,
+					Highlights: domain.ProductHighlights{Name: "Mouse", Description: "For <em>Macbook</em>"},
+				},
+			}, nil)
+
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{"search", "macbook"})
+
+		s.NoError(err)
+		s.Equal("ID  NAME         SCORE  MATCH\n2   Macbook Pro  2.00   <em>Macbook</em> Pro\n3   Mouse        1.00   For <em>Macbook</em>\n", s.stdout.String())
+	})
+}
+
+func (s *ProductsCommandsSuite) Test_Import() {
+	s.Run("Should register the products read from NDJSON and print the outcome of each one", func() {
+		s.SetupTest()
+		products := []domain.Product{
+			{Name: "Iphone 13", Description: "Cool", Price: 4500},
+			{Name: "Macbook Air M1", Description: "Fast!", Price: 6800},
+		}
+		s.cli.
+			On("RegisterProducts", mock.Anything, products).
+			Return([]domain.ProductResult{
+				{Product: domain.Product{ID: 1, Name: "Iphone 13", Description: "Cool", Price: 4500}},
+				{Err: errors.New("storage-limit-reached")},
+			}, nil)
+
+		stdin := `{"name":"Iphone 13","description":"Cool","price":4500}
+{"name":"Macbook Air M1","description":"Fast!","price":6800}
+`
+		err := s.commands(OutputJSON, stdin).Run(context.Background(), []string{"import"})
+
+		s.NoError(err)
+		s.JSONEq(`[
+			{"index":0,"product":{"id":1,"name":"Iphone 13","description":"Cool","price":4500}},
+			{"index":1,"error":"storage-limit-reached"}
+		]`, s.stdout.String())
+	})
+
+	s.Run("Should register the products read from a JSON array file", func() {
+		s.SetupTest()
+		file := filepath.Join(s.T().TempDir(), "products.json")
+		s.Require().NoError(os.WriteFile(file, []byte(`[{"name":"Iphone 13","description":"Cool","price":4500}]`), 0o600))
+
+		products := []domain.Product{{Name: "Iphone 13", Description: "Cool", Price: 4500}}
+		s.cli.
+			On("RegisterProducts", mock.Anything, products).
+			Return([]domain.ProductResult{{Product: domain.Product{ID: 1, Name: "Iphone 13"}}}, nil)
+
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{"import", "-file", file})
+
+		s.NoError(err)
+		s.Contains(s.stdout.String(), "Iphone 13")
+	})
+}
+
+func (s *ProductsCommandsSuite) Test_Export() {
+	exported := func() {
+		s.cli.
+			On("ExportProducts", mock.Anything, mock.AnythingOfType("func(domain.Product) error")).
+			Run(func(args mock.Arguments) {
+				fn := args.Get(1).(func(domain.Product) error)
+				_ = fn(domain.Product{ID: 1, SKU: "IPH-13", Name: "Iphone 13", Description: "Cool", Price: 4500})
+				_ = fn(domain.Product{ID: 2, Name: "Macbook Air M1", Description: "Fast!", Price: 6800})
+			}).
+			Return(nil)
+	}
+
+	s.Run("Should write every exported product as NDJSON that import reads back", func() {
+		s.SetupTest()
+		exported()
+
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{"export"})
+
+		s.NoError(err)
+		s.Equal(`{"id":1,"sku":"IPH-13","name":"Iphone 13","description":"Cool","price":4500}
+{"id":2,"name":"Macbook Air M1","description":"Fast!","price":6800}
+`, s.stdout.String())
+
+		products, err := decodeProducts(strings.NewReader(s.stdout.String()))
+
+		s.NoError(err)
+		s.Equal([]domain.Product{
+			{ID: 1, SKU: "IPH-13", Name: "Iphone 13", Description: "Cool", Price: 4500},
+			{ID: 2, Name: "Macbook Air M1", Description: "Fast!", Price: 6800},
+		}, products)
+	})
+
+	s.Run("Should write the exported products as a table when asked to", func() {
+		s.SetupTest()
+		exported()
+
+		err := s.commands(OutputJSON, "").Run(context.Background(), []string{"export", "-format", "table"})
+
+		s.NoError(err)
+		s.Contains(s.stdout.String(), "ID  NAME")
+		s.Contains(s.stdout.String(), "Macbook Air M1")
+	})
+
+	s.Run("Should reject an unknown format", func() {
+		s.SetupTest()
+
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{"export", "-format", "csv"})
+
+		s.ErrorIs(err, ErrInvalidOutput)
+		s.cli.AssertNotCalled(s.T(), "ExportProducts", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *ProductsCommandsSuite) Test_ImportCatalog() {
+	s.Run("Should import the catalog with the format matching the file extension", func() {
+		s.SetupTest()
+		file := filepath.Join(s.T().TempDir(), "catalog.csv")
+		s.Require().NoError(os.WriteFile(file, []byte("name,price\nIphone 13,4500\n"), 0o600))
+
+		s.cli.
+			On("ImportCatalog", mock.Anything, mock.Anything, domain.ImportOptions{
+				Format: domain.CatalogFormatCSV,
+				Mode:   domain.ImportModeUpsertBySKU,
+				DryRun: true,
+			}).
+			Return(domain.ImportReport{DryRun: true, Created: 1}, nil)
+
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{
+			"import-catalog", "-file", file, "-mode", "upsert-by-sku", "-dry-run",
+		})
+
+		s.NoError(err)
+		s.Equal("dry-run: 1 created, 0 updated, 0 failed\n", s.stdout.String())
+	})
+
+	s.Run("Should print the failed rows and fail", func() {
+		s.SetupTest()
+		s.cli.
+			On("ImportCatalog", mock.Anything, mock.Anything, domain.ImportOptions{
+				Format: domain.CatalogFormatNDJSON,
+				Mode:   domain.ImportModeCreate,
+			}).
+			Return(domain.ImportReport{
+				Created: 1,
+				Failed:  1,
+				Failures: []domain.ImportRowError{
+					{Line: 2, Product: domain.Product{SKU: "IPH-13", Name: "Iphone 13"}, Err: domain.ErrDuplicatedImportKey},
+				},
+			}, nil)
+
+		err := s.commands(OutputJSON, "").Run(context.Background(), []string{"import-catalog"})
+
+		s.ErrorIs(err, ErrImportFailed)
+		s.JSONEq(`{
+			"dry_run": false, "created": 1, "updated": 0, "failed": 1,
+			"failures": [{"line": 2, "sku": "IPH-13", "name": "Iphone 13", "error": "duplicated-import-key"}]
+		}`, s.stdout.String())
+	})
+}
+
+func (s *ProductsCommandsSuite) Test_ExportCatalog() {
+	s.Run("Should write the catalog in the requested format", func() {
+		s.SetupTest()
+		s.cli.
+			On("ExportCatalog", mock.Anything, mock.Anything, domain.CatalogFormatCSV).
+			Run(func(args mock.Arguments) {
+				_, _ = args.Get(1).(io.Writer).Write([]byte("id,sku,name,description,price\n"))
+			}).
+			Return(nil)
+
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{"export-catalog", "-format", "csv"})
+
+		s.NoError(err)
+		s.Equal("id,sku,name,description,price\n", s.stdout.String())
+	})
+}
+
+func (s *ProductsCommandsSuite) Test_Images() {
+	image := domain.ProductImage{
+		ID: 3, ProductID: 1, Position: 0, URL: "http://localhost:8080/media/products/1/a.png", ContentType: "image/png",
+		Width: 800, Height: 600, Size: 1024, AltText: "Front", CreatedAt: time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC),
+		Thumbnails: []domain.Thumbnail{{Name: "small", URL: "http://localhost:8080/media/products/1/a_small.png", Width: 150, Height: 112}},
+	}
+
+	s.Run("Should list the images of the product", func() {
+		s.SetupTest()
+		s.cli.
+			On("ListProductImages", mock.Anything, []int{1}).
+			Return([]domain.ProductImage{image}, nil)
+
+		err := s.commands(OutputJSON, "").Run(context.Background(), []string{"images", "1"})
+
+		s.NoError(err)
+		s.JSONEq(`[{
+			"id": 3, "product_id": 1, "position": 0, "url": "http://localhost:8080/media/products/1/a.png",
+			"content_type": "image/png", "width": 800, "height": 600, "size": 1024, "alt_text": "Front",
+			"thumbnails": [{"name": "small", "url": "http://localhost:8080/media/products/1/a_small.png", "width": 150, "height": 112}],
+			"created_at": "2022-03-01T12:00:00Z"
+		}]`, s.stdout.String())
+	})
+
+	s.Run("Should upload the image read from the file", func() {
+		s.SetupTest()
+		s.cli.
+			On("UploadProductImage", mock.Anything, domain.ImageUpload{ProductID: 1, AltText: "Front"}, mock.Anything).
+			Run(func(args mock.Arguments) {
+				content, _ := io.ReadAll(args.Get(2).(io.Reader))
+				s.Equal("png", string(content))
+			}).
+			Return(image, nil)
+
+		err := s.commands(OutputTable, "png").Run(context.Background(), []string{"upload-image", "1", "-file", "-", "-alt", "Front"})
+
+		s.NoError(err)
+		s.Contains(s.stdout.String(), "800x600")
+	})
+
+	s.Run("Should require the file to upload", func() {
+		s.SetupTest()
+
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{"upload-image", "1"})
+
+		s.Equal(ErrMissingFile, err)
+	})
+
+	s.Run("Should delete the image", func() {
+		s.SetupTest()
+		s.cli.On("DeleteProductImage", mock.Anything, 3).Return(nil)
+
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{"delete-image", "3"})
+
+		s.NoError(err)
+		s.Equal("image 3 deleted\n", s.stdout.String())
+	})
+
+	s.Run("Should reorder the images of the product", func() {
+		s.SetupTest()
+		s.cli.
+			On("ReorderProductImages", mock.Anything, 1, []int{3, 1, 2}).
+			Return([]domain.ProductImage{image}, nil)
+
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{"reorder-images", "1", "-order", "3, 1,2"})
+
+		s.NoError(err)
+		s.cli.AssertExpectations(s.T())
+	})
+
+	s.Run("Should reject invalid image ids", func() {
+		s.SetupTest()
+
+		err := s.commands(OutputTable, "").Run(context.Background(), []string{"reorder-images", "1", "-order", "3,x"})
+
+		s.ErrorContains(err, "invalid image id")
+	})
+}
+
+func TestProductsCommandsSuite(t *testing.T) {
+	suite.Run(t, new(ProductsCommandsSuite))
+}
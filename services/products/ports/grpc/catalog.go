@@ -0,0 +1,197 @@
+package grpc_port
+
+import (
+	"errors"
+	"io"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	pb "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrMissingImportOptions   = status.Error(codes.InvalidArgument, "the first message must carry the import options")
+	ErrDuplicatedImportOption = status.Error(codes.InvalidArgument, "the import options must be sent only once")
+)
+
+// ImportCatalog reads a catalog file streamed in chunks, after an initial message with the
+// import options, and replies with the import report
+func (r *ProductsResolver) ImportCatalog(stream pb.ProductsService_ImportCatalogServer) error {
+	ctx, span := r.Tracer.Start(stream.Context(), "resolver.ImportCatalog")
+	defer span.End()
+
+	req, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return ErrMissingImportOptions
+	}
+
+	if err != nil {
+		r.Logger.Debug("failed to receive the import options", zap.Error(err))
+		return err
+	}
+
+	pbOptions := req.GetOptions()
+	if pbOptions == nil {
+		return ErrMissingImportOptions
+	}
+
+	options := domain.ImportOptions{
+		Format: domain.CatalogFormat(pbOptions.Format),
+		Mode:   domain.ImportMode(pbOptions.Mode),
+		DryRun: pbOptions.DryRun,
+	}
+
+	r.Logger.Info("importing a catalog", zap.Any("options", options))
+
+	report, err := r.App.ImportCatalog(ctx, &chunkReader{stream: stream}, options)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+
+		if isInvalidCatalog(err) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		r.Logger.Sugar().Errorw(
+			"failed to import the catalog",
+			zap.Error(err),
+			zap.Any("options", options),
+		)
+
+		return InternalServerError
+	}
+
+	return stream.SendAndClose(toImportResponse(report))
+}
+
+// ExportCatalog streams every Product as a catalog file, split in chunks
+func (r *ProductsResolver) ExportCatalog(req *pb.ExportCatalogRequest, stream pb.ProductsService_ExportCatalogServer) error {
+	ctx, span := r.Tracer.Start(stream.Context(), "resolver.ExportCatalog")
+	defer span.End()
+
+	format := domain.CatalogFormat(req.Format)
+	if err := format.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	r.Logger.Info("exporting the catalog", zap.String("format", req.Format))
+
+	err := r.App.ExportCatalog(ctx, chunkWriter{stream: stream}, format)
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+
+		r.Logger.Sugar().Errorw(
+			"failed to export the catalog",
+			zap.Error(err),
+			zap.String("format", req.Format),
+		)
+
+		return InternalServerError
+	}
+
+	return nil
+}
+
+// chunkReader reads the chunks streamed by ImportCatalog as a single catalog file
+type chunkReader struct {
+	stream  pb.ProductsService_ImportCatalogServer
+	pending []byte
+}
+
+func (r *chunkReader) Read(data []byte) (int, error) {
+	for len(r.pending) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+
+		if req.GetOptions() != nil {
+			return 0, ErrDuplicatedImportOption
+		}
+
+		r.pending = req.GetChunk()
+	}
+
+	n := copy(data, r.pending)
+	r.pending = r.pending[n:]
+
+	return n, nil
+}
+
+// chunkWriter sends everything written into it as CatalogChunks
+type chunkWriter struct {
+	stream pb.ProductsService_ExportCatalogServer
+}
+
+func (w chunkWriter) Write(data []byte) (int, error) {
+	if err := w.stream.Send(&pb.CatalogChunk{Data: data}); err != nil {
+		return 0, err
+	}
+
+	return len(data), nil
+}
+
+func isInvalidCatalog(err error) bool {
+	for _, target := range []error{
+		domain.ErrInvalidCatalogFormat,
+		domain.ErrInvalidImportMode,
+		domain.ErrMissingCatalogColumn,
+		domain.ErrUnknownCatalogColumn,
+		domain.ErrDuplicatedCatalogColumn,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toImportResponse(report domain.ImportReport) *pb.ImportResponse {
+	response := &pb.ImportResponse{
+		DryRun:   report.DryRun,
+		Created:  int32(report.Created),
+		Updated:  int32(report.Updated),
+		Failed:   int32(report.Failed),
+		Failures: make([]*pb.ImportFailure, 0, len(report.Failures)),
+	}
+
+	for _, failure := range report.Failures {
+		item := &pb.ImportFailure{
+			Line:  int32(failure.Line),
+			Error: failure.Err.Error(),
+			Data: &pb.Product{
+				Id:          int32(failure.Product.ID),
+				Sku:         failure.Product.SKU,
+				Version:     int32(failure.Product.Version),
+				Name:        failure.Product.Name,
+				Description: failure.Product.Description,
+				Price:       int32(failure.Product.Price),
+				Category:    failure.Product.Category,
+				Attributes:  failure.Product.Attributes,
+				TaxClass:    failure.Product.TaxClass,
+				Weight:      int32(failure.Product.Weight),
+				Dimensions:  toProtoDimensions(failure.Product.Dimensions),
+			},
+		}
+
+		var validationErr *domain.ValidationError
+		if errors.As(failure.Err, &validationErr) {
+			for _, violation := range validationErr.Violations {
+				item.Violations = append(item.Violations, &pb.FieldViolation{
+					Field:       violation.Field,
+					Description: violation.Description,
+				})
+			}
+		}
+
+		response.Failures = append(response.Failures, item)
+	}
+
+	return response
+}
@@ -0,0 +1,155 @@
+package grpc_port
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	protog "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+)
+
+func (s *ProductsResolverSuite) Test_ImportCatalog() {
+	s.Run("Should reject streams that don't start with the import options", func() {
+		ctx := context.Background()
+
+		stream, err := s.grpcClient.ImportCatalog(ctx)
+		s.Require().NoError(err)
+
+		s.Require().NoError(stream.Send(&protog.ImportRequest{
+			Payload: &protog.ImportRequest_Chunk{Chunk: []byte("name,price\n")},
+		}))
+
+		_, err = stream.CloseAndRecv()
+
+		s.Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	s.Run("Should reject invalid catalogs", func() {
+		ctx := context.Background()
+		options := domain.ImportOptions{Format: domain.CatalogFormatCSV, Mode: domain.ImportModeCreate}
+
+		s.app.
+			On("ImportCatalog", mock.AnythingOfType("*context.valueCtx"), mock.Anything, options).
+			Return(domain.ImportReport{}, domain.ErrMissingCatalogColumn).
+			Once()
+
+		stream, err := s.grpcClient.ImportCatalog(ctx)
+		s.Require().NoError(err)
+
+		s.Require().NoError(stream.Send(&protog.ImportRequest{
+			Payload: &protog.ImportRequest_Options{Options: &protog.ImportOptions{Format: "csv", Mode: "create"}},
+		}))
+
+		_, err = stream.CloseAndRecv()
+
+		s.Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	s.Run("Should import the chunks in the order they were streamed and reply with the report", func() {
+		ctx := context.Background()
+		options := domain.ImportOptions{Format: domain.CatalogFormatNDJSON, Mode: domain.ImportModeUpsertBySKU, DryRun: true}
+		content := "{\"sku\":\"IPH-13\",\"name\":\"Iphone 13\",\"price\":4500}\n{\"name\":\"\",\"price\":1}\n"
+
+		var received []byte
+		s.app.
+			On("ImportCatalog", mock.AnythingOfType("*context.valueCtx"), mock.Anything, options).
+			Run(func(args mock.Arguments) {
+				received, _ = io.ReadAll(args.Get(1).(io.Reader))
+			}).
+			Return(domain.ImportReport{
+				DryRun:  true,
+				Created: 1,
+				Failed:  1,
+				Failures: []domain.ImportRowError{
+					{
+						Line:    2,
+						Product: domain.Product{Price: 1},
+						Err: &domain.ValidationError{Violations: []domain.FieldViolation{
+							{Field: "name", Description: "must not be empty"},
+						}},
+					},
+				},
+			}, nil).
+			Once()
+
+		stream, err := s.grpcClient.ImportCatalog(ctx)
+		s.Require().NoError(err)
+
+		s.Require().NoError(stream.Send(&protog.ImportRequest{
+			Payload: &protog.ImportRequest_Options{Options: &protog.ImportOptions{Format: "ndjson", Mode: "upsert-by-sku", DryRun: true}},
+		}))
+
+		for _, chunk := range []string{content[:10], content[10:30], content[30:]} {
+			s.Require().NoError(stream.Send(&protog.ImportRequest{
+				Payload: &protog.ImportRequest_Chunk{Chunk: []byte(chunk)},
+			}))
+		}
+
+		got, err := stream.CloseAndRecv()
+
+		s.Require().NoError(err)
+		s.Equal(content, string(received))
+		s.True(proto.Equal(&protog.ImportResponse{
+			DryRun:  true,
+			Created: 1,
+			Failed:  1,
+			Failures: []*protog.ImportFailure{
+				{
+					Line:       2,
+					Data:       &protog.Product{Price: 1},
+					Error:      "invalid-product: name must not be empty",
+					Violations: []*protog.FieldViolation{{Field: "name", Description: "must not be empty"}},
+				},
+			},
+		}, got))
+	})
+}
+
+func (s *ProductsResolverSuite) Test_ExportCatalog() {
+	s.Run("Should reject unknown formats", func() {
+		ctx := context.Background()
+
+		stream, err := s.grpcClient.ExportCatalog(ctx, &protog.ExportCatalogRequest{Format: "xml"})
+		s.Require().NoError(err)
+
+		_, err = stream.Recv()
+
+		s.Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	s.Run("Should stream the catalog written by the application", func() {
+		ctx := context.Background()
+		content := "id,sku,name,description,price\n1,IPH-13,Iphone 13,Cool,4500\n"
+
+		s.app.
+			On("ExportCatalog", mock.AnythingOfType("*context.valueCtx"), mock.Anything, domain.CatalogFormatCSV).
+			Run(func(args mock.Arguments) {
+				_, _ = args.Get(1).(io.Writer).Write([]byte(content))
+			}).
+			Return(nil).
+			Once()
+
+		stream, err := s.grpcClient.ExportCatalog(ctx, &protog.ExportCatalogRequest{Format: "csv"})
+		s.Require().NoError(err)
+
+		var got bytes.Buffer
+		for {
+			chunk, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			s.Require().NoError(err)
+			got.Write(chunk.Data)
+		}
+
+		s.Equal(content, got.String())
+	})
+}
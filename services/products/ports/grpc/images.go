@@ -0,0 +1,214 @@
+package grpc_port
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	pb "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	ErrMissingImageMetadata    = status.Error(codes.InvalidArgument, "the first message must carry the image metadata")
+	ErrDuplicatedImageMetadata = status.Error(codes.InvalidArgument, "the image metadata must be sent only once")
+)
+
+// UploadImage reads an image streamed in chunks, after an initial message with its metadata,
+// and replies with the stored image
+func (r *ProductsResolver) UploadImage(stream pb.ProductsService_UploadImageServer) error {
+	ctx, span := r.Tracer.Start(stream.Context(), "resolver.UploadImage")
+	defer span.End()
+
+	req, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return ErrMissingImageMetadata
+	}
+
+	if err != nil {
+		r.Logger.Debug("failed to receive the image metadata", zap.Error(err))
+		return err
+	}
+
+	metadata := req.GetMetadata()
+	if metadata == nil {
+		return ErrMissingImageMetadata
+	}
+
+	upload := domain.ImageUpload{
+		ProductID: int(metadata.ProductId),
+		AltText:   metadata.AltText,
+	}
+
+	r.Logger.Info("uploading a product image", zap.Any("upload", upload))
+
+	image, err := r.App.UploadProductImage(ctx, upload, &imageChunkReader{stream: stream})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+
+		switch {
+		case errors.Is(err, domain.ErrProductNotFound):
+			return status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, domain.ErrInvalidAltText),
+			errors.Is(err, domain.ErrImageTooLarge),
+			errors.Is(err, domain.ErrUnsupportedImageType),
+			errors.Is(err, domain.ErrInvalidImage):
+			return status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, domain.ErrTooManyImages):
+			return status.Error(codes.FailedPrecondition, err.Error())
+		}
+
+		r.Logger.Sugar().Errorw(
+			"failed to upload the product image",
+			zap.Error(err),
+			zap.Any("upload", upload),
+		)
+
+		return InternalServerError
+	}
+
+	return stream.SendAndClose(&pb.UploadImageResponse{Data: toProtoProductImage(image)})
+}
+
+func (r *ProductsResolver) ListImages(ctx context.Context, req *pb.ListImagesRequest) (*pb.ListImagesResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.ListImages")
+	defer span.End()
+
+	productIDs := make([]int, 0, len(req.ProductIds))
+	for _, id := range req.ProductIds {
+		productIDs = append(productIDs, int(id))
+	}
+
+	images, err := r.App.ListProductImages(ctx, productIDs)
+	if err != nil {
+		r.Logger.Sugar().Errorw(
+			"failed to list the product images",
+			zap.Error(err),
+			zap.Ints("productIds", productIDs),
+		)
+
+		return nil, InternalServerError
+	}
+
+	return &pb.ListImagesResponse{Data: toProtoProductImages(images)}, nil
+}
+
+func (r *ProductsResolver) DeleteImage(ctx context.Context, req *pb.DeleteImageRequest) (*pb.DeleteImageResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.DeleteImage")
+	defer span.End()
+
+	imageId := int(req.Id)
+
+	err := r.App.DeleteProductImage(ctx, imageId)
+	if err != nil {
+		if errors.Is(err, domain.ErrImageNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+
+		r.Logger.Sugar().Errorw(
+			"failed to delete the product image",
+			zap.Error(err),
+			zap.Int("imageId", imageId),
+		)
+
+		return nil, InternalServerError
+	}
+
+	return &pb.DeleteImageResponse{Data: "Image deleted successfully"}, nil
+}
+
+func (r *ProductsResolver) ReorderImages(ctx context.Context, req *pb.ReorderImagesRequest) (*pb.ReorderImagesResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.ReorderImages")
+	defer span.End()
+
+	productId := int(req.ProductId)
+	imageIDs := make([]int, 0, len(req.ImageIds))
+	for _, id := range req.ImageIds {
+		imageIDs = append(imageIDs, int(id))
+	}
+
+	images, err := r.App.ReorderProductImages(ctx, productId, imageIDs)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidImageOrder) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		r.Logger.Sugar().Errorw(
+			"failed to reorder the product images",
+			zap.Error(err),
+			zap.Int("productId", productId),
+			zap.Ints("imageIds", imageIDs),
+		)
+
+		return nil, InternalServerError
+	}
+
+	return &pb.ReorderImagesResponse{Data: toProtoProductImages(images)}, nil
+}
+
+// imageChunkReader reads the chunks streamed by UploadImage as a single image file
+type imageChunkReader struct {
+	stream  pb.ProductsService_UploadImageServer
+	pending []byte
+}
+
+func (r *imageChunkReader) Read(data []byte) (int, error) {
+	for len(r.pending) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+
+		if req.GetMetadata() != nil {
+			return 0, ErrDuplicatedImageMetadata
+		}
+
+		r.pending = req.GetChunk()
+	}
+
+	n := copy(data, r.pending)
+	r.pending = r.pending[n:]
+
+	return n, nil
+}
+
+func toProtoProductImages(images []domain.ProductImage) []*pb.ProductImage {
+	response := make([]*pb.ProductImage, 0, len(images))
+	for _, image := range images {
+		response = append(response, toProtoProductImage(image))
+	}
+
+	return response
+}
+
+func toProtoProductImage(image domain.ProductImage) *pb.ProductImage {
+	thumbnails := make([]*pb.Thumbnail, 0, len(image.Thumbnails))
+	for _, thumbnail := range image.Thumbnails {
+		thumbnails = append(thumbnails, &pb.Thumbnail{
+			Name:   thumbnail.Name,
+			Url:    thumbnail.URL,
+			Width:  int32(thumbnail.Width),
+			Height: int32(thumbnail.Height),
+		})
+	}
+
+	return &pb.ProductImage{
+		Id:          int32(image.ID),
+		ProductId:   int32(image.ProductID),
+		Position:    int32(image.Position),
+		Url:         image.URL,
+		ContentType: image.ContentType,
+		Width:       int32(image.Width),
+		Height:      int32(image.Height),
+		Size:        int32(image.Size),
+		AltText:     image.AltText,
+		Thumbnails:  thumbnails,
+		CreatedAt:   timestamppb.New(image.CreatedAt),
+	}
+}
@@ -0,0 +1,165 @@
+package grpc_port
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/lucasmls/ecommerce/services/products/domain"
+	protog "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+)
+
+func (s *ProductsResolverSuite) Test_UploadImage() {
+	s.Run("Should reject streams that don't start with the image metadata", func() {
+		stream, err := s.grpcClient.UploadImage(context.Background())
+		s.Require().NoError(err)
+
+		s.Require().NoError(stream.Send(&protog.UploadImageRequest{
+			Payload: &protog.UploadImageRequest_Chunk{Chunk: []byte("png")},
+		}))
+
+		_, err = stream.CloseAndRecv()
+
+		s.Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	s.Run("Should map the upload errors to their status codes", func() {
+		for err, code := range map[error]codes.Code{
+			domain.ErrProductNotFound:      codes.NotFound,
+			domain.ErrUnsupportedImageType: codes.InvalidArgument,
+			domain.ErrImageTooLarge:        codes.InvalidArgument,
+			domain.ErrTooManyImages:        codes.FailedPrecondition,
+		} {
+			upload := domain.ImageUpload{ProductID: 9, AltText: err.Error()}
+			s.app.
+				On("UploadProductImage", mock.AnythingOfType("*context.valueCtx"), upload, mock.Anything).
+				Return(domain.ProductImage{}, err).
+				Once()
+
+			stream, streamErr := s.grpcClient.UploadImage(context.Background())
+			s.Require().NoError(streamErr)
+
+			s.Require().NoError(stream.Send(&protog.UploadImageRequest{
+				Payload: &protog.UploadImageRequest_Metadata{Metadata: &protog.UploadImageMetadata{ProductId: 9, AltText: err.Error()}},
+			}))
+
+			_, streamErr = stream.CloseAndRecv()
+
+			s.Equal(code, status.Code(streamErr), err)
+		}
+	})
+
+	s.Run("Should upload the chunks in the order they were streamed and reply with the stored image", func() {
+		upload := domain.ImageUpload{ProductID: 1, AltText: "Front"}
+		createdAt := time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+
+		var received []byte
+		s.app.
+			On("UploadProductImage", mock.AnythingOfType("*context.valueCtx"), upload, mock.Anything).
+			Run(func(args mock.Arguments) {
+				received, _ = io.ReadAll(args.Get(2).(io.Reader))
+			}).
+			Return(domain.ProductImage{
+				ID: 3, ProductID: 1, Position: 2, URL: "/media/products/1/a.png", ContentType: "image/png",
+				Width: 800, Height: 600, Size: 7, AltText: "Front", CreatedAt: createdAt,
+				Thumbnails: []domain.Thumbnail{{Name: "small", URL: "/media/products/1/a_small.png", Width: 150, Height: 112}},
+			}, nil).
+			Once()
+
+		stream, err := s.grpcClient.UploadImage(context.Background())
+		s.Require().NoError(err)
+
+		s.Require().NoError(stream.Send(&protog.UploadImageRequest{
+			Payload: &protog.UploadImageRequest_Metadata{Metadata: &protog.UploadImageMetadata{ProductId: 1, AltText: "Front"}},
+		}))
+
+		for _, chunk := range []string{"png", "-data"} {
+			s.Require().NoError(stream.Send(&protog.UploadImageRequest{
+				Payload: &protog.UploadImageRequest_Chunk{Chunk: []byte(chunk)},
+			}))
+		}
+
+		got, err := stream.CloseAndRecv()
+
+		s.NoError(err)
+		s.Equal("png-data", string(received))
+		s.True(proto.Equal(&protog.ProductImage{
+			Id: 3, ProductId: 1, Position: 2, Url: "/media/products/1/a.png", ContentType: "image/png",
+			Width: 800, Height: 600, Size: 7, AltText: "Front", CreatedAt: timestamppb.New(createdAt),
+			Thumbnails: []*protog.Thumbnail{{Name: "small", Url: "/media/products/1/a_small.png", Width: 150, Height: 112}},
+		}, got.Data), got.Data)
+	})
+}
+
+func (s *ProductsResolverSuite) Test_ListImages() {
+	s.Run("Should list the images of the products", func() {
+		s.app.
+			On("ListProductImages", mock.AnythingOfType("*context.valueCtx"), []int{1, 2}).
+			Return([]domain.ProductImage{{ID: 1, ProductID: 1}, {ID: 2, ProductID: 2}}, nil).
+			Once()
+
+		got, err := s.grpcClient.ListImages(context.Background(), &protog.ListImagesRequest{ProductIds: []int32{1, 2}})
+
+		s.NoError(err)
+		s.Require().Len(got.Data, 2)
+		s.Equal([]int32{1, 2}, []int32{got.Data[0].ProductId, got.Data[1].ProductId})
+	})
+}
+
+func (s *ProductsResolverSuite) Test_DeleteImage() {
+	s.Run("Should return not found error in case the image isn't stored", func() {
+		s.app.
+			On("DeleteProductImage", mock.AnythingOfType("*context.valueCtx"), 404).
+			Return(domain.ErrImageNotFound).
+			Once()
+
+		_, err := s.grpcClient.DeleteImage(context.Background(), &protog.DeleteImageRequest{Id: 404})
+
+		s.Equal(codes.NotFound, status.Code(err))
+	})
+
+	s.Run("Should delete the image", func() {
+		s.app.
+			On("DeleteProductImage", mock.AnythingOfType("*context.valueCtx"), 3).
+			Return(nil).
+			Once()
+
+		got, err := s.grpcClient.DeleteImage(context.Background(), &protog.DeleteImageRequest{Id: 3})
+
+		s.NoError(err)
+		s.Equal("Image deleted successfully", got.Data)
+	})
+}
+
+func (s *ProductsResolverSuite) Test_ReorderImages() {
+	s.Run("Should reject orders that don't list every image of the product", func() {
+		s.app.
+			On("ReorderProductImages", mock.AnythingOfType("*context.valueCtx"), 1, []int{2}).
+			Return(nil, domain.ErrInvalidImageOrder).
+			Once()
+
+		_, err := s.grpcClient.ReorderImages(context.Background(), &protog.ReorderImagesRequest{ProductId: 1, ImageIds: []int32{2}})
+
+		s.Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	s.Run("Should reply with the reordered images", func() {
+		s.app.
+			On("ReorderProductImages", mock.AnythingOfType("*context.valueCtx"), 1, []int{2, 1}).
+			Return([]domain.ProductImage{{ID: 2, ProductID: 1, Position: 0}, {ID: 1, ProductID: 1, Position: 1}}, nil).
+			Once()
+
+		got, err := s.grpcClient.ReorderImages(context.Background(), &protog.ReorderImagesRequest{ProductId: 1, ImageIds: []int32{2, 1}})
+
+		s.NoError(err)
+		s.Require().Len(got.Data, 2)
+		s.Equal([]int32{2, 1}, []int32{got.Data[0].Id, got.Data[1].Id})
+		s.Equal([]int32{0, 1}, []int32{got.Data[0].Position, got.Data[1].Position})
+	})
+}
@@ -3,19 +3,35 @@ package grpc_port
 import (
 	"context"
 	"errors"
+	"io"
+	"time"
 
 	"github.com/lucasmls/ecommerce/services/products/domain"
 	pb "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var (
 	InternalServerError = status.Error(codes.Internal, "Internal server error")
 )
 
+const (
+	// bulkRegisterBatchSize is the amount of streamed Products registered at once by BulkRegister
+	bulkRegisterBatchSize = 100
+	// bulkRegisterInternalError is the error of a batch item that failed for a reason the clients shouldn't see
+	bulkRegisterInternalError = "internal error"
+
+	// defaultExportPageSize is the page size used by Export when the client doesn't provide one
+	defaultExportPageSize = 100
+	// maxExportPageSize is the biggest page size Export accepts
+	maxExportPageSize = 1000
+)
+
 var (
 	ErrMissingLogger = errors.New("missing required dependency: Logger")
 	ErrMisingTracer  = errors.New("missing required dependency: Tracer")
@@ -51,7 +67,7 @@ func NewProductsResolver(
 }
 
 // MustNewProductsResolver creates a new ProductsResolver instance.
-//It panics if any error is found
+// It panics if any error is found
 func MustNewProductsResolver(
 	logger *zap.Logger,
 	tracer trace.Tracer,
@@ -69,7 +85,12 @@ func (r *ProductsResolver) List(ctx context.Context, req *pb.ListRequest) (*pb.L
 	ctx, span := r.Tracer.Start(ctx, "resolver.List")
 	defer span.End()
 
-	var filter domain.ListProductsFilter
+	filter := domain.ListProductsFilter{
+		ProductFilter:  toDomainProductFilter(req.Filter),
+		IncludeDeleted: req.IncludeDeleted,
+		OnlyDeleted:    req.OnlyDeleted,
+		Sort:           toDomainSortKeys(req.Sort),
+	}
 	for _, id := range req.Ids {
 		filter.IDs = append(filter.IDs, int(id))
 	}
@@ -78,6 +99,10 @@ func (r *ProductsResolver) List(ctx context.Context, req *pb.ListRequest) (*pb.L
 
 	products, err := r.App.ListProducts(ctx, filter)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPriceRange) || errors.Is(err, domain.ErrInvalidSort) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
 		r.Logger.Sugar().Errorw(
 			"failed to list products",
 			zap.Error(err),
@@ -94,9 +119,17 @@ func (r *ProductsResolver) List(ctx context.Context, req *pb.ListRequest) (*pb.L
 	for _, product := range products {
 		response.Data = append(response.Data, &pb.Product{
 			Id:          int32(product.ID),
+			Sku:         product.SKU,
+			Version:     int32(product.Version),
 			Name:        product.Name,
 			Description: product.Description,
 			Price:       int32(product.Price),
+			Category:    product.Category,
+			Attributes:  product.Attributes,
+			TaxClass:    product.TaxClass,
+			Weight:      int32(product.Weight),
+			Dimensions:  toProtoDimensions(product.Dimensions),
+			DeletedAt:   toProtoDeletedAt(product.DeletedAt),
 		})
 	}
 
@@ -111,11 +144,22 @@ func (r *ProductsResolver) Register(ctx context.Context, req *pb.Product) (*pb.R
 
 	product, err := r.App.RegisterProduct(ctx, domain.Product{
 		ID:          int(req.Id),
+		SKU:         req.Sku,
+		Version:     int(req.Version),
 		Name:        req.Name,
 		Description: req.Description,
 		Price:       int(req.Price),
+		Category:    req.Category,
+		Attributes:  req.Attributes,
+		TaxClass:    req.TaxClass,
+		Weight:      int(req.Weight),
+		Dimensions:  toDomainDimensions(req.Dimensions),
 	})
 	if err != nil {
+		if errors.Is(err, domain.ErrProductSKUAlreadyExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+
 		r.Logger.Sugar().Errorw(
 			"failed to register the provided product",
 			zap.Error(err),
@@ -128,9 +172,16 @@ func (r *ProductsResolver) Register(ctx context.Context, req *pb.Product) (*pb.R
 	response := &pb.RegisterResponse{
 		Data: &pb.Product{
 			Id:          int32(product.ID),
+			Sku:         product.SKU,
+			Version:     int32(product.Version),
 			Name:        product.Name,
 			Description: product.Description,
 			Price:       int32(product.Price),
+			Category:    product.Category,
+			Attributes:  product.Attributes,
+			TaxClass:    product.TaxClass,
+			Weight:      int32(product.Weight),
+			Dimensions:  toProtoDimensions(product.Dimensions),
 		},
 	}
 
@@ -145,11 +196,22 @@ func (r *ProductsResolver) Update(ctx context.Context, req *pb.Product) (*pb.Upd
 
 	product, err := r.App.UpdateProduct(ctx, domain.Product{
 		ID:          int(req.Id),
+		SKU:         req.Sku,
+		Version:     int(req.Version),
 		Name:        req.Name,
 		Description: req.Description,
 		Price:       int(req.Price),
+		Category:    req.Category,
+		Attributes:  req.Attributes,
+		TaxClass:    req.TaxClass,
+		Weight:      int(req.Weight),
+		Dimensions:  toDomainDimensions(req.Dimensions),
 	})
 	if err != nil {
+		if errors.Is(err, domain.ErrMissingProductVersion) {
+			return nil, invalidArgument(err)
+		}
+
 		if errors.Is(err, domain.ErrProductNotFound) {
 			r.Logger.Debug(
 				"the provided product to be updated was not found",
@@ -160,6 +222,14 @@ func (r *ProductsResolver) Update(ctx context.Context, req *pb.Product) (*pb.Upd
 			return nil, status.Error(codes.NotFound, err.Error())
 		}
 
+		if errors.Is(err, domain.ErrProductSKUAlreadyExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+
+		if errors.Is(err, domain.ErrVersionConflict) {
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+
 		r.Logger.Sugar().Errorw(
 			"failed to update the provided product",
 			zap.Error(err),
@@ -172,9 +242,96 @@ func (r *ProductsResolver) Update(ctx context.Context, req *pb.Product) (*pb.Upd
 	response := &pb.UpdateResponse{
 		Data: &pb.Product{
 			Id:          int32(product.ID),
+			Sku:         product.SKU,
+			Version:     int32(product.Version),
 			Name:        product.Name,
 			Description: product.Description,
 			Price:       int32(product.Price),
+			Category:    product.Category,
+			Attributes:  product.Attributes,
+			TaxClass:    product.TaxClass,
+			Weight:      int32(product.Weight),
+			Dimensions:  toProtoDimensions(product.Dimensions),
+		},
+	}
+
+	return response, nil
+}
+
+func (r *ProductsResolver) Patch(ctx context.Context, req *pb.PatchRequest) (*pb.UpdateResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.Patch")
+	defer span.End()
+
+	r.Logger.Info("patching a product", zap.Any("req", req))
+
+	if req.Product == nil {
+		return nil, status.Error(codes.InvalidArgument, "product must be provided")
+	}
+
+	patch := domain.ProductPatch{
+		Product: domain.Product{
+			ID:          int(req.Product.Id),
+			SKU:         req.Product.Sku,
+			Name:        req.Product.Name,
+			Description: req.Product.Description,
+			Price:       int(req.Product.Price),
+			Category:    req.Product.Category,
+			Attributes:  req.Product.Attributes,
+			TaxClass:    req.Product.TaxClass,
+			Weight:      int(req.Product.Weight),
+			Dimensions:  toDomainDimensions(req.Product.Dimensions),
+			Version:     int(req.Product.Version),
+		},
+	}
+
+	for _, path := range req.GetUpdateMask().GetPaths() {
+		field, err := domain.ParseProductField(path)
+		if err != nil {
+			return nil, invalidArgument(&domain.ValidationError{Violations: []domain.FieldViolation{
+				{Field: "update_mask", Description: err.Error()},
+			}})
+		}
+
+		patch.Fields = append(patch.Fields, field)
+	}
+
+	product, err := r.App.PatchProduct(ctx, patch)
+	if err != nil {
+		var validationErr *domain.ValidationError
+		switch {
+		case errors.As(err, &validationErr), errors.Is(err, domain.ErrEmptyProductPatch),
+			errors.Is(err, domain.ErrMissingProductVersion):
+			return nil, invalidArgument(err)
+		case errors.Is(err, domain.ErrProductNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, domain.ErrProductSKUAlreadyExists):
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		case errors.Is(err, domain.ErrVersionConflict):
+			return nil, status.Error(codes.Aborted, err.Error())
+		}
+
+		r.Logger.Sugar().Errorw(
+			"failed to patch the provided product",
+			zap.Error(err),
+			zap.Any("patch", patch),
+		)
+
+		return nil, InternalServerError
+	}
+
+	response := &pb.UpdateResponse{
+		Data: &pb.Product{
+			Id:          int32(product.ID),
+			Sku:         product.SKU,
+			Version:     int32(product.Version),
+			Name:        product.Name,
+			Description: product.Description,
+			Price:       int32(product.Price),
+			Category:    product.Category,
+			Attributes:  product.Attributes,
+			TaxClass:    product.TaxClass,
+			Weight:      int32(product.Weight),
+			Dimensions:  toProtoDimensions(product.Dimensions),
 		},
 	}
 
@@ -210,3 +367,488 @@ func (r *ProductsResolver) Delete(ctx context.Context, req *pb.DeleteRequest) (*
 
 	return response, nil
 }
+
+func (r *ProductsResolver) Restore(ctx context.Context, req *pb.RestoreRequest) (*pb.RestoreResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.Restore")
+	defer span.End()
+
+	productId := int(req.Id)
+
+	r.Logger.Info("restoring a product", zap.Int("id", productId))
+
+	product, err := r.App.RestoreProduct(ctx, productId)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrProductNotFound):
+			return nil, status.Error(codes.NotFound, err.Error())
+		case errors.Is(err, domain.ErrProductNotDeleted):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+
+		r.Logger.Sugar().Errorw(
+			"failed to restore the product",
+			zap.Error(err),
+			zap.Int("productId", productId),
+		)
+
+		return nil, InternalServerError
+	}
+
+	response := &pb.RestoreResponse{
+		Data: &pb.Product{
+			Id:          int32(product.ID),
+			Sku:         product.SKU,
+			Version:     int32(product.Version),
+			Name:        product.Name,
+			Description: product.Description,
+			Price:       int32(product.Price),
+			Category:    product.Category,
+			Attributes:  product.Attributes,
+			TaxClass:    product.TaxClass,
+			Weight:      int32(product.Weight),
+			Dimensions:  toProtoDimensions(product.Dimensions),
+		},
+	}
+
+	return response, nil
+}
+
+func (r *ProductsResolver) Search(ctx context.Context, req *pb.SearchRequest) (*pb.SearchResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.Search")
+	defer span.End()
+
+	search := domain.ProductSearch{
+		Query:  req.Query,
+		Limit:  int(req.Limit),
+		Offset: int(req.Offset),
+		Sort:   toDomainSortKeys(req.Sort),
+	}
+
+	r.Logger.Info("searching products", zap.Any("search", search))
+
+	results, err := r.App.SearchProducts(ctx, search)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrEmptySearchQuery),
+			errors.Is(err, domain.ErrTooManySearchTerms),
+			errors.Is(err, domain.ErrInvalidSearchLimit),
+			errors.Is(err, domain.ErrInvalidSort):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		r.Logger.Sugar().Errorw(
+			"failed to search products",
+			zap.Error(err),
+			zap.Any("search", search),
+		)
+
+		return nil, InternalServerError
+	}
+
+	response := &pb.SearchResponse{
+		Results: []*pb.SearchResult{},
+	}
+
+	for _, result := range results {
+		response.Results = append(response.Results, &pb.SearchResult{
+			Product:              toProtoProduct(&result.Product),
+			Score:                result.Score,
+			NameHighlight:        result.Highlights.Name,
+			DescriptionHighlight: result.Highlights.Description,
+		})
+	}
+
+	return response, nil
+}
+
+func (r *ProductsResolver) GetFacets(ctx context.Context, req *pb.GetFacetsRequest) (*pb.GetFacetsResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.GetFacets")
+	defer span.End()
+
+	filter := domain.FacetsFilter{
+		ProductFilter: toDomainProductFilter(req.Filter),
+	}
+	for _, boundary := range req.PriceBoundaries {
+		filter.PriceBoundaries = append(filter.PriceBoundaries, int(boundary))
+	}
+
+	r.Logger.Info("counting the product facets", zap.Any("filter", filter))
+
+	facets, err := r.App.GetProductFacets(ctx, filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPriceRange) || errors.Is(err, domain.ErrInvalidPriceBoundaries) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		r.Logger.Sugar().Errorw(
+			"failed to count the product facets",
+			zap.Error(err),
+			zap.Any("filter", filter),
+		)
+
+		return nil, InternalServerError
+	}
+
+	response := &pb.GetFacetsResponse{
+		Total:       int32(facets.Total),
+		PriceRanges: []*pb.PriceRangeBucket{},
+		Categories:  toProtoFacetBuckets(facets.Categories),
+		Attributes:  []*pb.AttributeFacet{},
+	}
+
+	for _, bucket := range facets.PriceRanges {
+		response.PriceRanges = append(response.PriceRanges, &pb.PriceRangeBucket{
+			From:  int32(bucket.From),
+			To:    int32(bucket.To),
+			Count: int32(bucket.Count),
+		})
+	}
+
+	for _, attribute := range facets.Attributes {
+		response.Attributes = append(response.Attributes, &pb.AttributeFacet{
+			Name:   attribute.Name,
+			Values: toProtoFacetBuckets(attribute.Values),
+		})
+	}
+
+	return response, nil
+}
+
+func (r *ProductsResolver) GetProductHistory(ctx context.Context, req *pb.GetProductHistoryRequest) (*pb.GetProductHistoryResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.GetProductHistory")
+	defer span.End()
+
+	filter := domain.ProductHistoryFilter{
+		ProductID: int(req.ProductId),
+		BeforeID:  int(req.BeforeId),
+		Limit:     int(req.Limit),
+	}
+
+	r.Logger.Info("fetching the history of a product", zap.Any("filter", filter))
+
+	entries, err := r.App.GetProductHistory(ctx, filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidHistoryLimit) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		r.Logger.Sugar().Errorw(
+			"failed to fetch the history of the product",
+			zap.Error(err),
+			zap.Any("filter", filter),
+		)
+
+		return nil, InternalServerError
+	}
+
+	response := &pb.GetProductHistoryResponse{
+		Entries: []*pb.AuditEntry{},
+	}
+
+	for _, entry := range entries {
+		response.Entries = append(response.Entries, &pb.AuditEntry{
+			Id:        int32(entry.ID),
+			ProductId: int32(entry.ProductID),
+			Action:    string(entry.Action),
+			Actor:     entry.Actor,
+			Before:    toProtoProduct(entry.Before),
+			After:     toProtoProduct(entry.After),
+			TraceId:   entry.TraceID,
+			CreatedAt: timestamppb.New(entry.CreatedAt),
+		})
+	}
+
+	return response, nil
+}
+
+// bulkRegisterError tells why a Product of the batch wasn't registered. Only the
+// domain errors are sent as is, anything else is logged and hidden behind a generic
+// message so the storage details don't leak to the clients.
+func (r *ProductsResolver) bulkRegisterError(err error, index int32) string {
+	switch {
+	case errors.Is(err, domain.ErrProductSKUAlreadyExists),
+		errors.Is(err, domain.ErrProductAlreadyExists),
+		errors.Is(err, domain.ErrInvalidProductField):
+		r.Logger.Debug(
+			"failed to register a product of the batch",
+			zap.Error(err),
+			zap.Int32("index", index),
+		)
+
+		return err.Error()
+	default:
+		r.Logger.Error(
+			"failed to register a product of the batch",
+			zap.Error(err),
+			zap.Int32("index", index),
+		)
+
+		return bulkRegisterInternalError
+	}
+}
+
+func (r *ProductsResolver) BulkRegister(stream pb.ProductsService_BulkRegisterServer) error {
+	ctx, span := r.Tracer.Start(stream.Context(), "resolver.BulkRegister")
+	defer span.End()
+
+	r.Logger.Info("bulk registering products")
+
+	response := &pb.BulkRegisterResponse{
+		Results: []*pb.BulkRegisterResult{},
+	}
+
+	batch := make([]domain.Product, 0, bulkRegisterBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		results, err := r.App.RegisterProducts(ctx, batch)
+		if err != nil {
+			r.Logger.Sugar().Errorw(
+				"failed to register a batch of products",
+				zap.Error(err),
+				zap.Int("size", len(batch)),
+			)
+
+			return InternalServerError
+		}
+
+		for _, result := range results {
+			item := &pb.BulkRegisterResult{
+				Index: int32(len(response.Results)),
+			}
+
+			if result.Err != nil {
+				item.Error = r.bulkRegisterError(result.Err, item.Index)
+			} else {
+				item.Data = &pb.Product{
+					Id:          int32(result.Product.ID),
+					Sku:         result.Product.SKU,
+					Version:     int32(result.Product.Version),
+					Name:        result.Product.Name,
+					Description: result.Product.Description,
+					Price:       int32(result.Product.Price),
+					Category:    result.Product.Category,
+					Attributes:  result.Product.Attributes,
+					TaxClass:    result.Product.TaxClass,
+					Weight:      int32(result.Product.Weight),
+					Dimensions:  toProtoDimensions(result.Product.Dimensions),
+				}
+			}
+
+			response.Results = append(response.Results, item)
+		}
+
+		batch = batch[:0]
+		return nil
+	}
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			r.Logger.Debug("failed to receive a product from the stream", zap.Error(err))
+			return err
+		}
+
+		batch = append(batch, domain.Product{
+			ID:          int(req.Id),
+			SKU:         req.Sku,
+			Version:     int(req.Version),
+			Name:        req.Name,
+			Description: req.Description,
+			Price:       int(req.Price),
+			Category:    req.Category,
+			Attributes:  req.Attributes,
+			TaxClass:    req.TaxClass,
+			Weight:      int(req.Weight),
+			Dimensions:  toDomainDimensions(req.Dimensions),
+		})
+
+		if len(batch) == bulkRegisterBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return err
+	}
+
+	return stream.SendAndClose(response)
+}
+
+func (r *ProductsResolver) Export(req *pb.ExportRequest, stream pb.ProductsService_ExportServer) error {
+	ctx, span := r.Tracer.Start(stream.Context(), "resolver.Export")
+	defer span.End()
+
+	pageSize := int(req.PageSize)
+	if pageSize < 0 || pageSize > maxExportPageSize {
+		return status.Errorf(codes.InvalidArgument, "page_size must be between 0 and %d", maxExportPageSize)
+	}
+
+	if pageSize == 0 {
+		pageSize = defaultExportPageSize
+	}
+
+	r.Logger.Info("exporting products", zap.Int("pageSize", pageSize))
+
+	filter := domain.ExportProductsFilter{
+		Limit: pageSize,
+	}
+
+	for {
+		products, err := r.App.ExportProducts(ctx, filter)
+		if err != nil {
+			r.Logger.Sugar().Errorw(
+				"failed to export products",
+				zap.Error(err),
+				zap.Any("filter", filter),
+			)
+
+			return InternalServerError
+		}
+
+		for _, product := range products {
+			err := stream.Send(&pb.Product{
+				Id:          int32(product.ID),
+				Sku:         product.SKU,
+				Version:     int32(product.Version),
+				Name:        product.Name,
+				Description: product.Description,
+				Price:       int32(product.Price),
+				Category:    product.Category,
+				Attributes:  product.Attributes,
+				TaxClass:    product.TaxClass,
+				Weight:      int32(product.Weight),
+				Dimensions:  toProtoDimensions(product.Dimensions),
+			})
+			if err != nil {
+				r.Logger.Debug("failed to send a product through the stream", zap.Error(err))
+				return err
+			}
+		}
+
+		if len(products) < pageSize {
+			return nil
+		}
+
+		filter.AfterID = products[len(products)-1].ID
+	}
+}
+
+// toProtoDeletedAt converts the DeletedAt of a Product, leaving it unset if the Product isn't deleted
+func toProtoDeletedAt(deletedAt time.Time) *timestamppb.Timestamp {
+	if deletedAt.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(deletedAt)
+}
+
+// toProtoDimensions converts the Dimensions of a Product, leaving the unknown ones unset
+func toProtoDimensions(dimensions domain.Dimensions) *pb.Dimensions {
+	if dimensions.Zero() {
+		return nil
+	}
+
+	return &pb.Dimensions{
+		Length: int32(dimensions.Length),
+		Width:  int32(dimensions.Width),
+		Height: int32(dimensions.Height),
+	}
+}
+
+func toDomainDimensions(dimensions *pb.Dimensions) domain.Dimensions {
+	return domain.Dimensions{
+		Length: int(dimensions.GetLength()),
+		Width:  int(dimensions.GetWidth()),
+		Height: int(dimensions.GetHeight()),
+	}
+}
+
+// toProtoProduct converts a Product that may be missing, like the Before snapshot of an AuditEntry
+func toProtoProduct(product *domain.Product) *pb.Product {
+	if product == nil {
+		return nil
+	}
+
+	return &pb.Product{
+		Id:          int32(product.ID),
+		Sku:         product.SKU,
+		Version:     int32(product.Version),
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       int32(product.Price),
+		Category:    product.Category,
+		Attributes:  product.Attributes,
+		TaxClass:    product.TaxClass,
+		Weight:      int32(product.Weight),
+		Dimensions:  toProtoDimensions(product.Dimensions),
+		DeletedAt:   toProtoDeletedAt(product.DeletedAt),
+	}
+}
+
+// invalidArgument builds an InvalidArgument status, detailing the field violations of a domain.ValidationError
+// toDomainProductFilter converts a ProductFilter, a nil one matches every Product
+func toDomainProductFilter(filter *pb.ProductFilter) domain.ProductFilter {
+	return domain.ProductFilter{
+		Category:   filter.GetCategory(),
+		PriceFrom:  int(filter.GetPriceFrom()),
+		PriceTo:    int(filter.GetPriceTo()),
+		Attributes: filter.GetAttributes(),
+	}
+}
+
+func toDomainSortKeys(keys []*pb.SortKey) []domain.SortKey {
+	var response []domain.SortKey
+	for _, key := range keys {
+		response = append(response, domain.SortKey{
+			Field:      domain.SortField(key.Field),
+			Descending: key.Descending,
+		})
+	}
+
+	return response
+}
+
+func toProtoFacetBuckets(buckets []domain.FacetBucket) []*pb.FacetBucket {
+	response := make([]*pb.FacetBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		response = append(response, &pb.FacetBucket{
+			Value: bucket.Value,
+			Count: int32(bucket.Count),
+		})
+	}
+
+	return response
+}
+
+func invalidArgument(err error) error {
+	st := status.New(codes.InvalidArgument, err.Error())
+
+	var validationErr *domain.ValidationError
+	if !errors.As(err, &validationErr) {
+		return st.Err()
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range validationErr.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       violation.Field,
+			Description: violation.Description,
+		})
+	}
+
+	detailed, detailsErr := st.WithDetails(badRequest)
+	if detailsErr != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
@@ -3,9 +3,13 @@ package grpc_port
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"net"
 	"testing"
+	"time"
 
+	"github.com/lucasmls/ecommerce/shared/actor"
 	"github.com/lucasmls/ecommerce/shared/grpc"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -14,9 +18,12 @@ import (
 
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/lucasmls/ecommerce/services/products/domain"
 	"github.com/lucasmls/ecommerce/services/products/mocks"
@@ -190,10 +197,27 @@ func (s *ProductsResolverSuite) Test_List() {
 		s.Equal(expectedResult, err)
 	})
 
+	s.Run("Should return invalid argument in case the sort is invalid", func() {
+		ctx := context.Background()
+		filter := domain.ListProductsFilter{Sort: []domain.SortKey{{Field: domain.SortFieldRelevance}}}
+		sortErr := fmt.Errorf("%w: relevance is only allowed when searching", domain.ErrInvalidSort)
+
+		s.app.
+			On("ListProducts", mock.AnythingOfType("*context.valueCtx"), filter).
+			Return(nil, sortErr)
+
+		_, err := s.grpcClient.List(ctx, &protog.ListRequest{
+			Sort: []*protog.SortKey{{Field: "relevance"}},
+		})
+
+		s.Equal(status.Error(codes.InvalidArgument, sortErr.Error()), err)
+	})
+
 	s.Run("Should successfully list the Products", func() {
 		ctx := context.Background()
 		filter := domain.ListProductsFilter{
-			IDs: []int{1, 2},
+			IDs:  []int{1, 2},
+			Sort: []domain.SortKey{{Field: domain.SortFieldPrice, Descending: true}, {Field: domain.SortFieldName}},
 		}
 
 		products := []domain.Product{
@@ -232,7 +256,8 @@ func (s *ProductsResolverSuite) Test_List() {
 			Return(products, nil)
 
 		got, err := s.grpcClient.List(ctx, &protog.ListRequest{
-			Ids: []int32{1, 2},
+			Ids:  []int32{1, 2},
+			Sort: []*protog.SortKey{{Field: "price", Descending: true}, {Field: "name"}},
 		})
 
 		s.NoError(err)
@@ -245,6 +270,290 @@ func (s *ProductsResolverSuite) Test_List() {
 	})
 }
 
+func (s *ProductsResolverSuite) Test_ListTrash() {
+	s.Run("Should list the deleted Products with their deletion time", func() {
+		ctx := context.Background()
+		deletedAt := time.Date(2022, 4, 20, 10, 0, 0, 0, time.UTC)
+
+		s.app.
+			On("ListProducts",
+				mock.AnythingOfType("*context.valueCtx"),
+				domain.ListProductsFilter{OnlyDeleted: true},
+			).
+			Return([]domain.Product{
+				{ID: 7, Name: "Ipad", Description: "Big", Price: 3000, Version: 2, DeletedAt: deletedAt},
+			}, nil)
+
+		got, err := s.grpcClient.List(ctx, &protog.ListRequest{OnlyDeleted: true})
+
+		s.NoError(err)
+		s.Require().Len(got.Data, 1)
+		s.True(proto.Equal(&protog.Product{
+			Id:          7,
+			Name:        "Ipad",
+			Description: "Big",
+			Price:       3000,
+			Version:     2,
+			DeletedAt:   timestamppb.New(deletedAt),
+		}, got.Data[0]))
+	})
+}
+
+func (s *ProductsResolverSuite) Test_Restore() {
+	s.Run("Should return not found in case the provided Product isn't stored", func() {
+		ctx := context.Background()
+
+		s.app.
+			On("RestoreProduct", mock.AnythingOfType("*context.valueCtx"), 1).
+			Return(domain.Product{}, domain.ErrProductNotFound)
+
+		_, err := s.grpcClient.Restore(ctx, &protog.RestoreRequest{Id: 1})
+
+		s.Equal(status.Error(codes.NotFound, domain.ErrProductNotFound.Error()), err)
+	})
+
+	s.Run("Should return failed precondition in case the provided Product isn't deleted", func() {
+		ctx := context.Background()
+
+		s.app.
+			On("RestoreProduct", mock.AnythingOfType("*context.valueCtx"), 2).
+			Return(domain.Product{}, domain.ErrProductNotDeleted)
+
+		_, err := s.grpcClient.Restore(ctx, &protog.RestoreRequest{Id: 2})
+
+		s.Equal(status.Error(codes.FailedPrecondition, domain.ErrProductNotDeleted.Error()), err)
+	})
+
+	s.Run("Should successfully restore the provided Product", func() {
+		ctx := context.Background()
+
+		s.app.
+			On("RestoreProduct", mock.AnythingOfType("*context.valueCtx"), 3).
+			Return(domain.Product{ID: 3, Name: "Ipad", Description: "Big", Price: 3000, Version: 3}, nil)
+
+		got, err := s.grpcClient.Restore(ctx, &protog.RestoreRequest{Id: 3})
+
+		s.NoError(err)
+		s.True(proto.Equal(&protog.RestoreResponse{
+			Data: &protog.Product{Id: 3, Name: "Ipad", Description: "Big", Price: 3000, Version: 3},
+		}, got))
+	})
+}
+
+func (s *ProductsResolverSuite) Test_GetProductHistory() {
+	s.Run("Should return invalid argument in case the limit is out of bounds", func() {
+		ctx := context.Background()
+
+		s.app.
+			On("GetProductHistory", mock.AnythingOfType("*context.valueCtx"), domain.ProductHistoryFilter{ProductID: 1, Limit: 1000}).
+			Return(nil, domain.ErrInvalidHistoryLimit)
+
+		_, err := s.grpcClient.GetProductHistory(ctx, &protog.GetProductHistoryRequest{ProductId: 1, Limit: 1000})
+
+		s.Equal(status.Error(codes.InvalidArgument, domain.ErrInvalidHistoryLimit.Error()), err)
+	})
+
+	s.Run("Should return the history of the provided Product", func() {
+		ctx := context.Background()
+		createdAt := time.Date(2021, 10, 19, 12, 0, 0, 0, time.UTC)
+
+		s.app.
+			On("GetProductHistory", mock.AnythingOfType("*context.valueCtx"), domain.ProductHistoryFilter{ProductID: 2, BeforeID: 9, Limit: 2}).
+			Return([]domain.AuditEntry{
+				{
+					ID:        8,
+					ProductID: 2,
+					Action:    domain.AuditActionUpdate,
+					Actor:     "jane",
+					Before:    &domain.Product{ID: 2, Name: "Ipad", Price: 3000, Version: 1},
+					After:     &domain.Product{ID: 2, Name: "Ipad", Price: 3200, Version: 2},
+					TraceID:   "4bf92f3577b34da6a3ce929d0e0e4736",
+					CreatedAt: createdAt,
+				},
+				{
+					ID:        7,
+					ProductID: 2,
+					Action:    domain.AuditActionRegister,
+					Actor:     "jane",
+					After:     &domain.Product{ID: 2, Name: "Ipad", Price: 3000, Version: 1},
+					CreatedAt: createdAt,
+				},
+			}, nil)
+
+		got, err := s.grpcClient.GetProductHistory(ctx, &protog.GetProductHistoryRequest{ProductId: 2, BeforeId: 9, Limit: 2})
+
+		s.NoError(err)
+		s.True(proto.Equal(&protog.GetProductHistoryResponse{
+			Entries: []*protog.AuditEntry{
+				{
+					Id:        8,
+					ProductId: 2,
+					Action:    "update",
+					Actor:     "jane",
+					Before:    &protog.Product{Id: 2, Name: "Ipad", Price: 3000, Version: 1},
+					After:     &protog.Product{Id: 2, Name: "Ipad", Price: 3200, Version: 2},
+					TraceId:   "4bf92f3577b34da6a3ce929d0e0e4736",
+					CreatedAt: timestamppb.New(createdAt),
+				},
+				{
+					Id:        7,
+					ProductId: 2,
+					Action:    "register",
+					Actor:     "jane",
+					After:     &protog.Product{Id: 2, Name: "Ipad", Price: 3000, Version: 1},
+					CreatedAt: timestamppb.New(createdAt),
+				},
+			},
+		}, got))
+	})
+
+	s.Run("Should propagate the actor of the request to the application", func() {
+		ctx := actor.NewContext(context.Background(), "jane")
+
+		s.app.
+			On("GetProductHistory", mock.MatchedBy(func(ctx context.Context) bool {
+				name, ok := actor.FromContext(ctx)
+				return ok && name == "jane"
+			}), domain.ProductHistoryFilter{ProductID: 3}).
+			Return([]domain.AuditEntry{}, nil)
+
+		got, err := s.grpcClient.GetProductHistory(ctx, &protog.GetProductHistoryRequest{ProductId: 3})
+
+		s.NoError(err)
+		s.Empty(got.Entries)
+	})
+}
+
+func (s *ProductsResolverSuite) Test_GetFacets() {
+	s.Run("Should return invalid argument in case the price boundaries are invalid", func() {
+		ctx := context.Background()
+
+		s.app.
+			On("GetProductFacets", mock.AnythingOfType("*context.valueCtx"), domain.FacetsFilter{PriceBoundaries: []int{0}}).
+			Return(domain.ProductFacets{}, domain.ErrInvalidPriceBoundaries)
+
+		_, err := s.grpcClient.GetFacets(ctx, &protog.GetFacetsRequest{PriceBoundaries: []int32{0}})
+
+		s.Equal(status.Error(codes.InvalidArgument, domain.ErrInvalidPriceBoundaries.Error()), err)
+	})
+
+	s.Run("Should return the facets of the products matching the filter", func() {
+		ctx := context.Background()
+
+		s.app.
+			On("GetProductFacets", mock.AnythingOfType("*context.valueCtx"), domain.FacetsFilter{
+				ProductFilter: domain.ProductFilter{
+					Category:   "phones",
+					PriceFrom:  1000,
+					PriceTo:    9000,
+					Attributes: map[string]string{"color": "black"},
+				},
+				PriceBoundaries: []int{5000},
+			}).
+			Return(domain.ProductFacets{
+				Total: 2,
+				PriceRanges: []domain.PriceRangeBucket{
+					{From: 0, To: 5000, Count: 2},
+					{From: 5000},
+				},
+				Categories: []domain.FacetBucket{{Value: "phones", Count: 2}},
+				Attributes: []domain.AttributeFacet{
+					{Name: "color", Values: []domain.FacetBucket{{Value: "black", Count: 2}}},
+				},
+			}, nil)
+
+		got, err := s.grpcClient.GetFacets(ctx, &protog.GetFacetsRequest{
+			Filter: &protog.ProductFilter{
+				Category:   "phones",
+				PriceFrom:  1000,
+				PriceTo:    9000,
+				Attributes: map[string]string{"color": "black"},
+			},
+			PriceBoundaries: []int32{5000},
+		})
+
+		s.NoError(err)
+		s.True(proto.Equal(&protog.GetFacetsResponse{
+			Total: 2,
+			PriceRanges: []*protog.PriceRangeBucket{
+				{From: 0, To: 5000, Count: 2},
+				{From: 5000},
+			},
+			Categories: []*protog.FacetBucket{{Value: "phones", Count: 2}},
+			Attributes: []*protog.AttributeFacet{
+				{Name: "color", Values: []*protog.FacetBucket{{Value: "black", Count: 2}}},
+			},
+		}, got))
+	})
+}
+
+func (s *ProductsResolverSuite) Test_Search() {
+	s.Run("Should return invalid argument in case the search is invalid", func() {
+		ctx := context.Background()
+
+		s.app.
+			On("SearchProducts", mock.AnythingOfType("*context.valueCtx"), domain.ProductSearch{Query: "  "}).
+			Return(nil, domain.ErrEmptySearchQuery)
+
+		_, err := s.grpcClient.Search(ctx, &protog.SearchRequest{Query: "  "})
+
+		s.Equal(status.Error(codes.InvalidArgument, domain.ErrEmptySearchQuery.Error()), err)
+	})
+
+	s.Run("Should return a generic error in case we receive a error that we're not aware of", func() {
+		ctx := context.Background()
+
+		s.app.
+			On("SearchProducts", mock.AnythingOfType("*context.valueCtx"), domain.ProductSearch{Query: "ipod"}).
+			Return(nil, errors.New("connection refused"))
+
+		_, err := s.grpcClient.Search(ctx, &protog.SearchRequest{Query: "ipod"})
+
+		s.Equal(status.Error(codes.Internal, "Internal server error"), err)
+	})
+
+	s.Run("Should return the products matching the search", func() {
+		ctx := context.Background()
+
+		s.app.
+			On("SearchProducts", mock.AnythingOfType("*context.valueCtx"), domain.ProductSearch{
+				Query:  "ipad",
+				Limit:  5,
+				Offset: 5,
+				Sort:   []domain.SortKey{{Field: domain.SortFieldRelevance, Descending: true}},
+			}).
+			Return([]domain.ProductSearchResult{
+				{
+					Product: domain.Product{ID: 2, Name: "Ipad", Description: "Big", Price: 3000, Version: 1},
+					Score:   2,
+					Highlights: domain.ProductHighlights{
+						Name:        "<em>Ipad</em>",
+						Description: "Big",
+					},
+				},
+			}, nil)
+
+		got, err := s.grpcClient.Search(ctx, &protog.SearchRequest{
+			Query:  "ipad",
+			Limit:  5,
+			Offset: 5,
+			Sort:   []*protog.SortKey{{Field: "relevance", Descending: true}},
+		})
+
+		s.NoError(err)
+		s.True(proto.Equal(&protog.SearchResponse{
+			Results: []*protog.SearchResult{
+				{
+					Product:              &protog.Product{Id: 2, Name: "Ipad", Description: "Big", Price: 3000, Version: 1},
+					Score:                2,
+					NameHighlight:        "<em>Ipad</em>",
+					DescriptionHighlight: "Big",
+				},
+			},
+		}, got))
+	})
+}
+
 func (s *ProductsResolverSuite) Test_Register() {
 	s.Run("Should return a generic error in case we receive a error that we're not aware of", func() {
 		ctx := context.Background()
@@ -283,6 +592,9 @@ func (s *ProductsResolverSuite) Test_Register() {
 			Name:        "Macbook Air M1",
 			Description: "Fast",
 			Price:       6800,
+			TaxClass:    "electronics",
+			Weight:      1290,
+			Dimensions:  &protog.Dimensions{Length: 304, Width: 212, Height: 16},
 		}
 
 		expectedResult := &protog.RegisterResponse{
@@ -291,6 +603,9 @@ func (s *ProductsResolverSuite) Test_Register() {
 				Name:        req.Name,
 				Description: req.Description,
 				Price:       int32(req.Price),
+				TaxClass:    req.TaxClass,
+				Weight:      req.Weight,
+				Dimensions:  req.Dimensions,
 			},
 		}
 
@@ -299,6 +614,9 @@ func (s *ProductsResolverSuite) Test_Register() {
 			Name:        req.Name,
 			Description: req.Description,
 			Price:       int(req.Price),
+			TaxClass:    req.TaxClass,
+			Weight:      int(req.Weight),
+			Dimensions:  domain.Dimensions{Length: 304, Width: 212, Height: 16},
 		}
 
 		s.app.
@@ -346,6 +664,55 @@ func (s *ProductsResolverSuite) Test_Update() {
 		s.Equal(expectedResult, err)
 	})
 
+	s.Run("Should return invalid argument in case the version is missing", func() {
+		ctx := context.Background()
+		req := &protog.Product{Id: 4, Name: "Ipad", Description: "Big", Price: 3000}
+
+		s.app.
+			On("UpdateProduct",
+				mock.AnythingOfType("*context.valueCtx"),
+				domain.Product{ID: 4, Name: "Ipad", Description: "Big", Price: 3000},
+			).
+			Return(domain.Product{}, domain.ErrMissingProductVersion)
+
+		_, err := s.grpcClient.Update(ctx, req)
+
+		s.Equal(codes.InvalidArgument, status.Code(err))
+		s.Equal(domain.ErrMissingProductVersion.Error(), status.Convert(err).Message())
+	})
+
+	s.Run("Should return aborted in case the provided version is stale", func() {
+		ctx := context.Background()
+		req := &protog.Product{
+			Id:          5,
+			Name:        "Macbook Air M2",
+			Description: "Faster",
+			Price:       9000,
+			Version:     3,
+		}
+
+		product := domain.Product{
+			ID:          int(req.Id),
+			Name:        req.Name,
+			Description: req.Description,
+			Price:       int(req.Price),
+			Version:     int(req.Version),
+		}
+
+		expectedResult := status.Error(codes.Aborted, domain.ErrVersionConflict.Error())
+
+		s.app.
+			On("UpdateProduct",
+				mock.AnythingOfType("*context.valueCtx"),
+				product,
+			).
+			Return(domain.Product{}, domain.ErrVersionConflict)
+
+		_, err := s.grpcClient.Update(ctx, req)
+
+		s.Equal(expectedResult, err)
+	})
+
 	s.Run("Should return a generic error in case we receive a error that we're not aware of", func() {
 		ctx := context.Background()
 		req := &protog.Product{
@@ -415,6 +782,241 @@ func (s *ProductsResolverSuite) Test_Update() {
 	})
 }
 
+func (s *ProductsResolverSuite) Test_Patch() {
+	s.Run("Should return invalid argument in case the mask names an unknown field", func() {
+		ctx := context.Background()
+		req := &protog.PatchRequest{
+			Product:    &protog.Product{Id: 1, Price: 7000},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"price", "stock"}},
+		}
+
+		_, err := s.grpcClient.Patch(ctx, req)
+
+		st := status.Convert(err)
+		s.Equal(codes.InvalidArgument, st.Code())
+		s.Require().Len(st.Details(), 1)
+
+		badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+		s.Require().True(ok)
+		s.Equal("update_mask", badRequest.FieldViolations[0].Field)
+	})
+
+	s.Run("Should return invalid argument detailing the violations of the listed fields", func() {
+		ctx := context.Background()
+		req := &protog.PatchRequest{
+			Product:    &protog.Product{Id: 1, Price: -1},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"price"}},
+		}
+
+		validationErr := &domain.ValidationError{Violations: []domain.FieldViolation{
+			{Field: "price", Description: "must not be negative"},
+		}}
+
+		s.app.
+			On("PatchProduct",
+				mock.AnythingOfType("*context.valueCtx"),
+				domain.ProductPatch{
+					Product: domain.Product{ID: 1, Price: -1},
+					Fields:  []domain.ProductField{domain.ProductFieldPrice},
+				},
+			).
+			Return(domain.Product{}, validationErr)
+
+		_, err := s.grpcClient.Patch(ctx, req)
+
+		st := status.Convert(err)
+		s.Equal(codes.InvalidArgument, st.Code())
+		s.Equal(validationErr.Error(), st.Message())
+		s.Require().Len(st.Details(), 1)
+		s.True(proto.Equal(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "price", Description: "must not be negative"},
+			},
+		}, st.Details()[0].(*errdetails.BadRequest)))
+	})
+
+	s.Run("Should return not found in case the provided Product isn't stored", func() {
+		ctx := context.Background()
+		req := &protog.PatchRequest{
+			Product:    &protog.Product{Id: 2, Name: "Macbook Air M1"},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+		}
+
+		s.app.
+			On("PatchProduct",
+				mock.AnythingOfType("*context.valueCtx"),
+				domain.ProductPatch{
+					Product: domain.Product{ID: 2, Name: "Macbook Air M1"},
+					Fields:  []domain.ProductField{domain.ProductFieldName},
+				},
+			).
+			Return(domain.Product{}, domain.ErrProductNotFound)
+
+		_, err := s.grpcClient.Patch(ctx, req)
+
+		s.Equal(status.Error(codes.NotFound, domain.ErrProductNotFound.Error()), err)
+	})
+
+	s.Run("Should successfully patch the listed fields of the provided Product", func() {
+		ctx := context.Background()
+		req := &protog.PatchRequest{
+			Product:    &protog.Product{Id: 3, Description: "Faster", Price: 7200, Version: 1},
+			UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"description", "price"}},
+		}
+
+		product := domain.Product{ID: 3, Name: "Macbook Air M1", Description: "Faster", Price: 7200, Version: 2}
+
+		s.app.
+			On("PatchProduct",
+				mock.AnythingOfType("*context.valueCtx"),
+				domain.ProductPatch{
+					Product: domain.Product{ID: 3, Description: "Faster", Price: 7200, Version: 1},
+					Fields:  []domain.ProductField{domain.ProductFieldDescription, domain.ProductFieldPrice},
+				},
+			).
+			Return(product, nil)
+
+		got, err := s.grpcClient.Patch(ctx, req)
+
+		s.NoError(err)
+		s.True(proto.Equal(&protog.UpdateResponse{
+			Data: &protog.Product{Id: 3, Name: "Macbook Air M1", Description: "Faster", Price: 7200, Version: 2},
+		}, got))
+	})
+}
+
+func (s *ProductsResolverSuite) Test_BulkRegister() {
+	s.Run("Should report the outcome of every streamed Product", func() {
+		ctx := context.Background()
+		reqs := []*protog.Product{
+			{Id: 10, Name: "Iphone 13", Description: "Cool", Price: 4500},
+			{Id: 11, Name: "Macbook Pro M1 Max", Description: "Fast!", Price: 16500},
+			{Id: 13, Name: "Ipad Pro", Description: "Big", Price: 8000},
+		}
+
+		products := []domain.Product{
+			{ID: 10, Name: "Iphone 13", Description: "Cool", Price: 4500},
+			{ID: 11, Name: "Macbook Pro M1 Max", Description: "Fast!", Price: 16500},
+			{ID: 13, Name: "Ipad Pro", Description: "Big", Price: 8000},
+		}
+
+		s.app.
+			On("RegisterProducts",
+				mock.AnythingOfType("*context.valueCtx"),
+				products,
+			).
+			Return([]domain.ProductResult{
+				{Product: products[0]},
+				{Err: domain.ErrProductSKUAlreadyExists},
+				{Err: errors.New("pq: connection reset by peer")},
+			}, nil)
+
+		expectedResult := &protog.BulkRegisterResponse{
+			Results: []*protog.BulkRegisterResult{
+				{Index: 0, Data: reqs[0]},
+				{Index: 1, Error: "product-sku-already-exists"},
+				{Index: 2, Error: "internal error"},
+			},
+		}
+
+		stream, err := s.grpcClient.BulkRegister(ctx)
+		s.NoError(err)
+
+		for _, req := range reqs {
+			s.NoError(stream.Send(req))
+		}
+
+		got, err := stream.CloseAndRecv()
+
+		s.NoError(err)
+		s.True(proto.Equal(expectedResult, got))
+	})
+
+	s.Run("Should return a generic error in case we receive a error that we're not aware of", func() {
+		ctx := context.Background()
+		req := &protog.Product{Id: 12, Name: "Macbook Air M1", Description: "Nice!", Price: 6900}
+
+		s.app.
+			On("RegisterProducts",
+				mock.AnythingOfType("*context.valueCtx"),
+				[]domain.Product{{ID: 12, Name: "Macbook Air M1", Description: "Nice!", Price: 6900}},
+			).
+			Return(nil, errors.New("mock error"))
+
+		expectedResult := status.Error(codes.Internal, "Internal server error")
+
+		stream, err := s.grpcClient.BulkRegister(ctx)
+		s.NoError(err)
+		s.NoError(stream.Send(req))
+
+		_, err = stream.CloseAndRecv()
+
+		s.Equal(expectedResult, err)
+	})
+}
+
+func (s *ProductsResolverSuite) Test_Export() {
+	s.Run("Should reject page sizes bigger than the allowed maximum", func() {
+		ctx := context.Background()
+
+		stream, err := s.grpcClient.Export(ctx, &protog.ExportRequest{PageSize: maxExportPageSize + 1})
+		s.NoError(err)
+
+		_, err = stream.Recv()
+
+		s.Equal(codes.InvalidArgument, status.Code(err))
+	})
+
+	s.Run("Should stream every Product page by page", func() {
+		ctx := context.Background()
+		products := []domain.Product{
+			{ID: 1, Name: "Iphone 13", Description: "Cool", Price: 4500},
+			{ID: 2, Name: "Macbook Pro M1 Max", Description: "Fast!", Price: 16500},
+			{ID: 3, Name: "Macbook Air M1", Description: "Nice!", Price: 6900},
+		}
+
+		s.app.
+			On("ExportProducts",
+				mock.AnythingOfType("*context.valueCtx"),
+				domain.ExportProductsFilter{AfterID: 0, Limit: 2},
+			).
+			Return(products[:2], nil)
+
+		s.app.
+			On("ExportProducts",
+				mock.AnythingOfType("*context.valueCtx"),
+				domain.ExportProductsFilter{AfterID: 2, Limit: 2},
+			).
+			Return(products[2:], nil)
+
+		stream, err := s.grpcClient.Export(ctx, &protog.ExportRequest{PageSize: 2})
+		s.NoError(err)
+
+		var got []*protog.Product
+		for {
+			product, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			s.Require().NoError(err)
+			got = append(got, product)
+		}
+
+		s.Len(got, len(products))
+		for i, product := range products {
+			expected := &protog.Product{
+				Id:          int32(product.ID),
+				Name:        product.Name,
+				Description: product.Description,
+				Price:       int32(product.Price),
+			}
+
+			s.True(proto.Equal(expected, got[i]))
+		}
+	})
+}
+
 func TestProductsResolverSuite(t *testing.T) {
 	suite.Run(t, new(ProductsResolverSuite))
 }
@@ -21,7 +21,20 @@ type ProductsServiceClient interface {
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
 	Register(ctx context.Context, in *Product, opts ...grpc.CallOption) (*RegisterResponse, error)
 	Update(ctx context.Context, in *Product, opts ...grpc.CallOption) (*UpdateResponse, error)
+	Patch(ctx context.Context, in *PatchRequest, opts ...grpc.CallOption) (*UpdateResponse, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error)
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	GetFacets(ctx context.Context, in *GetFacetsRequest, opts ...grpc.CallOption) (*GetFacetsResponse, error)
+	GetProductHistory(ctx context.Context, in *GetProductHistoryRequest, opts ...grpc.CallOption) (*GetProductHistoryResponse, error)
+	BulkRegister(ctx context.Context, opts ...grpc.CallOption) (ProductsService_BulkRegisterClient, error)
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (ProductsService_ExportClient, error)
+	ImportCatalog(ctx context.Context, opts ...grpc.CallOption) (ProductsService_ImportCatalogClient, error)
+	ExportCatalog(ctx context.Context, in *ExportCatalogRequest, opts ...grpc.CallOption) (ProductsService_ExportCatalogClient, error)
+	UploadImage(ctx context.Context, opts ...grpc.CallOption) (ProductsService_UploadImageClient, error)
+	ListImages(ctx context.Context, in *ListImagesRequest, opts ...grpc.CallOption) (*ListImagesResponse, error)
+	DeleteImage(ctx context.Context, in *DeleteImageRequest, opts ...grpc.CallOption) (*DeleteImageResponse, error)
+	ReorderImages(ctx context.Context, in *ReorderImagesRequest, opts ...grpc.CallOption) (*ReorderImagesResponse, error)
 }
 
 type productsServiceClient struct {
@@ -59,6 +72,15 @@ func (c *productsServiceClient) Update(ctx context.Context, in *Product, opts ..
 	return out, nil
 }
 
+func (c *productsServiceClient) Patch(ctx context.Context, in *PatchRequest, opts ...grpc.CallOption) (*UpdateResponse, error) {
+	out := new(UpdateResponse)
+	err := c.cc.Invoke(ctx, "/grpc.ProductsService/Patch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *productsServiceClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
 	out := new(DeleteResponse)
 	err := c.cc.Invoke(ctx, "/grpc.ProductsService/Delete", in, out, opts...)
@@ -68,6 +90,235 @@ func (c *productsServiceClient) Delete(ctx context.Context, in *DeleteRequest, o
 	return out, nil
 }
 
+func (c *productsServiceClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error) {
+	out := new(RestoreResponse)
+	err := c.cc.Invoke(ctx, "/grpc.ProductsService/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productsServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, "/grpc.ProductsService/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productsServiceClient) GetFacets(ctx context.Context, in *GetFacetsRequest, opts ...grpc.CallOption) (*GetFacetsResponse, error) {
+	out := new(GetFacetsResponse)
+	err := c.cc.Invoke(ctx, "/grpc.ProductsService/GetFacets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productsServiceClient) GetProductHistory(ctx context.Context, in *GetProductHistoryRequest, opts ...grpc.CallOption) (*GetProductHistoryResponse, error) {
+	out := new(GetProductHistoryResponse)
+	err := c.cc.Invoke(ctx, "/grpc.ProductsService/GetProductHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productsServiceClient) BulkRegister(ctx context.Context, opts ...grpc.CallOption) (ProductsService_BulkRegisterClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ProductsService_ServiceDesc.Streams[0], "/grpc.ProductsService/BulkRegister", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &productsServiceBulkRegisterClient{stream}
+	return x, nil
+}
+
+type ProductsService_BulkRegisterClient interface {
+	Send(*Product) error
+	CloseAndRecv() (*BulkRegisterResponse, error)
+	grpc.ClientStream
+}
+
+type productsServiceBulkRegisterClient struct {
+	grpc.ClientStream
+}
+
+func (x *productsServiceBulkRegisterClient) Send(m *Product) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *productsServiceBulkRegisterClient) CloseAndRecv() (*BulkRegisterResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(BulkRegisterResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *productsServiceClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (ProductsService_ExportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ProductsService_ServiceDesc.Streams[1], "/grpc.ProductsService/Export", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &productsServiceExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ProductsService_ExportClient interface {
+	Recv() (*Product, error)
+	grpc.ClientStream
+}
+
+type productsServiceExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *productsServiceExportClient) Recv() (*Product, error) {
+	m := new(Product)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *productsServiceClient) ImportCatalog(ctx context.Context, opts ...grpc.CallOption) (ProductsService_ImportCatalogClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ProductsService_ServiceDesc.Streams[2], "/grpc.ProductsService/ImportCatalog", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &productsServiceImportCatalogClient{stream}
+	return x, nil
+}
+
+type ProductsService_ImportCatalogClient interface {
+	Send(*ImportRequest) error
+	CloseAndRecv() (*ImportResponse, error)
+	grpc.ClientStream
+}
+
+type productsServiceImportCatalogClient struct {
+	grpc.ClientStream
+}
+
+func (x *productsServiceImportCatalogClient) Send(m *ImportRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *productsServiceImportCatalogClient) CloseAndRecv() (*ImportResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *productsServiceClient) ExportCatalog(ctx context.Context, in *ExportCatalogRequest, opts ...grpc.CallOption) (ProductsService_ExportCatalogClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ProductsService_ServiceDesc.Streams[3], "/grpc.ProductsService/ExportCatalog", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &productsServiceExportCatalogClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ProductsService_ExportCatalogClient interface {
+	Recv() (*CatalogChunk, error)
+	grpc.ClientStream
+}
+
+type productsServiceExportCatalogClient struct {
+	grpc.ClientStream
+}
+
+func (x *productsServiceExportCatalogClient) Recv() (*CatalogChunk, error) {
+	m := new(CatalogChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *productsServiceClient) UploadImage(ctx context.Context, opts ...grpc.CallOption) (ProductsService_UploadImageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ProductsService_ServiceDesc.Streams[4], "/grpc.ProductsService/UploadImage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &productsServiceUploadImageClient{stream}
+	return x, nil
+}
+
+type ProductsService_UploadImageClient interface {
+	Send(*UploadImageRequest) error
+	CloseAndRecv() (*UploadImageResponse, error)
+	grpc.ClientStream
+}
+
+type productsServiceUploadImageClient struct {
+	grpc.ClientStream
+}
+
+func (x *productsServiceUploadImageClient) Send(m *UploadImageRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *productsServiceUploadImageClient) CloseAndRecv() (*UploadImageResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadImageResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *productsServiceClient) ListImages(ctx context.Context, in *ListImagesRequest, opts ...grpc.CallOption) (*ListImagesResponse, error) {
+	out := new(ListImagesResponse)
+	err := c.cc.Invoke(ctx, "/grpc.ProductsService/ListImages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productsServiceClient) DeleteImage(ctx context.Context, in *DeleteImageRequest, opts ...grpc.CallOption) (*DeleteImageResponse, error) {
+	out := new(DeleteImageResponse)
+	err := c.cc.Invoke(ctx, "/grpc.ProductsService/DeleteImage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productsServiceClient) ReorderImages(ctx context.Context, in *ReorderImagesRequest, opts ...grpc.CallOption) (*ReorderImagesResponse, error) {
+	out := new(ReorderImagesResponse)
+	err := c.cc.Invoke(ctx, "/grpc.ProductsService/ReorderImages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProductsServiceServer is the server API for ProductsService service.
 // All implementations must embed UnimplementedProductsServiceServer
 // for forward compatibility
@@ -75,7 +326,20 @@ type ProductsServiceServer interface {
 	List(context.Context, *ListRequest) (*ListResponse, error)
 	Register(context.Context, *Product) (*RegisterResponse, error)
 	Update(context.Context, *Product) (*UpdateResponse, error)
+	Patch(context.Context, *PatchRequest) (*UpdateResponse, error)
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	Restore(context.Context, *RestoreRequest) (*RestoreResponse, error)
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	GetFacets(context.Context, *GetFacetsRequest) (*GetFacetsResponse, error)
+	GetProductHistory(context.Context, *GetProductHistoryRequest) (*GetProductHistoryResponse, error)
+	BulkRegister(ProductsService_BulkRegisterServer) error
+	Export(*ExportRequest, ProductsService_ExportServer) error
+	ImportCatalog(ProductsService_ImportCatalogServer) error
+	ExportCatalog(*ExportCatalogRequest, ProductsService_ExportCatalogServer) error
+	UploadImage(ProductsService_UploadImageServer) error
+	ListImages(context.Context, *ListImagesRequest) (*ListImagesResponse, error)
+	DeleteImage(context.Context, *DeleteImageRequest) (*DeleteImageResponse, error)
+	ReorderImages(context.Context, *ReorderImagesRequest) (*ReorderImagesResponse, error)
 	mustEmbedUnimplementedProductsServiceServer()
 }
 
@@ -92,9 +356,48 @@ func (UnimplementedProductsServiceServer) Register(context.Context, *Product) (*
 func (UnimplementedProductsServiceServer) Update(context.Context, *Product) (*UpdateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
 }
+func (UnimplementedProductsServiceServer) Patch(context.Context, *PatchRequest) (*UpdateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Patch not implemented")
+}
 func (UnimplementedProductsServiceServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedProductsServiceServer) Restore(context.Context, *RestoreRequest) (*RestoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedProductsServiceServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedProductsServiceServer) GetFacets(context.Context, *GetFacetsRequest) (*GetFacetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFacets not implemented")
+}
+func (UnimplementedProductsServiceServer) GetProductHistory(context.Context, *GetProductHistoryRequest) (*GetProductHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductHistory not implemented")
+}
+func (UnimplementedProductsServiceServer) BulkRegister(ProductsService_BulkRegisterServer) error {
+	return status.Errorf(codes.Unimplemented, "method BulkRegister not implemented")
+}
+func (UnimplementedProductsServiceServer) Export(*ExportRequest, ProductsService_ExportServer) error {
+	return status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+func (UnimplementedProductsServiceServer) ImportCatalog(ProductsService_ImportCatalogServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportCatalog not implemented")
+}
+func (UnimplementedProductsServiceServer) ExportCatalog(*ExportCatalogRequest, ProductsService_ExportCatalogServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportCatalog not implemented")
+}
+func (UnimplementedProductsServiceServer) UploadImage(ProductsService_UploadImageServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadImage not implemented")
+}
+func (UnimplementedProductsServiceServer) ListImages(context.Context, *ListImagesRequest) (*ListImagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListImages not implemented")
+}
+func (UnimplementedProductsServiceServer) DeleteImage(context.Context, *DeleteImageRequest) (*DeleteImageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteImage not implemented")
+}
+func (UnimplementedProductsServiceServer) ReorderImages(context.Context, *ReorderImagesRequest) (*ReorderImagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderImages not implemented")
+}
 func (UnimplementedProductsServiceServer) mustEmbedUnimplementedProductsServiceServer() {}
 
 // UnsafeProductsServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -162,6 +465,24 @@ func _ProductsService_Update_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProductsService_Patch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServiceServer).Patch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.ProductsService/Patch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServiceServer).Patch(ctx, req.(*PatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProductsService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteRequest)
 	if err := dec(in); err != nil {
@@ -180,6 +501,252 @@ func _ProductsService_Delete_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProductsService_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.ProductsService/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServiceServer).Restore(ctx, req.(*RestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductsService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.ProductsService/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServiceServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductsService_GetFacets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFacetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServiceServer).GetFacets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.ProductsService/GetFacets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServiceServer).GetFacets(ctx, req.(*GetFacetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductsService_GetProductHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServiceServer).GetProductHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.ProductsService/GetProductHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServiceServer).GetProductHistory(ctx, req.(*GetProductHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductsService_BulkRegister_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ProductsServiceServer).BulkRegister(&productsServiceBulkRegisterServer{stream})
+}
+
+type ProductsService_BulkRegisterServer interface {
+	SendAndClose(*BulkRegisterResponse) error
+	Recv() (*Product, error)
+	grpc.ServerStream
+}
+
+type productsServiceBulkRegisterServer struct {
+	grpc.ServerStream
+}
+
+func (x *productsServiceBulkRegisterServer) SendAndClose(m *BulkRegisterResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *productsServiceBulkRegisterServer) Recv() (*Product, error) {
+	m := new(Product)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ProductsService_Export_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProductsServiceServer).Export(m, &productsServiceExportServer{stream})
+}
+
+type ProductsService_ExportServer interface {
+	Send(*Product) error
+	grpc.ServerStream
+}
+
+type productsServiceExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *productsServiceExportServer) Send(m *Product) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ProductsService_ImportCatalog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ProductsServiceServer).ImportCatalog(&productsServiceImportCatalogServer{stream})
+}
+
+type ProductsService_ImportCatalogServer interface {
+	SendAndClose(*ImportResponse) error
+	Recv() (*ImportRequest, error)
+	grpc.ServerStream
+}
+
+type productsServiceImportCatalogServer struct {
+	grpc.ServerStream
+}
+
+func (x *productsServiceImportCatalogServer) SendAndClose(m *ImportResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *productsServiceImportCatalogServer) Recv() (*ImportRequest, error) {
+	m := new(ImportRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ProductsService_ExportCatalog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportCatalogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProductsServiceServer).ExportCatalog(m, &productsServiceExportCatalogServer{stream})
+}
+
+type ProductsService_ExportCatalogServer interface {
+	Send(*CatalogChunk) error
+	grpc.ServerStream
+}
+
+type productsServiceExportCatalogServer struct {
+	grpc.ServerStream
+}
+
+func (x *productsServiceExportCatalogServer) Send(m *CatalogChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ProductsService_UploadImage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ProductsServiceServer).UploadImage(&productsServiceUploadImageServer{stream})
+}
+
+type ProductsService_UploadImageServer interface {
+	SendAndClose(*UploadImageResponse) error
+	Recv() (*UploadImageRequest, error)
+	grpc.ServerStream
+}
+
+type productsServiceUploadImageServer struct {
+	grpc.ServerStream
+}
+
+func (x *productsServiceUploadImageServer) SendAndClose(m *UploadImageResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *productsServiceUploadImageServer) Recv() (*UploadImageRequest, error) {
+	m := new(UploadImageRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ProductsService_ListImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServiceServer).ListImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.ProductsService/ListImages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServiceServer).ListImages(ctx, req.(*ListImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductsService_DeleteImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServiceServer).DeleteImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.ProductsService/DeleteImage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServiceServer).DeleteImage(ctx, req.(*DeleteImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductsService_ReorderImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductsServiceServer).ReorderImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.ProductsService/ReorderImages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductsServiceServer).ReorderImages(ctx, req.(*ReorderImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProductsService_ServiceDesc is the grpc.ServiceDesc for ProductsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -199,11 +766,69 @@ var ProductsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Update",
 			Handler:    _ProductsService_Update_Handler,
 		},
+		{
+			MethodName: "Patch",
+			Handler:    _ProductsService_Patch_Handler,
+		},
 		{
 			MethodName: "Delete",
 			Handler:    _ProductsService_Delete_Handler,
 		},
+		{
+			MethodName: "Restore",
+			Handler:    _ProductsService_Restore_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _ProductsService_Search_Handler,
+		},
+		{
+			MethodName: "GetFacets",
+			Handler:    _ProductsService_GetFacets_Handler,
+		},
+		{
+			MethodName: "GetProductHistory",
+			Handler:    _ProductsService_GetProductHistory_Handler,
+		},
+		{
+			MethodName: "ListImages",
+			Handler:    _ProductsService_ListImages_Handler,
+		},
+		{
+			MethodName: "DeleteImage",
+			Handler:    _ProductsService_DeleteImage_Handler,
+		},
+		{
+			MethodName: "ReorderImages",
+			Handler:    _ProductsService_ReorderImages_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BulkRegister",
+			Handler:       _ProductsService_BulkRegister_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Export",
+			Handler:       _ProductsService_Export_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportCatalog",
+			Handler:       _ProductsService_ImportCatalog_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportCatalog",
+			Handler:       _ProductsService_ExportCatalog_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadImage",
+			Handler:       _ProductsService_UploadImage_Handler,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "ports/grpc/proto/products.proto",
 }
@@ -0,0 +1,114 @@
+package carriers
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var ErrUnknownTrackingNumber = errors.New("unknown tracking number")
+
+// checkDigitWeights weigh the 8 serial digits of an S10 tracking number
+var checkDigitWeights = [8]int{8, 6, 4, 2, 3, 5, 9, 7}
+
+// FakeCarrier books the Shipments locally, issuing tracking numbers in the S10 format of the postal services,
+// e.g. FK123456785ZZ, so the whole shipping flow can run without a real carrier.
+type FakeCarrier struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	name string
+
+	// mu guards booked, the tracking numbers of the Shipments booked and not cancelled
+	mu     sync.Mutex
+	booked map[string]bool
+}
+
+// NewFakeCarrier creates a new FakeCarrier, referred to by the ShippingMethods by its name
+func NewFakeCarrier(logger *zap.Logger, tracer trace.Tracer, name string) *FakeCarrier {
+	return &FakeCarrier{
+		Logger: logger,
+		Tracer: tracer,
+		name:   name,
+		booked: map[string]bool{},
+	}
+}
+
+// Name identifies the FakeCarrier on the ShippingMethods
+func (c *FakeCarrier) Name() string {
+	return c.name
+}
+
+// CreateShipment issues a new tracking number for the Shipment
+func (c *FakeCarrier) CreateShipment(ctx context.Context, shipment domain.Shipment) (string, error) {
+	_, span := c.Tracer.Start(ctx, "carrier.CreateShipment")
+	defer span.End()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for {
+		serial, err := rand.Int(rand.Reader, big.NewInt(100_000_000))
+		if err != nil {
+			return "", err
+		}
+
+		trackingNumber := newTrackingNumber(int(serial.Int64()))
+		if c.booked[trackingNumber] {
+			continue
+		}
+
+		c.booked[trackingNumber] = true
+
+		c.Logger.Info("booked a shipment", zap.Int("orderId", shipment.OrderID), zap.String("trackingNumber", trackingNumber))
+
+		return trackingNumber, nil
+	}
+}
+
+// CancelShipment forgets the booking of a tracking number, failing with ErrUnknownTrackingNumber when it wasn't booked
+func (c *FakeCarrier) CancelShipment(ctx context.Context, trackingNumber string) error {
+	_, span := c.Tracer.Start(ctx, "carrier.CancelShipment")
+	defer span.End()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.booked[trackingNumber] {
+		return ErrUnknownTrackingNumber
+	}
+
+	delete(c.booked, trackingNumber)
+
+	return nil
+}
+
+// newTrackingNumber builds an S10 tracking number out of an 8 digits serial number
+func newTrackingNumber(serial int) string {
+	digits := fmt.Sprintf("%08d", serial)
+	return fmt.Sprintf("FK%s%dZZ", digits, checkDigit(digits))
+}
+
+// checkDigit is the S10 check digit of 8 serial digits, catching most typos of the tracking numbers
+func checkDigit(digits string) int {
+	sum := 0
+	for i, digit := range digits {
+		sum += int(digit-'0') * checkDigitWeights[i]
+	}
+
+	switch check := 11 - sum%11; check {
+	case 10:
+		return 0
+	case 11:
+		return 5
+	default:
+		return check
+	}
+}
@@ -0,0 +1,76 @@
+package carriers
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+)
+
+var trackingNumberPattern = regexp.MustCompile(`^FK(\d{8})(\d)ZZ$`)
+
+type FakeCarrierSuite struct {
+	suite.Suite
+
+	carrier *FakeCarrier
+}
+
+func (s *FakeCarrierSuite) SetupTest() {
+	s.carrier = NewFakeCarrier(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), "local")
+}
+
+func (s *FakeCarrierSuite) Test_CreateShipment() {
+	s.Run("Should issue distinct S10 tracking numbers", func() {
+		s.SetupTest()
+
+		seen := map[string]bool{}
+		for i := 0; i < 50; i++ {
+			trackingNumber, err := s.carrier.CreateShipment(context.Background(), domain.Shipment{OrderID: 1})
+			s.Require().NoError(err)
+
+			matches := trackingNumberPattern.FindStringSubmatch(trackingNumber)
+			s.Require().NotNil(matches, trackingNumber)
+			s.Equal(string(rune('0'+checkDigit(matches[1]))), matches[2])
+
+			s.False(seen[trackingNumber])
+			seen[trackingNumber] = true
+		}
+	})
+}
+
+func (s *FakeCarrierSuite) Test_CancelShipment() {
+	s.Run("Should cancel a booked shipment only once", func() {
+		s.SetupTest()
+
+		trackingNumber, err := s.carrier.CreateShipment(context.Background(), domain.Shipment{OrderID: 1})
+		s.Require().NoError(err)
+
+		s.NoError(s.carrier.CancelShipment(context.Background(), trackingNumber))
+		s.Equal(ErrUnknownTrackingNumber, s.carrier.CancelShipment(context.Background(), trackingNumber))
+	})
+}
+
+func (s *FakeCarrierSuite) Test_checkDigit() {
+	// RB473124829GB is the example of the S10 standard, a sum multiple of 11 gives a check digit of 5
+	testCases := []struct {
+		digits string
+		check  int
+	}{
+		{digits: "47312482", check: 9},
+		{digits: "12345678", check: 5},
+		{digits: "00000000", check: 5},
+	}
+
+	for _, testCase := range testCases {
+		s.Equal(testCase.check, checkDigit(testCase.digits), testCase.digits)
+	}
+}
+
+func TestFakeCarrierSuite(t *testing.T) {
+	suite.Run(t, new(FakeCarrierSuite))
+}
@@ -0,0 +1,100 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+
+	productsPb "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrMissingLogger         = errors.New("missing required dependency: Logger")
+	ErrMissingTracer         = errors.New("missing required dependency: Tracer")
+	ErrMissingProductsClient = errors.New("missing required dependency: ProductsClient")
+)
+
+// GrpcProductsCatalog implements domain.Catalog on top of the ProductsService gRPC API
+type GrpcProductsCatalog struct {
+	Logger         *zap.Logger
+	Tracer         trace.Tracer
+	ProductsClient productsPb.ProductsServiceClient
+}
+
+// NewGrpcProductsCatalog creates a new GrpcProductsCatalog instance
+func NewGrpcProductsCatalog(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	productsClient productsPb.ProductsServiceClient,
+) (*GrpcProductsCatalog, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMissingTracer
+	}
+
+	if productsClient == nil {
+		return nil, ErrMissingProductsClient
+	}
+
+	return &GrpcProductsCatalog{
+		Logger:         logger,
+		Tracer:         tracer,
+		ProductsClient: productsClient,
+	}, nil
+}
+
+// MustNewGrpcProductsCatalog creates a new GrpcProductsCatalog instance
+// It panics if any error is found
+func MustNewGrpcProductsCatalog(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	productsClient productsPb.ProductsServiceClient,
+) *GrpcProductsCatalog {
+	catalog, err := NewGrpcProductsCatalog(logger, tracer, productsClient)
+	if err != nil {
+		panic(err)
+	}
+
+	return catalog
+}
+
+// Products fetches the weight and dimensions of the given Products from the ProductsService.
+// The deleted Products are kept, since the Orders placed before they were deleted still have to be shipped.
+func (c *GrpcProductsCatalog) Products(ctx context.Context, ids []int) (map[int]domain.ProductSnapshot, error) {
+	ctx, span := c.Tracer.Start(ctx, "catalog.Products")
+	defer span.End()
+
+	products := map[int]domain.ProductSnapshot{}
+	if len(ids) == 0 {
+		return products, nil
+	}
+
+	req := &productsPb.ListRequest{Ids: make([]int32, 0, len(ids)), IncludeDeleted: true}
+	for _, id := range ids {
+		req.Ids = append(req.Ids, int32(id))
+	}
+
+	res, err := c.ProductsClient.List(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, product := range res.Data {
+		products[int(product.Id)] = domain.ProductSnapshot{
+			ID:     int(product.Id),
+			Weight: int(product.Weight),
+			Dimensions: domain.Dimensions{
+				Length: int(product.Dimensions.GetLength()),
+				Width:  int(product.Dimensions.GetWidth()),
+				Height: int(product.Dimensions.GetHeight()),
+			},
+		}
+	}
+
+	return products, nil
+}
@@ -0,0 +1,60 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	pb "github.com/lucasmls/ecommerce/services/shipping/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/shared/rmq"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RmqEventPublisher publishes the ShipmentEvents through the shipping exchange of RabbitMQ, routed by the status of their Shipments.
+type RmqEventPublisher struct {
+	Logger    *zap.Logger
+	Tracer    trace.Tracer
+	Publisher rmq.Publisher
+}
+
+// NewRmqEventPublisher creates a new RmqEventPublisher.
+func NewRmqEventPublisher(logger *zap.Logger, tracer trace.Tracer, publisher rmq.Publisher) *RmqEventPublisher {
+	return &RmqEventPublisher{
+		Logger:    logger,
+		Tracer:    tracer,
+		Publisher: publisher,
+	}
+}
+
+// Publish publishes a ShipmentEvent, routed by the status its TrackingEvent moved the Shipment to
+func (p *RmqEventPublisher) Publish(ctx context.Context, event domain.ShipmentEvent) error {
+	ctx, span := p.Tracer.Start(ctx, "events.Publish")
+	defer span.End()
+
+	shipment := event.Shipment
+	status := string(event.TrackingEvent.Status)
+
+	return p.Publisher.Publish(ctx, pb.Exchange, pb.EventRoutingKey(status), &pb.ShipmentEvent{
+		Status:         status,
+		ShipmentId:     int32(shipment.ID),
+		OrderId:        int32(shipment.OrderID),
+		CustomerId:     shipment.CustomerID,
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+		Method:         shipment.Method,
+		Location:       event.TrackingEvent.Location,
+		Description:    event.TrackingEvent.Description,
+		Country:        shipment.Destination.Country,
+		OccurredAt:     toProtoTimestamp(event.TrackingEvent.At),
+	})
+}
+
+func toProtoTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
@@ -0,0 +1,85 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	pb "github.com/lucasmls/ecommerce/services/shipping/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/shared/rmq/rmqtest"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type RmqEventPublisherSuite struct {
+	suite.Suite
+
+	publisher      *rmqtest.Publisher
+	eventPublisher *RmqEventPublisher
+}
+
+func (s *RmqEventPublisherSuite) SetupTest() {
+	s.publisher = &rmqtest.Publisher{}
+	s.eventPublisher = NewRmqEventPublisher(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""), s.publisher)
+}
+
+func (s *RmqEventPublisherSuite) Test_Publish() {
+	s.Run("Should route the event by the status of the shipment", func() {
+		at := time.Date(2022, 5, 10, 10, 0, 0, 0, time.UTC)
+
+		err := s.eventPublisher.Publish(context.Background(), domain.ShipmentEvent{
+			Shipment: domain.Shipment{
+				ID:             3,
+				OrderID:        7,
+				CustomerID:     "c1",
+				Method:         "express",
+				Carrier:        "local",
+				TrackingNumber: "FK473124829ZZ",
+				Destination:    domain.Address{Country: "US"},
+				Status:         domain.ShipmentStatusOutForDelivery,
+			},
+			TrackingEvent: domain.TrackingEvent{
+				Status:      domain.ShipmentStatusOutForDelivery,
+				Location:    "Brooklyn, NY",
+				Description: "Out for delivery",
+				At:          at,
+			},
+		})
+
+		s.NoError(err)
+		s.Equal("shipping", s.publisher.Exchange)
+		s.Equal("shipping.out_for_delivery", s.publisher.RoutingKey)
+		s.True(proto.Equal(&pb.ShipmentEvent{
+			Status:         "out_for_delivery",
+			ShipmentId:     3,
+			OrderId:        7,
+			CustomerId:     "c1",
+			Carrier:        "local",
+			TrackingNumber: "FK473124829ZZ",
+			Method:         "express",
+			Location:       "Brooklyn, NY",
+			Description:    "Out for delivery",
+			Country:        "US",
+			OccurredAt:     timestamppb.New(at),
+		}, s.publisher.Message))
+	})
+
+	s.Run("Should fail in case the event can't be published", func() {
+		s.publisher.Err = errors.New("connection closed")
+
+		err := s.eventPublisher.Publish(context.Background(), domain.ShipmentEvent{
+			TrackingEvent: domain.TrackingEvent{Status: domain.ShipmentStatusDelivered},
+		})
+
+		s.Equal(s.publisher.Err, err)
+	})
+}
+
+func TestRmqEventPublisherSuite(t *testing.T) {
+	suite.Run(t, new(RmqEventPublisherSuite))
+}
@@ -0,0 +1,188 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// FileRatesRepositoryInput is the input (aka dependencies) to create a FileRatesRepository.
+type FileRatesRepositoryInput struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	// Path is the JSON file holding the RateTable, as in rates/rates.json.
+	Path string
+}
+
+// rateTableFile is the layout of the rates file, the weights being in grams and the prices in cents.
+// The volumetric divisor is the DefaultVolumetricDivisor when it's left out.
+//
+//	{
+//	  "version": "2022-10-01",
+//	  "zones": [{"name": "domestic", "countries": ["US"]}, {"name": "international", "countries": ["*"]}],
+//	  "methods": [
+//	    {"code": "standard", "name": "Standard", "carrier": "local", "rates": {"domestic": [{"max_weight": 500, "price": 499}]}}
+//	  ]
+//	}
+type rateTableFile struct {
+	Version           string `json:"version"`
+	VolumetricDivisor int    `json:"volumetric_divisor"`
+	Zones             []struct {
+		Name      string   `json:"name"`
+		Countries []string `json:"countries"`
+	} `json:"zones"`
+	Methods []struct {
+		Code    string `json:"code"`
+		Name    string `json:"name"`
+		Carrier string `json:"carrier"`
+		Rates   map[string][]struct {
+			MaxWeight int `json:"max_weight"`
+			Price     int `json:"price"`
+		} `json:"rates"`
+	} `json:"methods"`
+}
+
+// FileRatesRepository reads the RateTable from a JSON file.
+// The file is read again whenever it changes, a change that can't be read keeps the previous RateTable in use.
+type FileRatesRepository struct {
+	in FileRatesRepositoryInput
+
+	// mu guards table and modTime, the modification time of the file table was read from
+	mu      sync.Mutex
+	table   domain.RateTable
+	modTime time.Time
+}
+
+// NewFileRatesRepository creates a new FileRatesRepository, failing when the file holds no valid RateTable.
+func NewFileRatesRepository(in FileRatesRepositoryInput) (*FileRatesRepository, error) {
+	if in.Path == "" {
+		return nil, errors.New("missing required dependency: Path")
+	}
+
+	info, err := os.Stat(in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	table, err := readRateTable(in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileRatesRepository{in: in, table: table, modTime: info.ModTime()}, nil
+}
+
+// MustNewFileRatesRepository creates a new FileRatesRepository.
+// It panics if any error is found.
+func MustNewFileRatesRepository(in FileRatesRepositoryInput) *FileRatesRepository {
+	repository, err := NewFileRatesRepository(in)
+	if err != nil {
+		panic(err)
+	}
+
+	return repository
+}
+
+// Get fetches the RateTable, reading the file again first when it changed since it was last read.
+func (r *FileRatesRepository) Get(ctx context.Context) (domain.RateTable, error) {
+	_, span := r.in.Tracer.Start(ctx, "repository.Get")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	info, err := os.Stat(r.in.Path)
+	if err != nil {
+		r.in.Logger.Warn("failed to check the rates file, keeping the current rates", zap.String("version", r.table.Version), zap.Error(err))
+		return r.table, nil
+	}
+
+	if info.ModTime().Equal(r.modTime) {
+		return r.table, nil
+	}
+
+	table, err := readRateTable(r.in.Path)
+	if err != nil {
+		r.in.Logger.Error("failed to read the changed rates file, keeping the current rates", zap.String("version", r.table.Version), zap.Error(err))
+		return r.table, nil
+	}
+
+	r.in.Logger.Info("read the changed rates file", zap.String("previousVersion", r.table.Version), zap.String("version", table.Version))
+
+	r.table = table
+	r.modTime = info.ModTime()
+
+	return r.table, nil
+}
+
+// readRateTable reads and validates the RateTable of a file
+func readRateTable(path string) (domain.RateTable, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return domain.RateTable{}, err
+	}
+	defer file.Close()
+
+	var content rateTableFile
+
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&content); err != nil {
+		return domain.RateTable{}, fmt.Errorf("%w: %s", domain.ErrInvalidRateTable, err)
+	}
+
+	table := domain.RateTable{
+		Version:           content.Version,
+		VolumetricDivisor: content.VolumetricDivisor,
+		Zones:             make([]domain.Zone, 0, len(content.Zones)),
+		Methods:           make([]domain.ShippingMethod, 0, len(content.Methods)),
+	}
+
+	if table.VolumetricDivisor == 0 {
+		table.VolumetricDivisor = domain.DefaultVolumetricDivisor
+	}
+
+	for _, zone := range content.Zones {
+		countries := make([]string, 0, len(zone.Countries))
+		for _, country := range zone.Countries {
+			countries = append(countries, strings.ToUpper(strings.TrimSpace(country)))
+		}
+
+		table.Zones = append(table.Zones, domain.Zone{Name: zone.Name, Countries: countries})
+	}
+
+	for _, method := range content.Methods {
+		rates := make(map[string][]domain.WeightTier, len(method.Rates))
+		for zone, tiers := range method.Rates {
+			rates[zone] = make([]domain.WeightTier, 0, len(tiers))
+
+			for _, tier := range tiers {
+				rates[zone] = append(rates[zone], domain.WeightTier{MaxWeight: tier.MaxWeight, Price: tier.Price})
+			}
+		}
+
+		table.Methods = append(table.Methods, domain.ShippingMethod{
+			Code:    method.Code,
+			Name:    method.Name,
+			Carrier: method.Carrier,
+			Rates:   rates,
+		})
+	}
+
+	if err := table.Validate(); err != nil {
+		return domain.RateTable{}, err
+	}
+
+	return table, nil
+}
@@ -0,0 +1,161 @@
+package repositories
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const rates = `{
+  "version": "2022-10-01",
+  "zones": [{"name": "domestic", "countries": ["us"]}, {"name": "international", "countries": ["*"]}],
+  "methods": [
+    {
+      "code": "standard", "name": "Standard", "carrier": "local",
+      "rates": {"domestic": [{"max_weight": 500, "price": 499}, {"max_weight": 2000, "price": 899}], "international": [{"max_weight": 2000, "price": 2499}]}
+    }
+  ]
+}`
+
+type FileRatesRepositorySuite struct {
+	suite.Suite
+
+	path string
+}
+
+func (s *FileRatesRepositorySuite) SetupTest() {
+	s.path = filepath.Join(s.T().TempDir(), "rates.json")
+}
+
+// write replaces the content of the rates file, moving its modification time forward so the change is noticed
+func (s *FileRatesRepositorySuite) write(content string, modTime time.Time) {
+	s.Require().NoError(os.WriteFile(s.path, []byte(content), 0o644))
+	s.Require().NoError(os.Chtimes(s.path, modTime, modTime))
+}
+
+func (s *FileRatesRepositorySuite) repository() (*FileRatesRepository, error) {
+	return NewFileRatesRepository(FileRatesRepositoryInput{
+		Logger: zap.NewNop(),
+		Tracer: trace.NewNoopTracerProvider().Tracer(""),
+		Path:   s.path,
+	})
+}
+
+func (s *FileRatesRepositorySuite) Test_NewFileRatesRepository() {
+	s.Run("Should fail in case the file is missing", func() {
+		s.SetupTest()
+
+		_, err := s.repository()
+
+		s.ErrorIs(err, os.ErrNotExist)
+	})
+
+	for name, content := range map[string]string{
+		"isn't JSON":                  "version: 1",
+		"has unknown fields":          `{"version": "1", "zones": [{"name": "domestic", "country": "US"}]}`,
+		"has no version":              `{"zones": []}`,
+		"has a negative divisor":      `{"version": "1", "volumetric_divisor": -1}`,
+		"repeats a zone":              `{"version": "1", "zones": [{"name": "a", "countries": ["US"]}, {"name": "a", "countries": ["CA"]}]}`,
+		"puts a country on two zones": `{"version": "1", "zones": [{"name": "a", "countries": ["US"]}, {"name": "b", "countries": ["us"]}]}`,
+		"has an invalid country":      `{"version": "1", "zones": [{"name": "a", "countries": ["USA"]}]}`,
+		"has an invalid method code":  `{"version": "1", "methods": [{"code": "Next Day", "name": "Next day", "carrier": "local"}]}`,
+		"prices an unknown zone":      `{"version": "1", "methods": [{"code": "standard", "name": "Standard", "carrier": "local", "rates": {"mars": [{"max_weight": 1, "price": 1}]}}]}`,
+		"has unsorted tiers": `{"version": "1", "zones": [{"name": "a", "countries": ["US"]}],
+			"methods": [{"code": "standard", "name": "Standard", "carrier": "local", "rates": {"a": [{"max_weight": 900, "price": 1}, {"max_weight": 500, "price": 1}]}}]}`,
+		"has a method without carrier": `{"version": "1", "methods": [{"code": "standard", "name": "Standard"}]}`,
+	} {
+		s.Run("Should fail in case the file "+name, func() {
+			s.SetupTest()
+			s.write(content, time.Now())
+
+			_, err := s.repository()
+
+			s.ErrorIs(err, domain.ErrInvalidRateTable)
+		})
+	}
+}
+
+func (s *FileRatesRepositorySuite) Test_Get() {
+	s.Run("Should read the zones and the methods, defaulting the volumetric divisor", func() {
+		s.SetupTest()
+		s.write(rates, time.Now())
+
+		repository, err := s.repository()
+		s.Require().NoError(err)
+
+		got, err := repository.Get(context.Background())
+
+		s.NoError(err)
+		s.Equal(domain.RateTable{
+			Version:           "2022-10-01",
+			VolumetricDivisor: domain.DefaultVolumetricDivisor,
+			Zones: []domain.Zone{
+				{Name: "domestic", Countries: []string{"US"}},
+				{Name: "international", Countries: []string{"*"}},
+			},
+			Methods: []domain.ShippingMethod{
+				{
+					Code:    "standard",
+					Name:    "Standard",
+					Carrier: "local",
+					Rates: map[string][]domain.WeightTier{
+						"domestic":      {{MaxWeight: 500, Price: 499}, {MaxWeight: 2000, Price: 899}},
+						"international": {{MaxWeight: 2000, Price: 2499}},
+					},
+				},
+			},
+		}, got)
+	})
+
+	s.Run("Should read the file again once it changes, keeping the previous rates while it's invalid", func() {
+		s.SetupTest()
+
+		modTime := time.Now().Add(-time.Hour)
+		s.write(rates, modTime)
+
+		repository, err := s.repository()
+		s.Require().NoError(err)
+
+		s.write(`{"version": "2023-01-01", "zones": [`, modTime.Add(time.Minute))
+
+		got, err := repository.Get(context.Background())
+		s.NoError(err)
+		s.Equal("2022-10-01", got.Version)
+
+		s.write(`{"version": "2023-01-01", "volumetric_divisor": 6000, "zones": [{"name": "domestic", "countries": ["US"]}]}`, modTime.Add(2*time.Minute))
+
+		got, err = repository.Get(context.Background())
+		s.NoError(err)
+		s.Equal(domain.RateTable{
+			Version:           "2023-01-01",
+			VolumetricDivisor: 6000,
+			Zones:             []domain.Zone{{Name: "domestic", Countries: []string{"US"}}},
+			Methods:           []domain.ShippingMethod{},
+		}, got)
+	})
+
+	s.Run("Should keep the rates in case the file is removed", func() {
+		s.SetupTest()
+		s.write(rates, time.Now())
+
+		repository, err := s.repository()
+		s.Require().NoError(err)
+		s.Require().NoError(os.Remove(s.path))
+
+		got, err := repository.Get(context.Background())
+
+		s.NoError(err)
+		s.Equal("2022-10-01", got.Version)
+	})
+}
+
+func TestFileRatesRepositorySuite(t *testing.T) {
+	suite.Run(t, new(FileRatesRepositorySuite))
+}
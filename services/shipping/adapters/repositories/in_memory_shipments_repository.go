@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// InMemoryShipmentsRepository keeps the Shipments in memory.
+type InMemoryShipmentsRepository struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	// mu guards shipments, whose IDs are their positions in the slice, and trackingNumbers, which maps the tracking numbers to their IDs
+	mu              sync.Mutex
+	shipments       []domain.Shipment
+	trackingNumbers map[string]int
+}
+
+// NewInMemoryShipmentsRepository creates a new InMemoryShipmentsRepository.
+func NewInMemoryShipmentsRepository(logger *zap.Logger, tracer trace.Tracer) *InMemoryShipmentsRepository {
+	return &InMemoryShipmentsRepository{
+		Logger:          logger,
+		Tracer:          tracer,
+		trackingNumbers: map[string]int{},
+	}
+}
+
+// Create stores a new Shipment in memory.
+func (r *InMemoryShipmentsRepository) Create(ctx context.Context, shipment domain.Shipment) (domain.Shipment, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Create")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.trackingNumbers[shipment.TrackingNumber]; ok {
+		return domain.Shipment{}, domain.ErrTrackingNumberTaken
+	}
+
+	shipment = copyShipment(shipment)
+	shipment.ID = len(r.shipments) + 1
+	shipment.Version = 1
+
+	r.shipments = append(r.shipments, shipment)
+	r.trackingNumbers[shipment.TrackingNumber] = shipment.ID
+
+	return copyShipment(shipment), nil
+}
+
+// Get fetches a single Shipment from memory.
+func (r *InMemoryShipmentsRepository) Get(ctx context.Context, id int) (domain.Shipment, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Get")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.get(id)
+}
+
+// GetByTrackingNumber fetches the Shipment of a tracking number from memory.
+func (r *InMemoryShipmentsRepository) GetByTrackingNumber(ctx context.Context, trackingNumber string) (domain.Shipment, error) {
+	_, span := r.Tracer.Start(ctx, "repository.GetByTrackingNumber")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.get(r.trackingNumbers[trackingNumber])
+}
+
+// List lists the Shipments matching the filter from memory, most recently created first.
+func (r *InMemoryShipmentsRepository) List(ctx context.Context, filter domain.ShipmentFilter) ([]domain.Shipment, error) {
+	_, span := r.Tracer.Start(ctx, "repository.List")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := []domain.Shipment{}
+	for _, shipment := range r.shipments {
+		if filter.OrderID != 0 && shipment.OrderID != filter.OrderID {
+			continue
+		}
+
+		if filter.Status != "" && shipment.Status != filter.Status {
+			continue
+		}
+
+		result = append(result, copyShipment(shipment))
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if !result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].CreatedAt.After(result[j].CreatedAt)
+		}
+
+		return result[i].ID > result[j].ID
+	})
+
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[:filter.Limit]
+	}
+
+	return result, nil
+}
+
+// Update replaces a Shipment in memory as long as its Version matches the stored one.
+func (r *InMemoryShipmentsRepository) Update(ctx context.Context, shipment domain.Shipment) (domain.Shipment, error) {
+	_, span := r.Tracer.Start(ctx, "repository.Update")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, err := r.get(shipment.ID)
+	if err != nil {
+		return domain.Shipment{}, err
+	}
+
+	if stored.Version != shipment.Version {
+		return domain.Shipment{}, domain.ErrShipmentVersionConflict
+	}
+
+	// the tracking number identifies the Shipment to its carrier, so it never changes
+	shipment = copyShipment(shipment)
+	shipment.TrackingNumber = stored.TrackingNumber
+	shipment.Version++
+
+	r.shipments[shipment.ID-1] = shipment
+
+	return copyShipment(shipment), nil
+}
+
+// get fetches a stored Shipment, the caller must hold mu
+func (r *InMemoryShipmentsRepository) get(id int) (domain.Shipment, error) {
+	if id <= 0 || id > len(r.shipments) {
+		return domain.Shipment{}, domain.ErrShipmentNotFound
+	}
+
+	return copyShipment(r.shipments[id-1]), nil
+}
+
+// copyShipment keeps the stored Items and TrackingEvents from being changed through the Shipments handed out by the repository
+func copyShipment(shipment domain.Shipment) domain.Shipment {
+	shipment.Items = append([]domain.Item{}, shipment.Items...)
+	shipment.TrackingEvents = append([]domain.TrackingEvent{}, shipment.TrackingEvents...)
+	return shipment
+}
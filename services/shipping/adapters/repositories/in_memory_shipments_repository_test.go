@@ -0,0 +1,137 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type InMemoryShipmentsRepositorySuite struct {
+	suite.Suite
+
+	shipmentsRepo *InMemoryShipmentsRepository
+	createdAt     time.Time
+}
+
+func (s *InMemoryShipmentsRepositorySuite) SetupTest() {
+	s.shipmentsRepo = NewInMemoryShipmentsRepository(zap.NewNop(), trace.NewNoopTracerProvider().Tracer(""))
+	s.createdAt = time.Date(2022, 5, 1, 10, 0, 0, 0, time.UTC)
+}
+
+func (s *InMemoryShipmentsRepositorySuite) create(trackingNumber string, orderID int, createdAt time.Time) domain.Shipment {
+	shipment, err := s.shipmentsRepo.Create(context.Background(), domain.Shipment{
+		OrderID:        orderID,
+		Method:         "standard",
+		Carrier:        "local",
+		TrackingNumber: trackingNumber,
+		Items:          []domain.Item{{ProductID: 1, Quantity: 1, Weight: 174}},
+		Status:         domain.ShipmentStatusLabelCreated,
+		TrackingEvents: []domain.TrackingEvent{{Status: domain.ShipmentStatusLabelCreated, At: createdAt}},
+		CreatedAt:      createdAt,
+		UpdatedAt:      createdAt,
+	})
+	s.Require().NoError(err)
+
+	return shipment
+}
+
+func (s *InMemoryShipmentsRepositorySuite) Test_Create() {
+	s.Run("Should set the ID and the first version of the shipment", func() {
+		shipment := s.create("FK000000015ZZ", 1, s.createdAt)
+
+		s.Equal(1, shipment.ID)
+		s.Equal(1, shipment.Version)
+
+		got, err := s.shipmentsRepo.Get(context.Background(), shipment.ID)
+		s.NoError(err)
+		s.Equal(shipment, got)
+
+		got, err = s.shipmentsRepo.GetByTrackingNumber(context.Background(), "FK000000015ZZ")
+		s.NoError(err)
+		s.Equal(shipment, got)
+	})
+
+	s.Run("Should fail in case the tracking number is taken", func() {
+		_, err := s.shipmentsRepo.Create(context.Background(), domain.Shipment{TrackingNumber: "FK000000015ZZ"})
+
+		s.Equal(domain.ErrTrackingNumberTaken, err)
+	})
+}
+
+func (s *InMemoryShipmentsRepositorySuite) Test_Get() {
+	for _, id := range []int{0, 1} {
+		_, err := s.shipmentsRepo.Get(context.Background(), id)
+
+		s.Equal(domain.ErrShipmentNotFound, err)
+	}
+
+	_, err := s.shipmentsRepo.GetByTrackingNumber(context.Background(), "FK000000015ZZ")
+	s.Equal(domain.ErrShipmentNotFound, err)
+}
+
+func (s *InMemoryShipmentsRepositorySuite) Test_List() {
+	first := s.create("FK000000015ZZ", 1, s.createdAt)
+	second := s.create("FK000000029ZZ", 2, s.createdAt.Add(time.Hour))
+	third := s.create("FK000000032ZZ", 1, s.createdAt.Add(time.Hour))
+
+	s.Require().NoError(third.Track(domain.TrackingEvent{Status: domain.ShipmentStatusInTransit}, s.createdAt.Add(2*time.Hour)))
+	third, err := s.shipmentsRepo.Update(context.Background(), third)
+	s.Require().NoError(err)
+
+	s.Run("Should list the most recently created shipments first", func() {
+		got, err := s.shipmentsRepo.List(context.Background(), domain.ShipmentFilter{})
+
+		s.NoError(err)
+		s.Equal([]domain.Shipment{third, second, first}, got)
+	})
+
+	s.Run("Should list the shipments of the order with the status", func() {
+		got, err := s.shipmentsRepo.List(context.Background(), domain.ShipmentFilter{OrderID: 1, Status: domain.ShipmentStatusLabelCreated})
+
+		s.NoError(err)
+		s.Equal([]domain.Shipment{first}, got)
+	})
+
+	s.Run("Should list up to the limit", func() {
+		got, err := s.shipmentsRepo.List(context.Background(), domain.ShipmentFilter{Limit: 1})
+
+		s.NoError(err)
+		s.Equal([]domain.Shipment{third}, got)
+	})
+}
+
+func (s *InMemoryShipmentsRepositorySuite) Test_Update() {
+	shipment := s.create("FK000000015ZZ", 1, s.createdAt)
+
+	s.Run("Should replace the shipment and increment its version", func() {
+		s.Require().NoError(shipment.Track(domain.TrackingEvent{Status: domain.ShipmentStatusInTransit}, s.createdAt))
+
+		updated, err := s.shipmentsRepo.Update(context.Background(), shipment)
+
+		s.NoError(err)
+		s.Equal(2, updated.Version)
+		s.Equal(domain.ShipmentStatusInTransit, updated.Status)
+		s.Len(updated.TrackingEvents, 2)
+	})
+
+	s.Run("Should fail in case the shipment was changed since it was read", func() {
+		_, err := s.shipmentsRepo.Update(context.Background(), shipment)
+
+		s.Equal(domain.ErrShipmentVersionConflict, err)
+	})
+
+	s.Run("Should fail in case the shipment doesn't exist", func() {
+		_, err := s.shipmentsRepo.Update(context.Background(), domain.Shipment{ID: 2, Version: 1})
+
+		s.Equal(domain.ErrShipmentNotFound, err)
+	})
+}
+
+func TestInMemoryShipmentsRepositorySuite(t *testing.T) {
+	suite.Run(t, new(InMemoryShipmentsRepositorySuite))
+}
@@ -0,0 +1,336 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"github.com/lucasmls/ecommerce/shared/pg"
+)
+
+const shipmentColumns = "id, order_id, customer_id, method, carrier, tracking_number, destination, items, billable_weight, price, rates_version, status, tracking_events, version, created_at, updated_at"
+
+// addressRecord is how the Address of a shipment is stored on its destination column
+type addressRecord struct {
+	Recipient  string `json:"recipient"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2,omitempty"`
+	City       string `json:"city"`
+	Region     string `json:"region,omitempty"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+	Phone      string `json:"phone,omitempty"`
+}
+
+// itemRecord is how an Item is stored on the items column of a shipment
+type itemRecord struct {
+	ProductID int `json:"product_id"`
+	Quantity  int `json:"quantity"`
+	Weight    int `json:"weight"`
+	Length    int `json:"length,omitempty"`
+	Width     int `json:"width,omitempty"`
+	Height    int `json:"height,omitempty"`
+}
+
+// trackingEventRecord is how a TrackingEvent is stored on the tracking_events column of a shipment
+type trackingEventRecord struct {
+	Status      string    `json:"status"`
+	Location    string    `json:"location,omitempty"`
+	Description string    `json:"description,omitempty"`
+	At          time.Time `json:"at"`
+}
+
+// PgShipmentsRepository keeps the Shipments on Postgres, each one on a single row.
+// The rows are only updated while their version is still the one the Shipment was read with,
+// so concurrent tracking events can't skip the state machine.
+type PgShipmentsRepository struct {
+	db *sql.DB
+}
+
+func NewPgShipmentsRepository(connectionString string) (*PgShipmentsRepository, error) {
+	db, err := sql.Open(
+		"postgres",
+		connectionString,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	repository := &PgShipmentsRepository{
+		db: db,
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+func MustNewPgShipmentsRepository(connectionString string) *PgShipmentsRepository {
+	repo, err := NewPgShipmentsRepository(connectionString)
+	if err != nil {
+		panic(err)
+	}
+
+	return repo
+}
+
+// Create inserts a new Shipment
+func (r *PgShipmentsRepository) Create(ctx context.Context, shipment domain.Shipment) (domain.Shipment, error) {
+	destination, items, events, err := marshalShipment(shipment)
+	if err != nil {
+		return domain.Shipment{}, err
+	}
+
+	row := r.db.QueryRowContext(
+		ctx,
+		`INSERT INTO shipments (order_id, customer_id, method, carrier, tracking_number, destination, items, billable_weight, price, rates_version, status, tracking_events, version, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, 1, $13, $14)
+		RETURNING `+shipmentColumns,
+		shipment.OrderID, shipment.CustomerID, shipment.Method, shipment.Carrier, shipment.TrackingNumber, destination, items,
+		shipment.BillableWeight, shipment.Price, shipment.RatesVersion, string(shipment.Status), events,
+		shipment.CreatedAt, shipment.UpdatedAt,
+	)
+
+	created, err := scanShipment(row)
+	if pg.IsUniqueViolation(err) {
+		return domain.Shipment{}, domain.ErrTrackingNumberTaken
+	}
+
+	return created, err
+}
+
+// Get fetches a single Shipment
+func (r *PgShipmentsRepository) Get(ctx context.Context, id int) (domain.Shipment, error) {
+	return r.get(ctx, "id", id)
+}
+
+// GetByTrackingNumber fetches the Shipment of a tracking number
+func (r *PgShipmentsRepository) GetByTrackingNumber(ctx context.Context, trackingNumber string) (domain.Shipment, error) {
+	return r.get(ctx, "tracking_number", trackingNumber)
+}
+
+// List lists the Shipments matching the filter, most recently created first
+func (r *PgShipmentsRepository) List(ctx context.Context, filter domain.ShipmentFilter) ([]domain.Shipment, error) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	if filter.OrderID != 0 {
+		args = append(args, filter.OrderID)
+		conditions = append(conditions, fmt.Sprintf("order_id = $%d", len(args)))
+	}
+
+	if filter.Status != "" {
+		args = append(args, string(filter.Status))
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	query := "SELECT " + shipmentColumns + " FROM shipments"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY created_at DESC, id DESC"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shipments := []domain.Shipment{}
+	for rows.Next() {
+		shipment, err := scanShipment(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		shipments = append(shipments, shipment)
+	}
+
+	return shipments, rows.Err()
+}
+
+// Update replaces a Shipment as long as the stored version matches, its tracking number never changes
+func (r *PgShipmentsRepository) Update(ctx context.Context, shipment domain.Shipment) (domain.Shipment, error) {
+	destination, items, events, err := marshalShipment(shipment)
+	if err != nil {
+		return domain.Shipment{}, err
+	}
+
+	row := r.db.QueryRowContext(
+		ctx,
+		`UPDATE shipments
+		SET destination = $3, items = $4, status = $5, tracking_events = $6, version = version + 1, updated_at = $7
+		WHERE id = $1 AND version = $2
+		RETURNING `+shipmentColumns,
+		shipment.ID, shipment.Version, destination, items, string(shipment.Status), events, shipment.UpdatedAt,
+	)
+
+	updated, err := scanShipment(row)
+	if !errors.Is(err, sql.ErrNoRows) {
+		return updated, err
+	}
+
+	// nothing was updated, either because the shipment doesn't exist or because its version changed
+	if _, err := r.Get(ctx, shipment.ID); err != nil {
+		return domain.Shipment{}, err
+	}
+
+	return domain.Shipment{}, domain.ErrShipmentVersionConflict
+}
+
+func (r *PgShipmentsRepository) get(ctx context.Context, column string, value interface{}) (domain.Shipment, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+shipmentColumns+" FROM shipments WHERE "+column+" = $1", value)
+
+	shipment, err := scanShipment(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Shipment{}, domain.ErrShipmentNotFound
+	}
+
+	return shipment, err
+}
+
+func marshalShipment(shipment domain.Shipment) ([]byte, []byte, []byte, error) {
+	address := shipment.Destination
+
+	destination, err := json.Marshal(addressRecord{
+		Recipient:  address.Recipient,
+		Line1:      address.Line1,
+		Line2:      address.Line2,
+		City:       address.City,
+		Region:     address.Region,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+		Phone:      address.Phone,
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	itemRecords := make([]itemRecord, 0, len(shipment.Items))
+	for _, item := range shipment.Items {
+		itemRecords = append(itemRecords, itemRecord{
+			ProductID: item.ProductID,
+			Quantity:  item.Quantity,
+			Weight:    item.Weight,
+			Length:    item.Dimensions.Length,
+			Width:     item.Dimensions.Width,
+			Height:    item.Dimensions.Height,
+		})
+	}
+
+	items, err := json.Marshal(itemRecords)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	eventRecords := make([]trackingEventRecord, 0, len(shipment.TrackingEvents))
+	for _, event := range shipment.TrackingEvents {
+		eventRecords = append(eventRecords, trackingEventRecord{
+			Status:      string(event.Status),
+			Location:    event.Location,
+			Description: event.Description,
+			At:          event.At,
+		})
+	}
+
+	events, err := json.Marshal(eventRecords)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return destination, items, events, nil
+}
+
+func scanShipment(row pg.Scanner) (domain.Shipment, error) {
+	var shipment domain.Shipment
+	var status string
+	var destination, items, events []byte
+
+	err := row.Scan(
+		&shipment.ID,
+		&shipment.OrderID,
+		&shipment.CustomerID,
+		&shipment.Method,
+		&shipment.Carrier,
+		&shipment.TrackingNumber,
+		&destination,
+		&items,
+		&shipment.BillableWeight,
+		&shipment.Price,
+		&shipment.RatesVersion,
+		&status,
+		&events,
+		&shipment.Version,
+		&shipment.CreatedAt,
+		&shipment.UpdatedAt,
+	)
+	if err != nil {
+		return domain.Shipment{}, err
+	}
+
+	var address addressRecord
+	if err := json.Unmarshal(destination, &address); err != nil {
+		return domain.Shipment{}, err
+	}
+
+	var itemRecords []itemRecord
+	if err := json.Unmarshal(items, &itemRecords); err != nil {
+		return domain.Shipment{}, err
+	}
+
+	var eventRecords []trackingEventRecord
+	if err := json.Unmarshal(events, &eventRecords); err != nil {
+		return domain.Shipment{}, err
+	}
+
+	shipment.Status = domain.ShipmentStatus(status)
+	shipment.Destination = domain.Address{
+		Recipient:  address.Recipient,
+		Line1:      address.Line1,
+		Line2:      address.Line2,
+		City:       address.City,
+		Region:     address.Region,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+		Phone:      address.Phone,
+	}
+
+	shipment.Items = make([]domain.Item, 0, len(itemRecords))
+	for _, record := range itemRecords {
+		shipment.Items = append(shipment.Items, domain.Item{
+			ProductID: record.ProductID,
+			Quantity:  record.Quantity,
+			Weight:    record.Weight,
+			Dimensions: domain.Dimensions{
+				Length: record.Length,
+				Width:  record.Width,
+				Height: record.Height,
+			},
+		})
+	}
+
+	shipment.TrackingEvents = make([]domain.TrackingEvent, 0, len(eventRecords))
+	for _, record := range eventRecords {
+		shipment.TrackingEvents = append(shipment.TrackingEvents, domain.TrackingEvent{
+			Status:      domain.ShipmentStatus(record.Status),
+			Location:    record.Location,
+			Description: record.Description,
+			At:          record.At,
+		})
+	}
+
+	return shipment, nil
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// application holds all dependencies the Application needs to work
+type application struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	RatesRepository     domain.RatesRepository
+	ShipmentsRepository domain.ShipmentsRepository
+	Catalog             domain.Catalog
+	EventPublisher      domain.EventPublisher
+
+	// Carriers are keyed by their Name, as the ShippingMethods refer to them
+	Carriers map[string]domain.Carrier
+}
+
+// NewApplication creates a new Application instance
+func NewApplication(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	ratesRepository domain.RatesRepository,
+	shipmentsRepository domain.ShipmentsRepository,
+	catalog domain.Catalog,
+	carriers []domain.Carrier,
+	eventPublisher domain.EventPublisher,
+) application {
+	carriersByName := make(map[string]domain.Carrier, len(carriers))
+	for _, carrier := range carriers {
+		carriersByName[carrier.Name()] = carrier
+	}
+
+	return application{
+		Logger:              logger,
+		Tracer:              tracer,
+		RatesRepository:     ratesRepository,
+		ShipmentsRepository: shipmentsRepository,
+		Catalog:             catalog,
+		EventPublisher:      eventPublisher,
+		Carriers:            carriersByName,
+	}
+}
+
+// MustNewApplication creates a new Application instance
+// It panics if any error is found
+func MustNewApplication(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	ratesRepository domain.RatesRepository,
+	shipmentsRepository domain.ShipmentsRepository,
+	catalog domain.Catalog,
+	carriers []domain.Carrier,
+	eventPublisher domain.EventPublisher,
+) application {
+	app := NewApplication(logger, tracer, ratesRepository, shipmentsRepository, catalog, carriers, eventPublisher)
+	return app
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) CancelShipment(ctx context.Context, id int) (domain.Shipment, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.CancelShipment")
+	defer span.End()
+
+	a.Logger.Info("cancelling a shipment", zap.Int("id", id))
+
+	if id <= 0 {
+		return domain.Shipment{}, domain.ErrInvalidShipment
+	}
+
+	shipment, err := a.ShipmentsRepository.Get(ctx, id)
+	if err != nil {
+		return domain.Shipment{}, err
+	}
+
+	now := time.Now()
+	cancelled := domain.TrackingEvent{Status: domain.ShipmentStatusCancelled, Description: "Shipment cancelled", At: now}
+
+	// checked before telling the carrier, which can't cancel a parcel it already picked up
+	if err := shipment.Track(cancelled, now); err != nil {
+		return domain.Shipment{}, err
+	}
+
+	carrier, ok := a.Carriers[shipment.Carrier]
+	if !ok {
+		a.Logger.Error("the carrier of a shipment isn't set up", zap.Int("id", id), zap.String("carrier", shipment.Carrier))
+		return domain.Shipment{}, domain.ErrCarrierUnavailable
+	}
+
+	if err := carrier.CancelShipment(ctx, shipment.TrackingNumber); err != nil {
+		return domain.Shipment{}, err
+	}
+
+	// a concurrent change fails the cancellation rather than being retried, since it means the carrier
+	// reported the parcel as picked up meanwhile
+	shipment, err = a.ShipmentsRepository.Update(ctx, shipment)
+	if err != nil {
+		return domain.Shipment{}, err
+	}
+
+	a.publish(ctx, shipment, cancelled)
+
+	return shipment, nil
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"github.com/lucasmls/ecommerce/services/shipping/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type CancelShipmentSuite struct {
+	suite.Suite
+
+	shipmentsRepo *mocks.ShipmentsRepository
+	carrier       *mocks.Carrier
+	publisher     *mocks.EventPublisher
+	app           domain.Application
+}
+
+func (s *CancelShipmentSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.shipmentsRepo = &mocks.ShipmentsRepository{}
+	s.carrier = &mocks.Carrier{}
+	s.publisher = &mocks.EventPublisher{}
+
+	s.carrier.On("Name").Return("local")
+
+	s.app = MustNewApplication(
+		loggerM, tracerM, &mocks.RatesRepository{}, s.shipmentsRepo, &mocks.Catalog{}, []domain.Carrier{s.carrier}, s.publisher,
+	)
+}
+
+func (s *CancelShipmentSuite) Test_CancelShipment() {
+	s.Run("Should fail in case the ID is invalid", func() {
+		s.SetupTest()
+
+		_, err := s.app.CancelShipment(context.Background(), -1)
+
+		s.Equal(domain.ErrInvalidShipment, err)
+	})
+
+	s.Run("Should fail without telling the carrier in case the shipment was picked up", func() {
+		s.SetupTest()
+
+		s.shipmentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), 3).Return(shipment(domain.ShipmentStatusInTransit), nil).Once()
+
+		_, err := s.app.CancelShipment(context.Background(), 3)
+
+		s.Equal(domain.ErrInvalidTransition, err)
+		s.carrier.AssertNotCalled(s.T(), "CancelShipment", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the carrier of the shipment isn't set up anymore", func() {
+		s.SetupTest()
+
+		booked := shipment(domain.ShipmentStatusLabelCreated)
+		booked.Carrier = "courier"
+
+		s.shipmentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), 3).Return(booked, nil).Once()
+
+		_, err := s.app.CancelShipment(context.Background(), 3)
+
+		s.Equal(domain.ErrCarrierUnavailable, err)
+	})
+
+	s.Run("Should keep the shipment in case the carrier can't cancel it", func() {
+		s.SetupTest()
+
+		s.shipmentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), 3).Return(shipment(domain.ShipmentStatusLabelCreated), nil).Once()
+		s.carrier.On("CancelShipment", mock.AnythingOfType("*context.valueCtx"), "FK473124829ZZ").Return(errors.New("connection refused")).Once()
+
+		_, err := s.app.CancelShipment(context.Background(), 3)
+
+		s.EqualError(err, "connection refused")
+		s.shipmentsRepo.AssertNotCalled(s.T(), "Update", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should cancel the shipment with its carrier and publish its cancellation", func() {
+		s.SetupTest()
+
+		s.shipmentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), 3).Return(shipment(domain.ShipmentStatusLabelCreated), nil).Once()
+		s.carrier.On("CancelShipment", mock.AnythingOfType("*context.valueCtx"), "FK473124829ZZ").Return(nil).Once()
+
+		s.shipmentsRepo.
+			On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(shipment domain.Shipment) bool {
+				return shipment.Status == domain.ShipmentStatusCancelled &&
+					len(shipment.TrackingEvents) == 2 &&
+					shipment.TrackingEvents[1].Status == domain.ShipmentStatusCancelled
+			})).
+			Return(shipment(domain.ShipmentStatusCancelled), nil).
+			Once()
+
+		s.publisher.
+			On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(event domain.ShipmentEvent) bool {
+				return event.TrackingEvent.Status == domain.ShipmentStatusCancelled
+			})).
+			Return(nil).
+			Once()
+
+		cancelled, err := s.app.CancelShipment(context.Background(), 3)
+
+		s.NoError(err)
+		s.Equal(domain.ShipmentStatusCancelled, cancelled.Status)
+		s.carrier.AssertExpectations(s.T())
+		s.publisher.AssertExpectations(s.T())
+	})
+}
+
+func TestCancelShipmentSuite(t *testing.T) {
+	suite.Run(t, new(CancelShipmentSuite))
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) CreateShipment(ctx context.Context, request domain.ShipmentRequest) (domain.Shipment, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.CreateShipment")
+	defer span.End()
+
+	a.Logger.Info("creating a shipment", zap.Int("orderId", request.OrderID), zap.String("method", request.Method))
+
+	request.Destination = request.Destination.Normalize()
+	if err := request.Validate(); err != nil {
+		return domain.Shipment{}, err
+	}
+
+	items, err := a.items(ctx, request.Items)
+	if err != nil {
+		return domain.Shipment{}, err
+	}
+
+	table, err := a.RatesRepository.Get(ctx)
+	if err != nil {
+		return domain.Shipment{}, err
+	}
+
+	zone, err := table.Zone(request.Destination.Country)
+	if err != nil {
+		return domain.Shipment{}, err
+	}
+
+	method, err := table.Method(request.Method)
+	if err != nil {
+		return domain.Shipment{}, err
+	}
+
+	carrier, ok := a.Carriers[method.Carrier]
+	if !ok {
+		a.Logger.Error("the carrier of a shipping method isn't set up", zap.String("method", method.Code), zap.String("carrier", method.Carrier))
+		return domain.Shipment{}, domain.ErrCarrierUnavailable
+	}
+
+	billableWeight := domain.BillableWeight(items, table.VolumetricDivisor)
+
+	price, err := method.Price(zone.Name, billableWeight)
+	if err != nil {
+		return domain.Shipment{}, err
+	}
+
+	now := time.Now()
+	created := domain.TrackingEvent{Status: domain.ShipmentStatusLabelCreated, Description: "Shipping label created", At: now}
+
+	shipment := domain.Shipment{
+		OrderID:        request.OrderID,
+		CustomerID:     request.CustomerID,
+		Method:         method.Code,
+		Carrier:        method.Carrier,
+		Destination:    request.Destination,
+		Items:          items,
+		BillableWeight: billableWeight,
+		Price:          price,
+		RatesVersion:   table.Version,
+		Status:         created.Status,
+		TrackingEvents: []domain.TrackingEvent{created},
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	shipment.TrackingNumber, err = carrier.CreateShipment(ctx, shipment)
+	if err != nil {
+		return domain.Shipment{}, err
+	}
+
+	stored, err := a.ShipmentsRepository.Create(ctx, shipment)
+	if err != nil {
+		// the booking isn't kept anywhere, so it's cancelled rather than left for the carrier to pick up
+		if cancelErr := carrier.CancelShipment(ctx, shipment.TrackingNumber); cancelErr != nil {
+			a.Logger.Error("failed to cancel a shipment that couldn't be stored", zap.Error(cancelErr))
+		}
+
+		return domain.Shipment{}, err
+	}
+
+	a.publish(ctx, stored, created)
+
+	return stored, nil
+}
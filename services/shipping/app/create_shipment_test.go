@@ -0,0 +1,213 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"github.com/lucasmls/ecommerce/services/shipping/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type CreateShipmentSuite struct {
+	suite.Suite
+
+	ratesRepo     *mocks.RatesRepository
+	shipmentsRepo *mocks.ShipmentsRepository
+	catalog       *mocks.Catalog
+	carrier       *mocks.Carrier
+	publisher     *mocks.EventPublisher
+	app           domain.Application
+}
+
+func (s *CreateShipmentSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.ratesRepo = &mocks.RatesRepository{}
+	s.shipmentsRepo = &mocks.ShipmentsRepository{}
+	s.catalog = &mocks.Catalog{}
+	s.carrier = &mocks.Carrier{}
+	s.publisher = &mocks.EventPublisher{}
+
+	s.carrier.On("Name").Return("local")
+
+	s.app = MustNewApplication(
+		loggerM, tracerM, s.ratesRepo, s.shipmentsRepo, s.catalog, []domain.Carrier{s.carrier}, s.publisher,
+	)
+}
+
+// priced makes the catalog and the repository return the products and the rateTable
+func (s *CreateShipmentSuite) priced() {
+	s.catalog.On("Products", mock.AnythingOfType("*context.valueCtx"), []int{1}).Return(products(), nil).Once()
+	s.ratesRepo.On("Get", mock.AnythingOfType("*context.valueCtx")).Return(rateTable(), nil).Once()
+}
+
+// shipmentRequest ships two laptops to the address with a method
+func shipmentRequest(method string) domain.ShipmentRequest {
+	return domain.ShipmentRequest{
+		OrderID:     7,
+		CustomerID:  "42",
+		Method:      method,
+		Destination: address(),
+		Items:       []domain.ItemRequest{{ProductID: 1, Quantity: 2}},
+	}
+}
+
+func (s *CreateShipmentSuite) Test_CreateShipment() {
+	s.Run("Should fail in case the request is invalid", func() {
+		s.SetupTest()
+
+		noCustomer := shipmentRequest("standard")
+		noCustomer.CustomerID = ""
+
+		noRecipient := shipmentRequest("standard")
+		noRecipient.Destination.Recipient = "  "
+
+		noItems := shipmentRequest("standard")
+		noItems.Items = nil
+
+		testCases := []struct {
+			request domain.ShipmentRequest
+			err     error
+		}{
+			{request: noCustomer, err: domain.ErrInvalidShipment},
+			{request: shipmentRequest(""), err: domain.ErrInvalidShipment},
+			{request: noRecipient, err: domain.ErrInvalidAddress},
+			{request: noItems, err: domain.ErrInvalidParcel},
+		}
+
+		for _, testCase := range testCases {
+			_, err := s.app.CreateShipment(context.Background(), testCase.request)
+
+			s.Equal(testCase.err, err)
+		}
+
+		s.carrier.AssertNotCalled(s.T(), "CreateShipment", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the method is unknown", func() {
+		s.SetupTest()
+		s.priced()
+
+		_, err := s.app.CreateShipment(context.Background(), shipmentRequest("drone"))
+
+		s.Equal(domain.ErrShippingMethodUnknown, err)
+	})
+
+	s.Run("Should fail in case the method doesn't ship to the destination", func() {
+		s.SetupTest()
+		s.priced()
+
+		request := shipmentRequest("express")
+		request.Destination.Country = "DE"
+
+		_, err := s.app.CreateShipment(context.Background(), request)
+
+		s.Equal(domain.ErrShippingMethodUnavailable, err)
+		s.carrier.AssertNotCalled(s.T(), "CreateShipment", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the carrier of the method isn't set up", func() {
+		s.SetupTest()
+		s.priced()
+
+		_, err := s.app.CreateShipment(context.Background(), shipmentRequest("overnight"))
+
+		s.Equal(domain.ErrCarrierUnavailable, err)
+	})
+
+	s.Run("Should fail in case the carrier can't book the shipment", func() {
+		s.SetupTest()
+		s.priced()
+
+		s.carrier.On("CreateShipment", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Shipment")).Return("", errors.New("connection refused")).Once()
+
+		_, err := s.app.CreateShipment(context.Background(), shipmentRequest("standard"))
+
+		s.EqualError(err, "connection refused")
+		s.shipmentsRepo.AssertNotCalled(s.T(), "Create", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should cancel the booking in case the shipment can't be stored", func() {
+		s.SetupTest()
+		s.priced()
+
+		s.carrier.On("CreateShipment", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Shipment")).Return("FK473124829ZZ", nil).Once()
+		s.shipmentsRepo.On("Create", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Shipment")).Return(domain.Shipment{}, domain.ErrTrackingNumberTaken).Once()
+		s.carrier.On("CancelShipment", mock.AnythingOfType("*context.valueCtx"), "FK473124829ZZ").Return(nil).Once()
+
+		_, err := s.app.CreateShipment(context.Background(), shipmentRequest("standard"))
+
+		s.Equal(domain.ErrTrackingNumberTaken, err)
+		s.carrier.AssertExpectations(s.T())
+		s.publisher.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should book the shipment, priced by its billable weight, and publish its creation", func() {
+		s.SetupTest()
+		s.priced()
+
+		request := shipmentRequest("standard")
+		request.Destination.Country = " us "
+
+		s.carrier.
+			On("CreateShipment", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(shipment domain.Shipment) bool {
+				return shipment.TrackingNumber == "" && shipment.Destination.Country == "US"
+			})).
+			Return("FK473124829ZZ", nil).
+			Once()
+
+		s.shipmentsRepo.
+			On("Create", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(shipment domain.Shipment) bool {
+				return shipment.OrderID == 7 &&
+					shipment.CustomerID == "42" &&
+					shipment.Method == "standard" &&
+					shipment.Carrier == "local" &&
+					shipment.TrackingNumber == "FK473124829ZZ" &&
+					shipment.BillableWeight == 2580 &&
+					shipment.Price == 899 &&
+					shipment.RatesVersion == "2022-10-01" &&
+					shipment.Status == domain.ShipmentStatusLabelCreated &&
+					len(shipment.TrackingEvents) == 1 &&
+					shipment.TrackingEvents[0].Status == domain.ShipmentStatusLabelCreated
+			})).
+			Return(shipment(domain.ShipmentStatusLabelCreated), nil).
+			Once()
+
+		s.publisher.
+			On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(event domain.ShipmentEvent) bool {
+				return event.Shipment.ID == 3 && event.TrackingEvent.Status == domain.ShipmentStatusLabelCreated
+			})).
+			Return(nil).
+			Once()
+
+		created, err := s.app.CreateShipment(context.Background(), request)
+
+		s.NoError(err)
+		s.Equal(shipment(domain.ShipmentStatusLabelCreated), created)
+		s.publisher.AssertExpectations(s.T())
+	})
+
+	s.Run("Should create the shipment even in case its creation can't be published", func() {
+		s.SetupTest()
+		s.priced()
+
+		s.carrier.On("CreateShipment", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Shipment")).Return("FK473124829ZZ", nil).Once()
+		s.shipmentsRepo.On("Create", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Shipment")).Return(shipment(domain.ShipmentStatusLabelCreated), nil).Once()
+		s.publisher.On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.ShipmentEvent")).Return(errors.New("channel closed")).Once()
+
+		created, err := s.app.CreateShipment(context.Background(), shipmentRequest("standard"))
+
+		s.NoError(err)
+		s.Equal(3, created.ID)
+	})
+}
+
+func TestCreateShipmentSuite(t *testing.T) {
+	suite.Run(t, new(CreateShipmentSuite))
+}
@@ -0,0 +1,21 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) GetShipment(ctx context.Context, id int) (domain.Shipment, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.GetShipment")
+	defer span.End()
+
+	a.Logger.Info("getting a shipment", zap.Int("id", id))
+
+	if id <= 0 {
+		return domain.Shipment{}, domain.ErrInvalidShipment
+	}
+
+	return a.ShipmentsRepository.Get(ctx, id)
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"github.com/lucasmls/ecommerce/services/shipping/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type GetShipmentSuite struct {
+	suite.Suite
+
+	shipmentsRepo *mocks.ShipmentsRepository
+	app           domain.Application
+}
+
+func (s *GetShipmentSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.shipmentsRepo = &mocks.ShipmentsRepository{}
+
+	s.app = MustNewApplication(
+		loggerM, tracerM, &mocks.RatesRepository{}, s.shipmentsRepo, &mocks.Catalog{}, nil, &mocks.EventPublisher{},
+	)
+}
+
+func (s *GetShipmentSuite) Test_GetShipment() {
+	s.Run("Should fail in case the ID is invalid", func() {
+		s.SetupTest()
+
+		_, err := s.app.GetShipment(context.Background(), 0)
+
+		s.Equal(domain.ErrInvalidShipment, err)
+		s.shipmentsRepo.AssertNotCalled(s.T(), "Get", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the shipment doesn't exist", func() {
+		s.SetupTest()
+
+		s.shipmentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), 3).Return(domain.Shipment{}, domain.ErrShipmentNotFound).Once()
+
+		_, err := s.app.GetShipment(context.Background(), 3)
+
+		s.Equal(domain.ErrShipmentNotFound, err)
+	})
+
+	s.Run("Should get the shipment", func() {
+		s.SetupTest()
+
+		s.shipmentsRepo.On("Get", mock.AnythingOfType("*context.valueCtx"), 3).Return(shipment(domain.ShipmentStatusInTransit), nil).Once()
+
+		found, err := s.app.GetShipment(context.Background(), 3)
+
+		s.NoError(err)
+		s.Equal(shipment(domain.ShipmentStatusInTransit), found)
+	})
+}
+
+func TestGetShipmentSuite(t *testing.T) {
+	suite.Run(t, new(GetShipmentSuite))
+}
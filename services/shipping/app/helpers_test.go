@@ -0,0 +1,98 @@
+package app
+
+import (
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+)
+
+// rateTable ships domestically within the US, to the rest of North America and everywhere else,
+// its overnight method being delivered by a courier no test sets up
+func rateTable() domain.RateTable {
+	return domain.RateTable{
+		Version:           "2022-10-01",
+		VolumetricDivisor: domain.DefaultVolumetricDivisor,
+		Zones: []domain.Zone{
+			{Name: "domestic", Countries: []string{"US"}},
+			{Name: "north_america", Countries: []string{"CA", "MX"}},
+			{Name: "international", Countries: []string{domain.AnyCountry}},
+		},
+		Methods: []domain.ShippingMethod{
+			{
+				Code:    "standard",
+				Name:    "Standard",
+				Carrier: "local",
+				Rates: map[string][]domain.WeightTier{
+					"domestic":      {{MaxWeight: 1000, Price: 599}, {MaxWeight: 5000, Price: 899}, {MaxWeight: 20000, Price: 1999}},
+					"north_america": {{MaxWeight: 1000, Price: 1299}, {MaxWeight: 5000, Price: 1999}},
+					"international": {{MaxWeight: 5000, Price: 2999}},
+				},
+			},
+			{
+				Code:    "express",
+				Name:    "Express",
+				Carrier: "local",
+				Rates: map[string][]domain.WeightTier{
+					"domestic":      {{MaxWeight: 1000, Price: 1499}, {MaxWeight: 5000, Price: 1999}},
+					"north_america": {{MaxWeight: 5000, Price: 3999}},
+				},
+			},
+			{
+				Code:    "overnight",
+				Name:    "Overnight",
+				Carrier: "courier",
+				Rates: map[string][]domain.WeightTier{
+					"domestic": {{MaxWeight: 5000, Price: 2999}},
+				},
+			},
+		},
+	}
+}
+
+// products has a laptop, heavier than it's bulky, and a pillow, bulkier than it's heavy
+func products() map[int]domain.ProductSnapshot {
+	return map[int]domain.ProductSnapshot{
+		1: {ID: 1, Weight: 1290, Dimensions: domain.Dimensions{Length: 304, Width: 212, Height: 16}},
+		2: {ID: 2, Weight: 300, Dimensions: domain.Dimensions{Length: 500, Width: 400, Height: 200}},
+	}
+}
+
+// address is somewhere in New York City
+func address() domain.Address {
+	return domain.Address{
+		Recipient:  "Jane Doe",
+		Line1:      "350 5th Ave",
+		City:       "New York",
+		Region:     "NY",
+		PostalCode: "10118",
+		Country:    "US",
+	}
+}
+
+// shipment is a stored Shipment of two laptops, in the given status
+func shipment(status domain.ShipmentStatus) domain.Shipment {
+	createdAt := time.Date(2022, 10, 3, 9, 30, 0, 0, time.UTC)
+
+	return domain.Shipment{
+		ID:             3,
+		OrderID:        7,
+		CustomerID:     "42",
+		Method:         "standard",
+		Carrier:        "local",
+		TrackingNumber: "FK473124829ZZ",
+		Destination:    address(),
+		Items: []domain.Item{
+			{ProductID: 1, Quantity: 2, Weight: 1290, Dimensions: domain.Dimensions{Length: 304, Width: 212, Height: 16}},
+		},
+		BillableWeight: 2580,
+		Price:          899,
+		RatesVersion:   "2022-10-01",
+		Status:         status,
+		TrackingEvents: []domain.TrackingEvent{
+			{Status: domain.ShipmentStatusLabelCreated, Description: "Shipping label created", At: createdAt},
+		},
+		Version:   1,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+}
@@ -0,0 +1,31 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) ListShipments(ctx context.Context, filter domain.ShipmentFilter) ([]domain.Shipment, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.ListShipments")
+	defer span.End()
+
+	a.Logger.Info("listing shipments", zap.Any("filter", filter))
+
+	if filter.OrderID < 0 || filter.Limit < 0 || filter.Limit > domain.MaxShipmentsLimit {
+		return nil, domain.ErrInvalidShipment
+	}
+
+	if filter.Status != "" {
+		if err := filter.Status.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = domain.DefaultShipmentsLimit
+	}
+
+	return a.ShipmentsRepository.List(ctx, filter)
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"github.com/lucasmls/ecommerce/services/shipping/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type ListShipmentsSuite struct {
+	suite.Suite
+
+	shipmentsRepo *mocks.ShipmentsRepository
+	app           domain.Application
+}
+
+func (s *ListShipmentsSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.shipmentsRepo = &mocks.ShipmentsRepository{}
+
+	s.app = MustNewApplication(
+		loggerM, tracerM, &mocks.RatesRepository{}, s.shipmentsRepo, &mocks.Catalog{}, nil, &mocks.EventPublisher{},
+	)
+}
+
+func (s *ListShipmentsSuite) Test_ListShipments() {
+	s.Run("Should fail in case the filter is invalid", func() {
+		s.SetupTest()
+
+		testCases := []struct {
+			filter domain.ShipmentFilter
+			err    error
+		}{
+			{filter: domain.ShipmentFilter{OrderID: -1}, err: domain.ErrInvalidShipment},
+			{filter: domain.ShipmentFilter{Limit: domain.MaxShipmentsLimit + 1}, err: domain.ErrInvalidShipment},
+			{filter: domain.ShipmentFilter{Status: "lost"}, err: domain.ErrInvalidShipmentStatus},
+		}
+
+		for _, testCase := range testCases {
+			_, err := s.app.ListShipments(context.Background(), testCase.filter)
+
+			s.Equal(testCase.err, err)
+		}
+
+		s.shipmentsRepo.AssertNotCalled(s.T(), "List", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should list the shipments of an order, limited by default", func() {
+		s.SetupTest()
+
+		filter := domain.ShipmentFilter{OrderID: 7, Status: domain.ShipmentStatusInTransit, Limit: domain.DefaultShipmentsLimit}
+		shipments := []domain.Shipment{shipment(domain.ShipmentStatusInTransit)}
+
+		s.shipmentsRepo.On("List", mock.AnythingOfType("*context.valueCtx"), filter).Return(shipments, nil).Once()
+
+		listed, err := s.app.ListShipments(context.Background(), domain.ShipmentFilter{OrderID: 7, Status: domain.ShipmentStatusInTransit})
+
+		s.NoError(err)
+		s.Equal(shipments, listed)
+	})
+}
+
+func TestListShipmentsSuite(t *testing.T) {
+	suite.Run(t, new(ListShipmentsSuite))
+}
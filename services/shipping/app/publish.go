@@ -0,0 +1,21 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"go.uber.org/zap"
+)
+
+// publish tells the other services a Shipment moved to another status once it's stored.
+// The event is only logged when it can't be published, the Shipment already changed.
+func (a application) publish(ctx context.Context, shipment domain.Shipment, event domain.TrackingEvent) {
+	if err := a.EventPublisher.Publish(ctx, domain.ShipmentEvent{Shipment: shipment, TrackingEvent: event}); err != nil {
+		a.Logger.Error(
+			"failed to publish a shipment event",
+			zap.Int("shipmentId", shipment.ID),
+			zap.String("status", string(event.Status)),
+			zap.Error(err),
+		)
+	}
+}
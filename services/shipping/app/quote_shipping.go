@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"strings"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) QuoteShipping(ctx context.Context, request domain.QuoteRequest) (domain.Quote, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.QuoteShipping")
+	defer span.End()
+
+	a.Logger.Info("quoting a shipping", zap.Any("request", request))
+
+	request.Country = strings.ToUpper(strings.TrimSpace(request.Country))
+	if err := request.Validate(); err != nil {
+		return domain.Quote{}, err
+	}
+
+	items, err := a.items(ctx, request.Items)
+	if err != nil {
+		return domain.Quote{}, err
+	}
+
+	table, err := a.RatesRepository.Get(ctx)
+	if err != nil {
+		return domain.Quote{}, err
+	}
+
+	quote, err := domain.NewQuote(request.Country, items, table)
+	if err != nil {
+		return domain.Quote{}, err
+	}
+
+	// the methods of the carriers that aren't set up can't be booked, so they aren't offered
+	options := make([]domain.ShippingOption, 0, len(quote.Options))
+	for _, option := range quote.Options {
+		if _, ok := a.Carriers[option.Carrier]; ok {
+			options = append(options, option)
+		}
+	}
+
+	quote.Options = options
+
+	return quote, nil
+}
+
+// items snapshots the weight and dimensions of the requested Products from the catalog
+func (a application) items(ctx context.Context, requests []domain.ItemRequest) ([]domain.Item, error) {
+	productIDs := make([]int, 0, len(requests))
+	for _, request := range requests {
+		productIDs = append(productIDs, request.ProductID)
+	}
+
+	products, err := a.Catalog.Products(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewItems(requests, products)
+}
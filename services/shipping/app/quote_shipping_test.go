@@ -0,0 +1,147 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"github.com/lucasmls/ecommerce/services/shipping/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type QuoteShippingSuite struct {
+	suite.Suite
+
+	ratesRepo *mocks.RatesRepository
+	catalog   *mocks.Catalog
+	app       domain.Application
+}
+
+func (s *QuoteShippingSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.ratesRepo = &mocks.RatesRepository{}
+	s.catalog = &mocks.Catalog{}
+
+	carrier := &mocks.Carrier{}
+	carrier.On("Name").Return("local")
+
+	s.app = MustNewApplication(
+		loggerM, tracerM, s.ratesRepo, &mocks.ShipmentsRepository{}, s.catalog, []domain.Carrier{carrier}, &mocks.EventPublisher{},
+	)
+}
+
+func (s *QuoteShippingSuite) Test_QuoteShipping() {
+	s.Run("Should fail in case the request is invalid", func() {
+		s.SetupTest()
+
+		testCases := []struct {
+			request domain.QuoteRequest
+			err     error
+		}{
+			{request: domain.QuoteRequest{Country: "USA", Items: []domain.ItemRequest{{ProductID: 1, Quantity: 1}}}, err: domain.ErrInvalidAddress},
+			{request: domain.QuoteRequest{Country: "US"}, err: domain.ErrInvalidParcel},
+			{request: domain.QuoteRequest{Country: "US", Items: []domain.ItemRequest{{ProductID: 1, Quantity: 0}}}, err: domain.ErrInvalidParcel},
+			{request: domain.QuoteRequest{Country: "US", Items: []domain.ItemRequest{{ProductID: 1, Quantity: 1000}}}, err: domain.ErrInvalidParcel},
+		}
+
+		for _, testCase := range testCases {
+			_, err := s.app.QuoteShipping(context.Background(), testCase.request)
+
+			s.Equal(testCase.err, err)
+		}
+
+		s.catalog.AssertNotCalled(s.T(), "Products", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case a product isn't on the catalog", func() {
+		s.SetupTest()
+
+		s.catalog.On("Products", mock.AnythingOfType("*context.valueCtx"), []int{1, 3}).Return(products(), nil).Once()
+
+		_, err := s.app.QuoteShipping(context.Background(), domain.QuoteRequest{
+			Country: "US",
+			Items:   []domain.ItemRequest{{ProductID: 1, Quantity: 1}, {ProductID: 3, Quantity: 1}},
+		})
+
+		s.Equal(domain.ErrProductNotShippable, err)
+		s.ratesRepo.AssertNotCalled(s.T(), "Get", mock.Anything)
+	})
+
+	s.Run("Should fail in case the catalog can't be reached", func() {
+		s.SetupTest()
+
+		s.catalog.On("Products", mock.AnythingOfType("*context.valueCtx"), []int{1}).Return(nil, errors.New("connection refused")).Once()
+
+		_, err := s.app.QuoteShipping(context.Background(), domain.QuoteRequest{
+			Country: "US",
+			Items:   []domain.ItemRequest{{ProductID: 1, Quantity: 1}},
+		})
+
+		s.EqualError(err, "connection refused")
+	})
+
+	s.Run("Should offer every method of a configured carrier, cheapest first", func() {
+		s.SetupTest()
+
+		s.catalog.On("Products", mock.AnythingOfType("*context.valueCtx"), []int{1}).Return(products(), nil).Once()
+		s.ratesRepo.On("Get", mock.AnythingOfType("*context.valueCtx")).Return(rateTable(), nil).Once()
+
+		quote, err := s.app.QuoteShipping(context.Background(), domain.QuoteRequest{
+			Country: " us ",
+			Items:   []domain.ItemRequest{{ProductID: 1, Quantity: 2}},
+		})
+
+		s.NoError(err)
+		s.Equal(domain.Quote{
+			RatesVersion:   "2022-10-01",
+			Zone:           "domestic",
+			BillableWeight: 2580,
+			Options: []domain.ShippingOption{
+				{Method: "standard", Name: "Standard", Carrier: "local", Price: 899},
+				{Method: "express", Name: "Express", Carrier: "local", Price: 1999},
+			},
+		}, quote)
+	})
+
+	s.Run("Should bill the bulky products by their volume, leaving out the methods that can't carry them", func() {
+		s.SetupTest()
+
+		s.catalog.On("Products", mock.AnythingOfType("*context.valueCtx"), []int{2}).Return(products(), nil).Once()
+		s.ratesRepo.On("Get", mock.AnythingOfType("*context.valueCtx")).Return(rateTable(), nil).Once()
+
+		quote, err := s.app.QuoteShipping(context.Background(), domain.QuoteRequest{
+			Country: "US",
+			Items:   []domain.ItemRequest{{ProductID: 2, Quantity: 1}},
+		})
+
+		s.NoError(err)
+		s.Equal(8000, quote.BillableWeight)
+		s.Equal([]domain.ShippingOption{{Method: "standard", Name: "Standard", Carrier: "local", Price: 1999}}, quote.Options)
+	})
+
+	s.Run("Should ship to the countries no zone lists through the catch-all zone", func() {
+		s.SetupTest()
+
+		s.catalog.On("Products", mock.AnythingOfType("*context.valueCtx"), []int{1}).Return(products(), nil).Once()
+		s.ratesRepo.On("Get", mock.AnythingOfType("*context.valueCtx")).Return(rateTable(), nil).Once()
+
+		quote, err := s.app.QuoteShipping(context.Background(), domain.QuoteRequest{
+			Country: "DE",
+			Items:   []domain.ItemRequest{{ProductID: 1, Quantity: 1}},
+		})
+
+		s.NoError(err)
+		s.Equal("international", quote.Zone)
+		s.Equal([]domain.ShippingOption{{Method: "standard", Name: "Standard", Carrier: "local", Price: 2999}}, quote.Options)
+	})
+}
+
+func TestQuoteShippingSuite(t *testing.T) {
+	suite.Run(t, new(QuoteShippingSuite))
+}
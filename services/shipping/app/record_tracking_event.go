@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"go.uber.org/zap"
+)
+
+// maxTrackingAttempts bounds how many times a TrackingEvent is applied again when the Shipment changes concurrently
+const maxTrackingAttempts = 3
+
+func (a application) RecordTrackingEvent(ctx context.Context, update domain.TrackingUpdate) (domain.Shipment, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.RecordTrackingEvent")
+	defer span.End()
+
+	a.Logger.Info("recording a tracking event", zap.Any("update", update))
+
+	update.TrackingNumber = strings.ToUpper(strings.TrimSpace(update.TrackingNumber))
+	update.Event.Location = strings.TrimSpace(update.Event.Location)
+	update.Event.Description = strings.TrimSpace(update.Event.Description)
+
+	if err := update.Validate(); err != nil {
+		return domain.Shipment{}, err
+	}
+
+	// the carriers may report an event without telling when it happened, it's then taken as happening now
+	if update.Event.At.IsZero() {
+		update.Event.At = time.Now()
+	}
+
+	// cancelling goes through CancelShipment, so the carrier is told about it
+	if update.Event.Status == domain.ShipmentStatusCancelled {
+		return domain.Shipment{}, domain.ErrInvalidTransition
+	}
+
+	var err error
+	for attempt := 1; attempt <= maxTrackingAttempts; attempt++ {
+		var shipment domain.Shipment
+		shipment, err = a.ShipmentsRepository.GetByTrackingNumber(ctx, update.TrackingNumber)
+		if err != nil {
+			return domain.Shipment{}, err
+		}
+
+		// the state machine is checked against the current status on every attempt, since a concurrent
+		// event may have made this one invalid
+		if err := shipment.Track(update.Event, time.Now()); err != nil {
+			return domain.Shipment{}, err
+		}
+
+		shipment, err = a.ShipmentsRepository.Update(ctx, shipment)
+		if err == nil {
+			a.publish(ctx, shipment, update.Event)
+			return shipment, nil
+		}
+
+		if !errors.Is(err, domain.ErrShipmentVersionConflict) {
+			return domain.Shipment{}, err
+		}
+
+		a.Logger.Info("the shipment changed while recording a tracking event, trying again", zap.Int("attempt", attempt))
+	}
+
+	return domain.Shipment{}, err
+}
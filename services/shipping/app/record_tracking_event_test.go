@@ -0,0 +1,173 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"github.com/lucasmls/ecommerce/services/shipping/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type RecordTrackingEventSuite struct {
+	suite.Suite
+
+	shipmentsRepo *mocks.ShipmentsRepository
+	publisher     *mocks.EventPublisher
+	app           domain.Application
+}
+
+func (s *RecordTrackingEventSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.shipmentsRepo = &mocks.ShipmentsRepository{}
+	s.publisher = &mocks.EventPublisher{}
+
+	s.app = MustNewApplication(
+		loggerM, tracerM, &mocks.RatesRepository{}, s.shipmentsRepo, &mocks.Catalog{}, nil, s.publisher,
+	)
+}
+
+// update reports a status for the Shipment of the helpers
+func update(status domain.ShipmentStatus) domain.TrackingUpdate {
+	return domain.TrackingUpdate{
+		TrackingNumber: "FK473124829ZZ",
+		Event:          domain.TrackingEvent{Status: status, Location: "Newark, NJ"},
+	}
+}
+
+func (s *RecordTrackingEventSuite) Test_RecordTrackingEvent() {
+	s.Run("Should fail in case the update is invalid", func() {
+		s.SetupTest()
+
+		noTrackingNumber := update(domain.ShipmentStatusInTransit)
+		noTrackingNumber.TrackingNumber = " "
+
+		tooLongLocation := update(domain.ShipmentStatusInTransit)
+		tooLongLocation.Event.Location = string(make([]rune, domain.MaxLocationLength+1))
+
+		testCases := []struct {
+			update domain.TrackingUpdate
+			err    error
+		}{
+			{update: noTrackingNumber, err: domain.ErrInvalidShipment},
+			{update: tooLongLocation, err: domain.ErrInvalidShipment},
+			{update: update("lost"), err: domain.ErrInvalidShipmentStatus},
+			{update: update(domain.ShipmentStatusCancelled), err: domain.ErrInvalidTransition},
+		}
+
+		for _, testCase := range testCases {
+			_, err := s.app.RecordTrackingEvent(context.Background(), testCase.update)
+
+			s.Equal(testCase.err, err)
+		}
+
+		s.shipmentsRepo.AssertNotCalled(s.T(), "GetByTrackingNumber", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should follow the state machine", func() {
+		testCases := []struct {
+			from domain.ShipmentStatus
+			to   domain.ShipmentStatus
+			err  error
+		}{
+			{from: domain.ShipmentStatusLabelCreated, to: domain.ShipmentStatusInTransit},
+			{from: domain.ShipmentStatusInTransit, to: domain.ShipmentStatusInTransit},
+			{from: domain.ShipmentStatusInTransit, to: domain.ShipmentStatusOutForDelivery},
+			{from: domain.ShipmentStatusOutForDelivery, to: domain.ShipmentStatusDelivered},
+			{from: domain.ShipmentStatusOutForDelivery, to: domain.ShipmentStatusException},
+			{from: domain.ShipmentStatusException, to: domain.ShipmentStatusOutForDelivery},
+			{from: domain.ShipmentStatusException, to: domain.ShipmentStatusReturned},
+			{from: domain.ShipmentStatusLabelCreated, to: domain.ShipmentStatusDelivered, err: domain.ErrInvalidTransition},
+			{from: domain.ShipmentStatusInTransit, to: domain.ShipmentStatusDelivered, err: domain.ErrInvalidTransition},
+			{from: domain.ShipmentStatusInTransit, to: domain.ShipmentStatusLabelCreated, err: domain.ErrInvalidTransition},
+			{from: domain.ShipmentStatusDelivered, to: domain.ShipmentStatusInTransit, err: domain.ErrInvalidTransition},
+			{from: domain.ShipmentStatusCancelled, to: domain.ShipmentStatusInTransit, err: domain.ErrInvalidTransition},
+			{from: domain.ShipmentStatusReturned, to: domain.ShipmentStatusException, err: domain.ErrInvalidTransition},
+		}
+
+		for _, testCase := range testCases {
+			testCase := testCase
+			s.SetupTest()
+
+			s.shipmentsRepo.
+				On("GetByTrackingNumber", mock.AnythingOfType("*context.valueCtx"), "FK473124829ZZ").
+				Return(shipment(testCase.from), nil).
+				Once()
+
+			if testCase.err == nil {
+				s.shipmentsRepo.
+					On("Update", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(shipment domain.Shipment) bool {
+						return shipment.Status == testCase.to && len(shipment.TrackingEvents) == 2
+					})).
+					Return(shipment(testCase.to), nil).
+					Once()
+
+				s.publisher.On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.ShipmentEvent")).Return(nil).Once()
+			}
+
+			tracked, err := s.app.RecordTrackingEvent(context.Background(), update(testCase.to))
+
+			s.Equal(testCase.err, err, "%s -> %s", testCase.from, testCase.to)
+			if testCase.err == nil {
+				s.Equal(testCase.to, tracked.Status)
+			}
+		}
+	})
+
+	s.Run("Should try again in case the shipment changed concurrently", func() {
+		s.SetupTest()
+
+		s.shipmentsRepo.On("GetByTrackingNumber", mock.AnythingOfType("*context.valueCtx"), "FK473124829ZZ").Return(shipment(domain.ShipmentStatusInTransit), nil).Twice()
+		s.shipmentsRepo.On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Shipment")).Return(domain.Shipment{}, domain.ErrShipmentVersionConflict).Once()
+		s.shipmentsRepo.On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Shipment")).Return(shipment(domain.ShipmentStatusOutForDelivery), nil).Once()
+
+		s.publisher.
+			On("Publish", mock.AnythingOfType("*context.valueCtx"), mock.MatchedBy(func(event domain.ShipmentEvent) bool {
+				return event.TrackingEvent.Status == domain.ShipmentStatusOutForDelivery &&
+					event.TrackingEvent.Location == "Newark, NJ" &&
+					!event.TrackingEvent.At.IsZero()
+			})).
+			Return(nil).
+			Once()
+
+		tracked, err := s.app.RecordTrackingEvent(context.Background(), update(domain.ShipmentStatusOutForDelivery))
+
+		s.NoError(err)
+		s.Equal(domain.ShipmentStatusOutForDelivery, tracked.Status)
+		s.shipmentsRepo.AssertExpectations(s.T())
+		s.publisher.AssertExpectations(s.T())
+	})
+
+	s.Run("Should give up in case the shipment keeps changing", func() {
+		s.SetupTest()
+
+		s.shipmentsRepo.On("GetByTrackingNumber", mock.AnythingOfType("*context.valueCtx"), "FK473124829ZZ").Return(shipment(domain.ShipmentStatusInTransit), nil).Times(maxTrackingAttempts)
+		s.shipmentsRepo.On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Shipment")).Return(domain.Shipment{}, domain.ErrShipmentVersionConflict).Times(maxTrackingAttempts)
+
+		_, err := s.app.RecordTrackingEvent(context.Background(), update(domain.ShipmentStatusOutForDelivery))
+
+		s.Equal(domain.ErrShipmentVersionConflict, err)
+		s.publisher.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case the shipment can't be updated", func() {
+		s.SetupTest()
+
+		s.shipmentsRepo.On("GetByTrackingNumber", mock.AnythingOfType("*context.valueCtx"), "FK473124829ZZ").Return(shipment(domain.ShipmentStatusInTransit), nil).Once()
+		s.shipmentsRepo.On("Update", mock.AnythingOfType("*context.valueCtx"), mock.AnythingOfType("domain.Shipment")).Return(domain.Shipment{}, errors.New("connection refused")).Once()
+
+		_, err := s.app.RecordTrackingEvent(context.Background(), update(domain.ShipmentStatusOutForDelivery))
+
+		s.EqualError(err, "connection refused")
+	})
+}
+
+func TestRecordTrackingEventSuite(t *testing.T) {
+	suite.Run(t, new(RecordTrackingEventSuite))
+}
@@ -0,0 +1,23 @@
+package app
+
+import (
+	"context"
+	"strings"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) TrackShipment(ctx context.Context, trackingNumber string) (domain.Shipment, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.TrackShipment")
+	defer span.End()
+
+	a.Logger.Info("tracking a shipment", zap.String("trackingNumber", trackingNumber))
+
+	trackingNumber = strings.ToUpper(strings.TrimSpace(trackingNumber))
+	if trackingNumber == "" {
+		return domain.Shipment{}, domain.ErrInvalidShipment
+	}
+
+	return a.ShipmentsRepository.GetByTrackingNumber(ctx, trackingNumber)
+}
@@ -0,0 +1,67 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"github.com/lucasmls/ecommerce/services/shipping/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type TrackShipmentSuite struct {
+	suite.Suite
+
+	shipmentsRepo *mocks.ShipmentsRepository
+	app           domain.Application
+}
+
+func (s *TrackShipmentSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.shipmentsRepo = &mocks.ShipmentsRepository{}
+
+	s.app = MustNewApplication(
+		loggerM, tracerM, &mocks.RatesRepository{}, s.shipmentsRepo, &mocks.Catalog{}, nil, &mocks.EventPublisher{},
+	)
+}
+
+func (s *TrackShipmentSuite) Test_TrackShipment() {
+	s.Run("Should fail in case the tracking number is empty", func() {
+		s.SetupTest()
+
+		_, err := s.app.TrackShipment(context.Background(), "  ")
+
+		s.Equal(domain.ErrInvalidShipment, err)
+		s.shipmentsRepo.AssertNotCalled(s.T(), "GetByTrackingNumber", mock.Anything, mock.Anything)
+	})
+
+	s.Run("Should fail in case no shipment has the tracking number", func() {
+		s.SetupTest()
+
+		s.shipmentsRepo.On("GetByTrackingNumber", mock.AnythingOfType("*context.valueCtx"), "FK000000005ZZ").Return(domain.Shipment{}, domain.ErrShipmentNotFound).Once()
+
+		_, err := s.app.TrackShipment(context.Background(), "FK000000005ZZ")
+
+		s.Equal(domain.ErrShipmentNotFound, err)
+	})
+
+	s.Run("Should find the shipment regardless of the case of the tracking number", func() {
+		s.SetupTest()
+
+		s.shipmentsRepo.On("GetByTrackingNumber", mock.AnythingOfType("*context.valueCtx"), "FK473124829ZZ").Return(shipment(domain.ShipmentStatusDelivered), nil).Once()
+
+		found, err := s.app.TrackShipment(context.Background(), " fk473124829zz ")
+
+		s.NoError(err)
+		s.Equal(shipment(domain.ShipmentStatusDelivered), found)
+	})
+}
+
+func TestTrackShipmentSuite(t *testing.T) {
+	suite.Run(t, new(TrackShipmentSuite))
+}
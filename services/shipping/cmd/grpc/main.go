@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	productsPb "github.com/lucasmls/ecommerce/services/products/ports/grpc/proto"
+	"github.com/lucasmls/ecommerce/services/shipping/adapters/carriers"
+	"github.com/lucasmls/ecommerce/services/shipping/adapters/catalog"
+	"github.com/lucasmls/ecommerce/services/shipping/adapters/events"
+	"github.com/lucasmls/ecommerce/services/shipping/adapters/repositories"
+	"github.com/lucasmls/ecommerce/services/shipping/app"
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	resolvers "github.com/lucasmls/ecommerce/services/shipping/ports/grpc"
+	protog "github.com/lucasmls/ecommerce/services/shipping/ports/grpc/proto"
+	rmqPb "github.com/lucasmls/ecommerce/services/shipping/ports/rmq/proto"
+	"github.com/lucasmls/ecommerce/shared/env"
+	"github.com/lucasmls/ecommerce/shared/grpc"
+	"github.com/lucasmls/ecommerce/shared/rmq"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otel "go.opentelemetry.io/otel"
+	otelJaegerExporter "go.opentelemetry.io/otel/exporters/jaeger"
+	otelPropagation "go.opentelemetry.io/otel/propagation"
+	otelSdkResource "go.opentelemetry.io/otel/sdk/resource"
+	otelTraceSdk "go.opentelemetry.io/otel/sdk/trace"
+	otelSemconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.uber.org/zap"
+	gGRPC "google.golang.org/grpc"
+)
+
+type ApplicationConfig struct {
+	ServiceName              string `mapstructure:"SERVICE_NAME"`
+	JaegerEndpoint           string `mapstructure:"JAEGER_ENDPOINT"`
+	GrpcServerPort           int    `mapstructure:"GRPC_SERVER_PORT"`
+	MetricsPort              int    `mapstructure:"METRICS_PORT"`
+	PostgresConnectionString string `mapstructure:"PG_CONNECTION_STRING"`
+	// RatesFile is the JSON file holding the zones and the shipping methods, it's read again whenever it changes
+	RatesFile string `mapstructure:"RATES_FILE"`
+	// ProductsServiceAddress is where the weight and dimensions of the products are snapshotted from, e.g. localhost:8081
+	ProductsServiceAddress string `mapstructure:"PRODUCTS_SERVICE_ADDRESS"`
+	// RabbitMQURL is where the shipment events are published to
+	RabbitMQURL string `mapstructure:"RABBITMQ_URL"`
+}
+
+func main() {
+	ctx := context.Background()
+
+	logger, _ := zap.NewProduction()
+	defer logger.Sync()
+
+	config, err := env.LoadConfig[ApplicationConfig]()
+	if err != nil {
+		logger.Fatal("failed to load application config", zap.Error(err))
+	}
+
+	jaegerExporter, err := otelJaegerExporter.New(
+		otelJaegerExporter.WithCollectorEndpoint(
+			otelJaegerExporter.WithEndpoint(config.JaegerEndpoint),
+		),
+	)
+	if err != nil {
+		logger.Fatal("failed to instantiate Jaeger exporter", zap.Error(err))
+	}
+
+	tracingProvider := otelTraceSdk.NewTracerProvider(
+		otelTraceSdk.WithBatcher(jaegerExporter),
+		otelTraceSdk.WithSampler(otelTraceSdk.AlwaysSample()),
+		otelTraceSdk.WithResource(otelSdkResource.NewWithAttributes(
+			otelSemconv.SchemaURL,
+			otelSemconv.ServiceNameKey.String(config.ServiceName),
+		)),
+	)
+
+	defer func() {
+		_ = tracingProvider.Shutdown(ctx)
+	}()
+
+	otel.SetTracerProvider(tracingProvider)
+	otel.SetTextMapPropagator(otelPropagation.NewCompositeTextMapPropagator(
+		otelPropagation.TraceContext{},
+		otelPropagation.Baggage{},
+	))
+
+	tracer := otel.Tracer(config.ServiceName)
+
+	rmqClient := rmq.MustNewClient(rmq.ClientInput{
+		URL:    config.RabbitMQURL,
+		Logger: logger,
+	}).MustConnect()
+	defer rmqClient.Close()
+
+	if err := rmqClient.DeclareExchange(rmqPb.Exchange); err != nil {
+		logger.Fatal("failed to declare the shipping exchange", zap.Error(err))
+	}
+
+	eventPublisher := events.NewRmqEventPublisher(logger, tracer, rmqClient)
+
+	productsServiceGRPCClient := grpc.MustNewClient(grpc.ClientInput{
+		Address: config.ProductsServiceAddress,
+		Logger:  logger,
+	})
+
+	productsServiceConn := productsServiceGRPCClient.MustConnect(ctx)
+
+	productsCatalog := catalog.MustNewGrpcProductsCatalog(logger, tracer, productsPb.NewProductsServiceClient(productsServiceConn))
+
+	fileRatesRepository := repositories.MustNewFileRatesRepository(repositories.FileRatesRepositoryInput{
+		Logger: logger,
+		Tracer: tracer,
+		Path:   config.RatesFile,
+	})
+
+	inMemoryShipmentsRepository := repositories.NewInMemoryShipmentsRepository(logger, tracer)
+	// postgresShipmentsRepository := repositories.MustNewPgShipmentsRepository(config.PostgresConnectionString)
+
+	// the carriers the shipping methods of the rates file refer to by name
+	shippingCarriers := []domain.Carrier{
+		carriers.NewFakeCarrier(logger, tracer, "local"),
+	}
+
+	application := app.MustNewApplication(
+		logger,
+		tracer,
+		fileRatesRepository,
+		inMemoryShipmentsRepository,
+		productsCatalog,
+		shippingCarriers,
+		eventPublisher,
+	)
+	shippingResolver := resolvers.MustNewShippingResolver(logger, tracer, application)
+
+	server := grpc.MustNewServer(grpc.ServerInput{
+		Port:   config.GrpcServerPort,
+		Logger: logger,
+		Registrator: func(server gGRPC.ServiceRegistrar) {
+			protog.RegisterShippingServiceServer(server, shippingResolver)
+		},
+	})
+
+	go func() {
+		port := fmt.Sprintf(":%d", config.MetricsPort)
+
+		http.Handle("/metrics", promhttp.Handler())
+		_ = http.ListenAndServe(port, nil)
+	}()
+
+	if err := server.Run(ctx); err != nil {
+		logger.Fatal("failed to run gRPC server", zap.Error(err))
+	}
+}
@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"context"
+)
+
+// Application defines boundary interfaces of the application
+// It should be called by the ports
+type Application interface {
+	// QuoteShipping prices shipping some Products to a country with every ShippingMethod able to carry them,
+	// failing with ErrDestinationNotServed when no Zone has the country, and with ErrProductNotShippable
+	// when a Product is missing from the catalog or has no weight.
+	QuoteShipping(context.Context, QuoteRequest) (Quote, error)
+
+	// CreateShipment books a Shipment with the Carrier of a ShippingMethod, which issues its tracking number.
+	// The Products are priced the way QuoteShipping does, failing with ErrShippingMethodUnavailable when the method can't carry them.
+	CreateShipment(context.Context, ShipmentRequest) (Shipment, error)
+
+	// GetShipment fetches a single Shipment
+	GetShipment(context.Context, int) (Shipment, error)
+
+	// TrackShipment fetches the Shipment of a tracking number
+	TrackShipment(context.Context, string) (Shipment, error)
+
+	// ListShipments lists the Shipments matching the filter, most recently created first
+	ListShipments(context.Context, ShipmentFilter) ([]Shipment, error)
+
+	// RecordTrackingEvent applies a TrackingEvent reported by a Carrier to the Shipment of its tracking number,
+	// failing with ErrInvalidTransition when the state machine doesn't allow it on the current status.
+	RecordTrackingEvent(context.Context, TrackingUpdate) (Shipment, error)
+
+	// CancelShipment cancels a Shipment with its Carrier, failing with ErrInvalidTransition once it was picked up
+	CancelShipment(context.Context, int) (Shipment, error)
+}
+
+type RatesRepository interface {
+	// Get fetches the RateTable in use
+	Get(context.Context) (RateTable, error)
+}
+
+type ShipmentsRepository interface {
+	// Create stores a new Shipment in a data storage, setting its ID and its first Version.
+	// It fails with ErrTrackingNumberTaken when another Shipment has the same TrackingNumber.
+	Create(context.Context, Shipment) (Shipment, error)
+
+	// Get fetches a single Shipment from a data storage.
+	Get(context.Context, int) (Shipment, error)
+
+	// GetByTrackingNumber fetches the Shipment of a tracking number from a data storage.
+	GetByTrackingNumber(context.Context, string) (Shipment, error)
+
+	// List lists the Shipments matching the filter from a data storage, most recently created first, ties broken by ID.
+	List(context.Context, ShipmentFilter) ([]Shipment, error)
+
+	// Update replaces a Shipment in a data storage, returning it with its new Version.
+	// It's only replaced while the stored Version is still the one it was read with, failing with
+	// ErrShipmentVersionConflict otherwise, so concurrent TrackingEvents can't skip the state machine.
+	Update(context.Context, Shipment) (Shipment, error)
+}
+
+type Catalog interface {
+	// Products fetches the weight and dimensions of the given Products, keyed by their ID.
+	// The missing Products are left out rather than failing.
+	Products(context.Context, []int) (map[int]ProductSnapshot, error)
+}
+
+// Carrier delivers the Shipments, e.g. a postal service or a courier
+type Carrier interface {
+	// Name identifies the Carrier on the ShippingMethods
+	Name() string
+
+	// CreateShipment books a Shipment, returning the tracking number the Carrier issued for it
+	CreateShipment(context.Context, Shipment) (string, error)
+
+	// CancelShipment cancels the booking of the Shipment of a tracking number
+	CancelShipment(context.Context, string) error
+}
+
+type EventPublisher interface {
+	// Publish tells the other services about a ShipmentEvent
+	Publish(context.Context, ShipmentEvent) error
+}
@@ -0,0 +1,8 @@
+package domain
+
+// ShipmentEvent tells the other services a Shipment moved to another ShipmentStatus, e.g. so the customer is notified
+type ShipmentEvent struct {
+	Shipment Shipment
+	// TrackingEvent is the one that moved the Shipment, the one it was created with when it was just booked
+	TrackingEvent TrackingEvent
+}
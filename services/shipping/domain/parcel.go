@@ -0,0 +1,229 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+const (
+	// MaxParcelItems is the maximum amount of different Products shipped at once
+	MaxParcelItems = 100
+	// MaxItemQuantity is the maximum amount of units of a single Product shipped at once
+	MaxItemQuantity = 999
+)
+
+var (
+	ErrInvalidParcel = errors.New("invalid-parcel")
+	// ErrProductNotShippable tells a Product can't be shipped, because it's not on the catalog or its weight is unknown
+	ErrProductNotShippable = errors.New("product-not-shippable")
+)
+
+// Dimensions are the size of a Product as shipped, in millimeters
+type Dimensions struct {
+	Length int
+	Width  int
+	Height int
+}
+
+// Volume is in cubic millimeters
+func (d Dimensions) Volume() int {
+	return d.Length * d.Width * d.Height
+}
+
+// ProductSnapshot is what shipping a Product needs to know about it, taken from the catalog.
+type ProductSnapshot struct {
+	ID int
+	// Weight is in grams, 0 when unknown
+	Weight int
+	// Dimensions are zero when unknown, the Product is then billed by its Weight only
+	Dimensions Dimensions
+}
+
+// ItemRequest asks for units of a Product to be shipped
+type ItemRequest struct {
+	ProductID int
+	Quantity  int
+}
+
+// validateItems checks there's at least one item and that each one ships some units of a Product
+func validateItems(items []ItemRequest) error {
+	if len(items) == 0 || len(items) > MaxParcelItems {
+		return ErrInvalidParcel
+	}
+
+	for _, item := range items {
+		if item.ProductID <= 0 || item.Quantity <= 0 || item.Quantity > MaxItemQuantity {
+			return ErrInvalidParcel
+		}
+	}
+
+	return nil
+}
+
+// Item is an amount of units of a Product on a parcel, along with the snapshots of their weight and dimensions
+type Item struct {
+	ProductID int
+	Quantity  int
+	// Weight is the weight of a unit, in grams
+	Weight     int
+	Dimensions Dimensions
+}
+
+// NewItems snapshots the weight and dimensions of the Products of the requests, adding up the units of a
+// Product asked for more than once. It fails with ErrProductNotShippable when a Product is missing or weightless.
+func NewItems(requests []ItemRequest, products map[int]ProductSnapshot) ([]Item, error) {
+	items := make([]Item, 0, len(requests))
+	indexes := make(map[int]int, len(requests))
+
+	for _, request := range requests {
+		if index, ok := indexes[request.ProductID]; ok {
+			items[index].Quantity += request.Quantity
+			continue
+		}
+
+		product, ok := products[request.ProductID]
+		if !ok || product.Weight <= 0 {
+			return nil, ErrProductNotShippable
+		}
+
+		indexes[request.ProductID] = len(items)
+		items = append(items, Item{
+			ProductID:  request.ProductID,
+			Quantity:   request.Quantity,
+			Weight:     product.Weight,
+			Dimensions: product.Dimensions,
+		})
+	}
+
+	return items, nil
+}
+
+// BillableWeight is the weight a parcel is priced by, in grams. Each unit counts as the larger of its
+// weight and its volumetric weight, its volume divided by the divisor and rounded up, since bulky and
+// light items take the room of heavier ones.
+func BillableWeight(items []Item, volumetricDivisor int) int {
+	total := 0
+	for _, item := range items {
+		weight := item.Weight
+		if volumetric := (item.Dimensions.Volume() + volumetricDivisor - 1) / volumetricDivisor; volumetric > weight {
+			weight = volumetric
+		}
+
+		total += weight * item.Quantity
+	}
+
+	return total
+}
+
+// Address is where a parcel is delivered to
+type Address struct {
+	Recipient  string
+	Line1      string
+	Line2      string
+	City       string
+	Region     string
+	PostalCode string
+	// Country is an ISO 3166-1 alpha-2 code, as in US
+	Country string
+	Phone   string
+}
+
+// Normalize trims the fields of the Address and upper cases its Country
+func (a Address) Normalize() Address {
+	return Address{
+		Recipient:  strings.TrimSpace(a.Recipient),
+		Line1:      strings.TrimSpace(a.Line1),
+		Line2:      strings.TrimSpace(a.Line2),
+		City:       strings.TrimSpace(a.City),
+		Region:     strings.TrimSpace(a.Region),
+		PostalCode: strings.TrimSpace(a.PostalCode),
+		Country:    strings.ToUpper(strings.TrimSpace(a.Country)),
+		Phone:      strings.TrimSpace(a.Phone),
+	}
+}
+
+// Validate checks a parcel can be delivered to the Address, which must be normalized
+func (a Address) Validate() error {
+	if a.Recipient == "" || a.Line1 == "" || a.City == "" || a.PostalCode == "" || !countryPattern.MatchString(a.Country) {
+		return ErrInvalidAddress
+	}
+
+	return nil
+}
+
+// QuoteRequest asks what shipping some Products to a country costs
+type QuoteRequest struct {
+	// Country is an ISO 3166-1 alpha-2 code, as in US
+	Country string
+	Items   []ItemRequest
+}
+
+// Validate checks the country, which must be upper cased, and the items
+func (r QuoteRequest) Validate() error {
+	if !countryPattern.MatchString(r.Country) {
+		return ErrInvalidAddress
+	}
+
+	return validateItems(r.Items)
+}
+
+// ShippingOption is a ShippingMethod able to carry a parcel, along with its price
+type ShippingOption struct {
+	Method  string
+	Name    string
+	Carrier string
+	// Price is in minor currency units (cents)
+	Price int
+}
+
+// Quote lists what shipping a parcel costs with every ShippingMethod able to carry it
+type Quote struct {
+	// RatesVersion is the Version of the RateTable the parcel was priced with
+	RatesVersion string
+	Zone         string
+	// BillableWeight is in grams
+	BillableWeight int
+	// Options are sorted by price, cheapest first, ties broken by method
+	Options []ShippingOption
+}
+
+// NewQuote prices the items with every ShippingMethod shipping to the country,
+// leaving out the ones that can't carry them, e.g. because they're too heavy
+func NewQuote(country string, items []Item, table RateTable) (Quote, error) {
+	zone, err := table.Zone(country)
+	if err != nil {
+		return Quote{}, err
+	}
+
+	quote := Quote{
+		RatesVersion:   table.Version,
+		Zone:           zone.Name,
+		BillableWeight: BillableWeight(items, table.VolumetricDivisor),
+		Options:        []ShippingOption{},
+	}
+
+	for _, method := range table.Methods {
+		price, err := method.Price(zone.Name, quote.BillableWeight)
+		if err != nil {
+			continue
+		}
+
+		quote.Options = append(quote.Options, ShippingOption{
+			Method:  method.Code,
+			Name:    method.Name,
+			Carrier: method.Carrier,
+			Price:   price,
+		})
+	}
+
+	sort.Slice(quote.Options, func(i, j int) bool {
+		if quote.Options[i].Price != quote.Options[j].Price {
+			return quote.Options[i].Price < quote.Options[j].Price
+		}
+
+		return quote.Options[i].Method < quote.Options[j].Method
+	})
+
+	return quote, nil
+}
@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+const (
+	// DefaultVolumetricDivisor turns a volume in cubic millimeters into grams, the usual 5000 cm³/kg of the carriers
+	DefaultVolumetricDivisor = 5000
+
+	// AnyCountry matches, on a Zone, every country no other Zone lists
+	AnyCountry = "*"
+)
+
+var (
+	ErrInvalidRateTable      = errors.New("invalid-rate-table")
+	ErrDestinationNotServed  = errors.New("destination-not-served")
+	ErrShippingMethodUnknown = errors.New("shipping-method-unknown")
+	// ErrShippingMethodUnavailable tells a ShippingMethod can't carry a parcel to a destination, e.g. it's too heavy
+	ErrShippingMethodUnavailable = errors.New("shipping-method-unavailable")
+)
+
+var (
+	methodCodePattern = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+	countryPattern    = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// Zone groups the countries shipped to at the same rates
+type Zone struct {
+	Name string
+	// Countries are ISO 3166-1 alpha-2 codes, as in US, or AnyCountry
+	Countries []string
+}
+
+// WeightTier prices the parcels weighing up to MaxWeight
+type WeightTier struct {
+	// MaxWeight is in grams, inclusive
+	MaxWeight int
+	// Price is in minor currency units (cents)
+	Price int
+}
+
+// ShippingMethod is a service a Carrier offers, as in standard or express delivery
+type ShippingMethod struct {
+	Code string
+	// Name is meant to be shown to the customers
+	Name string
+	// Carrier is the Name of the Carrier delivering the parcels
+	Carrier string
+	// Rates are the WeightTiers of each Zone the method ships to, keyed by the Zone Name, lightest first
+	Rates map[string][]WeightTier
+}
+
+// Price is what shipping a parcel of a billable weight to a Zone costs, the price of the lightest WeightTier
+// it fits in. It fails with ErrShippingMethodUnavailable when the method doesn't ship there or the parcel is too heavy.
+func (m ShippingMethod) Price(zone string, weight int) (int, error) {
+	for _, tier := range m.Rates[zone] {
+		if weight <= tier.MaxWeight {
+			return tier.Price, nil
+		}
+	}
+
+	return 0, ErrShippingMethodUnavailable
+}
+
+// RateTable holds the Zones shipped to and the ShippingMethods shipping there
+type RateTable struct {
+	// Version identifies the rates, every Quote tells the Version it was made with
+	Version string
+	// VolumetricDivisor turns the volume of a parcel, in cubic millimeters, into the grams it's billed as
+	VolumetricDivisor int
+	Zones             []Zone
+	Methods           []ShippingMethod
+}
+
+// Validate checks the RateTable has a Version, that every country is on a single Zone,
+// and that the ShippingMethods are priced by increasing weights on known Zones only
+func (t RateTable) Validate() error {
+	if strings.TrimSpace(t.Version) == "" || t.VolumetricDivisor <= 0 {
+		return ErrInvalidRateTable
+	}
+
+	zones := make(map[string]bool, len(t.Zones))
+	countries := map[string]bool{}
+	for _, zone := range t.Zones {
+		if strings.TrimSpace(zone.Name) == "" || zones[zone.Name] || len(zone.Countries) == 0 {
+			return ErrInvalidRateTable
+		}
+
+		zones[zone.Name] = true
+
+		for _, country := range zone.Countries {
+			if country != AnyCountry && !countryPattern.MatchString(country) {
+				return ErrInvalidRateTable
+			}
+
+			if countries[country] {
+				return ErrInvalidRateTable
+			}
+
+			countries[country] = true
+		}
+	}
+
+	methods := make(map[string]bool, len(t.Methods))
+	for _, method := range t.Methods {
+		if !methodCodePattern.MatchString(method.Code) || methods[method.Code] {
+			return ErrInvalidRateTable
+		}
+
+		methods[method.Code] = true
+
+		if strings.TrimSpace(method.Name) == "" || strings.TrimSpace(method.Carrier) == "" {
+			return ErrInvalidRateTable
+		}
+
+		for zone, tiers := range method.Rates {
+			if !zones[zone] || len(tiers) == 0 {
+				return ErrInvalidRateTable
+			}
+
+			previous := 0
+			for _, tier := range tiers {
+				if tier.MaxWeight <= previous || tier.Price < 0 {
+					return ErrInvalidRateTable
+				}
+
+				previous = tier.MaxWeight
+			}
+		}
+	}
+
+	return nil
+}
+
+// Zone finds the Zone of a country, the one listing AnyCountry when no Zone lists the country itself.
+// It fails with ErrDestinationNotServed when there's none.
+func (t RateTable) Zone(country string) (Zone, error) {
+	var fallback *Zone
+
+	for i, zone := range t.Zones {
+		for _, candidate := range zone.Countries {
+			switch candidate {
+			case country:
+				return zone, nil
+			case AnyCountry:
+				fallback = &t.Zones[i]
+			}
+		}
+	}
+
+	if fallback == nil {
+		return Zone{}, ErrDestinationNotServed
+	}
+
+	return *fallback, nil
+}
+
+// Method finds a ShippingMethod by its Code, failing with ErrShippingMethodUnknown when there's none
+func (t RateTable) Method(code string) (ShippingMethod, error) {
+	for _, method := range t.Methods {
+		if method.Code == code {
+			return method, nil
+		}
+	}
+
+	return ShippingMethod{}, ErrShippingMethodUnknown
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxLocationLength is the maximum amount of characters of the location of a TrackingEvent
+	MaxLocationLength = 200
+	// MaxDescriptionLength is the maximum amount of characters of the description of a TrackingEvent
+	MaxDescriptionLength = 500
+	// MaxCustomerIDLength is the maximum amount of characters of the ID of the customer a Shipment is delivered to
+	MaxCustomerIDLength = 255
+)
+
+var (
+	ErrShipmentNotFound        = errors.New("shipment-not-found")
+	ErrShipmentVersionConflict = errors.New("shipment-version-conflict")
+	ErrTrackingNumberTaken     = errors.New("tracking-number-taken")
+	ErrInvalidShipment         = errors.New("invalid-shipment")
+	ErrInvalidAddress          = errors.New("invalid-address")
+	ErrInvalidShipmentStatus   = errors.New("invalid-shipment-status")
+	ErrInvalidTransition       = errors.New("invalid-transition")
+	// ErrCarrierUnavailable tells the Carrier of a ShippingMethod isn't set up, or can't be reached
+	ErrCarrierUnavailable = errors.New("carrier-unavailable")
+)
+
+// TrackingEvent records a ShipmentStatus a Shipment went through, as reported by its Carrier.
+type TrackingEvent struct {
+	Status ShipmentStatus
+	// Location is where the event happened, e.g. the hub the parcel was scanned at, empty when unknown
+	Location    string
+	Description string
+	At          time.Time
+}
+
+// Shipment is a parcel carrying the Products of an Order to the customer.
+// Its Status only changes through the TrackingEvents its Carrier reports, see Shipment.Track.
+type Shipment struct {
+	ID      int
+	OrderID int
+	// CustomerID is the customer who placed the Order, who's told about the progress of the Shipment
+	CustomerID string
+	// Method is the Code of the ShippingMethod the Shipment was booked with
+	Method  string
+	Carrier string
+	// TrackingNumber identifies the Shipment to its Carrier and to the customer
+	TrackingNumber string
+	Destination    Address
+	Items          []Item
+	// BillableWeight is in grams
+	BillableWeight int
+	// Price is what the shipping costs, in minor currency units (cents)
+	Price int
+	// RatesVersion is the Version of the RateTable the Shipment was priced with
+	RatesVersion string
+	Status       ShipmentStatus
+	// TrackingEvents lists every TrackingEvent of the Shipment, oldest first
+	TrackingEvents []TrackingEvent
+	// Version is incremented on every change, starting at 1 when the Shipment is created.
+	Version   int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Track moves the Shipment to the status of the event, recording it.
+// It fails with ErrInvalidTransition, leaving the Shipment untouched, when its current status can't move there.
+func (s *Shipment) Track(event TrackingEvent, now time.Time) error {
+	if !s.Status.CanMoveTo(event.Status) {
+		return ErrInvalidTransition
+	}
+
+	s.TrackingEvents = append(s.TrackingEvents, event)
+	s.Status = event.Status
+	s.UpdatedAt = now
+
+	return nil
+}
+
+// ShipmentRequest asks for the Products of an Order to be shipped with a ShippingMethod
+type ShipmentRequest struct {
+	OrderID    int
+	CustomerID string
+	// Method is the Code of a ShippingMethod, as quoted by QuoteShipping
+	Method      string
+	Destination Address
+	Items       []ItemRequest
+}
+
+// Validate checks the Order, the customer, the Method and the items, the Destination must be normalized
+func (r ShipmentRequest) Validate() error {
+	if r.OrderID <= 0 || r.CustomerID == "" || len(r.CustomerID) > MaxCustomerIDLength || strings.TrimSpace(r.Method) == "" {
+		return ErrInvalidShipment
+	}
+
+	if err := r.Destination.Validate(); err != nil {
+		return err
+	}
+
+	return validateItems(r.Items)
+}
+
+// ShipmentFilter narrows the Shipments down, its unset fields match every Shipment.
+type ShipmentFilter struct {
+	OrderID int
+	Status  ShipmentStatus
+	// Limit bounds how many Shipments are listed, DefaultShipmentsLimit when unset
+	Limit int
+}
+
+const (
+	// DefaultShipmentsLimit is how many Shipments are listed when the ShipmentFilter has no Limit
+	DefaultShipmentsLimit = 20
+	// MaxShipmentsLimit is the maximum amount of Shipments listed at once
+	MaxShipmentsLimit = 100
+)
+
+// TrackingUpdate is a TrackingEvent a Carrier reports for the Shipment of a tracking number
+type TrackingUpdate struct {
+	TrackingNumber string
+	Event          TrackingEvent
+}
+
+// Validate checks the tracking number, the status and the lengths of the location and the description
+func (u TrackingUpdate) Validate() error {
+	if strings.TrimSpace(u.TrackingNumber) == "" {
+		return ErrInvalidShipment
+	}
+
+	if len([]rune(u.Event.Location)) > MaxLocationLength || len([]rune(u.Event.Description)) > MaxDescriptionLength {
+		return ErrInvalidShipment
+	}
+
+	return u.Event.Status.Validate()
+}
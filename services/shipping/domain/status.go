@@ -0,0 +1,61 @@
+package domain
+
+// ShipmentStatus is where a Shipment stands on its way to the customer.
+type ShipmentStatus string
+
+const (
+	// ShipmentStatusLabelCreated is the status of a Shipment booked with its Carrier that wasn't picked up yet
+	ShipmentStatusLabelCreated ShipmentStatus = "label_created"
+	// ShipmentStatusInTransit is the status of a Shipment moving through the network of its Carrier
+	ShipmentStatusInTransit ShipmentStatus = "in_transit"
+	// ShipmentStatusOutForDelivery is the status of a Shipment on the vehicle delivering it
+	ShipmentStatusOutForDelivery ShipmentStatus = "out_for_delivery"
+	// ShipmentStatusException is the status of a Shipment held up, e.g. by a failed delivery attempt or at customs
+	ShipmentStatusException ShipmentStatus = "exception"
+	// ShipmentStatusDelivered is the final status of a Shipment handed over to the customer
+	ShipmentStatusDelivered ShipmentStatus = "delivered"
+	// ShipmentStatusReturned is the final status of a Shipment sent back, e.g. after every delivery attempt failed
+	ShipmentStatusReturned ShipmentStatus = "returned"
+	// ShipmentStatusCancelled is the final status of a Shipment given up before being picked up
+	ShipmentStatusCancelled ShipmentStatus = "cancelled"
+)
+
+// Validate checks that the ShipmentStatus is one of the known ones
+func (s ShipmentStatus) Validate() error {
+	switch s {
+	case ShipmentStatusLabelCreated, ShipmentStatusInTransit, ShipmentStatusOutForDelivery,
+		ShipmentStatusException, ShipmentStatusDelivered, ShipmentStatusReturned, ShipmentStatusCancelled:
+		return nil
+	default:
+		return ErrInvalidShipmentStatus
+	}
+}
+
+// CanMoveTo reports whether a Shipment may go from this ShipmentStatus to another one
+func (s ShipmentStatus) CanMoveTo(to ShipmentStatus) bool {
+	for _, allowed := range transitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// transitions is the state machine of the Shipments, the statuses each one may move to.
+// A Shipment may stay in_transit or on exception over many TrackingEvents, e.g. as it's scanned at each hub:
+//
+//	label_created --> in_transit --> out_for_delivery --> delivered
+//	label_created --> cancelled
+//	in_transit, out_for_delivery <--> exception --> returned
+//	in_transit --> returned
+var transitions = map[ShipmentStatus][]ShipmentStatus{
+	ShipmentStatusLabelCreated: {ShipmentStatusInTransit, ShipmentStatusCancelled},
+	ShipmentStatusInTransit: {
+		ShipmentStatusInTransit, ShipmentStatusOutForDelivery, ShipmentStatusException, ShipmentStatusReturned,
+	},
+	ShipmentStatusOutForDelivery: {ShipmentStatusDelivered, ShipmentStatusInTransit, ShipmentStatusException},
+	ShipmentStatusException: {
+		ShipmentStatusException, ShipmentStatusInTransit, ShipmentStatusOutForDelivery, ShipmentStatusReturned,
+	},
+}
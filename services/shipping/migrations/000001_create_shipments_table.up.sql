@@ -0,0 +1,24 @@
+-- the destination, the items and the tracking events are kept along with their shipment, which is always read and written as a whole
+CREATE TABLE IF NOT EXISTS shipments (
+    id              SERIAL PRIMARY KEY,
+    order_id        INTEGER     NOT NULL,
+    customer_id     TEXT        NOT NULL,
+    method          TEXT        NOT NULL,
+    carrier         TEXT        NOT NULL,
+    tracking_number TEXT        NOT NULL UNIQUE,
+    destination     JSONB       NOT NULL,
+    items           JSONB       NOT NULL,
+    billable_weight INTEGER     NOT NULL,
+    price           INTEGER     NOT NULL,
+    rates_version   TEXT        NOT NULL,
+    status          TEXT        NOT NULL,
+    tracking_events JSONB       NOT NULL DEFAULT '[]',
+    version         INTEGER     NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL,
+    CONSTRAINT shipments_status_check CHECK (
+        status IN ('label_created', 'in_transit', 'out_for_delivery', 'exception', 'delivered', 'returned', 'cancelled')
+    )
+);
+
+CREATE INDEX shipments_order_id_created_at_idx ON shipments (order_id, created_at DESC);
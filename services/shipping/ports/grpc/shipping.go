@@ -0,0 +1,327 @@
+package grpc_port
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	pb "github.com/lucasmls/ecommerce/services/shipping/ports/grpc/proto"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var InternalServerError = status.Error(codes.Internal, "Internal server error")
+
+var (
+	ErrMissingLogger = errors.New("missing required dependency: Logger")
+	ErrMisingTracer  = errors.New("missing required dependency: Tracer")
+)
+
+// ShippingResolver ...
+type ShippingResolver struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+	App    domain.Application
+
+	pb.UnimplementedShippingServiceServer
+}
+
+func NewShippingResolver(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) (*ShippingResolver, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMisingTracer
+	}
+
+	return &ShippingResolver{
+		Logger: logger,
+		Tracer: tracer,
+		App:    app,
+	}, nil
+}
+
+// MustNewShippingResolver creates a new ShippingResolver instance.
+// It panics if any error is found
+func MustNewShippingResolver(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) *ShippingResolver {
+	shippingResolver, err := NewShippingResolver(logger, tracer, app)
+	if err != nil {
+		panic(err)
+	}
+
+	return shippingResolver
+}
+
+func (r *ShippingResolver) QuoteShipping(ctx context.Context, req *pb.QuoteShippingRequest) (*pb.QuoteShippingResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.QuoteShipping")
+	defer span.End()
+
+	request := domain.QuoteRequest{
+		Country: req.Country,
+		Items:   toDomainItemRequests(req.Items),
+	}
+
+	quote, err := r.App.QuoteShipping(ctx, request)
+	if err != nil {
+		return nil, r.toError(err, "failed to quote the shipping", zap.String("country", req.Country))
+	}
+
+	options := make([]*pb.ShippingOption, 0, len(quote.Options))
+	for _, option := range quote.Options {
+		options = append(options, &pb.ShippingOption{
+			Method:  option.Method,
+			Name:    option.Name,
+			Carrier: option.Carrier,
+			Price:   int32(option.Price),
+		})
+	}
+
+	return &pb.QuoteShippingResponse{
+		Data: &pb.Quote{
+			RatesVersion:   quote.RatesVersion,
+			Zone:           quote.Zone,
+			BillableWeight: int32(quote.BillableWeight),
+			Options:        options,
+		},
+	}, nil
+}
+
+func (r *ShippingResolver) CreateShipment(ctx context.Context, req *pb.CreateShipmentRequest) (*pb.CreateShipmentResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.CreateShipment")
+	defer span.End()
+
+	request := domain.ShipmentRequest{
+		OrderID:     int(req.OrderId),
+		CustomerID:  req.CustomerId,
+		Method:      req.Method,
+		Destination: toDomainAddress(req.Destination),
+		Items:       toDomainItemRequests(req.Items),
+	}
+
+	shipment, err := r.App.CreateShipment(ctx, request)
+	if err != nil {
+		return nil, r.toError(
+			err, "failed to create the shipment",
+			zap.Int32("order_id", req.OrderId), zap.String("method", req.Method),
+		)
+	}
+
+	return &pb.CreateShipmentResponse{Data: toProtoShipment(shipment)}, nil
+}
+
+func (r *ShippingResolver) GetShipment(ctx context.Context, req *pb.GetShipmentRequest) (*pb.GetShipmentResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.GetShipment")
+	defer span.End()
+
+	shipment, err := r.App.GetShipment(ctx, int(req.Id))
+	if err != nil {
+		return nil, r.toError(err, "failed to get the shipment", zap.Int32("id", req.Id))
+	}
+
+	return &pb.GetShipmentResponse{Data: toProtoShipment(shipment)}, nil
+}
+
+func (r *ShippingResolver) TrackShipment(ctx context.Context, req *pb.TrackShipmentRequest) (*pb.TrackShipmentResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.TrackShipment")
+	defer span.End()
+
+	shipment, err := r.App.TrackShipment(ctx, req.TrackingNumber)
+	if err != nil {
+		return nil, r.toError(err, "failed to track the shipment", zap.String("tracking_number", req.TrackingNumber))
+	}
+
+	return &pb.TrackShipmentResponse{Data: toProtoShipment(shipment)}, nil
+}
+
+func (r *ShippingResolver) ListShipments(ctx context.Context, req *pb.ListShipmentsRequest) (*pb.ListShipmentsResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.ListShipments")
+	defer span.End()
+
+	filter := domain.ShipmentFilter{
+		OrderID: int(req.OrderId),
+		Status:  domain.ShipmentStatus(req.Status),
+		Limit:   int(req.Limit),
+	}
+
+	shipments, err := r.App.ListShipments(ctx, filter)
+	if err != nil {
+		return nil, r.toError(err, "failed to list the shipments", zap.Any("filter", filter))
+	}
+
+	response := make([]*pb.Shipment, 0, len(shipments))
+	for _, shipment := range shipments {
+		response = append(response, toProtoShipment(shipment))
+	}
+
+	return &pb.ListShipmentsResponse{Data: response}, nil
+}
+
+func (r *ShippingResolver) RecordTrackingEvent(ctx context.Context, req *pb.RecordTrackingEventRequest) (*pb.RecordTrackingEventResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.RecordTrackingEvent")
+	defer span.End()
+
+	update := domain.TrackingUpdate{
+		TrackingNumber: req.TrackingNumber,
+		Event: domain.TrackingEvent{
+			Status:      domain.ShipmentStatus(req.Event.GetStatus()),
+			Location:    req.Event.GetLocation(),
+			Description: req.Event.GetDescription(),
+		},
+	}
+
+	if req.Event.GetAt() != nil {
+		update.Event.At = req.Event.GetAt().AsTime()
+	}
+
+	shipment, err := r.App.RecordTrackingEvent(ctx, update)
+	if err != nil {
+		return nil, r.toError(
+			err, "failed to record the tracking event",
+			zap.String("tracking_number", req.TrackingNumber), zap.String("status", req.Event.GetStatus()),
+		)
+	}
+
+	return &pb.RecordTrackingEventResponse{Data: toProtoShipment(shipment)}, nil
+}
+
+func (r *ShippingResolver) CancelShipment(ctx context.Context, req *pb.CancelShipmentRequest) (*pb.CancelShipmentResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.CancelShipment")
+	defer span.End()
+
+	shipment, err := r.App.CancelShipment(ctx, int(req.Id))
+	if err != nil {
+		return nil, r.toError(err, "failed to cancel the shipment", zap.Int32("id", req.Id))
+	}
+
+	return &pb.CancelShipmentResponse{Data: toProtoShipment(shipment)}, nil
+}
+
+// toError maps the Shipment errors to their gRPC codes, a carrier that can't be reached being Unavailable
+// so the call may be retried. Unknown errors are logged and reported as internal.
+func (r *ShippingResolver) toError(err error, message string, fields ...zap.Field) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidParcel),
+		errors.Is(err, domain.ErrInvalidAddress),
+		errors.Is(err, domain.ErrInvalidShipment),
+		errors.Is(err, domain.ErrInvalidShipmentStatus):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrShipmentNotFound),
+		errors.Is(err, domain.ErrDestinationNotServed),
+		errors.Is(err, domain.ErrShippingMethodUnknown):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidTransition),
+		errors.Is(err, domain.ErrShippingMethodUnavailable),
+		errors.Is(err, domain.ErrProductNotShippable):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrTrackingNumberTaken):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, domain.ErrShipmentVersionConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, domain.ErrCarrierUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	r.Logger.Error(message, append(fields, zap.Error(err))...)
+	return InternalServerError
+}
+
+func toDomainItemRequests(items []*pb.ItemRequest) []domain.ItemRequest {
+	requests := make([]domain.ItemRequest, 0, len(items))
+	for _, item := range items {
+		requests = append(requests, domain.ItemRequest{
+			ProductID: int(item.ProductId),
+			Quantity:  int(item.Quantity),
+		})
+	}
+
+	return requests
+}
+
+func toDomainAddress(address *pb.Address) domain.Address {
+	return domain.Address{
+		Recipient:  address.GetRecipient(),
+		Line1:      address.GetLine1(),
+		Line2:      address.GetLine2(),
+		City:       address.GetCity(),
+		Region:     address.GetRegion(),
+		PostalCode: address.GetPostalCode(),
+		Country:    address.GetCountry(),
+		Phone:      address.GetPhone(),
+	}
+}
+
+func toProtoShipment(shipment domain.Shipment) *pb.Shipment {
+	items := make([]*pb.Item, 0, len(shipment.Items))
+	for _, item := range shipment.Items {
+		items = append(items, &pb.Item{
+			ProductId: int32(item.ProductID),
+			Quantity:  int32(item.Quantity),
+			Weight:    int32(item.Weight),
+			Length:    int32(item.Dimensions.Length),
+			Width:     int32(item.Dimensions.Width),
+			Height:    int32(item.Dimensions.Height),
+		})
+	}
+
+	events := make([]*pb.TrackingEvent, 0, len(shipment.TrackingEvents))
+	for _, event := range shipment.TrackingEvents {
+		events = append(events, &pb.TrackingEvent{
+			Status:      string(event.Status),
+			Location:    event.Location,
+			Description: event.Description,
+			At:          toProtoTimestamp(event.At),
+		})
+	}
+
+	destination := shipment.Destination
+
+	return &pb.Shipment{
+		Id:             int32(shipment.ID),
+		OrderId:        int32(shipment.OrderID),
+		CustomerId:     shipment.CustomerID,
+		Method:         shipment.Method,
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+		Destination: &pb.Address{
+			Recipient:  destination.Recipient,
+			Line1:      destination.Line1,
+			Line2:      destination.Line2,
+			City:       destination.City,
+			Region:     destination.Region,
+			PostalCode: destination.PostalCode,
+			Country:    destination.Country,
+			Phone:      destination.Phone,
+		},
+		Items:          items,
+		BillableWeight: int32(shipment.BillableWeight),
+		Price:          int32(shipment.Price),
+		RatesVersion:   shipment.RatesVersion,
+		Status:         string(shipment.Status),
+		TrackingEvents: events,
+		Version:        int32(shipment.Version),
+		CreatedAt:      toProtoTimestamp(shipment.CreatedAt),
+		UpdatedAt:      toProtoTimestamp(shipment.UpdatedAt),
+	}
+}
+
+// toProtoTimestamp leaves the zero times unset
+func toProtoTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
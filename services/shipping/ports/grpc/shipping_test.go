@@ -0,0 +1,403 @@
+package grpc_port
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/shared/grpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	gGRPC "google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/lucasmls/ecommerce/services/shipping/domain"
+	"github.com/lucasmls/ecommerce/services/shipping/mocks"
+	protog "github.com/lucasmls/ecommerce/services/shipping/ports/grpc/proto"
+)
+
+type ShippingResolverSuite struct {
+	suite.Suite
+
+	app    *mocks.Application
+	logger *zap.Logger
+	tracer trace.Tracer
+
+	grpcClient     protog.ShippingServiceClient
+	grpcConnection *gGRPC.ClientConn
+}
+
+func (s *ShippingResolverSuite) SetupSuite() {
+	s.logger = zap.NewNop()
+	s.tracer = trace.NewNoopTracerProvider().Tracer("")
+	s.app = &mocks.Application{}
+
+	grpcClient, grpcConnection := makeGrpcClientAndServer(
+		context.Background(),
+		s.logger,
+		s.tracer,
+		s.app,
+	)
+
+	s.grpcClient = grpcClient
+	s.grpcConnection = grpcConnection
+}
+
+func (s *ShippingResolverSuite) TearDownSuite() {
+	err := s.grpcConnection.Close()
+	s.NoError(err)
+}
+
+func (s *ShippingResolverSuite) Test_NewShippingResolver() {
+	s.Run("Should fail to instantiate the ShippingResolver in case a Logger isn't provided", func() {
+		_, err := NewShippingResolver(nil, nil, nil)
+
+		s.Equal(ErrMissingLogger, err)
+	})
+
+	s.Run("Should fail to instantiate the ShippingResolver in case a Tracer isn't provided", func() {
+		_, err := NewShippingResolver(s.logger, nil, nil)
+
+		s.Equal(ErrMisingTracer, err)
+	})
+
+	s.Run("Should successfully instantiate the ShippingResolver", func() {
+		_, err := NewShippingResolver(s.logger, s.tracer, nil)
+
+		s.NoError(err)
+	})
+}
+
+func (s *ShippingResolverSuite) Test_QuoteShipping() {
+	quoteRequest := domain.QuoteRequest{
+		Country: "US",
+		Items:   []domain.ItemRequest{{ProductID: 1, Quantity: 2}},
+	}
+
+	request := &protog.QuoteShippingRequest{
+		Country: "US",
+		Items:   []*protog.ItemRequest{{ProductId: 1, Quantity: 2}},
+	}
+
+	s.Run("Should return a not found error in case the destination isn't served", func() {
+		s.app.On("QuoteShipping", mock.AnythingOfType("*context.valueCtx"), quoteRequest).Return(domain.Quote{}, domain.ErrDestinationNotServed).Once()
+
+		_, err := s.grpcClient.QuoteShipping(context.Background(), request)
+
+		s.Equal(status.Error(codes.NotFound, domain.ErrDestinationNotServed.Error()), err)
+	})
+
+	s.Run("Should return a failed precondition error in case a product can't be shipped", func() {
+		s.app.On("QuoteShipping", mock.AnythingOfType("*context.valueCtx"), quoteRequest).Return(domain.Quote{}, domain.ErrProductNotShippable).Once()
+
+		_, err := s.grpcClient.QuoteShipping(context.Background(), request)
+
+		s.Equal(status.Error(codes.FailedPrecondition, domain.ErrProductNotShippable.Error()), err)
+	})
+
+	s.Run("Should return the shipping options", func() {
+		quote := domain.Quote{
+			RatesVersion:   "2022-10-01",
+			Zone:           "domestic",
+			BillableWeight: 2580,
+			Options: []domain.ShippingOption{
+				{Method: "standard", Name: "Standard", Carrier: "local", Price: 899},
+				{Method: "express", Name: "Express", Carrier: "local", Price: 1999},
+			},
+		}
+
+		s.app.On("QuoteShipping", mock.AnythingOfType("*context.valueCtx"), quoteRequest).Return(quote, nil).Once()
+
+		response, err := s.grpcClient.QuoteShipping(context.Background(), request)
+
+		s.NoError(err)
+		s.True(proto.Equal(&protog.QuoteShippingResponse{
+			Data: &protog.Quote{
+				RatesVersion:   "2022-10-01",
+				Zone:           "domestic",
+				BillableWeight: 2580,
+				Options: []*protog.ShippingOption{
+					{Method: "standard", Name: "Standard", Carrier: "local", Price: 899},
+					{Method: "express", Name: "Express", Carrier: "local", Price: 1999},
+				},
+			},
+		}, response), response.String())
+	})
+}
+
+func (s *ShippingResolverSuite) Test_CreateShipment() {
+	shipmentRequest := domain.ShipmentRequest{
+		OrderID:    7,
+		CustomerID: "42",
+		Method:     "standard",
+		Destination: domain.Address{
+			Recipient:  "Jane Doe",
+			Line1:      "350 5th Ave",
+			City:       "New York",
+			Region:     "NY",
+			PostalCode: "10118",
+			Country:    "US",
+		},
+		Items: []domain.ItemRequest{{ProductID: 1, Quantity: 2}},
+	}
+
+	request := &protog.CreateShipmentRequest{
+		OrderId:    7,
+		CustomerId: "42",
+		Method:     "standard",
+		Destination: &protog.Address{
+			Recipient:  "Jane Doe",
+			Line1:      "350 5th Ave",
+			City:       "New York",
+			Region:     "NY",
+			PostalCode: "10118",
+			Country:    "US",
+		},
+		Items: []*protog.ItemRequest{{ProductId: 1, Quantity: 2}},
+	}
+
+	s.Run("Should return an invalid argument error in case the address is invalid", func() {
+		s.app.On("CreateShipment", mock.AnythingOfType("*context.valueCtx"), shipmentRequest).Return(domain.Shipment{}, domain.ErrInvalidAddress).Once()
+
+		_, err := s.grpcClient.CreateShipment(context.Background(), request)
+
+		s.Equal(status.Error(codes.InvalidArgument, domain.ErrInvalidAddress.Error()), err)
+	})
+
+	s.Run("Should return an unavailable error in case the carrier isn't set up", func() {
+		s.app.On("CreateShipment", mock.AnythingOfType("*context.valueCtx"), shipmentRequest).Return(domain.Shipment{}, domain.ErrCarrierUnavailable).Once()
+
+		_, err := s.grpcClient.CreateShipment(context.Background(), request)
+
+		s.Equal(status.Error(codes.Unavailable, domain.ErrCarrierUnavailable.Error()), err)
+	})
+
+	s.Run("Should return an internal error in case of an unknown failure", func() {
+		s.app.On("CreateShipment", mock.AnythingOfType("*context.valueCtx"), shipmentRequest).Return(domain.Shipment{}, errors.New("connection refused")).Once()
+
+		_, err := s.grpcClient.CreateShipment(context.Background(), request)
+
+		s.Equal(status.Error(codes.Internal, "Internal server error"), err)
+	})
+
+	s.Run("Should return the shipment with its tracking events", func() {
+		createdAt := time.Date(2022, 10, 3, 9, 30, 0, 0, time.UTC)
+		shipment := domain.Shipment{
+			ID:             3,
+			OrderID:        7,
+			CustomerID:     "42",
+			Method:         "standard",
+			Carrier:        "local",
+			TrackingNumber: "FK473124829ZZ",
+			Destination:    shipmentRequest.Destination,
+			Items: []domain.Item{
+				{ProductID: 1, Quantity: 2, Weight: 1290, Dimensions: domain.Dimensions{Length: 304, Width: 212, Height: 16}},
+			},
+			BillableWeight: 2580,
+			Price:          899,
+			RatesVersion:   "2022-10-01",
+			Status:         domain.ShipmentStatusLabelCreated,
+			TrackingEvents: []domain.TrackingEvent{
+				{Status: domain.ShipmentStatusLabelCreated, Description: "Shipping label created", At: createdAt},
+			},
+			Version:   1,
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		}
+
+		s.app.On("CreateShipment", mock.AnythingOfType("*context.valueCtx"), shipmentRequest).Return(shipment, nil).Once()
+
+		response, err := s.grpcClient.CreateShipment(context.Background(), request)
+
+		s.NoError(err)
+		s.True(proto.Equal(&protog.CreateShipmentResponse{
+			Data: &protog.Shipment{
+				Id:             3,
+				OrderId:        7,
+				CustomerId:     "42",
+				Method:         "standard",
+				Carrier:        "local",
+				TrackingNumber: "FK473124829ZZ",
+				Destination:    request.Destination,
+				Items: []*protog.Item{
+					{ProductId: 1, Quantity: 2, Weight: 1290, Length: 304, Width: 212, Height: 16},
+				},
+				BillableWeight: 2580,
+				Price:          899,
+				RatesVersion:   "2022-10-01",
+				Status:         "label_created",
+				TrackingEvents: []*protog.TrackingEvent{
+					{Status: "label_created", Description: "Shipping label created", At: timestamppb.New(createdAt)},
+				},
+				Version:   1,
+				CreatedAt: timestamppb.New(createdAt),
+				UpdatedAt: timestamppb.New(createdAt),
+			},
+		}, response), response.String())
+	})
+}
+
+func (s *ShippingResolverSuite) Test_RecordTrackingEvent() {
+	at := time.Date(2022, 10, 4, 6, 15, 0, 0, time.UTC)
+
+	s.Run("Should return a failed precondition error in case the status can't be reached", func() {
+		update := domain.TrackingUpdate{
+			TrackingNumber: "FK473124829ZZ",
+			Event:          domain.TrackingEvent{Status: domain.ShipmentStatusDelivered},
+		}
+
+		s.app.On("RecordTrackingEvent", mock.AnythingOfType("*context.valueCtx"), update).Return(domain.Shipment{}, domain.ErrInvalidTransition).Once()
+
+		_, err := s.grpcClient.RecordTrackingEvent(context.Background(), &protog.RecordTrackingEventRequest{
+			TrackingNumber: "FK473124829ZZ",
+			Event:          &protog.TrackingEvent{Status: "delivered"},
+		})
+
+		s.Equal(status.Error(codes.FailedPrecondition, domain.ErrInvalidTransition.Error()), err)
+	})
+
+	s.Run("Should return an aborted error in case the shipment kept changing", func() {
+		update := domain.TrackingUpdate{
+			TrackingNumber: "FK473124829ZZ",
+			Event:          domain.TrackingEvent{Status: domain.ShipmentStatusInTransit, At: at},
+		}
+
+		s.app.On("RecordTrackingEvent", mock.AnythingOfType("*context.valueCtx"), update).Return(domain.Shipment{}, domain.ErrShipmentVersionConflict).Once()
+
+		_, err := s.grpcClient.RecordTrackingEvent(context.Background(), &protog.RecordTrackingEventRequest{
+			TrackingNumber: "FK473124829ZZ",
+			Event:          &protog.TrackingEvent{Status: "in_transit", At: timestamppb.New(at)},
+		})
+
+		s.Equal(status.Error(codes.Aborted, domain.ErrShipmentVersionConflict.Error()), err)
+	})
+
+	s.Run("Should return the shipment with the event recorded", func() {
+		update := domain.TrackingUpdate{
+			TrackingNumber: "FK473124829ZZ",
+			Event: domain.TrackingEvent{
+				Status:      domain.ShipmentStatusInTransit,
+				Location:    "Newark, NJ",
+				Description: "Arrived at the sorting facility",
+				At:          at,
+			},
+		}
+
+		shipment := domain.Shipment{
+			ID:             3,
+			TrackingNumber: "FK473124829ZZ",
+			Status:         domain.ShipmentStatusInTransit,
+			TrackingEvents: []domain.TrackingEvent{update.Event},
+			Version:        2,
+		}
+
+		s.app.On("RecordTrackingEvent", mock.AnythingOfType("*context.valueCtx"), update).Return(shipment, nil).Once()
+
+		response, err := s.grpcClient.RecordTrackingEvent(context.Background(), &protog.RecordTrackingEventRequest{
+			TrackingNumber: "FK473124829ZZ",
+			Event: &protog.TrackingEvent{
+				Status:      "in_transit",
+				Location:    "Newark, NJ",
+				Description: "Arrived at the sorting facility",
+				At:          timestamppb.New(at),
+			},
+		})
+
+		s.NoError(err)
+		s.Equal("in_transit", response.Data.Status)
+		s.Equal(int32(2), response.Data.Version)
+		s.Len(response.Data.TrackingEvents, 1)
+	})
+}
+
+func (s *ShippingResolverSuite) Test_CancelShipment() {
+	s.Run("Should return a not found error in case the shipment doesn't exist", func() {
+		s.app.On("CancelShipment", mock.AnythingOfType("*context.valueCtx"), 3).Return(domain.Shipment{}, domain.ErrShipmentNotFound).Once()
+
+		_, err := s.grpcClient.CancelShipment(context.Background(), &protog.CancelShipmentRequest{Id: 3})
+
+		s.Equal(status.Error(codes.NotFound, domain.ErrShipmentNotFound.Error()), err)
+	})
+
+	s.Run("Should return a failed precondition error in case the shipment was picked up", func() {
+		s.app.On("CancelShipment", mock.AnythingOfType("*context.valueCtx"), 3).Return(domain.Shipment{}, domain.ErrInvalidTransition).Once()
+
+		_, err := s.grpcClient.CancelShipment(context.Background(), &protog.CancelShipmentRequest{Id: 3})
+
+		s.Equal(status.Error(codes.FailedPrecondition, domain.ErrInvalidTransition.Error()), err)
+	})
+}
+
+func TestShippingResolverSuite(t *testing.T) {
+	suite.Run(t, new(ShippingResolverSuite))
+}
+
+func makeGrpcClientAndServer(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	tracerM trace.Tracer,
+	appM domain.Application,
+) (protog.ShippingServiceClient, *gGRPC.ClientConn) {
+	listener := makeGrpcServer(ctx, loggerM, tracerM, appM)
+	grpcClient, grpcConnection := makeGrpcClient(ctx, loggerM, listener)
+
+	return grpcClient, grpcConnection
+}
+
+func makeGrpcClient(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	listener *bufconn.Listener,
+) (protog.ShippingServiceClient, *gGRPC.ClientConn) {
+	shippingServiceGRPCClient := grpc.MustNewClient(grpc.ClientInput{
+		Logger: loggerM,
+		AdditionalDialOptions: []gGRPC.DialOption{
+			gGRPC.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+				return listener.Dial()
+			}),
+		},
+	})
+
+	shippingServiceConn := shippingServiceGRPCClient.MustConnect(ctx)
+
+	grpcClient := protog.NewShippingServiceClient(shippingServiceConn)
+	return grpcClient, shippingServiceConn
+}
+
+func makeGrpcServer(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	tracerM trace.Tracer,
+	appM domain.Application,
+) *bufconn.Listener {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.MustNewServer(grpc.ServerInput{
+		Port:   1234,
+		Logger: loggerM,
+		Registrator: func(server gGRPC.ServiceRegistrar) {
+			protog.RegisterShippingServiceServer(server, &ShippingResolver{
+				Logger: loggerM,
+				Tracer: tracerM,
+				App:    appM,
+			})
+		},
+		Listener: listener,
+	})
+
+	go func() {
+		if err := grpcServer.Run(ctx); err != nil {
+			panic(err)
+		}
+	}()
+
+	return listener
+}
@@ -0,0 +1,9 @@
+package rmq_protobuf
+
+// Exchange is the topic exchange the ShipmentEvents go through
+const Exchange = "shipping"
+
+// EventRoutingKey routes the ShipmentEvents of a status, e.g. shipping.delivered
+func EventRoutingKey(status string) string {
+	return "shipping." + status
+}
@@ -0,0 +1,167 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/tax/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// FileRatesRepositoryInput is the input (aka dependencies) to create a FileRatesRepository.
+type FileRatesRepositoryInput struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	// Path is the JSON file holding the RateTable, as in rates/rates.json.
+	Path string
+}
+
+// rateTableFile is the layout of the rates file, the rates being percentages as in "8.875".
+//
+//	{
+//	  "version": "2022-10-01",
+//	  "jurisdictions": [
+//	    {"country": "DE", "classes": {"standard": [{"name": "VAT", "rate": "19"}], "reduced": [{"name": "VAT", "rate": "7"}]}}
+//	  ]
+//	}
+type rateTableFile struct {
+	Version       string `json:"version"`
+	Jurisdictions []struct {
+		Country string `json:"country"`
+		Region  string `json:"region"`
+		Classes map[string][]struct {
+			Name string `json:"name"`
+			Rate string `json:"rate"`
+		} `json:"classes"`
+	} `json:"jurisdictions"`
+}
+
+// FileRatesRepository reads the RateTable from a JSON file.
+// The file is read again whenever it changes, a change that can't be read keeps the previous RateTable in use.
+type FileRatesRepository struct {
+	in FileRatesRepositoryInput
+
+	// mu guards table and modTime, the modification time of the file table was read from
+	mu      sync.Mutex
+	table   domain.RateTable
+	modTime time.Time
+}
+
+// NewFileRatesRepository creates a new FileRatesRepository, failing when the file holds no valid RateTable.
+func NewFileRatesRepository(in FileRatesRepositoryInput) (*FileRatesRepository, error) {
+	if in.Path == "" {
+		return nil, errors.New("missing required dependency: Path")
+	}
+
+	info, err := os.Stat(in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	table, err := readRateTable(in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileRatesRepository{in: in, table: table, modTime: info.ModTime()}, nil
+}
+
+// MustNewFileRatesRepository creates a new FileRatesRepository.
+// It panics if any error is found.
+func MustNewFileRatesRepository(in FileRatesRepositoryInput) *FileRatesRepository {
+	repository, err := NewFileRatesRepository(in)
+	if err != nil {
+		panic(err)
+	}
+
+	return repository
+}
+
+// Get fetches the RateTable, reading the file again first when it changed since it was last read.
+func (r *FileRatesRepository) Get(ctx context.Context) (domain.RateTable, error) {
+	_, span := r.in.Tracer.Start(ctx, "repository.Get")
+	defer span.End()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	info, err := os.Stat(r.in.Path)
+	if err != nil {
+		r.in.Logger.Warn("failed to check the rates file, keeping the current rates", zap.String("version", r.table.Version), zap.Error(err))
+		return r.table, nil
+	}
+
+	if info.ModTime().Equal(r.modTime) {
+		return r.table, nil
+	}
+
+	table, err := readRateTable(r.in.Path)
+	if err != nil {
+		r.in.Logger.Error("failed to read the changed rates file, keeping the current rates", zap.String("version", r.table.Version), zap.Error(err))
+		return r.table, nil
+	}
+
+	r.in.Logger.Info("read the changed rates file", zap.String("previousVersion", r.table.Version), zap.String("version", table.Version))
+
+	r.table = table
+	r.modTime = info.ModTime()
+
+	return r.table, nil
+}
+
+// readRateTable reads and validates the RateTable of a file
+func readRateTable(path string) (domain.RateTable, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return domain.RateTable{}, err
+	}
+	defer file.Close()
+
+	var content rateTableFile
+
+	decoder := json.NewDecoder(file)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&content); err != nil {
+		return domain.RateTable{}, fmt.Errorf("%w: %s", domain.ErrInvalidRateTable, err)
+	}
+
+	table := domain.RateTable{
+		Version:       content.Version,
+		Jurisdictions: make([]domain.JurisdictionRates, 0, len(content.Jurisdictions)),
+	}
+
+	for _, jurisdiction := range content.Jurisdictions {
+		classes := make(map[string][]domain.Rate, len(jurisdiction.Classes))
+		for class, rates := range jurisdiction.Classes {
+			classes[class] = make([]domain.Rate, 0, len(rates))
+
+			for _, rate := range rates {
+				value, err := domain.ParseRate(rate.Rate)
+				if err != nil {
+					return domain.RateTable{}, fmt.Errorf("%w: rate %q of %s", domain.ErrInvalidRateTable, rate.Rate, rate.Name)
+				}
+
+				classes[class] = append(classes[class], domain.Rate{Name: rate.Name, Rate: value})
+			}
+		}
+
+		table.Jurisdictions = append(table.Jurisdictions, domain.JurisdictionRates{
+			Jurisdiction: domain.Jurisdiction{Country: jurisdiction.Country, Region: jurisdiction.Region}.Normalize(),
+			Classes:      classes,
+		})
+	}
+
+	if err := table.Validate(); err != nil {
+		return domain.RateTable{}, err
+	}
+
+	return table, nil
+}
@@ -0,0 +1,155 @@
+package repositories
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lucasmls/ecommerce/services/tax/domain"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const rates = `{
+  "version": "2022-10-01",
+  "jurisdictions": [
+    {"country": "us", "region": "ny", "classes": {"standard": [{"name": "State", "rate": "4"}, {"name": "City", "rate": "4.875"}], "groceries": []}},
+    {"country": "DE", "classes": {"standard": [{"name": "VAT", "rate": "19"}]}}
+  ]
+}`
+
+type FileRatesRepositorySuite struct {
+	suite.Suite
+
+	path string
+}
+
+func (s *FileRatesRepositorySuite) SetupTest() {
+	s.path = filepath.Join(s.T().TempDir(), "rates.json")
+}
+
+// write replaces the content of the rates file, moving its modification time forward so the change is noticed
+func (s *FileRatesRepositorySuite) write(content string, modTime time.Time) {
+	s.Require().NoError(os.WriteFile(s.path, []byte(content), 0o644))
+	s.Require().NoError(os.Chtimes(s.path, modTime, modTime))
+}
+
+func (s *FileRatesRepositorySuite) repository() (*FileRatesRepository, error) {
+	return NewFileRatesRepository(FileRatesRepositoryInput{
+		Logger: zap.NewNop(),
+		Tracer: trace.NewNoopTracerProvider().Tracer(""),
+		Path:   s.path,
+	})
+}
+
+func (s *FileRatesRepositorySuite) Test_NewFileRatesRepository() {
+	s.Run("Should fail in case the file is missing", func() {
+		s.SetupTest()
+
+		_, err := s.repository()
+
+		s.ErrorIs(err, os.ErrNotExist)
+	})
+
+	for name, content := range map[string]string{
+		"isn't JSON":              "version: 1",
+		"has unknown fields":      `{"version": "1", "jurisdictions": [{"country": "DE", "rates": {}}]}`,
+		"has no version":          `{"jurisdictions": []}`,
+		"has an invalid rate":     `{"version": "1", "jurisdictions": [{"country": "DE", "classes": {"standard": [{"name": "VAT", "rate": "19,5"}]}}]}`,
+		"repeats a jurisdiction":  `{"version": "1", "jurisdictions": [{"country": "DE", "classes": {}}, {"country": "de", "classes": {}}]}`,
+		"has an invalid class":    `{"version": "1", "jurisdictions": [{"country": "DE", "classes": {"Standard": []}}]}`,
+		"has an invalid country":  `{"version": "1", "jurisdictions": [{"country": "DEU", "classes": {}}]}`,
+		"levies more than 100%":   `{"version": "1", "jurisdictions": [{"country": "DE", "classes": {"standard": [{"name": "A", "rate": "60"}, {"name": "B", "rate": "50"}]}}]}`,
+		"has a rate without name": `{"version": "1", "jurisdictions": [{"country": "DE", "classes": {"standard": [{"name": "", "rate": "19"}]}}]}`,
+	} {
+		s.Run("Should fail in case the file "+name, func() {
+			s.SetupTest()
+			s.write(content, time.Now())
+
+			_, err := s.repository()
+
+			s.ErrorIs(err, domain.ErrInvalidRateTable)
+		})
+	}
+}
+
+func (s *FileRatesRepositorySuite) Test_Get() {
+	s.Run("Should parse the percentages and normalize the jurisdictions", func() {
+		s.SetupTest()
+		s.write(rates, time.Now())
+
+		repository, err := s.repository()
+		s.Require().NoError(err)
+
+		got, err := repository.Get(context.Background())
+
+		s.NoError(err)
+		s.Equal(domain.RateTable{
+			Version: "2022-10-01",
+			Jurisdictions: []domain.JurisdictionRates{
+				{
+					Jurisdiction: domain.Jurisdiction{Country: "US", Region: "NY"},
+					Classes: map[string][]domain.Rate{
+						"standard":  {{Name: "State", Rate: 40000}, {Name: "City", Rate: 48750}},
+						"groceries": {},
+					},
+				},
+				{
+					Jurisdiction: domain.Jurisdiction{Country: "DE"},
+					Classes:      map[string][]domain.Rate{"standard": {{Name: "VAT", Rate: 190000}}},
+				},
+			},
+		}, got)
+	})
+
+	s.Run("Should read the file again once it changes, keeping the previous rates while it's invalid", func() {
+		s.SetupTest()
+
+		modTime := time.Now().Add(-time.Hour)
+		s.write(rates, modTime)
+
+		repository, err := s.repository()
+		s.Require().NoError(err)
+
+		s.write(`{"version": "2023-01-01", "jurisdictions": [{"country": "DE", "classes": {"standard": [{"name": "VAT", "rate": "`, modTime.Add(time.Minute))
+
+		got, err := repository.Get(context.Background())
+		s.NoError(err)
+		s.Equal("2022-10-01", got.Version)
+
+		s.write(`{"version": "2023-01-01", "jurisdictions": [{"country": "DE", "classes": {"standard": [{"name": "VAT", "rate": "16"}]}}]}`, modTime.Add(2*time.Minute))
+
+		got, err = repository.Get(context.Background())
+		s.NoError(err)
+		s.Equal(domain.RateTable{
+			Version: "2023-01-01",
+			Jurisdictions: []domain.JurisdictionRates{
+				{
+					Jurisdiction: domain.Jurisdiction{Country: "DE"},
+					Classes:      map[string][]domain.Rate{"standard": {{Name: "VAT", Rate: 160000}}},
+				},
+			},
+		}, got)
+	})
+
+	s.Run("Should keep the rates in case the file is removed", func() {
+		s.SetupTest()
+		s.write(rates, time.Now())
+
+		repository, err := s.repository()
+		s.Require().NoError(err)
+		s.Require().NoError(os.Remove(s.path))
+
+		got, err := repository.Get(context.Background())
+
+		s.NoError(err)
+		s.Equal("2022-10-01", got.Version)
+	})
+}
+
+func TestFileRatesRepositorySuite(t *testing.T) {
+	suite.Run(t, new(FileRatesRepositorySuite))
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"github.com/lucasmls/ecommerce/services/tax/domain"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// application holds all dependencies the Application needs to work
+type application struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+
+	RatesRepository domain.RatesRepository
+}
+
+// NewApplication creates a new Application instance
+func NewApplication(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	ratesRepository domain.RatesRepository,
+) application {
+	return application{
+		Logger:          logger,
+		Tracer:          tracer,
+		RatesRepository: ratesRepository,
+	}
+}
+
+// MustNewApplication creates a new Application instance
+// It panics if any error is found
+func MustNewApplication(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	ratesRepository domain.RatesRepository,
+) application {
+	app := NewApplication(logger, tracer, ratesRepository)
+	return app
+}
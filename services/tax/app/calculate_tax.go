@@ -0,0 +1,28 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/tax/domain"
+	"go.uber.org/zap"
+)
+
+func (a application) CalculateTax(ctx context.Context, request domain.TaxRequest) (domain.TaxCalculation, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.CalculateTax")
+	defer span.End()
+
+	request.Jurisdiction = request.Jurisdiction.Normalize()
+
+	a.Logger.Info("calculating the taxes", zap.Stringer("jurisdiction", request.Jurisdiction), zap.Int("lines", len(request.Lines)))
+
+	if err := request.Validate(); err != nil {
+		return domain.TaxCalculation{}, err
+	}
+
+	table, err := a.RatesRepository.Get(ctx)
+	if err != nil {
+		return domain.TaxCalculation{}, err
+	}
+
+	return domain.NewTaxCalculation(request, table)
+}
@@ -0,0 +1,228 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/tax/domain"
+	"github.com/lucasmls/ecommerce/services/tax/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type CalculateTaxSuite struct {
+	suite.Suite
+
+	ratesRepo *mocks.RatesRepository
+	app       domain.Application
+}
+
+func (s *CalculateTaxSuite) SetupTest() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.ratesRepo = &mocks.RatesRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.ratesRepo)
+}
+
+// stored makes the repository return the rateTable
+func (s *CalculateTaxSuite) stored() {
+	s.ratesRepo.On("Get", mock.AnythingOfType("*context.valueCtx")).Return(rateTable(), nil).Once()
+}
+
+// request taxes the lines delivered to a jurisdiction, their prices excluding the taxes
+func request(country string, region string, lines ...domain.LineRequest) domain.TaxRequest {
+	return domain.TaxRequest{
+		Jurisdiction: domain.Jurisdiction{Country: country, Region: region},
+		Lines:        lines,
+	}
+}
+
+func (s *CalculateTaxSuite) Test_CalculateTax() {
+	s.Run("Should fail without reaching the repository in case the request is invalid", func() {
+		s.SetupTest()
+
+		for _, tc := range []struct {
+			name    string
+			request domain.TaxRequest
+			err     error
+		}{
+			{"no lines", request("US", "NY"), domain.ErrInvalidTaxRequest},
+			{"no quantity", request("US", "NY", domain.LineRequest{ProductID: 1, UnitPrice: 1000}), domain.ErrInvalidTaxRequest},
+			{"discount above the line", request("US", "NY", domain.LineRequest{ProductID: 1, Quantity: 1, UnitPrice: 1000, Discount: 1001}), domain.ErrInvalidTaxRequest},
+			{"line too expensive", request("US", "NY", domain.LineRequest{ProductID: 1, Quantity: 2, UnitPrice: domain.MaxLineAmount}), domain.ErrInvalidTaxRequest},
+			{"tax class", request("US", "NY", domain.LineRequest{ProductID: 1, TaxClass: "Groceries", Quantity: 1}), domain.ErrInvalidTaxClass},
+			{"country", request("USA", "", domain.LineRequest{ProductID: 1, Quantity: 1}), domain.ErrInvalidJurisdiction},
+			{"region", request("US", "N-Y", domain.LineRequest{ProductID: 1, Quantity: 1}), domain.ErrInvalidJurisdiction},
+		} {
+			_, err := s.app.CalculateTax(context.Background(), tc.request)
+
+			s.Equal(tc.err, err, tc.name)
+		}
+
+		s.ratesRepo.AssertNotCalled(s.T(), "Get", mock.Anything)
+	})
+
+	s.Run("Should fail when repository.Get returns any error", func() {
+		s.SetupTest()
+
+		repositoryErr := errors.New("permission denied")
+		s.ratesRepo.On("Get", mock.AnythingOfType("*context.valueCtx")).Return(domain.RateTable{}, repositoryErr).Once()
+
+		_, err := s.app.CalculateTax(context.Background(), request("US", "NY", domain.LineRequest{ProductID: 1, Quantity: 1, UnitPrice: 1000}))
+
+		s.Equal(repositoryErr, err)
+	})
+
+	s.Run("Should fail in case the jurisdiction has no rates", func() {
+		s.SetupTest()
+		s.stored()
+
+		_, err := s.app.CalculateTax(context.Background(), request("JP", "", domain.LineRequest{ProductID: 1, Quantity: 1, UnitPrice: 1000}))
+
+		s.Equal(domain.ErrJurisdictionNotFound, err)
+	})
+
+	s.Run("Should fail in case the tax class has no rates in the jurisdiction", func() {
+		s.SetupTest()
+		s.stored()
+
+		_, err := s.app.CalculateTax(context.Background(), request("DE", "", domain.LineRequest{ProductID: 1, TaxClass: "groceries", Quantity: 1, UnitPrice: 1000}))
+
+		s.Equal(domain.ErrTaxClassNotFound, err)
+	})
+
+	s.Run("Should add every rate of the region to the prices, rounding the tax of each line once", func() {
+		s.SetupTest()
+		s.stored()
+
+		got, err := s.app.CalculateTax(context.Background(), request("us", "ny",
+			domain.LineRequest{ProductID: 1, Quantity: 3, UnitPrice: 1999},
+			domain.LineRequest{ProductID: 2, TaxClass: "groceries", Quantity: 1, UnitPrice: 1000, Discount: 100},
+		))
+
+		s.NoError(err)
+		s.Equal(domain.TaxCalculation{
+			Jurisdiction: domain.Jurisdiction{Country: "US", Region: "NY"},
+			RatesVersion: "2022-10-01",
+			Lines: []domain.TaxLine{
+				{
+					ProductID: 1,
+					TaxClass:  "standard",
+					Quantity:  3,
+					Net:       5997,
+					// 5997 * 8.875% = 532.23
+					Tax:   532,
+					Gross: 6529,
+					Components: []domain.TaxComponent{
+						{Name: "New York State Sales Tax", Rate: 40000, Amount: 240},
+						{Name: "New York City Sales Tax", Rate: 45000, Amount: 270},
+						{Name: "Metropolitan Commuter Transportation District", Rate: 3750, Amount: 22},
+					},
+				},
+				{ProductID: 2, TaxClass: "groceries", Quantity: 1, Net: 900, Tax: 0, Gross: 900, Components: []domain.TaxComponent{}},
+			},
+			Net:   6897,
+			Tax:   532,
+			Gross: 7429,
+			Summaries: []domain.TaxSummary{
+				{Name: "New York State Sales Tax", Rate: 40000, Amount: 240},
+				{Name: "New York City Sales Tax", Rate: 45000, Amount: 270},
+				{Name: "Metropolitan Commuter Transportation District", Rate: 3750, Amount: 22},
+			},
+		}, got)
+	})
+
+	s.Run("Should take the taxes out of prices including them", func() {
+		s.SetupTest()
+		s.stored()
+
+		req := request("DE", "",
+			domain.LineRequest{ProductID: 1, Quantity: 2, UnitPrice: 1190},
+			domain.LineRequest{ProductID: 2, TaxClass: "reduced", Quantity: 1, UnitPrice: 999},
+		)
+		req.PricesIncludeTax = true
+
+		got, err := s.app.CalculateTax(context.Background(), req)
+
+		s.NoError(err)
+		s.Equal(domain.TaxCalculation{
+			Jurisdiction:     domain.Jurisdiction{Country: "DE"},
+			PricesIncludeTax: true,
+			RatesVersion:     "2022-10-01",
+			Lines: []domain.TaxLine{
+				{
+					ProductID:  1,
+					TaxClass:   "standard",
+					Quantity:   2,
+					Net:        2000,
+					Tax:        380,
+					Gross:      2380,
+					Components: []domain.TaxComponent{{Name: "Umsatzsteuer", Rate: 190000, Amount: 380}},
+				},
+				{
+					ProductID: 2,
+					TaxClass:  "reduced",
+					Quantity:  1,
+					// 999 / 1.07 = 933.64
+					Net:        934,
+					Tax:        65,
+					Gross:      999,
+					Components: []domain.TaxComponent{{Name: "Umsatzsteuer", Rate: 70000, Amount: 65}},
+				},
+			},
+			Net:   2934,
+			Tax:   445,
+			Gross: 3379,
+			Summaries: []domain.TaxSummary{
+				{Name: "Umsatzsteuer", Rate: 190000, Amount: 380},
+				{Name: "Umsatzsteuer", Rate: 70000, Amount: 65},
+			},
+		}, got)
+	})
+
+	s.Run("Should round the halves of a minor unit up", func() {
+		s.SetupTest()
+		s.stored()
+
+		// 50 * 19% = 9.5
+		got, err := s.app.CalculateTax(context.Background(), request("DE", "", domain.LineRequest{ProductID: 1, Quantity: 1, UnitPrice: 50}))
+
+		s.NoError(err)
+		s.Equal(10, got.Tax)
+	})
+
+	s.Run("Should give the minor units left over by the split of a line to the largest remainders", func() {
+		s.SetupTest()
+		s.stored()
+
+		// 1001 * 14.975% = 149.9 rounds to 150, split into 50.08 of GST and 99.92 of QST
+		got, err := s.app.CalculateTax(context.Background(), request("CA", "QC", domain.LineRequest{ProductID: 1, Quantity: 1, UnitPrice: 1001}))
+
+		s.NoError(err)
+		s.Equal(150, got.Tax)
+		s.Equal([]domain.TaxComponent{
+			{Name: "GST", Rate: 50000, Amount: 50},
+			{Name: "QST", Rate: 99750, Amount: 100},
+		}, got.Lines[0].Components)
+	})
+
+	s.Run("Should use the rates of the country in the regions without rates of their own", func() {
+		s.SetupTest()
+		s.stored()
+
+		got, err := s.app.CalculateTax(context.Background(), request("CA", "ON", domain.LineRequest{ProductID: 1, Quantity: 1, UnitPrice: 1000}))
+
+		s.NoError(err)
+		s.Equal(50, got.Tax)
+		s.Equal([]domain.TaxSummary{{Name: "GST", Rate: 50000, Amount: 50}}, got.Summaries)
+	})
+}
+
+func TestCalculateTaxSuite(t *testing.T) {
+	suite.Run(t, new(CalculateTaxSuite))
+}
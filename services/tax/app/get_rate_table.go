@@ -0,0 +1,16 @@
+package app
+
+import (
+	"context"
+
+	"github.com/lucasmls/ecommerce/services/tax/domain"
+)
+
+func (a application) GetRateTable(ctx context.Context) (domain.RateTable, error) {
+	ctx, span := a.Tracer.Start(ctx, "app.GetRateTable")
+	defer span.End()
+
+	a.Logger.Info("getting the rate table")
+
+	return a.RatesRepository.Get(ctx)
+}
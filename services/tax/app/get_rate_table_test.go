@@ -0,0 +1,54 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/lucasmls/ecommerce/services/tax/domain"
+	"github.com/lucasmls/ecommerce/services/tax/mocks"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type GetRateTableSuite struct {
+	suite.Suite
+
+	ratesRepo *mocks.RatesRepository
+	app       domain.Application
+}
+
+func (s *GetRateTableSuite) SetupSuite() {
+	loggerM := zap.NewNop()
+	tracerM := trace.NewNoopTracerProvider().Tracer("")
+	s.ratesRepo = &mocks.RatesRepository{}
+
+	s.app = MustNewApplication(loggerM, tracerM, s.ratesRepo)
+}
+
+func (s *GetRateTableSuite) Test_GetRateTable() {
+	s.Run("Should fail when repository.Get returns any error", func() {
+		repositoryErr := errors.New("permission denied")
+		s.ratesRepo.On("Get", mock.AnythingOfType("*context.valueCtx")).Return(domain.RateTable{}, repositoryErr).Once()
+
+		_, err := s.app.GetRateTable(context.Background())
+
+		s.Equal(repositoryErr, err)
+	})
+
+	s.Run("Should return the current rate table", func() {
+		s.ratesRepo.On("Get", mock.AnythingOfType("*context.valueCtx")).Return(rateTable(), nil).Once()
+
+		got, err := s.app.GetRateTable(context.Background())
+
+		s.NoError(err)
+		s.Equal(rateTable(), got)
+	})
+}
+
+func TestGetRateTableSuite(t *testing.T) {
+	suite.Run(t, new(GetRateTableSuite))
+}
@@ -0,0 +1,42 @@
+package app
+
+import "github.com/lucasmls/ecommerce/services/tax/domain"
+
+// rateTable holds the sales taxes of New York City, the VAT of Germany, the GST of Canada and the GST and QST of Quebec
+func rateTable() domain.RateTable {
+	return domain.RateTable{
+		Version: "2022-10-01",
+		Jurisdictions: []domain.JurisdictionRates{
+			{
+				Jurisdiction: domain.Jurisdiction{Country: "US", Region: "NY"},
+				Classes: map[string][]domain.Rate{
+					"standard": {
+						{Name: "New York State Sales Tax", Rate: 40000},
+						{Name: "New York City Sales Tax", Rate: 45000},
+						{Name: "Metropolitan Commuter Transportation District", Rate: 3750},
+					},
+					"groceries": {},
+				},
+			},
+			{
+				Jurisdiction: domain.Jurisdiction{Country: "DE"},
+				Classes: map[string][]domain.Rate{
+					"standard": {{Name: "Umsatzsteuer", Rate: 190000}},
+					"reduced":  {{Name: "Umsatzsteuer", Rate: 70000}},
+				},
+			},
+			{
+				Jurisdiction: domain.Jurisdiction{Country: "CA"},
+				Classes: map[string][]domain.Rate{
+					"standard": {{Name: "GST", Rate: 50000}},
+				},
+			},
+			{
+				Jurisdiction: domain.Jurisdiction{Country: "CA", Region: "QC"},
+				Classes: map[string][]domain.Rate{
+					"standard": {{Name: "GST", Rate: 50000}, {Name: "QST", Rate: 99750}},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,233 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+)
+
+const (
+	// MaxTaxLines is the maximum amount of lines taxed at once
+	MaxTaxLines = 100
+	// MaxLineAmount bounds the amount of a line, in minor currency units (cents),
+	// so even taxed at MaxRate it fits the 32 bits integers the amounts are carried on
+	MaxLineAmount = 1_000_000_000
+)
+
+var ErrInvalidTaxRequest = errors.New("invalid-tax-request")
+
+// LineRequest is some units of a Product to tax
+type LineRequest struct {
+	ProductID int
+	// TaxClass is the tax class of the Product, the DefaultTaxClass when empty
+	TaxClass string
+	Quantity int
+	// UnitPrice is the price of the Product, in minor currency units (cents)
+	UnitPrice int
+	// Discount is taken off the whole line before it's taxed, in minor currency units (cents)
+	Discount int
+}
+
+// amount is what the line costs before its taxes, or including them when the prices include the taxes
+func (l LineRequest) amount() int {
+	return l.UnitPrice*l.Quantity - l.Discount
+}
+
+// TaxRequest asks for the taxes levied on some Products delivered to a Jurisdiction, e.g. the ones on a cart
+type TaxRequest struct {
+	Jurisdiction Jurisdiction
+	// PricesIncludeTax tells the prices of the lines already include their taxes, as the consumer prices do in most of Europe
+	PricesIncludeTax bool
+	Lines            []LineRequest
+}
+
+// Validate checks the TaxRequest is valid, its Jurisdiction must be normalized
+func (r TaxRequest) Validate() error {
+	if err := r.Jurisdiction.Validate(); err != nil {
+		return err
+	}
+
+	if len(r.Lines) == 0 || len(r.Lines) > MaxTaxLines {
+		return ErrInvalidTaxRequest
+	}
+
+	for _, line := range r.Lines {
+		if line.ProductID <= 0 || line.Quantity <= 0 || line.UnitPrice < 0 || line.Discount < 0 {
+			return ErrInvalidTaxRequest
+		}
+
+		if line.UnitPrice > MaxLineAmount/line.Quantity || line.Discount > line.UnitPrice*line.Quantity {
+			return ErrInvalidTaxRequest
+		}
+
+		if line.TaxClass != "" {
+			if err := ValidateTaxClass(line.TaxClass); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// TaxComponent is the part of the tax of a line levied by one Rate
+type TaxComponent struct {
+	Name string
+	Rate int
+	// Amount is in minor currency units (cents)
+	Amount int
+}
+
+// TaxLine is the tax levied on some units of a Product, all amounts in minor currency units (cents)
+type TaxLine struct {
+	ProductID int
+	// TaxClass is the class the line was taxed by, the DefaultTaxClass when the request had none
+	TaxClass string
+	Quantity int
+	// Net is the amount of the line without its taxes
+	Net int
+	Tax int
+	// Gross is the amount of the line with its taxes, Net plus Tax
+	Gross      int
+	Components []TaxComponent
+}
+
+// TaxSummary sums up what a Rate levied over every line
+type TaxSummary struct {
+	Name string
+	Rate int
+	// Amount is in minor currency units (cents)
+	Amount int
+}
+
+// TaxCalculation is the tax levied on some Products delivered to a Jurisdiction, all amounts in minor currency units (cents)
+type TaxCalculation struct {
+	Jurisdiction     Jurisdiction
+	PricesIncludeTax bool
+	// RatesVersion is the Version of the RateTable the taxes were calculated with
+	RatesVersion string
+	Lines        []TaxLine
+	Net          int
+	Tax          int
+	Gross        int
+	// Summaries sum up each Rate over every line, in the order they're first levied
+	Summaries []TaxSummary
+}
+
+// NewTaxCalculation taxes the lines of a request with the Rates of its Jurisdiction.
+//
+// The tax of each line is rounded once, half up, to a minor unit, then split among its Rates,
+// so the totals are the sums of the lines and the components of a line always add up to its tax.
+func NewTaxCalculation(request TaxRequest, table RateTable) (TaxCalculation, error) {
+	calculation := TaxCalculation{
+		Jurisdiction:     request.Jurisdiction,
+		PricesIncludeTax: request.PricesIncludeTax,
+		RatesVersion:     table.Version,
+		Lines:            make([]TaxLine, 0, len(request.Lines)),
+		Summaries:        []TaxSummary{},
+	}
+
+	summaries := map[Rate]int{}
+
+	for _, lineRequest := range request.Lines {
+		class := NormalizeTaxClass(lineRequest.TaxClass)
+
+		rates, err := table.Rates(request.Jurisdiction, class)
+		if err != nil {
+			return TaxCalculation{}, err
+		}
+
+		line := taxLine(lineRequest, class, rates, request.PricesIncludeTax)
+
+		for _, component := range line.Components {
+			rate := Rate{Name: component.Name, Rate: component.Rate}
+
+			index, ok := summaries[rate]
+			if !ok {
+				index = len(calculation.Summaries)
+				summaries[rate] = index
+				calculation.Summaries = append(calculation.Summaries, TaxSummary{Name: rate.Name, Rate: rate.Rate})
+			}
+
+			calculation.Summaries[index].Amount += component.Amount
+		}
+
+		calculation.Lines = append(calculation.Lines, line)
+		calculation.Net += line.Net
+		calculation.Tax += line.Tax
+		calculation.Gross += line.Gross
+	}
+
+	return calculation, nil
+}
+
+// taxLine taxes a line with the Rates levied on its class
+func taxLine(request LineRequest, class string, rates []Rate, pricesIncludeTax bool) TaxLine {
+	total := 0
+	for _, rate := range rates {
+		total += rate.Rate
+	}
+
+	line := TaxLine{
+		ProductID: request.ProductID,
+		TaxClass:  class,
+		Quantity:  request.Quantity,
+	}
+
+	if pricesIncludeTax {
+		line.Gross = request.amount()
+		line.Net = divideRoundingHalfUp(line.Gross*RateScale, RateScale+total)
+		line.Tax = line.Gross - line.Net
+	} else {
+		line.Net = request.amount()
+		line.Tax = divideRoundingHalfUp(line.Net*total, RateScale)
+		line.Gross = line.Net + line.Tax
+	}
+
+	line.Components = splitTax(line.Tax, rates)
+
+	return line
+}
+
+// splitTax splits the tax of a line among its Rates in proportion to them. Each one gets the whole minor units of its share,
+// then the units left over go to the largest remainders, the first Rates listed on ties, so the components add up to the tax.
+func splitTax(tax int, rates []Rate) []TaxComponent {
+	total := 0
+	for _, rate := range rates {
+		total += rate.Rate
+	}
+
+	components := make([]TaxComponent, 0, len(rates))
+	remainders := make([]int, 0, len(rates))
+	left := tax
+
+	for _, rate := range rates {
+		amount, remainder := 0, 0
+		if total > 0 {
+			amount, remainder = tax*rate.Rate/total, tax*rate.Rate%total
+		}
+
+		components = append(components, TaxComponent{Name: rate.Name, Rate: rate.Rate, Amount: amount})
+		remainders = append(remainders, remainder)
+		left -= amount
+	}
+
+	order := make([]int, len(rates))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]] > remainders[order[j]]
+	})
+
+	for _, i := range order[:left] {
+		components[i].Amount++
+	}
+
+	return components
+}
+
+// divideRoundingHalfUp divides two non negative integers, rounding the halves up
+func divideRoundingHalfUp(dividend int, divisor int) int {
+	return (2*dividend + divisor) / (2 * divisor)
+}
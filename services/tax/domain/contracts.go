@@ -0,0 +1,18 @@
+package domain
+
+import "context"
+
+// Application defines boundary interfaces of the application
+// It should be called by the ports
+type Application interface {
+	// CalculateTax calculates the taxes levied on some Products delivered to a Jurisdiction
+	CalculateTax(context.Context, TaxRequest) (TaxCalculation, error)
+
+	// GetRateTable fetches the RateTable the taxes are currently calculated with
+	GetRateTable(context.Context) (RateTable, error)
+}
+
+type RatesRepository interface {
+	// Get fetches the RateTable the taxes are currently calculated with.
+	Get(context.Context) (RateTable, error)
+}
@@ -0,0 +1,220 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// RateScale is what a Rate is a fraction of, so a Rate of 190000 is 19%
+	RateScale = 1_000_000
+	// MaxRate is the highest Rate a component may have, 100%
+	MaxRate = RateScale
+
+	// DefaultTaxClass is the class of the Products registered without one
+	DefaultTaxClass = "standard"
+)
+
+var (
+	ErrInvalidRate          = errors.New("invalid-rate")
+	ErrInvalidTaxClass      = errors.New("invalid-tax-class")
+	ErrInvalidJurisdiction  = errors.New("invalid-jurisdiction")
+	ErrInvalidRateTable     = errors.New("invalid-rate-table")
+	ErrJurisdictionNotFound = errors.New("jurisdiction-not-found")
+	ErrTaxClassNotFound     = errors.New("tax-class-not-found")
+)
+
+var (
+	taxClassPattern = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+	countryPattern  = regexp.MustCompile(`^[A-Z]{2}$`)
+	regionPattern   = regexp.MustCompile(`^[A-Z0-9]{1,3}$`)
+)
+
+// NormalizeTaxClass gives the class a Product is taxed by, the Products without one being taxed by the DefaultTaxClass
+func NormalizeTaxClass(class string) string {
+	if class == "" {
+		return DefaultTaxClass
+	}
+
+	return class
+}
+
+// ValidateTaxClass checks the tax class is made of up to 32 lower case letters, digits, dashes and underscores,
+// the same ones the products service accepts
+func ValidateTaxClass(class string) error {
+	if !taxClassPattern.MatchString(class) {
+		return ErrInvalidTaxClass
+	}
+
+	return nil
+}
+
+// ParseRate parses a percentage with up to 4 decimal places, as in "8.875", into a Rate
+func ParseRate(percentage string) (int, error) {
+	whole, fraction, _ := strings.Cut(percentage, ".")
+	if whole == "" || len(fraction) > 4 || strings.ContainsAny(whole+fraction, "+-") {
+		return 0, ErrInvalidRate
+	}
+
+	wholeValue, err := strconv.Atoi(whole)
+	if err != nil {
+		return 0, ErrInvalidRate
+	}
+
+	fractionValue := 0
+	if fraction != "" {
+		fractionValue, err = strconv.Atoi(fraction + strings.Repeat("0", 4-len(fraction)))
+		if err != nil {
+			return 0, ErrInvalidRate
+		}
+	}
+
+	rate := wholeValue*10_000 + fractionValue
+	if rate > MaxRate {
+		return 0, ErrInvalidRate
+	}
+
+	return rate, nil
+}
+
+// FormatRate formats a Rate as a percentage, the opposite of ParseRate
+func FormatRate(rate int) string {
+	whole, fraction := rate/10_000, rate%10_000
+	if fraction == 0 {
+		return strconv.Itoa(whole)
+	}
+
+	return fmt.Sprintf("%d.%s", whole, strings.TrimRight(fmt.Sprintf("%04d", fraction), "0"))
+}
+
+// Jurisdiction is where the goods are delivered, which decides the taxes levied on them
+type Jurisdiction struct {
+	// Country is an ISO 3166-1 alpha-2 code, as in US
+	Country string
+	// Region is the subdivision part of an ISO 3166-2 code, as in NY for US-NY, empty when the whole country is meant
+	Region string
+}
+
+// Normalize upper cases the codes of the Jurisdiction
+func (j Jurisdiction) Normalize() Jurisdiction {
+	return Jurisdiction{
+		Country: strings.ToUpper(strings.TrimSpace(j.Country)),
+		Region:  strings.ToUpper(strings.TrimSpace(j.Region)),
+	}
+}
+
+// Validate checks the codes of the Jurisdiction, which must be normalized
+func (j Jurisdiction) Validate() error {
+	if !countryPattern.MatchString(j.Country) || (j.Region != "" && !regionPattern.MatchString(j.Region)) {
+		return ErrInvalidJurisdiction
+	}
+
+	return nil
+}
+
+// String formats the Jurisdiction as an ISO 3166-2 code
+func (j Jurisdiction) String() string {
+	if j.Region == "" {
+		return j.Country
+	}
+
+	return j.Country + "-" + j.Region
+}
+
+// Rate is one of the taxes levied on a tax class, as in a state or a city sales tax
+type Rate struct {
+	Name string
+	// Rate is a fraction of RateScale
+	Rate int
+}
+
+// JurisdictionRates are the Rates levied on each tax class in a Jurisdiction.
+// A class listed without any Rate is exempt there.
+type JurisdictionRates struct {
+	Jurisdiction Jurisdiction
+	Classes      map[string][]Rate
+}
+
+// RateTable holds the Rates of every Jurisdiction taxes are calculated for
+type RateTable struct {
+	// Version identifies the rates, every TaxCalculation tells the Version it was made with
+	Version       string
+	Jurisdictions []JurisdictionRates
+}
+
+// Validate checks the RateTable has a Version, that every Jurisdiction is listed once and that every Rate is valid
+func (t RateTable) Validate() error {
+	if strings.TrimSpace(t.Version) == "" {
+		return ErrInvalidRateTable
+	}
+
+	seen := make(map[Jurisdiction]bool, len(t.Jurisdictions))
+	for _, jurisdiction := range t.Jurisdictions {
+		if jurisdiction.Jurisdiction.Normalize() != jurisdiction.Jurisdiction || jurisdiction.Jurisdiction.Validate() != nil {
+			return ErrInvalidRateTable
+		}
+
+		if seen[jurisdiction.Jurisdiction] {
+			return ErrInvalidRateTable
+		}
+
+		seen[jurisdiction.Jurisdiction] = true
+
+		for class, rates := range jurisdiction.Classes {
+			if ValidateTaxClass(class) != nil {
+				return ErrInvalidRateTable
+			}
+
+			total := 0
+			for _, rate := range rates {
+				if strings.TrimSpace(rate.Name) == "" || rate.Rate < 0 || rate.Rate > MaxRate {
+					return ErrInvalidRateTable
+				}
+
+				total += rate.Rate
+			}
+
+			if total > MaxRate {
+				return ErrInvalidRateTable
+			}
+		}
+	}
+
+	return nil
+}
+
+// Rates finds the Rates levied on a tax class in a Jurisdiction.
+//
+// The Rates of a region replace the ones of its country, so a region lists every Rate levied there,
+// and the regions without Rates of their own get the ones of their country.
+func (t RateTable) Rates(jurisdiction Jurisdiction, class string) ([]Rate, error) {
+	var country, region *JurisdictionRates
+
+	for i, candidate := range t.Jurisdictions {
+		switch candidate.Jurisdiction {
+		case jurisdiction:
+			region = &t.Jurisdictions[i]
+		case Jurisdiction{Country: jurisdiction.Country}:
+			country = &t.Jurisdictions[i]
+		}
+	}
+
+	match := region
+	if match == nil {
+		match = country
+	}
+
+	if match == nil {
+		return nil, ErrJurisdictionNotFound
+	}
+
+	rates, ok := match.Classes[class]
+	if !ok {
+		return nil, ErrTaxClassNotFound
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,210 @@
+package grpc_port
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/lucasmls/ecommerce/services/tax/domain"
+	pb "github.com/lucasmls/ecommerce/services/tax/ports/grpc/proto"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var InternalServerError = status.Error(codes.Internal, "Internal server error")
+
+var (
+	ErrMissingLogger = errors.New("missing required dependency: Logger")
+	ErrMisingTracer  = errors.New("missing required dependency: Tracer")
+)
+
+// TaxResolver ...
+type TaxResolver struct {
+	Logger *zap.Logger
+	Tracer trace.Tracer
+	App    domain.Application
+
+	pb.UnimplementedTaxServiceServer
+}
+
+func NewTaxResolver(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) (*TaxResolver, error) {
+	if logger == nil {
+		return nil, ErrMissingLogger
+	}
+
+	if tracer == nil {
+		return nil, ErrMisingTracer
+	}
+
+	return &TaxResolver{
+		Logger: logger,
+		Tracer: tracer,
+		App:    app,
+	}, nil
+}
+
+// MustNewTaxResolver creates a new TaxResolver instance.
+// It panics if any error is found
+func MustNewTaxResolver(
+	logger *zap.Logger,
+	tracer trace.Tracer,
+	app domain.Application,
+) *TaxResolver {
+	taxResolver, err := NewTaxResolver(logger, tracer, app)
+	if err != nil {
+		panic(err)
+	}
+
+	return taxResolver
+}
+
+func (r *TaxResolver) CalculateTax(ctx context.Context, req *pb.CalculateTaxRequest) (*pb.CalculateTaxResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.CalculateTax")
+	defer span.End()
+
+	request := domain.TaxRequest{
+		Jurisdiction:     toDomainJurisdiction(req.Jurisdiction),
+		PricesIncludeTax: req.PricesIncludeTax,
+		Lines:            make([]domain.LineRequest, 0, len(req.Lines)),
+	}
+
+	for _, line := range req.Lines {
+		request.Lines = append(request.Lines, domain.LineRequest{
+			ProductID: int(line.ProductId),
+			TaxClass:  line.TaxClass,
+			Quantity:  int(line.Quantity),
+			UnitPrice: int(line.UnitPrice),
+			Discount:  int(line.Discount),
+		})
+	}
+
+	calculation, err := r.App.CalculateTax(ctx, request)
+	if err != nil {
+		return nil, r.toError(err, "failed to calculate the taxes", zap.Stringer("jurisdiction", request.Jurisdiction))
+	}
+
+	return &pb.CalculateTaxResponse{Data: toProtoTaxCalculation(calculation)}, nil
+}
+
+func (r *TaxResolver) GetRateTable(ctx context.Context, req *pb.GetRateTableRequest) (*pb.GetRateTableResponse, error) {
+	ctx, span := r.Tracer.Start(ctx, "resolver.GetRateTable")
+	defer span.End()
+
+	table, err := r.App.GetRateTable(ctx)
+	if err != nil {
+		return nil, r.toError(err, "failed to get the rate table")
+	}
+
+	return &pb.GetRateTableResponse{Data: toProtoRateTable(table)}, nil
+}
+
+// toError reports the invalid requests and the unknown jurisdictions or tax classes to the client,
+// and logs any other error, replying with an internal one
+func (r *TaxResolver) toError(err error, message string, fields ...zap.Field) error {
+	switch {
+	case errors.Is(err, domain.ErrInvalidTaxRequest),
+		errors.Is(err, domain.ErrInvalidJurisdiction),
+		errors.Is(err, domain.ErrInvalidTaxClass):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrJurisdictionNotFound),
+		errors.Is(err, domain.ErrTaxClassNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	r.Logger.Error(message, append(fields, zap.Error(err))...)
+	return InternalServerError
+}
+
+func toDomainJurisdiction(jurisdiction *pb.Jurisdiction) domain.Jurisdiction {
+	return domain.Jurisdiction{
+		Country: jurisdiction.GetCountry(),
+		Region:  jurisdiction.GetRegion(),
+	}
+}
+
+func toProtoJurisdiction(jurisdiction domain.Jurisdiction) *pb.Jurisdiction {
+	return &pb.Jurisdiction{
+		Country: jurisdiction.Country,
+		Region:  jurisdiction.Region,
+	}
+}
+
+func toProtoRateTable(table domain.RateTable) *pb.RateTable {
+	jurisdictions := make([]*pb.JurisdictionRates, 0, len(table.Jurisdictions))
+	for _, jurisdiction := range table.Jurisdictions {
+		classes := make([]*pb.ClassRates, 0, len(jurisdiction.Classes))
+		for class, rates := range jurisdiction.Classes {
+			protoRates := make([]*pb.Rate, 0, len(rates))
+			for _, rate := range rates {
+				protoRates = append(protoRates, &pb.Rate{Name: rate.Name, Rate: domain.FormatRate(rate.Rate)})
+			}
+
+			classes = append(classes, &pb.ClassRates{TaxClass: class, Rates: protoRates})
+		}
+
+		// the classes are kept on a map, they're sorted so the responses are stable
+		sort.Slice(classes, func(i, j int) bool {
+			return classes[i].TaxClass < classes[j].TaxClass
+		})
+
+		jurisdictions = append(jurisdictions, &pb.JurisdictionRates{
+			Jurisdiction: toProtoJurisdiction(jurisdiction.Jurisdiction),
+			Classes:      classes,
+		})
+	}
+
+	return &pb.RateTable{
+		Version:       table.Version,
+		Jurisdictions: jurisdictions,
+	}
+}
+
+func toProtoTaxCalculation(calculation domain.TaxCalculation) *pb.TaxCalculation {
+	lines := make([]*pb.TaxLine, 0, len(calculation.Lines))
+	for _, line := range calculation.Lines {
+		components := make([]*pb.TaxComponent, 0, len(line.Components))
+		for _, component := range line.Components {
+			components = append(components, &pb.TaxComponent{
+				Name:   component.Name,
+				Rate:   domain.FormatRate(component.Rate),
+				Amount: int32(component.Amount),
+			})
+		}
+
+		lines = append(lines, &pb.TaxLine{
+			ProductId:  int32(line.ProductID),
+			TaxClass:   line.TaxClass,
+			Quantity:   int32(line.Quantity),
+			Net:        int32(line.Net),
+			Tax:        int32(line.Tax),
+			Gross:      int32(line.Gross),
+			Components: components,
+		})
+	}
+
+	summaries := make([]*pb.TaxSummary, 0, len(calculation.Summaries))
+	for _, summary := range calculation.Summaries {
+		summaries = append(summaries, &pb.TaxSummary{
+			Name:   summary.Name,
+			Rate:   domain.FormatRate(summary.Rate),
+			Amount: int32(summary.Amount),
+		})
+	}
+
+	return &pb.TaxCalculation{
+		Jurisdiction:     toProtoJurisdiction(calculation.Jurisdiction),
+		PricesIncludeTax: calculation.PricesIncludeTax,
+		RatesVersion:     calculation.RatesVersion,
+		Lines:            lines,
+		Net:              int32(calculation.Net),
+		Tax:              int32(calculation.Tax),
+		Gross:            int32(calculation.Gross),
+		Summaries:        summaries,
+	}
+}
@@ -0,0 +1,293 @@
+package grpc_port
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/lucasmls/ecommerce/shared/grpc"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	gGRPC "google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/lucasmls/ecommerce/services/tax/domain"
+	"github.com/lucasmls/ecommerce/services/tax/mocks"
+	protog "github.com/lucasmls/ecommerce/services/tax/ports/grpc/proto"
+)
+
+type TaxResolverSuite struct {
+	suite.Suite
+
+	app    *mocks.Application
+	logger *zap.Logger
+	tracer trace.Tracer
+
+	grpcClient     protog.TaxServiceClient
+	grpcConnection *gGRPC.ClientConn
+}
+
+func (s *TaxResolverSuite) SetupSuite() {
+	s.logger = zap.NewNop()
+	s.tracer = trace.NewNoopTracerProvider().Tracer("")
+	s.app = &mocks.Application{}
+
+	grpcClient, grpcConnection := makeGrpcClientAndServer(
+		context.Background(),
+		s.logger,
+		s.tracer,
+		s.app,
+	)
+
+	s.grpcClient = grpcClient
+	s.grpcConnection = grpcConnection
+}
+
+func (s *TaxResolverSuite) TearDownSuite() {
+	err := s.grpcConnection.Close()
+	s.NoError(err)
+}
+
+func (s *TaxResolverSuite) Test_NewTaxResolver() {
+	s.Run("Should fail to instantiate the TaxResolver in case a Logger isn't provided", func() {
+		_, err := NewTaxResolver(nil, nil, nil)
+
+		s.Equal(ErrMissingLogger, err)
+	})
+
+	s.Run("Should fail to instantiate the TaxResolver in case a Tracer isn't provided", func() {
+		_, err := NewTaxResolver(s.logger, nil, nil)
+
+		s.Equal(ErrMisingTracer, err)
+	})
+
+	s.Run("Should successfully instantiate the TaxResolver", func() {
+		_, err := NewTaxResolver(s.logger, s.tracer, nil)
+
+		s.NoError(err)
+	})
+}
+
+func (s *TaxResolverSuite) Test_CalculateTax() {
+	taxRequest := domain.TaxRequest{
+		Jurisdiction: domain.Jurisdiction{Country: "US", Region: "NY"},
+		Lines: []domain.LineRequest{
+			{ProductID: 1, Quantity: 3, UnitPrice: 1999},
+			{ProductID: 2, TaxClass: "groceries", Quantity: 1, UnitPrice: 1000, Discount: 100},
+		},
+	}
+
+	request := &protog.CalculateTaxRequest{
+		Jurisdiction: &protog.Jurisdiction{Country: "US", Region: "NY"},
+		Lines: []*protog.TaxLineRequest{
+			{ProductId: 1, Quantity: 3, UnitPrice: 1999},
+			{ProductId: 2, TaxClass: "groceries", Quantity: 1, UnitPrice: 1000, Discount: 100},
+		},
+	}
+
+	s.Run("Should return an invalid argument error in case the request is invalid", func() {
+		s.app.On("CalculateTax", mock.AnythingOfType("*context.valueCtx"), taxRequest).Return(domain.TaxCalculation{}, domain.ErrInvalidTaxClass).Once()
+
+		_, err := s.grpcClient.CalculateTax(context.Background(), request)
+
+		s.Equal(status.Error(codes.InvalidArgument, domain.ErrInvalidTaxClass.Error()), err)
+	})
+
+	s.Run("Should return a not found error in case the jurisdiction has no rates", func() {
+		s.app.On("CalculateTax", mock.AnythingOfType("*context.valueCtx"), taxRequest).Return(domain.TaxCalculation{}, domain.ErrJurisdictionNotFound).Once()
+
+		_, err := s.grpcClient.CalculateTax(context.Background(), request)
+
+		s.Equal(status.Error(codes.NotFound, domain.ErrJurisdictionNotFound.Error()), err)
+	})
+
+	s.Run("Should return an internal error in case of an unknown failure", func() {
+		s.app.On("CalculateTax", mock.AnythingOfType("*context.valueCtx"), taxRequest).Return(domain.TaxCalculation{}, errors.New("permission denied")).Once()
+
+		_, err := s.grpcClient.CalculateTax(context.Background(), request)
+
+		s.Equal(status.Error(codes.Internal, "Internal server error"), err)
+	})
+
+	s.Run("Should return the taxes with their rates as percentages", func() {
+		calculation := domain.TaxCalculation{
+			Jurisdiction: domain.Jurisdiction{Country: "US", Region: "NY"},
+			RatesVersion: "2022-10-01",
+			Lines: []domain.TaxLine{
+				{
+					ProductID: 1,
+					TaxClass:  "standard",
+					Quantity:  3,
+					Net:       5997,
+					Tax:       532,
+					Gross:     6529,
+					Components: []domain.TaxComponent{
+						{Name: "State", Rate: 40000, Amount: 240},
+						{Name: "City", Rate: 48750, Amount: 292},
+					},
+				},
+				{ProductID: 2, TaxClass: "groceries", Quantity: 1, Net: 900, Gross: 900, Components: []domain.TaxComponent{}},
+			},
+			Net:   6897,
+			Tax:   532,
+			Gross: 7429,
+			Summaries: []domain.TaxSummary{
+				{Name: "State", Rate: 40000, Amount: 240},
+				{Name: "City", Rate: 48750, Amount: 292},
+			},
+		}
+
+		s.app.On("CalculateTax", mock.AnythingOfType("*context.valueCtx"), taxRequest).Return(calculation, nil).Once()
+
+		response, err := s.grpcClient.CalculateTax(context.Background(), request)
+
+		s.NoError(err)
+		s.True(proto.Equal(&protog.CalculateTaxResponse{
+			Data: &protog.TaxCalculation{
+				Jurisdiction: &protog.Jurisdiction{Country: "US", Region: "NY"},
+				RatesVersion: "2022-10-01",
+				Lines: []*protog.TaxLine{
+					{
+						ProductId: 1,
+						TaxClass:  "standard",
+						Quantity:  3,
+						Net:       5997,
+						Tax:       532,
+						Gross:     6529,
+						Components: []*protog.TaxComponent{
+							{Name: "State", Rate: "4", Amount: 240},
+							{Name: "City", Rate: "4.875", Amount: 292},
+						},
+					},
+					{ProductId: 2, TaxClass: "groceries", Quantity: 1, Net: 900, Gross: 900},
+				},
+				Net:   6897,
+				Tax:   532,
+				Gross: 7429,
+				Summaries: []*protog.TaxSummary{
+					{Name: "State", Rate: "4", Amount: 240},
+					{Name: "City", Rate: "4.875", Amount: 292},
+				},
+			},
+		}, response), response.String())
+	})
+}
+
+func (s *TaxResolverSuite) Test_GetRateTable() {
+	s.Run("Should return an internal error in case of an unknown failure", func() {
+		s.app.On("GetRateTable", mock.AnythingOfType("*context.valueCtx")).Return(domain.RateTable{}, errors.New("permission denied")).Once()
+
+		_, err := s.grpcClient.GetRateTable(context.Background(), &protog.GetRateTableRequest{})
+
+		s.Equal(status.Error(codes.Internal, "Internal server error"), err)
+	})
+
+	s.Run("Should return the rate table with its classes sorted by name", func() {
+		table := domain.RateTable{
+			Version: "2022-10-01",
+			Jurisdictions: []domain.JurisdictionRates{
+				{
+					Jurisdiction: domain.Jurisdiction{Country: "DE"},
+					Classes: map[string][]domain.Rate{
+						"standard": {{Name: "VAT", Rate: 190000}},
+						"reduced":  {{Name: "VAT", Rate: 70000}},
+						"exempt":   {},
+					},
+				},
+			},
+		}
+
+		s.app.On("GetRateTable", mock.AnythingOfType("*context.valueCtx")).Return(table, nil).Once()
+
+		response, err := s.grpcClient.GetRateTable(context.Background(), &protog.GetRateTableRequest{})
+
+		s.NoError(err)
+		s.True(proto.Equal(&protog.GetRateTableResponse{
+			Data: &protog.RateTable{
+				Version: "2022-10-01",
+				Jurisdictions: []*protog.JurisdictionRates{
+					{
+						Jurisdiction: &protog.Jurisdiction{Country: "DE"},
+						Classes: []*protog.ClassRates{
+							{TaxClass: "exempt"},
+							{TaxClass: "reduced", Rates: []*protog.Rate{{Name: "VAT", Rate: "7"}}},
+							{TaxClass: "standard", Rates: []*protog.Rate{{Name: "VAT", Rate: "19"}}},
+						},
+					},
+				},
+			},
+		}, response), response.String())
+	})
+}
+
+func TestTaxResolverSuite(t *testing.T) {
+	suite.Run(t, new(TaxResolverSuite))
+}
+
+func makeGrpcClientAndServer(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	tracerM trace.Tracer,
+	appM domain.Application,
+) (protog.TaxServiceClient, *gGRPC.ClientConn) {
+	listener := makeGrpcServer(ctx, loggerM, tracerM, appM)
+	grpcClient, grpcConnection := makeGrpcClient(ctx, loggerM, listener)
+
+	return grpcClient, grpcConnection
+}
+
+func makeGrpcClient(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	listener *bufconn.Listener,
+) (protog.TaxServiceClient, *gGRPC.ClientConn) {
+	taxServiceGRPCClient := grpc.MustNewClient(grpc.ClientInput{
+		Logger: loggerM,
+		AdditionalDialOptions: []gGRPC.DialOption{
+			gGRPC.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+				return listener.Dial()
+			}),
+		},
+	})
+
+	taxServiceConn := taxServiceGRPCClient.MustConnect(ctx)
+
+	grpcClient := protog.NewTaxServiceClient(taxServiceConn)
+	return grpcClient, taxServiceConn
+}
+
+func makeGrpcServer(
+	ctx context.Context,
+	loggerM *zap.Logger,
+	tracerM trace.Tracer,
+	appM domain.Application,
+) *bufconn.Listener {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.MustNewServer(grpc.ServerInput{
+		Port:   1234,
+		Logger: loggerM,
+		Registrator: func(server gGRPC.ServiceRegistrar) {
+			protog.RegisterTaxServiceServer(server, &TaxResolver{
+				Logger: loggerM,
+				Tracer: tracerM,
+				App:    appM,
+			})
+		},
+		Listener: listener,
+	})
+
+	go func() {
+		if err := grpcServer.Run(ctx); err != nil {
+			panic(err)
+		}
+	}()
+
+	return listener
+}
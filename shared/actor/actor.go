@@ -0,0 +1,21 @@
+// Package actor carries the identity of who triggered an operation through a context,
+// so it can be recorded by the services and propagated between them.
+package actor
+
+import "context"
+
+// Unknown is reported when an operation didn't identify its actor
+const Unknown = "unknown"
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the given actor
+func NewContext(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, contextKey{}, actor)
+}
+
+// FromContext returns the actor carried by ctx, if any
+func FromContext(ctx context.Context) (string, bool) {
+	actor, ok := ctx.Value(contextKey{}).(string)
+	return actor, ok && actor != ""
+}
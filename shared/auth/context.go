@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+	"strings"
+)
+
+// BearerPrefix starts the Authorization header, or metadata, carrying a token
+const BearerPrefix = "Bearer "
+
+type contextKey struct{}
+
+// verified is a token along with its verified claims
+type verified struct {
+	token  string
+	claims Claims
+}
+
+// NewContext returns a copy of ctx carrying a verified token and its claims
+func NewContext(ctx context.Context, token string, claims Claims) context.Context {
+	return context.WithValue(ctx, contextKey{}, verified{token: token, claims: claims})
+}
+
+// FromContext returns the claims of the token carried by ctx, if any
+func FromContext(ctx context.Context) (Claims, bool) {
+	v, ok := ctx.Value(contextKey{}).(verified)
+	return v.claims, ok
+}
+
+// TokenFromContext returns the token carried by ctx, if any, so it can be forwarded to other services
+func TokenFromContext(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(contextKey{}).(verified)
+	return v.token, ok
+}
+
+// ParseBearer returns the token of an Authorization header value, e.g. "Bearer <token>"
+func ParseBearer(value string) (string, bool) {
+	if len(value) < len(BearerPrefix) || !strings.EqualFold(value[:len(BearerPrefix)], BearerPrefix) {
+		return "", false
+	}
+
+	token := strings.TrimSpace(value[len(BearerPrefix):])
+	return token, token != ""
+}
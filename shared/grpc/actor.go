@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	"context"
+
+	grpcMiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/lucasmls/ecommerce/shared/actor"
+	gGRPC "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// ActorMetadataKey is the metadata key carrying the actor of a call between services
+const ActorMetadataKey = "x-actor"
+
+// incomingActor moves the actor of the incoming metadata into the context
+func incomingActor(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	values := md.Get(ActorMetadataKey)
+	if len(values) == 0 || values[0] == "" {
+		return ctx
+	}
+
+	return actor.NewContext(ctx, values[0])
+}
+
+// outgoingActor moves the actor of the context into the outgoing metadata
+func outgoingActor(ctx context.Context) context.Context {
+	name, ok := actor.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, ActorMetadataKey, name)
+}
+
+func actorUnaryServerInterceptor(
+	ctx context.Context,
+	req interface{},
+	_ *gGRPC.UnaryServerInfo,
+	handler gGRPC.UnaryHandler,
+) (interface{}, error) {
+	return handler(incomingActor(ctx), req)
+}
+
+func actorStreamServerInterceptor(
+	srv interface{},
+	stream gGRPC.ServerStream,
+	_ *gGRPC.StreamServerInfo,
+	handler gGRPC.StreamHandler,
+) error {
+	wrapped := grpcMiddleware.WrapServerStream(stream)
+	wrapped.WrappedContext = incomingActor(stream.Context())
+
+	return handler(srv, wrapped)
+}
+
+func actorUnaryClientInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *gGRPC.ClientConn,
+	invoker gGRPC.UnaryInvoker,
+	opts ...gGRPC.CallOption,
+) error {
+	return invoker(outgoingActor(ctx), method, req, reply, cc, opts...)
+}
+
+func actorStreamClientInterceptor(
+	ctx context.Context,
+	desc *gGRPC.StreamDesc,
+	cc *gGRPC.ClientConn,
+	method string,
+	streamer gGRPC.Streamer,
+	opts ...gGRPC.CallOption,
+) (gGRPC.ClientStream, error) {
+	return streamer(outgoingActor(ctx), desc, cc, method, opts...)
+}
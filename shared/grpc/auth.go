@@ -0,0 +1,108 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	grpcMiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/lucasmls/ecommerce/shared/actor"
+	"github.com/lucasmls/ecommerce/shared/auth"
+	gGRPC "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// AuthorizationMetadataKey is the metadata key carrying the token of the customer making a call, e.g. "Bearer <token>"
+const AuthorizationMetadataKey = "authorization"
+
+// incomingToken verifies the token of the incoming metadata, if any, moving it and its claims into the context.
+// The customer holding a verified token becomes the actor of the call.
+func incomingToken(ctx context.Context, secret []byte) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	values := md.Get(AuthorizationMetadataKey)
+	if len(values) == 0 || values[0] == "" {
+		return ctx, nil
+	}
+
+	token, ok := auth.ParseBearer(values[0])
+	if !ok {
+		return ctx, status.Error(codes.Unauthenticated, auth.ErrInvalidToken.Error())
+	}
+
+	claims, err := auth.Verify(secret, token, time.Now())
+	if err != nil {
+		return ctx, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	ctx = auth.NewContext(ctx, token, claims)
+	return actor.NewContext(ctx, claims.Subject), nil
+}
+
+// outgoingToken moves the token of the context into the outgoing metadata
+func outgoingToken(ctx context.Context) context.Context {
+	token, ok := auth.TokenFromContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, AuthorizationMetadataKey, auth.BearerPrefix+token)
+}
+
+func tokenUnaryServerInterceptor(secret []byte) gGRPC.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		_ *gGRPC.UnaryServerInfo,
+		handler gGRPC.UnaryHandler,
+	) (interface{}, error) {
+		ctx, err := incomingToken(ctx, secret)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func tokenStreamServerInterceptor(secret []byte) gGRPC.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream gGRPC.ServerStream,
+		_ *gGRPC.StreamServerInfo,
+		handler gGRPC.StreamHandler,
+	) error {
+		ctx, err := incomingToken(stream.Context(), secret)
+		if err != nil {
+			return err
+		}
+
+		wrapped := grpcMiddleware.WrapServerStream(stream)
+		wrapped.WrappedContext = ctx
+
+		return handler(srv, wrapped)
+	}
+}
+
+func tokenUnaryClientInterceptor(
+	ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *gGRPC.ClientConn,
+	invoker gGRPC.UnaryInvoker,
+	opts ...gGRPC.CallOption,
+) error {
+	return invoker(outgoingToken(ctx), method, req, reply, cc, opts...)
+}
+
+func tokenStreamClientInterceptor(
+	ctx context.Context,
+	desc *gGRPC.StreamDesc,
+	cc *gGRPC.ClientConn,
+	method string,
+	streamer gGRPC.Streamer,
+	opts ...gGRPC.CallOption,
+) (gGRPC.ClientStream, error) {
+	return streamer(outgoingToken(ctx), desc, cc, method, opts...)
+}
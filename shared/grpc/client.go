@@ -43,8 +43,8 @@ func (c Client) Connect(ctx context.Context) (*gGRPC.ClientConn, error) {
 
 	dialOptions := []gGRPC.DialOption{
 		gGRPC.WithInsecure(),
-		gGRPC.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
-		gGRPC.WithStreamInterceptor(otelgrpc.StreamClientInterceptor()),
+		gGRPC.WithChainUnaryInterceptor(otelgrpc.UnaryClientInterceptor(), actorUnaryClientInterceptor, tokenUnaryClientInterceptor),
+		gGRPC.WithChainStreamInterceptor(otelgrpc.StreamClientInterceptor(), actorStreamClientInterceptor, tokenStreamClientInterceptor),
 	}
 
 	dialOptions = append(dialOptions, c.in.AdditionalDialOptions...)
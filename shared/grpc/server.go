@@ -20,6 +20,8 @@ type ServerInput struct {
 	Logger      *zap.Logger
 
 	Listener net.Listener
+	// TokenSecret verifies the tokens of the customers making the calls, the tokens are ignored when it's empty
+	TokenSecret []byte
 }
 
 // Server is the GRPC server itself.
@@ -39,20 +41,28 @@ func NewServer(in ServerInput) (*Server, error) {
 		return nil, errors.New("missing required dependency: Registrator")
 	}
 
+	streamInterceptors := []gGRPC.StreamServerInterceptor{
+		otelgrpc.StreamServerInterceptor(),
+		grpcPrometheusInterceptors.StreamServerInterceptor,
+		actorStreamServerInterceptor,
+	}
+
+	unaryInterceptors := []gGRPC.UnaryServerInterceptor{
+		otelgrpc.UnaryServerInterceptor(),
+		grpcPrometheusInterceptors.UnaryServerInterceptor,
+		actorUnaryServerInterceptor,
+	}
+
+	// the token goes after the actor, so the customer holding it overrides whatever actor the caller told
+	if len(in.TokenSecret) > 0 {
+		streamInterceptors = append(streamInterceptors, tokenStreamServerInterceptor(in.TokenSecret))
+		unaryInterceptors = append(unaryInterceptors, tokenUnaryServerInterceptor(in.TokenSecret))
+	}
+
 	grpcPrometheusInterceptors.EnableHandlingTimeHistogram()
 	grpcServer := gGRPC.NewServer(
-		gGRPC.StreamInterceptor(
-			grpcMiddleware.ChainStreamServer(
-				otelgrpc.StreamServerInterceptor(),
-				grpcPrometheusInterceptors.StreamServerInterceptor,
-			),
-		),
-		gGRPC.UnaryInterceptor(
-			grpcMiddleware.ChainUnaryServer(
-				otelgrpc.UnaryServerInterceptor(),
-				grpcPrometheusInterceptors.UnaryServerInterceptor,
-			),
-		),
+		gGRPC.StreamInterceptor(grpcMiddleware.ChainStreamServer(streamInterceptors...)),
+		gGRPC.UnaryInterceptor(grpcMiddleware.ChainUnaryServer(unaryInterceptors...)),
 	)
 
 	return &Server{
@@ -0,0 +1,24 @@
+// Package pg holds what the Postgres repositories of the services have in common
+package pg
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+// uniqueViolationCode is the Postgres error code raised when a unique constraint is violated
+const uniqueViolationCode = "23505"
+
+// Scanner is implemented by both *sql.Row and *sql.Rows, so that a row is scanned the same way
+// whether it was queried alone or along with others
+type Scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// IsUniqueViolation tells whether Postgres failed because a unique constraint was violated,
+// e.g. when inserting a row whose key is already taken
+func IsUniqueViolation(err error) bool {
+	var pgErr *pq.Error
+	return errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode
+}
@@ -0,0 +1,230 @@
+package rmq
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/streadway/amqp"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// protobufContentType is the content type of the messages published by the Client
+const protobufContentType = "application/x-protobuf"
+
+// defaultPrefetchCount is how many messages a consumer holds without acknowledging them when the ConsumerInput doesn't tell
+const defaultPrefetchCount = 10
+
+// ClientInput is the input (aka dependencies) needed to create a RabbitMQ client
+type ClientInput struct {
+	URL    string
+	Logger *zap.Logger
+}
+
+// Client publishes and consumes protobuf messages through topic exchanges.
+// The messages carry the tracing context of the publisher on their headers.
+type Client struct {
+	in ClientInput
+
+	connection *amqp.Connection
+
+	// publishing is shared by the publishers, the mutex keeps their messages from interleaving
+	mu         sync.Mutex
+	publishing *amqp.Channel
+}
+
+// Publisher publishes a message to an exchange. It's implemented by the Client, and lets the services
+// depend on publishing only, so that they can be tested without a broker, see the rmqtest package.
+type Publisher interface {
+	Publish(ctx context.Context, exchange string, routingKey string, message proto.Message) error
+}
+
+var _ Publisher = (*Client)(nil)
+
+// NewClient is the RabbitMQ client constructor
+func NewClient(in ClientInput) (*Client, error) {
+	if in.URL == "" {
+		return nil, errors.New("missing required dependency: URL")
+	}
+
+	if in.Logger == nil {
+		return nil, errors.New("missing required dependency: Logger")
+	}
+
+	return &Client{in: in}, nil
+}
+
+// MustNewClient is the RabbitMQ client constructor
+// It panics if any error is found
+func MustNewClient(in ClientInput) *Client {
+	client, err := NewClient(in)
+	if err != nil {
+		panic(err)
+	}
+
+	return client
+}
+
+// Connect into the RabbitMQ broker
+func (c *Client) Connect() error {
+	c.in.Logger.Info("connecting to RabbitMQ")
+
+	connection, err := amqp.Dial(c.in.URL)
+	if err != nil {
+		c.in.Logger.Error("failed to connect into RabbitMQ", zap.Error(err))
+		return err
+	}
+
+	publishing, err := connection.Channel()
+	if err != nil {
+		_ = connection.Close()
+		return err
+	}
+
+	c.connection = connection
+	c.publishing = publishing
+
+	return nil
+}
+
+// MustConnect into the RabbitMQ broker
+// It panics if any error is found
+func (c *Client) MustConnect() *Client {
+	if err := c.Connect(); err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// Close closes the connection along with every channel opened on it
+func (c *Client) Close() error {
+	if c.connection == nil {
+		return nil
+	}
+
+	return c.connection.Close()
+}
+
+// DeclareExchange declares a durable topic exchange, declaring an existing one is a no-op
+func (c *Client) DeclareExchange(name string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.publishing.ExchangeDeclare(name, amqp.ExchangeTopic, true, false, false, false, nil)
+}
+
+// Publish sends a persistent message to an exchange
+func (c *Client) Publish(ctx context.Context, exchange string, routingKey string, message proto.Message) error {
+	body, err := proto.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	headers := amqp.Table{}
+	otel.GetTextMapPropagator().Inject(ctx, headersCarrier(headers))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.publishing.Publish(exchange, routingKey, false, false, amqp.Publishing{
+		Headers:      headers,
+		ContentType:  protobufContentType,
+		DeliveryMode: amqp.Persistent,
+		Body:         body,
+	})
+}
+
+// Handler handles the body of a message, the message is acknowledged when it succeeds
+type Handler func(ctx context.Context, body []byte) error
+
+// ConsumerInput tells where a consumer takes its messages from
+type ConsumerInput struct {
+	Exchange string
+	// Queue is declared durable, so the messages published while the consumer is down aren't lost
+	Queue       string
+	RoutingKeys []string
+	Handler     Handler
+
+	// PrefetchCount is how many messages are held without acknowledging them, 10 when unset
+	PrefetchCount int
+}
+
+// Consume handles the messages routed to a queue until the context is done or the connection is closed.
+// The messages the Handler fails to handle are dropped rather than requeued, so a message that can never
+// be handled doesn't keep the consumer busy: their publishers are expected to send them again.
+func (c *Client) Consume(ctx context.Context, in ConsumerInput) error {
+	if in.Handler == nil {
+		return errors.New("missing required dependency: Handler")
+	}
+
+	channel, err := c.connection.Channel()
+	if err != nil {
+		return err
+	}
+	defer channel.Close()
+
+	if err := channel.ExchangeDeclare(in.Exchange, amqp.ExchangeTopic, true, false, false, false, nil); err != nil {
+		return err
+	}
+
+	queue, err := channel.QueueDeclare(in.Queue, true, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, routingKey := range in.RoutingKeys {
+		if err := channel.QueueBind(queue.Name, routingKey, in.Exchange, false, nil); err != nil {
+			return err
+		}
+	}
+
+	prefetchCount := in.PrefetchCount
+	if prefetchCount <= 0 {
+		prefetchCount = defaultPrefetchCount
+	}
+
+	if err := channel.Qos(prefetchCount, 0, false); err != nil {
+		return err
+	}
+
+	deliveries, err := channel.Consume(queue.Name, "", false, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	c.in.Logger.Info("consuming messages", zap.String("queue", queue.Name), zap.Strings("routingKeys", in.RoutingKeys))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case delivery, ok := <-deliveries:
+			if !ok {
+				return errors.New("the deliveries channel was closed")
+			}
+
+			c.handle(ctx, in, delivery)
+		}
+	}
+}
+
+func (c *Client) handle(ctx context.Context, in ConsumerInput, delivery amqp.Delivery) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, headersCarrier(delivery.Headers))
+
+	if err := in.Handler(ctx, delivery.Body); err != nil {
+		c.in.Logger.Error(
+			"failed to handle a message, dropping it",
+			zap.String("queue", in.Queue),
+			zap.String("routingKey", delivery.RoutingKey),
+			zap.Error(err),
+		)
+
+		_ = delivery.Nack(false, false)
+		return
+	}
+
+	_ = delivery.Ack(false)
+}
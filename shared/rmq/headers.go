@@ -0,0 +1,29 @@
+package rmq
+
+import (
+	"github.com/streadway/amqp"
+)
+
+// headersCarrier lets the tracing context be carried on the headers of the messages
+type headersCarrier amqp.Table
+
+// Get returns the value of a header, or an empty string when it isn't a string
+func (c headersCarrier) Get(key string) string {
+	value, _ := c[key].(string)
+	return value
+}
+
+// Set sets the value of a header
+func (c headersCarrier) Set(key string, value string) {
+	c[key] = value
+}
+
+// Keys lists the headers
+func (c headersCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
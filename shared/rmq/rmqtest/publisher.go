@@ -0,0 +1,24 @@
+// Package rmqtest provides test doubles of the rmq package
+package rmqtest
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Publisher is an rmq.Publisher that records the last message it's asked to publish instead of sending it,
+// failing with Err when it's set
+type Publisher struct {
+	Exchange   string
+	RoutingKey string
+	Message    proto.Message
+	Err        error
+}
+
+func (p *Publisher) Publish(_ context.Context, exchange string, routingKey string, message proto.Message) error {
+	p.Exchange = exchange
+	p.RoutingKey = routingKey
+	p.Message = message
+	return p.Err
+}